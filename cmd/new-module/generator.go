@@ -0,0 +1,161 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+//go:embed all:templates
+var templates embed.FS
+
+// specBaseURL is where the OpenChoreo adapter API contracts are published.
+const specBaseURL = "https://raw.githubusercontent.com/openchoreo/openchoreo/main/openapi/"
+
+// adapterKind describes the upstream contract and conventions of an adapter kind.
+type adapterKind struct {
+	SpecFile string
+	Port     int
+}
+
+// adapterKinds are the adapter kinds with an upstream OpenChoreo contract.
+// Other kinds start from a local spec stub.
+var adapterKinds = map[string]adapterKind{
+	"logs":    {SpecFile: "observability-logs-adapter-api.yaml", Port: 9098},
+	"metrics": {SpecFile: "observability-metrics-adapter.yaml", Port: 9099},
+	"tracing": {SpecFile: "observability-tracing-adapter-api.yaml", Port: 9100},
+}
+
+// defaultStubPort is the default server port of adapters without an upstream contract.
+const defaultStubPort = 9101
+
+var namePattern = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
+
+// moduleSpec holds the values substituted into the module templates.
+type moduleSpec struct {
+	Kind    string
+	Backend string
+	// Name is the module directory, image and chart name prefix.
+	Name     string
+	GoModule string
+	Port     int
+	// SpecURL is the upstream contract, or empty when the module owns a
+	// local spec stub.
+	SpecURL string
+	// Handler is the name of the type implementing the generated server.
+	Handler string
+	// BackendEnv is the prefix of the backend environment variables.
+	BackendEnv string
+	// BackendField is the prefix of the backend configuration fields.
+	BackendField string
+}
+
+func newModuleSpec(kind, backend string, port int) (moduleSpec, error) {
+	if !namePattern.MatchString(kind) {
+		return moduleSpec{}, fmt.Errorf("invalid kind %q: use lower case letters, digits and dashes", kind)
+	}
+	if !namePattern.MatchString(backend) {
+		return moduleSpec{}, fmt.Errorf("invalid backend %q: use lower case letters, digits and dashes", backend)
+	}
+	if port < 0 || port > 65535 {
+		return moduleSpec{}, fmt.Errorf("invalid port %d", port)
+	}
+
+	spec := moduleSpec{
+		Kind:         kind,
+		Backend:      backend,
+		Name:         "observability-" + kind + "-" + backend,
+		Port:         defaultStubPort,
+		Handler:      camelCase(kind) + "Handler",
+		BackendEnv:   strings.ToUpper(strings.ReplaceAll(backend, "-", "_")),
+		BackendField: camelCase(backend),
+	}
+	spec.GoModule = "github.com/openchoreo/community-modules/" + spec.Name
+	if k, ok := adapterKinds[kind]; ok {
+		spec.SpecURL = specBaseURL + k.SpecFile
+		spec.Port = k.Port
+	}
+	if port != 0 {
+		spec.Port = port
+	}
+	return spec, nil
+}
+
+// camelCase converts a dash-separated name to an exported Go identifier.
+func camelCase(name string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(name, "-") {
+		if part != "" {
+			b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+		}
+	}
+	return b.String()
+}
+
+// generate renders the module templates into dir, which must not exist yet,
+// and returns the paths of the created files relative to dir. Go files are
+// gofmt-formatted. Templates that render to nothing, such as the spec stub
+// of kinds with an upstream contract, are skipped.
+func generate(spec moduleSpec, dir string) ([]string, error) {
+	if _, err := os.Stat(dir); err == nil {
+		return nil, fmt.Errorf("%s already exists", dir)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	var files []string
+	err := fs.WalkDir(templates, "templates", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		src, err := templates.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		tmpl, err := template.New(path.Base(name)).Parse(string(src))
+		if err != nil {
+			return fmt.Errorf("parse %s: %w", name, err)
+		}
+		var out bytes.Buffer
+		if err := tmpl.Execute(&out, spec); err != nil {
+			return fmt.Errorf("render %s: %w", name, err)
+		}
+		if len(bytes.TrimSpace(out.Bytes())) == 0 {
+			return nil
+		}
+
+		rel := strings.TrimSuffix(strings.TrimPrefix(name, "templates/"), ".tmpl")
+		content := out.Bytes()
+		if strings.HasSuffix(rel, ".go") {
+			// Field alignment depends on the substituted names.
+			if content, err = format.Source(content); err != nil {
+				return fmt.Errorf("format %s: %w", rel, err)
+			}
+		}
+		target := filepath.Join(dir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(target, content, 0o644); err != nil {
+			return err
+		}
+		files = append(files, rel)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
@@ -0,0 +1,135 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNewModuleSpec(t *testing.T) {
+	spec, err := newModuleSpec("logs", "victoria-logs", 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if spec.Name != "observability-logs-victoria-logs" ||
+		spec.GoModule != "github.com/openchoreo/community-modules/observability-logs-victoria-logs" {
+		t.Errorf("unexpected names: %+v", spec)
+	}
+	if spec.Handler != "LogsHandler" || spec.BackendEnv != "VICTORIA_LOGS" || spec.BackendField != "VictoriaLogs" {
+		t.Errorf("unexpected identifiers: %+v", spec)
+	}
+	if spec.Port != 9098 || !strings.HasSuffix(spec.SpecURL, "/observability-logs-adapter-api.yaml") {
+		t.Errorf("unexpected kind defaults: %+v", spec)
+	}
+
+	spec, err = newModuleSpec("profiling", "pyroscope", 9200)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if spec.SpecURL != "" || spec.Port != 9200 || spec.Handler != "ProfilingHandler" {
+		t.Errorf("unexpected spec for kind without contract: %+v", spec)
+	}
+
+	for _, tt := range []struct{ kind, backend string }{
+		{"", "loki"},
+		{"logs", ""},
+		{"Logs", "loki"},
+		{"logs", "loki_v2"},
+		{"logs", "-loki"},
+		{"logs", "../loki"},
+	} {
+		if _, err := newModuleSpec(tt.kind, tt.backend, 0); err == nil {
+			t.Errorf("expected error for kind %q, backend %q", tt.kind, tt.backend)
+		}
+	}
+	if _, err := newModuleSpec("logs", "loki", 70000); err == nil {
+		t.Error("expected error for invalid port")
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	t.Run("kind with upstream contract", func(t *testing.T) {
+		spec, _ := newModuleSpec("tracing", "tempo", 0)
+		dir := filepath.Join(t.TempDir(), spec.Name)
+		files, err := generate(spec, dir)
+		if err != nil {
+			t.Fatalf("generate() error = %v", err)
+		}
+
+		for _, want := range []string{
+			"Dockerfile", "Makefile", "README.md", "VERSION", "go.mod", "main.go", "module.yaml",
+			"internal/config.go", "internal/config_test.go", "internal/handlers.go", "internal/server.go",
+			"internal/api/cfg-client.yaml", "internal/api/cfg-models.yaml", "internal/api/cfg-server.yaml",
+		} {
+			if !slices.Contains(files, want) {
+				t.Errorf("expected %s to be generated, got %v", want, files)
+			}
+		}
+		if slices.Contains(files, "internal/api/openapi.yaml") {
+			t.Error("expected no spec stub for a kind with an upstream contract")
+		}
+
+		assertContains(t, filepath.Join(dir, "go.mod"), "module github.com/openchoreo/community-modules/observability-tracing-tempo")
+		assertContains(t, filepath.Join(dir, "Makefile"), "SPEC := "+specBaseURL+"observability-tracing-adapter-api.yaml")
+		assertContains(t, filepath.Join(dir, "Dockerfile"), "EXPOSE 9100")
+		assertContains(t, filepath.Join(dir, "module.yaml"), "- name: observability-tracing-tempo-adapter")
+		assertContains(t, filepath.Join(dir, "internal/config.go"), `getEnv("TEMPO_URL", "")`)
+		assertGoFilesParse(t, dir)
+	})
+
+	t.Run("kind without upstream contract", func(t *testing.T) {
+		spec, _ := newModuleSpec("profiling", "pyroscope", 0)
+		dir := filepath.Join(t.TempDir(), spec.Name)
+		files, err := generate(spec, dir)
+		if err != nil {
+			t.Fatalf("generate() error = %v", err)
+		}
+		if !slices.Contains(files, "internal/api/openapi.yaml") {
+			t.Errorf("expected spec stub to be generated, got %v", files)
+		}
+		assertContains(t, filepath.Join(dir, "Makefile"), "SPEC := openapi.yaml")
+		assertContains(t, filepath.Join(dir, "internal/handlers.go"), "func (h *ProfilingHandler) Health(")
+		assertGoFilesParse(t, dir)
+	})
+
+	t.Run("existing directory", func(t *testing.T) {
+		spec, _ := newModuleSpec("logs", "loki", 0)
+		if _, err := generate(spec, t.TempDir()); err == nil {
+			t.Error("expected error when the module directory exists")
+		}
+	})
+}
+
+func assertContains(t *testing.T, path, want string) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", path, err)
+	}
+	if !strings.Contains(string(data), want) {
+		t.Errorf("expected %s to contain %q, got:\n%s", path, want, data)
+	}
+}
+
+// assertGoFilesParse checks that the generated Go files are syntactically
+// valid. They cannot be compiled before the server code is generated.
+func assertGoFilesParse(t *testing.T, dir string) {
+	t.Helper()
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || !strings.HasSuffix(path, ".go") {
+			return err
+		}
+		_, err = parser.ParseFile(token.NewFileSet(), path, nil, parser.ParseComments)
+		return err
+	})
+	if err != nil {
+		t.Errorf("generated Go file does not parse: %v", err)
+	}
+}
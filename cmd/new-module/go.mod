@@ -0,0 +1,3 @@
+module github.com/openchoreo/community-modules/cmd/new-module
+
+go 1.25
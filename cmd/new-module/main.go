@@ -0,0 +1,48 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Command new-module scaffolds a community observability adapter module
+// following the layout of the existing logs and tracing adapters: an OpenAPI
+// codegen setup, server, configuration, Dockerfile and tests.
+//
+// Usage:
+//
+//	go run ./cmd/new-module -kind logs -backend victorialogs
+//
+// creates observability-logs-victorialogs/ in the repository root.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	kind := flag.String("kind", "", "adapter kind: logs, tracing, metrics, or another kind to start from a local spec stub")
+	backend := flag.String("backend", "", "backend name, e.g. victorialogs (lower case letters, digits and dashes)")
+	out := flag.String("out", ".", "directory to create the module in, usually the repository root")
+	port := flag.Int("port", 0, "default server port (default: the port of existing adapters of the kind)")
+	flag.Parse()
+
+	spec, err := newModuleSpec(*kind, *backend, *port)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "new-module:", err)
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	dir := filepath.Join(*out, spec.Name)
+	files, err := generate(spec, dir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "new-module:", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Created %s with %d files.\n\nNext steps:\n", dir, len(files))
+	fmt.Printf("  cd %s\n", dir)
+	fmt.Println("  make openapi-codegen && go mod tidy")
+	fmt.Println("  implement the generated server interface in internal/handlers.go")
+	fmt.Println("  make unit-test")
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package access
+
+import (
+	"context"
+	"net/http"
+)
+
+type contextKey struct{}
+
+// Content is the kind of data a request reads from a namespace.
+type Content int
+
+const (
+	// Aggregates are counts, histograms and summaries.
+	Aggregates Content = iota
+	// Raw content is log lines, events and spans.
+	Raw
+)
+
+// Restricted reports whether caller is restricted to aggregates in at least
+// one namespace.
+func (p *Policy) Restricted(caller string) bool {
+	for namespace := range p.rules {
+		if p.AggregationOnly(namespace, caller) {
+			return true
+		}
+	}
+	return false
+}
+
+// Authorize returns ErrAggregationOnly when caller may not read content from
+// namespace. Raw content read without a namespace may belong to any of
+// them, so it is denied to the callers restricted in at least one. A nil
+// policy authorizes every request.
+func (p *Policy) Authorize(namespace, caller string, content Content) error {
+	if p == nil || content != Raw {
+		return nil
+	}
+	if (namespace == "" && p.Restricted(caller)) || p.AggregationOnly(namespace, caller) {
+		return ErrAggregationOnly
+	}
+	return nil
+}
+
+// Middleware identifies the caller of each request and stores it in the
+// request context, where handlers read it with CallerFromContext. The
+// requests matching one of rawRoutes, ServeMux patterns such as
+// "POST /api/v1alpha1/traces/{traceId}/spans/query", read raw content from
+// the namespace namespace returns for them: those Authorize denies are
+// answered by reject with ErrAggregationOnly, so each module renders its own
+// 403 response. A nil policy serves all requests with next.
+func (p *Policy) Middleware(rawRoutes []string, namespace func(r *http.Request) string, reject func(w http.ResponseWriter, r *http.Request, err error), next http.Handler) http.Handler {
+	if p == nil {
+		return next
+	}
+	raw := http.NewServeMux()
+	for _, route := range rawRoutes {
+		raw.Handle(route, next)
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		caller := p.Caller(r)
+		r = r.WithContext(context.WithValue(r.Context(), contextKey{}, caller))
+		if _, route := raw.Handler(r); route != "" {
+			if err := p.Authorize(namespace(r), caller, Raw); err != nil {
+				reject(w, r, err)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// CallerFromContext returns the caller identified by Middleware, or
+// Anonymous.
+func CallerFromContext(ctx context.Context) string {
+	if caller, ok := ctx.Value(contextKey{}).(string); ok {
+		return caller
+	}
+	return Anonymous
+}
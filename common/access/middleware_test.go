@@ -0,0 +1,96 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package access
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPolicy_Authorize(t *testing.T) {
+	t.Setenv("SRE_TOKEN", "sre-token")
+	policy, err := NewPolicy(File{
+		Callers:         []Caller{{Name: "sre", TokenEnv: "SRE_TOKEN"}},
+		AggregationOnly: []Rule{{Namespaces: []string{"pay"}, Except: []string{"sre"}}},
+	})
+	if err != nil {
+		t.Fatalf("NewPolicy() error = %v", err)
+	}
+
+	tests := []struct {
+		namespace, caller string
+		content           Content
+		denied            bool
+	}{
+		{"pay", Anonymous, Raw, true},
+		{"pay", Anonymous, Aggregates, false},
+		{"pay", "sre", Raw, false},
+		{"default", Anonymous, Raw, false},
+		{"", Anonymous, Raw, true},
+		{"", Anonymous, Aggregates, false},
+		{"", "sre", Raw, false},
+	}
+	for _, tt := range tests {
+		err := policy.Authorize(tt.namespace, tt.caller, tt.content)
+		if denied := errors.Is(err, ErrAggregationOnly); denied != tt.denied {
+			t.Errorf("Authorize(%q, %q, %v) = %v, want denied=%v", tt.namespace, tt.caller, tt.content, err, tt.denied)
+		}
+	}
+	if err := (*Policy)(nil).Authorize("pay", Anonymous, Raw); err != nil {
+		t.Errorf("expected a nil policy to authorize everything, got %v", err)
+	}
+}
+
+func TestPolicy_Middleware(t *testing.T) {
+	t.Setenv("SRE_TOKEN", "sre-token")
+	policy, err := NewPolicy(File{
+		Callers:         []Caller{{Name: "sre", TokenEnv: "SRE_TOKEN"}},
+		AggregationOnly: []Rule{{Namespaces: []string{"pay"}, Except: []string{"sre"}}},
+	})
+	if err != nil {
+		t.Fatalf("NewPolicy() error = %v", err)
+	}
+	var caller string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		caller = CallerFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+	reject := func(w http.ResponseWriter, r *http.Request, err error) {
+		http.Error(w, err.Error(), http.StatusForbidden)
+	}
+	namespace := func(r *http.Request) string { return r.URL.Query().Get("namespace") }
+	handler := policy.Middleware([]string{"GET /spans/{spanId}"}, namespace, reject, next)
+
+	tests := []struct {
+		name, target, token string
+		want                int
+		caller              string
+	}{
+		{"raw in restricted namespace", "/spans/1?namespace=pay", "", http.StatusForbidden, ""},
+		{"raw for excepted caller", "/spans/1?namespace=pay", "sre-token", http.StatusOK, "sre"},
+		{"raw in other namespace", "/spans/1?namespace=default", "", http.StatusOK, Anonymous},
+		{"raw without namespace", "/spans/1", "", http.StatusForbidden, ""},
+		{"aggregates in restricted namespace", "/stats?namespace=pay", "", http.StatusOK, Anonymous},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			caller = ""
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.want || caller != tt.caller {
+				t.Errorf("got %d for caller %q, want %d for %q", rec.Code, caller, tt.want, tt.caller)
+			}
+		})
+	}
+
+	if CallerFromContext(httptest.NewRequest(http.MethodGet, "/", nil).Context()) != Anonymous {
+		t.Error("expected requests not identified by the middleware to come from the anonymous caller")
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package access implements the aggregation-only policy of sensitive
+// namespaces. Callers identify themselves with a bearer token; in the
+// namespaces of an aggregation-only rule the callers it restricts may read
+// aggregates such as histograms and summaries, but no raw log lines, events
+// or spans. The policy also names the admin callers allowed to run raw SQL
+// queries.
+package access
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/openchoreo/community-modules/common/auth"
+)
+
+// Anonymous is the name of callers that present no known bearer token.
+const Anonymous = "anonymous"
+
+// ErrAggregationOnly is returned when a caller restricted to aggregates in a
+// namespace requests raw content.
+var ErrAggregationOnly = errors.New("namespace is restricted to aggregate queries for this caller")
+
+// Caller is a named caller and its bearer token. The token is read from the
+// environment variable TokenEnv or the file TokenFile so that the policy
+// file itself holds no secrets.
+type Caller struct {
+	Name      string `json:"name"`
+	TokenEnv  string `json:"tokenEnv,omitempty"`
+	TokenFile string `json:"tokenFile,omitempty"`
+}
+
+// Rule restricts Callers to aggregates in Namespaces. All callers, including
+// anonymous ones, are restricted when Callers is empty. Callers listed in
+// Except keep access to raw content.
+type Rule struct {
+	Namespaces []string `json:"namespaces"`
+	Callers    []string `json:"callers,omitempty"`
+	Except     []string `json:"except,omitempty"`
+}
+
+// File is the access policy file: a JSON document of the form
+// {"callers": [{"name": ..., "tokenEnv": ...}], "aggregationOnly": [{"namespaces": [...], ...}], "admins": [...]}.
+type File struct {
+	Callers         []Caller `json:"callers"`
+	AggregationOnly []Rule   `json:"aggregationOnly"`
+	// Admins are the names of the callers allowed to run raw SQL queries.
+	Admins []string `json:"admins,omitempty"`
+}
+
+// LoadFile reads an access policy file.
+func LoadFile(path string) (File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return File{}, fmt.Errorf("failed to read access policy file: %w", err)
+	}
+	var file File
+	if err := json.Unmarshal(data, &file); err != nil {
+		return File{}, fmt.Errorf("failed to parse access policy file: %w", err)
+	}
+	return file, nil
+}
+
+// token returns the caller's bearer token.
+func (c Caller) token() (string, error) {
+	switch {
+	case c.TokenEnv != "":
+		if v := os.Getenv(c.TokenEnv); v != "" {
+			return v, nil
+		}
+		return "", fmt.Errorf("environment variable %s is not set", c.TokenEnv)
+	case c.TokenFile != "":
+		data, err := os.ReadFile(c.TokenFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read token file: %w", err)
+		}
+		if v := strings.TrimSpace(string(data)); v != "" {
+			return v, nil
+		}
+		return "", fmt.Errorf("token file %s is empty", c.TokenFile)
+	default:
+		return "", fmt.Errorf("tokenEnv or tokenFile is required")
+	}
+}
+
+// Policy identifies callers and decides which of them are restricted to
+// aggregates. It is safe for concurrent use.
+type Policy struct {
+	names  []string
+	tokens [][]byte
+	rules  map[string][]Rule
+	admins []string
+}
+
+// NewPolicy validates file and reads the callers' tokens.
+func NewPolicy(file File) (*Policy, error) {
+	p := &Policy{rules: make(map[string][]Rule)}
+	for i, c := range file.Callers {
+		if c.Name == "" {
+			return nil, fmt.Errorf("caller %d: name is required", i)
+		}
+		if c.Name == Anonymous || slices.Contains(p.names, c.Name) {
+			return nil, fmt.Errorf("caller %q is reserved or defined more than once", c.Name)
+		}
+		token, err := c.token()
+		if err != nil {
+			return nil, fmt.Errorf("caller %q: %w", c.Name, err)
+		}
+		p.names = append(p.names, c.Name)
+		p.tokens = append(p.tokens, []byte(token))
+	}
+	known := func(name string) bool { return name == Anonymous || slices.Contains(p.names, name) }
+	for i, rule := range file.AggregationOnly {
+		if len(rule.Namespaces) == 0 {
+			return nil, fmt.Errorf("aggregationOnly rule %d: at least one namespace is required", i)
+		}
+		for _, name := range append(slices.Clone(rule.Callers), rule.Except...) {
+			if !known(name) {
+				return nil, fmt.Errorf("aggregationOnly rule %d: unknown caller %q", i, name)
+			}
+		}
+		for _, ns := range rule.Namespaces {
+			p.rules[ns] = append(p.rules[ns], rule)
+		}
+	}
+	for _, name := range file.Admins {
+		// Admins must present a token; anonymous callers never are.
+		if !slices.Contains(p.names, name) {
+			return nil, fmt.Errorf("admins: unknown caller %q", name)
+		}
+	}
+	p.admins = file.Admins
+	return p, nil
+}
+
+// Caller returns the name of the caller whose token r carries in its
+// Authorization header, or Anonymous.
+func (p *Policy) Caller(r *http.Request) string {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return Anonymous
+	}
+	name := Anonymous
+	for i, t := range p.tokens {
+		// Every token is compared so that the time taken does not reveal
+		// which caller matched.
+		if subtle.ConstantTimeCompare([]byte(token), t) == 1 {
+			name = p.names[i]
+		}
+	}
+	return name
+}
+
+// Authenticate accepts the requests of the callers of the policy. It makes
+// their tokens valid credentials when request authentication is enabled.
+func (p *Policy) Authenticate(r *http.Request) error {
+	if p.Caller(r) == Anonymous {
+		return auth.ErrUnauthenticated
+	}
+	return nil
+}
+
+// AggregationOnly reports whether caller is restricted to aggregates in namespace.
+func (p *Policy) AggregationOnly(namespace, caller string) bool {
+	for _, rule := range p.rules[namespace] {
+		if slices.Contains(rule.Except, caller) {
+			continue
+		}
+		if len(rule.Callers) == 0 || slices.Contains(rule.Callers, caller) {
+			return true
+		}
+	}
+	return false
+}
+
+// Admin reports whether caller may run raw SQL queries.
+func (p *Policy) Admin(caller string) bool {
+	return caller != Anonymous && slices.Contains(p.admins, caller)
+}
@@ -0,0 +1,131 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package access
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "access.json")
+	data := `{"callers":[{"name":"sre","tokenEnv":"SRE_TOKEN"}],"aggregationOnly":[{"namespaces":["pay"],"except":["sre"]}]}`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile() error = %v", err)
+	}
+	if len(got.Callers) != 1 || got.Callers[0].TokenEnv != "SRE_TOKEN" ||
+		len(got.AggregationOnly) != 1 || got.AggregationOnly[0].Except[0] != "sre" {
+		t.Errorf("unexpected policy file: %+v", got)
+	}
+
+	if err := os.WriteFile(path, []byte("callers:"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadFile(path); err == nil {
+		t.Error("expected error for a malformed file")
+	}
+}
+
+func TestNewPolicy(t *testing.T) {
+	t.Setenv("SRE_TOKEN", "sre-token")
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("dash-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	callers := []Caller{
+		{Name: "sre", TokenEnv: "SRE_TOKEN"},
+		{Name: "dashboards", TokenFile: tokenFile},
+	}
+	if _, err := NewPolicy(File{Callers: callers}); err != nil {
+		t.Fatalf("NewPolicy() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		file File
+	}{
+		{"missing name", File{Callers: []Caller{{TokenEnv: "SRE_TOKEN"}}}},
+		{"reserved name", File{Callers: []Caller{{Name: Anonymous, TokenEnv: "SRE_TOKEN"}}}},
+		{"duplicate caller", File{Callers: []Caller{callers[0], callers[0]}}},
+		{"unset token", File{Callers: []Caller{{Name: "ci", TokenEnv: "UNSET_TOKEN"}}}},
+		{"missing token", File{Callers: []Caller{{Name: "ci"}}}},
+		{"rule without namespaces", File{AggregationOnly: []Rule{{Callers: []string{Anonymous}}}}},
+		{"unknown caller", File{Callers: callers, AggregationOnly: []Rule{{Namespaces: []string{"pay"}, Except: []string{"ci"}}}}},
+		{"unknown admin", File{Callers: callers, Admins: []string{"ci"}}},
+		{"anonymous admin", File{Callers: callers, Admins: []string{Anonymous}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewPolicy(tt.file); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestPolicy(t *testing.T) {
+	t.Setenv("SRE_TOKEN", "sre-token")
+	t.Setenv("DASH_TOKEN", "dash-token")
+	policy, err := NewPolicy(File{
+		Callers: []Caller{
+			{Name: "sre", TokenEnv: "SRE_TOKEN"},
+			{Name: "dashboards", TokenEnv: "DASH_TOKEN"},
+		},
+		AggregationOnly: []Rule{
+			{Namespaces: []string{"pay", "pay-ci"}, Except: []string{"sre"}},
+			{Namespaces: []string{"hr"}, Callers: []string{"dashboards"}},
+		},
+		Admins: []string{"sre"},
+	})
+	if err != nil {
+		t.Fatalf("NewPolicy() error = %v", err)
+	}
+
+	for header, want := range map[string]string{
+		"":                   Anonymous,
+		"Basic c3JlOnNyZQ==": Anonymous,
+		"Bearer unknown":     Anonymous,
+		"Bearer sre-token":   "sre",
+		"Bearer dash-token":  "dashboards",
+	} {
+		r := httptest.NewRequest("GET", "/", nil)
+		if header != "" {
+			r.Header.Set("Authorization", header)
+		}
+		if got := policy.Caller(r); got != want {
+			t.Errorf("Caller(%q) = %q, want %q", header, got, want)
+		}
+		if err := policy.Authenticate(r); (err == nil) != (want != Anonymous) {
+			t.Errorf("Authenticate(%q) = %v, want accepted=%v", header, err, want != Anonymous)
+		}
+	}
+
+	tests := []struct {
+		namespace, caller string
+		want              bool
+	}{
+		{"pay", Anonymous, true},
+		{"pay-ci", "dashboards", true},
+		{"pay", "sre", false},
+		{"hr", "dashboards", true},
+		{"hr", Anonymous, false},
+		{"default", Anonymous, false},
+	}
+	for _, tt := range tests {
+		if got := policy.AggregationOnly(tt.namespace, tt.caller); got != tt.want {
+			t.Errorf("AggregationOnly(%q, %q) = %v, want %v", tt.namespace, tt.caller, got, tt.want)
+		}
+	}
+	for caller, want := range map[string]bool{"sre": true, "dashboards": false, Anonymous: false} {
+		if got := policy.Admin(caller); got != want {
+			t.Errorf("Admin(%q) = %v, want %v", caller, got, want)
+		}
+	}
+}
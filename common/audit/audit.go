@@ -0,0 +1,424 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package audit records the query and alert operations served by an
+// adapter: who called them, on which scope and time range, with which
+// outcome and how many results, so that security teams can tell who queried
+// the data of a tenant. Events are written in batches, off the request path,
+// to standard output as JSON lines or to an OpenObserve stream.
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/openobserve"
+)
+
+// Sinks of the audit log.
+const (
+	// SinkNone disables the audit log.
+	SinkNone = "none"
+	// SinkStdout writes events to standard output as JSON lines.
+	SinkStdout = "stdout"
+	// SinkStream writes events to an OpenObserve logs stream.
+	SinkStream = "stream"
+)
+
+const (
+	// queueSize bounds the events waiting to be written. Events recorded
+	// while the queue is full are dropped and counted.
+	queueSize = 4096
+	// batchSize bounds the events written at once.
+	batchSize = 500
+	// flushInterval is how often Run writes the queued events.
+	flushInterval = time.Second
+	// maxCapturedBytes bounds the response bodies read for their result
+	// size. The result size of larger responses is unknown.
+	maxCapturedBytes = 16 << 20
+)
+
+// streamNamePattern matches the names of the streams of SinkStream.
+var streamNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Config sets where audit events are written.
+type Config struct {
+	// Sink is SinkNone, SinkStdout or SinkStream. An empty sink is SinkNone.
+	Sink string
+	// Stream is the OpenObserve logs stream of SinkStream.
+	Stream string
+}
+
+// Validate reports configuration errors.
+func (c Config) Validate() error {
+	switch c.Sink {
+	case "", SinkNone, SinkStdout:
+		return nil
+	case SinkStream:
+		if !streamNamePattern.MatchString(c.Stream) {
+			return fmt.Errorf("invalid stream %q: must be letters, digits and underscores", c.Stream)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown sink %q: must be %s, %s or %s", c.Sink, SinkNone, SinkStdout, SinkStream)
+	}
+}
+
+// Event is an audited operation.
+type Event struct {
+	Time time.Time `json:"time"`
+	// Operation names the operation, e.g. QueryLogs.
+	Operation string `json:"operation"`
+	// Caller identifies the caller, when the adapter knows it.
+	Caller     string `json:"caller,omitempty"`
+	RemoteAddr string `json:"remoteAddr,omitempty"`
+	// Scope holds the fields of the search scope of a query or of the
+	// metadata of an alert rule, such as namespace and componentUid.
+	Scope     map[string]string `json:"scope,omitempty"`
+	StartTime time.Time         `json:"startTime,omitzero"`
+	EndTime   time.Time         `json:"endTime,omitzero"`
+	// Rule is the name of the alert rule of an alert operation.
+	Rule   string `json:"rule,omitempty"`
+	Status int    `json:"status"`
+	// ResultSize is the number of results of a successful query, when
+	// known.
+	ResultSize *int  `json:"resultSize,omitempty"`
+	DurationMs int64 `json:"durationMs"`
+}
+
+// Sink writes audit events. It must be safe for concurrent use.
+type Sink interface {
+	Write(ctx context.Context, events []Event) error
+}
+
+// WriterSink writes events to a writer as JSON lines.
+type WriterSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewWriterSink returns a sink writing events to w as JSON lines.
+func NewWriterSink(w io.Writer) *WriterSink {
+	return &WriterSink{w: w}
+}
+
+// Write implements Sink.
+func (s *WriterSink) Write(_ context.Context, events []Event) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, e := range events {
+		if err := enc.Encode(e); err != nil {
+			return fmt.Errorf("failed to encode event: %w", err)
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err := s.w.Write(buf.Bytes())
+	return err
+}
+
+// StreamSink writes events to an OpenObserve logs stream, timestamped with
+// the time of their operation.
+type StreamSink struct {
+	client *openobserve.Client
+	stream string
+}
+
+// NewStreamSink returns a sink writing events to stream with client.
+func NewStreamSink(client *openobserve.Client, stream string) *StreamSink {
+	return &StreamSink{client: client, stream: stream}
+}
+
+// Write implements Sink.
+func (s *StreamSink) Write(ctx context.Context, events []Event) error {
+	records := make([]map[string]interface{}, 0, len(events))
+	for _, e := range events {
+		encoded, err := json.Marshal(e)
+		if err != nil {
+			return fmt.Errorf("failed to encode event: %w", err)
+		}
+		var record map[string]interface{}
+		if err := json.Unmarshal(encoded, &record); err != nil {
+			return fmt.Errorf("failed to encode event: %w", err)
+		}
+		record["_timestamp"] = e.Time.UnixMicro()
+		records = append(records, record)
+	}
+	return s.client.Ingest(ctx, s.stream, records)
+}
+
+// Log queues audit events and writes them to a sink in batches.
+type Log struct {
+	sink   Sink
+	prefix string
+	logger *slog.Logger
+	events chan Event
+
+	written atomic.Int64
+	dropped atomic.Int64
+	failed  atomic.Int64
+}
+
+// New builds the audit log c describes, writing to the streams of client
+// for SinkStream, and naming its metrics with prefix. It returns nil when
+// the audit log is disabled.
+func New(c Config, client *openobserve.Client, prefix string, logger *slog.Logger) (*Log, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	switch c.Sink {
+	case SinkStdout:
+		return NewLog(NewWriterSink(os.Stdout), prefix, logger), nil
+	case SinkStream:
+		return NewLog(NewStreamSink(client, c.Stream), prefix, logger), nil
+	default:
+		return nil, nil
+	}
+}
+
+// NewLog returns an audit log writing to sink, naming its metrics with
+// prefix. Events are only written while Run runs, and by Flush.
+func NewLog(sink Sink, prefix string, logger *slog.Logger) *Log {
+	return &Log{
+		sink:   sink,
+		prefix: prefix,
+		logger: logger,
+		events: make(chan Event, queueSize),
+	}
+}
+
+// Record queues e to be written. It never blocks: e is dropped when the
+// queue is full.
+func (l *Log) Record(e Event) {
+	select {
+	case l.events <- e:
+	default:
+		l.dropped.Add(1)
+	}
+}
+
+// Run writes the queued events every second until ctx is canceled.
+func (l *Log) Run(ctx context.Context) {
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			l.Flush(ctx)
+		}
+	}
+}
+
+// Flush writes the queued events, typically once more when the adapter
+// stops. A batch the sink fails to write is logged, counted and dropped.
+func (l *Log) Flush(ctx context.Context) {
+	for {
+		batch := make([]Event, 0, batchSize)
+	fill:
+		for len(batch) < batchSize {
+			select {
+			case e := <-l.events:
+				batch = append(batch, e)
+			default:
+				break fill
+			}
+		}
+		if len(batch) == 0 {
+			return
+		}
+		if err := l.sink.Write(ctx, batch); err != nil {
+			l.failed.Add(int64(len(batch)))
+			l.logger.Error("Failed to write audit events", slog.Int("events", len(batch)), slog.Any("error", err))
+		} else {
+			l.written.Add(int64(len(batch)))
+		}
+		if len(batch) < batchSize {
+			return
+		}
+	}
+}
+
+// Operation is an audited route.
+type Operation struct {
+	// Name names the operation in its events.
+	Name string
+	// Results is the top-level array field of the successful JSON
+	// responses of the route holding their results, whose length is the
+	// result size of the events. Empty when the route returns no results.
+	Results string
+}
+
+// Middleware records an event for each request served for the route
+// patterns of operations, whatever its outcome. The caller of the event is
+// the one caller returns, when caller is not nil. Its scope and time range
+// are read from the startTime, endTime and searchScope fields of the JSON
+// request body, or from its metadata field for alert rules, whose name is
+// the metadata name or the ruleName path value. Requests are passed through
+// untouched when the log is nil.
+func (l *Log) Middleware(operations map[string]Operation, caller func(r *http.Request) string, next http.Handler) http.Handler {
+	if l == nil {
+		return next
+	}
+	audited := http.NewServeMux()
+	for route, op := range operations {
+		audited.Handle(route, l.audit(op, caller, next))
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, route := audited.Handler(r); route == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		audited.ServeHTTP(w, r)
+	})
+}
+
+// audit serves the requests of op with next and records their events.
+func (l *Log) audit(op Operation, caller func(r *http.Request) string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		event := describe(r)
+		event.Time = start.UTC()
+		event.Operation = op.Name
+		event.RemoteAddr = r.RemoteAddr
+		if caller != nil {
+			event.Caller = caller(r)
+		}
+
+		rw := &recordingWriter{ResponseWriter: w, status: http.StatusOK, capture: op.Results != ""}
+		next.ServeHTTP(rw, r)
+
+		event.Status = rw.status
+		event.DurationMs = time.Since(start).Milliseconds()
+		if rw.capture && rw.status < http.StatusMultipleChoices {
+			event.ResultSize = resultSize(rw.body.Bytes(), op.Results)
+		}
+		l.Record(event)
+	})
+}
+
+// describe returns an event with the scope, time range and rule of r. The
+// body of r is read and restored.
+func describe(r *http.Request) Event {
+	var event Event
+	if r.Body != nil && r.Body != http.NoBody {
+		body, err := io.ReadAll(r.Body)
+		_ = r.Body.Close()
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		var request struct {
+			StartTime   time.Time                  `json:"startTime"`
+			EndTime     time.Time                  `json:"endTime"`
+			SearchScope map[string]json.RawMessage `json:"searchScope"`
+			Metadata    map[string]json.RawMessage `json:"metadata"`
+		}
+		if err == nil && json.Unmarshal(body, &request) == nil {
+			event.StartTime, event.EndTime = request.StartTime, request.EndTime
+			event.Scope = stringFields(request.SearchScope)
+			if metadata := stringFields(request.Metadata); event.Scope == nil && metadata != nil {
+				event.Rule = metadata["name"]
+				delete(metadata, "name")
+				event.Scope = metadata
+			}
+		}
+	}
+	if rule := r.PathValue("ruleName"); rule != "" {
+		event.Rule = rule
+	}
+	return event
+}
+
+// stringFields returns the non-empty string fields of fields, or nil when
+// there are none.
+func stringFields(fields map[string]json.RawMessage) map[string]string {
+	var values map[string]string
+	for name, raw := range fields {
+		var value string
+		if json.Unmarshal(raw, &value) != nil || strings.TrimSpace(value) == "" {
+			continue
+		}
+		if values == nil {
+			values = make(map[string]string)
+		}
+		values[name] = value
+	}
+	return values
+}
+
+// resultSize returns the length of the array field of the JSON object body,
+// or nil when body is not such an object.
+func resultSize(body []byte, field string) *int {
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(body, &fields) != nil {
+		return nil
+	}
+	var results []json.RawMessage
+	if json.Unmarshal(fields[field], &results) != nil {
+		return nil
+	}
+	n := len(results)
+	return &n
+}
+
+// ServeHTTP writes the metrics of the log in the Prometheus text exposition
+// format.
+func (l *Log) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	fmt.Fprintf(w, "# HELP %[1]s_audit_events_total Audit events, by whether they were written, dropped because the queue was full, or failed to be written.\n"+
+		"# TYPE %[1]s_audit_events_total counter\n"+
+		"%[1]s_audit_events_total{result=\"dropped\"} %[2]d\n"+
+		"%[1]s_audit_events_total{result=\"failed\"} %[3]d\n"+
+		"%[1]s_audit_events_total{result=\"written\"} %[4]d\n", l.prefix, l.dropped.Load(), l.failed.Load(), l.written.Load())
+}
+
+// recordingWriter records the status of a response and, when capture is
+// set, the start of its body.
+type recordingWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	capture     bool
+	body        bytes.Buffer
+}
+
+func (rw *recordingWriter) WriteHeader(status int) {
+	if !rw.wroteHeader {
+		rw.status, rw.wroteHeader = status, true
+	}
+	rw.ResponseWriter.WriteHeader(status)
+}
+
+func (rw *recordingWriter) Write(b []byte) (int, error) {
+	rw.wroteHeader = true
+	if rw.capture {
+		if rw.body.Len()+len(b) > maxCapturedBytes {
+			rw.capture = false
+			rw.body = bytes.Buffer{}
+		} else {
+			rw.body.Write(b)
+		}
+	}
+	return rw.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (rw *recordingWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
+func (rw *recordingWriter) Flush() {
+	if f, ok := rw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
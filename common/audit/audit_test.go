@@ -0,0 +1,179 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/openobserve"
+)
+
+type fakeSink struct {
+	mu     sync.Mutex
+	events []Event
+	err    error
+}
+
+func (f *fakeSink) Write(_ context.Context, events []Event) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return f.err
+	}
+	f.events = append(f.events, events...)
+	return nil
+}
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func TestMiddleware(t *testing.T) {
+	sink := &fakeSink{}
+	l := NewLog(sink, "logs_adapter", testLogger())
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Method == http.MethodPost && !bytes.Contains(body, []byte("searchScope")) && !bytes.Contains(body, []byte("metadata")) {
+			t.Errorf("expected the handler to read the body, got %q", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"logs":[{},{},{}],"total":3}`))
+	})
+	operations := map[string]Operation{
+		"POST /api/v1/logs/query":                      {Name: "QueryLogs", Results: "logs"},
+		"POST /api/v1alpha1/alerts/rules":              {Name: "CreateAlertRule"},
+		"DELETE /api/v1alpha1/alerts/rules/{ruleName}": {Name: "DeleteAlertRule"},
+	}
+	handler := l.Middleware(operations, func(*http.Request) string { return "sre" }, next)
+
+	serve := func(method, path, body string) {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	serve(http.MethodPost, "/api/v1/logs/query",
+		`{"startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-02T00:00:00Z","searchScope":{"namespace":"acme","componentUid":"c1","limit":3}}`)
+	serve(http.MethodPost, "/api/v1alpha1/alerts/rules", `{"metadata":{"name":"high-errors","namespace":"acme"}}`)
+	serve(http.MethodDelete, "/api/v1alpha1/alerts/rules/high-errors", "")
+	serve(http.MethodGet, "/health", "")
+	l.Flush(context.Background())
+
+	if len(sink.events) != 3 {
+		t.Fatalf("expected an event per audited request, got %+v", sink.events)
+	}
+	query := sink.events[0]
+	if query.Operation != "QueryLogs" || query.Caller != "sre" || query.Status != http.StatusOK ||
+		query.Scope["namespace"] != "acme" || query.Scope["componentUid"] != "c1" || len(query.Scope) != 2 ||
+		!query.StartTime.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) || query.ResultSize == nil || *query.ResultSize != 3 {
+		t.Errorf("unexpected query event: %+v", query)
+	}
+	if create := sink.events[1]; create.Rule != "high-errors" || create.Scope["namespace"] != "acme" || len(create.Scope) != 1 || create.ResultSize != nil {
+		t.Errorf("unexpected create event: %+v", create)
+	}
+	if del := sink.events[2]; del.Operation != "DeleteAlertRule" || del.Rule != "high-errors" || del.Scope != nil {
+		t.Errorf("unexpected delete event: %+v", del)
+	}
+}
+
+func TestMiddleware_Rejected(t *testing.T) {
+	sink := &fakeSink{}
+	l := NewLog(sink, "logs_adapter", testLogger())
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"logs":[]}`, http.StatusForbidden)
+	})
+	handler := l.Middleware(map[string]Operation{"POST /query": {Name: "QueryLogs", Results: "logs"}}, nil, next)
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/query", strings.NewReader(`{}`)))
+	l.Flush(context.Background())
+
+	if len(sink.events) != 1 || sink.events[0].Status != http.StatusForbidden || sink.events[0].ResultSize != nil || sink.events[0].Caller != "" {
+		t.Errorf("expected the rejection to be recorded without a result size, got %+v", sink.events)
+	}
+}
+
+func TestMiddleware_NilLog(t *testing.T) {
+	next := http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})
+	var l *Log
+	if got := l.Middleware(nil, nil, next); got == nil {
+		t.Fatal("expected the next handler")
+	}
+}
+
+func TestLog_Flush(t *testing.T) {
+	sink := &fakeSink{err: errors.New("boom")}
+	l := NewLog(sink, "logs_adapter", testLogger())
+	for i := 0; i < queueSize+1; i++ {
+		l.Record(Event{Operation: "QueryLogs"})
+	}
+	l.Flush(context.Background())
+	sink.err = nil
+	l.Record(Event{Operation: "QueryLogs"})
+	l.Flush(context.Background())
+
+	rec := httptest.NewRecorder()
+	l.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	for _, want := range []string{
+		`logs_adapter_audit_events_total{result="dropped"} 1`,
+		`logs_adapter_audit_events_total{result="failed"} 4096`,
+		`logs_adapter_audit_events_total{result="written"} 1`,
+	} {
+		if !strings.Contains(rec.Body.String(), want) {
+			t.Errorf("expected %q in metrics:\n%s", want, rec.Body.String())
+		}
+	}
+}
+
+func TestWriterSink(t *testing.T) {
+	var buf bytes.Buffer
+	events := []Event{{Operation: "QueryLogs", Status: 200}, {Operation: "QueryTraces", Status: 500}}
+	if err := NewWriterSink(&buf).Write(context.Background(), events); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[1], `"operation":"QueryTraces"`) || strings.Contains(lines[0], "startTime") {
+		t.Errorf("expected a JSON line per event, got %q", buf.String())
+	}
+}
+
+func TestStreamSink(t *testing.T) {
+	var gotPath string
+	var got []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		_, _ = w.Write([]byte(`{"status":[{"failed":0}]}`))
+	}))
+	defer server.Close()
+
+	at := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	sink := NewStreamSink(openobserve.NewClient(server.URL, "default", "admin", "pass", testLogger()), "audit")
+	if err := sink.Write(context.Background(), []Event{{Time: at, Operation: "QueryLogs", Caller: "sre"}}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if gotPath != "/api/default/audit/_json" || len(got) != 1 || got[0]["caller"] != "sre" || got[0]["_timestamp"] != float64(at.UnixMicro()) {
+		t.Errorf("unexpected ingestion of %s: %+v", gotPath, got)
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	for _, c := range []Config{{}, {Sink: SinkNone}, {Sink: SinkStdout}, {Sink: SinkStream, Stream: "audit"}} {
+		if err := c.Validate(); err != nil {
+			t.Errorf("Validate(%+v) error = %v", c, err)
+		}
+	}
+	for _, c := range []Config{{Sink: "file"}, {Sink: SinkStream}, {Sink: SinkStream, Stream: "audit/../x"}} {
+		if err := c.Validate(); err == nil {
+			t.Errorf("expected Validate(%+v) to fail", c)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package auth authenticates the requests served by an adapter, with a static
+// bearer token or with JSON Web Tokens validated against the keys of a JWKS
+// URL.
+package auth
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ErrUnauthenticated is returned for requests without valid credentials.
+var ErrUnauthenticated = errors.New("missing or invalid bearer token")
+
+// Authentication modes.
+const (
+	// ModeNone accepts all requests.
+	ModeNone = "none"
+	// ModeToken accepts requests carrying a static bearer token.
+	ModeToken = "token"
+	// ModeJWT accepts requests carrying a JWT signed by a key of a JWKS URL.
+	ModeJWT = "jwt"
+)
+
+// Authenticator decides whether a request may be served. Authenticate
+// returns an error wrapping ErrUnauthenticated when it may not.
+type Authenticator interface {
+	Authenticate(r *http.Request) error
+}
+
+// AuthenticatorFunc is an Authenticator implemented by a function.
+type AuthenticatorFunc func(r *http.Request) error
+
+// Authenticate calls f(r).
+func (f AuthenticatorFunc) Authenticate(r *http.Request) error {
+	return f(r)
+}
+
+// Any accepts the requests that one of authenticators accepts. It returns
+// the error of the first one otherwise.
+func Any(authenticators ...Authenticator) Authenticator {
+	return AuthenticatorFunc(func(r *http.Request) error {
+		var first error
+		for _, a := range authenticators {
+			err := a.Authenticate(r)
+			if err == nil {
+				return nil
+			}
+			if first == nil {
+				first = err
+			}
+		}
+		if first == nil {
+			first = ErrUnauthenticated
+		}
+		return first
+	})
+}
+
+// BearerToken returns the bearer token of the Authorization header of r.
+func BearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// StaticToken accepts the requests carrying one bearer token.
+type StaticToken struct {
+	token []byte
+}
+
+// NewStaticToken returns an Authenticator accepting token.
+func NewStaticToken(token string) *StaticToken {
+	return &StaticToken{token: []byte(token)}
+}
+
+// Authenticate implements Authenticator.
+func (s *StaticToken) Authenticate(r *http.Request) error {
+	token, ok := BearerToken(r)
+	if !ok || subtle.ConstantTimeCompare([]byte(token), s.token) != 1 {
+		return ErrUnauthenticated
+	}
+	return nil
+}
+
+// Config selects and configures the authentication of an adapter.
+type Config struct {
+	// Mode is ModeNone, ModeToken or ModeJWT. An empty mode is ModeNone.
+	Mode string
+	// Token is the bearer token of ModeToken.
+	Token string
+	// JWKSURL, Issuer and Audience configure ModeJWT; see JWTConfig.
+	JWKSURL  string
+	Issuer   string
+	Audience string
+	// ExemptPaths are served without authentication; see Middleware.
+	ExemptPaths []string
+}
+
+// MinTokenLength is the minimum length of the static bearer token.
+const MinTokenLength = 16
+
+// Validate reports configuration errors.
+func (c Config) Validate() error {
+	switch c.Mode {
+	case "", ModeNone:
+	case ModeToken:
+		if len(c.Token) < MinTokenLength {
+			return fmt.Errorf("the token must be at least %d bytes long", MinTokenLength)
+		}
+	case ModeJWT:
+		u, err := url.Parse(c.JWKSURL)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return errors.New("the JWKS URL must be an http or https URL")
+		}
+	default:
+		return fmt.Errorf("unknown mode %q: must be %s, %s or %s", c.Mode, ModeNone, ModeToken, ModeJWT)
+	}
+	return nil
+}
+
+// New returns the Authenticator configured by c, or nil in ModeNone.
+func New(c Config) (Authenticator, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	switch c.Mode {
+	case ModeToken:
+		return NewStaticToken(c.Token), nil
+	case ModeJWT:
+		return NewJWT(JWTConfig{
+			JWKSURL:  c.JWKSURL,
+			Issuer:   c.Issuer,
+			Audience: c.Audience,
+			HTTPClient: &http.Client{
+				Timeout: 10 * time.Second,
+			},
+		}), nil
+	}
+	return nil, nil
+}
+
+// Middleware serves the requests a accepts with next, and the others with
+// reject, after setting the WWW-Authenticate header. Requests for the
+// exempt paths are always served: paths ending with a slash exempt all the
+// paths they prefix, the others only themselves. A nil a accepts all
+// requests.
+func Middleware(a Authenticator, exempt []string, reject func(w http.ResponseWriter, r *http.Request, err error), next http.Handler) http.Handler {
+	if a == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isExempt(r.URL.Path, exempt) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if err := a.Authenticate(r); err != nil {
+			w.Header().Set("WWW-Authenticate", `Bearer`)
+			reject(w, r, err)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func isExempt(path string, exempt []string) bool {
+	for _, e := range exempt {
+		if path == e || (strings.HasSuffix(e, "/") && strings.HasPrefix(path, e)) {
+			return true
+		}
+	}
+	return false
+}
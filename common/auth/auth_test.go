@@ -0,0 +1,111 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func request(path, authorization string) *http.Request {
+	r := httptest.NewRequest(http.MethodGet, path, nil)
+	if authorization != "" {
+		r.Header.Set("Authorization", authorization)
+	}
+	return r
+}
+
+func TestStaticToken(t *testing.T) {
+	a := NewStaticToken("0123456789abcdef")
+	for authorization, want := range map[string]bool{
+		"Bearer 0123456789abcdef":  true,
+		"bearer 0123456789abcdef":  true,
+		"Bearer 0123456789abcdeX":  false,
+		"Basic 0123456789abcdef":   false,
+		"Bearer ":                  false,
+		"":                         false,
+		"Bearer 0123456789abcdef0": false,
+	} {
+		err := a.Authenticate(request("/", authorization))
+		if (err == nil) != want {
+			t.Errorf("Authenticate(%q) = %v, want accepted=%v", authorization, err, want)
+		}
+		if err != nil && !errors.Is(err, ErrUnauthenticated) {
+			t.Errorf("expected ErrUnauthenticated, got %v", err)
+		}
+	}
+}
+
+func TestAny(t *testing.T) {
+	a := Any(NewStaticToken("first-token-0123"), NewStaticToken("second-token-012"))
+	if err := a.Authenticate(request("/", "Bearer second-token-012")); err != nil {
+		t.Errorf("expected the second token to be accepted, got %v", err)
+	}
+	if err := a.Authenticate(request("/", "Bearer third-token-0123")); !errors.Is(err, ErrUnauthenticated) {
+		t.Errorf("expected ErrUnauthenticated, got %v", err)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	reject := func(w http.ResponseWriter, r *http.Request, err error) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}
+	handler := Middleware(NewStaticToken("0123456789abcdef"), []string{"/health", "/shared/"}, reject, next)
+
+	tests := []struct {
+		path, authorization string
+		want                int
+	}{
+		{"/api/logs", "Bearer 0123456789abcdef", http.StatusNoContent},
+		{"/api/logs", "", http.StatusUnauthorized},
+		{"/health", "", http.StatusNoContent},
+		{"/health/deep", "", http.StatusUnauthorized},
+		{"/shared/abc", "", http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, request(tt.path, tt.authorization))
+		if rec.Code != tt.want {
+			t.Errorf("%s with %q: expected %d, got %d", tt.path, tt.authorization, tt.want, rec.Code)
+		}
+		if rec.Code == http.StatusUnauthorized && rec.Header().Get("WWW-Authenticate") != "Bearer" {
+			t.Errorf("%s: expected a WWW-Authenticate header", tt.path)
+		}
+	}
+
+	if Middleware(nil, nil, reject, next) == nil {
+		t.Fatal("expected a handler")
+	}
+}
+
+func TestConfig(t *testing.T) {
+	for _, c := range []Config{
+		{},
+		{Mode: ModeNone},
+		{Mode: ModeToken, Token: "0123456789abcdef"},
+		{Mode: ModeJWT, JWKSURL: "https://issuer.example.com/.well-known/jwks.json"},
+	} {
+		if _, err := New(c); err != nil {
+			t.Errorf("New(%+v): unexpected error: %v", c, err)
+		}
+	}
+	if a, _ := New(Config{}); a != nil {
+		t.Errorf("expected no authenticator without a mode, got %T", a)
+	}
+	for _, c := range []Config{
+		{Mode: "oauth"},
+		{Mode: ModeToken, Token: "short"},
+		{Mode: ModeJWT},
+		{Mode: ModeJWT, JWKSURL: "file:///etc/jwks.json"},
+	} {
+		if err := c.Validate(); err == nil {
+			t.Errorf("Validate(%+v): expected error", c)
+		}
+	}
+}
@@ -0,0 +1,354 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Defaults of JWTConfig.
+const (
+	DefaultJWKSRefreshInterval = 15 * time.Minute
+	DefaultJWTLeeway           = 30 * time.Second
+)
+
+// minJWKSRefreshInterval bounds how often tokens signed with an unknown key
+// make the JWKS be fetched again, and how often a failed fetch is retried.
+const minJWKSRefreshInterval = 30 * time.Second
+
+// JWTConfig configures the validation of JSON Web Tokens.
+type JWTConfig struct {
+	// JWKSURL serves the JSON Web Key Set of the keys tokens are signed with.
+	JWKSURL string
+	// Issuer, when set, must be the iss claim of tokens.
+	Issuer string
+	// Audience, when set, must be one of the aud claims of tokens.
+	Audience string
+	// HTTPClient fetches the key set; http.DefaultClient when nil.
+	HTTPClient *http.Client
+	// RefreshInterval is how long the key set is cached;
+	// DefaultJWKSRefreshInterval when zero. Tokens signed with an unknown key
+	// make it be fetched sooner, so that rotated keys are picked up.
+	RefreshInterval time.Duration
+	// Leeway is the clock skew tolerated when checking the exp and nbf
+	// claims; DefaultJWTLeeway when zero.
+	Leeway time.Duration
+}
+
+// JWT accepts the requests carrying a JSON Web Token in their Authorization
+// header that is signed with RS256, RS384, RS512, PS256, PS384, PS512,
+// ES256, ES384 or ES512 by a key of the configured key set, has not expired
+// and, when configured, has the expected issuer and audience. It is safe for
+// concurrent use.
+type JWT struct {
+	config JWTConfig
+	now    func() time.Time
+
+	mu        sync.Mutex
+	keys      map[string]jwk
+	fetchedAt time.Time
+	// failedAt is when fetching the key set last failed.
+	failedAt time.Time
+}
+
+// NewJWT returns a JWT authenticator. The key set is fetched on first use.
+func NewJWT(config JWTConfig) *JWT {
+	if config.HTTPClient == nil {
+		config.HTTPClient = http.DefaultClient
+	}
+	if config.RefreshInterval <= 0 {
+		config.RefreshInterval = DefaultJWKSRefreshInterval
+	}
+	if config.Leeway <= 0 {
+		config.Leeway = DefaultJWTLeeway
+	}
+	return &JWT{config: config, now: time.Now}
+}
+
+// Authenticate implements Authenticator.
+func (j *JWT) Authenticate(r *http.Request) error {
+	token, ok := BearerToken(r)
+	if !ok {
+		return ErrUnauthenticated
+	}
+	if err := j.verify(r.Context(), token); err != nil {
+		return fmt.Errorf("%w: %w", ErrUnauthenticated, err)
+	}
+	return nil
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+type jwtClaims struct {
+	Issuer    string   `json:"iss"`
+	Audience  audience `json:"aud"`
+	ExpiresAt *float64 `json:"exp"`
+	NotBefore *float64 `json:"nbf"`
+}
+
+// audience is the aud claim, a string or an array of strings.
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return errors.New("aud must be a string or an array of strings")
+	}
+	*a = list
+	return nil
+}
+
+func (j *JWT) verify(ctx context.Context, token string) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return errors.New("malformed token")
+	}
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return fmt.Errorf("malformed token header: %w", err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return errors.New("malformed token signature")
+	}
+
+	key, err := j.key(ctx, header.Kid)
+	if err != nil {
+		return err
+	}
+	if key.alg != "" && key.alg != header.Alg {
+		return fmt.Errorf("key %q does not sign with %s", header.Kid, header.Alg)
+	}
+	if err := verifySignature(header.Alg, key.public, parts[0]+"."+parts[1], signature); err != nil {
+		return err
+	}
+
+	var claims jwtClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return fmt.Errorf("malformed token claims: %w", err)
+	}
+	now := j.now()
+	if claims.ExpiresAt == nil {
+		return errors.New("token has no exp claim")
+	}
+	if now.After(unixTime(*claims.ExpiresAt).Add(j.config.Leeway)) {
+		return errors.New("token has expired")
+	}
+	if claims.NotBefore != nil && now.Add(j.config.Leeway).Before(unixTime(*claims.NotBefore)) {
+		return errors.New("token is not valid yet")
+	}
+	if j.config.Issuer != "" && claims.Issuer != j.config.Issuer {
+		return fmt.Errorf("unexpected issuer %q", claims.Issuer)
+	}
+	if j.config.Audience != "" && !slices.Contains(claims.Audience, j.config.Audience) {
+		return errors.New("token is not intended for this audience")
+	}
+	return nil
+}
+
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func unixTime(seconds float64) time.Time {
+	return time.Unix(0, int64(seconds*float64(time.Second)))
+}
+
+// verifySignature checks signature of signed with the public key of alg.
+func verifySignature(alg string, public crypto.PublicKey, signed string, signature []byte) error {
+	var hash crypto.Hash
+	switch alg {
+	case "RS256", "PS256", "ES256":
+		hash = crypto.SHA256
+	case "RS384", "PS384", "ES384":
+		hash = crypto.SHA384
+	case "RS512", "PS512", "ES512":
+		hash = crypto.SHA512
+	default:
+		return fmt.Errorf("unsupported signing algorithm %q", alg)
+	}
+	h := hash.New()
+	h.Write([]byte(signed))
+	digest := h.Sum(nil)
+
+	errInvalid := errors.New("invalid token signature")
+	switch key := public.(type) {
+	case *rsa.PublicKey:
+		var err error
+		switch alg[0] {
+		case 'R':
+			err = rsa.VerifyPKCS1v15(key, hash, digest, signature)
+		case 'P':
+			err = rsa.VerifyPSS(key, hash, digest, signature, nil)
+		default:
+			return errInvalid
+		}
+		if err != nil {
+			return errInvalid
+		}
+	case *ecdsa.PublicKey:
+		size := (key.Curve.Params().BitSize + 7) / 8
+		if alg[0] != 'E' || len(signature) != 2*size {
+			return errInvalid
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(key, digest, r, s) {
+			return errInvalid
+		}
+	default:
+		return errInvalid
+	}
+	return nil
+}
+
+// jwk is a public key of the key set.
+type jwk struct {
+	alg    string
+	public crypto.PublicKey
+}
+
+// key returns the key kid of the key set, fetching the key set when it is
+// stale or does not have the key. A token without kid may be verified with
+// the only key of a key set.
+func (j *JWT) key(ctx context.Context, kid string) (jwk, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	now := j.now()
+	since := now.Sub(j.fetchedAt)
+	key, found := j.lookup(kid)
+	stale := j.keys == nil || since > j.config.RefreshInterval || (!found && since > minJWKSRefreshInterval)
+	if stale && now.Sub(j.failedAt) > minJWKSRefreshInterval {
+		keys, err := j.fetch(ctx)
+		if err != nil {
+			j.failedAt = now
+			// A stale key set is still better than none.
+			if found {
+				return key, nil
+			}
+			return jwk{}, err
+		}
+		j.keys, j.fetchedAt = keys, now
+		key, found = j.lookup(kid)
+	}
+	if !found {
+		return jwk{}, fmt.Errorf("unknown signing key %q", kid)
+	}
+	return key, nil
+}
+
+func (j *JWT) lookup(kid string) (jwk, bool) {
+	if kid == "" && len(j.keys) == 1 {
+		for _, key := range j.keys {
+			return key, true
+		}
+	}
+	key, ok := j.keys[kid]
+	return key, ok
+}
+
+// fetch fetches the key set. Keys that are not signing keys, or of a type
+// that is not supported, are left out.
+func (j *JWT) fetch(ctx context.Context) (map[string]jwk, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, j.config.JWKSURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JWKS request: %w", err)
+	}
+	resp, err := j.config.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch JWKS: status %d", resp.StatusCode)
+	}
+
+	var set struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Use string `json:"use"`
+			Alg string `json:"alg"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+			Crv string `json:"crv"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		} `json:"keys"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&set); err != nil {
+		return nil, fmt.Errorf("failed to parse JWKS: %w", err)
+	}
+
+	keys := make(map[string]jwk, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		var public crypto.PublicKey
+		switch k.Kty {
+		case "RSA":
+			n, errN := base64.RawURLEncoding.DecodeString(k.N)
+			e, errE := base64.RawURLEncoding.DecodeString(k.E)
+			if errN != nil || errE != nil || len(e) == 0 || len(e) > 4 {
+				continue
+			}
+			public = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+		case "EC":
+			var curve elliptic.Curve
+			switch k.Crv {
+			case "P-256":
+				curve = elliptic.P256()
+			case "P-384":
+				curve = elliptic.P384()
+			case "P-521":
+				curve = elliptic.P521()
+			default:
+				continue
+			}
+			x, errX := base64.RawURLEncoding.DecodeString(k.X)
+			y, errY := base64.RawURLEncoding.DecodeString(k.Y)
+			if errX != nil || errY != nil {
+				continue
+			}
+			key := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+			if !curve.IsOnCurve(key.X, key.Y) {
+				continue
+			}
+			public = key
+		default:
+			continue
+		}
+		keys[k.Kid] = jwk{alg: k.Alg, public: public}
+	}
+	return keys, nil
+}
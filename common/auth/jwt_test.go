@@ -0,0 +1,181 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+var b64 = base64.RawURLEncoding
+
+// sign returns a token of claims signed by key with alg.
+func sign(t *testing.T, alg, kid string, key crypto.Signer, claims map[string]any) string {
+	t.Helper()
+	header, _ := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signed := b64.EncodeToString(header) + "." + b64.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signed))
+
+	var signature []byte
+	var err error
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		if alg == "PS256" {
+			signature, err = rsa.SignPSS(rand.Reader, k, crypto.SHA256, digest[:], nil)
+		} else {
+			signature, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:])
+		}
+	case *ecdsa.PrivateKey:
+		var r, s *big.Int
+		r, s, err = ecdsa.Sign(rand.Reader, k, digest[:])
+		signature = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	}
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return signed + "." + b64.EncodeToString(signature)
+}
+
+type jwksServer struct {
+	*httptest.Server
+	keys    []map[string]string
+	fetches int
+}
+
+func newJWKSServer(t *testing.T) *jwksServer {
+	s := &jwksServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.fetches++
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": s.keys})
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func rsaJWK(kid string, key *rsa.PrivateKey) map[string]string {
+	return map[string]string{
+		"kty": "RSA", "kid": kid, "use": "sig",
+		"n": b64.EncodeToString(key.N.Bytes()),
+		"e": b64.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+func ecJWK(kid string, key *ecdsa.PrivateKey) map[string]string {
+	return map[string]string{
+		"kty": "EC", "kid": kid, "crv": "P-256",
+		"x": b64.EncodeToString(key.X.FillBytes(make([]byte, 32))),
+		"y": b64.EncodeToString(key.Y.FillBytes(make([]byte, 32))),
+	}
+}
+
+func TestJWT(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	otherKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	jwks := newJWKSServer(t)
+	jwks.keys = []map[string]string{rsaJWK("rsa-1", rsaKey), ecJWK("ec-1", ecKey)}
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	a := NewJWT(JWTConfig{JWKSURL: jwks.URL, Issuer: "https://issuer", Audience: "logs-adapter"})
+	a.now = func() time.Time { return now }
+
+	claims := func(overrides map[string]any) map[string]any {
+		c := map[string]any{"iss": "https://issuer", "aud": []string{"other", "logs-adapter"}, "exp": now.Add(time.Hour).Unix()}
+		for k, v := range overrides {
+			if v == nil {
+				delete(c, k)
+			} else {
+				c[k] = v
+			}
+		}
+		return c
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		ok    bool
+	}{
+		{"RS256", sign(t, "RS256", "rsa-1", rsaKey, claims(nil)), true},
+		{"PS256", sign(t, "PS256", "rsa-1", rsaKey, claims(nil)), true},
+		{"ES256", sign(t, "ES256", "ec-1", ecKey, claims(nil)), true},
+		{"single audience", sign(t, "RS256", "rsa-1", rsaKey, claims(map[string]any{"aud": "logs-adapter"})), true},
+		{"expired within leeway", sign(t, "RS256", "rsa-1", rsaKey, claims(map[string]any{"exp": now.Add(-10 * time.Second).Unix()})), true},
+		{"expired", sign(t, "RS256", "rsa-1", rsaKey, claims(map[string]any{"exp": now.Add(-time.Hour).Unix()})), false},
+		{"no exp", sign(t, "RS256", "rsa-1", rsaKey, claims(map[string]any{"exp": nil})), false},
+		{"not yet valid", sign(t, "RS256", "rsa-1", rsaKey, claims(map[string]any{"nbf": now.Add(time.Hour).Unix()})), false},
+		{"wrong issuer", sign(t, "RS256", "rsa-1", rsaKey, claims(map[string]any{"iss": "https://evil"})), false},
+		{"wrong audience", sign(t, "RS256", "rsa-1", rsaKey, claims(map[string]any{"aud": "tracing-adapter"})), false},
+		{"wrong key", sign(t, "RS256", "rsa-1", otherKey, claims(nil)), false},
+		{"algorithm of another key type", sign(t, "ES256", "rsa-1", ecKey, claims(nil)), false},
+		{"unknown key", sign(t, "RS256", "rsa-2", rsaKey, claims(nil)), false},
+		{"malformed", "not.a-token", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := a.Authenticate(request("/", "Bearer "+tt.token))
+			if (err == nil) != tt.ok {
+				t.Fatalf("expected accepted=%v, got %v", tt.ok, err)
+			}
+			if err != nil && !errors.Is(err, ErrUnauthenticated) {
+				t.Errorf("expected ErrUnauthenticated, got %v", err)
+			}
+		})
+	}
+
+	t.Run("none algorithm", func(t *testing.T) {
+		token := sign(t, "RS256", "rsa-1", rsaKey, claims(nil))
+		parts := strings.Split(token, ".")
+		header := b64.EncodeToString([]byte(`{"alg":"none","kid":"rsa-1"}`))
+		if err := a.Authenticate(request("/", "Bearer "+header+"."+parts[1]+".")); err == nil {
+			t.Fatal("expected unsigned tokens to be rejected")
+		}
+	})
+}
+
+func TestJWTKeyRotation(t *testing.T) {
+	oldKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	newKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	jwks := newJWKSServer(t)
+	jwks.keys = []map[string]string{rsaJWK("old", oldKey)}
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	a := NewJWT(JWTConfig{JWKSURL: jwks.URL})
+	a.now = func() time.Time { return now }
+	exp := map[string]any{"exp": now.Add(time.Hour).Unix()}
+
+	if err := a.Authenticate(request("/", "Bearer "+sign(t, "RS256", "old", oldKey, exp))); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := a.Authenticate(request("/", "Bearer "+sign(t, "RS256", "", oldKey, exp))); err != nil {
+		t.Fatalf("expected a token without kid to use the only key: %v", err)
+	}
+
+	jwks.keys = []map[string]string{rsaJWK("old", oldKey), rsaJWK("new", newKey)}
+	newToken := "Bearer " + sign(t, "RS256", "new", newKey, exp)
+	if err := a.Authenticate(request("/", newToken)); err == nil {
+		t.Fatal("expected the new key to be unknown until the key set may be fetched again")
+	}
+	now = now.Add(time.Minute)
+	if err := a.Authenticate(request("/", newToken)); err != nil {
+		t.Fatalf("expected the new key to be picked up: %v", err)
+	}
+	if jwks.fetches != 2 {
+		t.Errorf("expected 2 fetches, got %d", jwks.fetches)
+	}
+}
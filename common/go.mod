@@ -0,0 +1,3 @@
+module github.com/openchoreo/community-modules/common
+
+go 1.25
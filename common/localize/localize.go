@@ -0,0 +1,251 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package localize decorates the JSON responses of an adapter for display
+// when the request asks for it, converting timestamps to a time zone and
+// formatting durations, so that consoles do not have to.
+package localize
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidTimeZone rejects requests whose tz parameter is not a time zone.
+	ErrInvalidTimeZone = errors.New("tz must be an IANA time zone name")
+	// ErrInvalidHumanize rejects requests whose humanize parameter is not a boolean.
+	ErrInvalidHumanize = errors.New("humanize must be a boolean")
+)
+
+// durationUnits maps the suffixes of duration fields in responses to their unit.
+var durationUnits = []struct {
+	suffix string
+	unit   time.Duration
+}{
+	{"Ns", time.Nanosecond},
+	{"Ms", time.Millisecond},
+	{"Seconds", time.Second},
+}
+
+// localizer decorates decoded JSON responses for display.
+type localizer struct {
+	// Location is the zone timestamps are converted to, or nil to keep them as is.
+	Location *time.Location
+	// Humanize adds a display string next to each duration field.
+	Humanize bool
+}
+
+// Middleware decorates successful JSON responses for display when the
+// request carries the tz or humanize query parameters. tz=<IANA zone name>
+// converts RFC 3339 timestamp fields to that zone. humanize=true adds a
+// <name>Display field next to each duration field (durationNs, tookMs,
+// intervalSeconds, ...) holding the duration formatted for display, e.g.
+// "durationDisplay": "1.25ms". Original values are kept, so decorated
+// responses remain valid for the API contract. Other responses are passed
+// through unchanged. Requests with invalid parameters are answered by reject
+// with ErrInvalidTimeZone or ErrInvalidHumanize, so each module renders its
+// own 400 response.
+func Middleware(reject func(w http.ResponseWriter, r *http.Request, err error), next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if !query.Has("tz") && !query.Has("humanize") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		var l localizer
+		if tz := query.Get("tz"); tz != "" {
+			loc, err := time.LoadLocation(tz)
+			if err != nil {
+				reject(w, r, ErrInvalidTimeZone)
+				return
+			}
+			l.Location = loc
+		}
+		if v := query.Get("humanize"); v != "" {
+			humanize, err := strconv.ParseBool(v)
+			if err != nil {
+				reject(w, r, ErrInvalidHumanize)
+				return
+			}
+			l.Humanize = humanize
+		}
+		if l.Location == nil && !l.Humanize {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		bw := NewBufferingWriter(w)
+		next.ServeHTTP(bw, r)
+		status, body, ok := bw.Buffered()
+		if !ok {
+			return
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.UseNumber()
+		var v any
+		if err := decoder.Decode(&v); err == nil {
+			if decorated, err := json.Marshal(l.decorate(v)); err == nil {
+				body = append(decorated, '\n')
+			}
+		}
+		w.Header().Del("Content-Length")
+		w.WriteHeader(status)
+		_, _ = w.Write(body)
+	})
+}
+
+// BufferingWriter buffers successful JSON responses, for middlewares
+// rewriting them, and passes any other response straight through, so
+// streamed downloads are not held in memory.
+type BufferingWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	passthrough bool
+	buf         bytes.Buffer
+}
+
+// NewBufferingWriter returns a BufferingWriter writing through to w.
+func NewBufferingWriter(w http.ResponseWriter) *BufferingWriter {
+	return &BufferingWriter{ResponseWriter: w}
+}
+
+// Buffered returns the status and body of the buffered response. ok is
+// false when no response was written or it was passed through.
+func (bw *BufferingWriter) Buffered() (status int, body []byte, ok bool) {
+	if bw.passthrough || !bw.wroteHeader {
+		return 0, nil, false
+	}
+	return bw.status, bw.buf.Bytes(), true
+}
+
+func (bw *BufferingWriter) WriteHeader(status int) {
+	if bw.wroteHeader {
+		return
+	}
+	bw.wroteHeader = true
+	bw.status = status
+	mediaType, _, _ := mime.ParseMediaType(bw.Header().Get("Content-Type"))
+	if status < 200 || status >= 300 || mediaType != "application/json" {
+		bw.passthrough = true
+		bw.ResponseWriter.WriteHeader(status)
+	}
+}
+
+func (bw *BufferingWriter) Write(b []byte) (int, error) {
+	if !bw.wroteHeader {
+		bw.WriteHeader(http.StatusOK)
+	}
+	if bw.passthrough {
+		return bw.ResponseWriter.Write(b)
+	}
+	return bw.buf.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (bw *BufferingWriter) Unwrap() http.ResponseWriter {
+	return bw.ResponseWriter
+}
+
+func (bw *BufferingWriter) Flush() {
+	if f, ok := bw.ResponseWriter.(http.Flusher); ok && bw.passthrough {
+		f.Flush()
+	}
+}
+
+// decorate rewrites timestamp fields and adds duration display fields in v,
+// recursively, and returns it.
+func (l localizer) decorate(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		display := make(map[string]any)
+		for key, value := range v {
+			switch value := value.(type) {
+			case string:
+				if l.Location != nil && isTimestampKey(key) {
+					if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+						v[key] = t.In(l.Location).Format(time.RFC3339Nano)
+					}
+				}
+			case json.Number:
+				if l.Humanize {
+					if name, d, ok := durationField(key, value); ok {
+						display[name+"Display"] = humanizeDuration(d)
+					}
+				}
+			default:
+				v[key] = l.decorate(value)
+			}
+		}
+		for key, value := range display {
+			if _, exists := v[key]; !exists {
+				v[key] = value
+			}
+		}
+	case []any:
+		for i := range v {
+			v[i] = l.decorate(v[i])
+		}
+	}
+	return v
+}
+
+// isTimestampKey reports whether key names a timestamp field.
+func isTimestampKey(key string) bool {
+	return key == "time" || key == "timestamp" ||
+		strings.HasSuffix(key, "Time") || strings.HasSuffix(key, "Timestamp") || strings.HasSuffix(key, "At")
+}
+
+// durationField returns the display name and value of key when it names a
+// duration field.
+func durationField(key string, value json.Number) (string, time.Duration, bool) {
+	for _, u := range durationUnits {
+		name, ok := strings.CutSuffix(key, u.suffix)
+		if !ok || name == "" {
+			continue
+		}
+		f, err := value.Float64()
+		if err != nil {
+			return "", 0, false
+		}
+		return name, time.Duration(f * float64(u.unit)), true
+	}
+	return "", 0, false
+}
+
+// humanizeDuration formats d with its largest unit and at most two decimals,
+// e.g. "850µs", "1.25ms" or "3.5s". Durations of a minute or more use the
+// hour/minute/second form, e.g. "2m5s".
+func humanizeDuration(d time.Duration) string {
+	if d < 0 {
+		return "-" + humanizeDuration(-d)
+	}
+	if d.Round(time.Second) >= time.Minute {
+		return d.Round(time.Second).String()
+	}
+	for _, u := range []struct {
+		unit   time.Duration
+		suffix string
+	}{
+		{time.Second, "s"},
+		{time.Millisecond, "ms"},
+		{time.Microsecond, "µs"},
+	} {
+		// Round before comparing so that 999.999ms becomes "1s", not "1000ms".
+		if rounded := d.Round(u.unit / 100); rounded >= u.unit {
+			value := math.Round(float64(rounded)/float64(u.unit)*100) / 100
+			return strconv.FormatFloat(value, 'f', -1, 64) + u.suffix
+		}
+	}
+	return strconv.FormatInt(int64(d), 10) + "ns"
+}
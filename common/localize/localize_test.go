@@ -0,0 +1,119 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package localize
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHumanizeDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "0ns"},
+		{850 * time.Nanosecond, "850ns"},
+		{850 * time.Microsecond, "850µs"},
+		{1250 * time.Microsecond, "1.25ms"},
+		{999999 * time.Microsecond, "1s"},
+		{3500 * time.Millisecond, "3.5s"},
+		{125 * time.Second, "2m5s"},
+		{-2 * time.Millisecond, "-2ms"},
+	}
+	for _, tt := range tests {
+		if got := humanizeDuration(tt.d); got != tt.want {
+			t.Errorf("humanizeDuration(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	body := `{"logs":[{"timestamp":"2026-03-01T12:00:00Z","log":"started at 2026-03-01T12:00:00Z"}],` +
+		`"tookMs":1250,"total":1,"window":{"startTime":"2026-03-01T11:00:00.5Z","intervalSeconds":90}}`
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	})
+	reject := func(w http.ResponseWriter, r *http.Request, err error) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	}
+	handler := Middleware(reject, next)
+
+	t.Run("timezone and humanize", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/query?tz=Europe/Berlin&humanize=true", nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", rec.Code)
+		}
+
+		var got struct {
+			Logs []struct {
+				Timestamp string `json:"timestamp"`
+				Log       string `json:"log"`
+			} `json:"logs"`
+			TookMs      json.Number `json:"tookMs"`
+			TookDisplay string      `json:"tookDisplay"`
+			Total       json.Number `json:"total"`
+			Window      struct {
+				StartTime       string `json:"startTime"`
+				IntervalDisplay string `json:"intervalDisplay"`
+			} `json:"window"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if got.Logs[0].Timestamp != "2026-03-01T13:00:00+01:00" {
+			t.Errorf("unexpected timestamp: %s", got.Logs[0].Timestamp)
+		}
+		if got.Logs[0].Log != "started at 2026-03-01T12:00:00Z" {
+			t.Errorf("expected non-timestamp fields to be unchanged, got %q", got.Logs[0].Log)
+		}
+		if got.Window.StartTime != "2026-03-01T12:00:00.5+01:00" {
+			t.Errorf("unexpected nested timestamp: %s", got.Window.StartTime)
+		}
+		if got.TookMs != "1250" || got.TookDisplay != "1.25s" {
+			t.Errorf("unexpected took fields: %s, %q", got.TookMs, got.TookDisplay)
+		}
+		if got.Window.IntervalDisplay != "1m30s" {
+			t.Errorf("unexpected interval display: %q", got.Window.IntervalDisplay)
+		}
+		if got.Total != "1" {
+			t.Errorf("expected total to be unchanged, got %s", got.Total)
+		}
+	})
+
+	t.Run("no parameters", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/query", nil))
+		if rec.Body.String() != body {
+			t.Errorf("expected response to be unchanged, got %s", rec.Body.String())
+		}
+	})
+
+	t.Run("non-JSON response", func(t *testing.T) {
+		csv := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/csv")
+			_, _ = w.Write([]byte("timestamp,log\n"))
+		})
+		rec := httptest.NewRecorder()
+		Middleware(reject, csv).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?tz=UTC", nil))
+		if rec.Body.String() != "timestamp,log\n" {
+			t.Errorf("expected non-JSON response to be unchanged, got %q", rec.Body.String())
+		}
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		for _, query := range []string{"tz=Mars/Olympus", "humanize=maybe"} {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/query?"+query, nil))
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected 400 for %s, got %d", query, rec.Code)
+			}
+		}
+	})
+}
@@ -0,0 +1,321 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package metrics records the requests served by an adapter and the calls it
+// makes to OpenObserve, and serves them in the Prometheus text exposition
+// format.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/openobserve"
+)
+
+// DurationBuckets are the upper bounds, in seconds, of the buckets of the
+// duration histograms.
+var DurationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// unroutedHandler is the handler label of requests answered before reaching
+// a route, for example rejected by a middleware, or matching no route.
+const unroutedHandler = "unrouted"
+
+// noResponseCode is the code label of OpenObserve calls that failed without
+// a response.
+const noResponseCode = "error"
+
+// canceledCode is the code label of OpenObserve calls canceled by the
+// adapter, typically because the client of the request went away.
+const canceledCode = "canceled"
+
+// histogram counts observations in DurationBuckets.
+type histogram struct {
+	counts []int64
+	count  int64
+	sum    float64
+}
+
+func (h *histogram) observe(seconds float64) {
+	if h.counts == nil {
+		h.counts = make([]int64, len(DurationBuckets))
+	}
+	for i, upper := range DurationBuckets {
+		if seconds <= upper {
+			h.counts[i]++
+		}
+	}
+	h.count++
+	h.sum += seconds
+}
+
+// write writes the series of the histogram name with labels, which must be
+// empty or end with a comma.
+func (h *histogram) write(w http.ResponseWriter, name, labels string) {
+	for i, upper := range DurationBuckets {
+		var n int64
+		if h.counts != nil {
+			n = h.counts[i]
+		}
+		fmt.Fprintf(w, "%s_bucket{%sle=%q} %d\n", name, labels, strconv.FormatFloat(upper, 'g', -1, 64), n)
+	}
+	fmt.Fprintf(w, "%s_bucket{%sle=\"+Inf\"} %d\n", name, labels, h.count)
+	labels = strings.TrimSuffix(labels, ",")
+	fmt.Fprintf(w, "%s_sum{%s} %g\n%s_count{%s} %d\n", name, labels, h.sum, name, labels, h.count)
+}
+
+type requestKey struct {
+	handler, method, code string
+}
+
+type routeKey struct {
+	handler, method string
+}
+
+type backendKey struct {
+	operation, code string
+}
+
+// Metrics records the requests served by an adapter, by route and status
+// code, and its calls to OpenObserve, by operation and status code. A nil
+// *Metrics records nothing.
+type Metrics struct {
+	// prefix starts the names of the metrics, for example logs_adapter.
+	prefix string
+
+	mu               sync.Mutex
+	requests         map[requestKey]int64
+	requestDurations map[routeKey]*histogram
+	backendCalls     map[backendKey]int64
+	backendDurations map[string]*histogram
+	// canceledRequests counts the requests abandoned by their client, by route.
+	canceledRequests map[string]int64
+}
+
+// New returns metrics whose names start with prefix.
+func New(prefix string) *Metrics {
+	return &Metrics{
+		prefix:           prefix,
+		requests:         map[requestKey]int64{},
+		requestDurations: map[routeKey]*histogram{},
+		backendCalls:     map[backendKey]int64{},
+		backendDurations: map[string]*histogram{},
+		canceledRequests: map[string]int64{},
+	}
+}
+
+// routeContextKey is the context key of the route of a request, which Route
+// sets once the request has been routed.
+type routeContextKey struct{}
+
+// Instrument records the requests served by next. It should wrap all the
+// middlewares of the server, so that the requests they answer are counted
+// too, and the mux should be wrapped with Route so that requests are
+// labelled with the pattern of their route.
+func (m *Metrics) Instrument(next http.Handler) http.Handler {
+	if m == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := new(string)
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		start := time.Now()
+		next.ServeHTTP(sw, r.WithContext(context.WithValue(r.Context(), routeContextKey{}, route)))
+		// The request context is only canceled before ServeHTTP returns
+		// when the client went away.
+		m.observeRequest(*route, r.Method, sw.status, time.Since(start), errors.Is(r.Context().Err(), context.Canceled))
+	})
+}
+
+// Route records the pattern of the route mux serves a request with, for
+// Instrument.
+func (m *Metrics) Route(mux http.Handler) http.Handler {
+	if m == nil {
+		return mux
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mux.ServeHTTP(w, r)
+		SetRoute(r, r.Pattern)
+	})
+}
+
+// SetRoute records pattern as the route of r for Instrument, for requests
+// answered before reaching the mux wrapped by Route, for example from a
+// cache.
+func SetRoute(r *http.Request, pattern string) {
+	if route, ok := r.Context().Value(routeContextKey{}).(*string); ok && pattern != "" {
+		// The method of the pattern is left out: it is its own label.
+		_, path, found := strings.Cut(pattern, " ")
+		if !found {
+			path = pattern
+		}
+		*route = path
+	}
+}
+
+func (m *Metrics) observeRequest(handler, method string, status int, duration time.Duration, canceled bool) {
+	if handler == "" {
+		handler = unroutedHandler
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests[requestKey{handler, method, strconv.Itoa(status)}]++
+	if canceled {
+		m.canceledRequests[handler]++
+	}
+	key := routeKey{handler, method}
+	h, ok := m.requestDurations[key]
+	if !ok {
+		h = &histogram{}
+		m.requestDurations[key] = h
+	}
+	h.observe(duration.Seconds())
+}
+
+// ObserveBackend records a call to OpenObserve. It is an
+// openobserve.RequestObserver. Canceled calls are counted but left out of
+// the duration histograms.
+func (m *Metrics) ObserveBackend(info openobserve.RequestInfo) {
+	if m == nil {
+		return
+	}
+	code := noResponseCode
+	switch {
+	case info.StatusCode != 0:
+		code = strconv.Itoa(info.StatusCode)
+	case errors.Is(info.Err, context.Canceled):
+		code = canceledCode
+	}
+	operation := backendOperation(info.Path)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.backendCalls[backendKey{operation, code}]++
+	if code == canceledCode {
+		// Canceled calls last as long as their caller waited, not as long as
+		// OpenObserve took.
+		return
+	}
+	h, ok := m.backendDurations[operation]
+	if !ok {
+		h = &histogram{}
+		m.backendDurations[operation] = h
+	}
+	h.observe(info.Duration.Seconds())
+}
+
+// backendOperation names the OpenObserve API called at path. Paths embed
+// organization and stream names, which would make poor labels.
+func backendOperation(path string) string {
+	switch {
+	case strings.HasSuffix(path, "/_search"):
+		return "search"
+	case strings.Contains(path, "/prometheus/api/"):
+		return "promql"
+	case strings.Contains(path, "/alerts"):
+		return "alerts"
+	case strings.Contains(path, "/streams"):
+		return "streams"
+	case strings.Contains(path, "/ingest/") || strings.HasSuffix(path, "/_json"):
+		return "ingest"
+	case path == "/healthz":
+		return "health"
+	}
+	return "other"
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+
+	name := m.prefix + "_http_requests_total"
+	fmt.Fprintf(w, "# HELP %s Requests served, by route, method and status code.\n# TYPE %s counter\n", name, name)
+	requestKeys := sortedKeys(m.requests, func(a, b requestKey) int {
+		return strings.Compare(a.handler+" "+a.method+" "+a.code, b.handler+" "+b.method+" "+b.code)
+	})
+	for _, k := range requestKeys {
+		fmt.Fprintf(w, "%s{handler=%q,method=%q,code=%q} %d\n", name, k.handler, k.method, k.code, m.requests[k])
+	}
+
+	name = m.prefix + "_http_request_duration_seconds"
+	fmt.Fprintf(w, "# HELP %s Time spent serving requests, by route and method.\n# TYPE %s histogram\n", name, name)
+	routeKeys := sortedKeys(m.requestDurations, func(a, b routeKey) int {
+		return strings.Compare(a.handler+" "+a.method, b.handler+" "+b.method)
+	})
+	for _, k := range routeKeys {
+		m.requestDurations[k].write(w, name, fmt.Sprintf("handler=%q,method=%q,", k.handler, k.method))
+	}
+
+	name = m.prefix + "_http_requests_canceled_total"
+	fmt.Fprintf(w, "# HELP %s Requests abandoned by their client before the response was complete, by route.\n# TYPE %s counter\n", name, name)
+	for _, handler := range sortedKeys(m.canceledRequests, strings.Compare) {
+		fmt.Fprintf(w, "%s{handler=%q} %d\n", name, handler, m.canceledRequests[handler])
+	}
+
+	name = m.prefix + "_openobserve_requests_total"
+	fmt.Fprintf(w, "# HELP %s Calls to OpenObserve, by operation and status code; the code is %q when the call was canceled and %q when it got no response otherwise.\n# TYPE %s counter\n",
+		name, canceledCode, noResponseCode, name)
+	backendKeys := sortedKeys(m.backendCalls, func(a, b backendKey) int {
+		return strings.Compare(a.operation+" "+a.code, b.operation+" "+b.code)
+	})
+	for _, k := range backendKeys {
+		fmt.Fprintf(w, "%s{operation=%q,code=%q} %d\n", name, k.operation, k.code, m.backendCalls[k])
+	}
+
+	name = m.prefix + "_openobserve_request_duration_seconds"
+	fmt.Fprintf(w, "# HELP %s Round-trip time of calls to OpenObserve, retries included, by operation.\n# TYPE %s histogram\n", name, name)
+	for _, operation := range sortedKeys(m.backendDurations, strings.Compare) {
+		m.backendDurations[operation].write(w, name, fmt.Sprintf("operation=%q,", operation))
+	}
+}
+
+// sortedKeys returns the keys of m sorted by cmp, so that scrapes list the
+// series in a stable order.
+func sortedKeys[K comparable, V any](m map[K]V, cmp func(a, b K) int) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, cmp)
+	return keys
+}
+
+// statusWriter remembers the status code of a response.
+type statusWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (sw *statusWriter) WriteHeader(status int) {
+	if !sw.wroteHeader {
+		sw.wroteHeader = true
+		sw.status = status
+	}
+	sw.ResponseWriter.WriteHeader(status)
+}
+
+func (sw *statusWriter) Write(b []byte) (int, error) {
+	sw.wroteHeader = true
+	return sw.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (sw *statusWriter) Unwrap() http.ResponseWriter {
+	return sw.ResponseWriter
+}
+
+func (sw *statusWriter) Flush() {
+	if f, ok := sw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/openobserve"
+)
+
+func scrape(t *testing.T, m *Metrics) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	return rec.Body.String()
+}
+
+func TestInstrument(t *testing.T) {
+	m := New("test_adapter")
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/items/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") == "missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	})
+	// The middleware replaces the request, as most middlewares do.
+	withAuth := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") == "" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), struct{}{}, "caller")))
+		})
+	}
+	handler := m.Instrument(withAuth(m.Route(mux)))
+
+	for _, target := range []string{"/api/items/1", "/api/items/2", "/api/items/missing", "/nowhere"} {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.Header.Set("Authorization", "Bearer token")
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/items/1", nil))
+
+	body := scrape(t, m)
+	for _, want := range []string{
+		`test_adapter_http_requests_total{handler="/api/items/{id}",method="GET",code="200"} 2`,
+		`test_adapter_http_requests_total{handler="/api/items/{id}",method="GET",code="404"} 1`,
+		`test_adapter_http_requests_total{handler="unrouted",method="GET",code="404"} 1`,
+		`test_adapter_http_requests_total{handler="unrouted",method="GET",code="401"} 1`,
+		`test_adapter_http_request_duration_seconds_bucket{handler="/api/items/{id}",method="GET",le="+Inf"} 3`,
+		`test_adapter_http_request_duration_seconds_count{handler="/api/items/{id}",method="GET"} 3`,
+		"# TYPE test_adapter_http_request_duration_seconds histogram",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected %q in:\n%s", want, body)
+		}
+	}
+}
+
+func TestObserveBackend(t *testing.T) {
+	m := New("test_adapter")
+	m.ObserveBackend(openobserve.RequestInfo{Method: "POST", Path: "/api/default/_search", StatusCode: 200, Duration: 20 * time.Millisecond})
+	m.ObserveBackend(openobserve.RequestInfo{Method: "POST", Path: "/api/default/_search", StatusCode: 503, Duration: time.Second})
+	m.ObserveBackend(openobserve.RequestInfo{Method: "GET", Path: "/api/v2/default/alerts", Err: errors.New("refused")})
+	m.ObserveBackend(openobserve.RequestInfo{Method: "POST", Path: "/api/default/_search", Err: fmt.Errorf("Post: %w", context.Canceled)})
+
+	body := scrape(t, m)
+	for _, want := range []string{
+		`test_adapter_openobserve_requests_total{operation="search",code="200"} 1`,
+		`test_adapter_openobserve_requests_total{operation="search",code="503"} 1`,
+		`test_adapter_openobserve_requests_total{operation="alerts",code="error"} 1`,
+		`test_adapter_openobserve_requests_total{operation="search",code="canceled"} 1`,
+		`test_adapter_openobserve_request_duration_seconds_bucket{operation="search",le="0.025"} 1`,
+		`test_adapter_openobserve_request_duration_seconds_bucket{operation="search",le="1"} 2`,
+		`test_adapter_openobserve_request_duration_seconds_sum{operation="search"} 1.02`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected %q in:\n%s", want, body)
+		}
+	}
+}
+
+func TestInstrument_Canceled(t *testing.T) {
+	m := New("test_adapter")
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v1/logs/query", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	handler := m.Instrument(m.Route(mux))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/api/v1/logs/query", nil).WithContext(ctx))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/nowhere", nil))
+
+	body := scrape(t, m)
+	if want := `test_adapter_http_requests_canceled_total{handler="/api/v1/logs/query"} 1`; !strings.Contains(body, want) {
+		t.Errorf("expected %q in:\n%s", want, body)
+	}
+	if strings.Contains(body, `test_adapter_http_requests_canceled_total{handler="unrouted"}`) {
+		t.Errorf("expected only the abandoned request to be counted:\n%s", body)
+	}
+}
+
+func TestBackendOperation(t *testing.T) {
+	for path, want := range map[string]string{
+		"/api/default/_search":                 "search",
+		"/api/default/prometheus/api/v1/query": "promql",
+		"/api/v2/default/alerts/abc":           "alerts",
+		"/api/default/streams/default/schema":  "streams",
+		"/api/default/ingest/metrics/_json":    "ingest",
+		"/api/default/trace_archive/_json":     "ingest",
+		"/healthz":                             "health",
+		"/api/default/functions":               "other",
+	} {
+		if got := backendOperation(path); got != want {
+			t.Errorf("backendOperation(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestNilMetrics(t *testing.T) {
+	var m *Metrics
+	next := http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})
+	if m.Instrument(next) == nil || m.Route(next) == nil {
+		t.Fatal("expected handlers")
+	}
+	m.ObserveBackend(openobserve.RequestInfo{})
+}
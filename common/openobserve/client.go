@@ -0,0 +1,332 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package openobserve holds the HTTP plumbing shared by the modules calling
+// OpenObserve: authentication, request execution with retries and a circuit
+// breaker, search response decoding, SQL escaping and request metrics hooks.
+// The modules build their queries and parse their hits on top of it.
+package openobserve
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// streamNotFoundCode is the OpenObserve error code of searches on a stream
+// that does not exist yet: OpenObserve only creates a stream on first ingest.
+const streamNotFoundCode = 20002
+
+// ErrStreamNotFound is returned by Search when the searched stream does not
+// exist yet. Callers should treat it as an empty result, not a failure.
+var ErrStreamNotFound = errors.New("openobserve stream not found")
+
+// isStreamNotFound parses an OpenObserve error envelope and returns true when
+// its code is streamNotFoundCode.
+func isStreamNotFound(body []byte) bool {
+	if len(body) == 0 {
+		return false
+	}
+
+	var envelope struct {
+		Code int `json:"code"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return false
+	}
+	return envelope.Code == streamNotFoundCode
+}
+
+// StatusError is returned when OpenObserve answers with an unexpected status.
+type StatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("openobserve returned status %d: %s", e.StatusCode, e.Body)
+}
+
+// SearchResponse is the response of the OpenObserve search API.
+type SearchResponse struct {
+	Took  int                      `json:"took"`
+	Hits  []map[string]interface{} `json:"hits"`
+	Total int                      `json:"total"`
+	// IsPartial is set when OpenObserve answered with part of the data,
+	// e.g. because the search reached its timeout or a node failed.
+	IsPartial bool `json:"is_partial,omitempty"`
+}
+
+// RequestInfo describes a call to OpenObserve, retries included.
+type RequestInfo struct {
+	Method string
+	// Path is the URL path of the call, without its query.
+	Path string
+	// StatusCode is zero when the call failed without a response.
+	StatusCode int
+	Duration   time.Duration
+	Err        error
+}
+
+// RequestObserver is called after every call to OpenObserve, typically to
+// record metrics. It must be safe for concurrent use.
+type RequestObserver func(RequestInfo)
+
+// Client calls the API of an OpenObserve organization.
+type Client struct {
+	baseURL    string
+	org        string
+	httpClient *http.Client
+	logger     *slog.Logger
+	// jsonNumbers decodes the numbers of search hits as json.Number rather
+	// than float64.
+	jsonNumbers bool
+	observers   []RequestObserver
+
+	// credentialsMu guards the credentials, which SetCredentials and
+	// SetSecondaryCredentials replace when the password is rotated.
+	credentialsMu  sync.RWMutex
+	user           string
+	token          string
+	secondaryUser  string
+	secondaryToken string
+}
+
+// NewClient returns a client of the organization org of the OpenObserve
+// instance at baseURL, authenticating with basic auth.
+func NewClient(baseURL, org, user, token string, logger *slog.Logger) *Client {
+	return &Client{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		org:     org,
+		user:    user,
+		token:   token,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		logger: logger,
+	}
+}
+
+// BaseURL returns the URL of the OpenObserve instance, without trailing slash.
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// Org returns the organization of the client.
+func (c *Client) Org() string {
+	return c.org
+}
+
+// SetCredentials replaces the credentials used to call OpenObserve, for
+// example after the password was rotated in the secret store.
+func (c *Client) SetCredentials(user, token string) {
+	c.credentialsMu.Lock()
+	defer c.credentialsMu.Unlock()
+	c.user, c.token = user, token
+}
+
+// SetSecondaryCredentials sets the credentials a call is retried with when
+// OpenObserve rejects the primary ones, so that a password can be rotated
+// without a maintenance window: the new password is configured as the
+// secondary one before it is changed in OpenObserve. Once the secondary
+// credentials are accepted they become the primary ones. An empty token
+// removes them.
+func (c *Client) SetSecondaryCredentials(user, token string) {
+	c.credentialsMu.Lock()
+	defer c.credentialsMu.Unlock()
+	c.secondaryUser, c.secondaryToken = user, token
+}
+
+// SetJSONNumbers makes Search decode the numbers of hits as json.Number,
+// which keeps nanosecond timestamps exact.
+func (c *Client) SetJSONNumbers(enabled bool) {
+	c.jsonNumbers = enabled
+}
+
+// AddRequestObserver makes observer be called after every call, after the
+// observers added before it. Observers must be added before the client is
+// used.
+func (c *Client) AddRequestObserver(observer RequestObserver) {
+	c.observers = append(c.observers, observer)
+}
+
+// Do authenticates req and sends it to OpenObserve. A call rejected with
+// 401 is retried once with the secondary credentials, if any, provided its
+// body can be replayed.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	c.credentialsMu.RLock()
+	user, token := c.user, c.token
+	secondaryUser, secondaryToken := c.secondaryUser, c.secondaryToken
+	c.credentialsMu.RUnlock()
+
+	resp, err := c.send(req, user, token)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || secondaryToken == "" {
+		return resp, err
+	}
+	retry := req.Clone(req.Context())
+	if req.Body != nil && req.Body != http.NoBody {
+		if req.GetBody == nil {
+			return resp, nil
+		}
+		body, bodyErr := req.GetBody()
+		if bodyErr != nil {
+			return resp, nil
+		}
+		retry.Body = body
+	}
+	retryResp, err := c.send(retry, secondaryUser, secondaryToken)
+	if err != nil {
+		return resp, nil
+	}
+	resp.Body.Close()
+	if retryResp.StatusCode != http.StatusUnauthorized {
+		c.promoteSecondary(user, token, secondaryUser, secondaryToken)
+	}
+	return retryResp, nil
+}
+
+// promoteSecondary swaps the primary and secondary credentials after the
+// secondary ones were accepted, unless they were replaced in the meantime.
+func (c *Client) promoteSecondary(user, token, secondaryUser, secondaryToken string) {
+	c.credentialsMu.Lock()
+	defer c.credentialsMu.Unlock()
+	if c.user != user || c.token != token || c.secondaryUser != secondaryUser || c.secondaryToken != secondaryToken {
+		return
+	}
+	c.user, c.token, c.secondaryUser, c.secondaryToken = secondaryUser, secondaryToken, user, token
+	c.logger.Info("OpenObserve accepted the secondary credentials, using them as the primary ones",
+		slog.String("user", secondaryUser))
+}
+
+// send authenticates req with user and token, sends it and notifies the
+// observers.
+func (c *Client) send(req *http.Request, user, token string) (*http.Response, error) {
+	req.SetBasicAuth(user, token)
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if len(c.observers) > 0 {
+		info := RequestInfo{Method: req.Method, Path: req.URL.Path, Duration: time.Since(start), Err: err}
+		if resp != nil {
+			info.StatusCode = resp.StatusCode
+		}
+		for _, observer := range c.observers {
+			observer(info)
+		}
+	}
+	return resp, err
+}
+
+// Search runs a search query against the streams of the given type. It
+// returns ErrStreamNotFound when the stream does not exist yet and a
+// *StatusError when OpenObserve rejects the query.
+func (c *Client) Search(ctx context.Context, streamType string, queryJSON []byte) (*SearchResponse, error) {
+	return c.SearchOrg(ctx, c.org, streamType, queryJSON)
+}
+
+// SearchOrg is Search against the streams of another organization of the
+// OpenObserve instance, with the credentials of the client.
+func (c *Client) SearchOrg(ctx context.Context, org, streamType string, queryJSON []byte) (*SearchResponse, error) {
+	url := fmt.Sprintf("%s/api/%s/_search?type=%s", c.baseURL, org, streamType)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(queryJSON))
+	if err != nil {
+		c.logger.Error("Failed to create request", slog.Any("error", err))
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			// The caller gave up on the search, e.g. its client went away.
+			c.logger.Debug("Search request against OpenObserve canceled", slog.Any("error", err))
+		} else {
+			c.logger.Error("Failed to execute search request against OpenObserve", slog.Any("error", err))
+		}
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		c.logger.Error("Failed to read response body returned by OpenObserve", slog.Any("error", err))
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		if isStreamNotFound(body) {
+			return nil, ErrStreamNotFound
+		}
+		c.logger.Error("OpenObserve returned error",
+			slog.Int("statusCode", resp.StatusCode),
+			slog.String("body", string(body)))
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	var searchResp SearchResponse
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	if c.jsonNumbers {
+		decoder.UseNumber()
+	}
+	if err := decoder.Decode(&searchResp); err != nil {
+		c.logger.Error("Failed to unmarshal response from OpenObserve", slog.Any("error", err))
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return &searchResp, nil
+}
+
+// Ingest writes records to a logs stream of the organization through the
+// JSON ingestion API. OpenObserve creates the stream on first ingest. It
+// returns a *StatusError when OpenObserve rejects the request, and an error
+// when it rejects some of the records.
+func (c *Client) Ingest(ctx context.Context, stream string, records []map[string]interface{}) error {
+	payload, err := json.Marshal(records)
+	if err != nil {
+		return fmt.Errorf("failed to encode records: %w", err)
+	}
+	url := fmt.Sprintf("%s/api/%s/%s/_json", c.baseURL, c.org, stream)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return &StatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	var result struct {
+		Status []struct {
+			Failed int    `json:"failed"`
+			Error  string `json:"error"`
+		} `json:"status"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return fmt.Errorf("failed to unmarshal ingestion response: %w", err)
+	}
+	for _, s := range result.Status {
+		if s.Failed > 0 {
+			return fmt.Errorf("openobserve rejected %d records: %s", s.Failed, s.Error)
+		}
+	}
+	return nil
+}
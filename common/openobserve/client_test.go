@@ -0,0 +1,253 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func newTestClient(serverURL string) *Client {
+	return NewClient(serverURL, "default", "admin", "token", testLogger())
+}
+
+func TestNewClient(t *testing.T) {
+	c := NewClient("http://localhost:5080/", "myorg", "user", "pass", testLogger())
+	if c.BaseURL() != "http://localhost:5080" {
+		t.Errorf("expected trailing slash removed, got %q", c.BaseURL())
+	}
+	if c.Org() != "myorg" {
+		t.Errorf("unexpected org: %q", c.Org())
+	}
+}
+
+func TestSearch(t *testing.T) {
+	var gotPath, gotType, gotUser string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotType = r.URL.Path, r.URL.Query().Get("type")
+		gotUser, _, _ = r.BasicAuth()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"took":3,"total":1,"hits":[{"start_time":1735689600000000001}],"is_partial":true}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	resp, err := client.Search(context.Background(), "traces", []byte(`{}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotPath != "/api/default/_search" || gotType != "traces" || gotUser != "admin" {
+		t.Errorf("unexpected request: %s?type=%s as %s", gotPath, gotType, gotUser)
+	}
+	if resp.Took != 3 || resp.Total != 1 || len(resp.Hits) != 1 || !resp.IsPartial {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+	if _, ok := resp.Hits[0]["start_time"].(float64); !ok {
+		t.Errorf("expected float64 numbers by default, got %T", resp.Hits[0]["start_time"])
+	}
+
+	client.SetJSONNumbers(true)
+	client.SetCredentials("rotated", "secret")
+	resp, err = client.Search(context.Background(), "traces", []byte(`{}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n, ok := resp.Hits[0]["start_time"].(json.Number); !ok || n.String() != "1735689600000000001" {
+		t.Errorf("expected an exact json.Number, got %v", resp.Hits[0]["start_time"])
+	}
+	if gotUser != "rotated" {
+		t.Errorf("expected the rotated credentials, got %q", gotUser)
+	}
+
+	if _, err := client.SearchOrg(context.Background(), "build_east", "logs", []byte(`{}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotPath != "/api/build_east/_search" || gotType != "logs" || gotUser != "rotated" {
+		t.Errorf("unexpected request: %s?type=%s as %s", gotPath, gotType, gotUser)
+	}
+}
+
+func TestSearch_Errors(t *testing.T) {
+	status, body := http.StatusBadRequest, `{"code":20002,"message":"Search stream not found"}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	if _, err := client.Search(context.Background(), "logs", []byte(`{}`)); !errors.Is(err, ErrStreamNotFound) {
+		t.Errorf("expected ErrStreamNotFound, got %v", err)
+	}
+
+	status, body = http.StatusInternalServerError, "boom"
+	_, err := client.Search(context.Background(), "logs", []byte(`{}`))
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != 500 || statusErr.Body != "boom" {
+		t.Errorf("expected a StatusError, got %v", err)
+	}
+}
+
+func TestIngest(t *testing.T) {
+	var gotPath string
+	var got []map[string]interface{}
+	response := `{"code":200,"status":[{"name":"rollups","successful":2,"failed":0}]}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	records := []map[string]interface{}{{"_timestamp": 1, "count": 2}, {"_timestamp": 2, "count": 3}}
+	if err := client.Ingest(context.Background(), "rollups", records); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotPath != "/api/default/rollups/_json" || len(got) != 2 {
+		t.Errorf("unexpected request: %s with %v", gotPath, got)
+	}
+
+	response = `{"code":200,"status":[{"name":"rollups","successful":1,"failed":1,"error":"bad record"}]}`
+	if err := client.Ingest(context.Background(), "rollups", records); err == nil || !strings.Contains(err.Error(), "bad record") {
+		t.Errorf("expected the rejected records to fail the ingestion, got %v", err)
+	}
+}
+
+func TestIsStreamNotFound(t *testing.T) {
+	cases := []struct {
+		name string
+		body string
+		want bool
+	}{
+		{"matching code", `{"code":20002,"message":"..."}`, true},
+		{"other code", `{"code":20001,"message":"..."}`, false},
+		{"no code field", `{"message":"foo"}`, false},
+		{"empty body", ``, false},
+		{"malformed json", `{not json`, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := isStreamNotFound([]byte(tc.body))
+			if got != tc.want {
+				t.Fatalf("isStreamNotFound(%q) = %v, want %v", tc.body, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRequestObserver(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	defer server.Close()
+
+	var mu sync.Mutex
+	var calls []RequestInfo
+	client := newTestClient(server.URL)
+	for range 2 {
+		client.AddRequestObserver(func(info RequestInfo) {
+			mu.Lock()
+			defer mu.Unlock()
+			calls = append(calls, info)
+		})
+	}
+	client.Search(context.Background(), "logs", []byte(`{}`))
+
+	if len(calls) != 2 || calls[0].Method != http.MethodPost || calls[0].Path != "/api/default/_search" ||
+		calls[0].StatusCode != http.StatusTeapot || calls[0].Err != nil {
+		t.Errorf("unexpected observed calls: %+v", calls)
+	}
+}
+
+func TestSecondaryCredentials(t *testing.T) {
+	password := "new"
+	var mu sync.Mutex
+	var seen []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, pass, _ := r.BasicAuth()
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		defer mu.Unlock()
+		seen = append(seen, pass+":"+string(body))
+		if pass != password {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"took":1,"hits":[],"total":0}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "default", "admin", "old", testLogger())
+	client.SetSecondaryCredentials("admin", "new")
+
+	if _, err := client.Search(context.Background(), "logs", []byte(`{"q":1}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(seen) != 2 || seen[0] != `old:{"q":1}` || seen[1] != `new:{"q":1}` {
+		t.Fatalf("expected a retry with the secondary credentials and the same body, got %v", seen)
+	}
+
+	// The secondary credentials were promoted: the next call uses them first.
+	seen = nil
+	if _, err := client.Search(context.Background(), "logs", []byte(`{}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(seen) != 1 || seen[0] != "new:{}" {
+		t.Errorf("expected the promoted credentials to be used first, got %v", seen)
+	}
+
+	// Rolling back the password works through the demoted credentials.
+	mu.Lock()
+	password = "old"
+	seen = nil
+	mu.Unlock()
+	if _, err := client.Search(context.Background(), "logs", []byte(`{}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(seen) != 2 || seen[1] != "old:{}" {
+		t.Errorf("expected a retry with the demoted credentials, got %v", seen)
+	}
+}
+
+func TestSecondaryCredentials_BothRejected(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "default", "admin", "old", testLogger())
+	_, err := client.Search(context.Background(), "logs", []byte(`{}`))
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusUnauthorized || calls != 1 {
+		t.Fatalf("expected a single rejected call without secondary credentials, got %v after %d calls", err, calls)
+	}
+
+	calls = 0
+	client.SetSecondaryCredentials("admin", "new")
+	_, err = client.Search(context.Background(), "logs", []byte(`{}`))
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusUnauthorized || calls != 2 {
+		t.Fatalf("expected the 401 of the secondary credentials after 2 calls, got %v after %d calls", err, calls)
+	}
+	client.credentialsMu.RLock()
+	defer client.credentialsMu.RUnlock()
+	if client.token != "old" {
+		t.Errorf("rejected secondary credentials must not be promoted, primary is %q", client.token)
+	}
+}
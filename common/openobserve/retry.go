@@ -0,0 +1,227 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// ErrBackendUnavailable is returned when OpenObserve could not be reached
+// after retries, or when the circuit breaker rejects calls after repeated
+// failures.
+var ErrBackendUnavailable = errors.New("openobserve is unavailable")
+
+// RetryPolicy configures the retries and the circuit breaker of the calls of
+// a client to OpenObserve.
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts of a call, including the first.
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry, doubled before every
+	// following retry up to MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// BreakerThreshold is the number of consecutive failed calls that opens
+	// the circuit breaker. The breaker is disabled when it is zero.
+	BreakerThreshold int
+	// BreakerCooldown is how long an open breaker rejects calls before it
+	// lets a trial call through.
+	BreakerCooldown time.Duration
+}
+
+// DefaultRetryPolicy retries a call twice and opens the breaker after five
+// consecutive failed calls for 30 seconds.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:      3,
+	InitialBackoff:   200 * time.Millisecond,
+	MaxBackoff:       2 * time.Second,
+	BreakerThreshold: 5,
+	BreakerCooldown:  30 * time.Second,
+}
+
+// SetRetryPolicy makes the calls of the client to OpenObserve follow policy.
+// Searches and calls that do not change anything are retried on connection
+// errors, 429 and 5xx responses; other calls are never retried since they
+// may have taken effect. Every call counts towards the circuit breaker.
+func (c *Client) SetRetryPolicy(policy RetryPolicy) {
+	next := c.httpClient.Transport
+	if rt, ok := next.(*retryTransport); ok {
+		next = rt.next
+	}
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	rt := &retryTransport{next: next, policy: policy, logger: c.logger, sleep: sleepContext}
+	if policy.BreakerThreshold > 0 {
+		rt.breaker = &circuitBreaker{threshold: policy.BreakerThreshold, cooldown: policy.BreakerCooldown, now: time.Now}
+	}
+	c.httpClient.Transport = rt
+}
+
+// retryTransport is an http.RoundTripper retrying transient failures and
+// short-circuiting calls while its breaker is open.
+type retryTransport struct {
+	next    http.RoundTripper
+	policy  RetryPolicy
+	breaker *circuitBreaker
+	logger  *slog.Logger
+	sleep   func(ctx context.Context, d time.Duration) error
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.breaker != nil && !t.breaker.allow() {
+		return nil, fmt.Errorf("%w: circuit breaker is open", ErrBackendUnavailable)
+	}
+
+	attempts := max(t.policy.MaxAttempts, 1)
+	if !retryable(req) {
+		attempts = 1
+	}
+	backoff := t.policy.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		resp, err := t.next.RoundTrip(req)
+		failed := transientError(req.Context(), err) || (err == nil && transientStatus(resp.StatusCode))
+		if !failed || attempt == attempts {
+			t.record(failed)
+			if failed && err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrBackendUnavailable, err)
+			}
+			return resp, err
+		}
+
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+		t.logger.Debug("Retrying OpenObserve call",
+			slog.String("path", req.URL.Path),
+			slog.Int("attempt", attempt+1),
+			slog.Duration("backoff", backoff))
+		if err := t.sleep(req.Context(), backoff); err != nil {
+			return nil, err
+		}
+		backoff = min(backoff*2, t.policy.MaxBackoff)
+	}
+}
+
+// record reports the outcome of a call to the breaker.
+func (t *retryTransport) record(failed bool) {
+	if t.breaker == nil {
+		return
+	}
+	if t.breaker.record(failed) {
+		t.logger.Warn("OpenObserve circuit breaker opened",
+			slog.Int("consecutiveFailures", t.policy.BreakerThreshold),
+			slog.Duration("cooldown", t.policy.BreakerCooldown))
+	}
+}
+
+// retryable reports whether req may be sent again: it must not change
+// anything in OpenObserve, except for searches, and its body must be
+// replayable.
+func retryable(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	case http.MethodPost:
+		return strings.HasSuffix(req.URL.Path, "/_search")
+	}
+	return false
+}
+
+// transientStatus reports whether an OpenObserve response status denotes an
+// overloaded or failing backend.
+func transientStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= 500
+}
+
+// transientError reports whether err is a connection failure worth
+// retrying, rather than the cancellation of the call by its caller.
+func transientError(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	var netErr net.Error
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) ||
+		(errors.As(err, &netErr) && netErr.Timeout()) || errors.As(err, new(*net.OpError))
+}
+
+// sleepContext waits for d unless ctx is done first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// circuitBreaker rejects calls for a cooldown after threshold consecutive
+// failed calls. After the cooldown it lets a single trial call through,
+// which closes the breaker when it succeeds and reopens it otherwise.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	trial     bool
+}
+
+// allow reports whether a call may be made.
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.failures < b.threshold {
+		return true
+	}
+	if b.trial || b.now().Before(b.openUntil) {
+		return false
+	}
+	b.trial = true
+	return true
+}
+
+// record records the outcome of a call and reports whether it opened the
+// breaker.
+func (b *circuitBreaker) record(failed bool) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.trial = false
+	if !failed {
+		b.failures = 0
+		return false
+	}
+	b.failures++
+	if b.failures < b.threshold {
+		return false
+	}
+	b.openUntil = b.now().Add(b.cooldown)
+	return b.failures == b.threshold
+}
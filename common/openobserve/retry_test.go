@@ -0,0 +1,156 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testRetryPolicy retries without waiting.
+var testRetryPolicy = RetryPolicy{
+	MaxAttempts:      3,
+	InitialBackoff:   time.Millisecond,
+	MaxBackoff:       time.Millisecond,
+	BreakerThreshold: 2,
+	BreakerCooldown:  time.Hour,
+}
+
+func TestRetryPolicy_RetriesTransientStatuses(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) == "" {
+			t.Error("expected the search body to be replayed")
+		}
+		switch calls.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"took":1,"hits":[]}`))
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	client.SetRetryPolicy(testRetryPolicy)
+	if _, err := client.Search(context.Background(), "logs", []byte(`{}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := calls.Load(); got < 3 {
+		t.Errorf("expected the search to be retried, got %d calls", got)
+	}
+}
+
+func TestRetryPolicy_DoesNotRetryWrites(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	client.SetRetryPolicy(testRetryPolicy)
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/api/default/alerts", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected a single call, got %d", got)
+	}
+}
+
+func TestRetryPolicy_CircuitBreaker(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	client.SetRetryPolicy(testRetryPolicy)
+	for range 2 {
+		_, err := client.Search(context.Background(), "logs", []byte(`{}`))
+		var statusErr *StatusError
+		if !errors.As(err, &statusErr) || errors.Is(err, ErrBackendUnavailable) {
+			t.Fatalf("expected the error response of OpenObserve, got %v", err)
+		}
+	}
+	if got := calls.Load(); got != 6 {
+		t.Errorf("expected 3 attempts per search, got %d calls", got)
+	}
+
+	_, err := client.Search(context.Background(), "logs", []byte(`{}`))
+	if !errors.Is(err, ErrBackendUnavailable) {
+		t.Fatalf("expected ErrBackendUnavailable once the breaker is open, got %v", err)
+	}
+	if got := calls.Load(); got != 6 {
+		t.Errorf("expected the open breaker to short-circuit the call, got %d calls", got)
+	}
+}
+
+func TestRetryPolicy_ConnectionErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	client := newTestClient(url)
+	client.SetRetryPolicy(testRetryPolicy)
+	_, err := client.Search(context.Background(), "logs", []byte(`{}`))
+	if !errors.Is(err, ErrBackendUnavailable) {
+		t.Errorf("expected ErrBackendUnavailable, got %v", err)
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := &circuitBreaker{threshold: 2, cooldown: time.Minute, now: func() time.Time { return now }}
+
+	if opened := b.record(true); opened || !b.allow() {
+		t.Fatal("expected the breaker to stay closed below the threshold")
+	}
+	if opened := b.record(true); !opened {
+		t.Fatal("expected the breaker to open at the threshold")
+	}
+	if b.allow() {
+		t.Fatal("expected the open breaker to reject calls")
+	}
+
+	now = now.Add(time.Minute)
+	if !b.allow() {
+		t.Fatal("expected a trial call after the cooldown")
+	}
+	if b.allow() {
+		t.Fatal("expected a single trial call")
+	}
+	b.record(true)
+	if b.allow() {
+		t.Fatal("expected a failed trial to reopen the breaker")
+	}
+
+	now = now.Add(time.Minute)
+	if !b.allow() {
+		t.Fatal("expected a trial call after the cooldown")
+	}
+	b.record(false)
+	if !b.allow() || !b.allow() {
+		t.Fatal("expected a successful trial to close the breaker")
+	}
+}
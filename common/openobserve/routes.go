@@ -0,0 +1,135 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// StreamScope is the part of the search scope of a query that stream routes
+// select streams by. An empty ProjectID or EnvironmentIDs covers all the
+// projects or environments of the namespace.
+type StreamScope struct {
+	Namespace      string
+	ProjectID      string
+	EnvironmentIDs []string
+}
+
+// StreamRoute sends the queries of the scopes it selects to its streams,
+// for example the logs of one environment to a per-environment stream.
+// Empty selectors select every value.
+type StreamRoute struct {
+	Namespace     string
+	ProjectID     string
+	EnvironmentID string
+	Streams       []string
+}
+
+// StreamRoutes are the routes of a client, in the order they were listed.
+type StreamRoutes []StreamRoute
+
+// streamName is the pattern of the stream names routes accept.
+var streamName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ParseStreamRoutes parses a comma-separated list of routes of the form
+// <selector>[+<selector>...]=<stream>[|<stream>...], where a selector is
+// namespace:<name>, project:<uid> or environment:<uid>. For example,
+// "namespace:acme=acme_logs,environment:<uid>=prod_logs|default" reads the
+// logs of namespace acme from acme_logs and those of an environment from
+// both prod_logs and default.
+func ParseStreamRoutes(s string) (StreamRoutes, error) {
+	var routes StreamRoutes
+	for _, rule := range strings.Split(s, ",") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		selectors, streams, ok := strings.Cut(rule, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid stream route %q: expected <selector>=<stream>", rule)
+		}
+		var route StreamRoute
+		for _, selector := range strings.Split(selectors, "+") {
+			key, value, ok := strings.Cut(strings.TrimSpace(selector), ":")
+			value = strings.TrimSpace(value)
+			if !ok || value == "" {
+				return nil, fmt.Errorf("invalid selector %q in stream route %q: expected namespace:<name>, project:<uid> or environment:<uid>", selector, rule)
+			}
+			var target *string
+			switch strings.TrimSpace(key) {
+			case "namespace":
+				target = &route.Namespace
+			case "project":
+				target = &route.ProjectID
+			case "environment":
+				target = &route.EnvironmentID
+			default:
+				return nil, fmt.Errorf("invalid selector %q in stream route %q: expected namespace:<name>, project:<uid> or environment:<uid>", selector, rule)
+			}
+			if *target != "" {
+				return nil, fmt.Errorf("invalid stream route %q: %s is selected more than once", rule, key)
+			}
+			*target = value
+		}
+		for _, stream := range strings.Split(streams, "|") {
+			stream = strings.TrimSpace(stream)
+			if !streamName.MatchString(stream) {
+				return nil, fmt.Errorf("invalid stream %q in stream route %q", stream, rule)
+			}
+			route.Streams = append(route.Streams, stream)
+		}
+		routes = append(routes, route)
+	}
+	return routes, nil
+}
+
+// matches reports whether some of the data of scope may be routed by r.
+func (r StreamRoute) matches(scope StreamScope) bool {
+	return (r.Namespace == "" || r.Namespace == scope.Namespace) &&
+		(r.ProjectID == "" || scope.ProjectID == "" || r.ProjectID == scope.ProjectID) &&
+		(r.EnvironmentID == "" || len(scope.EnvironmentIDs) == 0 || slices.Contains(scope.EnvironmentIDs, r.EnvironmentID))
+}
+
+// covers reports whether all the data of scope is routed by r.
+func (r StreamRoute) covers(scope StreamScope) bool {
+	if !r.matches(scope) || (r.ProjectID != "" && r.ProjectID != scope.ProjectID) {
+		return false
+	}
+	if r.EnvironmentID == "" {
+		return true
+	}
+	for _, id := range scope.EnvironmentIDs {
+		if id != r.EnvironmentID {
+			return false
+		}
+	}
+	return len(scope.EnvironmentIDs) > 0
+}
+
+// Streams returns the streams holding the data of scope: those of the
+// routes matching it, and fallback, the stream of the client, unless one of
+// them covers the whole scope. A query of several streams is run against
+// each and the results merged.
+func (routes StreamRoutes) Streams(scope StreamScope, fallback string) []string {
+	var streams []string
+	covered := false
+	for _, r := range routes {
+		if !r.matches(scope) {
+			continue
+		}
+		covered = covered || r.covers(scope)
+		for _, stream := range r.Streams {
+			if !slices.Contains(streams, stream) {
+				streams = append(streams, stream)
+			}
+		}
+	}
+	if !covered && !slices.Contains(streams, fallback) {
+		streams = append(streams, fallback)
+	}
+	return streams
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseStreamRoutes(t *testing.T) {
+	routes, err := ParseStreamRoutes(" namespace:acme=acme_logs, namespace:acme+environment:prod-uid=prod_logs|default ,")
+	if err != nil {
+		t.Fatalf("ParseStreamRoutes() error = %v", err)
+	}
+	if len(routes) != 2 {
+		t.Fatalf("expected 2 routes, got %+v", routes)
+	}
+	if r := routes[1]; r.Namespace != "acme" || r.EnvironmentID != "prod-uid" || r.ProjectID != "" || !slices.Equal(r.Streams, []string{"prod_logs", "default"}) {
+		t.Errorf("unexpected route: %+v", r)
+	}
+
+	for _, invalid := range []string{
+		"acme_logs",
+		"namespace=acme_logs",
+		"cluster:a=logs",
+		"namespace:a+namespace:b=logs",
+		"namespace:acme=",
+		"namespace:acme=acme-logs",
+	} {
+		if _, err := ParseStreamRoutes(invalid); err == nil {
+			t.Errorf("ParseStreamRoutes(%q) expected an error", invalid)
+		}
+	}
+}
+
+func TestStreamRoutes_Streams(t *testing.T) {
+	routes, err := ParseStreamRoutes("namespace:acme=acme_logs,namespace:acme+environment:prod=prod_logs,project:p1=p1_logs|default")
+	if err != nil {
+		t.Fatalf("ParseStreamRoutes() error = %v", err)
+	}
+	tests := []struct {
+		name  string
+		scope StreamScope
+		want  string
+	}{
+		{"unrouted namespace", StreamScope{Namespace: "other"}, "p1_logs,default"},
+		{"unrouted project", StreamScope{Namespace: "other", ProjectID: "p2"}, "default"},
+		{"namespace route and more specific route", StreamScope{Namespace: "acme"}, "acme_logs,prod_logs,p1_logs,default"},
+		{"other environment", StreamScope{Namespace: "acme", EnvironmentIDs: []string{"dev"}}, "acme_logs,p1_logs,default"},
+		{"routed environment", StreamScope{Namespace: "acme", ProjectID: "p2", EnvironmentIDs: []string{"prod"}}, "acme_logs,prod_logs"},
+		{"project route only", StreamScope{Namespace: "other", ProjectID: "p1"}, "p1_logs,default"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := strings.Join(routes.Streams(tt.scope, "default"), ","); got != tt.want {
+				t.Errorf("Streams() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+	if got := StreamRoutes(nil).Streams(StreamScope{Namespace: "acme"}, "default"); !slices.Equal(got, []string{"default"}) {
+		t.Errorf("expected the fallback stream without routes, got %v", got)
+	}
+}
@@ -0,0 +1,21 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import "strings"
+
+// EscapeSQLString escapes backslashes and single quotes in a value to
+// prevent SQL injection when interpolating it into a single-quoted SQL
+// string.
+func EscapeSQLString(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `'`, `''`)
+	return value
+}
+
+// QuoteIdentifier quotes an SQL identifier, such as a stream name, doubling
+// the quotes it contains.
+func QuoteIdentifier(identifier string) string {
+	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+}
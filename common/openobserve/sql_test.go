@@ -0,0 +1,26 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import "testing"
+
+func TestEscapeSQLString(t *testing.T) {
+	tests := map[string]string{
+		"plain":        "plain",
+		"it's":         "it''s",
+		`back\slash`:   `back\\slash`,
+		`\' OR 1=1 --`: `\\'' OR 1=1 --`,
+	}
+	for in, want := range tests {
+		if got := EscapeSQLString(in); got != want {
+			t.Errorf("EscapeSQLString(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestQuoteIdentifier(t *testing.T) {
+	if got := QuoteIdentifier(`my"stream`); got != `"my""stream"` {
+		t.Errorf("QuoteIdentifier() = %s", got)
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package prefer honours the HTTP Prefer header (RFC 7240) on the query
+// endpoints of an adapter, so that clients can shrink the results of a
+// query and bound the time spent answering it.
+package prefer
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type contextKey struct{}
+
+const (
+	// MaxWait caps the wait preference; it must stay within the server
+	// write timeouts of the adapters.
+	MaxWait = 15 * time.Second
+	// DefaultLimit is the row limit of the queries whose request sets none.
+	DefaultLimit = 100
+)
+
+// ErrWaitElapsed is passed to the reject callback of Middleware for the
+// queries that did not complete within their preferred wait.
+var ErrWaitElapsed = errors.New("the query did not complete within the preferred wait; retry later or with a longer wait")
+
+// Preferences holds the preferences applied to a query request.
+type Preferences struct {
+	// MaxResults lowers the request limit when greater than zero.
+	MaxResults int
+	// Wait is the latency budget for the upstream query when greater than zero.
+	Wait time.Duration
+}
+
+// Middleware honours the Prefer header of the POST requests to the paths
+// query reports. Supported preferences are max-results=<n>, which caps the
+// result size at most at the limit of the request, and wait=<seconds>,
+// which bounds the time spent querying the backend. Values are clamped to
+// maxResults and MaxWait, and the preferences actually applied are echoed
+// back in the Preference-Applied response header. Unknown or malformed
+// preferences are ignored, as the RFC requires.
+//
+// Server errors written once the wait elapsed report the canceled upstream
+// query: they are dropped, and the request answered by reject with
+// ErrWaitElapsed after setting the Retry-After header, so each module
+// renders its own 504 response.
+func Middleware(maxResults int, query func(path string) bool, reject func(w http.ResponseWriter, r *http.Request, err error), next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		prefHeader := r.Header.Values("Prefer")
+		if len(prefHeader) == 0 || r.Method != http.MethodPost || !query(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		prefs, applied := Parse(prefHeader, maxResults)
+		if len(applied) == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), contextKey{}, prefs)
+		w.Header().Set("Preference-Applied", strings.Join(applied, ", "))
+		if prefs.Wait <= 0 {
+			next.ServeHTTP(w, r.WithContext(ctx))
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, prefs.Wait)
+		defer cancel()
+		ww := &waitWriter{ResponseWriter: w, ctx: ctx}
+		next.ServeHTTP(ww, r.WithContext(ctx))
+		if ww.expired {
+			w.Header().Del("Content-Length")
+			w.Header().Set("Retry-After", strconv.Itoa(int(prefs.Wait/time.Second)))
+			reject(w, r, ErrWaitElapsed)
+		}
+	})
+}
+
+// waitWriter holds back the server error responses written once the
+// preferred wait of ctx elapsed.
+type waitWriter struct {
+	http.ResponseWriter
+	ctx         context.Context
+	wroteHeader bool
+	expired     bool
+}
+
+func (ww *waitWriter) WriteHeader(status int) {
+	if ww.wroteHeader {
+		return
+	}
+	ww.wroteHeader = true
+	if status >= 500 && errors.Is(ww.ctx.Err(), context.DeadlineExceeded) {
+		ww.expired = true
+		return
+	}
+	ww.ResponseWriter.WriteHeader(status)
+}
+
+func (ww *waitWriter) Write(b []byte) (int, error) {
+	if !ww.wroteHeader {
+		ww.WriteHeader(http.StatusOK)
+	}
+	if ww.expired {
+		return len(b), nil
+	}
+	return ww.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (ww *waitWriter) Unwrap() http.ResponseWriter {
+	return ww.ResponseWriter
+}
+
+// Parse parses Prefer header values into the supported preferences, with
+// max-results clamped to maxResults, and returns them together with their
+// Preference-Applied representation.
+func Parse(values []string, maxResults int) (Preferences, []string) {
+	var prefs Preferences
+	var applied []string
+	for _, value := range values {
+		for _, pref := range strings.Split(value, ",") {
+			// Preference parameters (";param") are not used by any supported preference.
+			token, _, _ := strings.Cut(pref, ";")
+			name, val, _ := strings.Cut(strings.TrimSpace(token), "=")
+			name = strings.ToLower(strings.TrimSpace(name))
+			val = strings.Trim(strings.TrimSpace(val), `"`)
+
+			switch name {
+			case "max-results":
+				n, err := strconv.Atoi(val)
+				if err != nil || n <= 0 || prefs.MaxResults > 0 {
+					continue
+				}
+				prefs.MaxResults = min(n, maxResults)
+				applied = append(applied, "max-results="+strconv.Itoa(prefs.MaxResults))
+			case "wait":
+				secs, err := strconv.Atoi(val)
+				if err != nil || secs <= 0 || prefs.Wait > 0 {
+					continue
+				}
+				prefs.Wait = min(time.Duration(secs)*time.Second, MaxWait)
+				applied = append(applied, "wait="+strconv.Itoa(int(prefs.Wait/time.Second)))
+			}
+		}
+	}
+	return prefs, applied
+}
+
+// Limit returns the row limit of a query whose request asks for limit rows,
+// or for DefaultLimit when limit is not positive, lowered to the
+// max-results preference: the preference shrinks results, never grows them.
+func (p Preferences) Limit(limit int) int {
+	if p.MaxResults <= 0 {
+		return limit
+	}
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	return min(limit, p.MaxResults)
+}
+
+// FromContext returns the preferences applied by Middleware, or the zero
+// value when the request carried none.
+func FromContext(ctx context.Context) Preferences {
+	if prefs, ok := ctx.Value(contextKey{}).(Preferences); ok {
+		return prefs
+	}
+	return Preferences{}
+}
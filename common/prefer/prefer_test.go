@@ -0,0 +1,155 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prefer
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []string
+		want    Preferences
+		applied string
+	}{
+		{
+			name:    "both preferences",
+			values:  []string{"max-results=500, wait=10"},
+			want:    Preferences{MaxResults: 500, Wait: 10 * time.Second},
+			applied: "max-results=500, wait=10",
+		},
+		{
+			name:    "multiple headers and parameters",
+			values:  []string{`max-results="20"; strict`, "wait=3"},
+			want:    Preferences{MaxResults: 20, Wait: 3 * time.Second},
+			applied: "max-results=20, wait=3",
+		},
+		{
+			name:    "values are clamped",
+			values:  []string{"max-results=999999, wait=600"},
+			want:    Preferences{MaxResults: 10000, Wait: MaxWait},
+			applied: "max-results=10000, wait=15",
+		},
+		{
+			name:   "unknown and malformed preferences are ignored",
+			values: []string{"respond-async, max-results=abc, wait=-1"},
+		},
+		{
+			name:    "first occurrence wins",
+			values:  []string{"max-results=5, max-results=50"},
+			want:    Preferences{MaxResults: 5},
+			applied: "max-results=5",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, applied := Parse(tt.values, 10000)
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+			if strings.Join(applied, ", ") != tt.applied {
+				t.Errorf("applied = %q, want %q", strings.Join(applied, ", "), tt.applied)
+			}
+		})
+	}
+}
+
+func TestPreferencesLimit(t *testing.T) {
+	tests := []struct {
+		maxResults, limit, want int
+	}{
+		{maxResults: 0, limit: 50, want: 50},
+		{maxResults: 0, limit: 0, want: 0},
+		{maxResults: 20, limit: 50, want: 20},
+		{maxResults: 500, limit: 50, want: 50},
+		{maxResults: 500, limit: 0, want: DefaultLimit},
+		{maxResults: 20, limit: 0, want: 20},
+	}
+	for _, tt := range tests {
+		if got := (Preferences{MaxResults: tt.maxResults}).Limit(tt.limit); got != tt.want {
+			t.Errorf("max-results=%d Limit(%d) = %d, want %d", tt.maxResults, tt.limit, got, tt.want)
+		}
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	query := func(path string) bool { return path == "/query" }
+	reject := func(w http.ResponseWriter, r *http.Request, err error) {
+		http.Error(w, err.Error(), http.StatusGatewayTimeout)
+	}
+	serve := func(next http.Handler, path, prefer string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader("{}"))
+		req.Header.Set("Prefer", prefer)
+		rec := httptest.NewRecorder()
+		Middleware(1000, query, reject, next).ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("applies and echoes preferences", func(t *testing.T) {
+		var got Preferences
+		var hasDeadline bool
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got = FromContext(r.Context())
+			_, hasDeadline = r.Context().Deadline()
+			w.WriteHeader(http.StatusOK)
+		})
+
+		rec := serve(next, "/query", "max-results=5000, wait=10")
+		if got.MaxResults != 1000 {
+			t.Errorf("expected max-results clamped to 1000, got %d", got.MaxResults)
+		}
+		if !hasDeadline {
+			t.Error("expected wait preference to set a deadline")
+		}
+		if h := rec.Header().Get("Preference-Applied"); h != "max-results=1000, wait=10" {
+			t.Errorf("unexpected Preference-Applied header: %q", h)
+		}
+	})
+
+	t.Run("queries exceeding the wait are rejected", func(t *testing.T) {
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+			if errors.Is(r.Context().Err(), context.DeadlineExceeded) {
+				http.Error(w, "context deadline exceeded", http.StatusInternalServerError)
+			}
+		})
+
+		rec := serve(next, "/query", "wait=1")
+		if rec.Code != http.StatusGatewayTimeout || rec.Header().Get("Retry-After") != "1" {
+			t.Fatalf("expected 504 with Retry-After, got %d with %v: %s", rec.Code, rec.Header(), rec.Body.String())
+		}
+		if strings.TrimSpace(rec.Body.String()) != ErrWaitElapsed.Error() {
+			t.Errorf("unexpected body: %s", rec.Body.String())
+		}
+	})
+
+	t.Run("errors within the wait are kept", func(t *testing.T) {
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "boom", http.StatusInternalServerError)
+		})
+
+		rec := serve(next, "/query", "wait=10")
+		if rec.Code != http.StatusInternalServerError || !strings.Contains(rec.Body.String(), "boom") {
+			t.Errorf("expected the 500 to be kept, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("ignored on other endpoints", func(t *testing.T) {
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+
+		rec := serve(next, "/other", "max-results=500")
+		if h := rec.Header().Get("Preference-Applied"); h != "" {
+			t.Errorf("expected no Preference-Applied header, got %q", h)
+		}
+	})
+}
@@ -0,0 +1,248 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ratelimit bounds the rate of the query requests of each namespace
+// served by an adapter, and the number of them in flight at once, so that a
+// single misbehaving dashboard cannot saturate the backend for every tenant.
+// Requests over a limit are rejected with a Retry-After header telling when
+// to try again.
+package ratelimit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/metrics"
+)
+
+var (
+	// ErrRateLimited rejects the requests of a namespace that exceeded its
+	// rate of requests.
+	ErrRateLimited = errors.New("too many requests")
+	// ErrTooManyInFlight rejects the requests of a namespace that already
+	// has the maximum number of requests in flight.
+	ErrTooManyInFlight = errors.New("too many concurrent requests")
+)
+
+// inFlightRetryAfter is the Retry-After of the requests rejected because
+// their namespace had too many requests in flight, which typically complete
+// within seconds.
+const inFlightRetryAfter = time.Second
+
+// sweepInterval is how often the state of the namespaces without requests
+// in flight and with a full bucket is dropped.
+const sweepInterval = time.Minute
+
+// Config sets the limits applied to each namespace.
+type Config struct {
+	// RequestsPerSecond is the sustained rate of requests admitted per
+	// namespace. 0 disables the rate limit.
+	RequestsPerSecond float64
+	// Burst is the number of requests a namespace may make at once before
+	// being held to RequestsPerSecond. 0 is RequestsPerSecond rounded up.
+	Burst int
+	// MaxInFlight is the number of requests admitted in flight at once per
+	// namespace. 0 disables the limit.
+	MaxInFlight int
+}
+
+// Validate reports configuration errors.
+func (c Config) Validate() error {
+	if c.RequestsPerSecond < 0 || math.IsNaN(c.RequestsPerSecond) || math.IsInf(c.RequestsPerSecond, 0) {
+		return errors.New("the rate must not be negative")
+	}
+	if c.Burst < 0 {
+		return errors.New("the burst must not be negative")
+	}
+	if c.MaxInFlight < 0 {
+		return errors.New("the in-flight limit must not be negative")
+	}
+	return nil
+}
+
+// burst returns the size of the bucket of each namespace.
+func (c Config) burst() float64 {
+	if c.Burst > 0 {
+		return float64(c.Burst)
+	}
+	return math.Ceil(c.RequestsPerSecond)
+}
+
+// namespaceState is the bucket of tokens and the requests in flight of a
+// namespace.
+type namespaceState struct {
+	tokens   float64
+	updated  time.Time
+	inFlight int
+}
+
+// Limiter applies the limits of a Config to the requests of each namespace.
+// It serves its rejected requests, by reason, and the requests in flight in
+// the Prometheus text exposition format. Namespaces are left out of the
+// metrics.
+type Limiter struct {
+	config Config
+	prefix string
+	now    func() time.Time
+
+	mu          sync.Mutex
+	namespaces  map[string]*namespaceState
+	lastSweep   time.Time
+	rateLimited int64
+	tooMany     int64
+}
+
+// New returns the limiter configured by c, or nil when c sets no limit. Its
+// metrics start with prefix, for example logs_adapter.
+func New(c Config, prefix string) (*Limiter, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	if c.RequestsPerSecond == 0 && c.MaxInFlight == 0 {
+		return nil, nil
+	}
+	return &Limiter{config: c, prefix: prefix, now: time.Now, namespaces: map[string]*namespaceState{}}, nil
+}
+
+// Acquire admits a request of namespace. It returns an error wrapping
+// ErrRateLimited or ErrTooManyInFlight, and how long to wait before trying
+// again, when a limit is reached; otherwise the request must be completed by
+// calling release, which is safe to call twice.
+func (l *Limiter) Acquire(namespace string) (release func(), retryAfter time.Duration, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	l.sweep(now)
+	state, ok := l.namespaces[namespace]
+	if !ok {
+		state = &namespaceState{tokens: l.config.burst(), updated: now}
+		l.namespaces[namespace] = state
+	}
+
+	if l.config.MaxInFlight > 0 && state.inFlight >= l.config.MaxInFlight {
+		l.tooMany++
+		return nil, inFlightRetryAfter, fmt.Errorf("%w: at most %d requests may be in flight at once in namespace %q",
+			ErrTooManyInFlight, l.config.MaxInFlight, namespace)
+	}
+	if rate := l.config.RequestsPerSecond; rate > 0 {
+		state.tokens = min(l.config.burst(), state.tokens+now.Sub(state.updated).Seconds()*rate)
+		state.updated = now
+		if state.tokens < 1 {
+			l.rateLimited++
+			wait := time.Duration((1 - state.tokens) / rate * float64(time.Second))
+			return nil, wait, fmt.Errorf("%w: at most %g requests per second are admitted in namespace %q",
+				ErrRateLimited, rate, namespace)
+		}
+		state.tokens--
+	}
+
+	state.inFlight++
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			state.inFlight--
+		})
+	}, 0, nil
+}
+
+// sweep drops, at most every sweepInterval, the state of the namespaces
+// without requests in flight whose bucket has refilled, which is the state
+// of a namespace seen for the first time. l.mu must be held.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for namespace, state := range l.namespaces {
+		if state.inFlight > 0 {
+			continue
+		}
+		if rate := l.config.RequestsPerSecond; rate > 0 && state.tokens+now.Sub(state.updated).Seconds()*rate < l.config.burst() {
+			continue
+		}
+		delete(l.namespaces, namespace)
+	}
+}
+
+// Middleware limits the requests matching one of routes, ServeMux patterns
+// such as "POST /api/v1/logs/query", in the namespace namespace returns for
+// them; requests it returns no namespace for share the limits of the empty
+// namespace. Requests over a limit are answered by reject, after setting
+// the Retry-After header, and recorded under their route by
+// metrics.Instrument. A nil limiter serves all requests with next.
+func (l *Limiter) Middleware(routes []string, namespace func(r *http.Request) string, reject func(w http.ResponseWriter, r *http.Request, err error), next http.Handler) http.Handler {
+	if l == nil {
+		return next
+	}
+	limited := http.NewServeMux()
+	for _, route := range routes {
+		limited.Handle(route, next)
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, route := limited.Handler(r)
+		if route == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		release, retryAfter, err := l.Acquire(namespace(r))
+		if err != nil {
+			metrics.SetRoute(r, route)
+			// Retry-After is in whole seconds, rounded up so that the
+			// retry is admitted.
+			w.Header().Set("Retry-After", strconv.Itoa(max(1, int(math.Ceil(retryAfter.Seconds())))))
+			reject(w, r, err)
+			return
+		}
+		defer release()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Namespace returns the namespace of r: the namespace of the searchScope of
+// its JSON body, or else its namespace query parameter. The body is left
+// for the handler to read.
+func Namespace(r *http.Request) string {
+	if r.Body != nil && r.Body != http.NoBody {
+		body, err := io.ReadAll(r.Body)
+		_ = r.Body.Close()
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		var request struct {
+			SearchScope struct {
+				Namespace string `json:"namespace"`
+			} `json:"searchScope"`
+		}
+		if err == nil && json.Unmarshal(body, &request) == nil && strings.TrimSpace(request.SearchScope.Namespace) != "" {
+			return strings.TrimSpace(request.SearchScope.Namespace)
+		}
+	}
+	return strings.TrimSpace(r.URL.Query().Get("namespace"))
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format.
+func (l *Limiter) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	inFlight := 0
+	for _, state := range l.namespaces {
+		inFlight += state.inFlight
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	fmt.Fprintf(w, "# HELP %[1]s_rate_limited_requests_total Query requests rejected because their namespace exceeded a limit, by limit.\n"+
+		"# TYPE %[1]s_rate_limited_requests_total counter\n"+
+		"%[1]s_rate_limited_requests_total{limit=\"in_flight\"} %[2]d\n"+
+		"%[1]s_rate_limited_requests_total{limit=\"rate\"} %[3]d\n", l.prefix, l.tooMany, l.rateLimited)
+	fmt.Fprintf(w, "# HELP %[1]s_rate_limited_in_flight_requests Query requests in flight, across namespaces.\n"+
+		"# TYPE %[1]s_rate_limited_in_flight_requests gauge\n"+
+		"%[1]s_rate_limited_in_flight_requests %[2]d\n", l.prefix, inFlight)
+}
@@ -0,0 +1,143 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimit
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/metrics"
+)
+
+func TestLimiter_Rate(t *testing.T) {
+	l, err := New(Config{RequestsPerSecond: 2, Burst: 3}, "logs_adapter")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		release, _, err := l.Acquire("acme")
+		if err != nil {
+			t.Fatalf("expected request %d of the burst to be admitted, got %v", i+1, err)
+		}
+		release()
+	}
+	_, retryAfter, err := l.Acquire("acme")
+	if !errors.Is(err, ErrRateLimited) || retryAfter != 500*time.Millisecond {
+		t.Fatalf("expected the request over the burst to be rate limited for 500ms, got %v after %v", err, retryAfter)
+	}
+	if _, _, err := l.Acquire("globex"); err != nil {
+		t.Errorf("expected another namespace to be admitted, got %v", err)
+	}
+	now = now.Add(500 * time.Millisecond)
+	if _, _, err := l.Acquire("acme"); err != nil {
+		t.Errorf("expected a request to be admitted once a token was added, got %v", err)
+	}
+}
+
+func TestLimiter_InFlight(t *testing.T) {
+	l, err := New(Config{MaxInFlight: 2}, "logs_adapter")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	release1, _, err := l.Acquire("acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := l.Acquire("acme"); err != nil {
+		t.Fatal(err)
+	}
+	if _, retryAfter, err := l.Acquire("acme"); !errors.Is(err, ErrTooManyInFlight) || retryAfter != inFlightRetryAfter {
+		t.Fatalf("expected the third request in flight to be rejected, got %v after %v", err, retryAfter)
+	}
+	release1()
+	release1()
+	if _, _, err := l.Acquire("acme"); err != nil {
+		t.Errorf("expected a request to be admitted once one completed, got %v", err)
+	}
+	if _, _, err := l.Acquire("acme"); !errors.Is(err, ErrTooManyInFlight) {
+		t.Errorf("expected a double release to free a single slot, got %v", err)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	l, err := New(Config{RequestsPerSecond: 1}, "logs_adapter")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	l.now = func() time.Time { return time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC) }
+	var bodies []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v1/logs/query", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+	})
+	mux.HandleFunc("GET /health", func(w http.ResponseWriter, r *http.Request) {})
+	m := metrics.New("logs_adapter")
+	srv := m.Instrument(l.Middleware([]string{"POST /api/v1/logs/query"}, Namespace, func(w http.ResponseWriter, r *http.Request, err error) {
+		http.Error(w, err.Error(), http.StatusTooManyRequests)
+	}, m.Route(mux)))
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return rec
+	}
+	query := `{"searchScope":{"namespace":"acme"}}`
+	if rec := serve(http.MethodPost, "/api/v1/logs/query", query); rec.Code != http.StatusOK || len(bodies) != 1 || bodies[0] != query {
+		t.Fatalf("expected the first query to reach the handler with its body, got %d with %v", rec.Code, bodies)
+	}
+	rec := serve(http.MethodPost, "/api/v1/logs/query", query)
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") != "1" || !strings.Contains(rec.Body.String(), `"acme"`) {
+		t.Errorf("expected the second query to be rejected with Retry-After, got %d with %v: %s", rec.Code, rec.Header(), rec.Body.String())
+	}
+	if rec := serve(http.MethodPost, "/api/v1/logs/query?namespace=globex", ""); rec.Code != http.StatusOK {
+		t.Errorf("expected a query of another namespace to be admitted, got %d", rec.Code)
+	}
+	if rec := serve(http.MethodGet, "/health", ""); rec.Code != http.StatusOK {
+		t.Errorf("expected routes not listed to be left unlimited, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	l.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	for _, want := range []string{
+		`logs_adapter_http_requests_total{handler="/api/v1/logs/query",method="POST",code="429"} 1`,
+		`logs_adapter_rate_limited_requests_total{limit="rate"} 1`,
+		"logs_adapter_rate_limited_in_flight_requests 0\n",
+	} {
+		if !strings.Contains(rec.Body.String(), want) {
+			t.Errorf("expected metrics to contain %q, got:\n%s", want, rec.Body.String())
+		}
+	}
+}
+
+func TestMiddleware_NilLimiter(t *testing.T) {
+	l, err := New(Config{}, "logs_adapter")
+	if err != nil || l != nil {
+		t.Fatalf("expected no limiter without limits, got %v, %v", l, err)
+	}
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	if h := l.Middleware(nil, Namespace, nil, next); h == nil {
+		t.Error("expected a nil limiter to serve requests with next")
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	for _, c := range []Config{{RequestsPerSecond: -1}, {Burst: -1}, {MaxInFlight: -1}} {
+		if err := c.Validate(); err == nil {
+			t.Errorf("expected an error for %+v", c)
+		}
+	}
+	if err := (Config{RequestsPerSecond: 0.5, MaxInFlight: 4}).Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
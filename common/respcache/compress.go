@@ -0,0 +1,88 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package respcache
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Compression of the cached entries.
+const (
+	// CompressionNone stores the entries as they are.
+	CompressionNone = "none"
+	// CompressionGzip compresses the entries with gzip at its fastest level.
+	CompressionGzip = "gzip"
+)
+
+// compressedMarker starts the values holding a compressed entry. Encoded
+// entries start with a header field name or a blank line, never with it.
+const compressedMarker = 0
+
+// Codec compresses the entries of a Cache before they are stored, so that
+// a store of a given size holds more responses.
+type Codec interface {
+	// Compress returns entry compressed.
+	Compress(entry []byte) ([]byte, error)
+	// Decompress returns the entry a value returned by Compress holds.
+	Decompress(value []byte) ([]byte, error)
+}
+
+// newCodec returns the codec of a Config.Compression, or nil when entries
+// are stored uncompressed.
+func newCodec(compression string) (Codec, error) {
+	switch compression {
+	case "", CompressionNone:
+		return nil, nil
+	case CompressionGzip:
+		return GzipCodec{}, nil
+	default:
+		return nil, fmt.Errorf("unknown compression %q: must be %s or %s", compression, CompressionNone, CompressionGzip)
+	}
+}
+
+// GzipCodec is a Codec compressing with gzip at gzip.BestSpeed: JSON
+// responses shrink several times over at a small cost per request.
+type GzipCodec struct{}
+
+var gzipWriters = sync.Pool{New: func() any {
+	w, _ := gzip.NewWriterLevel(nil, gzip.BestSpeed)
+	return w
+}}
+
+// Compress implements Codec.
+func (GzipCodec) Compress(entry []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := gzipWriters.Get().(*gzip.Writer)
+	defer gzipWriters.Put(w)
+	w.Reset(&buf)
+	if _, err := w.Write(entry); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Decompress implements Codec. Values holding more than an entry of
+// MaxEntryBytes and its header fields are rejected.
+func (GzipCodec) Decompress(value []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(value))
+	if err != nil {
+		return nil, err
+	}
+	entry, err := io.ReadAll(io.LimitReader(r, 2*MaxEntryBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(entry) > 2*MaxEntryBytes {
+		return nil, errors.New("compressed entry is too large")
+	}
+	return entry, nil
+}
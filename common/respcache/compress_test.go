@@ -0,0 +1,93 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package respcache
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGzipCodec(t *testing.T) {
+	entry := []byte("Content-Type: application/json\r\n\r\n" + strings.Repeat(`{"log":"GET /healthz 200"},`, 100))
+	compressed, err := GzipCodec{}.Compress(entry)
+	if err != nil {
+		t.Fatalf("Compress() error = %v", err)
+	}
+	if len(compressed) >= len(entry)/4 {
+		t.Errorf("expected a repetitive entry to shrink, got %d of %d bytes", len(compressed), len(entry))
+	}
+	got, err := GzipCodec{}.Decompress(compressed)
+	if err != nil || string(got) != string(entry) {
+		t.Errorf("Decompress() = %q, %v", got, err)
+	}
+	if _, err := (GzipCodec{}).Decompress([]byte("not gzip")); err == nil {
+		t.Error("expected an error for a value that is not gzip")
+	}
+}
+
+func TestCache_Compression(t *testing.T) {
+	body := `{"logs":[` + strings.Repeat(`{"log":"GET /healthz 200","level":"INFO"},`, 200) + `{}]}`
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	})
+	policy := Policy{RecentTTL: time.Second, HistoricalTTL: time.Minute}
+
+	redis := newFakeRedis(t, "")
+	redisStore, err := NewRedisStore("redis://"+redis.listener.Addr().String(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, store := range map[string]Store{"memory": NewMemoryStore(MaxEntryBytes), "redis": redisStore} {
+		t.Run(name, func(t *testing.T) {
+			cache := NewCache(store, policy, "logs_adapter", testLogger())
+			cache.SetCodec(GzipCodec{})
+			handler := cache.Middleware([]string{"POST /query"}, nil, next)
+			serve := func() *httptest.ResponseRecorder {
+				rec := httptest.NewRecorder()
+				handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/query", strings.NewReader(`{"limit":200}`)))
+				return rec
+			}
+
+			serve()
+			rec := serve()
+			if rec.Header().Get(CacheHeader) != "hit" || rec.Body.String() != body || rec.Header().Get("Content-Type") != "application/json" {
+				t.Fatalf("expected the compressed entry to be served, got %q: %.40s", rec.Header().Get(CacheHeader), rec.Body.String())
+			}
+			if stored, entry := cache.storedBytes.Load(), cache.entryBytes.Load(); stored == 0 || stored*4 > entry {
+				t.Errorf("expected the stored entry to be compressed, got %d of %d bytes", stored, entry)
+			}
+
+			metricsRec := httptest.NewRecorder()
+			cache.ServeHTTP(metricsRec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+			_, after, _ := strings.Cut(metricsRec.Body.String(), "\nlogs_adapter_response_cache_compression_ratio ")
+			ratio, err := strconv.ParseFloat(strings.TrimSpace(after), 64)
+			if err != nil || ratio < 4 {
+				t.Errorf("expected a compression ratio above 4, got:\n%s", metricsRec.Body.String())
+			}
+		})
+	}
+}
+
+func TestCache_CompressedEntryWithoutCodec(t *testing.T) {
+	store := NewMemoryStore(MaxEntryBytes)
+	compressing := NewCache(store, Policy{}, "logs_adapter", testLogger())
+	compressing.SetCodec(GzipCodec{})
+	if err := compressing.set(context.Background(), "key", encodeEntry(http.Header{}, []byte("{}")), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	plain := NewCache(store, Policy{}, "logs_adapter", testLogger())
+	if _, ok := plain.get(context.Background(), "key"); ok || plain.errors.Load() != 1 {
+		t.Errorf("expected a compressed entry to be a miss counted as an error, got ok %v and %d errors", ok, plain.errors.Load())
+	}
+	if entry, ok := compressing.get(context.Background(), "key"); !ok || string(entry) != "\r\n{}" {
+		t.Errorf("expected the entry to be read with the codec, got %q, %v", entry, ok)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package respcache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore is a Store in the memory of the adapter. The least recently
+// used entries are evicted once the values it holds exceed its size.
+type MemoryStore struct {
+	maxBytes int64
+	now      func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+	bytes   int64
+}
+
+type memoryEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// NewMemoryStore returns a MemoryStore holding values of up to maxBytes in
+// total.
+func NewMemoryStore(maxBytes int64) *MemoryStore {
+	return &MemoryStore{maxBytes: maxBytes, now: time.Now, entries: map[string]*list.Element{}, order: list.New()}
+}
+
+// Get implements Store.
+func (s *MemoryStore) Get(_ context.Context, key string) ([]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	entry := e.Value.(*memoryEntry)
+	if !s.now().Before(entry.expires) {
+		s.remove(e)
+		return nil, false, nil
+	}
+	s.order.MoveToFront(e)
+	return entry.value, true, nil
+}
+
+// Set implements Store. Values larger than the store are not kept.
+func (s *MemoryStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	if int64(len(value)) > s.maxBytes {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.entries[key]; ok {
+		s.remove(e)
+	}
+	s.entries[key] = s.order.PushFront(&memoryEntry{key: key, value: value, expires: s.now().Add(ttl)})
+	s.bytes += int64(len(value))
+	for s.bytes > s.maxBytes {
+		s.remove(s.order.Back())
+	}
+	return nil
+}
+
+// remove drops the entry of e. s.mu must be held.
+func (s *MemoryStore) remove(e *list.Element) {
+	entry := s.order.Remove(e).(*memoryEntry)
+	delete(s.entries, entry.key)
+	s.bytes -= int64(len(entry.value))
+}
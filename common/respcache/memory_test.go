@@ -0,0 +1,45 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package respcache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1000, 0)
+	store := NewMemoryStore(10)
+	store.now = func() time.Time { return now }
+
+	store.Set(ctx, "a", []byte("aaaa"), time.Second)
+	store.Set(ctx, "b", []byte("bbbb"), time.Minute)
+	if value, ok, _ := store.Get(ctx, "a"); !ok || string(value) != "aaaa" {
+		t.Fatalf("expected a, got %q, %v", value, ok)
+	}
+
+	// a was used more recently than b, which is evicted to make room.
+	store.Set(ctx, "c", []byte("cccc"), time.Minute)
+	if _, ok, _ := store.Get(ctx, "b"); ok {
+		t.Error("expected the least recently used value to be evicted")
+	}
+	if store.bytes != 8 {
+		t.Errorf("expected 8 bytes held, got %d", store.bytes)
+	}
+
+	now = now.Add(time.Second)
+	if _, ok, _ := store.Get(ctx, "a"); ok {
+		t.Error("expected a to have expired")
+	}
+	if _, ok, _ := store.Get(ctx, "c"); !ok {
+		t.Error("expected c to be held")
+	}
+
+	store.Set(ctx, "big", make([]byte, 11), time.Minute)
+	if _, ok, _ := store.Get(ctx, "big"); ok || store.bytes != 4 {
+		t.Errorf("expected values larger than the store to be dropped, holding %d bytes", store.bytes)
+	}
+}
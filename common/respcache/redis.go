@@ -0,0 +1,198 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package respcache
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// redisTimeout bounds each command when the context has no earlier
+	// deadline, so that a stalled Redis slows queries down rather than
+	// blocking them.
+	redisTimeout = time.Second
+	// redisMaxIdle bounds the connections kept open between commands.
+	redisMaxIdle = 8
+)
+
+// errRedisNil is the reply to GET for a missing key.
+var errRedisNil = errors.New("redis: nil")
+
+// RedisStore is a Store in Redis, shared by the replicas of an adapter. It
+// speaks the subset of the Redis protocol it needs over a small pool of
+// connections, and relies on Redis to expire the values.
+type RedisStore struct {
+	addr     string
+	db       int
+	password string
+	dialer   net.Dialer
+
+	mu   sync.Mutex
+	idle []*redisConn
+}
+
+type redisConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+// NewRedisStore returns a RedisStore of the Redis server at rawURL,
+// redis://host:port[/db], authenticating with password when it is set.
+// Connections are opened on first use.
+func NewRedisStore(rawURL, password string) (*RedisStore, error) {
+	addr, db, err := parseRedisURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	return &RedisStore{addr: addr, db: db, password: password}, nil
+}
+
+// Get implements Store.
+func (s *RedisStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := s.do(ctx, "GET", key)
+	if errors.Is(err, errRedisNil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+// Set implements Store.
+func (s *RedisStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	_, err := s.do(ctx, "SET", key, string(value), "PX", strconv.FormatInt(max(ttl.Milliseconds(), 1), 10))
+	return err
+}
+
+// do sends a command on a pooled connection and returns its reply. The
+// connection is closed rather than pooled when the command fails other
+// than with a Redis error reply.
+func (s *RedisStore) do(ctx context.Context, args ...string) ([]byte, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reply, err := conn.do(ctx, args...)
+	var replyErr redisError
+	if err != nil && !errors.Is(err, errRedisNil) && !errors.As(err, &replyErr) {
+		conn.Close()
+		return nil, err
+	}
+	s.release(conn)
+	return reply, err
+}
+
+// conn returns an idle connection, or dials a new one.
+func (s *RedisStore) conn(ctx context.Context) (*redisConn, error) {
+	s.mu.Lock()
+	if n := len(s.idle); n > 0 {
+		conn := s.idle[n-1]
+		s.idle = s.idle[:n-1]
+		s.mu.Unlock()
+		return conn, nil
+	}
+	s.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, redisTimeout)
+	defer cancel()
+	c, err := s.dialer.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to redis: %w", err)
+	}
+	conn := &redisConn{Conn: c, r: bufio.NewReader(c)}
+	if s.password != "" {
+		if _, err := conn.do(ctx, "AUTH", s.password); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to authenticate with redis: %w", err)
+		}
+	}
+	if s.db != 0 {
+		if _, err := conn.do(ctx, "SELECT", strconv.Itoa(s.db)); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to select redis database %d: %w", s.db, err)
+		}
+	}
+	return conn, nil
+}
+
+// release returns conn to the pool, or closes it when the pool is full.
+func (s *RedisStore) release(conn *redisConn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.idle) >= redisMaxIdle {
+		conn.Close()
+		return
+	}
+	s.idle = append(s.idle, conn)
+}
+
+// redisError is an error reply of Redis.
+type redisError string
+
+func (e redisError) Error() string {
+	return "redis: " + string(e)
+}
+
+// do sends a command as an array of bulk strings and reads its reply.
+func (c *redisConn) do(ctx context.Context, args ...string) ([]byte, error) {
+	deadline := time.Now().Add(redisTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := c.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	command := make([]byte, 0, 64)
+	command = fmt.Appendf(command, "*%d\r\n", len(args))
+	for _, arg := range args {
+		command = fmt.Appendf(command, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := c.Write(command); err != nil {
+		return nil, fmt.Errorf("failed to send redis command: %w", err)
+	}
+	return c.readReply()
+}
+
+// readReply reads a simple string, error, integer or bulk string reply.
+func (c *redisConn) readReply() ([]byte, error) {
+	line, err := c.r.ReadSlice('\n')
+	if err != nil {
+		return nil, fmt.Errorf("failed to read redis reply: %w", err)
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, errors.New("malformed redis reply")
+	}
+	kind, payload := line[0], string(line[1:len(line)-2])
+	switch kind {
+	case '+', ':':
+		return []byte(payload), nil
+	case '-':
+		return nil, redisError(payload)
+	case '$':
+		n, err := strconv.Atoi(payload)
+		if err != nil || n < -1 {
+			return nil, errors.New("malformed redis bulk string length")
+		}
+		if n == -1 {
+			return nil, errRedisNil
+		}
+		value := make([]byte, n+2)
+		if _, err := io.ReadFull(c.r, value); err != nil {
+			return nil, fmt.Errorf("failed to read redis reply: %w", err)
+		}
+		return value[:n], nil
+	default:
+		return nil, fmt.Errorf("unexpected redis reply type %q", kind)
+	}
+}
@@ -0,0 +1,521 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package respcache caches the responses of the query endpoints of an
+// adapter, in memory or in Redis, so that dashboards re-issuing identical
+// queries every few seconds are answered without querying the backend
+// again. Responses are keyed by the normalized request and kept for a TTL
+// depending on its time window: windows ending near now still change as
+// data is ingested, while fully historical windows do not.
+package respcache
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/metrics"
+)
+
+// Cache backends.
+const (
+	// BackendNone disables the cache.
+	BackendNone = "none"
+	// BackendMemory keeps the responses in the memory of the adapter.
+	BackendMemory = "memory"
+	// BackendRedis keeps the responses in Redis, shared by the replicas
+	// of the adapter.
+	BackendRedis = "redis"
+)
+
+// MaxEntryBytes bounds the size of a cached response body. Larger responses
+// are served without being cached.
+const MaxEntryBytes = 1 << 20
+
+// Defaults of Policy.
+const (
+	DefaultRecentTTL     = 5 * time.Second
+	DefaultHistoricalTTL = 5 * time.Minute
+	DefaultSettle        = 5 * time.Minute
+)
+
+// CacheHeader tells whether a response was served from the cache ("hit") or
+// not ("miss").
+const CacheHeader = "X-Cache"
+
+// Store holds the cached responses under their keys.
+type Store interface {
+	// Get returns the value of key, with ok false when it is missing or
+	// expired.
+	Get(ctx context.Context, key string) (value []byte, ok bool, err error)
+	// Set stores value under key for ttl.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// Policy sets how long responses are cached.
+type Policy struct {
+	// RecentTTL is the TTL of the responses of windows ending within Settle
+	// of now, or without end time, whose results still change as data is
+	// ingested.
+	RecentTTL time.Duration
+	// HistoricalTTL is the TTL of the responses of the other windows.
+	HistoricalTTL time.Duration
+	// Settle is how long after their end time the results of a window are
+	// considered final.
+	Settle time.Duration
+}
+
+// ttl returns the TTL of the response of a request whose window ends at
+// end, or has no end time when end is zero.
+func (p Policy) ttl(end, now time.Time) time.Duration {
+	if end.IsZero() || now.Sub(end) < p.Settle {
+		return p.RecentTTL
+	}
+	return p.HistoricalTTL
+}
+
+// Config selects and configures the response cache of an adapter.
+type Config struct {
+	// Backend is BackendNone, BackendMemory or BackendRedis. An empty
+	// backend is BackendNone.
+	Backend string
+	// MaxBytes bounds the size of the responses held by BackendMemory.
+	MaxBytes int64
+	// RedisURL locates the Redis server of BackendRedis, as
+	// redis://host:port[/db]; RedisPassword authenticates with it.
+	RedisURL      string
+	RedisPassword string
+	// Compression is CompressionNone or CompressionGzip. An empty
+	// compression is CompressionNone.
+	Compression string
+	Policy
+}
+
+// Validate reports configuration errors.
+func (c Config) Validate() error {
+	switch c.Backend {
+	case "", BackendNone:
+		return nil
+	case BackendMemory:
+		if c.MaxBytes < MaxEntryBytes {
+			return fmt.Errorf("the memory size must be at least %d bytes", MaxEntryBytes)
+		}
+	case BackendRedis:
+		if _, _, err := parseRedisURL(c.RedisURL); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown backend %q: must be %s, %s or %s", c.Backend, BackendNone, BackendMemory, BackendRedis)
+	}
+	if c.RecentTTL <= 0 || c.HistoricalTTL <= 0 {
+		return errors.New("the TTLs must be positive durations")
+	}
+	if c.Settle < 0 {
+		return errors.New("the settle delay must not be negative")
+	}
+	_, err := newCodec(c.Compression)
+	return err
+}
+
+// New returns the cache configured by c, or nil with BackendNone. Its keys
+// and metrics start with prefix, for example logs_adapter.
+func New(c Config, prefix string, logger *slog.Logger) (*Cache, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	var store Store
+	switch c.Backend {
+	case BackendMemory:
+		store = NewMemoryStore(c.MaxBytes)
+	case BackendRedis:
+		redis, err := NewRedisStore(c.RedisURL, c.RedisPassword)
+		if err != nil {
+			return nil, err
+		}
+		store = redis
+	default:
+		return nil, nil
+	}
+	codec, err := newCodec(c.Compression)
+	if err != nil {
+		return nil, err
+	}
+	cache := NewCache(store, c.Policy, prefix, logger)
+	cache.SetCodec(codec)
+	return cache, nil
+}
+
+// Cache serves the responses of query requests from a Store. Failures of
+// the store are logged and counted, and the requests served as if the
+// cache were empty.
+//
+// It serves its hit, miss and error counters and the compression ratio of
+// its entries in the Prometheus text exposition format.
+type Cache struct {
+	store  Store
+	codec  Codec
+	policy Policy
+	prefix string
+	logger *slog.Logger
+	now    func() time.Time
+
+	hits   atomic.Int64
+	misses atomic.Int64
+	errors atomic.Int64
+	// entryBytes and storedBytes add up the sizes of the entries written
+	// to the store before and after compression.
+	entryBytes  atomic.Int64
+	storedBytes atomic.Int64
+}
+
+// NewCache returns a cache of the responses in store, kept as long as
+// policy sets. Its keys and metrics start with prefix.
+func NewCache(store Store, policy Policy, prefix string, logger *slog.Logger) *Cache {
+	return &Cache{store: store, policy: policy, prefix: prefix, logger: logger, now: time.Now}
+}
+
+// SetCodec compresses the entries written to the store with codec. Entries
+// are stored uncompressed with a nil codec, the default. Entries written
+// before are still read.
+func (c *Cache) SetCodec(codec Codec) {
+	c.codec = codec
+}
+
+// Middleware serves the requests matching one of routes, ServeMux patterns
+// such as "POST /api/v1/logs/query", from the cache, and caches the 200 JSON
+// responses next writes for them, unless they are streamed or carry
+// Cache-Control: no-store. Responses served
+// from the cache are recorded under their route by metrics.Instrument.
+//
+// Requests are keyed by their method, path, query, Authorization, Accept,
+// Accept-Language and Prefer headers and JSON body, normalized so that the
+// order of its fields does not matter, and by the parts returns, for example
+// the tenant of the request. The end time of their window is read from the
+// endTime field of the body or the endTime query parameter. Requests with
+// Cache-Control: no-cache are served by next, and their response cached. A
+// nil cache serves all requests with next.
+func (c *Cache) Middleware(routes []string, parts func(r *http.Request) []string, next http.Handler) http.Handler {
+	if c == nil {
+		return next
+	}
+	cacheable := http.NewServeMux()
+	for _, route := range routes {
+		cacheable.Handle(route, next)
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, route := cacheable.Handler(r)
+		if route == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		var body []byte
+		if r.Body != nil {
+			var err error
+			if body, err = io.ReadAll(r.Body); err != nil {
+				http.Error(w, "failed to read request body", http.StatusBadRequest)
+				return
+			}
+			_ = r.Body.Close()
+			r.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		normalized, end, ok := normalize(r, body)
+		if !ok {
+			// Malformed requests are left to the handler to reject.
+			next.ServeHTTP(w, r)
+			return
+		}
+		var extra []string
+		if parts != nil {
+			extra = parts(r)
+		}
+		key := c.key(r, normalized, extra)
+
+		if !noCache(r) {
+			if cached, ok := c.get(r.Context(), key); ok {
+				c.hits.Add(1)
+				metrics.SetRoute(r, route)
+				header, body := decodeEntry(cached)
+				for name, values := range header {
+					w.Header()[name] = values
+				}
+				w.Header().Set(CacheHeader, "hit")
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write(body)
+				return
+			}
+		}
+		c.misses.Add(1)
+
+		w.Header().Set(CacheHeader, "miss")
+		outer := w.Header().Clone()
+		rec := &recorder{ResponseWriter: w}
+		next.ServeHTTP(rec, r)
+		if !rec.cacheable() {
+			return
+		}
+		entry := encodeEntry(addedHeader(outer, rec.Header()), rec.body.Bytes())
+		ttl := c.policy.ttl(end, c.now())
+		// The response is complete, so the store is written even if the
+		// client already went away.
+		if err := c.set(context.WithoutCancel(r.Context()), key, entry, ttl); err != nil {
+			c.errors.Add(1)
+			c.logger.Warn("Failed to cache response", slog.String("path", r.URL.Path), slog.Any("error", err))
+		}
+	})
+}
+
+// get returns the entry of key, or ok false on a miss or a store failure.
+func (c *Cache) get(ctx context.Context, key string) ([]byte, bool) {
+	cached, ok, err := c.store.Get(ctx, key)
+	if err == nil && ok && len(cached) > 0 && cached[0] == compressedMarker {
+		if c.codec == nil {
+			err = errors.New("compressed entry read without compression configured")
+		} else {
+			cached, err = c.codec.Decompress(cached[1:])
+		}
+	}
+	if err != nil {
+		c.errors.Add(1)
+		c.logger.Warn("Failed to read cached response", slog.Any("error", err))
+		return nil, false
+	}
+	return cached, ok
+}
+
+// set stores entry under key for ttl, compressed with the codec of c when
+// it has one.
+func (c *Cache) set(ctx context.Context, key string, entry []byte, ttl time.Duration) error {
+	value := entry
+	if c.codec != nil {
+		compressed, err := c.codec.Compress(entry)
+		if err != nil {
+			return fmt.Errorf("failed to compress entry: %w", err)
+		}
+		value = append([]byte{compressedMarker}, compressed...)
+	}
+	if err := c.store.Set(ctx, key, value, ttl); err != nil {
+		return err
+	}
+	c.entryBytes.Add(int64(len(entry)))
+	c.storedBytes.Add(int64(len(value)))
+	return nil
+}
+
+// key returns the key of r, whose body normalizes to body, with the
+// parts of the module.
+func (c *Cache) key(r *http.Request, body []byte, parts []string) string {
+	h := sha256.New()
+	for _, part := range append([]string{
+		r.Method,
+		r.URL.Path,
+		r.URL.Query().Encode(),
+		r.Header.Get("Authorization"),
+		strings.Join(r.Header.Values("Accept"), ","),
+		strings.Join(r.Header.Values("Accept-Language"), ","),
+		strings.Join(r.Header.Values("Prefer"), ","),
+	}, parts...) {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	h.Write(body)
+	return c.prefix + ":" + hex.EncodeToString(h.Sum(nil))
+}
+
+// normalize returns the body of r re-encoded with its object fields in a
+// fixed order, and the end time of its window. ok is false when the body is
+// not JSON.
+func normalize(r *http.Request, body []byte) (normalized []byte, end time.Time, ok bool) {
+	if endTime := r.URL.Query().Get("endTime"); endTime != "" {
+		end, _ = time.Parse(time.RFC3339, endTime)
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil, end, true
+	}
+	var value any
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return nil, time.Time{}, false
+	}
+	if fields, isObject := value.(map[string]any); isObject {
+		if endTime, isString := fields["endTime"].(string); isString {
+			end, _ = time.Parse(time.RFC3339, endTime)
+		}
+	}
+	// Maps are encoded with sorted keys.
+	normalized, err := json.Marshal(value)
+	if err != nil {
+		return nil, time.Time{}, false
+	}
+	return normalized, end, true
+}
+
+// addedHeader returns the fields of header that are not in outer: those
+// set by the handler of the request rather than by the middlewares around
+// the cache, which set them again on hits.
+func addedHeader(outer, header http.Header) http.Header {
+	added := http.Header{}
+	for name, values := range header {
+		if _, ok := outer[name]; !ok {
+			added[name] = values
+		}
+	}
+	return added
+}
+
+// encodeEntry encodes a response as its header fields, one "Name: value"
+// per line, a blank line and its body.
+func encodeEntry(header http.Header, body []byte) []byte {
+	var entry bytes.Buffer
+	_ = header.Write(&entry)
+	entry.WriteString("\r\n")
+	entry.Write(body)
+	return entry.Bytes()
+}
+
+// decodeEntry decodes an entry encoded by encodeEntry.
+func decodeEntry(entry []byte) (http.Header, []byte) {
+	header := http.Header{}
+	if bytes.HasPrefix(entry, []byte("\r\n")) {
+		// No header fields.
+		return header, entry[2:]
+	}
+	fields, body, _ := bytes.Cut(entry, []byte("\r\n\r\n"))
+	for _, line := range strings.Split(string(fields), "\r\n") {
+		if name, value, ok := strings.Cut(line, ":"); ok {
+			header.Add(name, strings.TrimSpace(value))
+		}
+	}
+	return header, body
+}
+
+// noCache reports whether r asks for a response that is not served from a
+// cache.
+func noCache(r *http.Request) bool {
+	return hasNoCache(r.Header)
+}
+
+// hasNoCache reports whether the Cache-Control header of header has a
+// no-cache or no-store directive.
+func hasNoCache(header http.Header) bool {
+	for _, directive := range strings.Split(header.Get("Cache-Control"), ",") {
+		switch strings.ToLower(strings.TrimSpace(directive)) {
+		case "no-cache", "no-store":
+			return true
+		}
+	}
+	return false
+}
+
+// recorder passes a response through and keeps a copy of its body, up to
+// MaxEntryBytes.
+type recorder struct {
+	http.ResponseWriter
+	status   int
+	body     bytes.Buffer
+	overflow bool
+	flushed  bool
+}
+
+func (rec *recorder) WriteHeader(status int) {
+	if rec.status == 0 {
+		rec.status = status
+	}
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *recorder) Write(b []byte) (int, error) {
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+	if !rec.overflow {
+		if rec.body.Len()+len(b) > MaxEntryBytes {
+			rec.overflow = true
+			rec.body = bytes.Buffer{}
+		} else {
+			rec.body.Write(b)
+		}
+	}
+	return rec.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (rec *recorder) Unwrap() http.ResponseWriter {
+	return rec.ResponseWriter
+}
+
+// Flush marks the response streamed, which is not cached.
+func (rec *recorder) Flush() {
+	rec.flushed = true
+	if f, ok := rec.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// cacheable reports whether the recorded response can be cached: a
+// complete 200 JSON response of at most MaxEntryBytes, which handlers can
+// keep out of the cache with Cache-Control: no-store.
+func (rec *recorder) cacheable() bool {
+	if rec.status != http.StatusOK || rec.overflow || rec.flushed || hasNoCache(rec.Header()) {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(rec.Header().Get("Content-Type"))
+	return err == nil && mediaType == "application/json"
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format.
+func (c *Cache) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	fmt.Fprintf(w, "# HELP %[1]s_response_cache_hits_total Query responses served from the response cache.\n"+
+		"# TYPE %[1]s_response_cache_hits_total counter\n"+
+		"%[1]s_response_cache_hits_total %[2]d\n", c.prefix, c.hits.Load())
+	fmt.Fprintf(w, "# HELP %[1]s_response_cache_misses_total Cacheable query requests served by the backend.\n"+
+		"# TYPE %[1]s_response_cache_misses_total counter\n"+
+		"%[1]s_response_cache_misses_total %[2]d\n", c.prefix, c.misses.Load())
+	fmt.Fprintf(w, "# HELP %[1]s_response_cache_errors_total Failed reads and writes of the response cache store.\n"+
+		"# TYPE %[1]s_response_cache_errors_total counter\n"+
+		"%[1]s_response_cache_errors_total %[2]d\n", c.prefix, c.errors.Load())
+	fmt.Fprintf(w, "# HELP %[1]s_response_cache_entry_bytes_total Bytes of the entries written to the response cache, before compression.\n"+
+		"# TYPE %[1]s_response_cache_entry_bytes_total counter\n"+
+		"%[1]s_response_cache_entry_bytes_total %[2]d\n", c.prefix, c.entryBytes.Load())
+	fmt.Fprintf(w, "# HELP %[1]s_response_cache_stored_bytes_total Bytes written to the response cache store, after compression.\n"+
+		"# TYPE %[1]s_response_cache_stored_bytes_total counter\n"+
+		"%[1]s_response_cache_stored_bytes_total %[2]d\n", c.prefix, c.storedBytes.Load())
+	ratio := 1.0
+	if stored := c.storedBytes.Load(); stored > 0 {
+		ratio = float64(c.entryBytes.Load()) / float64(stored)
+	}
+	fmt.Fprintf(w, "# HELP %[1]s_response_cache_compression_ratio Bytes of the entries written to the response cache per byte stored.\n"+
+		"# TYPE %[1]s_response_cache_compression_ratio gauge\n"+
+		"%[1]s_response_cache_compression_ratio %[2]g\n", c.prefix, ratio)
+}
+
+// parseRedisURL returns the address and database of a redis:// URL.
+func parseRedisURL(rawURL string) (addr string, db int, err error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "redis" || u.Host == "" {
+		return "", 0, errors.New("the Redis URL must be a redis://host:port[/db] URL")
+	}
+	if path := strings.Trim(u.Path, "/"); path != "" {
+		if _, err := fmt.Sscanf(path, "%d", &db); err != nil || db < 0 || fmt.Sprint(db) != path {
+			return "", 0, fmt.Errorf("invalid Redis database %q", path)
+		}
+	}
+	addr = u.Host
+	if u.Port() == "" {
+		addr += ":6379"
+	}
+	return addr, db, nil
+}
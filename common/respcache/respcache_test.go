@@ -0,0 +1,153 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package respcache
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/metrics"
+)
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+// ttlStore is a MemoryStore recording the TTL of the last value set.
+type ttlStore struct {
+	*MemoryStore
+	ttl time.Duration
+}
+
+func (s *ttlStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	s.ttl = ttl
+	return s.MemoryStore.Set(ctx, key, value, ttl)
+}
+
+func TestMiddleware(t *testing.T) {
+	calls := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v1/logs/query", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+		if strings.Contains(string(body), "fail") {
+			http.Error(w, "boom", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Language", "fr")
+		if strings.Contains(string(body), "partial") {
+			w.Header().Set("Cache-Control", "no-store")
+		}
+		if strings.Contains(string(body), "stream") {
+			w.(http.Flusher).Flush()
+		}
+		w.Write([]byte(`{"total":1}`))
+	})
+	store := &ttlStore{MemoryStore: NewMemoryStore(MaxEntryBytes)}
+	policy := Policy{RecentTTL: 5 * time.Second, HistoricalTTL: 5 * time.Minute, Settle: 5 * time.Minute}
+	cache := NewCache(store, policy, "logs_adapter", testLogger())
+	m := metrics.New("logs_adapter")
+	srv := m.Instrument(cache.Middleware([]string{"POST /api/v1/logs/query"}, func(r *http.Request) []string {
+		return []string{r.Header.Get("X-Tenant")}
+	}, m.Route(mux)))
+
+	serve := func(body string, header http.Header) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/logs/query", strings.NewReader(body))
+		for name, values := range header {
+			req.Header[name] = values
+		}
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		return rec
+	}
+	historical := `{"startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-02T00:00:00Z","limit":10}`
+
+	if rec := serve(historical, nil); rec.Header().Get(CacheHeader) != "miss" || calls != 1 || store.ttl != 5*time.Minute {
+		t.Fatalf("expected a miss cached for the historical TTL, got %q after %d calls, TTL %v", rec.Header().Get(CacheHeader), calls, store.ttl)
+	}
+	rec := serve(`{"limit":10, "endTime":"2025-01-02T00:00:00Z","startTime":"2025-01-01T00:00:00Z"}`, nil)
+	if rec.Header().Get(CacheHeader) != "hit" || calls != 1 || rec.Body.String() != `{"total":1}` ||
+		rec.Header().Get("Content-Type") != "application/json" || rec.Header().Get("Content-Language") != "fr" {
+		t.Errorf("expected the same query with reordered fields to hit, got %q after %d calls: %s", rec.Header().Get(CacheHeader), calls, rec.Body.String())
+	}
+
+	for name, header := range map[string]http.Header{
+		"another caller": {"Authorization": {"Bearer other"}},
+		"another tenant": {"X-Tenant": {"acme"}},
+		"no-cache":       {"Cache-Control": {"no-cache"}},
+	} {
+		before := calls
+		if serve(historical, header); calls != before+1 {
+			t.Errorf("%s: expected the backend to be queried", name)
+		}
+	}
+
+	recent := `{"startTime":"2025-01-01T00:00:00Z","endTime":"` + time.Now().UTC().Format(time.RFC3339) + `"}`
+	if serve(recent, nil); store.ttl != 5*time.Second {
+		t.Errorf("expected a recent window to be cached for the recent TTL, got %v", store.ttl)
+	}
+
+	for _, body := range []string{`{"fail":true}`, `{"stream":true}`, `{"partial":true}`, `not json`} {
+		serve(body, nil)
+		before := calls
+		if serve(body, nil); calls != before+1 {
+			t.Errorf("expected the response to %s not to be cached", body)
+		}
+	}
+
+	metricsRec := httptest.NewRecorder()
+	m.ServeHTTP(metricsRec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	cache.ServeHTTP(metricsRec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	for _, want := range []string{
+		`logs_adapter_http_requests_total{handler="/api/v1/logs/query",method="POST",code="200"} 12`,
+		"logs_adapter_response_cache_hits_total 1\n",
+		"logs_adapter_response_cache_misses_total 11\n",
+	} {
+		if !strings.Contains(metricsRec.Body.String(), want) {
+			t.Errorf("expected metrics to contain %q, got:\n%s", want, metricsRec.Body.String())
+		}
+	}
+}
+
+func TestMiddleware_NilCache(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	var cache *Cache
+	if handler := cache.Middleware([]string{"POST /query"}, nil, next); handler == nil {
+		t.Fatal("expected next to be returned")
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	policy := Policy{RecentTTL: time.Second, HistoricalTTL: time.Minute}
+	for name, c := range map[string]Config{
+		"none":   {},
+		"memory": {Backend: BackendMemory, MaxBytes: 64 << 20, Policy: policy},
+		"redis":  {Backend: BackendRedis, RedisURL: "redis://cache:6380/2", Policy: policy},
+		"gzip":   {Backend: BackendMemory, MaxBytes: 64 << 20, Compression: CompressionGzip, Policy: policy},
+	} {
+		if err := c.Validate(); err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		}
+	}
+	for name, c := range map[string]Config{
+		"unknown backend": {Backend: "memcached", Policy: policy},
+		"small memory":    {Backend: BackendMemory, MaxBytes: 1024, Policy: policy},
+		"http redis URL":  {Backend: BackendRedis, RedisURL: "http://cache:6379", Policy: policy},
+		"bad database":    {Backend: BackendRedis, RedisURL: "redis://cache:6379/x", Policy: policy},
+		"zero TTL":        {Backend: BackendMemory, MaxBytes: 64 << 20, Policy: Policy{RecentTTL: time.Second}},
+		"unknown codec":   {Backend: BackendMemory, MaxBytes: 64 << 20, Compression: "zstd", Policy: policy},
+	} {
+		if err := c.Validate(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
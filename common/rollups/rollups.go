@@ -0,0 +1,162 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package rollups maintains pre-aggregated rollups of an OpenObserve stream
+// in a derived stream, one row per key and minute, and tracks the time range
+// they cover, so that summaries over long windows read a few rows per minute
+// rather than every hit.
+package rollups
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Resolution is the time span of a rollup row.
+const Resolution = time.Minute
+
+// settleDelay is the age a minute must reach before it is rolled up, so
+// that the hits ingested late are counted. Later hits are missing from the
+// rollups.
+const settleDelay = 2 * time.Minute
+
+// chunk bounds the minutes rolled up by a single call of the builder.
+const chunk = time.Hour
+
+// maxBackfill bounds how far back the minutes missing from the rollups are
+// rolled up, below the 5 hours OpenObserve accepts the ingestion of old
+// records for by default (ZO_INGEST_ALLOWED_UPTO).
+const maxBackfill = 4 * time.Hour
+
+// Builder builds the rollups of a derived stream.
+type Builder interface {
+	// RollupRange returns the first and last minutes of the rollups in the
+	// derived stream, with ok false when it holds none.
+	RollupRange(ctx context.Context) (first, last time.Time, ok bool, err error)
+	// BuildRollups rolls up the whole minutes of [start, end) and writes
+	// them to the derived stream.
+	BuildRollups(ctx context.Context, start, end time.Time) error
+}
+
+// Coverage is the time range the rollups of a derived stream cover. It is
+// safe for concurrent use, and a nil Coverage covers nothing.
+type Coverage struct {
+	mu            sync.RWMutex
+	from, through time.Time
+}
+
+// Range returns the covered range [from, through), zero before the first
+// rollups are built.
+func (c *Coverage) Range() (from, through time.Time) {
+	if c == nil {
+		return time.Time{}, time.Time{}
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.from, c.through
+}
+
+func (c *Coverage) set(from, through time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.from, c.through = from, through
+}
+
+// Split returns the whole minutes of [start, end), [innerStart, innerEnd),
+// when they are all covered. The partial minutes at the edges of the window
+// are read from the source stream. ok is false when the window holds no
+// whole minute or the rollups do not cover them.
+func (c *Coverage) Split(start, end time.Time) (innerStart, innerEnd time.Time, ok bool) {
+	from, through := c.Range()
+	innerStart, innerEnd = start.Truncate(Resolution), end.Truncate(Resolution)
+	if innerStart.Before(start) {
+		innerStart = innerStart.Add(Resolution)
+	}
+	if !innerStart.Before(innerEnd) || from.IsZero() || innerStart.Before(from) || innerEnd.After(through) {
+		return time.Time{}, time.Time{}, false
+	}
+	return innerStart, innerEnd, true
+}
+
+// Job keeps the rollups of a builder up to date, and their coverage.
+type Job struct {
+	builder  Builder
+	coverage *Coverage
+	interval time.Duration
+	window   time.Duration
+	logger   *slog.Logger
+}
+
+// NewJob returns a Job rolling up the minutes of the last window every
+// interval with builder, and recording the range built in coverage.
+func NewJob(builder Builder, coverage *Coverage, interval, window time.Duration, logger *slog.Logger) *Job {
+	return &Job{
+		builder:  builder,
+		coverage: coverage,
+		interval: interval,
+		window:   window,
+		logger:   logger,
+	}
+}
+
+// Run updates the rollups at once and then every interval until ctx is
+// done. A failed update is retried at the next one.
+func (j *Job) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+	now := time.Now()
+	for {
+		if err := j.Update(ctx, now); err != nil && ctx.Err() == nil {
+			j.logger.Warn("Failed to update rollups", slog.Any("error", err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case now = <-ticker.C:
+		}
+	}
+}
+
+// Update rolls up the minutes settled by now that are not rolled up yet,
+// an hour at a time. The rollups already in the derived stream are reused
+// when the job starts, and only the last window is covered: the minutes
+// rolled up before it are expected to expire from the derived stream.
+// Rollups more than maxBackfill behind start over, since the minutes they
+// miss can no longer be written.
+func (j *Job) Update(ctx context.Context, now time.Time) error {
+	target := now.Add(-settleDelay).Truncate(Resolution)
+	oldest := target.Add(-j.window).Truncate(Resolution)
+	earliest := target.Add(-min(j.window, maxBackfill)).Truncate(Resolution)
+	from, through := j.coverage.Range()
+	if through.IsZero() {
+		first, last, ok, err := j.builder.RollupRange(ctx)
+		if err != nil {
+			return err
+		}
+		if ok {
+			from, through = first, last.Add(Resolution)
+		}
+	}
+	if through.Before(earliest) {
+		from, through = earliest, earliest
+	}
+	if from.Before(oldest) {
+		from = oldest
+	}
+	j.coverage.set(from, through)
+
+	for through.Before(target) {
+		end := through.Add(chunk)
+		if end.After(target) {
+			end = target
+		}
+		if err := j.builder.BuildRollups(ctx, through, end); err != nil {
+			return err
+		}
+		through = end
+		j.coverage.set(from, through)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package rollups
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+type fakeBuilder struct {
+	first, last time.Time
+	ok          bool
+	built       [][2]time.Time
+	err         error
+}
+
+func (f *fakeBuilder) RollupRange(context.Context) (time.Time, time.Time, bool, error) {
+	return f.first, f.last, f.ok, nil
+}
+
+func (f *fakeBuilder) BuildRollups(_ context.Context, start, end time.Time) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.built = append(f.built, [2]time.Time{start, end})
+	return nil
+}
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func TestJob_Update(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 30, 40, 0, time.UTC)
+	builder := &fakeBuilder{}
+	coverage := &Coverage{}
+	job := NewJob(builder, coverage, time.Minute, 3*time.Hour, testLogger())
+
+	// The first update rolls up the whole window, an hour at a time, up to
+	// the last settled minute.
+	if err := job.Update(context.Background(), now); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	target := time.Date(2026, 1, 1, 12, 28, 0, 0, time.UTC)
+	if len(builder.built) != 3 || !builder.built[0][0].Equal(target.Add(-3*time.Hour)) || !builder.built[2][1].Equal(target) {
+		t.Fatalf("unexpected builds %v", builder.built)
+	}
+	if from, through := coverage.Range(); !from.Equal(target.Add(-3*time.Hour)) || !through.Equal(target) {
+		t.Errorf("unexpected coverage [%v, %v)", from, through)
+	}
+
+	// The next ones only roll up the new minutes.
+	builder.built = nil
+	if err := job.Update(context.Background(), now.Add(time.Minute)); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if len(builder.built) != 1 || !builder.built[0][0].Equal(target) || !builder.built[0][1].Equal(target.Add(time.Minute)) {
+		t.Errorf("unexpected builds %v", builder.built)
+	}
+
+	// A failed build keeps the coverage of the minutes built so far.
+	builder.err = errors.New("boom")
+	if err := job.Update(context.Background(), now.Add(time.Hour)); err == nil {
+		t.Error("expected the failure of the builder")
+	}
+	if _, through := coverage.Range(); !through.Equal(target.Add(time.Minute)) {
+		t.Errorf("unexpected coverage end %v", through)
+	}
+}
+
+func TestJob_UpdateResumes(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 30, 0, 0, time.UTC)
+	builder := &fakeBuilder{
+		first: now.Add(-2 * time.Hour).Truncate(time.Minute),
+		last:  now.Add(-10 * time.Minute).Truncate(time.Minute),
+		ok:    true,
+	}
+	coverage := &Coverage{}
+	job := NewJob(builder, coverage, time.Minute, time.Hour, testLogger())
+	if err := job.Update(context.Background(), now); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if len(builder.built) != 1 || !builder.built[0][0].Equal(builder.last.Add(time.Minute)) {
+		t.Errorf("expected the rollups to resume after the last one, got %v", builder.built)
+	}
+	if from, _ := coverage.Range(); !from.Equal(now.Add(-2*time.Minute - time.Hour)) {
+		t.Errorf("expected the coverage to start with the window, got %v", from)
+	}
+}
+
+func TestCoverage_Split(t *testing.T) {
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	coverage := &Coverage{}
+	coverage.set(base, base.Add(time.Hour))
+
+	start, end, ok := coverage.Split(base.Add(90*time.Second), base.Add(30*time.Minute+10*time.Second))
+	if !ok || !start.Equal(base.Add(2*time.Minute)) || !end.Equal(base.Add(30*time.Minute)) {
+		t.Errorf("unexpected split [%v, %v) %v", start, end, ok)
+	}
+	for _, window := range [][2]time.Time{
+		{base.Add(-time.Minute), base.Add(time.Minute)},
+		{base.Add(59 * time.Minute), base.Add(61 * time.Minute)},
+		{base.Add(10 * time.Second), base.Add(50 * time.Second)},
+	} {
+		if _, _, ok := coverage.Split(window[0], window[1]); ok {
+			t.Errorf("expected [%v, %v) not to be served from rollups", window[0], window[1])
+		}
+	}
+	if _, _, ok := (*Coverage)(nil).Split(base, base.Add(time.Minute)); ok {
+		t.Error("expected a nil coverage to cover nothing")
+	}
+}
+
+func TestJob_UpdateBoundsBackfill(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 2, 0, 0, time.UTC)
+	builder := &fakeBuilder{first: now.Add(-48 * time.Hour), last: now.Add(-10 * time.Hour), ok: true}
+	coverage := &Coverage{}
+	job := NewJob(builder, coverage, time.Minute, 24*time.Hour, testLogger())
+	if err := job.Update(context.Background(), now); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	start := time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC)
+	if len(builder.built) != 4 || !builder.built[0][0].Equal(start) {
+		t.Errorf("expected the rollups to start over %v ago, got %v", maxBackfill, builder.built)
+	}
+	if from, _ := coverage.Range(); !from.Equal(start) {
+		t.Errorf("unexpected coverage start %v", from)
+	}
+}
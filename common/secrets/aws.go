@@ -0,0 +1,144 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// awsSecret reads a secret from AWS Secrets Manager with the static
+// credentials in AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and the optional
+// AWS_SESSION_TOKEN, in the region AWS_REGION. With a key, the secret value
+// must be a JSON object and the value of the key is returned.
+type awsSecret struct {
+	endpoint        string
+	region          string
+	secretID        string
+	key             string
+	accessKeyID     string
+	secretAccessKey string
+	sessionToken    string
+	client          *http.Client
+	now             func() time.Time
+}
+
+func newAWSSecret(secretID, key string) (*awsSecret, error) {
+	if secretID == "" {
+		return nil, fmt.Errorf("invalid AWS Secrets Manager reference: expected aws-sm://<secret-id>[#<key>]")
+	}
+	region := os.Getenv("AWS_REGION")
+	if region == "" {
+		return nil, fmt.Errorf("AWS_REGION is required for AWS Secrets Manager secrets")
+	}
+	s := &awsSecret{
+		endpoint:        "https://secretsmanager." + region + ".amazonaws.com",
+		region:          region,
+		secretID:        secretID,
+		key:             key,
+		accessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		secretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		sessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+		client:          &http.Client{Timeout: 10 * time.Second},
+		now:             time.Now,
+	}
+	if s.accessKeyID == "" || s.secretAccessKey == "" {
+		return nil, fmt.Errorf("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY are required for AWS Secrets Manager secrets")
+	}
+	return s, nil
+}
+
+// Fetch implements Provider.
+func (s *awsSecret) Fetch(ctx context.Context) (string, error) {
+	body, err := json.Marshal(map[string]string{"SecretId": s.secretID})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint+"/", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+	if s.sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.sessionToken)
+	}
+	s.sign(req, body, s.now().UTC())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to get secret %s: %w", s.secretID, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to get secret %s: status %d", s.secretID, resp.StatusCode)
+	}
+
+	var secret struct {
+		SecretString string `json:"SecretString"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&secret); err != nil {
+		return "", fmt.Errorf("failed to decode secret %s: %w", s.secretID, err)
+	}
+	if s.key == "" {
+		return secret.SecretString, nil
+	}
+	var values map[string]interface{}
+	if err := json.Unmarshal([]byte(secret.SecretString), &values); err != nil {
+		return "", fmt.Errorf("secret %s is not a JSON object: %w", s.secretID, err)
+	}
+	value, ok := values[s.key].(string)
+	if !ok {
+		return "", fmt.Errorf("secret %s has no string key %q", s.secretID, s.key)
+	}
+	return value, nil
+}
+
+// sign adds an AWS Signature Version 4 Authorization header to req, signing
+// the host, content type and X-Amz-* headers.
+func (s *awsSecret) sign(req *http.Request, body []byte, t time.Time) {
+	amzDate := t.Format("20060102T150405Z")
+	date := t.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	names := []string{"host"}
+	canonicalHeaders := "host:" + req.URL.Host + "\n"
+	for _, name := range []string{"content-type", "x-amz-date", "x-amz-security-token", "x-amz-target"} {
+		if v := req.Header.Get(name); v != "" {
+			names = append(names, name)
+			canonicalHeaders += name + ":" + v + "\n"
+		}
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	payloadHash := sha256.Sum256(body)
+	canonicalRequest := req.Method + "\n/\n\n" + canonicalHeaders + "\n" + signedHeaders + "\n" + hex.EncodeToString(payloadHash[:])
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	scope := date + "/" + s.region + "/secretsmanager/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+s.secretAccessKey), date)
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "secretsmanager")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+s.accessKeyID+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
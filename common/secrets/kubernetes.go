@@ -0,0 +1,99 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package secrets
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// serviceAccountDir holds the token and CA certificate mounted into pods.
+const serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+// kubernetesSecret reads a key of a Kubernetes Secret from the API server
+// with the pod's service account, which needs permission to get the Secret.
+type kubernetesSecret struct {
+	apiURL    string
+	namespace string
+	name      string
+	key       string
+	tokenFile string
+	client    *http.Client
+}
+
+func newKubernetesSecret(namespace, name, key string) (*kubernetesSecret, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, fmt.Errorf("Kubernetes secrets can only be read in a cluster: KUBERNETES_SERVICE_HOST is not set")
+	}
+	ca, err := os.ReadFile(serviceAccountDir + "/ca.crt")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the service account CA certificate: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("invalid service account CA certificate")
+	}
+	return &kubernetesSecret{
+		apiURL:    "https://" + net.JoinHostPort(host, port),
+		namespace: namespace,
+		name:      name,
+		key:       key,
+		tokenFile: serviceAccountDir + "/token",
+		client: &http.Client{
+			Timeout:   10 * time.Second,
+			Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}},
+		},
+	}, nil
+}
+
+// Fetch implements Provider. The service account token is read on every
+// fetch because projected tokens are rotated by the kubelet.
+func (s *kubernetesSecret) Fetch(ctx context.Context) (string, error) {
+	token, err := os.ReadFile(s.tokenFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the service account token: %w", err)
+	}
+	u := fmt.Sprintf("%s/api/v1/namespaces/%s/secrets/%s", s.apiURL, url.PathEscape(s.namespace), url.PathEscape(s.name))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to get secret %s/%s: %w", s.namespace, s.name, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to get secret %s/%s: status %d", s.namespace, s.name, resp.StatusCode)
+	}
+
+	var secret struct {
+		Data map[string]string `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&secret); err != nil {
+		return "", fmt.Errorf("failed to decode secret %s/%s: %w", s.namespace, s.name, err)
+	}
+	encoded, ok := secret.Data[s.key]
+	if !ok {
+		return "", fmt.Errorf("secret %s/%s has no key %q", s.namespace, s.name, s.key)
+	}
+	value, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode key %q of secret %s/%s: %w", s.key, s.namespace, s.name, err)
+	}
+	return string(value), nil
+}
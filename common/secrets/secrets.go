@@ -0,0 +1,125 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package secrets reads credentials from external secret stores, so that
+// they do not have to be passed to the adapter in environment variables, and
+// detects when they are rotated.
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Provider fetches the current value of a secret.
+type Provider interface {
+	Fetch(ctx context.Context) (string, error)
+}
+
+// Parse returns the provider of a secret reference:
+//
+//	k8s://<namespace>/<name>/<key>  a key of a Kubernetes Secret, read with the pod's service account
+//	vault://<path>#<key>            a key of a HashiCorp Vault KV secret (v1 or v2), read from VAULT_ADDR
+//	aws-sm://<secret-id>[#<key>]    an AWS Secrets Manager secret, or a key of its JSON value
+func Parse(ref string) (Provider, error) {
+	scheme, rest, ok := strings.Cut(ref, "://")
+	if !ok || rest == "" {
+		return nil, fmt.Errorf("invalid secret reference %q: expected <provider>://<location>", ref)
+	}
+	switch scheme {
+	case "k8s":
+		parts := strings.Split(rest, "/")
+		if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+			return nil, fmt.Errorf("invalid Kubernetes secret reference %q: expected k8s://<namespace>/<name>/<key>", ref)
+		}
+		return newKubernetesSecret(parts[0], parts[1], parts[2])
+	case "vault":
+		path, key, _ := strings.Cut(rest, "#")
+		if path == "" || key == "" {
+			return nil, fmt.Errorf("invalid Vault secret reference %q: expected vault://<path>#<key>", ref)
+		}
+		return newVaultSecret(path, key)
+	case "aws-sm":
+		id, key, _ := strings.Cut(rest, "#")
+		return newAWSSecret(id, key)
+	default:
+		return nil, fmt.Errorf("unsupported secret provider %q: expected k8s, vault or aws-sm", scheme)
+	}
+}
+
+// Cached caches the value of a secret for a refresh interval.
+type Cached struct {
+	provider Provider
+	interval time.Duration
+
+	mu      sync.Mutex
+	value   string
+	fetched time.Time
+}
+
+// NewCached returns a cache that fetches the secret of provider at most once
+// per interval.
+func NewCached(provider Provider, interval time.Duration) *Cached {
+	return &Cached{provider: provider, interval: interval}
+}
+
+// Get returns the cached value of the secret, fetching it when it is older
+// than the refresh interval. If the fetch fails, the previous value is
+// returned along with the error.
+func (c *Cached) Get(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.fetched.IsZero() && time.Since(c.fetched) < c.interval {
+		return c.value, nil
+	}
+	value, _, err := c.refreshLocked(ctx)
+	return value, err
+}
+
+// refresh fetches the secret and reports whether its value changed.
+func (c *Cached) refresh(ctx context.Context) (string, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.refreshLocked(ctx)
+}
+
+func (c *Cached) refreshLocked(ctx context.Context) (string, bool, error) {
+	value, err := c.provider.Fetch(ctx)
+	if err != nil {
+		return c.value, false, err
+	}
+	if value == "" {
+		return c.value, false, fmt.Errorf("secret is empty")
+	}
+	changed := !c.fetched.IsZero() && value != c.value
+	c.value, c.fetched = value, time.Now()
+	return value, changed, nil
+}
+
+// Watch refreshes the secret every refresh interval until ctx is done and
+// calls onRotate with the new value whenever it changed. Failed refreshes are
+// logged and the previous value stays in use.
+func (c *Cached) Watch(ctx context.Context, onRotate func(value string), logger *slog.Logger) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			value, changed, err := c.refresh(ctx)
+			if err != nil {
+				logger.Warn("Failed to refresh secret; keeping the previous value", slog.Any("error", err))
+				continue
+			}
+			if changed {
+				logger.Info("Secret rotation detected")
+				onRotate(value)
+			}
+		}
+	}
+}
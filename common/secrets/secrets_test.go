@@ -0,0 +1,229 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeProvider struct {
+	mu     sync.Mutex
+	values []string
+	err    error
+	calls  int
+}
+
+func (p *fakeProvider) Fetch(context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls++
+	if p.err != nil {
+		return "", p.err
+	}
+	value := p.values[0]
+	if len(p.values) > 1 {
+		p.values = p.values[1:]
+	}
+	return value, nil
+}
+
+func TestParse(t *testing.T) {
+	t.Setenv("VAULT_ADDR", "http://vault:8200")
+	t.Setenv("VAULT_TOKEN", "root")
+	t.Setenv("AWS_REGION", "eu-west-1")
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKID")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+
+	if p, err := Parse("vault://secret/data/openobserve#password"); err != nil {
+		t.Errorf("Parse(vault) error = %v", err)
+	} else if v := p.(*vaultSecret); v.path != "secret/data/openobserve" || v.key != "password" {
+		t.Errorf("unexpected Vault provider: %+v", v)
+	}
+	if p, err := Parse("aws-sm://prod/openobserve#password"); err != nil {
+		t.Errorf("Parse(aws-sm) error = %v", err)
+	} else if a := p.(*awsSecret); a.secretID != "prod/openobserve" || a.endpoint != "https://secretsmanager.eu-west-1.amazonaws.com" {
+		t.Errorf("unexpected AWS provider: %+v", a)
+	}
+
+	for _, ref := range []string{
+		"",
+		"password",
+		"gcp://projects/p/secrets/s",
+		"k8s://ns/name",
+		"vault://secret/data/openobserve",
+		"aws-sm://#password",
+	} {
+		if _, err := Parse(ref); err == nil {
+			t.Errorf("Parse(%q) expected an error", ref)
+		}
+	}
+
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+	if _, err := Parse("k8s://ns/name/key"); err == nil {
+		t.Error("expected an error for Kubernetes secrets outside a cluster")
+	}
+}
+
+func TestCached(t *testing.T) {
+	provider := &fakeProvider{values: []string{"v1", "v2"}}
+	c := NewCached(provider, time.Hour)
+
+	for range 2 {
+		if v, err := c.Get(context.Background()); err != nil || v != "v1" {
+			t.Fatalf("Get() = %q, %v; want v1", v, err)
+		}
+	}
+	if provider.calls != 1 {
+		t.Errorf("expected one fetch within the refresh interval, got %d", provider.calls)
+	}
+
+	v, changed, err := c.refresh(context.Background())
+	if err != nil || v != "v2" || !changed {
+		t.Errorf("refresh() = %q, %v, %v; want a change to v2", v, changed, err)
+	}
+
+	provider.err = errors.New("store unavailable")
+	v, changed, err = c.refresh(context.Background())
+	if err == nil || v != "v2" || changed {
+		t.Errorf("refresh() = %q, %v, %v; want the previous value and the error", v, changed, err)
+	}
+}
+
+func TestCachedWatch(t *testing.T) {
+	provider := &fakeProvider{values: []string{"v1", "v1", "v2"}}
+	c := NewCached(provider, 10*time.Millisecond)
+	if _, err := c.Get(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	rotated := make(chan string, 1)
+	go c.Watch(ctx, func(value string) { rotated <- value }, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	select {
+	case v := <-rotated:
+		if v != "v2" {
+			t.Errorf("expected rotation to v2, got %q", v)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("rotation was not detected")
+	}
+}
+
+func TestKubernetesSecretFetch(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/namespaces/obs/secrets/openobserve" || r.Header.Get("Authorization") != "Bearer sa-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]string{"password": base64.StdEncoding.EncodeToString([]byte("s3cret"))},
+		})
+	}))
+	defer server.Close()
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("sa-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	s := &kubernetesSecret{apiURL: server.URL, namespace: "obs", name: "openobserve", key: "password", tokenFile: tokenFile, client: server.Client()}
+	if v, err := s.Fetch(context.Background()); err != nil || v != "s3cret" {
+		t.Errorf("Fetch() = %q, %v; want s3cret", v, err)
+	}
+	s.key = "user"
+	if _, err := s.Fetch(context.Background()); err == nil {
+		t.Error("expected an error for a missing key")
+	}
+	s.name = "other"
+	if _, err := s.Fetch(context.Background()); err == nil {
+		t.Error("expected an error for a forbidden secret")
+	}
+}
+
+func TestVaultSecretFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "root" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/openobserve":
+			w.Write([]byte(`{"data":{"data":{"password":"kv2"},"metadata":{"version":3}}}`))
+		case "/v1/kv/openobserve":
+			w.Write([]byte(`{"data":{"password":"kv1"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	for path, want := range map[string]string{"secret/data/openobserve": "kv2", "kv/openobserve": "kv1"} {
+		s := &vaultSecret{addr: server.URL, path: path, key: "password", token: "root", client: server.Client()}
+		if v, err := s.Fetch(context.Background()); err != nil || v != want {
+			t.Errorf("Fetch(%s) = %q, %v; want %q", path, v, err, want)
+		}
+	}
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("expired"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	s := &vaultSecret{addr: server.URL, path: "kv/openobserve", key: "password", tokenFile: tokenFile, client: server.Client()}
+	if _, err := s.Fetch(context.Background()); err == nil {
+		t.Error("expected an error for a rejected token")
+	}
+}
+
+func TestAWSSecretFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKID/20260301/eu-west-1/secretsmanager/aws4_request") ||
+			!strings.Contains(auth, "SignedHeaders=host;content-type;x-amz-date;x-amz-security-token;x-amz-target") ||
+			r.Header.Get("X-Amz-Target") != "secretsmanager.GetSecretValue" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		var body struct{ SecretId string }
+		json.NewDecoder(r.Body).Decode(&body)
+		json.NewEncoder(w).Encode(map[string]string{"SecretString": `{"password":"from-aws","user":"admin"}`})
+	}))
+	defer server.Close()
+
+	s := &awsSecret{
+		endpoint:        server.URL,
+		region:          "eu-west-1",
+		secretID:        "prod/openobserve",
+		key:             "password",
+		accessKeyID:     "AKID",
+		secretAccessKey: "secret",
+		sessionToken:    "session",
+		client:          server.Client(),
+		now:             func() time.Time { return time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC) },
+	}
+	if v, err := s.Fetch(context.Background()); err != nil || v != "from-aws" {
+		t.Errorf("Fetch() = %q, %v; want from-aws", v, err)
+	}
+	s.key = ""
+	if v, err := s.Fetch(context.Background()); err != nil || !strings.Contains(v, `"user":"admin"`) {
+		t.Errorf("Fetch() without key = %q, %v; want the whole secret string", v, err)
+	}
+	s.key = "token"
+	if _, err := s.Fetch(context.Background()); err == nil {
+		t.Error("expected an error for a missing key")
+	}
+}
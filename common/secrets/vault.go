@@ -0,0 +1,91 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// vaultSecret reads a key of a HashiCorp Vault KV secret. The token is read
+// from VAULT_TOKEN, or from the file named by VAULT_TOKEN_FILE on every fetch
+// so that an agent sidecar can renew it.
+type vaultSecret struct {
+	addr      string
+	path      string
+	key       string
+	token     string
+	tokenFile string
+	client    *http.Client
+}
+
+func newVaultSecret(path, key string) (*vaultSecret, error) {
+	addr := strings.TrimSuffix(os.Getenv("VAULT_ADDR"), "/")
+	if addr == "" {
+		return nil, fmt.Errorf("VAULT_ADDR is required for Vault secrets")
+	}
+	s := &vaultSecret{
+		addr:      addr,
+		path:      strings.Trim(path, "/"),
+		key:       key,
+		token:     os.Getenv("VAULT_TOKEN"),
+		tokenFile: os.Getenv("VAULT_TOKEN_FILE"),
+		client:    &http.Client{Timeout: 10 * time.Second},
+	}
+	if s.token == "" && s.tokenFile == "" {
+		return nil, fmt.Errorf("VAULT_TOKEN or VAULT_TOKEN_FILE is required for Vault secrets")
+	}
+	return s, nil
+}
+
+// Fetch implements Provider. KV version 2 paths include "data/", for example
+// "secret/data/openobserve"; their values are nested one level deeper.
+func (s *vaultSecret) Fetch(ctx context.Context) (string, error) {
+	token := s.token
+	if s.tokenFile != "" {
+		data, err := os.ReadFile(s.tokenFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read Vault token: %w", err)
+		}
+		token = strings.TrimSpace(string(data))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.addr+"/v1/"+s.path, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", token)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to read Vault secret %s: %w", s.path, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to read Vault secret %s: status %d", s.path, resp.StatusCode)
+	}
+
+	var body struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to decode Vault secret %s: %w", s.path, err)
+	}
+	data := body.Data
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		if _, isV2 := data["metadata"]; isV2 {
+			data = nested
+		}
+	}
+	value, ok := data[s.key].(string)
+	if !ok {
+		return "", fmt.Errorf("Vault secret %s has no string key %q", s.path, s.key)
+	}
+	return value, nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package shares signs and verifies short-lived links to a query, so that a
+// view of logs or traces can be shared with people who can then open it without
+// being able to change the query.
+package shares
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MinKeyLength is the minimum length in bytes of a signing key.
+const MinKeyLength = 32
+
+var (
+	// ErrInvalidToken is returned for tokens that are malformed or were not
+	// signed with the signing key.
+	ErrInvalidToken = errors.New("invalid share token")
+	// ErrExpired is returned for correctly signed tokens past their expiry.
+	ErrExpired = errors.New("share link has expired")
+)
+
+// View is the query a link shares: the request body of a POST query
+// endpoint of the adapter, and the time the link expires.
+type View struct {
+	Path      string
+	Query     json.RawMessage
+	ExpiresAt time.Time
+}
+
+// payload is the signed part of a token.
+type payload struct {
+	Path      string          `json:"p"`
+	Query     json.RawMessage `json:"q"`
+	ExpiresAt int64           `json:"exp"`
+}
+
+// Signer signs and verifies share tokens with an HMAC-SHA256 key.
+type Signer struct {
+	key []byte
+	now func() time.Time
+}
+
+// NewSigner returns a signer with key, which must be at least MinKeyLength
+// bytes long. Every adapter replica must use the same key.
+func NewSigner(key []byte) (*Signer, error) {
+	if len(key) < MinKeyLength {
+		return nil, fmt.Errorf("signing key must be at least %d bytes long", MinKeyLength)
+	}
+	return &Signer{key: key, now: time.Now}, nil
+}
+
+// Sign returns the token of view: the base64url-encoded view and its
+// signature, separated by a dot.
+func (s *Signer) Sign(view View) (string, error) {
+	data, err := json.Marshal(payload{Path: view.Path, Query: view.Query, ExpiresAt: view.ExpiresAt.Unix()})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode view: %w", err)
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(data)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(s.mac(encoded)), nil
+}
+
+// Verify returns the view of token. It fails with ErrInvalidToken when the
+// signature does not match and with ErrExpired when the link has expired.
+func (s *Signer) Verify(token string) (View, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return View{}, ErrInvalidToken
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, s.mac(encoded)) {
+		return View{}, ErrInvalidToken
+	}
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return View{}, ErrInvalidToken
+	}
+	var p payload
+	if err := json.Unmarshal(data, &p); err != nil {
+		return View{}, ErrInvalidToken
+	}
+	view := View{Path: p.Path, Query: p.Query, ExpiresAt: time.Unix(p.ExpiresAt, 0).UTC()}
+	if !s.now().Before(view.ExpiresAt) {
+		return View{}, ErrExpired
+	}
+	return view, nil
+}
+
+func (s *Signer) mac(encoded string) []byte {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package shares
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testKey = []byte(strings.Repeat("k", MinKeyLength))
+
+func TestNewSigner_ShortKey(t *testing.T) {
+	if _, err := NewSigner([]byte("short")); err == nil {
+		t.Fatal("expected error for a short key")
+	}
+}
+
+func TestSignVerify(t *testing.T) {
+	signer, err := NewSigner(testKey)
+	if err != nil {
+		t.Fatalf("NewSigner() error = %v", err)
+	}
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	signer.now = func() time.Time { return now }
+
+	view := View{
+		Path:      "/api/v1/logs/query",
+		Query:     json.RawMessage(`{"searchScope":{"namespace":"ns"}}`),
+		ExpiresAt: now.Add(time.Hour),
+	}
+	token, err := signer.Sign(view)
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+
+	got, err := signer.Verify(token)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if got.Path != view.Path || string(got.Query) != string(view.Query) || !got.ExpiresAt.Equal(view.ExpiresAt) {
+		t.Errorf("Verify() = %+v, want %+v", got, view)
+	}
+
+	now = now.Add(time.Hour)
+	if _, err := signer.Verify(token); !errors.Is(err, ErrExpired) {
+		t.Errorf("Verify() after expiry error = %v, want ErrExpired", err)
+	}
+}
+
+func TestVerify_Tampered(t *testing.T) {
+	signer, _ := NewSigner(testKey)
+	token, _ := signer.Sign(View{
+		Path:      "/api/v1/logs/query",
+		Query:     json.RawMessage(`{"searchScope":{"namespace":"ns"}}`),
+		ExpiresAt: time.Now().Add(time.Hour),
+	})
+	other, _ := NewSigner([]byte(strings.Repeat("o", MinKeyLength)))
+	forged, _ := other.Sign(View{
+		Path:      "/api/v1/logs/query",
+		Query:     json.RawMessage(`{"searchScope":{"namespace":"other"}}`),
+		ExpiresAt: time.Now().Add(time.Hour),
+	})
+	payload, signature, _ := strings.Cut(token, ".")
+	forgedPayload, _, _ := strings.Cut(forged, ".")
+
+	for name, tok := range map[string]string{
+		"empty":            "",
+		"no signature":     payload,
+		"other key":        forged,
+		"swapped payload":  forgedPayload + "." + signature,
+		"invalid encoding": payload + ".!!!",
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := signer.Verify(tok); !errors.Is(err, ErrInvalidToken) {
+				t.Errorf("Verify() error = %v, want ErrInvalidToken", err)
+			}
+		})
+	}
+}
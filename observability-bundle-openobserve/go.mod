@@ -0,0 +1,8 @@
+module github.com/openchoreo/community-modules/observability-bundle-openobserve
+
+go 1.25
+
+require github.com/openchoreo/community-modules/common v0.0.0
+
+// The common module is versioned with this repository.
+replace github.com/openchoreo/community-modules/common => ../common
@@ -0,0 +1,167 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Adapter is an OpenObserve adapter the bundle can run.
+type Adapter struct {
+	// Name is the name of the adapter in --enable, and, upper-cased, the
+	// prefix of the environment variables overriding the shared ones for it.
+	Name string
+	// Binary is the file name of the adapter executable in the adapter
+	// directory.
+	Binary string
+	// HealthPath is the path of the health endpoint of the adapter.
+	HealthPath string
+	// Routes are the mux patterns of the paths served by the adapter.
+	Routes []string
+}
+
+// Adapter names.
+const (
+	AdapterLogs    = "logs"
+	AdapterTracing = "tracing"
+	AdapterMetrics = "metrics"
+)
+
+// Alert rule routes. The logs and metrics adapters both serve them; the
+// bundle serves those of the metrics adapter under metricsAlertsPrefix when
+// the logs adapter is enabled too.
+const (
+	alertsPrefix        = "/api/v1alpha1/alerts/"
+	metricsAlertsPrefix = "/api/v1alpha1/metrics/alerts/"
+)
+
+// adapters are the adapters the bundle can run, in the order they are
+// started.
+var adapters = []Adapter{
+	{
+		Name:       AdapterLogs,
+		Binary:     "logs-adapter",
+		HealthPath: "/health",
+		Routes: []string{
+			"/api/v1/logs/",
+			"/api/v1/events/",
+			"/api/v1/workflows/",
+			"/api/v1/incidents/",
+			"/api/v1alpha1/support-bundle",
+			"/api/v1/openapi.json",
+			"/docs",
+		},
+	},
+	{
+		Name:       AdapterTracing,
+		Binary:     "tracing-adapter",
+		HealthPath: "/healthz",
+		Routes: []string{
+			"/api/v1alpha1/traces/",
+			"/api/v1alpha1/traces:batchGet",
+			"/api/v1alpha1/spans/",
+		},
+	},
+	{
+		Name:       AdapterMetrics,
+		Binary:     "metrics-adapter",
+		HealthPath: "/healthz",
+		Routes: []string{
+			"/api/v1/metrics/",
+			"/api/v1alpha1/metrics/",
+		},
+	},
+}
+
+// adapterNames lists the names of the adapters, for error messages.
+func adapterNames() string {
+	names := make([]string, len(adapters))
+	for i, a := range adapters {
+		names[i] = a.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// ParseAdapters parses a comma-separated list of adapter names, such as
+// "logs,tracing", into the adapters it enables, in the order they are
+// started.
+func ParseAdapters(s string) ([]Adapter, error) {
+	enabled := map[string]bool{}
+	for _, name := range strings.Split(s, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if !isAdapter(name) {
+			return nil, fmt.Errorf("unknown adapter %q: must be one of %s", name, adapterNames())
+		}
+		enabled[name] = true
+	}
+	var result []Adapter
+	for _, a := range adapters {
+		if enabled[a.Name] {
+			result = append(result, a)
+		}
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("no adapter enabled: list at least one of %s", adapterNames())
+	}
+	return result, nil
+}
+
+func isAdapter(name string) bool {
+	for _, a := range adapters {
+		if a.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// adapterEnv returns the environment of the process of adapter a listening
+// on port, from the environment of the bundle: the variables prefixed with
+// the upper-cased adapter name and an underscore, such as
+// TRACING_OPENOBSERVE_STREAM, override the shared variable they prefix. The
+// bundle authenticates requests itself, so the adapter accepts all of them.
+func adapterEnv(environ []string, a Adapter, port int) []string {
+	prefix := strings.ToUpper(a.Name) + "_"
+	vars := map[string]string{}
+	var keys []string
+	set := func(key, value string) {
+		if _, ok := vars[key]; !ok {
+			keys = append(keys, key)
+		}
+		vars[key] = value
+	}
+	for _, kv := range environ {
+		if key, value, ok := strings.Cut(kv, "="); ok && !isOverride(key) && !strings.HasPrefix(key, "AUTH_") {
+			set(key, value)
+		}
+	}
+	for _, kv := range environ {
+		if key, value, ok := strings.Cut(kv, "="); ok && strings.HasPrefix(key, prefix) && len(key) > len(prefix) {
+			set(strings.TrimPrefix(key, prefix), value)
+		}
+	}
+	set("SERVER_PORT", strconv.Itoa(port))
+	set("AUTH_MODE", "none")
+
+	env := make([]string, len(keys))
+	for i, key := range keys {
+		env[i] = key + "=" + vars[key]
+	}
+	return env
+}
+
+// isOverride reports whether key overrides a variable for one adapter.
+func isOverride(key string) bool {
+	for _, a := range adapters {
+		if strings.HasPrefix(key, strings.ToUpper(a.Name)+"_") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestAdapterEnv(t *testing.T) {
+	environ := []string{
+		"OPENOBSERVE_URL=http://openobserve:5080",
+		"OPENOBSERVE_STREAM=default",
+		"TRACING_OPENOBSERVE_STREAM=traces",
+		"LOGS_GATEWAY_LOG_STREAM=gateway",
+		"LOG_LEVEL=debug",
+		"SERVER_PORT=9098",
+		"AUTH_MODE=token",
+		"AUTH_TOKEN=secret",
+	}
+
+	logs := adapterEnv(environ, adapters[0], 19090)
+	want := []string{
+		"OPENOBSERVE_URL=http://openobserve:5080",
+		"OPENOBSERVE_STREAM=default",
+		"LOG_LEVEL=debug",
+		"SERVER_PORT=19090",
+		"GATEWAY_LOG_STREAM=gateway",
+		"AUTH_MODE=none",
+	}
+	if !slices.Equal(logs, want) {
+		t.Errorf("unexpected logs adapter environment:\n got %v\nwant %v", logs, want)
+	}
+
+	tracing := adapterEnv(environ, adapters[1], 19091)
+	if !slices.Contains(tracing, "OPENOBSERVE_STREAM=traces") || slices.Contains(tracing, "OPENOBSERVE_STREAM=default") {
+		t.Errorf("expected the tracing override of OPENOBSERVE_STREAM, got %v", tracing)
+	}
+	if slices.Contains(tracing, "GATEWAY_LOG_STREAM=gateway") || slices.Contains(tracing, "AUTH_TOKEN=secret") {
+		t.Errorf("unexpected variables in the tracing adapter environment: %v", tracing)
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/auth"
+)
+
+type Config struct {
+	ServerPort string
+	LogLevel   slog.Level
+
+	// Adapters are the adapters the bundle runs and serves.
+	Adapters []Adapter
+
+	// AdapterDir is the directory of the adapter executables.
+	AdapterDir string
+
+	// AdapterPortBase is the loopback port of the first adapter; the others
+	// listen on the ports following it.
+	AdapterPortBase int
+
+	// AdapterStartupTimeout is how long the bundle waits for an adapter to
+	// report healthy before giving up.
+	AdapterStartupTimeout time.Duration
+
+	// Auth configures the authentication of the requests served by the
+	// bundle, shared by all the adapters. All requests are accepted by
+	// default.
+	Auth auth.Config
+}
+
+// LoadConfig loads configuration from environment variables. enable, the
+// value of the --enable flag, takes precedence over BUNDLE_ENABLE.
+func LoadConfig(enable string) (*Config, error) {
+	serverPort := getEnv("SERVER_PORT", "9098")
+	if enable == "" {
+		enable = getEnv("BUNDLE_ENABLE", "logs,tracing,metrics")
+	}
+	adapterDir := getEnv("ADAPTER_DIR", ".")
+	adapterPortBase := getEnv("ADAPTER_PORT_BASE", "19090")
+	adapterStartupTimeout := getEnv("ADAPTER_STARTUP_TIMEOUT", "60s")
+	authConfig := auth.Config{
+		Mode:        getEnv("AUTH_MODE", auth.ModeNone),
+		Token:       getEnv("AUTH_TOKEN", ""),
+		JWKSURL:     getEnv("AUTH_JWKS_URL", ""),
+		Issuer:      getEnv("AUTH_JWT_ISSUER", ""),
+		Audience:    getEnv("AUTH_JWT_AUDIENCE", ""),
+		ExemptPaths: splitList(getEnv("AUTH_EXEMPT_PATHS", "/health,/healthz")),
+	}
+
+	// Parse log level
+	logLevel := slog.LevelInfo
+	if level := os.Getenv("LOG_LEVEL"); level != "" {
+		switch strings.ToUpper(level) {
+		case "DEBUG":
+			logLevel = slog.LevelDebug
+		case "INFO":
+			logLevel = slog.LevelInfo
+		case "WARN", "WARNING":
+			logLevel = slog.LevelWarn
+		case "ERROR":
+			logLevel = slog.LevelError
+		}
+	}
+
+	enabled, err := ParseAdapters(enable)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --enable or BUNDLE_ENABLE: %w", err)
+	}
+
+	portBase, err := strconv.Atoi(adapterPortBase)
+	if err != nil || portBase < 1 || portBase+len(adapters)-1 > 65535 {
+		return nil, fmt.Errorf("invalid ADAPTER_PORT_BASE: must be integer in 1..%d", 65535-len(adapters)+1)
+	}
+
+	startupTimeout, err := time.ParseDuration(adapterStartupTimeout)
+	if err != nil || startupTimeout <= 0 {
+		return nil, fmt.Errorf("invalid ADAPTER_STARTUP_TIMEOUT: must be a positive duration")
+	}
+
+	if err := authConfig.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid authentication settings (AUTH_*): %w", err)
+	}
+
+	port, err := strconv.Atoi(serverPort)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SERVER_PORT: must be integer in 1..65535: %w", err)
+	}
+	if port < 1 || port > 65535 {
+		return nil, fmt.Errorf("invalid SERVER_PORT: must be integer in 1..65535")
+	}
+	if port >= portBase && port < portBase+len(adapters) {
+		return nil, fmt.Errorf("invalid SERVER_PORT: %d is one of the adapter ports starting at ADAPTER_PORT_BASE", port)
+	}
+
+	return &Config{
+		ServerPort:            serverPort,
+		LogLevel:              logLevel,
+		Adapters:              enabled,
+		AdapterDir:            adapterDir,
+		AdapterPortBase:       portBase,
+		AdapterStartupTimeout: startupTimeout,
+		Auth:                  authConfig,
+	}, nil
+}
+
+// splitList splits a comma-separated list, dropping blank items.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,90 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/auth"
+)
+
+// setEnvVars sets multiple environment variables for the duration of the test.
+func setEnvVars(t *testing.T, vars map[string]string) {
+	t.Helper()
+	for k, v := range vars {
+		t.Setenv(k, v)
+	}
+}
+
+func TestLoadConfig_Defaults(t *testing.T) {
+	cfg, err := LoadConfig("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.ServerPort != "9098" {
+		t.Errorf("expected default ServerPort 9098, got %s", cfg.ServerPort)
+	}
+	if cfg.LogLevel != slog.LevelInfo {
+		t.Errorf("expected default LogLevel Info, got %v", cfg.LogLevel)
+	}
+	if len(cfg.Adapters) != 3 {
+		t.Errorf("expected all adapters enabled by default, got %+v", cfg.Adapters)
+	}
+	if cfg.AdapterDir != "." || cfg.AdapterPortBase != 19090 || cfg.AdapterStartupTimeout != time.Minute {
+		t.Errorf("unexpected adapter settings: %+v", cfg)
+	}
+	if cfg.Auth.Mode != auth.ModeNone || strings.Join(cfg.Auth.ExemptPaths, ",") != "/health,/healthz" {
+		t.Errorf("unexpected auth config: %+v", cfg.Auth)
+	}
+}
+
+func TestLoadConfig_Enable(t *testing.T) {
+	t.Setenv("BUNDLE_ENABLE", "metrics")
+
+	cfg, err := LoadConfig("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.Adapters) != 1 || cfg.Adapters[0].Name != AdapterMetrics {
+		t.Errorf("expected the metrics adapter from BUNDLE_ENABLE, got %+v", cfg.Adapters)
+	}
+
+	cfg, err = LoadConfig("tracing, logs")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.Adapters) != 2 || cfg.Adapters[0].Name != AdapterLogs || cfg.Adapters[1].Name != AdapterTracing {
+		t.Errorf("expected the flag to enable logs and tracing in order, got %+v", cfg.Adapters)
+	}
+}
+
+func TestLoadConfig_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		enable  string
+		env     map[string]string
+		wantErr string
+	}{
+		{"unknown adapter", "logs,events", nil, `unknown adapter "events"`},
+		{"no adapter", " , ", nil, "no adapter enabled"},
+		{"invalid port base", "", map[string]string{"ADAPTER_PORT_BASE": "65534"}, "invalid ADAPTER_PORT_BASE"},
+		{"invalid startup timeout", "", map[string]string{"ADAPTER_STARTUP_TIMEOUT": "0s"}, "invalid ADAPTER_STARTUP_TIMEOUT"},
+		{"invalid server port", "", map[string]string{"SERVER_PORT": "http"}, "invalid SERVER_PORT"},
+		{"server port among adapter ports", "", map[string]string{"SERVER_PORT": "19091"}, "adapter ports"},
+		{"token mode without token", "", map[string]string{"AUTH_MODE": auth.ModeToken}, "invalid authentication settings"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setEnvVars(t, tt.env)
+			_, err := LoadConfig(tt.enable)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/auth"
+	"github.com/openchoreo/community-modules/common/metrics"
+)
+
+// newProxy returns a reverse proxy to backend b. Requests whose path starts
+// with from are forwarded with that prefix replaced by to.
+func newProxy(b Backend, from, to string, logger *slog.Logger) http.Handler {
+	return &httputil.ReverseProxy{
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			pr.SetURL(b.URL)
+			pr.SetXForwarded()
+			pr.Out.Host = pr.In.Host
+			if from != "" {
+				pr.Out.URL.Path = to + strings.TrimPrefix(pr.Out.URL.Path, from)
+				if pr.Out.URL.RawPath != "" {
+					pr.Out.URL.RawPath = to + strings.TrimPrefix(pr.Out.URL.RawPath, from)
+				}
+			}
+		},
+		// Flush immediately, for streamed query results and followed logs.
+		FlushInterval: -1,
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			logger.Error("Failed to proxy request",
+				slog.String("adapter", b.Adapter.Name),
+				slog.String("path", r.URL.Path),
+				slog.Any("error", err),
+			)
+			writeJSONError(w, http.StatusBadGateway, "internalServerError", "the "+b.Adapter.Name+" adapter is unavailable")
+		},
+	}
+}
+
+// healthHandler reports the bundle healthy when all the adapters of
+// backends are, with the health of each.
+func healthHandler(backends []Backend) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status, adapters := "healthy", map[string]string{}
+		for _, b := range backends {
+			if err := checkHealth(r.Context(), b); err != nil {
+				status, adapters[b.Adapter.Name] = "unhealthy", err.Error()
+				continue
+			}
+			adapters[b.Adapter.Name] = "healthy"
+		}
+		code := http.StatusOK
+		if status != "healthy" {
+			code = http.StatusServiceUnavailable
+		}
+		writeJSON(w, code, map[string]any{"status": status, "adapters": adapters})
+	})
+}
+
+// metricsHandler serves the metrics of the bundle followed by those of the
+// adapters of backends. The metric names of each start with its own prefix,
+// so they do not collide.
+func metricsHandler(serverMetrics *metrics.Metrics, backends []Backend, logger *slog.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serverMetrics.ServeHTTP(w, r)
+		for _, b := range backends {
+			if err := copyAdapterMetrics(r.Context(), w, b); err != nil {
+				logger.Warn("Failed to collect adapter metrics",
+					slog.String("adapter", b.Adapter.Name), slog.Any("error", err))
+			}
+		}
+	})
+}
+
+func copyAdapterMetrics(ctx context.Context, w io.Writer, b Backend) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.URL.JoinPath("/metrics").String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		// The adapter serves no metrics.
+		return nil
+	}
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
+// withAuthentication rejects the requests authenticator does not accept with
+// 401, except those for the exempt paths. Requests are passed through
+// untouched when no authenticator is configured.
+func withAuthentication(authenticator auth.Authenticator, exempt []string, next http.Handler) http.Handler {
+	return auth.Middleware(authenticator, exempt, func(w http.ResponseWriter, r *http.Request, err error) {
+		writeJSONError(w, http.StatusUnauthorized, "unauthorized", auth.ErrUnauthenticated.Error())
+	}, next)
+}
+
+// writeJSONError writes an error in the format of the error responses of
+// the adapters.
+func writeJSONError(w http.ResponseWriter, status int, title, message string) {
+	writeJSON(w, status, map[string]string{"title": title, "message": message})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/auth"
+	"github.com/openchoreo/community-modules/common/metrics"
+)
+
+type Server struct {
+	port       string
+	httpServer *http.Server
+	logger     *slog.Logger
+}
+
+func NewServer(port string, handler http.Handler, logger *slog.Logger) *Server {
+	httpServer := &http.Server{
+		Addr:        ":" + port,
+		Handler:     handler,
+		ReadTimeout: 15 * time.Second,
+		// No write timeout: the adapters bound the time they take to
+		// answer, and extend it while streaming large results and
+		// following logs.
+		IdleTimeout: 60 * time.Second,
+	}
+
+	return &Server{
+		port:       port,
+		httpServer: httpServer,
+		logger:     logger,
+	}
+}
+
+// NewHandler returns the handler of the bundle: it proxies the requests
+// for the routes of each adapter of backends to it, behind the
+// authentication and request metrics shared by all of them.
+func NewHandler(backends []Backend, authenticator auth.Authenticator, exemptPaths []string, serverMetrics *metrics.Metrics, logger *slog.Logger) http.Handler {
+	mux := http.NewServeMux()
+	enabled := map[string]bool{}
+	for _, b := range backends {
+		enabled[b.Adapter.Name] = true
+	}
+	for _, b := range backends {
+		proxy := newProxy(b, "", "", logger)
+		for _, route := range b.Adapter.Routes {
+			mux.Handle(route, proxy)
+		}
+		switch {
+		case b.Adapter.Name == AdapterLogs:
+			mux.Handle(alertsPrefix, proxy)
+		case b.Adapter.Name == AdapterMetrics && enabled[AdapterLogs]:
+			mux.Handle(metricsAlertsPrefix, newProxy(b, metricsAlertsPrefix, alertsPrefix, logger))
+		case b.Adapter.Name == AdapterMetrics:
+			mux.Handle(alertsPrefix, proxy)
+		}
+	}
+	health := healthHandler(backends)
+	mux.Handle("GET /health", health)
+	mux.Handle("GET /healthz", health)
+	mux.Handle("GET /metrics", metricsHandler(serverMetrics, backends, logger))
+
+	return serverMetrics.Instrument(withAuthentication(authenticator, exemptPaths, serverMetrics.Route(mux)))
+}
+
+func (s *Server) Start() error {
+	s.logger.Info("Starting server", slog.String("port", s.port))
+	if err := s.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return fmt.Errorf("failed to start server: %w", err)
+	}
+	return nil
+}
+
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.logger.Info("Shutting down server")
+	return s.httpServer.Shutdown(ctx)
+}
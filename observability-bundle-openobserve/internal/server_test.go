@@ -0,0 +1,141 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/openchoreo/community-modules/common/auth"
+	"github.com/openchoreo/community-modules/common/metrics"
+)
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+// newTestBackend returns a backend for adapter a answering every request
+// with the adapter name and the path it received.
+func newTestBackend(t *testing.T, a Adapter) Backend {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/metrics" {
+			_, _ = io.WriteString(w, a.Name+"_adapter_up 1\n")
+			return
+		}
+		_, _ = io.WriteString(w, a.Name+" "+r.URL.Path)
+	}))
+	t.Cleanup(server.Close)
+	u, _ := url.Parse(server.URL)
+	return Backend{Adapter: a, URL: u}
+}
+
+func newTestHandler(t *testing.T, enable string, authenticator auth.Authenticator) http.Handler {
+	t.Helper()
+	enabled, err := ParseAdapters(enable)
+	if err != nil {
+		t.Fatalf("ParseAdapters() error = %v", err)
+	}
+	var backends []Backend
+	for _, a := range enabled {
+		backends = append(backends, newTestBackend(t, a))
+	}
+	return NewHandler(backends, authenticator, []string{"/health", "/healthz"}, metrics.New("bundle_adapter"), testLogger())
+}
+
+func get(handler http.Handler, method, path string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+	return rec
+}
+
+func TestNewHandler_Routes(t *testing.T) {
+	handler := newTestHandler(t, "logs,tracing,metrics", nil)
+	tests := []struct {
+		method string
+		path   string
+		want   string
+	}{
+		{http.MethodPost, "/api/v1/logs/query", "logs /api/v1/logs/query"},
+		{http.MethodGet, "/api/v1/workflows/volume", "logs /api/v1/workflows/volume"},
+		{http.MethodPost, "/api/v1alpha1/alerts/rules", "logs /api/v1alpha1/alerts/rules"},
+		{http.MethodPost, "/api/v1alpha1/traces/query", "tracing /api/v1alpha1/traces/query"},
+		{http.MethodPost, "/api/v1alpha1/traces:batchGet", "tracing /api/v1alpha1/traces:batchGet"},
+		{http.MethodGet, "/api/v1alpha1/spans/abc", "tracing /api/v1alpha1/spans/abc"},
+		{http.MethodPost, "/api/v1/metrics/query", "metrics /api/v1/metrics/query"},
+		{http.MethodPut, "/api/v1alpha1/metrics/alerts/rules/high-cpu", "metrics /api/v1alpha1/alerts/rules/high-cpu"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rec := get(handler, tt.method, tt.path)
+			if rec.Code != http.StatusOK || rec.Body.String() != tt.want {
+				t.Errorf("expected 200 %q, got %d %q", tt.want, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestNewHandler_PartialBundle(t *testing.T) {
+	handler := newTestHandler(t, "tracing,metrics", nil)
+
+	rec := get(handler, http.MethodPost, "/api/v1alpha1/alerts/rules")
+	if got := rec.Body.String(); got != "metrics /api/v1alpha1/alerts/rules" {
+		t.Errorf("expected the metrics adapter to serve alert rules without the logs adapter, got %q", got)
+	}
+	if rec := get(handler, http.MethodPost, "/api/v1/logs/query"); rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for the routes of a disabled adapter, got %d", rec.Code)
+	}
+}
+
+func TestNewHandler_HealthAndMetrics(t *testing.T) {
+	handler := newTestHandler(t, "logs,metrics", auth.NewStaticToken("secret"))
+
+	rec := get(handler, http.MethodGet, "/healthz")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var health struct {
+		Status   string            `json:"status"`
+		Adapters map[string]string `json:"adapters"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &health); err != nil {
+		t.Fatalf("invalid health response: %v", err)
+	}
+	if health.Status != "healthy" || health.Adapters["logs"] != "healthy" || health.Adapters["metrics"] != "healthy" {
+		t.Errorf("unexpected health: %+v", health)
+	}
+
+	if rec := get(handler, http.MethodGet, "/metrics"); rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected the shared authentication to reject /metrics, got %d", rec.Code)
+	}
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	body := rec.Body.String()
+	for _, want := range []string{`bundle_adapter_http_requests_total{handler="/healthz"`, "logs_adapter_up 1", "metrics_adapter_up 1"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected the metrics to contain %q, got:\n%s", want, body)
+		}
+	}
+}
+
+func TestNewHandler_UnavailableAdapter(t *testing.T) {
+	backend := newTestBackend(t, adapters[1])
+	backend.URL = &url.URL{Scheme: "http", Host: "127.0.0.1:1"}
+	handler := NewHandler([]Backend{backend}, nil, nil, metrics.New("bundle_adapter"), testLogger())
+
+	if rec := get(handler, http.MethodPost, "/api/v1alpha1/traces/query"); rec.Code != http.StatusBadGateway {
+		t.Errorf("expected 502, got %d", rec.Code)
+	}
+	if rec := get(handler, http.MethodGet, "/health"); rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// Backend is an adapter serving the requests the bundle proxies to it.
+type Backend struct {
+	Adapter Adapter
+	URL     *url.URL
+}
+
+// process is a started adapter process; done is closed once it has exited.
+type process struct {
+	cmd  *exec.Cmd
+	done chan struct{}
+}
+
+// Supervisor runs the processes of the enabled adapters, each on its own
+// loopback port, with the environment of the bundle.
+type Supervisor struct {
+	cfg       *Config
+	processes []*process
+	backends  []Backend
+	exited    chan error
+	logger    *slog.Logger
+}
+
+func NewSupervisor(cfg *Config, logger *slog.Logger) *Supervisor {
+	return &Supervisor{
+		cfg: cfg,
+		// Buffered so that adapters exiting after the first one do not block.
+		exited: make(chan error, len(cfg.Adapters)),
+		logger: logger,
+	}
+}
+
+// Start starts the adapters and waits until each reports healthy. The
+// adapters write their logs to those of the bundle.
+func (s *Supervisor) Start(ctx context.Context) error {
+	for i, a := range s.cfg.Adapters {
+		port := s.cfg.AdapterPortBase + i
+		cmd := exec.Command(filepath.Join(s.cfg.AdapterDir, a.Binary))
+		cmd.Env = adapterEnv(os.Environ(), a, port)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if err := cmd.Start(); err != nil {
+			return fmt.Errorf("failed to start the %s adapter: %w", a.Name, err)
+		}
+		s.logger.Info("Started adapter",
+			slog.String("adapter", a.Name), slog.Int("port", port), slog.Int("pid", cmd.Process.Pid))
+		p := &process{cmd: cmd, done: make(chan struct{})}
+		s.processes = append(s.processes, p)
+		go func() {
+			err := cmd.Wait()
+			close(p.done)
+			if err == nil {
+				err = errExited
+			}
+			s.exited <- fmt.Errorf("the %s adapter stopped: %w", a.Name, err)
+		}()
+		s.backends = append(s.backends, Backend{
+			Adapter: a,
+			URL:     &url.URL{Scheme: "http", Host: "127.0.0.1:" + strconv.Itoa(port)},
+		})
+	}
+
+	for _, b := range s.backends {
+		if err := s.waitHealthy(ctx, b); err != nil {
+			return err
+		}
+		s.logger.Info("Adapter is healthy", slog.String("adapter", b.Adapter.Name))
+	}
+	return nil
+}
+
+var errExited = errors.New("exited without error")
+
+// waitHealthy polls the health endpoint of b until it answers 200, one of
+// the adapters exits or the startup timeout passes.
+func (s *Supervisor) waitHealthy(ctx context.Context, b Backend) error {
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.AdapterStartupTimeout)
+	defer cancel()
+	ticker := time.NewTicker(250 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		if checkHealth(ctx, b) == nil {
+			return nil
+		}
+		select {
+		case err := <-s.exited:
+			return err
+		case <-ctx.Done():
+			return fmt.Errorf("the %s adapter did not become healthy within %s", b.Adapter.Name, s.cfg.AdapterStartupTimeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+// Backends returns the adapters started by Start.
+func (s *Supervisor) Backends() []Backend {
+	return s.backends
+}
+
+// Exited returns a channel receiving an error when an adapter process
+// exits. The bundle cannot serve the routes of that adapter any more, so it
+// should shut down and be restarted.
+func (s *Supervisor) Exited() <-chan error {
+	return s.exited
+}
+
+// Shutdown asks the adapters to shut down gracefully and kills those still
+// running when ctx is done.
+func (s *Supervisor) Shutdown(ctx context.Context) error {
+	for _, p := range s.processes {
+		_ = p.cmd.Process.Signal(syscall.SIGTERM)
+	}
+	for _, p := range s.processes {
+		select {
+		case <-p.done:
+		case <-ctx.Done():
+			for _, p := range s.processes {
+				_ = p.cmd.Process.Kill()
+			}
+			return fmt.Errorf("adapters did not shut down in time: %w", ctx.Err())
+		}
+	}
+	return nil
+}
+
+// checkHealth returns an error unless the health endpoint of b answers 200.
+func checkHealth(ctx context.Context, b Backend) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.URL.JoinPath(b.Adapter.HealthPath).String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := healthClient.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("health check returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+var healthClient = &http.Client{Timeout: 2 * time.Second}
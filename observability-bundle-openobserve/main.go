@@ -0,0 +1,116 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/auth"
+	"github.com/openchoreo/community-modules/common/metrics"
+	app "github.com/openchoreo/community-modules/observability-bundle-openobserve/internal"
+)
+
+func main() {
+	enable := flag.String("enable", "", "comma-separated adapters to run: logs, tracing and metrics (default BUNDLE_ENABLE, or all of them)")
+	flag.Parse()
+
+	cfg, err := app.LoadConfig(*enable)
+	if err != nil {
+		logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+			Level: slog.LevelInfo,
+		}))
+		logger.Error("Failed to load configuration", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+		Level: cfg.LogLevel,
+	}))
+
+	names := make([]string, len(cfg.Adapters))
+	for i, a := range cfg.Adapters {
+		names[i] = a.Name
+	}
+	logger.Info("Configurations loaded from environment variables successfully",
+		slog.String("Log Level", cfg.LogLevel.String()),
+		slog.Any("Adapters", names),
+		slog.String("Adapter Directory", cfg.AdapterDir),
+		slog.Int("Adapter Port Base", cfg.AdapterPortBase),
+		slog.String("Server Port", cfg.ServerPort),
+	)
+
+	authenticator, err := auth.New(cfg.Auth)
+	if err != nil {
+		logger.Error("Failed to configure authentication", slog.Any("error", err))
+		os.Exit(1)
+	}
+	if authenticator != nil {
+		logger.Info("Request authentication enabled",
+			slog.String("mode", cfg.Auth.Mode), slog.Any("exemptPaths", cfg.Auth.ExemptPaths))
+	}
+
+	// Start the adapters. Each checks its connection to OpenObserve when
+	// starting and exits when it fails, which fails the startup of the bundle.
+	supervisor := app.NewSupervisor(cfg, logger)
+	if err := supervisor.Start(context.Background()); err != nil {
+		logger.Error("Failed to start the adapters. Hence shutting down", slog.Any("error", err))
+		stopAdapters(supervisor, logger)
+		os.Exit(1)
+	}
+
+	handler := app.NewHandler(supervisor.Backends(), authenticator, cfg.Auth.ExemptPaths, metrics.New("bundle_adapter"), logger)
+	srv := app.NewServer(cfg.ServerPort, handler, logger)
+
+	go func() {
+		if err := srv.Start(); err != nil {
+			logger.Error("Server error", slog.Any("error", err))
+			stopAdapters(supervisor, logger)
+			os.Exit(1)
+		}
+	}()
+
+	// Shutdown logic: on a signal, or when an adapter stops, since its
+	// routes cannot be served any more.
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	exitCode := 0
+	select {
+	case <-quit:
+	case err := <-supervisor.Exited():
+		logger.Error("Adapter stopped unexpectedly", slog.Any("error", err))
+		exitCode = 1
+	}
+
+	logger.Info("Shutting down gracefully")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := srv.Shutdown(ctx); err != nil {
+		logger.Error("Error during shutdown", slog.Any("error", err))
+		exitCode = 1
+	}
+	if err := supervisor.Shutdown(ctx); err != nil {
+		logger.Error("Error during shutdown", slog.Any("error", err))
+		exitCode = 1
+	}
+
+	logger.Info("Server stopped")
+	os.Exit(exitCode)
+}
+
+// stopAdapters stops the adapters started so far.
+func stopAdapters(supervisor *app.Supervisor, logger *slog.Logger) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := supervisor.Shutdown(ctx); err != nil {
+		logger.Error("Error during shutdown", slog.Any("error", err))
+	}
+}
@@ -0,0 +1,261 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// datasetConfig describes the synthetic deployment to generate data for.
+type datasetConfig struct {
+	Namespace   string
+	Project     string
+	Environment string
+	// Components are called in order for every request: the first one is
+	// the entry point and calls the second, which calls the third, and so on.
+	Components []string
+	Start      time.Time
+	End        time.Time
+	// RequestsPerMinute is the average request rate of the entry point.
+	RequestsPerMinute int
+	// ErrorRate is the fraction of requests that fail in the last component.
+	ErrorRate float64
+}
+
+// dataset is the generated data: log records for the OpenObserve JSON
+// ingestion API and spans grouped by component for OTLP.
+type dataset struct {
+	Namespace      string
+	ProjectUID     string
+	EnvironmentUID string
+	Components     []component
+	Logs           []map[string]any
+	Spans          map[string][]span
+}
+
+// span is a single OTLP span of a component.
+type span struct {
+	TraceID      string
+	SpanID       string
+	ParentSpanID string
+	Name         string
+	Kind         int
+	Start        time.Time
+	End          time.Time
+	Error        string
+	Attributes   map[string]string
+}
+
+// spanKindServer is the OTLP SPAN_KIND_SERVER value.
+const spanKindServer = 2
+
+// component is a synthetic component with stable identifiers.
+type component struct {
+	Name string
+	UID  string
+	Pod  string
+}
+
+// generateDataset produces requests spread over the configured window. Every
+// request is one trace that crosses all components; each component writes
+// log lines carrying the trace and span IDs of the span it is serving, so
+// logs and traces can be correlated. The same rng seed yields the same data.
+//
+// The data contains no personal information: user IDs are opaque sequence
+// numbers and client addresses are taken from the TEST-NET-1 documentation
+// range (RFC 5737).
+func generateDataset(cfg datasetConfig, rng *rand.Rand) dataset {
+	projectUID := uidFor(rng)
+	environmentUID := uidFor(rng)
+	components := make([]component, len(cfg.Components))
+	for i, name := range cfg.Components {
+		components[i] = component{
+			Name: name,
+			UID:  uidFor(rng),
+			Pod:  fmt.Sprintf("%s-%s-%s", name, randomHex(rng, 5), randomHex(rng, 3)),
+		}
+	}
+
+	labels := func(c component) map[string]any {
+		return map[string]any{
+			"kubernetes_labels_openchoreo_dev_namespace":       cfg.Namespace,
+			"kubernetes_labels_openchoreo_dev_project":         cfg.Project,
+			"kubernetes_labels_openchoreo_dev_project_uid":     projectUID,
+			"kubernetes_labels_openchoreo_dev_environment":     cfg.Environment,
+			"kubernetes_labels_openchoreo_dev_environment_uid": environmentUID,
+			"kubernetes_labels_openchoreo_dev_component":       c.Name,
+			"kubernetes_labels_openchoreo_dev_component_uid":   c.UID,
+			"kubernetes_namespace_name":                        fmt.Sprintf("dp-%s-%s-%s", cfg.Namespace, cfg.Project, cfg.Environment),
+			"kubernetes_pod_name":                              c.Pod,
+			"kubernetes_container_name":                        "main",
+		}
+	}
+
+	data := dataset{
+		Namespace:      cfg.Namespace,
+		ProjectUID:     projectUID,
+		EnvironmentUID: environmentUID,
+		Components:     components,
+		Spans:          make(map[string][]span),
+	}
+	addLog := func(c component, t time.Time, level, traceID, spanID, msg string) {
+		record := labels(c)
+		record["_timestamp"] = t.UnixMicro()
+		record["logLevel"] = level
+		record["log"] = fmt.Sprintf("%s %s [trace_id=%s span_id=%s] %s", t.UTC().Format(time.RFC3339Nano), level, traceID, spanID, msg)
+		record["trace_id"] = traceID
+		record["span_id"] = spanID
+		data.Logs = append(data.Logs, record)
+	}
+
+	window := cfg.End.Sub(cfg.Start)
+	requests := int(window.Minutes() * float64(cfg.RequestsPerMinute))
+	for n := 0; n < requests; n++ {
+		start := cfg.Start.Add(time.Duration(rng.Int64N(int64(window))))
+		traceID := randomHex(rng, 16)
+		user := fmt.Sprintf("user-%04d", rng.IntN(500))
+		client := fmt.Sprintf("192.0.2.%d", 1+rng.IntN(254))
+		failed := rng.Float64() < cfg.ErrorRate
+
+		// Each component serves its span after a small network delay and
+		// finishes after all downstream calls return.
+		spanIDs := make([]string, len(components))
+		starts := make([]time.Time, len(components))
+		for i := range components {
+			spanIDs[i] = randomHex(rng, 8)
+			starts[i] = start
+			if i > 0 {
+				starts[i] = starts[i-1].Add(time.Duration(1+rng.IntN(5)) * time.Millisecond)
+			}
+		}
+		ends := make([]time.Time, len(components))
+		for i := len(components) - 1; i >= 0; i-- {
+			work := time.Duration(5+rng.IntN(80)) * time.Millisecond
+			ends[i] = starts[i].Add(work)
+			if i < len(components)-1 && ends[i+1].After(ends[i]) {
+				ends[i] = ends[i+1].Add(time.Duration(1+rng.IntN(3)) * time.Millisecond)
+			}
+		}
+
+		for i, c := range components {
+			s := span{
+				TraceID: traceID,
+				SpanID:  spanIDs[i],
+				Name:    "GET /api/" + c.Name,
+				Kind:    spanKindServer,
+				Start:   starts[i],
+				End:     ends[i],
+				Attributes: map[string]string{
+					"http.request.method": "GET",
+					"url.path":            "/api/" + c.Name,
+					"enduser.id":          user,
+				},
+			}
+			if i > 0 {
+				s.ParentSpanID = spanIDs[i-1]
+			}
+			last := i == len(components)-1
+			status := 200
+			if failed {
+				status = 502
+				if last {
+					status = 500
+					s.Error = "upstream dependency timed out"
+				}
+			}
+			s.Attributes["http.response.status_code"] = strconv.Itoa(status)
+			data.Spans[c.Name] = append(data.Spans[c.Name], s)
+
+			addLog(c, s.Start, "INFO", traceID, s.SpanID,
+				fmt.Sprintf("request received method=GET path=%s user=%s client=%s", s.Attributes["url.path"], user, client))
+			if i == 0 && rng.IntN(10) == 0 {
+				addLog(c, s.Start.Add(time.Millisecond), "DEBUG", traceID, s.SpanID, "cache miss, loading from upstream")
+			}
+			switch {
+			case failed && last:
+				addLog(c, s.End, "ERROR", traceID, s.SpanID, "request failed: "+s.Error)
+			case failed:
+				addLog(c, s.End, "WARN", traceID, s.SpanID, fmt.Sprintf("upstream %s returned status=502", components[i+1].Name))
+			default:
+				addLog(c, s.End, "INFO", traceID, s.SpanID,
+					fmt.Sprintf("request completed status=200 duration_ms=%d", s.End.Sub(s.Start).Milliseconds()))
+			}
+		}
+	}
+	return data
+}
+
+// otlpTraces returns an OTLP/JSON ExportTraceServiceRequest with one resource
+// per component. Resource attributes carry the openchoreo.dev labels that the
+// tracing adapter filters on.
+func otlpTraces(data dataset) map[string]any {
+	var resourceSpans []any
+	for _, c := range data.Components {
+		var otlpSpans []any
+		for _, s := range data.Spans[c.Name] {
+			attrs := make([]any, 0, len(s.Attributes))
+			for _, k := range slices.Sorted(maps.Keys(s.Attributes)) {
+				attrs = append(attrs, stringAttribute(k, s.Attributes[k]))
+			}
+			status := map[string]any{"code": 1}
+			if s.Error != "" {
+				status = map[string]any{"code": 2, "message": s.Error}
+			}
+			otlpSpan := map[string]any{
+				"traceId":           s.TraceID,
+				"spanId":            s.SpanID,
+				"name":              s.Name,
+				"kind":              s.Kind,
+				"startTimeUnixNano": strconv.FormatInt(s.Start.UnixNano(), 10),
+				"endTimeUnixNano":   strconv.FormatInt(s.End.UnixNano(), 10),
+				"attributes":        attrs,
+				"status":            status,
+			}
+			if s.ParentSpanID != "" {
+				otlpSpan["parentSpanId"] = s.ParentSpanID
+			}
+			otlpSpans = append(otlpSpans, otlpSpan)
+		}
+		resourceSpans = append(resourceSpans, map[string]any{
+			"resource": map[string]any{
+				"attributes": []any{
+					stringAttribute("service.name", c.Name),
+					stringAttribute("openchoreo.dev/namespace", data.Namespace),
+					stringAttribute("openchoreo.dev/project-uid", data.ProjectUID),
+					stringAttribute("openchoreo.dev/environment-uid", data.EnvironmentUID),
+					stringAttribute("openchoreo.dev/component-uid", c.UID),
+				},
+			},
+			"scopeSpans": []any{map[string]any{
+				"scope": map[string]any{"name": "openchoreo-seed"},
+				"spans": otlpSpans,
+			}},
+		})
+	}
+	return map[string]any{"resourceSpans": resourceSpans}
+}
+
+func stringAttribute(key, value string) map[string]any {
+	return map[string]any{"key": key, "value": map[string]any{"stringValue": value}}
+}
+
+// uidFor returns a random UUID-formatted identifier.
+func uidFor(rng *rand.Rand) string {
+	h := randomHex(rng, 16)
+	return h[0:8] + "-" + h[8:12] + "-4" + h[13:16] + "-a" + h[17:20] + "-" + h[20:32]
+}
+
+func randomHex(rng *rand.Rand, n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte(rng.IntN(256))
+	}
+	return hex.EncodeToString(b)
+}
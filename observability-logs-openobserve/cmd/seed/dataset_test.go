@@ -0,0 +1,168 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"math/rand/v2"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testDatasetConfig() datasetConfig {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	return datasetConfig{
+		Namespace:         "default",
+		Project:           "demo-shop",
+		Environment:       "development",
+		Components:        []string{"storefront", "orders", "payments"},
+		Start:             start,
+		End:               start.Add(10 * time.Minute),
+		RequestsPerMinute: 10,
+		ErrorRate:         0.2,
+	}
+}
+
+func TestGenerateDataset(t *testing.T) {
+	cfg := testDatasetConfig()
+	data := generateDataset(cfg, rand.New(rand.NewPCG(1, 1)))
+
+	t.Run("deterministic", func(t *testing.T) {
+		again := generateDataset(cfg, rand.New(rand.NewPCG(1, 1)))
+		if !reflect.DeepEqual(data, again) {
+			t.Error("expected the same seed to generate the same dataset")
+		}
+	})
+
+	t.Run("spans", func(t *testing.T) {
+		for _, c := range cfg.Components {
+			if got := len(data.Spans[c]); got != 100 {
+				t.Errorf("expected 100 spans for %s, got %d", c, got)
+			}
+		}
+		for i, s := range data.Spans["orders"] {
+			parent := data.Spans["storefront"][i]
+			if s.TraceID != parent.TraceID || s.ParentSpanID != parent.SpanID {
+				t.Fatalf("orders span %d is not a child of the storefront span", i)
+			}
+			if s.Start.Before(parent.Start) || s.End.After(parent.End) {
+				t.Fatalf("orders span %d is not nested in its parent", i)
+			}
+		}
+	})
+
+	t.Run("logs are labelled and correlated", func(t *testing.T) {
+		spanIDs := map[string]bool{}
+		for _, spans := range data.Spans {
+			for _, s := range spans {
+				spanIDs[s.TraceID+"/"+s.SpanID] = true
+			}
+		}
+		levels := map[string]int{}
+		for _, record := range data.Logs {
+			for _, key := range []string{
+				"kubernetes_labels_openchoreo_dev_namespace",
+				"kubernetes_labels_openchoreo_dev_project_uid",
+				"kubernetes_labels_openchoreo_dev_environment_uid",
+				"kubernetes_labels_openchoreo_dev_component_uid",
+				"kubernetes_pod_name",
+			} {
+				if v, _ := record[key].(string); v == "" {
+					t.Fatalf("log record without %s: %v", key, record)
+				}
+			}
+			if !spanIDs[record["trace_id"].(string)+"/"+record["span_id"].(string)] {
+				t.Fatalf("log record does not reference a generated span: %v", record)
+			}
+			ts := record["_timestamp"].(int64)
+			if ts < cfg.Start.UnixMicro() || ts > cfg.End.Add(time.Second).UnixMicro() {
+				t.Fatalf("log timestamp %d outside the window", ts)
+			}
+			levels[record["logLevel"].(string)]++
+		}
+		if levels["INFO"] == 0 || levels["WARN"] == 0 || levels["ERROR"] == 0 {
+			t.Errorf("expected a mix of levels, got %v", levels)
+		}
+	})
+
+	t.Run("no personal data", func(t *testing.T) {
+		payload, _ := json.Marshal(data.Logs)
+		if strings.Contains(string(payload), "@") {
+			t.Error("expected no e-mail addresses in logs")
+		}
+		for _, record := range data.Logs {
+			log := record["log"].(string)
+			if i := strings.Index(log, "client="); i >= 0 && !strings.HasPrefix(log[i+len("client="):], "192.0.2.") {
+				t.Fatalf("client address outside TEST-NET-1: %s", log)
+			}
+		}
+	})
+}
+
+func TestOTLPTraces(t *testing.T) {
+	data := generateDataset(testDatasetConfig(), rand.New(rand.NewPCG(1, 1)))
+	payload, err := json.Marshal(otlpTraces(data))
+	if err != nil {
+		t.Fatalf("failed to encode traces: %v", err)
+	}
+
+	var req struct {
+		ResourceSpans []struct {
+			Resource struct {
+				Attributes []struct {
+					Key   string `json:"key"`
+					Value struct {
+						StringValue string `json:"stringValue"`
+					} `json:"value"`
+				} `json:"attributes"`
+			} `json:"resource"`
+			ScopeSpans []struct {
+				Spans []json.RawMessage `json:"spans"`
+			} `json:"scopeSpans"`
+		} `json:"resourceSpans"`
+	}
+	if err := json.Unmarshal(payload, &req); err != nil {
+		t.Fatalf("failed to decode traces: %v", err)
+	}
+	if len(req.ResourceSpans) != 3 {
+		t.Fatalf("expected one resource per component, got %d", len(req.ResourceSpans))
+	}
+	attrs := map[string]string{}
+	for _, a := range req.ResourceSpans[0].Resource.Attributes {
+		attrs[a.Key] = a.Value.StringValue
+	}
+	if attrs["service.name"] != "storefront" || attrs["openchoreo.dev/component-uid"] != data.Components[0].UID {
+		t.Errorf("unexpected resource attributes %v", attrs)
+	}
+	if got := len(req.ResourceSpans[0].ScopeSpans[0].Spans); got != 100 {
+		t.Errorf("expected 100 spans, got %d", got)
+	}
+}
+
+func TestParseFlags(t *testing.T) {
+	opts, err := parseFlags([]string{"-url", "http://oo:5080", "-user", "u", "-password", "p", "-components", "a, b,", "-duration", "30m"})
+	if err != nil {
+		t.Fatalf("parseFlags() error = %v", err)
+	}
+	if !reflect.DeepEqual(opts.dataset.Components, []string{"a", "b"}) {
+		t.Errorf("unexpected components %v", opts.dataset.Components)
+	}
+	if opts.dataset.End.Sub(opts.dataset.Start) != 30*time.Minute {
+		t.Errorf("unexpected window %v - %v", opts.dataset.Start, opts.dataset.End)
+	}
+
+	for _, args := range [][]string{
+		{"-user", "u", "-password", "p"},
+		{"-url", "http://oo:5080", "-user", "u", "-password", "p", "-components", ","},
+		{"-url", "http://oo:5080", "-user", "u", "-password", "p", "-rate", "0"},
+		{"-url", "http://oo:5080", "-user", "u", "-password", "p", "-error-rate", "2"},
+	} {
+		t.Setenv("OPENOBSERVE_URL", "")
+		if _, err := parseFlags(args); err == nil {
+			t.Errorf("expected error for %v", args)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Command seed ingests a synthetic, PII-free dataset of correlated
+// multi-component logs and traces into OpenObserve, labelled the way
+// OpenChoreo labels workloads, so that demo, documentation and e2e
+// environments have meaningful data to display.
+//
+// Usage:
+//
+//	go run ./cmd/seed -url http://localhost:5080 -user admin@example.com -password ...
+//
+// The connection flags default to the OPENOBSERVE_URL, OPENOBSERVE_ORG,
+// OPENOBSERVE_STREAM, OPENOBSERVE_USER and OPENOBSERVE_PASSWORD environment
+// variables used by the adapter.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// batchSize is the number of log records sent per ingestion request.
+const batchSize = 5000
+
+type options struct {
+	url         string
+	org         string
+	logsStream  string
+	traceStream string
+	user        string
+	password    string
+	skipTraces  bool
+	seed        uint64
+	dataset     datasetConfig
+}
+
+func main() {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+
+	opts, err := parseFlags(os.Args[1:])
+	if err != nil {
+		logger.Error("Invalid arguments", slog.Any("error", err))
+		os.Exit(2)
+	}
+
+	data := generateDataset(opts.dataset, rand.New(rand.NewPCG(opts.seed, opts.seed)))
+	ingester := &ingester{
+		baseURL:    strings.TrimSuffix(opts.url, "/") + "/api/" + opts.org,
+		user:       opts.user,
+		password:   opts.password,
+		httpClient: &http.Client{Timeout: 60 * time.Second},
+	}
+
+	ctx := context.Background()
+	for start := 0; start < len(data.Logs); start += batchSize {
+		batch := data.Logs[start:min(start+batchSize, len(data.Logs))]
+		if err := ingester.post(ctx, "/"+opts.logsStream+"/_json", nil, batch); err != nil {
+			logger.Error("Failed to ingest logs", slog.Any("error", err))
+			os.Exit(1)
+		}
+	}
+	logger.Info("Ingested logs", slog.Int("records", len(data.Logs)), slog.String("stream", opts.logsStream))
+
+	if !opts.skipTraces {
+		header := http.Header{"Stream-Name": {opts.traceStream}}
+		if err := ingester.post(ctx, "/v1/traces", header, otlpTraces(data)); err != nil {
+			logger.Error("Failed to ingest traces", slog.Any("error", err))
+			os.Exit(1)
+		}
+		spans := 0
+		for _, s := range data.Spans {
+			spans += len(s)
+		}
+		logger.Info("Ingested traces", slog.Int("spans", spans), slog.String("stream", opts.traceStream))
+	}
+
+	for _, c := range data.Components {
+		logger.Info("Seeded component",
+			slog.String("component", c.Name),
+			slog.String("componentUid", c.UID),
+			slog.String("projectUid", data.ProjectUID),
+			slog.String("environmentUid", data.EnvironmentUID))
+	}
+}
+
+func parseFlags(args []string) (options, error) {
+	var opts options
+	var components string
+	var duration time.Duration
+
+	fs := flag.NewFlagSet("seed", flag.ContinueOnError)
+	fs.StringVar(&opts.url, "url", os.Getenv("OPENOBSERVE_URL"), "OpenObserve base URL")
+	fs.StringVar(&opts.org, "org", envOr("OPENOBSERVE_ORG", "default"), "OpenObserve organization")
+	fs.StringVar(&opts.logsStream, "logs-stream", envOr("OPENOBSERVE_STREAM", "default"), "logs stream to ingest into")
+	fs.StringVar(&opts.traceStream, "traces-stream", "default", "traces stream to ingest into")
+	fs.StringVar(&opts.user, "user", os.Getenv("OPENOBSERVE_USER"), "OpenObserve user")
+	fs.StringVar(&opts.password, "password", os.Getenv("OPENOBSERVE_PASSWORD"), "OpenObserve password")
+	fs.BoolVar(&opts.skipTraces, "skip-traces", false, "ingest logs only")
+	fs.Uint64Var(&opts.seed, "seed", 1, "random seed; the same seed generates the same dataset")
+	fs.StringVar(&opts.dataset.Namespace, "namespace", "default", "OpenChoreo namespace")
+	fs.StringVar(&opts.dataset.Project, "project", "demo-shop", "OpenChoreo project")
+	fs.StringVar(&opts.dataset.Environment, "environment", "development", "OpenChoreo environment")
+	fs.StringVar(&components, "components", "storefront,orders,payments", "comma-separated components, in call order")
+	fs.DurationVar(&duration, "duration", time.Hour, "window before now to spread data over")
+	fs.IntVar(&opts.dataset.RequestsPerMinute, "rate", 30, "requests per minute")
+	fs.Float64Var(&opts.dataset.ErrorRate, "error-rate", 0.05, "fraction of failing requests")
+	if err := fs.Parse(args); err != nil {
+		return options{}, err
+	}
+
+	if opts.url == "" || opts.user == "" || opts.password == "" {
+		return options{}, fmt.Errorf("-url, -user and -password are required")
+	}
+	for _, c := range strings.Split(components, ",") {
+		if c = strings.TrimSpace(c); c != "" {
+			opts.dataset.Components = append(opts.dataset.Components, c)
+		}
+	}
+	if len(opts.dataset.Components) == 0 {
+		return options{}, fmt.Errorf("at least one component is required")
+	}
+	if duration <= 0 || opts.dataset.RequestsPerMinute <= 0 {
+		return options{}, fmt.Errorf("-duration and -rate must be positive")
+	}
+	if opts.dataset.ErrorRate < 0 || opts.dataset.ErrorRate > 1 {
+		return options{}, fmt.Errorf("-error-rate must be between 0 and 1")
+	}
+	opts.dataset.End = time.Now()
+	opts.dataset.Start = opts.dataset.End.Add(-duration)
+	return opts, nil
+}
+
+func envOr(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// ingester posts JSON payloads to the OpenObserve ingestion API.
+type ingester struct {
+	baseURL    string
+	user       string
+	password   string
+	httpClient *http.Client
+}
+
+func (i *ingester) post(ctx context.Context, path string, header http.Header, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, i.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(i.user, i.password)
+
+	resp, err := i.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("OpenObserve returned status %d for %s: %s", resp.StatusCode, path, strings.TrimSpace(string(respBody)))
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Command shadow-export turns the shadow log written by the adapter with
+// SHADOW_LOG_PATH into a corpus for API compatibility tests: one entry per
+// distinct shape of request and response on each route, with how often it
+// was seen.
+//
+// Usage:
+//
+//	kubectl cp <adapter-pod>:/data/shadow.ndjson shadow.ndjson
+//	go run ./cmd/shadow-export -store shadow.ndjson -out corpus.json
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/shadow"
+)
+
+// corpus is the document written by the command.
+type corpus struct {
+	GeneratedAt time.Time            `json:"generatedAt"`
+	Records     int                  `json:"records"`
+	Entries     []shadow.CorpusEntry `json:"entries"`
+}
+
+type options struct {
+	store string
+	out   string
+	since time.Duration
+}
+
+func main() {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+
+	opts, err := parseFlags(os.Args[1:])
+	if err != nil {
+		logger.Error("Invalid arguments", slog.Any("error", err))
+		os.Exit(2)
+	}
+
+	out := io.Writer(os.Stdout)
+	if opts.out != "" {
+		f, err := os.Create(opts.out)
+		if err != nil {
+			logger.Error("Failed to create corpus file", slog.Any("error", err))
+			os.Exit(1)
+		}
+		defer f.Close()
+		out = f
+	}
+	c, err := export(opts, out, time.Now().UTC())
+	if err != nil {
+		logger.Error("Failed to export shadow log", slog.Any("error", err))
+		os.Exit(1)
+	}
+	logger.Info("Exported corpus", slog.Int("records", c.Records), slog.Int("entries", len(c.Entries)))
+}
+
+// export writes the corpus of the records of opts.store seen after
+// opts.since before now to out.
+func export(opts options, out io.Writer, now time.Time) (corpus, error) {
+	records, err := shadow.ReadRecords(opts.store)
+	if err != nil {
+		return corpus{}, err
+	}
+	if opts.since > 0 {
+		cutoff := now.Add(-opts.since)
+		kept := records[:0]
+		for _, rec := range records {
+			if !rec.Time.Before(cutoff) {
+				kept = append(kept, rec)
+			}
+		}
+		records = kept
+	}
+
+	c := corpus{GeneratedAt: now, Records: len(records), Entries: shadow.BuildCorpus(records)}
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(c); err != nil {
+		return corpus{}, fmt.Errorf("failed to write corpus: %w", err)
+	}
+	return c, nil
+}
+
+func parseFlags(args []string) (options, error) {
+	var opts options
+	fs := flag.NewFlagSet("shadow-export", flag.ContinueOnError)
+	fs.StringVar(&opts.store, "store", os.Getenv("SHADOW_LOG_PATH"), "shadow log file written by the adapter")
+	fs.StringVar(&opts.out, "out", "", "corpus file to write; standard output by default")
+	fs.DurationVar(&opts.since, "since", 0, "only export the records of this window before now; all records by default")
+	if err := fs.Parse(args); err != nil {
+		return options{}, err
+	}
+	if opts.store == "" {
+		return options{}, fmt.Errorf("-store is required")
+	}
+	if opts.since < 0 {
+		return options{}, fmt.Errorf("-since must not be negative")
+	}
+	return opts, nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/shadow"
+)
+
+func TestExport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "shadow.ndjson")
+	store, err := shadow.NewStore(path, shadow.Retention{MaxRecords: 100})
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+	now := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	for _, rec := range []shadow.Record{
+		{Time: now.Add(-48 * time.Hour), Method: "GET", Route: "/old", Status: 200},
+		{Time: now.Add(-time.Hour), Method: "GET", Route: "/a", Status: 200},
+		{Time: now.Add(-time.Minute), Method: "GET", Route: "/a", Status: 200},
+	} {
+		if err := store.Append(rec); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	var out bytes.Buffer
+	c, err := export(options{store: path, since: 24 * time.Hour}, &out, now)
+	if err != nil {
+		t.Fatalf("export() error = %v", err)
+	}
+	var written corpus
+	if err := json.Unmarshal(out.Bytes(), &written); err != nil {
+		t.Fatalf("invalid corpus: %v", err)
+	}
+	if written.Records != 2 || len(written.Entries) != 1 || written.Entries[0].Count != 2 || c.Records != 2 {
+		t.Errorf("unexpected corpus %+v", written)
+	}
+
+	if _, err := export(options{store: filepath.Join(t.TempDir(), "missing")}, &out, now); err == nil {
+		t.Error("expected error for a missing store")
+	}
+}
+
+func TestParseFlags(t *testing.T) {
+	t.Setenv("SHADOW_LOG_PATH", "")
+	if _, err := parseFlags(nil); err == nil {
+		t.Error("expected error without -store")
+	}
+	if _, err := parseFlags([]string{"-store", "s.ndjson", "-since", "-1h"}); err == nil {
+		t.Error("expected error for a negative -since")
+	}
+	opts, err := parseFlags([]string{"-store", "s.ndjson", "-since", "24h"})
+	if err != nil || opts.store != "s.ndjson" || opts.since != 24*time.Hour {
+		t.Errorf("unexpected options %+v, %v", opts, err)
+	}
+}
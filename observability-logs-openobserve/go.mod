@@ -4,8 +4,10 @@ go 1.25
 
 require (
 	github.com/getkin/kin-openapi v0.143.0
+	github.com/google/flatbuffers v25.2.10+incompatible
 	github.com/google/uuid v1.6.0
 	github.com/oapi-codegen/runtime v1.6.0
+	github.com/openchoreo/community-modules/common v0.0.0
 )
 
 require (
@@ -18,3 +20,6 @@ require (
 	github.com/santhosh-tekuri/jsonschema/v6 v6.0.2 // indirect
 	golang.org/x/text v0.32.0 // indirect
 )
+
+// The common module is versioned with this repository.
+replace github.com/openchoreo/community-modules/common => ../common
@@ -0,0 +1,205 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package alertsync
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+// Backend lists the alerts of an OpenObserve organization.
+type Backend interface {
+	ListAlertDetails(ctx context.Context) ([]openobserve.AlertDetail, error)
+}
+
+// Drift states of an alert rule.
+const (
+	// StateInSync is a rule whose alert matches the spec it was synced as.
+	StateInSync = "in_sync"
+	// StateMissing is a rule without an alert in OpenObserve.
+	StateMissing = "missing"
+	// StateModified is a rule whose alert was changed in OpenObserve.
+	StateModified = "modified"
+	// StateUnmanaged is an adapter-generated alert without a rule.
+	StateUnmanaged = "unmanaged"
+)
+
+// states lists the drift states in the order they are reported.
+var states = []string{StateInSync, StateMissing, StateModified, StateUnmanaged}
+
+// RuleDrift is a rule, or an alert without a rule, that is out of sync.
+type RuleDrift struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	State     string `json:"state"`
+	// Expected is the spec the rule was synced as, and Actual the spec of
+	// the alert in OpenObserve; each is unset when there is none.
+	Expected *openobserve.AlertSpec `json:"expected,omitempty"`
+	Actual   *openobserve.AlertSpec `json:"actual,omitempty"`
+}
+
+// Report is the outcome of a drift check.
+type Report struct {
+	CheckedAt time.Time `json:"checkedAt"`
+	// Counts holds the number of rules in each state.
+	Counts map[string]int `json:"counts"`
+	// Drift lists the rules and alerts that are not in sync.
+	Drift []RuleDrift `json:"drift"`
+	// Error is set when the check failed; the counts are then those of the
+	// last successful check.
+	Error string `json:"error,omitempty"`
+}
+
+// Checker periodically compares the rules of a store with the alerts of
+// the backends and keeps the last report. It serves the drift counts in the
+// Prometheus text exposition format.
+type Checker struct {
+	store    *FileStore
+	backends []Backend
+	interval time.Duration
+	logger   *slog.Logger
+
+	mu           sync.Mutex
+	last         *Report
+	checks       int64
+	failedChecks int64
+}
+
+// NewChecker returns a Checker comparing the rules of store with the alerts
+// of backends every interval.
+func NewChecker(store *FileStore, backends []Backend, interval time.Duration, logger *slog.Logger) *Checker {
+	return &Checker{store: store, backends: backends, interval: interval, logger: logger}
+}
+
+// Run checks for drift right away and then every interval until ctx is done.
+func (c *Checker) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		c.Check(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check compares the rules with the alerts of the backends, records the
+// report and returns it.
+func (c *Checker) Check(ctx context.Context) Report {
+	report, err := c.check(ctx)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks++
+	if err != nil {
+		c.failedChecks++
+		c.logger.Warn("Failed to check alert rules for drift", slog.Any("error", err))
+		failed := Report{CheckedAt: report.CheckedAt, Counts: map[string]int{}, Drift: []RuleDrift{}, Error: err.Error()}
+		if c.last != nil {
+			failed.Counts, failed.Drift = c.last.Counts, c.last.Drift
+		}
+		return failed
+	}
+	if drifted := len(report.Drift); drifted > 0 {
+		c.logger.Warn("Alert rules out of sync with OpenObserve",
+			slog.Int("missing", report.Counts[StateMissing]),
+			slog.Int("modified", report.Counts[StateModified]),
+			slog.Int("unmanaged", report.Counts[StateUnmanaged]))
+	}
+	c.last = &report
+	return report
+}
+
+// Last returns the report of the last successful check, or nil before the
+// first one.
+func (c *Checker) Last() *Report {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.last
+}
+
+func (c *Checker) check(ctx context.Context) (Report, error) {
+	report := Report{CheckedAt: time.Now().UTC(), Counts: map[string]int{}, Drift: []RuleDrift{}}
+	for _, state := range states {
+		report.Counts[state] = 0
+	}
+
+	alerts := map[string]openobserve.AlertDetail{}
+	for _, backend := range c.backends {
+		details, err := backend.ListAlertDetails(ctx)
+		if err != nil {
+			return report, fmt.Errorf("failed to list alerts: %w", err)
+		}
+		for _, d := range details {
+			alerts[d.Name] = d
+		}
+	}
+
+	for _, rule := range c.store.List() {
+		expected := rule.Spec
+		alert, ok := alerts[rule.Name]
+		delete(alerts, rule.Name)
+		switch {
+		case !ok:
+			report.Counts[StateMissing]++
+			report.Drift = append(report.Drift, RuleDrift{Name: rule.Name, Namespace: rule.Namespace, State: StateMissing, Expected: &expected})
+		case alert.Spec() != expected:
+			actual := alert.Spec()
+			report.Counts[StateModified]++
+			report.Drift = append(report.Drift, RuleDrift{Name: rule.Name, Namespace: rule.Namespace, State: StateModified, Expected: &expected, Actual: &actual})
+		default:
+			report.Counts[StateInSync]++
+		}
+	}
+
+	// Alerts generated by the adapter carry the namespace of their rule in
+	// their context attributes; other alerts of the organization are not
+	// the adapter's concern.
+	var unmanaged []RuleDrift
+	for name, alert := range alerts {
+		if alert.Namespace == "" {
+			continue
+		}
+		actual := alert.Spec()
+		unmanaged = append(unmanaged, RuleDrift{Name: name, Namespace: alert.Namespace, State: StateUnmanaged, Actual: &actual})
+	}
+	slices.SortFunc(unmanaged, func(a, b RuleDrift) int { return strings.Compare(a.Name, b.Name) })
+	report.Counts[StateUnmanaged] = len(unmanaged)
+	report.Drift = append(report.Drift, unmanaged...)
+	return report, nil
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format.
+func (c *Checker) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	if c.last != nil {
+		fmt.Fprint(w, "# HELP logs_adapter_alert_rules Alert rules by sync state; unmanaged counts adapter-generated alerts without a rule.\n"+
+			"# TYPE logs_adapter_alert_rules gauge\n")
+		for _, state := range states {
+			fmt.Fprintf(w, "logs_adapter_alert_rules{state=%q} %d\n", state, c.last.Counts[state])
+		}
+		fmt.Fprintf(w, "# HELP logs_adapter_alert_drift_last_check_timestamp_seconds Time of the last successful alert drift check.\n"+
+			"# TYPE logs_adapter_alert_drift_last_check_timestamp_seconds gauge\n"+
+			"logs_adapter_alert_drift_last_check_timestamp_seconds %d\n", c.last.CheckedAt.Unix())
+	}
+	fmt.Fprintf(w, "# HELP logs_adapter_alert_drift_checks_total Alert drift checks run.\n"+
+		"# TYPE logs_adapter_alert_drift_checks_total counter\n"+
+		"logs_adapter_alert_drift_checks_total %d\n", c.checks)
+	fmt.Fprintf(w, "# HELP logs_adapter_alert_drift_check_errors_total Alert drift checks that failed.\n"+
+		"# TYPE logs_adapter_alert_drift_check_errors_total counter\n"+
+		"logs_adapter_alert_drift_check_errors_total %d\n", c.failedChecks)
+}
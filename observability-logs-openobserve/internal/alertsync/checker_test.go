@@ -0,0 +1,96 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package alertsync
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+type fakeBackend struct {
+	alerts []openobserve.AlertDetail
+	err    error
+}
+
+func (b *fakeBackend) ListAlertDetails(context.Context) ([]openobserve.AlertDetail, error) {
+	return b.alerts, b.err
+}
+
+func TestChecker(t *testing.T) {
+	store, err := NewFileStore(filepath.Join(t.TempDir(), "alert-rules.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec := openobserve.AlertSpec{SQL: "SELECT 1", Operator: ">", Threshold: 5, Period: 5, Frequency: 1, Enabled: true}
+	for _, name := range []string{"in-sync", "edited", "deleted"} {
+		if err := store.Put(Rule{Name: name, Namespace: "ns", Spec: spec}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	backend := &fakeBackend{alerts: []openobserve.AlertDetail{
+		{Name: "in-sync", SQL: "SELECT 1", Operator: ">", Threshold: 5, Period: 5, Frequency: 1, Enabled: true},
+		{Name: "edited", SQL: "SELECT 1", Operator: ">", Threshold: 50, Period: 5, Frequency: 1, Enabled: true},
+		{Name: "orphan", Namespace: "ns", SQL: "SELECT 2"},
+		{Name: "handmade", SQL: "SELECT 3"},
+	}}
+	checker := NewChecker(store, []Backend{backend}, 0, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if checker.Last() != nil {
+		t.Fatal("expected no report before the first check")
+	}
+
+	report := checker.Check(context.Background())
+	if report.Error != "" {
+		t.Fatalf("unexpected error: %s", report.Error)
+	}
+	want := map[string]int{StateInSync: 1, StateMissing: 1, StateModified: 1, StateUnmanaged: 1}
+	for state, n := range want {
+		if report.Counts[state] != n {
+			t.Errorf("expected %d %s rules, got %d", n, state, report.Counts[state])
+		}
+	}
+	if len(report.Drift) != 3 {
+		t.Fatalf("expected 3 drifted rules, got %+v", report.Drift)
+	}
+	if d := report.Drift[1]; d.Name != "edited" || d.State != StateModified || d.Actual.Threshold != 50 || d.Expected.Threshold != 5 {
+		t.Errorf("unexpected modified rule: %+v", d)
+	}
+	if d := report.Drift[2]; d.Name != "orphan" || d.State != StateUnmanaged || d.Expected != nil {
+		t.Errorf("unexpected unmanaged alert: %+v", d)
+	}
+
+	backend.err = errors.New("unreachable")
+	failed := checker.Check(context.Background())
+	if failed.Error == "" || failed.Counts[StateModified] != 1 {
+		t.Errorf("expected the failed check to keep the last counts, got %+v", failed)
+	}
+	if last := checker.Last(); last == nil || last.Error != "" {
+		t.Errorf("expected the last successful report to be kept, got %+v", last)
+	}
+
+	rec := httptest.NewRecorder()
+	checker.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body := rec.Body.String()
+	for _, line := range []string{
+		`logs_adapter_alert_rules{state="in_sync"} 1`,
+		`logs_adapter_alert_rules{state="missing"} 1`,
+		`logs_adapter_alert_rules{state="modified"} 1`,
+		`logs_adapter_alert_rules{state="unmanaged"} 1`,
+		"logs_adapter_alert_drift_checks_total 2",
+		"logs_adapter_alert_drift_check_errors_total 1",
+		"logs_adapter_alert_drift_last_check_timestamp_seconds ",
+	} {
+		if !strings.Contains(body, line) {
+			t.Errorf("expected metrics to contain %q, got:\n%s", line, body)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package alertsync detects drift between the alert rules synced through the
+// adapter and the alerts present in OpenObserve: rules whose alert was
+// deleted or edited in OpenObserve, and adapter-generated alerts no rule
+// accounts for. The rules are registered in a JSON file on local disk so
+// that the register survives restarts of the adapter.
+package alertsync
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+// Rule is an alert rule synced to OpenObserve through the adapter.
+type Rule struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	// Spec is the alert the rule was synced as.
+	Spec     openobserve.AlertSpec `json:"spec"`
+	SyncedAt time.Time             `json:"syncedAt"`
+}
+
+// FileStore is a Rule register persisted to a JSON file. Every change
+// rewrites the file through a temporary file and a rename so that a crash
+// never leaves a truncated register behind.
+type FileStore struct {
+	path string
+
+	mu    sync.Mutex
+	rules []Rule
+}
+
+// NewFileStore opens the register at path, creating it on first write.
+func NewFileStore(path string) (*FileStore, error) {
+	s := &FileStore{path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read alert rule store: %w", err)
+	}
+	if err := json.Unmarshal(data, &s.rules); err != nil {
+		return nil, fmt.Errorf("failed to parse alert rule store %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Put registers rule, replacing the rule with the same name.
+func (s *FileStore) Put(rule Rule) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rules := slices.Clone(s.rules)
+	if i := s.indexLocked(rule.Name); i >= 0 {
+		rules[i] = rule
+	} else {
+		rules = append(rules, rule)
+		slices.SortFunc(rules, func(a, b Rule) int { return strings.Compare(a.Name, b.Name) })
+	}
+	return s.saveLocked(rules)
+}
+
+// Delete removes the rule with the given name. Removing a rule that is not
+// registered is not an error.
+func (s *FileStore) Delete(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.indexLocked(name)
+	if i < 0 {
+		return nil
+	}
+	return s.saveLocked(slices.Delete(slices.Clone(s.rules), i, i+1))
+}
+
+// SetEnabled records that the rule with the given name was enabled or
+// disabled in OpenObserve, so that drift checks expect its new state.
+// Rules that are not registered are ignored.
+func (s *FileStore) SetEnabled(name string, enabled bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.indexLocked(name)
+	if i < 0 || s.rules[i].Spec.Enabled == enabled {
+		return nil
+	}
+	rules := slices.Clone(s.rules)
+	rules[i].Spec.Enabled = enabled
+	return s.saveLocked(rules)
+}
+
+// List returns all rules ordered by name.
+func (s *FileStore) List() []Rule {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.rules)
+}
+
+func (s *FileStore) indexLocked(name string) int {
+	return slices.IndexFunc(s.rules, func(r Rule) bool { return r.Name == name })
+}
+
+// saveLocked writes rules to disk and, on success, makes them the current state.
+func (s *FileStore) saveLocked(rules []Rule) error {
+	data, err := json.MarshalIndent(rules, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode alert rule store: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to write alert rule store: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write alert rule store: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write alert rule store: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write alert rule store: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to write alert rule store: %w", err)
+	}
+	s.rules = rules
+	return nil
+}
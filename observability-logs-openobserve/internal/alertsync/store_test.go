@@ -0,0 +1,72 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package alertsync
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "alert-rules.json")
+	s, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+	if got := s.List(); len(got) != 0 {
+		t.Fatalf("expected an empty store, got %+v", got)
+	}
+
+	for _, r := range []Rule{
+		{Name: "b", Namespace: "ns", Spec: openobserve.AlertSpec{SQL: "b", Threshold: 1}},
+		{Name: "a", Namespace: "ns", Spec: openobserve.AlertSpec{SQL: "a"}},
+		{Name: "b", Namespace: "ns", Spec: openobserve.AlertSpec{SQL: "b", Threshold: 2}},
+	} {
+		if err := s.Put(r); err != nil {
+			t.Fatalf("Put() error = %v", err)
+		}
+	}
+
+	reopened, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+	got := reopened.List()
+	if len(got) != 2 || got[0].Name != "a" || got[1].Name != "b" || got[1].Spec.Threshold != 2 {
+		t.Errorf("unexpected rules: %+v", got)
+	}
+
+	if err := reopened.Delete("a"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := reopened.Delete("a"); err != nil {
+		t.Errorf("expected deleting an unknown rule to succeed, got %v", err)
+	}
+	if got := reopened.List(); len(got) != 1 || got[0].Name != "b" {
+		t.Errorf("unexpected rules after delete: %+v", got)
+	}
+
+	if err := reopened.SetEnabled("b", true); err != nil {
+		t.Fatalf("SetEnabled() error = %v", err)
+	}
+	if err := reopened.SetEnabled("a", true); err != nil {
+		t.Errorf("expected enabling an unknown rule to succeed, got %v", err)
+	}
+	if got := reopened.List(); len(got) != 1 || !got[0].Spec.Enabled {
+		t.Errorf("expected rule b to be enabled, got %+v", got)
+	}
+}
+
+func TestNewFileStore_Corrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "alert-rules.json")
+	if err := os.WriteFile(path, []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewFileStore(path); err == nil {
+		t.Error("expected an error for a corrupt store")
+	}
+}
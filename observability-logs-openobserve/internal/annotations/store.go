@@ -0,0 +1,226 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package annotations keeps the notes users attach to log entries and time
+// ranges while investigating incidents. Annotations are kept in a JSON file
+// on local disk so that they survive restarts of the adapter.
+package annotations
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	// MaxNoteLength bounds the length of the note of an annotation, in bytes.
+	MaxNoteLength = 2000
+	// MaxTags bounds the tags of an annotation.
+	MaxTags = 10
+	// MaxTagLength bounds the length of a tag, in bytes.
+	MaxTagLength = 64
+)
+
+// ErrNotFound is returned when no annotation has the requested ID.
+var ErrNotFound = errors.New("annotation not found")
+
+// Entry identifies the log entry an annotation is attached to.
+type Entry struct {
+	Timestamp time.Time `json:"timestamp"`
+	PodName   string    `json:"podName,omitempty"`
+}
+
+// Annotation is a note attached to a time range, or to a single log entry,
+// of the logs of a namespace.
+type Annotation struct {
+	ID             string `json:"id"`
+	Namespace      string `json:"namespace"`
+	ProjectUID     string `json:"projectUid,omitempty"`
+	EnvironmentUID string `json:"environmentUid,omitempty"`
+	ComponentUID   string `json:"componentUid,omitempty"`
+	// StartTime and EndTime are both the timestamp of Entry when the
+	// annotation is attached to a log entry.
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+	Entry     *Entry    `json:"entry,omitempty"`
+	Author    string    `json:"author"`
+	Note      string    `json:"note"`
+	Tags      []string  `json:"tags,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Validate checks the note, tags and time range of an annotation.
+func (a Annotation) Validate() error {
+	if a.Note == "" {
+		return errors.New("note is required")
+	}
+	if len(a.Note) > MaxNoteLength {
+		return fmt.Errorf("note must be at most %d bytes", MaxNoteLength)
+	}
+	if len(a.Tags) > MaxTags {
+		return fmt.Errorf("at most %d tags are allowed", MaxTags)
+	}
+	for _, tag := range a.Tags {
+		if tag == "" || len(tag) > MaxTagLength {
+			return fmt.Errorf("tags must be 1 to %d bytes", MaxTagLength)
+		}
+	}
+	if a.StartTime.IsZero() || a.EndTime.IsZero() {
+		return errors.New("startTime and endTime are required")
+	}
+	if a.EndTime.Before(a.StartTime) {
+		return errors.New("endTime must not be before startTime")
+	}
+	return nil
+}
+
+// Filter selects the annotations of a namespace that overlap a time range.
+// Annotations scoped to a project, environment or component are selected
+// unless the filter is scoped to a different one.
+type Filter struct {
+	Namespace      string
+	ProjectUID     string
+	EnvironmentUID string
+	ComponentUID   string
+	StartTime      time.Time
+	EndTime        time.Time
+}
+
+func (f Filter) matches(a Annotation) bool {
+	if a.Namespace != f.Namespace {
+		return false
+	}
+	if a.StartTime.After(f.EndTime) || a.EndTime.Before(f.StartTime) {
+		return false
+	}
+	return scopeMatches(a.ProjectUID, f.ProjectUID) &&
+		scopeMatches(a.EnvironmentUID, f.EnvironmentUID) &&
+		scopeMatches(a.ComponentUID, f.ComponentUID)
+}
+
+func scopeMatches(annotation, filter string) bool {
+	return annotation == "" || filter == "" || annotation == filter
+}
+
+// FileStore is an Annotation store persisted to a JSON file. Every change
+// rewrites the file through a temporary file and a rename so that a crash
+// never leaves a truncated store behind.
+type FileStore struct {
+	path string
+
+	mu          sync.Mutex
+	annotations []Annotation
+	// revision changes with every change of annotations. It starts from the
+	// time the store was opened, so that it never repeats across restarts.
+	revision int64
+}
+
+// NewFileStore opens the store at path, creating it on first write.
+func NewFileStore(path string) (*FileStore, error) {
+	s := &FileStore{path: path, revision: time.Now().UnixNano()}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read annotation store: %w", err)
+	}
+	if err := json.Unmarshal(data, &s.annotations); err != nil {
+		return nil, fmt.Errorf("failed to parse annotation store %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Create adds a new annotation. The ID must be unique.
+func (s *FileStore) Create(annotation Annotation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.indexLocked(annotation.ID) >= 0 {
+		return fmt.Errorf("annotation %s already exists", annotation.ID)
+	}
+	return s.saveLocked(append(slices.Clone(s.annotations), annotation))
+}
+
+// Delete removes the annotation with the given ID.
+func (s *FileStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.indexLocked(id)
+	if i < 0 {
+		return ErrNotFound
+	}
+	return s.saveLocked(slices.Delete(slices.Clone(s.annotations), i, i+1))
+}
+
+// Get returns the annotation with the given ID.
+func (s *FileStore) Get(id string) (Annotation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.indexLocked(id)
+	if i < 0 {
+		return Annotation{}, ErrNotFound
+	}
+	return s.annotations[i], nil
+}
+
+// List returns the annotations selected by filter, ordered by start time.
+func (s *FileStore) List(filter Filter) []Annotation {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var selected []Annotation
+	for _, a := range s.annotations {
+		if filter.matches(a) {
+			selected = append(selected, a)
+		}
+	}
+	slices.SortStableFunc(selected, func(a, b Annotation) int { return a.StartTime.Compare(b.StartTime) })
+	return selected
+}
+
+func (s *FileStore) indexLocked(id string) int {
+	return slices.IndexFunc(s.annotations, func(a Annotation) bool { return a.ID == id })
+}
+
+// saveLocked writes annotations to disk and, on success, makes them the
+// current state.
+func (s *FileStore) saveLocked(annotations []Annotation) error {
+	data, err := json.MarshalIndent(annotations, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode annotation store: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to write annotation store: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write annotation store: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write annotation store: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write annotation store: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to write annotation store: %w", err)
+	}
+	s.annotations = annotations
+	s.revision++
+	return nil
+}
+
+// Revision returns a value that changes whenever an annotation is created
+// or deleted.
+func (s *FileStore) Revision() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.revision
+}
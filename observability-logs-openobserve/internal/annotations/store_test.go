@@ -0,0 +1,105 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package annotations
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "annotations.json")
+	s, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	for _, a := range []Annotation{
+		{ID: "a2", Namespace: "ns", ComponentUID: "c1", StartTime: base.Add(30 * time.Minute), EndTime: base.Add(30 * time.Minute), Note: "entry"},
+		{ID: "a1", Namespace: "ns", StartTime: base, EndTime: base.Add(time.Hour), Note: "range"},
+		{ID: "a3", Namespace: "ns", ComponentUID: "c2", StartTime: base, EndTime: base.Add(time.Hour), Note: "other component"},
+		{ID: "a4", Namespace: "other", StartTime: base, EndTime: base.Add(time.Hour), Note: "other namespace"},
+	} {
+		if err := s.Create(a); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+	if err := s.Create(Annotation{ID: "a1"}); err == nil {
+		t.Error("expected error for duplicate annotation")
+	}
+
+	reopened, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+	got := reopened.List(Filter{Namespace: "ns", ComponentUID: "c1", StartTime: base.Add(10 * time.Minute), EndTime: base.Add(40 * time.Minute)})
+	if len(got) != 2 || got[0].ID != "a1" || got[1].ID != "a2" {
+		t.Errorf("unexpected overlapping annotations: %+v", got)
+	}
+	if got := reopened.List(Filter{Namespace: "ns", StartTime: base, EndTime: base.Add(time.Hour)}); len(got) != 3 {
+		t.Errorf("expected every annotation of the namespace, got %+v", got)
+	}
+	if got := reopened.List(Filter{Namespace: "ns", StartTime: base.Add(2 * time.Hour), EndTime: base.Add(3 * time.Hour)}); len(got) != 0 {
+		t.Errorf("expected no annotation outside the range, got %+v", got)
+	}
+
+	if err := reopened.Delete("a1"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := reopened.Delete("a1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if _, err := reopened.Get("a1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if a, err := reopened.Get("a2"); err != nil || a.Note != "entry" {
+		t.Errorf("Get() = %+v, %v", a, err)
+	}
+
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("expected only the store file, found %d entries", len(entries))
+	}
+}
+
+func TestAnnotationValidate(t *testing.T) {
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	valid := Annotation{Note: "n", Tags: []string{"db"}, StartTime: base, EndTime: base}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	tests := map[string]func(a *Annotation){
+		"missing note":  func(a *Annotation) { a.Note = "" },
+		"long note":     func(a *Annotation) { a.Note = strings.Repeat("x", MaxNoteLength+1) },
+		"too many tags": func(a *Annotation) { a.Tags = make([]string, MaxTags+1) },
+		"empty tag":     func(a *Annotation) { a.Tags = []string{""} },
+		"missing range": func(a *Annotation) { a.StartTime = time.Time{} },
+		"reversed":      func(a *Annotation) { a.EndTime = base.Add(-time.Second) },
+	}
+	for name, mutate := range tests {
+		t.Run(name, func(t *testing.T) {
+			a := valid
+			mutate(&a)
+			if err := a.Validate(); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestNewFileStore_Corrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "annotations.json")
+	if err := os.WriteFile(path, []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewFileStore(path); err == nil {
+		t.Error("expected error for corrupt store")
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/arrowipc"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+const arrowFormatKey contextKey = "arrowFormat"
+
+// withArrowNegotiation marks logs query requests that prefer the Arrow IPC
+// stream format so the handler can return columnar results instead of JSON.
+// Requests that do not list application/vnd.apache.arrow.stream in their
+// Accept header, list it with q=0 or give JSON a higher quality keep the
+// JSON response.
+func withArrowNegotiation(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == "/api/v1/logs/query" && acceptsArrow(r.Header.Values("Accept")) {
+			r = r.WithContext(context.WithValue(r.Context(), arrowFormatKey, true))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// acceptsArrow reports whether the Accept header values list the Arrow IPC
+// stream media type with a non-zero quality at least that of JSON. JSON
+// takes the quality of the most specific range matching application/json;
+// wildcards never select Arrow.
+func acceptsArrow(values []string) bool {
+	arrowQ := 0.0
+	jsonQ, jsonSpecificity := 0.0, -1
+	for _, value := range values {
+		for _, mediaRange := range strings.Split(value, ",") {
+			mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+			if err != nil {
+				continue
+			}
+			q := 1.0
+			if v, err := strconv.ParseFloat(params["q"], 64); err == nil {
+				q = v
+			}
+			specificity := -1
+			switch mediaType {
+			case arrowipc.MediaType:
+				arrowQ = max(arrowQ, q)
+			case "application/json":
+				specificity = 2
+			case "application/*":
+				specificity = 1
+			case "*/*":
+				specificity = 0
+			}
+			if specificity > jsonSpecificity {
+				jsonQ, jsonSpecificity = q, specificity
+			}
+		}
+	}
+	return arrowQ > 0 && arrowQ >= jsonQ
+}
+
+// arrowFormatFromContext reports whether withArrowNegotiation selected the Arrow format.
+func arrowFormatFromContext(ctx context.Context) bool {
+	arrow, _ := ctx.Value(arrowFormatKey).(bool)
+	return arrow
+}
+
+var componentLogsArrowFields = []arrowipc.Field{
+	{Name: "timestamp", Type: arrowipc.Timestamp},
+	{Name: "eventTime", Type: arrowipc.Timestamp},
+	{Name: "ingestTime", Type: arrowipc.Timestamp},
+	{Name: "log", Type: arrowipc.String},
+	{Name: "level", Type: arrowipc.String},
+	{Name: "componentUid", Type: arrowipc.String},
+	{Name: "componentName", Type: arrowipc.String},
+	{Name: "environmentUid", Type: arrowipc.String},
+	{Name: "environmentName", Type: arrowipc.String},
+	{Name: "projectUid", Type: arrowipc.String},
+	{Name: "projectName", Type: arrowipc.String},
+	{Name: "namespaceName", Type: arrowipc.String},
+	{Name: "podName", Type: arrowipc.String},
+	{Name: "podNamespace", Type: arrowipc.String},
+	{Name: "containerName", Type: arrowipc.String},
+}
+
+var workflowLogsArrowFields = []arrowipc.Field{
+	{Name: "timestamp", Type: arrowipc.Timestamp},
+	{Name: "eventTime", Type: arrowipc.Timestamp},
+	{Name: "ingestTime", Type: arrowipc.Timestamp},
+	{Name: "log", Type: arrowipc.String},
+}
+
+// componentLogsArrowResponse writes component log query results as an Arrow
+// IPC stream with one record batch. The total count and query time are
+// carried in the schema metadata as "total" and "tookMs".
+type componentLogsArrowResponse struct {
+	result *openobserve.ComponentLogsResult
+}
+
+func (response componentLogsArrowResponse) VisitQueryLogsResponse(w http.ResponseWriter) error {
+	logs := response.result.Logs
+	n := len(logs)
+	timestamps, eventTimes, ingestTimes := make([]time.Time, n), make([]time.Time, n), make([]time.Time, n)
+	columns := make([][]string, len(componentLogsArrowFields)-3)
+	for i := range columns {
+		columns[i] = make([]string, n)
+	}
+	for i, l := range logs {
+		timestamps[i], eventTimes[i], ingestTimes[i] = l.Timestamp, l.EventTime, l.IngestTime
+		for j, v := range []string{
+			l.Log, l.LogLevel, l.ComponentUID, l.ComponentName, l.EnvironmentUID, l.EnvironmentName,
+			l.ProjectUID, l.ProjectName, l.Namespace, l.PodName, l.PodNamespace, l.ContainerName,
+		} {
+			columns[j][i] = v
+		}
+	}
+
+	values := []any{timestamps, eventTimes, ingestTimes}
+	for _, c := range columns {
+		values = append(values, c)
+	}
+	return writeArrowStream(w, componentLogsArrowFields, response.result.TotalCount, response.result.Took, n, values)
+}
+
+// workflowLogsArrowResponse writes workflow log query results as an Arrow IPC
+// stream, in the same layout as componentLogsArrowResponse.
+type workflowLogsArrowResponse struct {
+	result *openobserve.WorkflowLogsResult
+}
+
+func (response workflowLogsArrowResponse) VisitQueryLogsResponse(w http.ResponseWriter) error {
+	logs := response.result.Logs
+	n := len(logs)
+	timestamps, eventTimes, ingestTimes := make([]time.Time, n), make([]time.Time, n), make([]time.Time, n)
+	lines := make([]string, n)
+	for i, l := range logs {
+		timestamps[i], eventTimes[i], ingestTimes[i], lines[i] = l.Timestamp, l.EventTime, l.IngestTime, l.Log
+	}
+	return writeArrowStream(w, workflowLogsArrowFields, response.result.TotalCount, response.result.Took, n,
+		[]any{timestamps, eventTimes, ingestTimes, lines})
+}
+
+func writeArrowStream(w http.ResponseWriter, fields []arrowipc.Field, total, took, length int, columns []any) error {
+	w.Header().Set("Content-Type", arrowipc.MediaType)
+	w.WriteHeader(http.StatusOK)
+
+	aw := arrowipc.NewWriter(w, fields, map[string]string{
+		"total":  strconv.Itoa(total),
+		"tookMs": strconv.Itoa(took),
+	})
+	if length > 0 {
+		if err := aw.WriteBatch(length, columns...); err != nil {
+			return err
+		}
+	}
+	return aw.Close()
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/arrowipc"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+func TestAcceptsArrow(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []string
+		want   bool
+	}{
+		{"no accept header", nil, false},
+		{"json only", []string{"application/json"}, false},
+		{"arrow", []string{"application/vnd.apache.arrow.stream"}, true},
+		{"arrow among others", []string{"application/json;q=0.5, application/vnd.apache.arrow.stream"}, true},
+		{"arrow refused", []string{"application/vnd.apache.arrow.stream;q=0"}, false},
+		{"wildcard", []string{"*/*"}, false},
+		{"json preferred", []string{"application/vnd.apache.arrow.stream;q=0.5, application/json"}, false},
+		{"json preferred across headers", []string{"application/vnd.apache.arrow.stream;q=0.8", "application/json;q=0.9"}, false},
+		{"wildcard preferred", []string{"application/vnd.apache.arrow.stream;q=0.5, */*"}, false},
+		{"arrow preferred to wildcard", []string{"*/*;q=0.1, application/vnd.apache.arrow.stream"}, true},
+		{"json refused", []string{"application/json;q=0, */*, application/vnd.apache.arrow.stream;q=0.2"}, true},
+		{"equal quality", []string{"application/json, application/vnd.apache.arrow.stream"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := acceptsArrow(tt.values); got != tt.want {
+				t.Errorf("acceptsArrow(%q) = %v, want %v", tt.values, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryLogs_ArrowFormat(t *testing.T) {
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := openobserve.OpenObserveResponse{
+			Hits: []map[string]interface{}{
+				{
+					"_timestamp": float64(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC).UnixMicro()),
+					"log":        "hello",
+				},
+			},
+			Total: 1,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	handler := NewLogsHandler(client, nil, testLogger())
+
+	scope := gen.LogsQueryRequest_SearchScope{}
+	_ = scope.FromComponentSearchScope(gen.ComponentSearchScope{Namespace: "test-ns"})
+	body := &gen.LogsQueryRequest{
+		StartTime:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndTime:     time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+		SearchScope: scope,
+	}
+
+	ctx := context.WithValue(context.Background(), arrowFormatKey, true)
+	resp, err := handler.QueryLogs(ctx, gen.QueryLogsRequestObject{Body: body})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	arrowResp, ok := resp.(componentLogsArrowResponse)
+	if !ok {
+		t.Fatalf("expected componentLogsArrowResponse, got %T", resp)
+	}
+
+	rec := httptest.NewRecorder()
+	if err := arrowResp.VisitQueryLogsResponse(rec); err != nil {
+		t.Fatalf("VisitQueryLogsResponse() error = %v", err)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != arrowipc.MediaType {
+		t.Errorf("expected Content-Type %q, got %q", arrowipc.MediaType, ct)
+	}
+	stream := rec.Body.Bytes()
+	if !bytes.HasPrefix(stream, []byte{0xFF, 0xFF, 0xFF, 0xFF}) {
+		t.Error("expected stream to start with a continuation marker")
+	}
+	if !bytes.HasSuffix(stream, []byte{0xFF, 0xFF, 0xFF, 0xFF, 0, 0, 0, 0}) {
+		t.Error("expected stream to end with the end-of-stream marker")
+	}
+	if !bytes.Contains(stream, []byte("hello")) {
+		t.Error("expected log line in record batch body")
+	}
+}
@@ -0,0 +1,266 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package arrowipc writes Apache Arrow IPC streams
+// (application/vnd.apache.arrow.stream) for flat record batches of UTF-8 and
+// timestamp columns. It implements only what the adapter needs to return log
+// query results in columnar form. The IPC metadata is encoded with the
+// FlatBuffers runtime following Schema.fbs and Message.fbs.
+package arrowipc
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+)
+
+// MediaType is the IANA media type of the Arrow IPC streaming format.
+const MediaType = "application/vnd.apache.arrow.stream"
+
+// Type is the Arrow data type of a column.
+type Type int
+
+const (
+	// String is a UTF-8 string column. Values are given as []string.
+	String Type = iota
+	// Timestamp is a nanosecond timestamp column in UTC. Values are given as
+	// []time.Time; zero times are written as nulls.
+	Timestamp
+)
+
+// Field describes a column of the stream schema.
+type Field struct {
+	Name string
+	Type Type
+}
+
+// Arrow IPC metadata constants from Schema.fbs and Message.fbs.
+const (
+	metadataVersionV5 = 4
+
+	messageHeaderSchema      = 1
+	messageHeaderRecordBatch = 3
+
+	typeUtf8      = 5
+	typeTimestamp = 10
+
+	timeUnitNanosecond = 3
+)
+
+const continuationMarker = 0xFFFFFFFF
+
+// Writer writes an Arrow IPC stream. The schema message is written before the
+// first record batch; Close writes the end-of-stream marker.
+type Writer struct {
+	w           io.Writer
+	fields      []Field
+	metadata    map[string]string
+	wroteSchema bool
+}
+
+// NewWriter returns a Writer for a stream with the given fields. metadata is
+// attached to the schema as custom key/value metadata and may be nil.
+func NewWriter(w io.Writer, fields []Field, metadata map[string]string) *Writer {
+	return &Writer{w: w, fields: fields, metadata: metadata}
+}
+
+// WriteBatch writes a record batch of length rows. columns holds one value
+// slice per field, in field order, each with exactly length elements.
+func (w *Writer) WriteBatch(length int, columns ...any) error {
+	if len(columns) != len(w.fields) {
+		return fmt.Errorf("expected %d columns, got %d", len(w.fields), len(columns))
+	}
+
+	var body []byte
+	var nodes, buffers []arrowStruct
+	addBuffer := func(data []byte) {
+		buffers = append(buffers, arrowStruct{int64(len(body)), int64(len(data))})
+		body = append(body, data...)
+		body = append(body, make([]byte, alignTo(len(data), 8)-len(data))...)
+	}
+
+	for i, field := range w.fields {
+		nullCount := 0
+		switch field.Type {
+		case String:
+			values, ok := columns[i].([]string)
+			if !ok || len(values) != length {
+				return fmt.Errorf("column %q: expected %d strings", field.Name, length)
+			}
+			offsets := make([]byte, 0, 4*(length+1))
+			var data []byte
+			offsets = binary.LittleEndian.AppendUint32(offsets, 0)
+			for _, v := range values {
+				data = append(data, v...)
+				offsets = binary.LittleEndian.AppendUint32(offsets, uint32(len(data)))
+			}
+			addBuffer(nil)
+			addBuffer(offsets)
+			addBuffer(data)
+		case Timestamp:
+			values, ok := columns[i].([]time.Time)
+			if !ok || len(values) != length {
+				return fmt.Errorf("column %q: expected %d timestamps", field.Name, length)
+			}
+			validity := make([]byte, (length+7)/8)
+			data := make([]byte, 0, 8*length)
+			for j, v := range values {
+				if v.IsZero() {
+					nullCount++
+					data = binary.LittleEndian.AppendUint64(data, 0)
+					continue
+				}
+				validity[j/8] |= 1 << (j % 8)
+				data = binary.LittleEndian.AppendUint64(data, uint64(v.UnixNano()))
+			}
+			if nullCount == 0 {
+				validity = nil
+			}
+			addBuffer(validity)
+			addBuffer(data)
+		}
+		nodes = append(nodes, arrowStruct{int64(length), int64(nullCount)})
+	}
+
+	if err := w.writeSchema(); err != nil {
+		return err
+	}
+	b := flatbuffers.NewBuilder(256)
+	nodesVector := structVector(b, nodes)
+	buffersVector := structVector(b, buffers)
+	b.StartObject(4)
+	b.PrependInt64Slot(0, int64(length), 0)
+	b.PrependUOffsetTSlot(1, nodesVector, 0)
+	b.PrependUOffsetTSlot(2, buffersVector, 0)
+	return w.writeMessage(b, messageHeaderRecordBatch, b.EndObject(), body)
+}
+
+// Close writes the schema if no batch was written and the end-of-stream
+// marker. It does not close the underlying writer.
+func (w *Writer) Close() error {
+	if err := w.writeSchema(); err != nil {
+		return err
+	}
+	eos := binary.LittleEndian.AppendUint32(nil, continuationMarker)
+	eos = binary.LittleEndian.AppendUint32(eos, 0)
+	_, err := w.w.Write(eos)
+	return err
+}
+
+func (w *Writer) writeSchema() error {
+	if w.wroteSchema {
+		return nil
+	}
+	w.wroteSchema = true
+
+	b := flatbuffers.NewBuilder(256)
+	fields := make([]flatbuffers.UOffsetT, len(w.fields))
+	for i, f := range w.fields {
+		name := b.CreateString(f.Name)
+		var typeType byte
+		var typ flatbuffers.UOffsetT
+		switch f.Type {
+		case String:
+			typeType = typeUtf8
+			b.StartObject(0)
+			typ = b.EndObject()
+		case Timestamp:
+			typeType = typeTimestamp
+			tz := b.CreateString("UTC")
+			b.StartObject(2)
+			b.PrependInt16Slot(0, timeUnitNanosecond, 0)
+			b.PrependUOffsetTSlot(1, tz, 0)
+			typ = b.EndObject()
+		}
+		// Readers require the children vector even for primitive types.
+		children := offsetVector(b, nil)
+		b.StartObject(7)
+		b.PrependUOffsetTSlot(0, name, 0)
+		b.PrependBoolSlot(1, true, false)
+		b.PrependByteSlot(2, typeType, 0)
+		b.PrependUOffsetTSlot(3, typ, 0)
+		b.PrependUOffsetTSlot(5, children, 0)
+		fields[i] = b.EndObject()
+	}
+	fieldsVector := offsetVector(b, fields)
+
+	var metadataVector flatbuffers.UOffsetT
+	if len(w.metadata) > 0 {
+		keys := make([]string, 0, len(w.metadata))
+		for k := range w.metadata {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		kvs := make([]flatbuffers.UOffsetT, len(keys))
+		for i, k := range keys {
+			key, value := b.CreateString(k), b.CreateString(w.metadata[k])
+			b.StartObject(2)
+			b.PrependUOffsetTSlot(0, key, 0)
+			b.PrependUOffsetTSlot(1, value, 0)
+			kvs[i] = b.EndObject()
+		}
+		metadataVector = offsetVector(b, kvs)
+	}
+
+	b.StartObject(4)
+	b.PrependUOffsetTSlot(1, fieldsVector, 0)
+	if metadataVector != 0 {
+		b.PrependUOffsetTSlot(2, metadataVector, 0)
+	}
+	return w.writeMessage(b, messageHeaderSchema, b.EndObject(), nil)
+}
+
+// writeMessage finishes the Message of header in b and frames it as an
+// encapsulated IPC message: continuation marker, metadata length, the
+// FlatBuffer padded to 8 bytes and the 8-byte aligned body.
+func (w *Writer) writeMessage(b *flatbuffers.Builder, headerType byte, header flatbuffers.UOffsetT, body []byte) error {
+	b.StartObject(5)
+	b.PrependInt16Slot(0, metadataVersionV5, 0)
+	b.PrependByteSlot(1, headerType, 0)
+	b.PrependUOffsetTSlot(2, header, 0)
+	b.PrependInt64Slot(3, int64(len(body)), 0)
+	b.Finish(b.EndObject())
+	msg := b.FinishedBytes()
+	msg = append(msg, make([]byte, alignTo(len(msg), 8)-len(msg))...)
+
+	prefix := binary.LittleEndian.AppendUint32(nil, continuationMarker)
+	prefix = binary.LittleEndian.AppendUint32(prefix, uint32(len(msg)))
+	for _, part := range [][]byte{prefix, msg, body} {
+		if _, err := w.w.Write(part); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// arrowStruct is a FieldNode (length, null count) or a Buffer (offset,
+// length) of a record batch: both are structs of two int64s.
+type arrowStruct [2]int64
+
+// structVector creates a vector of FieldNode or Buffer structs.
+func structVector(b *flatbuffers.Builder, elems []arrowStruct) flatbuffers.UOffsetT {
+	b.StartVector(16, len(elems), 8)
+	for _, e := range slices.Backward(elems) {
+		b.Prep(8, 16)
+		b.PrependInt64(e[1])
+		b.PrependInt64(e[0])
+	}
+	return b.EndVector(len(elems))
+}
+
+// offsetVector creates a vector of tables.
+func offsetVector(b *flatbuffers.Builder, offsets []flatbuffers.UOffsetT) flatbuffers.UOffsetT {
+	b.StartVector(4, len(offsets), 4)
+	for _, off := range slices.Backward(offsets) {
+		b.PrependUOffsetT(off)
+	}
+	return b.EndVector(len(offsets))
+}
+
+func alignTo(n, align int) int {
+	return (n + align - 1) / align * align
+}
@@ -0,0 +1,239 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package arrowipc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+)
+
+// fbReader reads FlatBuffers tables with the FlatBuffers runtime, the way
+// generated accessors do, so the tests check the encoding against the format
+// rather than the writer.
+type fbReader []byte
+
+func (r fbReader) u16(pos int) int { return int(flatbuffers.GetUint16(r[pos:])) }
+func (r fbReader) i64(pos int) int64 {
+	return flatbuffers.GetInt64(r[pos:])
+}
+
+func (r fbReader) root() int { return int(flatbuffers.GetUOffsetT(r)) }
+
+func (r fbReader) table(pos int) *flatbuffers.Table {
+	return &flatbuffers.Table{Bytes: r, Pos: flatbuffers.UOffsetT(pos)}
+}
+
+// field returns the absolute position of field id in the table at pos.
+func (r fbReader) field(table, id int) (int, bool) {
+	off := r.table(table).Offset(flatbuffers.VOffsetT(4 + 2*id))
+	if off == 0 {
+		return 0, false
+	}
+	return table + int(off), true
+}
+
+func (r fbReader) deref(pos int) int { return int(r.table(0).Indirect(flatbuffers.UOffsetT(pos))) }
+
+func (r fbReader) str(pos int) string { return r.table(0).String(flatbuffers.UOffsetT(pos)) }
+
+func (r fbReader) vector(pos int) (int, int) {
+	tab := r.table(0)
+	return int(tab.Vector(flatbuffers.UOffsetT(pos))), tab.VectorLen(flatbuffers.UOffsetT(pos))
+}
+
+type message struct {
+	meta fbReader
+	body []byte
+}
+
+func readMessages(t *testing.T, stream []byte) []message {
+	t.Helper()
+	var msgs []message
+	for {
+		if len(stream) < 8 || binary.LittleEndian.Uint32(stream) != continuationMarker {
+			t.Fatalf("missing continuation marker")
+		}
+		size := int(binary.LittleEndian.Uint32(stream[4:]))
+		if size == 0 {
+			return msgs
+		}
+		if (8+size)%8 != 0 {
+			t.Fatalf("metadata size %d is not 8-byte aligned", size)
+		}
+		meta := fbReader(stream[8 : 8+size])
+		bodyLenPos, _ := meta.field(meta.root(), 3)
+		bodyLen := 0
+		if bodyLenPos != 0 {
+			bodyLen = int(meta.i64(bodyLenPos))
+		}
+		msgs = append(msgs, message{meta: meta, body: stream[8+size : 8+size+bodyLen]})
+		stream = stream[8+size+bodyLen:]
+	}
+}
+
+func TestWriter(t *testing.T) {
+	ts := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	fields := []Field{{Name: "timestamp", Type: Timestamp}, {Name: "log", Type: String}}
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf, fields, map[string]string{"totalCount": "3"})
+	err := w.WriteBatch(3,
+		[]time.Time{ts, {}, ts.Add(time.Second)},
+		[]string{"first", "", "third line"},
+	)
+	if err != nil {
+		t.Fatalf("WriteBatch() error = %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	msgs := readMessages(t, buf.Bytes())
+	if len(msgs) != 2 {
+		t.Fatalf("expected schema and record batch messages, got %d", len(msgs))
+	}
+
+	t.Run("schema", func(t *testing.T) {
+		r := msgs[0].meta
+		msg := r.root()
+		if pos, _ := r.field(msg, 0); r.u16(pos) != metadataVersionV5 {
+			t.Errorf("expected metadata version V5")
+		}
+		if pos, _ := r.field(msg, 1); r[pos] != messageHeaderSchema {
+			t.Fatalf("expected schema header, got %d", r[pos])
+		}
+		headerPos, _ := r.field(msg, 2)
+		schema := r.deref(headerPos)
+
+		fieldsPos, _ := r.field(schema, 1)
+		elems, n := r.vector(fieldsPos)
+		if n != 2 {
+			t.Fatalf("expected 2 fields, got %d", n)
+		}
+		wantTypes := []byte{typeTimestamp, typeUtf8}
+		for i := 0; i < n; i++ {
+			field := r.deref(elems + 4*i)
+			namePos, _ := r.field(field, 0)
+			if got := r.str(namePos); got != fields[i].Name {
+				t.Errorf("field %d name = %q, want %q", i, got, fields[i].Name)
+			}
+			typeTypePos, _ := r.field(field, 2)
+			if r[typeTypePos] != wantTypes[i] {
+				t.Errorf("field %d type = %d, want %d", i, r[typeTypePos], wantTypes[i])
+			}
+			if _, ok := r.field(field, 5); !ok {
+				t.Errorf("field %d has no children vector", i)
+			}
+		}
+
+		tsField := r.deref(elems)
+		typePos, _ := r.field(tsField, 3)
+		tsType := r.deref(typePos)
+		unitPos, _ := r.field(tsType, 0)
+		tzPos, _ := r.field(tsType, 1)
+		if r.u16(unitPos) != timeUnitNanosecond || r.str(tzPos) != "UTC" {
+			t.Errorf("unexpected timestamp type unit=%d tz=%q", r.u16(unitPos), r.str(tzPos))
+		}
+
+		mdPos, ok := r.field(schema, 2)
+		if !ok {
+			t.Fatal("expected custom metadata")
+		}
+		kvs, _ := r.vector(mdPos)
+		kv := r.deref(kvs)
+		keyPos, _ := r.field(kv, 0)
+		valPos, _ := r.field(kv, 1)
+		if r.str(keyPos) != "totalCount" || r.str(valPos) != "3" {
+			t.Errorf("unexpected metadata %q=%q", r.str(keyPos), r.str(valPos))
+		}
+	})
+
+	t.Run("record batch", func(t *testing.T) {
+		r := msgs[1].meta
+		body := msgs[1].body
+		msg := r.root()
+		if pos, _ := r.field(msg, 1); r[pos] != messageHeaderRecordBatch {
+			t.Fatalf("expected record batch header, got %d", r[pos])
+		}
+		headerPos, _ := r.field(msg, 2)
+		batch := r.deref(headerPos)
+
+		lengthPos, _ := r.field(batch, 0)
+		if r.i64(lengthPos) != 3 {
+			t.Errorf("expected length 3, got %d", r.i64(lengthPos))
+		}
+
+		nodesPos, _ := r.field(batch, 1)
+		nodes, n := r.vector(nodesPos)
+		if n != 2 || nodes%8 != 0 {
+			t.Fatalf("expected 2 aligned field nodes, got %d at %d", n, nodes)
+		}
+		if nulls := r.i64(nodes + 8); nulls != 1 {
+			t.Errorf("expected 1 null timestamp, got %d", nulls)
+		}
+
+		buffersPos, _ := r.field(batch, 2)
+		bufs, n := r.vector(buffersPos)
+		if n != 5 {
+			t.Fatalf("expected 5 buffers, got %d", n)
+		}
+		buffer := func(i int) []byte {
+			off, length := r.i64(bufs+16*i), r.i64(bufs+16*i+8)
+			if off%8 != 0 {
+				t.Errorf("buffer %d offset %d is not 8-byte aligned", i, off)
+			}
+			return body[off : off+length]
+		}
+
+		if validity := buffer(0); len(validity) != 1 || validity[0] != 0b101 {
+			t.Errorf("unexpected validity bitmap %08b", validity)
+		}
+		values := buffer(1)
+		if got := int64(binary.LittleEndian.Uint64(values)); got != ts.UnixNano() {
+			t.Errorf("timestamp[0] = %d, want %d", got, ts.UnixNano())
+		}
+
+		if len(buffer(2)) != 0 {
+			t.Error("expected no validity bitmap for string column")
+		}
+		offsets, data := buffer(3), buffer(4)
+		var got []string
+		for i := 0; i < 3; i++ {
+			start := binary.LittleEndian.Uint32(offsets[4*i:])
+			end := binary.LittleEndian.Uint32(offsets[4*i+4:])
+			got = append(got, string(data[start:end]))
+		}
+		want := []string{"first", "", "third line"}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("log[%d] = %q, want %q", i, got[i], want[i])
+			}
+		}
+	})
+}
+
+func TestWriter_EmptyStream(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, []Field{{Name: "log", Type: String}}, nil)
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if msgs := readMessages(t, buf.Bytes()); len(msgs) != 1 {
+		t.Errorf("expected only the schema message, got %d", len(msgs))
+	}
+}
+
+func TestWriter_ColumnMismatch(t *testing.T) {
+	w := NewWriter(&bytes.Buffer{}, []Field{{Name: "log", Type: String}}, nil)
+	if err := w.WriteBatch(2, []string{"only one"}); err == nil {
+		t.Error("expected error for column length mismatch")
+	}
+	if err := w.WriteBatch(1); err == nil {
+		t.Error("expected error for missing column")
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/openchoreo/community-modules/common/audit"
+)
+
+// auditedOperations are the routes whose requests are audited: the queries
+// returning logs and the changes to alert rules.
+var auditedOperations = map[string]audit.Operation{
+	"POST /api/v1/logs/query":                      {Name: "QueryLogs", Results: "logs"},
+	"POST /api/v1/events/query":                    {Name: "QueryEvents", Results: "events"},
+	"POST /api/v1/logs/raw-query":                  {Name: "RawQueryLogs", Results: "hits"},
+	"POST /api/v1alpha1/alerts/rules":              {Name: "CreateAlertRule"},
+	"PUT /api/v1alpha1/alerts/rules/{ruleName}":    {Name: "UpdateAlertRule"},
+	"DELETE /api/v1alpha1/alerts/rules/{ruleName}": {Name: "DeleteAlertRule"},
+}
+
+// withAudit records the requests for the auditedOperations in log, with the
+// caller stored by withCallers, so it must be wrapped by withCallers.
+// Requests rejected or served from the response cache are audited too.
+// Requests are passed through untouched when no audit log is configured.
+func withAudit(log *audit.Log, next http.Handler) http.Handler {
+	return log.Middleware(auditedOperations, func(r *http.Request) string {
+		return callerFromContext(r.Context())
+	}, next)
+}
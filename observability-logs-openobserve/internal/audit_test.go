@@ -0,0 +1,66 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openchoreo/community-modules/common/access"
+	"github.com/openchoreo/community-modules/common/audit"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+func TestAudit(t *testing.T) {
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(openobserve.OpenObserveResponse{Hits: []map[string]interface{}{}})
+	}))
+	defer ooServer.Close()
+
+	t.Setenv("SRE_TOKEN", "sre-token")
+	policy, err := access.NewPolicy(access.File{Callers: []access.Caller{{Name: "sre", TokenEnv: "SRE_TOKEN"}}})
+	if err != nil {
+		t.Fatalf("NewPolicy() error = %v", err)
+	}
+	var out bytes.Buffer
+	log := audit.NewLog(audit.NewWriterSink(&out), "logs_adapter", testLogger())
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	handler := NewLogsHandler(client, nil, testLogger())
+	handler.SetAccessPolicy(policy)
+	handler.SetAuditLog(log)
+	srv := NewServer("0", handler, testLogger()).httpServer.Handler
+
+	body := `{"startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-02T00:00:00Z","searchScope":{"namespace":"payments"}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/logs/query", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer sre-token")
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	srv.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+	log.Flush(context.Background())
+
+	var event audit.Event
+	if err := json.Unmarshal(out.Bytes(), &event); err != nil {
+		t.Fatalf("expected a single JSON event, got %q: %v", out.String(), err)
+	}
+	if event.Operation != "QueryLogs" || event.Caller != "sre" || event.Scope["namespace"] != "payments" ||
+		event.Status != http.StatusOK || event.ResultSize == nil || *event.ResultSize != 0 {
+		t.Errorf("unexpected event: %+v", event)
+	}
+
+	rec = httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if want := `logs_adapter_audit_events_total{result="written"} 1`; !strings.Contains(rec.Body.String(), want) {
+		t.Errorf("expected metrics to contain %q, got:\n%s", want, rec.Body.String())
+	}
+}
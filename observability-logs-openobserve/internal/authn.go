@@ -0,0 +1,23 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/openchoreo/community-modules/common/auth"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/api/gen"
+)
+
+// withAuthentication rejects the requests authenticator does not accept with
+// 401, except those for the exempt paths and shared views, whose signed link
+// is their credential. Requests are passed through untouched when no
+// authenticator is configured.
+func withAuthentication(authenticator auth.Authenticator, exempt []string, next http.Handler) http.Handler {
+	exempt = append(slices.Clone(exempt), sharedViewPathPrefix)
+	return auth.Middleware(authenticator, exempt, func(w http.ResponseWriter, r *http.Request, err error) {
+		writeJSONError(w, http.StatusUnauthorized, gen.Unauthorized, auth.ErrUnauthenticated.Error())
+	}, next)
+}
@@ -0,0 +1,63 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/openchoreo/community-modules/common/access"
+	"github.com/openchoreo/community-modules/common/auth"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+func TestServerAuthentication(t *testing.T) {
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"took":1,"hits":[]}`))
+	}))
+	defer ooServer.Close()
+
+	t.Setenv("SRE_TOKEN", "sre-token")
+	policy, err := access.NewPolicy(access.File{Callers: []access.Caller{{Name: "sre", TokenEnv: "SRE_TOKEN"}}})
+	if err != nil {
+		t.Fatalf("NewPolicy() error = %v", err)
+	}
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	handler := NewLogsHandler(client, nil, testLogger())
+	handler.SetAccessPolicy(policy)
+	handler.SetAuthenticator(auth.Any(auth.NewStaticToken("0123456789abcdef"), policy), []string{"/health"})
+	srv := NewServer("0", handler, testLogger()).httpServer.Handler
+
+	tests := []struct {
+		name   string
+		target string
+		token  string
+		want   int
+	}{
+		{"service token", "/api/v1/logs/sources?namespace=test-ns", "0123456789abcdef", http.StatusOK},
+		{"access policy caller", "/api/v1/logs/sources?namespace=test-ns", "sre-token", http.StatusOK},
+		{"missing token", "/api/v1/logs/sources?namespace=test-ns", "", http.StatusUnauthorized},
+		{"unknown token", "/api/v1/logs/sources?namespace=test-ns", "unknown", http.StatusUnauthorized},
+		{"exempt health check", "/health", "", http.StatusOK},
+		{"shared view", "/api/v1/logs/shared/token", "", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			rec := httptest.NewRecorder()
+			srv.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Fatalf("expected %d, got %d: %s", tt.want, rec.Code, rec.Body.String())
+			}
+			if rec.Code == http.StatusUnauthorized && rec.Header().Get("WWW-Authenticate") != "Bearer" {
+				t.Errorf("expected a WWW-Authenticate header")
+			}
+		})
+	}
+}
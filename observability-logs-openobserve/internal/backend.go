@@ -0,0 +1,383 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/auth"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/loki"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/observer"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+// LogsBackend is a store of logs and alert rules serving the core logs API,
+// the queries of component and workflow logs and the alert rules. The
+// OpenObserve and Loki clients are.
+type LogsBackend interface {
+	GetComponentLogs(ctx context.Context, params openobserve.ComponentLogsParams) (*openobserve.ComponentLogsResult, error)
+	GetWorkflowLogs(ctx context.Context, params openobserve.WorkflowLogsParams) (*openobserve.WorkflowLogsResult, error)
+	CreateAlert(ctx context.Context, params openobserve.LogAlertParams) (string, error)
+	UpdateAlert(ctx context.Context, alertName string, params openobserve.LogAlertParams) (string, error)
+	DeleteAlert(ctx context.Context, alertName string) (string, error)
+	GetAlert(ctx context.Context, alertName string) (*openobserve.AlertDetail, error)
+}
+
+var (
+	_ LogsBackend = (*openobserve.Client)(nil)
+	_ LogsBackend = (*loki.Client)(nil)
+)
+
+// BackendHandler serves the core logs API from a LogsBackend, without the
+// extensions of the LogsHandler, which are specific to OpenObserve. It
+// serves the alerts of the Loki ruler through Alertmanager.
+type BackendHandler struct {
+	backend         LogsBackend
+	observerClient  *observer.Client
+	logger          *slog.Logger
+	authenticator   auth.Authenticator
+	authExemptPaths []string
+}
+
+// Ensure BackendHandler implements the interface at compile time.
+var _ gen.StrictServerInterface = (*BackendHandler)(nil)
+
+// NewBackendHandler creates a handler serving the logs API from backend.
+func NewBackendHandler(backend LogsBackend, observerClient *observer.Client, logger *slog.Logger) *BackendHandler {
+	return &BackendHandler{
+		backend:        backend,
+		observerClient: observerClient,
+		logger:         logger,
+	}
+}
+
+// SetAuthenticator requires the requests to authenticate with a, except
+// those for the exempt paths.
+func (h *BackendHandler) SetAuthenticator(a auth.Authenticator, exemptPaths []string) {
+	h.authenticator = a
+	h.authExemptPaths = exemptPaths
+}
+
+// Health implements the health check endpoint.
+func (h *BackendHandler) Health(ctx context.Context, _ gen.HealthRequestObject) (gen.HealthResponseObject, error) {
+	status := "healthy"
+	return gen.Health200JSONResponse{Status: &status}, nil
+}
+
+// QueryLogs implements POST /api/v1/logs/query for component and workflow
+// logs.
+func (h *BackendHandler) QueryLogs(ctx context.Context, request gen.QueryLogsRequestObject) (gen.QueryLogsResponseObject, error) {
+	if request.Body == nil {
+		return gen.QueryLogs400JSONResponse{
+			Title:   ptr(gen.BadRequest),
+			Message: ptr("request body is required"),
+		}, nil
+	}
+
+	workflowScope, err := request.Body.SearchScope.AsWorkflowSearchScope()
+	if err == nil && workflowScope.WorkflowRunName != nil {
+		if strings.TrimSpace(workflowScope.Namespace) == "" {
+			return gen.QueryLogs400JSONResponse{
+				Title:   ptr(gen.BadRequest),
+				Message: ptr("searchScope with a valid namespace is required"),
+			}, nil
+		}
+		result, err := h.backend.GetWorkflowLogs(ctx, toWorkflowLogsParams(request.Body, &workflowScope))
+		if err != nil {
+			h.logger.Error("Failed to query workflow logs",
+				slog.String("function", "QueryLogs"),
+				slog.String("namespace", workflowScope.Namespace),
+				slog.Any("error", err),
+			)
+			return backendQueryLogsError(err), nil
+		}
+		return gen.QueryLogs200JSONResponse(toWorkflowLogsQueryResponse(result)), nil
+	}
+
+	scope, err := request.Body.SearchScope.AsComponentSearchScope()
+	if err != nil || strings.TrimSpace(scope.Namespace) == "" {
+		return gen.QueryLogs400JSONResponse{
+			Title:   ptr(gen.BadRequest),
+			Message: ptr("searchScope with a valid namespace is required"),
+		}, nil
+	}
+	result, err := h.backend.GetComponentLogs(ctx, toComponentLogsParams(request.Body, &scope))
+	if err != nil {
+		h.logger.Error("Failed to query component logs",
+			slog.String("function", "QueryLogs"),
+			slog.String("namespace", scope.Namespace),
+			slog.Any("error", err),
+		)
+		return backendQueryLogsError(err), nil
+	}
+	return gen.QueryLogs200JSONResponse(toLogsQueryResponse(result)), nil
+}
+
+// backendQueryLogsError returns the response of a logs query that failed
+// with err, 400 for the parameters the backend cannot serve.
+func backendQueryLogsError(err error) gen.QueryLogsResponseObject {
+	if errors.Is(err, loki.ErrUnsupported) {
+		return gen.QueryLogs400JSONResponse{
+			Title:   ptr(gen.BadRequest),
+			Message: ptr(err.Error()),
+		}
+	}
+	return queryLogsError(err)
+}
+
+// QueryEvents implements POST /api/v1/events/query. Kubernetes events are
+// only collected to OpenObserve.
+func (h *BackendHandler) QueryEvents(ctx context.Context, request gen.QueryEventsRequestObject) (gen.QueryEventsResponseObject, error) {
+	return gen.QueryEvents400JSONResponse{
+		Title:   ptr(gen.BadRequest),
+		Message: ptr("events are not supported by the Loki backend"),
+	}, nil
+}
+
+// CreateAlertRule implements POST /api/v1alpha1/alerts/rules.
+func (h *BackendHandler) CreateAlertRule(ctx context.Context, request gen.CreateAlertRuleRequestObject) (gen.CreateAlertRuleResponseObject, error) {
+	if request.Body == nil {
+		return gen.CreateAlertRule400JSONResponse{
+			Title:   ptr(gen.BadRequest),
+			Message: ptr("request body is required"),
+		}, nil
+	}
+
+	params := toLogAlertParams(request.Body)
+	alertID, err := h.backend.CreateAlert(ctx, params)
+	if err != nil {
+		h.logger.Error("Failed to create alert",
+			slog.String("function", "CreateAlertRule"),
+			slog.Any("alertName", params.Name),
+			slog.Any("error", err),
+		)
+		if isAlertRuleRequestError(err) {
+			return gen.CreateAlertRule400JSONResponse{
+				Title:   ptr(gen.BadRequest),
+				Message: ptr(err.Error()),
+			}, nil
+		}
+		return gen.CreateAlertRule500JSONResponse{
+			Title:   ptr(gen.InternalServerError),
+			Message: ptr("internal server error"),
+		}, nil
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	return gen.CreateAlertRule201JSONResponse{
+		Action:        ptr(gen.Created),
+		Status:        ptr(gen.Synced),
+		RuleLogicalId: params.Name,
+		RuleBackendId: &alertID,
+		LastSyncedAt:  &now,
+	}, nil
+}
+
+// DeleteAlertRule implements DELETE /api/v1alpha1/alerts/rules/{ruleName}.
+func (h *BackendHandler) DeleteAlertRule(ctx context.Context, request gen.DeleteAlertRuleRequestObject) (gen.DeleteAlertRuleResponseObject, error) {
+	alertID, err := h.backend.DeleteAlert(ctx, request.RuleName)
+	if err != nil {
+		h.logger.Error("Failed to delete alert",
+			slog.String("function", "DeleteAlertRule"),
+			slog.String("ruleName", request.RuleName),
+			slog.Any("error", err),
+		)
+		if strings.Contains(err.Error(), "not found") {
+			return gen.DeleteAlertRule404JSONResponse{
+				Title:   ptr(gen.NotFound),
+				Message: ptr("alert rule not found"),
+			}, nil
+		}
+		return gen.DeleteAlertRule500JSONResponse{
+			Title:   ptr(gen.InternalServerError),
+			Message: ptr("internal server error"),
+		}, nil
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	return gen.DeleteAlertRule200JSONResponse{
+		Action:        ptr(gen.Deleted),
+		Status:        ptr(gen.Synced),
+		RuleLogicalId: &request.RuleName,
+		RuleBackendId: &alertID,
+		LastSyncedAt:  &now,
+	}, nil
+}
+
+// GetAlertRule implements GET /api/v1alpha1/alerts/rules/{ruleName}.
+func (h *BackendHandler) GetAlertRule(ctx context.Context, request gen.GetAlertRuleRequestObject) (gen.GetAlertRuleResponseObject, error) {
+	alert, err := h.backend.GetAlert(ctx, request.RuleName)
+	if err != nil {
+		h.logger.Error("Failed to get alert",
+			slog.String("function", "GetAlertRule"),
+			slog.String("ruleName", request.RuleName),
+			slog.Any("error", err),
+		)
+		if strings.Contains(err.Error(), "not found") {
+			return gen.GetAlertRule404JSONResponse{
+				Title:   ptr(gen.NotFound),
+				Message: ptr("alert rule not found"),
+			}, nil
+		}
+		return gen.GetAlertRule500JSONResponse{
+			Title:   ptr(gen.InternalServerError),
+			Message: ptr("internal server error"),
+		}, nil
+	}
+	return toAlertRuleResponse(alert), nil
+}
+
+// UpdateAlertRule implements PUT /api/v1alpha1/alerts/rules/{ruleName}.
+func (h *BackendHandler) UpdateAlertRule(ctx context.Context, request gen.UpdateAlertRuleRequestObject) (gen.UpdateAlertRuleResponseObject, error) {
+	if request.Body == nil {
+		return gen.UpdateAlertRule400JSONResponse{
+			Title:   ptr(gen.BadRequest),
+			Message: ptr("request body is required"),
+		}, nil
+	}
+
+	alertID, err := h.backend.UpdateAlert(ctx, request.RuleName, toLogAlertParams(request.Body))
+	if err != nil {
+		h.logger.Error("Failed to update alert",
+			slog.String("function", "UpdateAlertRule"),
+			slog.String("ruleName", request.RuleName),
+			slog.Any("error", err),
+		)
+		if strings.Contains(err.Error(), "not found") {
+			return gen.UpdateAlertRule404JSONResponse{
+				Title:   ptr(gen.NotFound),
+				Message: ptr("alert rule not found"),
+			}, nil
+		}
+		if isAlertRuleRequestError(err) {
+			return gen.UpdateAlertRule400JSONResponse{
+				Title:   ptr(gen.BadRequest),
+				Message: ptr(err.Error()),
+			}, nil
+		}
+		return gen.UpdateAlertRule500JSONResponse{
+			Title:   ptr(gen.InternalServerError),
+			Message: ptr("internal server error"),
+		}, nil
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	return gen.UpdateAlertRule200JSONResponse{
+		Action:        ptr(gen.Updated),
+		Status:        ptr(gen.Synced),
+		RuleLogicalId: &request.RuleName,
+		RuleBackendId: &alertID,
+		LastSyncedAt:  &now,
+	}, nil
+}
+
+// isAlertRuleRequestError reports whether an alert rule was rejected for
+// its parameters.
+func isAlertRuleRequestError(err error) bool {
+	return errors.Is(err, loki.ErrUnsupported) || strings.Contains(err.Error(), "invalid")
+}
+
+// HandleAlertWebhook implements POST /api/v1alpha1/alerts/webhook for the
+// notifications of Alertmanager. Each firing alert is forwarded to the
+// observer, with the namespace of its rule from the labels of the alert.
+func (h *BackendHandler) HandleAlertWebhook(ctx context.Context, request gen.HandleAlertWebhookRequestObject) (gen.HandleAlertWebhookResponseObject, error) {
+	if request.Body == nil {
+		h.logger.Warn("Alert webhook received with nil body")
+		return gen.HandleAlertWebhook200JSONResponse{
+			Message: ptr("alert webhook received successfully"),
+			Status:  ptr(gen.Success),
+		}, nil
+	}
+
+	alerts, err := parseAlertmanagerWebhookBody(*request.Body)
+	if err != nil {
+		h.logger.Error("Failed to parse alert webhook body", slog.Any("error", err))
+		return gen.HandleAlertWebhook200JSONResponse{
+			Message: ptr("alert webhook received successfully"),
+			Status:  ptr(gen.Success),
+		}, nil
+	}
+
+	go func() {
+		forwardCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		for _, alert := range alerts {
+			if err := h.observerClient.ForwardAlert(forwardCtx, alert.ruleName, alert.namespace, alert.count, alert.timestamp); err != nil {
+				h.logger.Error("Failed to forward alert webhook to observer API",
+					slog.String("alertName", alert.ruleName),
+					slog.Any("error", err),
+				)
+			}
+		}
+	}()
+
+	return gen.HandleAlertWebhook200JSONResponse{
+		Message: ptr("alert webhook received successfully"),
+		Status:  ptr(gen.Success),
+	}, nil
+}
+
+// firedAlert is an alert of an Alertmanager notification.
+type firedAlert struct {
+	ruleName  string
+	namespace string
+	count     float64
+	timestamp time.Time
+}
+
+// parseAlertmanagerWebhookBody returns the firing alerts of the rules of the
+// adapter in an Alertmanager notification. Resolved alerts and the alerts
+// of other rules are skipped.
+func parseAlertmanagerWebhookBody(body map[string]interface{}) ([]firedAlert, error) {
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	var notification struct {
+		Alerts []struct {
+			Status      string            `json:"status"`
+			Labels      map[string]string `json:"labels"`
+			Annotations map[string]string `json:"annotations"`
+			StartsAt    time.Time         `json:"startsAt"`
+		} `json:"alerts"`
+	}
+	if err := json.Unmarshal(raw, &notification); err != nil {
+		return nil, fmt.Errorf("invalid Alertmanager notification: %w", err)
+	}
+	if notification.Alerts == nil {
+		return nil, fmt.Errorf("missing alerts in webhook body")
+	}
+
+	var alerts []firedAlert
+	for _, alert := range notification.Alerts {
+		namespace := alert.Labels[loki.NamespaceLabel]
+		if alert.Status != "firing" || alert.Labels["alertname"] == "" || namespace == "" {
+			continue
+		}
+		fired := firedAlert{
+			ruleName:  alert.Labels["alertname"],
+			namespace: namespace,
+			timestamp: alert.StartsAt,
+		}
+		if count, ok := alert.Annotations[loki.AlertCountAnnot]; ok {
+			if fired.count, err = strconv.ParseFloat(count, 64); err != nil {
+				return nil, fmt.Errorf("failed to parse %s %q: %w", loki.AlertCountAnnot, count, err)
+			}
+		}
+		if fired.timestamp.IsZero() {
+			fired.timestamp = time.Now()
+		}
+		alerts = append(alerts, fired)
+	}
+	return alerts, nil
+}
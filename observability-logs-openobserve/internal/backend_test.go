@@ -0,0 +1,156 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/loki"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+// fakeBackend is a LogsBackend serving fixed results.
+type fakeBackend struct {
+	componentParams openobserve.ComponentLogsParams
+	err             error
+}
+
+func (b *fakeBackend) GetComponentLogs(_ context.Context, params openobserve.ComponentLogsParams) (*openobserve.ComponentLogsResult, error) {
+	b.componentParams = params
+	if b.err != nil {
+		return nil, b.err
+	}
+	return &openobserve.ComponentLogsResult{
+		Logs:       []openobserve.ComponentLogsEntry{{Log: "started", LogLevel: "INFO"}},
+		TotalCount: 1,
+	}, nil
+}
+
+func (b *fakeBackend) GetWorkflowLogs(context.Context, openobserve.WorkflowLogsParams) (*openobserve.WorkflowLogsResult, error) {
+	return &openobserve.WorkflowLogsResult{}, b.err
+}
+
+func (b *fakeBackend) CreateAlert(context.Context, openobserve.LogAlertParams) (string, error) {
+	return "openchoreo/rule", b.err
+}
+
+func (b *fakeBackend) UpdateAlert(context.Context, string, openobserve.LogAlertParams) (string, error) {
+	return "openchoreo/rule", b.err
+}
+
+func (b *fakeBackend) DeleteAlert(context.Context, string) (string, error) {
+	return "openchoreo/rule", b.err
+}
+
+func (b *fakeBackend) GetAlert(_ context.Context, name string) (*openobserve.AlertDetail, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	return &openobserve.AlertDetail{Name: name, SearchPattern: "ERROR", Operator: "gt", Period: 5, Frequency: 1, FrequencyType: "minutes"}, nil
+}
+
+func TestBackendHandler_QueryLogs(t *testing.T) {
+	backend := &fakeBackend{}
+	handler := NewBackendHandler(backend, nil, testLogger())
+	body := &gen.LogsQueryRequest{
+		StartTime: time.Now().Add(-time.Hour),
+		EndTime:   time.Now(),
+	}
+	if err := body.SearchScope.FromComponentSearchScope(gen.ComponentSearchScope{Namespace: "acme"}); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := handler.QueryLogs(context.Background(), gen.QueryLogsRequestObject{Body: body})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ok, isOK := resp.(gen.QueryLogs200JSONResponse)
+	if !isOK || ok.Total == nil || *ok.Total != 1 || backend.componentParams.Namespace != "acme" {
+		t.Fatalf("expected the logs of the backend, got %#v", resp)
+	}
+
+	backend.err = fmt.Errorf("offset: %w", loki.ErrUnsupported)
+	resp, _ = handler.QueryLogs(context.Background(), gen.QueryLogsRequestObject{Body: body})
+	if _, isBadRequest := resp.(gen.QueryLogs400JSONResponse); !isBadRequest {
+		t.Errorf("expected 400 for an unsupported query, got %T", resp)
+	}
+
+	backend.err = fmt.Errorf("%w: status 503", loki.ErrUnavailable)
+	resp, _ = handler.QueryLogs(context.Background(), gen.QueryLogsRequestObject{Body: body})
+	if _, isUnavailable := resp.(backendUnavailableResponse); !isUnavailable {
+		t.Errorf("expected 503 for an unavailable backend, got %T", resp)
+	}
+}
+
+func TestBackendHandler_GetAlertRule(t *testing.T) {
+	backend := &fakeBackend{}
+	handler := NewBackendHandler(backend, nil, testLogger())
+	resp, err := handler.GetAlertRule(context.Background(), gen.GetAlertRuleRequestObject{RuleName: "rule"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rule, ok := resp.(gen.GetAlertRule200JSONResponse)
+	if !ok {
+		t.Fatalf("expected 200 response, got %T", resp)
+	}
+	if *rule.Source.Query != "ERROR" || *rule.Condition.Window != "5m" || *rule.Condition.Operator != "gt" {
+		t.Errorf("unexpected alert rule %+v %+v", rule.Source, rule.Condition)
+	}
+
+	backend.err = fmt.Errorf(`alert "rule" not found`)
+	resp, _ = handler.GetAlertRule(context.Background(), gen.GetAlertRuleRequestObject{RuleName: "rule"})
+	if _, ok := resp.(gen.GetAlertRule404JSONResponse); !ok {
+		t.Errorf("expected 404 response, got %T", resp)
+	}
+}
+
+func TestBackendHandler_QueryEvents(t *testing.T) {
+	handler := NewBackendHandler(&fakeBackend{}, nil, testLogger())
+	resp, _ := handler.QueryEvents(context.Background(), gen.QueryEventsRequestObject{})
+	if _, ok := resp.(gen.QueryEvents400JSONResponse); !ok {
+		t.Errorf("expected 400 response, got %T", resp)
+	}
+}
+
+func TestParseAlertmanagerWebhookBody(t *testing.T) {
+	startsAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	body := map[string]interface{}{
+		"status": "firing",
+		"alerts": []interface{}{
+			map[string]interface{}{
+				"status":      "firing",
+				"labels":      map[string]interface{}{"alertname": "high-errors", "openchoreo_namespace": "acme"},
+				"annotations": map[string]interface{}{"alert_count": "12"},
+				"startsAt":    startsAt.Format(time.RFC3339),
+			},
+			map[string]interface{}{
+				"status": "resolved",
+				"labels": map[string]interface{}{"alertname": "high-errors", "openchoreo_namespace": "acme"},
+			},
+			map[string]interface{}{
+				"status": "firing",
+				"labels": map[string]interface{}{"alertname": "Watchdog"},
+			},
+		},
+	}
+	alerts, err := parseAlertmanagerWebhookBody(body)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(alerts) != 1 {
+		t.Fatalf("expected the firing alert of the adapter only, got %+v", alerts)
+	}
+	alert := alerts[0]
+	if alert.ruleName != "high-errors" || alert.namespace != "acme" || alert.count != 12 || !alert.timestamp.Equal(startsAt) {
+		t.Errorf("unexpected alert %+v", alert)
+	}
+
+	if _, err := parseAlertmanagerWebhookBody(map[string]interface{}{"alertName": "test"}); err == nil {
+		t.Error("expected an error for an OpenObserve notification")
+	}
+}
@@ -0,0 +1,26 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/openchoreo/community-modules/common/access"
+)
+
+// withCallers identifies the caller of each request by its bearer token with
+// policy and stores its name in the request context, where the handlers'
+// authorization checks read it. Raw content is authorized by clientFor
+// rather than by route, because the namespace of some requests, such as
+// gateway logs and stored annotations, is not in the request. Requests are
+// passed through untouched when no access policy is configured.
+func withCallers(policy *access.Policy, next http.Handler) http.Handler {
+	return policy.Middleware(nil, nil, nil, next)
+}
+
+// callerFromContext returns the caller stored by withCallers, or access.Anonymous.
+func callerFromContext(ctx context.Context) string {
+	return access.CallerFromContext(ctx)
+}
@@ -0,0 +1,134 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openchoreo/community-modules/common/access"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+func TestAggregationOnlyPolicy(t *testing.T) {
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query struct {
+				SQL string `json:"sql"`
+			} `json:"query"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		resp := openobserve.OpenObserveResponse{Hits: []map[string]interface{}{}}
+		switch {
+		case strings.Contains(body.Query.SQL, `"k8s_events"`):
+			resp.Hits = append(resp.Hits, map[string]interface{}{
+				"_timestamp":       float64(1735732800000000),
+				"k8s_event_reason": "BackOff",
+				"body":             "Back-off restarting failed container api in pod api-1",
+				"k8s_object_kind":  "Pod",
+				"k8s_object_name":  "api-1",
+				"k8s_object_label_openchoreo_dev_component_uid":   "comp-1",
+				"k8s_object_label_openchoreo_dev_environment_uid": "env-1",
+			})
+		default:
+			resp.Hits = append(resp.Hits, map[string]interface{}{"_timestamp": float64(1735732800000000), "log": "card 4111-1111"})
+			resp.Total = 1
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer ooServer.Close()
+
+	t.Setenv("SRE_TOKEN", "sre-token")
+	policy, err := access.NewPolicy(access.File{
+		Callers:         []access.Caller{{Name: "sre", TokenEnv: "SRE_TOKEN"}},
+		AggregationOnly: []access.Rule{{Namespaces: []string{"payments"}, Except: []string{"sre"}}},
+	})
+	if err != nil {
+		t.Fatalf("NewPolicy() error = %v", err)
+	}
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	handler := NewLogsHandler(client, nil, testLogger())
+	handler.SetAccessPolicy(policy)
+	srv := NewServer("0", handler, testLogger()).httpServer.Handler
+
+	serve := func(method, path, body, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		return rec
+	}
+	logsQuery := func(namespace string) string {
+		return `{"startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-02T00:00:00Z","searchScope":{"namespace":"` + namespace + `"}}`
+	}
+
+	t.Run("raw logs are rejected", func(t *testing.T) {
+		rec := serve(http.MethodPost, "/api/v1/logs/query", logsQuery("payments"), "")
+		if rec.Code != http.StatusForbidden {
+			t.Fatalf("expected 403, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if !strings.Contains(rec.Body.String(), "aggregate") {
+			t.Errorf("unexpected error: %s", rec.Body.String())
+		}
+		if rec := serve(http.MethodPost, "/api/v1/incidents/bundle", logsQuery("payments"), "unknown"); rec.Code != http.StatusForbidden {
+			t.Errorf("expected 403 for an incident bundle, got %d", rec.Code)
+		}
+	})
+
+	t.Run("exempt caller reads raw logs", func(t *testing.T) {
+		rec := serve(http.MethodPost, "/api/v1/logs/query", logsQuery("payments"), "sre-token")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("other namespaces are unrestricted", func(t *testing.T) {
+		rec := serve(http.MethodPost, "/api/v1/logs/query", logsQuery("default"), "")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("aggregates group by known fields only", func(t *testing.T) {
+		body := func(field string) string {
+			return `{"searchScope":{"namespace":"payments"},"groupBy":["` + field + `"]}`
+		}
+		if rec := serve(http.MethodPost, "/api/v1/logs/aggregate", body("pod"), ""); rec.Code != http.StatusOK {
+			t.Errorf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if rec := serve(http.MethodPost, "/api/v1/logs/aggregate", body("log"), ""); rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400 when grouping by log lines, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if rec := serve(http.MethodPost, "/api/v1/logs/aggregate", body("log"), "sre-token"); rec.Code != http.StatusOK {
+			t.Errorf("expected 200 for an exempt caller, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("summaries leave out messages", func(t *testing.T) {
+		body := `{"searchScope":{"namespace":"payments"},"sources":["events"]}`
+		for token, wantMessage := range map[string]bool{"": false, "sre-token": true} {
+			rec := serve(http.MethodPost, "/api/v1/logs/incidents/restarts", body, token)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+			}
+			var got openobserve.RestartSummaryResult
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(got.Components) != 1 || len(got.Components[0].Signals) != 1 {
+				t.Fatalf("unexpected summary: %+v", got)
+			}
+			if hasMessage := got.Components[0].Signals[0].Message != ""; hasMessage != wantMessage {
+				t.Errorf("token %q: message %q", token, got.Components[0].Signals[0].Message)
+			}
+		}
+	})
+}
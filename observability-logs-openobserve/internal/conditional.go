@@ -0,0 +1,138 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/annotations"
+)
+
+const (
+	// queryResultsSettle is how long after their end time the results of a
+	// query are considered final, allowing for late log ingestion.
+	queryResultsSettle = 5 * time.Minute
+	// queryResultsMaxAge bounds how long clients may reuse settled results
+	// without revalidating them, so that annotation changes are picked up.
+	queryResultsMaxAge = 5 * time.Minute
+)
+
+// withConditionalQueries makes the results of logs and events queries over
+// a settled time window cacheable. Their ETag is derived from the request
+// alone: everything that shapes the response, the caller and the revision
+// of the log annotations. A request whose If-None-Match lists it is answered
+// with 304 Not Modified without querying OpenObserve. Queries whose end time
+// is within queryResultsSettle of now, or missing, are passed through
+// untouched.
+func withConditionalQueries(notes *annotations.FileStore, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Body == nil || !isQueryPath(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		body, err := peekBody(r)
+		if err != nil {
+			http.Error(w, "failed to read request body", http.StatusBadRequest)
+			return
+		}
+		var window struct {
+			EndTime *time.Time `json:"endTime"`
+		}
+		if json.Unmarshal(body, &window) != nil || window.EndTime == nil || time.Since(*window.EndTime) < queryResultsSettle {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		etag := queryResultsETag(r, body, notes)
+		header := w.Header()
+		header.Set("Cache-Control", "private, max-age="+strconv.Itoa(int(queryResultsMaxAge.Seconds())))
+		header.Set("Vary", "Authorization, Accept, Prefer, "+TenancyHeader)
+		if etagListed(r.Header.Get("If-None-Match"), etag) {
+			header.Set("ETag", etag)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		next.ServeHTTP(&etagWriter{ResponseWriter: w, etag: etag}, r)
+	})
+}
+
+// queryResultsETag returns the weak ETag of the results of a query request
+// with body.
+func queryResultsETag(r *http.Request, body []byte, notes *annotations.FileStore) string {
+	h := sha256.New()
+	for _, part := range []string{
+		r.URL.Path,
+		r.URL.RawQuery,
+		callerFromContext(r.Context()),
+		r.Header.Get(TenancyHeader),
+		strings.Join(r.Header.Values("Accept"), ","),
+		strings.Join(r.Header.Values("Prefer"), ","),
+	} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	h.Write(body)
+	if notes != nil {
+		h.Write([]byte{0})
+		h.Write([]byte(strconv.FormatInt(notes.Revision(), 10)))
+	}
+	return `W/"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+}
+
+// etagListed reports whether the If-None-Match header value lists etag,
+// using the weak comparison RFC 9110 prescribes for it.
+func etagListed(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// etagWriter sets the ETag of successful responses, and drops the caching
+// headers of any other.
+type etagWriter struct {
+	http.ResponseWriter
+	etag        string
+	wroteHeader bool
+}
+
+func (ew *etagWriter) WriteHeader(status int) {
+	if !ew.wroteHeader {
+		ew.wroteHeader = true
+		if status == http.StatusOK {
+			ew.Header().Set("ETag", ew.etag)
+		} else {
+			ew.Header().Del("Cache-Control")
+		}
+	}
+	ew.ResponseWriter.WriteHeader(status)
+}
+
+func (ew *etagWriter) Write(b []byte) (int, error) {
+	if !ew.wroteHeader {
+		ew.WriteHeader(http.StatusOK)
+	}
+	return ew.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (ew *etagWriter) Unwrap() http.ResponseWriter {
+	return ew.ResponseWriter
+}
+
+func (ew *etagWriter) Flush() {
+	if f, ok := ew.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
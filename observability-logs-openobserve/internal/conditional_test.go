@@ -0,0 +1,93 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/annotations"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+func TestConditionalQueries(t *testing.T) {
+	var searches atomic.Int32
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		searches.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(openobserve.OpenObserveResponse{Hits: []map[string]interface{}{}})
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	handler := NewLogsHandler(client, nil, testLogger())
+	notes, err := annotations.NewFileStore(filepath.Join(t.TempDir(), "annotations.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler.SetAnnotationStore(notes)
+	srv := NewServer("0", handler, testLogger()).httpServer.Handler
+
+	serve := func(end time.Time, ifNoneMatch string) *httptest.ResponseRecorder {
+		body := `{"startTime":"2025-01-01T00:00:00Z","endTime":"` + end.Format(time.RFC3339) + `","searchScope":{"namespace":"payments"}}`
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/logs/query", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		return rec
+	}
+
+	past := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	rec := serve(past, "")
+	etag := rec.Header().Get("ETag")
+	if rec.Code != http.StatusOK || !strings.HasPrefix(etag, `W/"`) || !strings.Contains(rec.Header().Get("Cache-Control"), "max-age=") {
+		t.Fatalf("expected a cacheable response, got %d with %v", rec.Code, rec.Header())
+	}
+
+	before := searches.Load()
+	rec = serve(past, etag)
+	if rec.Code != http.StatusNotModified || rec.Body.Len() != 0 || rec.Header().Get("ETag") != etag {
+		t.Errorf("expected 304 for a matching ETag, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if searches.Load() != before {
+		t.Error("expected no OpenObserve query for a 304")
+	}
+
+	if err := notes.Create(annotations.Annotation{ID: "a1", Namespace: "payments", StartTime: past, EndTime: past, Note: "deploy"}); err != nil {
+		t.Fatal(err)
+	}
+	if rec := serve(past, etag); rec.Code != http.StatusOK || rec.Header().Get("ETag") == etag {
+		t.Errorf("expected a new ETag after an annotation change, got %d %q", rec.Code, rec.Header().Get("ETag"))
+	}
+
+	rec = serve(time.Now(), "")
+	if rec.Code != http.StatusOK || rec.Header().Get("ETag") != "" || rec.Header().Get("Cache-Control") != "" {
+		t.Errorf("expected a recent window not to be cacheable, got %v", rec.Header())
+	}
+}
+
+func TestETagListed(t *testing.T) {
+	etag := `W/"abc"`
+	for header, want := range map[string]bool{
+		`W/"abc"`:      true,
+		`"abc"`:        true,
+		`"x", W/"abc"`: true,
+		`*`:            true,
+		`W/"abd"`:      false,
+		``:             false,
+	} {
+		if got := etagListed(header, etag); got != want {
+			t.Errorf("etagListed(%q) = %v, want %v", header, got, want)
+		}
+	}
+}
@@ -4,14 +4,43 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"net/url"
 	"os"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/audit"
+	"github.com/openchoreo/community-modules/common/auth"
+	"github.com/openchoreo/community-modules/common/ratelimit"
+	"github.com/openchoreo/community-modules/common/respcache"
+	"github.com/openchoreo/community-modules/common/secrets"
+	"github.com/openchoreo/community-modules/common/shares"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/formats"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/loki"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/observer"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/scheduler"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/shadow"
+)
+
+// metricNamePattern matches Prometheus metric names.
+var metricNamePattern = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+
+// Backends of the logs and alert rules, selected with LOGS_BACKEND.
+const (
+	BackendOpenObserve = "openobserve"
+	BackendLoki        = "loki"
 )
 
+// streamNamePattern matches the names of the streams the adapter writes.
+var streamNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
 type Config struct {
 	ServerPort              string
 	OpenObserveURL          string
@@ -22,18 +51,315 @@ type Config struct {
 	OpenObservePassword     string
 	ObserverURL             string
 	LogLevel                slog.Level
+
+	// Export settings. Log export to object storage is enabled when ExportBucket is set.
+	ExportEndpoint        string
+	ExportBucket          string
+	ExportRegion          string
+	ExportAccessKeyID     string
+	ExportSecretAccessKey string
+	ExportPrefix          string
+	ExportObjectLock      bool
+
+	// AlertDestinations maps alert severities to the OpenObserve destinations
+	// their alerts notify.
+	AlertDestinations map[string][]string
+
+	// HoldStorePath is the file that registers legal holds. Legal holds are
+	// enabled when it and ExportBucket are set.
+	HoldStorePath string
+
+	// AnnotationStorePath is the file that stores log annotations. Log
+	// annotations are enabled when it is set.
+	AnnotationStorePath string
+
+	// PresetStorePath is the file that stores the query presets of
+	// namespaces. Query presets are enabled when it is set.
+	PresetStorePath string
+
+	// AlertRuleStorePath is the file that registers the alert rules synced
+	// to OpenObserve. Alert drift detection is enabled when it is set and
+	// checks the rules every AlertDriftCheckInterval.
+	AlertRuleStorePath      string
+	AlertDriftCheckInterval time.Duration
+
+	// GatewayNamespace is the Kubernetes namespace of the API gateway pods
+	// whose access logs are joined with traces. All namespaces are searched
+	// when it is empty.
+	GatewayNamespace string
+	// GatewayLogStream is the stream holding the gateway access logs. The
+	// logs stream is searched when it is empty.
+	GatewayLogStream string
+
+	// RequireTenancyHeader rejects requests that read a namespace without
+	// naming it in the X-OpenChoreo-Namespace header. The header is checked
+	// against the namespace of the request whenever it is sent.
+	RequireTenancyHeader bool
+
+	// TenantsFile is a JSON file assigning namespaces to OpenObserve tenants
+	// with their own organization, credentials and streams. Multi-tenant mode
+	// is enabled when it is set.
+	TenantsFile string
+
+	// PasswordSecret caches the OpenObserve password when it is read from the
+	// external secret store referenced by OPENOBSERVE_PASSWORD_SOURCE instead
+	// of OPENOBSERVE_PASSWORD. It is re-read every SecretRefreshInterval so
+	// that rotated passwords are picked up.
+	PasswordSecret        *secrets.Cached
+	SecretRefreshInterval time.Duration
+	// SecondaryUser and SecondaryPassword are the OpenObserve credentials
+	// a call rejected with the primary ones is retried with, so that the
+	// password can be rotated without a maintenance window. They are
+	// unused when SecondaryPassword is empty.
+	SecondaryUser     string
+	SecondaryPassword string
+
+	// ShareSigningKey is the HMAC key share links are signed with. Share
+	// links are enabled when it is set, and last at most ShareLinkMaxTTL.
+	ShareSigningKey string
+	ShareLinkMaxTTL time.Duration
+
+	// ObserverSigningKey is the HMAC key the alert notifications forwarded
+	// to the observer are signed with. They are not signed when it is empty.
+	ObserverSigningKey string
+
+	// OpenObserveTracesStream is the traces stream included in incident
+	// bundles.
+	OpenObserveTracesStream string
+	// TracesStreamDiscovery checks OpenObserveTracesStream against the
+	// traces streams of the organization at startup, and picks one of them
+	// when it does not exist.
+	TracesStreamDiscovery bool
+
+	// OpenObserveRetry configures the retries of the calls to OpenObserve
+	// and the circuit breaker rejecting them after repeated failures.
+	OpenObserveRetry openobserve.RetryPolicy
+
+	// QueryMaxConcurrency bounds the concurrent OpenObserve queries, which
+	// are shared between endpoint classes by QueryClassWeights. Queries are
+	// not scheduled when it is zero.
+	QueryMaxConcurrency int
+	QueryClassWeights   map[scheduler.Class]int
+
+	// QueryTimeBudget bounds each OpenObserve search of interactive logs
+	// queries, which return partial results once it runs out. Searches are
+	// not bounded when it is zero.
+	QueryTimeBudget time.Duration
+
+	// RetentionCacheTTL is how long the data retention of the logs
+	// streams is cached. Logs queries starting before the retention of a
+	// stream are clamped to it. Queries are not clamped when it is zero.
+	RetentionCacheTTL time.Duration
+
+	// ResponseCache configures the cache serving repeated logs queries. No
+	// response is cached by default.
+	ResponseCache respcache.Config
+
+	// RateLimit bounds the rate of the query requests of each namespace and
+	// the number of them in flight. Requests are not limited by default.
+	RateLimit ratelimit.Config
+
+	// Audit sets where the query and alert operations are audited. They
+	// are not audited by default.
+	Audit audit.Config
+
+	// FollowMaxSessions bounds the pod log follows each caller holds open in
+	// a namespace. Follows are not limited when it is zero.
+	FollowMaxSessions int
+
+	// LogSortTiebreakers are the stream fields that order log lines sharing
+	// a timestamp, so that paging is stable.
+	LogSortTiebreakers []string
+
+	// LogFieldMapping maps the fields log queries filter by and log entries
+	// read to the columns of the logs stream.
+	LogFieldMapping openobserve.FieldMapping
+
+	// LogStreamRoutes route the logs queries of some namespaces, projects
+	// or environments to other streams than the logs stream. Scopes routed
+	// to several streams are queried in each and the results merged.
+	LogStreamRoutes openobserve.StreamRoutes
+
+	// WorkflowBuildPlanes are the streams the workflow logs of each build
+	// plane are read from, when there are several. Workflow logs queries
+	// read all of them and tag each entry with its cluster.
+	WorkflowBuildPlanes []openobserve.BuildPlane
+
+	// QueryPlanCacheSize is the number of log query plans, the SQL generated
+	// for a scope and its filters, kept for repeated queries. Plans are not
+	// cached when it is zero.
+	QueryPlanCacheSize int
+
+	// StrictHitValidation validates the fields of the log hits returned by
+	// OpenObserve against the types the adapter expects, counting malformed
+	// hits in the metrics and in the parseErrors of query responses.
+	StrictHitValidation bool
+
+	// SLIPushInterval is how often the adapter writes its own SLIs to
+	// OpenObserve, as metrics named with the SLIMetricPrefix. SLIs are not
+	// written when it is zero.
+	SLIPushInterval time.Duration
+	SLIMetricPrefix string
+
+	// RollupStream is the stream the level rollups of the logs stream are
+	// kept in, rebuilt every RollupInterval for the last RollupWindow. Level
+	// histograms are read from them when they cover their window. Rollups
+	// are disabled when it is empty.
+	RollupStream   string
+	RollupInterval time.Duration
+	RollupWindow   time.Duration
+
+	// AccessPolicyFile is a JSON file naming callers by bearer token and the
+	// namespaces where they may only read aggregates. No caller is
+	// restricted when it is empty.
+	AccessPolicyFile string
+
+	// WarmupTasks are run at startup, for at most WarmupTimeout, before the
+	// adapter serves requests. WarmupNamespaces are the key namespaces whose
+	// histograms are precomputed and WarmupConnections the number of
+	// connections opened to each OpenObserve backend.
+	WarmupTasks       []string
+	WarmupNamespaces  []string
+	WarmupConnections int
+	WarmupTimeout     time.Duration
+
+	// LogFormatDetectors are the log format detectors tried on the log lines
+	// of all components but those in LogFormatComponents, which maps a
+	// component UID or name to its detector or "none".
+	LogFormatDetectors  []string
+	LogFormatComponents map[string]string
+
+	// Auth configures the authentication of the requests served by the
+	// adapter. All requests are accepted by default.
+	Auth auth.Config
+
+	// ShadowLogPath is the file that records the sanitized shape of the
+	// requests and responses for compatibility tests, within
+	// ShadowLogRetention. Shadow logging is enabled when it is set.
+	ShadowLogPath      string
+	ShadowLogRetention shadow.Retention
+
+	// SlowQueryThreshold is the duration from which OpenObserve calls are
+	// listed in support bundles.
+	SlowQueryThreshold time.Duration
+
+	// Backend is the store of the logs and alert rules, BackendOpenObserve
+	// or BackendLoki. The OpenObserve settings are only required for
+	// OpenObserve.
+	Backend string
+	// LokiURL, LokiTenantID, LokiUser and LokiPassword locate Loki and
+	// authenticate with it; the alert rules are kept in the ruler namespace
+	// LokiRulerNamespace.
+	LokiURL            string
+	LokiTenantID       string
+	LokiUser           string
+	LokiPassword       string
+	LokiRulerNamespace string
 }
 
 // LoadConfig loads configuration from environment variables
 func LoadConfig() (*Config, error) {
 	serverPort := getEnv("SERVER_PORT", "9098")
+	backend := getEnv("LOGS_BACKEND", BackendOpenObserve)
+	lokiURL := getEnv("LOKI_URL", "")
+	lokiTenantID := getEnv("LOKI_TENANT_ID", "")
+	lokiUser := getEnv("LOKI_USER", "")
+	lokiPassword := getEnv("LOKI_PASSWORD", "")
+	lokiRulerNamespace := getEnv("LOKI_RULER_NAMESPACE", loki.DefaultRulerNamespace)
 	openObserveURL := getEnv("OPENOBSERVE_URL", "")
 	openObserveOrg := getEnv("OPENOBSERVE_ORG", "default")
 	openObserveStream := getEnv("OPENOBSERVE_STREAM", "default")
 	openObserveEventsStream := getEnv("OPENOBSERVE_EVENTS_STREAM", "k8s_events")
+	openObserveTracesStream := getEnv("OPENOBSERVE_TRACES_STREAM", "default")
+	tracesStreamDiscovery := getEnv("TRACES_STREAM_DISCOVERY", "false")
+	retryMaxAttempts := getEnv("OPENOBSERVE_RETRY_MAX_ATTEMPTS", strconv.Itoa(openobserve.DefaultRetryPolicy.MaxAttempts))
+	retryInitialBackoff := getEnv("OPENOBSERVE_RETRY_INITIAL_BACKOFF", openobserve.DefaultRetryPolicy.InitialBackoff.String())
+	retryMaxBackoff := getEnv("OPENOBSERVE_RETRY_MAX_BACKOFF", openobserve.DefaultRetryPolicy.MaxBackoff.String())
+	breakerThreshold := getEnv("OPENOBSERVE_BREAKER_THRESHOLD", strconv.Itoa(openobserve.DefaultRetryPolicy.BreakerThreshold))
+	breakerCooldown := getEnv("OPENOBSERVE_BREAKER_COOLDOWN", openobserve.DefaultRetryPolicy.BreakerCooldown.String())
 	openObserveUser := getEnv("OPENOBSERVE_USER", "")
 	openObservePassword := getEnv("OPENOBSERVE_PASSWORD", "")
+	openObservePasswordSource := getEnv("OPENOBSERVE_PASSWORD_SOURCE", "")
+	secondaryUser := getEnv("OPENOBSERVE_SECONDARY_USER", openObserveUser)
+	secondaryPassword := getEnv("OPENOBSERVE_SECONDARY_PASSWORD", "")
+	secretRefreshInterval := getEnv("SECRET_REFRESH_INTERVAL", "5m")
+	shareSigningKey := getEnv("SHARE_SIGNING_KEY", "")
+	shareLinkMaxTTL := getEnv("SHARE_LINK_MAX_TTL", "24h")
 	observerURL := getEnv("OBSERVER_URL", "")
+	observerSigningKey := getEnv("OBSERVER_SIGNING_KEY", "")
+	exportEndpoint := getEnv("EXPORT_ENDPOINT", "https://s3.amazonaws.com")
+	exportBucket := getEnv("EXPORT_BUCKET", "")
+	exportRegion := getEnv("EXPORT_REGION", "us-east-1")
+	exportAccessKeyID := getEnv("EXPORT_ACCESS_KEY_ID", "")
+	exportSecretAccessKey := getEnv("EXPORT_SECRET_ACCESS_KEY", "")
+	exportPrefix := getEnv("EXPORT_PREFIX", "openchoreo-logs")
+	exportObjectLock := getEnv("EXPORT_OBJECT_LOCK_LEGAL_HOLD", "false")
+	holdStorePath := getEnv("HOLD_STORE_PATH", "")
+	annotationStorePath := getEnv("ANNOTATION_STORE_PATH", "")
+	presetStorePath := getEnv("PRESET_STORE_PATH", "")
+	alertRuleStorePath := getEnv("ALERT_RULE_STORE_PATH", "")
+	alertDriftCheckInterval := getEnv("ALERT_DRIFT_CHECK_INTERVAL", "5m")
+	gatewayNamespace := getEnv("GATEWAY_NAMESPACE", "openchoreo-data-plane")
+	gatewayLogStream := getEnv("GATEWAY_LOG_STREAM", "")
+	tenantsFile := getEnv("TENANTS_FILE", "")
+	requireTenancyHeader := getEnv("REQUIRE_TENANCY_HEADER", "false")
+	accessPolicyFile := getEnv("ACCESS_POLICY_FILE", "")
+	queryMaxConcurrency := getEnv("QUERY_MAX_CONCURRENCY", "0")
+	followMaxSessions := getEnv("FOLLOW_MAX_SESSIONS", "0")
+	queryPlanCacheSize := getEnv("QUERY_PLAN_CACHE_SIZE", "1024")
+	strictHitValidation := getEnv("STRICT_HIT_VALIDATION", "false")
+	logSortTiebreakers := getEnv("LOG_SORT_TIEBREAKERS", "")
+	logFieldMapping := getEnv("LOG_FIELD_MAPPING", "")
+	logStreamRoutes := getEnv("LOG_STREAM_ROUTES", "")
+	workflowBuildPlanes := getEnv("WORKFLOW_BUILD_PLANES", "")
+	sliPushInterval := getEnv("SLI_PUSH_INTERVAL", "0")
+	sliMetricPrefix := getEnv("SLI_METRIC_PREFIX", "logs_adapter_sli")
+	rollupStream := getEnv("ROLLUP_STREAM", "")
+	rollupInterval := getEnv("ROLLUP_INTERVAL", "1m")
+	rollupWindow := getEnv("ROLLUP_WINDOW", "24h")
+	queryClassWeights := getEnv("QUERY_CLASS_WEIGHTS", "")
+	queryTimeBudget := getEnv("QUERY_TIME_BUDGET", "0")
+	retentionCacheTTL := getEnv("RETENTION_CACHE_TTL", "1h")
+	responseCacheMemoryMB := getEnv("RESPONSE_CACHE_MEMORY_MB", "64")
+	responseCacheRecentTTL := getEnv("RESPONSE_CACHE_RECENT_TTL", respcache.DefaultRecentTTL.String())
+	responseCacheHistoricalTTL := getEnv("RESPONSE_CACHE_HISTORICAL_TTL", respcache.DefaultHistoricalTTL.String())
+	responseCacheConfig := respcache.Config{
+		Backend:       getEnv("RESPONSE_CACHE_BACKEND", respcache.BackendNone),
+		RedisURL:      getEnv("RESPONSE_CACHE_REDIS_URL", ""),
+		RedisPassword: getEnv("RESPONSE_CACHE_REDIS_PASSWORD", ""),
+		Compression:   getEnv("RESPONSE_CACHE_COMPRESSION", respcache.CompressionNone),
+		Policy:        respcache.Policy{Settle: respcache.DefaultSettle},
+	}
+	rateLimitRPS := getEnv("RATE_LIMIT_RPS", "0")
+	rateLimitBurst := getEnv("RATE_LIMIT_BURST", "0")
+	rateLimitMaxInFlight := getEnv("RATE_LIMIT_MAX_IN_FLIGHT", "0")
+	auditConfig := audit.Config{
+		Sink:   getEnv("AUDIT_LOG_SINK", audit.SinkNone),
+		Stream: getEnv("AUDIT_LOG_STREAM", "audit"),
+	}
+	warmupTasks := splitList(getEnv("WARMUP_TASKS", ""))
+	warmupNamespaces := splitList(getEnv("WARMUP_NAMESPACES", ""))
+	warmupConnections := getEnv("WARMUP_CONNECTIONS", "2")
+	warmupTimeout := getEnv("WARMUP_TIMEOUT", "30s")
+	logFormatDetectors := splitList(getEnv("LOG_FORMAT_DETECTORS", strings.Join(formats.Names(), ",")))
+	logFormatComponents := getEnv("LOG_FORMAT_COMPONENTS", "")
+	shadowLogPath := getEnv("SHADOW_LOG_PATH", "")
+	shadowLogMaxRecords := getEnv("SHADOW_LOG_MAX_RECORDS", "10000")
+	shadowLogMaxAge := getEnv("SHADOW_LOG_MAX_AGE", "168h")
+	slowQueryThreshold := getEnv("SLOW_QUERY_THRESHOLD", "2s")
+	authConfig := auth.Config{
+		Mode:        getEnv("AUTH_MODE", auth.ModeNone),
+		Token:       getEnv("AUTH_TOKEN", ""),
+		JWKSURL:     getEnv("AUTH_JWKS_URL", ""),
+		Issuer:      getEnv("AUTH_JWT_ISSUER", ""),
+		Audience:    getEnv("AUTH_JWT_AUDIENCE", ""),
+		ExemptPaths: splitList(getEnv("AUTH_EXEMPT_PATHS", "/health")),
+	}
+	alertDestinations := map[string][]string{
+		openobserve.AlertSeverityCritical: splitList(getEnv("ALERT_DESTINATIONS_CRITICAL", openobserve.DefaultAlertDestination)),
+		openobserve.AlertSeverityWarning:  splitList(getEnv("ALERT_DESTINATIONS_WARNING", openobserve.DefaultAlertDestination)),
+		openobserve.AlertSeverityInfo:     splitList(getEnv("ALERT_DESTINATIONS_INFO", openobserve.DefaultAlertDestination)),
+	}
 
 	// Parse log level
 	logLevel := slog.LevelInfo
@@ -50,16 +376,52 @@ func LoadConfig() (*Config, error) {
 		}
 	}
 
-	if openObserveURL == "" {
+	switch backend {
+	case BackendOpenObserve:
+	case BackendLoki:
+		parsedLokiURL, err := url.Parse(lokiURL)
+		if lokiURL == "" || err != nil || parsedLokiURL.Scheme == "" || parsedLokiURL.Host == "" {
+			return nil, fmt.Errorf("invalid LOKI_URL: a URL with scheme and host is required for the loki backend")
+		}
+	default:
+		return nil, fmt.Errorf("invalid LOGS_BACKEND %q: must be %s or %s", backend, BackendOpenObserve, BackendLoki)
+	}
+
+	if openObserveURL == "" && backend == BackendOpenObserve {
 		return nil, fmt.Errorf("Environment variable OPENOBSERVE_URL is required")
 	}
 
-	if openObserveUser == "" {
+	if openObserveUser == "" && backend == BackendOpenObserve {
 		return nil, fmt.Errorf("Environment variable OPENOBSERVE_USER is required")
 	}
 
-	if openObservePassword == "" {
-		return nil, fmt.Errorf("Environment variable OPENOBSERVE_PASSWORD is required")
+	refreshInterval, err := time.ParseDuration(secretRefreshInterval)
+	if err != nil || refreshInterval < time.Second {
+		return nil, fmt.Errorf("invalid SECRET_REFRESH_INTERVAL: must be a duration of at least 1s")
+	}
+
+	driftInterval, err := time.ParseDuration(alertDriftCheckInterval)
+	if err != nil || driftInterval < time.Second {
+		return nil, fmt.Errorf("invalid ALERT_DRIFT_CHECK_INTERVAL: must be a duration of at least 1s")
+	}
+
+	var passwordSecret *secrets.Cached
+	if openObservePasswordSource != "" {
+		provider, err := secrets.Parse(openObservePasswordSource)
+		if err != nil {
+			return nil, fmt.Errorf("invalid OPENOBSERVE_PASSWORD_SOURCE: %w", err)
+		}
+		passwordSecret = secrets.NewCached(provider, refreshInterval)
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		openObservePassword, err = passwordSecret.Get(ctx)
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the OpenObserve password from OPENOBSERVE_PASSWORD_SOURCE: %w", err)
+		}
+	}
+
+	if openObservePassword == "" && backend == BackendOpenObserve {
+		return nil, fmt.Errorf("Environment variable OPENOBSERVE_PASSWORD or OPENOBSERVE_PASSWORD_SOURCE is required")
 	}
 
 	if observerURL == "" {
@@ -69,6 +431,199 @@ func LoadConfig() (*Config, error) {
 	if err != nil || parsedURL.Scheme == "" || parsedURL.Host == "" {
 		return nil, fmt.Errorf("OBSERVER_URL must be a valid URL with scheme and host, got: %q", observerURL)
 	}
+	if observerSigningKey != "" && len(observerSigningKey) < observer.MinSigningKeyLength {
+		return nil, fmt.Errorf("invalid OBSERVER_SIGNING_KEY: must be at least %d bytes long", observer.MinSigningKeyLength)
+	}
+
+	if exportBucket != "" && (exportAccessKeyID == "" || exportSecretAccessKey == "") {
+		return nil, fmt.Errorf("EXPORT_ACCESS_KEY_ID and EXPORT_SECRET_ACCESS_KEY are required when EXPORT_BUCKET is set")
+	}
+
+	objectLock, err := strconv.ParseBool(exportObjectLock)
+	if err != nil {
+		return nil, fmt.Errorf("invalid EXPORT_OBJECT_LOCK_LEGAL_HOLD: %w", err)
+	}
+
+	requireTenancy, err := strconv.ParseBool(requireTenancyHeader)
+	if err != nil {
+		return nil, fmt.Errorf("invalid REQUIRE_TENANCY_HEADER: %w", err)
+	}
+
+	discoverTraces, err := strconv.ParseBool(tracesStreamDiscovery)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TRACES_STREAM_DISCOVERY: %w", err)
+	}
+
+	strictHits, err := strconv.ParseBool(strictHitValidation)
+	if err != nil {
+		return nil, fmt.Errorf("invalid STRICT_HIT_VALIDATION: %w", err)
+	}
+
+	if holdStorePath != "" && exportBucket == "" {
+		return nil, fmt.Errorf("EXPORT_BUCKET is required when HOLD_STORE_PATH is set")
+	}
+
+	if shareSigningKey != "" && len(shareSigningKey) < shares.MinKeyLength {
+		return nil, fmt.Errorf("invalid SHARE_SIGNING_KEY: must be at least %d bytes long", shares.MinKeyLength)
+	}
+	maxTTL, err := time.ParseDuration(shareLinkMaxTTL)
+	if err != nil || maxTTL <= 0 {
+		return nil, fmt.Errorf("invalid SHARE_LINK_MAX_TTL: must be a positive duration")
+	}
+
+	var retry openobserve.RetryPolicy
+	if retry.MaxAttempts, err = strconv.Atoi(retryMaxAttempts); err != nil || retry.MaxAttempts < 1 {
+		return nil, fmt.Errorf("invalid OPENOBSERVE_RETRY_MAX_ATTEMPTS: must be a positive integer")
+	}
+	if retry.InitialBackoff, err = time.ParseDuration(retryInitialBackoff); err != nil || retry.InitialBackoff <= 0 {
+		return nil, fmt.Errorf("invalid OPENOBSERVE_RETRY_INITIAL_BACKOFF: must be a positive duration")
+	}
+	if retry.MaxBackoff, err = time.ParseDuration(retryMaxBackoff); err != nil || retry.MaxBackoff < retry.InitialBackoff {
+		return nil, fmt.Errorf("invalid OPENOBSERVE_RETRY_MAX_BACKOFF: must be a duration of at least OPENOBSERVE_RETRY_INITIAL_BACKOFF")
+	}
+	if retry.BreakerThreshold, err = strconv.Atoi(breakerThreshold); err != nil || retry.BreakerThreshold < 0 {
+		return nil, fmt.Errorf("invalid OPENOBSERVE_BREAKER_THRESHOLD: must be a non-negative integer")
+	}
+	if retry.BreakerCooldown, err = time.ParseDuration(breakerCooldown); err != nil || retry.BreakerCooldown <= 0 {
+		return nil, fmt.Errorf("invalid OPENOBSERVE_BREAKER_COOLDOWN: must be a positive duration")
+	}
+
+	maxConcurrency, err := strconv.Atoi(queryMaxConcurrency)
+	if err != nil || maxConcurrency < 0 {
+		return nil, fmt.Errorf("invalid QUERY_MAX_CONCURRENCY: must be a non-negative integer")
+	}
+	timeBudget, err := time.ParseDuration(queryTimeBudget)
+	if err != nil || timeBudget < 0 {
+		return nil, fmt.Errorf("invalid QUERY_TIME_BUDGET: must be 0 or a positive duration")
+	}
+	retentionTTL, err := time.ParseDuration(retentionCacheTTL)
+	if err != nil || (retentionTTL != 0 && retentionTTL < time.Minute) {
+		return nil, fmt.Errorf("invalid RETENTION_CACHE_TTL: must be 0 or a duration of at least 1m")
+	}
+	memoryMB, err := strconv.ParseInt(responseCacheMemoryMB, 10, 64)
+	if err != nil || memoryMB < 1 {
+		return nil, fmt.Errorf("invalid RESPONSE_CACHE_MEMORY_MB: must be a positive integer")
+	}
+	responseCacheConfig.MaxBytes = memoryMB << 20
+	if responseCacheConfig.RecentTTL, err = time.ParseDuration(responseCacheRecentTTL); err != nil {
+		return nil, fmt.Errorf("invalid RESPONSE_CACHE_RECENT_TTL: %w", err)
+	}
+	if responseCacheConfig.HistoricalTTL, err = time.ParseDuration(responseCacheHistoricalTTL); err != nil {
+		return nil, fmt.Errorf("invalid RESPONSE_CACHE_HISTORICAL_TTL: %w", err)
+	}
+	if err := responseCacheConfig.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid response cache settings (RESPONSE_CACHE_*): %w", err)
+	}
+	var rateLimit ratelimit.Config
+	if rateLimit.RequestsPerSecond, err = strconv.ParseFloat(rateLimitRPS, 64); err != nil {
+		return nil, fmt.Errorf("invalid RATE_LIMIT_RPS: must be a non-negative number")
+	}
+	if rateLimit.Burst, err = strconv.Atoi(rateLimitBurst); err != nil {
+		return nil, fmt.Errorf("invalid RATE_LIMIT_BURST: must be a non-negative integer")
+	}
+	if rateLimit.MaxInFlight, err = strconv.Atoi(rateLimitMaxInFlight); err != nil {
+		return nil, fmt.Errorf("invalid RATE_LIMIT_MAX_IN_FLIGHT: must be a non-negative integer")
+	}
+	if err := rateLimit.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid rate limit settings (RATE_LIMIT_*): %w", err)
+	}
+	if err := auditConfig.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid audit log settings (AUDIT_LOG_*): %w", err)
+	}
+	maxFollows, err := strconv.Atoi(followMaxSessions)
+	if err != nil || maxFollows < 0 {
+		return nil, fmt.Errorf("invalid FOLLOW_MAX_SESSIONS: must be a non-negative integer")
+	}
+	fieldMapping, err := openobserve.ParseFieldMapping(logFieldMapping)
+	if err != nil {
+		return nil, fmt.Errorf("invalid LOG_FIELD_MAPPING: %w", err)
+	}
+	tiebreakers := splitList(logSortTiebreakers)
+	switch logSortTiebreakers {
+	case "":
+		tiebreakers = fieldMapping.SortTiebreakers()
+	case "none":
+		tiebreakers = []string{}
+	}
+	if err := openobserve.ValidateSortTiebreakers(tiebreakers); err != nil {
+		return nil, fmt.Errorf("invalid LOG_SORT_TIEBREAKERS: %w", err)
+	}
+	streamRoutes, err := openobserve.ParseStreamRoutes(logStreamRoutes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid LOG_STREAM_ROUTES: %w", err)
+	}
+	buildPlanes, err := openobserve.ParseBuildPlanes(workflowBuildPlanes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid WORKFLOW_BUILD_PLANES: %w", err)
+	}
+
+	planCacheSize, err := strconv.Atoi(queryPlanCacheSize)
+	if err != nil || planCacheSize < 0 {
+		return nil, fmt.Errorf("invalid QUERY_PLAN_CACHE_SIZE: must be a non-negative integer")
+	}
+	pushInterval, err := time.ParseDuration(sliPushInterval)
+	if err != nil || (pushInterval != 0 && pushInterval < time.Second) {
+		return nil, fmt.Errorf("invalid SLI_PUSH_INTERVAL: must be 0 or a duration of at least 1s")
+	}
+	if !metricNamePattern.MatchString(sliMetricPrefix) {
+		return nil, fmt.Errorf("invalid SLI_METRIC_PREFIX: must be a metric name")
+	}
+	if rollupStream != "" && (!streamNamePattern.MatchString(rollupStream) || rollupStream == openObserveStream) {
+		return nil, fmt.Errorf("invalid ROLLUP_STREAM: must be a stream name of letters, digits and underscores other than OPENOBSERVE_STREAM")
+	}
+	rollupEvery, err := time.ParseDuration(rollupInterval)
+	if err != nil || rollupEvery < 10*time.Second {
+		return nil, fmt.Errorf("invalid ROLLUP_INTERVAL: must be a duration of at least 10s")
+	}
+	rollupSpan, err := time.ParseDuration(rollupWindow)
+	if err != nil || rollupSpan < time.Hour {
+		return nil, fmt.Errorf("invalid ROLLUP_WINDOW: must be a duration of at least 1h")
+	}
+	classWeights, err := scheduler.ParseWeights(queryClassWeights)
+	if err != nil {
+		return nil, fmt.Errorf("invalid QUERY_CLASS_WEIGHTS: %w", err)
+	}
+
+	for _, task := range warmupTasks {
+		if !slices.Contains(WarmupTasks, task) {
+			return nil, fmt.Errorf("invalid WARMUP_TASKS: unknown task %q, must be one of %s", task, strings.Join(WarmupTasks, ", "))
+		}
+	}
+	if slices.Contains(warmupTasks, WarmupHistograms) && len(warmupNamespaces) == 0 {
+		return nil, fmt.Errorf("WARMUP_NAMESPACES is required when WARMUP_TASKS includes %s", WarmupHistograms)
+	}
+	connections, err := strconv.Atoi(warmupConnections)
+	if err != nil || connections < 1 {
+		return nil, fmt.Errorf("invalid WARMUP_CONNECTIONS: must be a positive integer")
+	}
+	timeout, err := time.ParseDuration(warmupTimeout)
+	if err != nil || timeout <= 0 {
+		return nil, fmt.Errorf("invalid WARMUP_TIMEOUT: must be a positive duration")
+	}
+
+	formatComponents, err := formats.ParseComponents(logFormatComponents)
+	if err != nil {
+		return nil, fmt.Errorf("invalid LOG_FORMAT_COMPONENTS: %w", err)
+	}
+	if _, err := formats.NewSet(logFormatDetectors, formatComponents); err != nil {
+		return nil, fmt.Errorf("invalid LOG_FORMAT_DETECTORS or LOG_FORMAT_COMPONENTS: %w", err)
+	}
+
+	var shadowRetention shadow.Retention
+	if shadowRetention.MaxRecords, err = strconv.Atoi(shadowLogMaxRecords); err != nil || shadowRetention.MaxRecords < 1 {
+		return nil, fmt.Errorf("invalid SHADOW_LOG_MAX_RECORDS: must be a positive integer")
+	}
+	if shadowRetention.MaxAge, err = time.ParseDuration(shadowLogMaxAge); err != nil || shadowRetention.MaxAge < 0 {
+		return nil, fmt.Errorf("invalid SHADOW_LOG_MAX_AGE: must be 0 or a positive duration")
+	}
+	slowThreshold, err := time.ParseDuration(slowQueryThreshold)
+	if err != nil || slowThreshold <= 0 {
+		return nil, fmt.Errorf("invalid SLOW_QUERY_THRESHOLD: must be a positive duration")
+	}
+
+	if err := authConfig.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid authentication settings (AUTH_*): %w", err)
+	}
 
 	if _, err := strconv.Atoi(serverPort); err != nil {
 		return nil, fmt.Errorf("invalid SERVER_PORT: %w", err)
@@ -84,6 +639,69 @@ func LoadConfig() (*Config, error) {
 		OpenObservePassword:     openObservePassword,
 		ObserverURL:             observerURL,
 		LogLevel:                logLevel,
+		ExportEndpoint:          exportEndpoint,
+		ExportBucket:            exportBucket,
+		ExportRegion:            exportRegion,
+		ExportAccessKeyID:       exportAccessKeyID,
+		ExportSecretAccessKey:   exportSecretAccessKey,
+		ExportPrefix:            exportPrefix,
+		ExportObjectLock:        objectLock,
+		HoldStorePath:           holdStorePath,
+		AnnotationStorePath:     annotationStorePath,
+		PresetStorePath:         presetStorePath,
+		AlertRuleStorePath:      alertRuleStorePath,
+		AlertDriftCheckInterval: driftInterval,
+		AlertDestinations:       alertDestinations,
+		GatewayNamespace:        gatewayNamespace,
+		GatewayLogStream:        gatewayLogStream,
+		TenantsFile:             tenantsFile,
+		RequireTenancyHeader:    requireTenancy,
+		PasswordSecret:          passwordSecret,
+		SecretRefreshInterval:   refreshInterval,
+		SecondaryUser:           secondaryUser,
+		SecondaryPassword:       secondaryPassword,
+		ShareSigningKey:         shareSigningKey,
+		ObserverSigningKey:      observerSigningKey,
+		ShareLinkMaxTTL:         maxTTL,
+		OpenObserveTracesStream: openObserveTracesStream,
+		TracesStreamDiscovery:   discoverTraces,
+		OpenObserveRetry:        retry,
+		AccessPolicyFile:        accessPolicyFile,
+		QueryMaxConcurrency:     maxConcurrency,
+		QueryTimeBudget:         timeBudget,
+		RetentionCacheTTL:       retentionTTL,
+		ResponseCache:           responseCacheConfig,
+		RateLimit:               rateLimit,
+		Audit:                   auditConfig,
+		FollowMaxSessions:       maxFollows,
+		QueryPlanCacheSize:      planCacheSize,
+		StrictHitValidation:     strictHits,
+		LogSortTiebreakers:      tiebreakers,
+		LogFieldMapping:         fieldMapping,
+		LogStreamRoutes:         streamRoutes,
+		WorkflowBuildPlanes:     buildPlanes,
+		SLIPushInterval:         pushInterval,
+		SLIMetricPrefix:         sliMetricPrefix,
+		RollupStream:            rollupStream,
+		RollupInterval:          rollupEvery,
+		RollupWindow:            rollupSpan,
+		QueryClassWeights:       classWeights,
+		WarmupTasks:             warmupTasks,
+		WarmupNamespaces:        warmupNamespaces,
+		WarmupConnections:       connections,
+		WarmupTimeout:           timeout,
+		LogFormatDetectors:      logFormatDetectors,
+		LogFormatComponents:     formatComponents,
+		Auth:                    authConfig,
+		ShadowLogPath:           shadowLogPath,
+		ShadowLogRetention:      shadowRetention,
+		SlowQueryThreshold:      slowThreshold,
+		Backend:                 backend,
+		LokiURL:                 lokiURL,
+		LokiTenantID:            lokiTenantID,
+		LokiUser:                lokiUser,
+		LokiPassword:            lokiPassword,
+		LokiRulerNamespace:      lokiRulerNamespace,
 	}, nil
 }
 
@@ -93,3 +711,14 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// splitList splits a comma-separated list, dropping empty items.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
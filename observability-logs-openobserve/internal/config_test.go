@@ -5,8 +5,22 @@ package app
 
 import (
 	"log/slog"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/audit"
+	"github.com/openchoreo/community-modules/common/auth"
+	"github.com/openchoreo/community-modules/common/ratelimit"
+	"github.com/openchoreo/community-modules/common/respcache"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/formats"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/scheduler"
 )
 
 // setEnvVars sets multiple environment variables and returns a cleanup function.
@@ -62,6 +76,9 @@ func TestLoadConfig_Success(t *testing.T) {
 	if cfg.LogLevel != slog.LevelInfo {
 		t.Errorf("expected default LogLevel Info, got %v", cfg.LogLevel)
 	}
+	if cfg.GatewayNamespace != "openchoreo-data-plane" {
+		t.Errorf("expected default GatewayNamespace openchoreo-data-plane, got %s", cfg.GatewayNamespace)
+	}
 }
 
 func TestLoadConfig_CustomValues(t *testing.T) {
@@ -70,6 +87,7 @@ func TestLoadConfig_CustomValues(t *testing.T) {
 	vars["OPENOBSERVE_ORG"] = "myorg"
 	vars["OPENOBSERVE_STREAM"] = "mystream"
 	vars["OPENOBSERVE_EVENTS_STREAM"] = "myevents"
+	vars["GATEWAY_NAMESPACE"] = "gateways"
 	setEnvVars(t, vars)
 
 	cfg, err := LoadConfig()
@@ -89,6 +107,9 @@ func TestLoadConfig_CustomValues(t *testing.T) {
 	if cfg.OpenObserveEventsStream != "myevents" {
 		t.Errorf("expected OpenObserveEventsStream myevents, got %s", cfg.OpenObserveEventsStream)
 	}
+	if cfg.GatewayNamespace != "gateways" {
+		t.Errorf("expected GatewayNamespace gateways, got %s", cfg.GatewayNamespace)
+	}
 }
 
 func TestLoadConfig_LogLevels(t *testing.T) {
@@ -150,6 +171,550 @@ func TestLoadConfig_MissingRequired(t *testing.T) {
 	}
 }
 
+func TestLoadConfig_PasswordSource(t *testing.T) {
+	vault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/secret/data/openobserve" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"data":{"data":{"password":"fromVault"},"metadata":{"version":1}}}`))
+	}))
+	defer vault.Close()
+
+	t.Run("reads the password from the secret store", func(t *testing.T) {
+		setEnvVars(t, validEnvVars())
+		os.Unsetenv("OPENOBSERVE_PASSWORD")
+		t.Setenv("VAULT_ADDR", vault.URL)
+		t.Setenv("VAULT_TOKEN", "root")
+		t.Setenv("OPENOBSERVE_PASSWORD_SOURCE", "vault://secret/data/openobserve#password")
+		t.Setenv("SECRET_REFRESH_INTERVAL", "1m")
+
+		cfg, err := LoadConfig()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cfg.OpenObservePassword != "fromVault" || cfg.PasswordSecret == nil || cfg.SecretRefreshInterval != time.Minute {
+			t.Errorf("unexpected password settings: %q, %v, %v", cfg.OpenObservePassword, cfg.PasswordSecret, cfg.SecretRefreshInterval)
+		}
+	})
+
+	for name, vars := range map[string]map[string]string{
+		"invalid reference": {"OPENOBSERVE_PASSWORD_SOURCE": "password"},
+		"missing secret":    {"OPENOBSERVE_PASSWORD_SOURCE": "vault://secret/data/other#password", "VAULT_ADDR": vault.URL, "VAULT_TOKEN": "root"},
+		"invalid interval":  {"SECRET_REFRESH_INTERVAL": "soon"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			setEnvVars(t, validEnvVars())
+			setEnvVars(t, vars)
+			if _, err := LoadConfig(); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestLoadConfig_ShareLinks(t *testing.T) {
+	setEnvVars(t, validEnvVars())
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.ShareSigningKey != "" || cfg.ShareLinkMaxTTL != 24*time.Hour {
+		t.Errorf("unexpected share link defaults: %q, %v", cfg.ShareSigningKey, cfg.ShareLinkMaxTTL)
+	}
+
+	for name, vars := range map[string]map[string]string{
+		"short signing key": {"SHARE_SIGNING_KEY": "short"},
+		"invalid max ttl":   {"SHARE_LINK_MAX_TTL": "-1h"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			setEnvVars(t, vars)
+			if _, err := LoadConfig(); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestLoadConfig_ObserverSigningKey(t *testing.T) {
+	setEnvVars(t, validEnvVars())
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.ObserverSigningKey != "" {
+		t.Errorf("expected forwarded alerts not to be signed by default, got %q", cfg.ObserverSigningKey)
+	}
+
+	key := strings.Repeat("k", 32)
+	setEnvVars(t, map[string]string{"OBSERVER_SIGNING_KEY": key})
+	if cfg, err = LoadConfig(); err != nil || cfg.ObserverSigningKey != key {
+		t.Errorf("expected the signing key to be loaded, got %q, %v", cfg.ObserverSigningKey, err)
+	}
+
+	setEnvVars(t, map[string]string{"OBSERVER_SIGNING_KEY": "short"})
+	if _, err := LoadConfig(); err == nil {
+		t.Fatal("expected error for a short signing key, got nil")
+	}
+}
+
+func TestLoadConfig_QueryScheduling(t *testing.T) {
+	setEnvVars(t, validEnvVars())
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.QueryMaxConcurrency != 0 || cfg.QueryClassWeights[scheduler.ClassInteractive] != scheduler.DefaultWeights[scheduler.ClassInteractive] {
+		t.Errorf("unexpected scheduling defaults: %d, %v", cfg.QueryMaxConcurrency, cfg.QueryClassWeights)
+	}
+	if cfg.QueryPlanCacheSize != 1024 {
+		t.Errorf("unexpected plan cache size: %d", cfg.QueryPlanCacheSize)
+	}
+	if cfg.SLIPushInterval != 0 || cfg.SLIMetricPrefix != "logs_adapter_sli" {
+		t.Errorf("unexpected SLI push defaults: %v, %q", cfg.SLIPushInterval, cfg.SLIMetricPrefix)
+	}
+	if cfg.FollowMaxSessions != 0 {
+		t.Errorf("expected follows to be unlimited by default, got %d", cfg.FollowMaxSessions)
+	}
+	if cfg.QueryTimeBudget != 0 {
+		t.Errorf("expected searches to be unbounded by default, got %v", cfg.QueryTimeBudget)
+	}
+	if cfg.RetentionCacheTTL != time.Hour {
+		t.Errorf("unexpected retention cache TTL: %v", cfg.RetentionCacheTTL)
+	}
+
+	setEnvVars(t, map[string]string{"QUERY_MAX_CONCURRENCY": "16", "QUERY_CLASS_WEIGHTS": "export=2", "FOLLOW_MAX_SESSIONS": "5", "QUERY_TIME_BUDGET": "10s"})
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.QueryMaxConcurrency != 16 || cfg.QueryClassWeights[scheduler.ClassExport] != 2 {
+		t.Errorf("unexpected scheduling settings: %d, %v", cfg.QueryMaxConcurrency, cfg.QueryClassWeights)
+	}
+	if cfg.FollowMaxSessions != 5 {
+		t.Errorf("unexpected follow session limit: %d", cfg.FollowMaxSessions)
+	}
+	if cfg.QueryTimeBudget != 10*time.Second {
+		t.Errorf("unexpected query time budget: %v", cfg.QueryTimeBudget)
+	}
+
+	for name, vars := range map[string]map[string]string{
+		"negative concurrency": {"QUERY_MAX_CONCURRENCY": "-1"},
+		"negative follows":     {"FOLLOW_MAX_SESSIONS": "-1"},
+		"negative plan cache":  {"QUERY_PLAN_CACHE_SIZE": "-1"},
+		"short SLI interval":   {"SLI_PUSH_INTERVAL": "10ms"},
+		"invalid SLI prefix":   {"SLI_METRIC_PREFIX": "adapter-sli"},
+		"unknown class":        {"QUERY_CLASS_WEIGHTS": "batch=1"},
+		"negative time budget": {"QUERY_TIME_BUDGET": "-1s"},
+		"short retention TTL":  {"RETENTION_CACHE_TTL": "10s"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			setEnvVars(t, vars)
+			if _, err := LoadConfig(); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestLoadConfig_ResponseCache(t *testing.T) {
+	setEnvVars(t, validEnvVars())
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.ResponseCache.Backend != respcache.BackendNone {
+		t.Errorf("expected responses not to be cached by default, got %q", cfg.ResponseCache.Backend)
+	}
+
+	setEnvVars(t, map[string]string{
+		"RESPONSE_CACHE_BACKEND":        "redis",
+		"RESPONSE_CACHE_REDIS_URL":      "redis://cache:6379/1",
+		"RESPONSE_CACHE_REDIS_PASSWORD": "secret",
+		"RESPONSE_CACHE_RECENT_TTL":     "10s",
+		"RESPONSE_CACHE_COMPRESSION":    "gzip",
+	})
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := respcache.Config{
+		Backend:       respcache.BackendRedis,
+		MaxBytes:      64 << 20,
+		RedisURL:      "redis://cache:6379/1",
+		RedisPassword: "secret",
+		Compression:   respcache.CompressionGzip,
+		Policy:        respcache.Policy{RecentTTL: 10 * time.Second, HistoricalTTL: respcache.DefaultHistoricalTTL, Settle: respcache.DefaultSettle},
+	}
+	if cfg.ResponseCache != want {
+		t.Errorf("unexpected response cache settings: %+v", cfg.ResponseCache)
+	}
+
+	for name, vars := range map[string]map[string]string{
+		"unknown backend":   {"RESPONSE_CACHE_BACKEND": "memcached"},
+		"missing redis URL": {"RESPONSE_CACHE_BACKEND": "redis", "RESPONSE_CACHE_REDIS_URL": ""},
+		"zero memory":       {"RESPONSE_CACHE_BACKEND": "memory", "RESPONSE_CACHE_MEMORY_MB": "0"},
+		"invalid TTL":       {"RESPONSE_CACHE_HISTORICAL_TTL": "forever"},
+		"unknown codec":     {"RESPONSE_CACHE_COMPRESSION": "zstd"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			setEnvVars(t, vars)
+			if _, err := LoadConfig(); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestLoadConfig_RateLimit(t *testing.T) {
+	setEnvVars(t, validEnvVars())
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.RateLimit != (ratelimit.Config{}) {
+		t.Errorf("expected requests not to be limited by default, got %+v", cfg.RateLimit)
+	}
+
+	setEnvVars(t, map[string]string{
+		"RATE_LIMIT_RPS":           "2.5",
+		"RATE_LIMIT_BURST":         "10",
+		"RATE_LIMIT_MAX_IN_FLIGHT": "4",
+	})
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := (ratelimit.Config{RequestsPerSecond: 2.5, Burst: 10, MaxInFlight: 4}); cfg.RateLimit != want {
+		t.Errorf("unexpected rate limit settings: %+v", cfg.RateLimit)
+	}
+
+	for name, vars := range map[string]map[string]string{
+		"invalid rate":      {"RATE_LIMIT_RPS": "fast"},
+		"negative rate":     {"RATE_LIMIT_RPS": "-1"},
+		"negative burst":    {"RATE_LIMIT_BURST": "-1"},
+		"invalid in-flight": {"RATE_LIMIT_MAX_IN_FLIGHT": "many"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			setEnvVars(t, vars)
+			if _, err := LoadConfig(); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestLoadConfig_Audit(t *testing.T) {
+	setEnvVars(t, validEnvVars())
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Audit.Sink != audit.SinkNone {
+		t.Errorf("expected operations not to be audited by default, got %+v", cfg.Audit)
+	}
+
+	setEnvVars(t, map[string]string{
+		"AUDIT_LOG_SINK":   "stream",
+		"AUDIT_LOG_STREAM": "adapter_audit",
+	})
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := (audit.Config{Sink: audit.SinkStream, Stream: "adapter_audit"}); cfg.Audit != want {
+		t.Errorf("unexpected audit log settings: %+v", cfg.Audit)
+	}
+
+	for name, vars := range map[string]map[string]string{
+		"unknown sink":   {"AUDIT_LOG_SINK": "syslog"},
+		"invalid stream": {"AUDIT_LOG_SINK": "stream", "AUDIT_LOG_STREAM": "audit-log"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			setEnvVars(t, vars)
+			if _, err := LoadConfig(); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestLoadConfig_LogSortTiebreakers(t *testing.T) {
+	setEnvVars(t, validEnvVars())
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(cfg.LogSortTiebreakers, openobserve.DefaultSortTiebreakers) {
+		t.Errorf("unexpected default tiebreakers: %v", cfg.LogSortTiebreakers)
+	}
+
+	for value, want := range map[string][]string{
+		"kubernetes_pod_name, seq": {"kubernetes_pod_name", "seq"},
+		"none":                     {},
+	} {
+		setEnvVars(t, map[string]string{"LOG_SORT_TIEBREAKERS": value})
+		cfg, err := LoadConfig()
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %v", value, err)
+		}
+		if !slices.Equal(cfg.LogSortTiebreakers, want) {
+			t.Errorf("LOG_SORT_TIEBREAKERS=%q: got %v", value, cfg.LogSortTiebreakers)
+		}
+	}
+
+	setEnvVars(t, map[string]string{"LOG_SORT_TIEBREAKERS": "seq DESC"})
+	if _, err := LoadConfig(); err == nil {
+		t.Error("expected an error for an invalid tiebreaker")
+	}
+}
+
+func TestLoadConfig_LogFieldMapping(t *testing.T) {
+	setEnvVars(t, validEnvVars())
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.LogFieldMapping != openobserve.DefaultFieldMapping {
+		t.Errorf("expected the default field mapping, got %+v", cfg.LogFieldMapping)
+	}
+
+	setEnvVars(t, map[string]string{"LOG_FIELD_MAPPING": "componentUid=k8s_label_component_uid,level=severity"})
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.LogFieldMapping.ComponentUID != "k8s_label_component_uid" || cfg.LogFieldMapping.Level != "severity" {
+		t.Errorf("unexpected field mapping: %+v", cfg.LogFieldMapping)
+	}
+
+	setEnvVars(t, map[string]string{"LOG_FIELD_MAPPING": "component=x"})
+	if _, err := LoadConfig(); err == nil {
+		t.Error("expected an error for an unknown field")
+	}
+}
+
+func TestLoadConfig_LogStreamRoutes(t *testing.T) {
+	setEnvVars(t, validEnvVars())
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.LogStreamRoutes) != 0 {
+		t.Errorf("expected no stream routes, got %+v", cfg.LogStreamRoutes)
+	}
+
+	setEnvVars(t, map[string]string{"LOG_STREAM_ROUTES": "namespace:acme=acme_logs,environment:prod-uid=prod_logs|default"})
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.LogStreamRoutes) != 2 || cfg.LogStreamRoutes[1].EnvironmentID != "prod-uid" || len(cfg.LogStreamRoutes[1].Streams) != 2 {
+		t.Errorf("unexpected stream routes: %+v", cfg.LogStreamRoutes)
+	}
+
+	setEnvVars(t, map[string]string{"LOG_STREAM_ROUTES": "cluster:a=logs"})
+	if _, err := LoadConfig(); err == nil {
+		t.Error("expected an error for an unknown selector")
+	}
+}
+
+func TestLoadConfig_WorkflowBuildPlanes(t *testing.T) {
+	setEnvVars(t, validEnvVars())
+	setEnvVars(t, map[string]string{"WORKFLOW_BUILD_PLANES": "build-east=ci_logs, build-west=build_west/default"})
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.WorkflowBuildPlanes) != 2 || cfg.WorkflowBuildPlanes[1].Org != "build_west" || cfg.WorkflowBuildPlanes[1].Stream != "default" {
+		t.Errorf("unexpected build planes: %+v", cfg.WorkflowBuildPlanes)
+	}
+
+	setEnvVars(t, map[string]string{"WORKFLOW_BUILD_PLANES": "build-east=ci-logs"})
+	if _, err := LoadConfig(); err == nil {
+		t.Error("expected an error for an invalid stream name")
+	}
+}
+
+func TestLoadConfig_Rollups(t *testing.T) {
+	setEnvVars(t, validEnvVars())
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.RollupStream != "" || cfg.RollupInterval != time.Minute || cfg.RollupWindow != 24*time.Hour {
+		t.Errorf("unexpected rollup defaults: %q, %v, %v", cfg.RollupStream, cfg.RollupInterval, cfg.RollupWindow)
+	}
+
+	setEnvVars(t, map[string]string{"ROLLUP_STREAM": "level_rollups", "ROLLUP_WINDOW": "6h"})
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.RollupStream != "level_rollups" || cfg.RollupWindow != 6*time.Hour {
+		t.Errorf("unexpected rollup settings: %q, %v", cfg.RollupStream, cfg.RollupWindow)
+	}
+
+	for name, vars := range map[string]map[string]string{
+		"logs stream":      {"ROLLUP_STREAM": "default"},
+		"invalid stream":   {"ROLLUP_STREAM": "level-rollups"},
+		"short interval":   {"ROLLUP_INTERVAL": "5s"},
+		"short window":     {"ROLLUP_WINDOW": "30m"},
+		"invalid duration": {"ROLLUP_WINDOW": "a day"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			setEnvVars(t, validEnvVars())
+			setEnvVars(t, vars)
+			if _, err := LoadConfig(); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestLoadConfig_LokiBackend(t *testing.T) {
+	setEnvVars(t, validEnvVars())
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Backend != BackendOpenObserve {
+		t.Errorf("expected the openobserve backend by default, got %q", cfg.Backend)
+	}
+
+	setEnvVars(t, map[string]string{
+		"LOGS_BACKEND":         BackendLoki,
+		"LOKI_URL":             "http://loki-gateway:3100",
+		"LOKI_TENANT_ID":       "openchoreo",
+		"OPENOBSERVE_URL":      "",
+		"OPENOBSERVE_USER":     "",
+		"OPENOBSERVE_PASSWORD": "",
+	})
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.LokiURL != "http://loki-gateway:3100" || cfg.LokiTenantID != "openchoreo" || cfg.LokiRulerNamespace != "openchoreo" {
+		t.Errorf("unexpected Loki settings: %q, %q, %q", cfg.LokiURL, cfg.LokiTenantID, cfg.LokiRulerNamespace)
+	}
+
+	for name, vars := range map[string]map[string]string{
+		"unknown backend": {"LOGS_BACKEND": "elasticsearch"},
+		"missing url":     {"LOGS_BACKEND": BackendLoki, "LOKI_URL": ""},
+		"invalid url":     {"LOGS_BACKEND": BackendLoki, "LOKI_URL": "loki:3100"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			setEnvVars(t, validEnvVars())
+			setEnvVars(t, vars)
+			if _, err := LoadConfig(); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestLoadConfig_Warmup(t *testing.T) {
+	setEnvVars(t, validEnvVars())
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.WarmupTasks) != 0 || cfg.WarmupConnections != 2 || cfg.WarmupTimeout != 30*time.Second {
+		t.Errorf("unexpected warm-up defaults: %v, %d, %v", cfg.WarmupTasks, cfg.WarmupConnections, cfg.WarmupTimeout)
+	}
+
+	setEnvVars(t, map[string]string{"WARMUP_TASKS": "connections, histograms", "WARMUP_NAMESPACES": "payments"})
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.WarmupTasks) != 2 || cfg.WarmupTasks[1] != WarmupHistograms || cfg.WarmupNamespaces[0] != "payments" {
+		t.Errorf("unexpected warm-up settings: %v, %v", cfg.WarmupTasks, cfg.WarmupNamespaces)
+	}
+
+	for name, vars := range map[string]map[string]string{
+		"unknown task":            {"WARMUP_TASKS": "caches"},
+		"histograms without keys": {"WARMUP_TASKS": "histograms", "WARMUP_NAMESPACES": " "},
+		"no connections":          {"WARMUP_CONNECTIONS": "0"},
+		"invalid timeout":         {"WARMUP_TIMEOUT": "soon"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			setEnvVars(t, vars)
+			if _, err := LoadConfig(); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestLoadConfig_OpenObserveRetry(t *testing.T) {
+	setEnvVars(t, validEnvVars())
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.OpenObserveRetry != openobserve.DefaultRetryPolicy {
+		t.Errorf("unexpected retry defaults: %+v", cfg.OpenObserveRetry)
+	}
+
+	setEnvVars(t, map[string]string{"OPENOBSERVE_RETRY_MAX_ATTEMPTS": "1", "OPENOBSERVE_BREAKER_THRESHOLD": "0"})
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.OpenObserveRetry.MaxAttempts != 1 || cfg.OpenObserveRetry.BreakerThreshold != 0 {
+		t.Errorf("unexpected retry settings: %+v", cfg.OpenObserveRetry)
+	}
+
+	for name, vars := range map[string]map[string]string{
+		"no attempts":           {"OPENOBSERVE_RETRY_MAX_ATTEMPTS": "0"},
+		"invalid backoff":       {"OPENOBSERVE_RETRY_INITIAL_BACKOFF": "soon"},
+		"max below initial":     {"OPENOBSERVE_RETRY_INITIAL_BACKOFF": "5s", "OPENOBSERVE_RETRY_MAX_BACKOFF": "1s"},
+		"negative threshold":    {"OPENOBSERVE_BREAKER_THRESHOLD": "-1"},
+		"non-positive cooldown": {"OPENOBSERVE_BREAKER_COOLDOWN": "0s"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			setEnvVars(t, vars)
+			if _, err := LoadConfig(); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestLoadConfig_LogFormats(t *testing.T) {
+	setEnvVars(t, validEnvVars())
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.LogFormatDetectors) != len(formats.Names()) || len(cfg.LogFormatComponents) != 0 {
+		t.Errorf("unexpected log format defaults: %v, %v", cfg.LogFormatDetectors, cfg.LogFormatComponents)
+	}
+
+	setEnvVars(t, map[string]string{"LOG_FORMAT_DETECTORS": "none", "LOG_FORMAT_COMPONENTS": "orders=springboot"})
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.LogFormatDetectors[0] != formats.None || cfg.LogFormatComponents["orders"] != formats.SpringBoot {
+		t.Errorf("unexpected log format settings: %v, %v", cfg.LogFormatDetectors, cfg.LogFormatComponents)
+	}
+
+	for name, vars := range map[string]map[string]string{
+		"unknown detector":           {"LOG_FORMAT_DETECTORS": "log4j"},
+		"unknown component detector": {"LOG_FORMAT_COMPONENTS": "orders=log4j"},
+		"malformed component":        {"LOG_FORMAT_COMPONENTS": "orders"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			setEnvVars(t, vars)
+			if _, err := LoadConfig(); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+}
+
 func TestLoadConfig_InvalidServerPort(t *testing.T) {
 	vars := validEnvVars()
 	vars["SERVER_PORT"] = "not-a-number"
@@ -161,6 +726,243 @@ func TestLoadConfig_InvalidServerPort(t *testing.T) {
 	}
 }
 
+func TestLoadConfig_Export(t *testing.T) {
+	t.Run("disabled by default", func(t *testing.T) {
+		setEnvVars(t, validEnvVars())
+		cfg, err := LoadConfig()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cfg.ExportBucket != "" {
+			t.Errorf("expected export to be disabled, got bucket %q", cfg.ExportBucket)
+		}
+	})
+
+	t.Run("bucket requires credentials", func(t *testing.T) {
+		vars := validEnvVars()
+		vars["EXPORT_BUCKET"] = "compliance"
+		setEnvVars(t, vars)
+		if _, err := LoadConfig(); err == nil {
+			t.Fatal("expected error for EXPORT_BUCKET without credentials, got nil")
+		}
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		vars := validEnvVars()
+		vars["EXPORT_BUCKET"] = "compliance"
+		vars["EXPORT_ACCESS_KEY_ID"] = "key"
+		vars["EXPORT_SECRET_ACCESS_KEY"] = "secret"
+		setEnvVars(t, vars)
+		cfg, err := LoadConfig()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cfg.ExportEndpoint != "https://s3.amazonaws.com" || cfg.ExportRegion != "us-east-1" || cfg.ExportPrefix != "openchoreo-logs" {
+			t.Errorf("unexpected export defaults: %+v", cfg)
+		}
+	})
+}
+
+func TestLoadConfig_Holds(t *testing.T) {
+	t.Run("requires export bucket", func(t *testing.T) {
+		vars := validEnvVars()
+		vars["HOLD_STORE_PATH"] = "/data/holds.json"
+		setEnvVars(t, vars)
+		if _, err := LoadConfig(); err == nil {
+			t.Fatal("expected error for HOLD_STORE_PATH without EXPORT_BUCKET, got nil")
+		}
+	})
+
+	t.Run("invalid object lock flag", func(t *testing.T) {
+		vars := validEnvVars()
+		vars["EXPORT_OBJECT_LOCK_LEGAL_HOLD"] = "maybe"
+		setEnvVars(t, vars)
+		if _, err := LoadConfig(); err == nil {
+			t.Fatal("expected error for invalid EXPORT_OBJECT_LOCK_LEGAL_HOLD, got nil")
+		}
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		vars := validEnvVars()
+		vars["EXPORT_BUCKET"] = "compliance"
+		vars["EXPORT_ACCESS_KEY_ID"] = "key"
+		vars["EXPORT_SECRET_ACCESS_KEY"] = "secret"
+		vars["EXPORT_OBJECT_LOCK_LEGAL_HOLD"] = "true"
+		vars["HOLD_STORE_PATH"] = "/data/holds.json"
+		setEnvVars(t, vars)
+		cfg, err := LoadConfig()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !cfg.ExportObjectLock || cfg.HoldStorePath != "/data/holds.json" {
+			t.Errorf("unexpected hold config: %+v", cfg)
+		}
+	})
+}
+
+func TestLoadConfig_AnnotationStorePath(t *testing.T) {
+	vars := validEnvVars()
+	setEnvVars(t, vars)
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.AnnotationStorePath != "" {
+		t.Errorf("expected annotations to be disabled by default, got %q", cfg.AnnotationStorePath)
+	}
+
+	vars["ANNOTATION_STORE_PATH"] = "/data/annotations.json"
+	setEnvVars(t, vars)
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.AnnotationStorePath != "/data/annotations.json" {
+		t.Errorf("unexpected AnnotationStorePath: %q", cfg.AnnotationStorePath)
+	}
+}
+
+func TestLoadConfig_PresetStorePath(t *testing.T) {
+	vars := validEnvVars()
+	setEnvVars(t, vars)
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.PresetStorePath != "" {
+		t.Errorf("expected query presets to be disabled by default, got %q", cfg.PresetStorePath)
+	}
+
+	vars["PRESET_STORE_PATH"] = "/data/presets.json"
+	setEnvVars(t, vars)
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.PresetStorePath != "/data/presets.json" {
+		t.Errorf("unexpected PresetStorePath: %q", cfg.PresetStorePath)
+	}
+}
+
+func TestLoadConfig_AlertDrift(t *testing.T) {
+	vars := validEnvVars()
+	setEnvVars(t, vars)
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.AlertRuleStorePath != "" || cfg.AlertDriftCheckInterval != 5*time.Minute {
+		t.Errorf("unexpected default alert drift config: %q, %v", cfg.AlertRuleStorePath, cfg.AlertDriftCheckInterval)
+	}
+
+	vars["ALERT_RULE_STORE_PATH"] = "/data/alert-rules.json"
+	vars["ALERT_DRIFT_CHECK_INTERVAL"] = "1m"
+	setEnvVars(t, vars)
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.AlertRuleStorePath != "/data/alert-rules.json" || cfg.AlertDriftCheckInterval != time.Minute {
+		t.Errorf("unexpected alert drift config: %q, %v", cfg.AlertRuleStorePath, cfg.AlertDriftCheckInterval)
+	}
+
+	vars["ALERT_DRIFT_CHECK_INTERVAL"] = "10ms"
+	setEnvVars(t, vars)
+	if _, err := LoadConfig(); err == nil {
+		t.Error("expected an error for an interval under 1s")
+	}
+}
+
+func TestLoadConfig_RequireTenancyHeader(t *testing.T) {
+	vars := validEnvVars()
+	setEnvVars(t, vars)
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.RequireTenancyHeader {
+		t.Error("expected the tenancy header to be optional by default")
+	}
+
+	vars["REQUIRE_TENANCY_HEADER"] = "true"
+	setEnvVars(t, vars)
+	if cfg, err = LoadConfig(); err != nil || !cfg.RequireTenancyHeader {
+		t.Errorf("expected the tenancy header to be required, got %v", err)
+	}
+
+	vars["REQUIRE_TENANCY_HEADER"] = "sometimes"
+	setEnvVars(t, vars)
+	if _, err := LoadConfig(); err == nil {
+		t.Error("expected an error for an invalid REQUIRE_TENANCY_HEADER")
+	}
+}
+
+func TestLoadConfig_TracesStreamDiscovery(t *testing.T) {
+	vars := validEnvVars()
+	setEnvVars(t, vars)
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.TracesStreamDiscovery {
+		t.Error("expected traces stream discovery to be disabled by default")
+	}
+
+	vars["TRACES_STREAM_DISCOVERY"] = "true"
+	setEnvVars(t, vars)
+	if cfg, err = LoadConfig(); err != nil || !cfg.TracesStreamDiscovery {
+		t.Errorf("expected traces stream discovery to be enabled, got %v", err)
+	}
+
+	vars["TRACES_STREAM_DISCOVERY"] = "auto"
+	setEnvVars(t, vars)
+	if _, err := LoadConfig(); err == nil {
+		t.Error("expected an error for an invalid TRACES_STREAM_DISCOVERY")
+	}
+}
+
+func TestLoadConfig_StrictHitValidation(t *testing.T) {
+	vars := validEnvVars()
+	setEnvVars(t, vars)
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.StrictHitValidation {
+		t.Error("expected hits not to be validated by default")
+	}
+
+	vars["STRICT_HIT_VALIDATION"] = "true"
+	setEnvVars(t, vars)
+	if cfg, err = LoadConfig(); err != nil || !cfg.StrictHitValidation {
+		t.Errorf("expected hits to be validated, got %v", err)
+	}
+
+	vars["STRICT_HIT_VALIDATION"] = "strict"
+	setEnvVars(t, vars)
+	if _, err := LoadConfig(); err == nil {
+		t.Error("expected an error for an invalid STRICT_HIT_VALIDATION")
+	}
+}
+
+func TestLoadConfig_AlertDestinations(t *testing.T) {
+	vars := validEnvVars()
+	vars["ALERT_DESTINATIONS_CRITICAL"] = "openchoreo, pagerduty"
+	setEnvVars(t, vars)
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string][]string{
+		"critical": {"openchoreo", "pagerduty"},
+		"warning":  {"openchoreo"},
+		"info":     {"openchoreo"},
+	}
+	if !reflect.DeepEqual(cfg.AlertDestinations, want) {
+		t.Errorf("AlertDestinations = %v, want %v", cfg.AlertDestinations, want)
+	}
+}
+
 func TestLoadConfig_InvalidObserverURL(t *testing.T) {
 	tests := []struct {
 		name string
@@ -184,6 +986,77 @@ func TestLoadConfig_InvalidObserverURL(t *testing.T) {
 	}
 }
 
+func TestLoadConfig_Auth(t *testing.T) {
+	vars := validEnvVars()
+	setEnvVars(t, vars)
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Auth.Mode != auth.ModeNone || !reflect.DeepEqual(cfg.Auth.ExemptPaths, []string{"/health"}) {
+		t.Errorf("unexpected auth defaults: %+v", cfg.Auth)
+	}
+
+	vars["AUTH_MODE"] = "token"
+	vars["AUTH_TOKEN"] = "0123456789abcdef"
+	vars["AUTH_EXEMPT_PATHS"] = "/health,/metrics"
+	setEnvVars(t, vars)
+	if cfg, err = LoadConfig(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Auth.Token != "0123456789abcdef" || !reflect.DeepEqual(cfg.Auth.ExemptPaths, []string{"/health", "/metrics"}) {
+		t.Errorf("unexpected auth settings: %+v", cfg.Auth)
+	}
+
+	vars["AUTH_TOKEN"] = "short"
+	setEnvVars(t, vars)
+	if _, err := LoadConfig(); err == nil {
+		t.Error("expected an error for a short AUTH_TOKEN")
+	}
+
+	vars["AUTH_MODE"] = "jwt"
+	setEnvVars(t, vars)
+	if _, err := LoadConfig(); err == nil {
+		t.Error("expected an error for a missing AUTH_JWKS_URL")
+	}
+}
+
+func TestLoadConfig_ShadowLog(t *testing.T) {
+	vars := validEnvVars()
+	setEnvVars(t, vars)
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.ShadowLogPath != "" || cfg.ShadowLogRetention.MaxRecords != 10000 || cfg.ShadowLogRetention.MaxAge != 168*time.Hour {
+		t.Errorf("unexpected shadow log defaults: %q %+v", cfg.ShadowLogPath, cfg.ShadowLogRetention)
+	}
+
+	vars["SHADOW_LOG_PATH"] = "/data/shadow.ndjson"
+	vars["SHADOW_LOG_MAX_RECORDS"] = "500"
+	vars["SHADOW_LOG_MAX_AGE"] = "0"
+	setEnvVars(t, vars)
+	if cfg, err = LoadConfig(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.ShadowLogPath != "/data/shadow.ndjson" || cfg.ShadowLogRetention.MaxRecords != 500 || cfg.ShadowLogRetention.MaxAge != 0 {
+		t.Errorf("unexpected shadow log settings: %q %+v", cfg.ShadowLogPath, cfg.ShadowLogRetention)
+	}
+
+	vars["SHADOW_LOG_MAX_RECORDS"] = "0"
+	setEnvVars(t, vars)
+	if _, err := LoadConfig(); err == nil {
+		t.Error("expected an error for SHADOW_LOG_MAX_RECORDS 0")
+	}
+
+	vars["SHADOW_LOG_MAX_RECORDS"] = "500"
+	vars["SHADOW_LOG_MAX_AGE"] = "-1h"
+	setEnvVars(t, vars)
+	if _, err := LoadConfig(); err == nil {
+		t.Error("expected an error for a negative SHADOW_LOG_MAX_AGE")
+	}
+}
+
 func TestGetEnv(t *testing.T) {
 	t.Setenv("TEST_GET_ENV_EXISTS", "value")
 
@@ -194,3 +1067,40 @@ func TestGetEnv(t *testing.T) {
 		t.Errorf("expected 'default', got %q", got)
 	}
 }
+
+func TestLoadConfig_SecondaryCredentials(t *testing.T) {
+	setEnvVars(t, validEnvVars())
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.SecondaryUser != "admin" || cfg.SecondaryPassword != "" {
+		t.Errorf("expected no secondary password and the primary user, got %q, %q", cfg.SecondaryUser, cfg.SecondaryPassword)
+	}
+
+	t.Setenv("OPENOBSERVE_SECONDARY_USER", "rotation")
+	t.Setenv("OPENOBSERVE_SECONDARY_PASSWORD", "next")
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.SecondaryUser != "rotation" || cfg.SecondaryPassword != "next" {
+		t.Errorf("unexpected secondary credentials: %q, %q", cfg.SecondaryUser, cfg.SecondaryPassword)
+	}
+}
+
+func TestLoadConfig_SlowQueryThreshold(t *testing.T) {
+	setEnvVars(t, validEnvVars())
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.SlowQueryThreshold != 2*time.Second {
+		t.Errorf("expected a default of 2s, got %v", cfg.SlowQueryThreshold)
+	}
+
+	t.Setenv("SLOW_QUERY_THRESHOLD", "0s")
+	if _, err := LoadConfig(); err == nil {
+		t.Error("expected an error for a zero threshold")
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openchoreo/community-modules/common/access"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+func TestQueryDebug(t *testing.T) {
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(openobserve.OpenObserveResponse{Hits: []map[string]interface{}{}})
+	}))
+	defer ooServer.Close()
+
+	t.Setenv("SRE_TOKEN", "sre-token")
+	t.Setenv("DASH_TOKEN", "dash-token")
+	policy, err := access.NewPolicy(access.File{
+		Callers: []access.Caller{
+			{Name: "sre", TokenEnv: "SRE_TOKEN"},
+			{Name: "dashboards", TokenEnv: "DASH_TOKEN"},
+		},
+		Admins: []string{"sre"},
+	})
+	if err != nil {
+		t.Fatalf("NewPolicy() error = %v", err)
+	}
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	handler := NewLogsHandler(client, nil, testLogger())
+	handler.SetAccessPolicy(policy)
+	srv := NewServer("0", handler, testLogger()).httpServer.Handler
+
+	serve := func(token string, header http.Header) *httptest.ResponseRecorder {
+		body := `{"startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-02T00:00:00Z","searchScope":{"namespace":"payments"}}`
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/logs/query", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		for name, values := range header {
+			for _, value := range values {
+				req.Header.Add(name, value)
+			}
+		}
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("admins get the queries", func(t *testing.T) {
+		rec := serve("sre-token", http.Header{DebugQueryHeader: {"true"}, RequestIDHeader: {"req-42"}})
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var got struct {
+			Logs         []any                    `json:"logs"`
+			DebugQueries []openobserve.DebugQuery `json:"debugQueries"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(got.DebugQueries) == 0 || !strings.Contains(got.DebugQueries[0].SQL, "payments") || got.Logs == nil {
+			t.Errorf("expected the response to list the generated SQL, got %s", rec.Body.String())
+		}
+		if rec.Header().Get(RequestIDHeader) != "req-42" || rec.Header().Get("Cache-Control") != "no-store" {
+			t.Errorf("expected the request ID and no-store, got %v", rec.Header())
+		}
+	})
+
+	t.Run("requests without the header are unchanged", func(t *testing.T) {
+		rec := serve("dash-token", nil)
+		if rec.Code != http.StatusOK || strings.Contains(rec.Body.String(), "debugQueries") || rec.Header().Get(RequestIDHeader) != "" {
+			t.Errorf("expected a plain response, got %d with %v: %s", rec.Code, rec.Header(), rec.Body.String())
+		}
+	})
+
+	t.Run("other callers are rejected", func(t *testing.T) {
+		if rec := serve("dash-token", http.Header{DebugQueryHeader: {"true"}}); rec.Code != http.StatusForbidden {
+			t.Errorf("expected 403, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("invalid values are rejected", func(t *testing.T) {
+		if rec := serve("sre-token", http.Header{DebugQueryHeader: {"yes please"}}); rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", rec.Code)
+		}
+	})
+}
@@ -0,0 +1,48 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/diagnostics"
+)
+
+// withDiagnostics records the routed requests that fail in journal, for
+// support bundles. Like withShadowLogging, it must be given the request the
+// mux is given, as the route pattern is read from it. Requests are passed
+// through untouched when no journal is set.
+func withDiagnostics(journal *diagnostics.Journal, next http.Handler) http.Handler {
+	if journal == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		start := time.Now()
+		next.ServeHTTP(sw, r)
+		if sw.status < http.StatusBadRequest || r.Pattern == "" {
+			return
+		}
+		_, route, found := strings.Cut(r.Pattern, " ")
+		if !found {
+			route = r.Pattern
+		}
+		var params []string
+		for name := range r.URL.Query() {
+			params = append(params, name)
+		}
+		slices.Sort(params)
+		journal.ObserveRequest(diagnostics.FailedRequest{
+			Time:        start.UTC(),
+			Method:      r.Method,
+			Route:       route,
+			Status:      sw.status,
+			DurationMs:  time.Since(start).Milliseconds(),
+			QueryParams: params,
+		})
+	})
+}
@@ -0,0 +1,143 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package diagnostics gathers what a bug report about the adapter needs:
+// the slow OpenObserve calls and failed requests it has recently seen, its
+// build version and its configuration with the secrets redacted. Nothing
+// from the bodies of the requests or of the calls is kept.
+package diagnostics
+
+import (
+	"sync"
+	"time"
+
+	ooclient "github.com/openchoreo/community-modules/common/openobserve"
+)
+
+// DefaultCapacity is the number of slow calls and of failed requests a
+// Journal keeps.
+const DefaultCapacity = 100
+
+// BackendCall is an OpenObserve call that took longer than the slow
+// threshold of the journal.
+type BackendCall struct {
+	Time   time.Time `json:"time"`
+	Method string    `json:"method"`
+	Path   string    `json:"path"`
+	// StatusCode is zero when the call failed without a response.
+	StatusCode int    `json:"statusCode,omitempty"`
+	DurationMs int64  `json:"durationMs"`
+	Error      string `json:"error,omitempty"`
+}
+
+// FailedRequest is a request served by the adapter with a 4xx or 5xx status.
+type FailedRequest struct {
+	Time   time.Time `json:"time"`
+	Method string    `json:"method"`
+	// Route is the pattern of the route the request was served by.
+	Route      string `json:"route"`
+	Status     int    `json:"status"`
+	DurationMs int64  `json:"durationMs"`
+	// QueryParams are the names of the query parameters, without values.
+	QueryParams []string `json:"queryParams,omitempty"`
+}
+
+// Journal keeps the most recent slow OpenObserve calls and failed requests.
+// It is safe for concurrent use.
+type Journal struct {
+	slowThreshold time.Duration
+	now           func() time.Time
+
+	mu     sync.Mutex
+	slow   ring[BackendCall]
+	failed ring[FailedRequest]
+}
+
+// NewJournal returns a Journal keeping the last capacity calls to
+// OpenObserve that took at least slowThreshold, and the last capacity
+// failed requests.
+func NewJournal(slowThreshold time.Duration, capacity int) *Journal {
+	return &Journal{
+		slowThreshold: slowThreshold,
+		now:           time.Now,
+		slow:          newRing[BackendCall](capacity),
+		failed:        newRing[FailedRequest](capacity),
+	}
+}
+
+// SlowThreshold returns the duration from which calls are kept.
+func (j *Journal) SlowThreshold() time.Duration {
+	return j.slowThreshold
+}
+
+// ObserveBackend records info if the call was slow. It is an
+// ooclient.RequestObserver.
+func (j *Journal) ObserveBackend(info ooclient.RequestInfo) {
+	if info.Duration < j.slowThreshold {
+		return
+	}
+	call := BackendCall{
+		Time:       j.now().UTC().Add(-info.Duration),
+		Method:     info.Method,
+		Path:       info.Path,
+		StatusCode: info.StatusCode,
+		DurationMs: info.Duration.Milliseconds(),
+	}
+	if info.Err != nil {
+		call.Error = info.Err.Error()
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.slow.add(call)
+}
+
+// ObserveRequest records req if it failed.
+func (j *Journal) ObserveRequest(req FailedRequest) {
+	if req.Status < 400 {
+		return
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.failed.add(req)
+}
+
+// SlowCalls returns the slow calls kept, oldest first.
+func (j *Journal) SlowCalls() []BackendCall {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.slow.list()
+}
+
+// FailedRequests returns the failed requests kept, oldest first.
+func (j *Journal) FailedRequests() []FailedRequest {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.failed.list()
+}
+
+// ring holds the last items added to it.
+type ring[T any] struct {
+	items []T
+	next  int
+	full  bool
+}
+
+func newRing[T any](capacity int) ring[T] {
+	return ring[T]{items: make([]T, max(capacity, 1))}
+}
+
+func (r *ring[T]) add(item T) {
+	r.items[r.next] = item
+	r.next = (r.next + 1) % len(r.items)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// list returns the items, oldest first.
+func (r *ring[T]) list() []T {
+	if !r.full {
+		return append([]T{}, r.items[:r.next]...)
+	}
+	return append(append([]T{}, r.items[r.next:]...), r.items[:r.next]...)
+}
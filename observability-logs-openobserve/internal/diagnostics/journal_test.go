@@ -0,0 +1,55 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package diagnostics
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	ooclient "github.com/openchoreo/community-modules/common/openobserve"
+)
+
+func TestJournal_SlowCalls(t *testing.T) {
+	j := NewJournal(time.Second, 2)
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	j.now = func() time.Time { return now }
+
+	j.ObserveBackend(ooclient.RequestInfo{Method: http.MethodPost, Path: "/fast", Duration: 10 * time.Millisecond})
+	for i, path := range []string{"/a", "/b", "/c"} {
+		j.ObserveBackend(ooclient.RequestInfo{
+			Method:     http.MethodPost,
+			Path:       path,
+			StatusCode: http.StatusOK,
+			Duration:   time.Duration(i+1) * time.Second,
+		})
+	}
+	j.ObserveBackend(ooclient.RequestInfo{Method: http.MethodGet, Path: "/d", Duration: 5 * time.Second, Err: errors.New("timeout")})
+
+	calls := j.SlowCalls()
+	if len(calls) != 2 || calls[0].Path != "/c" || calls[1].Path != "/d" {
+		t.Fatalf("expected the last 2 slow calls, oldest first, got %+v", calls)
+	}
+	if calls[0].DurationMs != 3000 || !calls[0].Time.Equal(now.Add(-3*time.Second)) {
+		t.Errorf("expected the call to start 3s before it was observed, got %+v", calls[0])
+	}
+	if calls[1].Error != "timeout" || calls[1].StatusCode != 0 {
+		t.Errorf("expected the error of the failed call, got %+v", calls[1])
+	}
+}
+
+func TestJournal_FailedRequests(t *testing.T) {
+	j := NewJournal(time.Second, 3)
+	if got := j.FailedRequests(); got == nil || len(got) != 0 {
+		t.Fatalf("expected no failed requests, got %#v", got)
+	}
+	for _, status := range []int{http.StatusOK, http.StatusBadRequest, http.StatusNotModified, http.StatusBadGateway} {
+		j.ObserveRequest(FailedRequest{Method: http.MethodGet, Route: "/r", Status: status})
+	}
+	got := j.FailedRequests()
+	if len(got) != 2 || got[0].Status != http.StatusBadRequest || got[1].Status != http.StatusBadGateway {
+		t.Errorf("expected the 4xx and 5xx requests only, got %+v", got)
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package diagnostics
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"runtime/debug"
+	"strings"
+	"time"
+)
+
+// Redacted replaces the values of secret settings.
+const Redacted = "[REDACTED]"
+
+// secretWords mark the names of the settings whose values are redacted.
+var secretWords = []string{"password", "secret", "token", "key"}
+
+// RedactConfig returns the exported fields of the struct cfg points to, by
+// name, with the values of secret fields replaced by Redacted. A field is
+// secret when its name contains password, secret, token or key; secret
+// fields left empty stay empty, so that a report tells unset from set.
+// Nested structs are redacted the same way. Durations, which are never
+// secret, are written as strings such as "5m0s".
+func RedactConfig(cfg any) map[string]any {
+	v := reflect.Indirect(reflect.ValueOf(cfg))
+	if v.Kind() != reflect.Struct {
+		return map[string]any{}
+	}
+	return redactStruct(v)
+}
+
+func redactStruct(v reflect.Value) map[string]any {
+	out := make(map[string]any, v.NumField())
+	for i := range v.NumField() {
+		field := v.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		value := v.Field(i)
+		switch {
+		case field.Type == reflect.TypeOf(time.Duration(0)):
+			out[field.Name] = time.Duration(value.Int()).String()
+		case isSecret(field.Name):
+			if value.IsZero() {
+				out[field.Name] = ""
+			} else {
+				out[field.Name] = Redacted
+			}
+		case value.Kind() == reflect.Struct && !field.Type.Implements(marshalerType) && !field.Type.Implements(textMarshalerType):
+			out[field.Name] = redactStruct(value)
+		default:
+			if _, err := json.Marshal(value.Interface()); err != nil {
+				out[field.Name] = fmt.Sprint(value.Interface())
+			} else {
+				out[field.Name] = value.Interface()
+			}
+		}
+	}
+	return out
+}
+
+var (
+	marshalerType     = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+func isSecret(name string) bool {
+	name = strings.ToLower(name)
+	for _, word := range secretWords {
+		if strings.Contains(name, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// Version describes the build of the adapter.
+type Version struct {
+	GoVersion string `json:"goVersion"`
+	Module    string `json:"module,omitempty"`
+	// Version is the module version, "(devel)" for local builds.
+	Version      string `json:"version,omitempty"`
+	Revision     string `json:"revision,omitempty"`
+	RevisionTime string `json:"revisionTime,omitempty"`
+	// Modified is set when the build had uncommitted changes.
+	Modified bool `json:"modified,omitempty"`
+}
+
+// ReadVersion returns the version of the running binary, from the build
+// information embedded by the Go toolchain.
+func ReadVersion() Version {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return Version{GoVersion: "unknown"}
+	}
+	version := Version{
+		GoVersion: info.GoVersion,
+		Module:    info.Main.Path,
+		Version:   info.Main.Version,
+	}
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			version.Revision = setting.Value
+		case "vcs.time":
+			version.RevisionTime = setting.Value
+		case "vcs.modified":
+			version.Modified = setting.Value == "true"
+		}
+	}
+	return version
+}
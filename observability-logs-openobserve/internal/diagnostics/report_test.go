@@ -0,0 +1,59 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package diagnostics
+
+import (
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestRedactConfig(t *testing.T) {
+	type authConfig struct {
+		Mode  string
+		Token string
+	}
+	type config struct {
+		OpenObserveURL        string
+		OpenObservePassword   string
+		ExportSecretAccessKey string
+		ShareSigningKey       string
+		SecretRefreshInterval time.Duration
+		LogLevel              slog.Level
+		Streams               []string
+		Auth                  authConfig
+		unexported            string
+	}
+	cfg := &config{
+		OpenObserveURL:        "http://openobserve:5080",
+		OpenObservePassword:   "hunter2",
+		SecretRefreshInterval: 5 * time.Minute,
+		LogLevel:              slog.LevelWarn,
+		Streams:               []string{"default"},
+		Auth:                  authConfig{Mode: "token", Token: "t0ken"},
+		unexported:            "hidden",
+	}
+
+	data, err := json.Marshal(RedactConfig(cfg))
+	if err != nil {
+		t.Fatalf("failed to encode: %v", err)
+	}
+	want := `{"Auth":{"Mode":"token","Token":"[REDACTED]"},"ExportSecretAccessKey":"","LogLevel":"WARN",` +
+		`"OpenObservePassword":"[REDACTED]","OpenObserveURL":"http://openobserve:5080",` +
+		`"SecretRefreshInterval":"5m0s","ShareSigningKey":"","Streams":["default"]}`
+	if string(data) != want {
+		t.Errorf("unexpected redacted config:\n got %s\nwant %s", data, want)
+	}
+
+	if got := RedactConfig("not a struct"); len(got) != 0 {
+		t.Errorf("expected nothing for a non-struct, got %v", got)
+	}
+}
+
+func TestReadVersion(t *testing.T) {
+	if v := ReadVersion(); v.GoVersion == "" {
+		t.Errorf("expected the Go version, got %+v", v)
+	}
+}
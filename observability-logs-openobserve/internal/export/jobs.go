@@ -0,0 +1,332 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package export runs asynchronous log export jobs that write query results
+// to object storage instead of returning them over HTTP.
+package export
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/scheduler"
+)
+
+// Status is the lifecycle state of an export job.
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusRunning   Status = "running"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+)
+
+const (
+	// pageSize is the number of log entries fetched from OpenObserve per query.
+	pageSize = 1000
+	// maxObjectBytes is the size at which an NDJSON object is flushed and a new part started.
+	maxObjectBytes = 64 << 20
+	// jobRetention is how long finished jobs stay queryable.
+	jobRetention = 24 * time.Hour
+
+	ndjsonContentType = "application/x-ndjson"
+	jsonContentType   = "application/json"
+)
+
+// LogsSource fetches component logs. It is implemented by *openobserve.Client.
+type LogsSource interface {
+	GetComponentLogs(ctx context.Context, params openobserve.ComponentLogsParams) (*openobserve.ComponentLogsResult, error)
+}
+
+// Options customizes an export job.
+type Options struct {
+	// KeyPrefix replaces the manager's prefix for the objects of this job.
+	KeyPrefix string
+	// LegalHold places an Object Lock legal hold on every object written.
+	LegalHold bool
+	// Manifest writes a manifest.json listing every object with its SHA-256
+	// checksum under the key prefix once all objects are written.
+	Manifest bool
+	// OnComplete, if set, is called with the final job once it has succeeded or failed.
+	OnComplete func(Job)
+}
+
+// Object describes an object written by an export job.
+type Object struct {
+	Key    string `json:"key"`
+	Bytes  int    `json:"bytes"`
+	SHA256 string `json:"sha256"`
+}
+
+// Job is the status of an export job.
+type Job struct {
+	ID          string     `json:"id"`
+	Status      Status     `json:"status"`
+	Namespace   string     `json:"namespace"`
+	StartTime   time.Time  `json:"startTime"`
+	EndTime     time.Time  `json:"endTime"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+	// Records is the number of log entries written so far.
+	Records int `json:"records"`
+	// Objects lists the objects written so far.
+	Objects []Object `json:"objects"`
+	// Manifest is the key of the manifest object, once written.
+	Manifest string `json:"manifest,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Manager runs export jobs and tracks their status in memory.
+type Manager struct {
+	source LogsSource
+	store  ObjectStore
+	prefix string
+	logger *slog.Logger
+
+	mu   sync.Mutex
+	jobs map[string]*Job
+}
+
+// NewManager returns a Manager that reads logs from source and writes objects
+// under prefix in store.
+func NewManager(source LogsSource, store ObjectStore, prefix string, logger *slog.Logger) *Manager {
+	return &Manager{
+		source: source,
+		store:  store,
+		prefix: prefix,
+		logger: logger,
+		jobs:   make(map[string]*Job),
+	}
+}
+
+// Prefix returns the key prefix under which jobs write their objects by default.
+func (m *Manager) Prefix() string {
+	return m.prefix
+}
+
+// Submit starts an export of the component logs matching params and returns
+// the new job. Limit, Offset and sort settings in params are ignored: all
+// matching entries are exported in ascending ingest time order.
+func (m *Manager) Submit(params openobserve.ComponentLogsParams, opts Options) (Job, error) {
+	if params.Namespace == "" {
+		return Job{}, fmt.Errorf("namespace is required")
+	}
+	if params.EndTime.Before(params.StartTime) {
+		return Job{}, fmt.Errorf("endTime must be >= startTime")
+	}
+
+	job := &Job{
+		ID:        uuid.New().String(),
+		Status:    StatusPending,
+		Namespace: params.Namespace,
+		StartTime: params.StartTime,
+		EndTime:   params.EndTime,
+		CreatedAt: time.Now().UTC(),
+		Objects:   []Object{},
+	}
+
+	m.mu.Lock()
+	m.pruneLocked(job.CreatedAt)
+	m.jobs[job.ID] = job
+	snapshot := *job
+	m.mu.Unlock()
+
+	go m.run(job.ID, params, opts)
+	return snapshot, nil
+}
+
+// Get returns the status of the job with the given ID.
+func (m *Manager) Get(id string) (Job, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job, ok := m.jobs[id]
+	if !ok {
+		return Job{}, false
+	}
+	snapshot := *job
+	snapshot.Objects = append([]Object{}, job.Objects...)
+	return snapshot, true
+}
+
+// pruneLocked drops jobs that finished more than jobRetention before now.
+func (m *Manager) pruneLocked(now time.Time) {
+	for id, job := range m.jobs {
+		if job.CompletedAt != nil && now.Sub(*job.CompletedAt) > jobRetention {
+			delete(m.jobs, id)
+		}
+	}
+}
+
+func (m *Manager) update(id string, fn func(job *Job)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if job, ok := m.jobs[id]; ok {
+		fn(job)
+	}
+}
+
+// run exports the logs one UTC day at a time so that objects are partitioned
+// by date. Each day is written as one or more NDJSON parts.
+func (m *Manager) run(id string, params openobserve.ComponentLogsParams, opts Options) {
+	m.update(id, func(job *Job) { job.Status = StatusRunning })
+
+	prefix := m.prefix
+	if opts.KeyPrefix != "" {
+		prefix = opts.KeyPrefix
+	}
+	// Export queries yield to interactive ones when OpenObserve is busy.
+	ctx := scheduler.WithClass(context.Background(), scheduler.ClassExport)
+	err := m.export(ctx, id, params, prefix, opts.LegalHold)
+	if err == nil && opts.Manifest {
+		err = m.writeManifest(ctx, id, prefix, opts.LegalHold)
+	}
+
+	now := time.Now().UTC()
+	m.update(id, func(job *Job) {
+		job.CompletedAt = &now
+		if err != nil {
+			job.Status = StatusFailed
+			job.Error = err.Error()
+			return
+		}
+		job.Status = StatusSucceeded
+	})
+	if err != nil {
+		m.logger.Error("Log export failed", slog.String("jobId", id), slog.Any("error", err))
+	}
+	if opts.OnComplete != nil {
+		if job, ok := m.Get(id); ok {
+			opts.OnComplete(job)
+		}
+	}
+}
+
+func (m *Manager) export(ctx context.Context, id string, params openobserve.ComponentLogsParams, prefix string, legalHold bool) error {
+	params.SortOrder = "asc"
+	params.SortField = ""
+	params.Limit = pageSize
+
+	part := 0
+	for dayStart := params.StartTime.UTC(); dayStart.Before(params.EndTime); {
+		dayEnd := dayStart.Truncate(24 * time.Hour).Add(24 * time.Hour)
+		if dayEnd.After(params.EndTime) {
+			dayEnd = params.EndTime
+		}
+
+		var buf bytes.Buffer
+		flush := func() error {
+			if buf.Len() == 0 {
+				return nil
+			}
+			key := objectKey(prefix, params.Namespace, dayStart, id, part)
+			obj, err := m.put(ctx, key, buf.Bytes(), PutOptions{ContentType: ndjsonContentType, LegalHold: legalHold})
+			if err != nil {
+				return err
+			}
+			part++
+			buf.Reset()
+			m.update(id, func(job *Job) { job.Objects = append(job.Objects, obj) })
+			return nil
+		}
+
+		page := params
+		page.StartTime, page.EndTime = dayStart, dayEnd
+		for page.Offset = 0; ; page.Offset += pageSize {
+			result, err := m.source.GetComponentLogs(ctx, page)
+			if err != nil {
+				return fmt.Errorf("failed to query logs: %w", err)
+			}
+			enc := json.NewEncoder(&buf)
+			for _, entry := range result.Logs {
+				if err := enc.Encode(entry); err != nil {
+					return fmt.Errorf("failed to encode log entry: %w", err)
+				}
+			}
+			n := len(result.Logs)
+			m.update(id, func(job *Job) { job.Records += n })
+
+			if buf.Len() >= maxObjectBytes {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+			if n < pageSize {
+				break
+			}
+		}
+		if err := flush(); err != nil {
+			return err
+		}
+		dayStart = dayEnd
+	}
+	return nil
+}
+
+// put writes an object and returns its description.
+func (m *Manager) put(ctx context.Context, key string, body []byte, opts PutOptions) (Object, error) {
+	if err := m.store.PutObject(ctx, key, body, opts); err != nil {
+		return Object{}, fmt.Errorf("failed to write %s: %w", key, err)
+	}
+	sum := sha256.Sum256(body)
+	return Object{Key: key, Bytes: len(body), SHA256: hex.EncodeToString(sum[:])}, nil
+}
+
+// manifest is the content of manifest.json, which lets the integrity of an
+// export be verified independently of the adapter.
+type manifest struct {
+	JobID     string    `json:"jobId"`
+	Namespace string    `json:"namespace"`
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+	CreatedAt time.Time `json:"createdAt"`
+	Records   int       `json:"records"`
+	Objects   []Object  `json:"objects"`
+}
+
+// writeManifest writes <prefix>/<jobId>-manifest.json for a job whose objects are all written.
+func (m *Manager) writeManifest(ctx context.Context, id, prefix string, legalHold bool) error {
+	job, ok := m.Get(id)
+	if !ok {
+		return fmt.Errorf("export job %s not found", id)
+	}
+	body, err := json.MarshalIndent(manifest{
+		JobID:     job.ID,
+		Namespace: job.Namespace,
+		StartTime: job.StartTime,
+		EndTime:   job.EndTime,
+		CreatedAt: time.Now().UTC(),
+		Records:   job.Records,
+		Objects:   job.Objects,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	key := path.Join(prefix, id+"-manifest.json")
+	if _, err := m.put(ctx, key, body, PutOptions{ContentType: jsonContentType, LegalHold: legalHold}); err != nil {
+		return err
+	}
+	m.update(id, func(job *Job) { job.Manifest = key })
+	return nil
+}
+
+// objectKey returns the key of an export part, partitioned by namespace and
+// date: <prefix>/namespace=<ns>/dt=<YYYY-MM-DD>/<jobId>-<part>.ndjson.
+func objectKey(prefix, namespace string, day time.Time, id string, part int) string {
+	return path.Join(prefix,
+		"namespace="+url.PathEscape(namespace),
+		"dt="+day.Format("2006-01-02"),
+		fmt.Sprintf("%s-%05d.ndjson", id, part))
+}
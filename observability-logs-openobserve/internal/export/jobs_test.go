@@ -0,0 +1,189 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package export
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+// fakeSource returns perDay entries for every queried day, paged by Offset.
+type fakeSource struct {
+	perDay int
+	err    error
+}
+
+func (s *fakeSource) GetComponentLogs(_ context.Context, params openobserve.ComponentLogsParams) (*openobserve.ComponentLogsResult, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	n := min(max(s.perDay-params.Offset, 0), params.Limit)
+	logs := make([]openobserve.ComponentLogsEntry, n)
+	for i := range logs {
+		logs[i] = openobserve.ComponentLogsEntry{Timestamp: params.StartTime, Log: "line"}
+	}
+	return &openobserve.ComponentLogsResult{Logs: logs}, nil
+}
+
+type memStore struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (s *memStore) PutObject(_ context.Context, key string, body []byte, _ PutOptions) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.objects[key]; ok {
+		return ErrObjectExists
+	}
+	s.objects[key] = append([]byte{}, body...)
+	return nil
+}
+
+func waitForJob(t *testing.T, m *Manager, id string) Job {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if job, _ := m.Get(id); job.CompletedAt != nil {
+			return job
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("job %s did not complete", id)
+	return Job{}
+}
+
+func TestManager_Export(t *testing.T) {
+	store := &memStore{objects: map[string][]byte{}}
+	m := NewManager(&fakeSource{perDay: 1500}, store, "exports", testLogger())
+
+	job, err := m.Submit(openobserve.ComponentLogsParams{
+		Namespace: "test-ns",
+		StartTime: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2025, 1, 2, 6, 0, 0, 0, time.UTC),
+	}, Options{})
+	if err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	if job.Status != StatusPending || job.ID == "" {
+		t.Errorf("unexpected submitted job: %+v", job)
+	}
+
+	done := waitForJob(t, m, job.ID)
+	if done.Status != StatusSucceeded {
+		t.Fatalf("expected succeeded job, got %+v", done)
+	}
+	if done.Records != 3000 {
+		t.Errorf("expected 3000 records across two days, got %d", done.Records)
+	}
+
+	want := []string{
+		"exports/namespace=test-ns/dt=2025-01-01/" + job.ID + "-00000.ndjson",
+		"exports/namespace=test-ns/dt=2025-01-02/" + job.ID + "-00001.ndjson",
+	}
+	if len(done.Objects) != len(want) {
+		t.Fatalf("expected objects %v, got %v", want, done.Objects)
+	}
+	for i, key := range want {
+		if done.Objects[i].Key != key {
+			t.Errorf("object %d = %q, want %q", i, done.Objects[i].Key, key)
+		}
+		if lines := bytes.Count(store.objects[key], []byte("\n")); lines != 1500 {
+			t.Errorf("expected 1500 NDJSON lines in %s, got %d", key, lines)
+		}
+	}
+}
+
+func TestManager_ExportManifest(t *testing.T) {
+	store := &memStore{objects: map[string][]byte{}}
+	m := NewManager(&fakeSource{perDay: 2}, store, "exports", testLogger())
+
+	completed := make(chan Job, 1)
+	job, err := m.Submit(openobserve.ComponentLogsParams{
+		Namespace: "test-ns",
+		StartTime: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC),
+	}, Options{
+		KeyPrefix:  "holds/h1",
+		LegalHold:  true,
+		Manifest:   true,
+		OnComplete: func(job Job) { completed <- job },
+	})
+	if err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+
+	var done Job
+	select {
+	case done = <-completed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("OnComplete was not called")
+	}
+	if done.Status != StatusSucceeded || done.Manifest != "holds/h1/"+job.ID+"-manifest.json" {
+		t.Fatalf("unexpected job: %+v", done)
+	}
+
+	var got manifest
+	if err := json.Unmarshal(store.objects[done.Manifest], &got); err != nil {
+		t.Fatalf("failed to decode manifest: %v", err)
+	}
+	if got.JobID != job.ID || got.Records != 2 || len(got.Objects) != 1 {
+		t.Fatalf("unexpected manifest: %+v", got)
+	}
+	obj := got.Objects[0]
+	sum := sha256.Sum256(store.objects[obj.Key])
+	if obj.SHA256 != hex.EncodeToString(sum[:]) || obj.Bytes != len(store.objects[obj.Key]) {
+		t.Errorf("manifest entry does not match object %s: %+v", obj.Key, obj)
+	}
+}
+
+func TestManager_ExportFailure(t *testing.T) {
+	m := NewManager(&fakeSource{err: errors.New("boom")}, &memStore{objects: map[string][]byte{}}, "exports", testLogger())
+
+	job, err := m.Submit(openobserve.ComponentLogsParams{
+		Namespace: "test-ns",
+		StartTime: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC),
+	}, Options{})
+	if err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	done := waitForJob(t, m, job.ID)
+	if done.Status != StatusFailed || done.Error == "" {
+		t.Errorf("expected failed job with error, got %+v", done)
+	}
+}
+
+func TestManager_SubmitValidation(t *testing.T) {
+	m := NewManager(&fakeSource{}, &memStore{objects: map[string][]byte{}}, "exports", testLogger())
+	if _, err := m.Submit(openobserve.ComponentLogsParams{}, Options{}); err == nil {
+		t.Error("expected error for missing namespace")
+	}
+	_, err := m.Submit(openobserve.ComponentLogsParams{
+		Namespace: "ns",
+		StartTime: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}, Options{})
+	if err == nil {
+		t.Error("expected error for inverted window")
+	}
+	if _, ok := m.Get("unknown"); ok {
+		t.Error("expected unknown job to be missing")
+	}
+}
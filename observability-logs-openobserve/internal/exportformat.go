@@ -0,0 +1,324 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/arrowipc"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+// exportFormat is a download format of logs query results.
+type exportFormat string
+
+const (
+	exportCSV    exportFormat = "csv"
+	exportNDJSON exportFormat = "ndjson"
+
+	csvMediaType    = "text/csv"
+	ndjsonMediaType = "application/x-ndjson"
+
+	// maxExportLimit is the largest limit of a logs query exported as CSV or
+	// NDJSON, and the limit of exports that do not set one.
+	maxExportLimit = 1000000
+)
+
+const exportFormatKey contextKey = "exportFormat"
+
+// withExportNegotiation marks logs query requests that ask for their results
+// as CSV or NDJSON, with a format query parameter of csv or ndjson or an
+// Accept header listing text/csv or application/x-ndjson. The query
+// parameter takes precedence; format=json keeps the JSON response.
+func withExportNegotiation(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/logs/query" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		var format exportFormat
+		if r.URL.Query().Has("format") {
+			switch value := r.URL.Query().Get("format"); value {
+			case "json":
+			case string(exportCSV), string(exportNDJSON):
+				format = exportFormat(value)
+			default:
+				writeJSONError(w, http.StatusBadRequest, gen.BadRequest,
+					fmt.Sprintf("unsupported format %q: must be json, csv or ndjson", value))
+				return
+			}
+		} else {
+			format = acceptedExportFormat(r.Header.Values("Accept"))
+		}
+		if format != "" {
+			r = r.WithContext(context.WithValue(r.Context(), exportFormatKey, format))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// acceptedExportFormat returns the export format of the first media range of
+// the Accept header values that is text/csv or application/x-ndjson with a
+// non-zero quality, or "" when there is none.
+func acceptedExportFormat(values []string) exportFormat {
+	for _, value := range values {
+		for _, mediaRange := range strings.Split(value, ",") {
+			mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+			if err != nil || (mediaType != csvMediaType && mediaType != ndjsonMediaType) {
+				continue
+			}
+			if q, ok := params["q"]; ok {
+				if v, err := strconv.ParseFloat(q, 64); err == nil && v == 0 {
+					continue
+				}
+			}
+			if mediaType == csvMediaType {
+				return exportCSV
+			}
+			return exportNDJSON
+		}
+	}
+	return ""
+}
+
+// exportFormatFromContext returns the format selected by withExportNegotiation,
+// or "" for the regular JSON response.
+func exportFormatFromContext(ctx context.Context) exportFormat {
+	format, _ := ctx.Value(exportFormatKey).(exportFormat)
+	return format
+}
+
+// exportLimit returns the limit of a logs query exported as CSV or NDJSON:
+// that of the request when it sets one, otherwise maxExportLimit.
+func exportLimit(req *gen.LogsQueryRequest) int {
+	if req.Limit != nil {
+		return *req.Limit
+	}
+	return maxExportLimit
+}
+
+var (
+	componentLogsExportColumns = arrowFieldNames(componentLogsArrowFields)
+	workflowLogsExportColumns  = arrowFieldNames(workflowLogsArrowFields)
+)
+
+// exportedLogsResponse writes the result of a logs query as a CSV document
+// with a header row, or as NDJSON with one entry per line. Like a
+// streamedLogsResponse, it fetches and writes the entries in chunks of
+// streamChunkSize and aborts the response when a chunk after the first fails.
+type exportedLogsResponse[E any] struct {
+	ctx      context.Context
+	format   exportFormat
+	filename string
+	limit    int
+	first    logsChunk[E]
+	fetch    func(ctx context.Context, offset, size int) (logsChunk[E], error)
+	// columns and record give the header row and the row of an entry of CSV exports.
+	columns []string
+	record  func(E) []string
+	logger  *slog.Logger
+}
+
+func (response exportedLogsResponse[E]) VisitQueryLogsResponse(w http.ResponseWriter) error {
+	rc := http.NewResponseController(w)
+	_ = rc.SetWriteDeadline(time.Now().Add(streamChunkTimeout))
+
+	contentType := ndjsonMediaType
+	if response.format == exportCSV {
+		contentType = csvMediaType + "; charset=utf-8"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", `attachment; filename="`+response.filename+`"`)
+	w.WriteHeader(http.StatusOK)
+
+	cw := csv.NewWriter(w)
+	if response.format == exportCSV {
+		if err := cw.Write(response.columns); err != nil {
+			return err
+		}
+	}
+
+	chunk, written := response.first, 0
+	for {
+		for _, entry := range chunk.entries {
+			if response.format == exportCSV {
+				if err := cw.Write(response.record(entry)); err != nil {
+					return err
+				}
+			} else {
+				data, err := json.Marshal(entry)
+				if err != nil {
+					return err
+				}
+				if _, err := w.Write(append(data, '\n')); err != nil {
+					return err
+				}
+			}
+			written++
+		}
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return err
+		}
+		_ = rc.Flush()
+
+		size := min(streamChunkSize, response.limit-written)
+		if len(chunk.entries) < streamChunkSize || size <= 0 {
+			return nil
+		}
+
+		_ = rc.SetWriteDeadline(time.Now().Add(streamChunkTimeout))
+		var err error
+		chunk, err = response.fetch(response.ctx, written, size)
+		if err != nil {
+			response.logger.Error("Failed to export logs",
+				slog.String("function", "QueryLogs"),
+				slog.String("format", string(response.format)),
+				slog.Int("offset", written),
+				slog.Any("error", err),
+			)
+			// The status has been sent; abort so the client does not mistake
+			// the truncated download for a complete result.
+			panic(http.ErrAbortHandler)
+		}
+	}
+}
+
+// exportComponentLogs answers a component logs query exported as CSV or
+// NDJSON with an exportedLogsResponse.
+func (h *LogsHandler) exportComponentLogs(ctx context.Context, client *openobserve.Client, params openobserve.ComponentLogsParams, format exportFormat) (gen.QueryLogsResponseObject, error) {
+	if params.Limit > maxExportLimit {
+		return gen.QueryLogs400JSONResponse{
+			Title:   ptr(gen.BadRequest),
+			Message: ptr(fmt.Sprintf("limit must be at most %d for %s exports", maxExportLimit, format)),
+		}, nil
+	}
+
+	fetch := componentLogsChunks(client, params)
+	first, err := fetch(ctx, 0, min(streamChunkSize, params.Limit))
+	if err != nil {
+		h.logger.Error("Failed to query component logs",
+			slog.String("function", "QueryLogs"),
+			slog.String("namespace", params.Namespace),
+			slog.Any("error", err),
+		)
+		return queryLogsError(err), nil
+	}
+
+	return exportedLogsResponse[componentLogEntry]{
+		ctx:      ctx,
+		format:   format,
+		filename: exportFilename(params.Namespace, params.StartTime, format),
+		limit:    params.Limit,
+		first:    first,
+		fetch:    fetch,
+		columns:  componentLogsExportColumns,
+		record:   componentLogRecord,
+		logger:   h.logger,
+	}, nil
+}
+
+// exportWorkflowLogs answers a workflow logs query exported as CSV or NDJSON
+// with an exportedLogsResponse.
+func (h *LogsHandler) exportWorkflowLogs(ctx context.Context, client *openobserve.Client, params openobserve.WorkflowLogsParams, format exportFormat) (gen.QueryLogsResponseObject, error) {
+	if params.Limit > maxExportLimit {
+		return gen.QueryLogs400JSONResponse{
+			Title:   ptr(gen.BadRequest),
+			Message: ptr(fmt.Sprintf("limit must be at most %d for %s exports", maxExportLimit, format)),
+		}, nil
+	}
+
+	fetch := workflowLogsChunks(client, params)
+	first, err := fetch(ctx, 0, min(streamChunkSize, params.Limit))
+	if err != nil {
+		h.logger.Error("Failed to query workflow logs",
+			slog.String("function", "QueryLogs"),
+			slog.String("namespace", params.Namespace),
+			slog.Any("error", err),
+		)
+		return queryLogsError(err), nil
+	}
+
+	return exportedLogsResponse[workflowLogEntry]{
+		ctx:      ctx,
+		format:   format,
+		filename: exportFilename(params.Namespace, params.StartTime, format),
+		limit:    params.Limit,
+		first:    first,
+		fetch:    fetch,
+		columns:  workflowLogsExportColumns,
+		record:   workflowLogRecord,
+		logger:   h.logger,
+	}, nil
+}
+
+// exportFilename names the download of an export of the logs of namespace
+// starting at start.
+func exportFilename(namespace string, start time.Time, format exportFormat) string {
+	return fmt.Sprintf("logs-%s-%s.%s", namespace, start.UTC().Format("20060102T150405Z"), format)
+}
+
+// componentLogRecord returns the CSV row of a component log entry, in the
+// order of componentLogsExportColumns.
+func componentLogRecord(e componentLogEntry) []string {
+	record := []string{
+		csvTime(e.Timestamp), csvTime(e.EventTime), csvTime(e.IngestTime), csvString(e.Log), csvString(e.Level),
+	}
+	if m := e.Metadata; m != nil {
+		return append(record,
+			csvUUID(m.ComponentUid), csvString(m.ComponentName),
+			csvUUID(m.EnvironmentUid), csvString(m.EnvironmentName),
+			csvUUID(m.ProjectUid), csvString(m.ProjectName),
+			csvString(m.NamespaceName), csvString(m.PodName), csvString(m.PodNamespace), csvString(m.ContainerName),
+		)
+	}
+	return append(record, make([]string, len(componentLogsExportColumns)-len(record))...)
+}
+
+// workflowLogRecord returns the CSV row of a workflow log entry, in the order
+// of workflowLogsExportColumns.
+func workflowLogRecord(e workflowLogEntry) []string {
+	return []string{csvTime(e.Timestamp), csvTime(e.EventTime), csvTime(e.IngestTime), csvString(e.Log)}
+}
+
+func arrowFieldNames(fields []arrowipc.Field) []string {
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		names[i] = f.Name
+	}
+	return names
+}
+
+func csvString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func csvUUID(u *openapi_types.UUID) string {
+	if u == nil {
+		return ""
+	}
+	return u.String()
+}
+
+func csvTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
@@ -0,0 +1,177 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+func TestWithExportNegotiation(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     string
+		accept     string
+		want       exportFormat
+		wantStatus int
+	}{
+		{"json by default", "/api/v1/logs/query", "", "", http.StatusOK},
+		{"csv parameter", "/api/v1/logs/query?format=csv", "", exportCSV, http.StatusOK},
+		{"ndjson parameter", "/api/v1/logs/query?format=ndjson", "", exportNDJSON, http.StatusOK},
+		{"json parameter wins over accept", "/api/v1/logs/query?format=json", "text/csv", "", http.StatusOK},
+		{"csv accepted", "/api/v1/logs/query", "application/json;q=0.5, text/csv", exportCSV, http.StatusOK},
+		{"ndjson accepted", "/api/v1/logs/query", "application/x-ndjson", exportNDJSON, http.StatusOK},
+		{"csv refused", "/api/v1/logs/query", "text/csv;q=0", "", http.StatusOK},
+		{"unknown parameter", "/api/v1/logs/query?format=xml", "", "", http.StatusBadRequest},
+		{"other route", "/api/v1/logs/aggregate?format=xml", "", "", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got exportFormat
+			handler := withExportNegotiation(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = exportFormatFromContext(r.Context())
+			}))
+			req := httptest.NewRequest(http.MethodPost, tt.target, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if got != tt.want {
+				t.Errorf("expected format %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestQueryLogs_ExportCSV(t *testing.T) {
+	server, sizes := newPagingOpenObserve(t, 2500, -1)
+	client := openobserve.NewClient(server.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	handler := NewLogsHandler(client, nil, testLogger())
+
+	request := largeLimitRequest(t, 0)
+	request.Body.Limit = nil
+	ctx := context.WithValue(context.Background(), exportFormatKey, exportCSV)
+	resp, err := handler.QueryLogs(ctx, request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resp.(exportedLogsResponse[componentLogEntry]); !ok {
+		t.Fatalf("expected an exported response, got %T", resp)
+	}
+
+	rec := httptest.NewRecorder()
+	if err := resp.VisitQueryLogsResponse(rec); err != nil {
+		t.Fatalf("VisitQueryLogsResponse() error = %v", err)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "text/csv; charset=utf-8" {
+		t.Errorf("unexpected Content-Type: %s", got)
+	}
+	if got := rec.Header().Get("Content-Disposition"); got != `attachment; filename="logs-test-ns-20250101T000000Z.csv"` {
+		t.Errorf("unexpected Content-Disposition: %s", got)
+	}
+
+	records, err := csv.NewReader(rec.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("export is not valid CSV: %v", err)
+	}
+	if len(records) != 2501 {
+		t.Fatalf("expected a header and 2500 rows, got %d records", len(records))
+	}
+	if got := strings.Join(records[0][:5], ","); got != "timestamp,eventTime,ingestTime,log,level" {
+		t.Errorf("unexpected header: %v", records[0])
+	}
+	if records[1][0] != "2025-01-01T12:00:00Z" || records[1][3] != "line" {
+		t.Errorf("unexpected first row: %v", records[1])
+	}
+	if len(*sizes) != 3 {
+		t.Errorf("expected 3 chunks, got sizes %v", *sizes)
+	}
+}
+
+func TestQueryLogs_ExportNDJSON(t *testing.T) {
+	server, _ := newPagingOpenObserve(t, 1500, -1)
+	client := openobserve.NewClient(server.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	handler := NewLogsHandler(client, nil, testLogger())
+
+	ctx := context.WithValue(context.Background(), exportFormatKey, exportNDJSON)
+	resp, err := handler.QueryLogs(ctx, largeLimitRequest(t, 1200))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	if err := resp.VisitQueryLogsResponse(rec); err != nil {
+		t.Fatalf("VisitQueryLogsResponse() error = %v", err)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "application/x-ndjson" {
+		t.Errorf("unexpected Content-Type: %s", got)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(rec.Body.String(), "\n"), "\n")
+	if len(lines) != 1200 {
+		t.Fatalf("expected 1200 lines, got %d", len(lines))
+	}
+	var entry struct {
+		Log string `json:"log"`
+	}
+	if err := json.Unmarshal([]byte(lines[len(lines)-1]), &entry); err != nil || entry.Log != "line" {
+		t.Errorf("unexpected last line %q: %v", lines[len(lines)-1], err)
+	}
+}
+
+func TestQueryLogs_ExportErrors(t *testing.T) {
+	t.Run("limit above the export maximum", func(t *testing.T) {
+		handler := NewLogsHandler(nil, nil, testLogger())
+		ctx := context.WithValue(context.Background(), exportFormatKey, exportCSV)
+		resp, err := handler.QueryLogs(ctx, largeLimitRequest(t, maxExportLimit+1))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, ok := resp.(gen.QueryLogs400JSONResponse); !ok {
+			t.Fatalf("expected 400 response, got %T", resp)
+		}
+	})
+
+	t.Run("log sources", func(t *testing.T) {
+		handler := NewLogsHandler(nil, nil, testLogger())
+		ctx := context.WithValue(context.Background(), exportFormatKey, exportNDJSON)
+		ctx = context.WithValue(ctx, queryExtensionsKey, queryExtensions{Sources: []string{"application"}})
+		resp, err := handler.QueryLogs(ctx, largeLimitRequest(t, 100))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, ok := resp.(gen.QueryLogs400JSONResponse); !ok {
+			t.Fatalf("expected 400 response, got %T", resp)
+		}
+	})
+
+	t.Run("later chunk aborts the response", func(t *testing.T) {
+		server, _ := newPagingOpenObserve(t, 5000, 2000)
+		client := openobserve.NewClient(server.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+		handler := NewLogsHandler(client, nil, testLogger())
+
+		ctx := context.WithValue(context.Background(), exportFormatKey, exportCSV)
+		resp, err := handler.QueryLogs(ctx, largeLimitRequest(t, 5000))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer func() {
+			if r := recover(); r != http.ErrAbortHandler {
+				t.Errorf("expected the response to be aborted, got %v", r)
+			}
+		}()
+		_ = resp.VisitQueryLogsResponse(httptest.NewRecorder())
+	})
+}
@@ -0,0 +1,185 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+type contextKey string
+
+const (
+	queryExtensionsKey     contextKey = "queryExtensions"
+	alertRuleExtensionsKey contextKey = "alertRuleExtensions"
+)
+
+// queryExtensions holds the request fields this adapter accepts on the query
+// endpoints in addition to the shared logs adapter OpenAPI contract. The
+// generated strict server decodes the body into the shared request type and
+// drops unknown fields, so these are decoded separately by withQueryExtensions.
+type queryExtensions struct {
+	// SortField selects the timestamp used for ordering: "eventTime" or "ingestTime".
+	SortField string `json:"sortField,omitempty"`
+	// Sources queries the listed log sources, "application" and "workflow",
+	// in parallel and returns merged results tagged with their source,
+	// regardless of the shape of the search scope. Logs queries only.
+	Sources []string `json:"sources,omitempty"`
+	// Extract extracts fields from the returned log lines with regular
+	// expressions. Logs queries only.
+	Extract []extractRule `json:"extract,omitempty"`
+	// Query filters the log lines with a full-text search query, see
+	// openobserve.SearchQuery. Logs queries only.
+	Query string `json:"query,omitempty"`
+}
+
+// componentScopeExtensions holds the ComponentSearchScope fields this
+// adapter accepts in addition to the shared contract, decoded like
+// workflowScopeExtensions.
+type componentScopeExtensions struct {
+	// EnvironmentUids queries the logs of a component across several
+	// environments; "*" queries every environment.
+	EnvironmentUids []string `json:"environmentUids,omitempty"`
+}
+
+// componentScopeExtensionsOf decodes the adapter-specific fields of a
+// component search scope, returning the zero value when the scope cannot be
+// decoded.
+func componentScopeExtensionsOf(scope gen.LogsQueryRequest_SearchScope) componentScopeExtensions {
+	var ext componentScopeExtensions
+	raw, err := scope.MarshalJSON()
+	if err != nil {
+		return ext
+	}
+	_ = json.Unmarshal(raw, &ext)
+	return ext
+}
+
+// workflowScopeExtensions holds the WorkflowSearchScope fields this adapter
+// accepts in addition to the shared contract. The generated search scope
+// union keeps the raw JSON, so they are decoded from it directly.
+type workflowScopeExtensions struct {
+	// StepName narrows workflow logs to the pods of a single workflow step.
+	StepName string `json:"stepName,omitempty"`
+	// PodName narrows workflow logs to a single pod.
+	PodName string `json:"podName,omitempty"`
+}
+
+// workflowScopeExtensionsOf decodes the adapter-specific fields of a workflow
+// search scope, returning the zero value when the scope cannot be decoded.
+func workflowScopeExtensionsOf(scope gen.LogsQueryRequest_SearchScope) workflowScopeExtensions {
+	var ext workflowScopeExtensions
+	raw, err := scope.MarshalJSON()
+	if err != nil {
+		return ext
+	}
+	_ = json.Unmarshal(raw, &ext)
+	return ext
+}
+
+// withQueryExtensions decodes adapter-specific fields from the body of POST
+// query requests into the request context and restores the body so the
+// generated handler can decode it as usual. Malformed bodies are passed
+// through untouched; the generated handler reports the decoding error.
+func withQueryExtensions(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Body == nil || !isQueryPath(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		body, err := peekBody(r)
+		if err != nil {
+			http.Error(w, "failed to read request body", http.StatusBadRequest)
+			return
+		}
+
+		var ext queryExtensions
+		if json.Unmarshal(body, &ext) == nil {
+			r = r.WithContext(context.WithValue(r.Context(), queryExtensionsKey, ext))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isQueryPath reports whether path is one of the query endpoints that accept extensions.
+func isQueryPath(path string) bool {
+	return path == "/api/v1/logs/query" || path == "/api/v1/events/query"
+}
+
+// queryExtensionsFromContext returns the extensions decoded by withQueryExtensions,
+// or the zero value when none were provided.
+func queryExtensionsFromContext(ctx context.Context) queryExtensions {
+	if ext, ok := ctx.Value(queryExtensionsKey).(queryExtensions); ok {
+		return ext
+	}
+	return queryExtensions{}
+}
+
+// alertRuleExtensions holds the adapter-specific fields accepted on alert rule
+// create and update requests in addition to the shared AlertRuleRequest.
+type alertRuleExtensions struct {
+	// Severity is "critical", "warning" or "info". It is stored with the
+	// OpenObserve alert and selects the destinations the alert notifies.
+	Severity string `json:"severity,omitempty"`
+	// Conditions are additional threshold conditions over log search patterns
+	// or Kubernetes event reasons, evaluated over the same window as the rule
+	// condition. ConditionMatch combines them with the rule condition: "all"
+	// (AND, the default) or "any" (OR).
+	Conditions     []openobserve.AlertCondition `json:"conditions,omitempty"`
+	ConditionMatch string                       `json:"conditionMatch,omitempty"`
+}
+
+// withAlertRuleExtensions decodes alertRuleExtensions from the body of alert
+// rule create (POST) and update (PUT) requests, like withQueryExtensions.
+func withAlertRuleExtensions(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		isCreate := r.Method == http.MethodPost && r.URL.Path == "/api/v1alpha1/alerts/rules"
+		isUpdate := r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/api/v1alpha1/alerts/rules/")
+		if r.Body == nil || !(isCreate || isUpdate) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		body, err := peekBody(r)
+		if err != nil {
+			http.Error(w, "failed to read request body", http.StatusBadRequest)
+			return
+		}
+
+		var ext alertRuleExtensions
+		if json.Unmarshal(body, &ext) == nil {
+			r = r.WithContext(context.WithValue(r.Context(), alertRuleExtensionsKey, ext))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// alertRuleExtensionsFromContext returns the extensions decoded by
+// withAlertRuleExtensions, or the zero value when none were provided.
+func alertRuleExtensionsFromContext(ctx context.Context) alertRuleExtensions {
+	if ext, ok := ctx.Value(alertRuleExtensionsKey).(alertRuleExtensions); ok {
+		return ext
+	}
+	return alertRuleExtensions{}
+}
+
+// peekBody reads the request body and replaces it with an in-memory copy so
+// the next handler can read it again.
+func peekBody(r *http.Request) ([]byte, error) {
+	body, err := io.ReadAll(r.Body)
+	_ = r.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
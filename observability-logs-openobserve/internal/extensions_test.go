@@ -0,0 +1,99 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWithQueryExtensions(t *testing.T) {
+	t.Run("decodes extensions and restores body", func(t *testing.T) {
+		var gotExt queryExtensions
+		var gotBody string
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotExt = queryExtensionsFromContext(r.Context())
+			b, _ := io.ReadAll(r.Body)
+			gotBody = string(b)
+		})
+
+		body := `{"searchScope":{"namespace":"ns"},"sortField":"eventTime"}`
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/logs/query", strings.NewReader(body))
+		withQueryExtensions(next).ServeHTTP(httptest.NewRecorder(), req)
+
+		if gotExt.SortField != "eventTime" {
+			t.Errorf("expected sortField eventTime, got %q", gotExt.SortField)
+		}
+		if gotBody != body {
+			t.Errorf("expected body to be restored, got %q", gotBody)
+		}
+	})
+
+	t.Run("ignores non-query paths", func(t *testing.T) {
+		var gotExt queryExtensions
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotExt = queryExtensionsFromContext(r.Context())
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1alpha1/alerts/rules", strings.NewReader(`{"sortField":"eventTime"}`))
+		withQueryExtensions(next).ServeHTTP(httptest.NewRecorder(), req)
+
+		if gotExt.SortField != "" {
+			t.Errorf("expected no extensions, got %+v", gotExt)
+		}
+	})
+
+	t.Run("malformed body is passed through", func(t *testing.T) {
+		called := false
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/logs/query", strings.NewReader(`{not json`))
+		withQueryExtensions(next).ServeHTTP(httptest.NewRecorder(), req)
+
+		if !called {
+			t.Error("expected next handler to be called")
+		}
+	})
+}
+
+func TestWithAlertRuleExtensions(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		want   string
+	}{
+		{"create", http.MethodPost, "/api/v1alpha1/alerts/rules", "critical"},
+		{"update", http.MethodPut, "/api/v1alpha1/alerts/rules/my-rule", "critical"},
+		{"query path", http.MethodPost, "/api/v1/logs/query", ""},
+		{"delete", http.MethodDelete, "/api/v1alpha1/alerts/rules/my-rule", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotExt alertRuleExtensions
+			var gotBody string
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotExt = alertRuleExtensionsFromContext(r.Context())
+				b, _ := io.ReadAll(r.Body)
+				gotBody = string(b)
+			})
+
+			body := `{"metadata":{"name":"my-rule"},"severity":"critical"}`
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(body))
+			withAlertRuleExtensions(next).ServeHTTP(httptest.NewRecorder(), req)
+
+			if gotExt.Severity != tt.want {
+				t.Errorf("expected severity %q, got %q", tt.want, gotExt.Severity)
+			}
+			if gotBody != body {
+				t.Errorf("expected body to be preserved, got %q", gotBody)
+			}
+		})
+	}
+}
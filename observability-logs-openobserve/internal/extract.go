@@ -0,0 +1,66 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+const (
+	// maxExtractRules bounds the extract rules of a logs query.
+	maxExtractRules = 10
+	// maxExtractPatternLength bounds the length of an extract pattern.
+	maxExtractPatternLength = 512
+)
+
+// extractFieldName is the syntax of the names of extracted fields.
+var extractFieldName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]{0,63}$`)
+
+// extractRule is a regular expression applied to the lines returned by a
+// logs query, in RE2 syntax. Its named capture groups extract the fields
+// they name; Name names the field of its first unnamed group, or of the
+// whole match when it has no groups. Extracted fields are returned in the
+// "extracted" object of the metadata of each entry.
+type extractRule struct {
+	Name    string `json:"name,omitempty"`
+	Pattern string `json:"pattern"`
+}
+
+// compileExtractRules validates and compiles the extract rules of a logs query.
+func compileExtractRules(rules []extractRule) ([]openobserve.Extractor, error) {
+	if len(rules) > maxExtractRules {
+		return nil, fmt.Errorf("extract must have at most %d rules", maxExtractRules)
+	}
+	extractors := make([]openobserve.Extractor, 0, len(rules))
+	for i, rule := range rules {
+		if rule.Pattern == "" || len(rule.Pattern) > maxExtractPatternLength {
+			return nil, fmt.Errorf("extract[%d].pattern must have 1 to %d characters", i, maxExtractPatternLength)
+		}
+		re, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("extract[%d].pattern is not a valid regular expression: %v", i, err)
+		}
+		named := false
+		for _, group := range re.SubexpNames() {
+			if group == "" {
+				continue
+			}
+			if !extractFieldName.MatchString(group) {
+				return nil, fmt.Errorf("extract[%d].pattern has an invalid group name %q", i, group)
+			}
+			named = true
+		}
+		if rule.Name == "" && !named {
+			return nil, fmt.Errorf("extract[%d].name is required when the pattern has no named groups", i)
+		}
+		if rule.Name != "" && !extractFieldName.MatchString(rule.Name) {
+			return nil, fmt.Errorf("extract[%d].name must be a letter or underscore followed by at most 63 letters, digits or underscores", i)
+		}
+		extractors = append(extractors, openobserve.Extractor{Name: rule.Name, Regexp: re})
+	}
+	return extractors, nil
+}
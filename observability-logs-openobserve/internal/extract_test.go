@@ -0,0 +1,103 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+func TestCompileExtractRules(t *testing.T) {
+	extractors, err := compileExtractRules([]extractRule{
+		{Name: "orderId", Pattern: `order=(\w+)`},
+		{Pattern: `(?P<route>/api/\S+)`},
+	})
+	if err != nil {
+		t.Fatalf("compileExtractRules() error = %v", err)
+	}
+	if len(extractors) != 2 || extractors[0].Name != "orderId" {
+		t.Errorf("unexpected extractors: %+v", extractors)
+	}
+
+	tooMany := make([]extractRule, maxExtractRules+1)
+	for i := range tooMany {
+		tooMany[i] = extractRule{Name: "f", Pattern: "x"}
+	}
+	for name, rules := range map[string][]extractRule{
+		"too many rules":     tooMany,
+		"empty pattern":      {{Name: "f"}},
+		"invalid pattern":    {{Name: "f", Pattern: `order=(\w+`}},
+		"backreference":      {{Name: "f", Pattern: `(a)\1`}},
+		"missing name":       {{Pattern: `order=(\w+)`}},
+		"invalid name":       {{Name: "order-id", Pattern: `order=(\w+)`}},
+		"invalid group name": {{Pattern: `(?P<_1éé>\w+)`}},
+		"long pattern":       {{Name: "f", Pattern: strings.Repeat("a", maxExtractPatternLength+1)}},
+	} {
+		if _, err := compileExtractRules(rules); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestQueryLogs_Extract(t *testing.T) {
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(openobserve.OpenObserveResponse{
+			Total: 2,
+			Hits: []map[string]interface{}{
+				{"_timestamp": float64(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC).UnixMicro()), "log": "paid order=A17 in 35ms"},
+				{"_timestamp": float64(time.Date(2025, 1, 1, 12, 0, 1, 0, time.UTC).UnixMicro()), "log": "ready"},
+			},
+		})
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	srv := NewServer("0", NewLogsHandler(client, nil, testLogger()), testLogger()).httpServer.Handler
+
+	query := func(extract string) *httptest.ResponseRecorder {
+		body := `{"startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-02T00:00:00Z","searchScope":{"namespace":"test-ns"},` +
+			`"extract":` + extract + `}`
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/logs/query", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := query(`[{"name":"orderId","pattern":"order=(\\w+)"},{"pattern":"in (?P<durationMs>\\d+)ms"}]`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var got struct {
+		Logs []struct {
+			Log      string `json:"log"`
+			Metadata struct {
+				Extracted map[string]string `json:"extracted"`
+			} `json:"metadata"`
+		} `json:"logs"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(got.Logs) != 2 {
+		t.Fatalf("expected 2 logs, got %s", rec.Body.String())
+	}
+	if e := got.Logs[0].Metadata.Extracted; e["orderId"] != "A17" || e["durationMs"] != "35" {
+		t.Errorf("unexpected extracted fields: %v", e)
+	}
+	if e := got.Logs[1].Metadata.Extracted; e != nil {
+		t.Errorf("expected no extracted fields for a line that does not match, got %v", e)
+	}
+
+	if rec := query(`[{"name":"orderId","pattern":"order=(\\w+"}]`); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an invalid pattern, got %d", rec.Code)
+	}
+}
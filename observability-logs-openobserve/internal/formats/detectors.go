@@ -0,0 +1,183 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package formats
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Built-in detectors, tried in this order by default.
+const (
+	SpringBoot = "springboot"
+	Pino       = "pino"
+	Winston    = "winston"
+	Python     = "python"
+	Envoy      = "envoy"
+)
+
+func init() {
+	Register(SpringBoot, detectSpringBoot)
+	Register(Pino, detectPino)
+	Register(Winston, detectWinston)
+	Register(Python, detectPython)
+	Register(Envoy, detectEnvoy)
+}
+
+var (
+	// springBootLine matches the default console format of Spring Boot,
+	// with or without the application name (3.2+):
+	//   2025-01-01T12:00:00.000Z  INFO 1 --- [demo] [           main] c.e.demo.DemoApplication : Started
+	springBootLine = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(?:[.,]\d+)?(?:Z|[+-]\d{2}:?\d{2})?\s+(TRACE|DEBUG|INFO|WARN|ERROR|FATAL)\s+\d+\s+---\s+(?:\[[^\]]*\]\s+)?\[\s*([^\]]*?)\s*\]\s+(\S+)\s*:`)
+	// javaException matches the first exception of a Java stack trace.
+	javaException = regexp.MustCompile(`(?m)^\s*(?:Caused by: |Exception in thread "[^"]*" )?((?:[a-zA-Z_$][\w$]*\.)+[A-Z][\w$]*(?:Exception|Error|Throwable))(?::|\s*$)`)
+
+	// pythonBasicLine matches the format of logging.basicConfig:
+	//   WARNING:payments.db:connection lost
+	pythonBasicLine = regexp.MustCompile(`^(DEBUG|INFO|WARNING|ERROR|CRITICAL):([^:\s]+):`)
+	// pythonTimedLine matches the "%(asctime)s - %(name)s - %(levelname)s - %(message)s" format:
+	//   2025-01-01 12:00:00,123 - payments.db - ERROR - connection lost
+	pythonTimedLine = regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2},\d{3} - (\S+) - (DEBUG|INFO|WARNING|ERROR|CRITICAL) - `)
+	// pythonException matches the last line of a traceback.
+	pythonException = regexp.MustCompile(`^([A-Za-z_][\w.]*)(?::|$)`)
+
+	// envoyLine matches the default application log format of Envoy:
+	//   [2025-01-01 12:00:00.123][14][info][upstream] [source/common/upstream/cds_api_helper.cc:35] cds: add 3 cluster(s)
+	envoyLine = regexp.MustCompile(`^\[\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}\.\d{3}\]\[(\d+)\]\[(\w+)\]\[([\w.]+)\]`)
+	// envoyAccessLine matches the default access log format of Envoy:
+	//   [2025-01-01T12:00:00.123Z] "GET /healthz HTTP/1.1" 200 - 0 2 1 0 ...
+	envoyAccessLine = regexp.MustCompile(`^\[\d{4}-\d{2}-\d{2}T[\d:.]+Z\] "[A-Z]+ \S+ \S+" (\d{1,3}) `)
+)
+
+func detectSpringBoot(line string) (Fields, bool) {
+	m := springBootLine.FindStringSubmatch(line)
+	if m == nil {
+		return Fields{}, false
+	}
+	fields := Fields{Level: m[1], Thread: m[2], Logger: m[3]}
+	if e := javaException.FindStringSubmatch(line[len(m[0]):]); e != nil {
+		fields.ExceptionClass = e[1]
+	}
+	return fields, true
+}
+
+// decodeJSONLine decodes a log line holding a single JSON object.
+func decodeJSONLine(line string) (map[string]any, bool) {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "{") {
+		return nil, false
+	}
+	var obj map[string]any
+	if err := json.Unmarshal([]byte(line), &obj); err != nil {
+		return nil, false
+	}
+	return obj, true
+}
+
+// detectPino recognizes the JSON lines of pino, whose levels are numbers.
+func detectPino(line string) (Fields, bool) {
+	obj, ok := decodeJSONLine(line)
+	if !ok {
+		return Fields{}, false
+	}
+	level, ok := obj["level"].(float64)
+	if _, hasMsg := obj["msg"]; !ok || !hasMsg {
+		return Fields{}, false
+	}
+	fields := Fields{Logger: stringOf(obj["name"])}
+	switch {
+	case level <= 10:
+		fields.Level = "TRACE"
+	case level <= 20:
+		fields.Level = "DEBUG"
+	case level <= 30:
+		fields.Level = "INFO"
+	case level <= 40:
+		fields.Level = "WARN"
+	case level <= 50:
+		fields.Level = "ERROR"
+	default:
+		fields.Level = "FATAL"
+	}
+	if err, ok := obj["err"].(map[string]any); ok {
+		fields.ExceptionClass = stringOf(err["type"])
+	}
+	return fields, true
+}
+
+// detectWinston recognizes the JSON lines of winston, whose levels are names.
+func detectWinston(line string) (Fields, bool) {
+	obj, ok := decodeJSONLine(line)
+	if !ok {
+		return Fields{}, false
+	}
+	level, ok := obj["level"].(string)
+	if _, hasMessage := obj["message"]; !ok || !hasMessage {
+		return Fields{}, false
+	}
+	fields := Fields{Level: normalizeLevel(level)}
+	for _, key := range []string{"label", "logger", "service"} {
+		if fields.Logger = stringOf(obj[key]); fields.Logger != "" {
+			break
+		}
+	}
+	if stack := stringOf(obj["stack"]); stack != "" {
+		first, _, _ := strings.Cut(stack, "\n")
+		if name, _, ok := strings.Cut(first, ":"); ok && !strings.ContainsAny(name, " \t") {
+			fields.ExceptionClass = name
+		}
+	}
+	return fields, true
+}
+
+func detectPython(line string) (Fields, bool) {
+	var fields Fields
+	if m := pythonBasicLine.FindStringSubmatch(line); m != nil {
+		fields = Fields{Level: normalizeLevel(m[1]), Logger: m[2]}
+	} else if m := pythonTimedLine.FindStringSubmatch(line); m != nil {
+		fields = Fields{Level: normalizeLevel(m[2]), Logger: m[1]}
+	} else {
+		return Fields{}, false
+	}
+	if _, traceback, ok := strings.Cut(line, "Traceback (most recent call last):"); ok {
+		// The exception is on the last line of the traceback that is not
+		// indented; the indented lines are its frames.
+		lines := strings.Split(strings.TrimRight(traceback, "\n"), "\n")
+		for i := len(lines) - 1; i >= 0; i-- {
+			if l := lines[i]; l != "" && l[0] != ' ' && l[0] != '\t' {
+				if m := pythonException.FindStringSubmatch(l); m != nil {
+					fields.ExceptionClass = m[1]
+				}
+				break
+			}
+		}
+	}
+	return fields, true
+}
+
+func detectEnvoy(line string) (Fields, bool) {
+	if m := envoyLine.FindStringSubmatch(line); m != nil {
+		return Fields{Level: normalizeLevel(m[2]), Thread: m[1], Logger: m[3]}, true
+	}
+	if m := envoyAccessLine.FindStringSubmatch(line); m != nil {
+		// Access logs have no level; it is derived from the response code,
+		// where 0 means no response was sent.
+		fields := Fields{Logger: "access", Level: "INFO"}
+		switch code, _ := strconv.Atoi(m[1]); {
+		case code == 0 || code >= 500:
+			fields.Level = "ERROR"
+		case code >= 400:
+			fields.Level = "WARN"
+		}
+		return fields, true
+	}
+	return Fields{}, false
+}
+
+func stringOf(v any) string {
+	s, _ := v.(string)
+	return s
+}
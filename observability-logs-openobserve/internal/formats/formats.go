@@ -0,0 +1,154 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package formats detects the log formats of common runtimes from the shape
+// of log lines and extracts their level, logger, thread and exception class,
+// so that unstructured application logs carry structured metadata. The
+// detectors tried can be chosen per component.
+package formats
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Fields are the fields extracted from a log line. Empty fields were not
+// found in the line.
+type Fields struct {
+	// Format is the name of the detector that recognized the line.
+	Format string `json:"logFormat"`
+	// Level is normalized to TRACE, DEBUG, INFO, WARN, ERROR or FATAL.
+	Level          string `json:"level,omitempty"`
+	Logger         string `json:"logger,omitempty"`
+	Thread         string `json:"thread,omitempty"`
+	ExceptionClass string `json:"exceptionClass,omitempty"`
+}
+
+// Detector recognizes the log lines of a format and extracts their fields.
+// It reports false for lines that are not in its format.
+type Detector func(line string) (Fields, bool)
+
+// None disables detection for a component.
+const None = "none"
+
+var (
+	detectors = map[string]Detector{}
+	names     []string
+)
+
+// Register adds a detector under name, making it available to NewSet. It
+// must be called before NewSet, typically from an init function, and panics
+// if name is already registered.
+func Register(name string, d Detector) {
+	if _, ok := detectors[name]; ok || name == None {
+		panic(fmt.Sprintf("formats: detector %q registered twice", name))
+	}
+	detectors[name] = d
+	names = append(names, name)
+}
+
+// Names returns the names of the registered detectors, built-in ones first.
+func Names() []string {
+	return append([]string(nil), names...)
+}
+
+type namedDetector struct {
+	name   string
+	detect Detector
+}
+
+// Set picks the detectors tried on the log lines of each component.
+type Set struct {
+	defaults   []namedDetector
+	components map[string][]namedDetector
+}
+
+// NewSet returns a Set trying the named detectors in order on the lines of
+// all components, except those in components, which maps a component UID or
+// name to the single detector tried on its lines, or None.
+func NewSet(defaults []string, components map[string]string) (*Set, error) {
+	s := &Set{components: make(map[string][]namedDetector, len(components))}
+	for _, name := range defaults {
+		if name == None {
+			continue
+		}
+		d, ok := detectors[name]
+		if !ok {
+			return nil, unknownDetector(name)
+		}
+		s.defaults = append(s.defaults, namedDetector{name, d})
+	}
+	for component, name := range components {
+		if name == None {
+			s.components[component] = nil
+			continue
+		}
+		d, ok := detectors[name]
+		if !ok {
+			return nil, unknownDetector(name)
+		}
+		s.components[component] = []namedDetector{{name, d}}
+	}
+	return s, nil
+}
+
+func unknownDetector(name string) error {
+	return fmt.Errorf("unknown log format %q: must be one of %s, %s", name, strings.Join(names, ", "), None)
+}
+
+// Detect returns the fields of line, logged by the component with the given
+// UID and name, or nil when none of its detectors recognizes it.
+func (s *Set) Detect(componentUID, componentName, line string) *Fields {
+	candidates, ok := s.components[componentUID]
+	if !ok {
+		if candidates, ok = s.components[componentName]; !ok {
+			candidates = s.defaults
+		}
+	}
+	for _, d := range candidates {
+		if fields, ok := d.detect(line); ok {
+			fields.Format = d.name
+			return &fields
+		}
+	}
+	return nil
+}
+
+// ParseComponents parses a comma-separated list of <component>=<format>
+// pairs, where component is a component UID or name.
+func ParseComponents(s string) (map[string]string, error) {
+	components := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		component, format, ok := strings.Cut(pair, "=")
+		component, format = strings.TrimSpace(component), strings.TrimSpace(format)
+		if !ok || component == "" || format == "" {
+			return nil, fmt.Errorf("invalid component format %q: expected <component>=<format>", pair)
+		}
+		components[component] = format
+	}
+	return components, nil
+}
+
+// normalizeLevel maps the level names of the supported runtimes to the
+// levels reported by the adapter.
+func normalizeLevel(level string) string {
+	switch level = strings.ToUpper(strings.TrimSpace(level)); level {
+	case "WARNING":
+		return "WARN"
+	case "CRITICAL", "CRIT", "PANIC":
+		return "FATAL"
+	case "ERR":
+		return "ERROR"
+	case "HTTP":
+		return "INFO"
+	case "VERBOSE":
+		return "DEBUG"
+	case "SILLY":
+		return "TRACE"
+	}
+	return level
+}
@@ -0,0 +1,125 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package formats
+
+import (
+	"testing"
+)
+
+func TestDetect(t *testing.T) {
+	set, err := NewSet(Names(), nil)
+	if err != nil {
+		t.Fatalf("NewSet() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		line string
+		want *Fields
+	}{
+		{
+			name: "spring boot",
+			line: "2025-01-01T12:00:00.000Z  INFO 1 --- [           main] c.e.demo.DemoApplication                 : Started DemoApplication in 2.1 seconds",
+			want: &Fields{Format: SpringBoot, Level: "INFO", Thread: "main", Logger: "c.e.demo.DemoApplication"},
+		},
+		{
+			name: "spring boot with application name and stack trace",
+			line: "2025-01-01T12:00:00.000+00:00 ERROR 1 --- [orders] [nio-8080-exec-1] o.a.c.c.C.[.[.[/].[dispatcherServlet]    : Servlet.service() failed\n" +
+				"java.lang.IllegalStateException: order not found\n\tat com.example.Orders.get(Orders.java:42)\n" +
+				"Caused by: java.sql.SQLException: timeout",
+			want: &Fields{Format: SpringBoot, Level: "ERROR", Thread: "nio-8080-exec-1", Logger: "o.a.c.c.C.[.[.[/].[dispatcherServlet]", ExceptionClass: "java.lang.IllegalStateException"},
+		},
+		{
+			name: "pino",
+			line: `{"level":50,"time":1735732800000,"pid":1,"hostname":"api-0","name":"checkout","err":{"type":"TypeError","message":"x is undefined"},"msg":"request failed"}`,
+			want: &Fields{Format: Pino, Level: "ERROR", Logger: "checkout", ExceptionClass: "TypeError"},
+		},
+		{
+			name: "winston",
+			line: `{"level":"warn","message":"retrying","service":"cart","stack":"RangeError: too deep\n    at f (index.js:1:1)"}`,
+			want: &Fields{Format: Winston, Level: "WARN", Logger: "cart", ExceptionClass: "RangeError"},
+		},
+		{
+			name: "python basic config",
+			line: "WARNING:payments.db:connection lost",
+			want: &Fields{Format: Python, Level: "WARN", Logger: "payments.db"},
+		},
+		{
+			name: "python with traceback",
+			line: "2025-01-01 12:00:00,123 - payments.api - CRITICAL - unhandled error\nTraceback (most recent call last):\n" +
+				"  File \"app.py\", line 3, in <module>\n    pay()\npayments.errors.CardDeclined: card declined\n",
+			want: &Fields{Format: Python, Level: "FATAL", Logger: "payments.api", ExceptionClass: "payments.errors.CardDeclined"},
+		},
+		{
+			name: "envoy",
+			line: "[2025-01-01 12:00:00.123][14][warning][upstream] [source/common/upstream/cds_api_helper.cc:35] cds: add 3 cluster(s)",
+			want: &Fields{Format: Envoy, Level: "WARN", Thread: "14", Logger: "upstream"},
+		},
+		{
+			name: "envoy access log",
+			line: `[2025-01-01T12:00:00.123Z] "GET /orders HTTP/1.1" 503 UF 0 91 2 - "-" "curl/8.0" "id" "orders:8080" "10.0.0.1:8080"`,
+			want: &Fields{Format: Envoy, Level: "ERROR", Logger: "access"},
+		},
+		{
+			name: "plain text",
+			line: "Listening on :8080",
+		},
+		{
+			name: "other JSON",
+			line: `{"severity":"INFO","text":"ready"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := set.Detect("comp-1", "api", tt.line)
+			if (got == nil) != (tt.want == nil) || got != nil && *got != *tt.want {
+				t.Errorf("Detect() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetComponents(t *testing.T) {
+	set, err := NewSet([]string{Python}, map[string]string{"comp-1": Envoy, "legacy": None})
+	if err != nil {
+		t.Fatalf("NewSet() error = %v", err)
+	}
+	python := "ERROR:root:boom"
+	envoy := "[2025-01-01 12:00:00.123][14][info][main] [source/server/server.cc:1] starting"
+
+	if got := set.Detect("comp-1", "api", python); got != nil {
+		t.Errorf("expected only the envoy detector for comp-1, got %+v", got)
+	}
+	if got := set.Detect("comp-1", "api", envoy); got == nil || got.Format != Envoy {
+		t.Errorf("expected an envoy line for comp-1, got %+v", got)
+	}
+	if got := set.Detect("comp-2", "legacy", python); got != nil {
+		t.Errorf("expected detection to be disabled by name, got %+v", got)
+	}
+	if got := set.Detect("comp-3", "worker", python); got == nil || got.Format != Python {
+		t.Errorf("expected the default detectors, got %+v", got)
+	}
+
+	if _, err := NewSet([]string{"log4j"}, nil); err == nil {
+		t.Error("expected an error for an unknown default detector")
+	}
+	if _, err := NewSet(nil, map[string]string{"comp-1": "log4j"}); err == nil {
+		t.Error("expected an error for an unknown component detector")
+	}
+}
+
+func TestParseComponents(t *testing.T) {
+	got, err := ParseComponents(" orders=springboot, 3f1c9a2e-0000-4000-8000-000000000001 = none ,")
+	if err != nil {
+		t.Fatalf("ParseComponents() error = %v", err)
+	}
+	if len(got) != 2 || got["orders"] != SpringBoot || got["3f1c9a2e-0000-4000-8000-000000000001"] != None {
+		t.Errorf("unexpected components: %v", got)
+	}
+	for _, s := range []string{"orders", "=pino", "orders="} {
+		if _, err := ParseComponents(s); err == nil {
+			t.Errorf("expected an error for %q", s)
+		}
+	}
+}
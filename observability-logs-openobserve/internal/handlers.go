@@ -5,25 +5,103 @@ package app
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	openapi_types "github.com/oapi-codegen/runtime/types"
-
+	"github.com/openchoreo/community-modules/common/prefer"
+
+	"github.com/openchoreo/community-modules/common/access"
+	"github.com/openchoreo/community-modules/common/audit"
+	"github.com/openchoreo/community-modules/common/auth"
+	"github.com/openchoreo/community-modules/common/metrics"
+	"github.com/openchoreo/community-modules/common/ratelimit"
+	"github.com/openchoreo/community-modules/common/respcache"
+	"github.com/openchoreo/community-modules/common/shares"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/alertsync"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/annotations"
 	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/diagnostics"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/export"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/formats"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/holds"
 	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/observer"
 	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/presets"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/scheduler"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/sessions"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/shadow"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/slis"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/tenants"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/usage"
 )
 
 // LogsHandler implements the generated StrictServerInterface.
 type LogsHandler struct {
 	client         *openobserve.Client
 	observerClient *observer.Client
-	logger         *slog.Logger
+	exporter       *export.Manager
+	holds          *holds.FileStore
+	holdObjectLock bool
+	annotations    *annotations.FileStore
+	// presets holds the query defaults of each namespace.
+	presets *presets.FileStore
+	// alertRules registers the alert rules synced to OpenObserve, which
+	// alertDrift compares with the alerts present there.
+	alertRules *alertsync.FileStore
+	alertDrift *alertsync.Checker
+	// alertDestinations maps alert severities to OpenObserve destinations.
+	alertDestinations map[string][]string
+	// gatewayNamespace is the namespace of the API gateway pods.
+	gatewayNamespace string
+	// tenants resolves the backend of each namespace in multi-tenant mode.
+	tenants *tenants.Registry
+	// shareSigner signs share links, which last at most shareMaxTTL.
+	shareSigner *shares.Signer
+	shareMaxTTL time.Duration
+	// access restricts callers to aggregates in sensitive namespaces.
+	access *access.Policy
+	// requireTenancy rejects requests without the tenancy header.
+	requireTenancy bool
+	// scheduler shares the OpenObserve query slots between endpoint classes.
+	scheduler *scheduler.Scheduler
+	// plans caches the SQL of log queries for the clients.
+	plans *openobserve.PlanCache
+	// schema validates the hits of log queries in strict mode.
+	schema *openobserve.SchemaValidator
+	// queryBudget bounds each search of interactive logs queries, which
+	// return partial results rather than failing once it runs out.
+	queryBudget time.Duration
+	// responseCache serves the responses of repeated queries.
+	responseCache *respcache.Cache
+	// rateLimiter bounds the rate and concurrency of the queries of each namespace.
+	rateLimiter *ratelimit.Limiter
+	// auditLog records the query and alert operations.
+	auditLog *audit.Log
+	// slis records the requests for the adapter's SLIs.
+	slis *slis.Recorder
+	// shadow records the shape of the requests for compatibility tests.
+	shadow *shadow.Recorder
+	// diagnostics records the recent failures for support bundles, which
+	// include redactedConfig.
+	diagnostics    *diagnostics.Journal
+	redactedConfig map[string]any
+	// metrics records the requests and OpenObserve calls for GET /metrics.
+	metrics *metrics.Metrics
+	// usage counts the requests by route, scope type and feature for GET /metrics.
+	usage *usage.Recorder
+	// follows bounds the pod log follows each caller holds open in a namespace.
+	follows *sessions.Limiter
+	// authenticator authenticates all requests but those for authExemptPaths.
+	authenticator   auth.Authenticator
+	authExemptPaths []string
+	logger          *slog.Logger
 }
 
 func NewLogsHandler(client *openobserve.Client, observerClient *observer.Client, logger *slog.Logger) *LogsHandler {
@@ -34,6 +112,214 @@ func NewLogsHandler(client *openobserve.Client, observerClient *observer.Client,
 	}
 }
 
+// SetExporter enables the log export endpoints, which are disabled by default.
+func (h *LogsHandler) SetExporter(exporter *export.Manager) {
+	h.exporter = exporter
+}
+
+// SetHoldStore enables the legal hold endpoints, which also require an
+// exporter. When objectLock is set, snapshot objects are written with an S3
+// Object Lock legal hold.
+func (h *LogsHandler) SetHoldStore(store *holds.FileStore, objectLock bool) {
+	h.holds = store
+	h.holdObjectLock = objectLock
+}
+
+// SetAnnotationStore enables the log annotation endpoints and the
+// annotations returned inline by logs queries.
+func (h *LogsHandler) SetAnnotationStore(store *annotations.FileStore) {
+	h.annotations = store
+}
+
+// SetPresetStore makes logs and events queries default the fields they
+// leave out to the preset of their namespace, and enables the admin
+// endpoints managing the presets.
+func (h *LogsHandler) SetPresetStore(store *presets.FileStore) {
+	h.presets = store
+}
+
+// SetAlertDriftChecker registers the alert rules synced through the adapter
+// in store and enables the alert drift report of checker, which compares
+// them with the alerts present in OpenObserve.
+func (h *LogsHandler) SetAlertDriftChecker(store *alertsync.FileStore, checker *alertsync.Checker) {
+	h.alertRules = store
+	h.alertDrift = checker
+}
+
+// SetAlertDestinations sets the OpenObserve destinations notified by alerts
+// of each severity. Alerts without a severity, or with a severity that has no
+// destinations, notify openobserve.DefaultAlertDestination.
+func (h *LogsHandler) SetAlertDestinations(destinations map[string][]string) {
+	h.alertDestinations = destinations
+}
+
+// SetGatewayNamespace sets the Kubernetes namespace searched for gateway
+// access logs. All namespaces are searched when it is empty.
+func (h *LogsHandler) SetGatewayNamespace(namespace string) {
+	h.gatewayNamespace = namespace
+}
+
+// SetTenants enables multi-tenant mode: each namespace is served by the
+// OpenObserve organization and credentials of its tenant, and requests for
+// namespaces without a tenant are rejected. The client passed to
+// NewLogsHandler is then only used for gateway access logs.
+func (h *LogsHandler) SetTenants(registry *tenants.Registry) {
+	h.tenants = registry
+}
+
+// SetShareSigner enables share links signed by signer that last at most maxTTL.
+func (h *LogsHandler) SetShareSigner(signer *shares.Signer, maxTTL time.Duration) {
+	h.shareSigner = signer
+	h.shareMaxTTL = maxTTL
+}
+
+// SetAccessPolicy restricts the callers named by policy to aggregates in
+// the namespaces of its aggregation-only rules.
+func (h *LogsHandler) SetAccessPolicy(policy *access.Policy) {
+	h.access = policy
+}
+
+// SetTenancyHeaderRequired makes the tenancy header mandatory for every
+// request that reads a namespace.
+func (h *LogsHandler) SetTenancyHeaderRequired(required bool) {
+	h.requireTenancy = required
+}
+
+// SetScheduler reports the scheduling of the OpenObserve queries of each
+// request with s in its response and metrics. The clients must share s.
+func (h *LogsHandler) SetScheduler(s *scheduler.Scheduler) {
+	h.scheduler = s
+}
+
+// SetPlanCache reports the plan cache p, which the clients must share, in
+// the metrics.
+func (h *LogsHandler) SetPlanCache(p *openobserve.PlanCache) {
+	h.plans = p
+}
+
+// SetSchemaValidator reports the malformed hits counted by v, which the
+// clients must share, in the metrics.
+func (h *LogsHandler) SetSchemaValidator(v *openobserve.SchemaValidator) {
+	h.schema = v
+}
+
+// SetQueryBudget bounds each OpenObserve search of interactive logs
+// queries to budget. Queries whose searches run out of it return the logs
+// received so far, flagged partial with a warning, instead of failing.
+// Exports and streamed queries are not bounded.
+func (h *LogsHandler) SetQueryBudget(budget time.Duration) {
+	h.queryBudget = budget
+}
+
+// SetResponseCache serves the responses of repeated queries from cache, and
+// its metrics.
+func (h *LogsHandler) SetResponseCache(cache *respcache.Cache) {
+	h.responseCache = cache
+}
+
+// SetRateLimiter rejects the queries of namespaces over the limits of
+// limiter with 429, and serves its metrics. Queries are not limited by
+// default.
+func (h *LogsHandler) SetRateLimiter(limiter *ratelimit.Limiter) {
+	h.rateLimiter = limiter
+}
+
+// SetAuditLog records the query and alert operations in log, and serves its
+// metrics. Operations are not audited by default.
+func (h *LogsHandler) SetAuditLog(log *audit.Log) {
+	h.auditLog = log
+}
+
+// SetSLIRecorder records the duration and outcome of every request with r.
+func (h *LogsHandler) SetSLIRecorder(r *slis.Recorder) {
+	h.slis = r
+}
+
+// SetShadowRecorder records the sanitized shape of every request and its
+// response with r.
+func (h *LogsHandler) SetShadowRecorder(r *shadow.Recorder) {
+	h.shadow = r
+}
+
+// SetUsageRecorder counts the requests served successfully with r, by route,
+// scope type and feature, and serves r in the metrics.
+func (h *LogsHandler) SetUsageRecorder(r *usage.Recorder) {
+	h.usage = r
+}
+
+// SetFollowLimiter rejects the pod log follows of callers already holding
+// the maximum of limiter open in the namespace with 429, and serves the
+// session metrics on GET /metrics. Follows are not limited by default.
+func (h *LogsHandler) SetFollowLimiter(limiter *sessions.Limiter) {
+	h.follows = limiter
+}
+
+// SetMetrics records every request with m and serves m in the metrics. The
+// clients must report their calls to m.
+func (h *LogsHandler) SetMetrics(m *metrics.Metrics) {
+	h.metrics = m
+}
+
+// SetAuthenticator rejects the requests a does not accept, except those for
+// exemptPaths; see auth.Middleware.
+func (h *LogsHandler) SetAuthenticator(a auth.Authenticator, exemptPaths []string) {
+	h.authenticator = a
+	h.authExemptPaths = exemptPaths
+}
+
+// authorize checks that the caller of ctx may read content from namespace,
+// and that namespace is the one of its tenancy header.
+func (h *LogsHandler) authorize(ctx context.Context, namespace string, content access.Content) error {
+	if err := checkTenancy(ctx, namespace); err != nil {
+		header, _ := tenancyFromContext(ctx)
+		h.logger.Warn("Rejected request outside its tenancy namespace",
+			slog.String("caller", callerFromContext(ctx)),
+			slog.String("header", header),
+			slog.String("namespace", namespace),
+		)
+		return err
+	}
+	return h.access.Authorize(namespace, callerFromContext(ctx), content)
+}
+
+// aggregationOnly reports whether the caller of ctx is restricted to
+// aggregates in namespace. Summaries served to such callers leave out the
+// log lines and event messages they quote.
+func (h *LogsHandler) aggregationOnly(ctx context.Context, namespace string) bool {
+	return h.access != nil && h.access.AggregationOnly(namespace, callerFromContext(ctx))
+}
+
+// clientFor returns the client that serves namespace once the caller of ctx
+// is authorized to read content from it.
+func (h *LogsHandler) clientFor(ctx context.Context, namespace string, content access.Content) (*openobserve.Client, error) {
+	if err := h.authorize(ctx, namespace, content); err != nil {
+		return nil, err
+	}
+	if h.tenants == nil {
+		return h.client, nil
+	}
+	return h.tenants.Client(namespace)
+}
+
+// alertClient returns the client of the tenant that owns the alert rule
+// ruleName. Alert rules are addressed by name only, so in multi-tenant mode
+// the tenants are searched in turn.
+func (h *LogsHandler) alertClient(ctx context.Context, ruleName string) (*openobserve.Client, error) {
+	if h.tenants == nil {
+		return h.client, nil
+	}
+	for _, client := range h.tenants.Clients() {
+		_, err := client.GetAlert(ctx, ruleName)
+		if err == nil {
+			return client, nil
+		}
+		if !strings.Contains(err.Error(), "not found") {
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("alert %q not found", ruleName)
+}
+
 // Ensure LogsHandler implements the interface at compile time.
 var _ gen.StrictServerInterface = (*LogsHandler)(nil)
 
@@ -51,6 +337,66 @@ func (h *LogsHandler) QueryLogs(ctx context.Context, request gen.QueryLogsReques
 			Message: ptr("request body is required"),
 		}, nil
 	}
+	h.applyLogsQueryPreset(request.Body)
+	if request.Body.SearchPhrase != nil && *request.Body.SearchPhrase != "" {
+		noteUsage(ctx, "", usage.FeatureSearchPhrase)
+	}
+	if request.Body.LogLevels != nil && len(*request.Body.LogLevels) > 0 {
+		noteUsage(ctx, "", usage.FeatureLogLevels)
+	}
+
+	ext := queryExtensionsFromContext(ctx)
+	if err := openobserve.ValidateSortField(ext.SortField); err != nil {
+		return gen.QueryLogs400JSONResponse{
+			Title:   ptr(gen.BadRequest),
+			Message: ptr(err.Error()),
+		}, nil
+	}
+	if err := validateLogSources(ext.Sources); err != nil {
+		return gen.QueryLogs400JSONResponse{
+			Title:   ptr(gen.BadRequest),
+			Message: ptr(err.Error()),
+		}, nil
+	}
+	extractors, err := compileExtractRules(ext.Extract)
+	if err != nil {
+		return gen.QueryLogs400JSONResponse{
+			Title:   ptr(gen.BadRequest),
+			Message: ptr(err.Error()),
+		}, nil
+	}
+	var searchQuery *openobserve.SearchQuery
+	if ext.Query != "" {
+		if searchQuery, err = openobserve.ParseSearchQuery(ext.Query); err != nil {
+			return gen.QueryLogs400JSONResponse{
+				Title:   ptr(gen.BadRequest),
+				Message: ptr("invalid query: " + err.Error()),
+			}, nil
+		}
+	}
+	if format := exportFormatFromContext(ctx); format != "" {
+		if _, gateway := asGatewaySearchScope(request.Body.SearchScope); gateway || len(ext.Sources) > 0 {
+			return gen.QueryLogs400JSONResponse{
+				Title:   ptr(gen.BadRequest),
+				Message: ptr(fmt.Sprintf("%s exports are only supported for component and workflow logs", format)),
+			}, nil
+		}
+	}
+	if len(ext.Sources) > 0 {
+		noteUsage(ctx, usage.ScopeSources)
+		return h.queryLogSources(ctx, request.Body, ext, extractors, searchQuery)
+	}
+
+	if gatewayScope, ok := asGatewaySearchScope(request.Body.SearchScope); ok {
+		noteUsage(ctx, usage.ScopeGateway)
+		if searchQuery != nil {
+			return gen.QueryLogs400JSONResponse{
+				Title:   ptr(gen.BadRequest),
+				Message: ptr("query is not supported for gateway logs"),
+			}, nil
+		}
+		return h.queryGatewayLogs(ctx, request.Body, gatewayScope)
+	}
 
 	// Try to interpret the search scope as a WorkflowSearchScope first
 	// A WorkflowSearchScope is identified by having a workflowRunName field
@@ -63,21 +409,57 @@ func (h *LogsHandler) QueryLogs(ctx context.Context, request gen.QueryLogsReques
 			}, nil
 		}
 
+		client, err := h.clientFor(ctx, workflowScope.Namespace, access.Raw)
+		if err != nil {
+			return gen.QueryLogs403JSONResponse{
+				Title:   ptr(gen.Forbidden),
+				Message: ptr(err.Error()),
+			}, nil
+		}
+
 		params := toWorkflowLogsParams(request.Body, &workflowScope)
-		result, err := h.client.GetWorkflowLogs(ctx, params)
+		params.SortField = ext.SortField
+		params.Extract = extractors
+		params.Query = searchQuery
+		format := exportFormatFromContext(ctx)
+		if format != "" {
+			params.Limit = exportLimit(request.Body)
+		}
+		params.Limit = prefer.FromContext(ctx).Limit(params.Limit)
+		noteUsage(ctx, usage.ScopeWorkflow)
+		if params.StepName != "" || params.PodName != "" {
+			noteUsage(ctx, "", usage.FeatureWorkflowStep)
+		}
+		if format != "" {
+			return h.exportWorkflowLogs(ctx, client, params, format)
+		}
+		if params.Limit > maxInteractiveLimit {
+			noteUsage(ctx, "", usage.FeatureStreaming)
+			return h.streamWorkflowLogs(ctx, client, params)
+		}
+		// Arrow responses cannot flag partial results, so they are not bounded.
+		if !arrowFormatFromContext(ctx) {
+			params.Budget = h.queryBudget
+		}
+		result, err := client.GetWorkflowLogs(ctx, params)
 		if err != nil {
 			h.logger.Error("Failed to query workflow logs",
 				slog.String("function", "QueryLogs"),
 				slog.String("namespace", workflowScope.Namespace),
 				slog.Any("error", err),
 			)
-			return gen.QueryLogs500JSONResponse{
-				Title:   ptr(gen.InternalServerError),
-				Message: ptr("internal server error"),
-			}, nil
+			return queryLogsError(err), nil
 		}
 
-		return gen.QueryLogs200JSONResponse(toWorkflowLogsQueryResponse(result)), nil
+		if arrowFormatFromContext(ctx) {
+			return workflowLogsArrowResponse{result: result}, nil
+		}
+		notes := h.overlappingAnnotations(annotations.Filter{
+			Namespace: workflowScope.Namespace,
+			StartTime: params.StartTime,
+			EndTime:   params.EndTime,
+		})
+		return queryLogsOK(ctx, toWorkflowLogsQueryResponse(result), notes, result.ParseErrors, result.PartialResult), nil
 	}
 
 	// Fall back to ComponentSearchScope
@@ -89,22 +471,67 @@ func (h *LogsHandler) QueryLogs(ctx context.Context, request gen.QueryLogsReques
 		}, nil
 	}
 
+	client, err := h.clientFor(ctx, scope.Namespace, access.Raw)
+	if err != nil {
+		return gen.QueryLogs403JSONResponse{
+			Title:   ptr(gen.Forbidden),
+			Message: ptr(err.Error()),
+		}, nil
+	}
+
 	params := toComponentLogsParams(request.Body, &scope)
+	params.SortField = ext.SortField
+	params.Extract = extractors
+	params.Query = searchQuery
+	format := exportFormatFromContext(ctx)
+	if format != "" {
+		params.Limit = exportLimit(request.Body)
+	}
+	params.Limit = prefer.FromContext(ctx).Limit(params.Limit)
+	noteUsage(ctx, usage.ScopeComponent)
+	if len(params.EnvironmentIDs) > 0 || params.EnvironmentID == openobserve.AllEnvironments {
+		noteUsage(ctx, "", usage.FeatureEnvironments)
+	}
+	if format != "" {
+		return h.exportComponentLogs(ctx, client, params, format)
+	}
+	if params.Limit > maxInteractiveLimit {
+		noteUsage(ctx, "", usage.FeatureStreaming)
+		return h.streamComponentLogs(ctx, client, params)
+	}
 
-	result, err := h.client.GetComponentLogs(ctx, params)
+	// Arrow responses cannot flag partial results, so they are not bounded.
+	if !arrowFormatFromContext(ctx) {
+		params.Budget = h.queryBudget
+	}
+	result, err := client.GetComponentLogs(ctx, params)
 	if err != nil {
 		h.logger.Error("Failed to query component logs",
 			slog.String("function", "QueryLogs"),
 			slog.String("namespace", scope.Namespace),
 			slog.Any("error", err),
 		)
-		return gen.QueryLogs500JSONResponse{
-			Title:   ptr(gen.InternalServerError),
-			Message: ptr("internal server error"),
-		}, nil
+		return queryLogsError(err), nil
 	}
 
-	return gen.QueryLogs200JSONResponse(toLogsQueryResponse(result)), nil
+	if arrowFormatFromContext(ctx) {
+		return componentLogsArrowResponse{result: result}, nil
+	}
+	filter := annotations.Filter{
+		Namespace:      params.Namespace,
+		ProjectUID:     params.ProjectID,
+		EnvironmentUID: params.EnvironmentID,
+		StartTime:      params.StartTime,
+		EndTime:        params.EndTime,
+	}
+	if len(params.EnvironmentIDs) > 0 || params.EnvironmentID == openobserve.AllEnvironments {
+		// Queries across environments receive the annotations of all of them.
+		filter.EnvironmentUID = ""
+	}
+	if len(params.ComponentIDs) == 1 {
+		filter.ComponentUID = params.ComponentIDs[0]
+	}
+	return queryLogsOK(ctx, toLogsQueryResponse(result), h.overlappingAnnotations(filter), result.ParseErrors, result.PartialResult), nil
 }
 
 // QueryEvents implements POST /api/v1/events/query.
@@ -115,6 +542,7 @@ func (h *LogsHandler) QueryEvents(ctx context.Context, request gen.QueryEventsRe
 			Message: ptr("request body is required"),
 		}, nil
 	}
+	h.applyEventsQueryPreset(request.Body)
 
 	// Try to interpret the search scope as a WorkflowSearchScope first.
 	// A WorkflowSearchScope is identified by having a workflowRunName field.
@@ -127,6 +555,7 @@ func (h *LogsHandler) QueryEvents(ctx context.Context, request gen.QueryEventsRe
 			}, nil
 		}
 
+		noteUsage(ctx, usage.ScopeWorkflow)
 		return h.queryWorkflowEvents(ctx, request.Body, &workflowScope)
 	}
 
@@ -139,10 +568,19 @@ func (h *LogsHandler) QueryEvents(ctx context.Context, request gen.QueryEventsRe
 		}, nil
 	}
 
+	noteUsage(ctx, usage.ScopeComponent)
 	return h.queryComponentEvents(ctx, request.Body, &scope)
 }
 
 func (h *LogsHandler) queryComponentEvents(ctx context.Context, req *gen.EventsQueryRequest, scope *gen.ComponentSearchScope) (gen.QueryEventsResponseObject, error) {
+	client, err := h.clientFor(ctx, scope.Namespace, access.Raw)
+	if err != nil {
+		return gen.QueryEvents403JSONResponse{
+			Title:   ptr(gen.Forbidden),
+			Message: ptr(err.Error()),
+		}, nil
+	}
+
 	params := openobserve.EventsQueryParams{
 		Namespace: scope.Namespace,
 		StartTime: req.StartTime,
@@ -163,24 +601,30 @@ func (h *LogsHandler) queryComponentEvents(ctx context.Context, req *gen.EventsQ
 	if req.SortOrder != nil {
 		params.SortOrder = string(*req.SortOrder)
 	}
+	params.Limit = prefer.FromContext(ctx).Limit(params.Limit)
 
-	result, err := h.client.GetComponentEvents(ctx, params)
+	result, err := client.GetComponentEvents(ctx, params)
 	if err != nil {
 		h.logger.Error("Failed to query component events",
 			slog.String("function", "QueryEvents"),
 			slog.String("namespace", scope.Namespace),
 			slog.Any("error", err),
 		)
-		return gen.QueryEvents500JSONResponse{
-			Title:   ptr(gen.InternalServerError),
-			Message: ptr("internal server error"),
-		}, nil
+		return queryEventsError(err), nil
 	}
 
-	return gen.QueryEvents200JSONResponse(toEventsQueryResponse(result)), nil
+	return queryEventsOK(ctx, toEventsQueryResponse(result)), nil
 }
 
 func (h *LogsHandler) queryWorkflowEvents(ctx context.Context, req *gen.EventsQueryRequest, scope *gen.WorkflowSearchScope) (gen.QueryEventsResponseObject, error) {
+	client, err := h.clientFor(ctx, scope.Namespace, access.Raw)
+	if err != nil {
+		return gen.QueryEvents403JSONResponse{
+			Title:   ptr(gen.Forbidden),
+			Message: ptr(err.Error()),
+		}, nil
+	}
+
 	params := openobserve.WorkflowEventsQueryParams{
 		Namespace: scope.Namespace,
 		StartTime: req.StartTime,
@@ -198,21 +642,19 @@ func (h *LogsHandler) queryWorkflowEvents(ctx context.Context, req *gen.EventsQu
 	if req.SortOrder != nil {
 		params.SortOrder = string(*req.SortOrder)
 	}
+	params.Limit = prefer.FromContext(ctx).Limit(params.Limit)
 
-	result, err := h.client.GetWorkflowEvents(ctx, params)
+	result, err := client.GetWorkflowEvents(ctx, params)
 	if err != nil {
 		h.logger.Error("Failed to query workflow events",
 			slog.String("function", "QueryEvents"),
 			slog.String("namespace", scope.Namespace),
 			slog.Any("error", err),
 		)
-		return gen.QueryEvents500JSONResponse{
-			Title:   ptr(gen.InternalServerError),
-			Message: ptr("internal server error"),
-		}, nil
+		return queryEventsError(err), nil
 	}
 
-	return gen.QueryEvents200JSONResponse(toEventsQueryResponse(result)), nil
+	return queryEventsOK(ctx, toEventsQueryResponse(result)), nil
 }
 
 // toEventsQueryResponse converts the internal events result to the generated response model.
@@ -287,8 +729,22 @@ func (h *LogsHandler) CreateAlertRule(ctx context.Context, request gen.CreateAle
 	}
 
 	params := toLogAlertParams(request.Body)
+	if err := h.applyAlertRuleExtensions(ctx, &params); err != nil {
+		return gen.CreateAlertRule400JSONResponse{
+			Title:   ptr(gen.BadRequest),
+			Message: ptr(err.Error()),
+		}, nil
+	}
+
+	client, err := h.clientFor(ctx, params.Namespace, access.Aggregates)
+	if err != nil {
+		return gen.CreateAlertRule400JSONResponse{
+			Title:   ptr(gen.BadRequest),
+			Message: ptr(err.Error()),
+		}, nil
+	}
 
-	alertID, err := h.client.CreateAlert(ctx, params)
+	alertID, err := client.CreateAlert(ctx, params)
 	if err != nil {
 		h.logger.Error("Failed to create alert",
 			slog.String("function", "CreateAlertRule"),
@@ -300,6 +756,7 @@ func (h *LogsHandler) CreateAlertRule(ctx context.Context, request gen.CreateAle
 			Message: ptr("internal server error"),
 		}, nil
 	}
+	h.recordAlertRule(client, params)
 
 	now := time.Now().UTC().Format(time.RFC3339)
 	return gen.CreateAlertRule201JSONResponse{
@@ -313,7 +770,11 @@ func (h *LogsHandler) CreateAlertRule(ctx context.Context, request gen.CreateAle
 
 // DeleteAlertRule implements DELETE /api/v1alpha1/alerts/rules/{ruleName}.
 func (h *LogsHandler) DeleteAlertRule(ctx context.Context, request gen.DeleteAlertRuleRequestObject) (gen.DeleteAlertRuleResponseObject, error) {
-	alertID, err := h.client.DeleteAlert(ctx, request.RuleName)
+	var alertID string
+	client, err := h.alertClient(ctx, request.RuleName)
+	if err == nil {
+		alertID, err = client.DeleteAlert(ctx, request.RuleName)
+	}
 	if err != nil {
 		h.logger.Error("Failed to delete alert",
 			slog.String("function", "DeleteAlertRule"),
@@ -325,6 +786,7 @@ func (h *LogsHandler) DeleteAlertRule(ctx context.Context, request gen.DeleteAle
 			Message: ptr("internal server error"),
 		}, nil
 	}
+	h.forgetAlertRule(request.RuleName)
 
 	now := time.Now().UTC().Format(time.RFC3339)
 	return gen.DeleteAlertRule200JSONResponse{
@@ -338,7 +800,11 @@ func (h *LogsHandler) DeleteAlertRule(ctx context.Context, request gen.DeleteAle
 
 // GetAlertRule implements GET /api/v1alpha1/alerts/rules/{ruleName}.
 func (h *LogsHandler) GetAlertRule(ctx context.Context, request gen.GetAlertRuleRequestObject) (gen.GetAlertRuleResponseObject, error) {
-	alert, err := h.client.GetAlert(ctx, request.RuleName)
+	var alert *openobserve.AlertDetail
+	client, err := h.alertClient(ctx, request.RuleName)
+	if err == nil {
+		alert, err = client.GetAlert(ctx, request.RuleName)
+	}
 	if err != nil {
 		h.logger.Error("Failed to get alert",
 			slog.String("function", "GetAlertRule"),
@@ -356,8 +822,15 @@ func (h *LogsHandler) GetAlertRule(ctx context.Context, request gen.GetAlertRule
 			Message: ptr("internal server error"),
 		}, nil
 	}
+	return toAlertRuleResponse(alert), nil
+}
 
-	searchPattern := openobserve.ExtractSearchPattern(alert.SQL)
+// toAlertRuleResponse converts an alert to the response of GetAlertRule.
+func toAlertRuleResponse(alert *openobserve.AlertDetail) gen.GetAlertRuleResponseObject {
+	searchPattern := alert.SearchPattern
+	if searchPattern == "" {
+		searchPattern = openobserve.ExtractSearchPattern(alert.SQL)
+	}
 	operator := gen.AlertRuleResponseConditionOperator(openobserve.ReverseMapOperator(alert.Operator))
 	threshold := float32(alert.Threshold)
 	window := openobserve.ToDurationString(alert.Period, alert.FrequencyType)
@@ -413,7 +886,47 @@ func (h *LogsHandler) GetAlertRule(ctx context.Context, request gen.GetAlertRule
 		},
 	}
 
-	return gen.GetAlertRule200JSONResponse(response), nil
+	if alert.Severity != "" || len(alert.Conditions) > 0 {
+		return alertRuleResponse{
+			AlertRuleResponse: response,
+			Severity:          strPtr(alert.Severity),
+			Conditions:        alert.Conditions,
+			ConditionMatch:    strPtr(alert.ConditionMatch),
+		}
+	}
+	return gen.GetAlertRule200JSONResponse(response)
+}
+
+// alertRuleResponse extends the generated AlertRuleResponse with the
+// adapter-specific alert rule extensions.
+type alertRuleResponse struct {
+	gen.AlertRuleResponse
+	Severity       *string                      `json:"severity,omitempty"`
+	Conditions     []openobserve.AlertCondition `json:"conditions,omitempty"`
+	ConditionMatch *string                      `json:"conditionMatch,omitempty"`
+}
+
+func (response alertRuleResponse) VisitGetAlertRuleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(response)
+}
+
+// applyAlertRuleExtensions sets the severity, the destinations configured
+// for it and the additional conditions from the alert rule extensions on params.
+func (h *LogsHandler) applyAlertRuleExtensions(ctx context.Context, params *openobserve.LogAlertParams) error {
+	ext := alertRuleExtensionsFromContext(ctx)
+	if err := openobserve.ValidateAlertSeverity(ext.Severity); err != nil {
+		return err
+	}
+	if err := openobserve.ValidateAlertConditions(ext.Conditions, ext.ConditionMatch); err != nil {
+		return err
+	}
+	params.Severity = ext.Severity
+	params.Destinations = h.alertDestinations[ext.Severity]
+	params.Conditions = ext.Conditions
+	params.ConditionMatch = ext.ConditionMatch
+	return nil
 }
 
 // UpdateAlertRule implements PUT /api/v1alpha1/alerts/rules/{ruleName}.
@@ -426,8 +939,22 @@ func (h *LogsHandler) UpdateAlertRule(ctx context.Context, request gen.UpdateAle
 	}
 
 	params := toLogAlertParams(request.Body)
+	if err := h.applyAlertRuleExtensions(ctx, &params); err != nil {
+		return gen.UpdateAlertRule400JSONResponse{
+			Title:   ptr(gen.BadRequest),
+			Message: ptr(err.Error()),
+		}, nil
+	}
+
+	client, err := h.clientFor(ctx, params.Namespace, access.Aggregates)
+	if err != nil {
+		return gen.UpdateAlertRule400JSONResponse{
+			Title:   ptr(gen.BadRequest),
+			Message: ptr(err.Error()),
+		}, nil
+	}
 
-	alertID, err := h.client.UpdateAlert(ctx, request.RuleName, params)
+	alertID, err := client.UpdateAlert(ctx, request.RuleName, params)
 	if err != nil {
 		h.logger.Error("Failed to update alert",
 			slog.String("function", "UpdateAlertRule"),
@@ -451,6 +978,8 @@ func (h *LogsHandler) UpdateAlertRule(ctx context.Context, request gen.UpdateAle
 			Message: ptr("internal server error"),
 		}, nil
 	}
+	params.Name = &request.RuleName
+	h.recordAlertRule(client, params)
 
 	now := time.Now().UTC().Format(time.RFC3339)
 	return gen.UpdateAlertRule200JSONResponse{
@@ -487,7 +1016,11 @@ func (h *LogsHandler) HandleAlertWebhook(ctx context.Context, request gen.Handle
 		defer cancel()
 
 		// Retrieve the alert details from OpenObserve to get the namespace. This is because the webhook body does not contain the namespace, but the observer's webhook API requires it.
-		alertDetail, err := h.client.GetAlert(forwardCtx, alertName)
+		var alertDetail *openobserve.AlertDetail
+		client, err := h.alertClient(forwardCtx, alertName)
+		if err == nil {
+			alertDetail, err = client.GetAlert(forwardCtx, alertName)
+		}
 		if err != nil {
 			h.logger.Error("Failed to get alert details from OpenObserve",
 				slog.String("alertName", alertName),
@@ -564,6 +1097,9 @@ func toWorkflowLogsParams(req *gen.LogsQueryRequest, scope *gen.WorkflowSearchSc
 	if scope.WorkflowRunName != nil {
 		params.WorkflowRunName = *scope.WorkflowRunName
 	}
+	ext := workflowScopeExtensionsOf(req.SearchScope)
+	params.StepName = ext.StepName
+	params.PodName = ext.PodName
 	if req.Limit != nil {
 		params.Limit = *req.Limit
 	}
@@ -585,27 +1121,38 @@ func toWorkflowLogsParams(req *gen.LogsQueryRequest, scope *gen.WorkflowSearchSc
 
 // toWorkflowLogsQueryResponse converts the internal workflow result to the generated response model.
 func toWorkflowLogsQueryResponse(result *openobserve.WorkflowLogsResult) gen.LogsQueryResponse {
-	entries := make([]gen.WorkflowLogEntry, 0, len(result.Logs))
-	for _, l := range result.Logs {
-		entry := gen.WorkflowLogEntry{
-			Timestamp: &l.Timestamp,
-			Log:       &l.Log,
-		}
-		entries = append(entries, entry)
-	}
-
 	resp := gen.LogsQueryResponse{
 		Total:  &result.TotalCount,
 		TookMs: &result.Took,
 	}
-
-	logs := gen.LogsQueryResponse_Logs{}
-	_ = logs.FromLogsQueryResponseLogs1(entries)
-	resp.Logs = &logs
+	resp.Logs = toLogsUnion(toWorkflowLogEntries(result.Logs))
 
 	return resp
 }
 
+// toWorkflowLogEntries converts internal workflow log entries to their serialized form.
+func toWorkflowLogEntries(logs []openobserve.WorkflowLogsEntry) []workflowLogEntry {
+	entries := make([]workflowLogEntry, 0, len(logs))
+	for _, l := range logs {
+		entry := workflowLogEntry{
+			WorkflowLogEntry: gen.WorkflowLogEntry{
+				Timestamp: &l.Timestamp,
+				Log:       &l.Log,
+			},
+			EventTime:        timePtr(l.EventTime),
+			IngestTime:       timePtr(l.IngestTime),
+			Cluster:          l.Cluster,
+			LogLevel:         l.LogLevel,
+			LogLevelInferred: l.LogLevelInferred,
+		}
+		if len(l.Extracted) > 0 {
+			entry.Metadata = &workflowLogMetadata{Extracted: l.Extracted}
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
 // toComponentLogsParams converts the generated request to internal query params.
 func toComponentLogsParams(req *gen.LogsQueryRequest, scope *gen.ComponentSearchScope) openobserve.ComponentLogsParams {
 	params := openobserve.ComponentLogsParams{
@@ -622,6 +1169,7 @@ func toComponentLogsParams(req *gen.LogsQueryRequest, scope *gen.ComponentSearch
 	if scope.ComponentUid != nil {
 		params.ComponentIDs = []string{*scope.ComponentUid}
 	}
+	params.EnvironmentIDs = componentScopeExtensionsOf(req.SearchScope).EnvironmentUids
 	if req.Limit != nil {
 		params.Limit = *req.Limit
 	}
@@ -661,24 +1209,105 @@ func toLogAlertParams(req *gen.AlertRuleRequest) openobserve.LogAlertParams {
 
 // toLogsQueryResponse converts the internal result to the generated response model.
 func toLogsQueryResponse(result *openobserve.ComponentLogsResult) gen.LogsQueryResponse {
-	entries := make([]gen.ComponentLogEntry, 0, len(result.Logs))
-	for _, l := range result.Logs {
-		entry := toComponentLogEntry(&l)
-		entries = append(entries, entry)
-	}
-
 	resp := gen.LogsQueryResponse{
 		Total:  &result.TotalCount,
 		TookMs: &result.Took,
 	}
-
-	logs := gen.LogsQueryResponse_Logs{}
-	_ = logs.FromLogsQueryResponseLogs0(entries)
-	resp.Logs = &logs
+	resp.Logs = toLogsUnion(toComponentLogEntries(result.Logs))
 
 	return resp
 }
 
+// toComponentLogEntries converts internal component log entries to their serialized form.
+func toComponentLogEntries(logs []openobserve.ComponentLogsEntry) []componentLogEntry {
+	entries := make([]componentLogEntry, 0, len(logs))
+	for _, l := range logs {
+		entry := componentLogEntry{
+			ComponentLogEntry: toComponentLogEntry(&l),
+			EventTime:         timePtr(l.EventTime),
+			IngestTime:        timePtr(l.IngestTime),
+		}
+		entry.Metadata = &componentLogMetadata{
+			genComponentLogMetadata: genComponentLogMetadata(*entry.ComponentLogEntry.Metadata),
+			Fields:                  l.Format,
+			Extracted:               l.Extracted,
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// componentLogEntry extends the generated ComponentLogEntry with the
+// adapter-specific timestamps. Embedding keeps the shared contract fields at
+// the top level of each serialized entry.
+type componentLogEntry struct {
+	gen.ComponentLogEntry
+	// Metadata shadows the metadata of the generated entry.
+	Metadata   *componentLogMetadata `json:"metadata,omitempty"`
+	EventTime  *time.Time            `json:"eventTime,omitempty"`
+	IngestTime *time.Time            `json:"ingestTime,omitempty"`
+}
+
+// componentLogMetadata extends the metadata of the generated
+// ComponentLogEntry with the fields detected from the log format: logFormat,
+// level, logger, thread and exceptionClass.
+type componentLogMetadata struct {
+	genComponentLogMetadata
+	*formats.Fields
+	// Extracted holds the fields extracted by the extract rules of the query.
+	Extracted map[string]string `json:"extracted,omitempty"`
+}
+
+// genComponentLogMetadata is the metadata of the generated ComponentLogEntry,
+// named so that it can be embedded. Converting to it fails to compile if the
+// two drift apart.
+type genComponentLogMetadata struct {
+	ComponentName   *string             `json:"componentName,omitempty"`
+	ComponentUid    *openapi_types.UUID `json:"componentUid,omitempty"`
+	ContainerName   *string             `json:"containerName,omitempty"`
+	EnvironmentName *string             `json:"environmentName,omitempty"`
+	EnvironmentUid  *openapi_types.UUID `json:"environmentUid,omitempty"`
+	NamespaceName   *string             `json:"namespaceName,omitempty"`
+	PodName         *string             `json:"podName,omitempty"`
+	PodNamespace    *string             `json:"podNamespace,omitempty"`
+	ProjectName     *string             `json:"projectName,omitempty"`
+	ProjectUid      *openapi_types.UUID `json:"projectUid,omitempty"`
+}
+
+// workflowLogEntry extends the generated WorkflowLogEntry with the
+// adapter-specific timestamps and metadata.
+type workflowLogEntry struct {
+	gen.WorkflowLogEntry
+	EventTime  *time.Time           `json:"eventTime,omitempty"`
+	IngestTime *time.Time           `json:"ingestTime,omitempty"`
+	Metadata   *workflowLogMetadata `json:"metadata,omitempty"`
+	// Cluster is the build plane the entry was read from, when the
+	// adapter reads several.
+	Cluster string `json:"cluster,omitempty"`
+	// LogLevel is the level of the entry, inferred from its log text when
+	// the step recorded none, which LogLevelInferred tells.
+	LogLevel         string `json:"logLevel,omitempty"`
+	LogLevelInferred bool   `json:"logLevelInferred,omitempty"`
+}
+
+// workflowLogMetadata is the metadata of a workflow log entry. The shared
+// contract has none, so it is only set when fields were extracted.
+type workflowLogMetadata struct {
+	// Extracted holds the fields extracted by the extract rules of the query.
+	Extracted map[string]string `json:"extracted,omitempty"`
+}
+
+// toLogsUnion serializes the extended entries into the generated logs union.
+// The generated From* helpers only accept the shared entry types, so the raw
+// JSON is loaded through UnmarshalJSON instead.
+func toLogsUnion(entries interface{}) *gen.LogsQueryResponse_Logs {
+	logs := gen.LogsQueryResponse_Logs{}
+	if raw, err := json.Marshal(entries); err == nil {
+		_ = logs.UnmarshalJSON(raw)
+	}
+	return &logs
+}
+
 func toComponentLogEntry(l *openobserve.ComponentLogsEntry) gen.ComponentLogEntry {
 	entry := gen.ComponentLogEntry{
 		Timestamp: &l.Timestamp,
@@ -737,6 +1366,13 @@ func ptr[T any](v T) *T {
 	return &v
 }
 
+func timePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
 func strPtr(s string) *string {
 	if s == "" {
 		return nil
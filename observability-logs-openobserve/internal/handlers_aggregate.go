@@ -0,0 +1,133 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/access"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+const (
+	// defaultAggregateWindow is the lookback used when an aggregate request has no startTime.
+	defaultAggregateWindow = time.Hour
+	// maxAggregateWindow bounds the window an aggregate query scans.
+	maxAggregateWindow = 7 * 24 * time.Hour
+)
+
+// logsAggregateRequest is the request body of POST /api/v1/logs/aggregate.
+type logsAggregateRequest struct {
+	SearchScope  *gen.ComponentSearchScope `json:"searchScope"`
+	StartTime    *time.Time                `json:"startTime"`
+	EndTime      *time.Time                `json:"endTime"`
+	SearchPhrase string                    `json:"searchPhrase"`
+	LogLevels    []string                  `json:"logLevels"`
+	GroupBy      []string                  `json:"groupBy"`
+	Aggregation  string                    `json:"aggregation"`
+	Field        string                    `json:"field"`
+	Limit        int                       `json:"limit"`
+}
+
+// logsAggregateResponse is the response body of POST /api/v1/logs/aggregate.
+type logsAggregateResponse struct {
+	Rows       []openobserve.AggregateRow `json:"rows"`
+	TookMs     int                        `json:"tookMs"`
+	QueryStats *queryStats                `json:"queryStats,omitempty"`
+}
+
+// AggregateLogs implements POST /api/v1/logs/aggregate. It returns the top
+// groups of the application logs of a scope, grouped by up to three fields,
+// by line count or by the number of distinct values of a field, so that
+// consoles can build simple analytics widgets such as the pods logging the
+// most errors.
+//
+// groupBy and field accept project, component, environment, pod, container
+// and level, or the name of another field of the logs stream, such as the
+// fields OpenObserve parses from JSON log lines. Callers restricted to
+// aggregates in the namespace may only use the former. aggregation is
+// "count" (the default) or "distinct". The window defaults to the last hour
+// and may span at most 7 days; limit defaults to 10 rows and is at most 100.
+func (h *LogsHandler) AggregateLogs(w http.ResponseWriter, r *http.Request) {
+	var req logsAggregateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "invalid request body")
+		return
+	}
+	if req.SearchScope == nil || strings.TrimSpace(req.SearchScope.Namespace) == "" {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "searchScope with a valid namespace is required")
+		return
+	}
+
+	params := openobserve.AggregateParams{
+		Namespace:    req.SearchScope.Namespace,
+		EndTime:      time.Now(),
+		SearchPhrase: req.SearchPhrase,
+		LogLevels:    req.LogLevels,
+		GroupBy:      req.GroupBy,
+		Aggregation:  req.Aggregation,
+		Field:        req.Field,
+		Limit:        req.Limit,
+		// Stream fields other than the OpenChoreo and Kubernetes ones may
+		// hold raw log content, e.g. the log field itself.
+		KnownFieldsOnly: h.aggregationOnly(r.Context(), req.SearchScope.Namespace),
+	}
+	if err := openobserve.ValidateAggregateParams(params); err != nil {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, err.Error())
+		return
+	}
+	if req.EndTime != nil {
+		params.EndTime = *req.EndTime
+	}
+	params.StartTime = params.EndTime.Add(-defaultAggregateWindow)
+	if req.StartTime != nil {
+		params.StartTime = *req.StartTime
+	}
+	if params.EndTime.Before(params.StartTime) {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "endTime must be >= startTime")
+		return
+	}
+	if params.EndTime.Sub(params.StartTime) > maxAggregateWindow {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "the window must not exceed 7 days")
+		return
+	}
+	scope := req.SearchScope
+	if scope.ProjectUid != nil {
+		params.ProjectID = *scope.ProjectUid
+	}
+	if scope.EnvironmentUid != nil {
+		params.EnvironmentID = *scope.EnvironmentUid
+	}
+	if scope.ComponentUid != nil {
+		params.ComponentID = *scope.ComponentUid
+	}
+
+	client, err := h.clientFor(r.Context(), params.Namespace, access.Aggregates)
+	if err != nil {
+		writeJSONError(w, http.StatusForbidden, gen.Forbidden, err.Error())
+		return
+	}
+
+	result, err := client.GetLogsAggregate(r.Context(), params)
+	if err != nil {
+		h.logger.Error("Failed to aggregate logs",
+			slog.String("function", "AggregateLogs"),
+			slog.String("namespace", params.Namespace),
+			slog.Any("error", err),
+		)
+		writeBackendError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, logsAggregateResponse{
+		Rows:       result.Rows,
+		TookMs:     result.Took,
+		QueryStats: queryStatsFromContext(r.Context()),
+	})
+}
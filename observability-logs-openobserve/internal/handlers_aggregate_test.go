@@ -0,0 +1,75 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+func TestAggregateLogs(t *testing.T) {
+	var queries []string
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query struct {
+				SQL string `json:"sql"`
+			} `json:"query"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		queries = append(queries, body.Query.SQL)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(openobserve.OpenObserveResponse{
+			Took: 2,
+			Hits: []map[string]interface{}{{"g0": "api-1", "value": float64(7)}},
+		})
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	handler := NewLogsHandler(client, nil, testLogger())
+
+	post := func(body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.AggregateLogs(rec, httptest.NewRequest(http.MethodPost, "/api/v1/logs/aggregate", strings.NewReader(body)))
+		return rec
+	}
+
+	t.Run("top pods by error count", func(t *testing.T) {
+		queries = nil
+		rec := post(`{"searchScope":{"namespace":"default","environmentUid":"env-1"},"logLevels":["ERROR"],"groupBy":["pod"],"limit":5}`)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var got logsAggregateResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(got.Rows) != 1 || got.Rows[0].Groups["pod"] != "api-1" || got.Rows[0].Value != 7 || got.TookMs != 2 {
+			t.Errorf("unexpected response: %s", rec.Body.String())
+		}
+		if len(queries) != 1 || !strings.Contains(queries[0], "environment_uid = 'env-1'") || !strings.Contains(queries[0], "GROUP BY g0") {
+			t.Errorf("expected one scoped GROUP BY query, got %v", queries)
+		}
+	})
+
+	for name, body := range map[string]string{
+		"invalid body":      `{`,
+		"missing namespace": `{"searchScope":{},"groupBy":["pod"]}`,
+		"missing groupBy":   `{"searchScope":{"namespace":"default"}}`,
+		"invalid field":     `{"searchScope":{"namespace":"default"},"groupBy":["Pod Name"]}`,
+		"inverted window":   `{"searchScope":{"namespace":"default"},"groupBy":["pod"],"startTime":"2026-03-02T00:00:00Z","endTime":"2026-03-01T00:00:00Z"}`,
+		"window too long":   `{"searchScope":{"namespace":"default"},"groupBy":["pod"],"startTime":"2026-01-01T00:00:00Z","endTime":"2026-03-01T00:00:00Z"}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			if rec := post(body); rec.Code != http.StatusBadRequest {
+				t.Errorf("expected 400, got %d: %s", rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/alertsync"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+// GetAlertDrift implements GET /api/v1alpha1/alerts/drift. It returns the
+// report of the last alert drift check: the number of alert rules in sync,
+// missing from OpenObserve or modified there, and of adapter-generated
+// alerts without a rule, with the details of each one out of sync. With
+// refresh=true the rules are checked again first.
+func (h *LogsHandler) GetAlertDrift(w http.ResponseWriter, r *http.Request) {
+	if h.alertDrift == nil {
+		writeJSONError(w, http.StatusNotImplemented, gen.BadRequest, "alert drift detection is not configured")
+		return
+	}
+	report := h.alertDrift.Last()
+	if report == nil || r.URL.Query().Get("refresh") == "true" {
+		checked := h.alertDrift.Check(r.Context())
+		report = &checked
+	}
+	writeJSON(w, http.StatusOK, report)
+}
+
+// recordAlertRule registers a rule synced to OpenObserve by client, so that
+// drift checks compare its alert with the alert generated for params. A
+// failure is logged: the rule is synced and only its drift goes unchecked.
+func (h *LogsHandler) recordAlertRule(client *openobserve.Client, params openobserve.LogAlertParams) {
+	if h.alertRules == nil {
+		return
+	}
+	spec, err := client.AlertSpec(params)
+	if err == nil {
+		err = h.alertRules.Put(alertsync.Rule{
+			Name:      *params.Name,
+			Namespace: params.Namespace,
+			Spec:      spec,
+			SyncedAt:  time.Now().UTC(),
+		})
+	}
+	if err != nil {
+		h.logger.Error("Failed to register alert rule for drift checks",
+			slog.String("ruleName", *params.Name),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// forgetAlertRule removes a rule deleted from OpenObserve from the register.
+func (h *LogsHandler) forgetAlertRule(name string) {
+	if h.alertRules == nil {
+		return
+	}
+	if err := h.alertRules.Delete(name); err != nil {
+		h.logger.Error("Failed to unregister alert rule",
+			slog.String("ruleName", name),
+			slog.Any("error", err),
+		)
+	}
+}
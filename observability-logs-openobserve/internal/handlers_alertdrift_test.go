@@ -0,0 +1,83 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/alertsync"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+type staticAlerts []openobserve.AlertDetail
+
+func (a staticAlerts) ListAlertDetails(context.Context) ([]openobserve.AlertDetail, error) {
+	return a, nil
+}
+
+func TestGetAlertDrift(t *testing.T) {
+	client := openobserve.NewClient("http://localhost", "default", "default", "k8s_events", "admin", "pass", testLogger())
+	handler := NewLogsHandler(client, nil, testLogger())
+
+	rec := httptest.NewRecorder()
+	handler.GetAlertDrift(rec, httptest.NewRequest(http.MethodGet, "/api/v1alpha1/alerts/drift", nil))
+	if rec.Code != http.StatusNotImplemented {
+		t.Fatalf("expected 501 without a checker, got %d", rec.Code)
+	}
+
+	store, err := alertsync.NewFileStore(filepath.Join(t.TempDir(), "alert-rules.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := "high-error-rate"
+	enabled := true
+	params := openobserve.LogAlertParams{
+		Name:           &name,
+		Namespace:      "ns-1",
+		SearchPattern:  "error",
+		Operator:       "gt",
+		ThresholdValue: 5,
+		Window:         "5m",
+		Interval:       "1m",
+		Enabled:        &enabled,
+	}
+	spec, err := client.AlertSpec(params)
+	if err != nil {
+		t.Fatalf("AlertSpec() error = %v", err)
+	}
+	alerts := staticAlerts{{Name: name, Namespace: "ns-1", SQL: spec.SQL, Operator: spec.Operator,
+		Threshold: spec.Threshold, Period: spec.Period, Frequency: spec.Frequency, Enabled: spec.Enabled}}
+	handler.SetAlertDriftChecker(store, alertsync.NewChecker(store, []alertsync.Backend{alerts}, 0, testLogger()))
+
+	handler.recordAlertRule(client, params)
+	get := func(target string) alertsync.Report {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		handler.GetAlertDrift(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var report alertsync.Report
+		if err := json.NewDecoder(rec.Body).Decode(&report); err != nil {
+			t.Fatal(err)
+		}
+		return report
+	}
+	if report := get("/api/v1alpha1/alerts/drift"); report.Counts[alertsync.StateInSync] != 1 || len(report.Drift) != 0 {
+		t.Errorf("expected the recorded rule to be in sync, got %+v", report)
+	}
+
+	handler.forgetAlertRule(name)
+	if report := get("/api/v1alpha1/alerts/drift"); report.Counts[alertsync.StateInSync] != 1 {
+		t.Errorf("expected the last report without refresh, got %+v", report)
+	}
+	if report := get("/api/v1alpha1/alerts/drift?refresh=true"); report.Counts[alertsync.StateUnmanaged] != 1 {
+		t.Errorf("expected the forgotten rule's alert to be unmanaged, got %+v", report)
+	}
+}
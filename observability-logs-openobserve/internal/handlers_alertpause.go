@@ -0,0 +1,95 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/api/gen"
+)
+
+// Custom methods of alert rules, POSTed to
+// /api/v1alpha1/alerts/rules/{ruleName}:{method}.
+const (
+	alertRulePause  = "pause"
+	alertRuleResume = "resume"
+)
+
+// alertRuleStateResponse is the response of the pause and resume methods:
+// the sync response of the rule and whether it is now enabled.
+type alertRuleStateResponse struct {
+	gen.AlertingRuleSyncResponse
+	Enabled bool `json:"enabled"`
+}
+
+// SetAlertRuleState implements POST /api/v1alpha1/alerts/rules/{ruleName}:pause
+// and :resume. Pausing disables the alert of the rule in OpenObserve
+// without deleting its definition, so it stops firing until it is resumed.
+// The action is unchanged when the rule already was in the requested state.
+// The state shows in the enabled field of the rule and of the alert states.
+func (h *LogsHandler) SetAlertRuleState(w http.ResponseWriter, r *http.Request) {
+	// Wildcards span whole path segments, so the method is split off here.
+	ruleName, method, ok := cutLast(r.PathValue("ruleName"), ":")
+	if !ok || ruleName == "" || (method != alertRulePause && method != alertRuleResume) {
+		writeJSONError(w, http.StatusNotFound, gen.NotFound, "unknown alert rule method; use :pause or :resume")
+		return
+	}
+	enabled := method == alertRuleResume
+
+	var alertID string
+	var changed bool
+	client, err := h.alertClient(r.Context(), ruleName)
+	if err == nil {
+		alertID, changed, err = client.SetAlertEnabled(r.Context(), ruleName, enabled)
+	}
+	if err != nil {
+		h.logger.Error("Failed to change alert state",
+			slog.String("function", "SetAlertRuleState"),
+			slog.String("ruleName", ruleName),
+			slog.String("method", method),
+			slog.Any("error", err),
+		)
+		if strings.Contains(err.Error(), "not found") {
+			writeJSONError(w, http.StatusNotFound, gen.NotFound, "alert rule not found")
+			return
+		}
+		writeJSONError(w, http.StatusInternalServerError, gen.InternalServerError, "internal server error")
+		return
+	}
+
+	action := gen.Unchanged
+	if changed {
+		action = gen.Updated
+		if h.alertRules != nil {
+			if err := h.alertRules.SetEnabled(ruleName, enabled); err != nil {
+				h.logger.Error("Failed to record alert rule state for drift checks",
+					slog.String("ruleName", ruleName),
+					slog.Any("error", err),
+				)
+			}
+		}
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	writeJSON(w, http.StatusOK, alertRuleStateResponse{
+		AlertingRuleSyncResponse: gen.AlertingRuleSyncResponse{
+			Action:        &action,
+			Status:        ptr(gen.Synced),
+			RuleLogicalId: &ruleName,
+			RuleBackendId: &alertID,
+			LastSyncedAt:  &now,
+		},
+		Enabled: enabled,
+	})
+}
+
+// cutLast slices s around the last instance of sep.
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
@@ -0,0 +1,92 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/alertsync"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+func TestSetAlertRuleState(t *testing.T) {
+	enabled := true
+	var patches []string
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v2/default/alerts":
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"list":[{"alert_id":"a1","name":"high-errors","enabled":%t}]}`, enabled)
+		case r.Method == http.MethodPatch && r.URL.Path == "/api/v2/default/alerts/a1/enable":
+			patches = append(patches, r.URL.RawQuery)
+			enabled = r.URL.Query().Get("value") == "true"
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	handler := NewLogsHandler(client, nil, testLogger())
+	store, err := alertsync.NewFileStore(filepath.Join(t.TempDir(), "alert-rules.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Put(alertsync.Rule{Name: "high-errors", Spec: openobserve.AlertSpec{Enabled: true}}); err != nil {
+		t.Fatal(err)
+	}
+	handler.SetAlertDriftChecker(store, nil)
+	srv := NewServer("0", handler, testLogger()).httpServer.Handler
+
+	post := func(path string) (*httptest.ResponseRecorder, alertRuleStateResponse) {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, nil))
+		var resp alertRuleStateResponse
+		if rec.Code == http.StatusOK {
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+		}
+		return rec, resp
+	}
+
+	rec, resp := post("/api/v1alpha1/alerts/rules/high-errors:pause")
+	if rec.Code != http.StatusOK || resp.Enabled || string(*resp.Action) != "updated" || *resp.RuleBackendId != "a1" {
+		t.Fatalf("expected the rule to be paused, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if len(patches) != 1 || patches[0] != "value=false" {
+		t.Errorf("unexpected enable calls %q", patches)
+	}
+	if rules := store.List(); rules[0].Spec.Enabled {
+		t.Errorf("expected drift checks to expect the paused rule, got %+v", rules)
+	}
+
+	rec, resp = post("/api/v1alpha1/alerts/rules/high-errors:pause")
+	if rec.Code != http.StatusOK || string(*resp.Action) != "unchanged" || len(patches) != 1 {
+		t.Errorf("expected pausing a paused rule to change nothing, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec, resp = post("/api/v1alpha1/alerts/rules/high-errors:resume")
+	if rec.Code != http.StatusOK || !resp.Enabled || len(patches) != 2 || patches[1] != "value=true" {
+		t.Errorf("expected the rule to be resumed, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	for path, want := range map[string]int{
+		"/api/v1alpha1/alerts/rules/unknown:pause":    http.StatusNotFound,
+		"/api/v1alpha1/alerts/rules/high-errors:stop": http.StatusNotFound,
+		"/api/v1alpha1/alerts/rules/high-errors":      http.StatusNotFound,
+	} {
+		if rec, _ := post(path); rec.Code != want {
+			t.Errorf("%s: expected %d, got %d", path, want, rec.Code)
+		}
+	}
+}
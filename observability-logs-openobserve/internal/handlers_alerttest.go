@@ -0,0 +1,46 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+// alertRuleTestRequest is the request body of POST
+// /api/v1alpha1/alerts/rules:test: an alert rule, as created with POST
+// /api/v1alpha1/alerts/rules, and the synthetic records to evaluate it
+// against.
+type alertRuleTestRequest struct {
+	gen.AlertRuleRequest
+	alertRuleExtensions
+	Logs   []openobserve.AlertTestLog   `json:"logs"`
+	Events []openobserve.AlertTestEvent `json:"events"`
+}
+
+// TestAlertRule implements POST /api/v1alpha1/alerts/rules:test. It reports
+// whether an alert rule would fire on synthetic log lines and events, and
+// which records each of its conditions matched, so that alert logic can be
+// tested in CI. Nothing is queried or created in OpenObserve.
+func (h *LogsHandler) TestAlertRule(w http.ResponseWriter, r *http.Request) {
+	var req alertRuleTestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "invalid request body")
+		return
+	}
+
+	params := toLogAlertParams(&req.AlertRuleRequest)
+	params.Severity = req.Severity
+	params.Conditions = req.Conditions
+	params.ConditionMatch = req.ConditionMatch
+	result, err := openobserve.EvaluateAlertRule(params, req.Logs, req.Events)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+func TestTestAlertRule(t *testing.T) {
+	// No OpenObserve is needed: requests reaching it fail the test.
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected OpenObserve request %s %s", r.Method, r.URL.Path)
+	}))
+	defer ooServer.Close()
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	srv := NewServer("0", NewLogsHandler(client, nil, testLogger()), testLogger()).httpServer.Handler
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1alpha1/alerts/rules:test", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		return rec
+	}
+	const rule = `"metadata":{"name":"errors","namespace":"default"},"source":{"query":"ERROR"},` +
+		`"condition":{"enabled":true,"window":"5m","interval":"1m","operator":"gt","threshold":1}`
+
+	rec := post(`{` + rule + `,"conditionMatch":"all",` +
+		`"conditions":[{"source":"events","query":"BackOff","operator":"gte","threshold":1}],` +
+		`"logs":[{"timestamp":"2025-01-01T12:00:00Z","log":"ERROR a"},{"timestamp":"2025-01-01T12:01:00Z","log":"ERROR b"}],` +
+		`"events":[{"timestamp":"2025-01-01T12:01:00Z","reason":"BackOff"}]}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var result openobserve.AlertTestResult
+	if err := json.NewDecoder(rec.Body).Decode(&result); err != nil {
+		t.Fatalf("failed to decode result: %v", err)
+	}
+	if !result.Fired || len(result.Conditions) != 2 || result.Conditions[0].Count != 2 || result.Conditions[1].Count != 1 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	rec = post(`{` + rule + `,"logs":[{"log":"ERROR a"}]}`)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"fired":false`) {
+		t.Errorf("expected the rule not to fire, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	if rec := post(`{` + strings.Replace(rule, `"gt"`, `"above"`, 1) + `}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an invalid operator, got %d", rec.Code)
+	}
+	if rec := post(`{`); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an invalid body, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/openchoreo/community-modules/common/access"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/annotations"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/api/gen"
+)
+
+// defaultAnnotationsWindow is the lookback of GET /api/v1/logs/annotations
+// when it has no startTime.
+const defaultAnnotationsWindow = 7 * 24 * time.Hour
+
+// createAnnotationRequest is the request body of POST /api/v1/logs/annotations.
+// It sets either startTime and endTime, or entry.
+type createAnnotationRequest struct {
+	SearchScope *gen.ComponentSearchScope `json:"searchScope"`
+	StartTime   *time.Time                `json:"startTime"`
+	EndTime     *time.Time                `json:"endTime"`
+	Entry       *annotations.Entry        `json:"entry"`
+	Author      string                    `json:"author"`
+	Note        string                    `json:"note"`
+	Tags        []string                  `json:"tags"`
+}
+
+// annotationsResponse is the response body of GET /api/v1/logs/annotations.
+type annotationsResponse struct {
+	Annotations []annotations.Annotation `json:"annotations"`
+}
+
+// CreateAnnotation implements POST /api/v1/logs/annotations. It attaches a
+// note to a time range, or to a single log entry, of the logs of a scope.
+// Logs queries overlapping the annotation return it inline.
+//
+// The author is the caller when the access policy identifies it, and
+// otherwise the author of the request, which is then required.
+func (h *LogsHandler) CreateAnnotation(w http.ResponseWriter, r *http.Request) {
+	if h.annotations == nil {
+		writeJSONError(w, http.StatusNotImplemented, gen.BadRequest, "log annotations are not configured")
+		return
+	}
+
+	var req createAnnotationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "invalid request body")
+		return
+	}
+	if req.SearchScope == nil || strings.TrimSpace(req.SearchScope.Namespace) == "" {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "searchScope with a valid namespace is required")
+		return
+	}
+
+	scope := req.SearchScope
+	annotation := annotations.Annotation{
+		ID:        uuid.New().String(),
+		Namespace: scope.Namespace,
+		Author:    strings.TrimSpace(req.Author),
+		Note:      req.Note,
+		Tags:      req.Tags,
+		CreatedAt: time.Now().UTC(),
+	}
+	if caller := callerFromContext(r.Context()); caller != access.Anonymous {
+		annotation.Author = caller
+	}
+	if annotation.Author == "" {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "author is required")
+		return
+	}
+	switch {
+	case req.Entry != nil && (req.StartTime != nil || req.EndTime != nil):
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "entry cannot be combined with startTime and endTime")
+		return
+	case req.Entry != nil:
+		annotation.Entry = req.Entry
+		annotation.StartTime, annotation.EndTime = req.Entry.Timestamp, req.Entry.Timestamp
+	case req.StartTime != nil && req.EndTime != nil:
+		annotation.StartTime, annotation.EndTime = *req.StartTime, *req.EndTime
+	default:
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "either entry or startTime and endTime is required")
+		return
+	}
+	if scope.ProjectUid != nil {
+		annotation.ProjectUID = *scope.ProjectUid
+	}
+	if scope.EnvironmentUid != nil {
+		annotation.EnvironmentUID = *scope.EnvironmentUid
+	}
+	if scope.ComponentUid != nil {
+		annotation.ComponentUID = *scope.ComponentUid
+	}
+	if err := annotation.Validate(); err != nil {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, err.Error())
+		return
+	}
+	if _, err := h.clientFor(r.Context(), scope.Namespace, access.Raw); err != nil {
+		writeJSONError(w, http.StatusForbidden, gen.Forbidden, err.Error())
+		return
+	}
+
+	if err := h.annotations.Create(annotation); err != nil {
+		h.logger.Error("Failed to create log annotation",
+			slog.String("function", "CreateAnnotation"),
+			slog.String("namespace", annotation.Namespace),
+			slog.Any("error", err),
+		)
+		writeJSONError(w, http.StatusInternalServerError, gen.InternalServerError, "internal server error")
+		return
+	}
+
+	w.Header().Set("Location", "/api/v1/logs/annotations/"+annotation.ID)
+	writeJSON(w, http.StatusCreated, annotation)
+}
+
+// ListAnnotations implements GET /api/v1/logs/annotations. It lists the
+// annotations of a namespace overlapping a time window, ordered by start time.
+//
+// Query parameters: namespace (required), projectUid, environmentUid,
+// componentUid, and startTime/endTime in RFC 3339 format. The window
+// defaults to the 7 days before endTime, and endTime defaults to now.
+func (h *LogsHandler) ListAnnotations(w http.ResponseWriter, r *http.Request) {
+	if h.annotations == nil {
+		writeJSONError(w, http.StatusNotImplemented, gen.BadRequest, "log annotations are not configured")
+		return
+	}
+
+	query := r.URL.Query()
+	filter := annotations.Filter{
+		Namespace:      strings.TrimSpace(query.Get("namespace")),
+		ProjectUID:     query.Get("projectUid"),
+		EnvironmentUID: query.Get("environmentUid"),
+		ComponentUID:   query.Get("componentUid"),
+	}
+	if filter.Namespace == "" {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "namespace is required")
+		return
+	}
+	start, end, err := parseTimeWindow(query, defaultAnnotationsWindow)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, err.Error())
+		return
+	}
+	filter.StartTime, filter.EndTime = start, end
+	if _, err := h.clientFor(r.Context(), filter.Namespace, access.Aggregates); err != nil {
+		writeJSONError(w, http.StatusForbidden, gen.Forbidden, err.Error())
+		return
+	}
+
+	list := h.annotations.List(filter)
+	if list == nil {
+		list = []annotations.Annotation{}
+	}
+	writeJSON(w, http.StatusOK, annotationsResponse{Annotations: list})
+}
+
+// DeleteAnnotation implements DELETE /api/v1/logs/annotations/{annotationId}.
+func (h *LogsHandler) DeleteAnnotation(w http.ResponseWriter, r *http.Request) {
+	if h.annotations == nil {
+		writeJSONError(w, http.StatusNotImplemented, gen.BadRequest, "log annotations are not configured")
+		return
+	}
+	annotation, err := h.annotations.Get(r.PathValue("annotationId"))
+	if errors.Is(err, annotations.ErrNotFound) {
+		writeJSONError(w, http.StatusNotFound, gen.NotFound, "annotation not found")
+		return
+	}
+	if _, err := h.clientFor(r.Context(), annotation.Namespace, access.Raw); err != nil {
+		writeJSONError(w, http.StatusForbidden, gen.Forbidden, err.Error())
+		return
+	}
+	err = h.annotations.Delete(annotation.ID)
+	if errors.Is(err, annotations.ErrNotFound) {
+		writeJSONError(w, http.StatusNotFound, gen.NotFound, "annotation not found")
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to delete log annotation",
+			slog.String("function", "DeleteAnnotation"),
+			slog.String("annotationId", annotation.ID),
+			slog.Any("error", err),
+		)
+		writeJSONError(w, http.StatusInternalServerError, gen.InternalServerError, "internal server error")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// overlappingAnnotations returns the annotations of filter's namespace and
+// scope that overlap its time range, or nil when annotations are disabled.
+func (h *LogsHandler) overlappingAnnotations(filter annotations.Filter) []annotations.Annotation {
+	if h.annotations == nil {
+		return nil
+	}
+	return h.annotations.List(filter)
+}
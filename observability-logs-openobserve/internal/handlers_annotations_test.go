@@ -0,0 +1,126 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/annotations"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+func TestLogAnnotations(t *testing.T) {
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(openobserve.OpenObserveResponse{
+			Hits: []map[string]interface{}{
+				{"_timestamp": float64(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC).UnixMicro()), "log": "hello"},
+			},
+		})
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	handler := NewLogsHandler(client, nil, testLogger())
+	srv := NewServer("0", handler, testLogger()).httpServer.Handler
+
+	serve := func(method, target, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		return rec
+	}
+	const create = `{"searchScope":{"namespace":"test-ns","componentUid":"comp-1"},` +
+		`"entry":{"timestamp":"2025-01-01T12:00:00Z","podName":"api-0"},"author":"alice","note":"first 500","tags":["db"]}`
+
+	t.Run("not configured", func(t *testing.T) {
+		if rec := serve(http.MethodPost, "/api/v1/logs/annotations", create); rec.Code != http.StatusNotImplemented {
+			t.Errorf("expected 501, got %d", rec.Code)
+		}
+	})
+
+	store, err := annotations.NewFileStore(filepath.Join(t.TempDir(), "annotations.json"))
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+	handler.SetAnnotationStore(store)
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, body := range []string{
+			`{"searchScope":{"namespace":"test-ns"},"author":"alice","note":"n"}`,
+			`{"searchScope":{"namespace":"test-ns"},"startTime":"2025-01-01T12:00:00Z","endTime":"2025-01-01T13:00:00Z","note":"n"}`,
+			`{"searchScope":{"namespace":"test-ns"},"startTime":"2025-01-01T12:00:00Z","endTime":"2025-01-01T13:00:00Z","author":"alice","note":""}`,
+			`{"searchScope":{"namespace":""},"startTime":"2025-01-01T12:00:00Z","endTime":"2025-01-01T13:00:00Z","author":"alice","note":"n"}`,
+		} {
+			if rec := serve(http.MethodPost, "/api/v1/logs/annotations", body); rec.Code != http.StatusBadRequest {
+				t.Errorf("expected 400 for %s, got %d", body, rec.Code)
+			}
+		}
+	})
+
+	var created annotations.Annotation
+	t.Run("create and list", func(t *testing.T) {
+		rec := serve(http.MethodPost, "/api/v1/logs/annotations", create)
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if err := json.NewDecoder(rec.Body).Decode(&created); err != nil {
+			t.Fatalf("failed to decode annotation: %v", err)
+		}
+		if created.ID == "" || created.Author != "alice" || created.ComponentUID != "comp-1" || created.Entry == nil ||
+			!created.StartTime.Equal(created.Entry.Timestamp) || rec.Header().Get("Location") != "/api/v1/logs/annotations/"+created.ID {
+			t.Fatalf("unexpected annotation: %+v", created)
+		}
+
+		rec = serve(http.MethodGet, "/api/v1/logs/annotations?namespace=test-ns&startTime=2025-01-01T00:00:00Z&endTime=2025-01-02T00:00:00Z", "")
+		var got annotationsResponse
+		if err := json.NewDecoder(rec.Body).Decode(&got); err != nil || len(got.Annotations) != 1 || got.Annotations[0].ID != created.ID {
+			t.Errorf("unexpected annotations: %d %+v %v", rec.Code, got, err)
+		}
+		rec = serve(http.MethodGet, "/api/v1/logs/annotations?namespace=other&startTime=2025-01-01T00:00:00Z&endTime=2025-01-02T00:00:00Z", "")
+		if !strings.Contains(rec.Body.String(), `"annotations":[]`) {
+			t.Errorf("expected no annotations, got %s", rec.Body.String())
+		}
+	})
+
+	t.Run("returned inline by overlapping queries", func(t *testing.T) {
+		query := func(scope, start, end string) []annotations.Annotation {
+			rec := serve(http.MethodPost, "/api/v1/logs/query",
+				`{"startTime":"`+start+`","endTime":"`+end+`","searchScope":`+scope+`}`)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+			}
+			var got struct {
+				Annotations []annotations.Annotation `json:"annotations"`
+			}
+			_ = json.Unmarshal(rec.Body.Bytes(), &got)
+			return got.Annotations
+		}
+		if got := query(`{"namespace":"test-ns","componentUid":"comp-1"}`, "2025-01-01T11:00:00Z", "2025-01-01T13:00:00Z"); len(got) != 1 || got[0].Note != "first 500" {
+			t.Errorf("expected the annotation inline, got %+v", got)
+		}
+		if got := query(`{"namespace":"test-ns","componentUid":"comp-2"}`, "2025-01-01T11:00:00Z", "2025-01-01T13:00:00Z"); len(got) != 0 {
+			t.Errorf("expected no annotation for another component, got %+v", got)
+		}
+		if got := query(`{"namespace":"test-ns"}`, "2025-01-01T13:00:00Z", "2025-01-01T14:00:00Z"); len(got) != 0 {
+			t.Errorf("expected no annotation outside the window, got %+v", got)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		if rec := serve(http.MethodDelete, "/api/v1/logs/annotations/"+created.ID, ""); rec.Code != http.StatusNoContent {
+			t.Fatalf("expected 204, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if rec := serve(http.MethodDelete, "/api/v1/logs/annotations/"+created.ID, ""); rec.Code != http.StatusNotFound {
+			t.Errorf("expected 404, got %d", rec.Code)
+		}
+	})
+}
@@ -0,0 +1,325 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/access"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/usage"
+)
+
+const (
+	// maxBundleWindow bounds the window an incident bundle covers.
+	maxBundleWindow = 7 * 24 * time.Hour
+	// bundleTimeout bounds the time spent gathering and writing a bundle. It
+	// replaces the server write timeout, which is too short for a bundle.
+	bundleTimeout = 2 * time.Minute
+)
+
+// incidentBundleRequest is the request body of POST /api/v1/incidents/bundle.
+type incidentBundleRequest struct {
+	SearchScope *gen.ComponentSearchScope `json:"searchScope"`
+	StartTime   time.Time                 `json:"startTime"`
+	EndTime     time.Time                 `json:"endTime"`
+	// Limit is the maximum number of log lines, events and spans in the
+	// bundle, each (default and maximum: 10000).
+	Limit int `json:"limit"`
+}
+
+// bundleManifest is the manifest.json of an incident bundle.
+type bundleManifest struct {
+	SearchScope gen.ComponentSearchScope `json:"searchScope"`
+	StartTime   time.Time                `json:"startTime"`
+	EndTime     time.Time                `json:"endTime"`
+	GeneratedAt time.Time                `json:"generatedAt"`
+	Files       []bundleFile             `json:"files"`
+	// Errors lists the sections that could not be gathered, by file name.
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// bundleFile describes a file of an incident bundle.
+type bundleFile struct {
+	Name    string `json:"name"`
+	Records int    `json:"records"`
+	// Truncated is set when the scope had more records than the limit.
+	Truncated bool   `json:"truncated,omitempty"`
+	SHA256    string `json:"sha256"`
+}
+
+// bundleSummary is the summary.json of an incident bundle.
+type bundleSummary struct {
+	Restarts *openobserve.RestartSummaryResult `json:"restarts,omitempty"`
+	// Levels is only included for component scopes.
+	Levels *openobserve.LevelHistogramResult `json:"levels,omitempty"`
+}
+
+// bundleWriter writes the files of an incident bundle and records them in its manifest.
+type bundleWriter struct {
+	zw       *zip.Writer
+	manifest *bundleManifest
+}
+
+// writeBundleNDJSON writes records to the named file, one JSON document per line.
+func writeBundleNDJSON[T any](b *bundleWriter, name string, records []T, truncated bool) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, record := range records {
+		if err := enc.Encode(record); err != nil {
+			return fmt.Errorf("failed to encode %s: %w", name, err)
+		}
+	}
+	return b.write(name, buf.Bytes(), len(records), truncated)
+}
+
+func (b *bundleWriter) write(name string, data []byte, records int, truncated bool) error {
+	f, err := b.zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: b.manifest.GeneratedAt})
+	if err != nil {
+		return fmt.Errorf("failed to add %s: %w", name, err)
+	}
+	if _, err := f.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	sum := sha256.Sum256(data)
+	b.manifest.Files = append(b.manifest.Files, bundleFile{
+		Name:      name,
+		Records:   records,
+		Truncated: truncated,
+		SHA256:    hex.EncodeToString(sum[:]),
+	})
+	return nil
+}
+
+// fail records a section that could not be gathered.
+func (b *bundleWriter) fail(name string, err error) {
+	if b.manifest.Errors == nil {
+		b.manifest.Errors = map[string]string{}
+	}
+	b.manifest.Errors[name] = err.Error()
+}
+
+// CreateIncidentBundle implements POST /api/v1/incidents/bundle. It gathers
+// the evidence of an incident for a scope and time window into a single zip
+// archive, to attach to postmortems or vendor support tickets:
+//
+//	logs.ndjson     application log lines, oldest first
+//	events.ndjson   Kubernetes events
+//	traces.ndjson   spans from the traces stream
+//	alerts.ndjson   the alert rules of the scope with their last trigger times
+//	summary.json    the restart summary and, for a component, the log level histogram
+//	manifest.json   the scope, window, and the record count and SHA-256 checksum of each file
+//
+// The logs are required; any other section that cannot be gathered is
+// left out and its error listed in the manifest. The window may span at
+// most 7 days.
+func (h *LogsHandler) CreateIncidentBundle(w http.ResponseWriter, r *http.Request) {
+	var req incidentBundleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "invalid request body")
+		return
+	}
+	if req.SearchScope == nil || strings.TrimSpace(req.SearchScope.Namespace) == "" {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "searchScope with a valid namespace is required")
+		return
+	}
+	if req.StartTime.IsZero() || req.EndTime.IsZero() {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "startTime and endTime are required")
+		return
+	}
+	if req.EndTime.Before(req.StartTime) {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "endTime must be >= startTime")
+		return
+	}
+	if req.EndTime.Sub(req.StartTime) > maxBundleWindow {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "the window must not exceed 7 days")
+		return
+	}
+	if req.Limit < 0 || req.Limit > maxInteractiveLimit {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "limit must be between 1 and "+strconv.Itoa(maxInteractiveLimit))
+		return
+	}
+	limit := req.Limit
+	if limit == 0 {
+		limit = maxInteractiveLimit
+	}
+
+	scope := req.SearchScope
+	var projectID, environmentID, componentID string
+	if scope.ProjectUid != nil {
+		projectID = *scope.ProjectUid
+	}
+	if scope.EnvironmentUid != nil {
+		environmentID = *scope.EnvironmentUid
+	}
+	if scope.ComponentUid != nil {
+		componentID = *scope.ComponentUid
+	}
+
+	client, err := h.clientFor(r.Context(), scope.Namespace, access.Raw)
+	if err != nil {
+		writeJSONError(w, http.StatusForbidden, gen.Forbidden, err.Error())
+		return
+	}
+	noteUsage(r.Context(), usage.ScopeComponent)
+
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Now().Add(bundleTimeout))
+	ctx, cancel := context.WithTimeout(r.Context(), bundleTimeout)
+	defer cancel()
+
+	logger := h.logger.With(slog.String("function", "CreateIncidentBundle"), slog.String("namespace", scope.Namespace))
+	logsParams := openobserve.ComponentLogsParams{
+		Namespace:     scope.Namespace,
+		EnvironmentID: environmentID,
+		ProjectID:     projectID,
+		StartTime:     req.StartTime,
+		EndTime:       req.EndTime,
+		Limit:         limit,
+		SortOrder:     "asc",
+	}
+	if componentID != "" {
+		logsParams.ComponentIDs = []string{componentID}
+	}
+	logs, err := client.GetComponentLogs(ctx, logsParams)
+	if err != nil {
+		logger.Error("Failed to query logs for incident bundle", slog.Any("error", err))
+		writeBackendError(w, err)
+		return
+	}
+
+	var archive bytes.Buffer
+	b := &bundleWriter{
+		zw: zip.NewWriter(&archive),
+		manifest: &bundleManifest{
+			SearchScope: *scope,
+			StartTime:   req.StartTime,
+			EndTime:     req.EndTime,
+			GeneratedAt: time.Now().UTC().Truncate(time.Second),
+			Files:       []bundleFile{},
+		},
+	}
+	err = writeBundleNDJSON(b, "logs.ndjson", logs.Logs, logs.TotalCount > len(logs.Logs))
+
+	if err == nil {
+		events, eventsErr := client.GetComponentEvents(ctx, openobserve.EventsQueryParams{
+			Namespace:     scope.Namespace,
+			ProjectID:     projectID,
+			ComponentID:   componentID,
+			EnvironmentID: environmentID,
+			StartTime:     req.StartTime,
+			EndTime:       req.EndTime,
+			Limit:         limit,
+			SortOrder:     "asc",
+		})
+		if eventsErr != nil {
+			logger.Warn("Failed to query events for incident bundle", slog.Any("error", eventsErr))
+			b.fail("events.ndjson", eventsErr)
+		} else {
+			err = writeBundleNDJSON(b, "events.ndjson", events.Events, events.TotalCount > len(events.Events))
+		}
+	}
+
+	if err == nil {
+		spans, spansErr := client.GetTraceSpans(ctx, openobserve.TraceSpansParams{
+			Namespace:     scope.Namespace,
+			ProjectID:     projectID,
+			EnvironmentID: environmentID,
+			ComponentID:   componentID,
+			StartTime:     req.StartTime,
+			EndTime:       req.EndTime,
+			Limit:         limit,
+		})
+		if spansErr != nil {
+			if !errors.Is(spansErr, openobserve.ErrNoTracesStream) {
+				logger.Warn("Failed to query traces for incident bundle", slog.Any("error", spansErr))
+			}
+			b.fail("traces.ndjson", spansErr)
+		} else {
+			err = writeBundleNDJSON(b, "traces.ndjson", spans.Spans, spans.Truncated)
+		}
+	}
+
+	if err == nil {
+		alerts, alertsErr := client.ListAlertStates(ctx, openobserve.AlertStateParams{
+			Namespace:     scope.Namespace,
+			ProjectID:     projectID,
+			EnvironmentID: environmentID,
+			ComponentID:   componentID,
+		})
+		if alertsErr != nil {
+			logger.Warn("Failed to list alerts for incident bundle", slog.Any("error", alertsErr))
+			b.fail("alerts.ndjson", alertsErr)
+		} else {
+			err = writeBundleNDJSON(b, "alerts.ndjson", alerts.Alerts, alerts.Truncated)
+		}
+	}
+
+	if err == nil {
+		var summary bundleSummary
+		var summaryErrs []error
+		summary.Restarts, err = client.GetRestartSummary(ctx, openobserve.RestartSummaryParams{
+			Namespace:     scope.Namespace,
+			ProjectID:     projectID,
+			EnvironmentID: environmentID,
+			ComponentID:   componentID,
+			StartTime:     req.StartTime,
+			EndTime:       req.EndTime,
+		})
+		summaryErrs = append(summaryErrs, err)
+		if componentID != "" {
+			interval, _ := levelHistogramInterval("", req.EndTime.Sub(req.StartTime))
+			summary.Levels, err = client.GetComponentLevelHistogram(ctx, openobserve.LevelHistogramParams{
+				Namespace:    scope.Namespace,
+				ComponentUID: componentID,
+				StartTime:    req.StartTime,
+				EndTime:      req.EndTime,
+				Interval:     interval,
+			})
+			summaryErrs = append(summaryErrs, err)
+		}
+		if summaryErr := errors.Join(summaryErrs...); summaryErr != nil {
+			logger.Warn("Failed to summarize incident bundle", slog.Any("error", summaryErr))
+			b.fail("summary.json", summaryErr)
+		}
+		var data []byte
+		if data, err = json.MarshalIndent(summary, "", "  "); err == nil {
+			err = b.write("summary.json", data, 1, false)
+		}
+	}
+
+	if err == nil {
+		var data []byte
+		if data, err = json.MarshalIndent(b.manifest, "", "  "); err == nil {
+			err = b.write("manifest.json", data, 1, false)
+		}
+	}
+	if err == nil {
+		err = b.zw.Close()
+	}
+	if err != nil {
+		logger.Error("Failed to write incident bundle", slog.Any("error", err))
+		writeJSONError(w, http.StatusInternalServerError, gen.InternalServerError, "internal server error")
+		return
+	}
+
+	filename := fmt.Sprintf("incident-%s-%s.zip", scope.Namespace, req.StartTime.UTC().Format("20060102T150405Z"))
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	w.Header().Set("Content-Length", strconv.Itoa(archive.Len()))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(archive.Bytes())
+}
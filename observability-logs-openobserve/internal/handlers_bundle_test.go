@@ -0,0 +1,128 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+func TestCreateIncidentBundle(t *testing.T) {
+	ts := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/api/v2/default/alerts") {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("type") == "traces" {
+			json.NewEncoder(w).Encode(openobserve.OpenObserveResponse{
+				Hits: []map[string]interface{}{
+					{"_timestamp": float64(ts.UnixMicro()), "trace_id": "trace-1", "span_id": "span-1", "operation_name": "GET /orders"},
+				},
+				Total: 1,
+			})
+			return
+		}
+		json.NewEncoder(w).Encode(openobserve.OpenObserveResponse{
+			Hits: []map[string]interface{}{
+				{"_timestamp": float64(ts.UnixMicro()), "log": "connection refused"},
+			},
+			Total: 1,
+		})
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	client.SetTracesStream("default")
+	srv := NewServer("0", NewLogsHandler(client, nil, testLogger()), testLogger()).httpServer.Handler
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+	}{
+		{"missing namespace", `{"startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-02T00:00:00Z"}`, http.StatusBadRequest},
+		{"missing times", `{"searchScope":{"namespace":"test-ns"}}`, http.StatusBadRequest},
+		{"reversed window", `{"searchScope":{"namespace":"test-ns"},"startTime":"2025-01-02T00:00:00Z","endTime":"2025-01-01T00:00:00Z"}`, http.StatusBadRequest},
+		{"window too long", `{"searchScope":{"namespace":"test-ns"},"startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-09T00:00:00Z"}`, http.StatusBadRequest},
+		{"limit too large", `{"searchScope":{"namespace":"test-ns"},"startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-02T00:00:00Z","limit":20000}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			srv.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/incidents/bundle", strings.NewReader(tt.body)))
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected %d, got %d: %s", tt.wantStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+
+	rec := httptest.NewRecorder()
+	body := `{"searchScope":{"namespace":"test-ns","componentUid":"comp-1"},"startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-02T00:00:00Z"}`
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/incidents/bundle", strings.NewReader(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/zip" {
+		t.Errorf("unexpected content type %q", ct)
+	}
+	if cd := rec.Header().Get("Content-Disposition"); cd != `attachment; filename="incident-test-ns-20250101T000000Z.zip"` {
+		t.Errorf("unexpected content disposition %q", cd)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(rec.Body.Bytes()), int64(rec.Body.Len()))
+	if err != nil {
+		t.Fatalf("failed to open bundle: %v", err)
+	}
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("failed to open %s: %v", f.Name, err)
+		}
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		files[f.Name] = string(data)
+	}
+	for _, name := range []string{"logs.ndjson", "events.ndjson", "traces.ndjson", "summary.json", "manifest.json"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("expected %s in bundle", name)
+		}
+	}
+	if _, ok := files["alerts.ndjson"]; ok {
+		t.Error("expected alerts.ndjson to be left out when alerts cannot be listed")
+	}
+	if !strings.Contains(files["logs.ndjson"], "connection refused") {
+		t.Errorf("unexpected logs.ndjson: %s", files["logs.ndjson"])
+	}
+	if !strings.Contains(files["traces.ndjson"], `"traceId":"trace-1"`) {
+		t.Errorf("unexpected traces.ndjson: %s", files["traces.ndjson"])
+	}
+
+	var manifest bundleManifest
+	if err := json.Unmarshal([]byte(files["manifest.json"]), &manifest); err != nil {
+		t.Fatalf("failed to decode manifest: %v", err)
+	}
+	if manifest.SearchScope.Namespace != "test-ns" || !manifest.StartTime.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected manifest scope: %+v", manifest)
+	}
+	if _, ok := manifest.Errors["alerts.ndjson"]; !ok {
+		t.Errorf("expected an alerts error in the manifest, got %v", manifest.Errors)
+	}
+	for _, f := range manifest.Files {
+		if f.Name == "logs.ndjson" && (f.Records != 1 || len(f.SHA256) != 64) {
+			t.Errorf("unexpected logs entry: %+v", f)
+		}
+	}
+}
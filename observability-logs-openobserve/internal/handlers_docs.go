@@ -0,0 +1,53 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	_ "embed"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/api/gen"
+)
+
+// docsPage is the API explorer served at /docs. It is self-contained so that
+// it works on clusters without internet access.
+//
+//go:embed docs.html
+var docsPage []byte
+
+// openAPISpec returns the OpenAPI document the adapter was generated from, as JSON.
+var openAPISpec = sync.OnceValues(func() ([]byte, error) {
+	spec, err := gen.GetSwagger()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the OpenAPI document: %w", err)
+	}
+	return spec.MarshalJSON()
+})
+
+// GetOpenAPISpec implements GET /api/v1/openapi.json. It serves the OpenAPI
+// document embedded in the adapter, so that integrators can tell the exact
+// version of the API a deployment implements.
+func (h *LogsHandler) GetOpenAPISpec(w http.ResponseWriter, r *http.Request) {
+	spec, err := openAPISpec()
+	if err != nil {
+		h.logger.Error("Failed to serve the OpenAPI document", slog.Any("error", err))
+		writeJSONError(w, http.StatusInternalServerError, gen.InternalServerError, "internal server error")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Length", strconv.Itoa(len(spec)))
+	_, _ = w.Write(spec)
+}
+
+// GetDocs implements GET /docs. It serves a page listing the operations of
+// the OpenAPI document, with example requests that can be sent from the page.
+func (h *LogsHandler) GetDocs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Content-Security-Policy", "default-src 'self'; script-src 'unsafe-inline'; style-src 'unsafe-inline'")
+	_, _ = w.Write(docsPage)
+}
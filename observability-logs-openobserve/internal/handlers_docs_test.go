@@ -0,0 +1,56 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+func TestGetOpenAPISpec(t *testing.T) {
+	handler := NewLogsHandler(openobserve.NewClient("http://localhost", "default", "default", "k8s_events", "admin", "pass", testLogger()), nil, testLogger())
+	srv := NewServer("0", handler, testLogger()).httpServer.Handler
+
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/openapi.json", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if got := rec.Header().Get("Content-Type"); got != "application/json" {
+		t.Errorf("unexpected content type %q", got)
+	}
+	var spec struct {
+		OpenAPI string `json:"openapi"`
+		Info    struct {
+			Version string `json:"version"`
+		} `json:"info"`
+		Paths map[string]any `json:"paths"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &spec); err != nil {
+		t.Fatalf("failed to decode the document: %v", err)
+	}
+	if !strings.HasPrefix(spec.OpenAPI, "3.") || spec.Info.Version == "" {
+		t.Errorf("unexpected document header: openapi %q, version %q", spec.OpenAPI, spec.Info.Version)
+	}
+	if _, ok := spec.Paths["/api/v1/logs/query"]; !ok {
+		t.Errorf("expected the logs query path in the document, got %d paths", len(spec.Paths))
+	}
+
+	rec = httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	if got := rec.Header().Get("Content-Type"); !strings.HasPrefix(got, "text/html") {
+		t.Errorf("unexpected content type %q", got)
+	}
+	if !strings.Contains(rec.Body.String(), "/api/v1/openapi.json") {
+		t.Error("expected the page to load the OpenAPI document")
+	}
+}
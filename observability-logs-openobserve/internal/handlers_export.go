@@ -0,0 +1,71 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/openchoreo/community-modules/common/access"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/export"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/usage"
+)
+
+// CreateLogExport implements POST /api/v1/logs/export. It accepts the same
+// body as the component logs query and starts a job that writes all matching
+// entries to object storage as NDJSON, partitioned by date. It responds with
+// 202 and the job; its status is available from GetLogExport.
+func (h *LogsHandler) CreateLogExport(w http.ResponseWriter, r *http.Request) {
+	if h.exporter == nil {
+		writeJSONError(w, http.StatusNotImplemented, gen.BadRequest, "log export is not configured")
+		return
+	}
+
+	var req gen.LogsQueryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "invalid request body")
+		return
+	}
+	scope, err := req.SearchScope.AsComponentSearchScope()
+	if err != nil || strings.TrimSpace(scope.Namespace) == "" {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "searchScope with a valid namespace is required")
+		return
+	}
+	if _, err := h.clientFor(r.Context(), scope.Namespace, access.Raw); err != nil {
+		writeJSONError(w, http.StatusForbidden, gen.Forbidden, err.Error())
+		return
+	}
+
+	job, err := h.exporter.Submit(toComponentLogsParams(&req, &scope), export.Options{})
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, err.Error())
+		return
+	}
+
+	noteUsage(r.Context(), usage.ScopeComponent, usage.FeatureNDJSON)
+	h.logger.Info("Log export started",
+		slog.String("jobId", job.ID),
+		slog.String("namespace", job.Namespace),
+	)
+	w.Header().Set("Location", "/api/v1/logs/export/"+job.ID)
+	writeJSON(w, http.StatusAccepted, job)
+}
+
+// GetLogExport implements GET /api/v1/logs/export/{jobId}.
+func (h *LogsHandler) GetLogExport(w http.ResponseWriter, r *http.Request) {
+	if h.exporter == nil {
+		writeJSONError(w, http.StatusNotImplemented, gen.BadRequest, "log export is not configured")
+		return
+	}
+
+	job, ok := h.exporter.Get(r.PathValue("jobId"))
+	if !ok {
+		writeJSONError(w, http.StatusNotFound, gen.NotFound, "export job not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, job)
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/export"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+type memObjectStore struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (s *memObjectStore) PutObject(_ context.Context, key string, body []byte, _ export.PutOptions) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects[key] = body
+	return nil
+}
+
+func TestLogExport(t *testing.T) {
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := openobserve.OpenObserveResponse{
+			Hits: []map[string]interface{}{
+				{"_timestamp": float64(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC).UnixMicro()), "log": "hello"},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	handler := NewLogsHandler(client, nil, testLogger())
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v1/logs/export", handler.CreateLogExport)
+	mux.HandleFunc("GET /api/v1/logs/export/{jobId}", handler.GetLogExport)
+
+	body := `{"startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-01T23:00:00Z","searchScope":{"namespace":"test-ns"}}`
+
+	t.Run("not configured", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/logs/export", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		if rec.Code != http.StatusNotImplemented {
+			t.Errorf("expected 501, got %d", rec.Code)
+		}
+	})
+
+	store := &memObjectStore{objects: map[string][]byte{}}
+	handler.SetExporter(export.NewManager(client, store, "exports", testLogger()))
+
+	t.Run("submit and poll", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/logs/export", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		if rec.Code != http.StatusAccepted {
+			t.Fatalf("expected 202, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var job export.Job
+		if err := json.NewDecoder(rec.Body).Decode(&job); err != nil {
+			t.Fatalf("failed to decode job: %v", err)
+		}
+		location := rec.Header().Get("Location")
+		if location != "/api/v1/logs/export/"+job.ID {
+			t.Errorf("unexpected Location %q", location)
+		}
+
+		deadline := time.Now().Add(5 * time.Second)
+		for job.Status != export.StatusSucceeded && time.Now().Before(deadline) {
+			time.Sleep(10 * time.Millisecond)
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, location, nil))
+			if rec.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d", rec.Code)
+			}
+			_ = json.NewDecoder(rec.Body).Decode(&job)
+		}
+		if job.Status != export.StatusSucceeded || job.Records != 1 || len(job.Objects) != 1 {
+			t.Fatalf("unexpected job: %+v", job)
+		}
+	})
+
+	t.Run("invalid scope", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/logs/export",
+			strings.NewReader(`{"startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-02T00:00:00Z","searchScope":{}}`))
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("unknown job", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/logs/export/unknown", nil))
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("expected 404, got %d", rec.Code)
+		}
+	})
+}
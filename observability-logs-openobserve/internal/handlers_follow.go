@@ -0,0 +1,243 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/access"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+const (
+	// followSettleDelay is how long after their timestamp entries are
+	// delivered by a follow, so that entries ingested out of order are
+	// read before the cursor moves past them.
+	followSettleDelay = 5 * time.Second
+	// followPollInterval is the time between two reads of a follow that
+	// caught up with the logs.
+	followPollInterval = 2 * time.Second
+	// followBatchSize is the number of entries read per query of a follow.
+	followBatchSize = 500
+	// followHeartbeatInterval is the time after which an idle follow sends
+	// a comment so that proxies keep the connection open.
+	followHeartbeatInterval = 15 * time.Second
+	// followWriteTimeout bounds the time spent writing one batch of a follow.
+	// It replaces the server write timeout, which would cut off the stream.
+	followWriteTimeout = 15 * time.Second
+	// defaultFollowBacklog is how far back a follow without a cursor or
+	// startTime starts.
+	defaultFollowBacklog = time.Minute
+	// followRetryAfter is the Retry-After of follows rejected because their
+	// caller holds too many open.
+	followRetryAfter = 30 * time.Second
+)
+
+// tooManyRequests is the title of the errors of requests rejected because
+// their caller exceeded a limit.
+const tooManyRequests gen.ErrorResponseTitle = "tooManyRequests"
+
+// followCursor is the position of a follow: every entry logged before Time
+// and the first Skip entries logged at Time were delivered.
+type followCursor struct {
+	Time time.Time
+	Skip int
+}
+
+// String encodes the cursor as an opaque token.
+func (c followCursor) String() string {
+	raw := "v1." + strconv.FormatInt(c.Time.UnixMicro(), 10) + "." + strconv.Itoa(c.Skip)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// advance moves the cursor past an entry logged at t.
+func (c *followCursor) advance(t time.Time) {
+	if t.Equal(c.Time) {
+		c.Skip++
+		return
+	}
+	c.Time, c.Skip = t, 1
+}
+
+// parseFollowCursor decodes a token returned by followCursor.String.
+func parseFollowCursor(token string) (followCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return followCursor{}, errors.New("invalid cursor")
+	}
+	parts := strings.Split(string(raw), ".")
+	if len(parts) != 3 || parts[0] != "v1" {
+		return followCursor{}, errors.New("invalid cursor")
+	}
+	us, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return followCursor{}, errors.New("invalid cursor")
+	}
+	skip, err := strconv.Atoi(parts[2])
+	if err != nil || skip < 0 {
+		return followCursor{}, errors.New("invalid cursor")
+	}
+	return followCursor{Time: time.UnixMicro(us), Skip: skip}, nil
+}
+
+// FollowPodLogs implements GET /api/v1/logs/pods/{podName}/follow. It
+// streams the application logs of a pod as server-sent events, oldest
+// first, for as long as the client stays connected.
+//
+// Every log event carries the cursor after its entry as its event ID, and
+// the stream opens with a cursor event carrying the start position. A
+// client that reconnects with the last cursor it received, in the cursor
+// query parameter or the Last-Event-ID header that EventSource sends,
+// resumes with the next entry: no entry is skipped or repeated. To make
+// that hold for entries ingested out of order, entries are delivered
+// followSettleDelay after their timestamp.
+//
+// Query parameters: namespace (required), cursor, and startTime in RFC 3339
+// format, which defaults to a minute ago and is ignored with a cursor.
+//
+// With a follow limiter, a caller holding the maximum number of follows open
+// in the namespace is answered 429 until it closes one.
+func (h *LogsHandler) FollowPodLogs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+	podName := r.PathValue("podName")
+	namespace := strings.TrimSpace(query.Get("namespace"))
+	if namespace == "" {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "namespace is required")
+		return
+	}
+
+	cursor := followCursor{Time: time.Now().Add(-defaultFollowBacklog)}
+	token := query.Get("cursor")
+	if token == "" {
+		token = r.Header.Get("Last-Event-ID")
+	}
+	if token != "" {
+		c, err := parseFollowCursor(token)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, gen.BadRequest, err.Error())
+			return
+		}
+		cursor = c
+	} else if v := query.Get("startTime"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "startTime must be an RFC 3339 timestamp")
+			return
+		}
+		cursor.Time = t
+	}
+	cursor.Time = cursor.Time.Truncate(time.Microsecond)
+
+	client, err := h.clientFor(ctx, namespace, access.Raw)
+	if err != nil {
+		writeJSONError(w, http.StatusForbidden, gen.Forbidden, err.Error())
+		return
+	}
+
+	if h.follows != nil {
+		caller := callerFromContext(ctx)
+		release, ok := h.follows.Acquire(caller, namespace)
+		if !ok {
+			h.logger.Warn("Rejected pod log follow over the session limit",
+				slog.String("caller", caller),
+				slog.String("namespace", namespace),
+				slog.Int("maxSessions", h.follows.Max()),
+			)
+			w.Header().Set("Retry-After", strconv.Itoa(int(followRetryAfter/time.Second)))
+			writeJSONError(w, http.StatusTooManyRequests, tooManyRequests, fmt.Sprintf(
+				"at most %d pod log follows may be open per caller in namespace %s; close one before opening another",
+				h.follows.Max(), namespace))
+			return
+		}
+		defer release()
+	}
+
+	rc := http.NewResponseController(w)
+	_ = rc.SetWriteDeadline(time.Now().Add(followWriteTimeout))
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	if err := writeFollowEvent(w, "cursor", cursor, map[string]string{"cursor": cursor.String()}); err != nil {
+		return
+	}
+	_ = rc.Flush()
+
+	lastWrite := time.Now()
+	for {
+		params := openobserve.PodLogsParams{
+			Namespace: namespace,
+			PodName:   podName,
+			StartTime: cursor.Time,
+			EndTime:   time.Now().Add(-followSettleDelay),
+			Offset:    cursor.Skip,
+			Limit:     followBatchSize,
+		}
+		var logs []openobserve.ComponentLogsEntry
+		if params.EndTime.After(params.StartTime) {
+			logs, err = client.GetPodLogs(ctx, params)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				h.logger.Error("Failed to follow pod logs",
+					slog.String("function", "FollowPodLogs"),
+					slog.String("namespace", namespace),
+					slog.String("podName", podName),
+					slog.Any("error", err),
+				)
+				_ = writeFollowEvent(w, "error", cursor, map[string]string{"message": "failed to read logs, reconnect to resume"})
+				_ = rc.Flush()
+				return
+			}
+		}
+
+		_ = rc.SetWriteDeadline(time.Now().Add(followWriteTimeout))
+		entries := toComponentLogEntries(logs)
+		for i, entry := range entries {
+			cursor.advance(logs[i].Timestamp)
+			if err := writeFollowEvent(w, "log", cursor, entry); err != nil {
+				return
+			}
+		}
+		if len(entries) > 0 {
+			lastWrite = time.Now()
+		} else if time.Since(lastWrite) >= followHeartbeatInterval {
+			if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				return
+			}
+			lastWrite = time.Now()
+		}
+		_ = rc.Flush()
+
+		if len(logs) == followBatchSize {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(followPollInterval):
+		}
+	}
+}
+
+// writeFollowEvent writes a server-sent event of the given type with the
+// cursor as its ID and v as JSON data.
+func writeFollowEvent(w http.ResponseWriter, event string, cursor followCursor, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", cursor, event, data)
+	return err
+}
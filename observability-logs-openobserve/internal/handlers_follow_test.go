@@ -0,0 +1,227 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/sessions"
+)
+
+func TestFollowCursor(t *testing.T) {
+	c := followCursor{Time: time.UnixMicro(1_700_000_000_000_001)}
+	c.advance(c.Time)
+	c.advance(c.Time)
+	got, err := parseFollowCursor(c.String())
+	if err != nil || !got.Time.Equal(c.Time) || got.Skip != 2 {
+		t.Errorf("parseFollowCursor() = %+v, %v", got, err)
+	}
+	c.advance(c.Time.Add(time.Microsecond))
+	if c.Skip != 1 {
+		t.Errorf("expected the skip to reset on a new timestamp, got %d", c.Skip)
+	}
+	for _, token := range []string{"%%%", "djEuYWJjLjE", "djIuMS4x"} {
+		if _, err := parseFollowCursor(token); err == nil {
+			t.Errorf("expected an error for %q", token)
+		}
+	}
+}
+
+// followEvent is a server-sent event read by readFollowEvents.
+type followEvent struct {
+	id, event, data string
+}
+
+func readFollowEvents(t *testing.T, resp *http.Response, n int) []followEvent {
+	t.Helper()
+	var events []followEvent
+	var current followEvent
+	scanner := bufio.NewScanner(resp.Body)
+	for len(events) < n && scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if current.event != "" {
+				events = append(events, current)
+			}
+			current = followEvent{}
+		case strings.HasPrefix(line, "id: "):
+			current.id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			current.event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			current.data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+	if len(events) < n {
+		t.Fatalf("expected %d events, got %+v (%v)", n, events, scanner.Err())
+	}
+	return events
+}
+
+func TestFollowPodLogs(t *testing.T) {
+	base := time.Now().Add(-time.Hour).Truncate(time.Second)
+	// Two entries share a timestamp, so resuming between them relies on
+	// the skip count of the cursor.
+	stored := []struct {
+		ts  time.Time
+		log string
+	}{
+		{base, "one"},
+		{base.Add(time.Second), "two-a"},
+		{base.Add(time.Second), "two-b"},
+		{base.Add(2 * time.Second), "three"},
+	}
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query struct {
+				SQL       string `json:"sql"`
+				StartTime int64  `json:"start_time"`
+				EndTime   int64  `json:"end_time"`
+				From      int    `json:"from"`
+				Size      int    `json:"size"`
+			} `json:"query"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		var hits []map[string]interface{}
+		for _, s := range stored {
+			if us := s.ts.UnixMicro(); us >= body.Query.StartTime && us < body.Query.EndTime {
+				hits = append(hits, map[string]interface{}{"_timestamp": float64(us), "log": s.log, "kubernetes_pod_name": "api-0"})
+			}
+		}
+		hits = hits[min(body.Query.From, len(hits)):]
+		hits = hits[:min(body.Query.Size, len(hits))]
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(openobserve.OpenObserveResponse{Hits: hits})
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	handler := NewLogsHandler(client, nil, testLogger())
+	srv := httptest.NewServer(NewServer("0", handler, testLogger()).httpServer.Handler)
+	defer srv.Close()
+
+	follow := func(query, lastEventID string) *http.Response {
+		req, _ := http.NewRequest(http.MethodGet, srv.URL+"/api/v1/logs/pods/api-0/follow?"+query, nil)
+		if lastEventID != "" {
+			req.Header.Set("Last-Event-ID", lastEventID)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("follow request failed: %v", err)
+		}
+		return resp
+	}
+	logOf := func(e followEvent) string {
+		var entry struct {
+			Log string `json:"log"`
+		}
+		if err := json.Unmarshal([]byte(e.data), &entry); err != nil {
+			t.Fatalf("invalid log event %q: %v", e.data, err)
+		}
+		return entry.Log
+	}
+
+	if resp := follow("", ""); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected 400 without a namespace, got %d", resp.StatusCode)
+	}
+	if resp := follow("namespace=ns&cursor=bogus", ""); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected 400 for an invalid cursor, got %d", resp.StatusCode)
+	}
+
+	start := base.Add(-time.Minute).Format(time.RFC3339)
+	resp := follow("namespace=ns&startTime="+start, "")
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("unexpected response: %d %s", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	events := readFollowEvents(t, resp, 3)
+	resp.Body.Close()
+	if events[0].event != "cursor" || logOf(events[1]) != "one" || logOf(events[2]) != "two-a" {
+		t.Fatalf("unexpected events: %+v", events)
+	}
+
+	// Reconnecting after "two-a" resumes with "two-b", logged at the same time.
+	resp = follow("namespace=ns&startTime="+start, events[2].id)
+	events = readFollowEvents(t, resp, 3)
+	resp.Body.Close()
+	if events[0].event != "cursor" || logOf(events[1]) != "two-b" || logOf(events[2]) != "three" {
+		t.Errorf("unexpected events after reconnecting: %+v", events)
+	}
+}
+
+func TestFollowPodLogs_SessionLimit(t *testing.T) {
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(openobserve.OpenObserveResponse{})
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	handler := NewLogsHandler(client, nil, testLogger())
+	limiter, err := sessions.NewLimiter(1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	handler.SetFollowLimiter(limiter)
+	srv := httptest.NewServer(NewServer("0", handler, testLogger()).httpServer.Handler)
+	defer srv.Close()
+
+	follow := func(namespace string) *http.Response {
+		resp, err := http.Get(srv.URL + "/api/v1/logs/pods/api-0/follow?namespace=" + namespace)
+		if err != nil {
+			t.Fatalf("follow request failed: %v", err)
+		}
+		return resp
+	}
+
+	first := follow("ns")
+	if first.StatusCode != http.StatusOK {
+		t.Fatalf("expected the first follow to open, got %d", first.StatusCode)
+	}
+	readFollowEvents(t, first, 1)
+
+	second := follow("ns")
+	second.Body.Close()
+	if second.StatusCode != http.StatusTooManyRequests || second.Header.Get("Retry-After") != "30" {
+		t.Fatalf("expected 429 with Retry-After, got %d %q", second.StatusCode, second.Header.Get("Retry-After"))
+	}
+	other := follow("other-ns")
+	if other.StatusCode != http.StatusOK {
+		t.Errorf("expected a follow in another namespace to open, got %d", other.StatusCode)
+	}
+	other.Body.Close()
+
+	// Closing the first follow frees its session once the handler notices.
+	first.Body.Close()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		resp := follow("ns")
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusOK {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the session to be released, still got %d", resp.StatusCode)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	resp, err := http.Get(srv.URL + "/metrics")
+	if err != nil {
+		t.Fatalf("metrics request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if !strings.Contains(string(body), `logs_adapter_follow_sessions_rejected_total{caller="anonymous"}`) {
+		t.Errorf("expected the rejected follows in the metrics:\n%s", body)
+	}
+}
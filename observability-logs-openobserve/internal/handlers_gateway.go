@@ -0,0 +1,170 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/access"
+	"github.com/openchoreo/community-modules/common/prefer"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+// defaultGatewayRequestWindow is the lookback used when a gateway request lookup has no startTime.
+const defaultGatewayRequestWindow = 24 * time.Hour
+
+// gatewayTraceResponse is the response body of GET /api/v1/logs/gateway/requests/{requestId}/trace.
+type gatewayTraceResponse struct {
+	TraceID string                     `json:"traceId"`
+	SpanID  string                     `json:"spanId"`
+	Sampled bool                       `json:"sampled"`
+	Request openobserve.GatewayRequest `json:"request"`
+}
+
+// GetGatewayRequestTrace implements GET /api/v1/logs/gateway/requests/{requestId}/trace.
+// It finds the gateway access log line of an API call by its request or
+// correlation ID, parses it into structured fields, and returns the trace ID
+// and gateway span ID from the W3C traceparent header it recorded, so that
+// the call can be opened in the tracing module.
+//
+// Query parameters: startTime/endTime in RFC 3339 format (default: the last
+// 24 hours).
+func (h *LogsHandler) GetGatewayRequestTrace(w http.ResponseWriter, r *http.Request) {
+	start, end, err := parseTimeWindow(r.URL.Query(), defaultGatewayRequestWindow)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, err.Error())
+		return
+	}
+	params := openobserve.GatewayRequestParams{
+		RequestID: r.PathValue("requestId"),
+		Namespace: h.gatewayNamespace,
+		StartTime: start,
+		EndTime:   end,
+	}
+	if err := h.authorize(r.Context(), params.Namespace, access.Raw); err != nil {
+		writeJSONError(w, http.StatusForbidden, gen.Forbidden, err.Error())
+		return
+	}
+
+	req, err := h.client.FindGatewayRequest(r.Context(), params)
+	if errors.Is(err, openobserve.ErrGatewayRequestNotFound) {
+		writeJSONError(w, http.StatusNotFound, gen.NotFound, "gateway request not found")
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to query gateway request",
+			slog.String("function", "GetGatewayRequestTrace"),
+			slog.String("requestId", params.RequestID),
+			slog.Any("error", err),
+		)
+		writeBackendError(w, err)
+		return
+	}
+	if req.Trace == nil {
+		writeJSONError(w, http.StatusNotFound, gen.NotFound, "gateway request has no trace context")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, gatewayTraceResponse{
+		TraceID: req.Trace.TraceID,
+		SpanID:  req.Trace.SpanID,
+		Sampled: req.Trace.Sampled,
+		Request: *req,
+	})
+}
+
+// GatewaySearchScope selects the gateway access logs in a logs query. It is
+// identified by having a gateway field, which restricts the query to gateway
+// pods whose name starts with it; an empty gateway selects every gateway pod
+// in the gateway namespace.
+type GatewaySearchScope struct {
+	Gateway     *string  `json:"gateway"`
+	Host        string   `json:"host,omitempty"`
+	PathPrefix  string   `json:"pathPrefix,omitempty"`
+	Methods     []string `json:"methods,omitempty"`
+	StatusCodes []int    `json:"statusCodes,omitempty"`
+}
+
+// asGatewaySearchScope returns the search scope as a GatewaySearchScope,
+// reporting false when it is not one.
+func asGatewaySearchScope(scope gen.LogsQueryRequest_SearchScope) (GatewaySearchScope, bool) {
+	raw, err := scope.MarshalJSON()
+	if err != nil {
+		return GatewaySearchScope{}, false
+	}
+	var gateway GatewaySearchScope
+	if err := json.Unmarshal(raw, &gateway); err != nil || gateway.Gateway == nil {
+		return GatewaySearchScope{}, false
+	}
+	return gateway, true
+}
+
+// queryGatewayLogs answers a logs query with a GatewaySearchScope with the
+// requests recorded in the access logs of the gateway pods.
+func (h *LogsHandler) queryGatewayLogs(ctx context.Context, req *gen.LogsQueryRequest, scope GatewaySearchScope) (gen.QueryLogsResponseObject, error) {
+	params := toGatewayLogsParams(req, scope)
+	params.Namespace = h.gatewayNamespace
+	params.Limit = prefer.FromContext(ctx).Limit(params.Limit)
+	if params.Limit > maxInteractiveLimit {
+		return gen.QueryLogs400JSONResponse{
+			Title:   ptr(gen.BadRequest),
+			Message: ptr(fmt.Sprintf("gateway log queries return at most %d entries", maxInteractiveLimit)),
+		}, nil
+	}
+	if err := h.authorize(ctx, params.Namespace, access.Raw); err != nil {
+		return gen.QueryLogs403JSONResponse{
+			Title:   ptr(gen.Forbidden),
+			Message: ptr(err.Error()),
+		}, nil
+	}
+
+	result, err := h.client.GetGatewayLogs(ctx, params)
+	if err != nil {
+		h.logger.Error("Failed to query gateway logs",
+			slog.String("function", "QueryLogs"),
+			slog.String("gateway", params.Gateway),
+			slog.Any("error", err),
+		)
+		return queryLogsError(err), nil
+	}
+
+	resp := gen.LogsQueryResponse{
+		Total:  &result.TotalCount,
+		TookMs: &result.Took,
+	}
+	resp.Logs = toLogsUnion(result.Logs)
+	return queryLogsOK(ctx, resp, nil, nil, openobserve.PartialResult{}), nil
+}
+
+// toGatewayLogsParams converts the generated request and a gateway search
+// scope to internal query params.
+func toGatewayLogsParams(req *gen.LogsQueryRequest, scope GatewaySearchScope) openobserve.GatewayLogsParams {
+	params := openobserve.GatewayLogsParams{
+		Gateway:     strings.TrimSpace(*scope.Gateway),
+		Host:        scope.Host,
+		PathPrefix:  scope.PathPrefix,
+		Methods:     scope.Methods,
+		StatusCodes: scope.StatusCodes,
+		StartTime:   req.StartTime,
+		EndTime:     req.EndTime,
+	}
+	if req.Limit != nil {
+		params.Limit = *req.Limit
+	}
+	if req.SortOrder != nil {
+		params.SortOrder = string(*req.SortOrder)
+	}
+	if req.SearchPhrase != nil {
+		params.SearchPhrase = *req.SearchPhrase
+	}
+	return params
+}
@@ -0,0 +1,163 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+func TestGetGatewayRequestTrace(t *testing.T) {
+	var gotSQL string
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query struct {
+				SQL string `json:"sql"`
+			} `json:"query"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		gotSQL = body.Query.SQL
+		resp := openobserve.OpenObserveResponse{Hits: []map[string]interface{}{}}
+		switch {
+		case strings.Contains(gotSQL, "req-1"):
+			resp.Hits = append(resp.Hits, map[string]interface{}{
+				"_timestamp": float64(1735732800000000),
+				"log":        `{"x-request-id":"req-1","method":"GET","path":"/orders","response_code":200,"traceparent":"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}`,
+			})
+		case strings.Contains(gotSQL, "req-untraced"):
+			resp.Hits = append(resp.Hits, map[string]interface{}{
+				"_timestamp": float64(1735732800000000),
+				"log":        `{"x-request-id":"req-untraced","method":"GET"}`,
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	handler := NewLogsHandler(client, nil, testLogger())
+	handler.SetGatewayNamespace("gateways")
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/logs/gateway/requests/{requestId}/trace", handler.GetGatewayRequestTrace)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	t.Run("success", func(t *testing.T) {
+		rec := get("/api/v1/logs/gateway/requests/req-1/trace")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if !strings.Contains(gotSQL, "kubernetes_namespace_name = 'gateways'") {
+			t.Errorf("expected gateway namespace filter in SQL: %s", gotSQL)
+		}
+
+		var resp gatewayTraceResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if resp.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" || resp.SpanID != "00f067aa0ba902b7" || !resp.Sampled {
+			t.Errorf("unexpected trace context: %+v", resp)
+		}
+		if resp.Request.Method != "GET" || resp.Request.Path != "/orders" || resp.Request.Status != 200 {
+			t.Errorf("unexpected request fields: %+v", resp.Request)
+		}
+	})
+
+	t.Run("no trace context", func(t *testing.T) {
+		if rec := get("/api/v1/logs/gateway/requests/req-untraced/trace"); rec.Code != http.StatusNotFound {
+			t.Errorf("expected 404, got %d", rec.Code)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		if rec := get("/api/v1/logs/gateway/requests/req-missing/trace"); rec.Code != http.StatusNotFound {
+			t.Errorf("expected 404, got %d", rec.Code)
+		}
+	})
+
+	t.Run("invalid time window", func(t *testing.T) {
+		if rec := get("/api/v1/logs/gateway/requests/req-1/trace?startTime=yesterday"); rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", rec.Code)
+		}
+	})
+}
+
+func TestQueryLogs_GatewaySearchScope(t *testing.T) {
+	var gotSQL string
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query struct {
+				SQL string `json:"sql"`
+			} `json:"query"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		resp := openobserve.OpenObserveResponse{Hits: []map[string]interface{}{{"total": float64(1)}}}
+		if !strings.Contains(body.Query.SQL, "count(*)") {
+			gotSQL = body.Query.SQL
+			resp.Hits = []map[string]interface{}{{
+				"_timestamp":          float64(1735732800000000),
+				"log":                 `{"method":"GET","path":"/orders","response_code":200,"upstream_host":"10.0.0.7:8080"}`,
+				"kubernetes_pod_name": "envoy-0",
+			}}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	handler := NewLogsHandler(client, nil, testLogger())
+	handler.SetGatewayNamespace("gateways")
+	srv := NewServer("0", handler, testLogger()).httpServer.Handler
+
+	query := func(scope string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		body := `{"startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-02T00:00:00Z","searchScope":` + scope + `}`
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/logs/query", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		srv.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("success", func(t *testing.T) {
+		rec := query(`{"gateway":"envoy","methods":["GET"]}`)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if !strings.Contains(gotSQL, "kubernetes_namespace_name = 'gateways'") || !strings.Contains(gotSQL, "kubernetes_pod_name LIKE 'envoy%'") {
+			t.Errorf("expected the gateway pods to be queried: %s", gotSQL)
+		}
+		var resp struct {
+			Logs  []openobserve.GatewayLogEntry `json:"logs"`
+			Total int                           `json:"total"`
+		}
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if resp.Total != 1 || len(resp.Logs) != 1 || resp.Logs[0].Method != "GET" || resp.Logs[0].UpstreamHost != "10.0.0.7:8080" {
+			t.Errorf("unexpected response: %+v", resp)
+		}
+	})
+
+	t.Run("limit too large", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		body := `{"startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-02T00:00:00Z","limit":20000,"searchScope":{"gateway":""}}`
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/logs/query", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		srv.ServeHTTP(rec, req)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+}
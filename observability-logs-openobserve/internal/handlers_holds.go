@@ -0,0 +1,173 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/openchoreo/community-modules/common/access"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/export"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/holds"
+)
+
+// createHoldRequest is the request body of POST /api/v1/logs/holds.
+type createHoldRequest struct {
+	Name        string                    `json:"name"`
+	Reason      string                    `json:"reason"`
+	StartTime   time.Time                 `json:"startTime"`
+	EndTime     time.Time                 `json:"endTime"`
+	SearchScope *gen.ComponentSearchScope `json:"searchScope"`
+}
+
+// holdsResponse is the response body of GET /api/v1/logs/holds.
+type holdsResponse struct {
+	Holds []holds.Hold `json:"holds"`
+}
+
+// CreateHold implements POST /api/v1/logs/holds. It registers a legal hold
+// and snapshots every log entry of the scope and time range to object
+// storage, under <prefix>/holds/<holdId>/, together with a manifest listing
+// the SHA-256 checksum of each object. Objects are written with
+// If-None-Match so that a snapshot is never overwritten and, when enabled,
+// with an S3 Object Lock legal hold.
+func (h *LogsHandler) CreateHold(w http.ResponseWriter, r *http.Request) {
+	if h.exporter == nil || h.holds == nil {
+		writeJSONError(w, http.StatusNotImplemented, gen.BadRequest, "legal holds are not configured")
+		return
+	}
+
+	var req createHoldRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "invalid request body")
+		return
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "name is required")
+		return
+	}
+	if req.SearchScope == nil || strings.TrimSpace(req.SearchScope.Namespace) == "" {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "searchScope with a valid namespace is required")
+		return
+	}
+	if req.StartTime.IsZero() || req.EndTime.IsZero() {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "startTime and endTime are required")
+		return
+	}
+	if _, err := h.clientFor(r.Context(), req.SearchScope.Namespace, access.Raw); err != nil {
+		writeJSONError(w, http.StatusForbidden, gen.Forbidden, err.Error())
+		return
+	}
+
+	scope := req.SearchScope
+	hold := holds.Hold{
+		ID:        uuid.New().String(),
+		Name:      req.Name,
+		Reason:    req.Reason,
+		Namespace: scope.Namespace,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+		CreatedAt: time.Now().UTC(),
+	}
+	if scope.ProjectUid != nil {
+		hold.ProjectUID = *scope.ProjectUid
+	}
+	if scope.EnvironmentUid != nil {
+		hold.EnvironmentUID = *scope.EnvironmentUid
+	}
+	if scope.ComponentUid != nil {
+		hold.ComponentUID = *scope.ComponentUid
+	}
+	hold.Prefix = path.Join(h.exporter.Prefix(), "holds", hold.ID)
+
+	params := toComponentLogsParams(&gen.LogsQueryRequest{StartTime: req.StartTime, EndTime: req.EndTime}, scope)
+	// The job may finish before the hold is registered, so its completion
+	// waits for registration before recording the outcome.
+	registered := make(chan struct{})
+	job, err := h.exporter.Submit(params, export.Options{
+		KeyPrefix: hold.Prefix,
+		LegalHold: h.holdObjectLock,
+		Manifest:  true,
+		OnComplete: func(job export.Job) {
+			<-registered
+			h.recordHoldSnapshot(hold.ID, job)
+		},
+	})
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, err.Error())
+		return
+	}
+	hold.ExportJobID = job.ID
+	hold.Status = string(job.Status)
+
+	err = h.holds.Create(hold)
+	close(registered)
+	if err != nil {
+		h.logger.Error("Failed to register legal hold",
+			slog.String("function", "CreateHold"),
+			slog.String("holdId", hold.ID),
+			slog.Any("error", err),
+		)
+		writeJSONError(w, http.StatusInternalServerError, gen.InternalServerError, "internal server error")
+		return
+	}
+
+	h.logger.Info("Legal hold created",
+		slog.String("holdId", hold.ID),
+		slog.String("name", hold.Name),
+		slog.String("namespace", hold.Namespace),
+		slog.String("jobId", job.ID),
+	)
+	w.Header().Set("Location", "/api/v1/logs/holds/"+hold.ID)
+	writeJSON(w, http.StatusAccepted, hold)
+}
+
+// ListHolds implements GET /api/v1/logs/holds.
+func (h *LogsHandler) ListHolds(w http.ResponseWriter, _ *http.Request) {
+	if h.holds == nil {
+		writeJSONError(w, http.StatusNotImplemented, gen.BadRequest, "legal holds are not configured")
+		return
+	}
+	writeJSON(w, http.StatusOK, holdsResponse{Holds: h.holds.List()})
+}
+
+// GetHold implements GET /api/v1/logs/holds/{holdId}.
+func (h *LogsHandler) GetHold(w http.ResponseWriter, r *http.Request) {
+	if h.holds == nil {
+		writeJSONError(w, http.StatusNotImplemented, gen.BadRequest, "legal holds are not configured")
+		return
+	}
+	hold, err := h.holds.Get(r.PathValue("holdId"))
+	if errors.Is(err, holds.ErrNotFound) {
+		writeJSONError(w, http.StatusNotFound, gen.NotFound, "hold not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, hold)
+}
+
+// recordHoldSnapshot stores the outcome of a hold's snapshot export.
+func (h *LogsHandler) recordHoldSnapshot(holdID string, job export.Job) {
+	err := h.holds.Update(holdID, func(hold *holds.Hold) {
+		hold.Status = string(job.Status)
+		hold.Records = job.Records
+		hold.Manifest = job.Manifest
+		hold.Error = job.Error
+		hold.CompletedAt = job.CompletedAt
+	})
+	if err != nil {
+		h.logger.Error("Failed to record legal hold snapshot",
+			slog.String("holdId", holdID),
+			slog.String("jobId", job.ID),
+			slog.Any("error", err),
+		)
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/export"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/holds"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+func TestLegalHolds(t *testing.T) {
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := openobserve.OpenObserveResponse{
+			Hits: []map[string]interface{}{
+				{"_timestamp": float64(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC).UnixMicro()), "log": "hello"},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	handler := NewLogsHandler(client, nil, testLogger())
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v1/logs/holds", handler.CreateHold)
+	mux.HandleFunc("GET /api/v1/logs/holds", handler.ListHolds)
+	mux.HandleFunc("GET /api/v1/logs/holds/{holdId}", handler.GetHold)
+
+	body := `{"name":"case-42","reason":"litigation","startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-01T23:00:00Z","searchScope":{"namespace":"test-ns"}}`
+
+	t.Run("not configured", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/logs/holds", strings.NewReader(body)))
+		if rec.Code != http.StatusNotImplemented {
+			t.Errorf("expected 501, got %d", rec.Code)
+		}
+	})
+
+	objects := &memObjectStore{objects: map[string][]byte{}}
+	handler.SetExporter(export.NewManager(client, objects, "exports", testLogger()))
+	holdStore, err := holds.NewFileStore(filepath.Join(t.TempDir(), "holds.json"))
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+	handler.SetHoldStore(holdStore, true)
+
+	t.Run("create and poll", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/logs/holds", strings.NewReader(body)))
+		if rec.Code != http.StatusAccepted {
+			t.Fatalf("expected 202, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var hold holds.Hold
+		if err := json.NewDecoder(rec.Body).Decode(&hold); err != nil {
+			t.Fatalf("failed to decode hold: %v", err)
+		}
+		if hold.Name != "case-42" || hold.Prefix != "exports/holds/"+hold.ID || hold.ExportJobID == "" {
+			t.Fatalf("unexpected hold: %+v", hold)
+		}
+
+		location := rec.Header().Get("Location")
+		deadline := time.Now().Add(5 * time.Second)
+		for hold.CompletedAt == nil && time.Now().Before(deadline) {
+			time.Sleep(10 * time.Millisecond)
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, location, nil))
+			if rec.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d", rec.Code)
+			}
+			_ = json.NewDecoder(rec.Body).Decode(&hold)
+		}
+		if hold.Status != string(export.StatusSucceeded) || hold.Records != 1 || hold.Manifest == "" {
+			t.Fatalf("unexpected hold: %+v", hold)
+		}
+		if _, ok := objects.objects[hold.Manifest]; !ok {
+			t.Errorf("manifest %s was not written", hold.Manifest)
+		}
+
+		rec = httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/logs/holds", nil))
+		var list holdsResponse
+		if err := json.NewDecoder(rec.Body).Decode(&list); err != nil {
+			t.Fatalf("failed to decode holds: %v", err)
+		}
+		if len(list.Holds) != 1 || list.Holds[0].ID != hold.ID {
+			t.Errorf("unexpected holds: %+v", list.Holds)
+		}
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		for _, body := range []string{
+			`{`,
+			`{"startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-02T00:00:00Z","searchScope":{"namespace":"ns"}}`,
+			`{"name":"n","startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-02T00:00:00Z"}`,
+			`{"name":"n","searchScope":{"namespace":"ns"}}`,
+		} {
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/logs/holds", strings.NewReader(body)))
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected 400 for %s, got %d", body, rec.Code)
+			}
+		}
+	})
+
+	t.Run("unknown hold", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/logs/holds/unknown", nil))
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("expected 404, got %d", rec.Code)
+		}
+	})
+}
@@ -0,0 +1,111 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/access"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+const (
+	// defaultRestartWindow is the lookback used when a restart summary request has no startTime.
+	defaultRestartWindow = 24 * time.Hour
+	// maxRestartWindow bounds the window a restart summary scans.
+	maxRestartWindow = 7 * 24 * time.Hour
+)
+
+// restartSummaryRequest is the request body of POST /api/v1/logs/incidents/restarts.
+type restartSummaryRequest struct {
+	SearchScope *gen.ComponentSearchScope `json:"searchScope"`
+	StartTime   *time.Time                `json:"startTime"`
+	EndTime     *time.Time                `json:"endTime"`
+	Sources     []string                  `json:"sources"`
+}
+
+// GetRestartSummary implements POST /api/v1/logs/incidents/restarts. It
+// answers "why did my pod restart" for a scope: it scans the Kubernetes
+// events and application logs of the window for OOM kills, crash loop
+// back-offs, liveness probe kills, evictions and process exits, and returns
+// them per component and environment with their timestamps and exit codes.
+//
+// The window defaults to the last 24 hours and may span at most 7 days.
+// sources restricts the scan to "events" or "logs".
+func (h *LogsHandler) GetRestartSummary(w http.ResponseWriter, r *http.Request) {
+	var req restartSummaryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "invalid request body")
+		return
+	}
+	if req.SearchScope == nil || strings.TrimSpace(req.SearchScope.Namespace) == "" {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "searchScope with a valid namespace is required")
+		return
+	}
+	if err := openobserve.ValidateRestartSources(req.Sources); err != nil {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, err.Error())
+		return
+	}
+
+	params := openobserve.RestartSummaryParams{
+		Namespace: req.SearchScope.Namespace,
+		EndTime:   time.Now(),
+		Sources:   req.Sources,
+	}
+	if req.EndTime != nil {
+		params.EndTime = *req.EndTime
+	}
+	params.StartTime = params.EndTime.Add(-defaultRestartWindow)
+	if req.StartTime != nil {
+		params.StartTime = *req.StartTime
+	}
+	if params.EndTime.Before(params.StartTime) {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "endTime must be >= startTime")
+		return
+	}
+	if params.EndTime.Sub(params.StartTime) > maxRestartWindow {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "the window must not exceed 7 days")
+		return
+	}
+	scope := req.SearchScope
+	if scope.ProjectUid != nil {
+		params.ProjectID = *scope.ProjectUid
+	}
+	if scope.EnvironmentUid != nil {
+		params.EnvironmentID = *scope.EnvironmentUid
+	}
+	if scope.ComponentUid != nil {
+		params.ComponentID = *scope.ComponentUid
+	}
+
+	client, err := h.clientFor(r.Context(), params.Namespace, access.Aggregates)
+	if err != nil {
+		writeJSONError(w, http.StatusForbidden, gen.Forbidden, err.Error())
+		return
+	}
+
+	result, err := client.GetRestartSummary(r.Context(), params)
+	if err != nil {
+		h.logger.Error("Failed to summarize restarts",
+			slog.String("function", "GetRestartSummary"),
+			slog.String("namespace", params.Namespace),
+			slog.Any("error", err),
+		)
+		writeBackendError(w, err)
+		return
+	}
+	if h.aggregationOnly(r.Context(), params.Namespace) {
+		for i := range result.Components {
+			for j := range result.Components[i].Signals {
+				result.Components[i].Signals[j].Message = ""
+			}
+		}
+	}
+	writeJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+func TestGetRestartSummary(t *testing.T) {
+	var queries []string
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query struct {
+				SQL string `json:"sql"`
+			} `json:"query"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		queries = append(queries, body.Query.SQL)
+		resp := openobserve.OpenObserveResponse{Hits: []map[string]interface{}{}}
+		if strings.Contains(body.Query.SQL, `"k8s_events"`) {
+			resp.Hits = append(resp.Hits, map[string]interface{}{
+				"_timestamp":       float64(1735732800000000),
+				"k8s_event_reason": "BackOff",
+				"body":             "Back-off restarting failed container api in pod api-1",
+				"k8s_object_kind":  "Pod",
+				"k8s_object_name":  "api-1",
+				"k8s_object_label_openchoreo_dev_component_uid":   "comp-1",
+				"k8s_object_label_openchoreo_dev_environment_uid": "env-1",
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	handler := NewLogsHandler(client, nil, testLogger())
+
+	post := func(body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.GetRestartSummary(rec, httptest.NewRequest(http.MethodPost, "/api/v1/logs/incidents/restarts", strings.NewReader(body)))
+		return rec
+	}
+
+	t.Run("success", func(t *testing.T) {
+		queries = nil
+		rec := post(`{"searchScope":{"namespace":"default","componentUid":"comp-1"},"sources":["events"]}`)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var got openobserve.RestartSummaryResult
+		if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(got.Components) != 1 || got.Components[0].Counts[openobserve.RestartKindCrashLoop] != 1 {
+			t.Errorf("unexpected summary: %+v", got)
+		}
+		if len(queries) != 1 || !strings.Contains(queries[0], "component_uid = 'comp-1'") {
+			t.Errorf("expected one scoped events query, got %v", queries)
+		}
+	})
+
+	for name, body := range map[string]string{
+		"invalid body":      `{`,
+		"missing namespace": `{"searchScope":{}}`,
+		"unknown source":    `{"searchScope":{"namespace":"default"},"sources":["metrics"]}`,
+		"inverted window":   `{"searchScope":{"namespace":"default"},"startTime":"2026-03-02T00:00:00Z","endTime":"2026-03-01T00:00:00Z"}`,
+		"window too long":   `{"searchScope":{"namespace":"default"},"startTime":"2026-01-01T00:00:00Z","endTime":"2026-03-01T00:00:00Z"}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			if rec := post(body); rec.Code != http.StatusBadRequest {
+				t.Errorf("expected 400, got %d: %s", rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
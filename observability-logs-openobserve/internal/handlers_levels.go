@@ -0,0 +1,111 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/access"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+const (
+	// defaultLevelHistogramWindow is the lookback used when a level histogram request has no startTime.
+	defaultLevelHistogramWindow = time.Hour
+	// levelHistogramTargetBuckets is the number of buckets the default interval aims for.
+	levelHistogramTargetBuckets = 60
+	// maxLevelHistogramBuckets rejects intervals that would split the window into too many buckets.
+	maxLevelHistogramBuckets = 1000
+	// minLevelHistogramInterval is the smallest default interval.
+	minLevelHistogramInterval = 10 * time.Second
+)
+
+// levelHistogramResponse is the response body of GET /api/v1/logs/components/{componentUid}/levels.
+type levelHistogramResponse struct {
+	IntervalSeconds int                                `json:"intervalSeconds"`
+	Buckets         []openobserve.LevelHistogramBucket `json:"buckets"`
+	TookMs          int                                `json:"tookMs"`
+	// FromRollups is set when the histogram was read from the level rollups.
+	FromRollups bool        `json:"fromRollups,omitempty"`
+	QueryStats  *queryStats `json:"queryStats,omitempty"`
+}
+
+// GetComponentLevelHistogram implements GET /api/v1/logs/components/{componentUid}/levels.
+// It returns the number of log lines per level of one component over time, as
+// stacked time series for the component detail page.
+//
+// Query parameters: namespace (required), startTime/endTime in RFC 3339
+// format (default: the last hour) and interval as a Go duration (default: the
+// window split into about 60 buckets, at least 10s).
+func (h *LogsHandler) GetComponentLevelHistogram(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	params := openobserve.LevelHistogramParams{
+		Namespace:    strings.TrimSpace(query.Get("namespace")),
+		ComponentUID: r.PathValue("componentUid"),
+	}
+	if params.Namespace == "" {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "namespace is required")
+		return
+	}
+	start, end, err := parseTimeWindow(query, defaultLevelHistogramWindow)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, err.Error())
+		return
+	}
+	params.StartTime, params.EndTime = start, end
+
+	params.Interval, err = levelHistogramInterval(query.Get("interval"), end.Sub(start))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, err.Error())
+		return
+	}
+
+	client, err := h.clientFor(r.Context(), params.Namespace, access.Aggregates)
+	if err != nil {
+		writeJSONError(w, http.StatusForbidden, gen.Forbidden, err.Error())
+		return
+	}
+
+	result, err := client.GetComponentLevelHistogram(r.Context(), params)
+	if err != nil {
+		h.logger.Error("Failed to query log level histogram",
+			slog.String("function", "GetComponentLevelHistogram"),
+			slog.String("namespace", params.Namespace),
+			slog.String("componentUid", params.ComponentUID),
+			slog.Any("error", err),
+		)
+		writeBackendError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, levelHistogramResponse{
+		IntervalSeconds: int(params.Interval / time.Second),
+		Buckets:         result.Buckets,
+		TookMs:          result.Took,
+		FromRollups:     result.FromRollups,
+		QueryStats:      queryStatsFromContext(r.Context()),
+	})
+}
+
+// levelHistogramInterval returns the requested bucket interval, or a default
+// derived from the window when none was given. Intervals are whole seconds.
+func levelHistogramInterval(value string, window time.Duration) (time.Duration, error) {
+	if value == "" {
+		return max((window / levelHistogramTargetBuckets).Truncate(time.Second), minLevelHistogramInterval), nil
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval < time.Second {
+		return 0, fmt.Errorf("interval must be a duration of at least 1s")
+	}
+	interval = interval.Truncate(time.Second)
+	if window/interval > maxLevelHistogramBuckets {
+		return 0, fmt.Errorf("interval is too small for the time window: at most %d buckets are allowed", maxLevelHistogramBuckets)
+	}
+	return interval, nil
+}
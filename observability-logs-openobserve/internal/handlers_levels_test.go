@@ -0,0 +1,122 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+func TestGetComponentLevelHistogram(t *testing.T) {
+	var gotSQL string
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query struct {
+				SQL string `json:"sql"`
+			} `json:"query"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		gotSQL = body.Query.SQL
+		resp := openobserve.OpenObserveResponse{
+			Took: 2,
+			Hits: []map[string]interface{}{
+				{"bucket": "2025-01-01T12:00:00", "level": "INFO", "count": float64(10)},
+				{"bucket": "2025-01-01T12:00:00", "level": "error", "count": float64(2)},
+				{"bucket": "2025-01-01T12:01:00", "level": "", "count": float64(1)},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	handler := NewLogsHandler(client, nil, testLogger())
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/logs/components/{componentUid}/levels", handler.GetComponentLevelHistogram)
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet,
+			"/api/v1/logs/components/comp-1/levels?namespace=test-ns&startTime=2025-01-01T12:00:00Z&endTime=2025-01-01T13:00:00Z&interval=1m", nil)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if !strings.Contains(gotSQL, "kubernetes_labels_openchoreo_dev_component_uid = 'comp-1'") ||
+			!strings.Contains(gotSQL, "histogram(_timestamp, '60 seconds')") {
+			t.Errorf("unexpected SQL: %s", gotSQL)
+		}
+
+		var resp levelHistogramResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if resp.IntervalSeconds != 60 || resp.TookMs != 2 || len(resp.Buckets) != 2 {
+			t.Fatalf("unexpected response: %+v", resp)
+		}
+		first := resp.Buckets[0]
+		if !first.Time.Equal(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)) {
+			t.Errorf("unexpected bucket time: %v", first.Time)
+		}
+		if first.Counts["INFO"] != 10 || first.Counts["ERROR"] != 2 {
+			t.Errorf("unexpected counts: %v", first.Counts)
+		}
+		if resp.Buckets[1].Counts["UNKNOWN"] != 1 {
+			t.Errorf("expected unlabelled lines counted as UNKNOWN, got %v", resp.Buckets[1].Counts)
+		}
+	})
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"missing namespace", ""},
+		{"invalid window", "namespace=ns&startTime=2025-01-02T00:00:00Z&endTime=2025-01-01T00:00:00Z"},
+		{"invalid interval", "namespace=ns&interval=soon"},
+		{"too many buckets", "namespace=ns&startTime=2025-01-01T00:00:00Z&endTime=2025-01-02T00:00:00Z&interval=1s"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/logs/components/comp-1/levels?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected 400, got %d", rec.Code)
+			}
+		})
+	}
+}
+
+func TestLevelHistogramInterval(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		window time.Duration
+		want   time.Duration
+	}{
+		{"default for an hour", "", time.Hour, time.Minute},
+		{"default floor", "", time.Minute, minLevelHistogramInterval},
+		{"explicit", "5m", time.Hour, 5 * time.Minute},
+		{"truncated to seconds", "1500ms", time.Minute, time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := levelHistogramInterval(tt.value, tt.window)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
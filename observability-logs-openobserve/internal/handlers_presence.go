@@ -0,0 +1,98 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/access"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+const (
+	// defaultPresenceHours is the lookback of GET /api/v1/logs/presence
+	// when it has no hours parameter.
+	defaultPresenceHours = 24
+	// maxPresenceHours bounds the lookback of a presence check.
+	maxPresenceHours = 30 * 24
+)
+
+// logsPresenceResponse is the response body of GET /api/v1/logs/presence.
+type logsPresenceResponse struct {
+	HasData    bool        `json:"hasData"`
+	Count      int         `json:"count"`
+	Earliest   *time.Time  `json:"earliest,omitempty"`
+	Latest     *time.Time  `json:"latest,omitempty"`
+	StartTime  time.Time   `json:"startTime"`
+	EndTime    time.Time   `json:"endTime"`
+	TookMs     int         `json:"tookMs"`
+	QueryStats *queryStats `json:"queryStats,omitempty"`
+}
+
+// GetLogsPresence implements GET /api/v1/logs/presence. It reports whether
+// a scope has any logs in the last hours, with the timestamps of its
+// earliest and latest lines, so that the console can tell a query matching
+// no logs apart from a component whose logs are not being ingested.
+//
+// Query parameters: namespace (required), projectUid, environmentUid,
+// componentUid, and hours (default 24, at most 720).
+func (h *LogsHandler) GetLogsPresence(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	params := openobserve.LogsPresenceParams{
+		Namespace:     strings.TrimSpace(query.Get("namespace")),
+		ProjectID:     query.Get("projectUid"),
+		EnvironmentID: query.Get("environmentUid"),
+		ComponentID:   query.Get("componentUid"),
+	}
+	if params.Namespace == "" {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "namespace is required")
+		return
+	}
+	hours := defaultPresenceHours
+	if v := query.Get("hours"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxPresenceHours {
+			writeJSONError(w, http.StatusBadRequest, gen.BadRequest,
+				fmt.Sprintf("hours must be an integer between 1 and %d", maxPresenceHours))
+			return
+		}
+		hours = n
+	}
+	params.EndTime = time.Now().UTC()
+	params.StartTime = params.EndTime.Add(-time.Duration(hours) * time.Hour)
+
+	client, err := h.clientFor(r.Context(), params.Namespace, access.Aggregates)
+	if err != nil {
+		writeJSONError(w, http.StatusForbidden, gen.Forbidden, err.Error())
+		return
+	}
+
+	result, err := client.GetLogsPresence(r.Context(), params)
+	if err != nil {
+		h.logger.Error("Failed to check logs presence",
+			slog.String("function", "GetLogsPresence"),
+			slog.String("namespace", params.Namespace),
+			slog.Any("error", err),
+		)
+		writeBackendError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, logsPresenceResponse{
+		HasData:    result.HasData,
+		Count:      result.Count,
+		Earliest:   result.Earliest,
+		Latest:     result.Latest,
+		StartTime:  params.StartTime,
+		EndTime:    params.EndTime,
+		TookMs:     result.Took,
+		QueryStats: queryStatsFromContext(r.Context()),
+	})
+}
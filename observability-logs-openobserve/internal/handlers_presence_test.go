@@ -0,0 +1,93 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+func TestGetLogsPresence(t *testing.T) {
+	latest := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	var gotStart, gotEnd int64
+	total := float64(7)
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query struct {
+				StartTime int64 `json:"start_time"`
+				EndTime   int64 `json:"end_time"`
+			} `json:"query"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		gotStart, gotEnd = body.Query.StartTime, body.Query.EndTime
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(openobserve.OpenObserveResponse{
+			Took: 2,
+			Hits: []map[string]interface{}{{
+				"earliest": float64(latest.Add(-time.Hour).UnixMicro()),
+				"latest":   float64(latest.UnixMicro()),
+				"total":    total,
+			}},
+		})
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	handler := NewLogsHandler(client, nil, testLogger())
+	get := func(target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.GetLogsPresence(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec
+	}
+
+	t.Run("data present", func(t *testing.T) {
+		rec := get("/api/v1/logs/presence?namespace=test-ns&componentUid=comp-1&hours=6")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var got logsPresenceResponse
+		if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if !got.HasData || got.Count != 7 || got.Latest == nil || !got.Latest.Equal(latest) || got.TookMs != 2 {
+			t.Errorf("unexpected response: %+v", got)
+		}
+		if window := time.Duration(gotEnd-gotStart) * time.Microsecond; window != 6*time.Hour {
+			t.Errorf("expected a 6h window, got %s", window)
+		}
+	})
+
+	t.Run("no data", func(t *testing.T) {
+		total = 0
+		rec := get("/api/v1/logs/presence?namespace=test-ns")
+		var got logsPresenceResponse
+		if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if got.HasData || got.Earliest != nil {
+			t.Errorf("expected no data, got %+v", got)
+		}
+		if window := got.EndTime.Sub(got.StartTime); window != 24*time.Hour {
+			t.Errorf("expected the default 24h window, got %s", window)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, target := range []string{
+			"/api/v1/logs/presence",
+			"/api/v1/logs/presence?namespace=test-ns&hours=0",
+			"/api/v1/logs/presence?namespace=test-ns&hours=721",
+			"/api/v1/logs/presence?namespace=test-ns&hours=1h",
+		} {
+			if rec := get(target); rec.Code != http.StatusBadRequest {
+				t.Errorf("expected 400 for %s, got %d", target, rec.Code)
+			}
+		}
+	})
+}
@@ -0,0 +1,185 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/presets"
+)
+
+// queryPresetRequest is the request body of
+// PUT /api/v1/logs/presets/{namespace}.
+type queryPresetRequest struct {
+	Window    string   `json:"window"`
+	Limit     int      `json:"limit"`
+	LogLevels []string `json:"logLevels"`
+}
+
+// queryPresetsResponse is the response body of GET /api/v1/logs/presets.
+type queryPresetsResponse struct {
+	Presets []presets.Preset `json:"presets"`
+}
+
+// requirePresetAdmin writes the error of a preset request that the adapter
+// cannot serve or the caller may not make, and reports whether it did.
+func (h *LogsHandler) requirePresetAdmin(w http.ResponseWriter, r *http.Request) bool {
+	if h.presets == nil {
+		writeJSONError(w, http.StatusNotImplemented, gen.BadRequest, "query presets are not configured")
+		return true
+	}
+	if h.access == nil || !h.access.Admin(callerFromContext(r.Context())) {
+		writeJSONError(w, http.StatusForbidden, gen.Forbidden, "query presets are restricted to admin callers")
+		return true
+	}
+	return false
+}
+
+// ListQueryPresets implements GET /api/v1/logs/presets. It lists the query
+// presets of all namespaces, ordered by namespace.
+func (h *LogsHandler) ListQueryPresets(w http.ResponseWriter, r *http.Request) {
+	if h.requirePresetAdmin(w, r) {
+		return
+	}
+	list := h.presets.List()
+	if list == nil {
+		list = []presets.Preset{}
+	}
+	writeJSON(w, http.StatusOK, queryPresetsResponse{Presets: list})
+}
+
+// GetQueryPreset implements GET /api/v1/logs/presets/{namespace}.
+func (h *LogsHandler) GetQueryPreset(w http.ResponseWriter, r *http.Request) {
+	if h.requirePresetAdmin(w, r) {
+		return
+	}
+	preset, err := h.presets.Get(r.PathValue("namespace"))
+	if errors.Is(err, presets.ErrNotFound) {
+		writeJSONError(w, http.StatusNotFound, gen.NotFound, "preset not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, preset)
+}
+
+// PutQueryPreset implements PUT /api/v1/logs/presets/{namespace}. It sets
+// the defaults of the logs and events queries of the namespace, replacing
+// its previous preset.
+func (h *LogsHandler) PutQueryPreset(w http.ResponseWriter, r *http.Request) {
+	if h.requirePresetAdmin(w, r) {
+		return
+	}
+
+	var req queryPresetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "invalid request body")
+		return
+	}
+	preset := presets.Preset{
+		Namespace: r.PathValue("namespace"),
+		Window:    req.Window,
+		Limit:     req.Limit,
+		LogLevels: req.LogLevels,
+		UpdatedBy: callerFromContext(r.Context()),
+		UpdatedAt: time.Now().UTC(),
+	}
+	if err := preset.Validate(); err != nil {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, err.Error())
+		return
+	}
+
+	if err := h.presets.Put(preset); err != nil {
+		h.logger.Error("Failed to save query preset",
+			slog.String("function", "PutQueryPreset"),
+			slog.String("namespace", preset.Namespace),
+			slog.Any("error", err),
+		)
+		writeJSONError(w, http.StatusInternalServerError, gen.InternalServerError, "internal server error")
+		return
+	}
+	writeJSON(w, http.StatusOK, preset)
+}
+
+// DeleteQueryPreset implements DELETE /api/v1/logs/presets/{namespace}.
+func (h *LogsHandler) DeleteQueryPreset(w http.ResponseWriter, r *http.Request) {
+	if h.requirePresetAdmin(w, r) {
+		return
+	}
+	namespace := r.PathValue("namespace")
+	err := h.presets.Delete(namespace)
+	if errors.Is(err, presets.ErrNotFound) {
+		writeJSONError(w, http.StatusNotFound, gen.NotFound, "preset not found")
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to delete query preset",
+			slog.String("function", "DeleteQueryPreset"),
+			slog.String("namespace", namespace),
+			slog.Any("error", err),
+		)
+		writeJSONError(w, http.StatusInternalServerError, gen.InternalServerError, "internal server error")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// queryPreset returns the preset of namespace, if any.
+func (h *LogsHandler) queryPreset(namespace string) (presets.Preset, bool) {
+	if h.presets == nil || namespace == "" {
+		return presets.Preset{}, false
+	}
+	preset, err := h.presets.Get(namespace)
+	return preset, err == nil
+}
+
+// applyQueryPreset fills the time range and limit of a query of namespace
+// that the client left out with the preset of the namespace, and returns it.
+func (h *LogsHandler) applyQueryPreset(namespace string, start, end *time.Time, limit **int) (presets.Preset, bool) {
+	preset, ok := h.queryPreset(namespace)
+	if !ok {
+		return preset, false
+	}
+	if window := preset.WindowDuration(); window > 0 && start.IsZero() {
+		if end.IsZero() {
+			*end = time.Now().UTC()
+		}
+		*start = end.Add(-window)
+	}
+	if *limit == nil && preset.Limit > 0 {
+		*limit = ptr(preset.Limit)
+	}
+	return preset, true
+}
+
+// applyLogsQueryPreset fills the fields of a logs query that the client left
+// out with the preset of the namespace of its scope.
+func (h *LogsHandler) applyLogsQueryPreset(req *gen.LogsQueryRequest) {
+	scope, err := req.SearchScope.AsComponentSearchScope()
+	if err != nil {
+		return
+	}
+	preset, ok := h.applyQueryPreset(scope.Namespace, &req.StartTime, &req.EndTime, &req.Limit)
+	if !ok || req.LogLevels != nil || len(preset.LogLevels) == 0 {
+		return
+	}
+	levels := make([]gen.LogsQueryRequestLogLevels, len(preset.LogLevels))
+	for i, level := range preset.LogLevels {
+		levels[i] = gen.LogsQueryRequestLogLevels(level)
+	}
+	req.LogLevels = &levels
+}
+
+// applyEventsQueryPreset fills the time range and limit of an events query
+// that the client left out with the preset of the namespace of its scope.
+func (h *LogsHandler) applyEventsQueryPreset(req *gen.EventsQueryRequest) {
+	scope, err := req.SearchScope.AsComponentSearchScope()
+	if err != nil {
+		return
+	}
+	h.applyQueryPreset(scope.Namespace, &req.StartTime, &req.EndTime, &req.Limit)
+}
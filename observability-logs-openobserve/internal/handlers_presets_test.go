@@ -0,0 +1,169 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/access"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/presets"
+)
+
+func TestQueryPresets(t *testing.T) {
+	type searchQuery struct {
+		SQL       string `json:"sql"`
+		StartTime int64  `json:"start_time"`
+		EndTime   int64  `json:"end_time"`
+		Size      int    `json:"size"`
+	}
+	var queries []searchQuery
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query searchQuery `json:"query"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		queries = append(queries, body.Query)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(openobserve.OpenObserveResponse{})
+	}))
+	defer ooServer.Close()
+
+	t.Setenv("SRE_TOKEN", "sre-token")
+	t.Setenv("DASH_TOKEN", "dash-token")
+	policy, err := access.NewPolicy(access.File{
+		Callers: []access.Caller{
+			{Name: "sre", TokenEnv: "SRE_TOKEN"},
+			{Name: "dashboards", TokenEnv: "DASH_TOKEN"},
+		},
+		Admins: []string{"sre"},
+	})
+	if err != nil {
+		t.Fatalf("NewPolicy() error = %v", err)
+	}
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	handler := NewLogsHandler(client, nil, testLogger())
+	handler.SetAccessPolicy(policy)
+	srv := NewServer("0", handler, testLogger()).httpServer.Handler
+
+	serve := func(method, target, body, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		return rec
+	}
+	const preset = `{"window":"15m","limit":25,"logLevels":["ERROR"]}`
+
+	t.Run("not configured", func(t *testing.T) {
+		if rec := serve(http.MethodPut, "/api/v1/logs/presets/prod", preset, "sre-token"); rec.Code != http.StatusNotImplemented {
+			t.Errorf("expected 501, got %d", rec.Code)
+		}
+	})
+
+	store, err := presets.NewFileStore(filepath.Join(t.TempDir(), "presets.json"))
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+	handler.SetPresetStore(store)
+
+	t.Run("admins only", func(t *testing.T) {
+		for _, token := range []string{"", "dash-token"} {
+			if rec := serve(http.MethodPut, "/api/v1/logs/presets/prod", preset, token); rec.Code != http.StatusForbidden {
+				t.Errorf("expected 403 for %q, got %d", token, rec.Code)
+			}
+			if rec := serve(http.MethodGet, "/api/v1/logs/presets", "", token); rec.Code != http.StatusForbidden {
+				t.Errorf("expected 403 for %q, got %d", token, rec.Code)
+			}
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, body := range []string{`{}`, `{"window":"soon"}`, `{"limit":20000}`, `{"logLevels":["FATAL"]}`, `{`} {
+			if rec := serve(http.MethodPut, "/api/v1/logs/presets/prod", body, "sre-token"); rec.Code != http.StatusBadRequest {
+				t.Errorf("expected 400 for %s, got %d", body, rec.Code)
+			}
+		}
+	})
+
+	t.Run("put, get and list", func(t *testing.T) {
+		rec := serve(http.MethodPut, "/api/v1/logs/presets/prod", preset, "sre-token")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		rec = serve(http.MethodGet, "/api/v1/logs/presets/prod", "", "sre-token")
+		var got presets.Preset
+		if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+			t.Fatalf("failed to decode preset: %v", err)
+		}
+		if got.Namespace != "prod" || got.Window != "15m" || got.Limit != 25 || got.UpdatedBy != "sre" {
+			t.Errorf("unexpected preset: %+v", got)
+		}
+		rec = serve(http.MethodGet, "/api/v1/logs/presets", "", "sre-token")
+		var list queryPresetsResponse
+		if err := json.NewDecoder(rec.Body).Decode(&list); err != nil {
+			t.Fatalf("failed to decode presets: %v", err)
+		}
+		if len(list.Presets) != 1 {
+			t.Errorf("expected 1 preset, got %+v", list.Presets)
+		}
+		if rec := serve(http.MethodGet, "/api/v1/logs/presets/dev", "", "sre-token"); rec.Code != http.StatusNotFound {
+			t.Errorf("expected 404, got %d", rec.Code)
+		}
+	})
+
+	t.Run("defaults apply to omitted fields", func(t *testing.T) {
+		queries = nil
+		before := time.Now()
+		rec := serve(http.MethodPost, "/api/v1/logs/query", `{"searchScope":{"namespace":"prod"}}`, "sre-token")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if len(queries) == 0 {
+			t.Fatal("expected a query")
+		}
+		q := queries[0]
+		if window := time.Duration(q.EndTime-q.StartTime) * time.Microsecond; window != 15*time.Minute {
+			t.Errorf("expected a 15m window, got %s", window)
+		}
+		if q.EndTime < before.UnixMicro() {
+			t.Errorf("expected the window to end now, got %d", q.EndTime)
+		}
+		if q.Size != 25 || !strings.Contains(q.SQL, "logLevel = 'ERROR'") {
+			t.Errorf("expected the preset limit and levels, got size %d and %s", q.Size, q.SQL)
+		}
+	})
+
+	t.Run("client fields win", func(t *testing.T) {
+		queries = nil
+		body := `{"searchScope":{"namespace":"prod"},"startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-01T06:00:00Z",` +
+			`"limit":5,"logLevels":["WARN"]}`
+		if rec := serve(http.MethodPost, "/api/v1/logs/query", body, "sre-token"); rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		q := queries[0]
+		if q.StartTime != time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).UnixMicro() || q.Size != 5 ||
+			strings.Contains(q.SQL, "'ERROR'") {
+			t.Errorf("expected the client fields to be kept, got %+v", q)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		if rec := serve(http.MethodDelete, "/api/v1/logs/presets/prod", "", "sre-token"); rec.Code != http.StatusNoContent {
+			t.Errorf("expected 204, got %d", rec.Code)
+		}
+		if rec := serve(http.MethodDelete, "/api/v1/logs/presets/prod", "", "sre-token"); rec.Code != http.StatusNotFound {
+			t.Errorf("expected 404, got %d", rec.Code)
+		}
+	})
+}
@@ -0,0 +1,129 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/access"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+// componentPurgeRequest is the request body of
+// POST /api/v1alpha1/components/{componentUid}/purge.
+type componentPurgeRequest struct {
+	Namespace string     `json:"namespace"`
+	StartTime *time.Time `json:"startTime"`
+	EndTime   *time.Time `json:"endTime"`
+	DryRun    bool       `json:"dryRun"`
+}
+
+// componentPurgeResponse is the response body of
+// POST /api/v1alpha1/components/{componentUid}/purge.
+type componentPurgeResponse struct {
+	ComponentUID string                     `json:"componentUid"`
+	Namespace    string                     `json:"namespace"`
+	DryRun       bool                       `json:"dryRun"`
+	Requests     []openobserve.PurgeRequest `json:"requests"`
+}
+
+// PurgeComponentData implements POST /api/v1alpha1/components/{componentUid}/purge.
+// It requests the deletion of the logs and traces of a component, for
+// erasure requests and decommissioned components, and is only available to
+// the admin callers of the access policy. The window defaults to all the
+// records of the component; dryRun only counts them.
+//
+// OpenObserve deletes the records in the background, so the response is
+// 202 with the deletion job of each stream holding records of the
+// component. Purges are logged with their caller.
+func (h *LogsHandler) PurgeComponentData(w http.ResponseWriter, r *http.Request) {
+	caller := callerFromContext(r.Context())
+	if h.access == nil || !h.access.Admin(caller) {
+		writeJSONError(w, http.StatusForbidden, gen.Forbidden, "purges are restricted to admin callers")
+		return
+	}
+
+	var req componentPurgeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "invalid request body")
+		return
+	}
+	componentUID := r.PathValue("componentUid")
+	if strings.TrimSpace(req.Namespace) == "" {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "namespace is required")
+		return
+	}
+	if _, ok := parseUUID(componentUID); !ok {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "componentUid must be a UUID")
+		return
+	}
+
+	params := openobserve.PurgeParams{
+		Namespace:   req.Namespace,
+		ComponentID: componentUID,
+		EndTime:     time.Now(),
+		DryRun:      req.DryRun,
+	}
+	if req.StartTime != nil {
+		params.StartTime = *req.StartTime
+	}
+	if req.EndTime != nil {
+		params.EndTime = *req.EndTime
+	}
+	if params.EndTime.Before(params.StartTime) {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "endTime must be >= startTime")
+		return
+	}
+
+	client, err := h.clientFor(r.Context(), params.Namespace, access.Raw)
+	if err != nil {
+		writeJSONError(w, http.StatusForbidden, gen.Forbidden, err.Error())
+		return
+	}
+
+	requests, err := client.PurgeComponent(r.Context(), params)
+	for _, request := range requests {
+		h.logger.Info("Purged component data",
+			slog.String("caller", caller),
+			slog.String("namespace", params.Namespace),
+			slog.String("componentUid", componentUID),
+			slog.String("stream", request.Stream),
+			slog.Int64("matched", request.Matched),
+			slog.String("status", request.Status),
+			slog.String("jobId", request.JobID),
+		)
+	}
+	if err != nil {
+		h.logger.Error("Failed to purge component data",
+			slog.String("function", "PurgeComponentData"),
+			slog.String("namespace", params.Namespace),
+			slog.String("componentUid", componentUID),
+			slog.Any("error", err),
+		)
+		if errors.Is(err, openobserve.ErrPurgeUnsupported) {
+			writeJSONError(w, http.StatusNotImplemented, gen.InternalServerError,
+				"this OpenObserve version cannot delete records by query")
+			return
+		}
+		writeBackendError(w, err)
+		return
+	}
+
+	status := http.StatusAccepted
+	if req.DryRun {
+		status = http.StatusOK
+	}
+	writeJSON(w, status, componentPurgeResponse{
+		ComponentUID: componentUID,
+		Namespace:    params.Namespace,
+		DryRun:       req.DryRun,
+		Requests:     requests,
+	})
+}
@@ -0,0 +1,97 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openchoreo/community-modules/common/access"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+func TestPurgeComponentData(t *testing.T) {
+	var deletes int
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/delete_by_query") {
+			deletes++
+			w.Write([]byte(`{"id":"job-7"}`))
+			return
+		}
+		w.Write([]byte(`{"took":1,"hits":[{"total":5}]}`))
+	}))
+	defer ooServer.Close()
+
+	t.Setenv("SRE_TOKEN", "sre-token")
+	t.Setenv("DASH_TOKEN", "dash-token")
+	policy, err := access.NewPolicy(access.File{
+		Callers: []access.Caller{
+			{Name: "sre", TokenEnv: "SRE_TOKEN"},
+			{Name: "dashboards", TokenEnv: "DASH_TOKEN"},
+		},
+		Admins: []string{"sre"},
+	})
+	if err != nil {
+		t.Fatalf("NewPolicy() error = %v", err)
+	}
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	handler := NewLogsHandler(client, nil, testLogger())
+	handler.SetAccessPolicy(policy)
+	srv := NewServer("0", handler, testLogger()).httpServer.Handler
+
+	const componentUID = "9f3c2a1e-8b7d-4c6e-a5f4-3b2a1c0d9e8f"
+	post := func(uid, body, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1alpha1/components/"+uid+"/purge", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("admins purge components", func(t *testing.T) {
+		rec := post(componentUID, `{"namespace":"acme"}`, "sre-token")
+		if rec.Code != http.StatusAccepted {
+			t.Fatalf("expected 202, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var got componentPurgeResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if got.ComponentUID != componentUID || len(got.Requests) != 1 || got.Requests[0].JobID != "job-7" || deletes != 1 {
+			t.Errorf("unexpected response %s after %d deletions", rec.Body.String(), deletes)
+		}
+	})
+
+	t.Run("dry runs delete nothing", func(t *testing.T) {
+		deletes = 0
+		rec := post(componentUID, `{"namespace":"acme","dryRun":true}`, "sre-token")
+		if rec.Code != http.StatusOK || deletes != 0 {
+			t.Errorf("expected 200 without deletions, got %d after %d: %s", rec.Code, deletes, rec.Body.String())
+		}
+	})
+
+	t.Run("other callers are rejected", func(t *testing.T) {
+		if rec := post(componentUID, `{"namespace":"acme"}`, "dash-token"); rec.Code != http.StatusForbidden {
+			t.Errorf("expected 403, got %d", rec.Code)
+		}
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		for name, rec := range map[string]*httptest.ResponseRecorder{
+			"no namespace": post(componentUID, `{}`, "sre-token"),
+			"invalid uid":  post("not-a-uid", `{"namespace":"acme"}`, "sre-token"),
+			"empty window": post(componentUID, `{"namespace":"acme","startTime":"2026-02-01T00:00:00Z","endTime":"2026-01-01T00:00:00Z"}`, "sre-token"),
+			"invalid json": post(componentUID, `{`, "sre-token"),
+		} {
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("%s: expected 400, got %d", name, rec.Code)
+			}
+		}
+	})
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/access"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+const (
+	// defaultRawQueryWindow is the lookback used when a raw query request has no startTime.
+	defaultRawQueryWindow = time.Hour
+	// maxRawQueryWindow bounds the window a raw query scans.
+	maxRawQueryWindow = 7 * 24 * time.Hour
+)
+
+// logsRawQueryRequest is the request body of POST /api/v1/logs/raw-query.
+type logsRawQueryRequest struct {
+	SearchScope *gen.ComponentSearchScope `json:"searchScope"`
+	StartTime   *time.Time                `json:"startTime"`
+	EndTime     *time.Time                `json:"endTime"`
+	SQL         string                    `json:"sql"`
+}
+
+// logsRawQueryResponse is the response body of POST /api/v1/logs/raw-query.
+type logsRawQueryResponse struct {
+	Hits       []map[string]interface{} `json:"hits"`
+	TookMs     int                      `json:"tookMs"`
+	QueryStats *queryStats              `json:"queryStats,omitempty"`
+}
+
+// RawQueryLogs implements POST /api/v1/logs/raw-query. It runs a SQL query
+// over the application logs of a scope for power users who need more than
+// the structured filters, and is only available to the admin callers of the
+// access policy.
+//
+// sql must be a single SELECT statement reading from one table, without
+// subqueries, joins, unions or comments. Whatever table it names is replaced
+// with the logs stream of the namespace, and the scope is ANDed with its
+// WHERE clause, so that it cannot read other streams or namespaces. Its LIMIT
+// must not exceed 1000 rows, which is also the number of rows returned when
+// it has none. The window defaults to the last hour and may span at most 7 days.
+func (h *LogsHandler) RawQueryLogs(w http.ResponseWriter, r *http.Request) {
+	caller := callerFromContext(r.Context())
+	if h.access == nil || !h.access.Admin(caller) {
+		writeJSONError(w, http.StatusForbidden, gen.Forbidden, "raw SQL queries are restricted to admin callers")
+		return
+	}
+
+	var req logsRawQueryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "invalid request body")
+		return
+	}
+	if req.SearchScope == nil || strings.TrimSpace(req.SearchScope.Namespace) == "" {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "searchScope with a valid namespace is required")
+		return
+	}
+	if err := openobserve.ValidateRawQuery(req.SQL); err != nil {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "invalid sql: "+err.Error())
+		return
+	}
+
+	params := openobserve.RawQueryParams{
+		Namespace: req.SearchScope.Namespace,
+		EndTime:   time.Now(),
+		SQL:       req.SQL,
+	}
+	if req.EndTime != nil {
+		params.EndTime = *req.EndTime
+	}
+	params.StartTime = params.EndTime.Add(-defaultRawQueryWindow)
+	if req.StartTime != nil {
+		params.StartTime = *req.StartTime
+	}
+	if params.EndTime.Before(params.StartTime) {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "endTime must be >= startTime")
+		return
+	}
+	if params.EndTime.Sub(params.StartTime) > maxRawQueryWindow {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "the window must not exceed 7 days")
+		return
+	}
+	scope := req.SearchScope
+	if scope.ProjectUid != nil {
+		params.ProjectID = *scope.ProjectUid
+	}
+	if scope.EnvironmentUid != nil {
+		params.EnvironmentID = *scope.EnvironmentUid
+	}
+	if scope.ComponentUid != nil {
+		params.ComponentID = *scope.ComponentUid
+	}
+
+	client, err := h.clientFor(r.Context(), params.Namespace, access.Raw)
+	if err != nil {
+		writeJSONError(w, http.StatusForbidden, gen.Forbidden, err.Error())
+		return
+	}
+
+	h.logger.Info("Running raw SQL query",
+		slog.String("caller", caller),
+		slog.String("namespace", params.Namespace),
+		slog.String("sql", params.SQL),
+	)
+	result, err := client.RawQuery(r.Context(), params)
+	if err != nil {
+		h.logger.Error("Failed to run raw SQL query",
+			slog.String("function", "RawQueryLogs"),
+			slog.String("namespace", params.Namespace),
+			slog.Any("error", err),
+		)
+		writeBackendError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, logsRawQueryResponse{
+		Hits:       result.Hits,
+		TookMs:     result.Took,
+		QueryStats: queryStatsFromContext(r.Context()),
+	})
+}
@@ -0,0 +1,121 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openchoreo/community-modules/common/access"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+func TestRawQueryLogs(t *testing.T) {
+	var queries []string
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query struct {
+				SQL  string `json:"sql"`
+				Size int    `json:"size"`
+			} `json:"query"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		queries = append(queries, body.Query.SQL)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(openobserve.OpenObserveResponse{
+			Took: 4,
+			Hits: []map[string]interface{}{{"code": float64(503), "n": float64(9)}},
+		})
+	}))
+	defer ooServer.Close()
+
+	t.Setenv("SRE_TOKEN", "sre-token")
+	t.Setenv("DASH_TOKEN", "dash-token")
+	policy, err := access.NewPolicy(access.File{
+		Callers: []access.Caller{
+			{Name: "sre", TokenEnv: "SRE_TOKEN"},
+			{Name: "dashboards", TokenEnv: "DASH_TOKEN"},
+		},
+		Admins: []string{"sre"},
+	})
+	if err != nil {
+		t.Fatalf("NewPolicy() error = %v", err)
+	}
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	handler := NewLogsHandler(client, nil, testLogger())
+	handler.SetAccessPolicy(policy)
+	srv := NewServer("0", handler, testLogger()).httpServer.Handler
+
+	post := func(body, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/logs/raw-query", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		return rec
+	}
+	const query = `{"searchScope":{"namespace":"default","componentUid":"comp-1"},` +
+		`"sql":"SELECT code, count(*) AS n FROM logs WHERE code >= 500 OR code = 0 GROUP BY code LIMIT 5"}`
+
+	t.Run("admins run scoped queries", func(t *testing.T) {
+		queries = nil
+		rec := post(query, "sre-token")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var got logsRawQueryResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(got.Hits) != 1 || got.Hits[0]["n"] != float64(9) || got.TookMs != 4 {
+			t.Errorf("unexpected response: %s", rec.Body.String())
+		}
+		want := `FROM "default" WHERE (kubernetes_labels_openchoreo_dev_namespace = 'default' AND ` +
+			`kubernetes_labels_openchoreo_dev_component_uid = 'comp-1') AND (code >= 500 OR code = 0) GROUP BY code LIMIT 5`
+		if len(queries) != 1 || !strings.Contains(queries[0], want) {
+			t.Errorf("expected a scoped query, got %v", queries)
+		}
+	})
+
+	t.Run("other callers are rejected", func(t *testing.T) {
+		queries = nil
+		for _, token := range []string{"", "dash-token"} {
+			if rec := post(query, token); rec.Code != http.StatusForbidden {
+				t.Errorf("token %q: expected 403, got %d: %s", token, rec.Code, rec.Body.String())
+			}
+		}
+		if len(queries) != 0 {
+			t.Errorf("expected no queries, got %v", queries)
+		}
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		for name, body := range map[string]string{
+			"malformed body": `{`,
+			"no namespace":   `{"sql":"SELECT * FROM logs"}`,
+			"invalid sql":    `{"searchScope":{"namespace":"default"},"sql":"SELECT * FROM logs; DROP TABLE logs"}`,
+			"limit too high": `{"searchScope":{"namespace":"default"},"sql":"SELECT * FROM logs LIMIT 5000"}`,
+			"window too long": `{"searchScope":{"namespace":"default"},"sql":"SELECT * FROM logs",` +
+				`"startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-09T00:00:00Z"}`,
+		} {
+			if rec := post(body, "sre-token"); rec.Code != http.StatusBadRequest {
+				t.Errorf("%s: expected 400, got %d: %s", name, rec.Code, rec.Body.String())
+			}
+		}
+	})
+
+	t.Run("disabled without an access policy", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		NewLogsHandler(client, nil, testLogger()).RawQueryLogs(rec,
+			httptest.NewRequest(http.MethodPost, "/api/v1/logs/raw-query", strings.NewReader(query)))
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("expected 403, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+}
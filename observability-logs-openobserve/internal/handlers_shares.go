@@ -0,0 +1,175 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/access"
+	"github.com/openchoreo/community-modules/common/shares"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/api/gen"
+)
+
+const (
+	// defaultShareLinkTTL is the lifetime of a share link created without a ttl.
+	defaultShareLinkTTL = time.Hour
+	// sharedViewPathPrefix is the path of share links, followed by the token.
+	sharedViewPathPrefix = "/api/v1/logs/shared/"
+)
+
+// createShareLinkRequest is the request body of POST /api/v1/logs/shares.
+type createShareLinkRequest struct {
+	// Path is the query endpoint, /api/v1/logs/query (the default) or
+	// /api/v1/events/query.
+	Path string `json:"path"`
+	// Query is the request body of the query endpoint.
+	Query json.RawMessage `json:"query"`
+	// TTL is the lifetime of the link as a Go duration, e.g. "30m".
+	TTL string `json:"ttl"`
+}
+
+// shareLinkResponse is the response body of POST /api/v1/logs/shares.
+type shareLinkResponse struct {
+	URL       string    `json:"url"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// CreateShareLink implements POST /api/v1/logs/shares. It signs a query
+// request (scope, time range and filters) into a short-lived link that
+// replays exactly that query when opened, so that a log view can be shared
+// with teammates or in incident tickets. The link carries the query, so
+// nothing is stored, and it cannot be altered without invalidating its
+// signature.
+func (h *LogsHandler) CreateShareLink(w http.ResponseWriter, r *http.Request) {
+	if h.shareSigner == nil {
+		writeJSONError(w, http.StatusNotImplemented, gen.BadRequest, "share links are not configured")
+		return
+	}
+
+	var req createShareLinkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "invalid request body")
+		return
+	}
+	if req.Path == "" {
+		req.Path = "/api/v1/logs/query"
+	}
+	if !isQueryPath(req.Path) {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "path must be /api/v1/logs/query or /api/v1/events/query")
+		return
+	}
+
+	var query struct {
+		StartTime   time.Time `json:"startTime"`
+		EndTime     time.Time `json:"endTime"`
+		SearchScope struct {
+			Namespace string `json:"namespace"`
+		} `json:"searchScope"`
+	}
+	if len(req.Query) == 0 || json.Unmarshal(req.Query, &query) != nil {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "query must be a query request body")
+		return
+	}
+	if strings.TrimSpace(query.SearchScope.Namespace) == "" {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "query.searchScope.namespace is required")
+		return
+	}
+	// Links share a fixed time range, so that they show the same logs to everyone.
+	if query.StartTime.IsZero() || query.EndTime.IsZero() {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "query.startTime and query.endTime are required")
+		return
+	}
+	if _, err := h.clientFor(r.Context(), query.SearchScope.Namespace, access.Raw); err != nil {
+		writeJSONError(w, http.StatusForbidden, gen.Forbidden, err.Error())
+		return
+	}
+
+	ttl := defaultShareLinkTTL
+	if req.TTL != "" {
+		d, err := time.ParseDuration(req.TTL)
+		if err != nil || d <= 0 {
+			writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "ttl must be a positive duration")
+			return
+		}
+		ttl = d
+	}
+	if ttl > h.shareMaxTTL {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "ttl must not exceed "+h.shareMaxTTL.String())
+		return
+	}
+
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, req.Query); err != nil {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "query must be a query request body")
+		return
+	}
+	view := shares.View{
+		Path:      req.Path,
+		Query:     compact.Bytes(),
+		ExpiresAt: time.Now().Add(ttl).UTC().Truncate(time.Second),
+	}
+	token, err := h.shareSigner.Sign(view)
+	if err != nil {
+		h.logger.Error("Failed to sign share link",
+			slog.String("function", "CreateShareLink"),
+			slog.Any("error", err),
+		)
+		writeJSONError(w, http.StatusInternalServerError, gen.InternalServerError, "internal server error")
+		return
+	}
+	writeJSON(w, http.StatusCreated, shareLinkResponse{
+		URL:       sharedViewPathPrefix + token,
+		Token:     token,
+		ExpiresAt: view.ExpiresAt,
+	})
+}
+
+// withSharedViews serves GET /api/v1/logs/shared/{token} by verifying the
+// token with signer and replaying the signed query against its query
+// endpoint, without any other credentials. The tz and humanize parameters
+// and the Accept header still apply, but Prefer is dropped so that the
+// shared view cannot be widened. Invalid and expired links are rejected
+// with 403.
+func withSharedViews(signer *shares.Signer, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.URL.Path, sharedViewPathPrefix)
+		if signer == nil || !ok || r.Method != http.MethodGet {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		view, err := signer.Verify(token)
+		if err != nil {
+			detail := "invalid share link"
+			if errors.Is(err, shares.ErrExpired) {
+				detail = "share link has expired"
+			}
+			writeJSONError(w, http.StatusForbidden, gen.Forbidden, detail)
+			return
+		}
+		if !isQueryPath(view.Path) {
+			writeJSONError(w, http.StatusForbidden, gen.Forbidden, "invalid share link")
+			return
+		}
+
+		replay := r.Clone(r.Context())
+		replay.Method = http.MethodPost
+		replay.URL.Path = view.Path
+		replay.URL.RawPath = ""
+		replay.RequestURI = ""
+		replay.Body = io.NopCloser(bytes.NewReader(view.Query))
+		replay.ContentLength = int64(len(view.Query))
+		replay.Header.Set("Content-Type", "application/json")
+		replay.Header.Del("Prefer")
+		next.ServeHTTP(w, replay)
+	})
+}
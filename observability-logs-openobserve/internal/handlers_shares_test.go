@@ -0,0 +1,130 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/shares"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+func TestShareLinks(t *testing.T) {
+	var searches []string
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query struct {
+				SQL string `json:"sql"`
+			} `json:"query"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		searches = append(searches, body.Query.SQL)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(openobserve.OpenObserveResponse{
+			Hits: []map[string]interface{}{
+				{"_timestamp": float64(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC).UnixMicro()), "log": "shared line"},
+			},
+			Total: 1,
+		})
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	handler := NewLogsHandler(client, nil, testLogger())
+	query := `{"startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-02T00:00:00Z","searchScope":{"namespace":"test-ns"},"searchPhrase":"timeout"}`
+
+	t.Run("not configured", func(t *testing.T) {
+		srv := NewServer("0", handler, testLogger()).httpServer.Handler
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/logs/shares", strings.NewReader(`{"query":`+query+`}`)))
+		if rec.Code != http.StatusNotImplemented {
+			t.Errorf("expected 501, got %d", rec.Code)
+		}
+	})
+
+	signer, err := shares.NewSigner([]byte(strings.Repeat("k", shares.MinKeyLength)))
+	if err != nil {
+		t.Fatalf("NewSigner() error = %v", err)
+	}
+	handler.SetShareSigner(signer, 24*time.Hour)
+	srv := NewServer("0", handler, testLogger()).httpServer.Handler
+
+	var link shareLinkResponse
+	t.Run("create", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/logs/shares", strings.NewReader(`{"query":`+query+`,"ttl":"30m"}`)))
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if err := json.NewDecoder(rec.Body).Decode(&link); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if link.URL != sharedViewPathPrefix+link.Token {
+			t.Errorf("unexpected url %q", link.URL)
+		}
+		if d := time.Until(link.ExpiresAt); d <= 29*time.Minute || d > 30*time.Minute {
+			t.Errorf("unexpected expiry %v", link.ExpiresAt)
+		}
+	})
+
+	t.Run("open", func(t *testing.T) {
+		searches = nil
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, link.URL, nil)
+		req.Header.Set("Prefer", "max-results=1")
+		srv.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if !strings.Contains(rec.Body.String(), "shared line") {
+			t.Errorf("expected the shared logs, got %s", rec.Body.String())
+		}
+		if rec.Header().Get("Preference-Applied") != "" {
+			t.Error("expected Prefer to be ignored on shared views")
+		}
+		if len(searches) == 0 || !strings.Contains(searches[0], "timeout") || !strings.Contains(searches[0], "test-ns") {
+			t.Errorf("expected the shared query to be replayed, got %v", searches)
+		}
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, link.URL+"x", nil))
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("expected 403, got %d", rec.Code)
+		}
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		token, _ := signer.Sign(shares.View{Path: "/api/v1/logs/query", Query: json.RawMessage(query), ExpiresAt: time.Now().Add(-time.Minute)})
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, sharedViewPathPrefix+token, nil))
+		if rec.Code != http.StatusForbidden || !strings.Contains(rec.Body.String(), "expired") {
+			t.Errorf("expected 403 expired, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	for name, body := range map[string]string{
+		"invalid body":        `{`,
+		"unsupported path":    `{"path":"/api/v1/logs/holds","query":` + query + `}`,
+		"missing query":       `{}`,
+		"missing namespace":   `{"query":{"startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-02T00:00:00Z"}}`,
+		"missing time range":  `{"query":{"searchScope":{"namespace":"test-ns"}}}`,
+		"invalid ttl":         `{"query":` + query + `,"ttl":"soon"}`,
+		"ttl above the limit": `{"query":` + query + `,"ttl":"48h"}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			srv.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/logs/shares", strings.NewReader(body)))
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected 400, got %d: %s", rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/access"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+// defaultLogSourcesWindow is the lookback used when a log sources request has no startTime.
+const defaultLogSourcesWindow = 24 * time.Hour
+
+// logSourcesResponse is the response body of GET /api/v1/logs/sources.
+type logSourcesResponse struct {
+	Sources    []openobserve.LogSource `json:"sources"`
+	TookMs     int                     `json:"tookMs"`
+	QueryStats *queryStats             `json:"queryStats,omitempty"`
+}
+
+// ListLogSources implements GET /api/v1/logs/sources. It lists the
+// components and environments of a namespace that produced logs in a time
+// window so that clients can tell which components have data before querying.
+//
+// Query parameters: namespace (required), projectUid, environmentUid, and
+// startTime/endTime in RFC 3339 format. The window defaults to the 24 hours
+// before endTime, and endTime defaults to now.
+func (h *LogsHandler) ListLogSources(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	params := openobserve.LogSourcesParams{
+		Namespace:     strings.TrimSpace(query.Get("namespace")),
+		ProjectID:     query.Get("projectUid"),
+		EnvironmentID: query.Get("environmentUid"),
+	}
+	if params.Namespace == "" {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "namespace is required")
+		return
+	}
+	start, end, err := parseTimeWindow(query, defaultLogSourcesWindow)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, err.Error())
+		return
+	}
+	params.StartTime, params.EndTime = start, end
+
+	client, err := h.clientFor(r.Context(), params.Namespace, access.Aggregates)
+	if err != nil {
+		writeJSONError(w, http.StatusForbidden, gen.Forbidden, err.Error())
+		return
+	}
+
+	result, err := client.GetLogSources(r.Context(), params)
+	if err != nil {
+		h.logger.Error("Failed to query log sources",
+			slog.String("function", "ListLogSources"),
+			slog.String("namespace", params.Namespace),
+			slog.Any("error", err),
+		)
+		writeBackendError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, logSourcesResponse{
+		Sources:    result.Sources,
+		TookMs:     result.Took,
+		QueryStats: queryStatsFromContext(r.Context()),
+	})
+}
+
+// parseTimeWindow reads the startTime and endTime query parameters (RFC 3339).
+// endTime defaults to now and startTime to defaultWindow before endTime.
+func parseTimeWindow(query url.Values, defaultWindow time.Duration) (time.Time, time.Time, error) {
+	end := time.Now()
+	if v := query.Get("endTime"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("endTime must be an RFC 3339 timestamp")
+		}
+		end = t
+	}
+	start := end.Add(-defaultWindow)
+	if v := query.Get("startTime"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("startTime must be an RFC 3339 timestamp")
+		}
+		start = t
+	}
+	if end.Before(start) {
+		return time.Time{}, time.Time{}, fmt.Errorf("endTime must be >= startTime")
+	}
+	return start, end, nil
+}
+
+// writeJSON writes v as a JSON response body with the given status code.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeJSONError writes an ErrorResponse in the same shape as the generated handlers.
+func writeJSONError(w http.ResponseWriter, status int, title gen.ErrorResponseTitle, message string) {
+	writeJSON(w, status, gen.ErrorResponse{Title: &title, Message: &message})
+}
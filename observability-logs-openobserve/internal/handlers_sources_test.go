@@ -0,0 +1,96 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+func TestListLogSources(t *testing.T) {
+	lastSeen := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	var gotSQL string
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query struct {
+				SQL string `json:"sql"`
+			} `json:"query"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		gotSQL = body.Query.SQL
+		resp := openobserve.OpenObserveResponse{
+			Took: 4,
+			Hits: []map[string]interface{}{
+				{
+					"component_uid":    "comp-1",
+					"component_name":   "api",
+					"environment_uid":  "env-1",
+					"environment_name": "dev",
+					"last_seen":        float64(lastSeen.UnixMicro()),
+					"log_count":        float64(42),
+				},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	handler := NewLogsHandler(client, nil, testLogger())
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/logs/sources?namespace=test-ns&projectUid=proj-1&startTime=2025-01-01T00:00:00Z&endTime=2025-01-02T00:00:00Z", nil)
+		rec := httptest.NewRecorder()
+		handler.ListLogSources(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if !strings.Contains(gotSQL, "kubernetes_labels_openchoreo_dev_project_uid = 'proj-1'") {
+			t.Errorf("expected project filter in SQL: %s", gotSQL)
+		}
+
+		var resp logSourcesResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if resp.TookMs != 4 || len(resp.Sources) != 1 {
+			t.Fatalf("unexpected response: %+v", resp)
+		}
+		source := resp.Sources[0]
+		if source.ComponentName != "api" || source.EnvironmentName != "dev" || source.LogCount != 42 {
+			t.Errorf("unexpected source: %+v", source)
+		}
+		if !source.LastSeen.Equal(lastSeen) {
+			t.Errorf("expected lastSeen %v, got %v", lastSeen, source.LastSeen)
+		}
+	})
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"missing namespace", ""},
+		{"invalid startTime", "namespace=ns&startTime=yesterday"},
+		{"invalid endTime", "namespace=ns&endTime=now"},
+		{"inverted window", "namespace=ns&startTime=2025-01-02T00:00:00Z&endTime=2025-01-01T00:00:00Z"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/logs/sources?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			handler.ListLogSources(rec, req)
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected 400, got %d", rec.Code)
+			}
+		})
+	}
+}
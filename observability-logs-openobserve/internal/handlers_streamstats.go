@@ -0,0 +1,86 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"log/slog"
+	"net/http"
+	"slices"
+
+	"github.com/openchoreo/community-modules/common/access"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+// streamStatsResponse is the response body of GET /api/v1/logs/streams/stats.
+type streamStatsResponse struct {
+	*openobserve.StreamStatsResult
+	QueryStats *queryStats `json:"queryStats,omitempty"`
+}
+
+// GetStreamStats implements GET /api/v1/logs/streams/stats. It returns the
+// fields of the logs, events and traces streams with their type, approximate
+// cardinality, null ratio and sample values over the last day, so that users
+// and support can see what is queryable in the installation. Statistics are
+// computed on first use and cached for a day.
+//
+// The namespace query parameter selects the streams of the tenant of a
+// namespace in multi-tenant mode; it defaults to the namespace of the
+// tenancy header. Only the admin callers of the access policy may leave it
+// out and read the streams of the default organization. Sample values hold
+// raw log content: they are only returned to the admin callers, so no caller
+// receives them when no access policy is configured.
+func (h *LogsHandler) GetStreamStats(w http.ResponseWriter, r *http.Request) {
+	admin := h.access != nil && h.access.Admin(callerFromContext(r.Context()))
+	client := h.client
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		namespace, _ = tenancyFromContext(r.Context())
+	}
+	if namespace == "" && !admin {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "namespace is required")
+		return
+	}
+	if namespace != "" {
+		var err error
+		client, err = h.clientFor(r.Context(), namespace, access.Aggregates)
+		if err != nil {
+			writeJSONError(w, http.StatusForbidden, gen.Forbidden, err.Error())
+			return
+		}
+	}
+
+	result, err := client.GetStreamStats(r.Context())
+	if err != nil {
+		h.logger.Error("Failed to get stream statistics",
+			slog.String("function", "GetStreamStats"),
+			slog.Any("error", err),
+		)
+		writeBackendError(w, err)
+		return
+	}
+
+	if !admin {
+		result = withoutSamples(result)
+	}
+	writeJSON(w, http.StatusOK, streamStatsResponse{
+		StreamStatsResult: result,
+		QueryStats:        queryStatsFromContext(r.Context()),
+	})
+}
+
+// withoutSamples returns a copy of result without sample values. result may
+// be cached and is left unchanged.
+func withoutSamples(result *openobserve.StreamStatsResult) *openobserve.StreamStatsResult {
+	stripped := *result
+	stripped.Streams = slices.Clone(result.Streams)
+	for i := range stripped.Streams {
+		fields := slices.Clone(stripped.Streams[i].Fields)
+		for j := range fields {
+			fields[j].Samples = nil
+		}
+		stripped.Streams[i].Fields = fields
+	}
+	return &stripped
+}
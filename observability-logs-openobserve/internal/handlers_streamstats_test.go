@@ -0,0 +1,92 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openchoreo/community-modules/common/access"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+func TestGetStreamStats(t *testing.T) {
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/schema") {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"schema": []map[string]string{{"name": "log", "type": "Utf8"}},
+			})
+			return
+		}
+		json.NewEncoder(w).Encode(openobserve.OpenObserveResponse{
+			Hits: []map[string]interface{}{{"total": float64(10), "c0": float64(10), "d0": float64(7), "log": "card 4111-1111"}},
+		})
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "", "admin", "pass", testLogger())
+	serve := func(handler *LogsHandler, query, token string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/logs/streams/stats"+query, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		NewServer("0", handler, testLogger()).httpServer.Handler.ServeHTTP(rec, req)
+		return rec
+	}
+	get := func(handler *LogsHandler, query, token string) streamStatsResponse {
+		t.Helper()
+		rec := serve(handler, query, token)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var got streamStatsResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		return got
+	}
+
+	t.Run("no samples without an access policy", func(t *testing.T) {
+		handler := NewLogsHandler(client, nil, testLogger())
+		got := get(handler, "?namespace=default", "")
+		if len(got.Streams) != 1 || len(got.Streams[0].Fields) != 1 {
+			t.Fatalf("unexpected response: %+v", got)
+		}
+		if f := got.Streams[0].Fields[0]; f.Cardinality != 7 || f.Samples != nil {
+			t.Errorf("expected statistics without samples, got %+v", f)
+		}
+		if rec := serve(handler, "", ""); rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400 without a namespace, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("samples only for admins", func(t *testing.T) {
+		t.Setenv("SRE_TOKEN", "sre-token")
+		policy, err := access.NewPolicy(access.File{
+			Callers: []access.Caller{{Name: "sre", TokenEnv: "SRE_TOKEN"}},
+			Admins:  []string{"sre"},
+		})
+		if err != nil {
+			t.Fatalf("NewPolicy() error = %v", err)
+		}
+		handler := NewLogsHandler(client, nil, testLogger())
+		handler.SetAccessPolicy(policy)
+
+		if f := get(handler, "?namespace=default", "").Streams[0].Fields[0]; f.Cardinality != 7 || f.Samples != nil {
+			t.Errorf("expected no samples for anonymous callers, got %+v", f)
+		}
+		if rec := serve(handler, "", ""); rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400 without a namespace for anonymous callers, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if f := get(handler, "", "sre-token").Streams[0].Fields[0]; len(f.Samples) != 1 {
+			t.Errorf("expected samples for admins, got %+v", f)
+		}
+	})
+}
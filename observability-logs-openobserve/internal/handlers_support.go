@@ -0,0 +1,152 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/diagnostics"
+)
+
+// supportProbeTimeout bounds each health probe of a support bundle.
+const supportProbeTimeout = 5 * time.Second
+
+// supportManifest is the manifest.json of a support bundle.
+type supportManifest struct {
+	GeneratedAt time.Time    `json:"generatedAt"`
+	Files       []bundleFile `json:"files"`
+}
+
+// healthProbe is the result of a health probe of a support bundle.
+type healthProbe struct {
+	Target     string `json:"target"`
+	URL        string `json:"url"`
+	Healthy    bool   `json:"healthy"`
+	StatusCode int    `json:"statusCode,omitempty"`
+	DurationMs int64  `json:"durationMs"`
+	Error      string `json:"error,omitempty"`
+}
+
+// slowQueries is the slow-queries.json of a support bundle.
+type slowQueries struct {
+	ThresholdMs int64                     `json:"thresholdMs"`
+	Calls       []diagnostics.BackendCall `json:"calls"`
+}
+
+// SetDiagnostics serves support bundles with the recent failures recorded
+// in journal and config, the adapter configuration with its secrets
+// redacted. The clients must report their calls to journal.
+func (h *LogsHandler) SetDiagnostics(journal *diagnostics.Journal, config map[string]any) {
+	h.diagnostics = journal
+	h.redactedConfig = config
+}
+
+// GetSupportBundle implements GET /api/v1alpha1/support-bundle. It gathers
+// what a bug report needs into a single zip archive:
+//
+//	version.json           the build of the adapter
+//	config.json            the configuration, with secrets redacted
+//	health.json            the result of a health probe of OpenObserve
+//	slow-queries.json      the recent OpenObserve calls slower than the threshold
+//	failed-requests.json   the recent requests that failed, without their content
+//	manifest.json          the generation time and the SHA-256 checksum of each file
+//
+// It is only available to the admin callers of the access policy.
+func (h *LogsHandler) GetSupportBundle(w http.ResponseWriter, r *http.Request) {
+	if h.access == nil || !h.access.Admin(callerFromContext(r.Context())) {
+		writeJSONError(w, http.StatusForbidden, gen.Forbidden, "support bundles are restricted to admin callers")
+		return
+	}
+	if h.diagnostics == nil {
+		writeJSONError(w, http.StatusNotFound, gen.NotFound, "support bundles are not enabled")
+		return
+	}
+
+	manifest := &supportManifest{GeneratedAt: time.Now().UTC().Truncate(time.Second), Files: []bundleFile{}}
+	// The files are written with the incident bundle writer, whose manifest
+	// only collects their list here.
+	var archive bytes.Buffer
+	b := &bundleWriter{zw: zip.NewWriter(&archive), manifest: &bundleManifest{GeneratedAt: manifest.GeneratedAt}}
+
+	sections := []struct {
+		name  string
+		value any
+	}{
+		{"version.json", diagnostics.ReadVersion()},
+		{"config.json", h.redactedConfig},
+		{"health.json", []healthProbe{h.probeOpenObserve(r.Context())}},
+		{"slow-queries.json", slowQueries{
+			ThresholdMs: h.diagnostics.SlowThreshold().Milliseconds(),
+			Calls:       h.diagnostics.SlowCalls(),
+		}},
+		{"failed-requests.json", h.diagnostics.FailedRequests()},
+	}
+	var err error
+	for _, section := range sections {
+		var data []byte
+		if data, err = json.MarshalIndent(section.value, "", "  "); err != nil {
+			break
+		}
+		if err = b.write(section.name, data, 1, false); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		manifest.Files = b.manifest.Files
+		var data []byte
+		if data, err = json.MarshalIndent(manifest, "", "  "); err == nil {
+			err = b.write("manifest.json", data, 1, false)
+		}
+	}
+	if err == nil {
+		err = b.zw.Close()
+	}
+	if err != nil {
+		h.logger.Error("Failed to write support bundle", slog.String("function", "GetSupportBundle"), slog.Any("error", err))
+		writeJSONError(w, http.StatusInternalServerError, gen.InternalServerError, "internal server error")
+		return
+	}
+
+	filename := fmt.Sprintf("support-bundle-%s.zip", manifest.GeneratedAt.Format("20060102T150405Z"))
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	w.Header().Set("Content-Length", strconv.Itoa(archive.Len()))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(archive.Bytes())
+}
+
+// probeOpenObserve checks the health endpoint of OpenObserve.
+func (h *LogsHandler) probeOpenObserve(ctx context.Context) healthProbe {
+	probe := healthProbe{Target: "openobserve", URL: h.client.BaseURL() + "/healthz"}
+	ctx, cancel := context.WithTimeout(ctx, supportProbeTimeout)
+	defer cancel()
+
+	start := time.Now()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, probe.URL, nil)
+	if err != nil {
+		probe.Error = err.Error()
+		return probe
+	}
+	resp, err := h.client.Do(req)
+	probe.DurationMs = time.Since(start).Milliseconds()
+	if err != nil {
+		probe.Error = err.Error()
+		return probe
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	probe.StatusCode = resp.StatusCode
+	probe.Healthy = resp.StatusCode == http.StatusOK
+	return probe
+}
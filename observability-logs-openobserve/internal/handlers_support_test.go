@@ -0,0 +1,134 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/access"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/diagnostics"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+func TestGetSupportBundle(t *testing.T) {
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/healthz" {
+			w.Write([]byte(`{"status":"ok"}`))
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ooServer.Close()
+
+	t.Setenv("SRE_TOKEN", "sre-token")
+	t.Setenv("DASH_TOKEN", "dash-token")
+	policy, err := access.NewPolicy(access.File{
+		Callers: []access.Caller{
+			{Name: "sre", TokenEnv: "SRE_TOKEN"},
+			{Name: "dashboards", TokenEnv: "DASH_TOKEN"},
+		},
+		Admins: []string{"sre"},
+	})
+	if err != nil {
+		t.Fatalf("NewPolicy() error = %v", err)
+	}
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	journal := diagnostics.NewJournal(time.Nanosecond, diagnostics.DefaultCapacity)
+	client.AddRequestObserver(journal.ObserveBackend)
+	handler := NewLogsHandler(client, nil, testLogger())
+	handler.SetAccessPolicy(policy)
+	handler.SetDiagnostics(journal, diagnostics.RedactConfig(&Config{OpenObserveURL: ooServer.URL, OpenObservePassword: "hunter2"}))
+	srv := NewServer("0", handler, testLogger()).httpServer.Handler
+
+	get := func(path, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := get("/api/v1alpha1/support-bundle", "dash-token"); rec.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for a non-admin caller, got %d", rec.Code)
+	}
+
+	rec := get("/api/v1alpha1/support-bundle", "sre-token")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if got := rec.Header().Get("Content-Type"); got != "application/zip" {
+		t.Errorf("unexpected content type %q", got)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(rec.Body.Bytes()), int64(rec.Body.Len()))
+	if err != nil {
+		t.Fatalf("failed to open bundle: %v", err)
+	}
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("failed to open %s: %v", f.Name, err)
+		}
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		files[f.Name] = string(data)
+	}
+
+	if strings.Contains(files["config.json"], "hunter2") || !strings.Contains(files["config.json"], `"OpenObservePassword": "[REDACTED]"`) {
+		t.Errorf("expected the password to be redacted: %s", files["config.json"])
+	}
+	var health []healthProbe
+	if err := json.Unmarshal([]byte(files["health.json"]), &health); err != nil || len(health) != 1 || !health[0].Healthy {
+		t.Errorf("expected a healthy OpenObserve probe, got %s", files["health.json"])
+	}
+	var slow slowQueries
+	if err := json.Unmarshal([]byte(files["slow-queries.json"]), &slow); err != nil || len(slow.Calls) == 0 || slow.Calls[0].Path != "/healthz" {
+		t.Errorf("expected the health probe among the slow calls, got %s", files["slow-queries.json"])
+	}
+	// The forbidden request above failed.
+	var failed []diagnostics.FailedRequest
+	if err := json.Unmarshal([]byte(files["failed-requests.json"]), &failed); err != nil || len(failed) != 1 ||
+		failed[0].Route != "/api/v1alpha1/support-bundle" || failed[0].Status != http.StatusForbidden {
+		t.Errorf("expected the forbidden request, got %s", files["failed-requests.json"])
+	}
+	var manifest supportManifest
+	if err := json.Unmarshal([]byte(files["manifest.json"]), &manifest); err != nil || len(manifest.Files) != 5 {
+		t.Errorf("expected the 5 other files in the manifest, got %s", files["manifest.json"])
+	}
+	if _, ok := files["version.json"]; !ok {
+		t.Error("expected version.json in the bundle")
+	}
+}
+
+func TestGetSupportBundle_NotEnabled(t *testing.T) {
+	t.Setenv("SRE_TOKEN", "sre-token")
+	policy, err := access.NewPolicy(access.File{
+		Callers: []access.Caller{{Name: "sre", TokenEnv: "SRE_TOKEN"}},
+		Admins:  []string{"sre"},
+	})
+	if err != nil {
+		t.Fatalf("NewPolicy() error = %v", err)
+	}
+	handler := NewLogsHandler(openobserve.NewClient("http://localhost", "default", "default", "k8s_events", "admin", "pass", testLogger()), nil, testLogger())
+	handler.SetAccessPolicy(policy)
+	srv := NewServer("0", handler, testLogger()).httpServer.Handler
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1alpha1/support-bundle", nil)
+	req.Header.Set("Authorization", "Bearer sre-token")
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 without diagnostics, got %d", rec.Code)
+	}
+}
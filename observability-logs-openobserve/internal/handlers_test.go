@@ -19,8 +19,10 @@ import (
 	openapi_types "github.com/oapi-codegen/runtime/types"
 
 	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/formats"
 	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/observer"
 	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/tenants"
 )
 
 func testLogger() *slog.Logger {
@@ -252,6 +254,24 @@ func TestToComponentLogsParams(t *testing.T) {
 	}
 }
 
+func TestToComponentLogsParams_Environments(t *testing.T) {
+	var req gen.LogsQueryRequest
+	body := `{"startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-02T00:00:00Z",` +
+		`"searchScope":{"namespace":"test-ns","componentUid":"comp-1","environmentUids":["env-dev","env-prod"]}}`
+	if err := json.Unmarshal([]byte(body), &req); err != nil {
+		t.Fatal(err)
+	}
+	scope, err := req.SearchScope.AsComponentSearchScope()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	params := toComponentLogsParams(&req, &scope)
+	if len(params.EnvironmentIDs) != 2 || params.EnvironmentIDs[0] != "env-dev" || params.EnvironmentIDs[1] != "env-prod" {
+		t.Errorf("expected environmentIDs [env-dev env-prod], got %v", params.EnvironmentIDs)
+	}
+}
+
 func TestToWorkflowLogsParams(t *testing.T) {
 	startTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
 	endTime := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
@@ -266,6 +286,10 @@ func TestToWorkflowLogsParams(t *testing.T) {
 		WorkflowRunName: &workflowRunName,
 	}
 
+	if err := req.SearchScope.UnmarshalJSON([]byte(`{"namespace":"test-ns","workflowRunName":"run-1","stepName":"build","podName":"run-1-build-1"}`)); err != nil {
+		t.Fatalf("failed to build scope: %v", err)
+	}
+
 	params := toWorkflowLogsParams(req, scope)
 
 	if params.Namespace != "test-ns" {
@@ -274,6 +298,9 @@ func TestToWorkflowLogsParams(t *testing.T) {
 	if params.WorkflowRunName != "run-1" {
 		t.Errorf("expected workflowRunName 'run-1', got %q", params.WorkflowRunName)
 	}
+	if params.StepName != "build" || params.PodName != "run-1-build-1" {
+		t.Errorf("expected stepName and podName from the search scope, got %q and %q", params.StepName, params.PodName)
+	}
 	if !params.StartTime.Equal(startTime) {
 		t.Errorf("expected startTime %v, got %v", startTime, params.StartTime)
 	}
@@ -375,14 +402,58 @@ func TestToLogsQueryResponse(t *testing.T) {
 	}
 }
 
+func TestToLogsQueryResponse_LogFormat(t *testing.T) {
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(openobserve.OpenObserveResponse{
+			Total: 1,
+			Hits: []map[string]interface{}{{
+				"_timestamp": float64(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC).UnixMicro()),
+				"log":        "2025-01-01T12:00:00.000Z  WARN 1 --- [           main] c.e.demo.Orders : slow query, retrying after ERROR",
+				"kubernetes_labels_openchoreo_dev_namespace": "test-ns",
+			}},
+		})
+	}))
+	defer ooServer.Close()
+
+	set, err := formats.NewSet(formats.Names(), nil)
+	if err != nil {
+		t.Fatalf("NewSet() error = %v", err)
+	}
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	client.SetFormats(set)
+	result, err := client.GetComponentLogs(context.Background(), openobserve.ComponentLogsParams{Namespace: "test-ns"})
+	if err != nil {
+		t.Fatalf("GetComponentLogs() error = %v", err)
+	}
+
+	raw, err := json.Marshal(toLogsQueryResponse(result))
+	if err != nil {
+		t.Fatalf("failed to marshal response: %v", err)
+	}
+	for _, want := range []string{
+		`"level":"WARN","log"`,
+		`"metadata":{`,
+		`"namespaceName":"test-ns"`,
+		`"logFormat":"springboot"`,
+		`"logger":"c.e.demo.Orders"`,
+		`"thread":"main"`,
+	} {
+		if !strings.Contains(string(raw), want) {
+			t.Errorf("expected %s in %s", want, raw)
+		}
+	}
+}
+
 func TestToWorkflowLogsQueryResponse(t *testing.T) {
 	result := &openobserve.WorkflowLogsResult{
 		TotalCount: 1,
 		Took:       10,
 		Logs: []openobserve.WorkflowLogsEntry{
 			{
-				Timestamp: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
-				Log:       "workflow log",
+				Timestamp:        time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+				Log:              "workflow log",
+				LogLevel:         "INFO",
+				LogLevelInferred: true,
 			},
 		},
 	}
@@ -395,6 +466,10 @@ func TestToWorkflowLogsQueryResponse(t *testing.T) {
 	if resp.TookMs == nil || *resp.TookMs != 10 {
 		t.Errorf("expected tookMs 10, got %v", resp.TookMs)
 	}
+	body, _ := json.Marshal(resp)
+	if !strings.Contains(string(body), `"logLevel":"INFO","logLevelInferred":true`) {
+		t.Errorf("expected the inferred level in the entry, got %s", body)
+	}
 }
 
 func TestToComponentLogEntry(t *testing.T) {
@@ -542,6 +617,136 @@ func TestQueryLogs_ComponentScope(t *testing.T) {
 	}
 }
 
+func TestQueryLogs_SortField(t *testing.T) {
+	var gotSQL string
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query struct {
+				SQL string `json:"sql"`
+			} `json:"query"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if !strings.Contains(body.Query.SQL, "count(*)") {
+			gotSQL = body.Query.SQL
+		}
+		resp := openobserve.OpenObserveResponse{
+			Hits: []map[string]interface{}{
+				{
+					"_timestamp": float64(time.Date(2025, 1, 1, 12, 0, 5, 0, time.UTC).UnixMicro()),
+					"date":       float64(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC).Unix()),
+					"log":        "late log line",
+				},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	handler := NewLogsHandler(client, nil, testLogger())
+
+	scope := gen.LogsQueryRequest_SearchScope{}
+	_ = scope.FromComponentSearchScope(gen.ComponentSearchScope{Namespace: "test-ns"})
+	body := &gen.LogsQueryRequest{
+		StartTime:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndTime:     time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+		SearchScope: scope,
+	}
+
+	t.Run("event time ordering", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), queryExtensionsKey, queryExtensions{SortField: "eventTime"})
+		resp, err := handler.QueryLogs(ctx, gen.QueryLogsRequestObject{Body: body})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		okResp, ok := resp.(gen.QueryLogs200JSONResponse)
+		if !ok {
+			t.Fatalf("expected 200 response, got %T", resp)
+		}
+		if !strings.Contains(gotSQL, "ORDER BY date DESC") {
+			t.Errorf("expected event time ordering, got SQL: %s", gotSQL)
+		}
+
+		raw, err := json.Marshal(okResp)
+		if err != nil {
+			t.Fatalf("failed to marshal response: %v", err)
+		}
+		if !strings.Contains(string(raw), `"eventTime":"2025-01-01T12:00:00Z"`) {
+			t.Errorf("expected eventTime in response: %s", raw)
+		}
+		if !strings.Contains(string(raw), `"ingestTime":"2025-01-01T12:00:05Z"`) {
+			t.Errorf("expected ingestTime in response: %s", raw)
+		}
+	})
+
+	t.Run("invalid sort field", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), queryExtensionsKey, queryExtensions{SortField: "bogus"})
+		resp, err := handler.QueryLogs(ctx, gen.QueryLogsRequestObject{Body: body})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, ok := resp.(gen.QueryLogs400JSONResponse); !ok {
+			t.Fatalf("expected 400 response, got %T", resp)
+		}
+	})
+}
+
+func TestQueryLogs_SearchQuery(t *testing.T) {
+	var gotSQL string
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query struct {
+				SQL string `json:"sql"`
+			} `json:"query"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if !strings.Contains(body.Query.SQL, "count(*)") {
+			gotSQL = body.Query.SQL
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(openobserve.OpenObserveResponse{Hits: []map[string]interface{}{}})
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	handler := NewLogsHandler(client, nil, testLogger())
+
+	scope := gen.LogsQueryRequest_SearchScope{}
+	_ = scope.FromComponentSearchScope(gen.ComponentSearchScope{Namespace: "test-ns"})
+	body := &gen.LogsQueryRequest{
+		StartTime:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndTime:     time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+		SearchScope: scope,
+	}
+
+	t.Run("filters with the query", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), queryExtensionsKey, queryExtensions{Query: `level:error AND "connection refused" NOT pod:canary`})
+		resp, err := handler.QueryLogs(ctx, gen.QueryLogsRequestObject{Body: body})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, ok := resp.(gen.QueryLogs200JSONResponse); !ok {
+			t.Fatalf("expected 200 response, got %T", resp)
+		}
+		want := `(logLevel = 'ERROR' AND log LIKE '%connection refused%' AND NOT kubernetes_pod_name = 'canary')`
+		if !strings.Contains(gotSQL, want) {
+			t.Errorf("expected %s in SQL: %s", want, gotSQL)
+		}
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), queryExtensionsKey, queryExtensions{Query: `(level:error`})
+		resp, err := handler.QueryLogs(ctx, gen.QueryLogsRequestObject{Body: body})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, ok := resp.(gen.QueryLogs400JSONResponse); !ok {
+			t.Fatalf("expected 400 response, got %T", resp)
+		}
+	})
+}
+
 func TestCreateAlertRule_Success(t *testing.T) {
 	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -609,6 +814,96 @@ func TestCreateAlertRule_Success(t *testing.T) {
 	}
 }
 
+func TestCreateAlertRule_Severity(t *testing.T) {
+	var gotConfig struct {
+		Destinations      []string          `json:"destinations"`
+		ContextAttributes map[string]string `json:"context_attributes"`
+	}
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&gotConfig)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]string{"id": "alert-123"})
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	handler := NewLogsHandler(client, nil, testLogger())
+	handler.SetAlertDestinations(map[string][]string{
+		"critical": {"openchoreo", "pagerduty"},
+		"info":     {"openchoreo"},
+	})
+
+	var body gen.AlertRuleRequest
+	if err := json.Unmarshal([]byte(`{
+		"metadata": {"name": "test-alert", "namespace": "ns-1",
+			"projectUid": "550e8400-e29b-41d4-a716-446655440000",
+			"environmentUid": "550e8400-e29b-41d4-a716-446655440001",
+			"componentUid": "550e8400-e29b-41d4-a716-446655440002"},
+		"source": {"query": "error"},
+		"condition": {"enabled": true, "operator": "gt", "threshold": 5, "window": "5m", "interval": "1m"}
+	}`), &body); err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+
+	t.Run("critical pages", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), alertRuleExtensionsKey, alertRuleExtensions{Severity: "critical"})
+		resp, err := handler.CreateAlertRule(ctx, gen.CreateAlertRuleRequestObject{Body: &body})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, ok := resp.(gen.CreateAlertRule201JSONResponse); !ok {
+			t.Fatalf("expected 201 response, got %T", resp)
+		}
+		if strings.Join(gotConfig.Destinations, ",") != "openchoreo,pagerduty" {
+			t.Errorf("unexpected destinations %v", gotConfig.Destinations)
+		}
+		if gotConfig.ContextAttributes["severity"] != "critical" {
+			t.Errorf("expected severity context attribute, got %v", gotConfig.ContextAttributes)
+		}
+	})
+
+	t.Run("unset severity uses default destination", func(t *testing.T) {
+		if _, err := handler.CreateAlertRule(context.Background(), gen.CreateAlertRuleRequestObject{Body: &body}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if strings.Join(gotConfig.Destinations, ",") != openobserve.DefaultAlertDestination {
+			t.Errorf("unexpected destinations %v", gotConfig.Destinations)
+		}
+	})
+
+	t.Run("invalid severity", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), alertRuleExtensionsKey, alertRuleExtensions{Severity: "urgent"})
+		resp, _ := handler.CreateAlertRule(ctx, gen.CreateAlertRuleRequestObject{Body: &body})
+		if _, ok := resp.(gen.CreateAlertRule400JSONResponse); !ok {
+			t.Errorf("expected 400 response, got %T", resp)
+		}
+	})
+
+	t.Run("composite conditions", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), alertRuleExtensionsKey, alertRuleExtensions{
+			Conditions: []openobserve.AlertCondition{{Source: "events", Query: "BackOff", Operator: "gt", Threshold: 2}},
+		})
+		resp, _ := handler.CreateAlertRule(ctx, gen.CreateAlertRuleRequestObject{Body: &body})
+		if _, ok := resp.(gen.CreateAlertRule201JSONResponse); !ok {
+			t.Fatalf("expected 201 response, got %T", resp)
+		}
+		if !strings.Contains(gotConfig.ContextAttributes["conditions"], `"match":"all"`) {
+			t.Errorf("expected stored conditions, got %v", gotConfig.ContextAttributes)
+		}
+	})
+
+	t.Run("invalid condition", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), alertRuleExtensionsKey, alertRuleExtensions{
+			Conditions: []openobserve.AlertCondition{{Query: "panic", Operator: "above"}},
+		})
+		resp, _ := handler.CreateAlertRule(ctx, gen.CreateAlertRuleRequestObject{Body: &body})
+		if _, ok := resp.(gen.CreateAlertRule400JSONResponse); !ok {
+			t.Errorf("expected 400 response, got %T", resp)
+		}
+	})
+}
+
 func TestDeleteAlertRule_Success(t *testing.T) {
 	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch {
@@ -1603,3 +1898,73 @@ func isEventsCountQuery(r *http.Request) bool {
 	sql, _ := q["sql"].(string)
 	return size == 0 && strings.Contains(strings.ToLower(sql), "count")
 }
+
+func TestMultiTenantIsolation(t *testing.T) {
+	t.Setenv("TENANT_A_PASSWORD", "a-secret")
+	t.Setenv("TENANT_B_PASSWORD", "b-secret")
+
+	newBackend := func(name string, hits *int) *httptest.Server {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*hits++
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(openobserve.OpenObserveResponse{
+				Hits: []map[string]interface{}{{"_timestamp": float64(1735732800000000), "log": "from " + name}},
+			})
+		}))
+		t.Cleanup(server.Close)
+		return server
+	}
+	var hitsA, hitsB, hitsDefault int
+	backendA, backendB, backendDefault := newBackend("a", &hitsA), newBackend("b", &hitsB), newBackend("default", &hitsDefault)
+
+	registry, err := tenants.NewRegistry([]tenants.Tenant{
+		{Name: "a", Namespaces: []string{"ns-a"}, URL: backendA.URL, Org: "a", User: "a", PasswordEnv: "TENANT_A_PASSWORD"},
+		{Name: "b", Namespaces: []string{"ns-b"}, URL: backendB.URL, Org: "b", User: "b", PasswordEnv: "TENANT_B_PASSWORD"},
+	}, tenants.Defaults{Stream: "default", EventsStream: "k8s_events"}, testLogger())
+	if err != nil {
+		t.Fatalf("NewRegistry() error = %v", err)
+	}
+	client := openobserve.NewClient(backendDefault.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	handler := NewLogsHandler(client, nil, testLogger())
+	handler.SetTenants(registry)
+
+	queryLogs := func(namespace string) gen.QueryLogsResponseObject {
+		scope := gen.LogsQueryRequest_SearchScope{}
+		if err := scope.FromComponentSearchScope(gen.ComponentSearchScope{Namespace: namespace}); err != nil {
+			t.Fatalf("failed to build scope: %v", err)
+		}
+		resp, err := handler.QueryLogs(context.Background(), gen.QueryLogsRequestObject{Body: &gen.LogsQueryRequest{
+			SearchScope: scope,
+			StartTime:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			EndTime:     time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+		}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return resp
+	}
+
+	resp, ok := queryLogs("ns-a").(gen.QueryLogs200JSONResponse)
+	if !ok {
+		t.Fatalf("expected 200 response for tenant a")
+	}
+	if body, _ := json.Marshal(resp); !strings.Contains(string(body), "from a") {
+		t.Fatalf("expected the logs of tenant a, got %s", body)
+	}
+	if hitsA == 0 || hitsB != 0 || hitsDefault != 0 {
+		t.Errorf("expected only tenant a's backend to be queried, got a=%d b=%d default=%d", hitsA, hitsB, hitsDefault)
+	}
+
+	if _, ok := queryLogs("ns-c").(gen.QueryLogs403JSONResponse); !ok {
+		t.Error("expected 403 for a namespace without a tenant")
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ListLogSources(rec, httptest.NewRequest(http.MethodGet, "/api/v1/logs/sources?namespace=ns-c", nil))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("expected 403 from log sources, got %d", rec.Code)
+	}
+	if hitsB != 0 || hitsDefault != 0 {
+		t.Errorf("expected no queries outside tenant a, got b=%d default=%d", hitsB, hitsDefault)
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/access"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+// defaultWorkflowSummaryWindow is the lookback used when a workflow summary
+// or workflow volume request has no startTime.
+const defaultWorkflowSummaryWindow = 24 * time.Hour
+
+// workflowVolumeResponse is the response body of GET /api/v1/workflows/volume.
+type workflowVolumeResponse struct {
+	IntervalSeconds int                                  `json:"intervalSeconds"`
+	Pipelines       []openobserve.WorkflowPipelineVolume `json:"pipelines"`
+	TookMs          int                                  `json:"tookMs"`
+	QueryStats      *queryStats                          `json:"queryStats,omitempty"`
+}
+
+// GetWorkflowSummary implements GET /api/v1/workflows/{workflowRunName}/summary.
+// It returns the log and error line counts, the first error line and the
+// duration of each step of a workflow run, inferred from its logs, for a CI
+// run overview that does not download the full logs.
+//
+// Query parameters: namespace (required) and startTime/endTime in RFC 3339
+// format (default: the last 24 hours).
+func (h *LogsHandler) GetWorkflowSummary(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	params := openobserve.WorkflowSummaryParams{
+		Namespace:       strings.TrimSpace(query.Get("namespace")),
+		WorkflowRunName: r.PathValue("workflowRunName"),
+	}
+	if params.Namespace == "" {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "namespace is required")
+		return
+	}
+	start, end, err := parseTimeWindow(query, defaultWorkflowSummaryWindow)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, err.Error())
+		return
+	}
+	params.StartTime, params.EndTime = start, end
+
+	client, err := h.clientFor(r.Context(), params.Namespace, access.Aggregates)
+	if err != nil {
+		writeJSONError(w, http.StatusForbidden, gen.Forbidden, err.Error())
+		return
+	}
+
+	result, err := client.GetWorkflowSummary(r.Context(), params)
+	if err != nil {
+		h.logger.Error("Failed to summarize workflow run",
+			slog.String("function", "GetWorkflowSummary"),
+			slog.String("namespace", params.Namespace),
+			slog.String("workflowRunName", params.WorkflowRunName),
+			slog.Any("error", err),
+		)
+		writeBackendError(w, err)
+		return
+	}
+	if len(result.Steps) == 0 {
+		writeJSONError(w, http.StatusNotFound, gen.NotFound, "no logs found for the workflow run")
+		return
+	}
+	if h.aggregationOnly(r.Context(), params.Namespace) {
+		for i := range result.Steps {
+			result.Steps[i].FirstError = ""
+		}
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// GetWorkflowVolume implements GET /api/v1/workflows/volume. It returns the
+// number of log lines and error lines of the workflow runs of a namespace
+// over time, per workflow template, so that build infrastructure owners can
+// spot noisy or failing pipelines.
+//
+// Query parameters: namespace (required), startTime/endTime in RFC 3339
+// format (default: the last 24 hours) and interval as a Go duration (default:
+// the window split into about 60 buckets, at least 10s).
+func (h *LogsHandler) GetWorkflowVolume(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	params := openobserve.WorkflowVolumeParams{
+		Namespace: strings.TrimSpace(query.Get("namespace")),
+	}
+	if params.Namespace == "" {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "namespace is required")
+		return
+	}
+	start, end, err := parseTimeWindow(query, defaultWorkflowSummaryWindow)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, err.Error())
+		return
+	}
+	params.StartTime, params.EndTime = start, end
+
+	params.Interval, err = levelHistogramInterval(query.Get("interval"), end.Sub(start))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, err.Error())
+		return
+	}
+
+	client, err := h.clientFor(r.Context(), params.Namespace, access.Aggregates)
+	if err != nil {
+		writeJSONError(w, http.StatusForbidden, gen.Forbidden, err.Error())
+		return
+	}
+
+	result, err := client.GetWorkflowVolume(r.Context(), params)
+	if err != nil {
+		h.logger.Error("Failed to query workflow log volume",
+			slog.String("function", "GetWorkflowVolume"),
+			slog.String("namespace", params.Namespace),
+			slog.Any("error", err),
+		)
+		writeBackendError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, workflowVolumeResponse{
+		IntervalSeconds: int(params.Interval / time.Second),
+		Pipelines:       result.Pipelines,
+		TookMs:          result.Took,
+		QueryStats:      queryStatsFromContext(r.Context()),
+	})
+}
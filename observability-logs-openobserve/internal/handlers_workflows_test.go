@@ -0,0 +1,126 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+func TestGetWorkflowSummary(t *testing.T) {
+	var hits []map[string]interface{}
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(openobserve.OpenObserveResponse{Hits: hits})
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	handler := NewLogsHandler(client, nil, testLogger())
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/workflows/{workflowRunName}/summary", handler.GetWorkflowSummary)
+
+	get := func(url string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+		return rec
+	}
+
+	t.Run("success", func(t *testing.T) {
+		hits = []map[string]interface{}{
+			{"node_name": "run-1.build", "log_count": float64(12), "error_count": float64(0)},
+		}
+		rec := get("/api/v1/workflows/run-1/summary?namespace=test-ns")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var got openobserve.WorkflowSummaryResult
+		if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if got.WorkflowRunName != "run-1" || len(got.Steps) != 1 || got.Steps[0].StepName != "build" {
+			t.Errorf("unexpected summary: %+v", got)
+		}
+	})
+
+	t.Run("unknown run", func(t *testing.T) {
+		hits = nil
+		if rec := get("/api/v1/workflows/run-2/summary?namespace=test-ns"); rec.Code != http.StatusNotFound {
+			t.Errorf("expected 404, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	for name, url := range map[string]string{
+		"missing namespace":  "/api/v1/workflows/run-1/summary",
+		"invalid start time": "/api/v1/workflows/run-1/summary?namespace=test-ns&startTime=yesterday",
+	} {
+		t.Run(name, func(t *testing.T) {
+			if rec := get(url); rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "badRequest") {
+				t.Errorf("expected 400, got %d: %s", rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestGetWorkflowVolume(t *testing.T) {
+	var sql string
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query struct {
+				SQL string `json:"sql"`
+			} `json:"query"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		sql = body.Query.SQL
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(openobserve.OpenObserveResponse{Hits: []map[string]interface{}{
+			{"bucket": "2026-03-01T12:00:00", "pipeline": "docker-build", "log_count": float64(12), "error_count": float64(2)},
+		}})
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	handler := NewLogsHandler(client, nil, testLogger())
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/workflows/volume", handler.GetWorkflowVolume)
+
+	get := func(url string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+		return rec
+	}
+
+	t.Run("success", func(t *testing.T) {
+		rec := get("/api/v1/workflows/volume?namespace=test-ns&startTime=2026-03-01T12:00:00Z&endTime=2026-03-01T13:00:00Z&interval=5m")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var got workflowVolumeResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if got.IntervalSeconds != 300 || len(got.Pipelines) != 1 || got.Pipelines[0].Pipeline != "docker-build" || got.Pipelines[0].ErrorCount != 2 {
+			t.Errorf("unexpected volume: %+v", got)
+		}
+		if !strings.Contains(sql, "'workflows-test-ns'") {
+			t.Errorf("expected the workflows namespace in the query, got %s", sql)
+		}
+	})
+
+	for name, url := range map[string]string{
+		"missing namespace": "/api/v1/workflows/volume",
+		"invalid interval":  "/api/v1/workflows/volume?namespace=test-ns&interval=soon",
+	} {
+		t.Run(name, func(t *testing.T) {
+			if rec := get(url); rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "badRequest") {
+				t.Errorf("expected 400, got %d: %s", rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
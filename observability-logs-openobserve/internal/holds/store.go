@@ -0,0 +1,146 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package holds keeps the register of legal holds: immutable snapshots of
+// logs taken for e-discovery. The register is a JSON file on local disk so
+// that it survives restarts of the adapter.
+package holds
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ErrNotFound is returned when no hold has the requested ID.
+var ErrNotFound = errors.New("hold not found")
+
+// Hold is a registered legal hold and the state of its snapshot.
+type Hold struct {
+	ID             string    `json:"id"`
+	Name           string    `json:"name"`
+	Reason         string    `json:"reason,omitempty"`
+	Namespace      string    `json:"namespace"`
+	ProjectUID     string    `json:"projectUid,omitempty"`
+	EnvironmentUID string    `json:"environmentUid,omitempty"`
+	ComponentUID   string    `json:"componentUid,omitempty"`
+	StartTime      time.Time `json:"startTime"`
+	EndTime        time.Time `json:"endTime"`
+	CreatedAt      time.Time `json:"createdAt"`
+
+	// Prefix is the object storage key prefix holding the snapshot.
+	Prefix string `json:"prefix"`
+	// ExportJobID is the export job that writes the snapshot.
+	ExportJobID string `json:"exportJobId"`
+	// Status is the status of the snapshot export.
+	Status      string     `json:"status"`
+	Records     int        `json:"records"`
+	Manifest    string     `json:"manifest,omitempty"`
+	Error       string     `json:"error,omitempty"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+}
+
+// FileStore is a Hold register persisted to a JSON file. Every change
+// rewrites the file through a temporary file and a rename so that a crash
+// never leaves a truncated register behind.
+type FileStore struct {
+	path string
+
+	mu    sync.Mutex
+	holds []Hold
+}
+
+// NewFileStore opens the register at path, creating it on first write.
+func NewFileStore(path string) (*FileStore, error) {
+	s := &FileStore{path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read hold store: %w", err)
+	}
+	if err := json.Unmarshal(data, &s.holds); err != nil {
+		return nil, fmt.Errorf("failed to parse hold store %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Create registers a new hold. The ID must be unique.
+func (s *FileStore) Create(hold Hold) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.indexLocked(hold.ID) >= 0 {
+		return fmt.Errorf("hold %s already exists", hold.ID)
+	}
+	return s.saveLocked(append(slices.Clone(s.holds), hold))
+}
+
+// Update applies fn to the hold with the given ID and persists the result.
+func (s *FileStore) Update(id string, fn func(hold *Hold)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.indexLocked(id)
+	if i < 0 {
+		return ErrNotFound
+	}
+	holds := slices.Clone(s.holds)
+	fn(&holds[i])
+	return s.saveLocked(holds)
+}
+
+// Get returns the hold with the given ID.
+func (s *FileStore) Get(id string) (Hold, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.indexLocked(id)
+	if i < 0 {
+		return Hold{}, ErrNotFound
+	}
+	return s.holds[i], nil
+}
+
+// List returns all holds in creation order.
+func (s *FileStore) List() []Hold {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.holds)
+}
+
+func (s *FileStore) indexLocked(id string) int {
+	return slices.IndexFunc(s.holds, func(h Hold) bool { return h.ID == id })
+}
+
+// saveLocked writes holds to disk and, on success, makes them the current state.
+func (s *FileStore) saveLocked(holds []Hold) error {
+	data, err := json.MarshalIndent(holds, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode hold store: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to write hold store: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write hold store: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write hold store: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write hold store: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to write hold store: %w", err)
+	}
+	s.holds = holds
+	return nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package holds
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "holds.json")
+	s, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+	if got := s.List(); len(got) != 0 {
+		t.Fatalf("expected empty store, got %v", got)
+	}
+
+	hold := Hold{ID: "h1", Name: "case-42", Namespace: "ns", CreatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	if err := s.Create(hold); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := s.Create(hold); err == nil {
+		t.Error("expected error for duplicate hold")
+	}
+	if err := s.Update("h1", func(h *Hold) { h.Status = "succeeded"; h.Records = 10 }); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if err := s.Update("missing", func(*Hold) {}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	reopened, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+	got, err := reopened.Get("h1")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Name != "case-42" || got.Status != "succeeded" || got.Records != 10 {
+		t.Errorf("unexpected persisted hold: %+v", got)
+	}
+	if _, err := reopened.Get("missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("expected only the store file, found %d entries", len(entries))
+	}
+}
+
+func TestNewFileStore_Corrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "holds.json")
+	if err := os.WriteFile(path, []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewFileStore(path); err == nil {
+		t.Error("expected error for corrupt store")
+	}
+}
@@ -0,0 +1,464 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package loki serves the logs API from Grafana Loki: component and workflow
+// log queries in LogQL, and alert rules through the Loki ruler. It takes and
+// returns the types of the OpenObserve client, so that the handlers serve
+// either backend.
+package loki
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+// ErrUnavailable is returned when Loki could not be reached. It is the
+// error of an unavailable OpenObserve, so that callers handle both alike.
+var ErrUnavailable = openobserve.ErrBackendUnavailable
+
+// ErrUnsupported is returned for the parameters of queries and alert rules
+// that Loki cannot serve.
+var ErrUnsupported = errors.New("not supported by the Loki backend")
+
+// defaultLimit matches the default limit of the OpenObserve queries.
+const defaultLimit = 100
+
+// Labels of the workflow pods, as flattened by the Fluent Bit pipeline.
+const (
+	workflowLabel         = "kubernetes_labels_workflows_argoproj_io_workflow"
+	workflowNodeNameLabel = "kubernetes_annotations_workflows_argoproj_io_node_name"
+)
+
+// labelNamePattern matches valid LogQL label names.
+var labelNamePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// Client queries the logs of a Loki tenant and manages its alert rules.
+type Client struct {
+	baseURL    string
+	tenantID   string
+	user       string
+	password   string
+	httpClient *http.Client
+	// fields maps the filtered fields to Loki labels, either stream labels
+	// or the fields of the JSON log lines.
+	fields openobserve.FieldMapping
+	// rulerNamespace is the ruler namespace holding the alert rules.
+	rulerNamespace string
+	logger         *slog.Logger
+}
+
+// NewClient returns a client of the Loki instance at baseURL. tenantID is
+// sent as the X-Scope-OrgID header when set.
+func NewClient(baseURL, tenantID string, logger *slog.Logger) *Client {
+	return &Client{
+		baseURL:        strings.TrimSuffix(baseURL, "/"),
+		tenantID:       tenantID,
+		httpClient:     &http.Client{Timeout: 30 * time.Second},
+		fields:         openobserve.DefaultFieldMapping,
+		rulerNamespace: DefaultRulerNamespace,
+		logger:         logger,
+	}
+}
+
+// SetBasicAuth authenticates the requests to Loki with user and password,
+// for instances behind an authenticating gateway.
+func (c *Client) SetBasicAuth(user, password string) {
+	c.user = user
+	c.password = password
+}
+
+// SetFieldMapping sets the labels the filtered fields are read from.
+func (c *Client) SetFieldMapping(fields openobserve.FieldMapping) error {
+	for _, label := range []string{fields.Level, fields.Namespace, fields.ProjectUID, fields.EnvironmentUID,
+		fields.ComponentUID, fields.PodName, fields.PodNamespace} {
+		if !labelNamePattern.MatchString(label) {
+			return fmt.Errorf("invalid Loki label %q", label)
+		}
+	}
+	c.fields = fields
+	return nil
+}
+
+// SetRulerNamespace sets the ruler namespace holding the alert rules.
+func (c *Client) SetRulerNamespace(namespace string) {
+	c.rulerNamespace = namespace
+}
+
+// CheckHealth returns an error unless Loki reports itself ready.
+func (c *Client) CheckHealth(ctx context.Context) error {
+	body, err := c.do(ctx, http.MethodGet, "/ready", nil, "")
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(string(body)) != "ready" {
+		return fmt.Errorf("loki is not ready: %s", body)
+	}
+	return nil
+}
+
+// GetComponentLogs retrieves the application logs of a component scope.
+// Loki has no offset, so only the first page of a query is served.
+func (c *Client) GetComponentLogs(ctx context.Context, params openobserve.ComponentLogsParams) (*openobserve.ComponentLogsResult, error) {
+	if err := checkPaging(params.Offset, params.SortField); err != nil {
+		return nil, err
+	}
+	if params.Namespace == "" {
+		return nil, fmt.Errorf("namespace is required for component log queries")
+	}
+	query := c.componentLogsQuery(params)
+	streams, took, err := c.queryRange(ctx, query, params.StartTime, params.EndTime, params.Limit, params.SortOrder)
+	if err != nil {
+		return nil, err
+	}
+	total, err := c.count(ctx, query, params.StartTime, params.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &openobserve.ComponentLogsResult{Logs: []openobserve.ComponentLogsEntry{}, TotalCount: total, Took: took}
+	for _, l := range sortLines(streams, params.Limit, params.SortOrder) {
+		entry := openobserve.ComponentLogsEntry{
+			Timestamp:       l.timestamp,
+			Log:             l.log(),
+			LogLevel:        strings.TrimSpace(l.labels[c.fields.Level]),
+			ComponentUID:    l.labels[c.fields.ComponentUID],
+			ComponentName:   l.labels[c.fields.ComponentName],
+			EnvironmentUID:  l.labels[c.fields.EnvironmentUID],
+			EnvironmentName: l.labels[c.fields.EnvironmentName],
+			ProjectUID:      l.labels[c.fields.ProjectUID],
+			ProjectName:     l.labels[c.fields.ProjectName],
+			Namespace:       l.labels[c.fields.Namespace],
+			PodName:         l.labels[c.fields.PodName],
+			PodNamespace:    l.labels[c.fields.PodNamespace],
+			ContainerName:   l.labels[c.fields.ContainerName],
+		}
+		if entry.LogLevel == "" {
+			entry.LogLevel = openobserve.ExtractLogLevel(entry.Log)
+		}
+		result.Logs = append(result.Logs, entry)
+	}
+	return result, nil
+}
+
+// GetWorkflowLogs retrieves the logs of a workflow run. Loki has no offset,
+// so only the first page of a query is served.
+func (c *Client) GetWorkflowLogs(ctx context.Context, params openobserve.WorkflowLogsParams) (*openobserve.WorkflowLogsResult, error) {
+	if err := checkPaging(params.Offset, params.SortField); err != nil {
+		return nil, err
+	}
+	if params.Namespace == "" {
+		return nil, fmt.Errorf("namespace is required for workflow log queries")
+	}
+	query := c.workflowLogsQuery(params)
+	streams, took, err := c.queryRange(ctx, query, params.StartTime, params.EndTime, params.Limit, params.SortOrder)
+	if err != nil {
+		return nil, err
+	}
+	total, err := c.count(ctx, query, params.StartTime, params.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &openobserve.WorkflowLogsResult{Logs: []openobserve.WorkflowLogsEntry{}, TotalCount: total, Took: took}
+	for _, l := range sortLines(streams, params.Limit, params.SortOrder) {
+		result.Logs = append(result.Logs, openobserve.WorkflowLogsEntry{
+			Timestamp: l.timestamp,
+			Log:       l.log(),
+		})
+	}
+	return result, nil
+}
+
+// checkPaging returns ErrUnsupported for the paging and ordering that Loki
+// cannot serve: offsets and orders other than by the time of the entries.
+func checkPaging(offset int, sortField string) error {
+	if offset > 0 {
+		return fmt.Errorf("offset: %w", ErrUnsupported)
+	}
+	if sortField != "" && sortField != openobserve.SortFieldIngestTime {
+		return fmt.Errorf("sort field %q: %w", sortField, ErrUnsupported)
+	}
+	return nil
+}
+
+// componentLogsQuery returns the LogQL query of the log lines of a
+// component scope. The namespace label selects the streams; the other
+// fields are read from the stream labels or the JSON log lines.
+func (c *Client) componentLogsQuery(params openobserve.ComponentLogsParams) string {
+	query := "{" + c.fields.Namespace + "=" + strconv.Quote(params.Namespace) + "}"
+	if params.SearchPhrase != "" {
+		query += " |= " + strconv.Quote(params.SearchPhrase)
+	}
+	query += " | json"
+	if params.ProjectID != "" {
+		query += " | " + c.fields.ProjectUID + "=" + strconv.Quote(params.ProjectID)
+	}
+	if environments := environmentIDs(params); len(environments) > 0 {
+		query += " | " + anyOf(c.fields.EnvironmentUID, environments)
+	}
+	if len(params.ComponentIDs) > 0 {
+		query += " | " + anyOf(c.fields.ComponentUID, params.ComponentIDs)
+	}
+	if len(params.LogLevels) > 0 {
+		query += " | " + anyOf(c.fields.Level, params.LogLevels)
+	}
+	return query
+}
+
+// workflowLogsQuery returns the LogQL query of the log lines of a workflow
+// run, read from the pods of the workflows-<namespace> namespace.
+func (c *Client) workflowLogsQuery(params openobserve.WorkflowLogsParams) string {
+	query := "{" + c.fields.PodNamespace + "=" + strconv.Quote("workflows-"+params.Namespace) + "}"
+	if params.SearchPhrase != "" {
+		query += " |= " + strconv.Quote(params.SearchPhrase)
+	}
+	query += " | json"
+	if params.WorkflowRunName != "" {
+		query += " | " + workflowLabel + "=" + strconv.Quote(params.WorkflowRunName)
+	}
+	if params.StepName != "" {
+		// Argo names the nodes of steps "<workflow>.<step>", with a "(n)"
+		// suffix for retries.
+		step := regexp.QuoteMeta(params.StepName)
+		query += " | " + workflowNodeNameLabel + "=~" + strconv.Quote(`.*\.`+step+`(\(.*)?`)
+	}
+	if params.PodName != "" {
+		query += " | " + c.fields.PodName + "=" + strconv.Quote(params.PodName)
+	}
+	if len(params.LogLevels) > 0 {
+		query += " | " + anyOf(c.fields.Level, params.LogLevels)
+	}
+	return query
+}
+
+// environmentIDs returns the distinct environments of a component query, or
+// nil when it covers every environment.
+func environmentIDs(params openobserve.ComponentLogsParams) []string {
+	var ids []string
+	for _, id := range append([]string{params.EnvironmentID}, params.EnvironmentIDs...) {
+		if id == openobserve.AllEnvironments {
+			return nil
+		}
+		if id != "" && !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// anyOf returns the label filter matching any of values.
+func anyOf(label string, values []string) string {
+	filters := make([]string, len(values))
+	for i, value := range values {
+		filters[i] = label + "=" + strconv.Quote(value)
+	}
+	return strings.Join(filters, " or ")
+}
+
+// stream is a stream of a query_range response: its labels, including the
+// labels extracted by the query, and its lines.
+type stream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+// queryResponse is the response of the Loki query APIs.
+type queryResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+		Stats      struct {
+			Summary struct {
+				ExecTime float64 `json:"execTime"`
+			} `json:"summary"`
+		} `json:"stats"`
+	} `json:"data"`
+}
+
+// queryRange runs a logs query over [start, end] and returns its streams
+// and the time Loki took in milliseconds.
+func (c *Client) queryRange(ctx context.Context, query string, start, end time.Time, limit int, sortOrder string) ([]stream, int, error) {
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	direction := "backward"
+	if strings.EqualFold(sortOrder, "asc") {
+		direction = "forward"
+	}
+	values := url.Values{}
+	values.Set("query", query)
+	values.Set("start", strconv.FormatInt(start.UnixNano(), 10))
+	values.Set("end", strconv.FormatInt(end.UnixNano(), 10))
+	values.Set("limit", strconv.Itoa(limit))
+	values.Set("direction", direction)
+
+	if c.logger.Enabled(ctx, slog.LevelDebug) {
+		c.logger.Debug("Generated LogQL query", slog.String("query", query))
+	}
+	var resp queryResponse
+	if err := c.getJSON(ctx, "/loki/api/v1/query_range?"+values.Encode(), &resp); err != nil {
+		return nil, 0, err
+	}
+	if resp.Data.ResultType != "streams" {
+		return nil, 0, fmt.Errorf("unexpected result type %q of a logs query", resp.Data.ResultType)
+	}
+	var streams []stream
+	if err := json.Unmarshal(resp.Data.Result, &streams); err != nil {
+		return nil, 0, fmt.Errorf("failed to unmarshal streams: %w", err)
+	}
+	return streams, int(resp.Data.Stats.Summary.ExecTime * 1000), nil
+}
+
+// count returns the number of log lines matching a logs query over
+// [start, end], counted by Loki.
+func (c *Client) count(ctx context.Context, query string, start, end time.Time) (int, error) {
+	seconds := int64(end.Sub(start).Seconds())
+	if seconds < 1 {
+		seconds = 1
+	}
+	values := url.Values{}
+	values.Set("query", fmt.Sprintf("sum(count_over_time(%s [%ds]))", query, seconds))
+	values.Set("time", strconv.FormatInt(end.UnixNano(), 10))
+
+	var resp queryResponse
+	if err := c.getJSON(ctx, "/loki/api/v1/query?"+values.Encode(), &resp); err != nil {
+		return 0, err
+	}
+	var samples []struct {
+		Value [2]interface{} `json:"value"`
+	}
+	if err := json.Unmarshal(resp.Data.Result, &samples); err != nil {
+		return 0, fmt.Errorf("failed to unmarshal the count of log lines: %w", err)
+	}
+	if len(samples) == 0 {
+		return 0, nil
+	}
+	value, _ := samples[0].Value[1].(string)
+	count, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid count of log lines %q", value)
+	}
+	return int(count), nil
+}
+
+// line is a log line of a query_range response with the labels of its stream.
+type line struct {
+	timestamp time.Time
+	raw       string
+	labels    map[string]string
+}
+
+// log returns the log field extracted from a JSON line, or the raw line.
+func (l line) log() string {
+	if log, ok := l.labels["log"]; ok {
+		return log
+	}
+	return l.raw
+}
+
+// sortLines merges the lines of streams in the order of the query, newest
+// first unless sortOrder is asc, and keeps the first limit.
+func sortLines(streams []stream, limit int, sortOrder string) []line {
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	var lines []line
+	for _, s := range streams {
+		for _, value := range s.Values {
+			ns, err := strconv.ParseInt(value[0], 10, 64)
+			if err != nil {
+				continue
+			}
+			lines = append(lines, line{timestamp: time.Unix(0, ns).UTC(), raw: value[1], labels: s.Stream})
+		}
+	}
+	asc := strings.EqualFold(sortOrder, "asc")
+	slices.SortStableFunc(lines, func(a, b line) int {
+		if asc {
+			return a.timestamp.Compare(b.timestamp)
+		}
+		return b.timestamp.Compare(a.timestamp)
+	})
+	if len(lines) > limit {
+		lines = lines[:limit]
+	}
+	return lines
+}
+
+// getJSON runs a GET request of Loki and decodes its JSON response into v.
+func (c *Client) getJSON(ctx context.Context, path string, v interface{}) error {
+	body, err := c.do(ctx, http.MethodGet, path, nil, "")
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return nil
+}
+
+// StatusError is returned for the responses of Loki with an unexpected
+// status code.
+type StatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("loki returned status %d: %s", e.StatusCode, e.Body)
+}
+
+// do runs a request of Loki and returns the body of its 2xx response.
+func (c *Client) do(ctx context.Context, method, path string, payload io.Reader, contentType string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.tenantID != "" {
+		req.Header.Set("X-Scope-OrgID", c.tenantID)
+	}
+	if c.user != "" {
+		req.SetBasicAuth(c.user, c.password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		c.logger.Error("Loki returned error",
+			slog.Int("statusCode", resp.StatusCode),
+			slog.String("body", string(body)))
+		if resp.StatusCode == http.StatusBadGateway || resp.StatusCode == http.StatusServiceUnavailable || resp.StatusCode == http.StatusGatewayTimeout {
+			return nil, fmt.Errorf("%w: status %d", ErrUnavailable, resp.StatusCode)
+		}
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(body))}
+	}
+	return body, nil
+}
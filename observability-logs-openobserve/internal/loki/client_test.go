@@ -0,0 +1,265 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loki
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+func newTestClient(serverURL string) *Client {
+	return NewClient(serverURL, "tenant-a", testLogger())
+}
+
+func TestComponentLogsQuery(t *testing.T) {
+	client := newTestClient("http://loki")
+	query := client.componentLogsQuery(openobserve.ComponentLogsParams{
+		Namespace:      "acme",
+		SearchPhrase:   `say "hi"`,
+		ProjectID:      "p1",
+		EnvironmentID:  "e1",
+		EnvironmentIDs: []string{"e1", "e2"},
+		ComponentIDs:   []string{"c1"},
+		LogLevels:      []string{"ERROR", "WARN"},
+	})
+	want := `{kubernetes_labels_openchoreo_dev_namespace="acme"} |= "say \"hi\"" | json` +
+		` | kubernetes_labels_openchoreo_dev_project_uid="p1"` +
+		` | kubernetes_labels_openchoreo_dev_environment_uid="e1" or kubernetes_labels_openchoreo_dev_environment_uid="e2"` +
+		` | kubernetes_labels_openchoreo_dev_component_uid="c1"` +
+		` | logLevel="ERROR" or logLevel="WARN"`
+	if query != want {
+		t.Errorf("componentLogsQuery() =\n%s\nwant\n%s", query, want)
+	}
+
+	query = client.componentLogsQuery(openobserve.ComponentLogsParams{
+		Namespace:     "acme",
+		EnvironmentID: openobserve.AllEnvironments,
+	})
+	if query != `{kubernetes_labels_openchoreo_dev_namespace="acme"} | json` {
+		t.Errorf("expected no environment filter across environments, got %s", query)
+	}
+}
+
+func TestWorkflowLogsQuery(t *testing.T) {
+	query := newTestClient("http://loki").workflowLogsQuery(openobserve.WorkflowLogsParams{
+		Namespace:       "acme",
+		WorkflowRunName: "build-1",
+		StepName:        "push",
+	})
+	want := `{kubernetes_namespace_name="workflows-acme"} | json` +
+		` | kubernetes_labels_workflows_argoproj_io_workflow="build-1"` +
+		` | kubernetes_annotations_workflows_argoproj_io_node_name=~".*\\.push(\\(.*)?"`
+	if query != want {
+		t.Errorf("workflowLogsQuery() =\n%s\nwant\n%s", query, want)
+	}
+}
+
+func TestGetComponentLogs(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Scope-OrgID") != "tenant-a" {
+			t.Errorf("expected the tenant header, got %q", r.Header.Get("X-Scope-OrgID"))
+		}
+		if user, password, ok := r.BasicAuth(); !ok || user != "reader" || password != "secret" {
+			t.Errorf("expected basic auth, got %q %q", user, password)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/loki/api/v1/query_range":
+			q := r.URL.Query()
+			if q.Get("limit") != "2" || q.Get("direction") != "backward" || q.Get("start") != "1767268800000000000" {
+				t.Errorf("unexpected query_range parameters %v", q)
+			}
+			w.Write([]byte(`{"status":"success","data":{"resultType":"streams","result":[` +
+				`{"stream":{"kubernetes_labels_openchoreo_dev_component_uid":"c1","logLevel":"INFO","log":"started"},` +
+				`"values":[["1767268860000000000","{\"log\":\"started\"}"]]},` +
+				`{"stream":{"kubernetes_labels_openchoreo_dev_component_uid":"c1"},` +
+				`"values":[["1767268920000000000","ERROR failed"],["1767268800000000000","older"]]}],` +
+				`"stats":{"summary":{"execTime":0.012}}}}`))
+		case "/loki/api/v1/query":
+			if !strings.HasPrefix(r.URL.Query().Get("query"), "sum(count_over_time({") ||
+				!strings.HasSuffix(r.URL.Query().Get("query"), " [3600s]))") {
+				t.Errorf("unexpected count query %q", r.URL.Query().Get("query"))
+			}
+			w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1767272400,"42"]}]}}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	client.SetBasicAuth("reader", "secret")
+	result, err := client.GetComponentLogs(context.Background(), openobserve.ComponentLogsParams{
+		Namespace: "acme",
+		StartTime: start,
+		EndTime:   start.Add(time.Hour),
+		Limit:     2,
+		SortOrder: "desc",
+	})
+	if err != nil {
+		t.Fatalf("GetComponentLogs() error = %v", err)
+	}
+	if result.TotalCount != 42 || result.Took != 12 || len(result.Logs) != 2 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	if result.Logs[0].Log != "ERROR failed" || result.Logs[0].LogLevel != "ERROR" {
+		t.Errorf("expected the newest line with its level extracted first, got %+v", result.Logs[0])
+	}
+	if result.Logs[1].Log != "started" || result.Logs[1].LogLevel != "INFO" || result.Logs[1].ComponentUID != "c1" {
+		t.Errorf("expected the log field of the JSON line, got %+v", result.Logs[1])
+	}
+}
+
+func TestGetComponentLogs_Unsupported(t *testing.T) {
+	client := newTestClient("http://loki")
+	_, err := client.GetComponentLogs(context.Background(), openobserve.ComponentLogsParams{Namespace: "acme", Offset: 100})
+	if !errors.Is(err, ErrUnsupported) {
+		t.Errorf("expected ErrUnsupported for an offset, got %v", err)
+	}
+}
+
+func TestGetWorkflowLogs_Unavailable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	_, err := newTestClient(server.URL).GetWorkflowLogs(context.Background(), openobserve.WorkflowLogsParams{Namespace: "acme"})
+	if !errors.Is(err, openobserve.ErrBackendUnavailable) {
+		t.Errorf("expected ErrBackendUnavailable, got %v", err)
+	}
+}
+
+func TestCreateAlert(t *testing.T) {
+	var group ruleGroup
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/loki/api/v1/rules/openchoreo" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Content-Type") != "application/yaml" {
+			t.Errorf("unexpected content type %q", r.Header.Get("Content-Type"))
+		}
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &group); err != nil {
+			t.Errorf("invalid rule group %s", body)
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	name := "high-errors"
+	enabled := false
+	id, err := newTestClient(server.URL).CreateAlert(context.Background(), openobserve.LogAlertParams{
+		Name:           &name,
+		Namespace:      "acme",
+		ComponentUID:   "c1",
+		SearchPattern:  "ERROR",
+		Operator:       "gte",
+		ThresholdValue: 5,
+		Window:         "1h",
+		Interval:       "5m",
+		Enabled:        &enabled,
+		Severity:       "critical",
+	})
+	if err != nil {
+		t.Fatalf("CreateAlert() error = %v", err)
+	}
+	if id != "openchoreo/high-errors" || group.Name != name || group.Interval != "5m" || len(group.Rules) != 1 {
+		t.Fatalf("unexpected rule group %+v with ID %q", group, id)
+	}
+	r := group.Rules[0]
+	want := `(sum(count_over_time({kubernetes_labels_openchoreo_dev_namespace="acme"} |= "ERROR" | json` +
+		` | kubernetes_labels_openchoreo_dev_component_uid="c1" [1h])) or vector(0)) >= 5 unless on() vector(1)`
+	if r.Alert != name || r.Expr != want {
+		t.Errorf("unexpected rule %s: %s", r.Alert, r.Expr)
+	}
+	if r.Labels[NamespaceLabel] != "acme" || r.Labels[componentLabel] != "c1" || r.Labels[severityLabel] != "critical" ||
+		r.Annotations[enabledAnnot] != "false" || r.Annotations[AlertCountAnnot] != "{{ $value }}" {
+		t.Errorf("unexpected labels %v and annotations %v", r.Labels, r.Annotations)
+	}
+}
+
+func TestCreateAlert_Invalid(t *testing.T) {
+	client := newTestClient("http://loki")
+	name := "rule"
+	tests := map[string]openobserve.LogAlertParams{
+		"operator":   {Name: &name, Operator: "between", Window: "5m", Interval: "1m"},
+		"window":     {Name: &name, Operator: "gt", Window: "30s", Interval: "1m"},
+		"conditions": {Name: &name, Operator: "gt", Window: "5m", Interval: "1m", Conditions: []openobserve.AlertCondition{{}}},
+	}
+	for field, params := range tests {
+		t.Run(field, func(t *testing.T) {
+			if _, err := client.CreateAlert(context.Background(), params); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestGetAlert(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/prometheus/api/v1/rules" || r.URL.Query().Get("file[]") != "openchoreo" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"success","data":{"groups":[{"name":"high-errors","file":"openchoreo","rules":[{` +
+			`"name":"high-errors","labels":{"openchoreo_namespace":"acme","openchoreo_component_uid":"c1","severity":"warning"},` +
+			`"annotations":{"search_pattern":"ERROR","operator":"gt","threshold":"10","window":"2h","interval":"1h","enabled":"true"}}]}]}}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	alert, err := client.GetAlert(context.Background(), "high-errors")
+	if err != nil {
+		t.Fatalf("GetAlert() error = %v", err)
+	}
+	if alert.Namespace != "acme" || alert.ComponentUID != "c1" || alert.SearchPattern != "ERROR" || alert.Operator != "gt" ||
+		alert.Threshold != 10 || !alert.Enabled || alert.Severity != "warning" {
+		t.Errorf("unexpected alert %+v", alert)
+	}
+	if alert.Period != 2 || alert.Frequency != 1 || alert.FrequencyType != "hours" {
+		t.Errorf("unexpected window %d and interval %d in %s", alert.Period, alert.Frequency, alert.FrequencyType)
+	}
+
+	if _, err := client.GetAlert(context.Background(), "other"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
+func TestDeleteAlert(t *testing.T) {
+	var deleted bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			if r.URL.Path != "/loki/api/v1/rules/openchoreo/high-errors" {
+				t.Errorf("unexpected delete of %s", r.URL.Path)
+			}
+			deleted = true
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		w.Write([]byte(`{"status":"success","data":{"groups":[{"name":"high-errors","file":"openchoreo","rules":[{"name":"high-errors"}]}]}}`))
+	}))
+	defer server.Close()
+
+	id, err := newTestClient(server.URL).DeleteAlert(context.Background(), "high-errors")
+	if err != nil || !deleted || id != "openchoreo/high-errors" {
+		t.Errorf("DeleteAlert() = %q, %v, deleted %v", id, err, deleted)
+	}
+}
@@ -0,0 +1,256 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loki
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+// DefaultRulerNamespace is the ruler namespace of the alert rules unless
+// configured otherwise.
+const DefaultRulerNamespace = "openchoreo"
+
+// Labels and annotations of the alerting rules, carrying the alert rule of
+// the API. The labels are also sent with the alerts to Alertmanager.
+const (
+	NamespaceLabel      = "openchoreo_namespace"
+	projectLabel        = "openchoreo_project_uid"
+	environmentLabel    = "openchoreo_environment_uid"
+	componentLabel      = "openchoreo_component_uid"
+	severityLabel       = "severity"
+	searchPatternAnnot  = "search_pattern"
+	operatorAnnot       = "operator"
+	thresholdAnnot      = "threshold"
+	windowAnnot         = "window"
+	intervalAnnot       = "interval"
+	enabledAnnot        = "enabled"
+	AlertCountAnnot     = "alert_count"
+	disabledRuleSuffix  = " unless on() vector(1)"
+	alertCountTemplate  = "{{ $value }}"
+	rulerContentType    = "application/yaml"
+	prometheusRulesPath = "/prometheus/api/v1/rules"
+)
+
+// logQLOperators maps the operators of alert rules to LogQL.
+var logQLOperators = map[string]string{
+	"gt":  ">",
+	"gte": ">=",
+	"lt":  "<",
+	"lte": "<=",
+	"eq":  "==",
+	"neq": "!=",
+}
+
+// ruleGroup is a rule group of the Loki ruler. The ruler reads YAML, of
+// which JSON is a subset.
+type ruleGroup struct {
+	Name     string `json:"name"`
+	Interval string `json:"interval"`
+	Rules    []rule `json:"rules"`
+}
+
+type rule struct {
+	Alert       string            `json:"alert"`
+	Expr        string            `json:"expr"`
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+}
+
+// CreateAlert writes the alert rule of params to the ruler, as a rule group
+// of one alerting rule named after it, and returns its ruler ID.
+func (c *Client) CreateAlert(ctx context.Context, params openobserve.LogAlertParams) (string, error) {
+	if params.Name == nil || *params.Name == "" {
+		return "", fmt.Errorf("invalid alert rule: name is required")
+	}
+	group, err := c.ruleGroup(params)
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(group)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal rule group: %w", err)
+	}
+	if _, err := c.do(ctx, http.MethodPost, c.rulesPath(""), bytes.NewReader(payload), rulerContentType); err != nil {
+		return "", fmt.Errorf("failed to write rule group: %w", err)
+	}
+	return c.rulerNamespace + "/" + group.Name, nil
+}
+
+// UpdateAlert replaces the alert rule alertName and returns its ruler ID.
+func (c *Client) UpdateAlert(ctx context.Context, alertName string, params openobserve.LogAlertParams) (string, error) {
+	if _, err := c.GetAlert(ctx, alertName); err != nil {
+		return "", err
+	}
+	params.Name = &alertName
+	return c.CreateAlert(ctx, params)
+}
+
+// DeleteAlert deletes the alert rule alertName and returns its ruler ID.
+func (c *Client) DeleteAlert(ctx context.Context, alertName string) (string, error) {
+	if _, err := c.GetAlert(ctx, alertName); err != nil {
+		return "", err
+	}
+	if _, err := c.do(ctx, http.MethodDelete, c.rulesPath(alertName), nil, ""); err != nil {
+		return "", fmt.Errorf("failed to delete rule group: %w", err)
+	}
+	return c.rulerNamespace + "/" + alertName, nil
+}
+
+// GetAlert returns the alert rule alertName, read from the rules the ruler
+// evaluates.
+func (c *Client) GetAlert(ctx context.Context, alertName string) (*openobserve.AlertDetail, error) {
+	var resp struct {
+		Data struct {
+			Groups []struct {
+				Name  string `json:"name"`
+				File  string `json:"file"`
+				Rules []struct {
+					Name        string            `json:"name"`
+					Labels      map[string]string `json:"labels"`
+					Annotations map[string]string `json:"annotations"`
+				} `json:"rules"`
+			} `json:"groups"`
+		} `json:"data"`
+	}
+	values := url.Values{}
+	values.Set("type", "alert")
+	values.Add("file[]", c.rulerNamespace)
+	values.Add("rule_group[]", alertName)
+	if err := c.getJSON(ctx, prometheusRulesPath+"?"+values.Encode(), &resp); err != nil {
+		return nil, fmt.Errorf("failed to list alert rules: %w", err)
+	}
+	for _, group := range resp.Data.Groups {
+		if group.File != c.rulerNamespace || group.Name != alertName || len(group.Rules) == 0 {
+			continue
+		}
+		r := group.Rules[0]
+		threshold, _ := strconv.ParseFloat(r.Annotations[thresholdAnnot], 64)
+		period, _ := time.ParseDuration(r.Annotations[windowAnnot])
+		frequency, _ := time.ParseDuration(r.Annotations[intervalAnnot])
+		detail := &openobserve.AlertDetail{
+			Name:           alertName,
+			Enabled:        r.Annotations[enabledAnnot] != "false",
+			SearchPattern:  r.Annotations[searchPatternAnnot],
+			Operator:       r.Annotations[operatorAnnot],
+			Threshold:      threshold,
+			Namespace:      r.Labels[NamespaceLabel],
+			ProjectUID:     r.Labels[projectLabel],
+			EnvironmentUID: r.Labels[environmentLabel],
+			ComponentUID:   r.Labels[componentLabel],
+			Severity:       r.Labels[severityLabel],
+		}
+		detail.Period, detail.Frequency, detail.FrequencyType = durationsOf(period, frequency)
+		return detail, nil
+	}
+	return nil, fmt.Errorf("alert %q not found", alertName)
+}
+
+// durationsOf returns the window and interval of an alert rule in the
+// largest unit dividing both, minutes or hours.
+func durationsOf(period, frequency time.Duration) (int, int, string) {
+	if period%time.Hour == 0 && frequency%time.Hour == 0 {
+		return int(period / time.Hour), int(frequency / time.Hour), "hours"
+	}
+	return int(period / time.Minute), int(frequency / time.Minute), "minutes"
+}
+
+// ruleGroup returns the rule group of an alert rule. The rule counts the
+// log lines of its scope matching the search pattern over the window, zero
+// when there are none. Disabled rules are kept but never fire.
+func (c *Client) ruleGroup(params openobserve.LogAlertParams) (ruleGroup, error) {
+	if len(params.Conditions) > 0 {
+		return ruleGroup{}, fmt.Errorf("composite alert conditions: %w", ErrUnsupported)
+	}
+	operator, ok := logQLOperators[params.Operator]
+	if !ok {
+		return ruleGroup{}, fmt.Errorf("invalid operator %q: must be one of gt, gte, lt, lte, eq, neq", params.Operator)
+	}
+	window, err := time.ParseDuration(params.Window)
+	if err != nil || window < time.Minute || window%time.Minute != 0 {
+		return ruleGroup{}, fmt.Errorf("invalid window %q: must be a whole number of minutes", params.Window)
+	}
+	interval, err := time.ParseDuration(params.Interval)
+	if err != nil || interval < time.Minute || interval%time.Minute != 0 {
+		return ruleGroup{}, fmt.Errorf("invalid interval %q: must be a whole number of minutes", params.Interval)
+	}
+
+	query := "{" + c.fields.Namespace + "=" + strconv.Quote(params.Namespace) + "}"
+	if params.SearchPattern != "" {
+		query += " |= " + strconv.Quote(params.SearchPattern)
+	}
+	query += " | json"
+	for _, filter := range [][2]string{
+		{c.fields.ProjectUID, params.ProjectUID},
+		{c.fields.EnvironmentUID, params.EnvironmentUID},
+		{c.fields.ComponentUID, params.ComponentUID},
+	} {
+		if filter[1] != "" {
+			query += " | " + filter[0] + "=" + strconv.Quote(filter[1])
+		}
+	}
+	threshold := strconv.FormatFloat(float64(params.ThresholdValue), 'f', -1, 32)
+	expr := fmt.Sprintf("(sum(count_over_time(%s [%s])) or vector(0)) %s %s",
+		query, promDuration(window), operator, threshold)
+	enabled := params.Enabled == nil || *params.Enabled
+	if !enabled {
+		expr += disabledRuleSuffix
+	}
+
+	labels := map[string]string{NamespaceLabel: params.Namespace}
+	for label, value := range map[string]string{
+		projectLabel:     params.ProjectUID,
+		environmentLabel: params.EnvironmentUID,
+		componentLabel:   params.ComponentUID,
+		severityLabel:    params.Severity,
+	} {
+		if value != "" {
+			labels[label] = value
+		}
+	}
+	return ruleGroup{
+		Name:     *params.Name,
+		Interval: promDuration(interval),
+		Rules: []rule{{
+			Alert:  *params.Name,
+			Expr:   expr,
+			Labels: labels,
+			Annotations: map[string]string{
+				searchPatternAnnot: params.SearchPattern,
+				operatorAnnot:      params.Operator,
+				thresholdAnnot:     threshold,
+				windowAnnot:        params.Window,
+				intervalAnnot:      params.Interval,
+				enabledAnnot:       strconv.FormatBool(enabled),
+				AlertCountAnnot:    alertCountTemplate,
+			},
+		}},
+	}, nil
+}
+
+// promDuration formats a whole number of minutes as a Prometheus duration.
+func promDuration(d time.Duration) string {
+	if d%time.Hour == 0 {
+		return fmt.Sprintf("%dh", d/time.Hour)
+	}
+	return fmt.Sprintf("%dm", d/time.Minute)
+}
+
+// rulesPath returns the ruler path of the rule groups of the ruler
+// namespace, or of the rule group named group.
+func (c *Client) rulesPath(group string) string {
+	path := "/loki/api/v1/rules/" + url.PathEscape(c.rulerNamespace)
+	if group != "" {
+		path += "/" + url.PathEscape(group)
+	}
+	return path
+}
@@ -0,0 +1,223 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/access"
+	"github.com/openchoreo/community-modules/common/prefer"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+// Log sources accepted in the sources query extension.
+const (
+	logSourceApplication = "application"
+	logSourceWorkflow    = "workflow"
+)
+
+// defaultMultiSourceLimit matches the default limit of the single-source queries.
+const defaultMultiSourceLimit = 100
+
+// validateLogSources returns an error if sources lists an unknown or duplicate source.
+func validateLogSources(sources []string) error {
+	seen := make(map[string]bool, len(sources))
+	for _, s := range sources {
+		if s != logSourceApplication && s != logSourceWorkflow {
+			return fmt.Errorf("unsupported source %q: must be one of %s, %s", s, logSourceApplication, logSourceWorkflow)
+		}
+		if seen[s] {
+			return fmt.Errorf("duplicate source %q", s)
+		}
+		seen[s] = true
+	}
+	return nil
+}
+
+// sourcedComponentLogEntry and sourcedWorkflowLogEntry tag an entry of a
+// multi-source query with the source it was read from.
+type sourcedComponentLogEntry struct {
+	componentLogEntry
+	Source string `json:"source"`
+}
+
+type sourcedWorkflowLogEntry struct {
+	workflowLogEntry
+	Source string `json:"source"`
+}
+
+// mergedLogEntry is an entry of either source together with its sort key.
+type mergedLogEntry struct {
+	sortTime time.Time
+	value    any
+}
+
+// queryLogSources runs the logs query against the application and workflow
+// log sources in parallel and merges the results into one response ordered
+// by the requested sort field and order. Each entry carries a "source" field
+// and total is the sum of the totals of both sources. The search scope is
+// read as both a component and a workflow scope, so callers do not need to
+// know which source holds the logs they are after. Results are always JSON.
+func (h *LogsHandler) queryLogSources(ctx context.Context, req *gen.LogsQueryRequest, ext queryExtensions, extractors []openobserve.Extractor, searchQuery *openobserve.SearchQuery) (gen.QueryLogsResponseObject, error) {
+	componentScope, err := req.SearchScope.AsComponentSearchScope()
+	if err != nil || strings.TrimSpace(componentScope.Namespace) == "" {
+		return gen.QueryLogs400JSONResponse{
+			Title:   ptr(gen.BadRequest),
+			Message: ptr("searchScope with a valid namespace is required"),
+		}, nil
+	}
+	workflowScope, _ := req.SearchScope.AsWorkflowSearchScope()
+	client, err := h.clientFor(ctx, componentScope.Namespace, access.Raw)
+	if err != nil {
+		return gen.QueryLogs403JSONResponse{
+			Title:   ptr(gen.Forbidden),
+			Message: ptr(err.Error()),
+		}, nil
+	}
+
+	componentParams := toComponentLogsParams(req, &componentScope)
+	componentParams.SortField = ext.SortField
+	componentParams.Extract = extractors
+	componentParams.Query = searchQuery
+	workflowParams := toWorkflowLogsParams(req, &workflowScope)
+	workflowParams.SortField = ext.SortField
+	workflowParams.Extract = extractors
+	workflowParams.Query = searchQuery
+	limit := prefer.FromContext(ctx).Limit(componentParams.Limit)
+	if limit <= 0 {
+		limit = defaultMultiSourceLimit
+	}
+	componentParams.Limit, workflowParams.Limit = limit, limit
+	componentParams.Budget, workflowParams.Budget = h.queryBudget, h.queryBudget
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// The first failure cancels the other query; only its error is reported.
+	var (
+		wg              sync.WaitGroup
+		errOnce         sync.Once
+		firstErr        error
+		componentResult *openobserve.ComponentLogsResult
+		workflowResult  *openobserve.WorkflowLogsResult
+	)
+	fail := func(err error) {
+		errOnce.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+	if slices.Contains(ext.Sources, logSourceApplication) {
+		wg.Go(func() {
+			result, err := client.GetComponentLogs(ctx, componentParams)
+			if err != nil {
+				fail(err)
+				return
+			}
+			componentResult = result
+		})
+	}
+	if slices.Contains(ext.Sources, logSourceWorkflow) {
+		wg.Go(func() {
+			result, err := client.GetWorkflowLogs(ctx, workflowParams)
+			if err != nil {
+				fail(err)
+				return
+			}
+			workflowResult = result
+		})
+	}
+	wg.Wait()
+
+	if err := firstErr; err != nil {
+		h.logger.Error("Failed to query log sources",
+			slog.String("function", "QueryLogs"),
+			slog.String("namespace", componentScope.Namespace),
+			slog.Any("sources", ext.Sources),
+			slog.Any("error", err),
+		)
+		return queryLogsError(err), nil
+	}
+
+	var entries []mergedLogEntry
+	total, took := 0, 0
+	var partial openobserve.PartialResult
+	if componentResult != nil {
+		total += componentResult.TotalCount
+		took = max(took, componentResult.Took)
+		partial.Merge(componentResult.PartialResult)
+		for _, l := range componentResult.Logs {
+			entries = append(entries, mergedLogEntry{
+				sortTime: sortTime(ext.SortField, l.EventTime, l.IngestTime),
+				value: sourcedComponentLogEntry{
+					componentLogEntry: componentLogEntry{
+						ComponentLogEntry: toComponentLogEntry(&l),
+						EventTime:         timePtr(l.EventTime),
+						IngestTime:        timePtr(l.IngestTime),
+					},
+					Source: logSourceApplication,
+				},
+			})
+		}
+	}
+	if workflowResult != nil {
+		total += workflowResult.TotalCount
+		took = max(took, workflowResult.Took)
+		partial.Merge(workflowResult.PartialResult)
+		for _, l := range workflowResult.Logs {
+			entries = append(entries, mergedLogEntry{
+				sortTime: sortTime(ext.SortField, l.EventTime, l.IngestTime),
+				value: sourcedWorkflowLogEntry{
+					workflowLogEntry: workflowLogEntry{
+						WorkflowLogEntry: gen.WorkflowLogEntry{Timestamp: &l.Timestamp, Log: &l.Log},
+						EventTime:        timePtr(l.EventTime),
+						IngestTime:       timePtr(l.IngestTime),
+						Cluster:          l.Cluster,
+						LogLevel:         l.LogLevel,
+						LogLevelInferred: l.LogLevelInferred,
+					},
+					Source: logSourceWorkflow,
+				},
+			})
+		}
+	}
+
+	ascending := strings.EqualFold(componentParams.SortOrder, "asc")
+	slices.SortStableFunc(entries, func(a, b mergedLogEntry) int {
+		if ascending {
+			return a.sortTime.Compare(b.sortTime)
+		}
+		return b.sortTime.Compare(a.sortTime)
+	})
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+
+	values := make([]any, len(entries))
+	for i, e := range entries {
+		values[i] = e.value
+	}
+	resp := gen.LogsQueryResponse{
+		Total:  &total,
+		TookMs: &took,
+	}
+	resp.Logs = toLogsUnion(values)
+	return queryLogsOK(ctx, resp, nil, nil, partial), nil
+}
+
+// sortTime returns the timestamp a merged entry is ordered by, matching the
+// column the per-source queries sort on.
+func sortTime(sortField string, eventTime, ingestTime time.Time) time.Time {
+	if sortField == openobserve.SortFieldEventTime {
+		return eventTime
+	}
+	return ingestTime
+}
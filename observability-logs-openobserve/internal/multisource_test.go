@@ -0,0 +1,132 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+func TestQueryLogs_Sources(t *testing.T) {
+	failWorkflow := false
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query struct {
+				SQL string `json:"sql"`
+			} `json:"query"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		workflow := strings.Contains(body.Query.SQL, "workflows-test-ns")
+		if workflow && failWorkflow {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		var resp openobserve.OpenObserveResponse
+		switch {
+		case strings.Contains(body.Query.SQL, "count(*)"):
+			resp.Hits = []map[string]interface{}{{"total": float64(1)}}
+		case workflow:
+			resp.Hits = []map[string]interface{}{
+				{"_timestamp": float64(time.Date(2025, 1, 1, 12, 0, 1, 0, time.UTC).UnixMicro()), "log": "build step"},
+			}
+		default:
+			resp.Hits = []map[string]interface{}{
+				{"_timestamp": float64(time.Date(2025, 1, 1, 12, 0, 2, 0, time.UTC).UnixMicro()), "log": "app line"},
+				{"_timestamp": float64(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC).UnixMicro()), "log": "older app line"},
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	handler := NewLogsHandler(client, nil, testLogger())
+
+	scope := gen.LogsQueryRequest_SearchScope{}
+	_ = scope.FromComponentSearchScope(gen.ComponentSearchScope{Namespace: "test-ns"})
+	body := &gen.LogsQueryRequest{
+		StartTime:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndTime:     time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+		SearchScope: scope,
+	}
+	ext := queryExtensions{Sources: []string{logSourceApplication, logSourceWorkflow}}
+
+	t.Run("merged and tagged", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), queryExtensionsKey, ext)
+		resp, err := handler.QueryLogs(ctx, gen.QueryLogsRequestObject{Body: body})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		okResp, ok := resp.(gen.QueryLogs200JSONResponse)
+		if !ok {
+			t.Fatalf("expected 200 response, got %T", resp)
+		}
+		if *okResp.Total != 2 {
+			t.Errorf("expected total 2, got %d", *okResp.Total)
+		}
+
+		raw, _ := json.Marshal(okResp)
+		var decoded struct {
+			Logs []struct {
+				Log    string `json:"log"`
+				Source string `json:"source"`
+			} `json:"logs"`
+		}
+		if err := json.Unmarshal(raw, &decoded); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		want := []struct{ log, source string }{
+			{"app line", logSourceApplication},
+			{"build step", logSourceWorkflow},
+			{"older app line", logSourceApplication},
+		}
+		if len(decoded.Logs) != len(want) {
+			t.Fatalf("expected %d entries, got %s", len(want), raw)
+		}
+		for i, w := range want {
+			if decoded.Logs[i].Log != w.log || decoded.Logs[i].Source != w.source {
+				t.Errorf("entry %d = %+v, want %s from %s", i, decoded.Logs[i], w.log, w.source)
+			}
+		}
+	})
+
+	t.Run("limit applies to merged results", func(t *testing.T) {
+		limited := *body
+		limited.Limit = ptr(2)
+		ctx := context.WithValue(context.Background(), queryExtensionsKey, ext)
+		resp, _ := handler.QueryLogs(ctx, gen.QueryLogsRequestObject{Body: &limited})
+		raw, _ := json.Marshal(resp)
+		if strings.Contains(string(raw), "older app line") {
+			t.Errorf("expected the oldest entry to be cut by the limit: %s", raw)
+		}
+	})
+
+	t.Run("unknown source", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), queryExtensionsKey, queryExtensions{Sources: []string{"metrics"}})
+		resp, _ := handler.QueryLogs(ctx, gen.QueryLogsRequestObject{Body: body})
+		if _, ok := resp.(gen.QueryLogs400JSONResponse); !ok {
+			t.Errorf("expected 400 response, got %T", resp)
+		}
+	})
+
+	t.Run("source failure", func(t *testing.T) {
+		failWorkflow = true
+		defer func() { failWorkflow = false }()
+		ctx := context.WithValue(context.Background(), queryExtensionsKey, ext)
+		resp, _ := handler.QueryLogs(ctx, gen.QueryLogsRequestObject{Body: body})
+		if _, ok := resp.(gen.QueryLogs500JSONResponse); !ok {
+			t.Errorf("expected 500 response, got %T", resp)
+		}
+	})
+}
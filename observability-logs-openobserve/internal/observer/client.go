@@ -17,6 +17,8 @@ import (
 type Client struct {
 	baseURL    string
 	httpClient *http.Client
+	// signingKey signs the forwarded notifications when set.
+	signingKey []byte
 }
 
 func NewClient(baseURL string) *Client {
@@ -60,6 +62,9 @@ func (c *Client) ForwardAlert(
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
+	if len(c.signingKey) > 0 {
+		c.sign(req, body, time.Now())
+	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -0,0 +1,104 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package observer
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Headers of the signed alert notifications. The signature is
+// "v1=" followed by the hex-encoded HMAC-SHA256, keyed with the signing
+// key, of the delivery ID, the timestamp and the body joined by dots:
+//
+//	v1=hex(HMAC-SHA256(key, id + "." + timestamp + "." + body))
+const (
+	// WebhookIDHeader is a random ID unique to each notification, which
+	// receivers remember for SignatureTolerance to reject replays.
+	WebhookIDHeader = "X-OpenChoreo-Webhook-Id"
+	// WebhookTimestampHeader is the time the notification was signed, in
+	// seconds since the Unix epoch.
+	WebhookTimestampHeader = "X-OpenChoreo-Webhook-Timestamp"
+	// WebhookSignatureHeader is the signature of the notification.
+	WebhookSignatureHeader = "X-OpenChoreo-Webhook-Signature"
+)
+
+// MinSigningKeyLength is the minimum length in bytes of a signing key.
+const MinSigningKeyLength = 32
+
+// SignatureTolerance is how far the timestamp of a notification may be from
+// the time it is verified.
+const SignatureTolerance = 5 * time.Minute
+
+// signatureVersion prefixes the signatures of the current scheme.
+const signatureVersion = "v1="
+
+var (
+	// ErrMissingSignature is returned for notifications without the
+	// signature headers.
+	ErrMissingSignature = errors.New("missing webhook signature")
+	// ErrStaleSignature is returned for notifications signed more than
+	// SignatureTolerance away from the time they are verified.
+	ErrStaleSignature = errors.New("webhook timestamp outside the tolerance")
+	// ErrInvalidSignature is returned for notifications whose signature
+	// does not match their body.
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+)
+
+// SetSigningKey signs the notifications forwarded by the client with key,
+// so that receivers can verify they come from the adapter. Notifications
+// are not signed by default.
+func (c *Client) SetSigningKey(key []byte) {
+	c.signingKey = key
+}
+
+// sign sets the signature headers of req, whose body is body, signed at now.
+func (c *Client) sign(req *http.Request, body []byte, now time.Time) {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	id := hex.EncodeToString(b)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	req.Header.Set(WebhookIDHeader, id)
+	req.Header.Set(WebhookTimestampHeader, timestamp)
+	req.Header.Set(WebhookSignatureHeader, signatureVersion+signature(c.signingKey, id, timestamp, body))
+}
+
+// signature returns the hex-encoded signature of a notification.
+func signature(key []byte, id, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(id + "." + timestamp + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature checks that the notification with header and body was
+// signed with key within SignatureTolerance of now. Replays within the
+// tolerance are only rejected by receivers remembering the IDs they
+// accepted.
+func VerifySignature(key []byte, header http.Header, body []byte, now time.Time) error {
+	id := header.Get(WebhookIDHeader)
+	timestamp := header.Get(WebhookTimestampHeader)
+	sig, ok := strings.CutPrefix(header.Get(WebhookSignatureHeader), signatureVersion)
+	if id == "" || timestamp == "" || !ok {
+		return ErrMissingSignature
+	}
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if skew := now.Sub(time.Unix(seconds, 0)); skew > SignatureTolerance || skew < -SignatureTolerance {
+		return ErrStaleSignature
+	}
+	if !hmac.Equal([]byte(sig), []byte(signature(key, id, timestamp, body))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
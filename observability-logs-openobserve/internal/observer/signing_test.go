@@ -0,0 +1,93 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package observer
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testSigningKey = []byte("0123456789abcdef0123456789abcdef")
+
+func TestForwardAlert_Signed(t *testing.T) {
+	var header http.Header
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	if err := client.ForwardAlert(context.Background(), "my-rule", "test-ns", 1, time.Now()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if header.Get(WebhookSignatureHeader) != "" {
+		t.Fatalf("expected notifications not to be signed by default, got %v", header)
+	}
+
+	client.SetSigningKey(testSigningKey)
+	if err := client.ForwardAlert(context.Background(), "my-rule", "test-ns", 1, time.Now()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(header.Get(WebhookSignatureHeader), "v1=") || len(header.Get(WebhookIDHeader)) != 32 {
+		t.Fatalf("unexpected signature headers: %v", header)
+	}
+	if err := VerifySignature(testSigningKey, header, body, time.Now()); err != nil {
+		t.Errorf("expected the signature to verify, got %v", err)
+	}
+	firstID := header.Get(WebhookIDHeader)
+	if err := client.ForwardAlert(context.Background(), "my-rule", "test-ns", 1, time.Now()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if header.Get(WebhookIDHeader) == firstID {
+		t.Error("expected each notification to have its own ID")
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	body := []byte(`{"ruleName":"my-rule"}`)
+	signed := func(key []byte, at time.Time) http.Header {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		(&Client{signingKey: key}).sign(req, body, at)
+		return req.Header
+	}
+
+	if err := VerifySignature(testSigningKey, signed(testSigningKey, now.Add(-time.Minute)), body, now); err != nil {
+		t.Errorf("expected a recent signature to verify, got %v", err)
+	}
+
+	otherKey := []byte(strings.Repeat("x", MinSigningKeyLength))
+	tampered := signed(testSigningKey, now)
+	tampered.Set(WebhookIDHeader, "another-id")
+	tests := []struct {
+		name   string
+		key    []byte
+		header http.Header
+		body   []byte
+		want   error
+	}{
+		{"unsigned", testSigningKey, http.Header{}, body, ErrMissingSignature},
+		{"stale", testSigningKey, signed(testSigningKey, now.Add(-10*time.Minute)), body, ErrStaleSignature},
+		{"future", testSigningKey, signed(testSigningKey, now.Add(10*time.Minute)), body, ErrStaleSignature},
+		{"other key", otherKey, signed(testSigningKey, now), body, ErrInvalidSignature},
+		{"other body", testSigningKey, signed(testSigningKey, now), []byte(`{}`), ErrInvalidSignature},
+		{"other ID", testSigningKey, tampered, body, ErrInvalidSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := VerifySignature(tt.key, tt.header, tt.body, now); !errors.Is(err, tt.want) {
+				t.Errorf("VerifySignature() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Aggregations of aggregate queries.
+const (
+	// AggregationCount counts the log lines of each group.
+	AggregationCount = "count"
+	// AggregationDistinct counts the distinct values of a field in each group.
+	AggregationDistinct = "distinct"
+)
+
+const (
+	// MaxAggregateGroupBy bounds the fields an aggregate query groups by.
+	MaxAggregateGroupBy = 3
+	// MaxAggregateRows bounds the rows an aggregate query returns.
+	MaxAggregateRows = 100
+	// defaultAggregateRows is the number of rows returned when no limit is set.
+	defaultAggregateRows = 10
+)
+
+// aggregateFieldNames are the names of the OpenChoreo and Kubernetes fields
+// aggregate queries accept; FieldMapping.AggregateFields maps them to columns.
+var aggregateFieldNames = []string{"project", "component", "environment", "pod", "container", "level"}
+
+// streamFieldName is the syntax of the other stream fields aggregate
+// queries accept, such as the fields OpenObserve parsed from JSON log lines.
+var streamFieldName = regexp.MustCompile(`^[a-z_][a-z0-9_]{0,127}$`)
+
+// AggregateParams holds parameters for aggregate queries over component logs.
+type AggregateParams struct {
+	Namespace     string
+	ProjectID     string
+	EnvironmentID string
+	ComponentID   string
+	StartTime     time.Time
+	EndTime       time.Time
+	SearchPhrase  string
+	LogLevels     []string
+	// GroupBy are the fields the log lines are grouped by: names of
+	// FieldMapping.AggregateFields or, unless KnownFieldsOnly is set, other stream fields.
+	GroupBy []string
+	// Aggregation is AggregationCount (the default) or AggregationDistinct,
+	// which counts the distinct values of Field.
+	Aggregation string
+	Field       string
+	// Limit is the number of groups returned, those with the highest values.
+	Limit int
+	// KnownFieldsOnly restricts GroupBy and Field to the names of
+	// FieldMapping.AggregateFields.
+	KnownFieldsOnly bool
+}
+
+// AggregateRow is a group of an aggregate query with its value.
+type AggregateRow struct {
+	Groups map[string]string `json:"groups"`
+	Value  int               `json:"value"`
+}
+
+// AggregateResult holds the top groups of an aggregate query.
+type AggregateResult struct {
+	Rows []AggregateRow `json:"rows"`
+	Took int            `json:"took"`
+}
+
+// ValidateAggregateParams checks the grouping and aggregation of params.
+func ValidateAggregateParams(params AggregateParams) error {
+	if len(params.GroupBy) == 0 || len(params.GroupBy) > MaxAggregateGroupBy {
+		return fmt.Errorf("groupBy must have 1 to %d fields", MaxAggregateGroupBy)
+	}
+	seen := make(map[string]bool, len(params.GroupBy))
+	for _, field := range params.GroupBy {
+		if err := validateAggregateField(field, params.KnownFieldsOnly); err != nil {
+			return err
+		}
+		if seen[field] {
+			return fmt.Errorf("duplicate groupBy field %q", field)
+		}
+		seen[field] = true
+	}
+	switch params.Aggregation {
+	case "", AggregationCount:
+		if params.Field != "" {
+			return fmt.Errorf("field is only supported with the %s aggregation", AggregationDistinct)
+		}
+	case AggregationDistinct:
+		if params.Field == "" {
+			return fmt.Errorf("field is required for the %s aggregation", AggregationDistinct)
+		}
+		if err := validateAggregateField(params.Field, params.KnownFieldsOnly); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported aggregation %q: must be one of %s, %s", params.Aggregation, AggregationCount, AggregationDistinct)
+	}
+	if params.Limit < 0 || params.Limit > MaxAggregateRows {
+		return fmt.Errorf("limit must be between 1 and %d", MaxAggregateRows)
+	}
+	return nil
+}
+
+// validateAggregateField checks that field is a known field or, unless
+// knownOnly is set, a stream field name.
+func validateAggregateField(field string, knownOnly bool) error {
+	if slices.Contains(aggregateFieldNames, field) {
+		return nil
+	}
+	if knownOnly {
+		return fmt.Errorf("unsupported field %q: must be one of %s", field, strings.Join(aggregateFieldNames, ", "))
+	}
+	if !streamFieldName.MatchString(field) {
+		return fmt.Errorf("unsupported field %q: must be one of %s or a stream field name", field, strings.Join(aggregateFieldNames, ", "))
+	}
+	return nil
+}
+
+// aggregateColumn returns the stream column of a validated aggregate query
+// field under fields.
+func aggregateColumn(fields FieldMapping, field string) string {
+	if column, ok := fields.AggregateFields()[field]; ok {
+		return column
+	}
+	return field
+}
+
+// generateAggregateQuery generates a GROUP BY query returning the groups of
+// component log lines with the highest counts or distinct counts. Groups are
+// selected as g0, g1, ... so that stream fields need no aliasing rules.
+func generateAggregateQuery(params AggregateParams, stream string, fields FieldMapping, logger *slog.Logger) ([]byte, error) {
+	if params.Namespace == "" {
+		return nil, fmt.Errorf("namespace is required for aggregate queries")
+	}
+	if err := ValidateAggregateParams(params); err != nil {
+		return nil, err
+	}
+
+	conditions := fields.scopeConditions(params.Namespace, params.ProjectID, params.EnvironmentID, params.ComponentID)
+	if params.SearchPhrase != "" {
+		conditions = append(conditions, "log LIKE '%"+escapeSQLString(params.SearchPhrase)+"%'")
+	}
+	if len(params.LogLevels) > 0 {
+		levelConditions := make([]string, len(params.LogLevels))
+		for i, level := range params.LogLevels {
+			levelConditions[i] = fields.Level + " = '" + escapeSQLString(level) + "'"
+		}
+		conditions = append(conditions, "("+strings.Join(levelConditions, " OR ")+")")
+	}
+
+	selects := make([]string, 0, len(params.GroupBy)+1)
+	groups := make([]string, 0, len(params.GroupBy))
+	for i, field := range params.GroupBy {
+		selects = append(selects, fmt.Sprintf("%s AS g%d", quoteIdentifier(aggregateColumn(fields, field)), i))
+		groups = append(groups, fmt.Sprintf("g%d", i))
+	}
+	if params.Aggregation == AggregationDistinct {
+		selects = append(selects, "count(DISTINCT "+quoteIdentifier(aggregateColumn(fields, params.Field))+") AS value")
+	} else {
+		selects = append(selects, "count(*) AS value")
+	}
+
+	sql := "SELECT " + strings.Join(selects, ", ") + " FROM " + quoteIdentifier(stream) +
+		" WHERE " + strings.Join(conditions, " AND ") +
+		" GROUP BY " + strings.Join(groups, ", ") +
+		" ORDER BY value DESC"
+
+	limit := params.Limit
+	if limit <= 0 {
+		limit = defaultAggregateRows
+	}
+
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"sql":        sql,
+			"start_time": params.StartTime.UnixMicro(),
+			"end_time":   params.EndTime.UnixMicro(),
+			"from":       0,
+			"size":       limit,
+		},
+	}
+
+	logGenerated(logger, query, "aggregate query")
+
+	return json.Marshal(query)
+}
+
+// GetLogsAggregate returns the groups of the component log lines of a scope
+// with the highest counts, or distinct counts of a field.
+func (c *Client) GetLogsAggregate(ctx context.Context, params AggregateParams) (*AggregateResult, error) {
+	queryJSON, err := generateAggregateQuery(params, c.stream, c.fields, c.logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate aggregate query: %w", err)
+	}
+
+	openObserveResp, err := c.executeSearchQuery(ctx, queryJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]AggregateRow, 0, len(openObserveResp.Hits))
+	for _, hit := range openObserveResp.Hits {
+		row := AggregateRow{Groups: make(map[string]string, len(params.GroupBy))}
+		for i, field := range params.GroupBy {
+			row.Groups[field] = aggregateValue(hit[fmt.Sprintf("g%d", i)])
+		}
+		if v, ok := hit["value"].(float64); ok {
+			row.Value = int(v)
+		}
+		rows = append(rows, row)
+	}
+
+	return &AggregateResult{Rows: rows, Took: openObserveResp.Took}, nil
+}
+
+// aggregateValue formats a group value of an aggregate query. Missing values
+// are returned as the empty string.
+func aggregateValue(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case nil:
+		return ""
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,103 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerateAggregateQuery(t *testing.T) {
+	params := AggregateParams{
+		Namespace:   "ns",
+		ComponentID: "comp-1",
+		StartTime:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndTime:     time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+		LogLevels:   []string{"ERROR"},
+		GroupBy:     []string{"pod", "route"},
+	}
+	raw, err := generateAggregateQuery(params, "default", DefaultFieldMapping, testLogger())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sql, q := sqlOf(t, raw)
+	for _, want := range []string{
+		`SELECT "kubernetes_pod_name" AS g0, "route" AS g1, count(*) AS value FROM "default"`,
+		"kubernetes_labels_openchoreo_dev_component_uid = 'comp-1'",
+		"(logLevel = 'ERROR')",
+		"GROUP BY g0, g1 ORDER BY value DESC",
+	} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("expected %q in %s", want, sql)
+		}
+	}
+	if q["size"] != float64(defaultAggregateRows) {
+		t.Errorf("expected size %d, got %v", defaultAggregateRows, q["size"])
+	}
+
+	params.GroupBy = []string{"component"}
+	params.Aggregation, params.Field, params.Limit = AggregationDistinct, "pod", 5
+	raw, err = generateAggregateQuery(params, "default", DefaultFieldMapping, testLogger())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sql, q = sqlOf(t, raw)
+	if !strings.Contains(sql, `count(DISTINCT "kubernetes_pod_name") AS value`) || q["size"] != float64(5) {
+		t.Errorf("unexpected distinct query: %s (size %v)", sql, q["size"])
+	}
+}
+
+func TestValidateAggregateParams(t *testing.T) {
+	for name, params := range map[string]AggregateParams{
+		"no groupBy":               {},
+		"too many groupBy":         {GroupBy: []string{"pod", "container", "level", "component"}},
+		"duplicate groupBy":        {GroupBy: []string{"pod", "pod"}},
+		"injected field":           {GroupBy: []string{`x" FROM secrets --`}},
+		"stream field when known":  {GroupBy: []string{"route"}, KnownFieldsOnly: true},
+		"distinct without field":   {GroupBy: []string{"pod"}, Aggregation: AggregationDistinct},
+		"count with field":         {GroupBy: []string{"pod"}, Field: "level"},
+		"unknown aggregation":      {GroupBy: []string{"pod"}, Aggregation: "sum"},
+		"distinct of raw log line": {GroupBy: []string{"pod"}, Aggregation: AggregationDistinct, Field: "log", KnownFieldsOnly: true},
+		"limit too high":           {GroupBy: []string{"pod"}, Limit: MaxAggregateRows + 1},
+	} {
+		if err := ValidateAggregateParams(params); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestGetLogsAggregate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(OpenObserveResponse{
+			Took: 3,
+			Hits: []map[string]interface{}{
+				{"g0": "api-1", "g1": float64(503), "value": float64(12)},
+				{"g0": "api-2", "g1": nil, "value": float64(4)},
+			},
+		})
+	}))
+	defer server.Close()
+
+	result, err := newTestClient(server.URL).GetLogsAggregate(context.Background(), AggregateParams{
+		Namespace: "ns",
+		GroupBy:   []string{"pod", "status"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Rows) != 2 || result.Took != 3 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if got := result.Rows[0]; got.Groups["pod"] != "api-1" || got.Groups["status"] != "503" || got.Value != 12 {
+		t.Errorf("unexpected first row: %+v", got)
+	}
+	if got := result.Rows[1]; got.Groups["status"] != "" || got.Value != 4 {
+		t.Errorf("unexpected second row: %+v", got)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// SetAlertEnabled enables or disables an alert by name, keeping its
+// definition, and returns its backend ID. changed is false when the alert
+// was already in the requested state, in which case OpenObserve is left
+// untouched.
+func (c *Client) SetAlertEnabled(ctx context.Context, alertName string, enabled bool) (alertID string, changed bool, err error) {
+	alerts, err := c.listAlerts(ctx)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to find alert %q: %w", alertName, err)
+	}
+	var alert *alertListEntry
+	for i := range alerts {
+		if alerts[i].Name == alertName {
+			alert = &alerts[i]
+			break
+		}
+	}
+	if alert == nil {
+		return "", false, fmt.Errorf("failed to find alert %q: alert %q not found", alertName, alertName)
+	}
+	if alert.Enabled == enabled {
+		return alert.AlertID, false, nil
+	}
+
+	url := fmt.Sprintf("%s/api/v2/%s/alerts/%s/enable?value=%s", c.BaseURL(), c.Org(), alert.AlertID, strconv.FormatBool(enabled))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, url, nil)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return "", false, fmt.Errorf("openobserve returned status %d: %s", resp.StatusCode, string(body))
+	}
+	return alert.AlertID, true, nil
+}
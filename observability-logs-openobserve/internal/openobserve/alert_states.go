@@ -0,0 +1,93 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// maxAlertStates caps the number of alerts whose details are fetched by
+// ListAlertStates, which costs one request per alert.
+const maxAlertStates = 200
+
+// AlertStateParams selects the alert rules of a component scope.
+type AlertStateParams struct {
+	Namespace     string `json:"namespace"`
+	ProjectID     string `json:"projectId,omitempty"`
+	EnvironmentID string `json:"environmentId,omitempty"`
+	ComponentID   string `json:"componentId,omitempty"`
+}
+
+// AlertState is the state of an alert rule: whether it is enabled and when
+// it last fired.
+type AlertState struct {
+	Name            string     `json:"name"`
+	Enabled         bool       `json:"enabled"`
+	Severity        string     `json:"severity,omitempty"`
+	Namespace       string     `json:"namespace"`
+	ProjectUID      string     `json:"projectUid,omitempty"`
+	EnvironmentUID  string     `json:"environmentUid,omitempty"`
+	ComponentUID    string     `json:"componentUid,omitempty"`
+	SearchPattern   string     `json:"searchPattern"`
+	LastTriggeredAt *time.Time `json:"lastTriggeredAt,omitempty"`
+	LastSatisfiedAt *time.Time `json:"lastSatisfiedAt,omitempty"`
+}
+
+// AlertStatesResult represents the alert rules of a component scope.
+type AlertStatesResult struct {
+	Alerts []AlertState `json:"alerts"`
+	// Truncated is set when the organization had more alerts than were inspected.
+	Truncated bool `json:"truncated"`
+}
+
+// ListAlertStates returns the state of the alert rules of a component scope.
+// Rules are matched by the scope stored with them when they were created;
+// the scope fields left empty in params match any value.
+func (c *Client) ListAlertStates(ctx context.Context, params AlertStateParams) (*AlertStatesResult, error) {
+	alerts, err := c.listAlerts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list alerts: %w", err)
+	}
+	result := &AlertStatesResult{Alerts: []AlertState{}}
+	if len(alerts) > maxAlertStates {
+		alerts, result.Truncated = alerts[:maxAlertStates], true
+	}
+
+	for _, alert := range alerts {
+		detail, err := c.getAlertByID(ctx, alert.AlertID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get alert %q: %w", alert.Name, err)
+		}
+		if detail.Namespace != params.Namespace ||
+			(params.ProjectID != "" && detail.ProjectUID != params.ProjectID) ||
+			(params.EnvironmentID != "" && detail.EnvironmentUID != params.EnvironmentID) ||
+			(params.ComponentID != "" && detail.ComponentUID != params.ComponentID) {
+			continue
+		}
+		result.Alerts = append(result.Alerts, AlertState{
+			Name:            alert.Name,
+			Enabled:         alert.Enabled,
+			Severity:        detail.Severity,
+			Namespace:       detail.Namespace,
+			ProjectUID:      detail.ProjectUID,
+			EnvironmentUID:  detail.EnvironmentUID,
+			ComponentUID:    detail.ComponentUID,
+			SearchPattern:   ExtractSearchPattern(detail.SQL),
+			LastTriggeredAt: microsTime(alert.LastTriggeredAt),
+			LastSatisfiedAt: microsTime(alert.LastSatisfiedAt),
+		})
+	}
+	return result, nil
+}
+
+// microsTime converts microseconds since the epoch to a time, or nil for zero.
+func microsTime(micros int64) *time.Time {
+	if micros == 0 {
+		return nil
+	}
+	t := time.UnixMicro(micros).UTC()
+	return &t
+}
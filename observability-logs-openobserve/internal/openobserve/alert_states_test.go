@@ -0,0 +1,77 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestListAlertStates(t *testing.T) {
+	triggered := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v2/default/alerts":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"list": []map[string]interface{}{
+					{"alert_id": "a1", "name": "errors", "enabled": true, "last_triggered_at": triggered.UnixMicro()},
+					{"alert_id": "a2", "name": "other-component", "enabled": true},
+					{"alert_id": "a3", "name": "other-namespace", "enabled": false},
+				},
+			})
+		case strings.HasPrefix(r.URL.Path, "/api/v2/default/alerts/"):
+			id := strings.TrimPrefix(r.URL.Path, "/api/v2/default/alerts/")
+			namespace, component := "ns", "comp-1"
+			switch id {
+			case "a2":
+				component = "comp-2"
+			case "a3":
+				namespace = "other"
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"query_condition": map[string]interface{}{
+					"sql": "SELECT count(*) as match_count FROM \"default\" WHERE str_match(log, 'error')",
+				},
+				"context_attributes": map[string]interface{}{
+					"namespace":    namespace,
+					"componentUid": component,
+					"severity":     "critical",
+				},
+			})
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	result, err := client.ListAlertStates(context.Background(), AlertStateParams{Namespace: "ns", ComponentID: "comp-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Alerts) != 1 || result.Truncated {
+		t.Fatalf("expected one alert, got %+v", result)
+	}
+	alert := result.Alerts[0]
+	if alert.Name != "errors" || !alert.Enabled || alert.Severity != "critical" || alert.SearchPattern != "error" {
+		t.Errorf("unexpected alert: %+v", alert)
+	}
+	if alert.LastTriggeredAt == nil || !alert.LastTriggeredAt.Equal(triggered) || alert.LastSatisfiedAt != nil {
+		t.Errorf("unexpected trigger times: %v, %v", alert.LastTriggeredAt, alert.LastSatisfiedAt)
+	}
+
+	result, err = client.ListAlertStates(context.Background(), AlertStateParams{Namespace: "ns"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Alerts) != 2 {
+		t.Errorf("expected the alerts of both components, got %+v", result.Alerts)
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// AlertSpec is the part of an OpenObserve alert that the adapter derives
+// from an alert rule. Two alerts with the same spec evaluate the same query
+// and trigger condition.
+type AlertSpec struct {
+	SQL       string  `json:"sql"`
+	Operator  string  `json:"operator"`
+	Threshold float64 `json:"threshold"`
+	// Period and Frequency are in minutes.
+	Period    int  `json:"period"`
+	Frequency int  `json:"frequency"`
+	Enabled   bool `json:"enabled"`
+}
+
+// Spec returns the spec of an alert read from OpenObserve.
+func (d *AlertDetail) Spec() AlertSpec {
+	return AlertSpec{
+		SQL:       d.SQL,
+		Operator:  d.Operator,
+		Threshold: d.Threshold,
+		Period:    d.Period,
+		Frequency: d.Frequency,
+		Enabled:   d.Enabled,
+	}
+}
+
+// AlertSpec returns the spec of the alert CreateAlert and UpdateAlert
+// generate for params.
+func (c *Client) AlertSpec(params LogAlertParams) (AlertSpec, error) {
+	alertJSON, err := generateAlertConfig(params, c.stream, c.eventsStream, c.fields, c.logger)
+	if err != nil {
+		return AlertSpec{}, err
+	}
+	var config struct {
+		Enabled        bool `json:"enabled"`
+		QueryCondition struct {
+			SQL string `json:"sql"`
+		} `json:"query_condition"`
+		TriggerCondition struct {
+			Period    int     `json:"period"`
+			Frequency int     `json:"frequency"`
+			Threshold float64 `json:"threshold"`
+			Operator  string  `json:"operator"`
+		} `json:"trigger_condition"`
+	}
+	if err := json.Unmarshal(alertJSON, &config); err != nil {
+		return AlertSpec{}, fmt.Errorf("failed to decode alert config: %w", err)
+	}
+	return AlertSpec{
+		SQL:       config.QueryCondition.SQL,
+		Operator:  config.TriggerCondition.Operator,
+		Threshold: config.TriggerCondition.Threshold,
+		Period:    config.TriggerCondition.Period,
+		Frequency: config.TriggerCondition.Frequency,
+		Enabled:   config.Enabled,
+	}, nil
+}
+
+// ListAlertDetails returns the details of every alert of the organization.
+func (c *Client) ListAlertDetails(ctx context.Context) ([]AlertDetail, error) {
+	alerts, err := c.listAlerts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	details := make([]AlertDetail, 0, len(alerts))
+	for _, alert := range alerts {
+		detail, err := c.getAlertByID(ctx, alert.AlertID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get alert %q: %w", alert.Name, err)
+		}
+		details = append(details, *detail)
+	}
+	return details, nil
+}
@@ -0,0 +1,170 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MaxAlertTestRecords bounds the synthetic log lines and events an alert
+// rule is evaluated against.
+const MaxAlertTestRecords = 10000
+
+// AlertTestLog is a synthetic log line an alert rule is evaluated against.
+type AlertTestLog struct {
+	// Timestamp is optional; lines without one fall in the window.
+	Timestamp *time.Time `json:"timestamp,omitempty"`
+	Log       string     `json:"log"`
+}
+
+// AlertTestEvent is a synthetic Kubernetes event an alert rule is
+// evaluated against.
+type AlertTestEvent struct {
+	// Timestamp is optional; events without one fall in the window.
+	Timestamp *time.Time `json:"timestamp,omitempty"`
+	Reason    string     `json:"reason"`
+}
+
+// AlertConditionResult is the outcome of a condition of a tested rule.
+type AlertConditionResult struct {
+	AlertCondition
+	// Count is the number of records in the window the condition matched.
+	Count int `json:"count"`
+	// Met reports whether Count satisfies the operator and threshold.
+	Met bool `json:"met"`
+	// Matches are the indexes of the matched records in the logs or events
+	// of the test, depending on the source of the condition.
+	Matches []int `json:"matches"`
+}
+
+// AlertTestResult is the outcome of evaluating an alert rule against
+// synthetic records.
+type AlertTestResult struct {
+	// Fired reports whether the rule would fire.
+	Fired bool `json:"fired"`
+	// Match is how the conditions were combined, AlertMatchAll or AlertMatchAny.
+	Match string `json:"match"`
+	// Conditions are the results of the rule condition followed by those of
+	// its additional conditions.
+	Conditions []AlertConditionResult `json:"conditions"`
+	// WindowStart and WindowEnd bound the records taken into account when
+	// some have timestamps.
+	WindowStart *time.Time `json:"windowStart,omitempty"`
+	WindowEnd   *time.Time `json:"windowEnd,omitempty"`
+}
+
+// EvaluateAlertRule evaluates an alert rule against synthetic log lines and
+// events the way the OpenObserve alert generated for it would, without
+// querying OpenObserve. Log conditions count the lines containing their
+// search pattern, as str_match does, and event conditions the events with
+// their reason. The rule is evaluated at the latest timestamp of the
+// records, over its window.
+func EvaluateAlertRule(params LogAlertParams, logs []AlertTestLog, events []AlertTestEvent) (*AlertTestResult, error) {
+	if len(logs)+len(events) > MaxAlertTestRecords {
+		return nil, fmt.Errorf("at most %d logs and events are allowed", MaxAlertTestRecords)
+	}
+	if params.SearchPattern == "" {
+		return nil, errors.New("source query is required")
+	}
+	if _, err := mapOperator(params.Operator); err != nil {
+		return nil, fmt.Errorf("invalid alert operator: %w", err)
+	}
+	if err := ValidateAlertConditions(params.Conditions, params.ConditionMatch); err != nil {
+		return nil, err
+	}
+	if err := ValidateAlertSeverity(params.Severity); err != nil {
+		return nil, err
+	}
+	windowMinutes, err := parseDurationMinutes(params.Window)
+	if err != nil {
+		return nil, fmt.Errorf("invalid alert window: %w", err)
+	}
+	if _, err := parseDurationMinutes(params.Interval); err != nil {
+		return nil, fmt.Errorf("invalid alert interval: %w", err)
+	}
+
+	result := &AlertTestResult{Match: AlertMatchAll}
+	if params.ConditionMatch == AlertMatchAny {
+		result.Match = AlertMatchAny
+	}
+
+	var end time.Time
+	for _, l := range logs {
+		if l.Timestamp != nil && l.Timestamp.After(end) {
+			end = *l.Timestamp
+		}
+	}
+	for _, e := range events {
+		if e.Timestamp != nil && e.Timestamp.After(end) {
+			end = *e.Timestamp
+		}
+	}
+	var start time.Time
+	if !end.IsZero() {
+		start = end.Add(-time.Duration(windowMinutes) * time.Minute)
+		result.WindowStart, result.WindowEnd = &start, &end
+	}
+	inWindow := func(t *time.Time) bool {
+		return t == nil || end.IsZero() || t.After(start)
+	}
+
+	conditions := append([]AlertCondition{{
+		Source:    AlertSourceLogs,
+		Query:     params.SearchPattern,
+		Operator:  params.Operator,
+		Threshold: params.ThresholdValue,
+	}}, params.Conditions...)
+	for _, c := range conditions {
+		cr := AlertConditionResult{AlertCondition: c, Matches: []int{}}
+		if c.Source == AlertSourceEvents {
+			for i, e := range events {
+				if e.Reason == c.Query && inWindow(e.Timestamp) {
+					cr.Matches = append(cr.Matches, i)
+				}
+			}
+		} else {
+			for i, l := range logs {
+				if strings.Contains(l.Log, c.Query) && inWindow(l.Timestamp) {
+					cr.Matches = append(cr.Matches, i)
+				}
+			}
+		}
+		cr.Count = len(cr.Matches)
+		cr.Met = compareThreshold(float64(cr.Count), c.Operator, float64(c.Threshold))
+		result.Conditions = append(result.Conditions, cr)
+	}
+
+	result.Fired = result.Match == AlertMatchAll
+	for _, cr := range result.Conditions {
+		if result.Match == AlertMatchAll {
+			result.Fired = result.Fired && cr.Met
+		} else {
+			result.Fired = result.Fired || cr.Met
+		}
+	}
+	return result, nil
+}
+
+// compareThreshold applies an API operator (gt, gte, ...) to value and threshold.
+func compareThreshold(value float64, operator string, threshold float64) bool {
+	switch operator {
+	case "gt":
+		return value > threshold
+	case "gte":
+		return value >= threshold
+	case "lt":
+		return value < threshold
+	case "lte":
+		return value <= threshold
+	case "eq":
+		return value == threshold
+	case "neq":
+		return value != threshold
+	default:
+		return false
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestEvaluateAlertRule(t *testing.T) {
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		t := base.Add(d)
+		return &t
+	}
+	logs := []AlertTestLog{
+		{Timestamp: at(-10 * time.Minute), Log: "ERROR connection refused"}, // outside a 5m window
+		{Timestamp: at(-4 * time.Minute), Log: "ERROR connection refused"},
+		{Timestamp: at(-1 * time.Minute), Log: "INFO request served"},
+		{Timestamp: at(0), Log: "ERROR timeout"},
+	}
+	events := []AlertTestEvent{
+		{Timestamp: at(-2 * time.Minute), Reason: "BackOff"},
+		{Reason: "OOMKilling"},
+	}
+	rule := LogAlertParams{SearchPattern: "ERROR", Operator: "gte", ThresholdValue: 2, Window: "5m", Interval: "1m"}
+
+	t.Run("threshold met within the window", func(t *testing.T) {
+		result, err := EvaluateAlertRule(rule, logs, events)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		c := result.Conditions[0]
+		if !result.Fired || c.Count != 2 || !slices.Equal(c.Matches, []int{1, 3}) {
+			t.Errorf("unexpected result: %+v", result)
+		}
+		if !result.WindowEnd.Equal(base) || !result.WindowStart.Equal(base.Add(-5*time.Minute)) {
+			t.Errorf("unexpected window: %v - %v", result.WindowStart, result.WindowEnd)
+		}
+	})
+
+	t.Run("pattern is case sensitive", func(t *testing.T) {
+		r := rule
+		r.SearchPattern = "error"
+		result, err := EvaluateAlertRule(r, logs, nil)
+		if err != nil || result.Fired || result.Conditions[0].Count != 0 {
+			t.Errorf("unexpected result: %+v, %v", result, err)
+		}
+	})
+
+	t.Run("composite conditions", func(t *testing.T) {
+		r := rule
+		r.Conditions = []AlertCondition{{Source: AlertSourceEvents, Query: "BackOff", Operator: "gt", Threshold: 1}}
+		result, err := EvaluateAlertRule(r, logs, events)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Fired || result.Match != AlertMatchAll || len(result.Conditions) != 2 || result.Conditions[1].Count != 1 {
+			t.Errorf("expected the all-match rule not to fire, got %+v", result)
+		}
+
+		r.ConditionMatch = AlertMatchAny
+		if result, _ := EvaluateAlertRule(r, logs, events); !result.Fired {
+			t.Errorf("expected the any-match rule to fire, got %+v", result)
+		}
+	})
+
+	t.Run("records without timestamps", func(t *testing.T) {
+		result, err := EvaluateAlertRule(rule, []AlertTestLog{{Log: "ERROR a"}, {Log: "ERROR b"}}, nil)
+		if err != nil || !result.Fired || result.WindowStart != nil {
+			t.Errorf("unexpected result: %+v, %v", result, err)
+		}
+	})
+
+	t.Run("invalid rules", func(t *testing.T) {
+		for name, mutate := range map[string]func(r *LogAlertParams){
+			"operator": func(r *LogAlertParams) { r.Operator = "approx" },
+			"window":   func(r *LogAlertParams) { r.Window = "5s" },
+			"pattern":  func(r *LogAlertParams) { r.SearchPattern = "" },
+			"condition": func(r *LogAlertParams) {
+				r.Conditions = []AlertCondition{{Source: "metrics", Query: "x", Operator: "gt"}}
+			},
+		} {
+			r := rule
+			mutate(&r)
+			if _, err := EvaluateAlertRule(r, logs, nil); err == nil {
+				t.Errorf("expected an error for an invalid %s", name)
+			}
+		}
+	})
+}
@@ -0,0 +1,77 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// BuildPlane is the stream holding the workflow logs of a build plane, in
+// the organization Org, or in the organization of the client when Org is
+// empty.
+type BuildPlane struct {
+	Cluster string
+	Org     string
+	Stream  string
+}
+
+// workflowSource is a stream workflow logs are read from, in the
+// organization org, tagged with cluster when it is set.
+type workflowSource struct {
+	cluster string
+	org     string
+	stream  string
+}
+
+var (
+	clusterNamePattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+	orgNamePattern     = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+	streamNamePattern  = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// ParseBuildPlanes parses a comma-separated list of build planes of the
+// form <cluster>=[<org>/]<stream>, such as
+// "build-east=ci_logs,build-west=build_west/default".
+func ParseBuildPlanes(s string) ([]BuildPlane, error) {
+	var planes []BuildPlane
+	seen := make(map[string]bool)
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		cluster, source, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid build plane %q: expected <cluster>=[<org>/]<stream>", item)
+		}
+		plane := BuildPlane{Cluster: strings.TrimSpace(cluster)}
+		plane.Org, plane.Stream, ok = strings.Cut(strings.TrimSpace(source), "/")
+		if !ok {
+			plane.Org, plane.Stream = "", plane.Org
+		} else if !orgNamePattern.MatchString(plane.Org) {
+			return nil, fmt.Errorf("invalid organization %q in build plane %q", plane.Org, item)
+		}
+		if !clusterNamePattern.MatchString(plane.Cluster) {
+			return nil, fmt.Errorf("invalid cluster %q in build plane %q", plane.Cluster, item)
+		}
+		if seen[plane.Cluster] {
+			return nil, fmt.Errorf("duplicate build plane %q", plane.Cluster)
+		}
+		if !streamNamePattern.MatchString(plane.Stream) {
+			return nil, fmt.Errorf("invalid stream %q in build plane %q", plane.Stream, item)
+		}
+		seen[plane.Cluster] = true
+		planes = append(planes, plane)
+	}
+	return planes, nil
+}
+
+// SetBuildPlanes reads the workflow logs of all the build planes, each from
+// its own stream, instead of the stream of the client, and tags each entry
+// with the cluster of its build plane. Stream routes do not apply to them.
+func (c *Client) SetBuildPlanes(planes []BuildPlane) {
+	c.buildPlanes = planes
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestParseBuildPlanes(t *testing.T) {
+	planes, err := ParseBuildPlanes(" build-east=ci_logs, build-west=build_west/default ,")
+	if err != nil {
+		t.Fatalf("ParseBuildPlanes() error = %v", err)
+	}
+	want := []BuildPlane{
+		{Cluster: "build-east", Stream: "ci_logs"},
+		{Cluster: "build-west", Org: "build_west", Stream: "default"},
+	}
+	if !reflect.DeepEqual(planes, want) {
+		t.Errorf("ParseBuildPlanes() = %+v, want %+v", planes, want)
+	}
+
+	for _, s := range []string{
+		"ci_logs",
+		"Build=ci_logs",
+		"a=ci_logs,a=other",
+		"a=org/",
+		"a=my org/ci_logs",
+		"a=ci-logs",
+	} {
+		if _, err := ParseBuildPlanes(s); err == nil {
+			t.Errorf("expected an error for %q", s)
+		}
+	}
+}
+
+func TestGetWorkflowLogs_BuildPlanes(t *testing.T) {
+	var mu sync.Mutex
+	var orgs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		org := strings.Split(r.URL.Path, "/")[2]
+		mu.Lock()
+		orgs = append(orgs, org)
+		mu.Unlock()
+		var body struct {
+			Query struct {
+				SQL string `json:"sql"`
+			} `json:"query"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		resp := OpenObserveResponse{Hits: []map[string]interface{}{}}
+		switch {
+		case strings.Contains(body.Query.SQL, "count(*)"):
+			resp.Hits = append(resp.Hits, map[string]interface{}{"total": 1})
+		case org == "build_west":
+			resp.Hits = append(resp.Hits, map[string]interface{}{"_timestamp": 2e6, "log": "west"})
+		default:
+			resp.Hits = append(resp.Hits, map[string]interface{}{"_timestamp": 1e6, "log": "east"})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	planes, err := ParseBuildPlanes("build-east=ci_logs,build-west=build_west/default")
+	if err != nil {
+		t.Fatalf("ParseBuildPlanes() error = %v", err)
+	}
+	client := newTestClient(server.URL)
+	client.SetBuildPlanes(planes)
+
+	result, err := client.GetWorkflowLogs(context.Background(), WorkflowLogsParams{
+		Namespace:       "acme",
+		WorkflowRunName: "build-1",
+		Limit:           10,
+	})
+	if err != nil {
+		t.Fatalf("GetWorkflowLogs() error = %v", err)
+	}
+	if result.TotalCount != 2 || len(result.Logs) != 2 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if result.Logs[0].Cluster != "build-west" || result.Logs[0].Log != "west" ||
+		result.Logs[1].Cluster != "build-east" || result.Logs[1].Log != "east" {
+		t.Errorf("expected the entries of both build planes tagged with their cluster, got %+v", result.Logs)
+	}
+	for _, org := range orgs {
+		if org != "default" && org != "build_west" {
+			t.Errorf("unexpected organization %q searched", org)
+		}
+	}
+}
@@ -7,29 +7,40 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
 	"strings"
 	"time"
+
+	ooclient "github.com/openchoreo/community-modules/common/openobserve"
+	"github.com/openchoreo/community-modules/common/rollups"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/formats"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/scheduler"
 )
 
-// extractLogLevel extracts log level from log content using common patterns.
-func extractLogLevel(log string) string {
+// logLevelKeywords are the levels ExtractLogLevel looks for, in order of
+// precedence. WARN also matches WARNING.
+var logLevelKeywords = []string{"ERROR", "FATAL", "SEVERE", "WARN", "INFO", "DEBUG"}
+
+// defaultLogLevel is the level of log lines naming none of the
+// logLevelKeywords.
+const defaultLogLevel = "INFO"
+
+// ExtractLogLevel extracts log level from log content using common patterns.
+// It is the level of log lines that carry none.
+func ExtractLogLevel(log string) string {
 	upper := strings.ToUpper(log)
 
-	levels := []string{"ERROR", "FATAL", "SEVERE", "WARN", "WARNING", "INFO", "DEBUG"}
-	for _, level := range levels {
+	for _, level := range logLevelKeywords {
 		if strings.Contains(upper, level) {
-			if level == "WARNING" {
-				return "WARN"
-			}
 			return level
 		}
 	}
 
-	return "INFO"
+	return defaultLogLevel
 }
 
 // ComponentLogsParams holds parameters for component log queries.
@@ -37,25 +48,62 @@ type ComponentLogsParams struct {
 	Namespace     string    `json:"namespace"`
 	ComponentIDs  []string  `json:"componentIds,omitempty"`
 	EnvironmentID string    `json:"environmentId"`
+	// EnvironmentIDs extends the query to the logs of several environments.
+	// AllEnvironments in either field queries every environment.
+	EnvironmentIDs []string `json:"environmentIds,omitempty"`
 	ProjectID     string    `json:"projectId"`
 	StartTime     time.Time `json:"startTime"`
 	EndTime       time.Time `json:"endTime"`
 	SearchPhrase  string    `json:"searchPhrase"`
 	LogLevels     []string  `json:"logLevels"`
 	Limit         int       `json:"limit"`
+	Offset        int       `json:"offset,omitempty"`
 	SortOrder     string    `json:"sortOrder"`
+	SortField     string    `json:"sortField,omitempty"`
+	// Tiebreakers order lines sharing a timestamp; the client's are used
+	// when unset.
+	Tiebreakers []string `json:"-"`
+	// Fields maps the filtered fields to stream columns; the client's
+	// mapping is used when unset.
+	Fields *FieldMapping `json:"-"`
+	// Extract extracts fields from the returned log lines.
+	Extract []Extractor `json:"-"`
+	// Query filters the log lines with a full-text search query, in
+	// addition to SearchPhrase.
+	Query *SearchQuery `json:"-"`
+	// Budget, when positive, bounds each search of the query. A search
+	// running out of it makes the result partial rather than failing it.
+	Budget time.Duration `json:"-"`
 }
 
 // WorkflowLogsParams holds parameters for workflow log queries.
 type WorkflowLogsParams struct {
 	Namespace       string    `json:"namespace"`
 	WorkflowRunName string    `json:"workflowRunName"`
+	StepName        string    `json:"stepName,omitempty"`
+	PodName         string    `json:"podName,omitempty"`
 	StartTime       time.Time `json:"startTime"`
 	EndTime         time.Time `json:"endTime"`
 	SearchPhrase    string    `json:"searchPhrase"`
 	LogLevels       []string  `json:"logLevels"`
 	Limit           int       `json:"limit"`
+	Offset          int       `json:"offset,omitempty"`
 	SortOrder       string    `json:"sortOrder"`
+	SortField       string    `json:"sortField,omitempty"`
+	// Tiebreakers order lines sharing a timestamp; the client's are used
+	// when unset.
+	Tiebreakers []string `json:"-"`
+	// Fields maps the filtered fields to stream columns; the client's
+	// mapping is used when unset.
+	Fields *FieldMapping `json:"-"`
+	// Extract extracts fields from the returned log lines.
+	Extract []Extractor `json:"-"`
+	// Query filters the log lines with a full-text search query, in
+	// addition to SearchPhrase.
+	Query *SearchQuery `json:"-"`
+	// Budget, when positive, bounds each search of the query. A search
+	// running out of it makes the result partial rather than failing it.
+	Budget time.Duration `json:"-"`
 }
 
 // LogAlertParams holds parameters for creating log alerts.
@@ -71,11 +119,33 @@ type LogAlertParams struct {
 	Window         string  `json:"window"`
 	Interval       string  `json:"interval"`
 	Enabled        *bool   `json:"enabled"`
+	// Severity is stored in the alert's context attributes when set.
+	Severity string `json:"severity,omitempty"`
+	// Destinations are the OpenObserve alert destinations to notify.
+	// DefaultAlertDestination is used when empty.
+	Destinations []string `json:"destinations,omitempty"`
+	// Conditions are evaluated together with the search pattern condition
+	// and combined according to ConditionMatch, AlertMatchAll by default.
+	Conditions     []AlertCondition `json:"conditions,omitempty"`
+	ConditionMatch string           `json:"conditionMatch,omitempty"`
+}
+
+// AlertCondition is a single threshold condition of a composite alert.
+type AlertCondition struct {
+	// Source is AlertSourceLogs (the default) or AlertSourceEvents.
+	Source string `json:"source,omitempty"`
+	// Query is the log search pattern, or the Kubernetes event reason
+	// (e.g. "BackOff") for event conditions.
+	Query     string  `json:"query"`
+	Operator  string  `json:"operator"`
+	Threshold float32 `json:"threshold"`
 }
 
 // ComponentLogsEntry represents a parsed log entry.
 type ComponentLogsEntry struct {
 	Timestamp       time.Time `json:"timestamp"`
+	EventTime       time.Time `json:"eventTime"`
+	IngestTime      time.Time `json:"ingestTime"`
 	Log             string    `json:"log"`
 	LogLevel        string    `json:"logLevel"`
 	ComponentUID    string    `json:"componentUid"`
@@ -88,6 +158,11 @@ type ComponentLogsEntry struct {
 	PodName         string    `json:"podName"`
 	PodNamespace    string    `json:"podNamespace"`
 	ContainerName   string    `json:"containerName"`
+	// Format holds the fields detected in Log, if its format was recognized.
+	Format *formats.Fields `json:"format,omitempty"`
+	// Extracted holds the fields extracted from Log by the Extract
+	// parameter of the query.
+	Extracted map[string]string `json:"extracted,omitempty"`
 }
 
 // ComponentLogsResult represents the result of a component log query.
@@ -95,13 +170,80 @@ type ComponentLogsResult struct {
 	Logs       []ComponentLogsEntry `json:"logs"`
 	TotalCount int                  `json:"totalCount"`
 	Took       int                  `json:"took"`
+	// ParseErrors summarizes the malformed hits in strict mode.
+	ParseErrors *ParseErrors `json:"parseErrors,omitempty"`
+	PartialResult
+}
+
+// LogSourcesParams holds parameters for listing the components that produced logs.
+type LogSourcesParams struct {
+	Namespace     string    `json:"namespace"`
+	ProjectID     string    `json:"projectId,omitempty"`
+	EnvironmentID string    `json:"environmentId,omitempty"`
+	StartTime     time.Time `json:"startTime"`
+	EndTime       time.Time `json:"endTime"`
+}
+
+// LogSource is a component/environment pair that produced logs in the queried window.
+type LogSource struct {
+	ProjectUID      string    `json:"projectUid"`
+	ProjectName     string    `json:"projectName"`
+	ComponentUID    string    `json:"componentUid"`
+	ComponentName   string    `json:"componentName"`
+	EnvironmentUID  string    `json:"environmentUid"`
+	EnvironmentName string    `json:"environmentName"`
+	LastSeen        time.Time `json:"lastSeen"`
+	LogCount        int       `json:"logCount"`
+}
+
+// LogSourcesResult represents the result of a log sources query.
+type LogSourcesResult struct {
+	Sources []LogSource `json:"sources"`
+	Took    int         `json:"took"`
+}
+
+// LevelHistogramParams holds parameters for a component's log level histogram.
+type LevelHistogramParams struct {
+	Namespace    string        `json:"namespace"`
+	ComponentUID string        `json:"componentUid"`
+	StartTime    time.Time     `json:"startTime"`
+	EndTime      time.Time     `json:"endTime"`
+	Interval     time.Duration `json:"interval"`
+}
+
+// LevelHistogramBucket holds the number of log lines per level in one time bucket.
+type LevelHistogramBucket struct {
+	Time   time.Time      `json:"time"`
+	Counts map[string]int `json:"counts"`
+}
+
+// LevelHistogramResult represents the result of a log level histogram query.
+type LevelHistogramResult struct {
+	Buckets []LevelHistogramBucket `json:"buckets"`
+	Took    int                    `json:"took"`
+	// FromRollups is set when the whole minutes of the window were read
+	// from the level rollups.
+	FromRollups bool `json:"fromRollups,omitempty"`
 }
 
 // WorkflowLogsEntry represents a parsed workflow log entry.
 type WorkflowLogsEntry struct {
-	Timestamp time.Time              `json:"timestamp"`
-	Log       string                 `json:"log"`
-	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+	Timestamp  time.Time              `json:"timestamp"`
+	EventTime  time.Time              `json:"eventTime"`
+	IngestTime time.Time              `json:"ingestTime"`
+	Log        string                 `json:"log"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+	// Extracted holds the fields extracted from Log by the Extract
+	// parameter of the query.
+	Extracted map[string]string `json:"extracted,omitempty"`
+	// Cluster is the build plane the entry was read from, when build
+	// planes are configured.
+	Cluster string `json:"cluster,omitempty"`
+	// LogLevel is the level of the entry. CI tooling rarely records one,
+	// so it is inferred from Log with ExtractLogLevel when the level field
+	// is empty, and LogLevelInferred is set.
+	LogLevel         string `json:"logLevel,omitempty"`
+	LogLevelInferred bool   `json:"logLevelInferred,omitempty"`
 }
 
 // WorkflowLogsResult represents the result of a workflow log query.
@@ -109,95 +251,140 @@ type WorkflowLogsResult struct {
 	Logs       []WorkflowLogsEntry `json:"logs"`
 	TotalCount int                 `json:"totalCount"`
 	Took       int                 `json:"took"`
+	// ParseErrors summarizes the malformed hits in strict mode.
+	ParseErrors *ParseErrors `json:"parseErrors,omitempty"`
+	PartialResult
 }
 
-type OpenObserveResponse struct {
-	Took  int                      `json:"took"`
-	Hits  []map[string]interface{} `json:"hits"`
-	Total int                      `json:"total"`
-}
-
-// openObserveErrCodeStreamNotFound is OpenObserve's error code for "Search stream not found".
-const openObserveErrCodeStreamNotFound = 20002
-
-type openObserveErrorBody struct {
-	Code int `json:"code"`
-}
+type OpenObserveResponse = ooclient.SearchResponse
 
+// Client queries the log, event and trace streams of an OpenObserve
+// organization. The embedded client authenticates and executes its requests.
 type Client struct {
-	baseURL      string
-	org          string
+	*ooclient.Client
 	stream       string
 	eventsStream string
-	user         string
-	token        string
-	httpClient   *http.Client
+	tracesStream string
 	logger       *slog.Logger
+	// scheduler, when set, bounds the concurrent searches of all clients
+	// sharing it.
+	scheduler *scheduler.Scheduler
+	// formats, when set, detects the format of application log lines.
+	formats *formats.Set
+	// stats caches the statistics of the streams.
+	stats statsCache
+	// plans, when set, caches the SQL of log queries for all clients
+	// sharing it.
+	plans *PlanCache
+	// tiebreakers order log lines sharing a timestamp; when nil, the
+	// tiebreakers of fields do.
+	tiebreakers []string
+	// fields maps the fields of log queries and entries to stream columns.
+	fields FieldMapping
+	// gatewayStream, when set, holds the gateway access logs instead of
+	// stream.
+	gatewayStream string
+	// schema, when set, validates the hits of log queries against the
+	// fields the parsers expect.
+	schema *SchemaValidator
+	// routes, when set, send the component and workflow logs queries of
+	// some scopes to other streams than stream.
+	routes ooclient.StreamRoutes
+	// buildPlanes are the streams workflow logs are read from, one per
+	// build plane, when set.
+	buildPlanes []BuildPlane
+	// rollupStream, when set, holds the level rollups of the logs stream,
+	// which cover the range of rollupCoverage.
+	rollupStream   string
+	rollupCoverage *rollups.Coverage
+	// retention, when set, caches the data retention of the streams logs
+	// queries are clamped to.
+	retention *retentionCache
 }
 
 func NewClient(baseURL, org, stream, eventsStream, user, token string, logger *slog.Logger) *Client {
 	return &Client{
-		baseURL:      strings.TrimSuffix(baseURL, "/"),
-		org:          org,
+		Client:       ooclient.NewClient(baseURL, org, user, token, logger),
 		stream:       stream,
 		eventsStream: eventsStream,
-		user:         user,
-		token:        token,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		logger: logger,
+		logger:       logger,
+		fields:       DefaultFieldMapping,
 	}
 }
 
-// executeSearchQuery executes a search query against OpenObserve and returns the parsed response
-func (c *Client) executeSearchQuery(ctx context.Context, queryJSON []byte) (*OpenObserveResponse, error) {
-	url := fmt.Sprintf("%s/api/%s/_search", c.baseURL, c.org)
+// SetScheduler makes searches wait for a slot of s, in the class of their context.
+func (c *Client) SetScheduler(s *scheduler.Scheduler) {
+	c.scheduler = s
+}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(queryJSON))
-	if err != nil {
-		c.logger.Error("Failed to create request", slog.Any("error", err))
-		return nil, fmt.Errorf("failed to create request: %w", err)
+// SetSortTiebreakers orders the log lines sharing a timestamp by columns,
+// which must be valid stream field names, instead of the tiebreakers of the
+// field mapping.
+func (c *Client) SetSortTiebreakers(columns []string) {
+	c.tiebreakers = columns
+}
+
+// sortTiebreakers returns the columns ordering log lines sharing a timestamp.
+func (c *Client) sortTiebreakers() []string {
+	if c.tiebreakers == nil {
+		return c.fields.SortTiebreakers()
 	}
+	return c.tiebreakers
+}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.SetBasicAuth(c.user, c.token)
+// SetFieldMapping makes the log queries of the client filter by, and its
+// log entries read, the columns of m instead of DefaultFieldMapping.
+func (c *Client) SetFieldMapping(m FieldMapping) {
+	c.fields = m
+}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		c.logger.Error("Failed to execute search request against OpenObserve", slog.Any("error", err))
-		return nil, fmt.Errorf("failed to execute request: %w", err)
-	}
-	defer resp.Body.Close()
+// SetPlanCache caches the SQL of the log queries of the client in p.
+func (c *Client) SetPlanCache(p *PlanCache) {
+	c.plans = p
+}
 
-	// Read response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		c.logger.Error("Failed to read response body returned by OpenObserve", slog.Any("error", err))
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
+// SetSchemaValidator enables strict parsing: the hits of log queries are
+// validated by v, and results summarize the malformed ones.
+func (c *Client) SetSchemaValidator(v *SchemaValidator) {
+	c.schema = v
+}
 
-	if resp.StatusCode != http.StatusOK {
-		// OpenObserve only creates a stream on first ingest; querying one that has never
-		// received data 400s with this code instead of returning zero hits like an
-		// existing-but-empty index would. Treat it as "no data yet", not a failure.
-		var oErr openObserveErrorBody
-		if json.Unmarshal(body, &oErr) == nil && oErr.Code == openObserveErrCodeStreamNotFound {
-			return &OpenObserveResponse{Hits: []map[string]interface{}{}}, nil
+// SetFormats makes application log entries carry the fields that s detects
+// in their log lines.
+func (c *Client) SetFormats(s *formats.Set) {
+	c.formats = s
+}
+
+// executeSearchQuery executes a search query against OpenObserve and returns the parsed response
+func (c *Client) executeSearchQuery(ctx context.Context, queryJSON []byte) (*OpenObserveResponse, error) {
+	return c.executeSearch(ctx, "logs", queryJSON)
+}
+
+// executeSearch executes a search query against streams of the given type.
+func (c *Client) executeSearch(ctx context.Context, streamType string, queryJSON []byte) (*OpenObserveResponse, error) {
+	return c.executeSearchOrg(ctx, c.Org(), streamType, queryJSON)
+}
+
+// executeSearchOrg executes a search query against streams of the given
+// type of the organization org.
+func (c *Client) executeSearchOrg(ctx context.Context, org, streamType string, queryJSON []byte) (*OpenObserveResponse, error) {
+	if c.scheduler != nil {
+		release, err := c.scheduler.Acquire(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to schedule search: %w", err)
 		}
-		c.logger.Error("OpenObserve returned error",
-			slog.Int("statusCode", resp.StatusCode),
-			slog.String("body", string(body)))
-		return nil, fmt.Errorf("openobserve returned status %d: %s", resp.StatusCode, string(body))
+		defer release()
 	}
 
-	var openObserveResp OpenObserveResponse
-	if err := json.Unmarshal(body, &openObserveResp); err != nil {
-		c.logger.Error("Failed to unmarshal response from OpenObserve", slog.Any("error", err))
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	c.debugSearch(ctx, org, streamType, queryJSON)
+	resp, err := c.SearchOrg(ctx, org, streamType, queryJSON)
+	if errors.Is(err, ooclient.ErrStreamNotFound) {
+		// OpenObserve only creates a stream on first ingest; querying one that has never
+		// received data 400s instead of returning zero hits like an existing-but-empty
+		// index would. Treat it as "no data yet", not a failure.
+		return &OpenObserveResponse{Hits: []map[string]interface{}{}}, nil
 	}
-
-	return &openObserveResp, nil
+	return resp, err
 }
 
 // extractTotalCount extracts the total count from a count query response.
@@ -213,86 +400,278 @@ func extractTotalCount(resp *OpenObserveResponse) int {
 	return 0
 }
 
+// GetComponentLogs queries OpenObserve for the logs of components. Scopes
+// routed to several streams are queried in each of them, and the results
+// merged.
 func (c *Client) GetComponentLogs(ctx context.Context, params ComponentLogsParams) (*ComponentLogsResult, error) {
-	queryJSON, err := generateComponentLogsQuery(params, c.stream, c.logger)
+	if params.Tiebreakers == nil {
+		params.Tiebreakers = c.sortTiebreakers()
+	}
+	if params.Fields == nil {
+		params.Fields = &c.fields
+	}
+	streams := c.componentStreams(params)
+	if len(streams) > 1 {
+		return c.getComponentLogsAcross(ctx, params, streams)
+	}
+	return c.getComponentLogs(ctx, params, streams[0])
+}
+
+// getComponentLogs runs a component logs query against one stream.
+func (c *Client) getComponentLogs(ctx context.Context, params ComponentLogsParams, stream string) (*ComponentLogsResult, error) {
+	var clamp PartialResult
+	params.StartTime = c.clampToRetention(ctx, c.Org(), stream, params.StartTime, &clamp)
+	if clamp.RangeClamped && !params.EndTime.After(params.StartTime) {
+		return &ComponentLogsResult{Logs: []ComponentLogsEntry{}, PartialResult: clamp}, nil
+	}
+
+	queryJSON, err := generateComponentLogsQuery(params, stream, c.plans, c.logger)
 	if err != nil {
 		c.logger.Error("Failed to marshal query", slog.Any("error", err))
 		return nil, fmt.Errorf("failed to marshal query: %w", err)
 	}
 
 	// Execute the search query
-	openObserveResp, err := c.executeSearchQuery(ctx, queryJSON)
+	openObserveResp, timedOut, err := c.searchWithinBudget(ctx, c.Org(), queryJSON, params.Budget)
 	if err != nil {
 		return nil, err
 	}
+	if timedOut {
+		result := &ComponentLogsResult{Logs: []ComponentLogsEntry{}, PartialResult: clamp}
+		result.warn(fmt.Sprintf(warningHitsBudget, params.Budget))
+		return result, nil
+	}
 
 	// Convert to LogEntry format
 	logs := make([]ComponentLogsEntry, 0, len(openObserveResp.Hits))
+	strict := c.schema.newValidator(hitKindApplication, c.fields.applicationHitSchema())
 	for _, hit := range openObserveResp.Hits {
+		strict.check(hit)
 		// Extract timestamp
 		timestamp := int64(0)
 		if ts, ok := hit["_timestamp"].(float64); ok {
 			timestamp = int64(ts)
 		}
 		entry := c.parseApplicationLogEntry(timestamp, hit)
+		entry.Extracted = Extract(entry.Log, params.Extract)
 		logs = append(logs, entry)
 	}
 
 	// Execute a separate count query to get the true total number of matching logs
-	countQueryJSON, err := generateComponentLogsCountQuery(params, c.stream, c.logger)
+	countQueryJSON, err := generateComponentLogsCountQuery(params, stream, c.plans, c.logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate component logs count query: %w", err)
 	}
-	countResp, err := c.executeSearchQuery(ctx, countQueryJSON)
+	countResp, timedOut, err := c.searchWithinBudget(ctx, c.Org(), countQueryJSON, params.Budget)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute component logs count query: %w", err)
 	}
 
-	return &ComponentLogsResult{
-		Logs:       logs,
-		TotalCount: extractTotalCount(countResp),
-		Took:       openObserveResp.Took,
+	result := &ComponentLogsResult{
+		Logs:          logs,
+		Took:          openObserveResp.Took,
+		ParseErrors:   strict.result(),
+		PartialResult: clamp,
+	}
+	result.TotalCount = countTotal(countResp, timedOut, params.Offset, len(logs), params.Budget, &result.PartialResult)
+	if openObserveResp.IsPartial {
+		result.warn(warningPartialData)
+	}
+	return result, nil
+}
+
+// GetLogSources queries OpenObserve for the distinct components and
+// environments in a namespace that produced logs in the given time window,
+// with the time of their latest log and the number of log lines.
+func (c *Client) GetLogSources(ctx context.Context, params LogSourcesParams) (*LogSourcesResult, error) {
+	queryJSON, err := generateLogSourcesQuery(params, c.stream, c.fields, c.logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate log sources query: %w", err)
+	}
+
+	openObserveResp, err := c.executeSearchQuery(ctx, queryJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	sources := make([]LogSource, 0, len(openObserveResp.Hits))
+	for _, hit := range openObserveResp.Hits {
+		source := LogSource{
+			ProjectUID:      stringField(hit, "project_uid"),
+			ProjectName:     stringField(hit, "project_name"),
+			ComponentUID:    stringField(hit, "component_uid"),
+			ComponentName:   stringField(hit, "component_name"),
+			EnvironmentUID:  stringField(hit, "environment_uid"),
+			EnvironmentName: stringField(hit, "environment_name"),
+		}
+		if v, ok := hit["last_seen"].(float64); ok {
+			source.LastSeen = time.UnixMicro(int64(v))
+		}
+		if v, ok := hit["log_count"].(float64); ok {
+			source.LogCount = int(v)
+		}
+		sources = append(sources, source)
+	}
+
+	return &LogSourcesResult{
+		Sources: sources,
+		Took:    openObserveResp.Took,
+	}, nil
+}
+
+// GetComponentLevelHistogram queries OpenObserve for the number of log lines
+// per level of a single component, bucketed by params.Interval. Buckets are
+// returned in ascending time order; lines without a level are counted as
+// "UNKNOWN". Histograms of whole minutes are read from the level rollups
+// when they cover the time window.
+func (c *Client) GetComponentLevelHistogram(ctx context.Context, params LevelHistogramParams) (*LevelHistogramResult, error) {
+	if innerStart, innerEnd, ok := c.levelRollupsWindow(params); ok {
+		return c.getLevelHistogramFromRollups(ctx, params, innerStart, innerEnd)
+	}
+	return c.getComponentLevelHistogram(ctx, params)
+}
+
+// getComponentLevelHistogram reads a level histogram from the logs stream.
+func (c *Client) getComponentLevelHistogram(ctx context.Context, params LevelHistogramParams) (*LevelHistogramResult, error) {
+	queryJSON, err := generateComponentLevelHistogramQuery(params, c.stream, c.fields, c.logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate level histogram query: %w", err)
+	}
+
+	openObserveResp, err := c.executeSearchQuery(ctx, queryJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LevelHistogramResult{
+		Buckets: parseLevelHistogram(openObserveResp.Hits),
+		Took:    openObserveResp.Took,
 	}, nil
 }
 
+// parseLevelHistogram parses the (bucket, level, count) rows of a level
+// histogram query into buckets, in the order of the rows.
+func parseLevelHistogram(hits []map[string]interface{}) []LevelHistogramBucket {
+	buckets := make([]LevelHistogramBucket, 0)
+	index := make(map[time.Time]int)
+	for _, hit := range hits {
+		bucketTime, ok := parseHistogramTime(hit["bucket"])
+		if !ok {
+			continue
+		}
+		level := strings.ToUpper(strings.TrimSpace(stringField(hit, "level")))
+		if level == "" {
+			level = "UNKNOWN"
+		}
+		count := 0
+		if v, ok := hit["count"].(float64); ok {
+			count = int(v)
+		}
+
+		i, ok := index[bucketTime]
+		if !ok {
+			i = len(buckets)
+			index[bucketTime] = i
+			buckets = append(buckets, LevelHistogramBucket{Time: bucketTime, Counts: map[string]int{}})
+		}
+		buckets[i].Counts[level] += count
+	}
+	return buckets
+}
+
+// parseHistogramTime parses a histogram() bucket value, which OpenObserve
+// returns either as a UTC timestamp string or as epoch microseconds.
+func parseHistogramTime(v interface{}) (time.Time, bool) {
+	switch t := v.(type) {
+	case string:
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05"} {
+			if parsed, err := time.ParseInLocation(layout, t, time.UTC); err == nil {
+				return parsed, true
+			}
+		}
+	case float64:
+		return time.UnixMicro(int64(t)).UTC(), true
+	}
+	return time.Time{}, false
+}
+
 // GetWorkflowLogs queries OpenObserve for workflow logs filtered by workflow run name.
+// Namespaces routed to several streams are queried in each of them, and the
+// results merged.
 func (c *Client) GetWorkflowLogs(ctx context.Context, params WorkflowLogsParams) (*WorkflowLogsResult, error) {
-	queryJSON, err := generateWorkflowLogsQuery(params, c.stream, c.logger)
+	if params.Tiebreakers == nil {
+		params.Tiebreakers = c.sortTiebreakers()
+	}
+	if params.Fields == nil {
+		params.Fields = &c.fields
+	}
+	sources := c.workflowSources(params)
+	if len(sources) > 1 {
+		return c.getWorkflowLogsAcross(ctx, params, sources)
+	}
+	return c.getWorkflowLogs(ctx, params, sources[0])
+}
+
+// getWorkflowLogs runs a workflow logs query against one source.
+func (c *Client) getWorkflowLogs(ctx context.Context, params WorkflowLogsParams, source workflowSource) (*WorkflowLogsResult, error) {
+	var clamp PartialResult
+	params.StartTime = c.clampToRetention(ctx, source.org, source.stream, params.StartTime, &clamp)
+	if clamp.RangeClamped && !params.EndTime.After(params.StartTime) {
+		return &WorkflowLogsResult{Logs: []WorkflowLogsEntry{}, PartialResult: clamp}, nil
+	}
+
+	queryJSON, err := generateWorkflowLogsQuery(params, source.stream, c.plans, c.logger)
 	if err != nil {
 		c.logger.Error("Failed to marshal query", slog.Any("error", err))
 		return nil, fmt.Errorf("failed to marshal query: %w", err)
 	}
 
-	openObserveResp, err := c.executeSearchQuery(ctx, queryJSON)
+	openObserveResp, timedOut, err := c.searchWithinBudget(ctx, source.org, queryJSON, params.Budget)
 	if err != nil {
 		return nil, err
 	}
+	if timedOut {
+		result := &WorkflowLogsResult{Logs: []WorkflowLogsEntry{}, PartialResult: clamp}
+		result.warn(fmt.Sprintf(warningHitsBudget, params.Budget))
+		return result, nil
+	}
 
 	logs := make([]WorkflowLogsEntry, 0, len(openObserveResp.Hits))
+	strict := c.schema.newValidator(hitKindWorkflow, c.fields.workflowHitSchema())
 	for _, hit := range openObserveResp.Hits {
+		strict.check(hit)
 		timestamp := int64(0)
 		if ts, ok := hit["_timestamp"].(float64); ok {
 			timestamp = int64(ts)
 		}
 		entry := parseWorkflowLogEntry(timestamp, hit)
+		entry.LogLevel, entry.LogLevelInferred = workflowLogLevel(hit, params.fields().Level, entry.Log)
+		entry.Extracted = Extract(entry.Log, params.Extract)
+		entry.Cluster = source.cluster
 		logs = append(logs, entry)
 	}
 
 	// Execute a separate count query to get the true total number of matching workflow logs
-	countQueryJSON, err := generateWorkflowLogsCountQuery(params, c.stream, c.logger)
+	countQueryJSON, err := generateWorkflowLogsCountQuery(params, source.stream, c.plans, c.logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate workflow logs count query: %w", err)
 	}
-	countResp, err := c.executeSearchQuery(ctx, countQueryJSON)
+	countResp, timedOut, err := c.searchWithinBudget(ctx, source.org, countQueryJSON, params.Budget)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute workflow logs count query: %w", err)
 	}
 
-	return &WorkflowLogsResult{
-		Logs:       logs,
-		TotalCount: extractTotalCount(countResp),
-		Took:       openObserveResp.Took,
-	}, nil
+	result := &WorkflowLogsResult{
+		Logs:          logs,
+		Took:          openObserveResp.Took,
+		ParseErrors:   strict.result(),
+		PartialResult: clamp,
+	}
+	result.TotalCount = countTotal(countResp, timedOut, params.Offset, len(logs), params.Budget, &result.PartialResult)
+	if openObserveResp.IsPartial {
+		result.warn(warningPartialData)
+	}
+	return result, nil
 }
 
 // GetComponentEvents queries OpenObserve for Kubernetes events scoped to a component,
@@ -359,8 +738,10 @@ func (c *Client) executeEventsQuery(ctx context.Context, queryJSON, countJSON []
 // parseWorkflowLogEntry parses a workflow log from OpenObserve response.
 func parseWorkflowLogEntry(timestamp int64, source map[string]interface{}) WorkflowLogsEntry {
 	entry := WorkflowLogsEntry{
-		Timestamp: time.UnixMicro(timestamp),
-		Metadata:  make(map[string]interface{}),
+		Timestamp:  time.UnixMicro(timestamp),
+		IngestTime: time.UnixMicro(timestamp),
+		EventTime:  parseEventTime(timestamp, source),
+		Metadata:   make(map[string]interface{}),
 	}
 
 	if log, ok := source["log"].(string); ok {
@@ -369,7 +750,7 @@ func parseWorkflowLogEntry(timestamp int64, source map[string]interface{}) Workf
 
 	// Copy all fields except internal ones into metadata
 	for k, v := range source {
-		if k == "log" || k == "_timestamp" {
+		if k == "log" || k == "_timestamp" || k == eventTimeColumn {
 			continue
 		}
 		entry.Metadata[k] = v
@@ -378,17 +759,36 @@ func parseWorkflowLogEntry(timestamp int64, source map[string]interface{}) Workf
 	return entry
 }
 
+// workflowLogLevel returns the level of a workflow log hit, read from its
+// level column or, when that is empty, inferred from its log line.
+func workflowLogLevel(hit map[string]interface{}, column, log string) (string, bool) {
+	if level, ok := hit[column].(string); ok && strings.TrimSpace(level) != "" {
+		return strings.TrimSpace(level), false
+	}
+	return ExtractLogLevel(log), true
+}
+
+// parseEventTime returns the time the log line was produced, as recorded by the
+// collector in the eventTimeColumn field (epoch seconds with fractional part).
+// Hits without that field fall back to the ingest timestamp.
+func parseEventTime(timestamp int64, source map[string]interface{}) time.Time {
+	if v, ok := source[eventTimeColumn].(float64); ok && v > 0 {
+		return time.UnixMicro(int64(v * 1e6))
+	}
+	return time.UnixMicro(timestamp)
+}
+
 // CreateAlert creates an alert in OpenObserve and returns the backend alert ID.
 func (c *Client) CreateAlert(ctx context.Context, params LogAlertParams) (string, error) {
 	// Generate alert configuration JSON
-	alertJSON, err := generateAlertConfig(params, c.stream, c.logger)
+	alertJSON, err := generateAlertConfig(params, c.stream, c.eventsStream, c.fields, c.logger)
 	if err != nil {
 		c.logger.Error("Failed to generate alert config", slog.Any("error", err))
 		return "", fmt.Errorf("failed to generate alert config: %w", err)
 	}
 
 	// Build the API endpoint
-	url := fmt.Sprintf("%s/api/v2/%s/alerts", c.baseURL, c.org)
+	url := fmt.Sprintf("%s/api/v2/%s/alerts", c.BaseURL(), c.Org())
 
 	// Create request
 	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(alertJSON))
@@ -399,10 +799,9 @@ func (c *Client) CreateAlert(ctx context.Context, params LogAlertParams) (string
 
 	// Set headers
 	req.Header.Set("Content-Type", "application/json")
-	req.SetBasicAuth(c.user, c.token)
 
 	// Execute request
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.Do(req)
 	if err != nil {
 		c.logger.Error("Failed to execute alert creation request", slog.Any("error", err))
 		return "", fmt.Errorf("failed to execute request: %w", err)
@@ -445,7 +844,7 @@ func (c *Client) DeleteAlert(ctx context.Context, alertName string) (string, err
 	}
 
 	// Build the API endpoint
-	url := fmt.Sprintf("%s/api/v2/%s/alerts/%s", c.baseURL, c.org, alertID)
+	url := fmt.Sprintf("%s/api/v2/%s/alerts/%s", c.BaseURL(), c.Org(), alertID)
 
 	// Create request
 	req, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
@@ -455,10 +854,9 @@ func (c *Client) DeleteAlert(ctx context.Context, alertName string) (string, err
 	}
 
 	// Set headers
-	req.SetBasicAuth(c.user, c.token)
 
 	// Execute request
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.Do(req)
 	if err != nil {
 		c.logger.Error("Failed to execute alert deletion request", slog.Any("error", err))
 		return "", fmt.Errorf("failed to execute request: %w", err)
@@ -485,46 +883,61 @@ func (c *Client) DeleteAlert(ctx context.Context, alertName string) (string, err
 
 // getAlertIDByName looks up an alert's ID by its name using the v2 list alerts API.
 func (c *Client) getAlertIDByName(ctx context.Context, name string) (string, error) {
-	url := fmt.Sprintf("%s/api/v2/%s/alerts", c.baseURL, c.org)
+	alerts, err := c.listAlerts(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	for _, alert := range alerts {
+		if alert.Name == name {
+			return alert.AlertID, nil
+		}
+	}
+
+	return "", fmt.Errorf("alert %q not found", name)
+}
+
+// alertListEntry is an alert of the v2 list alerts API. The trigger times are
+// in microseconds since the epoch, and zero for alerts that never fired.
+type alertListEntry struct {
+	AlertID         string `json:"alert_id"`
+	Name            string `json:"name"`
+	Enabled         bool   `json:"enabled"`
+	LastTriggeredAt int64  `json:"last_triggered_at"`
+	LastSatisfiedAt int64  `json:"last_satisfied_at"`
+}
+
+// listAlerts lists the alerts of the organization using the v2 list alerts API.
+func (c *Client) listAlerts(ctx context.Context) ([]alertListEntry, error) {
+	url := fmt.Sprintf("%s/api/v2/%s/alerts", c.BaseURL(), c.Org())
 
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
-	req.SetBasicAuth(c.user, c.token)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to execute request: %w", err)
+		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response body: %w", err)
+		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("openobserve returned status %d: %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("openobserve returned status %d: %s", resp.StatusCode, string(body))
 	}
 
 	var result struct {
-		List []struct {
-			AlertID string `json:"alert_id"`
-			Name    string `json:"name"`
-		} `json:"list"`
+		List []alertListEntry `json:"list"`
 	}
 	if err := json.Unmarshal(body, &result); err != nil {
-		return "", fmt.Errorf("failed to unmarshal response: %w", err)
-	}
-
-	for _, alert := range result.List {
-		if alert.Name == name {
-			return alert.AlertID, nil
-		}
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
-
-	return "", fmt.Errorf("alert %q not found", name)
+	return result.List, nil
 }
 
 // AlertDetail represents the parsed details of an OpenObserve alert.
@@ -532,6 +945,9 @@ type AlertDetail struct {
 	Name           string
 	Enabled        bool
 	SQL            string
+	// SearchPattern is set by backends whose alerts are not SQL queries;
+	// the search pattern is otherwise that of SQL.
+	SearchPattern  string
 	Operator       string
 	Threshold      float64
 	Period         int
@@ -541,6 +957,11 @@ type AlertDetail struct {
 	ProjectUID     string
 	EnvironmentUID string
 	ComponentUID   string
+	Severity       string
+	// Conditions and ConditionMatch are set for composite alerts; Operator
+	// and Threshold then describe the search pattern condition.
+	Conditions     []AlertCondition
+	ConditionMatch string
 }
 
 // UpdateAlert updates an alert in OpenObserve by name and returns the alert ID.
@@ -556,14 +977,14 @@ func (c *Client) UpdateAlert(ctx context.Context, alertName string, params LogAl
 	params.Name = &alertName
 
 	// Generate alert configuration JSON
-	alertJSON, err := generateAlertConfig(params, c.stream, c.logger)
+	alertJSON, err := generateAlertConfig(params, c.stream, c.eventsStream, c.fields, c.logger)
 	if err != nil {
 		c.logger.Error("Failed to generate alert config", slog.Any("error", err))
 		return "", fmt.Errorf("failed to generate alert config: %w", err)
 	}
 
 	// Build the API endpoint
-	url := fmt.Sprintf("%s/api/v2/%s/alerts/%s", c.baseURL, c.org, alertID)
+	url := fmt.Sprintf("%s/api/v2/%s/alerts/%s", c.BaseURL(), c.Org(), alertID)
 
 	// Create request
 	req, err := http.NewRequestWithContext(ctx, "PUT", url, bytes.NewBuffer(alertJSON))
@@ -574,10 +995,9 @@ func (c *Client) UpdateAlert(ctx context.Context, alertName string, params LogAl
 
 	// Set headers
 	req.Header.Set("Content-Type", "application/json")
-	req.SetBasicAuth(c.user, c.token)
 
 	// Execute request
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.Do(req)
 	if err != nil {
 		c.logger.Error("Failed to execute alert update request", slog.Any("error", err))
 		return "", fmt.Errorf("failed to execute request: %w", err)
@@ -610,17 +1030,20 @@ func (c *Client) GetAlert(ctx context.Context, alertName string) (*AlertDetail,
 	if err != nil {
 		return nil, fmt.Errorf("failed to find alert %q: %w", alertName, err)
 	}
+	return c.getAlertByID(ctx, alertID)
+}
 
-	url := fmt.Sprintf("%s/api/v2/%s/alerts/%s", c.baseURL, c.org, alertID)
+// getAlertByID fetches the details of an alert by its ID.
+func (c *Client) getAlertByID(ctx context.Context, alertID string) (*AlertDetail, error) {
+	url := fmt.Sprintf("%s/api/v2/%s/alerts/%s", c.BaseURL(), c.Org(), alertID)
 
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		c.logger.Error("Failed to create request", slog.Any("error", err))
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
-	req.SetBasicAuth(c.user, c.token)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.Do(req)
 	if err != nil {
 		c.logger.Error("Failed to execute get alert request", slog.Any("error", err))
 		return nil, fmt.Errorf("failed to execute request: %w", err)
@@ -691,56 +1114,101 @@ func (c *Client) GetAlert(ctx context.Context, alertName string) (*AlertDetail,
 		if v, ok := ca["componentUid"].(string); ok {
 			detail.ComponentUID = v
 		}
+		if v, ok := ca["severity"].(string); ok {
+			detail.Severity = v
+		}
+		if v, ok := ca["conditions"].(string); ok {
+			if err := parseAlertConditions(v, detail); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	return detail, nil
 }
 
-// parseApplicationLogEntry parses an application log from OpenObserve response
+// parseAlertConditions decodes the conditions of a composite alert stored in
+// its context attributes by generateAlertConfig. The first condition is the
+// search pattern condition; the trigger condition of a composite alert only
+// checks that the query returned a row, so its operator and threshold are
+// taken from here.
+func parseAlertConditions(encoded string, detail *AlertDetail) error {
+	var stored struct {
+		Match      string           `json:"match"`
+		Conditions []AlertCondition `json:"conditions"`
+	}
+	if err := json.Unmarshal([]byte(encoded), &stored); err != nil {
+		return fmt.Errorf("failed to decode alert conditions: %w", err)
+	}
+	if len(stored.Conditions) == 0 {
+		return nil
+	}
+	primary := stored.Conditions[0]
+	operator, err := mapOperator(primary.Operator)
+	if err != nil {
+		return fmt.Errorf("failed to decode alert conditions: %w", err)
+	}
+	detail.Operator = operator
+	detail.Threshold = float64(primary.Threshold)
+	detail.Conditions = stored.Conditions[1:]
+	detail.ConditionMatch = stored.Match
+	return nil
+}
+
+// parseApplicationLogEntry parses an application log from OpenObserve
+// response, reading the columns of the client's field mapping.
 func (c *Client) parseApplicationLogEntry(timestamp int64, source map[string]interface{}) ComponentLogsEntry {
 	entry := ComponentLogsEntry{
-		Timestamp: time.UnixMicro(timestamp),
+		Timestamp:  time.UnixMicro(timestamp),
+		IngestTime: time.UnixMicro(timestamp),
+		EventTime:  parseEventTime(timestamp, source),
 	}
 
 	// Parse fields with type assertions
 	if log, ok := source["log"].(string); ok {
 		entry.Log = log
 	}
-	if logLevel, ok := source["logLevel"].(string); ok && strings.TrimSpace(logLevel) != "" {
-		entry.LogLevel = strings.TrimSpace(logLevel)
-	} else {
-		entry.LogLevel = extractLogLevel(entry.Log)
-	}
-	if v, ok := source["kubernetes_labels_openchoreo_dev_component_uid"].(string); ok {
+	if v, ok := source[c.fields.ComponentUID].(string); ok {
 		entry.ComponentUID = v
 	}
-	if v, ok := source["kubernetes_labels_openchoreo_dev_component"].(string); ok {
+	if v, ok := source[c.fields.ComponentName].(string); ok {
 		entry.ComponentName = v
 	}
-	if v, ok := source["kubernetes_labels_openchoreo_dev_environment_uid"].(string); ok {
+	if v, ok := source[c.fields.EnvironmentUID].(string); ok {
 		entry.EnvironmentUID = v
 	}
-	if v, ok := source["kubernetes_labels_openchoreo_dev_environment"].(string); ok {
+	if v, ok := source[c.fields.EnvironmentName].(string); ok {
 		entry.EnvironmentName = v
 	}
-	if v, ok := source["kubernetes_labels_openchoreo_dev_project_uid"].(string); ok {
+	if v, ok := source[c.fields.ProjectUID].(string); ok {
 		entry.ProjectUID = v
 	}
-	if v, ok := source["kubernetes_labels_openchoreo_dev_project"].(string); ok {
+	if v, ok := source[c.fields.ProjectName].(string); ok {
 		entry.ProjectName = v
 	}
-	if v, ok := source["kubernetes_labels_openchoreo_dev_namespace"].(string); ok {
+	if v, ok := source[c.fields.Namespace].(string); ok {
 		entry.Namespace = v
 	}
-	if v, ok := source["kubernetes_pod_name"].(string); ok {
+	if v, ok := source[c.fields.PodName].(string); ok {
 		entry.PodName = v
 	}
-	if v, ok := source["kubernetes_namespace_name"].(string); ok {
+	if v, ok := source[c.fields.PodNamespace].(string); ok {
 		entry.PodNamespace = v
 	}
-	if v, ok := source["kubernetes_container_name"].(string); ok {
+	if v, ok := source[c.fields.ContainerName].(string); ok {
 		entry.ContainerName = v
 	}
 
+	if c.formats != nil {
+		entry.Format = c.formats.Detect(entry.ComponentUID, entry.ComponentName, entry.Log)
+	}
+	if logLevel, ok := source[c.fields.Level].(string); ok && strings.TrimSpace(logLevel) != "" {
+		entry.LogLevel = strings.TrimSpace(logLevel)
+	} else if entry.Format != nil && entry.Format.Level != "" {
+		entry.LogLevel = entry.Format.Level
+	} else {
+		entry.LogLevel = ExtractLogLevel(entry.Log)
+	}
+
 	return entry
 }
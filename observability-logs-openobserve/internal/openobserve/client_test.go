@@ -43,11 +43,11 @@ func newTestClient(serverURL string) *Client {
 func TestNewClient(t *testing.T) {
 	c := NewClient("http://localhost:5080/", "myorg", "mystream", "myevents", "user", "pass", testLogger())
 
-	if c.baseURL != "http://localhost:5080" {
-		t.Errorf("expected trailing slash removed, got %q", c.baseURL)
+	if c.BaseURL() != "http://localhost:5080" {
+		t.Errorf("expected trailing slash removed, got %q", c.BaseURL())
 	}
-	if c.org != "myorg" {
-		t.Errorf("unexpected org: %q", c.org)
+	if c.Org() != "myorg" {
+		t.Errorf("unexpected org: %q", c.Org())
 	}
 	if c.stream != "mystream" {
 		t.Errorf("unexpected stream: %q", c.stream)
@@ -55,12 +55,6 @@ func TestNewClient(t *testing.T) {
 	if c.eventsStream != "myevents" {
 		t.Errorf("unexpected events stream: %q", c.eventsStream)
 	}
-	if c.user != "user" {
-		t.Errorf("unexpected user: %q", c.user)
-	}
-	if c.token != "pass" {
-		t.Errorf("unexpected token: %q", c.token)
-	}
 }
 
 func TestGetComponentLogs(t *testing.T) {
@@ -157,6 +151,40 @@ func TestGetComponentLogs(t *testing.T) {
 	}
 }
 
+func TestGetComponentLogs_Tiebreakers(t *testing.T) {
+	var sqls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query struct {
+				SQL string `json:"sql"`
+			} `json:"query"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if !strings.Contains(body.Query.SQL, "count(*)") {
+			sqls = append(sqls, body.Query.SQL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(OpenObserveResponse{Hits: []map[string]interface{}{}})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	params := ComponentLogsParams{Namespace: "test-ns", SortOrder: "asc"}
+	if _, err := client.GetComponentLogs(context.Background(), params); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client.SetSortTiebreakers([]string{"seq"})
+	if _, err := client.GetComponentLogs(context.Background(), params); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(sqls) != 2 ||
+		!strings.HasSuffix(sqls[0], " ORDER BY _timestamp ASC, kubernetes_pod_name ASC, kubernetes_container_name ASC, log ASC") ||
+		!strings.HasSuffix(sqls[1], " ORDER BY _timestamp ASC, seq ASC") {
+		t.Errorf("unexpected queries: %q", sqls)
+	}
+}
+
 func TestGetComponentLogs_ServerError(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -422,6 +450,7 @@ func TestGetAlert(t *testing.T) {
 					"projectUid":     "proj-1",
 					"environmentUid": "env-1",
 					"componentUid":   "comp-1",
+					"severity":       "critical",
 				},
 			}
 			w.Header().Set("Content-Type", "application/json")
@@ -466,6 +495,9 @@ func TestGetAlert(t *testing.T) {
 	if detail.ProjectUID != "proj-1" {
 		t.Errorf("expected projectUID 'proj-1', got %q", detail.ProjectUID)
 	}
+	if detail.Severity != "critical" {
+		t.Errorf("expected severity 'critical', got %q", detail.Severity)
+	}
 	if detail.EnvironmentUID != "env-1" {
 		t.Errorf("expected environmentUID 'env-1', got %q", detail.EnvironmentUID)
 	}
@@ -712,9 +744,9 @@ func TestExtractLogLevel(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.log, func(t *testing.T) {
-			got := extractLogLevel(tt.log)
+			got := ExtractLogLevel(tt.log)
 			if got != tt.expected {
-				t.Errorf("extractLogLevel(%q) = %q, want %q", tt.log, got, tt.expected)
+				t.Errorf("ExtractLogLevel(%q) = %q, want %q", tt.log, got, tt.expected)
 			}
 		})
 	}
@@ -750,3 +782,57 @@ func TestParseWorkflowLogEntry(t *testing.T) {
 		t.Error("_timestamp should not be in metadata")
 	}
 }
+
+func TestWorkflowLogLevel(t *testing.T) {
+	for _, tt := range []struct {
+		name         string
+		hit          map[string]interface{}
+		log          string
+		wantLevel    string
+		wantInferred bool
+	}{
+		{"recorded", map[string]interface{}{"logLevel": " warn "}, "ERROR: ignored", "warn", false},
+		{"inferred from the text", map[string]interface{}{}, "npm ERR! Error: build failed", "ERROR", true},
+		{"blank level field", map[string]interface{}{"logLevel": ""}, "Warning: deprecated flag", "WARN", true},
+		{"no keyword", map[string]interface{}{}, "Step 3/7 : RUN make", "INFO", true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			level, inferred := workflowLogLevel(tt.hit, "logLevel", tt.log)
+			if level != tt.wantLevel || inferred != tt.wantInferred {
+				t.Errorf("workflowLogLevel() = %q, %v, want %q, %v", level, inferred, tt.wantLevel, tt.wantInferred)
+			}
+		})
+	}
+}
+
+func TestParseEventTime(t *testing.T) {
+	ingest := time.Date(2025, 1, 1, 12, 0, 5, 0, time.UTC)
+
+	t.Run("uses collector date field", func(t *testing.T) {
+		got := parseEventTime(ingest.UnixMicro(), map[string]interface{}{"date": 1735732800.25})
+		want := time.Date(2025, 1, 1, 12, 0, 0, 250000000, time.UTC)
+		if !got.Equal(want) {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	})
+
+	t.Run("falls back to ingest time", func(t *testing.T) {
+		got := parseEventTime(ingest.UnixMicro(), map[string]interface{}{})
+		if !got.Equal(ingest) {
+			t.Errorf("expected %v, got %v", ingest, got)
+		}
+	})
+}
+
+func TestParseHistogramTime(t *testing.T) {
+	want := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	for _, v := range []interface{}{"2025-01-01T12:00:00", "2025-01-01T12:00:00Z", float64(want.UnixMicro())} {
+		got, ok := parseHistogramTime(v)
+		if !ok || !got.Equal(want) {
+			t.Errorf("parseHistogramTime(%v) = %v, %v", v, got, ok)
+		}
+	}
+	if _, ok := parseHistogramTime(nil); ok {
+		t.Error("expected nil bucket to be rejected")
+	}
+}
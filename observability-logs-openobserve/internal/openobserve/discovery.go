@@ -0,0 +1,114 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// ErrNoTracesStreams is returned by DiscoverTracesStream when the
+// organization has no traces streams.
+var ErrNoTracesStreams = errors.New("the organization has no traces streams")
+
+// defaultTracesStream is the stream OpenObserve writes traces to when the
+// sender does not name one.
+const defaultTracesStream = "default"
+
+// StreamInfo describes a stream of the organization.
+type StreamInfo struct {
+	Name string `json:"name"`
+	// Type is the stream type, such as "logs", "metrics" or "traces".
+	Type string `json:"stream_type"`
+	// Docs is the number of records in the stream.
+	Docs int64 `json:"-"`
+}
+
+// ListStreams returns the streams of the organization of the given type.
+func (c *Client) ListStreams(ctx context.Context, streamType string) ([]StreamInfo, error) {
+	endpoint := fmt.Sprintf("%s/api/%s/streams?type=%s", c.BaseURL(), c.Org(), url.QueryEscape(streamType))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("openobserve returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		List []struct {
+			Name       string `json:"name"`
+			StreamType string `json:"stream_type"`
+			Stats      struct {
+				DocNum int64 `json:"doc_num"`
+			} `json:"stats"`
+		} `json:"list"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	streams := make([]StreamInfo, 0, len(result.List))
+	for _, s := range result.List {
+		// Older OpenObserve versions ignore the type parameter.
+		if s.StreamType != "" && s.StreamType != streamType {
+			continue
+		}
+		streams = append(streams, StreamInfo{Name: s.Name, Type: streamType, Docs: s.Stats.DocNum})
+	}
+	return streams, nil
+}
+
+// DiscoverTracesStream validates the traces stream of the client against the
+// traces streams of the organization and, if it does not exist, replaces it
+// with the one that does: the OpenObserve default stream when present,
+// otherwise the stream with the most records. A configured stream that
+// exists always wins, so it acts as an override. It returns the stream set
+// on the client, which is left unchanged on errors.
+func (c *Client) DiscoverTracesStream(ctx context.Context) (string, error) {
+	streams, err := c.ListStreams(ctx, "traces")
+	if err != nil {
+		return c.tracesStream, fmt.Errorf("failed to list traces streams: %w", err)
+	}
+	if len(streams) == 0 {
+		return c.tracesStream, ErrNoTracesStreams
+	}
+
+	hasStream := func(name string) bool {
+		return slices.ContainsFunc(streams, func(s StreamInfo) bool { return s.Name == name })
+	}
+	switch {
+	case c.tracesStream != "" && hasStream(c.tracesStream):
+	case hasStream(defaultTracesStream):
+		c.tracesStream = defaultTracesStream
+	default:
+		// Ties are broken by name so that discovery is deterministic.
+		slices.SortFunc(streams, func(a, b StreamInfo) int {
+			return cmp.Or(cmp.Compare(b.Docs, a.Docs), strings.Compare(a.Name, b.Name))
+		})
+		c.tracesStream = streams[0].Name
+	}
+	return c.tracesStream, nil
+}
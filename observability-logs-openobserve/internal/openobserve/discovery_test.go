@@ -0,0 +1,78 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDiscoverTracesStream(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured string
+		streams    []string
+		docs       []int64
+		want       string
+		wantErr    error
+	}{
+		{name: "configured stream exists", configured: "otel", streams: []string{"default", "otel"}, docs: []int64{5, 1}, want: "otel"},
+		{name: "falls back to the default stream", configured: "missing", streams: []string{"otel", "default"}, docs: []int64{9, 1}, want: "default"},
+		{name: "picks the largest stream", configured: "missing", streams: []string{"b", "a", "c"}, docs: []int64{3, 3, 1}, want: "a"},
+		{name: "unset stream", streams: []string{"otel"}, docs: []int64{0}, want: "otel"},
+		{name: "no traces streams", configured: "default", want: "default", wantErr: ErrNoTracesStreams},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/default/streams" || r.URL.Query().Get("type") != "traces" {
+					t.Errorf("unexpected request: %s", r.URL)
+				}
+				type stream struct {
+					Name       string `json:"name"`
+					StreamType string `json:"stream_type"`
+					Stats      struct {
+						DocNum int64 `json:"doc_num"`
+					} `json:"stats"`
+				}
+				list := []stream{{Name: "app", StreamType: "logs"}}
+				for i, name := range tt.streams {
+					s := stream{Name: name, StreamType: "traces"}
+					s.Stats.DocNum = tt.docs[i]
+					list = append(list, s)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(map[string]interface{}{"list": list})
+			}))
+			defer server.Close()
+
+			client := newTestClient(server.URL)
+			client.SetTracesStream(tt.configured)
+			got, err := client.DiscoverTracesStream(context.Background())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DiscoverTracesStream() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want || client.tracesStream != tt.want {
+				t.Errorf("DiscoverTracesStream() = %q (client %q), want %q", got, client.tracesStream, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiscoverTracesStream_ServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	client.SetTracesStream("traces")
+	if got, err := client.DiscoverTracesStream(context.Background()); err == nil || got != "traces" {
+		t.Errorf("expected an error keeping the configured stream, got %q, %v", got, err)
+	}
+}
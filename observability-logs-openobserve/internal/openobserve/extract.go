@@ -0,0 +1,50 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"regexp"
+)
+
+// Extractor extracts fields from the log lines matched by Regexp at query
+// time. The named capture groups of Regexp extract the fields they name.
+// Name, when set, names the field holding the first unnamed capture group,
+// or the whole match when Regexp has no capture groups.
+type Extractor struct {
+	Name   string
+	Regexp *regexp.Regexp
+}
+
+// Extract returns the fields extractors find in log, or nil when none of them
+// matches. Groups that did not participate in the match are left out.
+func Extract(log string, extractors []Extractor) map[string]string {
+	var fields map[string]string
+	for _, e := range extractors {
+		m := e.Regexp.FindStringSubmatchIndex(log)
+		if m == nil {
+			continue
+		}
+		if fields == nil {
+			fields = make(map[string]string)
+		}
+		unnamed := e.Name
+		for i, group := range e.Regexp.SubexpNames() {
+			start, end := m[2*i], m[2*i+1]
+			if start < 0 {
+				continue
+			}
+			switch {
+			case group != "":
+				fields[group] = log[start:end]
+			case i > 0 && unnamed != "":
+				fields[unnamed] = log[start:end]
+				unnamed = ""
+			}
+		}
+		if unnamed != "" && e.Regexp.NumSubexp() == 0 {
+			fields[unnamed] = log[m[0]:m[1]]
+		}
+	}
+	return fields
+}
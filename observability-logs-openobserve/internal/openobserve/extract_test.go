@@ -0,0 +1,33 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestExtract(t *testing.T) {
+	extractors := []Extractor{
+		{Name: "orderId", Regexp: regexp.MustCompile(`order=(\w+)`)},
+		{Regexp: regexp.MustCompile(`status=(?P<status>\d{3})(?: took=(?P<took>\d+)ms)?`)},
+		{Name: "slow", Regexp: regexp.MustCompile(`SLOW`)},
+	}
+
+	tests := []struct {
+		log  string
+		want map[string]string
+	}{
+		{"created order=A17 status=201 took=35ms", map[string]string{"orderId": "A17", "status": "201", "took": "35"}},
+		{"status=503", map[string]string{"status": "503"}},
+		{"SLOW query for order=B2", map[string]string{"orderId": "B2", "slow": "SLOW"}},
+		{"ready", nil},
+	}
+	for _, tt := range tests {
+		if got := Extract(tt.log, extractors); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Extract(%q) = %v, want %v", tt.log, got, tt.want)
+		}
+	}
+}
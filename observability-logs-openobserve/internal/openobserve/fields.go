@@ -0,0 +1,189 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FieldMapping maps the fields the adapter filters log lines by and reads
+// from their hits to the columns of the logs stream. The defaults are the
+// columns of the Fluent Bit pipeline of the OpenChoreo observability plane;
+// deployments flattening Kubernetes labels differently, for example with
+// Vector, remap them instead of forking the queries.
+type FieldMapping struct {
+	Level           string
+	Namespace       string
+	ProjectUID      string
+	ProjectName     string
+	EnvironmentUID  string
+	EnvironmentName string
+	ComponentUID    string
+	ComponentName   string
+	PodName         string
+	PodNamespace    string
+	ContainerName   string
+}
+
+// DefaultFieldMapping is the field mapping of clients that set none.
+var DefaultFieldMapping = FieldMapping{
+	Level:           "logLevel",
+	Namespace:       "kubernetes_labels_openchoreo_dev_namespace",
+	ProjectUID:      "kubernetes_labels_openchoreo_dev_project_uid",
+	ProjectName:     "kubernetes_labels_openchoreo_dev_project",
+	EnvironmentUID:  "kubernetes_labels_openchoreo_dev_environment_uid",
+	EnvironmentName: "kubernetes_labels_openchoreo_dev_environment",
+	ComponentUID:    "kubernetes_labels_openchoreo_dev_component_uid",
+	ComponentName:   "kubernetes_labels_openchoreo_dev_component",
+	PodName:         "kubernetes_pod_name",
+	PodNamespace:    "kubernetes_namespace_name",
+	ContainerName:   "kubernetes_container_name",
+}
+
+// fieldNames are the names of the fields of a FieldMapping accepted by
+// ParseFieldMapping, in the order of the struct.
+var fieldNames = []string{
+	"level", "namespace", "projectUid", "projectName", "environmentUid", "environmentName",
+	"componentUid", "componentName", "podName", "podNamespace", "containerName",
+}
+
+// column returns a pointer to the column of the field named name, or nil
+// for unknown names.
+func (m *FieldMapping) column(name string) *string {
+	switch name {
+	case "level":
+		return &m.Level
+	case "namespace":
+		return &m.Namespace
+	case "projectUid":
+		return &m.ProjectUID
+	case "projectName":
+		return &m.ProjectName
+	case "environmentUid":
+		return &m.EnvironmentUID
+	case "environmentName":
+		return &m.EnvironmentName
+	case "componentUid":
+		return &m.ComponentUID
+	case "componentName":
+		return &m.ComponentName
+	case "podName":
+		return &m.PodName
+	case "podNamespace":
+		return &m.PodNamespace
+	case "containerName":
+		return &m.ContainerName
+	}
+	return nil
+}
+
+// ParseFieldMapping parses a comma-separated list of <field>=<column> pairs,
+// such as "componentUid=k8s_label_component_uid,level=severity", into a
+// mapping. Fields left out keep their default column.
+func ParseFieldMapping(s string) (FieldMapping, error) {
+	m := DefaultFieldMapping
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, column, ok := strings.Cut(pair, "=")
+		name, column = strings.TrimSpace(name), strings.TrimSpace(column)
+		target := m.column(name)
+		if !ok || target == nil {
+			return FieldMapping{}, fmt.Errorf("invalid field mapping %q: expected <field>=<column> with field one of %s", pair, strings.Join(fieldNames, ", "))
+		}
+		if !streamFieldName.MatchString(column) {
+			return FieldMapping{}, fmt.Errorf("invalid column %q for field %s: must be a stream field name", column, name)
+		}
+		*target = column
+	}
+	return m, nil
+}
+
+// signature returns the columns of the mapping, for the keys of the plan cache.
+func (m FieldMapping) signature() string {
+	columns := make([]string, len(fieldNames))
+	for i, name := range fieldNames {
+		columns[i] = *m.column(name)
+	}
+	return strings.Join(columns, planListSeparator)
+}
+
+// searchColumn returns the column of a field of search queries.
+func (m FieldMapping) searchColumn(field string) string {
+	switch field {
+	case "level":
+		return m.Level
+	case "pod":
+		return m.PodName
+	case "container":
+		return m.ContainerName
+	case "component":
+		return m.ComponentName
+	case "environment":
+		return m.EnvironmentName
+	case "project":
+		return m.ProjectName
+	}
+	return "log"
+}
+
+// applicationHitSchema returns the fields the parser reads from application
+// log hits under the mapping.
+func (m FieldMapping) applicationHitSchema() map[string]hitField {
+	schema := map[string]hitField{
+		"_timestamp":    {typ: fieldNumber, required: true},
+		"log":           {typ: fieldString, required: true},
+		eventTimeColumn: {typ: fieldNumber},
+	}
+	for _, name := range fieldNames {
+		schema[*m.column(name)] = hitField{typ: fieldString}
+	}
+	return schema
+}
+
+// workflowHitSchema returns the fields the parser reads from workflow log
+// hits under the mapping.
+func (m FieldMapping) workflowHitSchema() map[string]hitField {
+	return map[string]hitField{
+		"_timestamp":    {typ: fieldNumber, required: true},
+		"log":           {typ: fieldString, required: true},
+		eventTimeColumn: {typ: fieldNumber},
+		m.PodName:       {typ: fieldString},
+	}
+}
+
+// scopeConditions returns the conditions restricting log lines to a
+// namespace and, when set, to a project, environment and component.
+func (m FieldMapping) scopeConditions(namespace, projectID, environmentID, componentID string) []string {
+	conditions := []string{m.Namespace + " = '" + escapeSQLString(namespace) + "'"}
+	if projectID != "" {
+		conditions = append(conditions, m.ProjectUID+" = '"+escapeSQLString(projectID)+"'")
+	}
+	if environmentID != "" {
+		conditions = append(conditions, m.EnvironmentUID+" = '"+escapeSQLString(environmentID)+"'")
+	}
+	if componentID != "" {
+		conditions = append(conditions, m.ComponentUID+" = '"+escapeSQLString(componentID)+"'")
+	}
+	return conditions
+}
+
+// AggregateFields maps the names of the OpenChoreo and Kubernetes fields
+// aggregate queries accept to their columns under the mapping.
+func (m FieldMapping) AggregateFields() map[string]string {
+	columns := make(map[string]string, len(aggregateFieldNames))
+	for _, name := range aggregateFieldNames {
+		columns[name] = m.searchColumn(name)
+	}
+	return columns
+}
+
+// SortTiebreakers returns the columns that order log lines sharing a
+// timestamp under the mapping: the pod, the container and the line itself.
+func (m FieldMapping) SortTiebreakers() []string {
+	return []string{m.PodName, m.ContainerName, "log"}
+}
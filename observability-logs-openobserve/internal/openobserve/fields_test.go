@@ -0,0 +1,207 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseFieldMapping(t *testing.T) {
+	m, err := ParseFieldMapping("")
+	if err != nil || m != DefaultFieldMapping {
+		t.Fatalf("expected the default mapping, got %+v, %v", m, err)
+	}
+
+	m, err = ParseFieldMapping(" componentUid = k8s_label_component_uid, level=severity ")
+	if err != nil {
+		t.Fatalf("ParseFieldMapping() error = %v", err)
+	}
+	if m.ComponentUID != "k8s_label_component_uid" || m.Level != "severity" || m.PodName != DefaultFieldMapping.PodName {
+		t.Errorf("unexpected mapping: %+v", m)
+	}
+
+	for _, invalid := range []string{"component=x", "componentUid", "componentUid=", "level=log level", "level=x;DROP"} {
+		if _, err := ParseFieldMapping(invalid); err == nil {
+			t.Errorf("expected error for %q", invalid)
+		}
+	}
+}
+
+func TestFieldMapping_Queries(t *testing.T) {
+	fields, err := ParseFieldMapping("namespace=ns_label,componentUid=comp_label,level=severity,podName=pod")
+	if err != nil {
+		t.Fatalf("ParseFieldMapping() error = %v", err)
+	}
+	query, err := ParseSearchQuery("level:error pod:api-*")
+	if err != nil {
+		t.Fatalf("ParseSearchQuery() error = %v", err)
+	}
+	params := ComponentLogsParams{
+		Namespace:    "default",
+		ComponentIDs: []string{"c1"},
+		LogLevels:    []string{"ERROR"},
+		Query:        query,
+		Fields:       &fields,
+	}
+	raw, err := generateComponentLogsQuery(params, "default", nil, testLogger())
+	if err != nil {
+		t.Fatalf("generateComponentLogsQuery() error = %v", err)
+	}
+	sql, _ := sqlOf(t, raw)
+	for _, want := range []string{
+		"ns_label = 'default'", "comp_label = 'c1'", "(severity = 'ERROR')",
+		"severity = 'ERROR' AND pod LIKE 'api-%'",
+	} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("expected query to contain %q, got %s", want, sql)
+		}
+	}
+	if strings.Contains(sql, "kubernetes_labels_openchoreo_dev") || strings.Contains(sql, "logLevel") {
+		t.Errorf("expected no default columns in the query, got %s", sql)
+	}
+
+	plans, err := NewPlanCache(8)
+	if err != nil {
+		t.Fatalf("NewPlanCache() error = %v", err)
+	}
+	params.Fields = nil
+	if _, err := generateComponentLogsQuery(params, "default", plans, testLogger()); err != nil {
+		t.Fatalf("generateComponentLogsQuery() error = %v", err)
+	}
+	params.Fields = &fields
+	raw, _ = generateComponentLogsQuery(params, "default", plans, testLogger())
+	if sql, _ := sqlOf(t, raw); !strings.Contains(sql, "ns_label") {
+		t.Errorf("expected the plan of another mapping not to be reused, got %s", sql)
+	}
+}
+
+func TestFieldMapping_Generators(t *testing.T) {
+	fields, err := ParseFieldMapping("level=severity,namespace=ns_label,projectUid=project_label,environmentUid=env_label," +
+		"componentUid=comp_label,projectName=project_name,environmentName=env_name,componentName=comp_name,podName=pod,containerName=container")
+	if err != nil {
+		t.Fatalf("ParseFieldMapping() error = %v", err)
+	}
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	enabled := true
+	name := "errors"
+
+	generators := map[string]func() ([]byte, error){
+		"raw": func() ([]byte, error) {
+			return generateRawQuery(RawQueryParams{
+				Namespace: "default", ProjectID: "p1", EnvironmentID: "e1", ComponentID: "c1",
+				StartTime: start, EndTime: start.Add(time.Hour), SQL: "SELECT * FROM logs",
+			}, "default", fields, testLogger())
+		},
+		"aggregate": func() ([]byte, error) {
+			return generateAggregateQuery(AggregateParams{
+				Namespace: "default", ProjectID: "p1", EnvironmentID: "e1", ComponentID: "c1",
+				StartTime: start, EndTime: start.Add(time.Hour), LogLevels: []string{"ERROR"},
+				GroupBy: []string{"pod", "level"}, Aggregation: AggregationDistinct, Field: "container",
+			}, "default", fields, testLogger())
+		},
+		"restart logs": func() ([]byte, error) {
+			return generateRestartLogsQuery(RestartSummaryParams{
+				Namespace: "default", ProjectID: "p1", EnvironmentID: "e1", ComponentID: "c1",
+				StartTime: start, EndTime: start.Add(time.Hour),
+			}, "default", fields, testLogger())
+		},
+		"pod logs": func() ([]byte, error) {
+			return generatePodLogsQuery(PodLogsParams{
+				Namespace: "default", PodName: "api-0", StartTime: start, EndTime: start.Add(time.Hour),
+			}, "default", fields, testLogger())
+		},
+		"alert": func() ([]byte, error) {
+			return generateAlertConfig(LogAlertParams{
+				Name: &name, EnvironmentUID: "e1", ComponentUID: "c1", SearchPattern: "error",
+				Operator: "gt", ThresholdValue: 10, Window: "5m", Interval: "1m", Enabled: &enabled,
+			}, "default", "k8s_events", fields, testLogger())
+		},
+		"composite alert": func() ([]byte, error) {
+			return generateAlertConfig(LogAlertParams{
+				Name: &name, EnvironmentUID: "e1", ComponentUID: "c1", SearchPattern: "error",
+				Operator: "gt", ThresholdValue: 10, Window: "5m", Interval: "1m", Enabled: &enabled,
+				Conditions: []AlertCondition{{Source: AlertSourceLogs, Query: "panic", Operator: "gte", Threshold: 1}},
+			}, "default", "k8s_events", fields, testLogger())
+		},
+		"component logs tiebreakers": func() ([]byte, error) {
+			return generateComponentLogsQuery(ComponentLogsParams{
+				Namespace: "default", Fields: &fields, Tiebreakers: fields.SortTiebreakers(),
+			}, "default", nil, testLogger())
+		},
+	}
+	wants := map[string][]string{
+		"raw":                        {"ns_label = 'default'", "project_label = 'p1'", "env_label = 'e1'", "comp_label = 'c1'"},
+		"aggregate":                  {"ns_label = 'default'", "comp_label = 'c1'", "(severity = 'ERROR')", `"pod" AS g0`, `"severity" AS g1`, `count(DISTINCT "container")`},
+		"restart logs":               {"ns_label = 'default'", "project_label = 'p1'", "env_label = 'e1'", "comp_label = 'c1'"},
+		"pod logs":                   {"ns_label = 'default'", "pod = 'api-0'", "container ASC"},
+		"alert":                      {"env_label = 'e1'", "comp_label = 'c1'"},
+		"composite alert":            {"env_label = 'e1'", "comp_label = 'c1'"},
+		"component logs tiebreakers": {"pod DESC, container DESC, log DESC"},
+	}
+	for label, generate := range generators {
+		t.Run(label, func(t *testing.T) {
+			raw, err := generate()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var query struct {
+				Query          struct{ SQL string } `json:"query"`
+				QueryCondition struct{ SQL string } `json:"query_condition"`
+			}
+			if err := json.Unmarshal(raw, &query); err != nil {
+				t.Fatalf("invalid JSON: %v", err)
+			}
+			sql := query.Query.SQL + query.QueryCondition.SQL
+			for _, want := range wants[label] {
+				if !strings.Contains(sql, want) {
+					t.Errorf("expected query to contain %q, got %s", want, sql)
+				}
+			}
+			if strings.Contains(sql, "kubernetes_") || strings.Contains(sql, "logLevel") {
+				t.Errorf("expected no default columns in the query, got %s", sql)
+			}
+		})
+	}
+
+	if got := fields.AggregateFields(); got["pod"] != "pod" || got["level"] != "severity" || got["project"] != "project_name" {
+		t.Errorf("unexpected aggregate fields: %v", got)
+	}
+}
+
+func TestFieldMapping_ParsesEntries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(OpenObserveResponse{Hits: []map[string]interface{}{{
+			"_timestamp": float64(1700000000000000),
+			"log":        "boom",
+			"severity":   "ERROR",
+			"comp_label": "c1",
+			"pod":        "api-0",
+		}}})
+	}))
+	defer server.Close()
+
+	fields, err := ParseFieldMapping("componentUid=comp_label,level=severity,podName=pod")
+	if err != nil {
+		t.Fatalf("ParseFieldMapping() error = %v", err)
+	}
+	client := newTestClient(server.URL)
+	client.SetFieldMapping(fields)
+	result, err := client.GetComponentLogs(context.Background(), ComponentLogsParams{Namespace: "default"})
+	if err != nil {
+		t.Fatalf("GetComponentLogs() error = %v", err)
+	}
+	if len(result.Logs) != 1 {
+		t.Fatalf("expected one entry, got %d", len(result.Logs))
+	}
+	if entry := result.Logs[0]; entry.ComponentUID != "c1" || entry.LogLevel != "ERROR" || entry.PodName != "api-0" {
+		t.Errorf("unexpected entry: %+v", entry)
+	}
+}
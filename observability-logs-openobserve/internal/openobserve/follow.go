@@ -0,0 +1,81 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// PodLogsParams holds parameters for reading the logs of a pod in order.
+type PodLogsParams struct {
+	Namespace string
+	PodName   string
+	// StartTime is inclusive: entries logged at StartTime are returned,
+	// after the first Offset of them.
+	StartTime time.Time
+	EndTime   time.Time
+	Offset    int
+	Limit     int
+}
+
+// GetPodLogs returns the application logs of a pod oldest first. Entries
+// logged at the same time are ordered by container and content, so that
+// repeated queries return them in the same order and a reader can resume
+// after the entries it already has by skipping them with Offset.
+func (c *Client) GetPodLogs(ctx context.Context, params PodLogsParams) ([]ComponentLogsEntry, error) {
+	queryJSON, err := generatePodLogsQuery(params, c.stream, c.fields, c.logger)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.executeSearchQuery(ctx, queryJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	logs := make([]ComponentLogsEntry, 0, len(resp.Hits))
+	for _, hit := range resp.Hits {
+		timestamp := int64(0)
+		if ts, ok := hit["_timestamp"].(float64); ok {
+			timestamp = int64(ts)
+		}
+		logs = append(logs, c.parseApplicationLogEntry(timestamp, hit))
+	}
+	return logs, nil
+}
+
+// generatePodLogsQuery generates the OpenObserve query for a page of the
+// logs of a pod, oldest first with a total order on entries logged at the
+// same time.
+func generatePodLogsQuery(params PodLogsParams, stream string, fields FieldMapping, logger *slog.Logger) ([]byte, error) {
+	if params.Namespace == "" || params.PodName == "" {
+		return nil, fmt.Errorf("namespace and pod name are required for pod log queries")
+	}
+	sql := "SELECT * FROM " + quoteIdentifier(stream) +
+		" WHERE " + fields.Namespace + " = '" + escapeSQLString(params.Namespace) + "'" +
+		" AND " + fields.PodName + " = '" + escapeSQLString(params.PodName) + "'" +
+		" ORDER BY _timestamp ASC, " + fields.ContainerName + " ASC, log ASC"
+
+	limit := params.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"sql":        sql,
+			"start_time": params.StartTime.UnixMicro(),
+			"end_time":   params.EndTime.UnixMicro(),
+			"from":       params.Offset,
+			"size":       limit,
+		},
+		"timeout": 0,
+	}
+
+	logGenerated(logger, query, "query to fetch %s pod logs", stream)
+
+	return json.Marshal(query)
+}
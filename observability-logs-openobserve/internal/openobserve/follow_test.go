@@ -0,0 +1,37 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGeneratePodLogsQuery(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	raw, err := generatePodLogsQuery(PodLogsParams{
+		Namespace: "ns",
+		PodName:   "api-0",
+		StartTime: start,
+		EndTime:   start.Add(time.Minute),
+		Offset:    2,
+		Limit:     500,
+	}, "default", DefaultFieldMapping, testLogger())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sql, q := sqlOf(t, raw)
+	want := `SELECT * FROM "default" WHERE kubernetes_labels_openchoreo_dev_namespace = 'ns' AND kubernetes_pod_name = 'api-0'` +
+		` ORDER BY _timestamp ASC, kubernetes_container_name ASC, log ASC`
+	if sql != want {
+		t.Errorf("unexpected sql:\n got %s\nwant %s", sql, want)
+	}
+	if q["start_time"] != float64(start.UnixMicro()) || q["from"] != float64(2) || q["size"] != float64(500) {
+		t.Errorf("unexpected query: %v", q)
+	}
+
+	if _, err := generatePodLogsQuery(PodLogsParams{Namespace: "ns"}, "default", DefaultFieldMapping, testLogger()); err == nil {
+		t.Error("expected an error without a pod name")
+	}
+}
@@ -0,0 +1,244 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrGatewayRequestNotFound is returned when no gateway access log line
+// records the requested request ID.
+var ErrGatewayRequestNotFound = errors.New("gateway request not found")
+
+// maxGatewayRequestCandidates bounds the log lines mentioning a request ID
+// that are parsed to find its access log line.
+const maxGatewayRequestCandidates = 20
+
+// Access log keys holding the request ID, trace context and request
+// attributes, in order of preference. Envoy based gateways use the header
+// names for request headers recorded with %REQ(...)%.
+var (
+	gatewayRequestIDKeys   = []string{"x-request-id", "x-correlation-id", "correlation-id", "request_id", "correlation_id", "requestid", "correlationid"}
+	gatewayTraceparentKeys = []string{"traceparent"}
+	gatewayMethodKeys      = []string{"method", ":method"}
+	gatewayPathKeys        = []string{"x-envoy-origin-path", "path", ":path"}
+	gatewayAuthorityKeys   = []string{":authority", "authority", "host"}
+	gatewayStatusKeys      = []string{"response_code", "status", "status_code"}
+	gatewayDurationKeys    = []string{"duration"}
+)
+
+var (
+	traceparentPattern      = regexp.MustCompile(`\b[0-9a-f]{2}-[0-9a-f]{32}-[0-9a-f]{16}-[0-9a-f]{2}\b`)
+	gatewayRequestIDPattern = regexp.MustCompile(`(?i)\b(?:x-request-id|x-correlation-id|correlation-id|request_id|correlation_id)["']?\s*[=:]\s*["']?([A-Za-z0-9._-]+)`)
+)
+
+// W3C trace context constants.
+const (
+	invalidTraceID      = "00000000000000000000000000000000"
+	invalidParentID     = "0000000000000000"
+	traceFlagSampled    = 0x01
+	invalidTraceVersion = "ff"
+)
+
+// TraceContext is a parsed W3C traceparent header.
+type TraceContext struct {
+	TraceID string `json:"traceId"`
+	SpanID  string `json:"spanId"`
+	Sampled bool   `json:"sampled"`
+}
+
+// GatewayRequest holds the structured fields of a gateway access log line.
+type GatewayRequest struct {
+	Timestamp   time.Time     `json:"timestamp"`
+	RequestID   string        `json:"requestId,omitempty"`
+	Method      string        `json:"method,omitempty"`
+	Path        string        `json:"path,omitempty"`
+	Authority   string        `json:"authority,omitempty"`
+	Status      int           `json:"status,omitempty"`
+	DurationMs  float64       `json:"durationMs,omitempty"`
+	Traceparent string        `json:"traceparent,omitempty"`
+	Trace       *TraceContext `json:"trace,omitempty"`
+	Namespace   string        `json:"namespace,omitempty"`
+	PodName     string        `json:"podName,omitempty"`
+	Log         string        `json:"log"`
+}
+
+// GatewayRequestParams holds parameters for gateway request lookups.
+type GatewayRequestParams struct {
+	RequestID string
+	// Namespace restricts the lookup to logs of gateway pods in this
+	// Kubernetes namespace. All namespaces are searched when empty.
+	Namespace string
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// ParseTraceparent parses a W3C traceparent header value
+// (version-traceid-parentid-flags). It rejects the invalid version ff and
+// all-zero trace and parent IDs.
+func ParseTraceparent(value string) (TraceContext, bool) {
+	parts := strings.Split(strings.ToLower(strings.TrimSpace(value)), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == invalidTraceVersion {
+		return TraceContext{}, false
+	}
+	// Version 00 has exactly four fields; later versions may append more.
+	if parts[0] == "00" && len(parts) != 4 {
+		return TraceContext{}, false
+	}
+	if !isHex(parts[0]) || len(parts[1]) != 32 || !isHex(parts[1]) || parts[1] == invalidTraceID ||
+		len(parts[2]) != 16 || !isHex(parts[2]) || parts[2] == invalidParentID ||
+		len(parts[3]) != 2 || !isHex(parts[3]) {
+		return TraceContext{}, false
+	}
+	flags, _ := strconv.ParseUint(parts[3], 16, 8)
+	return TraceContext{
+		TraceID: parts[1],
+		SpanID:  parts[2],
+		Sampled: flags&traceFlagSampled != 0,
+	}, true
+}
+
+func isHex(s string) bool {
+	for _, r := range s {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseGatewayAccessLog extracts the request ID, W3C trace context and
+// request attributes from a gateway access log line. JSON access logs, the
+// Envoy Gateway default, are read by key; for other formats the traceparent
+// and request ID are matched in the text. It returns false when the line
+// records neither a request ID nor a trace context.
+func ParseGatewayAccessLog(log string) (GatewayRequest, bool) {
+	req := GatewayRequest{Log: log}
+
+	if lower, ok := accessLogFields(log); ok {
+		req.RequestID = accessLogString(lower, gatewayRequestIDKeys)
+		req.Traceparent = accessLogString(lower, gatewayTraceparentKeys)
+		req.Method = accessLogString(lower, gatewayMethodKeys)
+		req.Path = accessLogString(lower, gatewayPathKeys)
+		req.Authority = accessLogString(lower, gatewayAuthorityKeys)
+		if status, err := strconv.Atoi(accessLogString(lower, gatewayStatusKeys)); err == nil {
+			req.Status = status
+		}
+		if duration, err := strconv.ParseFloat(accessLogString(lower, gatewayDurationKeys), 64); err == nil {
+			req.DurationMs = duration
+		}
+	} else {
+		req.Traceparent = traceparentPattern.FindString(strings.ToLower(log))
+		if m := gatewayRequestIDPattern.FindStringSubmatch(log); m != nil {
+			req.RequestID = m[1]
+		}
+	}
+
+	if tc, ok := ParseTraceparent(req.Traceparent); ok {
+		req.Trace = &tc
+	}
+	return req, req.RequestID != "" || req.Trace != nil
+}
+
+// accessLogFields decodes a JSON access log line into its fields, keyed by
+// their lower-cased names. It returns false for other formats.
+func accessLogFields(log string) (map[string]interface{}, bool) {
+	var fields map[string]interface{}
+	if json.Unmarshal([]byte(strings.TrimSpace(log)), &fields) != nil {
+		return nil, false
+	}
+	lower := make(map[string]interface{}, len(fields))
+	for k, v := range fields {
+		lower[strings.ToLower(k)] = v
+	}
+	return lower, true
+}
+
+// accessLogString returns the first value of keys present in fields as a
+// string. Envoy writes "-" for missing values, which is treated as absent.
+func accessLogString(fields map[string]interface{}, keys []string) string {
+	for _, key := range keys {
+		var s string
+		switch v := fields[key].(type) {
+		case string:
+			s = v
+		case float64:
+			s = strconv.FormatFloat(v, 'f', -1, 64)
+		default:
+			continue
+		}
+		if s = strings.TrimSpace(s); s != "" && s != "-" {
+			return s
+		}
+	}
+	return ""
+}
+
+// generateGatewayRequestQuery generates a query for the log lines that
+// mention a request ID, newest first. The lines are parsed by the caller to
+// find the access log line that records it.
+func generateGatewayRequestQuery(params GatewayRequestParams, stream string, logger *slog.Logger) ([]byte, error) {
+	if params.RequestID == "" {
+		return nil, fmt.Errorf("requestId is required for gateway request queries")
+	}
+
+	conditions := []string{"log LIKE '%" + escapeSQLString(params.RequestID) + "%'"}
+	if params.Namespace != "" {
+		conditions = append(conditions, "kubernetes_namespace_name = '"+escapeSQLString(params.Namespace)+"'")
+	}
+
+	sql := "SELECT _timestamp, log, kubernetes_namespace_name, kubernetes_pod_name FROM " + quoteIdentifier(stream) +
+		" WHERE " + strings.Join(conditions, " AND ") + " ORDER BY _timestamp DESC"
+
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"sql":        sql,
+			"start_time": params.StartTime.UnixMicro(),
+			"end_time":   params.EndTime.UnixMicro(),
+			"from":       0,
+			"size":       maxGatewayRequestCandidates,
+		},
+	}
+
+	logGenerated(logger, query, "gateway request query")
+
+	return json.Marshal(query)
+}
+
+// FindGatewayRequest finds the newest gateway access log line that records
+// params.RequestID as its request or correlation ID and returns its
+// structured fields, including the trace context the gateway propagated.
+func (c *Client) FindGatewayRequest(ctx context.Context, params GatewayRequestParams) (*GatewayRequest, error) {
+	queryJSON, err := generateGatewayRequestQuery(params, c.gatewayLogsStream(), c.logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate gateway request query: %w", err)
+	}
+
+	openObserveResp, err := c.executeSearchQuery(ctx, queryJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, hit := range openObserveResp.Hits {
+		req, ok := ParseGatewayAccessLog(stringField(hit, "log"))
+		if !ok || req.RequestID != params.RequestID {
+			continue
+		}
+		if v, ok := hit["_timestamp"].(float64); ok {
+			req.Timestamp = time.UnixMicro(int64(v)).UTC()
+		}
+		req.Namespace = stringField(hit, "kubernetes_namespace_name")
+		req.PodName = stringField(hit, "kubernetes_pod_name")
+		return &req, nil
+	}
+	return nil, fmt.Errorf("%w: requestId=%s", ErrGatewayRequestNotFound, params.RequestID)
+}
@@ -0,0 +1,140 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testTraceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestParseTraceparent(t *testing.T) {
+	tc, ok := ParseTraceparent(testTraceparent)
+	if !ok {
+		t.Fatal("expected valid traceparent")
+	}
+	want := TraceContext{TraceID: "4bf92f3577b34da6a3ce929d0e0e4736", SpanID: "00f067aa0ba902b7", Sampled: true}
+	if tc != want {
+		t.Errorf("ParseTraceparent() = %+v, want %+v", tc, want)
+	}
+	if tc, ok := ParseTraceparent("00-4BF92F3577B34DA6A3CE929D0E0E4736-00F067AA0BA902B7-00"); !ok || tc.Sampled {
+		t.Errorf("expected unsampled upper-case traceparent to parse, got %+v, %v", tc, ok)
+	}
+	if _, ok := ParseTraceparent("01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra"); !ok {
+		t.Error("expected future version with extra fields to parse")
+	}
+
+	for _, invalid := range []string{
+		"",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e473-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+		"00-zzf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	} {
+		if _, ok := ParseTraceparent(invalid); ok {
+			t.Errorf("expected %q to be rejected", invalid)
+		}
+	}
+}
+
+func TestParseGatewayAccessLog(t *testing.T) {
+	t.Run("envoy JSON access log", func(t *testing.T) {
+		log := `{"start_time":"2025-01-01T12:00:00.000Z","method":"GET","x-envoy-origin-path":"/orders/42",` +
+			`"response_code":503,"duration":12.5,":authority":"api.example.com","x-request-id":"req-1",` +
+			`"traceparent":"` + testTraceparent + `","user-agent":"-"}`
+		req, ok := ParseGatewayAccessLog(log)
+		if !ok {
+			t.Fatal("expected access log to parse")
+		}
+		if req.RequestID != "req-1" || req.Method != "GET" || req.Path != "/orders/42" ||
+			req.Authority != "api.example.com" || req.Status != 503 || req.DurationMs != 12.5 {
+			t.Errorf("unexpected fields: %+v", req)
+		}
+		if req.Trace == nil || req.Trace.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" {
+			t.Errorf("unexpected trace context: %+v", req.Trace)
+		}
+	})
+
+	t.Run("missing values", func(t *testing.T) {
+		req, ok := ParseGatewayAccessLog(`{"X-Correlation-ID":"corr-7","traceparent":"-","method":"POST"}`)
+		if !ok || req.RequestID != "corr-7" || req.Trace != nil || req.Traceparent != "" {
+			t.Errorf("unexpected result: %+v, %v", req, ok)
+		}
+	})
+
+	t.Run("text access log", func(t *testing.T) {
+		log := `[2025-01-01T12:00:00.000Z] "GET /orders/42 HTTP/1.1" 200 x-request-id=req-2 traceparent=` + testTraceparent
+		req, ok := ParseGatewayAccessLog(log)
+		if !ok || req.RequestID != "req-2" || req.Trace == nil || req.Trace.SpanID != "00f067aa0ba902b7" {
+			t.Errorf("unexpected result: %+v, %v", req, ok)
+		}
+	})
+
+	t.Run("unrelated line", func(t *testing.T) {
+		if _, ok := ParseGatewayAccessLog("connection reset by peer"); ok {
+			t.Error("expected line without request ID or trace context to be rejected")
+		}
+	})
+}
+
+func TestFindGatewayRequest(t *testing.T) {
+	var gotSQL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query struct {
+				SQL string `json:"sql"`
+			} `json:"query"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		gotSQL = body.Query.SQL
+		resp := OpenObserveResponse{Hits: []map[string]interface{}{}}
+		if strings.Contains(gotSQL, "req-1") {
+			resp.Hits = []map[string]interface{}{
+				// An upstream log line that only mentions the request ID.
+				{"_timestamp": float64(1735732800000001), "log": `handled upstream request req-1`},
+				{
+					"_timestamp":                float64(1735732800000000),
+					"log":                       `{"x-request-id":"req-1","traceparent":"` + testTraceparent + `"}`,
+					"kubernetes_namespace_name": "gateways",
+					"kubernetes_pod_name":       "envoy-0",
+				},
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	params := GatewayRequestParams{
+		RequestID: "req-1",
+		Namespace: "gateways",
+		StartTime: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+	req, err := client.FindGatewayRequest(context.Background(), params)
+	if err != nil {
+		t.Fatalf("FindGatewayRequest() error = %v", err)
+	}
+	if !strings.Contains(gotSQL, "log LIKE '%req-1%'") || !strings.Contains(gotSQL, "kubernetes_namespace_name = 'gateways'") {
+		t.Errorf("unexpected SQL: %s", gotSQL)
+	}
+	if req.PodName != "envoy-0" || req.Trace == nil || !req.Timestamp.Equal(time.UnixMicro(1735732800000000)) {
+		t.Errorf("unexpected request: %+v", req)
+	}
+
+	params.RequestID = "req-2"
+	if _, err := client.FindGatewayRequest(context.Background(), params); !errors.Is(err, ErrGatewayRequestNotFound) {
+		t.Errorf("expected ErrGatewayRequestNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,255 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Access log keys holding the upstream the gateway routed a request to and
+// the time the upstream took, in order of preference.
+var (
+	gatewayUpstreamHostKeys    = []string{"upstream_host", "upstream_addr"}
+	gatewayUpstreamClusterKeys = []string{"upstream_cluster", "route_name"}
+	gatewayUpstreamTimeKeys    = []string{"x-envoy-upstream-service-time", "upstream_service_time", "upstream_response_time"}
+)
+
+// GatewayLogsParams holds parameters for querying gateway access logs.
+type GatewayLogsParams struct {
+	// Namespace restricts the query to logs of gateway pods in this
+	// Kubernetes namespace. All namespaces are searched when empty.
+	Namespace string `json:"namespace"`
+	// Gateway restricts the query to gateway pods whose name starts with it.
+	Gateway string `json:"gateway,omitempty"`
+	// Host, PathPrefix, Methods and StatusCodes filter the requests by their
+	// authority, path, method and response status.
+	Host         string    `json:"host,omitempty"`
+	PathPrefix   string    `json:"pathPrefix,omitempty"`
+	Methods      []string  `json:"methods,omitempty"`
+	StatusCodes  []int     `json:"statusCodes,omitempty"`
+	SearchPhrase string    `json:"searchPhrase,omitempty"`
+	StartTime    time.Time `json:"startTime"`
+	EndTime      time.Time `json:"endTime"`
+	Limit        int       `json:"limit"`
+	SortOrder    string    `json:"sortOrder"`
+}
+
+// GatewayLogEntry holds the structured fields of a gateway access log line.
+// Fields the line does not record are left empty; lines that are not JSON
+// only carry their raw log.
+type GatewayLogEntry struct {
+	Timestamp         time.Time `json:"timestamp"`
+	Method            string    `json:"method,omitempty"`
+	Path              string    `json:"path,omitempty"`
+	Authority         string    `json:"authority,omitempty"`
+	StatusCode        int       `json:"statusCode,omitempty"`
+	LatencyMs         float64   `json:"latencyMs,omitempty"`
+	UpstreamHost      string    `json:"upstreamHost,omitempty"`
+	UpstreamCluster   string    `json:"upstreamCluster,omitempty"`
+	UpstreamLatencyMs float64   `json:"upstreamLatencyMs,omitempty"`
+	RequestID         string    `json:"requestId,omitempty"`
+	Namespace         string    `json:"namespace,omitempty"`
+	PodName           string    `json:"podName,omitempty"`
+	Log               string    `json:"log"`
+}
+
+// GatewayLogsResult holds the gateway access log entries of a query.
+type GatewayLogsResult struct {
+	Logs []GatewayLogEntry `json:"logs"`
+	// TotalCount is the number of log lines matching the query before the
+	// methods and status codes of the requests are checked, so it is an
+	// upper bound when those filters are set.
+	TotalCount int `json:"totalCount"`
+	Took       int `json:"took"`
+}
+
+// SetGatewayStream makes gateway access logs be read from stream rather than
+// from the logs stream.
+func (c *Client) SetGatewayStream(stream string) {
+	c.gatewayStream = stream
+}
+
+// gatewayLogsStream returns the stream holding gateway access logs.
+func (c *Client) gatewayLogsStream() string {
+	if c.gatewayStream != "" {
+		return c.gatewayStream
+	}
+	return c.stream
+}
+
+// ParseGatewayLogEntry parses the HTTP request attributes, upstream and
+// latencies from a JSON gateway access log line.
+func ParseGatewayLogEntry(log string) GatewayLogEntry {
+	entry := GatewayLogEntry{Log: log}
+	fields, ok := accessLogFields(log)
+	if !ok {
+		return entry
+	}
+	entry.Method = accessLogString(fields, gatewayMethodKeys)
+	entry.Path = accessLogString(fields, gatewayPathKeys)
+	entry.Authority = accessLogString(fields, gatewayAuthorityKeys)
+	entry.RequestID = accessLogString(fields, gatewayRequestIDKeys)
+	entry.UpstreamHost = accessLogString(fields, gatewayUpstreamHostKeys)
+	entry.UpstreamCluster = accessLogString(fields, gatewayUpstreamClusterKeys)
+	if status, err := strconv.Atoi(accessLogString(fields, gatewayStatusKeys)); err == nil {
+		entry.StatusCode = status
+	}
+	if latency, err := strconv.ParseFloat(accessLogString(fields, gatewayDurationKeys), 64); err == nil {
+		entry.LatencyMs = latency
+	}
+	if latency, err := strconv.ParseFloat(accessLogString(fields, gatewayUpstreamTimeKeys), 64); err == nil {
+		entry.UpstreamLatencyMs = latency
+	}
+	return entry
+}
+
+// matches reports whether the request of entry passes the filters of params
+// that the query could only approximate.
+func (entry GatewayLogEntry) matches(params GatewayLogsParams) bool {
+	if params.Host != "" && !strings.EqualFold(entry.Authority, params.Host) {
+		return false
+	}
+	if params.PathPrefix != "" && !strings.HasPrefix(entry.Path, params.PathPrefix) {
+		return false
+	}
+	if len(params.Methods) > 0 && !slices.ContainsFunc(params.Methods, func(m string) bool {
+		return strings.EqualFold(m, entry.Method)
+	}) {
+		return false
+	}
+	if len(params.StatusCodes) > 0 && !slices.Contains(params.StatusCodes, entry.StatusCode) {
+		return false
+	}
+	return true
+}
+
+// gatewayLogsConditions returns the SQL conditions of gateway log queries.
+// The request attributes are inside the log line, so the host, path and
+// methods only narrow the lines down to those mentioning them; the entries
+// are matched exactly once parsed.
+func gatewayLogsConditions(params GatewayLogsParams) []string {
+	var conditions []string
+	if params.Namespace != "" {
+		conditions = append(conditions, "kubernetes_namespace_name = '"+escapeSQLString(params.Namespace)+"'")
+	}
+	if params.Gateway != "" {
+		conditions = append(conditions, "kubernetes_pod_name LIKE '"+escapeSQLString(params.Gateway)+"%'")
+	}
+	if params.Host != "" {
+		conditions = append(conditions, "log LIKE '%"+escapeSQLString(params.Host)+"%'")
+	}
+	if params.PathPrefix != "" {
+		conditions = append(conditions, "log LIKE '%"+escapeSQLString(params.PathPrefix)+"%'")
+	}
+	if len(params.Methods) > 0 {
+		methodConditions := make([]string, len(params.Methods))
+		for i, method := range params.Methods {
+			methodConditions[i] = "log LIKE '%" + escapeSQLString(strings.ToUpper(method)) + "%'"
+		}
+		conditions = append(conditions, "("+strings.Join(methodConditions, " OR ")+")")
+	}
+	if params.SearchPhrase != "" {
+		conditions = append(conditions, "log LIKE '%"+escapeSQLString(params.SearchPhrase)+"%'")
+	}
+	return conditions
+}
+
+// gatewayLogsWhere returns the WHERE clause of gateway log queries.
+func gatewayLogsWhere(params GatewayLogsParams) string {
+	conditions := gatewayLogsConditions(params)
+	if len(conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(conditions, " AND ")
+}
+
+// generateGatewayLogsQuery generates the OpenObserve query for gateway access logs.
+func generateGatewayLogsQuery(params GatewayLogsParams, stream string, logger *slog.Logger) ([]byte, error) {
+	sql := "SELECT _timestamp, log, kubernetes_namespace_name, kubernetes_pod_name FROM " + quoteIdentifier(stream) +
+		gatewayLogsWhere(params) + logsSortClause("", params.SortOrder, nil)
+
+	limit := params.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"sql":        sql,
+			"start_time": params.StartTime.UnixMicro(),
+			"end_time":   params.EndTime.UnixMicro(),
+			"from":       0,
+			"size":       limit,
+		},
+	}
+
+	logGenerated(logger, query, "gateway logs query")
+
+	return json.Marshal(query)
+}
+
+// generateGatewayLogsCountQuery generates the query counting the gateway
+// access log lines matching params.
+func generateGatewayLogsCountQuery(params GatewayLogsParams, stream string) ([]byte, error) {
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"sql":        "SELECT count(*) as total FROM " + quoteIdentifier(stream) + gatewayLogsWhere(params),
+			"start_time": params.StartTime.UnixMicro(),
+			"end_time":   params.EndTime.UnixMicro(),
+		},
+	}
+	return json.Marshal(query)
+}
+
+// GetGatewayLogs queries the gateway access logs and parses the requests
+// they record. Lines whose request does not pass the filters of params are
+// left out, so fewer than params.Limit entries may be returned.
+func (c *Client) GetGatewayLogs(ctx context.Context, params GatewayLogsParams) (*GatewayLogsResult, error) {
+	stream := c.gatewayLogsStream()
+	queryJSON, err := generateGatewayLogsQuery(params, stream, c.logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate gateway logs query: %w", err)
+	}
+
+	openObserveResp, err := c.executeSearchQuery(ctx, queryJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	logs := make([]GatewayLogEntry, 0, len(openObserveResp.Hits))
+	for _, hit := range openObserveResp.Hits {
+		entry := ParseGatewayLogEntry(stringField(hit, "log"))
+		if !entry.matches(params) {
+			continue
+		}
+		if v, ok := hit["_timestamp"].(float64); ok {
+			entry.Timestamp = time.UnixMicro(int64(v)).UTC()
+		}
+		entry.Namespace = stringField(hit, "kubernetes_namespace_name")
+		entry.PodName = stringField(hit, "kubernetes_pod_name")
+		logs = append(logs, entry)
+	}
+
+	countQueryJSON, err := generateGatewayLogsCountQuery(params, stream)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate gateway logs count query: %w", err)
+	}
+	countResp, err := c.executeSearchQuery(ctx, countQueryJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute gateway logs count query: %w", err)
+	}
+
+	return &GatewayLogsResult{
+		Logs:       logs,
+		TotalCount: extractTotalCount(countResp),
+		Took:       openObserveResp.Took,
+	}, nil
+}
@@ -0,0 +1,111 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseGatewayLogEntry(t *testing.T) {
+	line := `{"method":"POST","x-envoy-origin-path":"/orders/42","authority":"api.example.com","response_code":503,` +
+		`"duration":120,"upstream_host":"10.0.0.7:8080","upstream_cluster":"httproute/default/orders/rule/0",` +
+		`"x-envoy-upstream-service-time":"-","x-request-id":"req-1"}`
+	entry := ParseGatewayLogEntry(line)
+	want := GatewayLogEntry{
+		Method:          "POST",
+		Path:            "/orders/42",
+		Authority:       "api.example.com",
+		StatusCode:      503,
+		LatencyMs:       120,
+		UpstreamHost:    "10.0.0.7:8080",
+		UpstreamCluster: "httproute/default/orders/rule/0",
+		RequestID:       "req-1",
+		Log:             line,
+	}
+	if entry != want {
+		t.Errorf("ParseGatewayLogEntry() = %+v, want %+v", entry, want)
+	}
+
+	text := `[2025-01-01T00:00:00.000Z] "GET /orders HTTP/1.1" 200`
+	if entry := ParseGatewayLogEntry(text); entry != (GatewayLogEntry{Log: text}) {
+		t.Errorf("expected only the raw log of a text line, got %+v", entry)
+	}
+}
+
+func TestGetGatewayLogs(t *testing.T) {
+	var sqls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query struct {
+				SQL string `json:"sql"`
+			} `json:"query"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		sqls = append(sqls, body.Query.SQL)
+		resp := OpenObserveResponse{Hits: []map[string]interface{}{
+			{
+				"_timestamp":                float64(1735732800000001),
+				"log":                       `{"method":"GET","path":"/orders/1","authority":"api.example.com","response_code":500,"duration":"35"}`,
+				"kubernetes_namespace_name": "gateways",
+				"kubernetes_pod_name":       "envoy-default-0",
+			},
+			// Mentions the path, but is not a request to it.
+			{"_timestamp": float64(1735732800000000), "log": `{"method":"GET","path":"/health?next=/orders","response_code":500}`},
+		}}
+		if strings.Contains(body.Query.SQL, "count(*)") {
+			resp.Hits = []map[string]interface{}{{"total": float64(2)}}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	client.SetGatewayStream("gateway_access")
+	result, err := client.GetGatewayLogs(context.Background(), GatewayLogsParams{
+		Namespace:   "gateways",
+		Gateway:     "envoy-default",
+		PathPrefix:  "/orders",
+		Methods:     []string{"get"},
+		StatusCodes: []int{500, 503},
+		StartTime:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndTime:     time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("GetGatewayLogs() error = %v", err)
+	}
+
+	if len(sqls) != 2 {
+		t.Fatalf("expected a search and a count query, got %q", sqls)
+	}
+	for _, want := range []string{
+		`FROM "gateway_access"`,
+		"kubernetes_namespace_name = 'gateways'",
+		"kubernetes_pod_name LIKE 'envoy-default%'",
+		"log LIKE '%/orders%'",
+		"(log LIKE '%GET%')",
+	} {
+		if !strings.Contains(sqls[0], want) || !strings.Contains(sqls[1], want) {
+			t.Errorf("expected %q in queries %q", want, sqls)
+		}
+	}
+	if !strings.HasSuffix(sqls[0], " ORDER BY _timestamp DESC") {
+		t.Errorf("expected newest lines first: %s", sqls[0])
+	}
+
+	if len(result.Logs) != 1 || result.TotalCount != 2 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	got := result.Logs[0]
+	if got.Path != "/orders/1" || got.StatusCode != 500 || got.LatencyMs != 35 || got.PodName != "envoy-default-0" ||
+		!got.Timestamp.Equal(time.UnixMicro(1735732800000001)) {
+		t.Errorf("unexpected entry: %+v", got)
+	}
+}
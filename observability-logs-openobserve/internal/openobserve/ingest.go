@@ -0,0 +1,63 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// MetricSample is a gauge sample written to OpenObserve, where each metric
+// name is a metrics stream of its own.
+type MetricSample struct {
+	Name   string
+	Labels map[string]string
+	Time   time.Time
+	Value  float64
+}
+
+// IngestMetrics writes samples to the metrics streams of the organization
+// through the JSON metrics ingestion API.
+func (c *Client) IngestMetrics(ctx context.Context, samples []MetricSample) error {
+	records := make([]map[string]interface{}, 0, len(samples))
+	for _, s := range samples {
+		record := map[string]interface{}{
+			"__name__":   s.Name,
+			"__type__":   "gauge",
+			"_timestamp": s.Time.UnixMicro(),
+			"value":      s.Value,
+		}
+		for k, v := range s.Labels {
+			record[k] = v
+		}
+		records = append(records, record)
+	}
+	body, err := json.Marshal(records)
+	if err != nil {
+		return fmt.Errorf("failed to marshal metrics: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/api/%s/ingest/metrics/_json", c.BaseURL(), c.Org())
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("openobserve returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+	return nil
+}
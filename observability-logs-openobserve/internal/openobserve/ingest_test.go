@@ -0,0 +1,56 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestIngestMetrics(t *testing.T) {
+	var got []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/myorg/ingest/metrics/_json" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if user, _, ok := r.BasicAuth(); !ok || user != "admin" {
+			t.Error("expected basic auth")
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("failed to decode body: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "myorg", "default", "k8s_events", "admin", "pass", testLogger())
+	at := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	err := client.IngestMetrics(context.Background(), []MetricSample{
+		{Name: "adapter_sli_error_ratio", Labels: map[string]string{"instance": "pod-1"}, Time: at, Value: 0.25},
+	})
+	if err != nil {
+		t.Fatalf("IngestMetrics() error = %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("expected 1 record, got %v", got)
+	}
+	record := got[0]
+	if record["__name__"] != "adapter_sli_error_ratio" || record["__type__"] != "gauge" || record["instance"] != "pod-1" ||
+		record["value"] != 0.25 || record["_timestamp"] != float64(at.UnixMicro()) {
+		t.Errorf("unexpected record: %v", record)
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "no", http.StatusUnauthorized)
+	}))
+	defer failing.Close()
+	client = NewClient(failing.URL, "myorg", "default", "k8s_events", "admin", "pass", testLogger())
+	if err := client.IngestMetrics(context.Background(), nil); err == nil {
+		t.Error("expected an error for a rejected write")
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"time"
+)
+
+// Warnings of partial results.
+const (
+	warningPartialData = "OpenObserve answered with partial data, some logs may be missing"
+	warningHitsBudget  = "the search exceeded its time budget of %s, no logs were received"
+	warningCountBudget = "counting the matching logs exceeded the time budget of %s, the total only covers the logs returned"
+)
+
+// PartialResult reports that a logs query result misses logs: OpenObserve
+// answered with part of its data, or a search ran out of the Budget of the
+// query. Warnings tell why. It also reports a query range clamped to the
+// retention of the streams, which leaves the result complete for the range
+// searched; see clampToRetention.
+type PartialResult struct {
+	Partial  bool     `json:"partial,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+	// RangeClamped is set when the query started before EarliestAvailable,
+	// the earliest time the streams queried keep logs for, and was only
+	// searched from then on.
+	RangeClamped      bool       `json:"rangeClamped,omitempty"`
+	EarliestAvailable *time.Time `json:"earliestAvailable,omitempty"`
+}
+
+// warn marks the result partial, with warning.
+func (p *PartialResult) warn(warning string) {
+	p.Partial = true
+	p.addWarning(warning)
+}
+
+// addWarning adds warning to the result, once.
+func (p *PartialResult) addWarning(warning string) {
+	if !slices.Contains(p.Warnings, warning) {
+		p.Warnings = append(p.Warnings, warning)
+	}
+}
+
+// Merge adds the warnings and range clamp of q to p. The earliest time
+// available of merged results is the latest of theirs.
+func (p *PartialResult) Merge(q PartialResult) {
+	for _, warning := range q.Warnings {
+		p.addWarning(warning)
+	}
+	p.Partial = p.Partial || q.Partial
+	if q.RangeClamped {
+		p.RangeClamped = true
+		if p.EarliestAvailable == nil || q.EarliestAvailable.After(*p.EarliestAvailable) {
+			p.EarliestAvailable = q.EarliestAvailable
+		}
+	}
+}
+
+// searchWithinBudget runs a logs search of org that takes at most budget,
+// when positive. OpenObserve is asked to stop the search at budget and
+// answer with the hits found so far; if it has not answered shortly after,
+// the search is abandoned and timedOut is set instead of returning an error.
+func (c *Client) searchWithinBudget(ctx context.Context, org string, queryJSON []byte, budget time.Duration) (resp *OpenObserveResponse, timedOut bool, err error) {
+	if budget <= 0 {
+		resp, err = c.executeSearchOrg(ctx, org, "logs", queryJSON)
+		return resp, false, err
+	}
+	queryJSON, err = withSearchTimeout(queryJSON, budget)
+	if err != nil {
+		return nil, false, err
+	}
+	// OpenObserve times searches out in whole seconds, and needs a moment
+	// to answer once they are.
+	searchCtx, cancel := context.WithTimeout(ctx, budget+max(budget/4, time.Second))
+	defer cancel()
+	resp, err = c.executeSearchOrg(searchCtx, org, "logs", queryJSON)
+	if err != nil && ctx.Err() == nil && errors.Is(searchCtx.Err(), context.DeadlineExceeded) {
+		c.logger.Warn("Search exceeded its time budget", slog.Duration("budget", budget))
+		return nil, true, nil
+	}
+	return resp, false, err
+}
+
+// withSearchTimeout sets the timeout of the search request queryJSON to
+// budget, rounded up to whole seconds.
+func withSearchTimeout(queryJSON []byte, budget time.Duration) ([]byte, error) {
+	var request map[string]json.RawMessage
+	if err := json.Unmarshal(queryJSON, &request); err != nil {
+		return nil, fmt.Errorf("failed to decode search request: %w", err)
+	}
+	request["timeout"] = json.RawMessage(fmt.Sprint(int64(math.Ceil(budget.Seconds()))))
+	return json.Marshal(request)
+}
+
+// countTotal returns the total of a count search of a logs query, and
+// records in partial when it is not exact: when the count ran out of budget,
+// the total only covers the page of count logs at offset.
+func countTotal(countResp *OpenObserveResponse, timedOut bool, offset, count int, budget time.Duration, partial *PartialResult) int {
+	if timedOut {
+		partial.warn(fmt.Sprintf(warningCountBudget, budget))
+		return max(offset, 0) + count
+	}
+	if countResp.IsPartial {
+		partial.warn(warningPartialData)
+	}
+	return extractTotalCount(countResp)
+}
@@ -0,0 +1,101 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGetComponentLogs_Budget(t *testing.T) {
+	// The hits of default are partial and its count never answers; the
+	// hits of prod_logs never answer.
+	var mu sync.Mutex
+	var timeouts []float64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var request struct {
+			Timeout float64 `json:"timeout"`
+		}
+		_ = json.Unmarshal(body, &request)
+		mu.Lock()
+		timeouts = append(timeouts, request.Timeout)
+		mu.Unlock()
+
+		sql, _ := sqlOf(t, body)
+		count := strings.Contains(sql, "count(*) as total")
+		if count == strings.Contains(sql, `FROM "default"`) {
+			<-r.Context().Done()
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(OpenObserveResponse{
+			Hits:      []map[string]interface{}{{"_timestamp": 2e6, "log": "a"}, {"_timestamp": 1e6, "log": "b"}},
+			IsPartial: true,
+		})
+	}))
+	defer server.Close()
+
+	routes, err := ParseStreamRoutes("environment:prod=prod_logs")
+	if err != nil {
+		t.Fatalf("ParseStreamRoutes() error = %v", err)
+	}
+	client := newTestClient(server.URL)
+	client.SetStreamRoutes(routes)
+
+	budget := 10 * time.Millisecond
+	result, err := client.GetComponentLogs(context.Background(), ComponentLogsParams{
+		Namespace:      "acme",
+		EnvironmentIDs: []string{"prod", "dev"},
+		Limit:          10,
+		Budget:         budget,
+	})
+	if err != nil {
+		t.Fatalf("GetComponentLogs() error = %v", err)
+	}
+	if len(result.Logs) != 2 || result.TotalCount != 2 {
+		t.Errorf("expected the logs received with a total covering them, got %d logs of %d", len(result.Logs), result.TotalCount)
+	}
+	want := []string{warningPartialData, fmt.Sprintf(warningCountBudget, budget), fmt.Sprintf(warningHitsBudget, budget)}
+	slices.Sort(want)
+	slices.Sort(result.Warnings)
+	if !result.Partial || !slices.Equal(result.Warnings, want) {
+		t.Errorf("expected a partial result, got %+v", result.PartialResult)
+	}
+	if len(timeouts) != 3 || slices.ContainsFunc(timeouts, func(timeout float64) bool { return timeout != 1 }) {
+		t.Errorf("expected OpenObserve to be asked to stop the searches after a second, got timeouts %v", timeouts)
+	}
+}
+
+func TestGetComponentLogs_NoBudget(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Timeout float64 `json:"timeout"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		if request.Timeout != 0 {
+			t.Errorf("expected the default search timeout without a budget, got %v", request.Timeout)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(OpenObserveResponse{Hits: []map[string]interface{}{{"total": float64(3)}}})
+	}))
+	defer server.Close()
+
+	result, err := newTestClient(server.URL).GetComponentLogs(context.Background(), ComponentLogsParams{Namespace: "acme", Limit: 10})
+	if err != nil {
+		t.Fatalf("GetComponentLogs() error = %v", err)
+	}
+	if result.Partial || result.Warnings != nil {
+		t.Errorf("expected a complete result, got %+v", result.PartialResult)
+	}
+}
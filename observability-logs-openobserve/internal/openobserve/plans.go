@@ -0,0 +1,123 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"container/list"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// planKeySeparator and planListSeparator cannot appear in the filters of
+	// a query, so distinct filters never share a plan key.
+	planKeySeparator  = "\x00"
+	planListSeparator = "\x01"
+)
+
+// planKey identifies the SQL of a kind of query on stream with the given
+// filter signature.
+func planKey(kind, stream string, signature []string) string {
+	return kind + planKeySeparator + stream + planKeySeparator + strings.Join(signature, planKeySeparator)
+}
+
+// PlanCache caches the SQL generated for log queries, keyed by the
+// structure of their filters, so that dashboards polling the same scope skip
+// rebuilding and escaping the conditions of every query. Only the SQL is
+// cached: the time window and paging are set on each query. The least
+// recently used plans are evicted beyond the cache size.
+//
+// It serves its hit, miss and build time counters in the Prometheus text
+// exposition format.
+type PlanCache struct {
+	size int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+	hits    int64
+	misses  int64
+	// buildTime is the time spent generating the SQL of misses.
+	buildTime time.Duration
+}
+
+type planEntry struct {
+	key string
+	sql string
+}
+
+// NewPlanCache returns a PlanCache holding up to size plans.
+func NewPlanCache(size int) (*PlanCache, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("plan cache size must be positive, got %d", size)
+	}
+	return &PlanCache{size: size, entries: map[string]*list.Element{}, order: list.New()}, nil
+}
+
+// sql returns the SQL cached under key, generating it with build on a miss.
+// A nil cache always builds.
+func (p *PlanCache) sql(key string, build func() string) string {
+	if p == nil {
+		return build()
+	}
+
+	p.mu.Lock()
+	if e, ok := p.entries[key]; ok {
+		p.order.MoveToFront(e)
+		p.hits++
+		p.mu.Unlock()
+		return e.Value.(*planEntry).sql
+	}
+	p.mu.Unlock()
+
+	start := time.Now()
+	sql := build()
+	elapsed := time.Since(start)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.misses++
+	p.buildTime += elapsed
+	if e, ok := p.entries[key]; ok {
+		p.order.MoveToFront(e)
+		return sql
+	}
+	p.entries[key] = p.order.PushFront(&planEntry{key: key, sql: sql})
+	if p.order.Len() > p.size {
+		oldest := p.order.Back()
+		p.order.Remove(oldest)
+		delete(p.entries, oldest.Value.(*planEntry).key)
+	}
+	return sql
+}
+
+// Stats returns the number of plan cache hits and misses.
+func (p *PlanCache) Stats() (hits, misses int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.hits, p.misses
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format.
+func (p *PlanCache) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	fmt.Fprintf(w, "# HELP logs_adapter_sql_plan_cache_hits_total Log queries whose SQL was served from the plan cache.\n"+
+		"# TYPE logs_adapter_sql_plan_cache_hits_total counter\n"+
+		"logs_adapter_sql_plan_cache_hits_total %d\n", p.hits)
+	fmt.Fprintf(w, "# HELP logs_adapter_sql_plan_cache_misses_total Log queries whose SQL was generated.\n"+
+		"# TYPE logs_adapter_sql_plan_cache_misses_total counter\n"+
+		"logs_adapter_sql_plan_cache_misses_total %d\n", p.misses)
+	fmt.Fprintf(w, "# HELP logs_adapter_sql_plan_build_seconds_total Time spent generating the SQL of plan cache misses.\n"+
+		"# TYPE logs_adapter_sql_plan_build_seconds_total counter\n"+
+		"logs_adapter_sql_plan_build_seconds_total %g\n", p.buildTime.Seconds())
+	fmt.Fprintf(w, "# HELP logs_adapter_sql_plan_cache_entries Plans held in the plan cache.\n"+
+		"# TYPE logs_adapter_sql_plan_cache_entries gauge\n"+
+		"logs_adapter_sql_plan_cache_entries %d\n", p.order.Len())
+}
@@ -0,0 +1,115 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPlanCache(t *testing.T) {
+	plans, err := NewPlanCache(2)
+	if err != nil {
+		t.Fatalf("NewPlanCache() error = %v", err)
+	}
+	if _, err := NewPlanCache(0); err == nil {
+		t.Error("expected an error for an empty cache")
+	}
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	params := ComponentLogsParams{
+		Namespace:    "payments",
+		ComponentIDs: []string{"c1", "c2"},
+		LogLevels:    []string{"ERROR"},
+		StartTime:    start,
+		EndTime:      start.Add(time.Hour),
+		Limit:        50,
+	}
+	uncached, err := generateComponentLogsQuery(params, "default", nil, testLogger())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		cached, err := generateComponentLogsQuery(params, "default", plans, testLogger())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(cached) != string(uncached) {
+			t.Fatalf("cached query differs:\n%s\n%s", cached, uncached)
+		}
+	}
+
+	// The time window and paging are not part of the plan.
+	moved := params
+	moved.StartTime, moved.EndTime, moved.Offset = start.Add(time.Minute), start.Add(61*time.Minute), 50
+	raw, _ := generateComponentLogsQuery(moved, "default", plans, testLogger())
+	if !strings.Contains(string(raw), `"from":50`) || !strings.Contains(string(raw), `"start_time":1735689660000000`) {
+		t.Errorf("expected the window and offset of the query, got %s", raw)
+	}
+
+	// Filters that differ only in how they split into lists have their own plan.
+	split := params
+	split.ComponentIDs = []string{"c1c2"}
+	raw, _ = generateComponentLogsQuery(split, "default", plans, testLogger())
+	if !strings.Contains(string(raw), "'c1c2'") {
+		t.Errorf("expected a plan of its own, got %s", raw)
+	}
+	if plans.hits != 3 || plans.misses != 2 {
+		t.Errorf("expected 3 hits and 2 misses, got %d and %d", plans.hits, plans.misses)
+	}
+
+	// The least recently used plan is evicted.
+	if _, err := generateComponentLogsCountQuery(params, "default", plans, testLogger()); err != nil {
+		t.Fatal(err)
+	}
+	if plans.order.Len() != 2 {
+		t.Errorf("expected 2 plans, got %d", plans.order.Len())
+	}
+	generateComponentLogsQuery(params, "default", plans, testLogger())
+	if plans.misses != 4 {
+		t.Errorf("expected the evicted plan to be generated again, got %d misses", plans.misses)
+	}
+
+	rec := httptest.NewRecorder()
+	plans.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	for _, line := range []string{
+		"logs_adapter_sql_plan_cache_hits_total 3",
+		"logs_adapter_sql_plan_cache_misses_total 4",
+		"logs_adapter_sql_plan_cache_entries 2",
+		"logs_adapter_sql_plan_build_seconds_total ",
+	} {
+		if !strings.Contains(rec.Body.String(), line) {
+			t.Errorf("expected metrics to contain %q, got:\n%s", line, rec.Body.String())
+		}
+	}
+}
+
+// BenchmarkComponentLogsQuery compares generating the query of a polled
+// dashboard panel with and without the plan cache.
+func BenchmarkComponentLogsQuery(b *testing.B) {
+	params := ComponentLogsParams{
+		Namespace:     "payments",
+		ProjectID:     "4f6c2d1e-8a7b-4c3d-9e2f-1a0b9c8d7e6f",
+		EnvironmentID: "0a1b2c3d-4e5f-6a7b-8c9d-0e1f2a3b4c5d",
+		ComponentIDs:  []string{"6b7c8d9e-0f1a-2b3c-4d5e-6f7a8b9c0d1e", "7c8d9e0f-1a2b-3c4d-5e6f-7a8b9c0d1e2f"},
+		SearchPhrase:  "timeout",
+		LogLevels:     []string{"ERROR", "WARN"},
+		StartTime:     time.Now().Add(-time.Hour),
+		EndTime:       time.Now(),
+		Limit:         100,
+	}
+	plans, _ := NewPlanCache(16)
+	for name, p := range map[string]*PlanCache{"uncached": nil, "cached": plans} {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := generateComponentLogsQuery(params, "default", p, testLogger()); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
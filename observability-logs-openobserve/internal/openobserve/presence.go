@@ -0,0 +1,100 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// LogsPresenceParams holds parameters for checking whether a scope has logs.
+type LogsPresenceParams struct {
+	Namespace     string    `json:"namespace"`
+	ProjectID     string    `json:"projectId,omitempty"`
+	EnvironmentID string    `json:"environmentId,omitempty"`
+	ComponentID   string    `json:"componentId,omitempty"`
+	StartTime     time.Time `json:"startTime"`
+	EndTime       time.Time `json:"endTime"`
+}
+
+// LogsPresenceResult reports whether a scope has logs in a window and, when
+// it does, the timestamps of its earliest and latest log lines.
+type LogsPresenceResult struct {
+	HasData  bool       `json:"hasData"`
+	Count    int        `json:"count"`
+	Earliest *time.Time `json:"earliest,omitempty"`
+	Latest   *time.Time `json:"latest,omitempty"`
+	Took     int        `json:"took"`
+}
+
+// generateLogsPresenceQuery generates the query counting the log lines of a
+// scope along with their earliest and latest timestamps. It only reads the
+// label columns and _timestamp, so it stays cheap over long windows.
+func generateLogsPresenceQuery(params LogsPresenceParams, stream string, fields FieldMapping, logger *slog.Logger) ([]byte, error) {
+	if params.Namespace == "" {
+		return nil, fmt.Errorf("namespace is required for logs presence queries")
+	}
+
+	scope := ComponentLogsParams{
+		Namespace:     params.Namespace,
+		ProjectID:     params.ProjectID,
+		EnvironmentID: params.EnvironmentID,
+		Fields:        &fields,
+	}
+	if params.ComponentID != "" {
+		scope.ComponentIDs = []string{params.ComponentID}
+	}
+	sql := "SELECT min(_timestamp) AS earliest, max(_timestamp) AS latest, count(*) AS total FROM " +
+		quoteIdentifier(stream) + " WHERE " + strings.Join(componentLogsConditions(scope), " AND ")
+
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"sql":        sql,
+			"start_time": params.StartTime.UnixMicro(),
+			"end_time":   params.EndTime.UnixMicro(),
+		},
+	}
+
+	logGenerated(logger, query, "logs presence query")
+
+	return json.Marshal(query)
+}
+
+// GetLogsPresence reports whether the scope of params has any logs in its
+// window, so that an empty logs query can be told apart from a component
+// whose logs are not being ingested at all.
+func (c *Client) GetLogsPresence(ctx context.Context, params LogsPresenceParams) (*LogsPresenceResult, error) {
+	queryJSON, err := generateLogsPresenceQuery(params, c.stream, c.fields, c.logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate logs presence query: %w", err)
+	}
+
+	openObserveResp, err := c.executeSearchQuery(ctx, queryJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &LogsPresenceResult{
+		Count: extractTotalCount(openObserveResp),
+		Took:  openObserveResp.Took,
+	}
+	if result.Count == 0 {
+		return result, nil
+	}
+	result.HasData = true
+	hit := openObserveResp.Hits[0]
+	if v, ok := hit["earliest"].(float64); ok {
+		earliest := time.UnixMicro(int64(v)).UTC()
+		result.Earliest = &earliest
+	}
+	if v, ok := hit["latest"].(float64); ok {
+		latest := time.UnixMicro(int64(v)).UTC()
+		result.Latest = &latest
+	}
+	return result, nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetLogsPresence(t *testing.T) {
+	earliest := time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)
+	latest := time.Date(2025, 1, 1, 11, 30, 0, 0, time.UTC)
+	var sql string
+	hits := []map[string]interface{}{{
+		"earliest": float64(earliest.UnixMicro()),
+		"latest":   float64(latest.UnixMicro()),
+		"total":    float64(42),
+	}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query struct {
+				SQL string `json:"sql"`
+			} `json:"query"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		sql = body.Query.SQL
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(OpenObserveResponse{Took: 3, Hits: hits})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	params := LogsPresenceParams{
+		Namespace:   "test-ns",
+		ComponentID: "comp-1",
+		StartTime:   earliest.Add(-time.Hour),
+		EndTime:     latest.Add(time.Hour),
+	}
+	result, err := client.GetLogsPresence(context.Background(), params)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.HasData || result.Count != 42 || result.Took != 3 ||
+		result.Earliest == nil || !result.Earliest.Equal(earliest) || result.Latest == nil || !result.Latest.Equal(latest) {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	for _, want := range []string{
+		"min(_timestamp) AS earliest",
+		"kubernetes_labels_openchoreo_dev_namespace = 'test-ns'",
+		"kubernetes_labels_openchoreo_dev_component_uid = 'comp-1'",
+	} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("expected %q in %s", want, sql)
+		}
+	}
+
+	hits = []map[string]interface{}{{"earliest": nil, "latest": nil, "total": float64(0)}}
+	result, err = client.GetLogsPresence(context.Background(), params)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.HasData || result.Earliest != nil || result.Latest != nil {
+		t.Errorf("expected no data, got %+v", result)
+	}
+
+	if _, err := client.GetLogsPresence(context.Background(), LogsPresenceParams{}); err == nil {
+		t.Error("expected error without namespace")
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	ooclient "github.com/openchoreo/community-modules/common/openobserve"
+)
+
+// ErrPurgeUnsupported is returned by PurgeComponent when the OpenObserve
+// instance cannot delete records by query.
+var ErrPurgeUnsupported = errors.New("openobserve does not support deletion by query")
+
+// Purge statuses of the streams of a component.
+const (
+	PurgeStatusRequested = "requested"
+	PurgeStatusEmpty     = "empty"
+	PurgeStatusDryRun    = "dryRun"
+)
+
+// PurgeParams selects the records of a component to delete.
+type PurgeParams struct {
+	Namespace   string
+	ComponentID string
+	// StartTime and EndTime bound the records deleted; the zero StartTime
+	// selects them from the beginning of the streams.
+	StartTime time.Time
+	EndTime   time.Time
+	// DryRun only counts the records that would be deleted.
+	DryRun bool
+}
+
+// PurgeRequest is the deletion of the records of a component from one
+// stream.
+type PurgeRequest struct {
+	Stream     string `json:"stream"`
+	StreamType string `json:"streamType"`
+	// Matched is the number of records of the component in the stream when
+	// the deletion was requested.
+	Matched int64  `json:"matched"`
+	Status  string `json:"status"`
+	// JobID identifies the deletion job of OpenObserve, which deletes the
+	// records asynchronously.
+	JobID string `json:"jobId,omitempty"`
+}
+
+// purgeTarget is a stream holding records of components and the columns
+// selecting those of one component.
+type purgeTarget struct {
+	stream          string
+	streamType      string
+	namespaceColumn string
+	componentColumn string
+}
+
+// PurgeComponent requests the deletion of the log lines and spans of a
+// component from the streams holding them: the logs streams its namespace
+// is routed to and the traces stream. Each stream is counted first and only
+// streams with matching records are purged. OpenObserve deletes the records
+// in the background, so they may still be returned by queries for a while
+// after the requests are accepted.
+func (c *Client) PurgeComponent(ctx context.Context, params PurgeParams) ([]PurgeRequest, error) {
+	if params.Namespace == "" || params.ComponentID == "" {
+		return nil, fmt.Errorf("namespace and component are required to purge a component")
+	}
+	if params.EndTime.IsZero() {
+		params.EndTime = time.Now()
+	}
+
+	var targets []purgeTarget
+	for _, stream := range c.routes.Streams(ooclient.StreamScope{Namespace: params.Namespace}, c.stream) {
+		targets = append(targets, purgeTarget{stream, "logs", c.fields.Namespace, c.fields.ComponentUID})
+	}
+	if c.tracesStream != "" {
+		targets = append(targets, purgeTarget{c.tracesStream, "traces",
+			"service_openchoreo_dev_namespace", "service_openchoreo_dev_component_uid"})
+	}
+
+	requests := make([]PurgeRequest, 0, len(targets))
+	for _, target := range targets {
+		request, err := c.purge(ctx, target, params)
+		if err != nil {
+			return requests, fmt.Errorf("failed to purge stream %s: %w", target.stream, err)
+		}
+		requests = append(requests, request)
+	}
+	return requests, nil
+}
+
+// purge counts the records of the component in a stream and, unless the
+// purge is a dry run, requests their deletion.
+func (c *Client) purge(ctx context.Context, target purgeTarget, params PurgeParams) (PurgeRequest, error) {
+	where := fmt.Sprintf(" WHERE %s = '%s' AND %s = '%s'",
+		target.namespaceColumn, escapeSQLString(params.Namespace),
+		target.componentColumn, escapeSQLString(params.ComponentID))
+	request := PurgeRequest{Stream: target.stream, StreamType: target.streamType}
+
+	countJSON, err := json.Marshal(map[string]interface{}{
+		"query": map[string]interface{}{
+			"sql":        "SELECT count(*) AS total FROM " + quoteIdentifier(target.stream) + where,
+			"start_time": params.StartTime.UnixMicro(),
+			"end_time":   params.EndTime.UnixMicro(),
+			"from":       0,
+			"size":       1,
+		},
+	})
+	if err != nil {
+		return request, err
+	}
+	resp, err := c.executeSearch(ctx, target.streamType, countJSON)
+	if err != nil {
+		return request, fmt.Errorf("failed to count the records of the component: %w", err)
+	}
+	request.Matched = int64(extractTotalCount(resp))
+
+	switch {
+	case request.Matched == 0:
+		request.Status = PurgeStatusEmpty
+		return request, nil
+	case params.DryRun:
+		request.Status = PurgeStatusDryRun
+		return request, nil
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"sql":        "SELECT * FROM " + quoteIdentifier(target.stream) + where,
+		"start_time": params.StartTime.UnixMicro(),
+		"end_time":   params.EndTime.UnixMicro(),
+	})
+	if err != nil {
+		return request, err
+	}
+	endpoint := fmt.Sprintf("%s/api/%s/streams/%s/delete_by_query?type=%s",
+		c.BaseURL(), c.Org(), url.PathEscape(target.stream), target.streamType)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return request, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := c.Do(req)
+	if err != nil {
+		return request, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return request, fmt.Errorf("failed to read response body: %w", err)
+	}
+	switch {
+	case httpResp.StatusCode == http.StatusNotFound || httpResp.StatusCode == http.StatusMethodNotAllowed:
+		return request, ErrPurgeUnsupported
+	case httpResp.StatusCode < 200 || httpResp.StatusCode > 299:
+		return request, &ooclient.StatusError{StatusCode: httpResp.StatusCode, Body: string(body)}
+	}
+
+	var job struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(body, &job); err != nil {
+		return request, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	request.Status = PurgeStatusRequested
+	request.JobID = job.ID
+	return request, nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPurgeComponent(t *testing.T) {
+	var deletes []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/delete_by_query") {
+			var body struct {
+				SQL string `json:"sql"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			deletes = append(deletes, r.URL.Path+"?"+r.URL.RawQuery+" "+body.SQL)
+			w.Write([]byte(`{"id":"job-1"}`))
+			return
+		}
+		var body struct {
+			Query struct {
+				SQL string `json:"sql"`
+			} `json:"query"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if strings.Contains(body.Query.SQL, `FROM "traces"`) {
+			w.Write([]byte(`{"took":1,"hits":[{"total":0}]}`))
+			return
+		}
+		w.Write([]byte(`{"took":1,"hits":[{"total":12}]}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	client.SetTracesStream("traces")
+	params := PurgeParams{Namespace: "acme", ComponentID: "c1", EndTime: time.Now()}
+	requests, err := client.PurgeComponent(context.Background(), params)
+	if err != nil {
+		t.Fatalf("PurgeComponent() error = %v", err)
+	}
+	if len(requests) != 2 {
+		t.Fatalf("expected the logs and traces streams, got %+v", requests)
+	}
+	if requests[0].Status != PurgeStatusRequested || requests[0].JobID != "job-1" || requests[0].Matched != 12 {
+		t.Errorf("unexpected logs request %+v", requests[0])
+	}
+	if requests[1].StreamType != "traces" || requests[1].Status != PurgeStatusEmpty {
+		t.Errorf("expected the empty traces stream to be skipped, got %+v", requests[1])
+	}
+	want := `/api/default/streams/default/delete_by_query?type=logs SELECT * FROM "default" WHERE ` +
+		`kubernetes_labels_openchoreo_dev_namespace = 'acme' AND kubernetes_labels_openchoreo_dev_component_uid = 'c1'`
+	if len(deletes) != 1 || deletes[0] != want {
+		t.Errorf("unexpected deletions %q", deletes)
+	}
+
+	deletes = nil
+	params.DryRun = true
+	requests, err = client.PurgeComponent(context.Background(), params)
+	if err != nil || len(deletes) != 0 || requests[0].Status != PurgeStatusDryRun {
+		t.Errorf("expected a dry run to delete nothing, got %+v, %v", requests, err)
+	}
+}
+
+func TestPurgeComponent_Unsupported(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/delete_by_query") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"took":1,"hits":[{"total":3}]}`))
+	}))
+	defer server.Close()
+
+	_, err := newTestClient(server.URL).PurgeComponent(context.Background(), PurgeParams{Namespace: "acme", ComponentID: "c1"})
+	if !errors.Is(err, ErrPurgeUnsupported) {
+		t.Errorf("expected ErrPurgeUnsupported, got %v", err)
+	}
+}
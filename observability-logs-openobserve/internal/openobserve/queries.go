@@ -4,28 +4,85 @@
 package openobserve
 
 import (
+	"cmp"
 	"encoding/json"
 	"fmt"
 	"log/slog"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
+
+	ooclient "github.com/openchoreo/community-modules/common/openobserve"
+)
+
+// Sort fields accepted by the log queries.
+const (
+	// SortFieldIngestTime orders log entries by the time OpenObserve ingested them (_timestamp).
+	SortFieldIngestTime = "ingestTime"
+	// SortFieldEventTime orders log entries by the time the log line was produced.
+	SortFieldEventTime = "eventTime"
 )
 
-// quoteIdentifier wraps a SQL identifier (e.g. table/stream name) in double
-// quotes and escapes any embedded double-quote characters to prevent SQL injection.
-func quoteIdentifier(identifier string) string {
-	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+// eventTimeColumn is the field Fluent Bit's HTTP output writes the record time
+// into (json_date_key). It carries the time the container runtime observed the
+// line, which can predate _timestamp considerably when ingestion is delayed.
+const eventTimeColumn = "date"
+
+// ValidateSortField returns an error if sortField is not one of the supported sort fields.
+// An empty value is accepted and means the default (ingest time).
+func ValidateSortField(sortField string) error {
+	switch sortField {
+	case "", SortFieldIngestTime, SortFieldEventTime:
+		return nil
+	default:
+		return fmt.Errorf("unsupported sortField %q: must be one of %s, %s", sortField, SortFieldEventTime, SortFieldIngestTime)
+	}
+}
+
+// DefaultSortTiebreakers are the columns that order log lines sharing a
+// timestamp under DefaultFieldMapping, so that paging through them with
+// offsets is stable.
+var DefaultSortTiebreakers = DefaultFieldMapping.SortTiebreakers()
+
+// ValidateSortTiebreakers checks that columns are stream field names.
+func ValidateSortTiebreakers(columns []string) error {
+	for _, column := range columns {
+		if !streamFieldName.MatchString(column) {
+			return fmt.Errorf("invalid sort tiebreaker %q: must be a stream field name", column)
+		}
+	}
+	return nil
 }
 
-// escapeSQLString escapes backslashes and single quotes in a value
-// to prevent SQL injection when interpolating into single-quoted SQL strings.
-func escapeSQLString(value string) string {
-	value = strings.ReplaceAll(value, `\`, `\\`)
-	value = strings.ReplaceAll(value, `'`, `''`)
-	return value
+// logsSortClause returns the ORDER BY clause for log queries. The column is chosen
+// from a fixed set and the direction is whitelisted, so neither is interpolated
+// from user input. Lines sharing a timestamp are ordered by the tiebreakers,
+// which are validated field names, in the same direction.
+func logsSortClause(sortField, sortOrder string, tiebreakers []string) string {
+	column := "_timestamp"
+	if sortField == SortFieldEventTime {
+		column = eventTimeColumn
+	}
+	direction := " DESC"
+	if sortOrder == "ASC" || sortOrder == "asc" {
+		direction = " ASC"
+	}
+	clause := " ORDER BY " + column + direction
+	for _, tiebreaker := range tiebreakers {
+		clause += ", " + tiebreaker + direction
+	}
+	return clause
 }
 
+// quoteIdentifier and escapeSQLString guard the identifiers and values
+// interpolated into SQL against injection.
+var (
+	quoteIdentifier = ooclient.QuoteIdentifier
+	escapeSQLString = ooclient.EscapeSQLString
+)
+
 // mapOperator maps the API operator string to the OpenObserve SQL operator.
 func mapOperator(op string) (string, error) {
 	switch op {
@@ -120,44 +177,39 @@ func parseDurationMinutes(duration string) (int, error) {
 	}
 }
 
-// generateComponentLogsCountQuery generates a count query to get the true total of matching component logs.
-func generateComponentLogsCountQuery(params ComponentLogsParams, stream string, logger *slog.Logger) ([]byte, error) {
+// maxLogSources bounds the number of component/environment groups returned by a log sources query.
+const maxLogSources = 1000
+
+// generateLogSourcesQuery generates an aggregation query listing the distinct
+// component/environment pairs of a namespace that produced logs, with the
+// latest ingest time and log count of each.
+func generateLogSourcesQuery(params LogSourcesParams, stream string, fields FieldMapping, logger *slog.Logger) ([]byte, error) {
 	if params.Namespace == "" {
-		return nil, fmt.Errorf("namespace is required for component log queries")
+		return nil, fmt.Errorf("namespace is required for log sources queries")
 	}
 
 	var conditions []string
 
-	conditions = append(conditions, "kubernetes_labels_openchoreo_dev_namespace = '"+escapeSQLString(params.Namespace)+"'")
+	conditions = append(conditions, fields.Namespace+" = '"+escapeSQLString(params.Namespace)+"'")
+	conditions = append(conditions, fields.ComponentUID+" IS NOT NULL")
 
 	if params.ProjectID != "" {
-		conditions = append(conditions, "kubernetes_labels_openchoreo_dev_project_uid = '"+escapeSQLString(params.ProjectID)+"'")
+		conditions = append(conditions, fields.ProjectUID+" = '"+escapeSQLString(params.ProjectID)+"'")
 	}
 	if params.EnvironmentID != "" {
-		conditions = append(conditions, "kubernetes_labels_openchoreo_dev_environment_uid = '"+escapeSQLString(params.EnvironmentID)+"'")
-	}
-	if len(params.ComponentIDs) > 0 {
-		componentConditions := make([]string, len(params.ComponentIDs))
-		for i, id := range params.ComponentIDs {
-			componentConditions[i] = "kubernetes_labels_openchoreo_dev_component_uid = '" + escapeSQLString(id) + "'"
-		}
-		conditions = append(conditions, "("+strings.Join(componentConditions, " OR ")+")")
-	}
-	if params.SearchPhrase != "" {
-		conditions = append(conditions, "log LIKE '%"+escapeSQLString(params.SearchPhrase)+"%'")
-	}
-	if len(params.LogLevels) > 0 {
-		levelConditions := make([]string, len(params.LogLevels))
-		for i, level := range params.LogLevels {
-			levelConditions[i] = "logLevel = '" + escapeSQLString(level) + "'"
-		}
-		conditions = append(conditions, "("+strings.Join(levelConditions, " OR ")+")")
+		conditions = append(conditions, fields.EnvironmentUID+" = '"+escapeSQLString(params.EnvironmentID)+"'")
 	}
 
-	sql := "SELECT count(*) as total FROM " + quoteIdentifier(stream)
-	if len(conditions) > 0 {
-		sql += " WHERE " + strings.Join(conditions, " AND ")
-	}
+	sql := "SELECT " + fields.ProjectUID + " AS project_uid, " +
+		fields.ProjectName + " AS project_name, " +
+		fields.ComponentUID + " AS component_uid, " +
+		fields.ComponentName + " AS component_name, " +
+		fields.EnvironmentUID + " AS environment_uid, " +
+		fields.EnvironmentName + " AS environment_name, " +
+		"max(_timestamp) AS last_seen, count(*) AS log_count FROM " + quoteIdentifier(stream) +
+		" WHERE " + strings.Join(conditions, " AND ") +
+		" GROUP BY project_uid, project_name, component_uid, component_name, environment_uid, environment_name" +
+		" ORDER BY last_seen DESC"
 
 	query := map[string]interface{}{
 		"query": map[string]interface{}{
@@ -165,46 +217,65 @@ func generateComponentLogsCountQuery(params ComponentLogsParams, stream string,
 			"start_time": params.StartTime.UnixMicro(),
 			"end_time":   params.EndTime.UnixMicro(),
 			"from":       0,
-			"size":       0,
+			"size":       maxLogSources,
 		},
 	}
 
-	if logger.Enabled(nil, slog.LevelDebug) {
-		if prettyJSON, err := json.MarshalIndent(query, "", "    "); err == nil {
-			fmt.Printf("Generated count query for component logs:\n")
-			fmt.Println(string(prettyJSON))
-		}
-	}
+	logGenerated(logger, query, "log sources query")
 
 	return json.Marshal(query)
 }
 
-// generateWorkflowLogsCountQuery generates a count query to get the true total of matching workflow logs.
-func generateWorkflowLogsCountQuery(params WorkflowLogsParams, stream string, logger *slog.Logger) ([]byte, error) {
-	var conditions []string
+// maxLevelHistogramRows bounds the (bucket, level) rows returned by a level histogram query.
+const maxLevelHistogramRows = 10000
 
-	if params.Namespace != "" {
-		conditions = append(conditions, "kubernetes_namespace_name = 'workflows-"+escapeSQLString(params.Namespace)+"'")
+// generateComponentLevelHistogramQuery generates a histogram query counting the
+// log lines of a single component per level and time bucket. It filters on
+// component_uid equality only, besides the namespace, so it stays cheap enough
+// to be polled.
+func generateComponentLevelHistogramQuery(params LevelHistogramParams, stream string, fields FieldMapping, logger *slog.Logger) ([]byte, error) {
+	if params.Namespace == "" {
+		return nil, fmt.Errorf("namespace is required for level histogram queries")
 	}
-	if params.WorkflowRunName != "" {
-		conditions = append(conditions, "kubernetes_labels_workflows_argoproj_io_workflow = '"+escapeSQLString(params.WorkflowRunName)+"'")
+	if params.ComponentUID == "" {
+		return nil, fmt.Errorf("componentUid is required for level histogram queries")
 	}
-	if params.SearchPhrase != "" {
-		conditions = append(conditions, "log LIKE '%"+escapeSQLString(params.SearchPhrase)+"%'")
+	if params.Interval < time.Second {
+		return nil, fmt.Errorf("histogram interval must be at least 1s")
 	}
-	if len(params.LogLevels) > 0 {
-		levelConditions := make([]string, len(params.LogLevels))
-		for i, level := range params.LogLevels {
-			levelConditions[i] = "logLevel = '" + escapeSQLString(level) + "'"
-		}
-		conditions = append(conditions, "("+strings.Join(levelConditions, " OR ")+")")
+
+	sql := fmt.Sprintf("SELECT histogram(_timestamp, '%d seconds') AS bucket, %s AS level, count(*) AS count FROM %s"+
+		" WHERE %s = '%s' AND %s = '%s'"+
+		" GROUP BY bucket, level ORDER BY bucket ASC",
+		int(params.Interval/time.Second), fields.Level, quoteIdentifier(stream),
+		fields.Namespace, escapeSQLString(params.Namespace), fields.ComponentUID, escapeSQLString(params.ComponentUID))
+
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"sql":        sql,
+			"start_time": params.StartTime.UnixMicro(),
+			"end_time":   params.EndTime.UnixMicro(),
+			"from":       0,
+			"size":       maxLevelHistogramRows,
+		},
 	}
 
-	sql := "SELECT count(*) as total FROM " + quoteIdentifier(stream)
-	if len(conditions) > 0 {
-		sql += " WHERE " + strings.Join(conditions, " AND ")
+	logGenerated(logger, query, "level histogram query")
+
+	return json.Marshal(query)
+}
+
+// generateComponentLogsCountQuery generates a count query to get the true total of matching component logs.
+func generateComponentLogsCountQuery(params ComponentLogsParams, stream string, plans *PlanCache, logger *slog.Logger) ([]byte, error) {
+	if params.Namespace == "" {
+		return nil, fmt.Errorf("namespace is required for component log queries")
 	}
 
+	sql := plans.sql(planKey("component-logs-count", stream, componentLogsSignature(params)), func() string {
+		return "SELECT count(*) as total FROM " + quoteIdentifier(stream) +
+			" WHERE " + strings.Join(componentLogsConditions(params), " AND ")
+	})
+
 	query := map[string]interface{}{
 		"query": map[string]interface{}{
 			"sql":        sql,
@@ -215,30 +286,173 @@ func generateWorkflowLogsCountQuery(params WorkflowLogsParams, stream string, lo
 		},
 	}
 
-	if logger.Enabled(nil, slog.LevelDebug) {
-		if prettyJSON, err := json.MarshalIndent(query, "", "    "); err == nil {
-			fmt.Printf("Generated count query for workflow logs:\n")
-			fmt.Println(string(prettyJSON))
+	logGenerated(logger, query, "count query for component logs")
+
+	return json.Marshal(query)
+}
+
+// generateWorkflowLogsCountQuery generates a count query to get the true total of matching workflow logs.
+func generateWorkflowLogsCountQuery(params WorkflowLogsParams, stream string, plans *PlanCache, logger *slog.Logger) ([]byte, error) {
+	sql := plans.sql(planKey("workflow-logs-count", stream, workflowLogsSignature(params)), func() string {
+		sql := "SELECT count(*) as total FROM " + quoteIdentifier(stream)
+		if conditions := workflowLogsConditions(params); len(conditions) > 0 {
+			sql += " WHERE " + strings.Join(conditions, " AND ")
 		}
+		return sql
+	})
+
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"sql":        sql,
+			"start_time": params.StartTime.UnixMicro(),
+			"end_time":   params.EndTime.UnixMicro(),
+			"from":       0,
+			"size":       0,
+		},
 	}
 
+	logGenerated(logger, query, "count query for workflow logs")
+
 	return json.Marshal(query)
 }
 
-// generateAlertConfig generates an OpenObserve alert configuration as JSON
-func generateAlertConfig(params LogAlertParams, streamName string, logger *slog.Logger) ([]byte, error) {
+// DefaultAlertDestination is the OpenObserve alert destination created by the
+// setup job, which calls the adapter's alert webhook.
+const DefaultAlertDestination = "openchoreo"
+
+// Alert rule severities.
+const (
+	AlertSeverityCritical = "critical"
+	AlertSeverityWarning  = "warning"
+	AlertSeverityInfo     = "info"
+)
+
+// ValidateAlertSeverity returns an error if severity is not one of the
+// supported severities. An empty value is accepted and means unset.
+func ValidateAlertSeverity(severity string) error {
+	switch severity {
+	case "", AlertSeverityCritical, AlertSeverityWarning, AlertSeverityInfo:
+		return nil
+	default:
+		return fmt.Errorf("invalid alert severity %q: must be one of %s, %s, %s",
+			severity, AlertSeverityCritical, AlertSeverityWarning, AlertSeverityInfo)
+	}
+}
+
+// Sources of an alert condition.
+const (
+	AlertSourceLogs   = "logs"
+	AlertSourceEvents = "events"
+)
+
+// Ways the conditions of a composite alert are combined.
+const (
+	AlertMatchAll = "all"
+	AlertMatchAny = "any"
+)
+
+// ValidateAlertConditions returns an error if a condition or the match mode is invalid.
+func ValidateAlertConditions(conditions []AlertCondition, match string) error {
+	if match != "" && match != AlertMatchAll && match != AlertMatchAny {
+		return fmt.Errorf("invalid alert condition match %q: must be one of %s, %s", match, AlertMatchAll, AlertMatchAny)
+	}
+	for i, c := range conditions {
+		if c.Source != "" && c.Source != AlertSourceLogs && c.Source != AlertSourceEvents {
+			return fmt.Errorf("invalid alert condition %d: unsupported source %q: must be one of %s, %s",
+				i, c.Source, AlertSourceLogs, AlertSourceEvents)
+		}
+		if c.Query == "" {
+			return fmt.Errorf("invalid alert condition %d: query is required", i)
+		}
+		if _, err := mapOperator(c.Operator); err != nil {
+			return fmt.Errorf("invalid alert condition %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// compositeAlertSQL builds a query over all conditions of a composite alert.
+// Each condition counts its matches in a scalar subquery, log conditions on
+// the logs stream and event conditions (matching the event reason) on the
+// events stream, and the outer WHERE clause combines the thresholds with AND
+// or OR. The query returns one row exactly when the composite condition
+// holds, so OpenObserve evaluates the whole rule in a single scheduled alert.
+func compositeAlertSQL(params LogAlertParams, conditions []AlertCondition, logsStream, eventsStream string, fields FieldMapping) (string, error) {
+	subqueries := make([]string, len(conditions))
+	predicates := make([]string, len(conditions))
+	for i, c := range conditions {
+		sqlOperator, err := mapOperator(c.Operator)
+		if err != nil {
+			return "", err
+		}
+		var from, where string
+		if c.Source == AlertSourceEvents {
+			from = quoteIdentifier(eventsStream)
+			where = fmt.Sprintf("%s = '%s' AND %s = '%s' AND %s = '%s'",
+				evReason, escapeSQLString(c.Query),
+				evEnvironmentID, escapeSQLString(params.EnvironmentUID),
+				evComponentID, escapeSQLString(params.ComponentUID))
+		} else {
+			from = quoteIdentifier(logsStream)
+			where = fmt.Sprintf("str_match(log, '%s') AND %s = '%s' AND %s = '%s'",
+				escapeSQLString(c.Query),
+				fields.EnvironmentUID, escapeSQLString(params.EnvironmentUID),
+				fields.ComponentUID, escapeSQLString(params.ComponentUID))
+		}
+		subqueries[i] = fmt.Sprintf("(SELECT count(*) AS c%d FROM %s WHERE %s) AS q%d", i, from, where, i)
+		predicates[i] = fmt.Sprintf("q%d.c%d %s %s", i, i, sqlOperator, strconv.FormatFloat(float64(c.Threshold), 'f', -1, 32))
+	}
+
+	combinator := " AND "
+	if params.ConditionMatch == AlertMatchAny {
+		combinator = " OR "
+	}
+	return "SELECT * FROM " + strings.Join(subqueries, " CROSS JOIN ") + " WHERE " + strings.Join(predicates, combinator), nil
+}
+
+// generateAlertConfig generates an OpenObserve alert configuration as JSON.
+// Alerts with additional conditions are generated as composite alerts that
+// fire when the query returns a row; see compositeAlertSQL.
+func generateAlertConfig(params LogAlertParams, streamName, eventsStream string, fields FieldMapping, logger *slog.Logger) ([]byte, error) {
 	query := fmt.Sprintf(
-		"SELECT _timestamp FROM %s WHERE str_match(log, '%s') AND kubernetes_labels_openchoreo_dev_environment_uid = '%s' AND kubernetes_labels_openchoreo_dev_component_uid = '%s'",
+		"SELECT _timestamp FROM %s WHERE str_match(log, '%s') AND %s = '%s' AND %s = '%s'",
 		quoteIdentifier(streamName),
 		escapeSQLString(params.SearchPattern),
-		escapeSQLString(params.EnvironmentUID),
-		escapeSQLString(params.ComponentUID),
+		fields.EnvironmentUID, escapeSQLString(params.EnvironmentUID),
+		fields.ComponentUID, escapeSQLString(params.ComponentUID),
 	)
 
 	sqlOperator, err := mapOperator(params.Operator)
 	if err != nil {
 		return nil, fmt.Errorf("invalid alert operator: %w", err)
 	}
+	threshold := params.ThresholdValue
+
+	if err := ValidateAlertConditions(params.Conditions, params.ConditionMatch); err != nil {
+		return nil, err
+	}
+	var encodedConditions string
+	if len(params.Conditions) > 0 {
+		conditions := append([]AlertCondition{{
+			Source:    AlertSourceLogs,
+			Query:     params.SearchPattern,
+			Operator:  params.Operator,
+			Threshold: params.ThresholdValue,
+		}}, params.Conditions...)
+		if query, err = compositeAlertSQL(params, conditions, streamName, eventsStream, fields); err != nil {
+			return nil, fmt.Errorf("invalid alert condition: %w", err)
+		}
+		sqlOperator, threshold = ">=", 1
+
+		encoded, err := json.Marshal(struct {
+			Match      string           `json:"match"`
+			Conditions []AlertCondition `json:"conditions"`
+		}{cmp.Or(params.ConditionMatch, AlertMatchAll), conditions})
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode alert conditions: %w", err)
+		}
+		encodedConditions = string(encoded)
+	}
 
 	alertName := ""
 	if params.Name != nil {
@@ -255,6 +469,26 @@ func generateAlertConfig(params LogAlertParams, streamName string, logger *slog.
 		return nil, fmt.Errorf("invalid alert interval: %w", err)
 	}
 
+	if err := ValidateAlertSeverity(params.Severity); err != nil {
+		return nil, err
+	}
+	destinations := params.Destinations
+	if len(destinations) == 0 {
+		destinations = []string{DefaultAlertDestination}
+	}
+	contextAttributes := map[string]interface{}{
+		"namespace":      params.Namespace,
+		"projectUid":     params.ProjectUID,
+		"environmentUid": params.EnvironmentUID,
+		"componentUid":   params.ComponentUID,
+	}
+	if params.Severity != "" {
+		contextAttributes["severity"] = params.Severity
+	}
+	if encodedConditions != "" {
+		contextAttributes["conditions"] = encodedConditions
+	}
+
 	alertConfig := map[string]interface{}{
 		"name":         alertName,
 		"stream_name":  streamName,
@@ -269,32 +503,23 @@ func generateAlertConfig(params LogAlertParams, streamName string, logger *slog.
 		"trigger_condition": map[string]interface{}{
 			"period":    period,
 			"frequency": frequency,
-			"threshold": params.ThresholdValue,
+			"threshold": threshold,
 			"operator":  sqlOperator,
 			"silence":   0,
 		},
-		"destinations": []string{"openchoreo"},
-		"context_attributes": map[string]interface{}{
-			"namespace":      params.Namespace,
-			"projectUid":     params.ProjectUID,
-			"environmentUid": params.EnvironmentUID,
-			"componentUid":   params.ComponentUID,
-		},
+		"destinations":       destinations,
+		"context_attributes": contextAttributes,
 	}
 
-	if logger.Enabled(nil, slog.LevelDebug) {
-		if prettyJSON, err := json.MarshalIndent(alertConfig, "", "    "); err == nil {
-			fmt.Printf("Generated alert config for %s:\n", alertName)
-			fmt.Println(string(prettyJSON))
-		}
-	}
+	logGenerated(logger, alertConfig, "alert config for %s", alertName)
 
 	return json.Marshal(alertConfig)
 }
 
-// generateWorkflowLogsQuery generates the OpenObserve query for workflow logs
-func generateWorkflowLogsQuery(params WorkflowLogsParams, stream string, logger *slog.Logger) ([]byte, error) {
+// workflowLogsConditions builds the SQL WHERE conditions of workflow log queries.
+func workflowLogsConditions(params WorkflowLogsParams) []string {
 	var conditions []string
+	fields := params.fields()
 
 	// Add namespace filter
 	if params.Namespace != "" {
@@ -306,32 +531,97 @@ func generateWorkflowLogsQuery(params WorkflowLogsParams, stream string, logger
 		conditions = append(conditions, "kubernetes_labels_workflows_argoproj_io_workflow = '"+escapeSQLString(params.WorkflowRunName)+"'")
 	}
 
+	// Add step and pod filters
+	if params.StepName != "" {
+		conditions = append(conditions, workflowStepCondition(params.StepName))
+	}
+	if params.PodName != "" {
+		conditions = append(conditions, fields.PodName+" = '"+escapeSQLString(params.PodName)+"'")
+	}
+
 	// Add search phrase filter
 	if params.SearchPhrase != "" {
 		conditions = append(conditions, "log LIKE '%"+escapeSQLString(params.SearchPhrase)+"%'")
 	}
 
-	// Add log levels filter
+	// Add log levels filter, falling back to the raw log text for the
+	// lines of CI tooling that carry no level
 	if len(params.LogLevels) > 0 {
-		levelConditions := make([]string, len(params.LogLevels))
-		for i, level := range params.LogLevels {
-			levelConditions[i] = "logLevel = '" + escapeSQLString(level) + "'"
+		conditions = append(conditions, workflowLogLevelsCondition(fields.Level, params.LogLevels))
+	}
+
+	// Add search query filter
+	if params.Query != nil {
+		conditions = append(conditions, params.Query.condition(fields))
+	}
+	return conditions
+}
+
+// workflowLogLevelsCondition matches the workflow log lines of the levels,
+// either recorded in the level column or, when it is empty, inferred from
+// the log text the way ExtractLogLevel infers it.
+func workflowLogLevelsCondition(column string, levels []string) string {
+	var inferred []string
+	for _, level := range levels {
+		if condition := inferredLogLevelCondition(level); condition != "" && !slices.Contains(inferred, condition) {
+			inferred = append(inferred, condition)
 		}
-		conditions = append(conditions, "("+strings.Join(levelConditions, " OR ")+")")
 	}
+	condition := logLevelsCondition(column, levels)
+	if len(inferred) == 0 {
+		return condition
+	}
+	return "(" + condition + " OR ((" + column + " IS NULL OR " + column + " = '') AND (" + strings.Join(inferred, " OR ") + ")))"
+}
 
-	// Build SQL
-	sql := "SELECT * FROM " + quoteIdentifier(stream)
-	if len(conditions) > 0 {
-		sql += " WHERE " + strings.Join(conditions, " AND ")
+// inferredLogLevelCondition matches the log lines ExtractLogLevel assigns
+// the level: those naming it and none of the levels of higher precedence, or
+// naming none at all for the default level. It is empty for levels that are
+// never inferred.
+func inferredLogLevelCondition(level string) string {
+	level = strings.ToUpper(strings.TrimSpace(level))
+	if level == "WARNING" {
+		level = "WARN"
 	}
+	i := slices.Index(logLevelKeywords, level)
+	if i < 0 {
+		return ""
+	}
+	var conditions []string
+	for _, keyword := range logLevelKeywords[:i] {
+		conditions = append(conditions, "UPPER(log) NOT LIKE '%"+keyword+"%'")
+	}
+	named := "UPPER(log) LIKE '%" + level + "%'"
+	if level == defaultLogLevel {
+		unnamed := make([]string, 0, len(logLevelKeywords)-i-1)
+		for _, keyword := range logLevelKeywords[i+1:] {
+			unnamed = append(unnamed, "UPPER(log) NOT LIKE '%"+keyword+"%'")
+		}
+		named = "(" + named + " OR (" + strings.Join(unnamed, " AND ") + "))"
+	}
+	conditions = append(conditions, named)
+	return "(" + strings.Join(conditions, " AND ") + ")"
+}
 
-	// Add sort order
-	if params.SortOrder == "ASC" || params.SortOrder == "asc" {
-		sql += " ORDER BY _timestamp ASC"
-	} else {
-		sql += " ORDER BY _timestamp DESC"
+// workflowLogsSignature returns the filters of params that the SQL of
+// workflow log queries depends on, leaving out the time window and paging.
+func workflowLogsSignature(params WorkflowLogsParams) []string {
+	return []string{
+		params.Namespace, params.WorkflowRunName, params.StepName, params.PodName,
+		params.SearchPhrase, strings.Join(params.LogLevels, planListSeparator), params.Query.condition(params.fields()),
+		params.SortField, params.SortOrder, strings.Join(params.Tiebreakers, planListSeparator), params.fields().signature(),
 	}
+}
+
+// generateWorkflowLogsQuery generates the OpenObserve query for workflow logs
+func generateWorkflowLogsQuery(params WorkflowLogsParams, stream string, plans *PlanCache, logger *slog.Logger) ([]byte, error) {
+	sql := plans.sql(planKey("workflow-logs", stream, workflowLogsSignature(params)), func() string {
+		sql := "SELECT * FROM " + quoteIdentifier(stream)
+		if conditions := workflowLogsConditions(params); len(conditions) > 0 {
+			sql += " WHERE " + strings.Join(conditions, " AND ")
+		}
+		return sql + logsSortClause(params.SortField, params.SortOrder, params.Tiebreakers)
+	})
 
 	// Set default limit if not specified
 	limit := params.Limit
@@ -344,48 +634,59 @@ func generateWorkflowLogsQuery(params WorkflowLogsParams, stream string, logger
 			"sql":        sql,
 			"start_time": params.StartTime.UnixMicro(),
 			"end_time":   params.EndTime.UnixMicro(),
-			"from":       0,
+			"from":       params.Offset,
 			"size":       limit,
 		},
 		"timeout": 0,
 	}
 
-	if logger.Enabled(nil, slog.LevelDebug) {
-		if prettyJSON, err := json.MarshalIndent(query, "", "    "); err == nil {
-			fmt.Printf("Generated query to fetch %s workflow logs:\n", stream)
-			fmt.Println(string(prettyJSON))
-		}
-	}
+	logGenerated(logger, query, "query to fetch %s workflow logs", stream)
 
 	return json.Marshal(query)
 }
 
-// generateComponentLogsQuery generates the OpenObserve query for application logs
-func generateComponentLogsQuery(params ComponentLogsParams, stream string, logger *slog.Logger) ([]byte, error) {
-	if params.Namespace == "" {
-		return nil, fmt.Errorf("namespace is required for component log queries")
-	}
+// workflowStepCondition matches the pods of a workflow step. Argo records the
+// node name of a step pod, "<workflow>.<step>" or "<workflow>[0].<step>" for
+// nested steps, in the workflows.argoproj.io/node-name annotation; retried
+// nodes carry a "(n)" suffix.
+func workflowStepCondition(step string) string {
+	escaped := escapeSQLString(step)
+	return "(" + workflowNodeNameField + " LIKE '%." + escaped +
+		"' OR " + workflowNodeNameField + " LIKE '%." + escaped + "(%')"
+}
 
+// componentLogsConditions builds the SQL WHERE conditions of application log
+// queries.
+func componentLogsConditions(params ComponentLogsParams) []string {
 	var conditions []string
+	fields := params.fields()
 
 	// Add namespace filter
-	conditions = append(conditions, "kubernetes_labels_openchoreo_dev_namespace = '"+escapeSQLString(params.Namespace)+"'")
+	conditions = append(conditions, fields.Namespace+" = '"+escapeSQLString(params.Namespace)+"'")
 
 	// Add project filter
 	if params.ProjectID != "" {
-		conditions = append(conditions, "kubernetes_labels_openchoreo_dev_project_uid = '"+escapeSQLString(params.ProjectID)+"'")
+		conditions = append(conditions, fields.ProjectUID+" = '"+escapeSQLString(params.ProjectID)+"'")
 	}
 
 	// Add environment filter
-	if params.EnvironmentID != "" {
-		conditions = append(conditions, "kubernetes_labels_openchoreo_dev_environment_uid = '"+escapeSQLString(params.EnvironmentID)+"'")
+	if environments := params.environmentIDs(); len(environments) > 0 {
+		environmentConditions := make([]string, len(environments))
+		for i, id := range environments {
+			environmentConditions[i] = fields.EnvironmentUID + " = '" + escapeSQLString(id) + "'"
+		}
+		if len(environmentConditions) == 1 {
+			conditions = append(conditions, environmentConditions[0])
+		} else {
+			conditions = append(conditions, "("+strings.Join(environmentConditions, " OR ")+")")
+		}
 	}
 
 	// Add optional component IDs filter
 	if len(params.ComponentIDs) > 0 {
 		componentConditions := make([]string, len(params.ComponentIDs))
 		for i, id := range params.ComponentIDs {
-			componentConditions[i] = "kubernetes_labels_openchoreo_dev_component_uid = '" + escapeSQLString(id) + "'"
+			componentConditions[i] = fields.ComponentUID + " = '" + escapeSQLString(id) + "'"
 		}
 		conditions = append(conditions, "("+strings.Join(componentConditions, " OR ")+")")
 	}
@@ -397,25 +698,83 @@ func generateComponentLogsQuery(params ComponentLogsParams, stream string, logge
 
 	// Add log levels filter
 	if len(params.LogLevels) > 0 {
-		levelConditions := make([]string, len(params.LogLevels))
-		for i, level := range params.LogLevels {
-			levelConditions[i] = "logLevel = '" + escapeSQLString(level) + "'"
+		conditions = append(conditions, logLevelsCondition(fields.Level, params.LogLevels))
+	}
+
+	// Add search query filter
+	if params.Query != nil {
+		conditions = append(conditions, params.Query.condition(fields))
+	}
+	return conditions
+}
+
+// AllEnvironments, as an environment ID of component log queries, selects
+// the logs of every environment.
+const AllEnvironments = "*"
+
+// environmentIDs returns the distinct environments the query is restricted
+// to, or nil when it covers every environment.
+func (params ComponentLogsParams) environmentIDs() []string {
+	var ids []string
+	for _, id := range append([]string{params.EnvironmentID}, params.EnvironmentIDs...) {
+		if id == AllEnvironments {
+			return nil
+		}
+		if id != "" && !slices.Contains(ids, id) {
+			ids = append(ids, id)
 		}
-		conditions = append(conditions, "("+strings.Join(levelConditions, " OR ")+")")
 	}
+	return ids
+}
 
-	// Build SQL
-	sql := "SELECT * FROM " + quoteIdentifier(stream)
-	if len(conditions) > 0 {
-		sql += " WHERE " + strings.Join(conditions, " AND ")
+// fields returns the field mapping of the query.
+func (params ComponentLogsParams) fields() FieldMapping {
+	if params.Fields == nil {
+		return DefaultFieldMapping
+	}
+	return *params.Fields
+}
+
+// fields returns the field mapping of the query.
+func (params WorkflowLogsParams) fields() FieldMapping {
+	if params.Fields == nil {
+		return DefaultFieldMapping
+	}
+	return *params.Fields
+}
+
+// componentLogsSignature returns the filters of params that the SQL of
+// application log queries depends on, leaving out the time window and paging.
+func componentLogsSignature(params ComponentLogsParams) []string {
+	return []string{
+		params.Namespace, params.ProjectID, strings.Join(params.environmentIDs(), planListSeparator),
+		strings.Join(params.ComponentIDs, planListSeparator),
+		params.SearchPhrase, strings.Join(params.LogLevels, planListSeparator), params.Query.condition(params.fields()),
+		params.SortField, params.SortOrder, strings.Join(params.Tiebreakers, planListSeparator), params.fields().signature(),
 	}
+}
 
-	// Add sort order (whitelist to prevent injection since this is not inside quotes)
-	if params.SortOrder == "ASC" || params.SortOrder == "asc" {
-		sql += " ORDER BY _timestamp ASC"
-	} else {
-		sql += " ORDER BY _timestamp DESC"
+// logLevelsCondition matches the log lines whose level column holds any of levels.
+func logLevelsCondition(column string, levels []string) string {
+	levelConditions := make([]string, len(levels))
+	for i, level := range levels {
+		levelConditions[i] = column + " = '" + escapeSQLString(level) + "'"
 	}
+	return "(" + strings.Join(levelConditions, " OR ") + ")"
+}
+
+// generateComponentLogsQuery generates the OpenObserve query for application logs
+func generateComponentLogsQuery(params ComponentLogsParams, stream string, plans *PlanCache, logger *slog.Logger) ([]byte, error) {
+	if params.Namespace == "" {
+		return nil, fmt.Errorf("namespace is required for component log queries")
+	}
+
+	// The sort clause is whitelisted to prevent injection since it is not inside quotes
+	sql := plans.sql(planKey("component-logs", stream, componentLogsSignature(params)), func() string {
+		return "SELECT * FROM " + quoteIdentifier(stream) +
+			" WHERE " + strings.Join(componentLogsConditions(params), " AND ") +
+			logsSortClause(params.SortField, params.SortOrder, params.Tiebreakers)
+	})
 
 	// Set default limit if not specified
 	limit := params.Limit
@@ -428,18 +787,13 @@ func generateComponentLogsQuery(params ComponentLogsParams, stream string, logge
 			"sql":        sql,
 			"start_time": params.StartTime.UnixMicro(),
 			"end_time":   params.EndTime.UnixMicro(),
-			"from":       0,
+			"from":       params.Offset,
 			"size":       limit,
 		},
 		"timeout": 0,
 	}
 
-	if logger.Enabled(nil, slog.LevelDebug) {
-		if prettyJSON, err := json.MarshalIndent(query, "", "    "); err == nil {
-			fmt.Printf("Generated query to fetch %s application logs:\n", stream)
-			fmt.Println(string(prettyJSON))
-		}
-	}
+	logGenerated(logger, query, "query to fetch %s application logs", stream)
 
 	return json.Marshal(query)
 }
@@ -508,12 +862,7 @@ func buildEventsQuery(conditions []string, stream, sortOrder string, limit int,
 		"timeout": 0,
 	}
 
-	if logger.Enabled(nil, slog.LevelDebug) {
-		if prettyJSON, err := json.MarshalIndent(query, "", "    "); err == nil {
-			fmt.Printf("Generated query to fetch %s %s:\n", stream, label)
-			fmt.Println(string(prettyJSON))
-		}
-	}
+	logGenerated(logger, query, "query to fetch %s %s", stream, label)
 
 	return json.Marshal(query)
 }
@@ -535,12 +884,7 @@ func buildEventsCountQuery(conditions []string, stream string, start, end time.T
 		},
 	}
 
-	if logger.Enabled(nil, slog.LevelDebug) {
-		if prettyJSON, err := json.MarshalIndent(query, "", "    "); err == nil {
-			fmt.Printf("Generated count query for %s:\n", label)
-			fmt.Println(string(prettyJSON))
-		}
-	}
+	logGenerated(logger, query, "count query for %s", label)
 
 	return json.Marshal(query)
 }
@@ -196,7 +196,7 @@ func TestGenerateComponentLogsQuery(t *testing.T) {
 			EndTime:   endTime,
 		}
 
-		result, err := generateComponentLogsQuery(params, "mystream", testLogger())
+		result, err := generateComponentLogsQuery(params, "mystream", nil, testLogger())
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -224,7 +224,7 @@ func TestGenerateComponentLogsQuery(t *testing.T) {
 			StartTime: startTime,
 			EndTime:   endTime,
 		}
-		_, err := generateComponentLogsQuery(params, "mystream", testLogger())
+		_, err := generateComponentLogsQuery(params, "mystream", nil, testLogger())
 		if err == nil {
 			t.Fatal("expected error for missing namespace")
 		}
@@ -244,7 +244,7 @@ func TestGenerateComponentLogsQuery(t *testing.T) {
 			EndTime:      endTime,
 		}
 
-		result, err := generateComponentLogsQuery(params, "mystream", testLogger())
+		result, err := generateComponentLogsQuery(params, "mystream", nil, testLogger())
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -275,6 +275,45 @@ func TestGenerateComponentLogsQuery(t *testing.T) {
 		}
 	})
 
+	t.Run("across environments", func(t *testing.T) {
+		tests := []struct {
+			name           string
+			environmentID  string
+			environmentIDs []string
+			want           string
+		}{
+			{"listed", "", []string{"dev", "prod", "dev"}, "(kubernetes_labels_openchoreo_dev_environment_uid = 'dev' OR kubernetes_labels_openchoreo_dev_environment_uid = 'prod')"},
+			{"merged with the scope", "stage", []string{"prod"}, "(kubernetes_labels_openchoreo_dev_environment_uid = 'stage' OR kubernetes_labels_openchoreo_dev_environment_uid = 'prod')"},
+			{"all", "dev", []string{AllEnvironments}, ""},
+			{"all in the scope", AllEnvironments, nil, ""},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				params := ComponentLogsParams{
+					Namespace:      "test-ns",
+					EnvironmentID:  tt.environmentID,
+					EnvironmentIDs: tt.environmentIDs,
+					StartTime:      startTime,
+					EndTime:        endTime,
+				}
+				result, err := generateComponentLogsQuery(params, "mystream", nil, testLogger())
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				var query map[string]interface{}
+				json.Unmarshal(result, &query)
+				sql := query["query"].(map[string]interface{})["sql"].(string)
+				if tt.want == "" {
+					if strings.Contains(sql, "environment_uid") {
+						t.Errorf("expected no environment filter, got: %s", sql)
+					}
+				} else if !strings.Contains(sql, tt.want) {
+					t.Errorf("expected SQL to contain %q, got: %s", tt.want, sql)
+				}
+			})
+		}
+	})
+
 	t.Run("SQL injection prevention", func(t *testing.T) {
 		params := ComponentLogsParams{
 			Namespace:    "test'; DROP TABLE users;--",
@@ -283,7 +322,7 @@ func TestGenerateComponentLogsQuery(t *testing.T) {
 			EndTime:      endTime,
 		}
 
-		result, err := generateComponentLogsQuery(params, "mystream", testLogger())
+		result, err := generateComponentLogsQuery(params, "mystream", nil, testLogger())
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -316,7 +355,7 @@ func TestGenerateWorkflowLogsQuery(t *testing.T) {
 			EndTime:         endTime,
 		}
 
-		result, err := generateWorkflowLogsQuery(params, "mystream", testLogger())
+		result, err := generateWorkflowLogsQuery(params, "mystream", nil, testLogger())
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -346,7 +385,7 @@ func TestGenerateWorkflowLogsQuery(t *testing.T) {
 			EndTime:         endTime,
 		}
 
-		result, err := generateWorkflowLogsQuery(params, "mystream", testLogger())
+		result, err := generateWorkflowLogsQuery(params, "mystream", nil, testLogger())
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -390,7 +429,7 @@ func TestGenerateAlertConfig(t *testing.T) {
 			Enabled:        &enabled,
 		}
 
-		result, err := generateAlertConfig(params, "mystream", testLogger())
+		result, err := generateAlertConfig(params, "mystream", "k8s_events", DefaultFieldMapping, testLogger())
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -437,6 +476,136 @@ func TestGenerateAlertConfig(t *testing.T) {
 		if ca["namespace"] != "ns-1" {
 			t.Errorf("expected namespace 'ns-1', got %v", ca["namespace"])
 		}
+		if _, ok := ca["severity"]; ok {
+			t.Errorf("expected no severity, got %v", ca["severity"])
+		}
+		if dest := config["destinations"].([]interface{}); len(dest) != 1 || dest[0] != DefaultAlertDestination {
+			t.Errorf("expected default destination, got %v", dest)
+		}
+	})
+
+	t.Run("severity and destinations", func(t *testing.T) {
+		params := LogAlertParams{
+			Name:         &name,
+			Operator:     "gt",
+			Window:       "5m",
+			Interval:     "1m",
+			Enabled:      &enabled,
+			Severity:     AlertSeverityCritical,
+			Destinations: []string{"openchoreo", "pagerduty"},
+		}
+		result, err := generateAlertConfig(params, "mystream", "k8s_events", DefaultFieldMapping, testLogger())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var config struct {
+			Destinations      []string          `json:"destinations"`
+			ContextAttributes map[string]string `json:"context_attributes"`
+		}
+		if err := json.Unmarshal(result, &config); err != nil {
+			t.Fatalf("invalid JSON: %v", err)
+		}
+		if strings.Join(config.Destinations, ",") != "openchoreo,pagerduty" {
+			t.Errorf("unexpected destinations %v", config.Destinations)
+		}
+		if config.ContextAttributes["severity"] != "critical" {
+			t.Errorf("expected severity critical, got %q", config.ContextAttributes["severity"])
+		}
+	})
+
+	t.Run("invalid severity", func(t *testing.T) {
+		params := LogAlertParams{
+			Name:     &name,
+			Operator: "gt",
+			Window:   "5m",
+			Interval: "1m",
+			Enabled:  &enabled,
+			Severity: "urgent",
+		}
+		if _, err := generateAlertConfig(params, "mystream", "k8s_events", DefaultFieldMapping, testLogger()); err == nil {
+			t.Fatal("expected error for invalid severity")
+		}
+	})
+
+	t.Run("composite conditions", func(t *testing.T) {
+		params := LogAlertParams{
+			Name:           &name,
+			EnvironmentUID: "env-uid",
+			ComponentUID:   "comp-uid",
+			SearchPattern:  "error",
+			Operator:       "gt",
+			ThresholdValue: 10,
+			Window:         "5m",
+			Interval:       "1m",
+			Enabled:        &enabled,
+			Conditions: []AlertCondition{
+				{Source: AlertSourceEvents, Query: "BackOff", Operator: "gte", Threshold: 3},
+			},
+			ConditionMatch: AlertMatchAny,
+		}
+		result, err := generateAlertConfig(params, "mystream", "k8s_events", DefaultFieldMapping, testLogger())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var config struct {
+			QueryCondition struct {
+				SQL string `json:"sql"`
+			} `json:"query_condition"`
+			TriggerCondition struct {
+				Operator  string  `json:"operator"`
+				Threshold float64 `json:"threshold"`
+			} `json:"trigger_condition"`
+			ContextAttributes map[string]string `json:"context_attributes"`
+		}
+		if err := json.Unmarshal(result, &config); err != nil {
+			t.Fatalf("invalid JSON: %v", err)
+		}
+
+		want := `SELECT * FROM (SELECT count(*) AS c0 FROM "mystream" WHERE str_match(log, 'error') AND kubernetes_labels_openchoreo_dev_environment_uid = 'env-uid' AND kubernetes_labels_openchoreo_dev_component_uid = 'comp-uid') AS q0` +
+			` CROSS JOIN (SELECT count(*) AS c1 FROM "k8s_events" WHERE k8s_event_reason = 'BackOff' AND k8s_object_label_openchoreo_dev_environment_uid = 'env-uid' AND k8s_object_label_openchoreo_dev_component_uid = 'comp-uid') AS q1` +
+			` WHERE q0.c0 > 10 OR q1.c1 >= 3`
+		if config.QueryCondition.SQL != want {
+			t.Errorf("unexpected SQL:\n got %s\nwant %s", config.QueryCondition.SQL, want)
+		}
+		if config.TriggerCondition.Operator != ">=" || config.TriggerCondition.Threshold != 1 {
+			t.Errorf("expected the alert to trigger on one row, got %+v", config.TriggerCondition)
+		}
+
+		detail := &AlertDetail{}
+		if err := parseAlertConditions(config.ContextAttributes["conditions"], detail); err != nil {
+			t.Fatalf("failed to parse stored conditions: %v", err)
+		}
+		if detail.Operator != ">" || detail.Threshold != 10 || detail.ConditionMatch != AlertMatchAny {
+			t.Errorf("unexpected primary condition %+v", detail)
+		}
+		if len(detail.Conditions) != 1 || detail.Conditions[0] != params.Conditions[0] {
+			t.Errorf("unexpected conditions %+v", detail.Conditions)
+		}
+	})
+
+	t.Run("invalid conditions", func(t *testing.T) {
+		for _, tc := range []struct {
+			conditions []AlertCondition
+			match      string
+		}{
+			{[]AlertCondition{{Query: "panic", Operator: "gt"}}, "either"},
+			{[]AlertCondition{{Source: "metrics", Query: "cpu", Operator: "gt"}}, ""},
+			{[]AlertCondition{{Query: "", Operator: "gt"}}, ""},
+			{[]AlertCondition{{Query: "panic", Operator: "above"}}, ""},
+		} {
+			params := LogAlertParams{
+				Name:           &name,
+				Operator:       "gt",
+				Window:         "5m",
+				Interval:       "1m",
+				Enabled:        &enabled,
+				Conditions:     tc.conditions,
+				ConditionMatch: tc.match,
+			}
+			if _, err := generateAlertConfig(params, "mystream", "k8s_events", DefaultFieldMapping, testLogger()); err == nil {
+				t.Errorf("expected error for conditions %+v match %q", tc.conditions, tc.match)
+			}
+		}
 	})
 
 	t.Run("invalid operator", func(t *testing.T) {
@@ -447,7 +616,7 @@ func TestGenerateAlertConfig(t *testing.T) {
 			Interval: "1m",
 			Enabled:  &enabled,
 		}
-		_, err := generateAlertConfig(params, "mystream", testLogger())
+		_, err := generateAlertConfig(params, "mystream", "k8s_events", DefaultFieldMapping, testLogger())
 		if err == nil {
 			t.Fatal("expected error for invalid operator")
 		}
@@ -461,7 +630,7 @@ func TestGenerateAlertConfig(t *testing.T) {
 			Interval: "1m",
 			Enabled:  &enabled,
 		}
-		_, err := generateAlertConfig(params, "mystream", testLogger())
+		_, err := generateAlertConfig(params, "mystream", "k8s_events", DefaultFieldMapping, testLogger())
 		if err == nil {
 			t.Fatal("expected error for invalid window")
 		}
@@ -475,9 +644,212 @@ func TestGenerateAlertConfig(t *testing.T) {
 			Interval: "bad",
 			Enabled:  &enabled,
 		}
-		_, err := generateAlertConfig(params, "mystream", testLogger())
+		_, err := generateAlertConfig(params, "mystream", "k8s_events", DefaultFieldMapping, testLogger())
 		if err == nil {
 			t.Fatal("expected error for invalid interval")
 		}
 	})
 }
+
+func TestLogsSortClause(t *testing.T) {
+	tests := []struct {
+		sortField   string
+		sortOrder   string
+		tiebreakers []string
+		want        string
+	}{
+		{"", "", nil, " ORDER BY _timestamp DESC"},
+		{"", "asc", nil, " ORDER BY _timestamp ASC"},
+		{SortFieldIngestTime, "ASC", nil, " ORDER BY _timestamp ASC"},
+		{SortFieldEventTime, "desc", nil, " ORDER BY date DESC"},
+		{SortFieldEventTime, "asc", nil, " ORDER BY date ASC"},
+		{"", "asc", []string{"kubernetes_pod_name", "seq"}, " ORDER BY _timestamp ASC, kubernetes_pod_name ASC, seq ASC"},
+		{"", "", DefaultSortTiebreakers, " ORDER BY _timestamp DESC, kubernetes_pod_name DESC, kubernetes_container_name DESC, log DESC"},
+	}
+	for _, tt := range tests {
+		if got := logsSortClause(tt.sortField, tt.sortOrder, tt.tiebreakers); got != tt.want {
+			t.Errorf("logsSortClause(%q, %q) = %q, want %q", tt.sortField, tt.sortOrder, got, tt.want)
+		}
+	}
+}
+
+func TestValidateSortTiebreakers(t *testing.T) {
+	if err := ValidateSortTiebreakers([]string{"kubernetes_pod_name", "seq"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	for _, column := range []string{"seq DESC", "log;--", "Seq"} {
+		if err := ValidateSortTiebreakers([]string{column}); err == nil {
+			t.Errorf("expected an error for %q", column)
+		}
+	}
+}
+
+func TestValidateSortField(t *testing.T) {
+	for _, f := range []string{"", SortFieldEventTime, SortFieldIngestTime} {
+		if err := ValidateSortField(f); err != nil {
+			t.Errorf("expected %q to be valid, got %v", f, err)
+		}
+	}
+	if err := ValidateSortField("date"); err == nil {
+		t.Error("expected error for unsupported sort field")
+	}
+}
+
+func TestGenerateWorkflowLogsQuery_EventTimeSort(t *testing.T) {
+	params := WorkflowLogsParams{
+		Namespace:       "ns",
+		WorkflowRunName: "run-1",
+		SortField:       SortFieldEventTime,
+		SortOrder:       "asc",
+	}
+	result, err := generateWorkflowLogsQuery(params, "mystream", nil, testLogger())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(result), "ORDER BY date ASC") {
+		t.Errorf("expected event time ordering in query: %s", result)
+	}
+}
+
+func TestGenerateWorkflowLogsQuery_StepAndPod(t *testing.T) {
+	params := WorkflowLogsParams{
+		Namespace:       "ns",
+		WorkflowRunName: "run-1",
+		StepName:        "build",
+		PodName:         "run-1-build-123",
+	}
+	wantStep := "(kubernetes_annotations_workflows_argoproj_io_node_name LIKE '%.build' OR " +
+		"kubernetes_annotations_workflows_argoproj_io_node_name LIKE '%.build(%')"
+	wantPod := "kubernetes_pod_name = 'run-1-build-123'"
+
+	raw, err := generateWorkflowLogsQuery(params, "mystream", nil, testLogger())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sql, _ := sqlOf(t, raw)
+	raw, err = generateWorkflowLogsCountQuery(params, "mystream", nil, testLogger())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	countSQL, _ := sqlOf(t, raw)
+	for _, got := range []string{sql, countSQL} {
+		if !strings.Contains(got, wantStep) || !strings.Contains(got, wantPod) {
+			t.Errorf("expected step and pod filters in query: %s", got)
+		}
+	}
+}
+
+func TestGenerateWorkflowLogsQuery_LevelFallback(t *testing.T) {
+	params := WorkflowLogsParams{
+		Namespace:       "ns",
+		WorkflowRunName: "run-1",
+		LogLevels:       []string{"ERROR", "WARN", "WARNING"},
+	}
+	want := "((logLevel = 'ERROR' OR logLevel = 'WARN' OR logLevel = 'WARNING') OR " +
+		"((logLevel IS NULL OR logLevel = '') AND " +
+		"((UPPER(log) LIKE '%ERROR%') OR " +
+		"(UPPER(log) NOT LIKE '%ERROR%' AND UPPER(log) NOT LIKE '%FATAL%' AND UPPER(log) NOT LIKE '%SEVERE%' AND UPPER(log) LIKE '%WARN%'))))"
+
+	raw, err := generateWorkflowLogsQuery(params, "mystream", nil, testLogger())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sql, _ := sqlOf(t, raw)
+	raw, err = generateWorkflowLogsCountQuery(params, "mystream", nil, testLogger())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	countSQL, _ := sqlOf(t, raw)
+	for _, got := range []string{sql, countSQL} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected levels matched in the level field or the log text, got: %s", got)
+		}
+	}
+
+	// Lines naming no level at all are INFO, as ExtractLogLevel infers.
+	if got := inferredLogLevelCondition("info"); !strings.HasSuffix(got, "(UPPER(log) LIKE '%INFO%' OR (UPPER(log) NOT LIKE '%DEBUG%')))") {
+		t.Errorf("unexpected INFO condition: %s", got)
+	}
+	// Levels that are never inferred only match the level field.
+	params.LogLevels = []string{"TRACE"}
+	raw, _ = generateWorkflowLogsQuery(params, "mystream", nil, testLogger())
+	if sql, _ := sqlOf(t, raw); !strings.Contains(sql, "(logLevel = 'TRACE')") || strings.Contains(sql, "UPPER(log)") {
+		t.Errorf("expected no text fallback for TRACE, got: %s", sql)
+	}
+}
+
+func TestGenerateLogSourcesQuery(t *testing.T) {
+	t.Run("requires namespace", func(t *testing.T) {
+		if _, err := generateLogSourcesQuery(LogSourcesParams{}, "mystream", DefaultFieldMapping, testLogger()); err == nil {
+			t.Error("expected error for missing namespace")
+		}
+	})
+
+	t.Run("groups by component and environment", func(t *testing.T) {
+		params := LogSourcesParams{
+			Namespace:     "ns",
+			EnvironmentID: "env-1",
+			StartTime:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			EndTime:       time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+		}
+		result, err := generateLogSourcesQuery(params, "mystream", DefaultFieldMapping, testLogger())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var q map[string]map[string]interface{}
+		if err := json.Unmarshal(result, &q); err != nil {
+			t.Fatalf("failed to unmarshal query: %v", err)
+		}
+		sql := q["query"]["sql"].(string)
+		for _, want := range []string{
+			`FROM "mystream"`,
+			"kubernetes_labels_openchoreo_dev_namespace = 'ns'",
+			"kubernetes_labels_openchoreo_dev_environment_uid = 'env-1'",
+			"max(_timestamp) AS last_seen",
+			"count(*) AS log_count",
+			"GROUP BY project_uid, project_name, component_uid, component_name, environment_uid, environment_name",
+		} {
+			if !strings.Contains(sql, want) {
+				t.Errorf("expected %q in SQL: %s", want, sql)
+			}
+		}
+		if strings.Contains(sql, "project_uid = ") {
+			t.Errorf("unexpected project filter in SQL: %s", sql)
+		}
+		if q["query"]["start_time"].(float64) != float64(params.StartTime.UnixMicro()) {
+			t.Errorf("unexpected start_time: %v", q["query"]["start_time"])
+		}
+	})
+}
+
+func TestGenerateComponentLevelHistogramQuery(t *testing.T) {
+	params := LevelHistogramParams{
+		Namespace:    "ns",
+		ComponentUID: "comp-'1",
+		Interval:     5 * time.Minute,
+	}
+	result, err := generateComponentLevelHistogramQuery(params, "mystream", DefaultFieldMapping, testLogger())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{
+		"histogram(_timestamp, '300 seconds') AS bucket",
+		"kubernetes_labels_openchoreo_dev_component_uid = 'comp-''1'",
+		"GROUP BY bucket, level ORDER BY bucket ASC",
+	} {
+		if !strings.Contains(string(result), want) {
+			t.Errorf("expected %q in query: %s", want, result)
+		}
+	}
+
+	for _, invalid := range []LevelHistogramParams{
+		{ComponentUID: "c", Interval: time.Minute},
+		{Namespace: "ns", Interval: time.Minute},
+		{Namespace: "ns", ComponentUID: "c"},
+	} {
+		if _, err := generateComponentLevelHistogramQuery(invalid, "mystream", DefaultFieldMapping, testLogger()); err == nil {
+			t.Errorf("expected error for %+v", invalid)
+		}
+	}
+}
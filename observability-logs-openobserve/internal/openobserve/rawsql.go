@@ -0,0 +1,332 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxRawQueryRows bounds the rows a raw SQL query returns.
+	MaxRawQueryRows = 1000
+	// MaxRawQueryLength bounds the length of a raw SQL query.
+	MaxRawQueryLength = 4096
+)
+
+// rawQueryForbidden are the keywords raw SQL queries must not use: they
+// combine several queries, read other streams or write results.
+var rawQueryForbidden = map[string]bool{
+	"UNION":     true,
+	"INTERSECT": true,
+	"EXCEPT":    true,
+	"JOIN":      true,
+	"WITH":      true,
+	"INTO":      true,
+}
+
+// rawQueryClauses are the clauses that may follow the FROM and WHERE
+// clauses of a raw SQL query.
+var rawQueryClauses = map[string]bool{
+	"GROUP":  true,
+	"HAVING": true,
+	"ORDER":  true,
+	"LIMIT":  true,
+	"OFFSET": true,
+}
+
+// RawQueryParams holds parameters for raw SQL queries over component logs.
+type RawQueryParams struct {
+	Namespace     string
+	ProjectID     string
+	EnvironmentID string
+	ComponentID   string
+	StartTime     time.Time
+	EndTime       time.Time
+	// SQL is a single SELECT statement over one table. The table is always
+	// replaced with the logs stream and the query restricted to the scope.
+	SQL string
+}
+
+// RawQueryResult holds the rows returned by a raw SQL query.
+type RawQueryResult struct {
+	Hits []map[string]interface{} `json:"hits"`
+	Took int                      `json:"took"`
+}
+
+// sqlToken is a token of a raw SQL query. Keywords and identifiers are
+// words; quoted identifiers, string literals, numbers and symbols keep their
+// text. end is the offset following the token in the query.
+type sqlToken struct {
+	kind  byte // 'w'ord, 'q'uoted identifier, 's'tring, 'n'umber or 'p'unctuation
+	text  string
+	start int
+	end   int
+	depth int
+}
+
+// keyword reports whether t is the keyword kw, case-insensitively.
+func (t sqlToken) keyword(kw string) bool {
+	return t.kind == 'w' && strings.EqualFold(t.text, kw)
+}
+
+// tokenizeSQL splits a raw SQL query into tokens, recording the parenthesis
+// depth of each. Comments, statement separators and backslashes in string
+// literals are rejected, as is anything else the rewriting does not account for.
+func tokenizeSQL(sql string) ([]sqlToken, error) {
+	var tokens []sqlToken
+	depth := 0
+	for i := 0; i < len(sql); {
+		c := sql[i]
+		start := i
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+			continue
+		case c == '-' && strings.HasPrefix(sql[i:], "--"), c == '/' && strings.HasPrefix(sql[i:], "/*"):
+			return nil, fmt.Errorf("comments are not supported")
+		case c == ';':
+			return nil, fmt.Errorf("only a single statement is supported")
+		case c == '\'' || c == '"':
+			i++
+			for {
+				end := strings.IndexByte(sql[i:], c)
+				if end < 0 {
+					return nil, fmt.Errorf("unterminated quoted text at offset %d", start)
+				}
+				i += end + 1
+				if i < len(sql) && sql[i] == c {
+					i++
+					continue
+				}
+				break
+			}
+			if c == '\'' && strings.ContainsRune(sql[start:i], '\\') {
+				return nil, fmt.Errorf("backslashes are not supported in string literals")
+			}
+			kind := byte('s')
+			if c == '"' {
+				kind = 'q'
+			}
+			tokens = append(tokens, sqlToken{kind: kind, text: sql[start:i], start: start, end: i, depth: depth})
+			continue
+		case isWordByte(c) && !isDigit(c):
+			for i < len(sql) && isWordByte(sql[i]) {
+				i++
+			}
+			tokens = append(tokens, sqlToken{kind: 'w', text: sql[start:i], start: start, end: i, depth: depth})
+			continue
+		case isDigit(c) || c == '.' && i+1 < len(sql) && isDigit(sql[i+1]):
+			for i < len(sql) && (isWordByte(sql[i]) || sql[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, sqlToken{kind: 'n', text: sql[start:i], start: start, end: i, depth: depth})
+			continue
+		case strings.IndexByte("(),.*+-/%=<>!|:[]", c) >= 0:
+			i++
+			if c == '(' {
+				depth++
+			}
+			tokenDepth := depth
+			if c == ')' {
+				if depth == 0 {
+					return nil, fmt.Errorf("unbalanced parentheses")
+				}
+				depth--
+			}
+			tokens = append(tokens, sqlToken{kind: 'p', text: sql[start:i], start: start, end: i, depth: tokenDepth})
+			continue
+		default:
+			return nil, fmt.Errorf("unsupported character %q at offset %d", c, start)
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced parentheses")
+	}
+	return tokens, nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || isDigit(c) || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// ValidateRawQuery checks that sql is a query raw SQL queries accept.
+func ValidateRawQuery(sql string) error {
+	_, _, err := rewriteRawQuery(sql, "default", "true")
+	return err
+}
+
+// rewriteRawQuery validates sql, reads its single table from stream and
+// restricts it with the scope condition. It returns the rewritten query and
+// the number of rows to return: the LIMIT of the query, which must not
+// exceed MaxRawQueryRows, or MaxRawQueryRows.
+func rewriteRawQuery(sql, stream, scope string) (string, int, error) {
+	if strings.TrimSpace(sql) == "" || len(sql) > MaxRawQueryLength {
+		return "", 0, fmt.Errorf("sql must have 1 to %d characters", MaxRawQueryLength)
+	}
+	tokens, err := tokenizeSQL(sql)
+	if err != nil {
+		return "", 0, err
+	}
+	if len(tokens) == 0 || !tokens[0].keyword("SELECT") {
+		return "", 0, fmt.Errorf("sql must be a SELECT statement")
+	}
+
+	from, where, clause := -1, -1, -1
+	limit := MaxRawQueryRows
+	for i, t := range tokens {
+		if t.kind != 'w' {
+			continue
+		}
+		kw := strings.ToUpper(t.text)
+		switch {
+		case kw == "SELECT" && i > 0:
+			return "", 0, fmt.Errorf("subqueries are not supported")
+		case rawQueryForbidden[kw]:
+			return "", 0, fmt.Errorf("%s is not supported", kw)
+		case t.depth > 0:
+		case kw == "FROM":
+			if from >= 0 {
+				return "", 0, fmt.Errorf("sql must have a single FROM clause")
+			}
+			from = i
+		case kw == "WHERE":
+			if from < 0 || where >= 0 || clause >= 0 {
+				return "", 0, fmt.Errorf("misplaced WHERE clause")
+			}
+			where = i
+		case rawQueryClauses[kw]:
+			if from < 0 {
+				return "", 0, fmt.Errorf("misplaced %s clause", kw)
+			}
+			if clause < 0 {
+				clause = i
+			}
+			if kw == "LIMIT" {
+				if i+1 >= len(tokens) || tokens[i+1].kind != 'n' {
+					return "", 0, fmt.Errorf("LIMIT must be a number")
+				}
+				n, err := strconv.Atoi(tokens[i+1].text)
+				if err != nil || n < 1 || n > MaxRawQueryRows {
+					return "", 0, fmt.Errorf("LIMIT must be between 1 and %d", MaxRawQueryRows)
+				}
+				limit = n
+			}
+		}
+	}
+	if from < 0 || from+1 >= len(tokens) {
+		return "", 0, fmt.Errorf("sql must read from a table")
+	}
+	table := tokens[from+1]
+	if table.kind != 'w' && table.kind != 'q' || from+1 == where || from+1 == clause {
+		return "", 0, fmt.Errorf("sql must read from a single table")
+	}
+	// The table may only be followed by an alias, with or without AS,
+	// before the next clause: anything else, such as a comma, may read
+	// another table.
+	fromEnd := len(tokens)
+	if where >= 0 {
+		fromEnd = where
+	} else if clause >= 0 {
+		fromEnd = clause
+	}
+	alias := tokens[from+2 : fromEnd]
+	if len(alias) > 0 && alias[0].keyword("AS") {
+		alias = alias[1:]
+		if len(alias) == 0 {
+			return "", 0, fmt.Errorf("sql must read from a single table")
+		}
+	}
+	if len(alias) > 1 || len(alias) == 1 && alias[0].kind != 'w' && alias[0].kind != 'q' {
+		return "", 0, fmt.Errorf("sql must read from a single table")
+	}
+
+	if where >= 0 && (where+1 == len(tokens) || where+1 == clause) {
+		return "", 0, fmt.Errorf("WHERE must have a condition")
+	}
+
+	// The scope is ANDed with the original condition, parenthesized so
+	// that its ORs cannot escape it.
+	end := len(sql)
+	if clause >= 0 {
+		end = tokens[clause].start
+	}
+	var b strings.Builder
+	b.WriteString(sql[:table.start])
+	b.WriteString(quoteIdentifier(stream))
+	if where >= 0 {
+		b.WriteString(sql[table.end:tokens[where].end])
+		b.WriteString(" (" + scope + ") AND (")
+		b.WriteString(strings.TrimSpace(sql[tokens[where].end:end]))
+		b.WriteString(")")
+	} else {
+		b.WriteString(strings.TrimRight(sql[table.end:end], " \t\r\n"))
+		b.WriteString(" WHERE " + scope)
+	}
+	if clause >= 0 {
+		b.WriteString(" ")
+		b.WriteString(sql[end:])
+	}
+	return b.String(), limit, nil
+}
+
+// rawQueryScope returns the condition restricting a raw SQL query to the
+// scope of params.
+func rawQueryScope(params RawQueryParams, fields FieldMapping) string {
+	return strings.Join(fields.scopeConditions(params.Namespace, params.ProjectID, params.EnvironmentID, params.ComponentID), " AND ")
+}
+
+// generateRawQuery generates the search query of a raw SQL query.
+func generateRawQuery(params RawQueryParams, stream string, fields FieldMapping, logger *slog.Logger) ([]byte, error) {
+	if params.Namespace == "" {
+		return nil, fmt.Errorf("namespace is required for raw SQL queries")
+	}
+	sql, limit, err := rewriteRawQuery(params.SQL, stream, rawQueryScope(params, fields))
+	if err != nil {
+		return nil, err
+	}
+
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"sql":        sql,
+			"start_time": params.StartTime.UnixMicro(),
+			"end_time":   params.EndTime.UnixMicro(),
+			"from":       0,
+			"size":       limit,
+		},
+	}
+
+	logGenerated(logger, query, "raw query")
+
+	return json.Marshal(query)
+}
+
+// RawQuery runs a raw SQL query over the component logs of a scope and
+// returns the rows as OpenObserve returns them.
+func (c *Client) RawQuery(ctx context.Context, params RawQueryParams) (*RawQueryResult, error) {
+	queryJSON, err := generateRawQuery(params, c.stream, c.fields, c.logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate raw query: %w", err)
+	}
+
+	openObserveResp, err := c.executeSearchQuery(ctx, queryJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	hits := openObserveResp.Hits
+	if hits == nil {
+		hits = []map[string]interface{}{}
+	}
+	return &RawQueryResult{Hits: hits, Took: openObserveResp.Took}, nil
+}
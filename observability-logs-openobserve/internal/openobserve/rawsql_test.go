@@ -0,0 +1,143 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRewriteRawQuery(t *testing.T) {
+	const scope = "ns = 'a'"
+	tests := []struct {
+		sql       string
+		want      string
+		wantLimit int
+	}{
+		{
+			sql:       "SELECT * FROM logs",
+			want:      `SELECT * FROM "default" WHERE ns = 'a'`,
+			wantLimit: MaxRawQueryRows,
+		},
+		{
+			sql:       "select code, count(*) AS n from logs where code > 499 or path = 'a;b' group by code order by n desc limit 20",
+			want:      `select code, count(*) AS n from "default" where (ns = 'a') AND (code > 499 or path = 'a;b') group by code order by n desc limit 20`,
+			wantLimit: 20,
+		},
+		{
+			sql:       `SELECT date_trunc('hour', _timestamp) AS h, count(*) FROM "my stream" l GROUP BY h LIMIT 5 OFFSET 5`,
+			want:      `SELECT date_trunc('hour', _timestamp) AS h, count(*) FROM "default" l WHERE ns = 'a' GROUP BY h LIMIT 5 OFFSET 5`,
+			wantLimit: 5,
+		},
+		{
+			sql:       `SELECT l.code FROM logs AS "l" WHERE l.code = 500`,
+			want:      `SELECT l.code FROM "default" AS "l" WHERE (ns = 'a') AND (l.code = 500)`,
+			wantLimit: MaxRawQueryRows,
+		},
+		{
+			sql:       "SELECT extract(hour FROM _timestamp) FROM logs WHERE log LIKE '%it''s%'",
+			want:      `SELECT extract(hour FROM _timestamp) FROM "default" WHERE (ns = 'a') AND (log LIKE '%it''s%')`,
+			wantLimit: MaxRawQueryRows,
+		},
+	}
+	for _, tt := range tests {
+		got, limit, err := rewriteRawQuery(tt.sql, "default", scope)
+		if err != nil {
+			t.Errorf("rewriteRawQuery(%q) error = %v", tt.sql, err)
+			continue
+		}
+		if got != tt.want || limit != tt.wantLimit {
+			t.Errorf("rewriteRawQuery(%q) = %q, %d; want %q, %d", tt.sql, got, limit, tt.want, tt.wantLimit)
+		}
+	}
+}
+
+func TestValidateRawQuery(t *testing.T) {
+	for name, sql := range map[string]string{
+		"empty":               " ",
+		"too long":            "SELECT * FROM logs WHERE log = '" + strings.Repeat("a", MaxRawQueryLength) + "'",
+		"not a select":        "DELETE FROM logs",
+		"two statements":      "SELECT * FROM logs; DROP TABLE logs",
+		"line comment":        "SELECT * FROM logs -- WHERE x",
+		"block comment":       "SELECT * FROM logs /* x */",
+		"subquery":            "SELECT * FROM logs WHERE a IN (SELECT a FROM other)",
+		"union":               "SELECT a FROM logs UNION ALL SELECT a FROM other",
+		"join":                "SELECT * FROM logs JOIN other ON logs.a = other.a",
+		"cte":                 "SELECT * FROM logs WITH x",
+		"two tables":          "SELECT * FROM logs, other",
+		"aliased two tables":  `SELECT b.* FROM x a, "audit" b`,
+		"as alias two tables": "SELECT * FROM x AS a, other",
+		"alias then table":    "SELECT * FROM x a other WHERE a = 1",
+		"missing alias":       "SELECT * FROM logs AS LIMIT 1",
+		"comma after alias":   "SELECT * FROM logs l , other GROUP BY a",
+		"qualified table":     "SELECT * FROM org.logs",
+		"table function":      "SELECT * FROM read_parquet('x')",
+		"no table":            "SELECT 1",
+		"keyword table":       "SELECT * FROM WHERE a = 1",
+		"empty where":         "SELECT * FROM logs WHERE LIMIT 1",
+		"limit too high":      "SELECT * FROM logs LIMIT 1001",
+		"limit not a number":  "SELECT * FROM logs LIMIT a",
+		"backslash escape":    `SELECT * FROM logs WHERE log = 'a\' OR 1=1 OR ''='`,
+		"unterminated string": "SELECT * FROM logs WHERE log = 'a",
+		"unbalanced":          "SELECT count(* FROM logs",
+		"placeholder":         "SELECT * FROM logs WHERE a = ?",
+	} {
+		if err := ValidateRawQuery(sql); err == nil {
+			t.Errorf("%s: expected an error for %q", name, sql)
+		}
+	}
+}
+
+func TestGenerateRawQuery(t *testing.T) {
+	params := RawQueryParams{
+		Namespace:   "ns'x",
+		ComponentID: "comp-1",
+		StartTime:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndTime:     time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+		SQL:         "SELECT * FROM logs WHERE a = 1 OR b = 2 LIMIT 10",
+	}
+	raw, err := generateRawQuery(params, "default", DefaultFieldMapping, testLogger())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sql, q := sqlOf(t, raw)
+	want := `SELECT * FROM "default" WHERE (kubernetes_labels_openchoreo_dev_namespace = 'ns''x' AND ` +
+		`kubernetes_labels_openchoreo_dev_component_uid = 'comp-1') AND (a = 1 OR b = 2) LIMIT 10`
+	if sql != want {
+		t.Errorf("unexpected sql:\n got %s\nwant %s", sql, want)
+	}
+	if q["size"] != float64(10) || q["start_time"] != float64(params.StartTime.UnixMicro()) {
+		t.Errorf("unexpected query: %v", q)
+	}
+
+	if _, err := generateRawQuery(RawQueryParams{SQL: "SELECT * FROM logs"}, "default", DefaultFieldMapping, testLogger()); err == nil {
+		t.Error("expected an error without a namespace")
+	}
+}
+
+func TestRawQuery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(OpenObserveResponse{
+			Took: 7,
+			Hits: []map[string]interface{}{{"code": float64(503), "n": float64(12)}},
+		})
+	}))
+	defer server.Close()
+
+	result, err := newTestClient(server.URL).RawQuery(context.Background(), RawQueryParams{
+		Namespace: "ns",
+		SQL:       "SELECT code, count(*) AS n FROM logs GROUP BY code",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Hits) != 1 || result.Hits[0]["n"] != float64(12) || result.Took != 7 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
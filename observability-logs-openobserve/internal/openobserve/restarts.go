@@ -0,0 +1,364 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Kinds of restart signals.
+const (
+	// RestartKindOOMKilled is a container killed for exceeding its memory
+	// limit, or a runtime reporting that it ran out of memory.
+	RestartKindOOMKilled = "oomKilled"
+	// RestartKindCrashLoop is a kubelet back-off restarting a failed container.
+	RestartKindCrashLoop = "crashLoopBackOff"
+	// RestartKindLivenessProbe is a container killed after failing its liveness probe.
+	RestartKindLivenessProbe = "livenessProbe"
+	// RestartKindEvicted is a pod evicted from its node.
+	RestartKindEvicted = "evicted"
+	// RestartKindExited is a log line reporting that a process exited.
+	RestartKindExited = "exited"
+)
+
+// Sources of restart signals.
+const (
+	RestartSourceEvents = "events"
+	RestartSourceLogs   = "logs"
+)
+
+// maxRestartSignals bounds the events and the log lines scanned per source.
+const maxRestartSignals = 1000
+
+// oomExitCode is the exit code of a container killed by the OOM killer (128 + SIGKILL).
+const oomExitCode = 137
+
+// restartEventReasons are the Kubernetes event reasons that may denote a restart.
+var restartEventReasons = []string{"BackOff", "Killing", "OOMKilling", "Evicted"}
+
+// Log line fragments denoting that a runtime ran out of memory or a process exited.
+var (
+	oomLogPatterns = []string{
+		"OutOfMemoryError",
+		"JavaScript heap out of memory",
+		"fatal error: runtime: out of memory",
+		"MemoryError",
+		"OOMKilled",
+	}
+	exitLogPatterns = []string{"exited with code", "Exited with code", "exit code", "Exit code", "exit status"}
+)
+
+var (
+	exitCodePattern        = regexp.MustCompile(`(?i)exit(?:ed with)? (?:code|status)[\s:=]*(-?\d+)`)
+	failedContainerPattern = regexp.MustCompile(`failed container "?([A-Za-z0-9._-]+)"?`)
+	eventContainerPattern  = regexp.MustCompile(`^Container "?([A-Za-z0-9._-]+)"? `)
+)
+
+// RestartSummaryParams holds parameters for a restart and OOM summary.
+type RestartSummaryParams struct {
+	Namespace     string    `json:"namespace"`
+	ProjectID     string    `json:"projectId,omitempty"`
+	EnvironmentID string    `json:"environmentId,omitempty"`
+	ComponentID   string    `json:"componentId,omitempty"`
+	StartTime     time.Time `json:"startTime"`
+	EndTime       time.Time `json:"endTime"`
+	// Sources lists the signal sources to scan, RestartSourceEvents and/or
+	// RestartSourceLogs. Both are scanned when empty.
+	Sources []string `json:"sources,omitempty"`
+}
+
+// RestartSignal is a Kubernetes event or log line denoting a restart.
+type RestartSignal struct {
+	Timestamp     time.Time `json:"timestamp"`
+	Kind          string    `json:"kind"`
+	Source        string    `json:"source"`
+	PodName       string    `json:"podName,omitempty"`
+	ContainerName string    `json:"containerName,omitempty"`
+	ExitCode      *int      `json:"exitCode,omitempty"`
+	Message       string    `json:"message"`
+}
+
+// ComponentRestartSummary summarizes the restart signals of a component in one environment.
+type ComponentRestartSummary struct {
+	ComponentUID    string         `json:"componentUid"`
+	ComponentName   string         `json:"componentName,omitempty"`
+	EnvironmentUID  string         `json:"environmentUid"`
+	EnvironmentName string         `json:"environmentName,omitempty"`
+	ProjectUID      string         `json:"projectUid,omitempty"`
+	FirstSeen       time.Time      `json:"firstSeen"`
+	LastSeen        time.Time      `json:"lastSeen"`
+	Counts          map[string]int `json:"counts"`
+	ExitCodes       []int          `json:"exitCodes"`
+	Pods            []string       `json:"pods"`
+	// Signals are the restart signals, latest first.
+	Signals []RestartSignal `json:"signals"`
+}
+
+// RestartSummaryResult is the restart and OOM summary of a scope, with the
+// components with the latest signals first.
+type RestartSummaryResult struct {
+	Components []ComponentRestartSummary `json:"components"`
+	// Truncated is set when a source had more signals than were scanned.
+	Truncated bool `json:"truncated"`
+	Took      int  `json:"tookMs"`
+}
+
+// ValidateRestartSources checks that sources lists known signal sources.
+func ValidateRestartSources(sources []string) error {
+	for _, s := range sources {
+		if s != RestartSourceEvents && s != RestartSourceLogs {
+			return fmt.Errorf("sources must contain only %q or %q", RestartSourceEvents, RestartSourceLogs)
+		}
+	}
+	return nil
+}
+
+// scansSource reports whether params selects source.
+func (params RestartSummaryParams) scansSource(source string) bool {
+	return len(params.Sources) == 0 || slices.Contains(params.Sources, source)
+}
+
+// generateRestartEventsQuery generates the query for the Kubernetes events of
+// a scope whose reason may denote a restart.
+func generateRestartEventsQuery(params RestartSummaryParams, stream string, logger *slog.Logger) ([]byte, error) {
+	if params.Namespace == "" {
+		return nil, fmt.Errorf("namespace is required for restart summaries")
+	}
+	reasons := make([]string, len(restartEventReasons))
+	for i, r := range restartEventReasons {
+		reasons[i] = "'" + r + "'"
+	}
+	conditions := componentEventsConditions(EventsQueryParams{
+		Namespace:     params.Namespace,
+		ProjectID:     params.ProjectID,
+		EnvironmentID: params.EnvironmentID,
+		ComponentID:   params.ComponentID,
+	})
+	conditions = append(conditions, "("+evReason+" IN ("+strings.Join(reasons, ", ")+") OR "+evMessage+" LIKE '%OOMKilled%')")
+	return buildRestartQuery(conditions, stream, params, logger, "restart events")
+}
+
+// generateRestartLogsQuery generates the query for the application log lines
+// of a scope reporting that a runtime ran out of memory or a process exited.
+func generateRestartLogsQuery(params RestartSummaryParams, stream string, fields FieldMapping, logger *slog.Logger) ([]byte, error) {
+	if params.Namespace == "" {
+		return nil, fmt.Errorf("namespace is required for restart summaries")
+	}
+	conditions := fields.scopeConditions(params.Namespace, params.ProjectID, params.EnvironmentID, params.ComponentID)
+	patterns := make([]string, 0, len(oomLogPatterns)+len(exitLogPatterns))
+	for _, p := range slices.Concat(oomLogPatterns, exitLogPatterns) {
+		patterns = append(patterns, "log LIKE '%"+escapeSQLString(p)+"%'")
+	}
+	conditions = append(conditions, "("+strings.Join(patterns, " OR ")+")")
+	return buildRestartQuery(conditions, stream, params, logger, "restart log lines")
+}
+
+func buildRestartQuery(conditions []string, stream string, params RestartSummaryParams, logger *slog.Logger, label string) ([]byte, error) {
+	sql := "SELECT * FROM " + quoteIdentifier(stream) +
+		" WHERE " + strings.Join(conditions, " AND ") +
+		" ORDER BY _timestamp DESC"
+
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"sql":        sql,
+			"start_time": params.StartTime.UnixMicro(),
+			"end_time":   params.EndTime.UnixMicro(),
+			"from":       0,
+			"size":       maxRestartSignals,
+		},
+		"timeout": 0,
+	}
+
+	logGenerated(logger, query, "query to fetch %s %s", stream, label)
+
+	return json.Marshal(query)
+}
+
+// classifyRestartEvent returns the restart signal an event denotes, if any.
+func classifyRestartEvent(event EventEntry) (RestartSignal, bool) {
+	signal := RestartSignal{
+		Timestamp: event.Timestamp,
+		Source:    RestartSourceEvents,
+		Message:   event.Message,
+		ExitCode:  parseExitCode(event.Message),
+	}
+	if event.ObjectKind == "" || event.ObjectKind == "Pod" {
+		signal.PodName = event.ObjectName
+	}
+
+	switch {
+	case event.Reason == "OOMKilling" || strings.Contains(event.Message, "OOMKilled"):
+		signal.Kind = RestartKindOOMKilled
+		if signal.ExitCode == nil {
+			code := oomExitCode
+			signal.ExitCode = &code
+		}
+	case event.Reason == "BackOff" && strings.Contains(event.Message, "restarting failed container"):
+		signal.Kind = RestartKindCrashLoop
+		if m := failedContainerPattern.FindStringSubmatch(event.Message); m != nil {
+			signal.ContainerName = m[1]
+		}
+	case event.Reason == "Killing" && strings.Contains(event.Message, "failed liveness probe"):
+		signal.Kind = RestartKindLivenessProbe
+		if m := eventContainerPattern.FindStringSubmatch(event.Message); m != nil {
+			signal.ContainerName = m[1]
+		}
+	case event.Reason == "Evicted":
+		signal.Kind = RestartKindEvicted
+	default:
+		return RestartSignal{}, false
+	}
+	return signal, true
+}
+
+// classifyRestartLog returns the restart signal a log line denotes, if any.
+func classifyRestartLog(entry ComponentLogsEntry) (RestartSignal, bool) {
+	signal := RestartSignal{
+		Timestamp:     entry.Timestamp,
+		Source:        RestartSourceLogs,
+		PodName:       entry.PodName,
+		ContainerName: entry.ContainerName,
+		Message:       entry.Log,
+		ExitCode:      parseExitCode(entry.Log),
+	}
+	for _, p := range oomLogPatterns {
+		if strings.Contains(entry.Log, p) {
+			signal.Kind = RestartKindOOMKilled
+			return signal, true
+		}
+	}
+	if signal.ExitCode != nil {
+		signal.Kind = RestartKindExited
+		return signal, true
+	}
+	return RestartSignal{}, false
+}
+
+// parseExitCode returns the exit code mentioned in message, if any.
+func parseExitCode(message string) *int {
+	m := exitCodePattern.FindStringSubmatch(message)
+	if m == nil {
+		return nil
+	}
+	code, err := strconv.Atoi(m[1])
+	if err != nil {
+		return nil
+	}
+	return &code
+}
+
+// GetRestartSummary scans the Kubernetes events and application logs of a
+// scope for container restarts, OOM kills, evictions and process exits, and
+// summarizes them per component and environment.
+func (c *Client) GetRestartSummary(ctx context.Context, params RestartSummaryParams) (*RestartSummaryResult, error) {
+	result := &RestartSummaryResult{Components: []ComponentRestartSummary{}}
+	summaries := map[string]*ComponentRestartSummary{}
+	add := func(key ComponentRestartSummary, signal RestartSignal) {
+		id := key.ComponentUID + "/" + key.EnvironmentUID
+		s, ok := summaries[id]
+		if !ok {
+			key.Counts = map[string]int{}
+			key.ExitCodes = []int{}
+			key.Pods = []string{}
+			key.Signals = []RestartSignal{}
+			s = &key
+			summaries[id] = s
+		}
+		s.Counts[signal.Kind]++
+		if signal.ExitCode != nil && !slices.Contains(s.ExitCodes, *signal.ExitCode) {
+			s.ExitCodes = append(s.ExitCodes, *signal.ExitCode)
+		}
+		if signal.PodName != "" && !slices.Contains(s.Pods, signal.PodName) {
+			s.Pods = append(s.Pods, signal.PodName)
+		}
+		if s.FirstSeen.IsZero() || signal.Timestamp.Before(s.FirstSeen) {
+			s.FirstSeen = signal.Timestamp
+		}
+		if signal.Timestamp.After(s.LastSeen) {
+			s.LastSeen = signal.Timestamp
+		}
+		s.Signals = append(s.Signals, signal)
+	}
+
+	if params.scansSource(RestartSourceEvents) {
+		queryJSON, err := generateRestartEventsQuery(params, c.eventsStream, c.logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate restart events query: %w", err)
+		}
+		resp, err := c.executeSearchQuery(ctx, queryJSON)
+		if err != nil {
+			return nil, err
+		}
+		result.Took += resp.Took
+		result.Truncated = result.Truncated || len(resp.Hits) >= maxRestartSignals
+		for _, hit := range resp.Hits {
+			timestamp := int64(0)
+			if ts, ok := hit[evTimestamp].(float64); ok {
+				timestamp = int64(ts)
+			}
+			event := parseEventEntry(timestamp, hit)
+			if signal, ok := classifyRestartEvent(event); ok {
+				add(ComponentRestartSummary{
+					ComponentUID:    event.ComponentID,
+					ComponentName:   event.ComponentName,
+					EnvironmentUID:  event.EnvironmentID,
+					EnvironmentName: event.EnvironmentName,
+					ProjectUID:      event.ProjectID,
+				}, signal)
+			}
+		}
+	}
+
+	if params.scansSource(RestartSourceLogs) {
+		queryJSON, err := generateRestartLogsQuery(params, c.stream, c.fields, c.logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate restart logs query: %w", err)
+		}
+		resp, err := c.executeSearchQuery(ctx, queryJSON)
+		if err != nil {
+			return nil, err
+		}
+		result.Took += resp.Took
+		result.Truncated = result.Truncated || len(resp.Hits) >= maxRestartSignals
+		for _, hit := range resp.Hits {
+			timestamp := int64(0)
+			if ts, ok := hit["_timestamp"].(float64); ok {
+				timestamp = int64(ts)
+			}
+			entry := c.parseApplicationLogEntry(timestamp, hit)
+			if signal, ok := classifyRestartLog(entry); ok {
+				add(ComponentRestartSummary{
+					ComponentUID:    entry.ComponentUID,
+					ComponentName:   entry.ComponentName,
+					EnvironmentUID:  entry.EnvironmentUID,
+					EnvironmentName: entry.EnvironmentName,
+					ProjectUID:      entry.ProjectUID,
+				}, signal)
+			}
+		}
+	}
+
+	for _, s := range summaries {
+		slices.SortFunc(s.Signals, func(a, b RestartSignal) int {
+			return b.Timestamp.Compare(a.Timestamp)
+		})
+		slices.Sort(s.ExitCodes)
+		result.Components = append(result.Components, *s)
+	}
+	slices.SortFunc(result.Components, func(a, b ComponentRestartSummary) int {
+		if c := b.LastSeen.Compare(a.LastSeen); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ComponentUID+a.EnvironmentUID, b.ComponentUID+b.EnvironmentUID)
+	})
+	return result, nil
+}
@@ -0,0 +1,242 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClassifyRestartEvent(t *testing.T) {
+	ts := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		event     EventEntry
+		kind      string
+		container string
+		exitCode  int
+	}{
+		{
+			name:      "crash loop back-off",
+			event:     EventEntry{Reason: "BackOff", ObjectKind: "Pod", ObjectName: "api-7d9f", Message: "Back-off restarting failed container api in pod api-7d9f_dp(123)"},
+			kind:      RestartKindCrashLoop,
+			container: "api",
+		},
+		{
+			name:     "OOM kill",
+			event:    EventEntry{Reason: "OOMKilling", ObjectName: "api-7d9f", Message: "Memory cgroup out of memory: Killed process 42 (java)"},
+			kind:     RestartKindOOMKilled,
+			exitCode: 137,
+		},
+		{
+			name:      "liveness probe",
+			event:     EventEntry{Reason: "Killing", ObjectKind: "Pod", ObjectName: "api-7d9f", Message: "Container api failed liveness probe, will be restarted"},
+			kind:      RestartKindLivenessProbe,
+			container: "api",
+		},
+		{
+			name:      "terminated with exit code",
+			event:     EventEntry{Reason: "BackOff", ObjectName: "api-7d9f", Message: `Back-off restarting failed container "api" in pod "api-7d9f": terminated with exit code 1`},
+			kind:      RestartKindCrashLoop,
+			container: "api",
+			exitCode:  1,
+		},
+		{
+			name:  "evicted",
+			event: EventEntry{Reason: "Evicted", ObjectName: "api-7d9f", Message: "The node was low on resource: memory."},
+			kind:  RestartKindEvicted,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.event.Timestamp = ts
+			signal, ok := classifyRestartEvent(tt.event)
+			if !ok {
+				t.Fatal("expected the event to be classified")
+			}
+			if signal.Kind != tt.kind || signal.ContainerName != tt.container || signal.PodName != "api-7d9f" || !signal.Timestamp.Equal(ts) {
+				t.Errorf("unexpected signal: %+v", signal)
+			}
+			if tt.exitCode == 0 && signal.ExitCode != nil {
+				t.Errorf("expected no exit code, got %d", *signal.ExitCode)
+			}
+			if tt.exitCode != 0 && (signal.ExitCode == nil || *signal.ExitCode != tt.exitCode) {
+				t.Errorf("expected exit code %d, got %v", tt.exitCode, signal.ExitCode)
+			}
+		})
+	}
+
+	for _, event := range []EventEntry{
+		{Reason: "Killing", Message: "Stopping container api"},
+		{Reason: "BackOff", Message: "Back-off pulling image \"api:latest\""},
+		{Reason: "Scheduled", Message: "Successfully assigned dp/api-7d9f to node-1"},
+	} {
+		if signal, ok := classifyRestartEvent(event); ok {
+			t.Errorf("expected %q to be ignored, got %+v", event.Message, signal)
+		}
+	}
+}
+
+func TestClassifyRestartLog(t *testing.T) {
+	signal, ok := classifyRestartLog(ComponentLogsEntry{Log: "Exception in thread \"main\" java.lang.OutOfMemoryError: Java heap space", PodName: "api-7d9f"})
+	if !ok || signal.Kind != RestartKindOOMKilled || signal.PodName != "api-7d9f" || signal.Source != RestartSourceLogs {
+		t.Errorf("unexpected OOM signal: %+v, %v", signal, ok)
+	}
+	signal, ok = classifyRestartLog(ComponentLogsEntry{Log: "worker exited with code 3"})
+	if !ok || signal.Kind != RestartKindExited || signal.ExitCode == nil || *signal.ExitCode != 3 {
+		t.Errorf("unexpected exit signal: %+v, %v", signal, ok)
+	}
+	if _, ok := classifyRestartLog(ComponentLogsEntry{Log: "checking exit code handling docs"}); ok {
+		t.Error("expected a line without an exit code to be ignored")
+	}
+}
+
+func TestGenerateRestartQueries(t *testing.T) {
+	params := RestartSummaryParams{
+		Namespace:   "default",
+		ComponentID: "comp-1",
+		StartTime:   time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		EndTime:     time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+	}
+
+	raw, err := generateRestartEventsQuery(params, "k8s_events", testLogger())
+	if err != nil {
+		t.Fatalf("generateRestartEventsQuery() error = %v", err)
+	}
+	eventsSQL, _ := sqlOf(t, raw)
+	for _, want := range []string{
+		`FROM "k8s_events"`,
+		evComponentID + " = 'comp-1'",
+		evReason + " IN ('BackOff', 'Killing', 'OOMKilling', 'Evicted')",
+		"ORDER BY _timestamp DESC",
+	} {
+		if !strings.Contains(eventsSQL, want) {
+			t.Errorf("expected events query to contain %q, got %s", want, eventsSQL)
+		}
+	}
+
+	raw, err = generateRestartLogsQuery(params, "default", DefaultFieldMapping, testLogger())
+	if err != nil {
+		t.Fatalf("generateRestartLogsQuery() error = %v", err)
+	}
+	logsSQL, _ := sqlOf(t, raw)
+	for _, want := range []string{
+		"kubernetes_labels_openchoreo_dev_component_uid = 'comp-1'",
+		"log LIKE '%OutOfMemoryError%'",
+		"log LIKE '%exited with code%'",
+	} {
+		if !strings.Contains(logsSQL, want) {
+			t.Errorf("expected logs query to contain %q, got %s", want, logsSQL)
+		}
+	}
+
+	if _, err := generateRestartLogsQuery(RestartSummaryParams{}, "default", DefaultFieldMapping, testLogger()); err == nil {
+		t.Error("expected error without namespace")
+	}
+}
+
+func TestGetRestartSummary(t *testing.T) {
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	var queried []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query struct {
+				SQL string `json:"sql"`
+			} `json:"query"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		queried = append(queried, body.Query.SQL)
+
+		var hits []map[string]interface{}
+		if strings.Contains(body.Query.SQL, `"k8s_events"`) {
+			hits = []map[string]interface{}{
+				{
+					"_timestamp":      float64(base.Add(2 * time.Minute).UnixMicro()),
+					evReason:          "BackOff",
+					evMessage:         "Back-off restarting failed container api in pod api-1",
+					evObjectKind:      "Pod",
+					evObjectName:      "api-1",
+					evComponentID:     "comp-1",
+					evEnvironmentID:   "env-1",
+					evEnvironmentName: "dev",
+				},
+				{
+					"_timestamp":    float64(base.UnixMicro()),
+					evReason:        "OOMKilling",
+					evMessage:       "Memory cgroup out of memory: Killed process 42 (java)",
+					evObjectName:    "api-1",
+					evComponentID:   "comp-1",
+					evEnvironmentID: "env-1",
+				},
+				{
+					"_timestamp":    float64(base.UnixMicro()),
+					evReason:        "Killing",
+					evMessage:       "Stopping container api",
+					evComponentID:   "comp-1",
+					evEnvironmentID: "env-1",
+				},
+			}
+		} else {
+			hits = []map[string]interface{}{
+				{
+					"_timestamp": float64(base.Add(time.Hour).UnixMicro()),
+					"log":        "worker exited with code 2",
+					"kubernetes_labels_openchoreo_dev_component_uid":   "comp-2",
+					"kubernetes_labels_openchoreo_dev_environment_uid": "env-1",
+					"kubernetes_pod_name":                              "worker-1",
+				},
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(OpenObserveResponse{Took: 3, Hits: hits})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	result, err := client.GetRestartSummary(context.Background(), RestartSummaryParams{
+		Namespace: "default",
+		StartTime: base.Add(-time.Hour),
+		EndTime:   base.Add(2 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("GetRestartSummary() error = %v", err)
+	}
+	if len(queried) != 2 || result.Took != 6 || result.Truncated {
+		t.Fatalf("expected both sources to be queried once, got %d queries, result %+v", len(queried), result)
+	}
+	if len(result.Components) != 2 {
+		t.Fatalf("expected 2 components, got %+v", result.Components)
+	}
+
+	worker, api := result.Components[0], result.Components[1]
+	if worker.ComponentUID != "comp-2" || worker.Counts[RestartKindExited] != 1 || len(worker.ExitCodes) != 1 || worker.ExitCodes[0] != 2 {
+		t.Errorf("unexpected worker summary: %+v", worker)
+	}
+	if api.ComponentUID != "comp-1" || api.EnvironmentName != "dev" ||
+		api.Counts[RestartKindCrashLoop] != 1 || api.Counts[RestartKindOOMKilled] != 1 || len(api.Signals) != 2 {
+		t.Errorf("unexpected api summary: %+v", api)
+	}
+	if len(api.ExitCodes) != 1 || api.ExitCodes[0] != oomExitCode || len(api.Pods) != 1 || api.Pods[0] != "api-1" {
+		t.Errorf("unexpected api exit codes or pods: %+v", api)
+	}
+	if !api.FirstSeen.Equal(base) || !api.LastSeen.Equal(base.Add(2*time.Minute)) || api.Signals[0].Kind != RestartKindCrashLoop {
+		t.Errorf("unexpected api timeline: %+v", api)
+	}
+
+	queried = nil
+	if _, err := client.GetRestartSummary(context.Background(), RestartSummaryParams{
+		Namespace: "default",
+		Sources:   []string{RestartSourceLogs},
+	}); err != nil {
+		t.Fatalf("GetRestartSummary() error = %v", err)
+	}
+	if len(queried) != 1 || strings.Contains(queried[0], "k8s_events") {
+		t.Errorf("expected only the logs stream to be queried, got %v", queried)
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const (
+	// warningRangeClamped tells that the start of a query was moved to the
+	// retention of a stream.
+	warningRangeClamped = "logs before %s are past the %d-day retention of stream %s and were not searched"
+	// retentionRetryInterval is how soon the retention of a stream is read
+	// again after failing.
+	retentionRetryInterval = time.Minute
+)
+
+// retentionCache caches the data retention of streams, in days, for ttl.
+type retentionCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	streams map[string]retentionEntry
+}
+
+type retentionEntry struct {
+	days    int
+	expires time.Time
+}
+
+// SetRetentionClamping clamps the start of the logs queries of the client
+// to the data retention of their streams, read from the stream settings and
+// cached for ttl.
+func (c *Client) SetRetentionClamping(ttl time.Duration) {
+	c.retention = &retentionCache{ttl: ttl, now: time.Now, streams: map[string]retentionEntry{}}
+}
+
+// streamRetention returns the data retention of stream of org, in days, or
+// 0 when it has none of its own or cannot be read.
+func (c *Client) streamRetention(ctx context.Context, org, stream string) int {
+	r := c.retention
+	key := org + "/" + stream
+	r.mu.Lock()
+	entry, ok := r.streams[key]
+	r.mu.Unlock()
+	now := r.now()
+	if ok && now.Before(entry.expires) {
+		return entry.days
+	}
+
+	var result struct {
+		Settings struct {
+			DataRetention int `json:"data_retention"`
+		} `json:"settings"`
+	}
+	entry = retentionEntry{expires: now.Add(r.ttl)}
+	if err := c.getStreamSchema(ctx, org, "logs", stream, &result); err != nil {
+		c.logger.Warn("Failed to read the retention of a stream, queries are not clamped to it",
+			slog.String("stream", stream), slog.Any("error", err))
+		entry.expires = now.Add(min(r.ttl, retentionRetryInterval))
+	} else {
+		entry.days = max(result.Settings.DataRetention, 0)
+	}
+	r.mu.Lock()
+	r.streams[key] = entry
+	r.mu.Unlock()
+	return entry.days
+}
+
+// clampToRetention returns start, or the earliest time stream of org keeps
+// logs for when start predates it, recording the clamp in clamp. Streams
+// without a retention of their own, which OpenObserve keeps for its global
+// retention, are not clamped.
+func (c *Client) clampToRetention(ctx context.Context, org, stream string, start time.Time, clamp *PartialResult) time.Time {
+	if c.retention == nil {
+		return start
+	}
+	days := c.streamRetention(ctx, org, stream)
+	if days == 0 {
+		return start
+	}
+	earliest := c.retention.now().Add(-time.Duration(days) * 24 * time.Hour).Truncate(time.Second)
+	if !start.Before(earliest) {
+		return start
+	}
+	clamp.RangeClamped = true
+	clamp.EarliestAvailable = &earliest
+	clamp.addWarning(fmt.Sprintf(warningRangeClamped, earliest.UTC().Format(time.RFC3339), days, stream))
+	return earliest
+}
@@ -0,0 +1,116 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGetComponentLogs_RetentionClamp(t *testing.T) {
+	// default keeps logs for 7 days, prod_logs for the global retention.
+	now := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+	var mu sync.Mutex
+	schemaReads := 0
+	starts := map[string]int64{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		mu.Lock()
+		defer mu.Unlock()
+		if strings.HasSuffix(r.URL.Path, "/schema") {
+			schemaReads++
+			retention := 0
+			if strings.Contains(r.URL.Path, "/streams/default/") {
+				retention = 7
+			}
+			fmt.Fprintf(w, `{"name":"x","settings":{"data_retention":%d}}`, retention)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		var request struct {
+			Query struct {
+				StartTime int64 `json:"start_time"`
+			} `json:"query"`
+		}
+		_ = json.Unmarshal(body, &request)
+		sql, _ := sqlOf(t, body)
+		stream := "default"
+		if strings.Contains(sql, `"prod_logs"`) {
+			stream = "prod_logs"
+		}
+		starts[stream] = request.Query.StartTime
+		_ = json.NewEncoder(w).Encode(OpenObserveResponse{Hits: []map[string]interface{}{{"_timestamp": 1e6, "log": "a"}}})
+	}))
+	defer server.Close()
+
+	routes, err := ParseStreamRoutes("environment:prod=prod_logs")
+	if err != nil {
+		t.Fatalf("ParseStreamRoutes() error = %v", err)
+	}
+	client := newTestClient(server.URL)
+	client.SetStreamRoutes(routes)
+	client.SetRetentionClamping(time.Hour)
+	client.retention.now = func() time.Time { return now }
+
+	start := now.Add(-30 * 24 * time.Hour)
+	earliest := now.Add(-7 * 24 * time.Hour)
+	params := ComponentLogsParams{
+		Namespace:      "acme",
+		EnvironmentIDs: []string{"prod", "dev"},
+		StartTime:      start,
+		EndTime:        now,
+		Limit:          10,
+	}
+	result, err := client.GetComponentLogs(context.Background(), params)
+	if err != nil {
+		t.Fatalf("GetComponentLogs() error = %v", err)
+	}
+	if !result.RangeClamped || result.EarliestAvailable == nil || !result.EarliestAvailable.Equal(earliest) || result.Partial {
+		t.Errorf("expected a complete result clamped to %v, got %+v", earliest, result.PartialResult)
+	}
+	if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "7-day retention of stream default") {
+		t.Errorf("expected a warning naming the retention, got %v", result.Warnings)
+	}
+	if starts["default"] != earliest.UnixMicro() || starts["prod_logs"] != start.UnixMicro() {
+		t.Errorf("expected only the stream with a retention to be clamped, got start times %v", starts)
+	}
+
+	// A window entirely past the retention is not searched.
+	delete(starts, "default")
+	params.EnvironmentIDs = []string{"dev"}
+	params.EndTime = now.Add(-10 * 24 * time.Hour)
+	result, err = client.GetComponentLogs(context.Background(), params)
+	if err != nil {
+		t.Fatalf("GetComponentLogs() error = %v", err)
+	}
+	if !result.RangeClamped || len(result.Logs) != 0 || result.TotalCount != 0 {
+		t.Errorf("expected an empty clamped result, got %+v", result)
+	}
+	if _, searched := starts["default"]; searched {
+		t.Error("expected no search of a window past the retention")
+	}
+	if schemaReads != 2 {
+		t.Errorf("expected the retention of each stream to be read once, got %d reads", schemaReads)
+	}
+}
+
+func TestPartialResult_MergeClamp(t *testing.T) {
+	early := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	late := early.Add(time.Hour)
+	var merged PartialResult
+	merged.Merge(PartialResult{RangeClamped: true, EarliestAvailable: &early, Warnings: []string{"a"}})
+	merged.Merge(PartialResult{RangeClamped: true, EarliestAvailable: &late, Warnings: []string{"b"}})
+	merged.Merge(PartialResult{})
+	if !merged.RangeClamped || !merged.EarliestAvailable.Equal(late) || merged.Partial || len(merged.Warnings) != 2 {
+		t.Errorf("expected a clamp to the latest earliest time, got %+v", merged)
+	}
+}
@@ -0,0 +1,17 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import ooclient "github.com/openchoreo/community-modules/common/openobserve"
+
+// RetryPolicy configures the retries and the circuit breaker of the calls of
+// a client to OpenObserve. See SetRetryPolicy.
+type RetryPolicy = ooclient.RetryPolicy
+
+// ErrBackendUnavailable is returned when OpenObserve could not be reached
+// after retries, or when the circuit breaker rejects calls.
+var ErrBackendUnavailable = ooclient.ErrBackendUnavailable
+
+// DefaultRetryPolicy is the retry policy used unless configured otherwise.
+var DefaultRetryPolicy = ooclient.DefaultRetryPolicy
@@ -0,0 +1,210 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	ooclient "github.com/openchoreo/community-modules/common/openobserve"
+	"github.com/openchoreo/community-modules/common/rollups"
+)
+
+// rollupBatchSize is the number of rollup rows read per query when rolling
+// up the logs stream.
+const rollupBatchSize = 5000
+
+// SetLevelRollups serves level histograms from the level rollups of the
+// logs stream, the number of log lines per component, level and minute,
+// kept in stream by a rollups.Job building them with the client. They are
+// read for the minutes of coverage.
+func (c *Client) SetLevelRollups(stream string, coverage *rollups.Coverage) {
+	c.rollupStream = stream
+	c.rollupCoverage = coverage
+}
+
+// RollupRange returns the first and last minutes of the level rollups.
+func (c *Client) RollupRange(ctx context.Context) (time.Time, time.Time, bool, error) {
+	sql := "SELECT min(_timestamp) AS first, max(_timestamp) AS last FROM " + quoteIdentifier(c.rollupStream)
+	queryJSON, err := json.Marshal(map[string]interface{}{
+		"query": map[string]interface{}{
+			"sql":        sql,
+			"start_time": 0,
+			"end_time":   time.Now().UnixMicro(),
+			"from":       0,
+			"size":       1,
+		},
+	})
+	if err != nil {
+		return time.Time{}, time.Time{}, false, err
+	}
+	c.debugSearch(ctx, c.Org(), "logs", queryJSON)
+	resp, err := c.Search(ctx, "logs", queryJSON)
+	if errors.Is(err, ooclient.ErrStreamNotFound) {
+		return time.Time{}, time.Time{}, false, nil
+	}
+	if err != nil {
+		return time.Time{}, time.Time{}, false, fmt.Errorf("failed to read the level rollups range: %w", err)
+	}
+	if len(resp.Hits) == 0 {
+		return time.Time{}, time.Time{}, false, nil
+	}
+	first, okFirst := resp.Hits[0]["first"].(float64)
+	last, okLast := resp.Hits[0]["last"].(float64)
+	if !okFirst || !okLast {
+		return time.Time{}, time.Time{}, false, nil
+	}
+	return time.UnixMicro(int64(first)).UTC(), time.UnixMicro(int64(last)).UTC(), true, nil
+}
+
+// BuildRollups counts the log lines of each component and level in each
+// minute of [start, end) and writes the counts to the rollups stream, all
+// in one request.
+func (c *Client) BuildRollups(ctx context.Context, start, end time.Time) error {
+	var records []map[string]interface{}
+	for offset := 0; ; offset += rollupBatchSize {
+		queryJSON, err := generateLevelRollupQuery(start, end, offset, c.stream, c.fields)
+		if err != nil {
+			return err
+		}
+		resp, err := c.executeSearchQuery(ctx, queryJSON)
+		if err != nil {
+			return fmt.Errorf("failed to roll up log levels: %w", err)
+		}
+		for _, hit := range resp.Hits {
+			minute, ok := parseHistogramTime(hit["minute"])
+			if !ok {
+				continue
+			}
+			count, _ := hit["log_count"].(float64)
+			records = append(records, map[string]interface{}{
+				"_timestamp":    minute.UnixMicro(),
+				"namespace":     stringField(hit, "namespace"),
+				"component_uid": stringField(hit, "component_uid"),
+				"level":         stringField(hit, "level"),
+				"log_count":     int(count),
+			})
+		}
+		if len(resp.Hits) < rollupBatchSize {
+			break
+		}
+	}
+	if len(records) == 0 {
+		return nil
+	}
+	if err := c.Ingest(ctx, c.rollupStream, records); err != nil {
+		return fmt.Errorf("failed to write level rollups: %w", err)
+	}
+	return nil
+}
+
+// generateLevelRollupQuery generates the query counting the log lines of
+// each component and level per minute between start and end, a page of
+// rollupBatchSize rows at offset.
+func generateLevelRollupQuery(start, end time.Time, offset int, stream string, fields FieldMapping) ([]byte, error) {
+	sql := fmt.Sprintf("SELECT histogram(_timestamp, '60 seconds') AS minute, %s AS namespace, %s AS component_uid, "+
+		"%s AS level, count(*) AS log_count FROM %s WHERE %s != ''"+
+		" GROUP BY minute, namespace, component_uid, level ORDER BY minute, namespace, component_uid, level",
+		fields.Namespace, fields.ComponentUID, fields.Level, quoteIdentifier(stream), fields.ComponentUID)
+	return json.Marshal(map[string]interface{}{
+		"query": map[string]interface{}{
+			"sql":        sql,
+			"start_time": start.UnixMicro(),
+			"end_time":   end.UnixMicro(),
+			"from":       offset,
+			"size":       rollupBatchSize,
+		},
+	})
+}
+
+// levelRollupsWindow returns the whole minutes of the window of a level
+// histogram that can be read from the level rollups. Only histograms of
+// whole minutes can.
+func (c *Client) levelRollupsWindow(params LevelHistogramParams) (time.Time, time.Time, bool) {
+	if c.rollupStream == "" || params.Interval%time.Minute != 0 {
+		return time.Time{}, time.Time{}, false
+	}
+	return c.rollupCoverage.Split(params.StartTime, params.EndTime)
+}
+
+// getLevelHistogramFromRollups reads the minutes of [innerStart, innerEnd)
+// of a level histogram from the level rollups, and the partial minutes at
+// the edges of its window from the logs stream. Buckets are aligned on the
+// interval, so the counts of both add up.
+func (c *Client) getLevelHistogramFromRollups(ctx context.Context, params LevelHistogramParams, innerStart, innerEnd time.Time) (*LevelHistogramResult, error) {
+	queryJSON, err := generateLevelRollupHistogramQuery(params, innerStart, innerEnd, c.rollupStream, c.logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate level histogram query: %w", err)
+	}
+	resp, err := c.executeSearchQuery(ctx, queryJSON)
+	if err != nil {
+		return nil, err
+	}
+	result := &LevelHistogramResult{
+		Buckets:     parseLevelHistogram(resp.Hits),
+		Took:        resp.Took,
+		FromRollups: true,
+	}
+
+	for _, edge := range [][2]time.Time{{params.StartTime, innerStart}, {innerEnd, params.EndTime}} {
+		if !edge[0].Before(edge[1]) {
+			continue
+		}
+		edgeParams := params
+		edgeParams.StartTime, edgeParams.EndTime = edge[0], edge[1]
+		edgeResult, err := c.getComponentLevelHistogram(ctx, edgeParams)
+		if err != nil {
+			return nil, err
+		}
+		result.Took += edgeResult.Took
+		result.Buckets = mergeLevelBuckets(result.Buckets, edgeResult.Buckets)
+	}
+	return result, nil
+}
+
+// generateLevelRollupHistogramQuery generates the query of the level
+// histogram of a component between start and end from the level rollups.
+func generateLevelRollupHistogramQuery(params LevelHistogramParams, start, end time.Time, stream string, logger *slog.Logger) ([]byte, error) {
+	sql := fmt.Sprintf("SELECT histogram(_timestamp, '%d seconds') AS bucket, level, sum(log_count) AS count FROM %s"+
+		" WHERE namespace = '%s' AND component_uid = '%s'"+
+		" GROUP BY bucket, level ORDER BY bucket ASC",
+		int(params.Interval/time.Second), quoteIdentifier(stream),
+		escapeSQLString(params.Namespace), escapeSQLString(params.ComponentUID))
+
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"sql":        sql,
+			"start_time": start.UnixMicro(),
+			"end_time":   end.UnixMicro(),
+			"from":       0,
+			"size":       maxLevelHistogramRows,
+		},
+	}
+
+	logGenerated(logger, query, "level rollup histogram query")
+
+	return json.Marshal(query)
+}
+
+// mergeLevelBuckets adds the counts of b to the buckets of a, and returns
+// them in ascending time order.
+func mergeLevelBuckets(a, b []LevelHistogramBucket) []LevelHistogramBucket {
+	for _, bucket := range b {
+		i := slices.IndexFunc(a, func(x LevelHistogramBucket) bool { return x.Time.Equal(bucket.Time) })
+		if i < 0 {
+			a = append(a, LevelHistogramBucket{Time: bucket.Time, Counts: map[string]int{}})
+			i = len(a) - 1
+		}
+		for level, n := range bucket.Counts {
+			a[i].Counts[level] += n
+		}
+	}
+	slices.SortFunc(a, func(x, y LevelHistogramBucket) int { return x.Time.Compare(y.Time) })
+	return a
+}
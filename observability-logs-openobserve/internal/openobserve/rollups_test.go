@@ -0,0 +1,134 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/rollups"
+)
+
+func TestBuildRollups(t *testing.T) {
+	var ingested []map[string]interface{}
+	var ingestPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/_json") {
+			ingestPath = r.URL.Path
+			_ = json.NewDecoder(r.Body).Decode(&ingested)
+			w.Write([]byte(`{"code":200,"status":[{"name":"level_rollups","successful":2,"failed":0}]}`))
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		sql, query := sqlOf(t, body)
+		if !strings.Contains(sql, "histogram(_timestamp, '60 seconds') AS minute") || !strings.Contains(sql, `FROM "default"`) {
+			t.Errorf("unexpected rollup query %q", sql)
+		}
+		if query["start_time"].(float64) != 1767268800000000 {
+			t.Errorf("unexpected start time %v", query["start_time"])
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(OpenObserveResponse{Hits: []map[string]interface{}{
+			{"minute": "2026-01-01T12:00:00", "namespace": "acme", "component_uid": "c1", "level": "ERROR", "log_count": 3},
+			{"minute": "2026-01-01T12:01:00", "namespace": "acme", "component_uid": "c1", "level": "INFO", "log_count": 5},
+		}})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	client.SetLevelRollups("level_rollups", &rollups.Coverage{})
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	if err := client.BuildRollups(context.Background(), start, start.Add(time.Hour)); err != nil {
+		t.Fatalf("BuildRollups() error = %v", err)
+	}
+	if ingestPath != "/api/default/level_rollups/_json" || len(ingested) != 2 {
+		t.Fatalf("unexpected ingestion of %v to %s", ingested, ingestPath)
+	}
+	if ingested[1]["_timestamp"].(float64) != float64(start.Add(time.Minute).UnixMicro()) ||
+		ingested[1]["level"] != "INFO" || ingested[1]["log_count"].(float64) != 5 {
+		t.Errorf("unexpected rollup record %v", ingested[1])
+	}
+}
+
+// coveredBuilder builds nothing, so that a job covers its window at once.
+type coveredBuilder struct{}
+
+func (coveredBuilder) RollupRange(context.Context) (time.Time, time.Time, bool, error) {
+	return time.Time{}, time.Time{}, false, nil
+}
+
+func (coveredBuilder) BuildRollups(context.Context, time.Time, time.Time) error { return nil }
+
+func TestGetComponentLevelHistogram_FromRollups(t *testing.T) {
+	var mu sync.Mutex
+	var rawWindows [][2]float64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		sql, query := sqlOf(t, body)
+		resp := OpenObserveResponse{}
+		if strings.Contains(sql, `FROM "level_rollups"`) {
+			if !strings.Contains(sql, "sum(log_count) AS count") || !strings.Contains(sql, "component_uid = 'c1'") {
+				t.Errorf("unexpected rollup histogram query %q", sql)
+			}
+			resp.Hits = []map[string]interface{}{
+				{"bucket": "2026-01-01T12:00:00", "level": "INFO", "count": 10},
+				{"bucket": "2026-01-01T12:05:00", "level": "ERROR", "count": 2},
+			}
+		} else {
+			mu.Lock()
+			rawWindows = append(rawWindows, [2]float64{query["start_time"].(float64), query["end_time"].(float64)})
+			mu.Unlock()
+			resp.Hits = []map[string]interface{}{{"bucket": "2026-01-01T12:00:00", "level": "INFO", "count": 1}}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	now := time.Date(2026, 1, 1, 14, 0, 0, 0, time.UTC)
+	coverage := &rollups.Coverage{}
+	if err := rollups.NewJob(coveredBuilder{}, coverage, time.Minute, 2*time.Hour, testLogger()).Update(context.Background(), now); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	client := newTestClient(server.URL)
+	client.SetLevelRollups("level_rollups", coverage)
+
+	start := time.Date(2026, 1, 1, 12, 0, 30, 0, time.UTC)
+	params := LevelHistogramParams{
+		Namespace:    "acme",
+		ComponentUID: "c1",
+		StartTime:    start,
+		EndTime:      start.Add(10 * time.Minute),
+		Interval:     5 * time.Minute,
+	}
+	result, err := client.GetComponentLevelHistogram(context.Background(), params)
+	if err != nil {
+		t.Fatalf("GetComponentLevelHistogram() error = %v", err)
+	}
+	if !result.FromRollups || len(result.Buckets) != 2 || result.Buckets[0].Counts["INFO"] != 12 || result.Buckets[1].Counts["ERROR"] != 2 {
+		t.Errorf("expected the rollups and both edges merged, got %+v", result)
+	}
+	if len(rawWindows) != 2 {
+		t.Fatalf("expected the partial minutes at both edges to be read from the logs stream, got %v", rawWindows)
+	}
+
+	// Histograms of buckets other than whole minutes are read from the
+	// logs stream.
+	rawWindows = nil
+	params.Interval = 90 * time.Second
+	result, err = client.GetComponentLevelHistogram(context.Background(), params)
+	if err != nil {
+		t.Fatalf("GetComponentLevelHistogram() error = %v", err)
+	}
+	if result.FromRollups || len(rawWindows) != 1 {
+		t.Errorf("expected a single query of the logs stream, got %v", rawWindows)
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// Kinds of hits validated against a schema.
+const (
+	hitKindApplication = "application"
+	hitKindWorkflow    = "workflow"
+)
+
+// fieldType is the JSON type expected of a hit field.
+type fieldType int
+
+const (
+	fieldString fieldType = iota
+	fieldNumber
+)
+
+// hitField describes a field of a hit. Optional fields may be absent or null,
+// but must have the expected type when present.
+type hitField struct {
+	typ      fieldType
+	required bool
+}
+
+// applicationHitSchema and workflowHitSchema list the fields the parsers read
+// from application and workflow log hits under the default field mapping.
+// Other fields are not validated.
+var (
+	applicationHitSchema = DefaultFieldMapping.applicationHitSchema()
+	workflowHitSchema    = DefaultFieldMapping.workflowHitSchema()
+)
+
+// malformedFields returns the sorted names of the fields of hit that are
+// missing although required, or do not have the type schema expects.
+func malformedFields(schema map[string]hitField, hit map[string]interface{}) []string {
+	var fields []string
+	for name, field := range schema {
+		v, ok := hit[name]
+		if !ok || v == nil {
+			if field.required {
+				fields = append(fields, name)
+			}
+			continue
+		}
+		valid := false
+		switch field.typ {
+		case fieldString:
+			_, valid = v.(string)
+		case fieldNumber:
+			_, valid = v.(float64)
+		}
+		if !valid {
+			fields = append(fields, name)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// ParseErrors summarizes the hits of a query that did not match the schema
+// of their kind. Malformed hits are still returned, parsed on a best-effort
+// basis.
+type ParseErrors struct {
+	// MalformedRows is the number of hits with at least one malformed field.
+	MalformedRows int `json:"malformedRows"`
+	// Fields counts the malformed hits by field name.
+	Fields map[string]int `json:"fields"`
+}
+
+// add records a hit whose fields are malformed.
+func (p *ParseErrors) add(fields []string) {
+	p.MalformedRows++
+	for _, f := range fields {
+		p.Fields[f]++
+	}
+}
+
+// SchemaValidator validates the hits returned by OpenObserve against the
+// fields the parsers expect, so that schema drift in the collector pipeline
+// shows up as counted parse errors rather than silently empty fields. Setting
+// it on a client enables strict parsing.
+//
+// It serves its malformed hit counters in the Prometheus text exposition
+// format.
+type SchemaValidator struct {
+	mu sync.Mutex
+	// malformed counts the malformed hits by kind and field.
+	malformed map[[2]string]int64
+}
+
+// NewSchemaValidator returns a SchemaValidator with no malformed hits.
+func NewSchemaValidator() *SchemaValidator {
+	return &SchemaValidator{malformed: map[[2]string]int64{}}
+}
+
+// validator validates the hits of one query of kind against schema.
+type validator struct {
+	kind   string
+	schema map[string]hitField
+	v      *SchemaValidator
+	errors ParseErrors
+}
+
+// newValidator returns a validator of the hits of kind, or nil if v is nil,
+// in which case hits are not validated.
+func (v *SchemaValidator) newValidator(kind string, schema map[string]hitField) *validator {
+	if v == nil {
+		return nil
+	}
+	return &validator{kind: kind, schema: schema, v: v, errors: ParseErrors{Fields: map[string]int{}}}
+}
+
+// check validates hit. A nil validator accepts every hit.
+func (val *validator) check(hit map[string]interface{}) {
+	if val == nil {
+		return
+	}
+	fields := malformedFields(val.schema, hit)
+	if len(fields) == 0 {
+		return
+	}
+	val.errors.add(fields)
+	val.v.record(val.kind, fields)
+}
+
+// result returns the parse errors of the query, or nil if every hit was valid
+// or hits were not validated.
+func (val *validator) result() *ParseErrors {
+	if val == nil || val.errors.MalformedRows == 0 {
+		return nil
+	}
+	return &val.errors
+}
+
+func (v *SchemaValidator) record(kind string, fields []string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for _, f := range fields {
+		v.malformed[[2]string{kind, f}]++
+	}
+}
+
+// Malformed returns the number of malformed hits of kind with field malformed.
+func (v *SchemaValidator) Malformed(kind, field string) int64 {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.malformed[[2]string{kind, field}]
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format.
+func (v *SchemaValidator) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	keys := make([][2]string, 0, len(v.malformed))
+	for k := range v.malformed {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	fmt.Fprint(w, "# HELP logs_adapter_malformed_hits_total Backend hits with a field missing or of an unexpected type.\n"+
+		"# TYPE logs_adapter_malformed_hits_total counter\n")
+	for _, k := range keys {
+		fmt.Fprintf(w, "logs_adapter_malformed_hits_total{kind=%q,field=%q} %d\n", k[0], k[1], v.malformed[k])
+	}
+}
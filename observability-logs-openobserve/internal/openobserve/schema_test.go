@@ -0,0 +1,120 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMalformedFields(t *testing.T) {
+	tests := []struct {
+		name string
+		hit  map[string]interface{}
+		want []string
+	}{
+		{
+			name: "valid",
+			hit:  map[string]interface{}{"_timestamp": float64(1), "log": "ok", "kubernetes_pod_name": "p", "extra": true},
+		},
+		{
+			name: "optional fields absent or null",
+			hit:  map[string]interface{}{"_timestamp": float64(1), "log": "ok", "logLevel": nil},
+		},
+		{
+			name: "required fields missing",
+			hit:  map[string]interface{}{"kubernetes_pod_name": "p"},
+			want: []string{"_timestamp", "log"},
+		},
+		{
+			name: "unexpected types",
+			hit:  map[string]interface{}{"_timestamp": "1", "log": "ok", "logLevel": float64(3), eventTimeColumn: "now"},
+			want: []string{"_timestamp", eventTimeColumn, "logLevel"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := malformedFields(applicationHitSchema, tt.hit); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("malformedFields() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetComponentLogs_StrictMode(t *testing.T) {
+	hits := []map[string]interface{}{
+		{"_timestamp": float64(1735689600000000), "log": "ok"},
+		{"_timestamp": float64(1735689600000001), "log": map[string]interface{}{"msg": "structured"}},
+		{"_timestamp": "1735689600000002", "log": float64(42), "kubernetes_pod_name": "p"},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(OpenObserveResponse{Hits: hits, Total: len(hits)})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	result, err := client.GetComponentLogs(context.Background(), ComponentLogsParams{Namespace: "test-ns"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.ParseErrors != nil {
+		t.Errorf("expected no parse errors outside strict mode, got %+v", result.ParseErrors)
+	}
+
+	schema := NewSchemaValidator()
+	client.SetSchemaValidator(schema)
+	result, err = client.GetComponentLogs(context.Background(), ComponentLogsParams{Namespace: "test-ns"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Logs) != 3 {
+		t.Errorf("expected malformed hits to be returned, got %d logs", len(result.Logs))
+	}
+	want := &ParseErrors{MalformedRows: 2, Fields: map[string]int{"log": 2, "_timestamp": 1}}
+	if !reflect.DeepEqual(result.ParseErrors, want) {
+		t.Errorf("ParseErrors = %+v, want %+v", result.ParseErrors, want)
+	}
+	if got := schema.Malformed(hitKindApplication, "log"); got != 2 {
+		t.Errorf("Malformed(application, log) = %d, want 2", got)
+	}
+
+	rec := httptest.NewRecorder()
+	schema.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := rec.Body.String()
+	for _, line := range []string{
+		`logs_adapter_malformed_hits_total{kind="application",field="_timestamp"} 1`,
+		`logs_adapter_malformed_hits_total{kind="application",field="log"} 2`,
+	} {
+		if !strings.Contains(body, line) {
+			t.Errorf("expected %q in metrics:\n%s", line, body)
+		}
+	}
+}
+
+func TestGetWorkflowLogs_StrictMode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(OpenObserveResponse{Hits: []map[string]interface{}{
+			{"_timestamp": float64(1735689600000000), "log": "step 1", eventTimeColumn: float64(1735689600)},
+			{"_timestamp": float64(1735689600000001), "log": "step 2"},
+		}})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	client.SetSchemaValidator(NewSchemaValidator())
+	result, err := client.GetWorkflowLogs(context.Background(), WorkflowLogsParams{Namespace: "test-ns", WorkflowRunName: "run-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.ParseErrors != nil {
+		t.Errorf("expected no parse errors for valid hits, got %+v", result.ParseErrors)
+	}
+}
@@ -0,0 +1,320 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+const (
+	// maxSearchQueryTerms bounds the terms of a search query, and so the
+	// size of the SQL it compiles to.
+	maxSearchQueryTerms = 64
+	// maxSearchQueryDepth bounds the nesting of parentheses.
+	maxSearchQueryDepth = 16
+)
+
+// searchQueryFields are the fields of search queries. Their columns are
+// given by the field mapping of the query; see FieldMapping.searchColumn.
+var searchQueryFields = []string{"log", "level", "pod", "container", "component", "environment", "project"}
+
+// SearchQuery is a parsed full-text search query. Bare words and quoted
+// phrases match log lines containing them; field:value terms match the
+// column of the field, exactly or, with a trailing *, by prefix. Terms are
+// combined with AND (also implied between terms), OR and NOT, grouped with
+// parentheses. AND binds tighter than OR, and the operators are only
+// recognized in upper case.
+type SearchQuery struct {
+	root searchNode
+}
+
+// searchNode is a node of a parsed search query.
+type searchNode interface {
+	sql(fields FieldMapping) string
+}
+
+type (
+	searchAnd  []searchNode
+	searchOr   []searchNode
+	searchNot  struct{ node searchNode }
+	searchTerm struct {
+		field string
+		value string
+		// prefix matches the values of the column starting with value.
+		prefix bool
+	}
+)
+
+func (n searchAnd) sql(fields FieldMapping) string { return joinSearchNodes(n, " AND ", fields) }
+func (n searchOr) sql(fields FieldMapping) string  { return joinSearchNodes(n, " OR ", fields) }
+func (n searchNot) sql(fields FieldMapping) string { return "NOT " + n.node.sql(fields) }
+
+func joinSearchNodes(nodes []searchNode, op string, fields FieldMapping) string {
+	parts := make([]string, len(nodes))
+	for i, node := range nodes {
+		parts[i] = node.sql(fields)
+	}
+	return "(" + strings.Join(parts, op) + ")"
+}
+
+func (n searchTerm) sql(fields FieldMapping) string {
+	column := fields.searchColumn(n.field)
+	switch {
+	case n.field == "log":
+		return "log LIKE '%" + escapeSQLString(n.value) + "%'"
+	case n.prefix:
+		return column + " LIKE '" + escapeSQLString(n.value) + "%'"
+	case n.field == "level":
+		return column + " = '" + escapeSQLString(strings.ToUpper(n.value)) + "'"
+	default:
+		return column + " = '" + escapeSQLString(n.value) + "'"
+	}
+}
+
+// condition returns the SQL condition matching the query, with the columns
+// of fields. Columns come from the field mapping and values are escaped, so
+// no user input is interpolated outside quotes. A nil query has no condition.
+func (q *SearchQuery) condition(fields FieldMapping) string {
+	if q == nil {
+		return ""
+	}
+	return q.root.sql(fields)
+}
+
+// searchToken is a token of a search query.
+type searchToken struct {
+	kind  searchTokenKind
+	text  string
+	field string
+	pos   int
+}
+
+type searchTokenKind int
+
+const (
+	searchTokenWord searchTokenKind = iota
+	searchTokenPhrase
+	searchTokenField
+	searchTokenOpen
+	searchTokenClose
+)
+
+// ParseSearchQuery parses a full-text search query, such as
+// `level:error AND "connection refused" NOT pod:canary`.
+func ParseSearchQuery(text string) (*SearchQuery, error) {
+	tokens, err := tokenizeSearchQuery(text)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("query is empty")
+	}
+	p := &searchParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t, ok := p.peek(); ok {
+		return nil, fmt.Errorf("unexpected %q at position %d", t.text, t.pos)
+	}
+	return &SearchQuery{root: root}, nil
+}
+
+// tokenizeSearchQuery splits a search query into words, quoted phrases,
+// field:value terms and parentheses.
+func tokenizeSearchQuery(text string) ([]searchToken, error) {
+	var tokens []searchToken
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(' || r == ')':
+			kind := searchTokenOpen
+			if r == ')' {
+				kind = searchTokenClose
+			}
+			tokens = append(tokens, searchToken{kind: kind, text: string(r), pos: i})
+			i++
+		case r == '"':
+			phrase, next, err := readSearchPhrase(runes, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, searchToken{kind: searchTokenPhrase, text: phrase, pos: i})
+			i = next
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != '(' && runes[i] != ')' && runes[i] != '"' {
+				i++
+			}
+			word := string(runes[start:i])
+			name, value, isField := strings.Cut(word, ":")
+			if !isField {
+				tokens = append(tokens, searchToken{kind: searchTokenWord, text: word, pos: start})
+				break
+			}
+			if !slices.Contains(searchQueryFields, strings.ToLower(name)) {
+				return nil, fmt.Errorf("unknown field %q at position %d", name, start)
+			}
+			if value == "" && i < len(runes) && runes[i] == '"' {
+				phrase, next, err := readSearchPhrase(runes, i)
+				if err != nil {
+					return nil, err
+				}
+				value, i = phrase, next
+			}
+			if value == "" {
+				return nil, fmt.Errorf("field %q at position %d has no value", name, start)
+			}
+			tokens = append(tokens, searchToken{kind: searchTokenField, field: strings.ToLower(name), text: value, pos: start})
+		}
+	}
+	return tokens, nil
+}
+
+// readSearchPhrase reads the quoted phrase starting at runes[start], in which
+// \" and \\ escape a quote and a backslash, and returns it with the position
+// after its closing quote.
+func readSearchPhrase(runes []rune, start int) (string, int, error) {
+	var b strings.Builder
+	for i := start + 1; i < len(runes); i++ {
+		switch runes[i] {
+		case '\\':
+			if i+1 < len(runes) && (runes[i+1] == '"' || runes[i+1] == '\\') {
+				i++
+			}
+			b.WriteRune(runes[i])
+		case '"':
+			if b.Len() == 0 {
+				return "", 0, fmt.Errorf("empty phrase at position %d", start)
+			}
+			return b.String(), i + 1, nil
+		default:
+			b.WriteRune(runes[i])
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated phrase at position %d", start)
+}
+
+// searchParser parses search query tokens by recursive descent.
+type searchParser struct {
+	tokens []searchToken
+	next   int
+	depth  int
+	terms  int
+}
+
+func (p *searchParser) peek() (searchToken, bool) {
+	if p.next >= len(p.tokens) {
+		return searchToken{}, false
+	}
+	return p.tokens[p.next], true
+}
+
+// peekOperator reports whether the next token is the operator op.
+func (p *searchParser) peekOperator(op string) bool {
+	t, ok := p.peek()
+	return ok && t.kind == searchTokenWord && t.text == op
+}
+
+func (p *searchParser) parseOr() (searchNode, error) {
+	var nodes searchOr
+	for {
+		node, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+		if !p.peekOperator("OR") {
+			break
+		}
+		p.next++
+	}
+	if len(nodes) == 1 {
+		return nodes[0], nil
+	}
+	return nodes, nil
+}
+
+func (p *searchParser) parseAnd() (searchNode, error) {
+	var nodes searchAnd
+	for {
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+		if p.peekOperator("AND") {
+			p.next++
+			continue
+		}
+		if t, ok := p.peek(); !ok || t.kind == searchTokenClose || p.peekOperator("OR") {
+			break
+		}
+	}
+	if len(nodes) == 1 {
+		return nodes[0], nil
+	}
+	return nodes, nil
+}
+
+func (p *searchParser) parseUnary() (searchNode, error) {
+	if p.peekOperator("NOT") {
+		p.next++
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return searchNot{node: node}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *searchParser) parsePrimary() (searchNode, error) {
+	t, ok := p.peek()
+	if !ok {
+		return nil, fmt.Errorf("unexpected end of query")
+	}
+	p.next++
+
+	switch t.kind {
+	case searchTokenOpen:
+		if p.depth++; p.depth > maxSearchQueryDepth {
+			return nil, fmt.Errorf("query nests more than %d parentheses", maxSearchQueryDepth)
+		}
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing, ok := p.peek(); !ok || closing.kind != searchTokenClose {
+			return nil, fmt.Errorf("missing ')' for '(' at position %d", t.pos)
+		}
+		p.next++
+		p.depth--
+		return node, nil
+	case searchTokenClose:
+		return nil, fmt.Errorf("unexpected ')' at position %d", t.pos)
+	case searchTokenWord:
+		if t.text == "AND" || t.text == "OR" {
+			return nil, fmt.Errorf("unexpected %s at position %d", t.text, t.pos)
+		}
+	}
+
+	if p.terms++; p.terms > maxSearchQueryTerms {
+		return nil, fmt.Errorf("query has more than %d terms", maxSearchQueryTerms)
+	}
+	if t.kind != searchTokenField {
+		return searchTerm{field: "log", value: t.text}, nil
+	}
+	term := searchTerm{field: t.field, value: t.text}
+	if value, ok := strings.CutSuffix(t.text, "*"); ok && value != "" && term.field != "log" {
+		term.value, term.prefix = value, true
+	}
+	return term, nil
+}
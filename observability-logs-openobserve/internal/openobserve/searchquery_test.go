@@ -0,0 +1,111 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseSearchQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{`timeout`, `log LIKE '%timeout%'`},
+		{`"connection refused"`, `log LIKE '%connection refused%'`},
+		{`level:error`, `logLevel = 'ERROR'`},
+		{`pod:api-*`, `kubernetes_pod_name LIKE 'api-%'`},
+		{`Container:"side car"`, `kubernetes_container_name = 'side car'`},
+		{`log:"a \"quoted\" word"`, `log LIKE '%a "quoted" word%'`},
+		{
+			`level:error AND "connection refused" NOT pod:canary`,
+			`(logLevel = 'ERROR' AND log LIKE '%connection refused%' AND NOT kubernetes_pod_name = 'canary')`,
+		},
+		{
+			`a b OR c`,
+			`((log LIKE '%a%' AND log LIKE '%b%') OR log LIKE '%c%')`,
+		},
+		{
+			`a (b OR c)`,
+			`(log LIKE '%a%' AND (log LIKE '%b%' OR log LIKE '%c%'))`,
+		},
+		{
+			`NOT (environment:prod OR project:x)`,
+			`NOT (kubernetes_labels_openchoreo_dev_environment = 'prod' OR kubernetes_labels_openchoreo_dev_project = 'x')`,
+		},
+		{`and or`, `(log LIKE '%and%' AND log LIKE '%or%')`},
+		{`it's`, `log LIKE '%it''s%'`},
+		{`component:x' OR 1=1 --`, `(kubernetes_labels_openchoreo_dev_component = 'x''' OR (log LIKE '%1=1%' AND log LIKE '%--%'))`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := ParseSearchQuery(tt.query)
+			if err != nil {
+				t.Fatalf("ParseSearchQuery() error = %v", err)
+			}
+			if got := q.condition(DefaultFieldMapping); got != tt.want {
+				t.Errorf("condition() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSearchQuery_Errors(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{``, "query is empty"},
+		{`   `, "query is empty"},
+		{`"unterminated`, "unterminated phrase"},
+		{`""`, "empty phrase"},
+		{`host:x`, `unknown field "host"`},
+		{`pod:`, "has no value"},
+		{`(a OR b`, "missing ')'"},
+		{`a)`, `unexpected ")"`},
+		{`a AND`, "unexpected end of query"},
+		{`OR a`, "unexpected OR"},
+		{`NOT`, "unexpected end of query"},
+		{strings.Repeat("(", maxSearchQueryDepth+1) + "a" + strings.Repeat(")", maxSearchQueryDepth+1), "nests more than"},
+		{strings.Repeat("a ", maxSearchQueryTerms+1), "more than 64 terms"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := ParseSearchQuery(tt.query)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ParseSearchQuery() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateComponentLogsQuery_SearchQuery(t *testing.T) {
+	q, err := ParseSearchQuery(`level:error OR "panic"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plans, _ := NewPlanCache(8)
+	params := ComponentLogsParams{
+		Namespace: "test-ns",
+		Query:     q,
+		StartTime: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+	raw, err := generateComponentLogsQuery(params, "default", plans, testLogger())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(raw), `AND (logLevel = 'ERROR' OR log LIKE '%panic%')`) {
+		t.Errorf("expected the search query condition, got %s", raw)
+	}
+
+	// Queries differing only in their search query have their own plan.
+	params.Query, _ = ParseSearchQuery(`level:warn`)
+	raw, _ = generateComponentLogsQuery(params, "default", plans, testLogger())
+	if !strings.Contains(string(raw), `AND logLevel = 'WARN'`) {
+		t.Errorf("expected the new search query condition, got %s", raw)
+	}
+}
@@ -0,0 +1,231 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// StreamStatsTTL is how long the statistics of the streams of a client
+	// are cached.
+	StreamStatsTTL = 24 * time.Hour
+	// statsWindow is the window the statistics are computed over.
+	statsWindow = 24 * time.Hour
+	// statsFieldsPerQuery bounds the fields whose statistics one query computes.
+	statsFieldsPerQuery = 50
+	// statsSampleRows is the number of recent rows sample values are taken from.
+	statsSampleRows = 100
+	// statsMaxSamples bounds the sample values of a field.
+	statsMaxSamples = 3
+	// statsMaxSampleLength bounds the length of a sample value, in bytes.
+	statsMaxSampleLength = 120
+)
+
+// FieldStats holds the statistics of a field of a stream.
+type FieldStats struct {
+	Name string `json:"name"`
+	// Type is the Arrow data type of the field, e.g. Utf8 or Int64.
+	Type string `json:"type"`
+	// Cardinality is the approximate number of distinct values of the field.
+	Cardinality int `json:"cardinality"`
+	// NullRatio is the share of the rows without a value for the field.
+	NullRatio float64 `json:"nullRatio"`
+	// Samples are distinct values of the field found in recent rows.
+	Samples []string `json:"samples,omitempty"`
+}
+
+// StreamStats holds the statistics of the fields of a stream.
+type StreamStats struct {
+	Stream string `json:"stream"`
+	// Type is the stream type, "logs" or "traces".
+	Type   string       `json:"type"`
+	Rows   int          `json:"rows"`
+	Fields []FieldStats `json:"fields"`
+	// Error is set when the statistics of the stream could not be computed.
+	Error string `json:"error,omitempty"`
+}
+
+// StreamStatsResult holds the statistics of the streams of a client,
+// computed over the window from StartTime to EndTime.
+type StreamStatsResult struct {
+	Streams    []StreamStats `json:"streams"`
+	StartTime  time.Time     `json:"startTime"`
+	EndTime    time.Time     `json:"endTime"`
+	ComputedAt time.Time     `json:"computedAt"`
+}
+
+// statsCache caches the stream statistics of a client. Its mutex is held
+// while they are computed, so that concurrent requests share the computation.
+type statsCache struct {
+	mu     sync.Mutex
+	result *StreamStatsResult
+}
+
+// GetStreamStats returns the per-field cardinality, null ratio and sample
+// values of the logs, events and traces streams of the client over the last
+// day. Statistics are cached for StreamStatsTTL; those of streams that
+// failed are not cached.
+func (c *Client) GetStreamStats(ctx context.Context) (*StreamStatsResult, error) {
+	c.stats.mu.Lock()
+	defer c.stats.mu.Unlock()
+
+	now := time.Now()
+	if c.stats.result != nil && now.Sub(c.stats.result.ComputedAt) < StreamStatsTTL {
+		return c.stats.result, nil
+	}
+
+	result := &StreamStatsResult{StartTime: now.Add(-statsWindow), EndTime: now, ComputedAt: now}
+	streams := []struct{ streamType, name string }{
+		{"logs", c.stream},
+		{"logs", c.eventsStream},
+		{"traces", c.tracesStream},
+	}
+	failed := false
+	for _, s := range streams {
+		if s.name == "" {
+			continue
+		}
+		stats, err := c.computeStreamStats(ctx, s.streamType, s.name, result.StartTime, result.EndTime)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			c.logger.Warn("Failed to compute stream statistics",
+				slog.String("stream", s.name),
+				slog.Any("error", err),
+			)
+			stats = StreamStats{Stream: s.name, Type: s.streamType, Fields: []FieldStats{}, Error: err.Error()}
+			failed = true
+		}
+		result.Streams = append(result.Streams, stats)
+	}
+	if !failed {
+		c.stats.result = result
+	}
+	return result, nil
+}
+
+// computeStreamStats computes the statistics of the fields of stream.
+func (c *Client) computeStreamStats(ctx context.Context, streamType, stream string, start, end time.Time) (StreamStats, error) {
+	schema, err := c.streamSchema(ctx, streamType, stream)
+	if err != nil {
+		return StreamStats{}, fmt.Errorf("failed to get schema: %w", err)
+	}
+	slices.SortFunc(schema, func(a, b schemaField) int { return strings.Compare(a.Name, b.Name) })
+
+	stats := StreamStats{Stream: stream, Type: streamType, Fields: make([]FieldStats, len(schema))}
+	for i, field := range schema {
+		stats.Fields[i] = FieldStats{Name: field.Name, Type: field.Type}
+	}
+
+	for offset := 0; offset < len(schema); offset += statsFieldsPerQuery {
+		batch := schema[offset:min(offset+statsFieldsPerQuery, len(schema))]
+		queryJSON, err := generateFieldStatsQuery(batch, stream, start, end)
+		if err != nil {
+			return StreamStats{}, err
+		}
+		resp, err := c.executeSearch(ctx, streamType, queryJSON)
+		if err != nil {
+			return StreamStats{}, err
+		}
+		if len(resp.Hits) == 0 {
+			continue
+		}
+		hit := resp.Hits[0]
+		rows, _ := hit["total"].(float64)
+		stats.Rows = int(rows)
+		for i := range batch {
+			field := &stats.Fields[offset+i]
+			nonNull, _ := hit[fmt.Sprintf("c%d", i)].(float64)
+			distinct, _ := hit[fmt.Sprintf("d%d", i)].(float64)
+			field.Cardinality = int(distinct)
+			if rows > 0 {
+				field.NullRatio = 1 - nonNull/rows
+			}
+		}
+	}
+
+	if stats.Rows == 0 {
+		return stats, nil
+	}
+	queryJSON, err := json.Marshal(map[string]interface{}{
+		"query": map[string]interface{}{
+			"sql":        "SELECT * FROM " + quoteIdentifier(stream) + " ORDER BY _timestamp DESC",
+			"start_time": start.UnixMicro(),
+			"end_time":   end.UnixMicro(),
+			"from":       0,
+			"size":       statsSampleRows,
+		},
+	})
+	if err != nil {
+		return StreamStats{}, err
+	}
+	resp, err := c.executeSearch(ctx, streamType, queryJSON)
+	if err != nil {
+		return StreamStats{}, fmt.Errorf("failed to sample rows: %w", err)
+	}
+	for i := range stats.Fields {
+		field := &stats.Fields[i]
+		for _, hit := range resp.Hits {
+			v, ok := hit[field.Name]
+			if !ok || v == nil {
+				continue
+			}
+			sample := truncateSample(aggregateValue(v))
+			if !slices.Contains(field.Samples, sample) {
+				field.Samples = append(field.Samples, sample)
+			}
+			if len(field.Samples) == statsMaxSamples {
+				break
+			}
+		}
+	}
+	return stats, nil
+}
+
+// generateFieldStatsQuery generates a query counting the rows of stream and,
+// for each field, its values (c0, c1, ...) and approximate distinct values
+// (d0, d1, ...).
+func generateFieldStatsQuery(fields []schemaField, stream string, start, end time.Time) ([]byte, error) {
+	selects := make([]string, 0, 2*len(fields)+1)
+	selects = append(selects, "count(*) AS total")
+	for i, field := range fields {
+		column := quoteIdentifier(field.Name)
+		selects = append(selects,
+			fmt.Sprintf("count(%s) AS c%d", column, i),
+			fmt.Sprintf("approx_distinct(%s) AS d%d", column, i),
+		)
+	}
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"sql":        "SELECT " + strings.Join(selects, ", ") + " FROM " + quoteIdentifier(stream),
+			"start_time": start.UnixMicro(),
+			"end_time":   end.UnixMicro(),
+			"from":       0,
+			"size":       1,
+		},
+	}
+	return json.Marshal(query)
+}
+
+// truncateSample shortens a sample value to statsMaxSampleLength bytes,
+// without splitting a UTF-8 sequence.
+func truncateSample(s string) string {
+	if len(s) <= statsMaxSampleLength {
+		return s
+	}
+	cut := statsMaxSampleLength
+	for cut > 0 && s[cut]&0xC0 == 0x80 {
+		cut--
+	}
+	return s[:cut] + "…"
+}
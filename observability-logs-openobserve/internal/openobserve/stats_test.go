@@ -0,0 +1,113 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerateFieldStatsQuery(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	raw, err := generateFieldStatsQuery([]schemaField{{Name: "log"}, {Name: `we"ird`}}, "default", start, start.Add(statsWindow))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sql, q := sqlOf(t, raw)
+	want := `SELECT count(*) AS total, count("log") AS c0, approx_distinct("log") AS d0, ` +
+		`count("we""ird") AS c1, approx_distinct("we""ird") AS d1 FROM "default"`
+	if sql != want {
+		t.Errorf("unexpected sql:\n got %s\nwant %s", sql, want)
+	}
+	if q["start_time"] != float64(start.UnixMicro()) {
+		t.Errorf("unexpected start_time: %v", q["start_time"])
+	}
+}
+
+func TestGetStreamStats(t *testing.T) {
+	var searches int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/default/schema"):
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"schema": []map[string]string{{"name": "log", "type": "Utf8"}, {"name": "logLevel", "type": "Utf8"}},
+			})
+		case strings.HasSuffix(r.URL.Path, "/schema"):
+			http.Error(w, `{"code":404,"message":"stream not found"}`, http.StatusNotFound)
+		default:
+			searches++
+			var body struct {
+				Query struct {
+					SQL string `json:"sql"`
+				} `json:"query"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			resp := OpenObserveResponse{}
+			if strings.Contains(body.Query.SQL, "approx_distinct") {
+				resp.Hits = []map[string]interface{}{{
+					"total": float64(200),
+					"c0":    float64(200), "d0": float64(180),
+					"c1": float64(50), "d1": float64(2),
+				}}
+			} else {
+				resp.Hits = []map[string]interface{}{
+					{"log": strings.Repeat("é", statsMaxSampleLength), "logLevel": "INFO"},
+					{"log": "b", "logLevel": "INFO"},
+					{"log": "c"},
+					{"log": "d", "logLevel": "ERROR"},
+				}
+			}
+			json.NewEncoder(w).Encode(resp)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	result, err := client.GetStreamStats(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Streams) != 2 {
+		t.Fatalf("expected the logs and events streams, got %+v", result.Streams)
+	}
+	logs := result.Streams[0]
+	if logs.Stream != "default" || logs.Rows != 200 || len(logs.Fields) != 2 || logs.Error != "" {
+		t.Fatalf("unexpected logs stream stats: %+v", logs)
+	}
+	if f := logs.Fields[0]; f.Name != "log" || f.Type != "Utf8" || f.Cardinality != 180 || f.NullRatio != 0 ||
+		len(f.Samples) != 3 || !strings.HasSuffix(f.Samples[0], "…") || len(f.Samples[0]) > statsMaxSampleLength+len("…") {
+		t.Errorf("unexpected log field stats: %+v", f)
+	}
+	if f := logs.Fields[1]; f.Cardinality != 2 || f.NullRatio != 0.75 || len(f.Samples) != 2 || f.Samples[1] != "ERROR" {
+		t.Errorf("unexpected logLevel field stats: %+v", f)
+	}
+	if events := result.Streams[1]; events.Stream != "k8s_events" || events.Error == "" {
+		t.Errorf("expected an error for the events stream, got %+v", events)
+	}
+
+	// Failed streams are not cached.
+	if _, err := client.GetStreamStats(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if searches != 4 {
+		t.Errorf("expected statistics to be recomputed after a failure, got %d searches", searches)
+	}
+
+	client.eventsStream = ""
+	client.stats.result = nil
+	if _, err := client.GetStreamStats(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.GetStreamStats(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if searches != 6 {
+		t.Errorf("expected cached statistics to be reused, got %d searches", searches)
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	ooclient "github.com/openchoreo/community-modules/common/openobserve"
+)
+
+// StreamRoutes route the logs queries of namespaces, projects or
+// environments to streams. See ParseStreamRoutes.
+type StreamRoutes = ooclient.StreamRoutes
+
+// ParseStreamRoutes parses a comma-separated list of stream routes, such as
+// "namespace:acme=acme_logs,environment:<uid>=prod_logs|default".
+var ParseStreamRoutes = ooclient.ParseStreamRoutes
+
+// SetStreamRoutes routes the component and workflow logs queries of the
+// scopes selected by routes, for example those of per-environment or
+// per-data-plane streams, to their streams. Scopes routed to several
+// streams, or only partly routed, are queried in each stream, stream
+// included, and their results merged.
+func (c *Client) SetStreamRoutes(routes StreamRoutes) {
+	c.routes = routes
+}
+
+// componentStreams returns the streams holding the logs of a component
+// logs query.
+func (c *Client) componentStreams(params ComponentLogsParams) []string {
+	return c.routes.Streams(ooclient.StreamScope{
+		Namespace:      params.Namespace,
+		ProjectID:      params.ProjectID,
+		EnvironmentIDs: params.environmentIDs(),
+	}, c.stream)
+}
+
+// workflowSources returns the sources holding the logs of a workflow logs
+// query: the streams of the build planes when they are set, and the routed
+// streams otherwise. Workflow runs belong to no project or environment, so
+// only the routes of their whole namespace cover them.
+func (c *Client) workflowSources(params WorkflowLogsParams) []workflowSource {
+	if len(c.buildPlanes) > 0 {
+		sources := make([]workflowSource, len(c.buildPlanes))
+		for i, p := range c.buildPlanes {
+			sources[i] = workflowSource{cluster: p.Cluster, org: cmp.Or(p.Org, c.Org()), stream: p.Stream}
+		}
+		return sources
+	}
+	streams := c.routes.Streams(ooclient.StreamScope{Namespace: params.Namespace}, c.stream)
+	sources := make([]workflowSource, len(streams))
+	for i, stream := range streams {
+		sources[i] = workflowSource{org: c.Org(), stream: stream}
+	}
+	return sources
+}
+
+// streamQueryParams returns the limit and offset of the query of each
+// stream of a query across streams: the entries of the page can be anywhere
+// in each of them, so each is read from the start to the end of the page.
+func streamQueryParams(limit, offset int) (int, int) {
+	return max(offset, 0) + limit, 0
+}
+
+// getComponentLogsAcross runs a component logs query against each of
+// streams in parallel, and merges their pages into the page of the query,
+// ordered by its sort field and order. The total is the sum of theirs.
+// Streams whose searches run out of the budget of the query leave the
+// result partial.
+func (c *Client) getComponentLogsAcross(ctx context.Context, params ComponentLogsParams, streams []string) (*ComponentLogsResult, error) {
+	perStream := params
+	perStream.Limit, perStream.Offset = streamQueryParams(params.Limit, params.Offset)
+	results := make([]*ComponentLogsResult, len(streams))
+	err := queryEach(ctx, len(streams), func(ctx context.Context, i int) error {
+		var err error
+		results[i], err = c.getComponentLogs(ctx, perStream, streams[i])
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	merged := &ComponentLogsResult{Logs: []ComponentLogsEntry{}}
+	for _, r := range results {
+		merged.Logs = append(merged.Logs, r.Logs...)
+		merged.TotalCount += r.TotalCount
+		merged.Took = max(merged.Took, r.Took)
+		merged.ParseErrors = mergeParseErrors(merged.ParseErrors, r.ParseErrors)
+		merged.Merge(r.PartialResult)
+	}
+	sortByTime(merged.Logs, params.SortField, params.SortOrder, func(e ComponentLogsEntry) (time.Time, time.Time) {
+		return e.EventTime, e.IngestTime
+	})
+	merged.Logs = page(merged.Logs, params.Offset, params.Limit)
+	return merged, nil
+}
+
+// getWorkflowLogsAcross is getComponentLogsAcross for workflow logs queries,
+// run against sources.
+func (c *Client) getWorkflowLogsAcross(ctx context.Context, params WorkflowLogsParams, sources []workflowSource) (*WorkflowLogsResult, error) {
+	perStream := params
+	perStream.Limit, perStream.Offset = streamQueryParams(params.Limit, params.Offset)
+	results := make([]*WorkflowLogsResult, len(sources))
+	err := queryEach(ctx, len(sources), func(ctx context.Context, i int) error {
+		var err error
+		results[i], err = c.getWorkflowLogs(ctx, perStream, sources[i])
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	merged := &WorkflowLogsResult{Logs: []WorkflowLogsEntry{}}
+	for _, r := range results {
+		merged.Logs = append(merged.Logs, r.Logs...)
+		merged.TotalCount += r.TotalCount
+		merged.Took = max(merged.Took, r.Took)
+		merged.ParseErrors = mergeParseErrors(merged.ParseErrors, r.ParseErrors)
+		merged.Merge(r.PartialResult)
+	}
+	sortByTime(merged.Logs, params.SortField, params.SortOrder, func(e WorkflowLogsEntry) (time.Time, time.Time) {
+		return e.EventTime, e.IngestTime
+	})
+	merged.Logs = page(merged.Logs, params.Offset, params.Limit)
+	return merged, nil
+}
+
+// queryEach calls query for 0 to n-1 in parallel, and returns the first
+// error. The queries still running are canceled once one fails, so the
+// first error is the failure rather than a cancellation it caused.
+func queryEach(ctx context.Context, n int, query func(ctx context.Context, i int) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		wg    sync.WaitGroup
+		once  sync.Once
+		first error
+	)
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := query(ctx, i); err != nil {
+				once.Do(func() {
+					first = err
+					cancel()
+				})
+			}
+		}()
+	}
+	wg.Wait()
+	return first
+}
+
+// sortByTime orders entries by the sort field and order of a logs query.
+// The sort is stable, so entries sharing a time keep the order of their
+// stream.
+func sortByTime[E any](entries []E, sortField, sortOrder string, times func(E) (eventTime, ingestTime time.Time)) {
+	key := func(e E) time.Time {
+		eventTime, ingestTime := times(e)
+		if sortField == SortFieldEventTime {
+			return eventTime
+		}
+		return ingestTime
+	}
+	asc := sortOrder == "ASC" || sortOrder == "asc"
+	slices.SortStableFunc(entries, func(a, b E) int {
+		if asc {
+			return key(a).Compare(key(b))
+		}
+		return key(b).Compare(key(a))
+	})
+}
+
+// page returns the entries of the page at offset of size limit.
+func page[E any](entries []E, offset, limit int) []E {
+	offset = min(max(offset, 0), len(entries))
+	return entries[offset:min(offset+limit, len(entries))]
+}
+
+// mergeParseErrors adds the malformed hits of b to a.
+func mergeParseErrors(a, b *ParseErrors) *ParseErrors {
+	if b == nil {
+		return a
+	}
+	if a == nil {
+		a = &ParseErrors{Fields: map[string]int{}}
+	}
+	a.MalformedRows += b.MalformedRows
+	for field, n := range b.Fields {
+		a.Fields[field] += n
+	}
+	return a
+}
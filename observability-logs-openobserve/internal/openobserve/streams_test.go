@@ -0,0 +1,149 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// newStreamsServer returns an OpenObserve server answering log queries with
+// the hits of the stream they read, given as timestamps in microseconds,
+// and records the size of the queries of each stream.
+func newStreamsServer(t *testing.T, hits map[string][]float64) (*httptest.Server, map[string]int) {
+	t.Helper()
+	var mu sync.Mutex
+	sizes := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		sql, q := sqlOf(t, body)
+		var stream string
+		for name := range hits {
+			if strings.Contains(sql, `FROM "`+name+`"`) {
+				stream = name
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(sql, "count(*) as total") {
+			_ = json.NewEncoder(w).Encode(OpenObserveResponse{Hits: []map[string]interface{}{{"total": float64(len(hits[stream]))}}})
+			return
+		}
+		mu.Lock()
+		size, _ := q["size"].(float64)
+		sizes[stream] = int(size)
+		mu.Unlock()
+		resp := OpenObserveResponse{Hits: []map[string]interface{}{}, Took: len(stream)}
+		for _, ts := range hits[stream] {
+			resp.Hits = append(resp.Hits, map[string]interface{}{"_timestamp": ts, "log": stream})
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+	return server, sizes
+}
+
+func TestGetComponentLogs_AcrossStreams(t *testing.T) {
+	server, sizes := newStreamsServer(t, map[string][]float64{
+		"default":   {5e6, 2e6},
+		"prod_logs": {6e6, 4e6, 1e6},
+	})
+	routes, err := ParseStreamRoutes("environment:prod=prod_logs")
+	if err != nil {
+		t.Fatalf("ParseStreamRoutes() error = %v", err)
+	}
+	client := newTestClient(server.URL)
+	client.SetStreamRoutes(routes)
+
+	result, err := client.GetComponentLogs(context.Background(), ComponentLogsParams{
+		Namespace:      "acme",
+		EnvironmentIDs: []string{"prod", "dev"},
+		Limit:          2,
+		Offset:         1,
+	})
+	if err != nil {
+		t.Fatalf("GetComponentLogs() error = %v", err)
+	}
+	if result.TotalCount != 5 {
+		t.Errorf("expected the total of both streams, got %d", result.TotalCount)
+	}
+	if len(result.Logs) != 2 || result.Logs[0].Log != "default" || result.Logs[1].Log != "prod_logs" ||
+		result.Logs[0].IngestTime.UnixMicro() != 5e6 || result.Logs[1].IngestTime.UnixMicro() != 4e6 {
+		t.Errorf("unexpected merged page: %+v", result.Logs)
+	}
+	if sizes["default"] != 3 || sizes["prod_logs"] != 3 {
+		t.Errorf("expected each stream to be read up to the end of the page, got sizes %v", sizes)
+	}
+	if result.Took != len("prod_logs") {
+		t.Errorf("expected the time of the slowest stream, got %d", result.Took)
+	}
+
+	result, err = client.GetComponentLogs(context.Background(), ComponentLogsParams{
+		Namespace:     "acme",
+		EnvironmentID: "prod",
+		Limit:         10,
+		SortOrder:     "asc",
+	})
+	if err != nil {
+		t.Fatalf("GetComponentLogs() error = %v", err)
+	}
+	if len(result.Logs) != 3 || result.Logs[0].Log != "prod_logs" {
+		t.Errorf("expected the covered scope to read prod_logs only, got %+v", result.Logs)
+	}
+}
+
+func TestGetWorkflowLogs_AcrossStreams(t *testing.T) {
+	server, _ := newStreamsServer(t, map[string][]float64{
+		"ci_east": {3e6, 1e6},
+		"ci_west": {2e6},
+	})
+	routes, err := ParseStreamRoutes("namespace:acme=ci_east|ci_west")
+	if err != nil {
+		t.Fatalf("ParseStreamRoutes() error = %v", err)
+	}
+	client := newTestClient(server.URL)
+	client.SetStreamRoutes(routes)
+
+	result, err := client.GetWorkflowLogs(context.Background(), WorkflowLogsParams{
+		Namespace:       "acme",
+		WorkflowRunName: "build-1",
+		Limit:           10,
+		SortOrder:       "ASC",
+	})
+	if err != nil {
+		t.Fatalf("GetWorkflowLogs() error = %v", err)
+	}
+	var got []string
+	for _, e := range result.Logs {
+		got = append(got, e.Log)
+	}
+	if strings.Join(got, ",") != "ci_east,ci_west,ci_east" || result.TotalCount != 3 {
+		t.Errorf("unexpected merged logs %v with total %d", got, result.TotalCount)
+	}
+}
+
+func TestGetComponentLogs_AcrossStreamsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if sql, _ := sqlOf(t, body); strings.Contains(sql, `FROM "broken"`) {
+			http.Error(w, "boom", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(OpenObserveResponse{Hits: []map[string]interface{}{}})
+	}))
+	defer server.Close()
+	routes, _ := ParseStreamRoutes("namespace:acme=broken|default")
+	client := newTestClient(server.URL)
+	client.SetStreamRoutes(routes)
+
+	if _, err := client.GetComponentLogs(context.Background(), ComponentLogsParams{Namespace: "acme", Limit: 10}); err == nil {
+		t.Error("expected the failure of one stream to fail the query")
+	}
+}
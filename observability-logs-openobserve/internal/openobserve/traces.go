@@ -0,0 +1,146 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// maxTraceSpans caps the number of spans returned by a trace spans query.
+const maxTraceSpans = 10000
+
+// ErrNoTracesStream is returned for trace queries when no traces stream is set.
+var ErrNoTracesStream = errors.New("no traces stream is configured")
+
+// TraceSpansParams holds parameters for listing the spans of a component
+// scope, as written by the OpenTelemetry collector of the tracing module.
+type TraceSpansParams struct {
+	Namespace     string    `json:"namespace"`
+	ProjectID     string    `json:"projectId,omitempty"`
+	EnvironmentID string    `json:"environmentId,omitempty"`
+	ComponentID   string    `json:"componentId,omitempty"`
+	StartTime     time.Time `json:"startTime"`
+	EndTime       time.Time `json:"endTime"`
+	Limit         int       `json:"limit"`
+}
+
+// TraceSpan is a span of the traces stream.
+type TraceSpan struct {
+	TraceID       string    `json:"traceId"`
+	SpanID        string    `json:"spanId"`
+	ParentSpanID  string    `json:"parentSpanId,omitempty"`
+	Name          string    `json:"name"`
+	Kind          string    `json:"kind,omitempty"`
+	ServiceName   string    `json:"serviceName,omitempty"`
+	Status        string    `json:"status,omitempty"`
+	StatusMessage string    `json:"statusMessage,omitempty"`
+	StartTime     time.Time `json:"startTime"`
+	EndTime       time.Time `json:"endTime"`
+	DurationNs    int64     `json:"durationNs"`
+}
+
+// TraceSpansResult represents the result of a trace spans query.
+type TraceSpansResult struct {
+	Spans []TraceSpan `json:"spans"`
+	// Truncated is set when the scope had more spans than the limit.
+	Truncated bool `json:"truncated"`
+	Took      int  `json:"tookMs"`
+}
+
+// SetTracesStream sets the traces stream searched by GetTraceSpans. Trace
+// queries fail with ErrNoTracesStream while it is unset.
+func (c *Client) SetTracesStream(stream string) {
+	c.tracesStream = stream
+}
+
+// generateTraceSpansQuery generates the OpenObserve query listing the spans
+// of a component scope, newest first.
+func generateTraceSpansQuery(params TraceSpansParams, stream string, logger *slog.Logger) ([]byte, error) {
+	if params.Namespace == "" {
+		return nil, fmt.Errorf("namespace is required for trace span queries")
+	}
+	conditions := []string{"service_openchoreo_dev_namespace = '" + escapeSQLString(params.Namespace) + "'"}
+	if params.ProjectID != "" {
+		conditions = append(conditions, "service_openchoreo_dev_project_uid = '"+escapeSQLString(params.ProjectID)+"'")
+	}
+	if params.EnvironmentID != "" {
+		conditions = append(conditions, "service_openchoreo_dev_environment_uid = '"+escapeSQLString(params.EnvironmentID)+"'")
+	}
+	if params.ComponentID != "" {
+		conditions = append(conditions, "service_openchoreo_dev_component_uid = '"+escapeSQLString(params.ComponentID)+"'")
+	}
+
+	// Start and end times are in nanoseconds, which float64 cannot hold
+	// exactly, so spans are timed by their microsecond _timestamp and the
+	// duration computed by OpenObserve.
+	sql := "SELECT _timestamp, trace_id, span_id, reference_parent_span_id, operation_name, span_kind, service_name, " +
+		"span_status, status_message, end_time - start_time AS duration FROM " + quoteIdentifier(stream) +
+		" WHERE " + strings.Join(conditions, " AND ") +
+		" ORDER BY start_time DESC"
+
+	limit := params.Limit
+	if limit <= 0 || limit > maxTraceSpans {
+		limit = maxTraceSpans
+	}
+
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"sql":        sql,
+			"start_time": params.StartTime.UnixMicro(),
+			"end_time":   params.EndTime.UnixMicro(),
+			"from":       0,
+			"size":       limit,
+		},
+	}
+
+	logGenerated(logger, query, "query to list trace spans")
+
+	return json.Marshal(query)
+}
+
+// GetTraceSpans lists the spans of a component scope from the traces stream.
+func (c *Client) GetTraceSpans(ctx context.Context, params TraceSpansParams) (*TraceSpansResult, error) {
+	if c.tracesStream == "" {
+		return nil, ErrNoTracesStream
+	}
+	queryJSON, err := generateTraceSpansQuery(params, c.tracesStream, c.logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate trace spans query: %w", err)
+	}
+	resp, err := c.executeSearch(ctx, "traces", queryJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &TraceSpansResult{Spans: make([]TraceSpan, 0, len(resp.Hits)), Took: resp.Took}
+	limit := params.Limit
+	if limit <= 0 || limit > maxTraceSpans {
+		limit = maxTraceSpans
+	}
+	result.Truncated = len(resp.Hits) >= limit
+	for _, hit := range resp.Hits {
+		span := TraceSpan{}
+		span.TraceID, _ = hit["trace_id"].(string)
+		span.SpanID, _ = hit["span_id"].(string)
+		span.ParentSpanID, _ = hit["reference_parent_span_id"].(string)
+		span.Name, _ = hit["operation_name"].(string)
+		span.Kind, _ = hit["span_kind"].(string)
+		span.ServiceName, _ = hit["service_name"].(string)
+		span.Status, _ = hit["span_status"].(string)
+		span.StatusMessage, _ = hit["status_message"].(string)
+		timestamp, _ := hit["_timestamp"].(float64)
+		duration, _ := hit["duration"].(float64)
+		span.StartTime = time.UnixMicro(int64(timestamp)).UTC()
+		span.DurationNs = int64(duration)
+		span.EndTime = span.StartTime.Add(time.Duration(span.DurationNs))
+		result.Spans = append(result.Spans, span)
+	}
+	return result, nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerateTraceSpansQuery(t *testing.T) {
+	params := TraceSpansParams{
+		Namespace:   "ns",
+		ComponentID: "comp-1",
+		StartTime:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndTime:     time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+	raw, err := generateTraceSpansQuery(params, "traces", testLogger())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sql, q := sqlOf(t, raw)
+	for _, want := range []string{
+		`FROM "traces"`,
+		"service_openchoreo_dev_namespace = 'ns'",
+		"service_openchoreo_dev_component_uid = 'comp-1'",
+		"ORDER BY start_time DESC",
+	} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("expected %q in %s", want, sql)
+		}
+	}
+	if strings.Contains(sql, "environment_uid") {
+		t.Errorf("unexpected environment condition in %s", sql)
+	}
+	if q["size"] != float64(maxTraceSpans) {
+		t.Errorf("expected size %d, got %v", maxTraceSpans, q["size"])
+	}
+
+	if _, err := generateTraceSpansQuery(TraceSpansParams{}, "traces", testLogger()); err == nil {
+		t.Error("expected error without a namespace")
+	}
+}
+
+func TestGetTraceSpans(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("type") != "traces" {
+			t.Errorf("expected a traces search, got type=%q", r.URL.Query().Get("type"))
+		}
+		json.NewEncoder(w).Encode(OpenObserveResponse{
+			Took: 4,
+			Hits: []map[string]interface{}{{
+				"trace_id":       "trace-1",
+				"span_id":        "span-1",
+				"operation_name": "GET /orders",
+				"span_kind":      "SERVER",
+				"span_status":    "ERROR",
+				"_timestamp":     float64(start.UnixMicro()),
+				"duration":       float64(250 * time.Millisecond),
+			}},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	if _, err := client.GetTraceSpans(context.Background(), TraceSpansParams{Namespace: "ns"}); !errors.Is(err, ErrNoTracesStream) {
+		t.Fatalf("expected ErrNoTracesStream, got %v", err)
+	}
+
+	client.SetTracesStream("default")
+	result, err := client.GetTraceSpans(context.Background(), TraceSpansParams{Namespace: "ns", StartTime: start, EndTime: start.Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Spans) != 1 || result.Took != 4 || result.Truncated {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	span := result.Spans[0]
+	if span.TraceID != "trace-1" || span.Name != "GET /orders" || span.Status != "ERROR" ||
+		span.DurationNs != int64(250*time.Millisecond) || !span.StartTime.Equal(start) {
+		t.Errorf("unexpected span: %+v", span)
+	}
+}
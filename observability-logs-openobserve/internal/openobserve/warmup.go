@@ -0,0 +1,131 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// GetStreamSchema returns the names of the fields of stream, of the given
+// type ("logs" or "traces"). Fetching it also loads the schema into the
+// caches of OpenObserve.
+func (c *Client) GetStreamSchema(ctx context.Context, streamType, stream string) ([]string, error) {
+	schema, err := c.streamSchema(ctx, streamType, stream)
+	if err != nil {
+		return nil, err
+	}
+	fields := make([]string, 0, len(schema))
+	for _, field := range schema {
+		fields = append(fields, field.Name)
+	}
+	return fields, nil
+}
+
+// schemaField is a field of a stream schema, with its Arrow data type.
+type schemaField struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// streamSchema returns the fields of stream, of the given type.
+func (c *Client) streamSchema(ctx context.Context, streamType, stream string) ([]schemaField, error) {
+	var result struct {
+		Schema []schemaField `json:"schema"`
+	}
+	if err := c.getStreamSchema(ctx, c.Org(), streamType, stream, &result); err != nil {
+		return nil, err
+	}
+	return result.Schema, nil
+}
+
+// getStreamSchema decodes the schema and settings of stream of org, of the
+// given type, into v.
+func (c *Client) getStreamSchema(ctx context.Context, org, streamType, stream string, v any) error {
+	url := fmt.Sprintf("%s/api/%s/streams/%s/schema?type=%s", c.BaseURL(), org, stream, streamType)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("openobserve returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return nil
+}
+
+// WarmSchemas fetches the schemas of the logs, events and traces streams of
+// the client, returning the number of fields found.
+func (c *Client) WarmSchemas(ctx context.Context) (int, error) {
+	streams := []struct{ streamType, name string }{
+		{"logs", c.stream},
+		{"logs", c.eventsStream},
+		{"traces", c.tracesStream},
+	}
+	var fields int
+	var errs []error
+	for _, s := range streams {
+		if s.name == "" {
+			continue
+		}
+		names, err := c.GetStreamSchema(ctx, s.streamType, s.name)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("stream %q: %w", s.name, err))
+			continue
+		}
+		fields += len(names)
+	}
+	return fields, errors.Join(errs...)
+}
+
+// WarmConnections opens n connections to OpenObserve in parallel, including
+// their TLS handshakes, so that the first queries reuse them. Connections
+// beyond the idle limit of the client's transport are closed again.
+func (c *Client) WarmConnections(ctx context.Context, n int) error {
+	url := c.BaseURL() + "/healthz"
+	var wg sync.WaitGroup
+	errs := make([]error, n)
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+			if err != nil {
+				errs[i] = fmt.Errorf("failed to create request: %w", err)
+				return
+			}
+			resp, err := c.Do(req)
+			if err != nil {
+				errs[i] = fmt.Errorf("failed to execute request: %w", err)
+				return
+			}
+			// The body is drained so that the connection returns to the pool.
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
@@ -0,0 +1,68 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestWarmSchemas(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path+"?"+r.URL.RawQuery)
+		if strings.Contains(r.URL.Path, "k8s_events") {
+			http.Error(w, "stream not found", http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"name":"default","schema":[{"name":"_timestamp","type":"Int64"},{"name":"log","type":"Utf8"}]}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	client.SetTracesStream("traces")
+	fields, err := client.WarmSchemas(context.Background())
+	if err == nil || !strings.Contains(err.Error(), `stream "k8s_events"`) {
+		t.Errorf("expected an error for the events stream, got %v", err)
+	}
+	if fields != 4 {
+		t.Errorf("expected 4 fields, got %d", fields)
+	}
+	want := []string{
+		"/api/default/streams/default/schema?type=logs",
+		"/api/default/streams/k8s_events/schema?type=logs",
+		"/api/default/streams/traces/schema?type=traces",
+	}
+	if strings.Join(requests, " ") != strings.Join(want, " ") {
+		t.Errorf("unexpected requests: %v", requests)
+	}
+}
+
+func TestWarmConnections(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/healthz" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		requests.Add(1)
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer server.Close()
+
+	if err := newTestClient(server.URL).WarmConnections(context.Background(), 3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requests.Load() != 3 {
+		t.Errorf("expected 3 requests, got %d", requests.Load())
+	}
+
+	server.Close()
+	if err := newTestClient(server.URL).WarmConnections(context.Background(), 1); err == nil {
+		t.Error("expected an error when OpenObserve is down")
+	}
+}
@@ -0,0 +1,348 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// maxWorkflowSteps bounds the number of workflow nodes returned by a workflow summary.
+	maxWorkflowSteps = 1000
+	// workflowNodeNameField is the column of the workflows.argoproj.io/node-name pod annotation.
+	workflowNodeNameField = "kubernetes_annotations_workflows_argoproj_io_node_name"
+	// workflowErrorLevels is the SQL list of the log levels counted as errors.
+	workflowErrorLevels = "('ERROR', 'FATAL')"
+)
+
+// retrySuffix matches the "(n)" suffix Argo appends to the node names of retried steps.
+var retrySuffix = regexp.MustCompile(`\(\d+\)$`)
+
+// WorkflowSummaryParams holds parameters for summarizing the logs of a workflow run.
+type WorkflowSummaryParams struct {
+	Namespace       string    `json:"namespace"`
+	WorkflowRunName string    `json:"workflowRunName"`
+	StartTime       time.Time `json:"startTime"`
+	EndTime         time.Time `json:"endTime"`
+}
+
+// WorkflowStepSummary summarizes the logs of one workflow node, a step pod.
+// Durations are inferred from the first and last log line of the node.
+type WorkflowStepSummary struct {
+	StepName       string     `json:"stepName"`
+	NodeName       string     `json:"nodeName"`
+	LogCount       int        `json:"logCount"`
+	ErrorCount     int        `json:"errorCount"`
+	FirstError     string     `json:"firstError,omitempty"`
+	FirstErrorTime *time.Time `json:"firstErrorTime,omitempty"`
+	StartTime      time.Time  `json:"startTime"`
+	EndTime        time.Time  `json:"endTime"`
+	DurationMs     int64      `json:"durationMs"`
+}
+
+// WorkflowSummaryResult represents the result of a workflow summary query.
+// Steps are ordered by the time of their first log line.
+type WorkflowSummaryResult struct {
+	WorkflowRunName string                `json:"workflowRunName"`
+	Steps           []WorkflowStepSummary `json:"steps"`
+	LogCount        int                   `json:"logCount"`
+	ErrorCount      int                   `json:"errorCount"`
+	StartTime       *time.Time            `json:"startTime,omitempty"`
+	EndTime         *time.Time            `json:"endTime,omitempty"`
+	DurationMs      int64                 `json:"durationMs"`
+	Took            int                   `json:"tookMs"`
+}
+
+// workflowRunConditions returns the conditions selecting the logs of a workflow run.
+func workflowRunConditions(namespace, workflowRunName string) []string {
+	return []string{
+		"kubernetes_namespace_name = 'workflows-" + escapeSQLString(namespace) + "'",
+		"kubernetes_labels_workflows_argoproj_io_workflow = '" + escapeSQLString(workflowRunName) + "'",
+	}
+}
+
+// generateWorkflowSummaryQuery generates an aggregation query counting the log
+// lines and error lines of each node of a workflow run, with the time of its
+// first and last line.
+func generateWorkflowSummaryQuery(params WorkflowSummaryParams, stream string, logger *slog.Logger) ([]byte, error) {
+	if params.Namespace == "" {
+		return nil, fmt.Errorf("namespace is required for workflow summary queries")
+	}
+	if params.WorkflowRunName == "" {
+		return nil, fmt.Errorf("workflowRunName is required for workflow summary queries")
+	}
+
+	sql := "SELECT " + workflowNodeNameField + " AS node_name, count(*) AS log_count, " +
+		"sum(CASE WHEN logLevel IN " + workflowErrorLevels + " THEN 1 ELSE 0 END) AS error_count, " +
+		"min(_timestamp) AS first_seen, max(_timestamp) AS last_seen FROM " + quoteIdentifier(stream) +
+		" WHERE " + strings.Join(workflowRunConditions(params.Namespace, params.WorkflowRunName), " AND ") +
+		" GROUP BY node_name ORDER BY first_seen ASC"
+
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"sql":        sql,
+			"start_time": params.StartTime.UnixMicro(),
+			"end_time":   params.EndTime.UnixMicro(),
+			"from":       0,
+			"size":       maxWorkflowSteps,
+		},
+	}
+
+	logGenerated(logger, query, "workflow summary query")
+
+	return json.Marshal(query)
+}
+
+// generateWorkflowFirstErrorQuery generates a query for the first error line
+// of a single node of a workflow run.
+func generateWorkflowFirstErrorQuery(params WorkflowSummaryParams, nodeName string, stream string, logger *slog.Logger) ([]byte, error) {
+	conditions := workflowRunConditions(params.Namespace, params.WorkflowRunName)
+	if nodeName == "" {
+		conditions = append(conditions, workflowNodeNameField+" IS NULL")
+	} else {
+		conditions = append(conditions, workflowNodeNameField+" = '"+escapeSQLString(nodeName)+"'")
+	}
+	conditions = append(conditions, "logLevel IN "+workflowErrorLevels)
+
+	sql := "SELECT _timestamp, log FROM " + quoteIdentifier(stream) +
+		" WHERE " + strings.Join(conditions, " AND ") +
+		" ORDER BY _timestamp ASC"
+
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"sql":        sql,
+			"start_time": params.StartTime.UnixMicro(),
+			"end_time":   params.EndTime.UnixMicro(),
+			"from":       0,
+			"size":       1,
+		},
+	}
+
+	logGenerated(logger, query, "workflow first error query")
+
+	return json.Marshal(query)
+}
+
+// workflowStepName returns the step name of an Argo node name such as
+// "run-1.build", "run-1[0].test" or "run-1.build(1)".
+func workflowStepName(nodeName string) string {
+	name := retrySuffix.ReplaceAllString(nodeName, "")
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
+
+// GetWorkflowSummary summarizes the logs of a workflow run per node: the
+// number of log and error lines, the first error line and the duration
+// between the first and last line. The first error of each failing node is
+// fetched with a separate query.
+func (c *Client) GetWorkflowSummary(ctx context.Context, params WorkflowSummaryParams) (*WorkflowSummaryResult, error) {
+	queryJSON, err := generateWorkflowSummaryQuery(params, c.stream, c.logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate workflow summary query: %w", err)
+	}
+
+	resp, err := c.executeSearchQuery(ctx, queryJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &WorkflowSummaryResult{
+		WorkflowRunName: params.WorkflowRunName,
+		Steps:           make([]WorkflowStepSummary, 0, len(resp.Hits)),
+		Took:            resp.Took,
+	}
+	for _, hit := range resp.Hits {
+		nodeName := stringField(hit, "node_name")
+		step := WorkflowStepSummary{
+			StepName: workflowStepName(nodeName),
+			NodeName: nodeName,
+		}
+		if v, ok := hit["log_count"].(float64); ok {
+			step.LogCount = int(v)
+		}
+		if v, ok := hit["error_count"].(float64); ok {
+			step.ErrorCount = int(v)
+		}
+		if v, ok := hit["first_seen"].(float64); ok {
+			step.StartTime = time.UnixMicro(int64(v))
+		}
+		if v, ok := hit["last_seen"].(float64); ok {
+			step.EndTime = time.UnixMicro(int64(v))
+		}
+		step.DurationMs = step.EndTime.Sub(step.StartTime).Milliseconds()
+
+		if step.ErrorCount > 0 {
+			queryJSON, err := generateWorkflowFirstErrorQuery(params, nodeName, c.stream, c.logger)
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate workflow first error query: %w", err)
+			}
+			errResp, err := c.executeSearchQuery(ctx, queryJSON)
+			if err != nil {
+				return nil, err
+			}
+			result.Took += errResp.Took
+			if len(errResp.Hits) > 0 {
+				step.FirstError = stringField(errResp.Hits[0], "log")
+				if v, ok := errResp.Hits[0]["_timestamp"].(float64); ok {
+					firstErrorTime := time.UnixMicro(int64(v))
+					step.FirstErrorTime = &firstErrorTime
+				}
+			}
+		}
+
+		result.LogCount += step.LogCount
+		result.ErrorCount += step.ErrorCount
+		if result.StartTime == nil || step.StartTime.Before(*result.StartTime) {
+			startTime := step.StartTime
+			result.StartTime = &startTime
+		}
+		if result.EndTime == nil || step.EndTime.After(*result.EndTime) {
+			endTime := step.EndTime
+			result.EndTime = &endTime
+		}
+		result.Steps = append(result.Steps, step)
+	}
+
+	sort.SliceStable(result.Steps, func(i, j int) bool {
+		return result.Steps[i].StartTime.Before(result.Steps[j].StartTime)
+	})
+	if result.StartTime != nil {
+		result.DurationMs = result.EndTime.Sub(*result.StartTime).Milliseconds()
+	}
+	return result, nil
+}
+
+const (
+	// workflowPipelineField is the column of the workflows.argoproj.io/workflow-template
+	// pod label, which names the template a workflow run was submitted from.
+	workflowPipelineField = "kubernetes_labels_workflows_argoproj_io_workflow_template"
+	// maxWorkflowVolumeRows bounds the (bucket, pipeline) rows returned by a workflow volume query.
+	maxWorkflowVolumeRows = 10000
+)
+
+// WorkflowVolumeParams holds parameters for the log volume of the workflow
+// pipelines of a namespace.
+type WorkflowVolumeParams struct {
+	Namespace string        `json:"namespace"`
+	StartTime time.Time     `json:"startTime"`
+	EndTime   time.Time     `json:"endTime"`
+	Interval  time.Duration `json:"interval"`
+}
+
+// WorkflowVolumeBucket holds the number of log and error lines of a pipeline
+// in one time bucket.
+type WorkflowVolumeBucket struct {
+	Time       time.Time `json:"time"`
+	LogCount   int       `json:"logCount"`
+	ErrorCount int       `json:"errorCount"`
+}
+
+// WorkflowPipelineVolume holds the log volume of one pipeline over time,
+// with its totals. Pipeline is empty for the runs of no template.
+type WorkflowPipelineVolume struct {
+	Pipeline   string                 `json:"pipeline"`
+	LogCount   int                    `json:"logCount"`
+	ErrorCount int                    `json:"errorCount"`
+	Buckets    []WorkflowVolumeBucket `json:"buckets"`
+}
+
+// WorkflowVolumeResult represents the result of a workflow volume query.
+// Pipelines are ordered by decreasing log count.
+type WorkflowVolumeResult struct {
+	Pipelines []WorkflowPipelineVolume `json:"pipelines"`
+	Took      int                      `json:"took"`
+}
+
+// generateWorkflowVolumeQuery generates an aggregation query counting the log
+// lines and error lines of the workflow runs of a namespace per time bucket
+// and workflow template.
+func generateWorkflowVolumeQuery(params WorkflowVolumeParams, stream string, logger *slog.Logger) ([]byte, error) {
+	if params.Namespace == "" {
+		return nil, fmt.Errorf("namespace is required for workflow volume queries")
+	}
+	if params.Interval < time.Second {
+		return nil, fmt.Errorf("histogram interval must be at least 1s")
+	}
+
+	sql := fmt.Sprintf("SELECT histogram(_timestamp, '%d seconds') AS bucket, %s AS pipeline, count(*) AS log_count, "+
+		"sum(CASE WHEN logLevel IN %s THEN 1 ELSE 0 END) AS error_count FROM %s"+
+		" WHERE kubernetes_namespace_name = 'workflows-%s'"+
+		" GROUP BY bucket, pipeline ORDER BY bucket ASC",
+		int(params.Interval/time.Second), workflowPipelineField, workflowErrorLevels, quoteIdentifier(stream),
+		escapeSQLString(params.Namespace))
+
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"sql":        sql,
+			"start_time": params.StartTime.UnixMicro(),
+			"end_time":   params.EndTime.UnixMicro(),
+			"from":       0,
+			"size":       maxWorkflowVolumeRows,
+		},
+	}
+
+	logGenerated(logger, query, "workflow volume query")
+
+	return json.Marshal(query)
+}
+
+// GetWorkflowVolume returns the number of log and error lines of the workflow
+// runs of a namespace over time, per workflow template, to spot noisy or
+// failing pipelines.
+func (c *Client) GetWorkflowVolume(ctx context.Context, params WorkflowVolumeParams) (*WorkflowVolumeResult, error) {
+	queryJSON, err := generateWorkflowVolumeQuery(params, c.stream, c.logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate workflow volume query: %w", err)
+	}
+
+	resp, err := c.executeSearchQuery(ctx, queryJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &WorkflowVolumeResult{
+		Pipelines: make([]WorkflowPipelineVolume, 0),
+		Took:      resp.Took,
+	}
+	index := make(map[string]int)
+	for _, hit := range resp.Hits {
+		bucketTime, ok := parseHistogramTime(hit["bucket"])
+		if !ok {
+			continue
+		}
+		bucket := WorkflowVolumeBucket{Time: bucketTime}
+		if v, ok := hit["log_count"].(float64); ok {
+			bucket.LogCount = int(v)
+		}
+		if v, ok := hit["error_count"].(float64); ok {
+			bucket.ErrorCount = int(v)
+		}
+
+		pipeline := stringField(hit, "pipeline")
+		i, ok := index[pipeline]
+		if !ok {
+			i = len(result.Pipelines)
+			index[pipeline] = i
+			result.Pipelines = append(result.Pipelines, WorkflowPipelineVolume{Pipeline: pipeline})
+		}
+		p := &result.Pipelines[i]
+		p.LogCount += bucket.LogCount
+		p.ErrorCount += bucket.ErrorCount
+		p.Buckets = append(p.Buckets, bucket)
+	}
+
+	sort.SliceStable(result.Pipelines, func(i, j int) bool {
+		return result.Pipelines[i].LogCount > result.Pipelines[j].LogCount
+	})
+	return result, nil
+}
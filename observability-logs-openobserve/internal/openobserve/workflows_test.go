@@ -0,0 +1,188 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWorkflowStepName(t *testing.T) {
+	for nodeName, want := range map[string]string{
+		"run-1.build":          "build",
+		"run-1[0].test":        "test",
+		"run-1.build(1)":       "build",
+		"run-1[0].fan-out(12)": "fan-out",
+		"run-1":                "run-1",
+		"":                     "",
+	} {
+		if got := workflowStepName(nodeName); got != want {
+			t.Errorf("workflowStepName(%q) = %q, want %q", nodeName, got, want)
+		}
+	}
+}
+
+func TestGenerateWorkflowSummaryQuery(t *testing.T) {
+	if _, err := generateWorkflowSummaryQuery(WorkflowSummaryParams{WorkflowRunName: "run-1"}, "default", testLogger()); err == nil {
+		t.Error("expected error without namespace")
+	}
+	if _, err := generateWorkflowSummaryQuery(WorkflowSummaryParams{Namespace: "ns"}, "default", testLogger()); err == nil {
+		t.Error("expected error without workflowRunName")
+	}
+
+	raw, err := generateWorkflowSummaryQuery(WorkflowSummaryParams{Namespace: "ns", WorkflowRunName: "run-1"}, "default", testLogger())
+	if err != nil {
+		t.Fatalf("generateWorkflowSummaryQuery() error = %v", err)
+	}
+	sql, _ := sqlOf(t, raw)
+	for _, want := range []string{
+		"kubernetes_namespace_name = 'workflows-ns'",
+		"kubernetes_labels_workflows_argoproj_io_workflow = 'run-1'",
+		"GROUP BY node_name",
+		"logLevel IN ('ERROR', 'FATAL')",
+	} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("expected summary query to contain %q, got %s", want, sql)
+		}
+	}
+}
+
+func TestGetWorkflowSummary(t *testing.T) {
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	var queried []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query struct {
+				SQL string `json:"sql"`
+			} `json:"query"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		queried = append(queried, body.Query.SQL)
+
+		var hits []map[string]interface{}
+		if strings.Contains(body.Query.SQL, "GROUP BY node_name") {
+			hits = []map[string]interface{}{
+				{
+					"node_name":   "run-1.test",
+					"log_count":   float64(40),
+					"error_count": float64(2),
+					"first_seen":  float64(base.Add(time.Minute).UnixMicro()),
+					"last_seen":   float64(base.Add(3 * time.Minute).UnixMicro()),
+				},
+				{
+					"node_name":   "run-1.build",
+					"log_count":   float64(100),
+					"error_count": float64(0),
+					"first_seen":  float64(base.UnixMicro()),
+					"last_seen":   float64(base.Add(50 * time.Second).UnixMicro()),
+				},
+			}
+		} else {
+			hits = []map[string]interface{}{
+				{"_timestamp": float64(base.Add(2 * time.Minute).UnixMicro()), "log": "FAIL: TestLogin"},
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(OpenObserveResponse{Took: 4, Hits: hits})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	result, err := client.GetWorkflowSummary(context.Background(), WorkflowSummaryParams{
+		Namespace:       "ns",
+		WorkflowRunName: "run-1",
+		StartTime:       base.Add(-time.Hour),
+		EndTime:         base.Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("GetWorkflowSummary() error = %v", err)
+	}
+	if len(queried) != 2 || !strings.Contains(queried[1], "= 'run-1.test'") {
+		t.Fatalf("expected a first error query for the failing step only, got %v", queried)
+	}
+	if len(result.Steps) != 2 || result.Steps[0].StepName != "build" || result.Steps[1].StepName != "test" {
+		t.Fatalf("expected steps ordered by start time, got %+v", result.Steps)
+	}
+
+	build, test := result.Steps[0], result.Steps[1]
+	if build.DurationMs != 50000 || build.FirstError != "" || build.FirstErrorTime != nil {
+		t.Errorf("unexpected build step: %+v", build)
+	}
+	if test.ErrorCount != 2 || test.FirstError != "FAIL: TestLogin" || test.FirstErrorTime == nil ||
+		!test.FirstErrorTime.Equal(base.Add(2*time.Minute)) || test.DurationMs != 120000 {
+		t.Errorf("unexpected test step: %+v", test)
+	}
+	if result.LogCount != 140 || result.ErrorCount != 2 || result.DurationMs != 180000 || result.Took != 8 {
+		t.Errorf("unexpected totals: %+v", result)
+	}
+	if !result.StartTime.Equal(base) || !result.EndTime.Equal(base.Add(3*time.Minute)) {
+		t.Errorf("unexpected run window: %v - %v", result.StartTime, result.EndTime)
+	}
+}
+
+func TestGenerateWorkflowVolumeQuery(t *testing.T) {
+	if _, err := generateWorkflowVolumeQuery(WorkflowVolumeParams{Interval: time.Minute}, "default", testLogger()); err == nil {
+		t.Error("expected error without namespace")
+	}
+	if _, err := generateWorkflowVolumeQuery(WorkflowVolumeParams{Namespace: "ns"}, "default", testLogger()); err == nil {
+		t.Error("expected error without interval")
+	}
+
+	raw, err := generateWorkflowVolumeQuery(WorkflowVolumeParams{Namespace: "n's", Interval: 5 * time.Minute}, "default", testLogger())
+	if err != nil {
+		t.Fatalf("generateWorkflowVolumeQuery() error = %v", err)
+	}
+	sql, _ := sqlOf(t, raw)
+	for _, want := range []string{
+		"histogram(_timestamp, '300 seconds') AS bucket",
+		"kubernetes_labels_workflows_argoproj_io_workflow_template AS pipeline",
+		"kubernetes_namespace_name = 'workflows-n''s'",
+		"logLevel IN ('ERROR', 'FATAL')",
+		"GROUP BY bucket, pipeline",
+	} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("expected volume query to contain %q, got %s", want, sql)
+		}
+	}
+}
+
+func TestGetWorkflowVolume(t *testing.T) {
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits := []map[string]interface{}{
+			{"bucket": base.Format("2006-01-02T15:04:05"), "pipeline": "docker-build", "log_count": float64(10), "error_count": float64(0)},
+			{"bucket": base.Format("2006-01-02T15:04:05"), "pipeline": "buildpacks", "log_count": float64(30), "error_count": float64(3)},
+			{"bucket": base.Add(time.Minute).Format("2006-01-02T15:04:05"), "pipeline": "docker-build", "log_count": float64(5), "error_count": float64(1)},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(OpenObserveResponse{Took: 3, Hits: hits})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	result, err := client.GetWorkflowVolume(context.Background(), WorkflowVolumeParams{
+		Namespace: "ns",
+		StartTime: base,
+		EndTime:   base.Add(time.Hour),
+		Interval:  time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("GetWorkflowVolume() error = %v", err)
+	}
+	if len(result.Pipelines) != 2 || result.Pipelines[0].Pipeline != "buildpacks" {
+		t.Fatalf("expected pipelines ordered by log count, got %+v", result.Pipelines)
+	}
+	docker := result.Pipelines[1]
+	if docker.LogCount != 15 || docker.ErrorCount != 1 || len(docker.Buckets) != 2 || !docker.Buckets[1].Time.Equal(base.Add(time.Minute)) {
+		t.Errorf("unexpected docker-build volume: %+v", docker)
+	}
+	if result.Took != 3 {
+		t.Errorf("expected took 3, got %d", result.Took)
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/openchoreo/community-modules/common/prefer"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/api/gen"
+)
+
+// maxPreferredResults caps the max-results preference a client may request.
+const maxPreferredResults = 10000
+
+// gatewayTimeout is the title of the errors of queries that did not
+// complete within their preferred wait. The API spec has no such title, but
+// clients only branch on the status code.
+const gatewayTimeout gen.ErrorResponseTitle = "gatewayTimeout"
+
+// withPreferences honours the HTTP Prefer header (RFC 7240) on the query
+// endpoints, with max-results capped at maxPreferredResults. Queries failing
+// because their preferred wait elapsed are answered 504 rather than 500.
+func withPreferences(next http.Handler) http.Handler {
+	return prefer.Middleware(maxPreferredResults, isQueryPath, func(w http.ResponseWriter, r *http.Request, err error) {
+		writeJSONError(w, http.StatusGatewayTimeout, gatewayTimeout, err.Error())
+	}, next)
+}
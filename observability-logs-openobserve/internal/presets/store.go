@@ -0,0 +1,186 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package presets keeps the default query settings that platform admins set
+// per namespace, such as the time window of queries that do not set one.
+// Presets are kept in a JSON file on local disk so that they survive
+// restarts of the adapter.
+package presets
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// MaxWindow bounds the default time window of a preset.
+	MaxWindow = 7 * 24 * time.Hour
+	// MaxLimit bounds the default limit of a preset.
+	MaxLimit = 10000
+)
+
+// ErrNotFound is returned when a namespace has no preset.
+var ErrNotFound = errors.New("preset not found")
+
+// logLevels are the levels a preset may filter logs queries by.
+var logLevels = []string{"DEBUG", "INFO", "WARN", "ERROR"}
+
+// Preset holds the defaults applied to the logs and events queries of a
+// namespace that leave the corresponding fields out.
+type Preset struct {
+	Namespace string `json:"namespace"`
+	// Window is the time range, as a Go duration such as "15m", of queries
+	// without a startTime. It ends at their endTime, or now without one.
+	Window string `json:"window,omitempty"`
+	// Limit is the limit of queries without one.
+	Limit int `json:"limit,omitempty"`
+	// LogLevels are the levels of logs queries without levels.
+	LogLevels []string  `json:"logLevels,omitempty"`
+	UpdatedBy string    `json:"updatedBy"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Validate checks the namespace and the defaults of a preset.
+func (p Preset) Validate() error {
+	if strings.TrimSpace(p.Namespace) == "" {
+		return errors.New("namespace is required")
+	}
+	if p.Window == "" && p.Limit == 0 && len(p.LogLevels) == 0 {
+		return errors.New("at least one of window, limit and logLevels is required")
+	}
+	if p.Window != "" {
+		window, err := time.ParseDuration(p.Window)
+		if err != nil {
+			return fmt.Errorf("invalid window: %w", err)
+		}
+		if window <= 0 || window > MaxWindow {
+			return fmt.Errorf("window must be positive and at most %s", MaxWindow)
+		}
+	}
+	if p.Limit < 0 || p.Limit > MaxLimit {
+		return fmt.Errorf("limit must be between 1 and %d", MaxLimit)
+	}
+	for _, level := range p.LogLevels {
+		if !slices.Contains(logLevels, level) {
+			return fmt.Errorf("invalid log level %q, must be one of %v", level, logLevels)
+		}
+	}
+	return nil
+}
+
+// WindowDuration returns the window of the preset, or zero when it has none.
+func (p Preset) WindowDuration() time.Duration {
+	window, _ := time.ParseDuration(p.Window)
+	return window
+}
+
+// FileStore is a Preset store persisted to a JSON file. Every change
+// rewrites the file through a temporary file and a rename so that a crash
+// never leaves a truncated store behind.
+type FileStore struct {
+	path string
+
+	mu      sync.Mutex
+	presets []Preset
+}
+
+// NewFileStore opens the store at path, creating it on first write.
+func NewFileStore(path string) (*FileStore, error) {
+	s := &FileStore{path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read preset store: %w", err)
+	}
+	if err := json.Unmarshal(data, &s.presets); err != nil {
+		return nil, fmt.Errorf("failed to parse preset store %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Put sets the preset of its namespace, replacing any previous one.
+func (s *FileStore) Put(preset Preset) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	presets := slices.Clone(s.presets)
+	if i := s.indexLocked(preset.Namespace); i >= 0 {
+		presets[i] = preset
+	} else {
+		presets = append(presets, preset)
+	}
+	return s.saveLocked(presets)
+}
+
+// Delete removes the preset of namespace.
+func (s *FileStore) Delete(namespace string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.indexLocked(namespace)
+	if i < 0 {
+		return ErrNotFound
+	}
+	return s.saveLocked(slices.Delete(slices.Clone(s.presets), i, i+1))
+}
+
+// Get returns the preset of namespace.
+func (s *FileStore) Get(namespace string) (Preset, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.indexLocked(namespace)
+	if i < 0 {
+		return Preset{}, ErrNotFound
+	}
+	return s.presets[i], nil
+}
+
+// List returns the presets ordered by namespace.
+func (s *FileStore) List() []Preset {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	presets := slices.Clone(s.presets)
+	slices.SortFunc(presets, func(a, b Preset) int { return strings.Compare(a.Namespace, b.Namespace) })
+	return presets
+}
+
+func (s *FileStore) indexLocked(namespace string) int {
+	return slices.IndexFunc(s.presets, func(p Preset) bool { return p.Namespace == namespace })
+}
+
+// saveLocked writes presets to disk and, on success, makes them the current
+// state.
+func (s *FileStore) saveLocked(presets []Preset) error {
+	data, err := json.MarshalIndent(presets, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode preset store: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to write preset store: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write preset store: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write preset store: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write preset store: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to write preset store: %w", err)
+	}
+	s.presets = presets
+	return nil
+}
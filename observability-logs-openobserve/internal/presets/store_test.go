@@ -0,0 +1,92 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package presets
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "presets.json")
+	s, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+
+	for _, p := range []Preset{
+		{Namespace: "prod", Window: "1h"},
+		{Namespace: "dev", Limit: 50},
+		{Namespace: "prod", Window: "15m", LogLevels: []string{"ERROR"}},
+	} {
+		if err := s.Put(p); err != nil {
+			t.Fatalf("Put() error = %v", err)
+		}
+	}
+
+	reopened, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+	list := reopened.List()
+	if len(list) != 2 || list[0].Namespace != "dev" || list[1].Namespace != "prod" {
+		t.Fatalf("unexpected presets: %+v", list)
+	}
+	prod, err := reopened.Get("prod")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if prod.WindowDuration() != 15*time.Minute || len(prod.LogLevels) != 1 {
+		t.Errorf("expected the preset to be replaced, got %+v", prod)
+	}
+
+	if err := reopened.Delete("prod"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := reopened.Delete("prod"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if _, err := reopened.Get("prod"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestNewFileStore_Corrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "presets.json")
+	if err := os.WriteFile(path, []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewFileStore(path); err == nil {
+		t.Error("expected error for a corrupt store")
+	}
+}
+
+func TestPreset_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		preset  Preset
+		wantErr bool
+	}{
+		{name: "window", preset: Preset{Namespace: "ns", Window: "15m"}},
+		{name: "all defaults", preset: Preset{Namespace: "ns", Window: "24h", Limit: 500, LogLevels: []string{"WARN", "ERROR"}}},
+		{name: "no namespace", preset: Preset{Window: "15m"}, wantErr: true},
+		{name: "no defaults", preset: Preset{Namespace: "ns"}, wantErr: true},
+		{name: "invalid window", preset: Preset{Namespace: "ns", Window: "soon"}, wantErr: true},
+		{name: "negative window", preset: Preset{Namespace: "ns", Window: "-1h"}, wantErr: true},
+		{name: "window too long", preset: Preset{Namespace: "ns", Window: "200h"}, wantErr: true},
+		{name: "limit too high", preset: Preset{Namespace: "ns", Limit: MaxLimit + 1}, wantErr: true},
+		{name: "negative limit", preset: Preset{Namespace: "ns", Limit: -1}, wantErr: true},
+		{name: "invalid level", preset: Preset{Namespace: "ns", LogLevels: []string{"error"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.preset.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/openchoreo/community-modules/common/ratelimit"
+)
+
+// limitedRoutes are the routes whose requests are rate limited per
+// namespace: those querying OpenObserve while the client waits. Pod log
+// follows have their own session limit.
+var limitedRoutes = []string{
+	"POST /api/v1/logs/query",
+	"POST /api/v1/events/query",
+	"POST /api/v1/logs/aggregate",
+	"POST /api/v1/logs/raw-query",
+	"POST /api/v1/logs/incidents/restarts",
+	"POST /api/v1/incidents/bundle",
+	"POST /api/v1alpha1/alerts/rules:test",
+	"GET /api/v1/logs/sources",
+	"GET /api/v1/logs/presence",
+	"GET /api/v1/logs/components/{componentUid}/levels",
+	"GET /api/v1/logs/gateway/requests/{requestId}/trace",
+	"GET /api/v1/logs/streams/stats",
+	"GET /api/v1/workflows/{workflowRunName}/summary",
+	"GET /api/v1/workflows/volume",
+}
+
+// withRateLimit rejects the requests for the limitedRoutes of namespaces
+// over the limits of limiter with 429. The namespace of a request is the
+// one of its tenancy header, or else the one it queries. Requests are
+// passed through untouched when no limiter is configured.
+func withRateLimit(limiter *ratelimit.Limiter, next http.Handler) http.Handler {
+	return limiter.Middleware(limitedRoutes, func(r *http.Request) string {
+		if namespace := strings.TrimSpace(r.Header.Get(TenancyHeader)); namespace != "" {
+			return namespace
+		}
+		return ratelimit.Namespace(r)
+	}, func(w http.ResponseWriter, r *http.Request, err error) {
+		writeJSONError(w, http.StatusTooManyRequests, tooManyRequests, err.Error())
+	}, next)
+}
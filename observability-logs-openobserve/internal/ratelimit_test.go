@@ -0,0 +1,69 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openchoreo/community-modules/common/ratelimit"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+func TestRateLimit(t *testing.T) {
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(openobserve.OpenObserveResponse{Hits: []map[string]interface{}{}})
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	handler := NewLogsHandler(client, nil, testLogger())
+	limiter, err := ratelimit.New(ratelimit.Config{RequestsPerSecond: 0.01}, "logs_adapter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler.SetRateLimiter(limiter)
+	srv := NewServer("0", handler, testLogger()).httpServer.Handler
+
+	serve := func(namespace string, header http.Header) *httptest.ResponseRecorder {
+		body := `{"startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-02T00:00:00Z","searchScope":{"namespace":"` + namespace + `"}}`
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/logs/query", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		for name, values := range header {
+			req.Header[name] = values
+		}
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := serve("payments", nil); rec.Code != http.StatusOK {
+		t.Fatalf("expected the first query to be served, got %d: %s", rec.Code, rec.Body.String())
+	}
+	rec := serve("payments", nil)
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") != "100" {
+		t.Fatalf("expected the second query to be rejected with Retry-After, got %d with %v", rec.Code, rec.Header())
+	}
+	var errResp map[string]string
+	if err := json.Unmarshal(rec.Body.Bytes(), &errResp); err != nil || errResp["title"] != string(tooManyRequests) {
+		t.Errorf("expected a tooManyRequests error, got %s", rec.Body.String())
+	}
+	if rec := serve("billing", nil); rec.Code != http.StatusOK {
+		t.Errorf("expected another namespace to be served, got %d", rec.Code)
+	}
+	// The tenancy header takes precedence over the namespace queried.
+	if rec := serve("billing", http.Header{TenancyHeader: {"payments"}}); rec.Code != http.StatusTooManyRequests {
+		t.Errorf("expected the namespace of the tenancy header to be limited, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if want := `logs_adapter_rate_limited_requests_total{limit="rate"} 2`; !strings.Contains(rec.Body.String(), want) {
+		t.Errorf("expected metrics to contain %q, got:\n%s", want, rec.Body.String())
+	}
+}
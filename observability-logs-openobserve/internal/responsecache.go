@@ -0,0 +1,36 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/openchoreo/community-modules/common/respcache"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/annotations"
+)
+
+// cachedRoutes are the routes whose responses are served from the response
+// cache: the queries dashboards poll.
+var cachedRoutes = []string{
+	"POST /api/v1/logs/query",
+	"POST /api/v1/events/query",
+	"POST /api/v1/logs/aggregate",
+	"GET /api/v1/logs/sources",
+	"GET /api/v1/logs/components/{componentUid}/levels",
+}
+
+// withResponseCache serves the cachedRoutes from cache, keyed in addition
+// by the caller, the tenant and the revision of the log annotations, which
+// logs queries return inline. Requests are passed through untouched when no
+// cache is configured.
+func withResponseCache(cache *respcache.Cache, notes *annotations.FileStore, next http.Handler) http.Handler {
+	return cache.Middleware(cachedRoutes, func(r *http.Request) []string {
+		parts := []string{callerFromContext(r.Context()), r.Header.Get(TenancyHeader)}
+		if notes != nil {
+			parts = append(parts, strconv.FormatInt(notes.Revision(), 10))
+		}
+		return parts
+	}, next)
+}
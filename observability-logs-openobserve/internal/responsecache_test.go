@@ -0,0 +1,79 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/respcache"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/annotations"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+func TestResponseCache(t *testing.T) {
+	var searches atomic.Int32
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		searches.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(openobserve.OpenObserveResponse{Hits: []map[string]interface{}{}})
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	handler := NewLogsHandler(client, nil, testLogger())
+	notes, err := annotations.NewFileStore(filepath.Join(t.TempDir(), "annotations.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler.SetAnnotationStore(notes)
+	policy := respcache.Policy{RecentTTL: time.Minute, HistoricalTTL: time.Minute, Settle: respcache.DefaultSettle}
+	handler.SetResponseCache(respcache.NewCache(respcache.NewMemoryStore(respcache.MaxEntryBytes), policy, "logs_adapter", testLogger()))
+	srv := NewServer("0", handler, testLogger()).httpServer.Handler
+
+	serve := func(namespace string) *httptest.ResponseRecorder {
+		body := `{"startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-02T00:00:00Z","searchScope":{"namespace":"` + namespace + `"}}`
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/logs/query", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		return rec
+	}
+
+	first := serve("payments")
+	if first.Code != http.StatusOK || first.Header().Get(respcache.CacheHeader) != "miss" {
+		t.Fatalf("expected a miss, got %d with %v", first.Code, first.Header())
+	}
+	before := searches.Load()
+	rec := serve("payments")
+	if rec.Header().Get(respcache.CacheHeader) != "hit" || rec.Body.String() != first.Body.String() || searches.Load() != before {
+		t.Errorf("expected the repeated query to be served from cache, got %v: %s", rec.Header(), rec.Body.String())
+	}
+	if rec.Header().Get("ETag") != first.Header().Get("ETag") {
+		t.Errorf("expected hits to keep the ETag of the response, got %q", rec.Header().Get("ETag"))
+	}
+
+	if rec := serve("billing"); rec.Header().Get(respcache.CacheHeader) != "miss" {
+		t.Errorf("expected another scope to miss, got %q", rec.Header().Get(respcache.CacheHeader))
+	}
+	past := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	if err := notes.Create(annotations.Annotation{ID: "a1", Namespace: "payments", StartTime: past, EndTime: past, Note: "deploy"}); err != nil {
+		t.Fatal(err)
+	}
+	if rec := serve("payments"); rec.Header().Get(respcache.CacheHeader) != "miss" || !strings.Contains(rec.Body.String(), "deploy") {
+		t.Errorf("expected an annotation change to miss, got %q: %s", rec.Header().Get(respcache.CacheHeader), rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if want := "logs_adapter_response_cache_hits_total 1\n"; !strings.Contains(rec.Body.String(), want) {
+		t.Errorf("expected metrics to contain %q, got:\n%s", want, rec.Body.String())
+	}
+}
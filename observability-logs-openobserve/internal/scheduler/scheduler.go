@@ -0,0 +1,308 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package scheduler bounds the number of concurrent OpenObserve queries and
+// shares them between endpoint classes by weighted fair queueing, so that
+// heavy export jobs cannot starve interactive queries. While queries are
+// queued, each class is served in proportion to its weight; an idle class
+// leaves its share to the others.
+package scheduler
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Class is the endpoint class a query is scheduled in.
+type Class string
+
+const (
+	// ClassInteractive is logs and events queries, and shared views.
+	ClassInteractive Class = "interactive"
+	// ClassSummary is histograms, summaries and source listings.
+	ClassSummary Class = "summary"
+	// ClassExport is export jobs, legal holds and incident bundles.
+	ClassExport Class = "export"
+)
+
+// Classes lists the endpoint classes.
+var Classes = []Class{ClassInteractive, ClassSummary, ClassExport}
+
+// DefaultWeights are the weights of the classes not set explicitly.
+var DefaultWeights = map[Class]int{
+	ClassInteractive: 8,
+	ClassSummary:     4,
+	ClassExport:      1,
+}
+
+type contextKey int
+
+const (
+	classKey contextKey = iota
+	statsKey
+)
+
+// WithClass returns a context whose queries are scheduled in class.
+func WithClass(ctx context.Context, class Class) context.Context {
+	return context.WithValue(ctx, classKey, class)
+}
+
+// ClassFrom returns the class of ctx, ClassInteractive by default.
+func ClassFrom(ctx context.Context) Class {
+	if class, ok := ctx.Value(classKey).(Class); ok {
+		return class
+	}
+	return ClassInteractive
+}
+
+// Stats accumulates the queries scheduled for a request and the time they
+// spent queued. It is safe for concurrent use.
+type Stats struct {
+	queries atomic.Int64
+	waitNs  atomic.Int64
+}
+
+// WithStats returns a context that records its queries in the returned Stats.
+func WithStats(ctx context.Context) (context.Context, *Stats) {
+	stats := &Stats{}
+	return context.WithValue(ctx, statsKey, stats), stats
+}
+
+// StatsFrom returns the Stats of ctx, or nil.
+func StatsFrom(ctx context.Context) *Stats {
+	stats, _ := ctx.Value(statsKey).(*Stats)
+	return stats
+}
+
+// Queries returns the number of queries scheduled.
+func (s *Stats) Queries() int {
+	return int(s.queries.Load())
+}
+
+// QueueWait returns the total time the queries spent queued.
+func (s *Stats) QueueWait() time.Duration {
+	return time.Duration(s.waitNs.Load())
+}
+
+// ParseWeights parses class weights of the form "interactive=8,export=1".
+// Classes left out keep their default weight.
+func ParseWeights(s string) (map[Class]int, error) {
+	weights := make(map[Class]int, len(Classes))
+	for class, weight := range DefaultWeights {
+		weights[class] = weight
+	}
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(pair, "=")
+		class := Class(strings.TrimSpace(name))
+		if _, known := DefaultWeights[class]; !ok || !known {
+			return nil, fmt.Errorf("invalid class weight %q: expected <class>=<weight> with class one of interactive, summary, export", pair)
+		}
+		weight, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || weight < 1 {
+			return nil, fmt.Errorf("invalid weight for class %s: must be a positive integer", class)
+		}
+		weights[class] = weight
+	}
+	return weights, nil
+}
+
+// waiter is a query queued for a slot.
+type waiter struct {
+	class  Class
+	finish float64
+	seq    uint64
+	ready  chan struct{}
+	index  int
+}
+
+// waitQueue orders waiters by virtual finish time, then arrival.
+type waitQueue []*waiter
+
+func (q waitQueue) Len() int { return len(q) }
+func (q waitQueue) Less(i, j int) bool {
+	if q[i].finish != q[j].finish {
+		return q[i].finish < q[j].finish
+	}
+	return q[i].seq < q[j].seq
+}
+func (q waitQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index, q[j].index = i, j
+}
+func (q *waitQueue) Push(x any) {
+	w := x.(*waiter)
+	w.index = len(*q)
+	*q = append(*q, w)
+}
+func (q *waitQueue) Pop() any {
+	old := *q
+	w := old[len(old)-1]
+	old[len(old)-1] = nil
+	w.index = -1
+	*q = old[:len(old)-1]
+	return w
+}
+
+// classCounters holds the scheduling counters of a class.
+type classCounters struct {
+	queries     int64
+	waitSeconds float64
+	queued      int64
+	// canceled counts the queries given up while waiting for a slot.
+	canceled int64
+}
+
+// Scheduler grants at most capacity concurrent query slots. It is safe for
+// concurrent use.
+type Scheduler struct {
+	mu       sync.Mutex
+	capacity int
+	inFlight int
+	weights  map[Class]int
+	queue    waitQueue
+	seq      uint64
+	// vtime is the finish time of the last dispatched waiter, and
+	// lastFinish the finish time of the last queued waiter of each class.
+	vtime      float64
+	lastFinish map[Class]float64
+	counters   map[Class]*classCounters
+}
+
+// New returns a scheduler granting at most capacity concurrent slots,
+// shared between the classes by weights.
+func New(capacity int, weights map[Class]int) (*Scheduler, error) {
+	if capacity < 1 {
+		return nil, fmt.Errorf("capacity must be at least 1")
+	}
+	s := &Scheduler{
+		capacity:   capacity,
+		weights:    make(map[Class]int, len(Classes)),
+		lastFinish: make(map[Class]float64, len(Classes)),
+		counters:   make(map[Class]*classCounters, len(Classes)),
+	}
+	for _, class := range Classes {
+		weight := weights[class]
+		if weight < 1 {
+			return nil, fmt.Errorf("weight of class %s must be at least 1", class)
+		}
+		s.weights[class] = weight
+		s.counters[class] = &classCounters{}
+	}
+	return s, nil
+}
+
+// Acquire waits for a slot for a query in the class of ctx and returns the
+// function that releases it. The time spent waiting is recorded in the Stats
+// of ctx. It fails with the context error if ctx is done first.
+func (s *Scheduler) Acquire(ctx context.Context) (func(), error) {
+	class := ClassFrom(ctx)
+	if _, ok := s.weights[class]; !ok {
+		class = ClassInteractive
+	}
+	start := time.Now()
+
+	s.mu.Lock()
+	if s.inFlight < s.capacity && len(s.queue) == 0 {
+		s.inFlight++
+		s.mu.Unlock()
+		s.record(ctx, class, 0)
+		return s.releaseFunc(), nil
+	}
+	w := &waiter{class: class, seq: s.seq, ready: make(chan struct{})}
+	s.seq++
+	w.finish = max(s.vtime, s.lastFinish[class]) + 1/float64(s.weights[class])
+	s.lastFinish[class] = w.finish
+	heap.Push(&s.queue, w)
+	s.counters[class].queued++
+	s.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		s.record(ctx, class, time.Since(start))
+		return s.releaseFunc(), nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		s.counters[class].canceled++
+		if w.index >= 0 {
+			heap.Remove(&s.queue, w.index)
+			s.counters[class].queued--
+			s.mu.Unlock()
+			return nil, ctx.Err()
+		}
+		s.mu.Unlock()
+		// The slot was granted as the context was done; hand it on.
+		s.release()
+		return nil, ctx.Err()
+	}
+}
+
+func (s *Scheduler) record(ctx context.Context, class Class, wait time.Duration) {
+	if stats := StatsFrom(ctx); stats != nil {
+		stats.queries.Add(1)
+		stats.waitNs.Add(int64(wait))
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c := s.counters[class]
+	c.queries++
+	c.waitSeconds += wait.Seconds()
+}
+
+func (s *Scheduler) releaseFunc() func() {
+	var once sync.Once
+	return func() { once.Do(s.release) }
+}
+
+// release hands the slot to the waiter with the earliest finish time, or
+// frees it.
+func (s *Scheduler) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.queue) == 0 {
+		s.inFlight--
+		return
+	}
+	w := heap.Pop(&s.queue).(*waiter)
+	s.vtime = w.finish
+	s.counters[w.class].queued--
+	close(w.ready)
+}
+
+// ServeHTTP writes the scheduling metrics in the Prometheus text exposition format.
+func (s *Scheduler) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	for _, metric := range []struct {
+		name, help, kind string
+		value            func(c *classCounters) string
+	}{
+		{"logs_adapter_backend_queries_total", "OpenObserve queries scheduled for the endpoint class.", "counter",
+			func(c *classCounters) string { return fmt.Sprint(c.queries) }},
+		{"logs_adapter_backend_queue_wait_seconds_sum", "Total time queries of the endpoint class waited for a slot.", "counter",
+			func(c *classCounters) string { return fmt.Sprintf("%g", c.waitSeconds) }},
+		{"logs_adapter_backend_queued_queries", "Queries of the endpoint class waiting for a slot.", "gauge",
+			func(c *classCounters) string { return fmt.Sprint(c.queued) }},
+		{"logs_adapter_backend_canceled_queries_total", "Queries of the endpoint class canceled while waiting for a slot, typically because their client went away.", "counter",
+			func(c *classCounters) string { return fmt.Sprint(c.canceled) }},
+	} {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", metric.name, metric.help, metric.name, metric.kind)
+		for _, class := range Classes {
+			fmt.Fprintf(w, "%s{class=%q} %s\n", metric.name, class, metric.value(s.counters[class]))
+		}
+	}
+	fmt.Fprintf(w, "# HELP logs_adapter_backend_queries_in_flight OpenObserve queries running.\n"+
+		"# TYPE logs_adapter_backend_queries_in_flight gauge\nlogs_adapter_backend_queries_in_flight %d\n", s.inFlight)
+}
@@ -0,0 +1,163 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// waitQueued waits until n queries are queued in s.
+func waitQueued(t *testing.T, s *Scheduler, n int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		s.mu.Lock()
+		queued := len(s.queue)
+		s.mu.Unlock()
+		if queued == n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d queued queries", n)
+}
+
+func TestParseWeights(t *testing.T) {
+	got, err := ParseWeights("interactive=10, export=2")
+	if err != nil {
+		t.Fatalf("ParseWeights() error = %v", err)
+	}
+	if got[ClassInteractive] != 10 || got[ClassExport] != 2 || got[ClassSummary] != DefaultWeights[ClassSummary] {
+		t.Errorf("unexpected weights: %v", got)
+	}
+	if got, err := ParseWeights(""); err != nil || got[ClassInteractive] != DefaultWeights[ClassInteractive] {
+		t.Errorf("ParseWeights(\"\") = %v, %v", got, err)
+	}
+	for _, s := range []string{"batch=1", "export", "export=0", "export=x"} {
+		if _, err := ParseWeights(s); err == nil {
+			t.Errorf("ParseWeights(%q): expected error", s)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New(0, DefaultWeights); err == nil {
+		t.Error("expected error for zero capacity")
+	}
+	if _, err := New(1, map[Class]int{ClassInteractive: 1}); err == nil {
+		t.Error("expected error for a missing weight")
+	}
+}
+
+func TestWeightedFairOrder(t *testing.T) {
+	s, err := New(1, map[Class]int{ClassInteractive: 4, ClassSummary: 1, ClassExport: 1})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	release, err := s.Acquire(context.Background())
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+
+	var mu sync.Mutex
+	var order []string
+	var wg sync.WaitGroup
+	enqueue := func(class Class, name string) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release, err := s.Acquire(WithClass(context.Background(), class))
+			if err != nil {
+				t.Errorf("Acquire() error = %v", err)
+				return
+			}
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+			release()
+		}()
+	}
+	// The export job queues its pages first, then interactive queries arrive.
+	for i, name := range []string{"e1", "e2", "e3", "e4", "i1", "i2", "i3", "i4"} {
+		class := ClassExport
+		if name[0] == 'i' {
+			class = ClassInteractive
+		}
+		enqueue(class, name)
+		waitQueued(t, s, i+1)
+	}
+	release()
+	wg.Wait()
+
+	want := "i1 i2 i3 e1 i4 e2 e3 e4"
+	if got := strings.Join(order, " "); got != want {
+		t.Errorf("served in order %q, want %q", got, want)
+	}
+}
+
+func TestAcquireStatsAndCancel(t *testing.T) {
+	s, err := New(1, DefaultWeights)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	release, err := s.Acquire(context.Background())
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errc := make(chan error, 1)
+	go func() {
+		_, err := s.Acquire(ctx)
+		errc <- err
+	}()
+	waitQueued(t, s, 1)
+	cancel()
+	if err := <-errc; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+
+	ctx, stats := WithStats(WithClass(context.Background(), ClassExport))
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		release, err := s.Acquire(ctx)
+		if err != nil {
+			t.Errorf("Acquire() error = %v", err)
+			return
+		}
+		release()
+	}()
+	waitQueued(t, s, 1)
+	time.Sleep(10 * time.Millisecond)
+	release()
+	release() // releasing twice must not free a second slot
+	<-done
+
+	if stats.Queries() != 1 || stats.QueueWait() < 10*time.Millisecond {
+		t.Errorf("unexpected stats: %d queries, %v wait", stats.Queries(), stats.QueueWait())
+	}
+	if s.inFlight != 0 {
+		t.Errorf("expected no queries in flight, got %d", s.inFlight)
+	}
+
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	for _, want := range []string{
+		`logs_adapter_backend_queries_total{class="export"} 1`,
+		`logs_adapter_backend_queued_queries{class="interactive"} 0`,
+		`logs_adapter_backend_canceled_queries_total{class="interactive"} 1`,
+		"logs_adapter_backend_queries_in_flight 0",
+	} {
+		if !strings.Contains(rec.Body.String(), want) {
+			t.Errorf("expected metrics to contain %q, got:\n%s", want, rec.Body.String())
+		}
+	}
+}
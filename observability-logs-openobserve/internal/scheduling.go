@@ -0,0 +1,110 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/annotations"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/scheduler"
+)
+
+// queryStats reports how the OpenObserve queries of a request were scheduled.
+type queryStats struct {
+	// UpstreamQueries is the number of OpenObserve queries the request made.
+	UpstreamQueries int `json:"upstreamQueries"`
+	// QueueWaitMs is the total time they waited for a query slot.
+	QueueWaitMs int64 `json:"queueWaitMs"`
+}
+
+// withScheduling records the scheduling of the OpenObserve queries of each
+// request in its context, for the queryStats of its response. Requests are
+// passed through untouched when no scheduler is configured.
+func withScheduling(s *scheduler.Scheduler, next http.Handler) http.Handler {
+	if s == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, _ := scheduler.WithStats(r.Context())
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// withQueryClass schedules the OpenObserve queries of handler in class.
+// Requests not wrapped are scheduled as interactive.
+func withQueryClass(class scheduler.Class, handler http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler(w, r.WithContext(scheduler.WithClass(r.Context(), class)))
+	})
+}
+
+// queryStatsFromContext returns the query statistics of the request of ctx,
+// or nil when no scheduler is configured.
+func queryStatsFromContext(ctx context.Context) *queryStats {
+	stats := scheduler.StatsFrom(ctx)
+	if stats == nil {
+		return nil
+	}
+	return &queryStats{
+		UpstreamQueries: stats.Queries(),
+		QueueWaitMs:     stats.QueueWait().Milliseconds(),
+	}
+}
+
+// logsQueryResponse extends the generated LogsQueryResponse with query
+// statistics, the annotations overlapping the query, the malformed hits
+// found in strict mode and whether logs are missing from a partial result.
+type logsQueryResponse struct {
+	gen.LogsQueryResponse
+	QueryStats  *queryStats              `json:"queryStats,omitempty"`
+	Annotations []annotations.Annotation `json:"annotations,omitempty"`
+	ParseErrors *openobserve.ParseErrors `json:"parseErrors,omitempty"`
+	openobserve.PartialResult
+}
+
+func (response logsQueryResponse) VisitQueryLogsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	if response.Partial {
+		// Partial results must not be reused once the searches succeed.
+		w.Header().Set("Cache-Control", "no-store")
+	}
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(response)
+}
+
+// eventsQueryResponse extends the generated EventsQueryResponse with query statistics.
+type eventsQueryResponse struct {
+	gen.EventsQueryResponse
+	QueryStats *queryStats `json:"queryStats,omitempty"`
+}
+
+func (response eventsQueryResponse) VisitQueryEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(response)
+}
+
+// queryLogsOK returns the 200 response of a logs query, with query
+// statistics when a scheduler is configured, with notes, the annotations
+// overlapping the query, with the parse errors of its hits and with the
+// warnings of a partial or retention-clamped result.
+func queryLogsOK(ctx context.Context, response gen.LogsQueryResponse, notes []annotations.Annotation, parseErrors *openobserve.ParseErrors, partial openobserve.PartialResult) gen.QueryLogsResponseObject {
+	if stats := queryStatsFromContext(ctx); stats != nil || len(notes) > 0 || parseErrors != nil || partial.Partial || partial.RangeClamped {
+		return logsQueryResponse{LogsQueryResponse: response, QueryStats: stats, Annotations: notes, ParseErrors: parseErrors, PartialResult: partial}
+	}
+	return gen.QueryLogs200JSONResponse(response)
+}
+
+// queryEventsOK returns the 200 response of an events query, with query
+// statistics when a scheduler is configured.
+func queryEventsOK(ctx context.Context, response gen.EventsQueryResponse) gen.QueryEventsResponseObject {
+	if stats := queryStatsFromContext(ctx); stats != nil {
+		return eventsQueryResponse{EventsQueryResponse: response, QueryStats: stats}
+	}
+	return gen.QueryEvents200JSONResponse(response)
+}
@@ -0,0 +1,192 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/scheduler"
+)
+
+func TestQueryScheduling(t *testing.T) {
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(openobserve.OpenObserveResponse{
+			Hits:  []map[string]interface{}{{"_timestamp": float64(1735732800000000), "log": "ready"}},
+			Total: 1,
+		})
+	}))
+	defer ooServer.Close()
+
+	querySched, err := scheduler.New(2, scheduler.DefaultWeights)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	client.SetScheduler(querySched)
+	handler := NewLogsHandler(client, nil, testLogger())
+	handler.SetScheduler(querySched)
+	srv := NewServer("0", handler, testLogger()).httpServer.Handler
+
+	t.Run("logs query reports query stats", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		body := `{"startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-02T00:00:00Z","searchScope":{"namespace":"test-ns"}}`
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/logs/query", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		srv.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var got struct {
+			Logs       []json.RawMessage `json:"logs"`
+			QueryStats *queryStats       `json:"queryStats"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(got.Logs) != 1 || got.QueryStats == nil || got.QueryStats.UpstreamQueries < 1 {
+			t.Errorf("unexpected response: %s", rec.Body.String())
+		}
+	})
+
+	t.Run("histograms are scheduled as summaries", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/logs/components/comp-1/levels?namespace=test-ns", nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if !strings.Contains(rec.Body.String(), `"queryStats":{"upstreamQueries":1`) {
+			t.Errorf("expected query stats, got %s", rec.Body.String())
+		}
+
+		rec = httptest.NewRecorder()
+		srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		for _, want := range []string{
+			`logs_adapter_backend_queries_total{class="summary"} 1`,
+			`logs_adapter_backend_queries_total{class="export"} 0`,
+		} {
+			if !strings.Contains(rec.Body.String(), want) {
+				t.Errorf("expected metrics to contain %q, got:\n%s", want, rec.Body.String())
+			}
+		}
+	})
+}
+
+func TestQueryLogs_ParseErrors(t *testing.T) {
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(openobserve.OpenObserveResponse{
+			Hits:  []map[string]interface{}{{"_timestamp": float64(1735732800000000), "log": map[string]interface{}{"msg": "ready"}}},
+			Total: 1,
+		})
+	}))
+	defer ooServer.Close()
+
+	schema := openobserve.NewSchemaValidator()
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	client.SetSchemaValidator(schema)
+	handler := NewLogsHandler(client, nil, testLogger())
+	handler.SetSchemaValidator(schema)
+	srv := NewServer("0", handler, testLogger()).httpServer.Handler
+
+	rec := httptest.NewRecorder()
+	body := `{"startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-02T00:00:00Z","searchScope":{"namespace":"test-ns"}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/logs/query", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	srv.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), `"parseErrors":{"malformedRows":1,"fields":{"log":1}}`) {
+		t.Errorf("expected parse errors, got %s", rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if want := `logs_adapter_malformed_hits_total{kind="application",field="log"} 1`; !strings.Contains(rec.Body.String(), want) {
+		t.Errorf("expected metrics to contain %q, got:\n%s", want, rec.Body.String())
+	}
+}
+
+func TestQueryLogs_Partial(t *testing.T) {
+	var timeout float64
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Timeout float64 `json:"timeout"`
+		}
+		json.NewDecoder(r.Body).Decode(&request)
+		timeout = max(timeout, request.Timeout)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(openobserve.OpenObserveResponse{
+			Hits:      []map[string]interface{}{{"_timestamp": float64(1735732800000000), "log": "ready"}},
+			Total:     1,
+			IsPartial: true,
+		})
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	handler := NewLogsHandler(client, nil, testLogger())
+	handler.SetQueryBudget(1500 * time.Millisecond)
+	srv := NewServer("0", handler, testLogger()).httpServer.Handler
+
+	rec := httptest.NewRecorder()
+	body := `{"startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-02T00:00:00Z","searchScope":{"namespace":"test-ns"}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/logs/query", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	srv.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), `"partial":true,"warnings":["OpenObserve answered with partial data`) {
+		t.Errorf("expected a partial result with a warning, got %s", rec.Body.String())
+	}
+	if timeout != 2 {
+		t.Errorf("expected the budget rounded up to whole seconds as search timeout, got %v", timeout)
+	}
+}
+
+func TestQueryLogs_RetentionClamp(t *testing.T) {
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/schema") {
+			w.Write([]byte(`{"settings":{"data_retention":30}}`))
+			return
+		}
+		json.NewEncoder(w).Encode(openobserve.OpenObserveResponse{Hits: []map[string]interface{}{}})
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	client.SetRetentionClamping(time.Hour)
+	srv := NewServer("0", NewLogsHandler(client, nil, testLogger()), testLogger()).httpServer.Handler
+
+	rec := httptest.NewRecorder()
+	start := time.Now().Add(-90 * 24 * time.Hour).UTC().Format(time.RFC3339)
+	body := `{"startTime":"` + start + `","endTime":"` + time.Now().UTC().Format(time.RFC3339) + `","searchScope":{"namespace":"test-ns"}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/logs/query", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	srv.ServeHTTP(rec, req)
+	var resp struct {
+		Partial           bool       `json:"partial"`
+		RangeClamped      bool       `json:"rangeClamped"`
+		EarliestAvailable *time.Time `json:"earliestAvailable"`
+		Warnings          []string   `json:"warnings"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if !resp.RangeClamped || resp.EarliestAvailable == nil || resp.Partial || len(resp.Warnings) != 1 {
+		t.Errorf("expected a complete result clamped to the retention, got %s", rec.Body.String())
+	}
+	if rec.Header().Get("Cache-Control") == "no-store" {
+		t.Error("expected clamped results to stay cacheable")
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/scheduler"
 )
 
 type Server struct {
@@ -23,11 +24,125 @@ func NewServer(port string, logsHandler *LogsHandler, logger *slog.Logger) *Serv
 	strictHandler := gen.NewStrictHandler(logsHandler, nil)
 
 	mux := http.NewServeMux()
+	mux.Handle("GET /api/v1/logs/sources", withQueryClass(scheduler.ClassSummary, logsHandler.ListLogSources))
+	mux.Handle("GET /api/v1/logs/presence", withQueryClass(scheduler.ClassSummary, logsHandler.GetLogsPresence))
+	mux.Handle("GET /api/v1/logs/components/{componentUid}/levels", withQueryClass(scheduler.ClassSummary, logsHandler.GetComponentLevelHistogram))
+	mux.HandleFunc("GET /api/v1/logs/pods/{podName}/follow", logsHandler.FollowPodLogs)
+	mux.HandleFunc("GET /api/v1/logs/gateway/requests/{requestId}/trace", logsHandler.GetGatewayRequestTrace)
+	mux.Handle("POST /api/v1/logs/aggregate", withQueryClass(scheduler.ClassSummary, logsHandler.AggregateLogs))
+	mux.HandleFunc("POST /api/v1alpha1/alerts/rules:test", logsHandler.TestAlertRule)
+	mux.HandleFunc("GET /api/v1alpha1/alerts/drift", logsHandler.GetAlertDrift)
+	mux.HandleFunc("POST /api/v1alpha1/alerts/rules/{ruleName}", logsHandler.SetAlertRuleState)
+	mux.HandleFunc("POST /api/v1/logs/raw-query", logsHandler.RawQueryLogs)
+	mux.Handle("GET /api/v1/logs/streams/stats", withQueryClass(scheduler.ClassSummary, logsHandler.GetStreamStats))
+	mux.Handle("POST /api/v1/logs/incidents/restarts", withQueryClass(scheduler.ClassSummary, logsHandler.GetRestartSummary))
+	mux.Handle("GET /api/v1/workflows/{workflowRunName}/summary", withQueryClass(scheduler.ClassSummary, logsHandler.GetWorkflowSummary))
+	mux.Handle("GET /api/v1/workflows/volume", withQueryClass(scheduler.ClassSummary, logsHandler.GetWorkflowVolume))
+	mux.HandleFunc("POST /api/v1/logs/export", logsHandler.CreateLogExport)
+	mux.HandleFunc("GET /api/v1/logs/export/{jobId}", logsHandler.GetLogExport)
+	mux.HandleFunc("POST /api/v1/logs/holds", logsHandler.CreateHold)
+	mux.HandleFunc("GET /api/v1/logs/holds", logsHandler.ListHolds)
+	mux.HandleFunc("GET /api/v1/logs/holds/{holdId}", logsHandler.GetHold)
+	mux.HandleFunc("POST /api/v1/logs/annotations", logsHandler.CreateAnnotation)
+	mux.HandleFunc("GET /api/v1/logs/annotations", logsHandler.ListAnnotations)
+	mux.HandleFunc("DELETE /api/v1/logs/annotations/{annotationId}", logsHandler.DeleteAnnotation)
+	mux.HandleFunc("GET /api/v1/logs/presets", logsHandler.ListQueryPresets)
+	mux.HandleFunc("GET /api/v1/logs/presets/{namespace}", logsHandler.GetQueryPreset)
+	mux.HandleFunc("PUT /api/v1/logs/presets/{namespace}", logsHandler.PutQueryPreset)
+	mux.HandleFunc("DELETE /api/v1/logs/presets/{namespace}", logsHandler.DeleteQueryPreset)
+	mux.HandleFunc("POST /api/v1/logs/shares", logsHandler.CreateShareLink)
+	mux.HandleFunc("GET /api/v1alpha1/support-bundle", logsHandler.GetSupportBundle)
+	mux.HandleFunc("POST /api/v1alpha1/components/{componentUid}/purge", logsHandler.PurgeComponentData)
+	mux.HandleFunc("GET /api/v1/openapi.json", logsHandler.GetOpenAPISpec)
+	mux.HandleFunc("GET /docs", logsHandler.GetDocs)
+	mux.Handle("POST /api/v1/incidents/bundle", withQueryClass(scheduler.ClassExport, logsHandler.CreateIncidentBundle))
+	var metrics []http.Handler
+	if logsHandler.metrics != nil {
+		metrics = append(metrics, logsHandler.metrics)
+	}
+	if logsHandler.tenants != nil {
+		metrics = append(metrics, logsHandler.tenants.Metrics())
+	}
+	if logsHandler.scheduler != nil {
+		metrics = append(metrics, logsHandler.scheduler)
+	}
+	if logsHandler.plans != nil {
+		metrics = append(metrics, logsHandler.plans)
+	}
+	if logsHandler.schema != nil {
+		metrics = append(metrics, logsHandler.schema)
+	}
+	if logsHandler.alertDrift != nil {
+		metrics = append(metrics, logsHandler.alertDrift)
+	}
+	if logsHandler.usage != nil {
+		metrics = append(metrics, logsHandler.usage)
+	}
+	if logsHandler.follows != nil {
+		metrics = append(metrics, logsHandler.follows)
+	}
+	if logsHandler.responseCache != nil {
+		metrics = append(metrics, logsHandler.responseCache)
+	}
+	if logsHandler.rateLimiter != nil {
+		metrics = append(metrics, logsHandler.rateLimiter)
+	}
+	if logsHandler.auditLog != nil {
+		metrics = append(metrics, logsHandler.auditLog)
+	}
+	if len(metrics) > 0 {
+		mux.HandleFunc("GET /metrics", func(w http.ResponseWriter, r *http.Request) {
+			for _, m := range metrics {
+				m.ServeHTTP(w, r)
+			}
+		})
+	}
 	handler := gen.HandlerFromMux(strictHandler, mux)
 
+	// The middlewares are listed from the outermost, which sees a request
+	// first, to the innermost, which is given the routed mux.
+	middlewares := []middleware{
+		// Count every request, including the rejected ones.
+		logsHandler.metrics.Instrument,
+		// Reject unauthenticated requests before they are served from the
+		// cache, spend a rate limit token or reach OpenObserve.
+		func(next http.Handler) http.Handler {
+			return withAuthentication(logsHandler.authenticator, logsHandler.authExemptPaths, next)
+		},
+		func(next http.Handler) http.Handler { return withSLIs(logsHandler.slis, next) },
+		// Identify the caller for the audit log, query debugging, the cache
+		// key and the access policy checks of the handlers.
+		func(next http.Handler) http.Handler { return withCallers(logsHandler.access, next) },
+		func(next http.Handler) http.Handler { return withAudit(logsHandler.auditLog, next) },
+		// Debugged requests bypass the conditional requests and the cache.
+		func(next http.Handler) http.Handler { return withQueryDebug(logsHandler.access, next) },
+		func(next http.Handler) http.Handler { return withTenancy(logsHandler.requireTenancy, next) },
+		func(next http.Handler) http.Handler { return withScheduling(logsHandler.scheduler, next) },
+		// Shared views replay their query through the middlewares below, so
+		// they are cached and rate limited like the query they share.
+		func(next http.Handler) http.Handler { return withSharedViews(logsHandler.shareSigner, next) },
+		func(next http.Handler) http.Handler { return withConditionalQueries(logsHandler.annotations, next) },
+		// Cache hits do not spend a rate limit token.
+		func(next http.Handler) http.Handler {
+			return withResponseCache(logsHandler.responseCache, logsHandler.annotations, next)
+		},
+		func(next http.Handler) http.Handler { return withRateLimit(logsHandler.rateLimiter, next) },
+		withLocalization,
+		withPreferences,
+		withArrowNegotiation,
+		withExportNegotiation,
+		withQueryExtensions,
+		withAlertRuleExtensions,
+		// withUsage must wrap withShadowLogging, and withShadowLogging and
+		// withDiagnostics must be given the request the mux is given.
+		func(next http.Handler) http.Handler { return withUsage(logsHandler.usage, next) },
+		func(next http.Handler) http.Handler { return withShadowLogging(logsHandler.shadow, next) },
+		func(next http.Handler) http.Handler { return withDiagnostics(logsHandler.diagnostics, next) },
+	}
+
 	httpServer := &http.Server{
 		Addr:         ":" + port,
-		Handler:      handler,
+		Handler:      chain(logsHandler.metrics.Route(handler), middlewares...),
 		ReadTimeout:  15 * time.Second,
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
@@ -40,6 +155,39 @@ func NewServer(port string, logsHandler *LogsHandler, logger *slog.Logger) *Serv
 	}
 }
 
+// NewBackendServer creates a server of the core logs API of a
+// BackendHandler.
+func NewBackendServer(port string, backendHandler *BackendHandler, logger *slog.Logger) *Server {
+	strictHandler := gen.NewStrictHandler(backendHandler, nil)
+	handler := gen.HandlerFromMux(strictHandler, http.NewServeMux())
+
+	httpServer := &http.Server{
+		Addr:         ":" + port,
+		Handler:      withAuthentication(backendHandler.authenticator, backendHandler.authExemptPaths, handler),
+		ReadTimeout:  15 * time.Second,
+		WriteTimeout: 15 * time.Second,
+		IdleTimeout:  60 * time.Second,
+	}
+
+	return &Server{
+		port:       port,
+		httpServer: httpServer,
+		logger:     logger,
+	}
+}
+
+// middleware wraps a handler with behaviour shared by the routes.
+type middleware func(next http.Handler) http.Handler
+
+// chain wraps handler with middlewares, the first of which sees a request
+// first.
+func chain(handler http.Handler, middlewares ...middleware) http.Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return handler
+}
+
 func (s *Server) Start() error {
 	s.logger.Info("Starting server", slog.String("port", s.port))
 	if err := s.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -0,0 +1,112 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/auth"
+	"github.com/openchoreo/community-modules/common/metrics"
+	"github.com/openchoreo/community-modules/common/ratelimit"
+	"github.com/openchoreo/community-modules/common/respcache"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+func TestServerMetrics(t *testing.T) {
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"took":1,"hits":[]}`))
+	}))
+	defer ooServer.Close()
+
+	m := metrics.New("logs_adapter")
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	client.AddRequestObserver(m.ObserveBackend)
+	handler := NewLogsHandler(client, nil, testLogger())
+	handler.SetMetrics(m)
+	srv := NewServer("0", handler, testLogger()).httpServer.Handler
+
+	for _, target := range []string{"/api/v1/logs/sources?namespace=test-ns", "/api/v1/logs/sources"} {
+		srv.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, target, nil))
+	}
+
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{
+		`logs_adapter_http_requests_total{handler="/api/v1/logs/sources",method="GET",code="200"} 1`,
+		`logs_adapter_http_requests_total{handler="/api/v1/logs/sources",method="GET",code="400"} 1`,
+		`logs_adapter_http_request_duration_seconds_count{handler="/api/v1/logs/sources",method="GET"} 2`,
+		`logs_adapter_openobserve_requests_total{operation="search",code="200"}`,
+		`logs_adapter_openobserve_request_duration_seconds_count{operation="search"}`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected %q in:\n%s", want, body)
+		}
+	}
+}
+
+func TestServerAuthenticatesBeforeCachingAndRateLimiting(t *testing.T) {
+	var searches atomic.Int32
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		searches.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(openobserve.OpenObserveResponse{Hits: []map[string]interface{}{}})
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	handler := NewLogsHandler(client, nil, testLogger())
+	handler.SetAuthenticator(auth.NewStaticToken("0123456789abcdef"), nil)
+	limiter, err := ratelimit.New(ratelimit.Config{RequestsPerSecond: 0.01}, "logs_adapter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler.SetRateLimiter(limiter)
+	policy := respcache.Policy{RecentTTL: time.Minute, HistoricalTTL: time.Minute, Settle: respcache.DefaultSettle}
+	handler.SetResponseCache(respcache.NewCache(respcache.NewMemoryStore(respcache.MaxEntryBytes), policy, "logs_adapter", testLogger()))
+	srv := NewServer("0", handler, testLogger()).httpServer.Handler
+
+	serve := func(token string) *httptest.ResponseRecorder {
+		body := `{"startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-02T00:00:00Z","searchScope":{"namespace":"payments"}}`
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/logs/query", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for range 2 {
+		if rec := serve(""); rec.Code != http.StatusUnauthorized || rec.Header().Get(respcache.CacheHeader) != "" {
+			t.Fatalf("expected 401 without the cache, got %d with %v", rec.Code, rec.Header())
+		}
+	}
+	// Had the unauthenticated requests spent the only rate limit token, this
+	// one would be rejected with 429.
+	if rec := serve("0123456789abcdef"); rec.Code != http.StatusOK || rec.Header().Get(respcache.CacheHeader) != "miss" {
+		t.Fatalf("expected the first authenticated query to miss, got %d with %v", rec.Code, rec.Header())
+	}
+	before := searches.Load()
+	if rec := serve("0123456789abcdef"); rec.Header().Get(respcache.CacheHeader) != "hit" {
+		t.Fatalf("expected the repeated query to hit, got %d with %v", rec.Code, rec.Header())
+	}
+	if rec := serve(""); rec.Code != http.StatusUnauthorized || rec.Header().Get(respcache.CacheHeader) != "" {
+		t.Errorf("expected cached responses to require authentication, got %d with %v", rec.Code, rec.Header())
+	}
+	if n := searches.Load(); n != before {
+		t.Errorf("expected only the authenticated miss to query OpenObserve, got %d more searches", n-before)
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package sessions bounds the number of live streaming sessions, such as
+// pod log follows, each caller holds open in a namespace, so that a single
+// dashboard wall cannot open hundreds of follows polling OpenObserve.
+package sessions
+
+import (
+	"cmp"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+)
+
+type key struct {
+	caller, namespace string
+}
+
+// Limiter admits at most a fixed number of concurrent sessions per caller
+// and namespace. It serves the open and rejected sessions, by caller, in the
+// Prometheus text exposition format. Callers are the names of the access
+// policy, a fixed set; namespaces are left out of the metrics.
+type Limiter struct {
+	max int
+
+	mu       sync.Mutex
+	active   map[key]int
+	opened   map[string]int64
+	rejected map[string]int64
+}
+
+// NewLimiter returns a limiter admitting max sessions per caller and
+// namespace. max must be positive.
+func NewLimiter(max int) (*Limiter, error) {
+	if max < 1 {
+		return nil, fmt.Errorf("invalid session limit %d: must be positive", max)
+	}
+	return &Limiter{
+		max:      max,
+		active:   map[key]int{},
+		opened:   map[string]int64{},
+		rejected: map[string]int64{},
+	}, nil
+}
+
+// Max returns the number of sessions admitted per caller and namespace.
+func (l *Limiter) Max() int {
+	return l.max
+}
+
+// Acquire opens a session of caller in namespace. It returns false when the
+// caller already holds the maximum number of sessions there; otherwise the
+// session must be closed by calling release, which is safe to call twice.
+func (l *Limiter) Acquire(caller, namespace string) (release func(), ok bool) {
+	k := key{caller, namespace}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.active[k] >= l.max {
+		l.rejected[caller]++
+		return nil, false
+	}
+	l.active[k]++
+	l.opened[caller]++
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			if l.active[k]--; l.active[k] == 0 {
+				delete(l.active, k)
+			}
+		})
+	}, true
+}
+
+// ServeHTTP writes the session metrics in the Prometheus text exposition format.
+func (l *Limiter) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	active := map[string]int64{}
+	for k, n := range l.active {
+		active[k.caller] += int64(n)
+	}
+	// Callers keep their series once seen, at zero when idle.
+	for caller := range l.opened {
+		if _, ok := active[caller]; !ok {
+			active[caller] = 0
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	fmt.Fprintf(w, "# HELP logs_adapter_follow_sessions_max Follow sessions admitted per caller and namespace.\n"+
+		"# TYPE logs_adapter_follow_sessions_max gauge\nlogs_adapter_follow_sessions_max %d\n", l.max)
+	for _, metric := range []struct {
+		name, help, kind string
+		values           map[string]int64
+	}{
+		{"logs_adapter_follow_sessions", "Follow sessions open, by caller.", "gauge", active},
+		{"logs_adapter_follow_sessions_opened_total", "Follow sessions opened, by caller.", "counter", l.opened},
+		{"logs_adapter_follow_sessions_rejected_total", "Follow sessions rejected because the caller held the maximum in the namespace, by caller.", "counter", l.rejected},
+	} {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", metric.name, metric.help, metric.name, metric.kind)
+		callers := make([]string, 0, len(metric.values))
+		for caller := range metric.values {
+			callers = append(callers, caller)
+		}
+		slices.SortFunc(callers, cmp.Compare)
+		for _, caller := range callers {
+			fmt.Fprintf(w, "%s{caller=%q} %d\n", metric.name, caller, metric.values[caller])
+		}
+	}
+}
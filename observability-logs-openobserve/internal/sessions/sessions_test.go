@@ -0,0 +1,64 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sessions
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewLimiter_Invalid(t *testing.T) {
+	if _, err := NewLimiter(0); err == nil {
+		t.Error("expected an error for a limit of 0")
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	l, err := NewLimiter(2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	release1, ok := l.Acquire("dashboard", "ns")
+	if !ok {
+		t.Fatal("expected the first session to be admitted")
+	}
+	if _, ok := l.Acquire("dashboard", "ns"); !ok {
+		t.Fatal("expected the second session to be admitted")
+	}
+	if _, ok := l.Acquire("dashboard", "ns"); ok {
+		t.Fatal("expected the third session to be rejected")
+	}
+	if _, ok := l.Acquire("dashboard", "other-ns"); !ok {
+		t.Error("expected a session in another namespace to be admitted")
+	}
+	if _, ok := l.Acquire("cli", "ns"); !ok {
+		t.Error("expected a session of another caller to be admitted")
+	}
+
+	release1()
+	release1()
+	if _, ok := l.Acquire("dashboard", "ns"); !ok {
+		t.Error("expected a session to be admitted after one was released")
+	}
+	if _, ok := l.Acquire("dashboard", "ns"); ok {
+		t.Error("expected a double release to free a single session")
+	}
+
+	rec := httptest.NewRecorder()
+	l.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := rec.Body.String()
+	for _, want := range []string{
+		"logs_adapter_follow_sessions_max 2\n",
+		`logs_adapter_follow_sessions{caller="cli"} 1`,
+		`logs_adapter_follow_sessions{caller="dashboard"} 3`,
+		`logs_adapter_follow_sessions_opened_total{caller="dashboard"} 4`,
+		`logs_adapter_follow_sessions_rejected_total{caller="dashboard"} 2`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected %q in:\n%s", want, body)
+		}
+	}
+}
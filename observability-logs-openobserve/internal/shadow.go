@@ -0,0 +1,90 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/shadow"
+)
+
+// withShadowLogging records the sanitized shape of each routed request and
+// its response with recorder. It must wrap the mux directly, as the route
+// pattern is read from the request the mux was given. Health checks and
+// metrics scrapes are not recorded. Requests are passed through untouched
+// when no recorder is set.
+func withShadowLogging(recorder *shadow.Recorder, next http.Handler) http.Handler {
+	if recorder == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health" || r.URL.Path == "/metrics" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		var reqBody []byte
+		reqTruncated := false
+		if r.Body != nil {
+			// Read one byte beyond the limit to tell a body of exactly
+			// MaxBodyBytes from a longer one, and hand the handler the
+			// whole body.
+			reqBody, _ = io.ReadAll(io.LimitReader(r.Body, shadow.MaxBodyBytes+1))
+			if len(reqBody) > shadow.MaxBodyBytes {
+				reqTruncated = true
+			}
+			r.Body = readCloser{io.MultiReader(bytes.NewReader(reqBody), r.Body), r.Body}
+		}
+		sw := &shadowWriter{statusWriter: statusWriter{ResponseWriter: w, status: http.StatusOK}}
+		next.ServeHTTP(sw, r)
+		if r.Pattern == "" {
+			return
+		}
+		_, route, found := strings.Cut(r.Pattern, " ")
+		if !found {
+			route = r.Pattern
+		}
+		if reqTruncated {
+			reqBody = nil
+		}
+		recorder.Record(shadow.Exchange{
+			Method:              r.Method,
+			Route:               route,
+			Query:               r.URL.Query(),
+			RequestBody:         reqBody,
+			RequestTruncated:    reqTruncated,
+			Status:              sw.status,
+			ResponseContentType: sw.Header().Get("Content-Type"),
+			ResponseBody:        sw.body.Bytes(),
+			ResponseTruncated:   sw.truncated,
+		})
+	})
+}
+
+// readCloser reads from a reader and closes the closer.
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// shadowWriter keeps a copy of the first shadow.MaxBodyBytes of a response.
+type shadowWriter struct {
+	statusWriter
+	body      bytes.Buffer
+	truncated bool
+}
+
+func (sw *shadowWriter) Write(b []byte) (int, error) {
+	if !sw.truncated {
+		if sw.body.Len()+len(b) > shadow.MaxBodyBytes {
+			sw.truncated = true
+			sw.body.Reset()
+		} else {
+			sw.body.Write(b)
+		}
+	}
+	return sw.statusWriter.Write(b)
+}
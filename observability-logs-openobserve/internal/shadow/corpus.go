@@ -0,0 +1,68 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package shadow
+
+import (
+	"cmp"
+	"encoding/json"
+	"slices"
+	"time"
+)
+
+// CorpusEntry is a distinct shape of request and response observed on a
+// route, with how often and when it was seen.
+type CorpusEntry struct {
+	Method              string    `json:"method"`
+	Route               string    `json:"route"`
+	Status              int       `json:"status"`
+	QueryParams         []string  `json:"queryParams,omitempty"`
+	RequestSchema       *Schema   `json:"requestSchema,omitempty"`
+	ResponseContentType string    `json:"responseContentType,omitempty"`
+	ResponseSchema      *Schema   `json:"responseSchema,omitempty"`
+	Count               int       `json:"count"`
+	FirstSeen           time.Time `json:"firstSeen"`
+	LastSeen            time.Time `json:"lastSeen"`
+}
+
+// BuildCorpus folds records sharing a method, route, status, query
+// parameters, content type and schemas into one entry. Entries are sorted
+// by route, method and status.
+func BuildCorpus(records []Record) []CorpusEntry {
+	entries := []CorpusEntry{}
+	index := make(map[string]int)
+	for _, rec := range records {
+		entry := CorpusEntry{
+			Method:              rec.Method,
+			Route:               rec.Route,
+			Status:              rec.Status,
+			QueryParams:         rec.QueryParams,
+			RequestSchema:       rec.RequestSchema,
+			ResponseContentType: rec.ResponseContentType,
+			ResponseSchema:      rec.ResponseSchema,
+		}
+		// Maps are encoded with sorted keys, so equal shapes have equal keys.
+		key, err := json.Marshal(entry)
+		if err != nil {
+			continue
+		}
+		i, ok := index[string(key)]
+		if !ok {
+			i = len(entries)
+			index[string(key)] = i
+			entry.FirstSeen = rec.Time
+			entries = append(entries, entry)
+		}
+		entries[i].Count++
+		if rec.Time.Before(entries[i].FirstSeen) {
+			entries[i].FirstSeen = rec.Time
+		}
+		if rec.Time.After(entries[i].LastSeen) {
+			entries[i].LastSeen = rec.Time
+		}
+	}
+	slices.SortStableFunc(entries, func(a, b CorpusEntry) int {
+		return cmp.Or(cmp.Compare(a.Route, b.Route), cmp.Compare(a.Method, b.Method), cmp.Compare(a.Status, b.Status))
+	})
+	return entries
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package shadow
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"mime"
+	"net/url"
+	"slices"
+	"time"
+)
+
+// MaxBodyBytes is the size of the largest request or response body whose
+// schema and hash are recorded.
+const MaxBodyBytes = 1 << 20
+
+// Exchange is a request served by the adapter and its response, as
+// captured from the wire.
+type Exchange struct {
+	Method string
+	Route  string
+	Query  url.Values
+	// RequestBody and ResponseBody hold at most MaxBodyBytes; the
+	// truncated flags are set when the bodies were longer.
+	RequestBody         []byte
+	RequestTruncated    bool
+	Status              int
+	ResponseContentType string
+	ResponseBody        []byte
+	ResponseTruncated   bool
+}
+
+// Recorder sanitizes exchanges into the records of a Store.
+type Recorder struct {
+	store  *Store
+	logger *slog.Logger
+	now    func() time.Time
+}
+
+// NewRecorder returns a Recorder appending to store.
+func NewRecorder(store *Store, logger *slog.Logger) *Recorder {
+	return &Recorder{store: store, logger: logger, now: time.Now}
+}
+
+// Record appends the sanitized record of ex to the store. Failures are
+// logged: they must not fail the request.
+func (r *Recorder) Record(ex Exchange) {
+	if err := r.store.Append(r.sanitize(ex)); err != nil {
+		r.logger.Warn("Failed to record shadow request", slog.String("route", ex.Route), slog.Any("error", err))
+	}
+}
+
+func (r *Recorder) sanitize(ex Exchange) Record {
+	rec := Record{
+		Time:                r.now().UTC(),
+		Method:              ex.Method,
+		Route:               ex.Route,
+		Status:              ex.Status,
+		ResponseContentType: ex.ResponseContentType,
+		Truncated:           ex.RequestTruncated || ex.ResponseTruncated,
+	}
+	for name := range ex.Query {
+		rec.QueryParams = append(rec.QueryParams, name)
+	}
+	slices.Sort(rec.QueryParams)
+
+	if !ex.RequestTruncated {
+		if len(ex.RequestBody) > 0 {
+			// Request bodies are JSON in this API; any other body is only hashed.
+			rec.RequestSchema, _ = InferSchema(ex.RequestBody)
+		}
+		if len(ex.RequestBody) > 0 || len(ex.Query) > 0 {
+			rec.RequestHash = hash([]byte(ex.Query.Encode()), ex.RequestBody)
+		}
+	}
+	if !ex.ResponseTruncated && len(ex.ResponseBody) > 0 {
+		if mediaType, _, _ := mime.ParseMediaType(ex.ResponseContentType); mediaType == "application/json" {
+			rec.ResponseSchema, _ = InferSchema(ex.ResponseBody)
+		}
+		rec.ResponseHash = hash(ex.ResponseBody)
+	}
+	return rec
+}
+
+// hash returns the hex SHA-256 of parts, separated so that moving bytes
+// between parts changes the hash.
+func hash(parts ...[]byte) string {
+	h := sha256.New()
+	for i, part := range parts {
+		if i > 0 {
+			h.Write([]byte{0})
+		}
+		h.Write(part)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
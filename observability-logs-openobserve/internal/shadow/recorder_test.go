@@ -0,0 +1,89 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package shadow
+
+import (
+	"io"
+	"log/slog"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecorder_Record(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "shadow.ndjson")
+	store, err := NewStore(path, Retention{MaxRecords: 100})
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+	recorder := NewRecorder(store, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	recorder.Record(Exchange{
+		Method:              "POST",
+		Route:               "/api/v1/logs/query",
+		Query:               url.Values{"lang": {"de"}, "debug": {"secret-value"}},
+		RequestBody:         []byte(`{"searchScope":{"namespace":"team-a"}}`),
+		Status:              200,
+		ResponseContentType: "application/json; charset=utf-8",
+		ResponseBody:        []byte(`{"logs":[{"log":"password=hunter2"}]}`),
+	})
+	recorder.Record(Exchange{
+		Method:              "GET",
+		Route:               "/api/v1/logs/pods/{podName}/follow",
+		Status:              200,
+		ResponseContentType: "text/event-stream",
+		ResponseBody:        []byte("data: {}\n\n"),
+		ResponseTruncated:   true,
+	})
+
+	records, err := ReadRecords(path)
+	if err != nil || len(records) != 2 {
+		t.Fatalf("ReadRecords() = %d records, %v", len(records), err)
+	}
+	query := records[0]
+	if strings.Join(query.QueryParams, ",") != "debug,lang" {
+		t.Errorf("unexpected query params %v", query.QueryParams)
+	}
+	if query.RequestSchema.Properties["searchScope"].Properties["namespace"].Type != TypeString {
+		t.Errorf("unexpected request schema %+v", query.RequestSchema)
+	}
+	if query.ResponseSchema.Properties["logs"].Items.Properties["log"].Type != TypeString {
+		t.Errorf("unexpected response schema %+v", query.ResponseSchema)
+	}
+	if len(query.RequestHash) != 64 || len(query.ResponseHash) != 64 || query.Truncated {
+		t.Errorf("unexpected hashes in %+v", query)
+	}
+	if time.Since(query.Time) > time.Minute {
+		t.Errorf("unexpected record time %v", query.Time)
+	}
+
+	follow := records[1]
+	if !follow.Truncated || follow.ResponseSchema != nil || follow.ResponseHash != "" || follow.RequestHash != "" {
+		t.Errorf("unexpected record of a truncated response %+v", follow)
+	}
+}
+
+func TestBuildCorpus(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	object := &Schema{Type: TypeObject, Properties: map[string]*Schema{"total": {Type: TypeNumber}}}
+	records := []Record{
+		{Time: start.Add(2 * time.Minute), Method: "POST", Route: "/b", Status: 200, ResponseSchema: object, ResponseHash: "1"},
+		{Time: start, Method: "POST", Route: "/b", Status: 200, ResponseSchema: object, ResponseHash: "2"},
+		{Time: start, Method: "POST", Route: "/b", Status: 400},
+		{Time: start, Method: "GET", Route: "/a", Status: 200, QueryParams: []string{"namespace"}},
+	}
+	entries := BuildCorpus(records)
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %+v", entries)
+	}
+	if entries[0].Route != "/a" || entries[2].Status != 400 {
+		t.Errorf("unexpected order %+v", entries)
+	}
+	b := entries[1]
+	if b.Count != 2 || !b.FirstSeen.Equal(start) || !b.LastSeen.Equal(start.Add(2*time.Minute)) {
+		t.Errorf("unexpected entry %+v", b)
+	}
+}
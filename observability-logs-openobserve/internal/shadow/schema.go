@@ -0,0 +1,100 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package shadow
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// JSON types of a Schema.
+const (
+	TypeObject  = "object"
+	TypeArray   = "array"
+	TypeString  = "string"
+	TypeNumber  = "number"
+	TypeBoolean = "boolean"
+	TypeNull    = "null"
+)
+
+// Schema is the shape of a JSON value without its content. A value that
+// takes several types, such as the items of a mixed array, has them all in
+// Type, sorted and separated by "|".
+type Schema struct {
+	Type       string             `json:"type"`
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	Items      *Schema            `json:"items,omitempty"`
+}
+
+// InferSchema returns the schema of the JSON document data.
+func InferSchema(data []byte) (*Schema, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	return schemaOf(v), nil
+}
+
+func schemaOf(v any) *Schema {
+	switch v := v.(type) {
+	case map[string]any:
+		s := &Schema{Type: TypeObject, Properties: make(map[string]*Schema, len(v))}
+		for key, value := range v {
+			s.Properties[key] = schemaOf(value)
+		}
+		return s
+	case []any:
+		s := &Schema{Type: TypeArray}
+		for _, item := range v {
+			s.Items = mergeSchemas(s.Items, schemaOf(item))
+		}
+		return s
+	case string:
+		return &Schema{Type: TypeString}
+	case json.Number:
+		return &Schema{Type: TypeNumber}
+	case bool:
+		return &Schema{Type: TypeBoolean}
+	default:
+		return &Schema{Type: TypeNull}
+	}
+}
+
+// mergeSchemas returns a schema that describes the values of both a and b.
+// Object properties are united, so a property missing from some items of
+// an array is still listed.
+func mergeSchemas(a, b *Schema) *Schema {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	merged := &Schema{Type: mergeTypes(a.Type, b.Type), Items: mergeSchemas(a.Items, b.Items)}
+	if a.Properties != nil || b.Properties != nil {
+		merged.Properties = maps.Clone(a.Properties)
+		if merged.Properties == nil {
+			merged.Properties = make(map[string]*Schema, len(b.Properties))
+		}
+		for key, s := range b.Properties {
+			merged.Properties[key] = mergeSchemas(merged.Properties[key], s)
+		}
+	}
+	return merged
+}
+
+func mergeTypes(a, b string) string {
+	if a == b {
+		return a
+	}
+	types := append(strings.Split(a, "|"), strings.Split(b, "|")...)
+	slices.Sort(types)
+	return strings.Join(slices.Compact(types), "|")
+}
@@ -0,0 +1,34 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package shadow
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestInferSchema(t *testing.T) {
+	schema, err := InferSchema([]byte(`{"logs":[{"log":"a","level":"INFO"},{"log":"b","traceId":null}],` +
+		`"total":2,"tookMs":1.5,"partial":false,"ids":[1,"x"]}`))
+	if err != nil {
+		t.Fatalf("InferSchema() error = %v", err)
+	}
+	got, _ := json.Marshal(schema)
+	want := `{"type":"object","properties":{` +
+		`"ids":{"type":"array","items":{"type":"number|string"}},` +
+		`"logs":{"type":"array","items":{"type":"object","properties":{` +
+		`"level":{"type":"string"},"log":{"type":"string"},"traceId":{"type":"null"}}}},` +
+		`"partial":{"type":"boolean"},"tookMs":{"type":"number"},"total":{"type":"number"}}}`
+	if string(got) != want {
+		t.Errorf("InferSchema() =\n%s\nwant\n%s", got, want)
+	}
+
+	empty, err := InferSchema([]byte(`[]`))
+	if err != nil || empty.Type != TypeArray || empty.Items != nil {
+		t.Errorf("unexpected schema of an empty array: %+v, %v", empty, err)
+	}
+	if _, err := InferSchema([]byte(`{`)); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package shadow records the shape of the requests served by the adapter
+// and of their responses, to build a corpus for compatibility tests when
+// the OpenAPI specs evolve. Records are sanitized: JSON bodies are reduced
+// to their schema, query parameters to their names and the content of the
+// requests and responses to a SHA-256 hash. They are appended to a
+// newline-delimited JSON file on local disk.
+package shadow
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Record is the sanitized shape of a request and its response.
+type Record struct {
+	Time   time.Time `json:"time"`
+	Method string    `json:"method"`
+	// Route is the pattern of the route the request was served by.
+	Route  string `json:"route"`
+	Status int    `json:"status"`
+	// QueryParams are the names of the query parameters, sorted.
+	QueryParams   []string `json:"queryParams,omitempty"`
+	RequestSchema *Schema  `json:"requestSchema,omitempty"`
+	// RequestHash is the SHA-256 of the request body and query, or empty
+	// when the request had neither.
+	RequestHash         string  `json:"requestHash,omitempty"`
+	ResponseContentType string  `json:"responseContentType,omitempty"`
+	ResponseSchema      *Schema `json:"responseSchema,omitempty"`
+	ResponseHash        string  `json:"responseHash,omitempty"`
+	// Truncated is set when a body was too large to be captured, so that
+	// its schema and hash are missing.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// Retention bounds the records kept by a Store.
+type Retention struct {
+	// MaxRecords is the number of most recent records kept.
+	MaxRecords int
+	// MaxAge is how long records are kept. They are kept until MaxRecords
+	// is reached when it is zero.
+	MaxAge time.Duration
+}
+
+// Store is a file of records. Records are appended as they come, and the
+// file is rewritten without the records beyond the retention limits once
+// they exceed MaxRecords by a tenth.
+type Store struct {
+	path      string
+	retention Retention
+	now       func() time.Time
+
+	mu    sync.Mutex
+	count int
+}
+
+// NewStore opens the store at path, creating it on first write, and drops
+// the records it holds beyond the retention limits.
+func NewStore(path string, retention Retention) (*Store, error) {
+	if retention.MaxRecords < 1 {
+		return nil, fmt.Errorf("shadow store must keep at least one record, got %d", retention.MaxRecords)
+	}
+	s := &Store{path: path, retention: retention, now: time.Now}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.compactLocked(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Append adds rec to the store.
+func (s *Store) Append(rec Record) error {
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("failed to encode shadow record: %w", err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := os.OpenFile(s.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to write shadow store: %w", err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write shadow store: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write shadow store: %w", err)
+	}
+	s.count++
+	if s.count > s.retention.MaxRecords+max(s.retention.MaxRecords/10, 1) {
+		return s.compactLocked()
+	}
+	return nil
+}
+
+// compactLocked rewrites the store with only the records within the
+// retention limits.
+func (s *Store) compactLocked() error {
+	records, err := ReadRecords(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		s.count = 0
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if s.retention.MaxAge > 0 {
+		cutoff := s.now().Add(-s.retention.MaxAge)
+		kept := records[:0]
+		for _, rec := range records {
+			if !rec.Time.Before(cutoff) {
+				kept = append(kept, rec)
+			}
+		}
+		records = kept
+	}
+	if len(records) > s.retention.MaxRecords {
+		records = records[len(records)-s.retention.MaxRecords:]
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, rec := range records {
+		if err := enc.Encode(rec); err != nil {
+			return fmt.Errorf("failed to encode shadow record: %w", err)
+		}
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to write shadow store: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write shadow store: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write shadow store: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to write shadow store: %w", err)
+	}
+	s.count = len(records)
+	return nil
+}
+
+// ReadRecords reads the records of the store file at path, oldest first.
+// A line cut short by a crash while it was appended is skipped.
+func ReadRecords(path string) ([]Record, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read shadow store: %w", err)
+	}
+	defer f.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 16<<20)
+	for scanner.Scan() {
+		var rec Record
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			continue
+		}
+		records = append(records, rec)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read shadow store: %w", err)
+	}
+	return records, nil
+}
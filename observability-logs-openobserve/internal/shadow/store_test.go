@@ -0,0 +1,69 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package shadow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStore_Retention(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "shadow.ndjson")
+	store, err := NewStore(path, Retention{MaxRecords: 10})
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := range 12 {
+		if err := store.Append(Record{Time: start.Add(time.Duration(i) * time.Minute), Route: "/r", Status: 200}); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+	records, err := ReadRecords(path)
+	if err != nil {
+		t.Fatalf("ReadRecords() error = %v", err)
+	}
+	// The file is compacted once it holds a tenth more than MaxRecords.
+	if len(records) != 10 || !records[0].Time.Equal(start.Add(2*time.Minute)) {
+		t.Fatalf("expected the 10 newest records, got %d starting at %v", len(records), records[0].Time)
+	}
+
+	// Reopening drops the records older than MaxAge.
+	store, err = NewStore(path, Retention{MaxRecords: 10, MaxAge: time.Hour})
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+	if records, _ := ReadRecords(path); len(records) != 0 {
+		t.Errorf("expected old records to be dropped, got %d", len(records))
+	}
+	if err := store.Append(Record{Time: time.Now(), Route: "/r"}); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	if records, _ := ReadRecords(path); len(records) != 1 {
+		t.Errorf("expected 1 record, got %d", len(records))
+	}
+}
+
+func TestReadRecords_SkipsTruncatedLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "shadow.ndjson")
+	data := `{"route":"/a","status":200}` + "\n" + `{"route":"/b","sta`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	records, err := ReadRecords(path)
+	if err != nil {
+		t.Fatalf("ReadRecords() error = %v", err)
+	}
+	if len(records) != 1 || records[0].Route != "/a" {
+		t.Errorf("unexpected records %+v", records)
+	}
+}
+
+func TestNewStore_InvalidRetention(t *testing.T) {
+	if _, err := NewStore(filepath.Join(t.TempDir(), "shadow.ndjson"), Retention{}); err == nil {
+		t.Error("expected error for MaxRecords 0")
+	}
+}
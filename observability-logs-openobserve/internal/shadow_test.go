@@ -0,0 +1,68 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/shadow"
+)
+
+func TestWithShadowLogging(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "shadow.ndjson")
+	store, err := shadow.NewStore(path, shadow.Retention{MaxRecords: 100})
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v1/items/{id}", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"echo":` + string(body) + `}`))
+	})
+	handler := withShadowLogging(shadow.NewRecorder(store, testLogger()), mux)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/items/42?namespace=team-a", strings.NewReader(`{"name":"secret"}`))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	// The handler still reads the whole request body.
+	if rec.Code != http.StatusCreated || rec.Body.String() != `{"echo":{"name":"secret"}}` {
+		t.Fatalf("unexpected response %d: %s", rec.Code, rec.Body.String())
+	}
+	for _, target := range []string{"/health", "/nowhere"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, target, nil))
+	}
+
+	records, err := shadow.ReadRecords(path)
+	if err != nil {
+		t.Fatalf("ReadRecords() error = %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("expected only the routed request to be recorded, got %+v", records)
+	}
+	got := records[0]
+	if got.Method != http.MethodPost || got.Route != "/api/v1/items/{id}" || got.Status != http.StatusCreated {
+		t.Errorf("unexpected record %+v", got)
+	}
+	if len(got.QueryParams) != 1 || got.QueryParams[0] != "namespace" {
+		t.Errorf("unexpected query params %v", got.QueryParams)
+	}
+	if got.ResponseSchema == nil || got.ResponseSchema.Properties["echo"].Properties["name"].Type != shadow.TypeString {
+		t.Errorf("unexpected response schema %+v", got.ResponseSchema)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "secret") || strings.Contains(string(data), "team-a") {
+		t.Errorf("expected content to be left out of the store: %s", data)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package slis
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+// Ingester writes metric samples to OpenObserve.
+type Ingester interface {
+	IngestMetrics(ctx context.Context, samples []openobserve.MetricSample) error
+}
+
+// Pusher periodically writes the SLIs of the last interval to OpenObserve:
+//
+//   - <prefix>_requests, the requests served;
+//   - <prefix>_query_latency_seconds, their mean duration;
+//   - <prefix>_error_ratio, the share of them that failed;
+//   - <prefix>_plan_cache_hit_ratio, the share of log queries whose SQL was
+//     served from the plan cache.
+//
+// Ratios are only written for intervals with requests or queries. Each
+// sample carries the instance label of the adapter replica.
+type Pusher struct {
+	ingester Ingester
+	recorder *Recorder
+	plans    *openobserve.PlanCache
+	prefix   string
+	instance string
+	interval time.Duration
+	logger   *slog.Logger
+
+	last                 Counters
+	lastHits, lastMisses int64
+}
+
+// NewPusher returns a Pusher writing the SLIs of recorder, and of plans when
+// it is set, to ingester every interval.
+func NewPusher(ingester Ingester, recorder *Recorder, plans *openobserve.PlanCache, prefix, instance string, interval time.Duration, logger *slog.Logger) *Pusher {
+	return &Pusher{
+		ingester: ingester,
+		recorder: recorder,
+		plans:    plans,
+		prefix:   prefix,
+		instance: instance,
+		interval: interval,
+		logger:   logger,
+	}
+}
+
+// Run writes the SLIs every interval until ctx is done.
+func (p *Pusher) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := p.Push(ctx, now); err != nil {
+				p.logger.Warn("Failed to write adapter SLIs", slog.Any("error", err))
+			}
+		}
+	}
+}
+
+// Push writes the SLIs since the previous push, stamped with now. The
+// samples of a failed push are dropped.
+func (p *Pusher) Push(ctx context.Context, now time.Time) error {
+	samples := p.samples(now)
+	if len(samples) == 0 {
+		return nil
+	}
+	return p.ingester.IngestMetrics(ctx, samples)
+}
+
+func (p *Pusher) samples(now time.Time) []openobserve.MetricSample {
+	var samples []openobserve.MetricSample
+	add := func(name string, value float64) {
+		samples = append(samples, openobserve.MetricSample{
+			Name:   p.prefix + "_" + name,
+			Labels: map[string]string{"instance": p.instance},
+			Time:   now,
+			Value:  value,
+		})
+	}
+
+	counters := p.recorder.Counters()
+	requests := counters.Requests - p.last.Requests
+	add("requests", float64(requests))
+	if requests > 0 {
+		add("query_latency_seconds", (counters.DurationSeconds-p.last.DurationSeconds)/float64(requests))
+		add("error_ratio", float64(counters.Errors-p.last.Errors)/float64(requests))
+	}
+	p.last = counters
+
+	if p.plans != nil {
+		hits, misses := p.plans.Stats()
+		if lookups := hits - p.lastHits + misses - p.lastMisses; lookups > 0 {
+			add("plan_cache_hit_ratio", float64(hits-p.lastHits)/float64(lookups))
+		}
+		p.lastHits, p.lastMisses = hits, misses
+	}
+	return samples
+}
@@ -0,0 +1,64 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package slis
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+type fakeIngester struct {
+	pushes [][]openobserve.MetricSample
+}
+
+func (f *fakeIngester) IngestMetrics(_ context.Context, samples []openobserve.MetricSample) error {
+	f.pushes = append(f.pushes, samples)
+	return nil
+}
+
+func TestPusher(t *testing.T) {
+	ingester := &fakeIngester{}
+	recorder := NewRecorder()
+	pusher := NewPusher(ingester, recorder, nil, "adapter_sli", "pod-1", time.Minute, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	recorder.Observe(100*time.Millisecond, false)
+	recorder.Observe(300*time.Millisecond, true)
+	if err := pusher.Push(context.Background(), now); err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+	got := map[string]float64{}
+	for _, s := range ingester.pushes[0] {
+		if s.Labels["instance"] != "pod-1" || !s.Time.Equal(now) {
+			t.Errorf("unexpected sample: %+v", s)
+		}
+		got[s.Name] = s.Value
+	}
+	want := map[string]float64{
+		"adapter_sli_requests":              2,
+		"adapter_sli_query_latency_seconds": 0.2,
+		"adapter_sli_error_ratio":           0.5,
+	}
+	for name, value := range want {
+		if v, ok := got[name]; !ok || v < value-1e-9 || v > value+1e-9 {
+			t.Errorf("expected %s = %g, got %g (present: %v)", name, value, v, ok)
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("unexpected samples: %v", got)
+	}
+
+	// The next push only covers the requests since the previous one.
+	if err := pusher.Push(context.Background(), now.Add(time.Minute)); err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+	if samples := ingester.pushes[1]; len(samples) != 1 || samples[0].Name != "adapter_sli_requests" || samples[0].Value != 0 {
+		t.Errorf("expected only an empty request count, got %+v", samples)
+	}
+}
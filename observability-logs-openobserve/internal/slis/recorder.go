@@ -0,0 +1,50 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package slis measures the service level indicators of the adapter, the
+// latency and error ratio of its requests and the hit ratio of its query plan
+// cache, and writes them to OpenObserve, so that operators can dashboard and
+// alert on the adapter with the backend they already run.
+package slis
+
+import (
+	"sync"
+	"time"
+)
+
+// Counters are the cumulative request counters of a Recorder.
+type Counters struct {
+	Requests        int64
+	Errors          int64
+	DurationSeconds float64
+}
+
+// Recorder counts the requests served by the adapter.
+type Recorder struct {
+	mu       sync.Mutex
+	counters Counters
+}
+
+// NewRecorder returns a Recorder with zero counters.
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Observe records a request that took duration; failed requests count as
+// errors.
+func (r *Recorder) Observe(duration time.Duration, failed bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.counters.Requests++
+	r.counters.DurationSeconds += duration.Seconds()
+	if failed {
+		r.counters.Errors++
+	}
+}
+
+// Counters returns the counters recorded so far.
+func (r *Recorder) Counters() Counters {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.counters
+}
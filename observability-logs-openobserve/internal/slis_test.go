@@ -0,0 +1,31 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/slis"
+)
+
+func TestWithSLIs(t *testing.T) {
+	recorder := slis.NewRecorder()
+	handler := withSLIs(recorder, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	for _, path := range []string{"/ok", "/fail", "/health", "/metrics"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	got := recorder.Counters()
+	if got.Requests != 2 || got.Errors != 1 {
+		t.Errorf("expected 2 requests and 1 error, got %+v", got)
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+const (
+	// maxInteractiveLimit is the largest limit a logs query is answered with
+	// as a single JSON document. Queries with a larger limit are streamed.
+	maxInteractiveLimit = 10000
+	// streamChunkSize is the number of entries fetched from OpenObserve per
+	// chunk of a streamed logs query.
+	streamChunkSize = 1000
+	// streamChunkTimeout bounds the time spent fetching and writing one chunk.
+	// It replaces the server write timeout, which would cut off long streams.
+	streamChunkTimeout = 15 * time.Second
+)
+
+// logsChunk is one page of entries of a streamed logs query.
+type logsChunk[E any] struct {
+	entries []E
+	total   int
+	took    int
+}
+
+// streamedLogsResponse writes the result of a logs query with a limit above
+// maxInteractiveLimit as a chunked JSON document, in the same shape as the
+// regular query response. The entries are fetched from OpenObserve and
+// written in chunks of streamChunkSize, so the adapter holds at most one
+// chunk in memory. The first chunk is fetched by the handler so that a
+// failing query is still reported with an error status; a later failure
+// aborts the response, leaving the client with an incomplete document.
+type streamedLogsResponse[E any] struct {
+	ctx    context.Context
+	limit  int
+	first  logsChunk[E]
+	fetch  func(ctx context.Context, offset, size int) (logsChunk[E], error)
+	logger *slog.Logger
+}
+
+func (response streamedLogsResponse[E]) VisitQueryLogsResponse(w http.ResponseWriter) error {
+	rc := http.NewResponseController(w)
+	_ = rc.SetWriteDeadline(time.Now().Add(streamChunkTimeout))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if _, err := fmt.Fprint(w, `{"logs":[`); err != nil {
+		return err
+	}
+
+	chunk, written, took := response.first, 0, response.first.took
+	for {
+		for _, entry := range chunk.entries {
+			data, err := json.Marshal(entry)
+			if err != nil {
+				return err
+			}
+			if written > 0 {
+				data = append([]byte{','}, data...)
+			}
+			if _, err := w.Write(data); err != nil {
+				return err
+			}
+			written++
+		}
+		_ = rc.Flush()
+
+		size := min(streamChunkSize, response.limit-written)
+		if len(chunk.entries) < streamChunkSize || size <= 0 {
+			break
+		}
+
+		_ = rc.SetWriteDeadline(time.Now().Add(streamChunkTimeout))
+		var err error
+		chunk, err = response.fetch(response.ctx, written, size)
+		if err != nil {
+			response.logger.Error("Failed to stream logs",
+				slog.String("function", "QueryLogs"),
+				slog.Int("offset", written),
+				slog.Any("error", err),
+			)
+			// The status has been sent; abort so the client does not mistake
+			// the truncated document for a complete result.
+			panic(http.ErrAbortHandler)
+		}
+		took += chunk.took
+	}
+
+	_, err := fmt.Fprintf(w, `],"total":%d,"tookMs":%d}`+"\n", response.first.total, took)
+	return err
+}
+
+// streamComponentLogs answers a component logs query with a limit above
+// maxInteractiveLimit with a streamedLogsResponse.
+func (h *LogsHandler) streamComponentLogs(ctx context.Context, client *openobserve.Client, params openobserve.ComponentLogsParams) (gen.QueryLogsResponseObject, error) {
+	if arrowFormatFromContext(ctx) {
+		return gen.QueryLogs400JSONResponse{
+			Title:   ptr(gen.BadRequest),
+			Message: ptr(fmt.Sprintf("limit must be at most %d for Arrow responses; use the export endpoint for larger results", maxInteractiveLimit)),
+		}, nil
+	}
+
+	fetch := componentLogsChunks(client, params)
+	first, err := fetch(ctx, 0, min(streamChunkSize, params.Limit))
+	if err != nil {
+		h.logger.Error("Failed to query component logs",
+			slog.String("function", "QueryLogs"),
+			slog.String("namespace", params.Namespace),
+			slog.Any("error", err),
+		)
+		return queryLogsError(err), nil
+	}
+
+	return streamedLogsResponse[componentLogEntry]{
+		ctx:    ctx,
+		limit:  params.Limit,
+		first:  first,
+		fetch:  fetch,
+		logger: h.logger,
+	}, nil
+}
+
+// streamWorkflowLogs answers a workflow logs query with a limit above
+// maxInteractiveLimit with a streamedLogsResponse.
+func (h *LogsHandler) streamWorkflowLogs(ctx context.Context, client *openobserve.Client, params openobserve.WorkflowLogsParams) (gen.QueryLogsResponseObject, error) {
+	if arrowFormatFromContext(ctx) {
+		return gen.QueryLogs400JSONResponse{
+			Title:   ptr(gen.BadRequest),
+			Message: ptr(fmt.Sprintf("limit must be at most %d for Arrow responses", maxInteractiveLimit)),
+		}, nil
+	}
+
+	fetch := workflowLogsChunks(client, params)
+	first, err := fetch(ctx, 0, min(streamChunkSize, params.Limit))
+	if err != nil {
+		h.logger.Error("Failed to query workflow logs",
+			slog.String("function", "QueryLogs"),
+			slog.String("namespace", params.Namespace),
+			slog.Any("error", err),
+		)
+		return queryLogsError(err), nil
+	}
+
+	return streamedLogsResponse[workflowLogEntry]{
+		ctx:    ctx,
+		limit:  params.Limit,
+		first:  first,
+		fetch:  fetch,
+		logger: h.logger,
+	}, nil
+}
+
+// componentLogsChunks returns a function fetching the chunk of size entries
+// at offset of the component logs query of params.
+func componentLogsChunks(client *openobserve.Client, params openobserve.ComponentLogsParams) func(ctx context.Context, offset, size int) (logsChunk[componentLogEntry], error) {
+	return func(ctx context.Context, offset, size int) (logsChunk[componentLogEntry], error) {
+		page := params
+		page.Offset, page.Limit = offset, size
+		result, err := client.GetComponentLogs(ctx, page)
+		if err != nil {
+			return logsChunk[componentLogEntry]{}, err
+		}
+		return logsChunk[componentLogEntry]{
+			entries: toComponentLogEntries(result.Logs),
+			total:   result.TotalCount,
+			took:    result.Took,
+		}, nil
+	}
+}
+
+// workflowLogsChunks returns a function fetching the chunk of size entries at
+// offset of the workflow logs query of params.
+func workflowLogsChunks(client *openobserve.Client, params openobserve.WorkflowLogsParams) func(ctx context.Context, offset, size int) (logsChunk[workflowLogEntry], error) {
+	return func(ctx context.Context, offset, size int) (logsChunk[workflowLogEntry], error) {
+		page := params
+		page.Offset, page.Limit = offset, size
+		result, err := client.GetWorkflowLogs(ctx, page)
+		if err != nil {
+			return logsChunk[workflowLogEntry]{}, err
+		}
+		return logsChunk[workflowLogEntry]{
+			entries: toWorkflowLogEntries(result.Logs),
+			total:   result.TotalCount,
+			took:    result.Took,
+		}, nil
+	}
+}
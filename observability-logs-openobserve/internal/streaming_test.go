@@ -0,0 +1,191 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+// newPagingOpenObserve returns a mock OpenObserve holding total log lines
+// that serves the requested page of each search and records the page sizes.
+// Searches from offset failFrom on fail; a negative failFrom disables failures.
+func newPagingOpenObserve(t *testing.T, total int, failFrom int) (*httptest.Server, *[]int) {
+	t.Helper()
+	var mu sync.Mutex
+	var sizes []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query struct {
+				SQL  string `json:"sql"`
+				From int    `json:"from"`
+				Size int    `json:"size"`
+			} `json:"query"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(body.Query.SQL, "count(*) as total") {
+			_ = json.NewEncoder(w).Encode(openobserve.OpenObserveResponse{
+				Hits: []map[string]interface{}{{"total": float64(total)}},
+			})
+			return
+		}
+		if failFrom >= 0 && body.Query.From >= failFrom {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		mu.Lock()
+		sizes = append(sizes, body.Query.Size)
+		mu.Unlock()
+		hits := []map[string]interface{}{}
+		for i := body.Query.From; i < min(body.Query.From+body.Query.Size, total); i++ {
+			hits = append(hits, map[string]interface{}{
+				"_timestamp": float64(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC).UnixMicro() + int64(i)),
+				"log":        "line",
+			})
+		}
+		_ = json.NewEncoder(w).Encode(openobserve.OpenObserveResponse{Took: 2, Hits: hits})
+	}))
+	t.Cleanup(server.Close)
+	return server, &sizes
+}
+
+func largeLimitRequest(t *testing.T, limit int) gen.QueryLogsRequestObject {
+	t.Helper()
+	scope := gen.LogsQueryRequest_SearchScope{}
+	if err := scope.FromComponentSearchScope(gen.ComponentSearchScope{Namespace: "test-ns"}); err != nil {
+		t.Fatalf("failed to build scope: %v", err)
+	}
+	return gen.QueryLogsRequestObject{Body: &gen.LogsQueryRequest{
+		SearchScope: scope,
+		StartTime:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndTime:     time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+		Limit:       &limit,
+	}}
+}
+
+func TestQueryLogs_StreamsLargeLimits(t *testing.T) {
+	server, sizes := newPagingOpenObserve(t, 2500, -1)
+	client := openobserve.NewClient(server.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	handler := NewLogsHandler(client, nil, testLogger())
+
+	resp, err := handler.QueryLogs(context.Background(), largeLimitRequest(t, 20000))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resp.(streamedLogsResponse[componentLogEntry]); !ok {
+		t.Fatalf("expected a streamed response, got %T", resp)
+	}
+
+	rec := httptest.NewRecorder()
+	if err := resp.VisitQueryLogsResponse(rec); err != nil {
+		t.Fatalf("VisitQueryLogsResponse() error = %v", err)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "application/json" {
+		t.Errorf("unexpected Content-Type: %s", got)
+	}
+
+	var body struct {
+		Logs   []json.RawMessage `json:"logs"`
+		Total  int               `json:"total"`
+		TookMs int               `json:"tookMs"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("streamed body is not valid JSON: %v", err)
+	}
+	if len(body.Logs) != 2500 || body.Total != 2500 {
+		t.Errorf("expected 2500 logs and total, got %d logs and total %d", len(body.Logs), body.Total)
+	}
+	if body.TookMs != 6 {
+		t.Errorf("expected tookMs summed over 3 chunks, got %d", body.TookMs)
+	}
+	if len(*sizes) != 3 || (*sizes)[0] != streamChunkSize {
+		t.Errorf("expected 3 chunks of %d, got sizes %v", streamChunkSize, *sizes)
+	}
+}
+
+func TestQueryLogs_StreamStopsAtLimit(t *testing.T) {
+	server, sizes := newPagingOpenObserve(t, 50000, -1)
+	client := openobserve.NewClient(server.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	handler := NewLogsHandler(client, nil, testLogger())
+
+	resp, err := handler.QueryLogs(context.Background(), largeLimitRequest(t, 10500))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	if err := resp.VisitQueryLogsResponse(rec); err != nil {
+		t.Fatalf("VisitQueryLogsResponse() error = %v", err)
+	}
+
+	var body struct {
+		Logs  []json.RawMessage `json:"logs"`
+		Total int               `json:"total"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("streamed body is not valid JSON: %v", err)
+	}
+	if len(body.Logs) != 10500 || body.Total != 50000 {
+		t.Errorf("expected 10500 logs of 50000, got %d logs and total %d", len(body.Logs), body.Total)
+	}
+	if last := (*sizes)[len(*sizes)-1]; last != 500 {
+		t.Errorf("expected the last chunk to request the remaining 500 entries, got %d", last)
+	}
+}
+
+func TestQueryLogs_StreamFailures(t *testing.T) {
+	t.Run("first chunk", func(t *testing.T) {
+		server, _ := newPagingOpenObserve(t, 5000, 0)
+		client := openobserve.NewClient(server.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+		handler := NewLogsHandler(client, nil, testLogger())
+
+		resp, err := handler.QueryLogs(context.Background(), largeLimitRequest(t, 20000))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, ok := resp.(gen.QueryLogs500JSONResponse); !ok {
+			t.Fatalf("expected 500 response, got %T", resp)
+		}
+	})
+
+	t.Run("later chunk aborts the response", func(t *testing.T) {
+		server, _ := newPagingOpenObserve(t, 5000, 2000)
+		client := openobserve.NewClient(server.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+		handler := NewLogsHandler(client, nil, testLogger())
+
+		resp, err := handler.QueryLogs(context.Background(), largeLimitRequest(t, 20000))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer func() {
+			if r := recover(); r != http.ErrAbortHandler {
+				t.Errorf("expected the response to be aborted, got %v", r)
+			}
+		}()
+		_ = resp.VisitQueryLogsResponse(httptest.NewRecorder())
+	})
+
+	t.Run("arrow", func(t *testing.T) {
+		handler := NewLogsHandler(nil, nil, testLogger())
+		ctx := context.WithValue(context.Background(), arrowFormatKey, true)
+		resp, err := handler.QueryLogs(ctx, largeLimitRequest(t, 20000))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, ok := resp.(gen.QueryLogs400JSONResponse); !ok {
+			t.Fatalf("expected 400 response, got %T", resp)
+		}
+	})
+}
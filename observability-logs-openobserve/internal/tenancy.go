@@ -0,0 +1,62 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// TenancyHeader names the namespace a request is made on behalf of. When it
+// is sent, the namespace of the request's search scope must match it.
+const TenancyHeader = "X-OpenChoreo-Namespace"
+
+const tenancyKey contextKey = "tenancy"
+
+var (
+	errTenancyHeaderMissing = errors.New(TenancyHeader + " header is required")
+	errTenancyMismatch      = errors.New("namespace does not match the " + TenancyHeader + " header")
+)
+
+// withTenancy stores the namespace of the tenancy header in the request
+// context, where authorize cross-checks it with the namespace each request
+// reads. When required is set, requests without the header are still
+// passed through, so that health checks and metrics keep working, but every
+// namespace they read is rejected. Shared views are exempt: their query
+// was checked when the share link was created.
+func withTenancy(required bool, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		namespace := strings.TrimSpace(r.Header.Get(TenancyHeader))
+		if (namespace == "" && !required) || strings.HasPrefix(r.URL.Path, sharedViewPathPrefix) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), tenancyKey, namespace)))
+	})
+}
+
+// tenancyFromContext returns the namespace of the tenancy header, and
+// whether the request is subject to the tenancy check. Requests made by the
+// adapter itself, such as the warm-up queries, are not.
+func tenancyFromContext(ctx context.Context) (string, bool) {
+	namespace, ok := ctx.Value(tenancyKey).(string)
+	return namespace, ok
+}
+
+// checkTenancy checks namespace against the tenancy header of ctx.
+func checkTenancy(ctx context.Context, namespace string) error {
+	header, ok := tenancyFromContext(ctx)
+	switch {
+	case !ok:
+		return nil
+	case header == "":
+		return errTenancyHeaderMissing
+	case header != namespace:
+		return fmt.Errorf("%w: %q", errTenancyMismatch, namespace)
+	}
+	return nil
+}
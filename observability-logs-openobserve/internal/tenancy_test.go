@@ -0,0 +1,69 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+func TestTenancyHeader(t *testing.T) {
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(openobserve.OpenObserveResponse{Hits: []map[string]interface{}{}})
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	handler := NewLogsHandler(client, nil, testLogger())
+	serve := func(srv http.Handler, namespace, header string) *httptest.ResponseRecorder {
+		body := `{"startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-02T00:00:00Z","searchScope":{"namespace":"` + namespace + `"}}`
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/logs/query", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if header != "" {
+			req.Header.Set(TenancyHeader, header)
+		}
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("optional", func(t *testing.T) {
+		srv := NewServer("0", handler, testLogger()).httpServer.Handler
+		if rec := serve(srv, "payments", ""); rec.Code != http.StatusOK {
+			t.Errorf("expected 200 without the header, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if rec := serve(srv, "payments", "payments"); rec.Code != http.StatusOK {
+			t.Errorf("expected 200 for a matching header, got %d: %s", rec.Code, rec.Body.String())
+		}
+		rec := serve(srv, "payments", "checkout")
+		if rec.Code != http.StatusForbidden || !strings.Contains(rec.Body.String(), TenancyHeader) {
+			t.Errorf("expected 403 for a mismatched header, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("required", func(t *testing.T) {
+		handler.SetTenancyHeaderRequired(true)
+		defer handler.SetTenancyHeaderRequired(false)
+		srv := NewServer("0", handler, testLogger()).httpServer.Handler
+		rec := serve(srv, "payments", "")
+		if rec.Code != http.StatusForbidden || !strings.Contains(rec.Body.String(), "required") {
+			t.Errorf("expected 403 without the header, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if rec := serve(srv, "payments", "payments"); rec.Code != http.StatusOK {
+			t.Errorf("expected 200 for a matching header, got %d: %s", rec.Code, rec.Body.String())
+		}
+
+		health := httptest.NewRecorder()
+		srv.ServeHTTP(health, httptest.NewRequest(http.MethodGet, "/health", nil))
+		if health.Code != http.StatusOK {
+			t.Errorf("expected health checks to pass without the header, got %d", health.Code)
+		}
+	})
+}
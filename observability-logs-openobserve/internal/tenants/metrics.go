@@ -0,0 +1,94 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tenants
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	ooclient "github.com/openchoreo/community-modules/common/openobserve"
+)
+
+// tenantCounters holds the OpenObserve request counters of one tenant.
+type tenantCounters struct {
+	requests        int64
+	errors          int64
+	durationSeconds float64
+}
+
+// Metrics counts the OpenObserve requests made for each tenant and the
+// requests rejected because their namespace has no tenant. It serves them in
+// the Prometheus text exposition format.
+type Metrics struct {
+	mu       sync.Mutex
+	tenants  map[string]*tenantCounters
+	rejected int64
+}
+
+func newMetrics() *Metrics {
+	return &Metrics{tenants: map[string]*tenantCounters{}}
+}
+
+// observer returns a request observer that records the requests of tenant.
+// Failed requests and 5xx responses count as errors.
+func (m *Metrics) observer(tenant string) ooclient.RequestObserver {
+	m.mu.Lock()
+	m.tenants[tenant] = &tenantCounters{}
+	m.mu.Unlock()
+	return func(info ooclient.RequestInfo) {
+		m.observe(tenant, info.Duration, info.Err != nil || info.StatusCode >= http.StatusInternalServerError)
+	}
+}
+
+func (m *Metrics) observe(tenant string, duration time.Duration, failed bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c := m.tenants[tenant]
+	c.requests++
+	c.durationSeconds += duration.Seconds()
+	if failed {
+		c.errors++
+	}
+}
+
+func (m *Metrics) reject() {
+	m.mu.Lock()
+	m.rejected++
+	m.mu.Unlock()
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	names := make([]string, 0, len(m.tenants))
+	for name := range m.tenants {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	for _, metric := range []struct {
+		name, help, kind string
+		value            func(c *tenantCounters) string
+	}{
+		{"logs_adapter_tenant_backend_requests_total", "OpenObserve requests made for the tenant.", "counter",
+			func(c *tenantCounters) string { return fmt.Sprint(c.requests) }},
+		{"logs_adapter_tenant_backend_errors_total", "OpenObserve requests of the tenant that failed or returned a 5xx status.", "counter",
+			func(c *tenantCounters) string { return fmt.Sprint(c.errors) }},
+		{"logs_adapter_tenant_backend_request_duration_seconds_sum", "Total time spent in OpenObserve requests of the tenant.", "counter",
+			func(c *tenantCounters) string { return fmt.Sprintf("%g", c.durationSeconds) }},
+	} {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", metric.name, metric.help, metric.name, metric.kind)
+		for _, name := range names {
+			fmt.Fprintf(w, "%s{tenant=%q} %s\n", metric.name, name, metric.value(m.tenants[name]))
+		}
+	}
+	fmt.Fprintf(w, "# HELP logs_adapter_tenant_rejected_requests_total Requests rejected because their namespace is not assigned to a tenant.\n"+
+		"# TYPE logs_adapter_tenant_rejected_requests_total counter\nlogs_adapter_tenant_rejected_requests_total %d\n", m.rejected)
+}
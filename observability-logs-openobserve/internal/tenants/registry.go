@@ -0,0 +1,187 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tenants maps OpenChoreo namespaces to isolated OpenObserve tenants.
+// Each tenant has its own OpenObserve organization, credentials and streams,
+// so that one adapter deployment can serve business units whose logs must
+// never be read with another unit's credentials.
+package tenants
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+// ErrUnknownNamespace is returned when a namespace is not assigned to a tenant.
+var ErrUnknownNamespace = errors.New("namespace is not assigned to a tenant")
+
+// Tenant is the OpenObserve backend of a set of namespaces. The password is
+// read from the environment variable PasswordEnv or the file PasswordFile so
+// that the tenants file itself holds no secrets.
+type Tenant struct {
+	Name         string   `json:"name"`
+	Namespaces   []string `json:"namespaces"`
+	URL          string   `json:"url,omitempty"`
+	Org          string   `json:"org"`
+	Stream       string   `json:"stream,omitempty"`
+	EventsStream string   `json:"eventsStream,omitempty"`
+	TracesStream string   `json:"tracesStream,omitempty"`
+	User         string   `json:"user"`
+	PasswordEnv  string   `json:"passwordEnv,omitempty"`
+	PasswordFile string   `json:"passwordFile,omitempty"`
+}
+
+// Defaults holds the settings of tenants that do not override them.
+type Defaults struct {
+	URL          string
+	Stream       string
+	EventsStream string
+	TracesStream string
+}
+
+// LoadFile reads a tenants file: a JSON document of the form
+// {"tenants": [{"name": ..., "namespaces": [...], ...}]}.
+func LoadFile(path string) ([]Tenant, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tenants file: %w", err)
+	}
+	var file struct {
+		Tenants []Tenant `json:"tenants"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse tenants file: %w", err)
+	}
+	return file.Tenants, nil
+}
+
+// password returns the tenant's OpenObserve password.
+func (t Tenant) password() (string, error) {
+	switch {
+	case t.PasswordEnv != "":
+		if v := os.Getenv(t.PasswordEnv); v != "" {
+			return v, nil
+		}
+		return "", fmt.Errorf("environment variable %s is not set", t.PasswordEnv)
+	case t.PasswordFile != "":
+		data, err := os.ReadFile(t.PasswordFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read password file: %w", err)
+		}
+		if v := strings.TrimSpace(string(data)); v != "" {
+			return v, nil
+		}
+		return "", fmt.Errorf("password file %s is empty", t.PasswordFile)
+	default:
+		return "", fmt.Errorf("passwordEnv or passwordFile is required")
+	}
+}
+
+// Registry resolves the OpenObserve client of the tenant a namespace belongs to.
+// It is safe for concurrent use.
+type Registry struct {
+	names       []string
+	clients     map[string]*openobserve.Client
+	byNamespace map[string]string
+	metrics     *Metrics
+}
+
+// NewRegistry validates tenants and creates a client for each of them. A
+// namespace may belong to one tenant only.
+func NewRegistry(tenants []Tenant, defaults Defaults, logger *slog.Logger) (*Registry, error) {
+	if len(tenants) == 0 {
+		return nil, fmt.Errorf("at least one tenant is required")
+	}
+	r := &Registry{
+		clients:     make(map[string]*openobserve.Client, len(tenants)),
+		byNamespace: make(map[string]string),
+		metrics:     newMetrics(),
+	}
+	for i, t := range tenants {
+		if t.Name == "" {
+			return nil, fmt.Errorf("tenant %d: name is required", i)
+		}
+		if _, ok := r.clients[t.Name]; ok {
+			return nil, fmt.Errorf("tenant %q is defined more than once", t.Name)
+		}
+		if t.Org == "" || t.User == "" {
+			return nil, fmt.Errorf("tenant %q: org and user are required", t.Name)
+		}
+		if len(t.Namespaces) == 0 {
+			return nil, fmt.Errorf("tenant %q: at least one namespace is required", t.Name)
+		}
+		password, err := t.password()
+		if err != nil {
+			return nil, fmt.Errorf("tenant %q: %w", t.Name, err)
+		}
+		for _, ns := range t.Namespaces {
+			if other, ok := r.byNamespace[ns]; ok {
+				return nil, fmt.Errorf("namespace %q is assigned to tenants %q and %q", ns, other, t.Name)
+			}
+			r.byNamespace[ns] = t.Name
+		}
+
+		url, stream, eventsStream, tracesStream := t.URL, t.Stream, t.EventsStream, t.TracesStream
+		if url == "" {
+			url = defaults.URL
+		}
+		if stream == "" {
+			stream = defaults.Stream
+		}
+		if eventsStream == "" {
+			eventsStream = defaults.EventsStream
+		}
+		if tracesStream == "" {
+			tracesStream = defaults.TracesStream
+		}
+		client := openobserve.NewClient(url, t.Org, stream, eventsStream, t.User, password,
+			logger.With(slog.String("tenant", t.Name)))
+		client.SetTracesStream(tracesStream)
+		client.AddRequestObserver(r.metrics.observer(t.Name))
+		r.clients[t.Name] = client
+		r.names = append(r.names, t.Name)
+	}
+	return r, nil
+}
+
+// Client returns the client of the tenant namespace belongs to, or
+// ErrUnknownNamespace. Unknown namespaces are counted as rejected requests.
+func (r *Registry) Client(namespace string) (*openobserve.Client, error) {
+	if name, ok := r.byNamespace[namespace]; ok {
+		return r.clients[name], nil
+	}
+	r.metrics.reject()
+	return nil, fmt.Errorf("%w: %q", ErrUnknownNamespace, namespace)
+}
+
+// Clients returns the clients of all tenants in the order they were defined.
+func (r *Registry) Clients() []*openobserve.Client {
+	clients := make([]*openobserve.Client, len(r.names))
+	for i, name := range r.names {
+		clients[i] = r.clients[name]
+	}
+	return clients
+}
+
+// Metrics returns the per-tenant metrics of the registry's clients.
+func (r *Registry) Metrics() *Metrics {
+	return r.metrics
+}
+
+// GetComponentLogs queries the component logs of params.Namespace with the
+// client of its tenant, so that the registry can serve as the logs source of
+// log exports.
+func (r *Registry) GetComponentLogs(ctx context.Context, params openobserve.ComponentLogsParams) (*openobserve.ComponentLogsResult, error) {
+	client, err := r.Client(params.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	return client.GetComponentLogs(ctx, params)
+}
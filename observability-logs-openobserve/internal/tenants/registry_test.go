@@ -0,0 +1,152 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tenants
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tenants.json")
+	data := `{"tenants":[{"name":"payments","namespaces":["pay","pay-ci"],"org":"payments","user":"pay@example.com","passwordEnv":"PAY_PASSWORD"}]}`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile() error = %v", err)
+	}
+	if len(got) != 1 || got[0].Name != "payments" || len(got[0].Namespaces) != 2 || got[0].PasswordEnv != "PAY_PASSWORD" {
+		t.Errorf("unexpected tenants: %+v", got)
+	}
+
+	if err := os.WriteFile(path, []byte("tenants:"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadFile(path); err == nil {
+		t.Error("expected error for a malformed file")
+	}
+}
+
+func TestNewRegistry(t *testing.T) {
+	t.Setenv("PAY_PASSWORD", "pay-secret")
+	passwordFile := filepath.Join(t.TempDir(), "password")
+	if err := os.WriteFile(passwordFile, []byte("hr-secret\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	payments := Tenant{Name: "payments", Namespaces: []string{"pay"}, Org: "payments", User: "pay", PasswordEnv: "PAY_PASSWORD"}
+	hr := Tenant{Name: "hr", Namespaces: []string{"hr"}, Org: "hr", User: "hr", PasswordFile: passwordFile}
+
+	registry, err := NewRegistry([]Tenant{payments, hr}, Defaults{URL: "http://oo:5080"}, testLogger())
+	if err != nil {
+		t.Fatalf("NewRegistry() error = %v", err)
+	}
+	if len(registry.Clients()) != 2 {
+		t.Errorf("expected 2 clients, got %d", len(registry.Clients()))
+	}
+	if _, err := registry.Client("pay"); err != nil {
+		t.Errorf("Client(pay) error = %v", err)
+	}
+	if _, err := registry.Client("marketing"); !errors.Is(err, ErrUnknownNamespace) {
+		t.Errorf("expected ErrUnknownNamespace, got %v", err)
+	}
+
+	for name, tenants := range map[string][]Tenant{
+		"no tenants":         nil,
+		"missing name":       {{Namespaces: []string{"a"}, Org: "a", User: "a", PasswordEnv: "PAY_PASSWORD"}},
+		"duplicate name":     {payments, payments},
+		"missing org":        {{Name: "a", Namespaces: []string{"a"}, User: "a", PasswordEnv: "PAY_PASSWORD"}},
+		"missing namespaces": {{Name: "a", Org: "a", User: "a", PasswordEnv: "PAY_PASSWORD"}},
+		"missing password":   {{Name: "a", Namespaces: []string{"a"}, Org: "a", User: "a"}},
+		"unset password env": {{Name: "a", Namespaces: []string{"a"}, Org: "a", User: "a", PasswordEnv: "UNSET_PASSWORD"}},
+		"shared namespace":   {payments, {Name: "b", Namespaces: []string{"pay"}, Org: "b", User: "b", PasswordEnv: "PAY_PASSWORD"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewRegistry(tenants, Defaults{}, testLogger()); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestRegistryIsolation(t *testing.T) {
+	t.Setenv("PAY_PASSWORD", "pay-secret")
+	t.Setenv("HR_PASSWORD", "hr-secret")
+
+	type call struct{ org, user, password string }
+	var calls []call
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, _ := r.BasicAuth()
+		calls = append(calls, call{org: strings.Split(r.URL.Path, "/")[2], user: user, password: password})
+		if user == "hr" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"took":1,"hits":[]}`))
+	}))
+	defer server.Close()
+
+	registry, err := NewRegistry([]Tenant{
+		{Name: "payments", Namespaces: []string{"pay"}, Org: "payments", User: "pay", PasswordEnv: "PAY_PASSWORD"},
+		{Name: "hr", Namespaces: []string{"hr"}, Org: "hr", User: "hr", PasswordEnv: "HR_PASSWORD"},
+	}, Defaults{URL: server.URL, Stream: "default", EventsStream: "k8s_events"}, testLogger())
+	if err != nil {
+		t.Fatalf("NewRegistry() error = %v", err)
+	}
+
+	if _, err := registry.GetComponentLogs(context.Background(), openobserve.ComponentLogsParams{Namespace: "pay"}); err != nil {
+		t.Fatalf("GetComponentLogs(pay) error = %v", err)
+	}
+	for _, c := range calls {
+		if c != (call{"payments", "pay", "pay-secret"}) {
+			t.Errorf("payments query used another tenant's backend: %+v", c)
+		}
+	}
+
+	calls = nil
+	if _, err := registry.GetComponentLogs(context.Background(), openobserve.ComponentLogsParams{Namespace: "hr"}); err == nil {
+		t.Error("expected the hr backend failure to be returned")
+	}
+	if len(calls) == 0 || calls[0] != (call{"hr", "hr", "hr-secret"}) {
+		t.Errorf("hr query used another tenant's backend: %+v", calls)
+	}
+
+	calls = nil
+	if _, err := registry.GetComponentLogs(context.Background(), openobserve.ComponentLogsParams{Namespace: "marketing"}); !errors.Is(err, ErrUnknownNamespace) {
+		t.Errorf("expected ErrUnknownNamespace, got %v", err)
+	}
+	if len(calls) != 0 {
+		t.Errorf("expected no backend call for an unknown namespace, got %+v", calls)
+	}
+
+	rec := httptest.NewRecorder()
+	registry.Metrics().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := rec.Body.String()
+	for _, want := range []string{
+		`logs_adapter_tenant_backend_requests_total{tenant="payments"} 2`,
+		`logs_adapter_tenant_backend_errors_total{tenant="payments"} 0`,
+		`logs_adapter_tenant_backend_errors_total{tenant="hr"} 1`,
+		"logs_adapter_tenant_rejected_requests_total 1",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected metrics to contain %q, got:\n%s", want, body)
+		}
+	}
+}
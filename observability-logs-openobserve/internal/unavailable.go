@@ -0,0 +1,113 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+// serviceUnavailable is the title of the errors of requests that failed
+// because OpenObserve is unavailable. The API spec has no such title, but
+// clients only branch on the status code.
+const serviceUnavailable gen.ErrorResponseTitle = "serviceUnavailable"
+
+// backendUnavailableMessage tells clients that a request may succeed later.
+const backendUnavailableMessage = "the log backend is temporarily unavailable, retry later"
+
+// backendUnavailableResponse is the 503 response of queries that failed
+// because OpenObserve is unavailable.
+type backendUnavailableResponse struct{}
+
+func (backendUnavailableResponse) visit(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusServiceUnavailable)
+	return json.NewEncoder(w).Encode(gen.ErrorResponse{
+		Title:   ptr(serviceUnavailable),
+		Message: ptr(backendUnavailableMessage),
+	})
+}
+
+func (response backendUnavailableResponse) VisitQueryLogsResponse(w http.ResponseWriter) error {
+	return response.visit(w)
+}
+
+func (response backendUnavailableResponse) VisitQueryEventsResponse(w http.ResponseWriter) error {
+	return response.visit(w)
+}
+
+// statusClientClosedRequest is the status of requests whose client went away
+// before they were answered, after the nginx convention. It is never seen by
+// the client, but keeps abandoned requests apart from failures in the
+// metrics and SLIs.
+const statusClientClosedRequest = 499
+
+// clientClosedRequest is the title of the errors of abandoned requests.
+const clientClosedRequest gen.ErrorResponseTitle = "clientClosedRequest"
+
+// clientClosedResponse is the response of queries canceled because their
+// client went away.
+type clientClosedResponse struct{}
+
+func (clientClosedResponse) visit(w http.ResponseWriter) error {
+	writeJSONError(w, statusClientClosedRequest, clientClosedRequest, "the client closed the request")
+	return nil
+}
+
+func (response clientClosedResponse) VisitQueryLogsResponse(w http.ResponseWriter) error {
+	return response.visit(w)
+}
+
+func (response clientClosedResponse) VisitQueryEventsResponse(w http.ResponseWriter) error {
+	return response.visit(w)
+}
+
+// queryLogsError returns the response of a logs query that failed with err.
+func queryLogsError(err error) gen.QueryLogsResponseObject {
+	if errors.Is(err, context.Canceled) {
+		return clientClosedResponse{}
+	}
+	if errors.Is(err, openobserve.ErrBackendUnavailable) {
+		return backendUnavailableResponse{}
+	}
+	return gen.QueryLogs500JSONResponse{
+		Title:   ptr(gen.InternalServerError),
+		Message: ptr("internal server error"),
+	}
+}
+
+// queryEventsError returns the response of an events query that failed
+// with err.
+func queryEventsError(err error) gen.QueryEventsResponseObject {
+	if errors.Is(err, context.Canceled) {
+		return clientClosedResponse{}
+	}
+	if errors.Is(err, openobserve.ErrBackendUnavailable) {
+		return backendUnavailableResponse{}
+	}
+	return gen.QueryEvents500JSONResponse{
+		Title:   ptr(gen.InternalServerError),
+		Message: ptr("internal server error"),
+	}
+}
+
+// writeBackendError writes the response of a request whose OpenObserve call
+// failed with err: 499 when the call was canceled because the client went
+// away, 503 when OpenObserve is unavailable and 500 otherwise.
+func writeBackendError(w http.ResponseWriter, err error) {
+	if errors.Is(err, context.Canceled) {
+		writeJSONError(w, statusClientClosedRequest, clientClosedRequest, "the client closed the request")
+		return
+	}
+	if errors.Is(err, openobserve.ErrBackendUnavailable) {
+		writeJSONError(w, http.StatusServiceUnavailable, serviceUnavailable, backendUnavailableMessage)
+		return
+	}
+	writeJSONError(w, http.StatusInternalServerError, gen.InternalServerError, "internal server error")
+}
@@ -0,0 +1,57 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+func TestBackendUnavailable(t *testing.T) {
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := ooServer.URL
+	ooServer.Close()
+
+	client := openobserve.NewClient(url, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	client.SetRetryPolicy(openobserve.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+	handler := NewLogsHandler(client, nil, testLogger())
+	srv := NewServer("0", handler, testLogger()).httpServer.Handler
+
+	for _, tt := range []struct{ method, target, body string }{
+		{http.MethodPost, "/api/v1/logs/query", `{"searchScope":{"namespace":"test-ns"},"startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-01T01:00:00Z"}`},
+		{http.MethodPost, "/api/v1/events/query", `{"searchScope":{"namespace":"test-ns"},"startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-01T01:00:00Z"}`},
+		{http.MethodGet, "/api/v1/logs/sources?namespace=test-ns", ""},
+	} {
+		req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		if rec.Code != http.StatusServiceUnavailable || !strings.Contains(rec.Body.String(), "serviceUnavailable") {
+			t.Errorf("%s %s: expected 503, got %d: %s", tt.method, tt.target, rec.Code, rec.Body.String())
+		}
+	}
+}
+
+func TestBackendError_ClientClosed(t *testing.T) {
+	err := fmt.Errorf("search failed: %w", context.Canceled)
+	if _, ok := queryLogsError(err).(clientClosedResponse); !ok {
+		t.Errorf("queryLogsError: expected clientClosedResponse, got %T", queryLogsError(err))
+	}
+	if _, ok := queryEventsError(err).(clientClosedResponse); !ok {
+		t.Errorf("queryEventsError: expected clientClosedResponse, got %T", queryEventsError(err))
+	}
+
+	rec := httptest.NewRecorder()
+	writeBackendError(rec, err)
+	if rec.Code != statusClientClosedRequest || !strings.Contains(rec.Body.String(), "clientClosedRequest") {
+		t.Errorf("writeBackendError: expected 499, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
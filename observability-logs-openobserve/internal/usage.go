@@ -0,0 +1,93 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/openchoreo/community-modules/common/prefer"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/usage"
+)
+
+// usageNotes are the scope type and features handlers report for a request.
+type usageNotes struct {
+	scope    string
+	features []string
+}
+
+type usageNotesKey struct{}
+
+// noteUsage reports the scope type, when not empty, and the features of the
+// request of ctx to withUsage.
+func noteUsage(ctx context.Context, scope string, features ...string) {
+	notes, ok := ctx.Value(usageNotesKey{}).(*usageNotes)
+	if !ok {
+		return
+	}
+	if scope != "" {
+		notes.scope = scope
+	}
+	notes.features = append(notes.features, features...)
+}
+
+// withUsage records the routed requests served successfully with recorder,
+// with the scope type and features noted by their handler and those of the
+// query extensions, Arrow or export format and preferences in their context. It must
+// wrap withShadowLogging, as the route pattern is read from the request it
+// gives the mux. Health checks and metrics scrapes are not recorded.
+// Requests are passed through untouched when no recorder is set.
+func withUsage(recorder *usage.Recorder, next http.Handler) http.Handler {
+	if recorder == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health" || r.URL.Path == "/metrics" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		notes := &usageNotes{}
+		r = r.WithContext(context.WithValue(r.Context(), usageNotesKey{}, notes))
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(sw, r)
+		if sw.status >= http.StatusBadRequest || r.Pattern == "" {
+			return
+		}
+		_, route, found := strings.Cut(r.Pattern, " ")
+		if !found {
+			route = r.Pattern
+		}
+
+		features := notes.features
+		ext := queryExtensionsFromContext(r.Context())
+		if ext.Query != "" {
+			features = append(features, usage.FeatureSearchQuery)
+		}
+		if len(ext.Extract) > 0 {
+			features = append(features, usage.FeatureExtract)
+		}
+		if ext.SortField != "" {
+			features = append(features, usage.FeatureSortField)
+		}
+		if len(ext.Sources) > 0 {
+			features = append(features, usage.FeatureSources)
+		}
+		if arrowFormatFromContext(r.Context()) {
+			features = append(features, usage.FeatureArrow)
+		}
+		switch exportFormatFromContext(r.Context()) {
+		case exportCSV:
+			features = append(features, usage.FeatureCSV)
+		case exportNDJSON:
+			features = append(features, usage.FeatureNDJSON)
+		}
+		if prefer.FromContext(r.Context()).MaxResults > 0 {
+			features = append(features, usage.FeaturePreferMaxResults)
+		}
+		slices.Sort(features)
+		recorder.Observe(route, notes.scope, slices.Compact(features))
+	})
+}
@@ -0,0 +1,116 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package usage counts the requests served by the adapter by endpoint, type
+// of search scope and optional feature, so that maintainers can tell which
+// capabilities are used before evolving the API. Only names from fixed sets
+// are recorded, never request content.
+package usage
+
+import (
+	"cmp"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+)
+
+// Types of search scopes.
+const (
+	ScopeNone      = "none"
+	ScopeComponent = "component"
+	ScopeWorkflow  = "workflow"
+	ScopeGateway   = "gateway"
+	// ScopeSources is a query of several log sources at once.
+	ScopeSources = "sources"
+)
+
+// Features of requests.
+const (
+	FeatureSearchPhrase     = "search_phrase"
+	FeatureSearchQuery      = "search_query"
+	FeatureLogLevels        = "log_levels"
+	FeatureExtract          = "extract"
+	FeatureSortField        = "sort_field"
+	FeatureSources          = "sources"
+	FeatureArrow            = "format_arrow"
+	FeatureNDJSON           = "format_ndjson"
+	FeatureCSV              = "format_csv"
+	FeatureStreaming        = "streaming"
+	FeatureEnvironments     = "environments"
+	FeatureWorkflowStep     = "workflow_step"
+	FeaturePreferMaxResults = "prefer_max_results"
+)
+
+type requestKey struct {
+	handler, scope string
+}
+
+type featureKey struct {
+	handler, feature string
+}
+
+// Recorder counts requests by endpoint and scope type, and their features by
+// endpoint. It serves the counts in the Prometheus text exposition format.
+type Recorder struct {
+	mu       sync.Mutex
+	requests map[requestKey]int64
+	features map[featureKey]int64
+}
+
+// NewRecorder returns a recorder with no requests.
+func NewRecorder() *Recorder {
+	return &Recorder{
+		requests: map[requestKey]int64{},
+		features: map[featureKey]int64{},
+	}
+}
+
+// Observe records a request served by handler, a route pattern, for a scope
+// of the given type (ScopeNone when empty), using features.
+func (r *Recorder) Observe(handler, scope string, features []string) {
+	if scope == "" {
+		scope = ScopeNone
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests[requestKey{handler, scope}]++
+	for _, feature := range features {
+		r.features[featureKey{handler, feature}]++
+	}
+}
+
+// ServeHTTP writes the counts in the Prometheus text exposition format.
+func (r *Recorder) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	fmt.Fprint(w, "# HELP logs_adapter_usage_requests_total Requests served successfully, by route and search scope type.\n"+
+		"# TYPE logs_adapter_usage_requests_total counter\n")
+	requestKeys := sortedKeys(r.requests, func(a, b requestKey) int {
+		return cmp.Or(cmp.Compare(a.handler, b.handler), cmp.Compare(a.scope, b.scope))
+	})
+	for _, k := range requestKeys {
+		fmt.Fprintf(w, "logs_adapter_usage_requests_total{handler=%q,scope=%q} %d\n", k.handler, k.scope, r.requests[k])
+	}
+	fmt.Fprint(w, "# HELP logs_adapter_usage_features_total Requests served successfully using an optional feature, by route and feature.\n"+
+		"# TYPE logs_adapter_usage_features_total counter\n")
+	featureKeys := sortedKeys(r.features, func(a, b featureKey) int {
+		return cmp.Or(cmp.Compare(a.handler, b.handler), cmp.Compare(a.feature, b.feature))
+	})
+	for _, k := range featureKeys {
+		fmt.Fprintf(w, "logs_adapter_usage_features_total{handler=%q,feature=%q} %d\n", k.handler, k.feature, r.features[k])
+	}
+}
+
+// sortedKeys returns the keys of m sorted by cmp, so that scrapes list the
+// series in a stable order.
+func sortedKeys[K comparable, V any](m map[K]V, cmp func(a, b K) int) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, cmp)
+	return keys
+}
@@ -0,0 +1,33 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package usage
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRecorder(t *testing.T) {
+	r := NewRecorder()
+	r.Observe("/api/v1/logs/query", ScopeWorkflow, []string{FeatureSearchPhrase})
+	r.Observe("/api/v1/logs/query", ScopeComponent, []string{FeatureSearchPhrase, FeatureArrow})
+	r.Observe("/api/v1/logs/query", ScopeComponent, nil)
+	r.Observe("/api/v1/logs/sources", "", nil)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	want := `# HELP logs_adapter_usage_requests_total Requests served successfully, by route and search scope type.
+# TYPE logs_adapter_usage_requests_total counter
+logs_adapter_usage_requests_total{handler="/api/v1/logs/query",scope="component"} 2
+logs_adapter_usage_requests_total{handler="/api/v1/logs/query",scope="workflow"} 1
+logs_adapter_usage_requests_total{handler="/api/v1/logs/sources",scope="none"} 1
+# HELP logs_adapter_usage_features_total Requests served successfully using an optional feature, by route and feature.
+# TYPE logs_adapter_usage_features_total counter
+logs_adapter_usage_features_total{handler="/api/v1/logs/query",feature="format_arrow"} 1
+logs_adapter_usage_features_total{handler="/api/v1/logs/query",feature="search_phrase"} 2
+`
+	if got := rec.Body.String(); got != want {
+		t.Errorf("unexpected metrics:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/usage"
+)
+
+func TestWithUsage(t *testing.T) {
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"took":1,"total":0,"hits":[]}`))
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	handler := NewLogsHandler(client, nil, testLogger())
+	handler.SetUsageRecorder(usage.NewRecorder())
+	srv := NewServer("0", handler, testLogger()).httpServer.Handler
+
+	for _, body := range []string{
+		`{"searchScope":{"namespace":"team-a","componentUid":"c1"},"startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-01T01:00:00Z","searchPhrase":"timeout","sortField":"ingestTime"}`,
+		`{"searchScope":{"namespace":"team-a","workflowRunName":"build-1"},"startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-01T01:00:00Z","logLevels":["ERROR"]}`,
+		// Rejected requests are not counted.
+		`{"searchScope":{},"startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-01T01:00:00Z"}`,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/logs/query", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		srv.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	for _, want := range []string{
+		`logs_adapter_usage_requests_total{handler="/api/v1/logs/query",scope="component"} 1`,
+		`logs_adapter_usage_requests_total{handler="/api/v1/logs/query",scope="workflow"} 1`,
+		`logs_adapter_usage_features_total{handler="/api/v1/logs/query",feature="search_phrase"} 1`,
+		`logs_adapter_usage_features_total{handler="/api/v1/logs/query",feature="sort_field"} 1`,
+		`logs_adapter_usage_features_total{handler="/api/v1/logs/query",feature="log_levels"} 1`,
+	} {
+		if !strings.Contains(rec.Body.String(), want) {
+			t.Errorf("expected %q in the metrics:\n%s", want, rec.Body.String())
+		}
+	}
+	if strings.Contains(rec.Body.String(), `scope="none"`) || strings.Contains(rec.Body.String(), `handler="/metrics"`) {
+		t.Errorf("expected rejected requests and scrapes to be left out:\n%s", rec.Body.String())
+	}
+}
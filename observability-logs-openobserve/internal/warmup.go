@@ -0,0 +1,150 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/access"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/scheduler"
+)
+
+// Warm-up tasks.
+const (
+	// WarmupConnections opens connections to OpenObserve, with their TLS handshakes.
+	WarmupConnections = "connections"
+	// WarmupSchemas fetches the schemas of the streams.
+	WarmupSchemas = "schemas"
+	// WarmupHistograms computes the default level histograms of the
+	// components of the key namespaces.
+	WarmupHistograms = "histograms"
+)
+
+// WarmupTasks lists the warm-up tasks.
+var WarmupTasks = []string{WarmupConnections, WarmupSchemas, WarmupHistograms}
+
+// maxWarmupComponents bounds the components per namespace whose histograms are warmed up.
+const maxWarmupComponents = 20
+
+// WarmupOptions selects the warm-up tasks run by WarmUp.
+type WarmupOptions struct {
+	Tasks []string
+	// Namespaces are the key namespaces whose histograms are computed.
+	Namespaces []string
+	// Connections is the number of connections opened to each OpenObserve client.
+	Connections int
+}
+
+// WarmUp runs the warm-up tasks of opts in parallel so that the first user
+// queries after a deploy do not pay for cold connections and caches. Failed
+// tasks are logged and otherwise ignored: warming up is an optimization and
+// never keeps the adapter from starting. It returns when all tasks are done
+// or ctx is done.
+func (h *LogsHandler) WarmUp(ctx context.Context, opts WarmupOptions) {
+	clients := []*openobserve.Client{h.client}
+	if h.tenants != nil {
+		clients = append(clients, h.tenants.Clients()...)
+	}
+
+	tasks := map[string]func(ctx context.Context) (string, error){
+		WarmupConnections: func(ctx context.Context) (string, error) {
+			var errs []error
+			for _, client := range clients {
+				errs = append(errs, client.WarmConnections(ctx, opts.Connections))
+			}
+			return fmt.Sprintf("%d connections to %d clients", opts.Connections, len(clients)), errors.Join(errs...)
+		},
+		WarmupSchemas: func(ctx context.Context) (string, error) {
+			var fields int
+			var errs []error
+			for _, client := range clients {
+				n, err := client.WarmSchemas(ctx)
+				fields += n
+				errs = append(errs, err)
+			}
+			return fmt.Sprintf("%d fields", fields), errors.Join(errs...)
+		},
+		WarmupHistograms: func(ctx context.Context) (string, error) {
+			var histograms int
+			var errs []error
+			for _, namespace := range opts.Namespaces {
+				n, err := h.warmHistograms(ctx, namespace)
+				histograms += n
+				if err != nil {
+					errs = append(errs, fmt.Errorf("namespace %q: %w", namespace, err))
+				}
+			}
+			return fmt.Sprintf("%d histograms", histograms), errors.Join(errs...)
+		},
+	}
+
+	var wg sync.WaitGroup
+	for _, name := range WarmupTasks {
+		if !slices.Contains(opts.Tasks, name) {
+			continue
+		}
+		wg.Add(1)
+		go func(name string, task func(ctx context.Context) (string, error)) {
+			defer wg.Done()
+			start := time.Now()
+			summary, err := task(ctx)
+			logger := h.logger.With(slog.String("task", name), slog.Duration("duration", time.Since(start)))
+			if err != nil {
+				logger.Warn("Warm-up task failed", slog.String("result", summary), slog.Any("error", err))
+				return
+			}
+			logger.Info("Warm-up task completed", slog.String("result", summary))
+		}(name, tasks[name])
+	}
+	wg.Wait()
+}
+
+// warmHistograms computes the level histograms the component pages of
+// namespace request by default, for its components that logged in that
+// window, returning the number computed.
+func (h *LogsHandler) warmHistograms(ctx context.Context, namespace string) (int, error) {
+	ctx = scheduler.WithClass(ctx, scheduler.ClassSummary)
+	client, err := h.clientFor(ctx, namespace, access.Aggregates)
+	if err != nil {
+		return 0, err
+	}
+	end := time.Now()
+	start := end.Add(-defaultLevelHistogramWindow)
+	sources, err := client.GetLogSources(ctx, openobserve.LogSourcesParams{Namespace: namespace, StartTime: start, EndTime: end})
+	if err != nil {
+		return 0, err
+	}
+
+	interval, _ := levelHistogramInterval("", defaultLevelHistogramWindow)
+	var components []string
+	for _, source := range sources.Sources {
+		if !slices.Contains(components, source.ComponentUID) && len(components) < maxWarmupComponents {
+			components = append(components, source.ComponentUID)
+		}
+	}
+	var computed int
+	var errs []error
+	for _, component := range components {
+		_, err := client.GetComponentLevelHistogram(ctx, openobserve.LevelHistogramParams{
+			Namespace:    namespace,
+			ComponentUID: component,
+			StartTime:    start,
+			EndTime:      end,
+			Interval:     interval,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("component %q: %w", component, err))
+			continue
+		}
+		computed++
+	}
+	return computed, errors.Join(errs...)
+}
@@ -0,0 +1,105 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+)
+
+func TestWarmUp(t *testing.T) {
+	var mu sync.Mutex
+	var histograms []string
+	var healthChecks, schemas int
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.URL.Path == "/healthz":
+			healthChecks++
+			w.Write([]byte(`{"status":"ok"}`))
+			return
+		case strings.HasSuffix(r.URL.Path, "/schema"):
+			schemas++
+			w.Write([]byte(`{"schema":[{"name":"_timestamp"},{"name":"log"}]}`))
+			return
+		}
+		var body struct {
+			Query struct {
+				SQL string `json:"sql"`
+			} `json:"query"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		resp := openobserve.OpenObserveResponse{}
+		if strings.Contains(body.Query.SQL, "histogram(") {
+			histograms = append(histograms, body.Query.SQL)
+		} else {
+			resp.Hits = []map[string]interface{}{
+				{"component_uid": "comp-1", "environment_uid": "env-1", "log_count": float64(42)},
+				{"component_uid": "comp-1", "environment_uid": "env-2", "log_count": float64(7)},
+				{"component_uid": "comp-2", "environment_uid": "env-1", "log_count": float64(3)},
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer ooServer.Close()
+
+	var logs bytes.Buffer
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	handler := NewLogsHandler(client, nil, slog.New(slog.NewTextHandler(&logs, nil)))
+	handler.WarmUp(context.Background(), WarmupOptions{
+		Tasks:       WarmupTasks,
+		Namespaces:  []string{"payments"},
+		Connections: 2,
+	})
+
+	if healthChecks != 2 {
+		t.Errorf("expected 2 connections, got %d", healthChecks)
+	}
+	if schemas != 2 {
+		t.Errorf("expected the schemas of the logs and events streams, got %d", schemas)
+	}
+	if len(histograms) != 2 {
+		t.Fatalf("expected a histogram per component, got %d", len(histograms))
+	}
+	for _, sql := range histograms {
+		if !strings.Contains(sql, "'60 seconds'") {
+			t.Errorf("expected the default interval in %s", sql)
+		}
+	}
+	for _, want := range []string{"task=connections", "task=schemas", "result=\"4 fields\"", "result=\"2 histograms\""} {
+		if !strings.Contains(logs.String(), want) {
+			t.Errorf("expected logs to contain %q, got:\n%s", want, logs.String())
+		}
+	}
+}
+
+func TestWarmUpFailuresAreLogged(t *testing.T) {
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer ooServer.Close()
+
+	var logs bytes.Buffer
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "k8s_events", "admin", "pass", testLogger())
+	handler := NewLogsHandler(client, nil, slog.New(slog.NewTextHandler(&logs, nil)))
+	handler.WarmUp(context.Background(), WarmupOptions{
+		Tasks:      []string{WarmupSchemas, WarmupHistograms},
+		Namespaces: []string{"payments"},
+	})
+
+	if got := strings.Count(logs.String(), "Warm-up task failed"); got != 2 {
+		t.Errorf("expected 2 failed tasks, got %d:\n%s", got, logs.String())
+	}
+}
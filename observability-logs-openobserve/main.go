@@ -14,10 +14,33 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata" // time zones for the tz query parameter; the image has no zoneinfo
 
+	"github.com/openchoreo/community-modules/common/access"
+	"github.com/openchoreo/community-modules/common/audit"
+	"github.com/openchoreo/community-modules/common/auth"
+	"github.com/openchoreo/community-modules/common/metrics"
+	"github.com/openchoreo/community-modules/common/ratelimit"
+	"github.com/openchoreo/community-modules/common/respcache"
+	"github.com/openchoreo/community-modules/common/rollups"
+	"github.com/openchoreo/community-modules/common/shares"
 	app "github.com/openchoreo/community-modules/observability-logs-openobserve/internal"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/alertsync"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/annotations"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/diagnostics"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/export"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/formats"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/holds"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/loki"
 	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/observer"
 	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/openobserve"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/presets"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/scheduler"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/sessions"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/shadow"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/slis"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/tenants"
+	"github.com/openchoreo/community-modules/observability-logs-openobserve/internal/usage"
 )
 
 func main() {
@@ -34,12 +57,18 @@ func main() {
 		Level: cfg.LogLevel,
 	}))
 
+	if cfg.Backend == app.BackendLoki {
+		runLokiBackend(cfg, logger)
+		return
+	}
+
 	logger.Info("Configurations loaded from environment variables successfully",
 		slog.String("Log Level", cfg.LogLevel.String()),
 		slog.String("OpenObserve URL", cfg.OpenObserveURL),
 		slog.String("OpenObserve Org", cfg.OpenObserveOrg),
 		slog.String("OpenObserve Stream", cfg.OpenObserveStream),
 		slog.String("OpenObserve Events Stream", cfg.OpenObserveEventsStream),
+		slog.String("OpenObserve Traces Stream", cfg.OpenObserveTracesStream),
 		slog.String("OpenObserve User", cfg.OpenObserveUser),
 		slog.String("OpenObserve Password", string(cfg.OpenObservePassword[0])+"*****"),
 		slog.String("Server Port", cfg.ServerPort),
@@ -54,6 +83,12 @@ func main() {
 		cfg.OpenObservePassword,
 		logger,
 	)
+	if cfg.SecondaryPassword != "" {
+		client.SetSecondaryCredentials(cfg.SecondaryUser, cfg.SecondaryPassword)
+		logger.Info("Secondary OpenObserve credentials configured", slog.String("user", cfg.SecondaryUser))
+	}
+	client.SetTracesStream(cfg.OpenObserveTracesStream)
+	client.SetGatewayStream(cfg.GatewayLogStream)
 
 	// Check OpenObserve connectivity when starting the adapter. If the connection fails,
 	// exit with an error because the adapter cannot function without connecting to
@@ -97,9 +132,370 @@ func main() {
 
 	logger.Info("Successfully connected to OpenObserve")
 
+	// Pick up rotated passwords from the secret store without a restart.
+	watchCtx, stopWatch := context.WithCancel(context.Background())
+	defer stopWatch()
+	if cfg.PasswordSecret != nil {
+		go cfg.PasswordSecret.Watch(watchCtx, func(password string) {
+			client.SetCredentials(cfg.OpenObserveUser, password)
+		}, logger.With(slog.String("secret", "OPENOBSERVE_PASSWORD_SOURCE")))
+		logger.Info("Watching the OpenObserve password for rotation",
+			slog.Duration("interval", cfg.SecretRefreshInterval))
+	}
+
 	// Create observer client and handlers
-	observerClient := observer.NewClient(cfg.ObserverURL)
+	observerClient := newObserverClient(cfg, logger)
 	logsHandler := app.NewLogsHandler(client, observerClient, logger)
+	logsHandler.SetAlertDestinations(cfg.AlertDestinations)
+	logsHandler.SetGatewayNamespace(cfg.GatewayNamespace)
+	logsHandler.SetTenancyHeaderRequired(cfg.RequireTenancyHeader)
+
+	// Log exports read through the tenant registry in multi-tenant mode so
+	// that each export uses the credentials of its namespace's tenant.
+	var exportSource export.LogsSource = client
+	clients := []*openobserve.Client{client}
+	if cfg.TenantsFile != "" {
+		tenantList, err := tenants.LoadFile(cfg.TenantsFile)
+		if err != nil {
+			logger.Error("Failed to load tenants", slog.Any("error", err))
+			os.Exit(1)
+		}
+		registry, err := tenants.NewRegistry(tenantList, tenants.Defaults{
+			URL:          cfg.OpenObserveURL,
+			Stream:       cfg.OpenObserveStream,
+			EventsStream: cfg.OpenObserveEventsStream,
+			TracesStream: cfg.OpenObserveTracesStream,
+		}, logger)
+		if err != nil {
+			logger.Error("Failed to configure tenants", slog.Any("error", err))
+			os.Exit(1)
+		}
+		logsHandler.SetTenants(registry)
+		exportSource = registry
+		clients = append(clients, registry.Clients()...)
+		logger.Info("Multi-tenant mode enabled",
+			slog.String("file", cfg.TenantsFile),
+			slog.Int("tenants", len(tenantList)))
+	}
+
+	// Each client has its own circuit breaker, so that a failing tenant
+	// backend does not reject the calls of the others. The calls of all the
+	// clients are reported together in the metrics.
+	serverMetrics := metrics.New("logs_adapter")
+	journal := diagnostics.NewJournal(cfg.SlowQueryThreshold, diagnostics.DefaultCapacity)
+	for _, c := range clients {
+		c.SetRetryPolicy(cfg.OpenObserveRetry)
+		c.AddRequestObserver(serverMetrics.ObserveBackend)
+		c.AddRequestObserver(journal.ObserveBackend)
+	}
+	logsHandler.SetMetrics(serverMetrics)
+	logsHandler.SetUsageRecorder(usage.NewRecorder())
+	logsHandler.SetDiagnostics(journal, diagnostics.RedactConfig(cfg))
+
+	if cfg.TracesStreamDiscovery {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		for _, c := range clients {
+			stream, err := c.DiscoverTracesStream(ctx)
+			if err != nil {
+				logger.Warn("Failed to discover the traces stream, keeping the configured one",
+					slog.String("stream", stream), slog.Any("error", err))
+				continue
+			}
+			logger.Info("Traces stream discovered", slog.String("stream", stream))
+		}
+		cancel()
+	}
+
+	// Queries of all tenants share the query slots of the OpenObserve
+	// deployment, so a single scheduler is set on every client.
+	if cfg.QueryMaxConcurrency > 0 {
+		querySched, err := scheduler.New(cfg.QueryMaxConcurrency, cfg.QueryClassWeights)
+		if err != nil {
+			logger.Error("Failed to configure query scheduling", slog.Any("error", err))
+			os.Exit(1)
+		}
+		for _, c := range clients {
+			c.SetScheduler(querySched)
+		}
+		logsHandler.SetScheduler(querySched)
+		logger.Info("Query scheduling enabled",
+			slog.Int("maxConcurrency", cfg.QueryMaxConcurrency),
+			slog.Any("weights", cfg.QueryClassWeights))
+	}
+
+	if cfg.QueryTimeBudget > 0 {
+		logsHandler.SetQueryBudget(cfg.QueryTimeBudget)
+		logger.Info("Logs queries return partial results after their time budget", slog.Duration("budget", cfg.QueryTimeBudget))
+	}
+
+	if cfg.RetentionCacheTTL > 0 {
+		for _, c := range clients {
+			c.SetRetentionClamping(cfg.RetentionCacheTTL)
+		}
+		logger.Info("Logs queries clamped to the stream retention", slog.Duration("cacheTTL", cfg.RetentionCacheTTL))
+	}
+
+	responseCache, err := respcache.New(cfg.ResponseCache, "logs_adapter", logger)
+	if err != nil {
+		logger.Error("Failed to configure the response cache", slog.Any("error", err))
+		os.Exit(1)
+	}
+	if responseCache != nil {
+		logsHandler.SetResponseCache(responseCache)
+		logger.Info("Response cache enabled",
+			slog.String("backend", cfg.ResponseCache.Backend),
+			slog.Duration("recentTTL", cfg.ResponseCache.RecentTTL),
+			slog.Duration("historicalTTL", cfg.ResponseCache.HistoricalTTL))
+	}
+
+	rateLimiter, err := ratelimit.New(cfg.RateLimit, "logs_adapter")
+	if err != nil {
+		logger.Error("Failed to configure the rate limits", slog.Any("error", err))
+		os.Exit(1)
+	}
+	if rateLimiter != nil {
+		logsHandler.SetRateLimiter(rateLimiter)
+		logger.Info("Queries rate limited per namespace",
+			slog.Float64("requestsPerSecond", cfg.RateLimit.RequestsPerSecond),
+			slog.Int("burst", cfg.RateLimit.Burst),
+			slog.Int("maxInFlight", cfg.RateLimit.MaxInFlight))
+	}
+
+	auditLog, err := audit.New(cfg.Audit, client.Client, "logs_adapter", logger)
+	if err != nil {
+		logger.Error("Failed to configure the audit log", slog.Any("error", err))
+		os.Exit(1)
+	}
+	if auditLog != nil {
+		logsHandler.SetAuditLog(auditLog)
+		go auditLog.Run(watchCtx)
+		logger.Info("Query and alert operations audited",
+			slog.String("sink", cfg.Audit.Sink),
+			slog.String("stream", cfg.Audit.Stream))
+	}
+
+	if cfg.FollowMaxSessions > 0 {
+		limiter, err := sessions.NewLimiter(cfg.FollowMaxSessions)
+		if err != nil {
+			logger.Error("Failed to configure the follow session limit", slog.Any("error", err))
+			os.Exit(1)
+		}
+		logsHandler.SetFollowLimiter(limiter)
+		logger.Info("Pod log follows limited", slog.Int("maxSessionsPerCaller", cfg.FollowMaxSessions))
+	}
+
+	for _, c := range clients {
+		c.SetSortTiebreakers(cfg.LogSortTiebreakers)
+		c.SetFieldMapping(cfg.LogFieldMapping)
+		c.SetStreamRoutes(cfg.LogStreamRoutes)
+		c.SetBuildPlanes(cfg.WorkflowBuildPlanes)
+	}
+	if len(cfg.LogStreamRoutes) > 0 {
+		logger.Info("Logs stream routing enabled", slog.Int("routes", len(cfg.LogStreamRoutes)))
+	}
+	if len(cfg.WorkflowBuildPlanes) > 0 {
+		clusters := make([]string, len(cfg.WorkflowBuildPlanes))
+		for i, p := range cfg.WorkflowBuildPlanes {
+			clusters[i] = p.Cluster
+		}
+		logger.Info("Workflow logs read from several build planes", slog.Any("clusters", clusters))
+	}
+
+	var plans *openobserve.PlanCache
+	if cfg.QueryPlanCacheSize > 0 {
+		plans, err = openobserve.NewPlanCache(cfg.QueryPlanCacheSize)
+		if err != nil {
+			logger.Error("Failed to configure the query plan cache", slog.Any("error", err))
+			os.Exit(1)
+		}
+		for _, c := range clients {
+			c.SetPlanCache(plans)
+		}
+		logsHandler.SetPlanCache(plans)
+	}
+
+	if cfg.StrictHitValidation {
+		schema := openobserve.NewSchemaValidator()
+		for _, c := range clients {
+			c.SetSchemaValidator(schema)
+		}
+		logsHandler.SetSchemaValidator(schema)
+		logger.Info("Strict hit validation enabled")
+	}
+
+	if cfg.SLIPushInterval > 0 {
+		instance, err := os.Hostname()
+		if err != nil {
+			instance = "unknown"
+		}
+		recorder := slis.NewRecorder()
+		logsHandler.SetSLIRecorder(recorder)
+		pusher := slis.NewPusher(client, recorder, plans, cfg.SLIMetricPrefix, instance, cfg.SLIPushInterval, logger)
+		go pusher.Run(watchCtx)
+		logger.Info("Adapter SLI push enabled",
+			slog.String("prefix", cfg.SLIMetricPrefix),
+			slog.Duration("interval", cfg.SLIPushInterval))
+	}
+
+	// Level rollups are built with the default client only; the level
+	// histograms of tenants are read from their logs streams.
+	if cfg.RollupStream != "" {
+		coverage := &rollups.Coverage{}
+		client.SetLevelRollups(cfg.RollupStream, coverage)
+		job := rollups.NewJob(client, coverage, cfg.RollupInterval, cfg.RollupWindow, logger.With(slog.String("rollups", cfg.RollupStream)))
+		go job.Run(watchCtx)
+		logger.Info("Level rollups enabled",
+			slog.String("stream", cfg.RollupStream),
+			slog.Duration("interval", cfg.RollupInterval),
+			slog.Duration("window", cfg.RollupWindow))
+	}
+
+	formatSet, err := formats.NewSet(cfg.LogFormatDetectors, cfg.LogFormatComponents)
+	if err != nil {
+		logger.Error("Failed to configure log format detection", slog.Any("error", err))
+		os.Exit(1)
+	}
+	for _, c := range clients {
+		c.SetFormats(formatSet)
+	}
+	logger.Info("Log format detection configured",
+		slog.Any("detectors", cfg.LogFormatDetectors),
+		slog.Int("components", len(cfg.LogFormatComponents)))
+
+	var accessPolicy *access.Policy
+	if cfg.AccessPolicyFile != "" {
+		policyFile, err := access.LoadFile(cfg.AccessPolicyFile)
+		if err != nil {
+			logger.Error("Failed to load access policy", slog.Any("error", err))
+			os.Exit(1)
+		}
+		policy, err := access.NewPolicy(policyFile)
+		if err != nil {
+			logger.Error("Failed to configure access policy", slog.Any("error", err))
+			os.Exit(1)
+		}
+		logsHandler.SetAccessPolicy(policy)
+		accessPolicy = policy
+		logger.Info("Aggregation-only access policy enabled",
+			slog.String("file", cfg.AccessPolicyFile),
+			slog.Int("callers", len(policyFile.Callers)),
+			slog.Int("rules", len(policyFile.AggregationOnly)))
+	}
+
+	authenticator, err := auth.New(cfg.Auth)
+	if err != nil {
+		logger.Error("Failed to configure authentication", slog.Any("error", err))
+		os.Exit(1)
+	}
+	if authenticator != nil {
+		// The callers of the access policy authenticate with their own tokens.
+		if accessPolicy != nil {
+			authenticator = auth.Any(authenticator, accessPolicy)
+		}
+		logsHandler.SetAuthenticator(authenticator, cfg.Auth.ExemptPaths)
+		logger.Info("Request authentication enabled",
+			slog.String("mode", cfg.Auth.Mode), slog.Any("exemptPaths", cfg.Auth.ExemptPaths))
+	}
+
+	if cfg.ExportBucket != "" {
+		store, err := export.NewS3Store(cfg.ExportEndpoint, cfg.ExportBucket, cfg.ExportRegion,
+			cfg.ExportAccessKeyID, cfg.ExportSecretAccessKey)
+		if err != nil {
+			logger.Error("Failed to configure log export", slog.Any("error", err))
+			os.Exit(1)
+		}
+		logsHandler.SetExporter(export.NewManager(exportSource, store, cfg.ExportPrefix, logger))
+		logger.Info("Log export to object storage enabled",
+			slog.String("endpoint", cfg.ExportEndpoint),
+			slog.String("bucket", cfg.ExportBucket))
+	}
+
+	if cfg.ShadowLogPath != "" {
+		shadowStore, err := shadow.NewStore(cfg.ShadowLogPath, cfg.ShadowLogRetention)
+		if err != nil {
+			logger.Error("Failed to open shadow log store", slog.Any("error", err))
+			os.Exit(1)
+		}
+		logsHandler.SetShadowRecorder(shadow.NewRecorder(shadowStore, logger))
+		logger.Info("Shadow logging enabled",
+			slog.String("store", cfg.ShadowLogPath),
+			slog.Int("maxRecords", cfg.ShadowLogRetention.MaxRecords),
+			slog.Duration("maxAge", cfg.ShadowLogRetention.MaxAge))
+	}
+
+	if cfg.HoldStorePath != "" {
+		holdStore, err := holds.NewFileStore(cfg.HoldStorePath)
+		if err != nil {
+			logger.Error("Failed to open legal hold store", slog.Any("error", err))
+			os.Exit(1)
+		}
+		logsHandler.SetHoldStore(holdStore, cfg.ExportObjectLock)
+		logger.Info("Legal holds enabled",
+			slog.String("store", cfg.HoldStorePath),
+			slog.Bool("objectLock", cfg.ExportObjectLock))
+	}
+
+	if cfg.AnnotationStorePath != "" {
+		annotationStore, err := annotations.NewFileStore(cfg.AnnotationStorePath)
+		if err != nil {
+			logger.Error("Failed to open log annotation store", slog.Any("error", err))
+			os.Exit(1)
+		}
+		logsHandler.SetAnnotationStore(annotationStore)
+		logger.Info("Log annotations enabled", slog.String("store", cfg.AnnotationStorePath))
+	}
+
+	if cfg.PresetStorePath != "" {
+		presetStore, err := presets.NewFileStore(cfg.PresetStorePath)
+		if err != nil {
+			logger.Error("Failed to open query preset store", slog.Any("error", err))
+			os.Exit(1)
+		}
+		logsHandler.SetPresetStore(presetStore)
+		logger.Info("Query presets enabled", slog.String("store", cfg.PresetStorePath))
+	}
+
+	if cfg.AlertRuleStorePath != "" {
+		alertRuleStore, err := alertsync.NewFileStore(cfg.AlertRuleStorePath)
+		if err != nil {
+			logger.Error("Failed to open alert rule store", slog.Any("error", err))
+			os.Exit(1)
+		}
+		backends := make([]alertsync.Backend, 0, len(clients))
+		for _, c := range clients {
+			backends = append(backends, c)
+		}
+		checker := alertsync.NewChecker(alertRuleStore, backends, cfg.AlertDriftCheckInterval, logger)
+		go checker.Run(watchCtx)
+		logsHandler.SetAlertDriftChecker(alertRuleStore, checker)
+		logger.Info("Alert drift detection enabled",
+			slog.String("store", cfg.AlertRuleStorePath),
+			slog.Duration("interval", cfg.AlertDriftCheckInterval))
+	}
+
+	if cfg.ShareSigningKey != "" {
+		signer, err := shares.NewSigner([]byte(cfg.ShareSigningKey))
+		if err != nil {
+			logger.Error("Failed to configure share links", slog.Any("error", err))
+			os.Exit(1)
+		}
+		logsHandler.SetShareSigner(signer, cfg.ShareLinkMaxTTL)
+		logger.Info("Share links enabled", slog.Duration("maxTTL", cfg.ShareLinkMaxTTL))
+	}
+
+	if len(cfg.WarmupTasks) > 0 {
+		// The server only listens once warm-up is done, so the adapter
+		// becomes ready after it.
+		logger.Info("Warming up", slog.Any("tasks", cfg.WarmupTasks), slog.Duration("timeout", cfg.WarmupTimeout))
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.WarmupTimeout)
+		logsHandler.WarmUp(ctx, app.WarmupOptions{
+			Tasks:       cfg.WarmupTasks,
+			Namespaces:  cfg.WarmupNamespaces,
+			Connections: cfg.WarmupConnections,
+		})
+		cancel()
+	}
+
 	srv := app.NewServer(cfg.ServerPort, logsHandler, logger)
 
 	go func() {
@@ -119,6 +515,78 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	if err := srv.Shutdown(ctx); err != nil {
+		logger.Error("Error during shutdown", slog.Any("error", err))
+		os.Exit(1)
+	}
+	if auditLog != nil {
+		auditLog.Flush(ctx)
+	}
+
+	logger.Info("Server stopped")
+}
+
+// runLokiBackend serves the core logs API from Loki until the adapter is
+// stopped.
+func runLokiBackend(cfg *app.Config, logger *slog.Logger) {
+	logger.Info("Configurations loaded from environment variables successfully",
+		slog.String("Log Level", cfg.LogLevel.String()),
+		slog.String("Logs Backend", cfg.Backend),
+		slog.String("Loki URL", cfg.LokiURL),
+		slog.String("Loki Tenant ID", cfg.LokiTenantID),
+		slog.String("Loki Ruler Namespace", cfg.LokiRulerNamespace),
+		slog.String("Server Port", cfg.ServerPort),
+	)
+
+	client := loki.NewClient(cfg.LokiURL, cfg.LokiTenantID, logger)
+	client.SetBasicAuth(cfg.LokiUser, cfg.LokiPassword)
+	client.SetRulerNamespace(cfg.LokiRulerNamespace)
+	if err := client.SetFieldMapping(cfg.LogFieldMapping); err != nil {
+		logger.Error("Invalid LOG_FIELD_MAPPING for the loki backend", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	// The adapter cannot function without Loki, so it exits when Loki is
+	// not ready.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	err := client.CheckHealth(ctx)
+	cancel()
+	if err != nil {
+		logger.Error("Failed to connect to Loki. Cannot continue without it. Hence shutting down", slog.Any("error", err))
+		os.Exit(1)
+	}
+	logger.Info("Loki connectivity check succeeded")
+
+	backendHandler := app.NewBackendHandler(client, newObserverClient(cfg, logger), logger)
+	authenticator, err := auth.New(cfg.Auth)
+	if err != nil {
+		logger.Error("Failed to configure authentication", slog.Any("error", err))
+		os.Exit(1)
+	}
+	if authenticator != nil {
+		backendHandler.SetAuthenticator(authenticator, cfg.Auth.ExemptPaths)
+		logger.Info("Request authentication enabled",
+			slog.String("mode", cfg.Auth.Mode), slog.Any("exemptPaths", cfg.Auth.ExemptPaths))
+	}
+
+	srv := app.NewBackendServer(cfg.ServerPort, backendHandler, logger)
+
+	go func() {
+		if err := srv.Start(); err != nil {
+			logger.Error("Server error", slog.Any("error", err))
+			os.Exit(1)
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	logger.Info("Shutting down gracefully")
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
 	if err := srv.Shutdown(ctx); err != nil {
 		logger.Error("Error during shutdown", slog.Any("error", err))
 		os.Exit(1)
@@ -126,3 +594,14 @@ func main() {
 
 	logger.Info("Server stopped")
 }
+
+// newObserverClient returns the client forwarding alerts to the observer,
+// signing them with the key cfg sets, if any.
+func newObserverClient(cfg *app.Config, logger *slog.Logger) *observer.Client {
+	observerClient := observer.NewClient(cfg.ObserverURL)
+	if cfg.ObserverSigningKey != "" {
+		observerClient.SetSigningKey([]byte(cfg.ObserverSigningKey))
+		logger.Info("Forwarded alerts signed")
+	}
+	return observerClient
+}
@@ -0,0 +1,139 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/auth"
+	ooclient "github.com/openchoreo/community-modules/common/openobserve"
+)
+
+type Config struct {
+	ServerPort          string
+	OpenObserveURL      string
+	OpenObserveOrg      string
+	OpenObserveUser     string
+	OpenObservePassword string
+	LogLevel            slog.Level
+
+	// OpenObserveRetry configures the retries of the calls to OpenObserve
+	// and the circuit breaker rejecting them after repeated failures.
+	OpenObserveRetry ooclient.RetryPolicy
+
+	// Auth configures the authentication of the requests served by the
+	// adapter. All requests are accepted by default.
+	Auth auth.Config
+}
+
+// LoadConfig loads configuration from environment variables
+func LoadConfig() (*Config, error) {
+	serverPort := getEnv("SERVER_PORT", "9099")
+	openObserveURL := getEnv("OPENOBSERVE_URL", "")
+	openObserveOrg := getEnv("OPENOBSERVE_ORG", "default")
+	openObserveUser := getEnv("OPENOBSERVE_USER", "")
+	openObservePassword := getEnv("OPENOBSERVE_PASSWORD", "")
+	retryMaxAttempts := getEnv("OPENOBSERVE_RETRY_MAX_ATTEMPTS", strconv.Itoa(ooclient.DefaultRetryPolicy.MaxAttempts))
+	retryInitialBackoff := getEnv("OPENOBSERVE_RETRY_INITIAL_BACKOFF", ooclient.DefaultRetryPolicy.InitialBackoff.String())
+	retryMaxBackoff := getEnv("OPENOBSERVE_RETRY_MAX_BACKOFF", ooclient.DefaultRetryPolicy.MaxBackoff.String())
+	breakerThreshold := getEnv("OPENOBSERVE_BREAKER_THRESHOLD", strconv.Itoa(ooclient.DefaultRetryPolicy.BreakerThreshold))
+	breakerCooldown := getEnv("OPENOBSERVE_BREAKER_COOLDOWN", ooclient.DefaultRetryPolicy.BreakerCooldown.String())
+	authConfig := auth.Config{
+		Mode:        getEnv("AUTH_MODE", auth.ModeNone),
+		Token:       getEnv("AUTH_TOKEN", ""),
+		JWKSURL:     getEnv("AUTH_JWKS_URL", ""),
+		Issuer:      getEnv("AUTH_JWT_ISSUER", ""),
+		Audience:    getEnv("AUTH_JWT_AUDIENCE", ""),
+		ExemptPaths: splitList(getEnv("AUTH_EXEMPT_PATHS", "/healthz")),
+	}
+
+	// Parse log level
+	logLevel := slog.LevelInfo
+	if level := os.Getenv("LOG_LEVEL"); level != "" {
+		switch strings.ToUpper(level) {
+		case "DEBUG":
+			logLevel = slog.LevelDebug
+		case "INFO":
+			logLevel = slog.LevelInfo
+		case "WARN", "WARNING":
+			logLevel = slog.LevelWarn
+		case "ERROR":
+			logLevel = slog.LevelError
+		}
+	}
+
+	if openObserveURL == "" {
+		return nil, fmt.Errorf("environment variable OPENOBSERVE_URL is required")
+	}
+	if openObserveUser == "" {
+		return nil, fmt.Errorf("environment variable OPENOBSERVE_USER is required")
+	}
+	if openObservePassword == "" {
+		return nil, fmt.Errorf("environment variable OPENOBSERVE_PASSWORD is required")
+	}
+
+	var retry ooclient.RetryPolicy
+	var err error
+	if retry.MaxAttempts, err = strconv.Atoi(retryMaxAttempts); err != nil || retry.MaxAttempts < 1 {
+		return nil, fmt.Errorf("invalid OPENOBSERVE_RETRY_MAX_ATTEMPTS: must be a positive integer")
+	}
+	if retry.InitialBackoff, err = time.ParseDuration(retryInitialBackoff); err != nil || retry.InitialBackoff <= 0 {
+		return nil, fmt.Errorf("invalid OPENOBSERVE_RETRY_INITIAL_BACKOFF: must be a positive duration")
+	}
+	if retry.MaxBackoff, err = time.ParseDuration(retryMaxBackoff); err != nil || retry.MaxBackoff < retry.InitialBackoff {
+		return nil, fmt.Errorf("invalid OPENOBSERVE_RETRY_MAX_BACKOFF: must be a duration of at least OPENOBSERVE_RETRY_INITIAL_BACKOFF")
+	}
+	if retry.BreakerThreshold, err = strconv.Atoi(breakerThreshold); err != nil || retry.BreakerThreshold < 0 {
+		return nil, fmt.Errorf("invalid OPENOBSERVE_BREAKER_THRESHOLD: must be a non-negative integer")
+	}
+	if retry.BreakerCooldown, err = time.ParseDuration(breakerCooldown); err != nil || retry.BreakerCooldown <= 0 {
+		return nil, fmt.Errorf("invalid OPENOBSERVE_BREAKER_COOLDOWN: must be a positive duration")
+	}
+
+	if err := authConfig.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid authentication settings (AUTH_*): %w", err)
+	}
+
+	port, err := strconv.Atoi(serverPort)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SERVER_PORT: must be integer in 1..65535: %w", err)
+	}
+	if port < 1 || port > 65535 {
+		return nil, fmt.Errorf("invalid SERVER_PORT: must be integer in 1..65535")
+	}
+
+	return &Config{
+		ServerPort:          serverPort,
+		OpenObserveURL:      openObserveURL,
+		OpenObserveOrg:      openObserveOrg,
+		OpenObserveUser:     openObserveUser,
+		OpenObservePassword: openObservePassword,
+		LogLevel:            logLevel,
+		OpenObserveRetry:    retry,
+		Auth:                authConfig,
+	}, nil
+}
+
+// splitList splits a comma-separated list, dropping blank items.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,144 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/auth"
+	ooclient "github.com/openchoreo/community-modules/common/openobserve"
+)
+
+// setEnvVars sets multiple environment variables for the duration of the test.
+func setEnvVars(t *testing.T, vars map[string]string) {
+	t.Helper()
+	for k, v := range vars {
+		t.Setenv(k, v)
+	}
+}
+
+// validEnvVars returns the minimal set of environment variables required for LoadConfig.
+func validEnvVars() map[string]string {
+	return map[string]string{
+		"OPENOBSERVE_URL":      "http://localhost:5080",
+		"OPENOBSERVE_USER":     "admin",
+		"OPENOBSERVE_PASSWORD": "fakeOpenObservePassword",
+	}
+}
+
+func TestLoadConfig_Success(t *testing.T) {
+	setEnvVars(t, validEnvVars())
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.ServerPort != "9099" {
+		t.Errorf("expected default ServerPort 9099, got %s", cfg.ServerPort)
+	}
+	if cfg.OpenObserveURL != "http://localhost:5080" {
+		t.Errorf("unexpected OpenObserveURL: %s", cfg.OpenObserveURL)
+	}
+	if cfg.OpenObserveOrg != "default" {
+		t.Errorf("expected default OpenObserveOrg, got %s", cfg.OpenObserveOrg)
+	}
+	if cfg.LogLevel != slog.LevelInfo {
+		t.Errorf("expected default LogLevel Info, got %v", cfg.LogLevel)
+	}
+	if cfg.OpenObserveRetry != ooclient.DefaultRetryPolicy {
+		t.Errorf("expected the default retry policy, got %+v", cfg.OpenObserveRetry)
+	}
+	if cfg.Auth.Mode != auth.ModeNone || len(cfg.Auth.ExemptPaths) != 1 || cfg.Auth.ExemptPaths[0] != "/healthz" {
+		t.Errorf("unexpected auth config: %+v", cfg.Auth)
+	}
+}
+
+func TestLoadConfig_LogLevel(t *testing.T) {
+	setEnvVars(t, validEnvVars())
+	t.Setenv("LOG_LEVEL", "debug")
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.LogLevel != slog.LevelDebug {
+		t.Errorf("expected LogLevel Debug, got %v", cfg.LogLevel)
+	}
+}
+
+func TestLoadConfig_Retry(t *testing.T) {
+	setEnvVars(t, validEnvVars())
+	setEnvVars(t, map[string]string{
+		"OPENOBSERVE_RETRY_MAX_ATTEMPTS":    "5",
+		"OPENOBSERVE_RETRY_INITIAL_BACKOFF": "100ms",
+		"OPENOBSERVE_RETRY_MAX_BACKOFF":     "1s",
+		"OPENOBSERVE_BREAKER_THRESHOLD":     "0",
+		"OPENOBSERVE_BREAKER_COOLDOWN":      "1m",
+	})
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := ooclient.RetryPolicy{
+		MaxAttempts:      5,
+		InitialBackoff:   100 * time.Millisecond,
+		MaxBackoff:       time.Second,
+		BreakerThreshold: 0,
+		BreakerCooldown:  time.Minute,
+	}
+	if cfg.OpenObserveRetry != want {
+		t.Errorf("expected %+v, got %+v", want, cfg.OpenObserveRetry)
+	}
+
+	for key, value := range map[string]string{
+		"OPENOBSERVE_RETRY_MAX_ATTEMPTS":    "0",
+		"OPENOBSERVE_RETRY_INITIAL_BACKOFF": "fast",
+		"OPENOBSERVE_RETRY_MAX_BACKOFF":     "10ms",
+		"OPENOBSERVE_BREAKER_THRESHOLD":     "-1",
+		"OPENOBSERVE_BREAKER_COOLDOWN":      "0s",
+	} {
+		t.Run(key, func(t *testing.T) {
+			t.Setenv(key, value)
+			if _, err := LoadConfig(); err == nil {
+				t.Errorf("expected error for %s=%q", key, value)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_MissingRequired(t *testing.T) {
+	for _, key := range []string{"OPENOBSERVE_URL", "OPENOBSERVE_USER", "OPENOBSERVE_PASSWORD"} {
+		t.Run(key, func(t *testing.T) {
+			setEnvVars(t, validEnvVars())
+			t.Setenv(key, "")
+			if _, err := LoadConfig(); err == nil {
+				t.Errorf("expected error when %s is not set", key)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_InvalidAuth(t *testing.T) {
+	setEnvVars(t, validEnvVars())
+	t.Setenv("AUTH_MODE", auth.ModeToken)
+
+	if _, err := LoadConfig(); err == nil {
+		t.Error("expected error for token authentication without AUTH_TOKEN")
+	}
+}
+
+func TestLoadConfig_InvalidServerPort(t *testing.T) {
+	setEnvVars(t, validEnvVars())
+
+	for _, port := range []string{"abc", "0", "70000"} {
+		t.Setenv("SERVER_PORT", port)
+		if _, err := LoadConfig(); err == nil {
+			t.Errorf("expected error for SERVER_PORT %q", port)
+		}
+	}
+}
@@ -0,0 +1,288 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/auth"
+	"github.com/openchoreo/community-modules/common/metrics"
+	"github.com/openchoreo/community-modules/observability-metrics-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-metrics-openobserve/internal/openobserve"
+)
+
+const (
+	// defaultMetricsStep is the step of metrics queries that do not set one.
+	defaultMetricsStep = 5 * time.Minute
+	// maxQueryPoints bounds the points of each series of a metrics query, as
+	// the Prometheus query API does.
+	maxQueryPoints = 11000
+)
+
+// notSupportedDetail is the detail of the errors of the endpoints of the
+// metrics adapter API the adapter does not implement.
+const notSupportedDetail = "not supported by the OpenObserve metrics adapter"
+
+// MetricsHandler implements the generated StrictServerInterface.
+type MetricsHandler struct {
+	client *openobserve.Client
+	logger *slog.Logger
+	// metrics records the requests and OpenObserve calls for GET /metrics.
+	metrics *metrics.Metrics
+	// authenticator authenticates all requests but those for authExemptPaths.
+	authenticator   auth.Authenticator
+	authExemptPaths []string
+}
+
+func NewMetricsHandler(client *openobserve.Client, logger *slog.Logger) *MetricsHandler {
+	return &MetricsHandler{
+		client: client,
+		logger: logger,
+	}
+}
+
+// SetMetrics records every request with m and serves m on GET /metrics. The
+// client must report its calls to m.
+func (h *MetricsHandler) SetMetrics(m *metrics.Metrics) {
+	h.metrics = m
+}
+
+// SetAuthenticator rejects the requests a does not accept, except those for
+// exemptPaths; see auth.Middleware.
+func (h *MetricsHandler) SetAuthenticator(a auth.Authenticator, exemptPaths []string) {
+	h.authenticator = a
+	h.authExemptPaths = exemptPaths
+}
+
+// Ensure MetricsHandler implements the interface at compile time.
+var _ gen.StrictServerInterface = (*MetricsHandler)(nil)
+
+// Health reports whether OpenObserve is up.
+func (h *MetricsHandler) Health(ctx context.Context, _ gen.HealthRequestObject) (gen.HealthResponseObject, error) {
+	if err := h.client.HealthCheck(ctx); err != nil {
+		return gen.Health503JSONResponse{
+			Status: ptr("unhealthy"),
+			Error:  ptr(fmt.Sprintf("openobserve: %v", err)),
+		}, nil
+	}
+	return gen.Health200JSONResponse{Status: ptr("healthy")}, nil
+}
+
+// QueryMetrics implements POST /api/v1/metrics/query. Resource metrics
+// carry the container restarts of the pods in addition to the fields of the
+// API.
+func (h *MetricsHandler) QueryMetrics(ctx context.Context, request gen.QueryMetricsRequestObject) (gen.QueryMetricsResponseObject, error) {
+	if request.Body == nil {
+		return badRequestMetrics("request body is required"), nil
+	}
+	body := request.Body
+	if strings.TrimSpace(body.SearchScope.Namespace) == "" {
+		return badRequestMetrics("searchScope.namespace is required"), nil
+	}
+	if !body.EndTime.After(body.StartTime) {
+		return badRequestMetrics("endTime must be after startTime"), nil
+	}
+	step := defaultMetricsStep
+	if body.Step != nil && *body.Step != "" {
+		parsed, err := time.ParseDuration(*body.Step)
+		if err != nil {
+			return badRequestMetrics(fmt.Sprintf("invalid step format: %s", *body.Step)), nil
+		}
+		if parsed < time.Second {
+			return badRequestMetrics("step must be at least 1s"), nil
+		}
+		step = parsed
+	}
+	if body.EndTime.Sub(body.StartTime)/step >= maxQueryPoints {
+		return badRequestMetrics(fmt.Sprintf("the time range holds more than %d steps, use a larger step", maxQueryPoints)), nil
+	}
+
+	scope := openobserve.Scope{
+		Namespace:      body.SearchScope.Namespace,
+		ComponentUID:   deref(body.SearchScope.ComponentUid),
+		ProjectUID:     deref(body.SearchScope.ProjectUid),
+		EnvironmentUID: deref(body.SearchScope.EnvironmentUid),
+	}
+	switch body.Metric {
+	case gen.MetricsQueryRequestMetricResource:
+		var result resourceMetricsTimeSeries
+		err := h.runQueries(ctx, []metricQuery{
+			{"cpuUsage", openobserve.CPUUsageQuery(scope), &result.CpuUsage},
+			{"cpuRequests", openobserve.CPURequestsQuery(scope), &result.CpuRequests},
+			{"cpuLimits", openobserve.CPULimitsQuery(scope), &result.CpuLimits},
+			{"memoryUsage", openobserve.MemoryUsageQuery(scope), &result.MemoryUsage},
+			{"memoryRequests", openobserve.MemoryRequestsQuery(scope), &result.MemoryRequests},
+			{"memoryLimits", openobserve.MemoryLimitsQuery(scope), &result.MemoryLimits},
+			{"restarts", openobserve.RestartsQuery(scope, step), &result.Restarts},
+		}, body.StartTime, body.EndTime, step)
+		if err != nil {
+			return serverErrorMetrics(err), nil
+		}
+		return metricsQueryOKResponse{result}, nil
+	case gen.MetricsQueryRequestMetricHttp:
+		var result gen.HttpMetricsTimeSeries
+		err := h.runQueries(ctx, []metricQuery{
+			{"requestCount", openobserve.HTTPRequestCountQuery(scope), &result.RequestCount},
+			{"successfulRequestCount", openobserve.SuccessfulHTTPRequestCountQuery(scope), &result.SuccessfulRequestCount},
+			{"unsuccessfulRequestCount", openobserve.UnsuccessfulHTTPRequestCountQuery(scope), &result.UnsuccessfulRequestCount},
+			{"meanLatency", openobserve.MeanHTTPLatencyQuery(scope), &result.MeanLatency},
+			{"latencyP50", openobserve.HTTPLatencyQuantileQuery(scope, 0.5), &result.LatencyP50},
+			{"latencyP90", openobserve.HTTPLatencyQuantileQuery(scope, 0.9), &result.LatencyP90},
+			{"latencyP99", openobserve.HTTPLatencyQuantileQuery(scope, 0.99), &result.LatencyP99},
+		}, body.StartTime, body.EndTime, step)
+		if err != nil {
+			return serverErrorMetrics(err), nil
+		}
+		return metricsQueryOKResponse{result}, nil
+	default:
+		return badRequestMetrics(fmt.Sprintf("unknown metric type: %s", body.Metric)), nil
+	}
+}
+
+// resourceMetricsTimeSeries extends the resource metrics of the API with the
+// container restarts of the pods during each step.
+type resourceMetricsTimeSeries struct {
+	gen.ResourceMetricsTimeSeries
+	Restarts *[]gen.MetricsTimeSeriesItem `json:"restarts,omitempty"`
+}
+
+// metricQuery is a PromQL query whose series fills dest.
+type metricQuery struct {
+	name  string
+	query string
+	dest  **[]gen.MetricsTimeSeriesItem
+}
+
+// runQueries runs queries concurrently and fills their destinations. The
+// queries sum their series by scope, so only the first series is kept; the
+// destination of a query without series is left nil.
+func (h *MetricsHandler) runQueries(ctx context.Context, queries []metricQuery, start, end time.Time, step time.Duration) error {
+	var wg sync.WaitGroup
+	errs := make([]error, len(queries))
+	for i, q := range queries {
+		wg.Go(func() {
+			h.logger.Debug("Metrics query", slog.String("name", q.name), slog.String("query", q.query))
+			series, err := h.client.QueryRange(ctx, q.query, start, end, step)
+			if err != nil {
+				errs[i] = fmt.Errorf("query %q failed: %w", q.name, err)
+				return
+			}
+			if len(series) > 0 {
+				items := toTimeSeriesItems(series[0].Samples)
+				*q.dest = &items
+			}
+		})
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func toTimeSeriesItems(samples []openobserve.Sample) []gen.MetricsTimeSeriesItem {
+	items := make([]gen.MetricsTimeSeriesItem, len(samples))
+	for i, s := range samples {
+		items[i] = gen.MetricsTimeSeriesItem{Timestamp: ptr(s.Time), Value: ptr(s.Value)}
+	}
+	return items
+}
+
+// metricsQueryOKResponse is the 200 response of metrics queries. The
+// generated QueryMetrics200JSONResponse wraps the union of the time series
+// types, which cannot carry the extension fields of resource metrics.
+type metricsQueryOKResponse struct {
+	series any
+}
+
+func (r metricsQueryOKResponse) VisitQueryMetricsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(r.series)
+}
+
+func badRequestMetrics(detail string) gen.QueryMetrics400JSONResponse {
+	return gen.QueryMetrics400JSONResponse{
+		Title:  ptr(gen.BadRequest),
+		Detail: ptr(detail),
+	}
+}
+
+// serverErrorMetrics returns the response of a metrics query that failed
+// with err. The error is not detailed since it may hold the queries.
+func serverErrorMetrics(err error) gen.QueryMetrics500JSONResponse {
+	detail := "failed to query metrics"
+	if errors.Is(err, openobserve.ErrBackendUnavailable) {
+		detail = "the metrics backend is temporarily unavailable, retry later"
+	}
+	return gen.QueryMetrics500JSONResponse{
+		Title:  ptr(gen.InternalServerError),
+		Detail: ptr(detail),
+	}
+}
+
+// CreateAlertRule is not supported: metric alert rules are not implemented
+// on OpenObserve yet.
+func (h *MetricsHandler) CreateAlertRule(context.Context, gen.CreateAlertRuleRequestObject) (gen.CreateAlertRuleResponseObject, error) {
+	return gen.CreateAlertRule500JSONResponse(notSupported()), nil
+}
+
+// GetAlertRule is not supported.
+func (h *MetricsHandler) GetAlertRule(context.Context, gen.GetAlertRuleRequestObject) (gen.GetAlertRuleResponseObject, error) {
+	return gen.GetAlertRule500JSONResponse(notSupported()), nil
+}
+
+// UpdateAlertRule is not supported.
+func (h *MetricsHandler) UpdateAlertRule(context.Context, gen.UpdateAlertRuleRequestObject) (gen.UpdateAlertRuleResponseObject, error) {
+	return gen.UpdateAlertRule500JSONResponse(notSupported()), nil
+}
+
+// DeleteAlertRule is not supported.
+func (h *MetricsHandler) DeleteAlertRule(context.Context, gen.DeleteAlertRuleRequestObject) (gen.DeleteAlertRuleResponseObject, error) {
+	return gen.DeleteAlertRule500JSONResponse(notSupported()), nil
+}
+
+// HandleAlertWebhook is not supported, since the adapter creates no alerts.
+func (h *MetricsHandler) HandleAlertWebhook(context.Context, gen.HandleAlertWebhookRequestObject) (gen.HandleAlertWebhookResponseObject, error) {
+	return gen.HandleAlertWebhook500JSONResponse(notSupported()), nil
+}
+
+// QueryRuntimeTopology is not supported.
+func (h *MetricsHandler) QueryRuntimeTopology(context.Context, gen.QueryRuntimeTopologyRequestObject) (gen.QueryRuntimeTopologyResponseObject, error) {
+	return gen.QueryRuntimeTopology500JSONResponse(notSupported()), nil
+}
+
+func notSupported() gen.ErrorResponse {
+	return gen.ErrorResponse{
+		Title:  ptr(gen.InternalServerError),
+		Detail: ptr(notSupportedDetail),
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeJSONError writes an ErrorResponse in the same shape as the generated handlers.
+func writeJSONError(w http.ResponseWriter, status int, title gen.ErrorResponseTitle, detail string) {
+	writeJSON(w, status, gen.ErrorResponse{Title: &title, Detail: &detail})
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,249 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	ooclient "github.com/openchoreo/community-modules/common/openobserve"
+	"github.com/openchoreo/community-modules/observability-metrics-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-metrics-openobserve/internal/openobserve"
+)
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+func newTestClient(serverURL string) *openobserve.Client {
+	c := openobserve.NewClient(serverURL, "default", "admin", "token", testLogger())
+	c.SetRetryPolicy(ooclient.RetryPolicy{MaxAttempts: 1})
+	return c
+}
+
+var (
+	testStart = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	testEnd   = testStart.Add(time.Hour)
+)
+
+// promServer answers every range query with one series holding value at
+// the start of the range, and records the queries.
+func promServer(t *testing.T, value string) (*httptest.Server, *[]string) {
+	t.Helper()
+	var mu sync.Mutex
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		queries = append(queries, r.URL.Query().Get("query"))
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"matrix","result":[{"metric":{},"values":[[` +
+			r.URL.Query().Get("start") + `,"` + value + `"]]}]}}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &queries
+}
+
+func metricsRequest(metric gen.MetricsQueryRequestMetric) *gen.MetricsQueryRequest {
+	return &gen.MetricsQueryRequest{
+		Metric:      metric,
+		StartTime:   testStart,
+		EndTime:     testEnd,
+		SearchScope: gen.ComponentSearchScope{Namespace: "test-ns", ComponentUid: ptr("c1")},
+	}
+}
+
+func TestHealth_Unhealthy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	handler := NewMetricsHandler(newTestClient(server.URL), testLogger())
+	resp, err := handler.Health(context.Background(), gen.HealthRequestObject{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resp.(gen.Health503JSONResponse); !ok {
+		t.Fatalf("expected 503 response, got %T", resp)
+	}
+}
+
+func TestQueryMetrics_Validation(t *testing.T) {
+	handler := NewMetricsHandler(nil, testLogger())
+	for name, tc := range map[string]struct {
+		body *gen.MetricsQueryRequest
+		want string
+	}{
+		"nil body": {nil, "request body is required"},
+		"empty namespace": {&gen.MetricsQueryRequest{
+			Metric: gen.MetricsQueryRequestMetricResource, StartTime: testStart, EndTime: testEnd,
+			SearchScope: gen.ComponentSearchScope{Namespace: " "},
+		}, "searchScope.namespace is required"},
+		"end before start": {&gen.MetricsQueryRequest{
+			Metric: gen.MetricsQueryRequestMetricResource, StartTime: testEnd, EndTime: testStart,
+			SearchScope: gen.ComponentSearchScope{Namespace: "ns"},
+		}, "endTime must be after startTime"},
+		"invalid step": {&gen.MetricsQueryRequest{
+			Metric: gen.MetricsQueryRequestMetricResource, StartTime: testStart, EndTime: testEnd, Step: ptr("5 minutes"),
+			SearchScope: gen.ComponentSearchScope{Namespace: "ns"},
+		}, "invalid step format: 5 minutes"},
+		"step below a second": {&gen.MetricsQueryRequest{
+			Metric: gen.MetricsQueryRequestMetricResource, StartTime: testStart, EndTime: testEnd, Step: ptr("100ms"),
+			SearchScope: gen.ComponentSearchScope{Namespace: "ns"},
+		}, "step must be at least 1s"},
+		"too many points": {&gen.MetricsQueryRequest{
+			Metric: gen.MetricsQueryRequestMetricResource, StartTime: testStart, EndTime: testStart.Add(24 * time.Hour), Step: ptr("1s"),
+			SearchScope: gen.ComponentSearchScope{Namespace: "ns"},
+		}, "the time range holds more than 11000 steps, use a larger step"},
+		"unknown metric": {&gen.MetricsQueryRequest{
+			Metric: "disk", StartTime: testStart, EndTime: testEnd,
+			SearchScope: gen.ComponentSearchScope{Namespace: "ns"},
+		}, "unknown metric type: disk"},
+	} {
+		resp, err := handler.QueryMetrics(context.Background(), gen.QueryMetricsRequestObject{Body: tc.body})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		badRequest, ok := resp.(gen.QueryMetrics400JSONResponse)
+		if !ok {
+			t.Fatalf("%s: expected 400 response, got %T", name, resp)
+		}
+		if *badRequest.Detail != tc.want {
+			t.Errorf("%s: detail = %q, want %q", name, *badRequest.Detail, tc.want)
+		}
+	}
+}
+
+func TestQueryMetrics_Resource(t *testing.T) {
+	server, queries := promServer(t, "0.25")
+	handler := NewMetricsHandler(newTestClient(server.URL), testLogger())
+
+	resp, err := handler.QueryMetrics(context.Background(), gen.QueryMetricsRequestObject{Body: metricsRequest(gen.MetricsQueryRequestMetricResource)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	if err := resp.VisitQueryMetricsResponse(rec); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	if len(*queries) != 7 {
+		t.Errorf("expected 7 queries, got %d", len(*queries))
+	}
+
+	var body map[string][]gen.MetricsTimeSeriesItem
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	for _, field := range []string{"cpuUsage", "cpuRequests", "cpuLimits", "memoryUsage", "memoryRequests", "memoryLimits", "restarts"} {
+		items := body[field]
+		if len(items) != 1 || *items[0].Value != 0.25 || !items[0].Timestamp.Equal(testStart) {
+			t.Errorf("unexpected %s: %+v", field, items)
+		}
+	}
+}
+
+func TestQueryMetrics_HTTP(t *testing.T) {
+	server, queries := promServer(t, "3")
+	handler := NewMetricsHandler(newTestClient(server.URL), testLogger())
+
+	request := metricsRequest(gen.MetricsQueryRequestMetricHttp)
+	request.Step = ptr("1m")
+	resp, err := handler.QueryMetrics(context.Background(), gen.QueryMetricsRequestObject{Body: request})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	if err := resp.VisitQueryMetricsResponse(rec); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var body gen.HttpMetricsTimeSeries
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	for name, items := range map[string]*[]gen.MetricsTimeSeriesItem{
+		"requestCount":             body.RequestCount,
+		"successfulRequestCount":   body.SuccessfulRequestCount,
+		"unsuccessfulRequestCount": body.UnsuccessfulRequestCount,
+		"meanLatency":              body.MeanLatency,
+		"latencyP50":               body.LatencyP50,
+		"latencyP90":               body.LatencyP90,
+		"latencyP99":               body.LatencyP99,
+	} {
+		if items == nil || len(*items) != 1 || *(*items)[0].Value != 3 {
+			t.Errorf("unexpected %s: %+v", name, items)
+		}
+	}
+	for _, q := range *queries {
+		if !strings.Contains(q, `label_openchoreo_dev_component_uid="c1"`) {
+			t.Errorf("expected the query to be scoped to the component: %s", q)
+		}
+	}
+}
+
+func TestQueryMetrics_NoSeries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"matrix","result":[]}}`))
+	}))
+	defer server.Close()
+
+	handler := NewMetricsHandler(newTestClient(server.URL), testLogger())
+	resp, err := handler.QueryMetrics(context.Background(), gen.QueryMetricsRequestObject{Body: metricsRequest(gen.MetricsQueryRequestMetricHttp)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	if err := resp.VisitQueryMetricsResponse(rec); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.TrimSpace(rec.Body.String()); got != "{}" {
+		t.Errorf("expected an empty object, got %s", got)
+	}
+}
+
+func TestQueryMetrics_BackendError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	handler := NewMetricsHandler(newTestClient(server.URL), testLogger())
+	resp, err := handler.QueryMetrics(context.Background(), gen.QueryMetricsRequestObject{Body: metricsRequest(gen.MetricsQueryRequestMetricResource)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	serverErr, ok := resp.(gen.QueryMetrics500JSONResponse)
+	if !ok {
+		t.Fatalf("expected 500 response, got %T", resp)
+	}
+	if strings.Contains(*serverErr.Detail, "kube_pod_labels") {
+		t.Errorf("expected the queries to be left out of the detail: %s", *serverErr.Detail)
+	}
+}
+
+func TestAlertRules_NotSupported(t *testing.T) {
+	handler := NewMetricsHandler(nil, testLogger())
+	resp, err := handler.CreateAlertRule(context.Background(), gen.CreateAlertRuleRequestObject{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	notSupported, ok := resp.(gen.CreateAlertRule500JSONResponse)
+	if !ok {
+		t.Fatalf("expected 500 response, got %T", resp)
+	}
+	if *notSupported.Detail != notSupportedDetail {
+		t.Errorf("unexpected detail: %s", *notSupported.Detail)
+	}
+}
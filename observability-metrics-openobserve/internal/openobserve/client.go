@@ -0,0 +1,173 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	ooclient "github.com/openchoreo/community-modules/common/openobserve"
+)
+
+// ErrBackendUnavailable is returned when OpenObserve could not be reached.
+var ErrBackendUnavailable = ooclient.ErrBackendUnavailable
+
+// Sample is the value of a series at a point in time.
+type Sample struct {
+	Time  time.Time
+	Value float64
+}
+
+// Series is a time series returned by a PromQL query.
+type Series struct {
+	Labels  map[string]string
+	Samples []Sample
+}
+
+// queryResponse is the response of the Prometheus query API.
+type queryResponse struct {
+	Status    string `json:"status"`
+	ErrorType string `json:"errorType"`
+	Error     string `json:"error"`
+	Data      struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Metric map[string]string `json:"metric"`
+			Values [][2]any          `json:"values"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// Client runs PromQL queries against the Prometheus compatible API of an
+// OpenObserve organization, which serves the metrics streams written to it
+// with Prometheus remote write. The embedded client authenticates and
+// executes its requests.
+type Client struct {
+	*ooclient.Client
+	logger *slog.Logger
+}
+
+func NewClient(baseURL, org, user, token string, logger *slog.Logger) *Client {
+	return &Client{
+		Client: ooclient.NewClient(baseURL, org, user, token, logger),
+		logger: logger,
+	}
+}
+
+// QueryRange evaluates query at every step from start to end. Samples that
+// are not finite numbers, such as the NaN of a division by zero, are
+// dropped.
+func (c *Client) QueryRange(ctx context.Context, query string, start, end time.Time, step time.Duration) ([]Series, error) {
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("start", strconv.FormatInt(start.Unix(), 10))
+	params.Set("end", strconv.FormatInt(end.Unix(), 10))
+	params.Set("step", promDuration(step))
+	reqURL := fmt.Sprintf("%s/api/%s/prometheus/api/v1/query_range?%s", c.BaseURL(), url.PathEscape(c.Org()), params.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var queryResp queryResponse
+	decodeErr := json.Unmarshal(body, &queryResp)
+	if resp.StatusCode != http.StatusOK {
+		if decodeErr == nil && queryResp.Error != "" {
+			return nil, fmt.Errorf("openobserve returned status %d: %s: %s", resp.StatusCode, queryResp.ErrorType, queryResp.Error)
+		}
+		// Other error bodies may echo the query; they are only logged.
+		c.logger.Error("OpenObserve returned error",
+			slog.Int("statusCode", resp.StatusCode),
+			slog.String("body", string(body)))
+		return nil, fmt.Errorf("openobserve returned status %d: response body omitted", resp.StatusCode)
+	}
+	if decodeErr != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", decodeErr)
+	}
+	if queryResp.Status != "success" {
+		return nil, fmt.Errorf("query failed: %s: %s", queryResp.ErrorType, queryResp.Error)
+	}
+
+	series := make([]Series, 0, len(queryResp.Data.Result))
+	for _, result := range queryResp.Data.Result {
+		s := Series{Labels: result.Metric, Samples: make([]Sample, 0, len(result.Values))}
+		for _, value := range result.Values {
+			sample, ok := parseSample(value)
+			if !ok {
+				continue
+			}
+			s.Samples = append(s.Samples, sample)
+		}
+		series = append(series, s)
+	}
+	return series, nil
+}
+
+// parseSample parses a [unix seconds, "value"] pair of the query API.
+func parseSample(pair [2]any) (Sample, bool) {
+	seconds, ok := pair[0].(float64)
+	if !ok {
+		return Sample{}, false
+	}
+	raw, ok := pair[1].(string)
+	if !ok {
+		return Sample{}, false
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		return Sample{}, false
+	}
+	sec, frac := math.Modf(seconds)
+	return Sample{
+		Time:  time.Unix(int64(sec), int64(math.Round(frac*1e3))*int64(time.Millisecond)).UTC(),
+		Value: value,
+	}, true
+}
+
+// HealthCheck checks that OpenObserve is up.
+func (c *Client) HealthCheck(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.BaseURL()+"/healthz", nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var health struct {
+		Status string `json:"status"`
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("openobserve returned status %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&health); err != nil {
+		return fmt.Errorf("failed to decode health response: %w", err)
+	}
+	if health.Status != "ok" {
+		return errors.New("openobserve reported status " + strconv.Quote(health.Status))
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	ooclient "github.com/openchoreo/community-modules/common/openobserve"
+)
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+func newTestClient(serverURL string) *Client {
+	c := NewClient(serverURL, "default", "admin", "token", testLogger())
+	c.SetRetryPolicy(ooclient.RetryPolicy{MaxAttempts: 1})
+	return c
+}
+
+func TestQueryRange(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/default/prometheus/api/v1/query_range" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("query") != "up" || q.Get("start") != "1735689600" || q.Get("end") != "1735693200" || q.Get("step") != "300s" {
+			t.Errorf("unexpected query parameters: %v", q)
+		}
+		if user, _, ok := r.BasicAuth(); !ok || user != "admin" {
+			t.Errorf("expected basic auth")
+		}
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"matrix","result":[
+			{"metric":{"pod":"a"},"values":[[1735689600,"1.5"],[1735689900.5,"NaN"],[1735690200,"+Inf"],[1735690500,"2"]]}
+		]}}`))
+	}))
+	defer server.Close()
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	series, err := newTestClient(server.URL).QueryRange(context.Background(), "up", start, start.Add(time.Hour), 5*time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(series) != 1 || series[0].Labels["pod"] != "a" {
+		t.Fatalf("unexpected series: %+v", series)
+	}
+	samples := series[0].Samples
+	if len(samples) != 2 {
+		t.Fatalf("expected the non-finite samples to be dropped, got %+v", samples)
+	}
+	if !samples[0].Time.Equal(start) || samples[0].Value != 1.5 {
+		t.Errorf("unexpected first sample: %+v", samples[0])
+	}
+	if !samples[1].Time.Equal(start.Add(15*time.Minute)) || samples[1].Value != 2 {
+		t.Errorf("unexpected second sample: %+v", samples[1])
+	}
+}
+
+func TestQueryRange_QueryError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"status":"error","errorType":"bad_data","error":"parse error"}`))
+	}))
+	defer server.Close()
+
+	_, err := newTestClient(server.URL).QueryRange(context.Background(), "up{", time.Unix(0, 0), time.Unix(60, 0), time.Minute)
+	if err == nil || !strings.Contains(err.Error(), "bad_data: parse error") {
+		t.Fatalf("expected the query error, got %v", err)
+	}
+}
+
+func TestQueryRange_ErrorBodyOmitted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`forbidden: secret_query`))
+	}))
+	defer server.Close()
+
+	_, err := newTestClient(server.URL).QueryRange(context.Background(), "secret_query", time.Unix(0, 0), time.Unix(60, 0), time.Minute)
+	if err == nil || strings.Contains(err.Error(), "secret_query") {
+		t.Fatalf("expected an error without the body, got %v", err)
+	}
+}
+
+func TestQueryRange_Unavailable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	_, err := newTestClient(server.URL).QueryRange(context.Background(), "up", time.Unix(0, 0), time.Unix(60, 0), time.Minute)
+	if !errors.Is(err, ErrBackendUnavailable) {
+		t.Fatalf("expected ErrBackendUnavailable, got %v", err)
+	}
+}
+
+func TestHealthCheck(t *testing.T) {
+	status := "ok"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/healthz" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"status":"` + status + `"}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	if err := client.HealthCheck(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	status = "degraded"
+	if err := client.HealthCheck(context.Background()); err == nil {
+		t.Fatal("expected an error for a degraded status")
+	}
+}
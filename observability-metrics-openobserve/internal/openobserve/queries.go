@@ -0,0 +1,197 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Labels of the pods of OpenChoreo components, as exposed by the
+// kube_pod_labels series of kube-state-metrics.
+const (
+	LabelNamespace      = "label_openchoreo_dev_namespace"
+	LabelComponentUID   = "label_openchoreo_dev_component_uid"
+	LabelProjectUID     = "label_openchoreo_dev_project_uid"
+	LabelEnvironmentUID = "label_openchoreo_dev_environment_uid"
+)
+
+// rateWindow is the range of the rates of counters. It spans several scrapes
+// at the usual scrape intervals.
+const rateWindow = "2m"
+
+// minRestartsWindow bounds the window restarts are counted over from below,
+// so that it spans at least two scrapes.
+const minRestartsWindow = time.Minute
+
+// Scope selects the pods of the OpenChoreo components whose metrics are
+// queried. Namespace is required; the UIDs that are set narrow the scope.
+type Scope struct {
+	Namespace      string
+	ComponentUID   string
+	ProjectUID     string
+	EnvironmentUID string
+}
+
+// podLabels returns the kube_pod_labels series of the pods of scope.
+func podLabels(scope Scope) string {
+	matchers := []string{`job="kube-state-metrics"`, fmt.Sprintf("%s=%q", LabelNamespace, scope.Namespace)}
+	for _, m := range []struct{ label, value string }{
+		{LabelComponentUID, scope.ComponentUID},
+		{LabelProjectUID, scope.ProjectUID},
+		{LabelEnvironmentUID, scope.EnvironmentUID},
+	} {
+		if m.value != "" {
+			matchers = append(matchers, fmt.Sprintf("%s=%q", m.label, m.value))
+		}
+	}
+	return "kube_pod_labels{" + strings.Join(matchers, ",") + "}"
+}
+
+// scopeLabels returns the labels of the UIDs set in scope, by which the
+// series of a query are summed, so that each query returns one series.
+func scopeLabels(scope Scope) []string {
+	labels := make([]string, 0, 3)
+	if scope.ComponentUID != "" {
+		labels = append(labels, LabelComponentUID)
+	}
+	if scope.ProjectUID != "" {
+		labels = append(labels, LabelProjectUID)
+	}
+	if scope.EnvironmentUID != "" {
+		labels = append(labels, LabelEnvironmentUID)
+	}
+	return labels
+}
+
+// joinPods sums expr, a vector of per pod series, over the pods of scope.
+// The pods are matched by the pod and namespace labels of their
+// kube_pod_labels series.
+func joinPods(expr string, scope Scope, extraBy ...string) string {
+	labels := scopeLabels(scope)
+	by := strings.Join(append(append([]string{}, labels...), extraBy...), ", ")
+	return fmt.Sprintf("sum by (%s) (%s * on (pod, namespace) group_left (%s) %s)",
+		by, expr, strings.Join(labels, ", "), podLabels(scope))
+}
+
+// joinHubblePods sums expr, a vector of Hubble series, over the destination
+// pods of scope: Hubble reports the server side of HTTP requests with
+// destination_namespace and destination_pod labels.
+func joinHubblePods(expr string, scope Scope, extraBy ...string) string {
+	labels := scopeLabels(scope)
+	by := strings.Join(append(append([]string{}, labels...), extraBy...), ", ")
+	pods := fmt.Sprintf(`label_replace(label_replace(%s, "destination_namespace", "$1", "namespace", "(.*)"), "destination_pod", "$1", "pod", "(.*)")`,
+		podLabels(scope))
+	return fmt.Sprintf("sum by (%s) (%s * on (destination_namespace, destination_pod) group_left (%s) %s)",
+		by, expr, strings.Join(labels, ", "), pods)
+}
+
+// runningPodResource returns the requests or limits (kind) of resource of
+// the containers of the running pods of scope.
+func runningPodResource(kind, resource string, scope Scope) string {
+	expr := fmt.Sprintf(`(kube_pod_container_resource_%s{resource=%q,job="kube-state-metrics"} and on (pod, namespace) (kube_pod_status_phase{phase="Running"} == 1))`,
+		kind, resource)
+	return joinPods(expr, scope)
+}
+
+// CPUUsageQuery returns the CPU used by the containers of scope, in cores.
+func CPUUsageQuery(scope Scope) string {
+	return joinPods(`rate(container_cpu_usage_seconds_total{container!=""}[`+rateWindow+`])`, scope)
+}
+
+// CPURequestsQuery returns the CPU requested by the running containers of
+// scope, in cores.
+func CPURequestsQuery(scope Scope) string {
+	return runningPodResource("requests", "cpu", scope)
+}
+
+// CPULimitsQuery returns the CPU limits of the running containers of scope,
+// in cores.
+func CPULimitsQuery(scope Scope) string {
+	return runningPodResource("limits", "cpu", scope)
+}
+
+// MemoryUsageQuery returns the working set memory of the containers of
+// scope, in bytes.
+func MemoryUsageQuery(scope Scope) string {
+	return joinPods(`container_memory_working_set_bytes{container!=""}`, scope)
+}
+
+// MemoryRequestsQuery returns the memory requested by the running
+// containers of scope, in bytes.
+func MemoryRequestsQuery(scope Scope) string {
+	return runningPodResource("requests", "memory", scope)
+}
+
+// MemoryLimitsQuery returns the memory limits of the running containers of
+// scope, in bytes.
+func MemoryLimitsQuery(scope Scope) string {
+	return runningPodResource("limits", "memory", scope)
+}
+
+// RestartsQuery returns the number of container restarts of the pods of
+// scope during the window ending at each point, at least a minute long.
+// Querying with the step as window counts every restart once.
+func RestartsQuery(scope Scope, window time.Duration) string {
+	window = max(window, minRestartsWindow)
+	expr := fmt.Sprintf(`increase(kube_pod_container_status_restarts_total{job="kube-state-metrics"}[%s])`, promDuration(window))
+	return joinPods(expr, scope)
+}
+
+// HTTP status matchers of successful (1xx to 3xx) and unsuccessful (4xx and
+// 5xx) requests.
+const (
+	successfulStatus   = `status=~"^[123]..?$"`
+	unsuccessfulStatus = `status=~"^[45]..?$"`
+)
+
+// httpRequestRate returns the rate of the HTTP requests served by the pods
+// of scope, in requests per second, whose status matches statusMatcher
+// when set.
+func httpRequestRate(scope Scope, statusMatcher string) string {
+	matchers := `reporter="server"`
+	if statusMatcher != "" {
+		matchers += "," + statusMatcher
+	}
+	return joinHubblePods(fmt.Sprintf("rate(hubble_http_requests_total{%s}[%s])", matchers, rateWindow), scope)
+}
+
+// HTTPRequestCountQuery returns the rate of the HTTP requests served by the
+// pods of scope, in requests per second.
+func HTTPRequestCountQuery(scope Scope) string {
+	return httpRequestRate(scope, "")
+}
+
+// SuccessfulHTTPRequestCountQuery returns the rate of the HTTP requests
+// served by the pods of scope with a 1xx, 2xx or 3xx status.
+func SuccessfulHTTPRequestCountQuery(scope Scope) string {
+	return httpRequestRate(scope, successfulStatus)
+}
+
+// UnsuccessfulHTTPRequestCountQuery returns the rate of the HTTP requests
+// served by the pods of scope with a 4xx or 5xx status.
+func UnsuccessfulHTTPRequestCountQuery(scope Scope) string {
+	return httpRequestRate(scope, unsuccessfulStatus)
+}
+
+// MeanHTTPLatencyQuery returns the mean duration of the HTTP requests served
+// by the pods of scope, in seconds.
+func MeanHTTPLatencyQuery(scope Scope) string {
+	sum := joinHubblePods(`rate(hubble_http_request_duration_seconds_sum{reporter="server"}[`+rateWindow+`])`, scope)
+	return fmt.Sprintf("(%s / %s) >= 0", sum, HTTPRequestCountQuery(scope))
+}
+
+// HTTPLatencyQuantileQuery returns the quantile, between 0 and 1, of the
+// durations of the HTTP requests served by the pods of scope, in seconds.
+func HTTPLatencyQuantileQuery(scope Scope, quantile float64) string {
+	buckets := joinHubblePods(`rate(hubble_http_request_duration_seconds_bucket{reporter="server"}[`+rateWindow+`])`, scope, "le")
+	return fmt.Sprintf("histogram_quantile(%s, %s) >= 0", strconv.FormatFloat(quantile, 'f', -1, 64), buckets)
+}
+
+// promDuration formats d as a PromQL duration in whole seconds, e.g. 300s.
+func promDuration(d time.Duration) string {
+	return strconv.FormatInt(int64(d/time.Second), 10) + "s"
+}
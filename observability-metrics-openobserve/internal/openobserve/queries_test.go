@@ -0,0 +1,115 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPodLabels(t *testing.T) {
+	got := podLabels(Scope{Namespace: "ns", ComponentUID: "c1", EnvironmentUID: "e1"})
+	want := `kube_pod_labels{job="kube-state-metrics",label_openchoreo_dev_namespace="ns",label_openchoreo_dev_component_uid="c1",label_openchoreo_dev_environment_uid="e1"}`
+	if got != want {
+		t.Errorf("podLabels() = %s, want %s", got, want)
+	}
+}
+
+func TestPodLabels_EscapesValues(t *testing.T) {
+	got := podLabels(Scope{Namespace: `ns"} or vector(1) #`})
+	if !strings.Contains(got, `label_openchoreo_dev_namespace="ns\"} or vector(1) #"`) {
+		t.Errorf("namespace not escaped: %s", got)
+	}
+}
+
+func TestCPUUsageQuery(t *testing.T) {
+	got := CPUUsageQuery(Scope{Namespace: "ns", ComponentUID: "c1"})
+	want := `sum by (label_openchoreo_dev_component_uid) (rate(container_cpu_usage_seconds_total{container!=""}[2m]) * on (pod, namespace) group_left (label_openchoreo_dev_component_uid) kube_pod_labels{job="kube-state-metrics",label_openchoreo_dev_namespace="ns",label_openchoreo_dev_component_uid="c1"})`
+	if got != want {
+		t.Errorf("CPUUsageQuery() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestResourceQueries_RunningPods(t *testing.T) {
+	scope := Scope{Namespace: "ns", ProjectUID: "p1"}
+	for name, tc := range map[string]struct {
+		query string
+		want  string
+	}{
+		"cpu requests":    {CPURequestsQuery(scope), `kube_pod_container_resource_requests{resource="cpu"`},
+		"cpu limits":      {CPULimitsQuery(scope), `kube_pod_container_resource_limits{resource="cpu"`},
+		"memory requests": {MemoryRequestsQuery(scope), `kube_pod_container_resource_requests{resource="memory"`},
+		"memory limits":   {MemoryLimitsQuery(scope), `kube_pod_container_resource_limits{resource="memory"`},
+	} {
+		if !strings.Contains(tc.query, tc.want) {
+			t.Errorf("%s: expected %s in %s", name, tc.want, tc.query)
+		}
+		if !strings.Contains(tc.query, `kube_pod_status_phase{phase="Running"} == 1`) {
+			t.Errorf("%s: expected running pods only: %s", name, tc.query)
+		}
+		if !strings.HasPrefix(tc.query, "sum by (label_openchoreo_dev_project_uid)") {
+			t.Errorf("%s: expected sum by project: %s", name, tc.query)
+		}
+	}
+}
+
+func TestMemoryUsageQuery(t *testing.T) {
+	got := MemoryUsageQuery(Scope{Namespace: "ns"})
+	if !strings.HasPrefix(got, `sum by () (container_memory_working_set_bytes{container!=""} * on (pod, namespace)`) {
+		t.Errorf("unexpected query: %s", got)
+	}
+}
+
+func TestRestartsQuery(t *testing.T) {
+	scope := Scope{Namespace: "ns"}
+	if got := RestartsQuery(scope, 5*time.Minute); !strings.Contains(got, "kube_pod_container_status_restarts_total{job=\"kube-state-metrics\"}[300s]") {
+		t.Errorf("expected the step as window: %s", got)
+	}
+	if got := RestartsQuery(scope, 10*time.Second); !strings.Contains(got, "[60s]") {
+		t.Errorf("expected the window to be at least a minute: %s", got)
+	}
+}
+
+func TestHTTPRequestCountQueries(t *testing.T) {
+	scope := Scope{Namespace: "ns", ComponentUID: "c1"}
+	all := HTTPRequestCountQuery(scope)
+	if !strings.Contains(all, `rate(hubble_http_requests_total{reporter="server"}[2m])`) {
+		t.Errorf("unexpected request count query: %s", all)
+	}
+	if !strings.Contains(all, `* on (destination_namespace, destination_pod) group_left (label_openchoreo_dev_component_uid)`) {
+		t.Errorf("expected a join on the destination pods: %s", all)
+	}
+	if got := SuccessfulHTTPRequestCountQuery(scope); !strings.Contains(got, `{reporter="server",status=~"^[123]..?$"}`) {
+		t.Errorf("unexpected successful request count query: %s", got)
+	}
+	if got := UnsuccessfulHTTPRequestCountQuery(scope); !strings.Contains(got, `{reporter="server",status=~"^[45]..?$"}`) {
+		t.Errorf("unexpected unsuccessful request count query: %s", got)
+	}
+}
+
+func TestHTTPLatencyQueries(t *testing.T) {
+	scope := Scope{Namespace: "ns", ComponentUID: "c1"}
+	mean := MeanHTTPLatencyQuery(scope)
+	if !strings.Contains(mean, "hubble_http_request_duration_seconds_sum") || !strings.HasSuffix(mean, ") >= 0") {
+		t.Errorf("unexpected mean latency query: %s", mean)
+	}
+	p99 := HTTPLatencyQuantileQuery(scope, 0.99)
+	if !strings.HasPrefix(p99, "histogram_quantile(0.99, sum by (label_openchoreo_dev_component_uid, le) (") {
+		t.Errorf("unexpected quantile query: %s", p99)
+	}
+}
+
+func TestPromDuration(t *testing.T) {
+	for d, want := range map[time.Duration]string{
+		time.Second:             "1s",
+		90 * time.Second:        "90s",
+		time.Hour:               "3600s",
+		1500 * time.Millisecond: "1s",
+	} {
+		if got := promDuration(d); got != want {
+			t.Errorf("promDuration(%s) = %s, want %s", d, got, want)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-metrics-openobserve/internal/api/gen"
+)
+
+type Server struct {
+	port       string
+	httpServer *http.Server
+	logger     *slog.Logger
+}
+
+func NewServer(port string, metricsHandler *MetricsHandler, logger *slog.Logger) *Server {
+	strictHandler := gen.NewStrictHandler(metricsHandler, nil)
+
+	mux := http.NewServeMux()
+	if metricsHandler.metrics != nil {
+		mux.Handle("GET /metrics", metricsHandler.metrics)
+	}
+	handler := gen.HandlerFromMux(strictHandler, mux)
+
+	httpServer := &http.Server{
+		Addr:         ":" + port,
+		Handler:      metricsHandler.metrics.Instrument(withAuthentication(metricsHandler.authenticator, metricsHandler.authExemptPaths, metricsHandler.metrics.Route(handler))),
+		ReadTimeout:  15 * time.Second,
+		WriteTimeout: 15 * time.Second,
+		IdleTimeout:  60 * time.Second,
+	}
+
+	return &Server{
+		port:       port,
+		httpServer: httpServer,
+		logger:     logger,
+	}
+}
+
+func (s *Server) Start() error {
+	s.logger.Info("Starting server", slog.String("port", s.port))
+	if err := s.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return fmt.Errorf("failed to start server: %w", err)
+	}
+	return nil
+}
+
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.logger.Info("Shutting down server")
+	return s.httpServer.Shutdown(ctx)
+}
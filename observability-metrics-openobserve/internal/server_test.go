@@ -0,0 +1,52 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openchoreo/community-modules/common/metrics"
+)
+
+func TestServerMetrics(t *testing.T) {
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer ooServer.Close()
+
+	client := newTestClient(ooServer.URL)
+
+	rec := httptest.NewRecorder()
+	NewServer("0", NewMetricsHandler(client, testLogger()), testLogger()).httpServer.Handler.
+		ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 without metrics, got %d", rec.Code)
+	}
+
+	m := metrics.New("metrics_adapter")
+	client.AddRequestObserver(m.ObserveBackend)
+	handler := NewMetricsHandler(client, testLogger())
+	handler.SetMetrics(m)
+	srv := NewServer("0", handler, testLogger()).httpServer.Handler
+	srv.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/api/v1/metrics/query",
+		strings.NewReader(`{"metric":"http","startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-01T01:00:00Z","searchScope":{"namespace":"test-ns"}}`)))
+
+	rec = httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{
+		`metrics_adapter_http_requests_total{handler="/api/v1/metrics/query",method="POST",code="500"} 1`,
+		`metrics_adapter_openobserve_requests_total{operation="promql",code="502"} 7`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected %q in:\n%s", want, body)
+		}
+	}
+}
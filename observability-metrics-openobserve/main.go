@@ -0,0 +1,104 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/auth"
+	"github.com/openchoreo/community-modules/common/metrics"
+	app "github.com/openchoreo/community-modules/observability-metrics-openobserve/internal"
+	"github.com/openchoreo/community-modules/observability-metrics-openobserve/internal/openobserve"
+)
+
+func main() {
+	cfg, err := app.LoadConfig()
+	if err != nil {
+		logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+			Level: slog.LevelInfo,
+		}))
+		logger.Error("Failed to load configuration", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+		Level: cfg.LogLevel,
+	}))
+
+	logger.Info("Configurations loaded from environment variables successfully",
+		slog.String("Log Level", cfg.LogLevel.String()),
+		slog.String("OpenObserve URL", cfg.OpenObserveURL),
+		slog.String("OpenObserve Org", cfg.OpenObserveOrg),
+		slog.String("OpenObserve User", cfg.OpenObserveUser),
+		slog.String("OpenObserve Password", string(cfg.OpenObservePassword[0])+"*****"),
+		slog.String("Server Port", cfg.ServerPort),
+	)
+
+	client := openobserve.NewClient(
+		cfg.OpenObserveURL,
+		cfg.OpenObserveOrg,
+		cfg.OpenObserveUser,
+		cfg.OpenObservePassword,
+		logger,
+	)
+	client.SetRetryPolicy(cfg.OpenObserveRetry)
+	serverMetrics := metrics.New("metrics_adapter")
+	client.AddRequestObserver(serverMetrics.ObserveBackend)
+
+	// Check OpenObserve connectivity when starting the adapter. If the connection fails,
+	// exit with an error because the adapter cannot function without connecting to
+	// OpenObserve.
+	healthCtx, cancelHealth := context.WithTimeout(context.Background(), 10*time.Second)
+	err = client.HealthCheck(healthCtx)
+	cancelHealth()
+	if err != nil {
+		logger.Error("Failed to connect to OpenObserve. Cannot continue without it. Hence shutting down", slog.Any("error", err))
+		os.Exit(1)
+	}
+	logger.Info("Successfully connected to OpenObserve")
+
+	// Create handlers and server
+	metricsHandler := app.NewMetricsHandler(client, logger)
+	metricsHandler.SetMetrics(serverMetrics)
+	authenticator, err := auth.New(cfg.Auth)
+	if err != nil {
+		logger.Error("Failed to configure authentication", slog.Any("error", err))
+		os.Exit(1)
+	}
+	if authenticator != nil {
+		metricsHandler.SetAuthenticator(authenticator, cfg.Auth.ExemptPaths)
+		logger.Info("Request authentication enabled",
+			slog.String("mode", cfg.Auth.Mode), slog.Any("exemptPaths", cfg.Auth.ExemptPaths))
+	}
+	srv := app.NewServer(cfg.ServerPort, metricsHandler, logger)
+
+	go func() {
+		if err := srv.Start(); err != nil {
+			logger.Error("Server error", slog.Any("error", err))
+			os.Exit(1)
+		}
+	}()
+
+	// Shutdown logic
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	logger.Info("Shutting down gracefully")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := srv.Shutdown(ctx); err != nil {
+		logger.Error("Error during shutdown", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	logger.Info("Server stopped")
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/openchoreo/community-modules/common/access"
+	"github.com/openchoreo/community-modules/common/ratelimit"
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/api/gen"
+)
+
+// rawRoutes are the routes returning spans or span details, which the
+// callers of an aggregation-only rule may not read in its namespaces. Share
+// links hand their query to anyone holding them, so creating one counts as
+// reading raw content. Trace summaries, services and statistics are
+// aggregates. The span lookups have no namespace and are therefore denied to
+// the callers restricted in any.
+var rawRoutes = []string{
+	"POST /api/v1alpha1/traces/{traceId}/spans/query",
+	"GET /api/v1alpha1/traces/{traceId}/spans/{spanId}",
+	"GET /api/v1alpha1/spans/{spanId}",
+	"POST /api/v1alpha1/traces/shares",
+}
+
+// withAccessPolicy identifies the caller of each request with policy and
+// rejects the requests for the rawRoutes of namespaces it is restricted to
+// aggregates in with 403. Requests are passed through untouched when no
+// access policy is configured.
+func withAccessPolicy(policy *access.Policy, next http.Handler) http.Handler {
+	return policy.Middleware(rawRoutes, accessNamespace, func(w http.ResponseWriter, r *http.Request, err error) {
+		writeJSONError(w, http.StatusForbidden, gen.Forbidden, err.Error())
+	}, next)
+}
+
+// accessNamespace returns the namespace a request reads: the one of its
+// searchScope or namespace query parameter or, for share links, the one of
+// the query they share. The body is left for the handler to read.
+func accessNamespace(r *http.Request) string {
+	if namespace := ratelimit.Namespace(r); namespace != "" || r.Body == nil || r.Body == http.NoBody {
+		return namespace
+	}
+	body, err := io.ReadAll(r.Body)
+	_ = r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	var share struct {
+		Query struct {
+			SearchScope struct {
+				Namespace string `json:"namespace"`
+			} `json:"searchScope"`
+		} `json:"query"`
+	}
+	if err != nil || json.Unmarshal(body, &share) != nil {
+		return ""
+	}
+	return strings.TrimSpace(share.Query.SearchScope.Namespace)
+}
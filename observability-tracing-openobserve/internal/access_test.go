@@ -0,0 +1,95 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/openchoreo/community-modules/common/access"
+	"github.com/openchoreo/community-modules/common/auth"
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/openobserve"
+)
+
+func TestAggregationOnlyPolicy(t *testing.T) {
+	var searches atomic.Int32
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		searches.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"took":1,"hits":[],"total":0}`))
+	}))
+	defer ooServer.Close()
+
+	t.Setenv("SRE_TOKEN", "sre-token-0123456789")
+	policy, err := access.NewPolicy(access.File{
+		Callers:         []access.Caller{{Name: "sre", TokenEnv: "SRE_TOKEN"}},
+		AggregationOnly: []access.Rule{{Namespaces: []string{"payments"}, Except: []string{"sre"}}},
+	})
+	if err != nil {
+		t.Fatalf("NewPolicy() error = %v", err)
+	}
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "admin", "pass", testLogger())
+	handler := NewTracingHandler(client, testLogger())
+	handler.SetAuthenticator(auth.NewStaticToken("service-token-0123456789"), nil)
+	handler.SetAccessPolicy(policy)
+	srv := NewServer("0", handler, testLogger()).httpServer.Handler
+
+	serve := func(method, target, body, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		return rec
+	}
+	query := func(namespace string) string {
+		return `{"startTime":"2026-01-01T00:00:00Z","endTime":"2026-01-01T01:00:00Z","searchScope":{"namespace":"` + namespace + `"}}`
+	}
+	const restricted = "service-token-0123456789"
+
+	t.Run("spans are rejected", func(t *testing.T) {
+		searches.Store(0)
+		for _, tc := range []struct{ method, target, body string }{
+			{http.MethodPost, "/api/v1alpha1/traces/t1/spans/query", query("payments")},
+			{http.MethodGet, "/api/v1alpha1/traces/t1/spans/s1", ""},
+			{http.MethodGet, "/api/v1alpha1/spans/s1", ""},
+			{http.MethodPost, "/api/v1alpha1/traces/shares", `{"path":"/api/v1alpha1/traces/t1/spans/query","query":` + query("payments") + `}`},
+		} {
+			rec := serve(tc.method, tc.target, tc.body, restricted)
+			if rec.Code != http.StatusForbidden || !strings.Contains(rec.Body.String(), access.ErrAggregationOnly.Error()) {
+				t.Errorf("%s %s: expected 403, got %d: %s", tc.method, tc.target, rec.Code, rec.Body.String())
+			}
+		}
+		if n := searches.Load(); n != 0 {
+			t.Errorf("expected no search for rejected requests, got %d", n)
+		}
+	})
+
+	t.Run("aggregates are served", func(t *testing.T) {
+		for _, tc := range []struct{ method, target, body string }{
+			{http.MethodPost, "/api/v1alpha1/traces/query", query("payments")},
+			{http.MethodGet, "/api/v1alpha1/traces/services?namespace=payments", ""},
+			{http.MethodPost, "/api/v1alpha1/traces/operations/stats", query("payments")},
+		} {
+			if rec := serve(tc.method, tc.target, tc.body, restricted); rec.Code != http.StatusOK {
+				t.Errorf("%s %s: expected 200, got %d: %s", tc.method, tc.target, rec.Code, rec.Body.String())
+			}
+		}
+	})
+
+	t.Run("exempt caller reads spans", func(t *testing.T) {
+		if rec := serve(http.MethodPost, "/api/v1alpha1/traces/t1/spans/query", query("payments"), "sre-token-0123456789"); rec.Code != http.StatusOK {
+			t.Errorf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("other namespaces are unrestricted", func(t *testing.T) {
+		if rec := serve(http.MethodPost, "/api/v1alpha1/traces/t1/spans/query", query("default"), restricted); rec.Code != http.StatusOK {
+			t.Errorf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+}
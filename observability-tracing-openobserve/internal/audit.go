@@ -0,0 +1,27 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/openchoreo/community-modules/common/audit"
+)
+
+// auditedOperations are the routes whose requests are audited: the trace
+// queries and the changes to alert rules.
+var auditedOperations = map[string]audit.Operation{
+	"POST /api/v1alpha1/traces/query":                     {Name: "QueryTraces", Results: "traces"},
+	"POST /api/v1alpha1/traces/alerts/rules":              {Name: "CreateAlertRule"},
+	"PUT /api/v1alpha1/traces/alerts/rules/{ruleName}":    {Name: "UpdateAlertRule"},
+	"DELETE /api/v1alpha1/traces/alerts/rules/{ruleName}": {Name: "DeleteAlertRule"},
+}
+
+// withAudit records the requests for the auditedOperations in log. The
+// adapter does not name its callers, so they are identified by their
+// remote address. Requests served from the response cache are audited too.
+// Requests are passed through untouched when no audit log is configured.
+func withAudit(log *audit.Log, next http.Handler) http.Handler {
+	return log.Middleware(auditedOperations, nil, next)
+}
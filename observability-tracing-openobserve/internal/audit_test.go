@@ -0,0 +1,49 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openchoreo/community-modules/common/audit"
+)
+
+func TestAudit(t *testing.T) {
+	var out bytes.Buffer
+	log := audit.NewLog(audit.NewWriterSink(&out), "tracing_adapter", testLogger())
+	handler := NewBackendHandler(&fakeBackend{}, testLogger())
+	handler.SetAuditLog(log)
+	srv := NewBackendServer("0", handler, testLogger()).httpServer.Handler
+
+	body := `{"startTime":"2026-01-01T00:00:00Z","endTime":"2026-01-01T01:00:00Z","searchScope":{"namespace":"acme"}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1alpha1/traces/query", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	log.Flush(context.Background())
+
+	var event audit.Event
+	if err := json.Unmarshal(out.Bytes(), &event); err != nil {
+		t.Fatalf("expected a single JSON event, got %q: %v", out.String(), err)
+	}
+	if event.Operation != "QueryTraces" || event.Scope["namespace"] != "acme" || event.RemoteAddr == "" ||
+		event.Status != http.StatusOK || event.ResultSize == nil || *event.ResultSize != 1 {
+		t.Errorf("unexpected event: %+v", event)
+	}
+
+	rec = httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if want := `tracing_adapter_audit_events_total{result="written"} 1`; !strings.Contains(rec.Body.String(), want) {
+		t.Errorf("expected metrics to contain %q, got:\n%s", want, rec.Body.String())
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/openchoreo/community-modules/common/auth"
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/openobserve"
+)
+
+func TestServerAuthentication(t *testing.T) {
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"took":1,"hits":[]}`))
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "admin", "pass", testLogger())
+	handler := NewTracingHandler(client, testLogger())
+	handler.SetAuthenticator(auth.NewStaticToken("0123456789abcdef"), []string{"/healthz"})
+	srv := NewServer("0", handler, testLogger()).httpServer.Handler
+
+	tests := []struct {
+		name          string
+		target        string
+		authorization string
+		want          int
+	}{
+		{"valid token", "/api/v1alpha1/traces/services?namespace=test-ns", "Bearer 0123456789abcdef", http.StatusOK},
+		{"missing token", "/api/v1alpha1/traces/services?namespace=test-ns", "", http.StatusUnauthorized},
+		{"wrong token", "/api/v1alpha1/traces/services?namespace=test-ns", "Bearer wrong", http.StatusUnauthorized},
+		{"exempt health check", "/healthz", "", http.StatusOK},
+		{"shared view", "/api/v1alpha1/traces/shared/token", "", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			srv.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Fatalf("expected %d, got %d: %s", tt.want, rec.Code, rec.Body.String())
+			}
+			if rec.Code == http.StatusUnauthorized && rec.Header().Get("WWW-Authenticate") != "Bearer" {
+				t.Errorf("expected a WWW-Authenticate header")
+			}
+		})
+	}
+}
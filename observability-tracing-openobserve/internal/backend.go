@@ -0,0 +1,37 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/openobserve"
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/tempo"
+)
+
+// TracesBackend is a store of spans serving the core tracing API: the
+// queries of traces, of the spans of a trace and of span details. The
+// OpenObserve and Tempo clients are.
+type TracesBackend interface {
+	GetTraces(ctx context.Context, params openobserve.TracesQueryParams) (*openobserve.TracesResult, error)
+	GetSpans(ctx context.Context, params openobserve.TracesQueryParams) (*openobserve.SpansResult, error)
+	GetSpanDetail(ctx context.Context, params openobserve.TracesQueryParams) (*openobserve.SpanDetailResult, error)
+}
+
+var (
+	_ TracesBackend = (*openobserve.Client)(nil)
+	_ TracesBackend = (*tempo.Client)(nil)
+)
+
+// NewBackendHandler creates a handler serving the core tracing API from
+// backend. The extensions of the API that are specific to OpenObserve, such
+// as alerts, pins and service graphs, are not available; serve it with
+// NewBackendServer.
+func NewBackendHandler(backend TracesBackend, logger *slog.Logger) *TracingHandler {
+	return &TracingHandler{
+		backend: backend,
+		logger:  logger,
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/openobserve"
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/tempo"
+)
+
+// fakeBackend is a TracesBackend serving fixed results.
+type fakeBackend struct {
+	tracesParams openobserve.TracesQueryParams
+	err          error
+}
+
+func (b *fakeBackend) GetTraces(_ context.Context, params openobserve.TracesQueryParams) (*openobserve.TracesResult, error) {
+	b.tracesParams = params
+	if b.err != nil {
+		return nil, b.err
+	}
+	return &openobserve.TracesResult{
+		Traces: []openobserve.TraceEntry{{TraceID: "t1", SpanCount: 2, Complete: true}},
+		Total:  1,
+	}, nil
+}
+
+func (b *fakeBackend) GetSpans(context.Context, openobserve.TracesQueryParams) (*openobserve.SpansResult, error) {
+	start := time.Unix(1, 0)
+	return &openobserve.SpansResult{
+		Spans: []openobserve.SpanEntry{{
+			SpanID:    "s1",
+			StartTime: start,
+			EndTime:   start.Add(time.Second),
+			Events:    []openobserve.SpanEvent{{Name: "exception", Time: start}},
+		}},
+		Total: 1,
+	}, b.err
+}
+
+func (b *fakeBackend) GetSpanDetail(_ context.Context, params openobserve.TracesQueryParams) (*openobserve.SpanDetailResult, error) {
+	return &openobserve.SpanDetailResult{Span: openobserve.SpanDetail{SpanID: params.SpanID}}, b.err
+}
+
+func TestBackendServer(t *testing.T) {
+	backend := &fakeBackend{}
+	srv := NewBackendServer("0", NewBackendHandler(backend, testLogger()), testLogger()).httpServer.Handler
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		return rec
+	}
+	const query = `{"startTime":"2026-01-01T00:00:00Z","endTime":"2026-01-01T01:00:00Z","searchScope":{"namespace":"acme"}`
+
+	t.Run("traces", func(t *testing.T) {
+		rec := serve(http.MethodPost, "/api/v1alpha1/traces/query", query+`,"errorsOnly":true}`)
+		if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"traceId":"t1"`) {
+			t.Fatalf("expected the traces of the backend, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if backend.tracesParams.Scope.Namespace != "acme" || !backend.tracesParams.Filters.ErrorsOnly {
+			t.Errorf("expected the scope and filters to reach the backend, got %+v", backend.tracesParams)
+		}
+	})
+
+	t.Run("unsupported traces queries", func(t *testing.T) {
+		backend.err = fmt.Errorf("offset: %w", tempo.ErrUnsupported)
+		defer func() { backend.err = nil }()
+		if rec := serve(http.MethodPost, "/api/v1alpha1/traces/query", query+`}`); rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("span timelines without correlated logs", func(t *testing.T) {
+		rec := serve(http.MethodPost, "/api/v1alpha1/traces/t1/spans/query", query+`,"timeline":true}`)
+		if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"name":"exception"`) {
+			t.Errorf("expected the span events in the timeline, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("span details", func(t *testing.T) {
+		rec := serve(http.MethodGet, "/api/v1alpha1/traces/t1/spans/s1", "")
+		if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"spanId":"s1"`) {
+			t.Errorf("expected the span of the backend, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("extensions of OpenObserve", func(t *testing.T) {
+		if rec := serve(http.MethodGet, "/api/v1alpha1/traces/services", ""); rec.Code != http.StatusNotFound {
+			t.Errorf("expected 404, got %d", rec.Code)
+		}
+	})
+}
@@ -4,11 +4,30 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
+	"net/url"
 	"os"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/audit"
+	"github.com/openchoreo/community-modules/common/auth"
+	ooclient "github.com/openchoreo/community-modules/common/openobserve"
+	"github.com/openchoreo/community-modules/common/ratelimit"
+	"github.com/openchoreo/community-modules/common/respcache"
+	"github.com/openchoreo/community-modules/common/secrets"
+	"github.com/openchoreo/community-modules/common/shares"
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/openobserve"
+)
+
+// Backends of the traces, selected with TRACES_BACKEND.
+const (
+	BackendOpenObserve = "openobserve"
+	BackendTempo       = "tempo"
 )
 
 type Config struct {
@@ -19,16 +38,161 @@ type Config struct {
 	OpenObserveUser     string
 	OpenObservePassword string
 	LogLevel            slog.Level
+	// AlertDestinations are the OpenObserve alert destinations notified by
+	// trace alert rules.
+	AlertDestinations []string
+	// OpenObserveLogsStream is the logs stream searched for log lines
+	// correlated with spans.
+	OpenObserveLogsStream string
+
+	// PasswordSecret caches the OpenObserve password when it is read from the
+	// external secret store referenced by OPENOBSERVE_PASSWORD_SOURCE instead
+	// of OPENOBSERVE_PASSWORD. It is re-read every SecretRefreshInterval so
+	// that rotated passwords are picked up.
+	PasswordSecret        *secrets.Cached
+	SecretRefreshInterval time.Duration
+	// SecondaryUser and SecondaryPassword are the OpenObserve credentials
+	// a call rejected with the primary ones is retried with, so that the
+	// password can be rotated without a maintenance window. They are
+	// unused when SecondaryPassword is empty.
+	SecondaryUser     string
+	SecondaryPassword string
+
+	// ShareSigningKey is the HMAC key share links are signed with. Share
+	// links are enabled when it is set, and last at most ShareLinkMaxTTL.
+	ShareSigningKey string
+	ShareLinkMaxTTL time.Duration
+
+	// TraceArchiveStream is the long-retention OpenObserve logs stream pinned
+	// traces are copied to. Pinning is disabled when it is empty.
+	TraceArchiveStream string
+
+	// TraceStreamRoutes route the traces queries of scopes to other streams
+	// than OpenObserveStream.
+	TraceStreamRoutes openobserve.StreamRoutes
+
+	// RollupStream is the stream the latency rollups of the traces stream
+	// are kept in, rebuilt every RollupInterval for the last RollupWindow.
+	// Latency histograms are read from them when they cover their window.
+	// Rollups are disabled when it is empty.
+	RollupStream   string
+	RollupInterval time.Duration
+	RollupWindow   time.Duration
+
+	// OpenObserveRetry configures the retries of the calls to OpenObserve
+	// and the circuit breaker rejecting them after repeated failures.
+	OpenObserveRetry ooclient.RetryPolicy
+
+	// SpanAttributes classify the fields of spans into span and resource
+	// attributes in span details, and drop noisy ones.
+	SpanAttributes openobserve.AttributeRules
+
+	// Auth configures the authentication of the requests served by the
+	// adapter. All requests are accepted by default.
+	Auth auth.Config
+
+	// ResponseCache configures the cache serving repeated trace queries. No
+	// response is cached by default.
+	ResponseCache respcache.Config
+
+	// RateLimit bounds the rate of the query requests of each namespace and
+	// the number of them in flight. Requests are not limited by default.
+	RateLimit ratelimit.Config
+
+	// Audit sets where the trace queries and alert operations are audited.
+	// They are not audited by default.
+	Audit audit.Config
+
+	// DebugQueryToken is the bearer token of the admin callers allowed to
+	// debug the OpenObserve searches of their requests with
+	// X-Debug-Query. Queries cannot be debugged when it is empty.
+	DebugQueryToken string
+
+	// AccessPolicyFile is a JSON file naming callers by bearer token and the
+	// namespaces in which some of them may read trace summaries, services
+	// and statistics but no spans. No caller is restricted when it is empty.
+	AccessPolicyFile string
+
+	// Backend is the store of the traces, BackendOpenObserve or
+	// BackendTempo. The OpenObserve settings are only required for
+	// BackendOpenObserve.
+	Backend string
+	// TempoURL, TempoTenantID, TempoUser and TempoPassword locate Tempo and
+	// authenticate with it when Backend is BackendTempo.
+	TempoURL      string
+	TempoTenantID string
+	TempoUser     string
+	TempoPassword string
 }
 
+// streamNamePattern is the syntax of OpenObserve stream names.
+var streamNamePattern = regexp.MustCompile(`^[a-zA-Z0-9_]+$`)
+
 // LoadConfig loads configuration from environment variables
 func LoadConfig() (*Config, error) {
 	serverPort := getEnv("SERVER_PORT", "9100")
 	openObserveURL := getEnv("OPENOBSERVE_URL", "")
 	openObserveOrg := getEnv("OPENOBSERVE_ORG", "default")
 	openObserveStream := getEnv("OPENOBSERVE_STREAM", "default")
+	openObserveLogsStream := getEnv("OPENOBSERVE_LOGS_STREAM", "default")
 	openObserveUser := getEnv("OPENOBSERVE_USER", "")
 	openObservePassword := getEnv("OPENOBSERVE_PASSWORD", "")
+	openObservePasswordSource := getEnv("OPENOBSERVE_PASSWORD_SOURCE", "")
+	secondaryUser := getEnv("OPENOBSERVE_SECONDARY_USER", openObserveUser)
+	secondaryPassword := getEnv("OPENOBSERVE_SECONDARY_PASSWORD", "")
+	secretRefreshInterval := getEnv("SECRET_REFRESH_INTERVAL", "5m")
+	shareSigningKey := getEnv("SHARE_SIGNING_KEY", "")
+	shareLinkMaxTTL := getEnv("SHARE_LINK_MAX_TTL", "24h")
+	traceArchiveStream := getEnv("TRACE_ARCHIVE_STREAM", "")
+	traceStreamRoutes := getEnv("TRACE_STREAM_ROUTES", "")
+	rollupStream := getEnv("ROLLUP_STREAM", "")
+	rollupInterval := getEnv("ROLLUP_INTERVAL", "1m")
+	rollupWindow := getEnv("ROLLUP_WINDOW", "24h")
+	retryMaxAttempts := getEnv("OPENOBSERVE_RETRY_MAX_ATTEMPTS", strconv.Itoa(ooclient.DefaultRetryPolicy.MaxAttempts))
+	retryInitialBackoff := getEnv("OPENOBSERVE_RETRY_INITIAL_BACKOFF", ooclient.DefaultRetryPolicy.InitialBackoff.String())
+	retryMaxBackoff := getEnv("OPENOBSERVE_RETRY_MAX_BACKOFF", ooclient.DefaultRetryPolicy.MaxBackoff.String())
+	breakerThreshold := getEnv("OPENOBSERVE_BREAKER_THRESHOLD", strconv.Itoa(ooclient.DefaultRetryPolicy.BreakerThreshold))
+	breakerCooldown := getEnv("OPENOBSERVE_BREAKER_COOLDOWN", ooclient.DefaultRetryPolicy.BreakerCooldown.String())
+	alertDestinations := splitList(getEnv("ALERT_DESTINATIONS", "openchoreo"))
+	// An empty SPAN_RESOURCE_PREFIXES is kept: it makes all fields span attributes.
+	spanResourcePrefixes, ok := os.LookupEnv("SPAN_RESOURCE_PREFIXES")
+	if !ok {
+		spanResourcePrefixes = strings.Join(openobserve.DefaultAttributeRules.ResourcePrefixes, ",")
+	}
+	spanAttributeFields := getEnv("SPAN_ATTRIBUTE_FIELDS", "")
+	spanDroppedFields := getEnv("SPAN_DROPPED_FIELDS", "")
+	backend := getEnv("TRACES_BACKEND", BackendOpenObserve)
+	tempoURL := getEnv("TEMPO_URL", "")
+	tempoTenantID := getEnv("TEMPO_TENANT_ID", "")
+	tempoUser := getEnv("TEMPO_USER", "")
+	tempoPassword := getEnv("TEMPO_PASSWORD", "")
+	responseCacheMemoryMB := getEnv("RESPONSE_CACHE_MEMORY_MB", "64")
+	responseCacheRecentTTL := getEnv("RESPONSE_CACHE_RECENT_TTL", respcache.DefaultRecentTTL.String())
+	responseCacheHistoricalTTL := getEnv("RESPONSE_CACHE_HISTORICAL_TTL", respcache.DefaultHistoricalTTL.String())
+	responseCacheConfig := respcache.Config{
+		Backend:       getEnv("RESPONSE_CACHE_BACKEND", respcache.BackendNone),
+		RedisURL:      getEnv("RESPONSE_CACHE_REDIS_URL", ""),
+		RedisPassword: getEnv("RESPONSE_CACHE_REDIS_PASSWORD", ""),
+		Compression:   getEnv("RESPONSE_CACHE_COMPRESSION", respcache.CompressionNone),
+		Policy:        respcache.Policy{Settle: respcache.DefaultSettle},
+	}
+	rateLimitRPS := getEnv("RATE_LIMIT_RPS", "0")
+	rateLimitBurst := getEnv("RATE_LIMIT_BURST", "0")
+	rateLimitMaxInFlight := getEnv("RATE_LIMIT_MAX_IN_FLIGHT", "0")
+	auditConfig := audit.Config{
+		Sink:   getEnv("AUDIT_LOG_SINK", audit.SinkNone),
+		Stream: getEnv("AUDIT_LOG_STREAM", "audit"),
+	}
+	debugQueryToken := getEnv("DEBUG_QUERY_TOKEN", "")
+	accessPolicyFile := getEnv("ACCESS_POLICY_FILE", "")
+	authConfig := auth.Config{
+		Mode:        getEnv("AUTH_MODE", auth.ModeNone),
+		Token:       getEnv("AUTH_TOKEN", ""),
+		JWKSURL:     getEnv("AUTH_JWKS_URL", ""),
+		Issuer:      getEnv("AUTH_JWT_ISSUER", ""),
+		Audience:    getEnv("AUTH_JWT_AUDIENCE", ""),
+		ExemptPaths: splitList(getEnv("AUTH_EXEMPT_PATHS", "/healthz")),
+	}
 
 	// Parse log level
 	logLevel := slog.LevelInfo
@@ -45,16 +209,156 @@ func LoadConfig() (*Config, error) {
 		}
 	}
 
-	if openObserveURL == "" {
+	switch backend {
+	case BackendOpenObserve:
+	case BackendTempo:
+		parsedTempoURL, err := url.Parse(tempoURL)
+		if tempoURL == "" || err != nil || parsedTempoURL.Scheme == "" || parsedTempoURL.Host == "" {
+			return nil, fmt.Errorf("invalid TEMPO_URL: a URL with scheme and host is required for the tempo backend")
+		}
+	default:
+		return nil, fmt.Errorf("invalid TRACES_BACKEND %q: must be %s or %s", backend, BackendOpenObserve, BackendTempo)
+	}
+
+	if openObserveURL == "" && backend == BackendOpenObserve {
 		return nil, fmt.Errorf("Environment variable OPENOBSERVE_URL is required")
 	}
 
-	if openObserveUser == "" {
+	if openObserveUser == "" && backend == BackendOpenObserve {
 		return nil, fmt.Errorf("Environment variable OPENOBSERVE_USER is required")
 	}
 
-	if openObservePassword == "" {
-		return nil, fmt.Errorf("Environment variable OPENOBSERVE_PASSWORD is required")
+	refreshInterval, err := time.ParseDuration(secretRefreshInterval)
+	if err != nil || refreshInterval < time.Second {
+		return nil, fmt.Errorf("invalid SECRET_REFRESH_INTERVAL: must be a duration of at least 1s")
+	}
+
+	var passwordSecret *secrets.Cached
+	if openObservePasswordSource != "" {
+		provider, err := secrets.Parse(openObservePasswordSource)
+		if err != nil {
+			return nil, fmt.Errorf("invalid OPENOBSERVE_PASSWORD_SOURCE: %w", err)
+		}
+		passwordSecret = secrets.NewCached(provider, refreshInterval)
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		openObservePassword, err = passwordSecret.Get(ctx)
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the OpenObserve password from OPENOBSERVE_PASSWORD_SOURCE: %w", err)
+		}
+	}
+
+	if openObservePassword == "" && backend == BackendOpenObserve {
+		return nil, fmt.Errorf("Environment variable OPENOBSERVE_PASSWORD or OPENOBSERVE_PASSWORD_SOURCE is required")
+	}
+
+	if shareSigningKey != "" && len(shareSigningKey) < shares.MinKeyLength {
+		return nil, fmt.Errorf("invalid SHARE_SIGNING_KEY: must be at least %d bytes long", shares.MinKeyLength)
+	}
+	maxTTL, err := time.ParseDuration(shareLinkMaxTTL)
+	if err != nil || maxTTL <= 0 {
+		return nil, fmt.Errorf("invalid SHARE_LINK_MAX_TTL: must be a positive duration")
+	}
+
+	if traceArchiveStream != "" && (!streamNamePattern.MatchString(traceArchiveStream) || traceArchiveStream == openObserveStream) {
+		return nil, fmt.Errorf("invalid TRACE_ARCHIVE_STREAM: must be a stream name of letters, digits and underscores other than OPENOBSERVE_STREAM")
+	}
+	streamRoutes, err := openobserve.ParseStreamRoutes(traceStreamRoutes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TRACE_STREAM_ROUTES: %w", err)
+	}
+	if rollupStream != "" && (!streamNamePattern.MatchString(rollupStream) || rollupStream == openObserveStream) {
+		return nil, fmt.Errorf("invalid ROLLUP_STREAM: must be a stream name of letters, digits and underscores other than OPENOBSERVE_STREAM")
+	}
+	rollupEvery, err := time.ParseDuration(rollupInterval)
+	if err != nil || rollupEvery < 10*time.Second {
+		return nil, fmt.Errorf("invalid ROLLUP_INTERVAL: must be a duration of at least 10s")
+	}
+	rollupSpan, err := time.ParseDuration(rollupWindow)
+	if err != nil || rollupSpan < time.Hour {
+		return nil, fmt.Errorf("invalid ROLLUP_WINDOW: must be a duration of at least 1h")
+	}
+
+	var retry ooclient.RetryPolicy
+	if retry.MaxAttempts, err = strconv.Atoi(retryMaxAttempts); err != nil || retry.MaxAttempts < 1 {
+		return nil, fmt.Errorf("invalid OPENOBSERVE_RETRY_MAX_ATTEMPTS: must be a positive integer")
+	}
+	if retry.InitialBackoff, err = time.ParseDuration(retryInitialBackoff); err != nil || retry.InitialBackoff <= 0 {
+		return nil, fmt.Errorf("invalid OPENOBSERVE_RETRY_INITIAL_BACKOFF: must be a positive duration")
+	}
+	if retry.MaxBackoff, err = time.ParseDuration(retryMaxBackoff); err != nil || retry.MaxBackoff < retry.InitialBackoff {
+		return nil, fmt.Errorf("invalid OPENOBSERVE_RETRY_MAX_BACKOFF: must be a duration of at least OPENOBSERVE_RETRY_INITIAL_BACKOFF")
+	}
+	if retry.BreakerThreshold, err = strconv.Atoi(breakerThreshold); err != nil || retry.BreakerThreshold < 0 {
+		return nil, fmt.Errorf("invalid OPENOBSERVE_BREAKER_THRESHOLD: must be a non-negative integer")
+	}
+	if retry.BreakerCooldown, err = time.ParseDuration(breakerCooldown); err != nil || retry.BreakerCooldown <= 0 {
+		return nil, fmt.Errorf("invalid OPENOBSERVE_BREAKER_COOLDOWN: must be a positive duration")
+	}
+
+	spanAttributes := openobserve.AttributeRules{
+		ResourcePrefixes: splitList(spanResourcePrefixes),
+		Dropped:          splitList(spanDroppedFields),
+	}
+	for _, mapping := range splitList(spanAttributeFields) {
+		field, className, found := strings.Cut(mapping, "=")
+		field = strings.TrimSpace(field)
+		if !found || field == "" {
+			return nil, fmt.Errorf("invalid SPAN_ATTRIBUTE_FIELDS: %q must be of the form <field>=<class>", mapping)
+		}
+		class, err := openobserve.ParseAttributeClass(strings.TrimSpace(className))
+		if err != nil {
+			return nil, fmt.Errorf("invalid SPAN_ATTRIBUTE_FIELDS: %w", err)
+		}
+		if spanAttributes.Fields == nil {
+			spanAttributes.Fields = map[string]openobserve.AttributeClass{}
+		}
+		spanAttributes.Fields[field] = class
+	}
+
+	if err := authConfig.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid authentication settings (AUTH_*): %w", err)
+	}
+
+	memoryMB, err := strconv.ParseInt(responseCacheMemoryMB, 10, 64)
+	if err != nil || memoryMB < 1 {
+		return nil, fmt.Errorf("invalid RESPONSE_CACHE_MEMORY_MB: must be a positive integer")
+	}
+	responseCacheConfig.MaxBytes = memoryMB << 20
+	if responseCacheConfig.RecentTTL, err = time.ParseDuration(responseCacheRecentTTL); err != nil {
+		return nil, fmt.Errorf("invalid RESPONSE_CACHE_RECENT_TTL: %w", err)
+	}
+	if responseCacheConfig.HistoricalTTL, err = time.ParseDuration(responseCacheHistoricalTTL); err != nil {
+		return nil, fmt.Errorf("invalid RESPONSE_CACHE_HISTORICAL_TTL: %w", err)
+	}
+	if err := responseCacheConfig.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid response cache settings (RESPONSE_CACHE_*): %w", err)
+	}
+
+	var rateLimit ratelimit.Config
+	if rateLimit.RequestsPerSecond, err = strconv.ParseFloat(rateLimitRPS, 64); err != nil {
+		return nil, fmt.Errorf("invalid RATE_LIMIT_RPS: must be a non-negative number")
+	}
+	if rateLimit.Burst, err = strconv.Atoi(rateLimitBurst); err != nil {
+		return nil, fmt.Errorf("invalid RATE_LIMIT_BURST: must be a non-negative integer")
+	}
+	if rateLimit.MaxInFlight, err = strconv.Atoi(rateLimitMaxInFlight); err != nil {
+		return nil, fmt.Errorf("invalid RATE_LIMIT_MAX_IN_FLIGHT: must be a non-negative integer")
+	}
+	if err := rateLimit.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid rate limit settings (RATE_LIMIT_*): %w", err)
+	}
+	if err := auditConfig.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid audit log settings (AUDIT_LOG_*): %w", err)
+	}
+	if auditConfig.Sink == audit.SinkStream && backend == BackendTempo {
+		return nil, fmt.Errorf("invalid AUDIT_LOG_SINK: %s requires the %s backend", audit.SinkStream, BackendOpenObserve)
+	}
+	if debugQueryToken != "" && len(debugQueryToken) < auth.MinTokenLength {
+		return nil, fmt.Errorf("invalid DEBUG_QUERY_TOKEN: must be at least %d bytes long", auth.MinTokenLength)
+	}
+	if debugQueryToken != "" && backend == BackendTempo {
+		return nil, fmt.Errorf("invalid DEBUG_QUERY_TOKEN: query debugging requires the %s backend", BackendOpenObserve)
 	}
 
 	port, err := strconv.Atoi(serverPort)
@@ -66,16 +370,53 @@ func LoadConfig() (*Config, error) {
 	}
 
 	return &Config{
-		ServerPort:          serverPort,
-		OpenObserveURL:      openObserveURL,
-		OpenObserveOrg:      openObserveOrg,
-		OpenObserveStream:   openObserveStream,
-		OpenObserveUser:     openObserveUser,
-		OpenObservePassword: openObservePassword,
-		LogLevel:            logLevel,
+		ServerPort:            serverPort,
+		OpenObserveURL:        openObserveURL,
+		OpenObserveOrg:        openObserveOrg,
+		OpenObserveStream:     openObserveStream,
+		OpenObserveUser:       openObserveUser,
+		OpenObservePassword:   openObservePassword,
+		LogLevel:              logLevel,
+		AlertDestinations:     alertDestinations,
+		OpenObserveLogsStream: openObserveLogsStream,
+		PasswordSecret:        passwordSecret,
+		SecretRefreshInterval: refreshInterval,
+		SecondaryUser:         secondaryUser,
+		SecondaryPassword:     secondaryPassword,
+		ShareSigningKey:       shareSigningKey,
+		ShareLinkMaxTTL:       maxTTL,
+		TraceArchiveStream:    traceArchiveStream,
+		TraceStreamRoutes:     streamRoutes,
+		RollupStream:          rollupStream,
+		RollupInterval:        rollupEvery,
+		RollupWindow:          rollupSpan,
+		OpenObserveRetry:      retry,
+		SpanAttributes:        spanAttributes,
+		Auth:                  authConfig,
+		ResponseCache:         responseCacheConfig,
+		RateLimit:             rateLimit,
+		Audit:                 auditConfig,
+		DebugQueryToken:       debugQueryToken,
+		AccessPolicyFile:      accessPolicyFile,
+		Backend:               backend,
+		TempoURL:              tempoURL,
+		TempoTenantID:         tempoTenantID,
+		TempoUser:             tempoUser,
+		TempoPassword:         tempoPassword,
 	}, nil
 }
 
+// splitList splits a comma-separated list, dropping blank items.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
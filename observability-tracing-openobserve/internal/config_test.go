@@ -5,8 +5,20 @@ package app
 
 import (
 	"log/slog"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/audit"
+	"github.com/openchoreo/community-modules/common/auth"
+	ooclient "github.com/openchoreo/community-modules/common/openobserve"
+	"github.com/openchoreo/community-modules/common/ratelimit"
+	"github.com/openchoreo/community-modules/common/respcache"
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/openobserve"
 )
 
 // setEnvVars sets multiple environment variables and returns a cleanup function.
@@ -46,6 +58,9 @@ func TestLoadConfig_Success(t *testing.T) {
 	if cfg.OpenObserveStream != "default" {
 		t.Errorf("expected default OpenObserveStream, got %s", cfg.OpenObserveStream)
 	}
+	if cfg.OpenObserveLogsStream != "default" {
+		t.Errorf("expected default OpenObserveLogsStream, got %s", cfg.OpenObserveLogsStream)
+	}
 	if cfg.OpenObserveUser != "admin" {
 		t.Errorf("unexpected OpenObserveUser: %s", cfg.OpenObserveUser)
 	}
@@ -137,6 +152,205 @@ func TestLoadConfig_MissingRequired(t *testing.T) {
 	}
 }
 
+func TestLoadConfig_PasswordSource(t *testing.T) {
+	vault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/secret/data/openobserve" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"data":{"data":{"password":"fromVault"},"metadata":{"version":1}}}`))
+	}))
+	defer vault.Close()
+
+	t.Run("reads the password from the secret store", func(t *testing.T) {
+		setEnvVars(t, validEnvVars())
+		os.Unsetenv("OPENOBSERVE_PASSWORD")
+		t.Setenv("VAULT_ADDR", vault.URL)
+		t.Setenv("VAULT_TOKEN", "root")
+		t.Setenv("OPENOBSERVE_PASSWORD_SOURCE", "vault://secret/data/openobserve#password")
+		t.Setenv("SECRET_REFRESH_INTERVAL", "1m")
+
+		cfg, err := LoadConfig()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cfg.OpenObservePassword != "fromVault" || cfg.PasswordSecret == nil || cfg.SecretRefreshInterval != time.Minute {
+			t.Errorf("unexpected password settings: %q, %v, %v", cfg.OpenObservePassword, cfg.PasswordSecret, cfg.SecretRefreshInterval)
+		}
+	})
+
+	for name, vars := range map[string]map[string]string{
+		"invalid reference": {"OPENOBSERVE_PASSWORD_SOURCE": "password"},
+		"missing secret":    {"OPENOBSERVE_PASSWORD_SOURCE": "vault://secret/data/other#password", "VAULT_ADDR": vault.URL, "VAULT_TOKEN": "root"},
+		"invalid interval":  {"SECRET_REFRESH_INTERVAL": "soon"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			setEnvVars(t, validEnvVars())
+			setEnvVars(t, vars)
+			if _, err := LoadConfig(); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestLoadConfig_ShareLinks(t *testing.T) {
+	setEnvVars(t, validEnvVars())
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.ShareSigningKey != "" || cfg.ShareLinkMaxTTL != 24*time.Hour {
+		t.Errorf("unexpected share link defaults: %q, %v", cfg.ShareSigningKey, cfg.ShareLinkMaxTTL)
+	}
+
+	for name, vars := range map[string]map[string]string{
+		"short signing key": {"SHARE_SIGNING_KEY": "short"},
+		"invalid max ttl":   {"SHARE_LINK_MAX_TTL": "-1h"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			setEnvVars(t, vars)
+			if _, err := LoadConfig(); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestLoadConfig_ResponseCache(t *testing.T) {
+	setEnvVars(t, validEnvVars())
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.ResponseCache.Backend != respcache.BackendNone {
+		t.Errorf("expected responses not to be cached by default, got %q", cfg.ResponseCache.Backend)
+	}
+
+	setEnvVars(t, map[string]string{
+		"RESPONSE_CACHE_BACKEND":        "memory",
+		"RESPONSE_CACHE_MEMORY_MB":      "16",
+		"RESPONSE_CACHE_HISTORICAL_TTL": "1h",
+	})
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := respcache.Config{
+		Backend:     respcache.BackendMemory,
+		MaxBytes:    16 << 20,
+		Compression: respcache.CompressionNone,
+		Policy:      respcache.Policy{RecentTTL: respcache.DefaultRecentTTL, HistoricalTTL: time.Hour, Settle: respcache.DefaultSettle},
+	}
+	if cfg.ResponseCache != want {
+		t.Errorf("unexpected response cache settings: %+v", cfg.ResponseCache)
+	}
+
+	for name, vars := range map[string]map[string]string{
+		"unknown backend":   {"RESPONSE_CACHE_BACKEND": "memcached"},
+		"missing redis URL": {"RESPONSE_CACHE_BACKEND": "redis"},
+		"invalid memory":    {"RESPONSE_CACHE_MEMORY_MB": "lots"},
+		"zero TTL":          {"RESPONSE_CACHE_RECENT_TTL": "0s"},
+		"unknown codec":     {"RESPONSE_CACHE_COMPRESSION": "zstd"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			setEnvVars(t, vars)
+			if _, err := LoadConfig(); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestLoadConfig_RateLimit(t *testing.T) {
+	setEnvVars(t, validEnvVars())
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.RateLimit != (ratelimit.Config{}) {
+		t.Errorf("expected requests not to be limited by default, got %+v", cfg.RateLimit)
+	}
+
+	setEnvVars(t, map[string]string{"RATE_LIMIT_RPS": "5", "RATE_LIMIT_MAX_IN_FLIGHT": "2"})
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := (ratelimit.Config{RequestsPerSecond: 5, MaxInFlight: 2}); cfg.RateLimit != want {
+		t.Errorf("unexpected rate limit settings: %+v", cfg.RateLimit)
+	}
+
+	for name, vars := range map[string]map[string]string{
+		"invalid rate":       {"RATE_LIMIT_RPS": "fast"},
+		"negative burst":     {"RATE_LIMIT_BURST": "-1"},
+		"negative in-flight": {"RATE_LIMIT_MAX_IN_FLIGHT": "-2"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			setEnvVars(t, vars)
+			if _, err := LoadConfig(); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestLoadConfig_Audit(t *testing.T) {
+	setEnvVars(t, validEnvVars())
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Audit.Sink != audit.SinkNone {
+		t.Errorf("expected operations not to be audited by default, got %+v", cfg.Audit)
+	}
+
+	setEnvVars(t, map[string]string{"AUDIT_LOG_SINK": "stdout"})
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := (audit.Config{Sink: audit.SinkStdout, Stream: "audit"}); cfg.Audit != want {
+		t.Errorf("unexpected audit log settings: %+v", cfg.Audit)
+	}
+
+	for name, vars := range map[string]map[string]string{
+		"unknown sink":   {"AUDIT_LOG_SINK": "syslog"},
+		"invalid stream": {"AUDIT_LOG_SINK": "stream", "AUDIT_LOG_STREAM": "audit-log"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			setEnvVars(t, vars)
+			if _, err := LoadConfig(); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestLoadConfig_DebugQueryToken(t *testing.T) {
+	setEnvVars(t, validEnvVars())
+	t.Setenv("DEBUG_QUERY_TOKEN", "admin-token-0123456789")
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.DebugQueryToken != "admin-token-0123456789" {
+		t.Errorf("unexpected debug query token: %q", cfg.DebugQueryToken)
+	}
+
+	for name, vars := range map[string]map[string]string{
+		"short token":   {"DEBUG_QUERY_TOKEN": "short"},
+		"tempo backend": {"TRACES_BACKEND": "tempo", "TEMPO_URL": "http://tempo:3200"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			setEnvVars(t, vars)
+			if _, err := LoadConfig(); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+}
+
 func TestLoadConfig_InvalidServerPort(t *testing.T) {
 	vars := validEnvVars()
 	vars["SERVER_PORT"] = "not-a-number"
@@ -182,3 +396,271 @@ func TestGetEnv(t *testing.T) {
 		t.Errorf("expected 'default', got %q", got)
 	}
 }
+
+func TestLoadConfig_AlertDestinations(t *testing.T) {
+	setEnvVars(t, validEnvVars())
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(cfg.AlertDestinations, ",") != "openchoreo" {
+		t.Errorf("expected default alert destination, got %v", cfg.AlertDestinations)
+	}
+
+	t.Setenv("ALERT_DESTINATIONS", "openchoreo, pagerduty,")
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(cfg.AlertDestinations, ",") != "openchoreo,pagerduty" {
+		t.Errorf("unexpected alert destinations %v", cfg.AlertDestinations)
+	}
+}
+
+func TestLoadConfig_TraceArchiveStream(t *testing.T) {
+	setEnvVars(t, validEnvVars())
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.TraceArchiveStream != "" {
+		t.Errorf("expected the trace archive to be disabled by default, got %q", cfg.TraceArchiveStream)
+	}
+
+	t.Setenv("TRACE_ARCHIVE_STREAM", "pinned_traces")
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.TraceArchiveStream != "pinned_traces" {
+		t.Errorf("unexpected TraceArchiveStream %q", cfg.TraceArchiveStream)
+	}
+
+	for _, stream := range []string{"pinned-traces", "default"} {
+		t.Setenv("TRACE_ARCHIVE_STREAM", stream)
+		if _, err := LoadConfig(); err == nil {
+			t.Errorf("expected error for TRACE_ARCHIVE_STREAM=%s", stream)
+		}
+	}
+}
+
+func TestLoadConfig_Rollups(t *testing.T) {
+	setEnvVars(t, validEnvVars())
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.RollupStream != "" || cfg.RollupInterval != time.Minute || cfg.RollupWindow != 24*time.Hour {
+		t.Errorf("unexpected rollup defaults: %q, %v, %v", cfg.RollupStream, cfg.RollupInterval, cfg.RollupWindow)
+	}
+
+	t.Setenv("ROLLUP_STREAM", "latency_rollups")
+	t.Setenv("ROLLUP_INTERVAL", "30s")
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.RollupStream != "latency_rollups" || cfg.RollupInterval != 30*time.Second {
+		t.Errorf("unexpected rollup settings: %q, %v", cfg.RollupStream, cfg.RollupInterval)
+	}
+
+	for name, vars := range map[string]map[string]string{
+		"traces stream":  {"ROLLUP_STREAM": "default"},
+		"invalid stream": {"ROLLUP_STREAM": "latency-rollups"},
+		"short interval": {"ROLLUP_INTERVAL": "1s"},
+		"short window":   {"ROLLUP_WINDOW": "10m"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			setEnvVars(t, vars)
+			if _, err := LoadConfig(); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestLoadConfig_TraceStreamRoutes(t *testing.T) {
+	setEnvVars(t, validEnvVars())
+	t.Setenv("TRACE_STREAM_ROUTES", "namespace:acme=acme_traces, environment:env-1=prod_traces|default")
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.TraceStreamRoutes) != 2 || cfg.TraceStreamRoutes[1].EnvironmentID != "env-1" ||
+		len(cfg.TraceStreamRoutes[1].Streams) != 2 {
+		t.Errorf("unexpected TraceStreamRoutes %+v", cfg.TraceStreamRoutes)
+	}
+
+	for _, routes := range []string{"acme_traces", "component:c1=traces", "namespace:acme=acme-traces"} {
+		t.Setenv("TRACE_STREAM_ROUTES", routes)
+		if _, err := LoadConfig(); err == nil {
+			t.Errorf("expected error for TRACE_STREAM_ROUTES=%s", routes)
+		}
+	}
+}
+
+func TestLoadConfig_OpenObserveRetry(t *testing.T) {
+	setEnvVars(t, validEnvVars())
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.OpenObserveRetry != ooclient.DefaultRetryPolicy {
+		t.Errorf("unexpected retry defaults: %+v", cfg.OpenObserveRetry)
+	}
+
+	setEnvVars(t, map[string]string{"OPENOBSERVE_RETRY_MAX_ATTEMPTS": "1", "OPENOBSERVE_BREAKER_THRESHOLD": "0"})
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.OpenObserveRetry.MaxAttempts != 1 || cfg.OpenObserveRetry.BreakerThreshold != 0 {
+		t.Errorf("unexpected retry settings: %+v", cfg.OpenObserveRetry)
+	}
+
+	for name, vars := range map[string]map[string]string{
+		"no attempts":       {"OPENOBSERVE_RETRY_MAX_ATTEMPTS": "0"},
+		"max below initial": {"OPENOBSERVE_RETRY_INITIAL_BACKOFF": "5s", "OPENOBSERVE_RETRY_MAX_BACKOFF": "1s"},
+		"invalid cooldown":  {"OPENOBSERVE_BREAKER_COOLDOWN": "later"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			setEnvVars(t, vars)
+			if _, err := LoadConfig(); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestLoadConfig_SpanAttributes(t *testing.T) {
+	setEnvVars(t, validEnvVars())
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(cfg.SpanAttributes, openobserve.DefaultAttributeRules) {
+		t.Errorf("unexpected span attribute defaults: %+v", cfg.SpanAttributes)
+	}
+
+	setEnvVars(t, map[string]string{
+		"SPAN_RESOURCE_PREFIXES": "",
+		"SPAN_ATTRIBUTE_FIELDS":  "k8s_pod_name=resource, service_flag = span",
+		"SPAN_DROPPED_FIELDS":    "_o2_id,events",
+	})
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := openobserve.AttributeRules{
+		Fields: map[string]openobserve.AttributeClass{
+			"k8s_pod_name": openobserve.ResourceAttribute,
+			"service_flag": openobserve.SpanAttribute,
+		},
+		Dropped: []string{"_o2_id", "events"},
+	}
+	if !reflect.DeepEqual(cfg.SpanAttributes, want) {
+		t.Errorf("expected %+v, got %+v", want, cfg.SpanAttributes)
+	}
+
+	for _, fields := range []string{"k8s_pod_name", "=resource", "k8s_pod_name=metric"} {
+		t.Run(fields, func(t *testing.T) {
+			setEnvVars(t, map[string]string{"SPAN_ATTRIBUTE_FIELDS": fields})
+			if _, err := LoadConfig(); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestLoadConfig_Auth(t *testing.T) {
+	setEnvVars(t, validEnvVars())
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Auth.Mode != auth.ModeNone || !reflect.DeepEqual(cfg.Auth.ExemptPaths, []string{"/healthz"}) {
+		t.Errorf("unexpected auth defaults: %+v", cfg.Auth)
+	}
+
+	setEnvVars(t, map[string]string{
+		"AUTH_MODE":         "jwt",
+		"AUTH_JWKS_URL":     "https://issuer.example.com/jwks",
+		"AUTH_JWT_AUDIENCE": "tracing-adapter",
+		"AUTH_EXEMPT_PATHS": "/healthz, /metrics",
+	})
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Auth.Audience != "tracing-adapter" || !reflect.DeepEqual(cfg.Auth.ExemptPaths, []string{"/healthz", "/metrics"}) {
+		t.Errorf("unexpected auth settings: %+v", cfg.Auth)
+	}
+
+	for name, vars := range map[string]map[string]string{
+		"unknown mode": {"AUTH_MODE": "basic"},
+		"short token":  {"AUTH_MODE": "token", "AUTH_TOKEN": "secret"},
+		"jwt no jwks":  {"AUTH_MODE": "jwt", "AUTH_JWKS_URL": "jwks.json"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			setEnvVars(t, vars)
+			if _, err := LoadConfig(); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestLoadConfig_SecondaryCredentials(t *testing.T) {
+	setEnvVars(t, validEnvVars())
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.SecondaryUser != "admin" || cfg.SecondaryPassword != "" {
+		t.Errorf("expected no secondary password and the primary user, got %q, %q", cfg.SecondaryUser, cfg.SecondaryPassword)
+	}
+
+	t.Setenv("OPENOBSERVE_SECONDARY_USER", "rotation")
+	t.Setenv("OPENOBSERVE_SECONDARY_PASSWORD", "next")
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.SecondaryUser != "rotation" || cfg.SecondaryPassword != "next" {
+		t.Errorf("unexpected secondary credentials: %q, %q", cfg.SecondaryUser, cfg.SecondaryPassword)
+	}
+}
+
+func TestLoadConfig_TempoBackend(t *testing.T) {
+	t.Setenv("OPENOBSERVE_URL", "")
+	t.Setenv("OPENOBSERVE_USER", "")
+	t.Setenv("OPENOBSERVE_PASSWORD", "")
+	t.Setenv("TRACES_BACKEND", "tempo")
+	t.Setenv("TEMPO_URL", "http://tempo:3200")
+	t.Setenv("TEMPO_TENANT_ID", "openchoreo")
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("expected the OpenObserve settings to be optional, got %v", err)
+	}
+	if cfg.Backend != BackendTempo || cfg.TempoURL != "http://tempo:3200" || cfg.TempoTenantID != "openchoreo" {
+		t.Errorf("unexpected Tempo settings %q, %q, %q", cfg.Backend, cfg.TempoURL, cfg.TempoTenantID)
+	}
+
+	for name, vars := range map[string]map[string]string{
+		"missing url":     {"TEMPO_URL": ""},
+		"invalid url":     {"TEMPO_URL": "tempo:3200"},
+		"unknown backend": {"TRACES_BACKEND": "jaeger"},
+		"audit stream":    {"AUDIT_LOG_SINK": "stream"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			setEnvVars(t, vars)
+			if _, err := LoadConfig(); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
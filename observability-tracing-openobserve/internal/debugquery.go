@@ -0,0 +1,94 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/openchoreo/community-modules/common/auth"
+	"github.com/openchoreo/community-modules/common/localize"
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/openobserve"
+)
+
+const (
+	// DebugQueryHeader, set to true by an admin caller, returns the
+	// OpenObserve searches of a request in its response and logs them.
+	DebugQueryHeader = "X-Debug-Query"
+	// RequestIDHeader correlates the logs of a debugged request. It is
+	// generated when the request carries none, and returned in the response.
+	RequestIDHeader = "X-Request-ID"
+)
+
+// withQueryDebug debugs the OpenObserve searches of the requests carrying
+// DebugQueryHeader: each search is logged with the request ID, and the
+// successful JSON object responses gain a debugQueries field listing them.
+// Debugged requests bypass the response cache, so that their searches run,
+// and their extended responses are not stored. Only the requests admins
+// accepts may debug queries; the others are rejected with 403.
+func withQueryDebug(admins auth.Authenticator, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		value := r.Header.Get(DebugQueryHeader)
+		if value == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, gen.BadRequest, DebugQueryHeader+" must be a boolean")
+			return
+		}
+		if !enabled {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if admins == nil || admins.Authenticate(r) != nil {
+			writeJSONError(w, http.StatusForbidden, gen.Forbidden, "query debugging is restricted to admin callers")
+			return
+		}
+
+		requestID := r.Header.Get(RequestIDHeader)
+		if requestID == "" {
+			requestID = newRequestID()
+		}
+		ctx, debug := openobserve.WithQueryDebug(r.Context(), requestID)
+		r = r.Clone(ctx)
+		r.Header.Set("Cache-Control", "no-cache")
+		w.Header().Set(RequestIDHeader, requestID)
+
+		bw := localize.NewBufferingWriter(w)
+		next.ServeHTTP(bw, r)
+		status, body, ok := bw.Buffered()
+		if !ok {
+			return
+		}
+
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(body, &fields); err == nil && fields != nil {
+			if queries, err := json.Marshal(debug.Queries()); err == nil {
+				fields["debugQueries"] = queries
+				if extended, err := json.Marshal(fields); err == nil {
+					body = append(extended, '\n')
+				}
+			}
+		}
+		// The body lists queries that must not be reused.
+		w.Header().Del("Content-Length")
+		w.Header().Del("ETag")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(status)
+		_, _ = w.Write(body)
+	})
+}
+
+// newRequestID returns a random request ID.
+func newRequestID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
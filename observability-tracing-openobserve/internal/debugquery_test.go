@@ -0,0 +1,86 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openchoreo/community-modules/common/auth"
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/openobserve"
+)
+
+func TestQueryDebug(t *testing.T) {
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(openobserve.OpenObserveResponse{Hits: []map[string]interface{}{}})
+	}))
+	defer ooServer.Close()
+
+	const (
+		userToken  = "user-token-0123456789"
+		adminToken = "admin-token-0123456789"
+	)
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "admin", "pass", testLogger())
+	handler := NewTracingHandler(client, testLogger())
+	handler.SetAuthenticator(auth.NewStaticToken(userToken), nil)
+	handler.SetQueryDebugToken(adminToken)
+	srv := NewServer("0", handler, testLogger()).httpServer.Handler
+
+	serve := func(token string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1alpha1/traces/latency/threshold?namespace=payments&operation=checkout&threshold=1s"+
+			"&startTime=2025-01-01T00:00:00Z&endTime=2025-01-01T01:00:00Z", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		for name, values := range header {
+			for _, value := range values {
+				req.Header.Add(name, value)
+			}
+		}
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("admins get the queries", func(t *testing.T) {
+		rec := serve(adminToken, http.Header{DebugQueryHeader: {"true"}, RequestIDHeader: {"req-42"}})
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var got struct {
+			Buckets      []any                    `json:"buckets"`
+			DebugQueries []openobserve.DebugQuery `json:"debugQueries"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(got.DebugQueries) != 1 || !strings.Contains(got.DebugQueries[0].SQL, "payments") || got.Buckets == nil {
+			t.Errorf("expected the response to list the generated SQL, got %s", rec.Body.String())
+		}
+		if rec.Header().Get(RequestIDHeader) != "req-42" || rec.Header().Get("Cache-Control") != "no-store" {
+			t.Errorf("expected the request ID and no-store, got %v", rec.Header())
+		}
+	})
+
+	t.Run("requests without the header are unchanged", func(t *testing.T) {
+		rec := serve(userToken, nil)
+		if rec.Code != http.StatusOK || strings.Contains(rec.Body.String(), "debugQueries") || rec.Header().Get(RequestIDHeader) != "" {
+			t.Errorf("expected a plain response, got %d with %v: %s", rec.Code, rec.Header(), rec.Body.String())
+		}
+	})
+
+	t.Run("other callers are rejected", func(t *testing.T) {
+		if rec := serve(userToken, http.Header{DebugQueryHeader: {"true"}}); rec.Code != http.StatusForbidden {
+			t.Errorf("expected 403, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("invalid values are rejected", func(t *testing.T) {
+		if rec := serve(adminToken, http.Header{DebugQueryHeader: {"yes please"}}); rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", rec.Code)
+		}
+	})
+}
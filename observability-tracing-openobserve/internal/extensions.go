@@ -0,0 +1,165 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/openobserve"
+)
+
+type contextKey string
+
+const queryExtensionsKey contextKey = "queryExtensions"
+
+// queryExtensions holds the request fields this adapter accepts on the query
+// endpoints in addition to the shared tracing adapter OpenAPI contract. The
+// generated strict server decodes the body into the shared request type and
+// drops unknown fields, so these are decoded separately by withQueryExtensions.
+type queryExtensions struct {
+	// SkewCorrection enables the clock-skew correction pass on spans of a trace.
+	SkewCorrection bool `json:"skewCorrection,omitempty"`
+	// Timeline adds to each span of a spans query a time-ordered timeline of
+	// its span events and correlated log lines, of at most TimelineLimit
+	// entries per span (defaultTimelineLimit when unset).
+	Timeline      bool `json:"timeline,omitempty"`
+	TimelineLimit int  `json:"timelineLimit,omitempty"`
+	// Format is the layout of the spans of a spans query: flat (the default)
+	// or tree, where they are nested under their parents.
+	Format string `json:"format,omitempty"`
+	// AttributeFilters, such as "http.status_code >= 500", OperationContains,
+	// MinDurationNs and ErrorsOnly narrow a traces query to the traces with
+	// a span matching all of them.
+	AttributeFilters  []string `json:"attributeFilters,omitempty"`
+	OperationContains string   `json:"operationContains,omitempty"`
+	MinDurationNs     int64    `json:"minDurationNs,omitempty"`
+	ErrorsOnly        bool     `json:"errorsOnly,omitempty"`
+	// SortBy orders a traces query by trace start time (startTime, the
+	// default) or duration (duration), in the request's sortOrder.
+	SortBy string `json:"sortBy,omitempty"`
+	// PageToken is the nextPageToken of the previous page of a traces query.
+	PageToken string `json:"pageToken,omitempty"`
+}
+
+// Bounds of the per-span timeline requested with the timeline extension.
+const (
+	defaultTimelineLimit = 20
+	maxTimelineLimit     = 100
+)
+
+// spanFilters returns the span filters of a traces query.
+func (ext queryExtensions) spanFilters() (openobserve.SpanFilters, error) {
+	filters := openobserve.SpanFilters{
+		OperationContains: strings.TrimSpace(ext.OperationContains),
+		MinDurationNs:     ext.MinDurationNs,
+		ErrorsOnly:        ext.ErrorsOnly,
+	}
+	for _, expr := range ext.AttributeFilters {
+		filter, err := openobserve.ParseAttributeFilter(expr)
+		if err != nil {
+			return openobserve.SpanFilters{}, err
+		}
+		filters.Attributes = append(filters.Attributes, filter)
+	}
+	return filters, openobserve.ValidateSpanFilters(filters)
+}
+
+// Layouts of the spans of a spans query.
+const (
+	spansFormatFlat = "flat"
+	spansFormatTree = "tree"
+)
+
+// spansTree reports whether a spans query asks for the tree layout.
+func (ext queryExtensions) spansTree() (bool, error) {
+	switch ext.Format {
+	case "", spansFormatFlat:
+		return false, nil
+	case spansFormatTree:
+		return true, nil
+	}
+	return false, errors.New("format must be flat or tree")
+}
+
+// traceSort returns the order of a traces query.
+func (ext queryExtensions) traceSort() (string, error) {
+	switch ext.SortBy {
+	case "", openobserve.TraceSortStartTime:
+		return openobserve.TraceSortStartTime, nil
+	case openobserve.TraceSortDuration:
+		return openobserve.TraceSortDuration, nil
+	}
+	return "", errors.New("sortBy must be startTime or duration")
+}
+
+// encodeTracePageToken encodes the offset of the next page of a traces query
+// as an opaque token.
+func encodeTracePageToken(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("v1." + strconv.Itoa(offset)))
+}
+
+// parseTracePageToken decodes a token returned by encodeTracePageToken.
+func parseTracePageToken(token string) (int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, errors.New("invalid pageToken")
+	}
+	offset, err := strconv.Atoi(strings.TrimPrefix(string(raw), "v1."))
+	if err != nil || !strings.HasPrefix(string(raw), "v1.") || offset < 0 {
+		return 0, errors.New("invalid pageToken")
+	}
+	return offset, nil
+}
+
+// withQueryExtensions decodes adapter-specific fields from the body of POST
+// query requests into the request context and restores the body so the
+// generated handler can decode it as usual. Malformed bodies are passed
+// through untouched; the generated handler reports the decoding error.
+func withQueryExtensions(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Body == nil || !isQueryPath(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		_ = r.Body.Close()
+		if err != nil {
+			http.Error(w, "failed to read request body", http.StatusBadRequest)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		var ext queryExtensions
+		if json.Unmarshal(body, &ext) == nil {
+			r = r.WithContext(context.WithValue(r.Context(), queryExtensionsKey, ext))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isQueryPath reports whether path is one of the query endpoints that accept extensions.
+func isQueryPath(path string) bool {
+	if path == "/api/v1alpha1/traces/query" {
+		return true
+	}
+	return strings.HasPrefix(path, "/api/v1alpha1/traces/") && strings.HasSuffix(path, "/spans/query")
+}
+
+// queryExtensionsFromContext returns the extensions decoded by withQueryExtensions,
+// or the zero value when none were provided.
+func queryExtensionsFromContext(ctx context.Context) queryExtensions {
+	if ext, ok := ctx.Value(queryExtensionsKey).(queryExtensions); ok {
+		return ext
+	}
+	return queryExtensions{}
+}
@@ -0,0 +1,63 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWithQueryExtensions(t *testing.T) {
+	t.Run("decodes extensions and restores body", func(t *testing.T) {
+		var gotExt queryExtensions
+		var gotBody string
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotExt = queryExtensionsFromContext(r.Context())
+			b, _ := io.ReadAll(r.Body)
+			gotBody = string(b)
+		})
+
+		body := `{"searchScope":{"namespace":"ns"},"skewCorrection":true}`
+		req := httptest.NewRequest(http.MethodPost, "/api/v1alpha1/traces/abc/spans/query", strings.NewReader(body))
+		withQueryExtensions(next).ServeHTTP(httptest.NewRecorder(), req)
+
+		if !gotExt.SkewCorrection {
+			t.Error("expected skewCorrection to be decoded")
+		}
+		if gotBody != body {
+			t.Errorf("expected body to be restored, got %q", gotBody)
+		}
+	})
+
+	t.Run("ignores other paths", func(t *testing.T) {
+		var gotExt queryExtensions
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotExt = queryExtensionsFromContext(r.Context())
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1alpha1/other", strings.NewReader(`{"skewCorrection":true}`))
+		withQueryExtensions(next).ServeHTTP(httptest.NewRecorder(), req)
+
+		if gotExt.SkewCorrection {
+			t.Errorf("expected no extensions, got %+v", gotExt)
+		}
+	})
+}
+
+func TestIsQueryPath(t *testing.T) {
+	tests := map[string]bool{
+		"/api/v1alpha1/traces/query":              true,
+		"/api/v1alpha1/traces/abc123/spans/query": true,
+		"/api/v1alpha1/traces/abc123/spans/def":   false,
+		"/healthz":                                false,
+	}
+	for path, want := range tests {
+		if got := isQueryPath(path); got != want {
+			t.Errorf("isQueryPath(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
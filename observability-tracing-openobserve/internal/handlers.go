@@ -4,28 +4,135 @@
 package app
 
 import (
+	"bytes"
+	"cmp"
 	"context"
+	"encoding/json"
+	"errors"
 	"log/slog"
+	"net/http"
 	"strings"
 	"time"
 
+	"github.com/openchoreo/community-modules/common/access"
+	"github.com/openchoreo/community-modules/common/audit"
+	"github.com/openchoreo/community-modules/common/auth"
+	"github.com/openchoreo/community-modules/common/metrics"
+	"github.com/openchoreo/community-modules/common/prefer"
+	"github.com/openchoreo/community-modules/common/ratelimit"
+	"github.com/openchoreo/community-modules/common/respcache"
+	"github.com/openchoreo/community-modules/common/shares"
 	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/api/gen"
 	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/openobserve"
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/tempo"
 )
 
 // TracingHandler implements the generated StrictServerInterface.
 type TracingHandler struct {
 	client *openobserve.Client
-	logger *slog.Logger
+	// backend serves the traces, spans and span details of the core API. It
+	// is the client unless the handler was created with NewBackendHandler,
+	// in which case the client is nil.
+	backend TracesBackend
+	logger  *slog.Logger
+	// alertDestinations are the OpenObserve destinations notified by trace alerts.
+	alertDestinations []string
+	// shareSigner signs share links, which last at most shareMaxTTL.
+	shareSigner *shares.Signer
+	shareMaxTTL time.Duration
+	// metrics records the requests and OpenObserve calls for GET /metrics.
+	metrics *metrics.Metrics
+	// authenticator authenticates all requests but those for authExemptPaths.
+	authenticator   auth.Authenticator
+	authExemptPaths []string
+	// responseCache serves repeated queries without querying the backend.
+	responseCache *respcache.Cache
+	// rateLimiter bounds the rate and concurrency of the queries of each
+	// namespace.
+	rateLimiter *ratelimit.Limiter
+	// auditLog records the trace queries and alert operations.
+	auditLog *audit.Log
+	// queryDebugAdmins authenticates the admin callers allowed to debug the
+	// searches of their requests.
+	queryDebugAdmins auth.Authenticator
+	// access restricts callers to aggregates in sensitive namespaces.
+	access *access.Policy
 }
 
 func NewTracingHandler(client *openobserve.Client, logger *slog.Logger) *TracingHandler {
 	return &TracingHandler{
-		client: client,
-		logger: logger,
+		client:  client,
+		backend: client,
+		logger:  logger,
 	}
 }
 
+// SetMetrics records every request with m and serves m on GET /metrics. The
+// client must report its calls to m.
+func (h *TracingHandler) SetMetrics(m *metrics.Metrics) {
+	h.metrics = m
+}
+
+// SetAuthenticator rejects the requests a does not accept, except those for
+// exemptPaths; see auth.Middleware.
+func (h *TracingHandler) SetAuthenticator(a auth.Authenticator, exemptPaths []string) {
+	h.authenticator = a
+	h.authExemptPaths = exemptPaths
+}
+
+// SetResponseCache serves the responses of repeated queries from cache, and
+// its metrics.
+func (h *TracingHandler) SetResponseCache(cache *respcache.Cache) {
+	h.responseCache = cache
+}
+
+// SetRateLimiter rejects the queries of namespaces over the limits of
+// limiter with 429, and serves its metrics. Queries are not limited by
+// default.
+func (h *TracingHandler) SetRateLimiter(limiter *ratelimit.Limiter) {
+	h.rateLimiter = limiter
+}
+
+// SetAuditLog records the trace queries and alert operations in log, and
+// serves its metrics. Operations are not audited by default.
+func (h *TracingHandler) SetAuditLog(log *audit.Log) {
+	h.auditLog = log
+}
+
+// SetQueryDebugToken lets the requests carrying token as their bearer token
+// debug their OpenObserve searches with DebugQueryHeader. Such requests are
+// authenticated by token as well. Queries cannot be debugged by default.
+func (h *TracingHandler) SetQueryDebugToken(token string) {
+	h.queryDebugAdmins = auth.NewStaticToken(token)
+}
+
+// SetAccessPolicy restricts the callers named by policy to trace summaries,
+// services and statistics in the namespaces of its aggregation-only rules.
+// Their tokens are accepted when requests are authenticated.
+func (h *TracingHandler) SetAccessPolicy(policy *access.Policy) {
+	h.access = policy
+}
+
+// requestAuthenticator returns the authenticator of the requests, which
+// also accepts the admin callers debugging queries and the callers of the
+// access policy, or nil when requests are not authenticated.
+func (h *TracingHandler) requestAuthenticator() auth.Authenticator {
+	if h.authenticator == nil {
+		return nil
+	}
+	authenticators := []auth.Authenticator{h.authenticator}
+	if h.queryDebugAdmins != nil {
+		authenticators = append(authenticators, h.queryDebugAdmins)
+	}
+	if h.access != nil {
+		authenticators = append(authenticators, h.access)
+	}
+	if len(authenticators) == 1 {
+		return h.authenticator
+	}
+	return auth.Any(authenticators...)
+}
+
 // Ensure TracingHandler implements the interface at compile time.
 var _ gen.StrictServerInterface = (*TracingHandler)(nil)
 
@@ -56,8 +163,38 @@ func (h *TracingHandler) QueryTraces(ctx context.Context, request gen.QueryTrace
 		}, nil
 	}
 	params := toTracesQueryParams(request.Body)
+	params.Limit = prefer.FromContext(ctx).Limit(params.Limit)
+	filters, err := queryExtensionsFromContext(ctx).spanFilters()
+	if err != nil {
+		return gen.QueryTraces400JSONResponse{
+			Title:  ptr(gen.BadRequest),
+			Detail: ptr(err.Error()),
+		}, nil
+	}
+	params.Filters = filters
+	ext := queryExtensionsFromContext(ctx)
+	if params.SortBy, err = ext.traceSort(); err != nil {
+		return gen.QueryTraces400JSONResponse{
+			Title:  ptr(gen.BadRequest),
+			Detail: ptr(err.Error()),
+		}, nil
+	}
+	if ext.PageToken != "" {
+		if params.Offset, err = parseTracePageToken(ext.PageToken); err != nil {
+			return gen.QueryTraces400JSONResponse{
+				Title:  ptr(gen.BadRequest),
+				Detail: ptr(err.Error()),
+			}, nil
+		}
+	}
 
-	result, err := h.client.GetTraces(ctx, params)
+	result, err := h.backend.GetTraces(ctx, params)
+	if errors.Is(err, tempo.ErrUnsupported) {
+		return gen.QueryTraces400JSONResponse{
+			Title:  ptr(gen.BadRequest),
+			Detail: ptr(err.Error()),
+		}, nil
+	}
 	if err != nil {
 		h.logger.Error("Failed to query traces", slog.Any("error", err))
 		detail := err.Error()
@@ -67,7 +204,11 @@ func (h *TracingHandler) QueryTraces(ctx context.Context, request gen.QueryTrace
 		}, nil
 	}
 
-	return gen.QueryTraces200JSONResponse(toTracesListResponse(result)), nil
+	response := toTracesQueryResponse(result)
+	if result.HasMore {
+		response.NextPageToken = encodeTracePageToken(params.Offset + len(result.Traces))
+	}
+	return response, nil
 }
 
 // QuerySpansForTrace implements POST /api/v1alpha1/traces/{traceId}/spans/query.
@@ -91,9 +232,26 @@ func (h *TracingHandler) QuerySpansForTrace(ctx context.Context, request gen.Que
 		}, nil
 	}
 	params := toTracesQueryParams(request.Body)
+	params.Limit = prefer.FromContext(ctx).Limit(params.Limit)
 	params.TraceID = request.TraceId
+	ext := queryExtensionsFromContext(ctx)
+	params.IncludeEvents = ext.Timeline
+	timelineLimit := min(cmp.Or(ext.TimelineLimit, defaultTimelineLimit), maxTimelineLimit)
+	if ext.Timeline && timelineLimit < 0 {
+		return gen.QuerySpansForTrace400JSONResponse{
+			Title:  ptr(gen.BadRequest),
+			Detail: ptr("timelineLimit must be positive"),
+		}, nil
+	}
+	tree, err := ext.spansTree()
+	if err != nil {
+		return gen.QuerySpansForTrace400JSONResponse{
+			Title:  ptr(gen.BadRequest),
+			Detail: ptr(err.Error()),
+		}, nil
+	}
 
-	result, err := h.client.GetSpans(ctx, params)
+	result, err := h.backend.GetSpans(ctx, params)
 	if err != nil {
 		h.logger.Error("Failed to query spans", slog.Any("error", err))
 		detail := err.Error()
@@ -103,17 +261,139 @@ func (h *TracingHandler) QuerySpansForTrace(ctx context.Context, request gen.Que
 		}, nil
 	}
 
-	return gen.QuerySpansForTrace200JSONResponse(toSpansListResponse(result)), nil
+	if !ext.SkewCorrection && !ext.Timeline && !tree {
+		return gen.QuerySpansForTrace200JSONResponse(toSpansListResponse(result)), nil
+	}
+
+	var response spansListResponse
+	if ext.SkewCorrection {
+		response.SkewCorrections = openobserve.CorrectClockSkew(result.Spans)
+		if response.SkewCorrections == nil {
+			response.SkewCorrections = []openobserve.SkewCorrection{}
+		}
+	}
+	if ext.Timeline {
+		// Correlated logs only enrich the timeline; without them the
+		// timeline still carries the span events. They are only read from
+		// OpenObserve.
+		var logs []openobserve.TraceLogEntry
+		if len(result.Spans) > 0 && h.client != nil {
+			logsParams := params
+			logsParams.Limit = len(result.Spans) * timelineLimit
+			if logs, err = h.client.GetTraceLogs(ctx, logsParams); err != nil {
+				h.logger.Warn("Failed to query logs for span timelines",
+					slog.String("traceId", params.TraceID),
+					slog.Any("error", err))
+			}
+		}
+		response.Timelines = openobserve.BuildSpanTimelines(result.Spans, logs, timelineLimit)
+	}
+	if tree {
+		// Built after the skew correction, so that offsets and self times
+		// use the corrected timestamps.
+		response.Tree = openobserve.BuildSpanTree(result.Spans)
+	}
+	response.TraceSpansListResponse = toSpansListResponse(result)
+	return response, nil
+}
+
+// spansListResponse extends the generated TraceSpansListResponse with the
+// adapter-specific fields returned when query extensions are requested.
+type spansListResponse struct {
+	gen.TraceSpansListResponse
+	// SkewCorrections lists the spans whose timestamps were shifted by the
+	// clock-skew correction pass. It is always present (possibly empty) when
+	// skew correction was requested, and nil otherwise.
+	SkewCorrections []openobserve.SkewCorrection
+	// Timelines holds the timeline of each span by span ID when requested.
+	// They are encoded as "timeline" and "timelineTruncated" on the spans.
+	Timelines map[string]openobserve.SpanTimeline
+	// Tree holds the roots of the span hierarchy when the tree format is
+	// requested. The spans are then encoded nested under their parents as
+	// "tree" instead of "spans", with their depth, offset from the start of
+	// the trace and self time.
+	Tree []*openobserve.SpanTreeNode
+}
+
+// encodeSpanTree nests the encoded spans, indexed like the spans the tree was
+// built from, under their parents.
+func encodeSpanTree(nodes []*openobserve.SpanTreeNode, spans []any) []any {
+	encoded := make([]any, 0, len(nodes))
+	for _, node := range nodes {
+		var span map[string]any
+		if node.Index < len(spans) {
+			span, _ = spans[node.Index].(map[string]any)
+		}
+		if span == nil {
+			span = map[string]any{}
+		}
+		span["depth"] = node.Depth
+		span["offsetNs"] = node.OffsetNs
+		span["selfTimeNs"] = node.SelfTimeNs
+		if node.Orphan {
+			span["orphan"] = true
+		}
+		span["children"] = encodeSpanTree(node.Children, spans)
+		encoded = append(encoded, span)
+	}
+	return encoded
+}
+
+func (response spansListResponse) VisitQuerySpansForTraceResponse(w http.ResponseWriter) error {
+	body, err := json.Marshal(response.TraceSpansListResponse)
+	if err != nil {
+		return err
+	}
+	var fields map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&fields); err != nil {
+		return err
+	}
+
+	if response.SkewCorrections != nil {
+		fields["skewCorrections"] = response.SkewCorrections
+	}
+	if response.Timelines != nil {
+		spans, _ := fields["spans"].([]any)
+		for _, s := range spans {
+			span, ok := s.(map[string]any)
+			if !ok {
+				continue
+			}
+			spanID, _ := span["spanId"].(string)
+			timeline := response.Timelines[spanID]
+			if timeline.Entries == nil {
+				timeline.Entries = []openobserve.TimelineEntry{}
+			}
+			span["timeline"] = timeline.Entries
+			span["timelineTruncated"] = timeline.Truncated
+		}
+	}
+	if response.Tree != nil {
+		spans, _ := fields["spans"].([]any)
+		delete(fields, "spans")
+		fields["tree"] = encodeSpanTree(response.Tree, spans)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(fields)
 }
 
 // GetSpanDetailsForTrace implements GET /api/v1alpha1/traces/{traceId}/spans/{spanId}.
+// The startTime and endTime query parameters, decoded by withSpanTimeHints,
+// narrow the search for the span.
 func (h *TracingHandler) GetSpanDetailsForTrace(ctx context.Context, request gen.GetSpanDetailsForTraceRequestObject) (gen.GetSpanDetailsForTraceResponseObject, error) {
 	params := openobserve.TracesQueryParams{
 		TraceID: request.TraceId,
 		SpanID:  request.SpanId,
 	}
+	if hint, ok := spanTimeHintFromContext(ctx); ok {
+		params.StartTime, params.EndTime = hint.StartTime, hint.EndTime
+	}
 
-	result, err := h.client.GetSpanDetail(ctx, params)
+	result, err := h.backend.GetSpanDetail(ctx, params)
 	if err != nil {
 		h.logger.Error("Failed to query span detail", slog.Any("error", err))
 		detail := err.Error()
@@ -123,7 +403,40 @@ func (h *TracingHandler) GetSpanDetailsForTrace(ctx context.Context, request gen
 		}, nil
 	}
 
-	return gen.GetSpanDetailsForTrace200JSONResponse(toSpanDetailsResponse(&result.Span)), nil
+	return newSpanDetailsResponse(&result.Span), nil
+}
+
+// spanDetailsResponse extends the generated TraceSpanDetailsResponse with the
+// events and links of the span.
+type spanDetailsResponse struct {
+	gen.TraceSpanDetailsResponse
+	// Events are the span events, e.g. exceptions with their stack traces.
+	Events []openobserve.SpanEvent `json:"events"`
+	// Links are the spans of other traces the span is linked to.
+	Links []openobserve.SpanLink `json:"links"`
+}
+
+// newSpanDetailsResponse converts the internal span detail to the span
+// details response. Events and links are always present, possibly empty.
+func newSpanDetailsResponse(span *openobserve.SpanDetail) spanDetailsResponse {
+	response := spanDetailsResponse{
+		TraceSpanDetailsResponse: toSpanDetailsResponse(span),
+		Events:                   span.Events,
+		Links:                    span.Links,
+	}
+	if response.Events == nil {
+		response.Events = []openobserve.SpanEvent{}
+	}
+	if response.Links == nil {
+		response.Links = []openobserve.SpanLink{}
+	}
+	return response
+}
+
+func (response spanDetailsResponse) VisitGetSpanDetailsForTraceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(response)
 }
 
 // toTracesQueryParams converts the generated request body to internal query params.
@@ -153,20 +466,23 @@ func toTracesQueryParams(req *gen.TracesQueryRequest) openobserve.TracesQueryPar
 	return params
 }
 
+// traceListItem is the element type of the generated TracesListResponse.Traces.
+type traceListItem = struct {
+	DurationNs   *int64     `json:"durationNs,omitempty"`
+	EndTime      *time.Time `json:"endTime,omitempty"`
+	HasErrors    *bool      `json:"hasErrors,omitempty"`
+	RootSpanId   *string    `json:"rootSpanId,omitempty"`
+	RootSpanKind *string    `json:"rootSpanKind,omitempty"`
+	RootSpanName *string    `json:"rootSpanName,omitempty"`
+	SpanCount    *int       `json:"spanCount,omitempty"`
+	StartTime    *time.Time `json:"startTime,omitempty"`
+	TraceId      *string    `json:"traceId,omitempty"`
+	TraceName    *string    `json:"traceName,omitempty"`
+}
+
 // toTracesListResponse converts the internal result to the generated response model.
 func toTracesListResponse(result *openobserve.TracesResult) gen.TracesListResponse {
-	traces := make([]struct {
-		DurationNs   *int64     `json:"durationNs,omitempty"`
-		EndTime      *time.Time `json:"endTime,omitempty"`
-		HasErrors    *bool      `json:"hasErrors,omitempty"`
-		RootSpanId   *string    `json:"rootSpanId,omitempty"`
-		RootSpanKind *string    `json:"rootSpanKind,omitempty"`
-		RootSpanName *string    `json:"rootSpanName,omitempty"`
-		SpanCount    *int       `json:"spanCount,omitempty"`
-		StartTime    *time.Time `json:"startTime,omitempty"`
-		TraceId      *string    `json:"traceId,omitempty"`
-		TraceName    *string    `json:"traceName,omitempty"`
-	}, 0, len(result.Traces))
+	traces := make([]traceListItem, 0, len(result.Traces))
 
 	for _, t := range result.Traces {
 		dur := t.DurationNs
@@ -179,18 +495,7 @@ func toTracesListResponse(result *openobserve.TracesResult) gen.TracesListRespon
 		rootSpanName := t.RootSpanName
 		rootSpanKind := t.RootSpanKind
 		hasErrors := t.HasErrors
-		traces = append(traces, struct {
-			DurationNs   *int64     `json:"durationNs,omitempty"`
-			EndTime      *time.Time `json:"endTime,omitempty"`
-			HasErrors    *bool      `json:"hasErrors,omitempty"`
-			RootSpanId   *string    `json:"rootSpanId,omitempty"`
-			RootSpanKind *string    `json:"rootSpanKind,omitempty"`
-			RootSpanName *string    `json:"rootSpanName,omitempty"`
-			SpanCount    *int       `json:"spanCount,omitempty"`
-			StartTime    *time.Time `json:"startTime,omitempty"`
-			TraceId      *string    `json:"traceId,omitempty"`
-			TraceName    *string    `json:"traceName,omitempty"`
-		}{
+		traces = append(traces, traceListItem{
 			DurationNs:   &dur,
 			StartTime:    &startTime,
 			EndTime:      &endTime,
@@ -213,6 +518,50 @@ func toTracesListResponse(result *openobserve.TracesResult) gen.TracesListRespon
 	}
 }
 
+// traceEntry extends a generated trace list item with the adapter-specific
+// completeness indicator.
+type traceEntry struct {
+	traceListItem
+	Complete         bool   `json:"complete"`
+	IncompleteReason string `json:"incompleteReason,omitempty"`
+	TreeTruncated    bool   `json:"treeTruncated,omitempty"`
+}
+
+// tracesListResponse extends the generated TracesListResponse with the
+// adapter-specific trace entry fields and the token of the next page.
+type tracesListResponse struct {
+	TookMs        *int         `json:"tookMs,omitempty"`
+	Total         *int         `json:"total,omitempty"`
+	Traces        []traceEntry `json:"traces"`
+	NextPageToken string       `json:"nextPageToken,omitempty"`
+}
+
+func (response tracesListResponse) VisitQueryTracesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(response)
+}
+
+// toTracesQueryResponse converts the internal result to the QueryTraces response,
+// adding the completeness indicator to each trace.
+func toTracesQueryResponse(result *openobserve.TracesResult) tracesListResponse {
+	base := toTracesListResponse(result)
+	traces := make([]traceEntry, 0, len(*base.Traces))
+	for i, item := range *base.Traces {
+		traces = append(traces, traceEntry{
+			traceListItem:    item,
+			Complete:         result.Traces[i].Complete,
+			IncompleteReason: result.Traces[i].IncompleteReason,
+			TreeTruncated:    result.Traces[i].TreeTruncated,
+		})
+	}
+	return tracesListResponse{
+		TookMs: base.TookMs,
+		Total:  base.Total,
+		Traces: traces,
+	}
+}
+
 // toSpansListResponse converts the internal result to the generated response model.
 func toSpansListResponse(result *openobserve.SpansResult) gen.TraceSpansListResponse {
 	spans := make([]struct {
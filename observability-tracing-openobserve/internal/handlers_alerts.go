@@ -0,0 +1,193 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/openobserve"
+)
+
+// errorTitleNotFound matches the notFound title of the shared adapter API.
+const errorTitleNotFound gen.ErrorResponseTitle = "notFound"
+
+// traceAlertRule is the request and response body of the trace alert rule
+// endpoints. It follows the shape of the logs adapter's alert rules, with a
+// source describing the span metric instead of a log query.
+type traceAlertRule struct {
+	Metadata struct {
+		Name           string `json:"name"`
+		Namespace      string `json:"namespace"`
+		ProjectUid     string `json:"projectUid,omitempty"`
+		EnvironmentUid string `json:"environmentUid"`
+		ComponentUid   string `json:"componentUid"`
+	} `json:"metadata"`
+	Source struct {
+		// Metric is "latency" (milliseconds), "errorRate" (percent of spans)
+		// or "errorCount" (spans).
+		Metric string `json:"metric"`
+		// Percentile is the latency percentile, 99 by default.
+		Percentile float64 `json:"percentile,omitempty"`
+		// Operation restricts the rule to spans with this operation name.
+		Operation string `json:"operation,omitempty"`
+	} `json:"source"`
+	Condition struct {
+		Enabled   bool    `json:"enabled"`
+		Operator  string  `json:"operator"`
+		Threshold float64 `json:"threshold"`
+		Window    string  `json:"window"`
+		Interval  string  `json:"interval"`
+	} `json:"condition"`
+}
+
+// traceAlertRuleSyncResponse reports the outcome of a create, update or delete.
+type traceAlertRuleSyncResponse struct {
+	Action        string `json:"action"`
+	Status        string `json:"status"`
+	RuleLogicalId string `json:"ruleLogicalId"`
+	RuleBackendId string `json:"ruleBackendId"`
+	LastSyncedAt  string `json:"lastSyncedAt"`
+}
+
+// SetAlertDestinations sets the OpenObserve destinations notified by trace
+// alerts. openobserve.DefaultAlertDestination is used when none are set.
+func (h *TracingHandler) SetAlertDestinations(destinations []string) {
+	h.alertDestinations = destinations
+}
+
+// CreateAlertRule implements POST /api/v1alpha1/traces/alerts/rules. It
+// creates a scheduled OpenObserve alert on the traces stream that fires when
+// a latency percentile or the error rate of a component's spans crosses the
+// threshold, e.g. p99 latency > 2000ms evaluated over a 10m window.
+func (h *TracingHandler) CreateAlertRule(w http.ResponseWriter, r *http.Request) {
+	params, ok := h.decodeTraceAlertRule(w, r)
+	if !ok {
+		return
+	}
+
+	alertID, err := h.client.CreateAlert(r.Context(), params)
+	if err != nil {
+		h.logger.Error("Failed to create trace alert", slog.String("ruleName", params.Name), slog.Any("error", err))
+		writeJSONError(w, http.StatusInternalServerError, gen.InternalServerError, "internal server error")
+		return
+	}
+	writeJSON(w, http.StatusCreated, alertRuleSynced("created", params.Name, alertID))
+}
+
+// UpdateAlertRule implements PUT /api/v1alpha1/traces/alerts/rules/{ruleName}.
+func (h *TracingHandler) UpdateAlertRule(w http.ResponseWriter, r *http.Request) {
+	params, ok := h.decodeTraceAlertRule(w, r)
+	if !ok {
+		return
+	}
+
+	ruleName := r.PathValue("ruleName")
+	alertID, err := h.client.UpdateAlert(r.Context(), ruleName, params)
+	if err != nil {
+		h.writeAlertError(w, "update", ruleName, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, alertRuleSynced("updated", ruleName, alertID))
+}
+
+// DeleteAlertRule implements DELETE /api/v1alpha1/traces/alerts/rules/{ruleName}.
+func (h *TracingHandler) DeleteAlertRule(w http.ResponseWriter, r *http.Request) {
+	ruleName := r.PathValue("ruleName")
+	alertID, err := h.client.DeleteAlert(r.Context(), ruleName)
+	if err != nil {
+		h.writeAlertError(w, "delete", ruleName, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, alertRuleSynced("deleted", ruleName, alertID))
+}
+
+// GetAlertRule implements GET /api/v1alpha1/traces/alerts/rules/{ruleName}.
+func (h *TracingHandler) GetAlertRule(w http.ResponseWriter, r *http.Request) {
+	ruleName := r.PathValue("ruleName")
+	alert, err := h.client.GetAlert(r.Context(), ruleName)
+	if err != nil {
+		h.writeAlertError(w, "get", ruleName, err)
+		return
+	}
+
+	var rule traceAlertRule
+	rule.Metadata.Name = alert.Name
+	rule.Metadata.Namespace = alert.Namespace
+	rule.Metadata.ProjectUid = alert.ProjectUID
+	rule.Metadata.EnvironmentUid = alert.EnvironmentUID
+	rule.Metadata.ComponentUid = alert.ComponentUID
+	rule.Source.Metric = alert.Metric
+	rule.Source.Percentile = alert.Percentile
+	rule.Source.Operation = alert.Operation
+	rule.Condition.Enabled = alert.Enabled
+	rule.Condition.Operator = alert.Operator
+	rule.Condition.Threshold = alert.Threshold
+	rule.Condition.Window = alert.Window
+	rule.Condition.Interval = alert.Interval
+	writeJSON(w, http.StatusOK, rule)
+}
+
+// decodeTraceAlertRule decodes and validates a trace alert rule request body.
+// It writes a 400 response and returns false when the body is invalid.
+func (h *TracingHandler) decodeTraceAlertRule(w http.ResponseWriter, r *http.Request) (openobserve.TraceAlertParams, bool) {
+	var rule traceAlertRule
+	if err := json.NewDecoder(r.Body).Decode(&rule); err != nil {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "invalid request body: "+err.Error())
+		return openobserve.TraceAlertParams{}, false
+	}
+
+	params := openobserve.TraceAlertParams{
+		Name:           rule.Metadata.Name,
+		Namespace:      strings.TrimSpace(rule.Metadata.Namespace),
+		ProjectUID:     rule.Metadata.ProjectUid,
+		EnvironmentUID: rule.Metadata.EnvironmentUid,
+		ComponentUID:   rule.Metadata.ComponentUid,
+		Metric:         rule.Source.Metric,
+		Percentile:     rule.Source.Percentile,
+		Operation:      rule.Source.Operation,
+		Operator:       rule.Condition.Operator,
+		Threshold:      rule.Condition.Threshold,
+		Window:         rule.Condition.Window,
+		Interval:       rule.Condition.Interval,
+		Enabled:        rule.Condition.Enabled,
+		Destinations:   h.alertDestinations,
+	}
+	if params.Metric == openobserve.AlertMetricLatency && params.Percentile == 0 {
+		params.Percentile = openobserve.DefaultAlertPercentile
+	}
+	if r.Method == http.MethodPut {
+		params.Name = r.PathValue("ruleName")
+	}
+	if err := openobserve.ValidateTraceAlert(params); err != nil {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, err.Error())
+		return openobserve.TraceAlertParams{}, false
+	}
+	return params, true
+}
+
+// writeAlertError logs a failed alert operation and writes 404 when the rule
+// does not exist and 500 otherwise.
+func (h *TracingHandler) writeAlertError(w http.ResponseWriter, op, ruleName string, err error) {
+	h.logger.Error("Failed to "+op+" trace alert", slog.String("ruleName", ruleName), slog.Any("error", err))
+	if strings.Contains(err.Error(), "not found") || strings.Contains(err.Error(), "not a trace alert") {
+		writeJSONError(w, http.StatusNotFound, errorTitleNotFound, "alert rule not found")
+		return
+	}
+	writeJSONError(w, http.StatusInternalServerError, gen.InternalServerError, "internal server error")
+}
+
+func alertRuleSynced(action, ruleName, alertID string) traceAlertRuleSyncResponse {
+	return traceAlertRuleSyncResponse{
+		Action:        action,
+		Status:        "synced",
+		RuleLogicalId: ruleName,
+		RuleBackendId: alertID,
+		LastSyncedAt:  time.Now().UTC().Format(time.RFC3339),
+	}
+}
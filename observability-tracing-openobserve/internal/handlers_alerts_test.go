@@ -0,0 +1,114 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/openobserve"
+)
+
+const testTraceAlertRule = `{
+	"metadata": {"name": "checkout-p99", "namespace": "test-ns", "environmentUid": "env-1", "componentUid": "comp-1"},
+	"source": {"metric": "latency"},
+	"condition": {"enabled": true, "operator": "gt", "threshold": 2000, "window": "10m", "interval": "1m"}
+}`
+
+func TestTraceAlertRules(t *testing.T) {
+	var created []byte
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost:
+			created, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"alert-1"}`))
+		case r.URL.Path == "/api/v2/default/alerts":
+			_, _ = w.Write([]byte(`{"list":[{"alert_id":"alert-1","name":"checkout-p99"}]}`))
+		case r.Method == http.MethodGet:
+			_, _ = w.Write(created)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "admin", "pass", testLogger())
+	handler := NewTracingHandler(client, testLogger())
+	handler.SetAlertDestinations([]string{"openchoreo", "pagerduty"})
+	srv := NewServer("0", handler, testLogger())
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		srv.httpServer.Handler.ServeHTTP(rec, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return rec
+	}
+
+	t.Run("create", func(t *testing.T) {
+		rec := do(http.MethodPost, "/api/v1alpha1/traces/alerts/rules", testTraceAlertRule)
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var config struct {
+			Destinations []string `json:"destinations"`
+			Query        struct {
+				SQL string `json:"sql"`
+			} `json:"query_condition"`
+		}
+		_ = json.Unmarshal(created, &config)
+		if strings.Join(config.Destinations, ",") != "openchoreo,pagerduty" {
+			t.Errorf("unexpected destinations %v", config.Destinations)
+		}
+		if !strings.Contains(config.Query.SQL, "approx_percentile_cont(end_time - start_time, 0.99)") {
+			t.Errorf("expected p99 by default: %s", config.Query.SQL)
+		}
+	})
+
+	t.Run("get", func(t *testing.T) {
+		rec := do(http.MethodGet, "/api/v1alpha1/traces/alerts/rules/checkout-p99", "")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var rule traceAlertRule
+		if err := json.NewDecoder(rec.Body).Decode(&rule); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if rule.Source.Metric != "latency" || rule.Source.Percentile != 99 || rule.Condition.Threshold != 2000 || rule.Condition.Window != "10m" {
+			t.Errorf("unexpected rule %+v", rule)
+		}
+	})
+
+	t.Run("get unknown rule", func(t *testing.T) {
+		if rec := do(http.MethodGet, "/api/v1alpha1/traces/alerts/rules/missing", ""); rec.Code != http.StatusNotFound {
+			t.Errorf("expected 404, got %d", rec.Code)
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		if rec := do(http.MethodPut, "/api/v1alpha1/traces/alerts/rules/checkout-p99", testTraceAlertRule); rec.Code != http.StatusOK {
+			t.Errorf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		if rec := do(http.MethodDelete, "/api/v1alpha1/traces/alerts/rules/checkout-p99", ""); rec.Code != http.StatusOK {
+			t.Errorf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("invalid rule", func(t *testing.T) {
+		body := strings.Replace(testTraceAlertRule, `"latency"`, `"throughput"`, 1)
+		if rec := do(http.MethodPost, "/api/v1alpha1/traces/alerts/rules", body); rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", rec.Code)
+		}
+		if rec := do(http.MethodPost, "/api/v1alpha1/traces/alerts/rules", "{"); rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400 for malformed body, got %d", rec.Code)
+		}
+	})
+}
@@ -0,0 +1,82 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/openobserve"
+)
+
+// traceBatchRequest is the request body of POST /api/v1alpha1/traces:batchGet.
+// The time range is optional, but both ends must be set together.
+type traceBatchRequest struct {
+	TraceIds    []string                 `json:"traceIds"`
+	SearchScope gen.ComponentSearchScope `json:"searchScope"`
+	StartTime   *time.Time               `json:"startTime,omitempty"`
+	EndTime     *time.Time               `json:"endTime,omitempty"`
+}
+
+// traceBatchResponse is a traces query response with the IDs of the
+// requested traces that were not found.
+type traceBatchResponse struct {
+	tracesListResponse
+	MissingTraceIds []string `json:"missingTraceIds"`
+}
+
+// BatchGetTraces implements POST /api/v1alpha1/traces:batchGet. It returns
+// the summaries of up to openobserve.MaxBatchTraceIDs traces of a scope with
+// one query, for clients resolving many trace links at once.
+func (h *TracingHandler) BatchGetTraces(w http.ResponseWriter, r *http.Request) {
+	var req traceBatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "invalid request body")
+		return
+	}
+	if strings.TrimSpace(req.SearchScope.Namespace) == "" {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "namespace is required")
+		return
+	}
+	if (req.StartTime == nil) != (req.EndTime == nil) {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "startTime and endTime must be set together")
+		return
+	}
+
+	params := openobserve.TraceBatchParams{
+		TracesQueryParams: toTracesQueryParams(&gen.TracesQueryRequest{SearchScope: req.SearchScope}),
+		TraceIDs:          req.TraceIds,
+	}
+	if req.StartTime != nil {
+		if req.EndTime.Before(*req.StartTime) {
+			writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "endTime must be >= startTime")
+			return
+		}
+		params.StartTime, params.EndTime = *req.StartTime, *req.EndTime
+	}
+	if err := openobserve.ValidateTraceBatch(params); err != nil {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, err.Error())
+		return
+	}
+
+	result, err := h.client.GetTracesByID(r.Context(), params)
+	if err != nil {
+		h.logger.Error("Failed to look up traces", slog.Int("traceIds", len(params.TraceIDs)), slog.Any("error", err))
+		writeJSONError(w, http.StatusInternalServerError, gen.InternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, traceBatchResponse{
+		tracesListResponse: toTracesQueryResponse(&openobserve.TracesResult{
+			Traces: result.Traces,
+			Total:  len(result.Traces),
+			TookMs: result.TookMs,
+		}),
+		MissingTraceIds: result.MissingTraceIDs,
+	})
+}
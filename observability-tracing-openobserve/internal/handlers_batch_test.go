@@ -0,0 +1,86 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/openobserve"
+)
+
+func TestBatchGetTraces(t *testing.T) {
+	var queries []string
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query struct {
+				SQL string `json:"sql"`
+			} `json:"query"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		queries = append(queries, body.Query.SQL)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"took":3,"hits":[{"trace_id":"t2","span_count":2,"start_time":1000,"end_time":3000,` +
+			`"error_count":0,"root_span_id":"s1","root_span_name":"GET /cart","root_span_kind":"SERVER"}]}`))
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "admin", "pass", testLogger())
+	handler := NewTracingHandler(client, testLogger())
+
+	t.Run("success", func(t *testing.T) {
+		queries = nil
+		body := `{"searchScope":{"namespace":"test-ns","component":"comp-1"},"traceIds":["t1","t2"]}`
+		req := httptest.NewRequest(http.MethodPost, "/api/v1alpha1/traces:batchGet", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		handler.BatchGetTraces(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var resp traceBatchResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(resp.Traces) != 1 || *resp.Traces[0].TraceId != "t2" || !resp.Traces[0].Complete {
+			t.Fatalf("unexpected traces: %+v", resp.Traces)
+		}
+		if len(resp.MissingTraceIds) != 1 || resp.MissingTraceIds[0] != "t1" {
+			t.Errorf("expected t1 to be missing, got %v", resp.MissingTraceIds)
+		}
+		if len(queries) != 1 || !strings.Contains(queries[0], "trace_id IN ('t1', 't2')") {
+			t.Errorf("unexpected queries: %v", queries)
+		}
+	})
+
+	tooMany := make([]string, openobserve.MaxBatchTraceIDs+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("%q", fmt.Sprintf("t%d", i))
+	}
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"invalid body", `{`},
+		{"missing namespace", `{"traceIds":["t1"]}`},
+		{"no trace IDs", `{"searchScope":{"namespace":"ns"}}`},
+		{"too many trace IDs", `{"searchScope":{"namespace":"ns"},"traceIds":[` + strings.Join(tooMany, ",") + `]}`},
+		{"start time only", `{"searchScope":{"namespace":"ns"},"traceIds":["t1"],"startTime":"2025-01-01T00:00:00Z"}`},
+		{"reversed times", `{"searchScope":{"namespace":"ns"},"traceIds":["t1"],"startTime":"2025-01-02T00:00:00Z","endTime":"2025-01-01T00:00:00Z"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1alpha1/traces:batchGet", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			handler.BatchGetTraces(rec, req)
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected 400, got %d", rec.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/openobserve"
+)
+
+// traceGroupsRequest is the request body of POST /api/v1alpha1/traces/groups:
+// a traces query request, whose limit bounds the number of groups, and the
+// number of sample trace IDs of each group.
+type traceGroupsRequest struct {
+	gen.TracesQueryRequest
+	Samples *int `json:"samples,omitempty"`
+}
+
+// QueryTraceGroups implements POST /api/v1alpha1/traces/groups. It rolls up
+// the traces of a scope and time range by the operation of their root span,
+// busiest first, with the trace count, error rate and p95 duration of each
+// group and a few sample trace IDs to drill into.
+func (h *TracingHandler) QueryTraceGroups(w http.ResponseWriter, r *http.Request) {
+	var req traceGroupsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "invalid request body")
+		return
+	}
+	if strings.TrimSpace(req.SearchScope.Namespace) == "" {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "namespace is required")
+		return
+	}
+	if req.StartTime.IsZero() || req.EndTime.IsZero() {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "startTime and endTime are required")
+		return
+	}
+	if req.EndTime.Before(req.StartTime) {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "endTime must be >= startTime")
+		return
+	}
+
+	params := openobserve.TraceGroupsParams{
+		TracesQueryParams: toTracesQueryParams(&req.TracesQueryRequest),
+		Samples:           openobserve.DefaultTraceGroupSamples,
+	}
+	if req.Limit == nil {
+		params.Limit = openobserve.DefaultTraceGroupsLimit
+	}
+	if req.Samples != nil {
+		params.Samples = *req.Samples
+	}
+	if err := openobserve.ValidateTraceGroups(params); err != nil {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, err.Error())
+		return
+	}
+
+	result, err := h.client.GetTraceGroups(r.Context(), params)
+	if err != nil {
+		h.logger.Error("Failed to query trace groups", slog.Any("error", err))
+		writeJSONError(w, http.StatusInternalServerError, gen.InternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/openobserve"
+)
+
+func TestQueryTraceGroups(t *testing.T) {
+	var sizes []int
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query struct {
+				SQL  string `json:"sql"`
+				Size int    `json:"size"`
+			} `json:"query"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		sizes = append(sizes, body.Query.Size)
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(body.Query.SQL, "sample_rank") {
+			_, _ = w.Write([]byte(`{"took":1,"hits":[{"operation_name":"GET /cart","trace_id":"t1"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"took":2,"hits":[{"operation_name":"GET /cart","trace_count":4,"error_count":1,"p95_duration":2000}]}`))
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "admin", "pass", testLogger())
+	handler := NewTracingHandler(client, testLogger())
+
+	t.Run("success", func(t *testing.T) {
+		sizes = nil
+		body := `{"searchScope":{"namespace":"test-ns","component":"comp-1"},` +
+			`"startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-02T00:00:00Z","samples":2}`
+		req := httptest.NewRequest(http.MethodPost, "/api/v1alpha1/traces/groups", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		handler.QueryTraceGroups(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var resp openobserve.TraceGroupsResult
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(resp.Groups) != 1 || resp.Groups[0].ErrorRate != 0.25 || resp.Groups[0].SampleTraceIDs[0] != "t1" {
+			t.Fatalf("unexpected response: %+v", resp)
+		}
+		if len(sizes) != 2 || sizes[0] != openobserve.DefaultTraceGroupsLimit || sizes[1] != 2 {
+			t.Errorf("unexpected query sizes: %v", sizes)
+		}
+	})
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"invalid body", `{`},
+		{"missing namespace", `{"startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-02T00:00:00Z"}`},
+		{"missing times", `{"searchScope":{"namespace":"ns"}}`},
+		{"reversed times", `{"searchScope":{"namespace":"ns"},"startTime":"2025-01-02T00:00:00Z","endTime":"2025-01-01T00:00:00Z"}`},
+		{"limit too large", `{"searchScope":{"namespace":"ns"},"startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-02T00:00:00Z","limit":1000}`},
+		{"too many samples", `{"searchScope":{"namespace":"ns"},"startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-02T00:00:00Z","samples":50}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1alpha1/traces/groups", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			handler.QueryTraceGroups(rec, req)
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected 400, got %d", rec.Code)
+			}
+		})
+	}
+}
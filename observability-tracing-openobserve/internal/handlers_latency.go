@@ -0,0 +1,71 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/openobserve"
+)
+
+// GetLatencyHistogram implements GET /api/v1alpha1/traces/latency. It returns
+// a histogram of span durations within a scope and time range, with latency
+// percentiles estimated from it. Buckets grow exponentially from one
+// microsecond, so durations from microseconds to minutes are covered by a
+// few dozen buckets with a bounded relative error; the bucket scheme is
+// returned so clients can interpret the bucket boundaries.
+//
+// Query parameters: those of ListServices, plus operation, growthFactor
+// (2 by default) and percentiles, a comma-separated list (50,90,95,99 by
+// default).
+func (h *TracingHandler) GetLatencyHistogram(w http.ResponseWriter, r *http.Request) {
+	scope, ok := parseScopeQuery(w, r)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	params := openobserve.LatencyHistogramParams{
+		TracesQueryParams: scope,
+		Operation:         query.Get("operation"),
+		GrowthFactor:      openobserve.DefaultGrowthFactor,
+		Percentiles:       openobserve.DefaultLatencyPercentiles,
+	}
+	if v := query.Get("growthFactor"); v != "" {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "growthFactor must be a number")
+			return
+		}
+		params.GrowthFactor = f
+	}
+	if v := query.Get("percentiles"); v != "" {
+		params.Percentiles = nil
+		for _, s := range strings.Split(v, ",") {
+			p, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+			if err != nil {
+				writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "percentiles must be a comma-separated list of numbers")
+				return
+			}
+			params.Percentiles = append(params.Percentiles, p)
+		}
+	}
+	if err := openobserve.ValidateLatencyHistogram(params); err != nil {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, err.Error())
+		return
+	}
+
+	result, err := h.client.GetLatencyHistogram(r.Context(), params)
+	if err != nil {
+		h.logger.Error("Failed to query latency histogram", slog.Any("error", err))
+		writeJSONError(w, http.StatusInternalServerError, gen.InternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
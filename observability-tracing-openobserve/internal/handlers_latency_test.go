@@ -0,0 +1,79 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/openobserve"
+)
+
+func TestGetLatencyHistogram(t *testing.T) {
+	var gotSQL string
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query struct {
+				SQL string `json:"sql"`
+			} `json:"query"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		gotSQL = body.Query.SQL
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"took":2,"hits":[{"bucket":10,"span_count":90},{"bucket":14,"span_count":10}]}`))
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "admin", "pass", testLogger())
+	handler := NewTracingHandler(client, testLogger())
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet,
+			"/api/v1alpha1/traces/latency?namespace=test-ns&operation=GET%20%2Fcart&growthFactor=1.5&percentiles=50,%2099.9", nil)
+		rec := httptest.NewRecorder()
+		handler.GetLatencyHistogram(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if !strings.Contains(gotSQL, "ln(1.5)") || !strings.Contains(gotSQL, "operation_name = 'GET /cart'") {
+			t.Errorf("unexpected SQL: %s", gotSQL)
+		}
+
+		var resp openobserve.LatencyHistogramResult
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if resp.Scheme.GrowthFactor != 1.5 || resp.Total != 100 || len(resp.Buckets) != 2 {
+			t.Fatalf("unexpected response: %+v", resp)
+		}
+		if len(resp.Percentiles) != 2 || resp.Percentiles[1].Percentile != 99.9 {
+			t.Errorf("unexpected percentiles: %+v", resp.Percentiles)
+		}
+	})
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"missing namespace", ""},
+		{"invalid growthFactor", "namespace=ns&growthFactor=fast"},
+		{"growthFactor out of range", "namespace=ns&growthFactor=1"},
+		{"invalid percentiles", "namespace=ns&percentiles=p99"},
+		{"percentile out of range", "namespace=ns&percentiles=100"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1alpha1/traces/latency?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			handler.GetLatencyHistogram(rec, req)
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected 400, got %d", rec.Code)
+			}
+		})
+	}
+}
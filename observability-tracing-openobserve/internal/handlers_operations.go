@@ -0,0 +1,52 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/openobserve"
+)
+
+// QueryOperationStats implements POST /api/v1alpha1/traces/operations/stats.
+// It takes a traces query request, whose limit bounds the number of
+// operations, and returns the p50, p90 and p99 latency, throughput and error
+// rate of each operation of the scope and time range, busiest first.
+func (h *TracingHandler) QueryOperationStats(w http.ResponseWriter, r *http.Request) {
+	var req gen.TracesQueryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "invalid request body")
+		return
+	}
+	if strings.TrimSpace(req.SearchScope.Namespace) == "" {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "namespace is required")
+		return
+	}
+	if req.StartTime.IsZero() || req.EndTime.IsZero() {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "startTime and endTime are required")
+		return
+	}
+
+	params := toTracesQueryParams(&req)
+	if req.Limit == nil {
+		params.Limit = openobserve.DefaultOperationStatsLimit
+	}
+	if err := openobserve.ValidateOperationStats(params); err != nil {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, err.Error())
+		return
+	}
+
+	result, err := h.client.GetOperationStats(r.Context(), params)
+	if err != nil {
+		h.logger.Error("Failed to query operation stats", slog.Any("error", err))
+		writeJSONError(w, http.StatusInternalServerError, gen.InternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,66 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/openobserve"
+)
+
+func TestQueryOperationStats(t *testing.T) {
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"took":2,"hits":[{"service_name":"cart","operation_name":"GET /cart","span_count":864,` +
+			`"error_count":0,"p50_duration":100,"p90_duration":400,"p99_duration":900}]}`))
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "admin", "pass", testLogger())
+	srv := NewServer("0", NewTracingHandler(client, testLogger()), testLogger()).httpServer.Handler
+
+	t.Run("success", func(t *testing.T) {
+		body := `{"searchScope":{"namespace":"test-ns","component":"comp-1"},` +
+			`"startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-02T00:00:00Z"}`
+		req := httptest.NewRequest(http.MethodPost, "/api/v1alpha1/traces/operations/stats", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var resp openobserve.OperationStatsResult
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(resp.Operations) != 1 || resp.Operations[0].Throughput != 0.01 || resp.Operations[0].P99DurationNs != 900 {
+			t.Fatalf("unexpected response: %+v", resp)
+		}
+	})
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"invalid body", `{`},
+		{"missing namespace", `{"startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-02T00:00:00Z"}`},
+		{"missing times", `{"searchScope":{"namespace":"ns"}}`},
+		{"reversed times", `{"searchScope":{"namespace":"ns"},"startTime":"2025-01-02T00:00:00Z","endTime":"2025-01-01T00:00:00Z"}`},
+		{"limit too large", `{"searchScope":{"namespace":"ns"},"startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-02T00:00:00Z","limit":5000}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1alpha1/traces/operations/stats", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			srv.ServeHTTP(rec, req)
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected 400, got %d", rec.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/openobserve"
+)
+
+// maxPinReasonLength bounds the reason recorded with a pinned trace.
+const maxPinReasonLength = 500
+
+// pinTraceRequest is the optional request body of POST /api/v1alpha1/traces/{traceId}/pin.
+type pinTraceRequest struct {
+	Reason    string     `json:"reason"`
+	StartTime *time.Time `json:"startTime"`
+	EndTime   *time.Time `json:"endTime"`
+}
+
+// pinnedTracesResponse is the response body of GET /api/v1alpha1/traces/pins.
+type pinnedTracesResponse struct {
+	Pins []openobserve.PinnedTrace `json:"pins"`
+}
+
+// PinTrace implements POST /api/v1alpha1/traces/{traceId}/pin. It copies
+// all the spans of a trace to the long-retention archive stream, so that an
+// important incident trace survives the retention of the traces stream. The
+// span endpoints serve pinned traces from the archive once the traces stream
+// no longer holds them.
+//
+// The traces stream is searched over all time unless both startTime and
+// endTime are set; narrowing the window makes pinning much cheaper. It
+// responds 201 with the pinned trace, or 200 when the trace was pinned
+// already, in which case nothing is copied.
+func (h *TracingHandler) PinTrace(w http.ResponseWriter, r *http.Request) {
+	var req pinTraceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "invalid request body")
+		return
+	}
+	if len(req.Reason) > maxPinReasonLength {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "reason must be at most 500 characters")
+		return
+	}
+	var start, end time.Time
+	if req.StartTime != nil || req.EndTime != nil {
+		if req.StartTime == nil || req.EndTime == nil {
+			writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "startTime and endTime must be set together")
+			return
+		}
+		if req.EndTime.Before(*req.StartTime) {
+			writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "endTime must be >= startTime")
+			return
+		}
+		start, end = *req.StartTime, *req.EndTime
+	}
+
+	traceID := r.PathValue("traceId")
+	result, err := h.client.PinTrace(r.Context(), traceID, req.Reason, start, end)
+	switch {
+	case errors.Is(err, openobserve.ErrArchiveDisabled):
+		writeJSONError(w, http.StatusNotImplemented, gen.BadRequest, "the trace archive is not configured")
+		return
+	case errors.Is(err, openobserve.ErrTraceNotFound):
+		writeJSONError(w, http.StatusNotFound, errorTitleNotFound, "trace not found")
+		return
+	case err != nil:
+		h.logger.Error("Failed to pin trace", slog.String("traceId", traceID), slog.Any("error", err))
+		writeJSONError(w, http.StatusInternalServerError, gen.InternalServerError, err.Error())
+		return
+	}
+
+	status := http.StatusCreated
+	if result.AlreadyPinned {
+		status = http.StatusOK
+	} else {
+		h.logger.Info("Pinned trace",
+			slog.String("traceId", traceID),
+			slog.Int("spans", result.SpanCount),
+			slog.String("reason", req.Reason))
+	}
+	writeJSON(w, status, result)
+}
+
+// ListPinnedTraces implements GET /api/v1alpha1/traces/pins. It lists the
+// traces in the archive stream, most recently pinned first.
+func (h *TracingHandler) ListPinnedTraces(w http.ResponseWriter, r *http.Request) {
+	pins, err := h.client.ListPinnedTraces(r.Context())
+	if errors.Is(err, openobserve.ErrArchiveDisabled) {
+		writeJSONError(w, http.StatusNotImplemented, gen.BadRequest, "the trace archive is not configured")
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to list pinned traces", slog.Any("error", err))
+		writeJSONError(w, http.StatusInternalServerError, gen.InternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, pinnedTracesResponse{Pins: pins})
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/openobserve"
+)
+
+func TestPinTrace(t *testing.T) {
+	var archived int
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/_json") {
+			archived++
+			_, _ = w.Write([]byte(`{"code":200,"status":[{"name":"pinned","successful":1,"failed":0}]}`))
+			return
+		}
+		var body struct {
+			Query struct {
+				SQL string `json:"sql"`
+			} `json:"query"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		switch {
+		case strings.Contains(body.Query.SQL, "FROM pinned") &&
+			(strings.Contains(body.Query.SQL, "'trace-2'") || !strings.Contains(body.Query.SQL, "WHERE")):
+			_, _ = w.Write([]byte(`{"took":1,"hits":[{"trace_id":"trace-2","span_count":3,"pinned_at":1735732800000000}]}`))
+		case strings.Contains(body.Query.SQL, "'trace-1'") && !strings.Contains(body.Query.SQL, "FROM pinned"):
+			_, _ = w.Write([]byte(`{"took":1,"hits":[{"trace_id":"trace-1","span_id":"span-1","start_time":1735732800000000000}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"took":1,"hits":[]}`))
+		}
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "admin", "pass", testLogger())
+	handler := NewTracingHandler(client, testLogger())
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v1alpha1/traces/{traceId}/pin", handler.PinTrace)
+	mux.HandleFunc("GET /api/v1alpha1/traces/pins", handler.ListPinnedTraces)
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return rec
+	}
+
+	if rec := serve(http.MethodPost, "/api/v1alpha1/traces/trace-1/pin", ""); rec.Code != http.StatusNotImplemented {
+		t.Fatalf("expected 501 without an archive stream, got %d: %s", rec.Code, rec.Body.String())
+	}
+	client.SetArchiveStream("pinned")
+
+	t.Run("pins a trace", func(t *testing.T) {
+		rec := serve(http.MethodPost, "/api/v1alpha1/traces/trace-1/pin", `{"reason":"INC-42"}`)
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var got openobserve.PinResult
+		if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if got.TraceID != "trace-1" || got.SpanCount != 1 || got.Reason != "INC-42" || archived != 1 {
+			t.Errorf("unexpected response %s with %d ingestions", rec.Body.String(), archived)
+		}
+	})
+
+	t.Run("already pinned", func(t *testing.T) {
+		rec := serve(http.MethodPost, "/api/v1alpha1/traces/trace-2/pin", "")
+		if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"alreadyPinned":true`) {
+			t.Errorf("expected 200 for a pinned trace, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("unknown trace", func(t *testing.T) {
+		if rec := serve(http.MethodPost, "/api/v1alpha1/traces/missing/pin", ""); rec.Code != http.StatusNotFound {
+			t.Errorf("expected 404, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		for name, body := range map[string]string{
+			"malformed body":  `{`,
+			"long reason":     `{"reason":"` + strings.Repeat("a", maxPinReasonLength+1) + `"}`,
+			"half a window":   `{"startTime":"2025-01-01T00:00:00Z"}`,
+			"inverted window": `{"startTime":"2025-01-02T00:00:00Z","endTime":"2025-01-01T00:00:00Z"}`,
+		} {
+			if rec := serve(http.MethodPost, "/api/v1alpha1/traces/trace-1/pin", body); rec.Code != http.StatusBadRequest {
+				t.Errorf("%s: expected 400, got %d: %s", name, rec.Code, rec.Body.String())
+			}
+		}
+	})
+
+	t.Run("lists pins", func(t *testing.T) {
+		rec := serve(http.MethodGet, "/api/v1alpha1/traces/pins", "")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var got pinnedTracesResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(got.Pins) != 1 || got.Pins[0].TraceID != "trace-2" || got.Pins[0].SpanCount != 3 {
+			t.Errorf("unexpected pins: %s", rec.Body.String())
+		}
+	})
+}
@@ -0,0 +1,57 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/openobserve"
+)
+
+// QueryServiceGraph implements POST /api/v1alpha1/traces/service-graph. It
+// takes a traces query request, whose limit bounds the number of edges, and
+// returns the calls between the services of the scope and time range as a
+// dependency graph, computed in OpenObserve from the parent-child relations
+// of the spans.
+func (h *TracingHandler) QueryServiceGraph(w http.ResponseWriter, r *http.Request) {
+	var req gen.TracesQueryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "invalid request body")
+		return
+	}
+	if strings.TrimSpace(req.SearchScope.Namespace) == "" {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "namespace is required")
+		return
+	}
+	if req.StartTime.IsZero() || req.EndTime.IsZero() {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "startTime and endTime are required")
+		return
+	}
+	if req.EndTime.Before(req.StartTime) {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "endTime must be >= startTime")
+		return
+	}
+
+	params := toTracesQueryParams(&req)
+	if req.Limit == nil {
+		params.Limit = openobserve.DefaultServiceGraphEdges
+	}
+	if err := openobserve.ValidateServiceGraph(params); err != nil {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, err.Error())
+		return
+	}
+
+	result, err := h.client.GetServiceGraph(r.Context(), params)
+	if err != nil {
+		h.logger.Error("Failed to query service graph", slog.Any("error", err))
+		writeJSONError(w, http.StatusInternalServerError, gen.InternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/openobserve"
+)
+
+func TestQueryServiceGraph(t *testing.T) {
+	var sizes []int
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query struct {
+				Size int `json:"size"`
+			} `json:"query"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		sizes = append(sizes, body.Query.Size)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"took":2,"hits":[{"source":"gateway","target":"cart","call_count":4,"error_count":1,` +
+			`"p50_duration":100,"p95_duration":900}]}`))
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "admin", "pass", testLogger())
+	handler := NewTracingHandler(client, testLogger())
+
+	t.Run("success", func(t *testing.T) {
+		sizes = nil
+		body := `{"searchScope":{"namespace":"test-ns","project":"proj-1","environment":"dev"},` +
+			`"startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-02T00:00:00Z"}`
+		req := httptest.NewRequest(http.MethodPost, "/api/v1alpha1/traces/service-graph", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		handler.QueryServiceGraph(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var resp openobserve.ServiceGraphResult
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(resp.Edges) != 1 || resp.Edges[0].P95DurationNs != 900 || len(resp.Nodes) != 2 {
+			t.Fatalf("unexpected response: %+v", resp)
+		}
+		if len(sizes) != 1 || sizes[0] != openobserve.DefaultServiceGraphEdges {
+			t.Errorf("unexpected query sizes: %v", sizes)
+		}
+	})
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"invalid body", `{`},
+		{"missing namespace", `{"startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-02T00:00:00Z"}`},
+		{"missing times", `{"searchScope":{"namespace":"ns"}}`},
+		{"reversed times", `{"searchScope":{"namespace":"ns"},"startTime":"2025-01-02T00:00:00Z","endTime":"2025-01-01T00:00:00Z"}`},
+		{"limit too large", `{"searchScope":{"namespace":"ns"},"startTime":"2025-01-01T00:00:00Z","endTime":"2025-01-02T00:00:00Z","limit":5000}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1alpha1/traces/service-graph", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			handler.QueryServiceGraph(rec, req)
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected 400, got %d", rec.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/openobserve"
+)
+
+// defaultServicesWindow is the lookback used when an aggregate request has no startTime.
+const defaultServicesWindow = 24 * time.Hour
+
+// ListServices implements GET /api/v1alpha1/traces/services. It lists the
+// distinct services emitting spans within a scope and time range, with their
+// span counts, for a tracing coverage overview.
+//
+// Query parameters: namespace (required), projectUid, environmentUid,
+// componentUid, and startTime/endTime in RFC 3339 format. The window defaults
+// to the 24 hours before endTime, and endTime defaults to now.
+func (h *TracingHandler) ListServices(w http.ResponseWriter, r *http.Request) {
+	params, ok := parseScopeQuery(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := h.client.GetServices(r.Context(), params)
+	if err != nil {
+		h.logger.Error("Failed to query services", slog.Any("error", err))
+		writeJSONError(w, http.StatusInternalServerError, gen.InternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// parseScopeQuery parses the namespace, projectUid, environmentUid,
+// componentUid, startTime and endTime query parameters shared by the
+// aggregate endpoints. It writes a 400 response and returns false when a
+// parameter is invalid.
+func parseScopeQuery(w http.ResponseWriter, r *http.Request) (openobserve.TracesQueryParams, bool) {
+	query := r.URL.Query()
+	params := openobserve.TracesQueryParams{
+		EndTime: time.Now(),
+		Scope: openobserve.Scope{
+			Namespace:     strings.TrimSpace(query.Get("namespace")),
+			ProjectID:     query.Get("projectUid"),
+			EnvironmentID: query.Get("environmentUid"),
+			ComponentID:   query.Get("componentUid"),
+		},
+	}
+	if params.Scope.Namespace == "" {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "namespace is required")
+		return params, false
+	}
+	if v := query.Get("endTime"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "endTime must be an RFC 3339 timestamp")
+			return params, false
+		}
+		params.EndTime = t
+	}
+	params.StartTime = params.EndTime.Add(-defaultServicesWindow)
+	if v := query.Get("startTime"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "startTime must be an RFC 3339 timestamp")
+			return params, false
+		}
+		params.StartTime = t
+	}
+	if params.EndTime.Before(params.StartTime) {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "endTime must be >= startTime")
+		return params, false
+	}
+	return params, true
+}
+
+// writeJSON writes v as a JSON response body with the given status code.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeJSONError writes an ErrorResponse in the same shape as the generated handlers.
+func writeJSONError(w http.ResponseWriter, status int, title gen.ErrorResponseTitle, detail string) {
+	writeJSON(w, status, gen.ErrorResponse{Title: &title, Detail: &detail})
+}
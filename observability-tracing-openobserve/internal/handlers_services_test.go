@@ -0,0 +1,104 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/openobserve"
+)
+
+func TestListServices(t *testing.T) {
+	var gotSQL string
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query struct {
+				SQL string `json:"sql"`
+			} `json:"query"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		gotSQL = body.Query.SQL
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"took":3,"hits":[` +
+			`{"service_name":"checkout","component_uid":"comp-1","environment_uid":"env-1","span_count":120,"last_seen":1735732800000000000},` +
+			`{"service_name":"payments","component_uid":"comp-2","environment_uid":"env-1","span_count":7,"last_seen":1735732700000000000}]}`))
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "admin", "pass", testLogger())
+	handler := NewTracingHandler(client, testLogger())
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1alpha1/traces/services?namespace=test-ns&projectUid=proj-1", nil)
+		rec := httptest.NewRecorder()
+		handler.ListServices(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if !strings.Contains(gotSQL, "service_openchoreo_dev_project_uid = 'proj-1'") {
+			t.Errorf("expected project filter in SQL: %s", gotSQL)
+		}
+
+		var resp openobserve.ServicesResult
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if resp.TookMs != 3 || len(resp.Services) != 2 {
+			t.Fatalf("unexpected response: %+v", resp)
+		}
+		if resp.Services[0].ServiceName != "checkout" || resp.Services[0].SpanCount != 120 {
+			t.Errorf("unexpected first service: %+v", resp.Services[0])
+		}
+		if resp.Services[0].LastSeen.UnixNano() != 1735732800000000000 {
+			t.Errorf("unexpected lastSeen: %v", resp.Services[0].LastSeen)
+		}
+	})
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"missing namespace", ""},
+		{"invalid startTime", "namespace=ns&startTime=yesterday"},
+		{"invalid endTime", "namespace=ns&endTime=now"},
+		{"inverted window", "namespace=ns&startTime=2025-01-02T00:00:00Z&endTime=2025-01-01T00:00:00Z"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1alpha1/traces/services?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			handler.ListServices(rec, req)
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected 400, got %d", rec.Code)
+			}
+		})
+	}
+}
+
+func TestListServices_StreamNotFound(t *testing.T) {
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":20002,"message":"Search stream not found"}`))
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "admin", "pass", testLogger())
+	handler := NewTracingHandler(client, testLogger())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1alpha1/traces/services?namespace=test-ns", nil)
+	rec := httptest.NewRecorder()
+	handler.ListServices(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), `"services":[]`) {
+		t.Errorf("expected empty services list, got %s", rec.Body.String())
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/openobserve"
+)
+
+// spanLookupResponse is the span details response with the ID of the trace
+// that owns the span.
+type spanLookupResponse struct {
+	TraceId string `json:"traceId"`
+	spanDetailsResponse
+}
+
+// GetSpan implements GET /api/v1alpha1/spans/{spanId}. It finds the trace
+// that owns a span when only the span ID is known, e.g. from an error log
+// line, and returns the span details with the trace ID. With redirect=true it
+// redirects to /api/v1alpha1/traces/{traceId}/spans/{spanId} instead.
+//
+// The search spans all time unless both startTime and endTime are set, in
+// RFC 3339 format; narrowing the window makes the lookup much cheaper.
+func (h *TracingHandler) GetSpan(w http.ResponseWriter, r *http.Request) {
+	params := openobserve.TracesQueryParams{SpanID: r.PathValue("spanId")}
+	query := r.URL.Query()
+	startTime, endTime, err := parseTimeWindow(query)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, err.Error())
+		return
+	}
+	params.StartTime, params.EndTime = startTime, endTime
+
+	result, err := h.client.FindSpan(r.Context(), params)
+	if errors.Is(err, openobserve.ErrSpanNotFound) {
+		writeJSONError(w, http.StatusNotFound, errorTitleNotFound, "span not found")
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to look up span", slog.String("spanId", params.SpanID), slog.Any("error", err))
+		writeJSONError(w, http.StatusInternalServerError, gen.InternalServerError, err.Error())
+		return
+	}
+
+	if query.Get("redirect") == "true" {
+		location := "/api/v1alpha1/traces/" + url.PathEscape(result.TraceID) + "/spans/" + url.PathEscape(params.SpanID)
+		http.Redirect(w, r, location, http.StatusFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, spanLookupResponse{
+		TraceId:             result.TraceID,
+		spanDetailsResponse: newSpanDetailsResponse(&result.Span),
+	})
+}
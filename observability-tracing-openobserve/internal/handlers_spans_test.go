@@ -0,0 +1,83 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/openobserve"
+)
+
+func TestGetSpan(t *testing.T) {
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query struct {
+				SQL string `json:"sql"`
+			} `json:"query"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(body.Query.SQL, "'missing'") {
+			_, _ = w.Write([]byte(`{"took":1,"hits":[]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"took":1,"hits":[{"trace_id":"trace-1","span_id":"span-1","operation_name":"GET /cart"}]}`))
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "admin", "pass", testLogger())
+	handler := NewTracingHandler(client, testLogger())
+
+	get := func(path string) *httptest.ResponseRecorder {
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /api/v1alpha1/spans/{spanId}", handler.GetSpan)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	t.Run("returns trace context", func(t *testing.T) {
+		rec := get("/api/v1alpha1/spans/span-1")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var resp struct {
+			TraceId  string `json:"traceId"`
+			SpanId   string `json:"spanId"`
+			SpanName string `json:"spanName"`
+		}
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if resp.TraceId != "trace-1" || resp.SpanId != "span-1" || resp.SpanName != "GET /cart" {
+			t.Errorf("unexpected response: %+v", resp)
+		}
+	})
+
+	t.Run("redirect", func(t *testing.T) {
+		rec := get("/api/v1alpha1/spans/span-1?redirect=true")
+		if rec.Code != http.StatusFound {
+			t.Fatalf("expected 302, got %d", rec.Code)
+		}
+		if loc := rec.Header().Get("Location"); loc != "/api/v1alpha1/traces/trace-1/spans/span-1" {
+			t.Errorf("unexpected Location %q", loc)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		if rec := get("/api/v1alpha1/spans/missing"); rec.Code != http.StatusNotFound {
+			t.Errorf("expected 404, got %d", rec.Code)
+		}
+	})
+
+	t.Run("partial time range", func(t *testing.T) {
+		if rec := get("/api/v1alpha1/spans/span-1?startTime=2025-01-01T00:00:00Z"); rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", rec.Code)
+		}
+	})
+}
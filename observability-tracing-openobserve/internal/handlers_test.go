@@ -7,10 +7,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -464,6 +466,16 @@ func TestQueryTraces_Success(t *testing.T) {
 				},
 			},
 		}
+		if body, _ := io.ReadAll(r.Body); strings.Contains(string(body), "GROUP BY trace_id") {
+			// The page of traces summarizes their spans.
+			resp.Hits = []map[string]interface{}{{
+				"trace_id":     "trace-1",
+				"span_count":   json.Number("2"),
+				"start_time":   json.Number(fmt.Sprintf("%d", startNs)),
+				"end_time":     json.Number(fmt.Sprintf("%d", endNs)),
+				"root_span_id": "span-root",
+			}}
+		}
 		w.Header().Set("Content-Type", "application/json")
 		// Use json.Number-compatible encoding
 		data, _ := json.Marshal(resp)
@@ -487,9 +499,61 @@ func TestQueryTraces_Success(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	if _, ok := resp.(gen.QueryTraces200JSONResponse); !ok {
+	tracesResp, ok := resp.(tracesListResponse)
+	if !ok {
 		t.Fatalf("expected 200 response, got %T", resp)
 	}
+	if len(tracesResp.Traces) != 1 || !tracesResp.Traces[0].Complete {
+		t.Errorf("expected one complete trace, got %+v", tracesResp.Traces)
+	}
+}
+
+func TestToTracesQueryResponse(t *testing.T) {
+	result := &openobserve.TracesResult{
+		Traces: []openobserve.TraceEntry{
+			{TraceID: "trace-1", RootSpanID: "root", Complete: true},
+			{TraceID: "trace-2", Complete: false, IncompleteReason: openobserve.IncompleteReasonMissingRoot},
+		},
+		Total:  2,
+		TookMs: 4,
+	}
+
+	resp := toTracesQueryResponse(result)
+
+	rec := httptest.NewRecorder()
+	if err := resp.VisitQueryTracesResponse(rec); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", rec.Code)
+	}
+
+	var body struct {
+		Total  int `json:"total"`
+		Traces []struct {
+			TraceID          string  `json:"traceId"`
+			Complete         *bool   `json:"complete"`
+			IncompleteReason *string `json:"incompleteReason"`
+		} `json:"traces"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if body.Total != 2 || len(body.Traces) != 2 {
+		t.Fatalf("unexpected body: %s", rec.Body.String())
+	}
+	if body.Traces[0].TraceID != "trace-1" || body.Traces[0].Complete == nil || !*body.Traces[0].Complete {
+		t.Errorf("expected trace-1 to be complete: %s", rec.Body.String())
+	}
+	if body.Traces[0].IncompleteReason != nil {
+		t.Errorf("expected no reason for a complete trace: %s", rec.Body.String())
+	}
+	if body.Traces[1].Complete == nil || *body.Traces[1].Complete {
+		t.Errorf("expected trace-2 to be partial: %s", rec.Body.String())
+	}
+	if body.Traces[1].IncompleteReason == nil || *body.Traces[1].IncompleteReason != "missingRootSpan" {
+		t.Errorf("expected missingRootSpan reason: %s", rec.Body.String())
+	}
 }
 
 func TestQuerySpansForTrace_Success(t *testing.T) {
@@ -540,6 +604,78 @@ func TestQuerySpansForTrace_Success(t *testing.T) {
 	}
 }
 
+func TestQuerySpansForTrace_SkewCorrection(t *testing.T) {
+	rootStart := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC).UnixNano()
+	rootEnd := rootStart + int64(100*time.Millisecond)
+	childStart := rootStart - int64(70*time.Millisecond)
+	childEnd := rootStart - int64(10*time.Millisecond)
+
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := openobserve.OpenObserveResponse{
+			Hits: []map[string]interface{}{
+				{
+					"span_id":    "root",
+					"start_time": json.Number(fmt.Sprintf("%d", rootStart)),
+					"end_time":   json.Number(fmt.Sprintf("%d", rootEnd)),
+				},
+				{
+					"span_id":                  "child",
+					"reference_parent_span_id": "root",
+					"start_time":               json.Number(fmt.Sprintf("%d", childStart)),
+					"end_time":                 json.Number(fmt.Sprintf("%d", childEnd)),
+				},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		data, _ := json.Marshal(resp)
+		w.Write(data)
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "admin", "pass", testLogger())
+	handler := NewTracingHandler(client, testLogger())
+
+	ctx := context.WithValue(context.Background(), queryExtensionsKey, queryExtensions{SkewCorrection: true})
+	resp, err := handler.QuerySpansForTrace(ctx, gen.QuerySpansForTraceRequestObject{
+		TraceId: "trace-1",
+		Body: &gen.TracesQueryRequest{
+			StartTime:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			EndTime:     time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+			SearchScope: gen.ComponentSearchScope{Namespace: "test-ns"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	spansResp, ok := resp.(spansListResponse)
+	if !ok {
+		t.Fatalf("expected extended spans response, got %T", resp)
+	}
+	if len(spansResp.SkewCorrections) != 1 || spansResp.SkewCorrections[0].SpanID != "child" {
+		t.Fatalf("unexpected corrections: %+v", spansResp.SkewCorrections)
+	}
+	child := (*spansResp.Spans)[1]
+	if child.StartTime.Before(time.Unix(0, rootStart)) {
+		t.Errorf("expected child to start within its parent, got %v", child.StartTime)
+	}
+
+	rec := httptest.NewRecorder()
+	if err := spansResp.VisitQuerySpansForTraceResponse(rec); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var body map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if _, ok := body["spans"]; !ok {
+		t.Error("expected spans at the top level of the response")
+	}
+	if _, ok := body["skewCorrections"]; !ok {
+		t.Error("expected skewCorrections in the response")
+	}
+}
+
 func TestGetSpanDetailsForTrace_Success(t *testing.T) {
 	startNs := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC).UnixNano()
 	endNs := time.Date(2025, 1, 1, 12, 0, 1, 0, time.UTC).UnixNano()
@@ -559,6 +695,8 @@ func TestGetSpanDetailsForTrace_Success(t *testing.T) {
 					"reference_parent_span_id": "span-root",
 					"http.method":              "GET",
 					"service.name":             "my-service",
+					"events":                   `[{"name":"exception","_timestamp":1735732800500000000,"exception.type":"TimeoutError"}]`,
+					"links":                    `[{"context":{"traceId":"trace-2","spanId":"span-9"},"attributes":{"messaging.operation":"publish"}}]`,
 				},
 			},
 		}
@@ -579,7 +717,7 @@ func TestGetSpanDetailsForTrace_Success(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	detailResp, ok := resp.(gen.GetSpanDetailsForTrace200JSONResponse)
+	detailResp, ok := resp.(spanDetailsResponse)
 	if !ok {
 		t.Fatalf("expected 200 response, got %T", resp)
 	}
@@ -589,6 +727,31 @@ func TestGetSpanDetailsForTrace_Success(t *testing.T) {
 	if detailResp.SpanName == nil || *detailResp.SpanName != "db.query" {
 		t.Errorf("expected spanName 'db.query', got %v", detailResp.SpanName)
 	}
+	if _, ok := (*detailResp.Attributes)["events"]; ok {
+		t.Error("expected events to be left out of the attributes")
+	}
+
+	rec := httptest.NewRecorder()
+	if err := detailResp.VisitGetSpanDetailsForTraceResponse(rec); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var body struct {
+		SpanID string                  `json:"spanId"`
+		Events []openobserve.SpanEvent `json:"events"`
+		Links  []openobserve.SpanLink  `json:"links"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if body.SpanID != "span-1" {
+		t.Errorf("expected the generated fields at the top level, got spanId %q", body.SpanID)
+	}
+	if len(body.Events) != 1 || body.Events[0].Name != "exception" || body.Events[0].Attributes["exception.type"] != "TimeoutError" {
+		t.Errorf("unexpected events %+v", body.Events)
+	}
+	if len(body.Links) != 1 || body.Links[0].TraceID != "trace-2" || body.Links[0].Attributes["messaging.operation"] != "publish" {
+		t.Errorf("unexpected links %+v", body.Links)
+	}
 }
 
 func TestGetSpanDetailsForTrace_NotFound(t *testing.T) {
@@ -620,6 +783,104 @@ func TestGetSpanDetailsForTrace_NotFound(t *testing.T) {
 	}
 }
 
+func TestQuerySpansForTrace_Tree(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC).UnixNano()
+	ms := int64(time.Millisecond)
+
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := openobserve.OpenObserveResponse{
+			Hits: []map[string]interface{}{
+				{
+					"span_id":    "root",
+					"start_time": json.Number(fmt.Sprintf("%d", start)),
+					"end_time":   json.Number(fmt.Sprintf("%d", start+100*ms)),
+				},
+				{
+					"span_id":                  "child",
+					"reference_parent_span_id": "root",
+					"start_time":               json.Number(fmt.Sprintf("%d", start+10*ms)),
+					"end_time":                 json.Number(fmt.Sprintf("%d", start+40*ms)),
+				},
+				{
+					"span_id":                  "orphan",
+					"reference_parent_span_id": "missing",
+					"start_time":               json.Number(fmt.Sprintf("%d", start+50*ms)),
+					"end_time":                 json.Number(fmt.Sprintf("%d", start+60*ms)),
+				},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		data, _ := json.Marshal(resp)
+		w.Write(data)
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "admin", "pass", testLogger())
+	handler := NewTracingHandler(client, testLogger())
+	request := gen.QuerySpansForTraceRequestObject{
+		TraceId: "trace-1",
+		Body: &gen.TracesQueryRequest{
+			StartTime:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			EndTime:     time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+			SearchScope: gen.ComponentSearchScope{Namespace: "test-ns"},
+		},
+	}
+
+	ctx := context.WithValue(context.Background(), queryExtensionsKey, queryExtensions{Format: "nested"})
+	resp, err := handler.QuerySpansForTrace(ctx, request)
+	if _, ok := resp.(gen.QuerySpansForTrace400JSONResponse); err != nil || !ok {
+		t.Errorf("expected 400 for an unknown format, got %T", resp)
+	}
+
+	ctx = context.WithValue(context.Background(), queryExtensionsKey, queryExtensions{Format: "tree"})
+	resp, err = handler.QuerySpansForTrace(ctx, request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	spansResp, ok := resp.(spansListResponse)
+	if !ok {
+		t.Fatalf("expected extended spans response, got %T", resp)
+	}
+	rec := httptest.NewRecorder()
+	if err := spansResp.VisitQuerySpansForTraceResponse(rec); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var body struct {
+		Spans json.RawMessage `json:"spans"`
+		Tree  []struct {
+			SpanID     string `json:"spanId"`
+			Depth      int    `json:"depth"`
+			OffsetNs   int64  `json:"offsetNs"`
+			SelfTimeNs int64  `json:"selfTimeNs"`
+			Orphan     bool   `json:"orphan"`
+			Children   []struct {
+				SpanID   string `json:"spanId"`
+				Depth    int    `json:"depth"`
+				OffsetNs int64  `json:"offsetNs"`
+			} `json:"children"`
+		} `json:"tree"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if body.Spans != nil {
+		t.Error("expected the flat spans to be replaced by the tree")
+	}
+	if len(body.Tree) != 2 {
+		t.Fatalf("expected the root and the orphan at the top, got %+v", body.Tree)
+	}
+	root, orphan := body.Tree[0], body.Tree[1]
+	if root.SpanID != "root" || root.Orphan || root.SelfTimeNs != 70*ms || len(root.Children) != 1 {
+		t.Errorf("unexpected root %+v", root)
+	}
+	if c := root.Children[0]; c.SpanID != "child" || c.Depth != 1 || c.OffsetNs != 10*ms {
+		t.Errorf("unexpected child %+v", c)
+	}
+	if orphan.SpanID != "orphan" || !orphan.Orphan || orphan.Depth != 0 || orphan.OffsetNs != 50*ms {
+		t.Errorf("unexpected orphan %+v", orphan)
+	}
+}
+
 func TestQuerySpansForTrace_ServerError(t *testing.T) {
 	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -676,3 +937,205 @@ func TestQueryTraces_ServerError(t *testing.T) {
 		t.Fatalf("expected 500 response, got %T", resp)
 	}
 }
+
+func TestQuerySpansForTrace_Timeline(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("type") == "logs" {
+			fmt.Fprintf(w, `{"hits":[{"_timestamp":%d,"log":"charging card","span_id":"root"}]}`, start.Add(time.Millisecond).UnixMicro())
+			return
+		}
+		events := fmt.Sprintf(`[{"name":"exception","_timestamp":%d}]`, start.Add(2*time.Millisecond).UnixNano())
+		resp := openobserve.OpenObserveResponse{
+			Hits: []map[string]interface{}{
+				{"span_id": "root", "start_time": json.Number(fmt.Sprintf("%d", start.UnixNano())), "events": events},
+				{"span_id": "child", "reference_parent_span_id": "root"},
+			},
+		}
+		data, _ := json.Marshal(resp)
+		w.Write(data)
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "admin", "pass", testLogger())
+	client.SetLogsStream("default")
+	handler := NewTracingHandler(client, testLogger())
+
+	ctx := context.WithValue(context.Background(), queryExtensionsKey, queryExtensions{Timeline: true})
+	resp, err := handler.QuerySpansForTrace(ctx, gen.QuerySpansForTraceRequestObject{
+		TraceId: "trace-1",
+		Body: &gen.TracesQueryRequest{
+			StartTime:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			EndTime:     time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+			SearchScope: gen.ComponentSearchScope{Namespace: "test-ns"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	if err := resp.VisitQuerySpansForTraceResponse(rec); err != nil {
+		t.Fatalf("failed to write response: %v", err)
+	}
+	var body struct {
+		Spans []struct {
+			SpanID   string `json:"spanId"`
+			Timeline []struct {
+				Kind string `json:"kind"`
+				Name string `json:"name"`
+				Log  string `json:"log"`
+			} `json:"timeline"`
+			TimelineTruncated *bool `json:"timelineTruncated"`
+		} `json:"spans"`
+		SkewCorrections []json.RawMessage `json:"skewCorrections"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(body.Spans) != 2 {
+		t.Fatalf("expected 2 spans, got %s", rec.Body.String())
+	}
+	root := body.Spans[0]
+	if len(root.Timeline) != 2 || root.Timeline[0].Log != "charging card" || root.Timeline[1].Name != "exception" {
+		t.Errorf("unexpected root timeline %+v", root.Timeline)
+	}
+	child := body.Spans[1]
+	if child.Timeline == nil || len(child.Timeline) != 0 || child.TimelineTruncated == nil {
+		t.Errorf("expected an empty timeline on the child span: %s", rec.Body.String())
+	}
+	if body.SkewCorrections != nil {
+		t.Errorf("expected no skew corrections when not requested")
+	}
+}
+
+func TestQueryTraces_SpanFilters(t *testing.T) {
+	var queries []string
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query struct {
+				SQL string `json:"sql"`
+			} `json:"query"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		queries = append(queries, body.Query.SQL)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"took":1,"hits":[]}`))
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "admin", "pass", testLogger())
+	handler := NewTracingHandler(client, testLogger())
+	request := gen.QueryTracesRequestObject{
+		Body: &gen.TracesQueryRequest{
+			StartTime:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			EndTime:     time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+			SearchScope: gen.ComponentSearchScope{Namespace: "test-ns"},
+		},
+	}
+
+	ctx := context.WithValue(context.Background(), queryExtensionsKey, queryExtensions{
+		AttributeFilters:  []string{"http.status_code >= 500"},
+		OperationContains: "checkout",
+		MinDurationNs:     1000000,
+		ErrorsOnly:        true,
+	})
+	resp, err := handler.QueryTraces(ctx, request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resp.(tracesListResponse); !ok {
+		t.Fatalf("expected 200 response, got %T", resp)
+	}
+	if len(queries) != 2 {
+		t.Fatalf("expected list and count queries, got %v", queries)
+	}
+	for _, want := range []string{
+		"http_status_code >= 500",
+		"str_match_ignore_case(operation_name, 'checkout')",
+		"end_time - start_time >= 1000000",
+		"span_status = 'ERROR'",
+	} {
+		if !strings.Contains(queries[0], want) || !strings.Contains(queries[1], want) {
+			t.Errorf("expected %q in queries: %v", want, queries)
+		}
+	}
+
+	ctx = context.WithValue(context.Background(), queryExtensionsKey, queryExtensions{AttributeFilters: []string{"db.system > postgres"}})
+	resp, err = handler.QueryTraces(ctx, request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resp.(gen.QueryTraces400JSONResponse); !ok {
+		t.Errorf("expected 400 for an invalid attribute filter, got %T", resp)
+	}
+}
+
+func TestQueryTraces_Pagination(t *testing.T) {
+	var pageQuery string
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(string(body), "count(distinct trace_id)"):
+			w.Write([]byte(`{"took":1,"hits":[{"total":10}]}`))
+		case strings.Contains(string(body), "GROUP BY trace_id"):
+			pageQuery = string(body)
+			w.Write([]byte(`{"took":1,"hits":[{"trace_id":"t1","span_count":1,"root_span_id":"s1"},{"trace_id":"t2","span_count":1,"root_span_id":"s2"}]}`))
+		default:
+			w.Write([]byte(`{"took":1,"hits":[{"trace_id":"t1","span_id":"s1"},{"trace_id":"t2","span_id":"s2"}]}`))
+		}
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "admin", "pass", testLogger())
+	handler := NewTracingHandler(client, testLogger())
+	request := gen.QueryTracesRequestObject{
+		Body: &gen.TracesQueryRequest{
+			StartTime:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			EndTime:     time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+			SearchScope: gen.ComponentSearchScope{Namespace: "test-ns"},
+			Limit:       ptr(2),
+		},
+	}
+
+	ctx := context.WithValue(context.Background(), queryExtensionsKey, queryExtensions{SortBy: "duration"})
+	resp, err := handler.QueryTraces(ctx, request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	page, ok := resp.(tracesListResponse)
+	if !ok {
+		t.Fatalf("expected 200 response, got %T", resp)
+	}
+	if !strings.Contains(pageQuery, "ORDER BY duration_ns DESC") || !strings.Contains(pageQuery, `"from":0`) {
+		t.Errorf("unexpected first page query: %s", pageQuery)
+	}
+	if page.NextPageToken == "" {
+		t.Fatal("expected a next page token")
+	}
+
+	ctx = context.WithValue(context.Background(), queryExtensionsKey, queryExtensions{SortBy: "duration", PageToken: page.NextPageToken})
+	if _, err := handler.QueryTraces(ctx, request); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(pageQuery, `"from":2`) {
+		t.Errorf("expected the second page to start at offset 2: %s", pageQuery)
+	}
+
+	for name, ext := range map[string]queryExtensions{
+		"invalid sort":  {SortBy: "name"},
+		"invalid token": {PageToken: "not-a-token"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resp, err := handler.QueryTraces(context.WithValue(context.Background(), queryExtensionsKey, ext), request)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if _, ok := resp.(gen.QueryTraces400JSONResponse); !ok {
+				t.Errorf("expected 400, got %T", resp)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/api/gen"
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/openobserve"
+)
+
+// GetLatencyThreshold implements GET /api/v1alpha1/traces/latency/threshold.
+// It returns, per time bucket, the number of spans of an operation and the
+// share of them exceeding a duration threshold, so that SLA widgets need
+// not compute it from raw spans.
+//
+// Query parameters: those of ListServices, plus operation and threshold (a
+// duration such as 500ms), both required, and optionally service and
+// interval (a duration splitting the window into 60 buckets by default).
+func (h *TracingHandler) GetLatencyThreshold(w http.ResponseWriter, r *http.Request) {
+	scope, ok := parseScopeQuery(w, r)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	params := openobserve.LatencyThresholdParams{
+		TracesQueryParams: scope,
+		Operation:         query.Get("operation"),
+		Service:           query.Get("service"),
+		Interval:          openobserve.DefaultThresholdInterval(scope),
+	}
+	if v := query.Get("threshold"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "threshold must be a duration")
+			return
+		}
+		params.Threshold = d
+	}
+	if v := query.Get("interval"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, gen.BadRequest, "interval must be a duration")
+			return
+		}
+		params.Interval = d
+	}
+	if err := openobserve.ValidateLatencyThreshold(params); err != nil {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, err.Error())
+		return
+	}
+
+	result, err := h.client.GetLatencyThreshold(r.Context(), params)
+	if err != nil {
+		h.logger.Error("Failed to query latency threshold", slog.Any("error", err))
+		writeJSONError(w, http.StatusInternalServerError, gen.InternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
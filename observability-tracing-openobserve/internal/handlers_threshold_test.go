@@ -0,0 +1,78 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/openobserve"
+)
+
+func TestGetLatencyThreshold(t *testing.T) {
+	var gotSQL string
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query struct {
+				SQL string `json:"sql"`
+			} `json:"query"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		gotSQL = body.Query.SQL
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"took":2,"hits":[{"bucket":1735689600000000,"span_count":8,"over_count":2}]}`))
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "admin", "pass", testLogger())
+	handler := NewTracingHandler(client, testLogger())
+	window := "startTime=2025-01-01T00:00:00Z&endTime=2025-01-01T01:00:00Z"
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet,
+			"/api/v1alpha1/traces/latency/threshold?namespace=test-ns&operation=GET%20%2Fcart&threshold=250ms&"+window, nil)
+		rec := httptest.NewRecorder()
+		handler.GetLatencyThreshold(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if !strings.Contains(gotSQL, "> 250000000 THEN") || !strings.Contains(gotSQL, "% 60000000 AS bucket") {
+			t.Errorf("unexpected SQL: %s", gotSQL)
+		}
+
+		var resp openobserve.LatencyThresholdResult
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(resp.Buckets) != 60 || resp.OverRatio != 0.25 || resp.Buckets[0].OverCount != 2 {
+			t.Errorf("unexpected response: %+v", resp)
+		}
+	})
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"missing namespace", "operation=op&threshold=1s"},
+		{"missing operation", "namespace=ns&threshold=1s"},
+		{"missing threshold", "namespace=ns&operation=op"},
+		{"invalid threshold", "namespace=ns&operation=op&threshold=slow"},
+		{"invalid interval", "namespace=ns&operation=op&threshold=1s&interval=often"},
+		{"too many buckets", "namespace=ns&operation=op&threshold=1s&interval=1s&" + window},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1alpha1/traces/latency/threshold?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			handler.GetLatencyThreshold(rec, req)
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected 400, got %d", rec.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/openchoreo/community-modules/common/localize"
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/api/gen"
+)
+
+// withLocalization decorates the successful JSON responses of requests
+// carrying the tz or humanize query parameters for display, and rejects
+// invalid parameters with 400.
+func withLocalization(next http.Handler) http.Handler {
+	return localize.Middleware(func(w http.ResponseWriter, r *http.Request, err error) {
+		writeJSONError(w, http.StatusBadRequest, gen.BadRequest, err.Error())
+	}, next)
+}
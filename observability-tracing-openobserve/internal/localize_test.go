@@ -0,0 +1,89 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithLocalization(t *testing.T) {
+	body := `{"spans":[{"startTime":"2026-03-01T12:00:00Z","spanName":"GET 2026-03-01T12:00:00Z","durationNs":1250000}],` +
+		`"tookMs":90000,"total":1}`
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	})
+	handler := withLocalization(next)
+
+	t.Run("timezone and humanize", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1alpha1/traces/services?tz=Europe/Berlin&humanize=true", nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", rec.Code)
+		}
+
+		var got struct {
+			Spans []struct {
+				StartTime       string      `json:"startTime"`
+				SpanName        string      `json:"spanName"`
+				DurationNs      json.Number `json:"durationNs"`
+				DurationDisplay string      `json:"durationDisplay"`
+			} `json:"spans"`
+			TookDisplay string      `json:"tookDisplay"`
+			Total       json.Number `json:"total"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		span := got.Spans[0]
+		if span.StartTime != "2026-03-01T13:00:00+01:00" {
+			t.Errorf("unexpected startTime: %s", span.StartTime)
+		}
+		if span.SpanName != "GET 2026-03-01T12:00:00Z" {
+			t.Errorf("expected non-timestamp fields to be unchanged, got %q", span.SpanName)
+		}
+		if span.DurationNs != "1250000" || span.DurationDisplay != "1.25ms" {
+			t.Errorf("unexpected duration fields: %s, %q", span.DurationNs, span.DurationDisplay)
+		}
+		if got.TookDisplay != "1m30s" {
+			t.Errorf("unexpected took display: %q", got.TookDisplay)
+		}
+		if got.Total != "1" {
+			t.Errorf("expected total to be unchanged, got %s", got.Total)
+		}
+	})
+
+	t.Run("no parameters", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1alpha1/traces/services", nil))
+		if rec.Body.String() != body {
+			t.Errorf("expected response to be unchanged, got %s", rec.Body.String())
+		}
+	})
+
+	t.Run("non-JSON response", func(t *testing.T) {
+		csv := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/csv")
+			_, _ = w.Write([]byte("spanId,durationNs\n"))
+		})
+		rec := httptest.NewRecorder()
+		withLocalization(csv).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?tz=UTC", nil))
+		if rec.Body.String() != "spanId,durationNs\n" {
+			t.Errorf("expected non-JSON response to be unchanged, got %q", rec.Body.String())
+		}
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		for _, query := range []string{"tz=Mars/Olympus", "humanize=maybe"} {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1alpha1/traces/services?"+query, nil))
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected 400 for %s, got %d", query, rec.Code)
+			}
+		}
+	})
+}
@@ -0,0 +1,412 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Span metrics a trace alert can be defined on.
+const (
+	// AlertMetricLatency is a latency percentile of the spans, in milliseconds.
+	AlertMetricLatency = "latency"
+	// AlertMetricErrorRate is the percentage of spans with an error status.
+	AlertMetricErrorRate = "errorRate"
+	// AlertMetricErrorCount is the number of spans with an error status.
+	AlertMetricErrorCount = "errorCount"
+)
+
+// DefaultAlertPercentile is the latency percentile used when none is given.
+const DefaultAlertPercentile = 99
+
+// DefaultAlertDestination is the OpenObserve alert destination created by the
+// OpenChoreo setup, which forwards alerts to the observer.
+const DefaultAlertDestination = "openchoreo"
+
+// TraceAlertParams holds parameters for creating or updating a trace alert.
+type TraceAlertParams struct {
+	Name           string
+	Namespace      string
+	ProjectUID     string
+	EnvironmentUID string
+	ComponentUID   string
+	// Metric is AlertMetricLatency, AlertMetricErrorRate or
+	// AlertMetricErrorCount.
+	Metric string
+	// Percentile is the latency percentile, e.g. 99 for p99. Latency only.
+	Percentile float64
+	// Operation restricts the alert to spans with this operation name.
+	Operation string
+	// Operator is one of gt, gte, lt, lte, eq, neq.
+	Operator string
+	// Threshold is in milliseconds for latency, in percent for error rate
+	// and in spans for error count.
+	Threshold float64
+	Window    string
+	Interval  string
+	Enabled   bool
+	// Destinations are the OpenObserve alert destinations to notify.
+	// DefaultAlertDestination is used when empty.
+	Destinations []string
+}
+
+// TraceAlertDetail represents the parsed details of a trace alert.
+type TraceAlertDetail struct {
+	Name           string
+	Enabled        bool
+	Namespace      string
+	ProjectUID     string
+	EnvironmentUID string
+	ComponentUID   string
+	Metric         string
+	Percentile     float64
+	Operation      string
+	Operator       string
+	Threshold      float64
+	Window         string
+	Interval       string
+}
+
+// ValidateTraceAlert returns an error if params do not describe a valid alert.
+func ValidateTraceAlert(params TraceAlertParams) error {
+	if params.Name == "" {
+		return fmt.Errorf("alert name is required")
+	}
+	if params.Namespace == "" || params.EnvironmentUID == "" || params.ComponentUID == "" {
+		return fmt.Errorf("namespace, environmentUid and componentUid are required")
+	}
+	switch params.Metric {
+	case AlertMetricLatency:
+		if params.Percentile <= 0 || params.Percentile >= 100 {
+			return fmt.Errorf("invalid percentile %v: must be between 0 and 100", params.Percentile)
+		}
+	case AlertMetricErrorRate, AlertMetricErrorCount:
+	default:
+		return fmt.Errorf("unsupported metric %q: must be one of %s, %s, %s",
+			params.Metric, AlertMetricLatency, AlertMetricErrorRate, AlertMetricErrorCount)
+	}
+	if _, err := mapOperator(params.Operator); err != nil {
+		return err
+	}
+	if _, err := parseDurationMinutes(params.Window); err != nil {
+		return fmt.Errorf("invalid window: %w", err)
+	}
+	if _, err := parseDurationMinutes(params.Interval); err != nil {
+		return fmt.Errorf("invalid interval: %w", err)
+	}
+	return nil
+}
+
+// mapOperator maps the API operator to the SQL comparison operator.
+func mapOperator(op string) (string, error) {
+	switch op {
+	case "gt":
+		return ">", nil
+	case "gte":
+		return ">=", nil
+	case "lt":
+		return "<", nil
+	case "lte":
+		return "<=", nil
+	case "eq":
+		return "=", nil
+	case "neq":
+		return "!=", nil
+	default:
+		return "", fmt.Errorf("unsupported operator %q: must be one of gt, gte, lt, lte, eq, neq", op)
+	}
+}
+
+// parseDurationMinutes parses a duration string like "5m" or "2h" and returns the value in minutes.
+func parseDurationMinutes(duration string) (int, error) {
+	if len(duration) < 2 {
+		return 0, fmt.Errorf("invalid duration string: %q", duration)
+	}
+	value, err := strconv.Atoi(duration[:len(duration)-1])
+	if err != nil || value <= 0 {
+		return 0, fmt.Errorf("invalid duration value in %q", duration)
+	}
+	switch duration[len(duration)-1] {
+	case 'm':
+		return value, nil
+	case 'h':
+		return value * 60, nil
+	default:
+		return 0, fmt.Errorf("unsupported duration unit in %q", duration)
+	}
+}
+
+// generateTraceAlertSQL builds the alert query. The inner query aggregates
+// the metric over the spans of the component in the alert period; the outer
+// query returns that single row only when it crosses the threshold, so the
+// alert triggers on one or more rows. Span times are in nanoseconds.
+func generateTraceAlertSQL(params TraceAlertParams, stream string) (string, error) {
+	safeStream, err := validateSQLIdentifier(stream)
+	if err != nil {
+		return "", fmt.Errorf("invalid stream identifier: %w", err)
+	}
+	sqlOperator, err := mapOperator(params.Operator)
+	if err != nil {
+		return "", err
+	}
+
+	var value string
+	switch params.Metric {
+	case AlertMetricLatency:
+		value = fmt.Sprintf("approx_percentile_cont(end_time - start_time, %s) / 1000000.0",
+			strconv.FormatFloat(params.Percentile/100, 'f', -1, 64))
+	case AlertMetricErrorRate:
+		value = "100.0 * SUM(CASE WHEN span_status = 'ERROR' THEN 1 ELSE 0 END) / NULLIF(COUNT(*), 0)"
+	case AlertMetricErrorCount:
+		value = "SUM(CASE WHEN span_status = 'ERROR' THEN 1 ELSE 0 END)"
+	default:
+		return "", fmt.Errorf("unsupported metric %q", params.Metric)
+	}
+
+	conditions := buildFilterConditions(TracesQueryParams{Scope: Scope{
+		Namespace:     params.Namespace,
+		ProjectID:     params.ProjectUID,
+		EnvironmentID: params.EnvironmentUID,
+		ComponentID:   params.ComponentUID,
+	}})
+	if params.Operation != "" {
+		conditions = append(conditions, "operation_name = '"+escapeSQLString(params.Operation)+"'")
+	}
+
+	return fmt.Sprintf("SELECT value FROM (SELECT %s AS value FROM %s WHERE %s) WHERE value %s %s",
+		value, safeStream, strings.Join(conditions, " AND "),
+		sqlOperator, strconv.FormatFloat(params.Threshold, 'f', -1, 64)), nil
+}
+
+// generateTraceAlertConfig generates an OpenObserve scheduled alert
+// configuration on the traces stream as JSON. The rule definition is kept in
+// the context attributes so that it can be read back by GetAlert.
+func generateTraceAlertConfig(params TraceAlertParams, stream string, logger *slog.Logger) ([]byte, error) {
+	if err := ValidateTraceAlert(params); err != nil {
+		return nil, err
+	}
+	sql, err := generateTraceAlertSQL(params, stream)
+	if err != nil {
+		return nil, err
+	}
+	period, _ := parseDurationMinutes(params.Window)
+	frequency, _ := parseDurationMinutes(params.Interval)
+
+	destinations := params.Destinations
+	if len(destinations) == 0 {
+		destinations = []string{DefaultAlertDestination}
+	}
+	contextAttributes := map[string]interface{}{
+		"namespace":      params.Namespace,
+		"projectUid":     params.ProjectUID,
+		"environmentUid": params.EnvironmentUID,
+		"componentUid":   params.ComponentUID,
+		"metric":         params.Metric,
+		"operator":       params.Operator,
+		"threshold":      strconv.FormatFloat(params.Threshold, 'f', -1, 64),
+		"window":         params.Window,
+		"interval":       params.Interval,
+	}
+	if params.Metric == AlertMetricLatency {
+		contextAttributes["percentile"] = strconv.FormatFloat(params.Percentile, 'f', -1, 64)
+	}
+	if params.Operation != "" {
+		contextAttributes["operation"] = params.Operation
+	}
+
+	alertConfig := map[string]interface{}{
+		"name":         params.Name,
+		"stream_name":  stream,
+		"stream_type":  "traces",
+		"enabled":      params.Enabled,
+		"is_real_time": false,
+		"query_condition": map[string]interface{}{
+			"type":       "sql",
+			"sql":        sql,
+			"conditions": nil,
+		},
+		"trigger_condition": map[string]interface{}{
+			"period":    period,
+			"frequency": frequency,
+			"threshold": 1,
+			"operator":  ">=",
+			"silence":   0,
+		},
+		"destinations":       destinations,
+		"context_attributes": contextAttributes,
+	}
+
+	logGenerated(logger, alertConfig, "trace alert config for %s", params.Name)
+
+	return json.Marshal(alertConfig)
+}
+
+// CreateAlert creates a trace alert in OpenObserve and returns the backend alert ID.
+func (c *Client) CreateAlert(ctx context.Context, params TraceAlertParams) (string, error) {
+	alertJSON, err := generateTraceAlertConfig(params, c.stream, c.logger)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate alert config: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/api/v2/%s/alerts", c.BaseURL(), c.Org())
+	body, err := c.doAlertRequest(ctx, http.MethodPost, url, alertJSON)
+	if err != nil {
+		return "", err
+	}
+
+	var createResp struct {
+		AlertID string `json:"id"`
+	}
+	if err := json.Unmarshal(body, &createResp); err == nil && createResp.AlertID != "" {
+		return createResp.AlertID, nil
+	}
+	return "", fmt.Errorf("openobserve create alert response missing id")
+}
+
+// UpdateAlert replaces the trace alert with the given name and returns its backend ID.
+func (c *Client) UpdateAlert(ctx context.Context, alertName string, params TraceAlertParams) (string, error) {
+	// The name in the path is canonical; validate before looking up the alert.
+	params.Name = alertName
+	alertJSON, err := generateTraceAlertConfig(params, c.stream, c.logger)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate alert config: %w", err)
+	}
+
+	alertID, err := c.getAlertIDByName(ctx, alertName)
+	if err != nil {
+		return "", fmt.Errorf("failed to find alert %q: %w", alertName, err)
+	}
+
+	url := fmt.Sprintf("%s/api/v2/%s/alerts/%s", c.BaseURL(), c.Org(), alertID)
+	if _, err := c.doAlertRequest(ctx, http.MethodPut, url, alertJSON); err != nil {
+		return "", err
+	}
+	return alertID, nil
+}
+
+// DeleteAlert deletes the trace alert with the given name and returns its backend ID.
+func (c *Client) DeleteAlert(ctx context.Context, alertName string) (string, error) {
+	alertID, err := c.getAlertIDByName(ctx, alertName)
+	if err != nil {
+		return "", fmt.Errorf("failed to find alert %q: %w", alertName, err)
+	}
+
+	url := fmt.Sprintf("%s/api/v2/%s/alerts/%s", c.BaseURL(), c.Org(), alertID)
+	if _, err := c.doAlertRequest(ctx, http.MethodDelete, url, nil); err != nil {
+		return "", err
+	}
+	return alertID, nil
+}
+
+// GetAlert retrieves the trace alert with the given name.
+func (c *Client) GetAlert(ctx context.Context, alertName string) (*TraceAlertDetail, error) {
+	alertID, err := c.getAlertIDByName(ctx, alertName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find alert %q: %w", alertName, err)
+	}
+
+	url := fmt.Sprintf("%s/api/v2/%s/alerts/%s", c.BaseURL(), c.Org(), alertID)
+	body, err := c.doAlertRequest(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw struct {
+		Name              string            `json:"name"`
+		Enabled           bool              `json:"enabled"`
+		ContextAttributes map[string]string `json:"context_attributes"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	ca := raw.ContextAttributes
+	detail := &TraceAlertDetail{
+		Name:           raw.Name,
+		Enabled:        raw.Enabled,
+		Namespace:      ca["namespace"],
+		ProjectUID:     ca["projectUid"],
+		EnvironmentUID: ca["environmentUid"],
+		ComponentUID:   ca["componentUid"],
+		Metric:         ca["metric"],
+		Operation:      ca["operation"],
+		Operator:       ca["operator"],
+		Window:         ca["window"],
+		Interval:       ca["interval"],
+	}
+	if detail.Metric == "" {
+		return nil, fmt.Errorf("alert %q is not a trace alert", alertName)
+	}
+	detail.Threshold, _ = strconv.ParseFloat(ca["threshold"], 64)
+	detail.Percentile, _ = strconv.ParseFloat(ca["percentile"], 64)
+	return detail, nil
+}
+
+// getAlertIDByName looks up an alert's ID by its name using the v2 list alerts API.
+func (c *Client) getAlertIDByName(ctx context.Context, name string) (string, error) {
+	url := fmt.Sprintf("%s/api/v2/%s/alerts", c.BaseURL(), c.Org())
+	body, err := c.doAlertRequest(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var result struct {
+		List []struct {
+			AlertID string `json:"alert_id"`
+			Name    string `json:"name"`
+		} `json:"list"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	for _, alert := range result.List {
+		if alert.Name == name {
+			return alert.AlertID, nil
+		}
+	}
+	return "", fmt.Errorf("alert %q not found", name)
+}
+
+// doAlertRequest sends a request to the OpenObserve alerts API and returns
+// the response body of a successful (2xx) response.
+func (c *Client) doAlertRequest(ctx context.Context, method, url string, payload []byte) ([]byte, error) {
+	var reqBody io.Reader
+	if payload != nil {
+		reqBody = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		c.logger.Error("Failed to execute alert request", slog.String("method", method), slog.Any("error", err))
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		c.logger.Error("OpenObserve returned error",
+			slog.Int("statusCode", resp.StatusCode),
+			slog.String("body", string(body)))
+		return nil, fmt.Errorf("openobserve returned status %d: %s", resp.StatusCode, string(body))
+	}
+	return body, nil
+}
@@ -0,0 +1,197 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func testTraceAlertParams() TraceAlertParams {
+	return TraceAlertParams{
+		Name:           "checkout-p99",
+		Namespace:      "test-ns",
+		EnvironmentUID: "env-1",
+		ComponentUID:   "comp-1",
+		Metric:         AlertMetricLatency,
+		Percentile:     99,
+		Operator:       "gt",
+		Threshold:      2000,
+		Window:         "10m",
+		Interval:       "1m",
+		Enabled:        true,
+	}
+}
+
+func TestGenerateTraceAlertSQL(t *testing.T) {
+	t.Run("latency percentile", func(t *testing.T) {
+		params := testTraceAlertParams()
+		params.Operation = "GET /cart"
+		sql, err := generateTraceAlertSQL(params, "default")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := "SELECT value FROM (SELECT approx_percentile_cont(end_time - start_time, 0.99) / 1000000.0 AS value FROM default" +
+			" WHERE service_openchoreo_dev_namespace = 'test-ns' AND service_openchoreo_dev_environment_uid = 'env-1'" +
+			" AND service_openchoreo_dev_component_uid = 'comp-1' AND operation_name = 'GET /cart') WHERE value > 2000"
+		if sql != want {
+			t.Errorf("unexpected SQL:\n got %s\nwant %s", sql, want)
+		}
+	})
+
+	t.Run("error rate", func(t *testing.T) {
+		params := testTraceAlertParams()
+		params.Metric = AlertMetricErrorRate
+		params.Operator = "gte"
+		params.Threshold = 5
+		sql, err := generateTraceAlertSQL(params, "default")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(sql, "100.0 * SUM(CASE WHEN span_status = 'ERROR' THEN 1 ELSE 0 END) / NULLIF(COUNT(*), 0) AS value") {
+			t.Errorf("expected error rate aggregation: %s", sql)
+		}
+		if !strings.HasSuffix(sql, "WHERE value >= 5") {
+			t.Errorf("expected threshold predicate: %s", sql)
+		}
+	})
+
+	t.Run("error count", func(t *testing.T) {
+		params := testTraceAlertParams()
+		params.Metric = AlertMetricErrorCount
+		params.Threshold = 10
+		sql, err := generateTraceAlertSQL(params, "default")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(sql, "SELECT SUM(CASE WHEN span_status = 'ERROR' THEN 1 ELSE 0 END) AS value FROM default") {
+			t.Errorf("expected error count aggregation: %s", sql)
+		}
+		if !strings.HasSuffix(sql, "WHERE value > 10") {
+			t.Errorf("expected threshold predicate: %s", sql)
+		}
+	})
+
+	t.Run("invalid stream", func(t *testing.T) {
+		if _, err := generateTraceAlertSQL(testTraceAlertParams(), "bad stream"); err == nil {
+			t.Error("expected error for invalid stream")
+		}
+	})
+}
+
+func TestValidateTraceAlert(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*TraceAlertParams)
+	}{
+		{"missing name", func(p *TraceAlertParams) { p.Name = "" }},
+		{"missing component", func(p *TraceAlertParams) { p.ComponentUID = "" }},
+		{"unknown metric", func(p *TraceAlertParams) { p.Metric = "throughput" }},
+		{"percentile out of range", func(p *TraceAlertParams) { p.Percentile = 100 }},
+		{"invalid operator", func(p *TraceAlertParams) { p.Operator = "above" }},
+		{"invalid window", func(p *TraceAlertParams) { p.Window = "10s" }},
+		{"invalid interval", func(p *TraceAlertParams) { p.Interval = "" }},
+	}
+	if err := ValidateTraceAlert(testTraceAlertParams()); err != nil {
+		t.Fatalf("unexpected error for valid params: %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := testTraceAlertParams()
+			tt.modify(&params)
+			if err := ValidateTraceAlert(params); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestGenerateTraceAlertConfig(t *testing.T) {
+	result, err := generateTraceAlertConfig(testTraceAlertParams(), "default", testLogger())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var config struct {
+		StreamType       string   `json:"stream_type"`
+		Destinations     []string `json:"destinations"`
+		TriggerCondition struct {
+			Period    int     `json:"period"`
+			Frequency int     `json:"frequency"`
+			Operator  string  `json:"operator"`
+			Threshold float64 `json:"threshold"`
+		} `json:"trigger_condition"`
+		ContextAttributes map[string]string `json:"context_attributes"`
+	}
+	if err := json.Unmarshal(result, &config); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if config.StreamType != "traces" {
+		t.Errorf("expected traces stream type, got %q", config.StreamType)
+	}
+	if strings.Join(config.Destinations, ",") != DefaultAlertDestination {
+		t.Errorf("unexpected destinations %v", config.Destinations)
+	}
+	tc := config.TriggerCondition
+	if tc.Period != 10 || tc.Frequency != 1 || tc.Operator != ">=" || tc.Threshold != 1 {
+		t.Errorf("unexpected trigger condition %+v", tc)
+	}
+	if config.ContextAttributes["percentile"] != "99" || config.ContextAttributes["threshold"] != "2000" {
+		t.Errorf("unexpected context attributes %v", config.ContextAttributes)
+	}
+}
+
+func TestTraceAlertLifecycle(t *testing.T) {
+	var created []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v2/default/alerts":
+			created, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"alert-1"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v2/default/alerts":
+			_, _ = w.Write([]byte(`{"list":[{"alert_id":"alert-1","name":"checkout-p99"}]}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v2/default/alerts/alert-1":
+			_, _ = w.Write(created)
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v2/default/alerts/alert-1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	ctx := context.Background()
+
+	id, err := client.CreateAlert(ctx, testTraceAlertParams())
+	if err != nil || id != "alert-1" {
+		t.Fatalf("CreateAlert() = %q, %v", id, err)
+	}
+
+	detail, err := client.GetAlert(ctx, "checkout-p99")
+	if err != nil {
+		t.Fatalf("GetAlert() error = %v", err)
+	}
+	want := TraceAlertDetail{
+		Name: "checkout-p99", Enabled: true, Namespace: "test-ns", EnvironmentUID: "env-1", ComponentUID: "comp-1",
+		Metric: AlertMetricLatency, Percentile: 99, Operator: "gt", Threshold: 2000, Window: "10m", Interval: "1m",
+	}
+	if *detail != want {
+		t.Errorf("GetAlert() = %+v, want %+v", *detail, want)
+	}
+
+	if _, err := client.GetAlert(ctx, "missing"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("expected not found error, got %v", err)
+	}
+	if id, err := client.DeleteAlert(ctx, "checkout-p99"); err != nil || id != "alert-1" {
+		t.Errorf("DeleteAlert() = %q, %v", id, err)
+	}
+}
@@ -0,0 +1,308 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"time"
+)
+
+const (
+	// MaxPinnedSpans bounds the spans copied to the archive when a trace is pinned.
+	MaxPinnedSpans = 10000
+	// archiveBatchSize is the number of spans read and ingested per request.
+	archiveBatchSize = MaxQueryLimit
+	// archiveStreamType is the type of the archive stream: spans are copied
+	// with the JSON ingestion API, which writes logs streams.
+	archiveStreamType = "logs"
+)
+
+// ErrArchiveDisabled is returned when a trace is pinned while no archive
+// stream is set.
+var ErrArchiveDisabled = errors.New("trace archive is not configured")
+
+// ErrTraceNotFound is returned when a trace to pin has no spans.
+var ErrTraceNotFound = errors.New("trace not found")
+
+// PinnedTrace is a trace whose spans were copied to the archive stream.
+type PinnedTrace struct {
+	TraceID   string    `json:"traceId"`
+	SpanCount int       `json:"spanCount"`
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+	PinnedAt  time.Time `json:"pinnedAt"`
+	Reason    string    `json:"reason,omitempty"`
+}
+
+// PinResult is the outcome of pinning a trace.
+type PinResult struct {
+	PinnedTrace
+	// AlreadyPinned is set when the trace was in the archive already, in
+	// which case nothing was copied.
+	AlreadyPinned bool `json:"alreadyPinned"`
+}
+
+// SetArchiveStream sets the long-retention stream pinned traces are copied
+// to. Traces cannot be pinned while it is unset. Span queries that find
+// nothing in the traces stream fall back to it, so pinned traces outlive
+// the retention of the traces stream.
+func (c *Client) SetArchiveStream(stream string) {
+	c.archiveStream = stream
+}
+
+// PinTrace copies the spans of traceID, with all their fields, to the
+// archive stream. The traces stream is searched between start and end, or
+// over all time when they are zero. Pinning a trace that is already in the
+// archive copies nothing.
+func (c *Client) PinTrace(ctx context.Context, traceID, reason string, start, end time.Time) (*PinResult, error) {
+	if c.archiveStream == "" {
+		return nil, ErrArchiveDisabled
+	}
+	if pinned, err := c.getPinnedTrace(ctx, traceID); err != nil {
+		return nil, err
+	} else if pinned != nil {
+		return &PinResult{PinnedTrace: *pinned, AlreadyPinned: true}, nil
+	}
+
+	pinnedAt := time.Now().UTC()
+	result := &PinResult{PinnedTrace: PinnedTrace{TraceID: traceID, PinnedAt: pinnedAt, Reason: reason}}
+	for offset := 0; offset < MaxPinnedSpans; offset += archiveBatchSize {
+		queryJSON, err := generateTraceSpansCopyQuery(traceID, c.stream, start, end, offset)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.executeSearchQuery(ctx, queryJSON)
+		if errors.Is(err, ErrStreamNotFound) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read spans: %w", err)
+		}
+		for _, hit := range resp.Hits {
+			hit["pinned_at"] = pinnedAt.UnixMicro()
+			hit["pin_reason"] = reason
+			startTime, endTime := spanTimes(hit)
+			if result.StartTime.IsZero() || startTime.Before(result.StartTime) {
+				result.StartTime = startTime
+			}
+			if endTime.After(result.EndTime) {
+				result.EndTime = endTime
+			}
+		}
+		if len(resp.Hits) > 0 {
+			if err := c.ingestArchive(ctx, resp.Hits); err != nil {
+				return nil, err
+			}
+		}
+		result.SpanCount += len(resp.Hits)
+		if len(resp.Hits) < archiveBatchSize {
+			break
+		}
+	}
+	if result.SpanCount == 0 {
+		return nil, fmt.Errorf("%w: traceId=%s", ErrTraceNotFound, traceID)
+	}
+	if result.SpanCount >= MaxPinnedSpans {
+		c.logger.Warn("Pinned trace truncated",
+			slog.String("traceId", traceID),
+			slog.Int("spans", result.SpanCount))
+	}
+	return result, nil
+}
+
+// ListPinnedTraces returns the traces in the archive stream, most recently
+// pinned first.
+func (c *Client) ListPinnedTraces(ctx context.Context) ([]PinnedTrace, error) {
+	if c.archiveStream == "" {
+		return nil, ErrArchiveDisabled
+	}
+	return c.queryPinnedTraces(ctx, "")
+}
+
+// getPinnedTrace returns the pinned trace traceID, or nil when it is not in
+// the archive stream.
+func (c *Client) getPinnedTrace(ctx context.Context, traceID string) (*PinnedTrace, error) {
+	pins, err := c.queryPinnedTraces(ctx, traceID)
+	if err != nil || len(pins) == 0 {
+		return nil, err
+	}
+	return &pins[0], nil
+}
+
+// queryPinnedTraces returns the traces in the archive stream, or only
+// traceID when it is set.
+func (c *Client) queryPinnedTraces(ctx context.Context, traceID string) ([]PinnedTrace, error) {
+	queryJSON, err := generatePinnedTracesQuery(traceID, c.archiveStream)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.executeSearch(ctx, archiveStreamType, queryJSON)
+	if errors.Is(err, ErrStreamNotFound) {
+		return []PinnedTrace{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the trace archive: %w", err)
+	}
+
+	pins := make([]PinnedTrace, 0, len(resp.Hits))
+	for _, hit := range resp.Hits {
+		pin := PinnedTrace{}
+		pin.TraceID, _ = hit["trace_id"].(string)
+		pin.Reason, _ = hit["pin_reason"].(string)
+		pin.StartTime, pin.EndTime = spanTimes(hit)
+		if v, ok := hit["span_count"].(json.Number); ok {
+			n, _ := v.Int64()
+			pin.SpanCount = int(n)
+		}
+		if v, ok := hit["pinned_at"].(json.Number); ok {
+			us, _ := v.Int64()
+			pin.PinnedAt = time.UnixMicro(us).UTC()
+		}
+		pins = append(pins, pin)
+	}
+	return pins, nil
+}
+
+// spanTimes returns the start_time and end_time of a hit, in nanoseconds
+// since the epoch.
+func spanTimes(hit map[string]interface{}) (time.Time, time.Time) {
+	var start, end time.Time
+	if v, ok := hit["start_time"].(json.Number); ok {
+		ns, _ := v.Int64()
+		start = time.Unix(0, ns).UTC()
+	}
+	if v, ok := hit["end_time"].(json.Number); ok {
+		ns, _ := v.Int64()
+		end = time.Unix(0, ns).UTC()
+	}
+	return start, end
+}
+
+// ingestArchive writes records to the archive stream.
+func (c *Client) ingestArchive(ctx context.Context, records []map[string]interface{}) error {
+	payload, err := json.Marshal(records)
+	if err != nil {
+		return fmt.Errorf("failed to encode spans: %w", err)
+	}
+	url := fmt.Sprintf("%s/api/%s/%s/_json", c.BaseURL(), c.Org(), c.archiveStream)
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		c.logger.Error("OpenObserve returned error",
+			slog.Int("statusCode", resp.StatusCode),
+			slog.String("body", string(body)))
+		return fmt.Errorf("openobserve returned status %d: response body omitted", resp.StatusCode)
+	}
+
+	var result struct {
+		Status []struct {
+			Failed int    `json:"failed"`
+			Error  string `json:"error"`
+		} `json:"status"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return fmt.Errorf("failed to unmarshal ingestion response: %w", err)
+	}
+	for _, s := range result.Status {
+		if s.Failed > 0 {
+			return fmt.Errorf("failed to archive %d spans: %s", s.Failed, s.Error)
+		}
+	}
+	return nil
+}
+
+// searchWithArchive runs the query generate builds for the traces stream
+// and, when it finds nothing and an archive stream is set, for the archive
+// stream. It returns the response with the stream and stream type searched
+// last, so that follow-up queries search the same stream.
+func (c *Client) searchWithArchive(ctx context.Context, generate func(stream string) ([]byte, error)) (*OpenObserveResponse, string, string, error) {
+	queryJSON, err := generate(c.stream)
+	if err != nil {
+		return nil, "", "", err
+	}
+	resp, err := c.executeSearchQuery(ctx, queryJSON)
+	if c.archiveStream == "" || (err != nil && !errors.Is(err, ErrStreamNotFound)) || (err == nil && len(resp.Hits) > 0) {
+		return resp, c.stream, "traces", err
+	}
+
+	archiveJSON, err := generate(c.archiveStream)
+	if err != nil {
+		return nil, "", "", err
+	}
+	resp, err = c.executeSearch(ctx, archiveStreamType, archiveJSON)
+	return resp, c.archiveStream, archiveStreamType, err
+}
+
+// generateTraceSpansCopyQuery generates the query for a batch of the spans
+// of a trace, with all their fields, between start and end or over all time.
+func generateTraceSpansCopyQuery(traceID, stream string, start, end time.Time, offset int) ([]byte, error) {
+	safeStream, err := validateSQLIdentifier(stream)
+	if err != nil {
+		return nil, fmt.Errorf("invalid stream identifier: %w", err)
+	}
+	startTime, endTime := int64(1), int64(math.MaxInt64/2)
+	if !start.IsZero() && !end.IsZero() {
+		startTime, endTime = start.UnixMicro(), end.UnixMicro()
+	}
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"sql":        fmt.Sprintf("SELECT * FROM %s WHERE trace_id = '%s' ORDER BY start_time ASC", safeStream, escapeSQLString(traceID)),
+			"start_time": startTime,
+			"end_time":   endTime,
+			"from":       offset,
+			"size":       archiveBatchSize,
+		},
+		"timeout": 0,
+	}
+	return json.Marshal(query)
+}
+
+// generatePinnedTracesQuery generates the query for the traces of the
+// archive stream, or only traceID when it is set.
+func generatePinnedTracesQuery(traceID, stream string) ([]byte, error) {
+	safeStream, err := validateSQLIdentifier(stream)
+	if err != nil {
+		return nil, fmt.Errorf("invalid stream identifier: %w", err)
+	}
+	where := ""
+	if traceID != "" {
+		where = " WHERE trace_id = '" + escapeSQLString(traceID) + "'"
+	}
+	sql := "SELECT trace_id, count(*) AS span_count, min(start_time) AS start_time, max(end_time) AS end_time, " +
+		"max(pinned_at) AS pinned_at, max(pin_reason) AS pin_reason FROM " + safeStream + where +
+		" GROUP BY trace_id ORDER BY pinned_at DESC"
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"sql":        sql,
+			"start_time": 1,
+			"end_time":   int64(math.MaxInt64 / 2),
+			"from":       0,
+			"size":       MaxQueryLimit,
+		},
+		"timeout": 0,
+	}
+	return json.Marshal(query)
+}
@@ -0,0 +1,144 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// archiveServer is an OpenObserve mock with a traces stream "default" and
+// a logs stream "pinned" written through the JSON ingestion API.
+type archiveServer struct {
+	mu       sync.Mutex
+	spans    []map[string]interface{}
+	archived []map[string]interface{}
+	searches []string
+}
+
+func (s *archiveServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if strings.HasSuffix(r.URL.Path, "/pinned/_json") {
+		var records []map[string]interface{}
+		decoder := json.NewDecoder(r.Body)
+		decoder.UseNumber()
+		_ = decoder.Decode(&records)
+		s.archived = append(s.archived, records...)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"code":   200,
+			"status": []map[string]interface{}{{"name": "pinned", "successful": len(records), "failed": 0}},
+		})
+		return
+	}
+
+	var body struct {
+		Query struct {
+			SQL string `json:"sql"`
+		} `json:"query"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&body)
+	sql := body.Query.SQL
+	s.searches = append(s.searches, r.URL.Query().Get("type")+": "+sql)
+
+	resp := OpenObserveResponse{Hits: []map[string]interface{}{}}
+	switch {
+	case strings.Contains(sql, "FROM pinned") && strings.Contains(sql, "GROUP BY trace_id"):
+		if len(s.archived) > 0 && (!strings.Contains(sql, "WHERE") || strings.Contains(sql, "'t-1'")) {
+			first := s.archived[0]
+			resp.Hits = append(resp.Hits, map[string]interface{}{
+				"trace_id":   first["trace_id"],
+				"span_count": len(s.archived),
+				"start_time": first["start_time"],
+				"end_time":   first["end_time"],
+				"pinned_at":  first["pinned_at"],
+				"pin_reason": first["pin_reason"],
+			})
+		}
+	case strings.Contains(sql, "FROM pinned") && strings.Contains(sql, "count("):
+		resp.Hits = append(resp.Hits, map[string]interface{}{"total": len(s.archived)})
+	case strings.Contains(sql, "FROM pinned"):
+		resp.Hits = s.archived
+	case strings.Contains(sql, "count("):
+		resp.Hits = append(resp.Hits, map[string]interface{}{"total": len(s.spans)})
+	default:
+		resp.Hits = s.spans
+	}
+	json.NewEncoder(w).Encode(resp)
+}
+
+func TestPinTrace(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	oo := &archiveServer{spans: []map[string]interface{}{
+		{"_timestamp": start.UnixMicro(), "trace_id": "t-1", "span_id": "s-1", "operation_name": "GET /",
+			"start_time": start.UnixNano(), "end_time": start.Add(time.Second).UnixNano()},
+	}}
+	server := httptest.NewServer(oo)
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	if _, err := client.PinTrace(context.Background(), "t-1", "", time.Time{}, time.Time{}); !errors.Is(err, ErrArchiveDisabled) {
+		t.Fatalf("expected ErrArchiveDisabled, got %v", err)
+	}
+	client.SetArchiveStream("pinned")
+
+	result, err := client.PinTrace(context.Background(), "t-1", "INC-42", time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("PinTrace() error = %v", err)
+	}
+	if result.AlreadyPinned || result.SpanCount != 1 || !result.StartTime.Equal(start) || result.Reason != "INC-42" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if len(oo.archived) != 1 || oo.archived[0]["span_id"] != "s-1" || oo.archived[0]["pin_reason"] != "INC-42" ||
+		oo.archived[0]["_timestamp"] != json.Number(strconv.FormatInt(start.UnixMicro(), 10)) {
+		t.Errorf("unexpected archived spans: %v", oo.archived)
+	}
+
+	result, err = client.PinTrace(context.Background(), "t-1", "", time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("PinTrace() error = %v", err)
+	}
+	if !result.AlreadyPinned || result.SpanCount != 1 || len(oo.archived) != 1 {
+		t.Errorf("expected the trace to be pinned once, got %+v with %d archived spans", result, len(oo.archived))
+	}
+
+	pins, err := client.ListPinnedTraces(context.Background())
+	if err != nil {
+		t.Fatalf("ListPinnedTraces() error = %v", err)
+	}
+	if len(pins) != 1 || pins[0].TraceID != "t-1" || pins[0].PinnedAt.IsZero() || pins[0].Reason != "INC-42" {
+		t.Errorf("unexpected pins: %+v", pins)
+	}
+
+	// Once the traces stream no longer holds the trace, spans are read
+	// from the archive.
+	oo.spans = nil
+	spans, err := client.GetSpans(context.Background(), TracesQueryParams{TraceID: "t-1", StartTime: start, EndTime: start.Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("GetSpans() error = %v", err)
+	}
+	if len(spans.Spans) != 1 || spans.Spans[0].SpanID != "s-1" || spans.Total != 1 {
+		t.Errorf("expected the archived span, got %+v", spans)
+	}
+	detail, err := client.GetSpanDetail(context.Background(), TracesQueryParams{TraceID: "t-1", SpanID: "s-1"})
+	if err != nil || detail.Span.SpanID != "s-1" {
+		t.Errorf("GetSpanDetail() = %+v, %v", detail, err)
+	}
+	last := oo.searches[len(oo.searches)-1]
+	if !strings.HasPrefix(last, "logs: ") {
+		t.Errorf("expected the archive to be searched as a logs stream, got %q", last)
+	}
+
+	if _, err := client.PinTrace(context.Background(), "t-2", "", time.Time{}, time.Time{}); !errors.Is(err, ErrTraceNotFound) {
+		t.Errorf("expected ErrTraceNotFound, got %v", err)
+	}
+}
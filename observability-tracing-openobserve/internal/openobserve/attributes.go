@@ -0,0 +1,74 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AttributeClass is where a span field is placed in span details.
+type AttributeClass string
+
+const (
+	// SpanAttribute places a field in the attributes of a span.
+	SpanAttribute AttributeClass = "span"
+	// ResourceAttribute places a field in the resource attributes of a span.
+	ResourceAttribute AttributeClass = "resource"
+	// DroppedAttribute leaves a field out of span details.
+	DroppedAttribute AttributeClass = "drop"
+)
+
+// AttributeRules classify the fields of a span that are not mapped to
+// SpanDetail fields into span and resource attributes.
+type AttributeRules struct {
+	// Fields maps field names to their class. It takes precedence over the
+	// other rules.
+	Fields map[string]AttributeClass
+	// Dropped are fields left out of span details, typically noisy columns
+	// internal to the backend.
+	Dropped []string
+	// ResourcePrefixes are the prefixes of the fields that are resource
+	// attributes. All other fields are span attributes.
+	ResourcePrefixes []string
+}
+
+// DefaultAttributeRules treat the fields starting with service or resource
+// as resource attributes.
+var DefaultAttributeRules = AttributeRules{
+	ResourcePrefixes: []string{"service", "resource"},
+}
+
+// classify returns the class of the field key.
+func (r *AttributeRules) classify(key string) AttributeClass {
+	if class, ok := r.Fields[key]; ok {
+		return class
+	}
+	for _, f := range r.Dropped {
+		if f == key {
+			return DroppedAttribute
+		}
+	}
+	for _, prefix := range r.ResourcePrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return ResourceAttribute
+		}
+	}
+	return SpanAttribute
+}
+
+// ParseAttributeClass parses the name of an attribute class.
+func ParseAttributeClass(name string) (AttributeClass, error) {
+	switch class := AttributeClass(name); class {
+	case SpanAttribute, ResourceAttribute, DroppedAttribute:
+		return class, nil
+	}
+	return "", fmt.Errorf("unknown attribute class %q: must be %s, %s or %s", name, SpanAttribute, ResourceAttribute, DroppedAttribute)
+}
+
+// SetAttributeRules sets the rules classifying the attributes of span
+// details. DefaultAttributeRules apply until it is called.
+func (c *Client) SetAttributeRules(rules AttributeRules) {
+	c.attributeRules = rules
+}
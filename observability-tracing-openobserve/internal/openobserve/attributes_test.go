@@ -0,0 +1,83 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAttributeRulesClassify(t *testing.T) {
+	rules := AttributeRules{
+		Fields: map[string]AttributeClass{
+			"service_flag": SpanAttribute,
+			"k8s_pod_name": ResourceAttribute,
+			"_o2_id":       SpanAttribute,
+		},
+		Dropped:          []string{"_o2_id", "events"},
+		ResourcePrefixes: []string{"service"},
+	}
+	for key, want := range map[string]AttributeClass{
+		"service_name":  ResourceAttribute,
+		"service_flag":  SpanAttribute,
+		"k8s_pod_name":  ResourceAttribute,
+		"events":        DroppedAttribute,
+		"_o2_id":        SpanAttribute,
+		"http_method":   SpanAttribute,
+		"resource_host": SpanAttribute,
+	} {
+		if got := rules.classify(key); got != want {
+			t.Errorf("classify(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestParseAttributeClass(t *testing.T) {
+	for _, name := range []string{"span", "resource", "drop"} {
+		if _, err := ParseAttributeClass(name); err != nil {
+			t.Errorf("ParseAttributeClass(%q): unexpected error: %v", name, err)
+		}
+	}
+	if _, err := ParseAttributeClass("metric"); err == nil {
+		t.Error("expected error for an unknown class")
+	}
+}
+
+func TestGetSpanDetail_AttributeRules(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(OpenObserveResponse{
+			Took: 1,
+			Hits: []map[string]interface{}{{
+				"span_id":      "span-1",
+				"service_name": "cart",
+				"k8s_pod_name": "cart-1",
+				"http_method":  "GET",
+				"_o2_id":       "7",
+			}},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	client.SetAttributeRules(AttributeRules{
+		Fields:           map[string]AttributeClass{"k8s_pod_name": ResourceAttribute},
+		Dropped:          []string{"_o2_id"},
+		ResourcePrefixes: []string{"service"},
+	})
+	result, err := client.GetSpanDetail(context.Background(), TracesQueryParams{TraceID: "trace-1", SpanID: "span-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	span := result.Span
+	if len(span.ResourceAttributes) != 2 || span.ResourceAttributes["k8s_pod_name"] != "cart-1" || span.ResourceAttributes["service_name"] != "cart" {
+		t.Errorf("unexpected resource attributes: %v", span.ResourceAttributes)
+	}
+	if len(span.Attributes) != 1 || span.Attributes["http_method"] != "GET" {
+		t.Errorf("unexpected attributes: %v", span.Attributes)
+	}
+}
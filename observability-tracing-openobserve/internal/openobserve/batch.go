@@ -0,0 +1,203 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+	"time"
+)
+
+// MaxBatchTraceIDs bounds the number of traces looked up at once.
+const MaxBatchTraceIDs = 100
+
+// TraceBatchParams holds the parameters of a lookup of traces by ID. The
+// scope restricts the spans summarized, and the time range, when set,
+// bounds the search; all time is searched otherwise.
+type TraceBatchParams struct {
+	TracesQueryParams
+	TraceIDs []string
+}
+
+// TraceBatchResult holds the summaries of the traces found, in the order of
+// their IDs, and the IDs of the traces that were not found.
+type TraceBatchResult struct {
+	Traces          []TraceEntry `json:"traces"`
+	MissingTraceIDs []string     `json:"missingTraceIds"`
+	TookMs          int          `json:"tookMs"`
+}
+
+// ValidateTraceBatch returns an error if there are no trace IDs, too many of
+// them, or blank ones.
+func ValidateTraceBatch(params TraceBatchParams) error {
+	if len(params.TraceIDs) == 0 {
+		return errors.New("traceIds is required")
+	}
+	if len(params.TraceIDs) > MaxBatchTraceIDs {
+		return fmt.Errorf("at most %d traceIds may be requested", MaxBatchTraceIDs)
+	}
+	for _, id := range params.TraceIDs {
+		if strings.TrimSpace(id) == "" {
+			return errors.New("traceIds must not be blank")
+		}
+	}
+	return nil
+}
+
+// generateTraceBatchQuery generates the OpenObserve query summarizing the
+// traces traceIDs, one row per trace. The root span of a trace is its span
+// without a parent.
+func generateTraceBatchQuery(params TracesQueryParams, traceIDs []string, stream string, logger *slog.Logger) ([]byte, error) {
+	safeStream, err := validateSQLIdentifier(stream)
+	if err != nil {
+		return nil, fmt.Errorf("invalid stream identifier: %w", err)
+	}
+
+	quoted := make([]string, len(traceIDs))
+	for i, id := range traceIDs {
+		quoted[i] = "'" + escapeSQLString(id) + "'"
+	}
+	conditions := append(buildFilterConditions(params), "trace_id IN ("+strings.Join(quoted, ", ")+")")
+	sql := traceSummarySQL(safeStream, conditions)
+
+	startTime, endTime := int64(1), int64(math.MaxInt64/2)
+	if !params.StartTime.IsZero() && !params.EndTime.IsZero() {
+		startTime, endTime = params.StartTime.UnixMicro(), params.EndTime.UnixMicro()
+	}
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"sql":        sql,
+			"start_time": startTime,
+			"end_time":   endTime,
+			"from":       0,
+			"size":       len(traceIDs),
+		},
+	}
+
+	logGenerated(logger, query, "trace batch query")
+
+	return json.Marshal(query)
+}
+
+// GetTracesByID summarizes the traces params.TraceIDs with one query. Traces
+// not found in the traces stream are looked up in the archive stream when
+// one is set. Summaries are built from aggregates, so a trace is reported
+// incomplete only when it has no root span; gaps in the span tree are not
+// detected.
+func (c *Client) GetTracesByID(ctx context.Context, params TraceBatchParams) (*TraceBatchResult, error) {
+	var traceIDs []string
+	seen := make(map[string]bool, len(params.TraceIDs))
+	for _, id := range params.TraceIDs {
+		if !seen[id] {
+			seen[id] = true
+			traceIDs = append(traceIDs, id)
+		}
+	}
+
+	found := make(map[string]TraceEntry, len(traceIDs))
+	result := &TraceBatchResult{}
+	search := func(stream, streamType string, ids []string) error {
+		queryJSON, err := generateTraceBatchQuery(params.TracesQueryParams, ids, stream, c.logger)
+		if err != nil {
+			return fmt.Errorf("failed to generate trace batch query: %w", err)
+		}
+		resp, err := c.executeSearch(ctx, streamType, queryJSON)
+		if errors.Is(err, ErrStreamNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		result.TookMs += resp.Took
+		for _, hit := range resp.Hits {
+			if entry := parseTraceSummary(hit); entry.TraceID != "" {
+				found[entry.TraceID] = entry
+			}
+		}
+		return nil
+	}
+
+	if err := search(c.stream, "traces", traceIDs); err != nil {
+		return nil, err
+	}
+	if c.archiveStream != "" && len(found) < len(traceIDs) {
+		var missing []string
+		for _, id := range traceIDs {
+			if _, ok := found[id]; !ok {
+				missing = append(missing, id)
+			}
+		}
+		if err := search(c.archiveStream, archiveStreamType, missing); err != nil {
+			return nil, err
+		}
+	}
+
+	result.Traces = make([]TraceEntry, 0, len(found))
+	result.MissingTraceIDs = []string{}
+	for _, id := range traceIDs {
+		if entry, ok := found[id]; ok {
+			result.Traces = append(result.Traces, entry)
+		} else {
+			result.MissingTraceIDs = append(result.MissingTraceIDs, id)
+		}
+	}
+	return result, nil
+}
+
+// traceSummarySQL returns the SQL summarizing the traces of the spans of
+// stream matching conditions, one row per trace. The root span of a trace is
+// its span without a parent.
+func traceSummarySQL(stream string, conditions []string) string {
+	rootField := func(field string) string {
+		return "max(CASE WHEN " + rootSpanCondition + " THEN " + field + " END)"
+	}
+	return "SELECT trace_id, count(*) AS span_count, " +
+		"min(start_time) AS start_time, max(end_time) AS end_time, " +
+		"max(end_time) - min(start_time) AS duration_ns, " +
+		"sum(CASE WHEN span_status = 'ERROR' THEN 1 ELSE 0 END) AS error_count, " +
+		rootField("span_id") + " AS root_span_id, " +
+		rootField("operation_name") + " AS root_span_name, " +
+		rootField("span_kind") + " AS root_span_kind " +
+		"FROM " + stream + " WHERE " + strings.Join(conditions, " AND ") + " GROUP BY trace_id"
+}
+
+// parseTraceSummary converts a row of the trace summary SQL into a trace
+// entry.
+func parseTraceSummary(hit map[string]interface{}) TraceEntry {
+	var entry TraceEntry
+	entry.TraceID, _ = hit["trace_id"].(string)
+	entry.RootSpanID, _ = hit["root_span_id"].(string)
+	entry.RootSpanName, _ = hit["root_span_name"].(string)
+	entry.RootSpanKind, _ = hit["root_span_kind"].(string)
+	entry.TraceName = entry.RootSpanName
+
+	var start, end int64
+	if v, ok := hit["span_count"].(json.Number); ok {
+		n, _ := v.Int64()
+		entry.SpanCount = int(n)
+	}
+	if v, ok := hit["start_time"].(json.Number); ok {
+		start, _ = v.Int64()
+	}
+	if v, ok := hit["end_time"].(json.Number); ok {
+		end, _ = v.Int64()
+	}
+	if v, ok := hit["error_count"].(json.Number); ok {
+		n, _ := v.Int64()
+		entry.HasErrors = n > 0
+	}
+	entry.StartTime = time.Unix(0, start)
+	entry.EndTime = time.Unix(0, end)
+	entry.DurationNs = end - start
+	entry.Complete = entry.RootSpanID != ""
+	if !entry.Complete {
+		entry.IncompleteReason = IncompleteReasonMissingRoot
+	}
+	return entry
+}
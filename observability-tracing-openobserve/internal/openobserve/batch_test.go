@@ -0,0 +1,131 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerateTraceBatchQuery(t *testing.T) {
+	params := TracesQueryParams{Scope: Scope{Namespace: "test-ns"}}
+	result, err := generateTraceBatchQuery(params, []string{"t1", "it's"}, "default", testLogger())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var query struct {
+		Query struct {
+			SQL       string `json:"sql"`
+			StartTime int64  `json:"start_time"`
+			Size      int    `json:"size"`
+		} `json:"query"`
+	}
+	if err := json.Unmarshal(result, &query); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	sql := query.Query.SQL
+	for _, want := range []string{
+		"service_openchoreo_dev_namespace = 'test-ns'",
+		"trace_id IN ('t1', 'it''s')",
+		"max(CASE WHEN " + rootSpanCondition + " THEN operation_name END) AS root_span_name",
+		"GROUP BY trace_id",
+	} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("expected %q in SQL: %s", want, sql)
+		}
+	}
+	if query.Query.Size != 2 || query.Query.StartTime != 1 {
+		t.Errorf("expected size 2 over all time, got %+v", query.Query)
+	}
+
+	params.StartTime = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	params.EndTime = params.StartTime.Add(time.Hour)
+	result, _ = generateTraceBatchQuery(params, []string{"t1"}, "default", testLogger())
+	_ = json.Unmarshal(result, &query)
+	if query.Query.StartTime != params.StartTime.UnixMicro() {
+		t.Errorf("expected the time range to bound the search, got %d", query.Query.StartTime)
+	}
+
+	if _, err := generateTraceBatchQuery(params, []string{"t1"}, "bad stream", testLogger()); err == nil {
+		t.Error("expected error for invalid stream")
+	}
+}
+
+func TestValidateTraceBatch(t *testing.T) {
+	if err := ValidateTraceBatch(TraceBatchParams{TraceIDs: []string{"t1"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for name, ids := range map[string][]string{
+		"no ids":   nil,
+		"blank id": {"t1", " "},
+		"too many": make([]string, MaxBatchTraceIDs+1),
+	} {
+		if err := ValidateTraceBatch(TraceBatchParams{TraceIDs: ids}); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestGetTracesByID(t *testing.T) {
+	var searches []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query struct {
+				SQL string `json:"sql"`
+			} `json:"query"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		searches = append(searches, r.URL.Query().Get("type")+": "+body.Query.SQL)
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("type") == "logs" {
+			w.Write([]byte(`{"took":2,"hits":[{"trace_id":"pinned","span_count":1,"start_time":1000,"end_time":3000,"error_count":0,"root_span_id":"r2","root_span_name":"job","root_span_kind":"internal"}]}`))
+			return
+		}
+		w.Write([]byte(`{"took":3,"hits":[` +
+			`{"trace_id":"t2","span_count":4,"start_time":1000,"end_time":5000,"error_count":1,"root_span_id":"r1","root_span_name":"GET /cart","root_span_kind":"server"},` +
+			`{"trace_id":"t1","span_count":2,"start_time":2000,"end_time":2500,"error_count":0}]}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	client.SetArchiveStream("trace_archive")
+	result, err := client.GetTracesByID(context.Background(), TraceBatchParams{
+		TraceIDs: []string{"t1", "t2", "pinned", "t1", "gone"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var ids []string
+	for _, trace := range result.Traces {
+		ids = append(ids, trace.TraceID)
+	}
+	if !reflect.DeepEqual(ids, []string{"t1", "t2", "pinned"}) {
+		t.Errorf("expected traces in request order, got %v", ids)
+	}
+	if !reflect.DeepEqual(result.MissingTraceIDs, []string{"gone"}) {
+		t.Errorf("unexpected missing traces: %v", result.MissingTraceIDs)
+	}
+	t2 := result.Traces[1]
+	if t2.SpanCount != 4 || !t2.HasErrors || t2.DurationNs != 4000 || t2.TraceName != "GET /cart" || !t2.Complete {
+		t.Errorf("unexpected summary: %+v", t2)
+	}
+	if t1 := result.Traces[0]; t1.Complete || t1.IncompleteReason != IncompleteReasonMissingRoot {
+		t.Errorf("expected a trace without root span to be incomplete: %+v", t1)
+	}
+	if result.TookMs != 5 {
+		t.Errorf("expected tookMs 5, got %d", result.TookMs)
+	}
+	if len(searches) != 2 || !strings.Contains(searches[0], "('t1', 't2', 'pinned', 'gone')") ||
+		!strings.HasPrefix(searches[1], "logs: ") || !strings.Contains(searches[1], "trace_id IN ('pinned', 'gone')") {
+		t.Errorf("unexpected searches: %v", searches)
+	}
+}
@@ -4,43 +4,25 @@
 package openobserve
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"log/slog"
-	"net/http"
 	"strings"
 	"time"
-)
 
-// openObserveStreamNotFoundCode is the OpenObserve error code returned when
-// a search target stream has not yet been created (lazy stream creation).
-// Surfaced as HTTP 400 with body {"code":20002,"message":"Search stream not found: ..."}.
-const openObserveStreamNotFoundCode = 20002
+	ooclient "github.com/openchoreo/community-modules/common/openobserve"
+	"github.com/openchoreo/community-modules/common/rollups"
+)
 
 // ErrStreamNotFound is returned by executeSearchQuery when OpenObserve reports
 // the search stream does not yet exist. Callers should treat this as an empty
 // result set, not a retrieval failure.
-var ErrStreamNotFound = errors.New("openobserve stream not found")
+var ErrStreamNotFound = ooclient.ErrStreamNotFound
 
-// isStreamNotFound parses an OpenObserve error envelope and returns true when
-// code == openObserveStreamNotFoundCode.
-func isStreamNotFound(body []byte) bool {
-	if len(body) == 0 {
-		return false
-	}
-
-	var envelope struct {
-		Code int `json:"code"`
-	}
-	if err := json.Unmarshal(body, &envelope); err != nil {
-		return false
-	}
-	return envelope.Code == openObserveStreamNotFoundCode
-}
+// ErrSpanNotFound is returned when no span matches a span lookup.
+var ErrSpanNotFound = errors.New("span not found")
 
 // Scope holds the filtering scope for trace queries.
 type Scope struct {
@@ -59,8 +41,28 @@ type TracesQueryParams struct {
 	Scope     Scope     `json:"scope"`
 	TraceID   string    `json:"-"`
 	SpanID    string    `json:"-"`
+	// IncludeEvents fetches the span events of listed spans.
+	IncludeEvents bool `json:"-"`
+	// Filters narrow traces queries to the traces with a matching span.
+	Filters SpanFilters `json:"-"`
+	// SortBy is the order of traces queries, TraceSortStartTime by default.
+	SortBy string `json:"-"`
+	// Offset is the number of traces skipped by traces queries, to list the
+	// pages after the first one.
+	Offset int `json:"-"`
 }
 
+// Orders of traces queries.
+const (
+	TraceSortStartTime = "startTime"
+	TraceSortDuration  = "duration"
+)
+
+// MaxTraceTreeSpans bounds the spans listed to check the span trees of a
+// page of traces. Traces whose spans are not all listed are only checked for
+// a root span.
+const MaxTraceTreeSpans = 10 * MaxQueryLimit
+
 // TraceEntry represents a trace in the traces list response
 type TraceEntry struct {
 	TraceID      string    `json:"traceId"`
@@ -73,13 +75,48 @@ type TraceEntry struct {
 	EndTime      time.Time `json:"endTime"`
 	DurationNs   int64     `json:"durationNs"`
 	HasErrors    bool      `json:"hasErrors"`
+	// Complete is false when spans of the trace are missing from the result,
+	// e.g. because the trace was sampled or is still in flight.
+	Complete bool `json:"complete"`
+	// IncompleteReason explains why Complete is false.
+	IncompleteReason string `json:"incompleteReason,omitempty"`
+	// TreeTruncated is set when the trace had more spans than could be
+	// listed to check its span tree for gaps. Complete then only reflects
+	// whether its root span was found.
+	TreeTruncated bool `json:"treeTruncated,omitempty"`
 }
 
+// Reasons reported on TraceEntry.IncompleteReason.
+const (
+	// IncompleteReasonMissingRoot means no span without a parent was found for the trace.
+	IncompleteReasonMissingRoot = "missingRootSpan"
+	// IncompleteReasonMissingParent means some spans reference a parent span that was not found.
+	IncompleteReasonMissingParent = "missingParentSpans"
+)
+
 // TracesResult represents the response when listing traces
 type TracesResult struct {
 	Traces []TraceEntry `json:"traces"`
 	Total  int          `json:"total"`
 	TookMs int          `json:"tookMs"`
+	// HasMore is set when traces follow the page.
+	HasMore bool `json:"hasMore"`
+}
+
+// ServiceEntry represents a service emitting spans in the services list response
+type ServiceEntry struct {
+	ServiceName    string    `json:"serviceName"`
+	ComponentUID   string    `json:"componentUid,omitempty"`
+	ProjectUID     string    `json:"projectUid,omitempty"`
+	EnvironmentUID string    `json:"environmentUid,omitempty"`
+	SpanCount      int       `json:"spanCount"`
+	LastSeen       time.Time `json:"lastSeen"`
+}
+
+// ServicesResult represents the response when listing traced services
+type ServicesResult struct {
+	Services []ServiceEntry `json:"services"`
+	TookMs   int            `json:"tookMs"`
 }
 
 // SpanEntry represents a span in the spans list response
@@ -93,6 +130,8 @@ type SpanEntry struct {
 	ParentSpanID  string    `json:"parentSpanId"`
 	Status        string    `json:"status,omitempty"`
 	StatusMessage string    `json:"statusMessage,omitempty"`
+	// Events are set when the spans were queried with IncludeEvents.
+	Events []SpanEvent `json:"-"`
 }
 
 // SpansResult represents the response when listing spans for a trace
@@ -115,6 +154,8 @@ type SpanDetail struct {
 	StatusMessage      string                 `json:"statusMessage,omitempty"`
 	Attributes         map[string]interface{} `json:"attributes"`
 	ResourceAttributes map[string]interface{} `json:"resourceAttributes"`
+	Events             []SpanEvent            `json:"events"`
+	Links              []SpanLink             `json:"links"`
 }
 
 // SpanDetailResult represents the response when fetching a single span
@@ -122,89 +163,97 @@ type SpanDetailResult struct {
 	Span SpanDetail `json:"span"`
 }
 
-// OpenObserveResponse represents the raw response from OpenObserve search API
-type OpenObserveResponse struct {
-	Took  int                      `json:"took"`
-	Hits  []map[string]interface{} `json:"hits"`
-	Total int                      `json:"total"`
+// SpanLookupResult represents the response when finding a span by its ID alone
+type SpanLookupResult struct {
+	TraceID string     `json:"traceId"`
+	Span    SpanDetail `json:"span"`
 }
 
+// OpenObserveResponse represents the raw response from OpenObserve search API
+type OpenObserveResponse = ooclient.SearchResponse
+
+// Client queries the traces stream of an OpenObserve organization. The
+// embedded client authenticates and executes its requests.
 type Client struct {
-	baseURL    string
-	org        string
+	*ooclient.Client
 	stream     string
-	user       string
-	token      string
-	httpClient *http.Client
-	logger     *slog.Logger
+	logsStream string
+	// archiveStream is the long-retention stream of pinned traces.
+	archiveStream string
+	// routes route the traces queries of scopes to other streams.
+	routes ooclient.StreamRoutes
+	// rollupStream and rollupCoverage are the stream of the latency rollups
+	// and the time range they cover.
+	rollupStream   string
+	rollupCoverage *rollups.Coverage
+	// attributeRules classify the attributes of span details.
+	attributeRules AttributeRules
+	logger         *slog.Logger
 }
 
 func NewClient(baseURL, org, stream, user, token string, logger *slog.Logger) *Client {
+	client := ooclient.NewClient(baseURL, org, user, token, logger)
+	// Span timestamps are nanoseconds, which float64 cannot hold exactly.
+	client.SetJSONNumbers(true)
 	return &Client{
-		baseURL: strings.TrimSuffix(baseURL, "/"),
-		org:     org,
-		stream:  stream,
-		user:    user,
-		token:   token,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		logger: logger,
+		Client:         client,
+		stream:         stream,
+		attributeRules: DefaultAttributeRules,
+		logger:         logger,
 	}
 }
 
+// SetLogsStream sets the logs stream searched for log lines correlated with
+// spans. Correlated logs are not fetched while it is unset.
+func (c *Client) SetLogsStream(stream string) {
+	c.logsStream = stream
+}
+
 // executeSearchQuery executes a search query against OpenObserve and returns the parsed response
 func (c *Client) executeSearchQuery(ctx context.Context, queryJSON []byte) (*OpenObserveResponse, error) {
-	url := fmt.Sprintf("%s/api/%s/_search?type=traces", c.baseURL, c.org)
-
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(queryJSON))
-	if err != nil {
-		c.logger.Error("Failed to create request", slog.Any("error", err))
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.SetBasicAuth(c.user, c.token)
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		c.logger.Error("Failed to execute search request against OpenObserve", slog.Any("error", err))
-		return nil, fmt.Errorf("failed to execute request: %w", err)
-	}
-	defer resp.Body.Close()
+	return c.executeSearch(ctx, "traces", queryJSON)
+}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		c.logger.Error("Failed to read response body returned by OpenObserve", slog.Any("error", err))
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+// executeSearch executes a search query against streams of the given type.
+func (c *Client) executeSearch(ctx context.Context, streamType string, queryJSON []byte) (*OpenObserveResponse, error) {
+	c.debugSearch(ctx, streamType, queryJSON)
+	resp, err := c.Search(ctx, streamType, queryJSON)
+	var statusErr *ooclient.StatusError
+	if errors.As(err, &statusErr) {
+		// The body may echo the query; it is only logged.
+		return nil, fmt.Errorf("openobserve returned status %d: response body omitted", statusErr.StatusCode)
 	}
+	return resp, err
+}
 
-	if resp.StatusCode != http.StatusOK {
-		if isStreamNotFound(body) {
-			return nil, ErrStreamNotFound
-		}
-		c.logger.Error("OpenObserve returned error",
-			slog.Int("statusCode", resp.StatusCode),
-			slog.String("body", string(body)))
-		return nil, fmt.Errorf("openobserve returned status %d: response body omitted", resp.StatusCode)
+// intColumn returns the integer column of hit, and false when it is null,
+// missing or not an integer.
+func intColumn(hit map[string]interface{}, column string) (int64, bool) {
+	v, ok := hit[column].(json.Number)
+	if !ok {
+		return 0, false
 	}
+	n, err := v.Int64()
+	return n, err == nil
+}
 
-	var openObserveResp OpenObserveResponse
-	decoder := json.NewDecoder(bytes.NewReader(body))
-	decoder.UseNumber()
-	if err := decoder.Decode(&openObserveResp); err != nil {
-		c.logger.Error("Failed to unmarshal response from OpenObserve", slog.Any("error", err))
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+// GetTraces queries OpenObserve for a page of traces using the search API.
+// A first query summarizes the spans of the page's traces, grouped by
+// trace_id, so that the limit applies to traces rather than spans. A second
+// query lists the span and parent IDs of those traces only, to detect gaps
+// in their span trees, and a third counts all matching traces. Scopes routed
+// to several streams are queried in each of them.
+func (c *Client) GetTraces(ctx context.Context, params TracesQueryParams) (*TracesResult, error) {
+	streams := c.traceStreams(params)
+	if len(streams) > 1 {
+		return c.getTracesAcross(ctx, params, streams)
 	}
-
-	return &openObserveResp, nil
+	return c.getTraces(ctx, params, streams[0])
 }
 
-// GetTraces queries OpenObserve for a list of traces using the search API.
-// It fetches individual spans, groups them by trace_id, and identifies the root span
-// (the span with no parent) per trace to populate rootSpanId, rootSpanName, and rootSpanKind.
-func (c *Client) GetTraces(ctx context.Context, params TracesQueryParams) (*TracesResult, error) {
-	queryJSON, err := generateTracesListQuery(params, c.stream, c.logger)
+// getTraces queries stream for a page of traces.
+func (c *Client) getTraces(ctx context.Context, params TracesQueryParams, stream string) (*TracesResult, error) {
+	queryJSON, err := generateTracesListQuery(params, stream, c.logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate traces query: %w", err)
 	}
@@ -217,86 +266,36 @@ func (c *Client) GetTraces(ctx context.Context, params TracesQueryParams) (*Trac
 		return nil, err
 	}
 
-	// Group spans by trace_id and build trace entries
-	type traceAgg struct {
-		entry    TraceEntry
-		minStart int64
-		maxEnd   int64
-	}
-	traceMap := make(map[string]*traceAgg)
-	traceOrder := make([]string, 0)
-
+	traces := make([]TraceEntry, 0, len(openObserveResp.Hits))
+	traceIDs := make([]string, 0, len(openObserveResp.Hits))
+	spanCount := 0
 	for _, hit := range openObserveResp.Hits {
-		traceID, _ := hit["trace_id"].(string)
-		if traceID == "" {
+		entry := parseTraceSummary(hit)
+		if entry.TraceID == "" {
 			continue
 		}
-
-		agg, exists := traceMap[traceID]
-		if !exists {
-			agg = &traceAgg{}
-			agg.entry.TraceID = traceID
-			traceMap[traceID] = agg
-			traceOrder = append(traceOrder, traceID)
-		}
-
-		agg.entry.SpanCount++
-
-		var startTime, endTime int64
-		if v, ok := hit["start_time"].(json.Number); ok {
-			startTime, _ = v.Int64()
-			if agg.minStart == 0 || startTime < agg.minStart {
-				agg.minStart = startTime
-			}
-		}
-		if v, ok := hit["end_time"].(json.Number); ok {
-			endTime, _ = v.Int64()
-			if endTime > agg.maxEnd {
-				agg.maxEnd = endTime
-			}
-		}
-
-		// Identify root span: the span with no parent
-		parentSpanID, _ := hit["reference_parent_span_id"].(string)
-		if parentSpanID == "" {
-			if v, ok := hit["span_id"].(string); ok {
-				agg.entry.RootSpanID = v
-			}
-			if v, ok := hit["operation_name"].(string); ok {
-				agg.entry.RootSpanName = v
-			}
-			if v, ok := hit["span_kind"].(string); ok {
-				agg.entry.RootSpanKind = v
-			}
-		}
-
-		// Propagate error status: once any span errors, the trace has errors
-		spanStatus := determineSpanStatus(hit)
-		if spanStatus == "error" {
-			agg.entry.HasErrors = true
-		}
+		traces = append(traces, entry)
+		traceIDs = append(traceIDs, entry.TraceID)
+		spanCount += entry.SpanCount
 	}
 
-	traces := make([]TraceEntry, 0, len(traceOrder))
-	for _, traceID := range traceOrder {
-		agg := traceMap[traceID]
-		agg.entry.StartTime = time.Unix(0, agg.minStart)
-		agg.entry.EndTime = time.Unix(0, agg.maxEnd)
-		agg.entry.DurationNs = agg.maxEnd - agg.minStart
-		agg.entry.TraceName = agg.entry.RootSpanName
-		traces = append(traces, agg.entry)
+	if len(traceIDs) > 0 {
+		if err := c.checkTraceTrees(ctx, params, stream, traces, traceIDs, min(spanCount, MaxTraceTreeSpans)); err != nil {
+			return nil, err
+		}
 	}
 
 	// Execute a separate count query to get the true total number of matching traces
-	countQueryJSON, err := generateTracesCountQuery(params, c.stream, c.logger)
+	countQueryJSON, err := generateTracesCountQuery(params, stream, c.logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate traces count query: %w", err)
 	}
+	offset := max(params.Offset, 0)
 	countResp, err := c.executeSearchQuery(ctx, countQueryJSON)
 	if errors.Is(err, ErrStreamNotFound) {
 		return &TracesResult{
 			Traces: traces,
-			Total:  len(traces),
+			Total:  offset + len(traces),
 			TookMs: openObserveResp.Took,
 		}, nil
 	}
@@ -304,21 +303,85 @@ func (c *Client) GetTraces(ctx context.Context, params TracesQueryParams) (*Trac
 		return nil, fmt.Errorf("failed to execute traces count query: %w", err)
 	}
 
+	total := extractTotalCount(countResp)
 	return &TracesResult{
-		Traces: traces,
-		Total:  extractTotalCount(countResp),
-		TookMs: openObserveResp.Took,
+		Traces:  traces,
+		Total:   total,
+		TookMs:  openObserveResp.Took,
+		HasMore: len(traces) > 0 && offset+len(traces) < total,
 	}, nil
 }
 
-// GetSpans queries OpenObserve for a list of spans belonging to the given traceId.
-func (c *Client) GetSpans(ctx context.Context, params TracesQueryParams) (*SpansResult, error) {
-	queryJSON, err := generateSpansListQuery(params, c.stream, c.logger)
+// checkTraceTrees lists the spans of traces in stream, at most size of them,
+// and marks the traces whose span trees have gaps incomplete.
+func (c *Client) checkTraceTrees(ctx context.Context, params TracesQueryParams, stream string, traces []TraceEntry, traceIDs []string, size int) error {
+	queryJSON, err := generateTraceTreeQuery(params, traceIDs, size, stream, c.logger)
+	if err != nil {
+		return fmt.Errorf("failed to generate trace tree query: %w", err)
+	}
+	resp, err := c.executeSearchQuery(ctx, queryJSON)
+	if errors.Is(err, ErrStreamNotFound) {
+		return nil
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate spans query: %w", err)
+		return fmt.Errorf("failed to execute trace tree query: %w", err)
 	}
 
-	openObserveResp, err := c.executeSearchQuery(ctx, queryJSON)
+	spanIDs := make(map[string]map[string]bool, len(traceIDs))
+	parentIDs := make(map[string][]string, len(traceIDs))
+	for _, hit := range resp.Hits {
+		traceID, _ := hit["trace_id"].(string)
+		spanID, _ := hit["span_id"].(string)
+		if traceID == "" || spanID == "" {
+			continue
+		}
+		if spanIDs[traceID] == nil {
+			spanIDs[traceID] = make(map[string]bool)
+		}
+		spanIDs[traceID][spanID] = true
+		if parentID, _ := hit["reference_parent_span_id"].(string); parentID != "" {
+			parentIDs[traceID] = append(parentIDs[traceID], parentID)
+		}
+	}
+	for i := range traces {
+		entry := &traces[i]
+		// Traces cut short by the size bound keep the root span check of
+		// their summary: their missing spans are not gaps.
+		if len(spanIDs[entry.TraceID]) < entry.SpanCount {
+			entry.TreeTruncated = true
+			continue
+		}
+		entry.Complete, entry.IncompleteReason = traceCompleteness(entry.RootSpanID, spanIDs[entry.TraceID], parentIDs[entry.TraceID])
+	}
+	return nil
+}
+
+// traceCompleteness reports whether a trace assembled from the given spans is
+// complete. A trace is partial when it has no root span, or when a span
+// references a parent that is not part of the result (a gap in the tree).
+func traceCompleteness(rootSpanID string, spanIDs map[string]bool, parentIDs []string) (bool, string) {
+	if rootSpanID == "" {
+		return false, IncompleteReasonMissingRoot
+	}
+	for _, parentID := range parentIDs {
+		if !spanIDs[parentID] {
+			return false, IncompleteReasonMissingParent
+		}
+	}
+	return true, ""
+}
+
+// GetSpans queries OpenObserve for a list of spans belonging to the given traceId.
+// Pinned traces are read from the archive stream once the traces stream no
+// longer holds them.
+func (c *Client) GetSpans(ctx context.Context, params TracesQueryParams) (*SpansResult, error) {
+	openObserveResp, stream, streamType, err := c.searchWithArchive(ctx, func(stream string) ([]byte, error) {
+		queryJSON, err := generateSpansListQuery(params, stream, c.logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate spans query: %w", err)
+		}
+		return queryJSON, nil
+	})
 	if errors.Is(err, ErrStreamNotFound) {
 		return &SpansResult{Spans: []SpanEntry{}, Total: 0, TookMs: 0}, nil
 	}
@@ -333,11 +396,11 @@ func (c *Client) GetSpans(ctx context.Context, params TracesQueryParams) (*Spans
 	}
 
 	// Execute a separate count query to get the true total number of matching spans
-	countQueryJSON, err := generateSpansCountQuery(params, c.stream, c.logger)
+	countQueryJSON, err := generateSpansCountQuery(params, stream, c.logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate spans count query: %w", err)
 	}
-	countResp, err := c.executeSearchQuery(ctx, countQueryJSON)
+	countResp, err := c.executeSearch(ctx, streamType, countQueryJSON)
 	if errors.Is(err, ErrStreamNotFound) {
 		return &SpansResult{
 			Spans:  spans,
@@ -356,29 +419,177 @@ func (c *Client) GetSpans(ctx context.Context, params TracesQueryParams) (*Spans
 	}, nil
 }
 
-// GetSpanDetail queries OpenObserve for a single span identified by traceId and spanId.
+// SpanDetailHintPadding is how far GetSpanDetail widens the window of
+// params.StartTime..params.EndTime on each side when the span is not found
+// within it.
+const SpanDetailHintPadding = 24 * time.Hour
+
+// GetSpanDetail queries OpenObserve for a single span identified by traceId and spanId,
+// falling back to the archive stream for pinned traces. The search spans all
+// time unless params.StartTime and params.EndTime are set, in which case they
+// are a hint: when the span is not found within them, the search is retried
+// once over the window widened by SpanDetailHintPadding.
 func (c *Client) GetSpanDetail(ctx context.Context, params TracesQueryParams) (*SpanDetailResult, error) {
-	queryJSON, err := generateSpanDetailQuery(params, c.stream, c.logger)
+	hit, err := c.searchSpanDetail(ctx, params)
+	if hit == nil && err == nil && !params.StartTime.IsZero() && !params.EndTime.IsZero() {
+		c.logger.Debug("Span not found within the time hint, widening the search",
+			slog.String("traceId", params.TraceID), slog.String("spanId", params.SpanID))
+		params.StartTime = params.StartTime.Add(-SpanDetailHintPadding)
+		params.EndTime = params.EndTime.Add(SpanDetailHintPadding)
+		hit, err = c.searchSpanDetail(ctx, params)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate span detail query: %w", err)
+		return nil, err
+	}
+	if hit == nil {
+		return nil, fmt.Errorf("%w: traceId=%s, spanId=%s", ErrSpanNotFound, params.TraceID, params.SpanID)
 	}
 
-	openObserveResp, err := c.executeSearchQuery(ctx, queryJSON)
+	return &SpanDetailResult{
+		Span: parseSpanDetail(hit, &c.attributeRules),
+	}, nil
+}
+
+// searchSpanDetail returns the hit of the span of params, or nil when there
+// is none.
+func (c *Client) searchSpanDetail(ctx context.Context, params TracesQueryParams) (map[string]interface{}, error) {
+	openObserveResp, _, _, err := c.searchWithArchive(ctx, func(stream string) ([]byte, error) {
+		queryJSON, err := generateSpanDetailQuery(params, stream, c.logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate span detail query: %w", err)
+		}
+		return queryJSON, nil
+	})
+	if errors.Is(err, ErrStreamNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(openObserveResp.Hits) == 0 {
+		return nil, nil
+	}
+	return openObserveResp.Hits[0], nil
+}
+
+// FindSpan queries OpenObserve for a span by its ID alone and returns it with
+// the ID of the trace that owns it. The search spans all time unless
+// params.StartTime and params.EndTime are set. Spans of pinned traces are
+// also found in the archive stream.
+func (c *Client) FindSpan(ctx context.Context, params TracesQueryParams) (*SpanLookupResult, error) {
+	params.TraceID = ""
+	openObserveResp, _, _, err := c.searchWithArchive(ctx, func(stream string) ([]byte, error) {
+		queryJSON, err := generateSpanDetailQuery(params, stream, c.logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate span lookup query: %w", err)
+		}
+		return queryJSON, nil
+	})
 	if errors.Is(err, ErrStreamNotFound) {
-		return nil, fmt.Errorf("span not found: traceId=%s, spanId=%s", params.TraceID, params.SpanID)
+		return nil, fmt.Errorf("%w: spanId=%s", ErrSpanNotFound, params.SpanID)
 	}
 	if err != nil {
 		return nil, err
 	}
 
 	if len(openObserveResp.Hits) == 0 {
-		return nil, fmt.Errorf("span not found: traceId=%s, spanId=%s", params.TraceID, params.SpanID)
+		return nil, fmt.Errorf("%w: spanId=%s", ErrSpanNotFound, params.SpanID)
 	}
 
-	span := parseSpanDetail(openObserveResp.Hits[0])
+	hit := openObserveResp.Hits[0]
+	traceID, _ := hit["trace_id"].(string)
+	return &SpanLookupResult{
+		TraceID: traceID,
+		Span:    parseSpanDetail(hit, &c.attributeRules),
+	}, nil
+}
 
-	return &SpanDetailResult{
-		Span: span,
+// GetTraceLogs queries the logs stream for the log lines of params.TraceID,
+// oldest first and bounded by params.Limit. It returns no entries when no
+// logs stream is set or the stream does not exist yet.
+func (c *Client) GetTraceLogs(ctx context.Context, params TracesQueryParams) ([]TraceLogEntry, error) {
+	if c.logsStream == "" {
+		return nil, nil
+	}
+	queryJSON, err := generateTraceLogsQuery(params, c.logsStream, c.logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate trace logs query: %w", err)
+	}
+
+	openObserveResp, err := c.executeSearch(ctx, "logs", queryJSON)
+	if errors.Is(err, ErrStreamNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	logs := make([]TraceLogEntry, 0, len(openObserveResp.Hits))
+	for _, hit := range openObserveResp.Hits {
+		var entry TraceLogEntry
+		if v, ok := hit["span_id"].(string); ok {
+			entry.SpanID = v
+		}
+		if v, ok := hit["_timestamp"].(json.Number); ok {
+			us, _ := v.Int64()
+			entry.Timestamp = time.UnixMicro(us).UTC()
+		}
+		if v, ok := hit["log"].(string); ok {
+			entry.Log = v
+		}
+		if v, ok := hit["logLevel"].(string); ok {
+			entry.LogLevel = v
+		}
+		logs = append(logs, entry)
+	}
+	return logs, nil
+}
+
+// GetServices queries OpenObserve for the distinct services emitting spans in
+// the given scope and time range, with the number of spans each produced.
+func (c *Client) GetServices(ctx context.Context, params TracesQueryParams) (*ServicesResult, error) {
+	queryJSON, err := generateServicesListQuery(params, c.stream, c.logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate services query: %w", err)
+	}
+
+	openObserveResp, err := c.executeSearchQuery(ctx, queryJSON)
+	if errors.Is(err, ErrStreamNotFound) {
+		return &ServicesResult{Services: []ServiceEntry{}, TookMs: 0}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	services := make([]ServiceEntry, 0, len(openObserveResp.Hits))
+	for _, hit := range openObserveResp.Hits {
+		entry := ServiceEntry{}
+		if v, ok := hit["service_name"].(string); ok {
+			entry.ServiceName = v
+		}
+		if v, ok := hit["component_uid"].(string); ok {
+			entry.ComponentUID = v
+		}
+		if v, ok := hit["project_uid"].(string); ok {
+			entry.ProjectUID = v
+		}
+		if v, ok := hit["environment_uid"].(string); ok {
+			entry.EnvironmentUID = v
+		}
+		if v, ok := hit["span_count"].(json.Number); ok {
+			n, _ := v.Int64()
+			entry.SpanCount = int(n)
+		}
+		if v, ok := hit["last_seen"].(json.Number); ok {
+			n, _ := v.Int64()
+			entry.LastSeen = time.Unix(0, n)
+		}
+		services = append(services, entry)
+	}
+
+	return &ServicesResult{
+		Services: services,
+		TookMs:   openObserveResp.Took,
 	}, nil
 }
 
@@ -428,6 +639,7 @@ func parseSpanEntry(hit map[string]interface{}) SpanEntry {
 	}
 	entry.Status = determineSpanStatus(hit)
 	entry.StatusMessage = determineSpanStatusMessage(hit)
+	entry.Events = parseSpanEvents(hit["events"])
 
 	return entry
 }
@@ -474,10 +686,13 @@ var internalFields = []string{
 	"span_status",
 	"status_code",
 	"status_message",
+	"events",
+	"links",
 }
 
 // parseSpanDetail converts a raw OpenObserve hit into a SpanDetail with attributes
-func parseSpanDetail(hit map[string]interface{}) SpanDetail {
+// classified by rules.
+func parseSpanDetail(hit map[string]interface{}, rules *AttributeRules) SpanDetail {
 	detail := SpanDetail{}
 
 	if v, ok := hit["span_id"].(string); ok {
@@ -505,6 +720,8 @@ func parseSpanDetail(hit map[string]interface{}) SpanDetail {
 	}
 	detail.Status = determineSpanStatus(hit)
 	detail.StatusMessage = determineSpanStatusMessage(hit)
+	detail.Events = parseSpanEvents(hit["events"])
+	detail.Links = parseSpanLinks(hit["links"])
 
 	excludeFields := make(map[string]bool, len(internalFields))
 	for _, f := range internalFields {
@@ -517,9 +734,10 @@ func parseSpanDetail(hit map[string]interface{}) SpanDetail {
 		if excludeFields[key] {
 			continue
 		}
-		if strings.HasPrefix(key, "service") || strings.HasPrefix(key, "resource") {
+		switch rules.classify(key) {
+		case ResourceAttribute:
 			resourceAttributes[key] = value
-		} else {
+		case SpanAttribute:
 			attributes[key] = value
 		}
 	}
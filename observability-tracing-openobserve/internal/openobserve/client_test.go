@@ -30,21 +30,15 @@ func newTestClient(serverURL string) *Client {
 func TestNewClient(t *testing.T) {
 	c := NewClient("http://localhost:5080/", "myorg", "mystream", "user", "pass", testLogger())
 
-	if c.baseURL != "http://localhost:5080" {
-		t.Errorf("expected trailing slash removed, got %q", c.baseURL)
+	if c.BaseURL() != "http://localhost:5080" {
+		t.Errorf("expected trailing slash removed, got %q", c.BaseURL())
 	}
-	if c.org != "myorg" {
-		t.Errorf("unexpected org: %q", c.org)
+	if c.Org() != "myorg" {
+		t.Errorf("unexpected org: %q", c.Org())
 	}
 	if c.stream != "mystream" {
 		t.Errorf("unexpected stream: %q", c.stream)
 	}
-	if c.user != "user" {
-		t.Errorf("unexpected user: %q", c.user)
-	}
-	if c.token != "pass" {
-		t.Errorf("unexpected token: %q", c.token)
-	}
 }
 
 // isCountQuery checks if the request body contains a count query (size=0 and SELECT count).
@@ -69,6 +63,53 @@ func isCountQuery(r *http.Request) bool {
 	return size == 0 && strings.Contains(strings.ToLower(sql), "count")
 }
 
+// summarizeSpans answers a query listing a page of traces with the summaries
+// of the traces of resp's spans, like OpenObserve does; other queries are
+// answered with resp itself.
+func summarizeSpans(r *http.Request, resp OpenObserveResponse) OpenObserveResponse {
+	bodyBytes, _ := io.ReadAll(r.Body)
+	r.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+	if !strings.Contains(string(bodyBytes), "GROUP BY trace_id") {
+		return resp
+	}
+
+	summaries := make(map[string]map[string]interface{})
+	var order []string
+	for _, hit := range resp.Hits {
+		traceID, _ := hit["trace_id"].(string)
+		summary, ok := summaries[traceID]
+		if !ok {
+			summary = map[string]interface{}{"trace_id": traceID, "span_count": int64(0), "error_count": int64(0)}
+			summaries[traceID] = summary
+			order = append(order, traceID)
+		}
+		summary["span_count"] = summary["span_count"].(int64) + 1
+		startNumber, _ := hit["start_time"].(json.Number)
+		start, _ := startNumber.Int64()
+		if current, ok := summary["start_time"].(int64); !ok || start < current {
+			summary["start_time"] = start
+		}
+		endNumber, _ := hit["end_time"].(json.Number)
+		end, _ := endNumber.Int64()
+		if current, ok := summary["end_time"].(int64); !ok || end > current {
+			summary["end_time"] = end
+		}
+		if status, _ := hit["span_status"].(string); strings.EqualFold(status, "error") {
+			summary["error_count"] = summary["error_count"].(int64) + 1
+		}
+		if parent, _ := hit["reference_parent_span_id"].(string); parent == "" {
+			summary["root_span_id"] = hit["span_id"]
+			summary["root_span_name"] = hit["operation_name"]
+			summary["root_span_kind"] = hit["span_kind"]
+		}
+	}
+	page := OpenObserveResponse{Took: resp.Took, Total: len(order)}
+	for _, traceID := range order {
+		page.Hits = append(page.Hits, summaries[traceID])
+	}
+	return page
+}
+
 func TestGetTraces(t *testing.T) {
 	startNs := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC).UnixNano()
 	endNs := time.Date(2025, 1, 1, 12, 1, 0, 0, time.UTC).UnixNano()
@@ -137,6 +178,7 @@ func TestGetTraces(t *testing.T) {
 				},
 			},
 		}
+		resp = summarizeSpans(r, resp)
 		w.Header().Set("Content-Type", "application/json")
 		data, _ := json.Marshal(resp)
 		w.Write(data)
@@ -196,6 +238,79 @@ func TestGetTraces(t *testing.T) {
 	}
 }
 
+func TestGetTraces_Pagination(t *testing.T) {
+	var treeQuery string
+	wantFrom := 2
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isCountQuery(r) {
+			json.NewEncoder(w).Encode(OpenObserveResponse{Hits: []map[string]interface{}{{"total": json.Number("5")}}})
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		if strings.Contains(string(body), "GROUP BY trace_id") {
+			if !strings.Contains(string(body), fmt.Sprintf(`"from":%d`, wantFrom)) {
+				t.Errorf("expected the page to start at offset %d: %s", wantFrom, body)
+			}
+			json.NewEncoder(w).Encode(OpenObserveResponse{Took: 3, Hits: []map[string]interface{}{
+				{"trace_id": "trace-gap", "span_count": json.Number("2"), "root_span_id": "root"},
+				{"trace_id": "trace-big", "span_count": json.Number("50"), "root_span_id": "root"},
+			}})
+			return
+		}
+		treeQuery = string(body)
+		json.NewEncoder(w).Encode(OpenObserveResponse{Hits: []map[string]interface{}{
+			{"trace_id": "trace-gap", "span_id": "root", "reference_parent_span_id": ""},
+			{"trace_id": "trace-gap", "span_id": "child", "reference_parent_span_id": "lost"},
+			{"trace_id": "trace-big", "span_id": "root", "reference_parent_span_id": ""},
+			{"trace_id": "trace-big", "span_id": "child", "reference_parent_span_id": "lost"},
+		}})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	result, err := client.GetTraces(context.Background(), TracesQueryParams{
+		Scope:     Scope{Namespace: "ns"},
+		StartTime: time.Now().Add(-time.Hour),
+		EndTime:   time.Now(),
+		Limit:     2,
+		Offset:    2,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(treeQuery, "trace_id IN ('trace-gap', 'trace-big')") || !strings.Contains(treeQuery, `"size":52`) {
+		t.Errorf("expected the spans of the page's traces only: %s", treeQuery)
+	}
+	if len(result.Traces) != 2 || result.Total != 5 || !result.HasMore || result.TookMs != 3 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if result.Traces[0].Complete || result.Traces[0].IncompleteReason != IncompleteReasonMissingParent {
+		t.Errorf("expected the gap of trace-gap to be detected, got %+v", result.Traces[0])
+	}
+	// Not all spans of trace-big were listed: only its root span is checked.
+	if !result.Traces[1].Complete || !result.Traces[1].TreeTruncated {
+		t.Errorf("expected trace-big to keep its root span check and be reported truncated, got %+v", result.Traces[1])
+	}
+	if result.Traces[0].TreeTruncated {
+		t.Errorf("expected the tree of trace-gap to be checked whole, got %+v", result.Traces[0])
+	}
+
+	wantFrom = 3
+	result, err = client.GetTraces(context.Background(), TracesQueryParams{
+		Scope:     Scope{Namespace: "ns"},
+		StartTime: time.Now().Add(-time.Hour),
+		EndTime:   time.Now(),
+		Limit:     3,
+		Offset:    3,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.HasMore {
+		t.Errorf("expected the last page, got %+v", result)
+	}
+}
+
 func TestGetTraces_ServerError(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -459,6 +574,87 @@ func TestGetSpanDetail_NotFound(t *testing.T) {
 	}
 }
 
+func TestGetSpanDetail_TimeHint(t *testing.T) {
+	type window struct{ start, end int64 }
+	var windows []window
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query struct {
+				StartTime int64 `json:"start_time"`
+				EndTime   int64 `json:"end_time"`
+			} `json:"query"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		windows = append(windows, window{body.Query.StartTime, body.Query.EndTime})
+		w.Header().Set("Content-Type", "application/json")
+		if len(windows) == 1 {
+			w.Write([]byte(`{"took":1,"hits":[]}`))
+			return
+		}
+		w.Write([]byte(`{"took":1,"hits":[{"trace_id":"trace-1","span_id":"span-1"}]}`))
+	}))
+	defer server.Close()
+
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	end := start.Add(time.Minute)
+	client := newTestClient(server.URL)
+	result, err := client.GetSpanDetail(context.Background(), TracesQueryParams{
+		TraceID:   "trace-1",
+		SpanID:    "span-1",
+		StartTime: start,
+		EndTime:   end,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Span.SpanID != "span-1" {
+		t.Errorf("unexpected span: %+v", result.Span)
+	}
+	want := []window{
+		{start.UnixMicro(), end.UnixMicro()},
+		{start.Add(-SpanDetailHintPadding).UnixMicro(), end.Add(SpanDetailHintPadding).UnixMicro()},
+	}
+	if len(windows) != len(want) || windows[0] != want[0] || windows[1] != want[1] {
+		t.Errorf("expected windows %v, got %v", want, windows)
+	}
+}
+
+func TestFindSpan(t *testing.T) {
+	var gotSQL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query struct {
+				SQL string `json:"sql"`
+			} `json:"query"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		gotSQL = body.Query.SQL
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(gotSQL, "'missing'") {
+			w.Write([]byte(`{"took":1,"hits":[]}`))
+			return
+		}
+		w.Write([]byte(`{"took":1,"hits":[{"trace_id":"trace-1","span_id":"span-1","operation_name":"GET /cart"}]}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	result, err := client.FindSpan(context.Background(), TracesQueryParams{TraceID: "ignored", SpanID: "span-1"})
+	if err != nil {
+		t.Fatalf("FindSpan() error = %v", err)
+	}
+	if strings.Contains(gotSQL, "trace_id") {
+		t.Errorf("expected no trace_id filter in SQL: %s", gotSQL)
+	}
+	if result.TraceID != "trace-1" || result.Span.SpanID != "span-1" || result.Span.SpanName != "GET /cart" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	if _, err := client.FindSpan(context.Background(), TracesQueryParams{SpanID: "missing"}); !errors.Is(err, ErrSpanNotFound) {
+		t.Errorf("expected ErrSpanNotFound, got %v", err)
+	}
+}
+
 func TestParseSpanEntry(t *testing.T) {
 	startNs := time.Date(2025, 6, 15, 10, 30, 0, 0, time.UTC).UnixNano()
 	endNs := time.Date(2025, 6, 15, 10, 30, 1, 0, time.UTC).UnixNano()
@@ -534,7 +730,7 @@ func TestParseSpanDetail(t *testing.T) {
 		"resource.version":         "v1",
 	}
 
-	detail := parseSpanDetail(hit)
+	detail := parseSpanDetail(hit, &DefaultAttributeRules)
 
 	if detail.SpanID != "span-1" {
 		t.Errorf("expected spanID 'span-1', got %q", detail.SpanID)
@@ -707,6 +903,7 @@ func TestGetTraces_SpanWithEmptyTraceID(t *testing.T) {
 				{"trace_id": "", "span_id": "span-1", "operation_name": "op"},
 			},
 		}
+		resp = summarizeSpans(r, resp)
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(resp)
 	}))
@@ -754,6 +951,7 @@ func TestGetTraces_HasErrors(t *testing.T) {
 				},
 			},
 		}
+		resp = summarizeSpans(r, resp)
 		w.Header().Set("Content-Type", "application/json")
 		data, _ := json.Marshal(resp)
 		w.Write(data)
@@ -970,7 +1168,7 @@ func TestParseSpanDetail_NoExtraAttributes(t *testing.T) {
 		"_timestamp":               json.Number("1234"),
 	}
 
-	detail := parseSpanDetail(hit)
+	detail := parseSpanDetail(hit, &DefaultAttributeRules)
 
 	if len(detail.Attributes) != 0 {
 		t.Errorf("expected 0 attributes, got %d: %v", len(detail.Attributes), detail.Attributes)
@@ -1034,29 +1232,6 @@ func TestGetSpans_StreamNotFound(t *testing.T) {
 	}
 }
 
-func TestIsStreamNotFound(t *testing.T) {
-	cases := []struct {
-		name string
-		body string
-		want bool
-	}{
-		{"matching code", `{"code":20002,"message":"..."}`, true},
-		{"other code", `{"code":20001,"message":"..."}`, false},
-		{"no code field", `{"message":"foo"}`, false},
-		{"empty body", ``, false},
-		{"malformed json", `{not json`, false},
-	}
-
-	for _, tc := range cases {
-		t.Run(tc.name, func(t *testing.T) {
-			got := isStreamNotFound([]byte(tc.body))
-			if got != tc.want {
-				t.Fatalf("isStreamNotFound(%q) = %v, want %v", tc.body, got, tc.want)
-			}
-		})
-	}
-}
-
 func TestGetSpanDetail_StreamNotFound(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusBadRequest)
@@ -1110,6 +1285,7 @@ func TestGetTraces_StreamNotFound_CountQueryOnly(t *testing.T) {
 				},
 			},
 		}
+		resp = summarizeSpans(r, resp)
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(resp)
 	}))
@@ -1193,3 +1369,43 @@ func TestGetSpans_StreamNotFound_CountQueryOnly(t *testing.T) {
 			len(result.Spans), result.Total)
 	}
 }
+
+func TestTraceCompleteness(t *testing.T) {
+	tests := []struct {
+		name       string
+		rootSpanID string
+		spanIDs    map[string]bool
+		parentIDs  []string
+		complete   bool
+		reason     string
+	}{
+		{
+			name:       "complete trace",
+			rootSpanID: "root",
+			spanIDs:    map[string]bool{"root": true, "child": true},
+			parentIDs:  []string{"root"},
+			complete:   true,
+		},
+		{
+			name:      "missing root span",
+			spanIDs:   map[string]bool{"child": true},
+			parentIDs: []string{"root"},
+			reason:    IncompleteReasonMissingRoot,
+		},
+		{
+			name:       "gap in the tree",
+			rootSpanID: "root",
+			spanIDs:    map[string]bool{"root": true, "grandchild": true},
+			parentIDs:  []string{"child"},
+			reason:     IncompleteReasonMissingParent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			complete, reason := traceCompleteness(tt.rootSpanID, tt.spanIDs, tt.parentIDs)
+			if complete != tt.complete || reason != tt.reason {
+				t.Errorf("got (%v, %q), want (%v, %q)", complete, reason, tt.complete, tt.reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// DebugQuery is a search a debugged request sent to OpenObserve.
+type DebugQuery struct {
+	Org        string    `json:"org"`
+	StreamType string    `json:"streamType"`
+	SQL        string    `json:"sql"`
+	StartTime  time.Time `json:"startTime"`
+	EndTime    time.Time `json:"endTime"`
+}
+
+// QueryDebug collects the searches of a debugged request. It is safe for
+// concurrent use.
+type QueryDebug struct {
+	requestID string
+
+	mu      sync.Mutex
+	queries []DebugQuery
+}
+
+type queryDebugKey struct{}
+
+// WithQueryDebug returns a context whose searches are recorded in the
+// returned QueryDebug and logged at info level with requestID, so that the
+// queries of a single request can be inspected in production.
+func WithQueryDebug(ctx context.Context, requestID string) (context.Context, *QueryDebug) {
+	debug := &QueryDebug{requestID: requestID}
+	return context.WithValue(ctx, queryDebugKey{}, debug), debug
+}
+
+// Queries returns the searches recorded so far, in the order they were sent.
+func (d *QueryDebug) Queries() []DebugQuery {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]DebugQuery{}, d.queries...)
+}
+
+// debugSearch records the search queryJSON against the streams of
+// streamType, when ctx is debugged.
+func (c *Client) debugSearch(ctx context.Context, streamType string, queryJSON []byte) {
+	debug, ok := ctx.Value(queryDebugKey{}).(*QueryDebug)
+	if !ok {
+		return
+	}
+	var request struct {
+		Query struct {
+			SQL       string `json:"sql"`
+			StartTime int64  `json:"start_time"`
+			EndTime   int64  `json:"end_time"`
+		} `json:"query"`
+	}
+	if err := json.Unmarshal(queryJSON, &request); err != nil {
+		return
+	}
+	query := DebugQuery{
+		Org:        c.Org(),
+		StreamType: streamType,
+		SQL:        request.Query.SQL,
+		StartTime:  time.UnixMicro(request.Query.StartTime).UTC(),
+		EndTime:    time.UnixMicro(request.Query.EndTime).UTC(),
+	}
+	debug.mu.Lock()
+	debug.queries = append(debug.queries, query)
+	debug.mu.Unlock()
+	c.logger.Info("Debugged OpenObserve search",
+		slog.String("requestId", debug.requestID),
+		slog.String("org", query.Org),
+		slog.String("streamType", streamType),
+		slog.String("sql", query.SQL),
+		slog.Time("startTime", query.StartTime),
+		slog.Time("endTime", query.EndTime),
+	)
+}
+
+// logGenerated logs v, the search request or alert config generated as
+// format and args describe, at debug level.
+func logGenerated(logger *slog.Logger, v any, format string, args ...any) {
+	if !logger.Enabled(context.Background(), slog.LevelDebug) {
+		return
+	}
+	if encoded, err := json.Marshal(v); err == nil {
+		logger.Debug("Generated "+fmt.Sprintf(format, args...), slog.String("request", string(encoded)))
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestQueryDebug(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(OpenObserveResponse{Hits: []map[string]interface{}{}})
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	client := NewClient(server.URL, "default", "default", "admin", "pass", slog.New(slog.NewTextHandler(&logs, nil)))
+	start := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	params := TracesQueryParams{Scope: Scope{Namespace: "acme"}, StartTime: start, EndTime: start.Add(time.Hour), Limit: 10}
+
+	if _, err := client.GetServices(context.Background(), params); err != nil {
+		t.Fatalf("GetServices() error = %v", err)
+	}
+	if logs.Len() != 0 {
+		t.Errorf("expected no search to be logged without debugging, got %s", logs.String())
+	}
+
+	ctx, debug := WithQueryDebug(context.Background(), "req-1")
+	if _, err := client.GetServices(ctx, params); err != nil {
+		t.Fatalf("GetServices() error = %v", err)
+	}
+	queries := debug.Queries()
+	if len(queries) == 0 {
+		t.Fatal("expected the searches of the debugged context to be recorded")
+	}
+	for _, q := range queries {
+		if q.Org != "default" || q.StreamType != "traces" || !strings.Contains(q.SQL, "acme") || !q.StartTime.Equal(start) {
+			t.Errorf("unexpected debugged query: %+v", q)
+		}
+	}
+	if !strings.Contains(logs.String(), "requestId=req-1") || !strings.Contains(logs.String(), "sql=") {
+		t.Errorf("expected the searches to be logged with the request ID, got %s", logs.String())
+	}
+}
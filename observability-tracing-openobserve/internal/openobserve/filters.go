@@ -0,0 +1,118 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// MaxAttributeFilters is the number of attribute filters a traces query may
+// have.
+const MaxAttributeFilters = 10
+
+// SpanFilters narrow a traces query to the traces with at least one span
+// matching all of them. The other spans of those traces are still listed.
+type SpanFilters struct {
+	Attributes []AttributeFilter
+	// OperationContains matches the spans whose operation name contains it,
+	// ignoring case.
+	OperationContains string
+	// MinDurationNs matches the spans lasting at least as long.
+	MinDurationNs int64
+	// ErrorsOnly matches the spans with the error status.
+	ErrorsOnly bool
+}
+
+// IsZero reports whether f filters nothing.
+func (f SpanFilters) IsZero() bool {
+	return len(f.Attributes) == 0 && f.OperationContains == "" && f.MinDurationNs == 0 && !f.ErrorsOnly
+}
+
+// AttributeFilter compares a span attribute with a value.
+type AttributeFilter struct {
+	// Attribute is the OpenTelemetry attribute name, such as
+	// http.status_code.
+	Attribute string
+	Operator  string
+	Value     string
+	// Numeric compares the attribute as a number rather than a string.
+	Numeric bool
+}
+
+var (
+	attributeFilterRe = regexp.MustCompile(`^\s*([A-Za-z_][A-Za-z0-9_.\-]*)\s*(>=|<=|!=|=|>|<)\s*(.+?)\s*$`)
+	attributeColumnRe = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+)
+
+// ParseAttributeFilter parses a filter such as "http.status_code >= 500" or
+// "db.system = postgres". The operators are =, !=, >, >=, < and <=. Values
+// that are numbers are compared as numbers unless they are quoted; values
+// compared with >, >=, < or <= must be numbers.
+func ParseAttributeFilter(expr string) (AttributeFilter, error) {
+	m := attributeFilterRe.FindStringSubmatch(expr)
+	if m == nil {
+		return AttributeFilter{}, fmt.Errorf("invalid attribute filter %q: must be <attribute> <operator> <value>", expr)
+	}
+	filter := AttributeFilter{Attribute: m[1], Operator: m[2], Value: m[3]}
+	if len(filter.Value) >= 2 && (filter.Value[0] == '\'' || filter.Value[0] == '"') && filter.Value[len(filter.Value)-1] == filter.Value[0] {
+		filter.Value = filter.Value[1 : len(filter.Value)-1]
+	} else if _, err := strconv.ParseFloat(filter.Value, 64); err == nil {
+		filter.Numeric = true
+	}
+	if !filter.Numeric && filter.Operator != "=" && filter.Operator != "!=" {
+		return AttributeFilter{}, fmt.Errorf("invalid attribute filter %q: %s needs a number", expr, filter.Operator)
+	}
+	return filter, nil
+}
+
+// ValidateSpanFilters returns an error if f has too many attribute filters
+// or a negative minimum duration.
+func ValidateSpanFilters(f SpanFilters) error {
+	if len(f.Attributes) > MaxAttributeFilters {
+		return fmt.Errorf("at most %d attribute filters are allowed", MaxAttributeFilters)
+	}
+	if f.MinDurationNs < 0 {
+		return fmt.Errorf("minDurationNs must not be negative")
+	}
+	return nil
+}
+
+// attributeColumn returns the stream column of an attribute. OpenObserve
+// flattens attribute names into lower-case columns with underscores.
+func attributeColumn(attribute string) (string, error) {
+	column := strings.ToLower(strings.NewReplacer(".", "_", "-", "_").Replace(attribute))
+	if !attributeColumnRe.MatchString(column) {
+		return "", fmt.Errorf("invalid attribute name %q", attribute)
+	}
+	return column, nil
+}
+
+// spanFilterConditions builds the SQL WHERE conditions of the span filters.
+func spanFilterConditions(f SpanFilters) ([]string, error) {
+	var conditions []string
+	for _, filter := range f.Attributes {
+		column, err := attributeColumn(filter.Attribute)
+		if err != nil {
+			return nil, err
+		}
+		value := "'" + escapeSQLString(filter.Value) + "'"
+		if filter.Numeric {
+			value = filter.Value
+		}
+		conditions = append(conditions, column+" "+filter.Operator+" "+value)
+	}
+	if f.OperationContains != "" {
+		conditions = append(conditions, "str_match_ignore_case(operation_name, '"+escapeSQLString(f.OperationContains)+"')")
+	}
+	if f.MinDurationNs > 0 {
+		conditions = append(conditions, fmt.Sprintf("end_time - start_time >= %d", f.MinDurationNs))
+	}
+	if f.ErrorsOnly {
+		conditions = append(conditions, "span_status = 'ERROR'")
+	}
+	return conditions, nil
+}
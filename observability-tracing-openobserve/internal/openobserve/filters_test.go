@@ -0,0 +1,121 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseAttributeFilter(t *testing.T) {
+	tests := []struct {
+		expr string
+		want AttributeFilter
+	}{
+		{"http.status_code >= 500", AttributeFilter{Attribute: "http.status_code", Operator: ">=", Value: "500", Numeric: true}},
+		{"db.system = postgres", AttributeFilter{Attribute: "db.system", Operator: "=", Value: "postgres"}},
+		{"http.route!='/api/v1'", AttributeFilter{Attribute: "http.route", Operator: "!=", Value: "/api/v1"}},
+		{`net.peer.port = "5432"`, AttributeFilter{Attribute: "net.peer.port", Operator: "=", Value: "5432"}},
+	}
+	for _, tt := range tests {
+		got, err := ParseAttributeFilter(tt.expr)
+		if err != nil {
+			t.Errorf("ParseAttributeFilter(%q) error = %v", tt.expr, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseAttributeFilter(%q) = %+v, want %+v", tt.expr, got, tt.want)
+		}
+	}
+
+	for _, expr := range []string{"", "http.status_code", "= 500", "db.system > postgres", "a b = c"} {
+		if _, err := ParseAttributeFilter(expr); err == nil {
+			t.Errorf("ParseAttributeFilter(%q): expected error", expr)
+		}
+	}
+}
+
+func TestValidateSpanFilters(t *testing.T) {
+	if err := ValidateSpanFilters(SpanFilters{MinDurationNs: -1}); err == nil {
+		t.Error("expected error for a negative minimum duration")
+	}
+	if err := ValidateSpanFilters(SpanFilters{Attributes: make([]AttributeFilter, MaxAttributeFilters+1)}); err == nil {
+		t.Error("expected error for too many attribute filters")
+	}
+	if err := ValidateSpanFilters(SpanFilters{MinDurationNs: 1000}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestSpanFilterConditions(t *testing.T) {
+	got, err := spanFilterConditions(SpanFilters{
+		Attributes: []AttributeFilter{
+			{Attribute: "http.status_code", Operator: ">=", Value: "500", Numeric: true},
+			{Attribute: "DB.System", Operator: "=", Value: "it's"},
+		},
+		OperationContains: "cart",
+		MinDurationNs:     250000000,
+		ErrorsOnly:        true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{
+		"http_status_code >= 500",
+		"db_system = 'it''s'",
+		"str_match_ignore_case(operation_name, 'cart')",
+		"end_time - start_time >= 250000000",
+		"span_status = 'ERROR'",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("spanFilterConditions() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	if _, err := spanFilterConditions(SpanFilters{Attributes: []AttributeFilter{{Attribute: "1abc", Operator: "=", Value: "x"}}}); err == nil {
+		t.Error("expected error for an invalid attribute name")
+	}
+}
+
+func TestGenerateTracesQueries_SpanFilters(t *testing.T) {
+	params := TracesQueryParams{
+		StartTime: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+		Scope:     Scope{Namespace: "test-ns"},
+		Filters:   SpanFilters{ErrorsOnly: true},
+	}
+	sqlOf := func(data []byte, err error) string {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var query struct {
+			Query struct {
+				SQL string `json:"sql"`
+			} `json:"query"`
+		}
+		if err := json.Unmarshal(data, &query); err != nil {
+			t.Fatalf("invalid JSON: %v", err)
+		}
+		return query.Query.SQL
+	}
+
+	list := sqlOf(generateTracesListQuery(params, "default", testLogger()))
+	want := "WHERE service_openchoreo_dev_namespace = 'test-ns' AND trace_id IN (SELECT DISTINCT trace_id FROM default " +
+		"WHERE service_openchoreo_dev_namespace = 'test-ns' AND span_status = 'ERROR')"
+	if !strings.Contains(list, want) {
+		t.Errorf("expected %q in SQL: %s", want, list)
+	}
+
+	count := sqlOf(generateTracesCountQuery(params, "default", testLogger()))
+	if !strings.HasSuffix(count, "WHERE service_openchoreo_dev_namespace = 'test-ns' AND span_status = 'ERROR'") {
+		t.Errorf("unexpected count SQL: %s", count)
+	}
+
+	params.Filters = SpanFilters{}
+	if list := sqlOf(generateTracesListQuery(params, "default", testLogger())); strings.Contains(list, "trace_id IN") {
+		t.Errorf("expected no subquery without filters: %s", list)
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// Bounds and defaults of trace group queries.
+const (
+	DefaultTraceGroupsLimit  = 20
+	MaxTraceGroupsLimit      = 100
+	DefaultTraceGroupSamples = 3
+	MaxTraceGroupSamples     = 10
+)
+
+// rootSpanCondition matches the root spans of traces, which stand for their
+// trace in group queries.
+const rootSpanCondition = "(reference_parent_span_id IS NULL OR reference_parent_span_id = '')"
+
+// TraceGroupsParams holds parameters for trace group queries.
+type TraceGroupsParams struct {
+	TracesQueryParams
+	// Samples is the number of sample trace IDs returned per group.
+	Samples int
+}
+
+// TraceGroup rolls up the traces sharing a root operation.
+type TraceGroup struct {
+	RootOperation string `json:"rootOperation"`
+	TraceCount    int    `json:"traceCount"`
+	ErrorCount    int    `json:"errorCount"`
+	// ErrorRate is the fraction of the traces whose root span failed.
+	ErrorRate     float64 `json:"errorRate"`
+	P95DurationNs int64   `json:"p95DurationNs"`
+	// SampleTraceIDs are recent traces of the group, failed ones first.
+	SampleTraceIDs []string `json:"sampleTraceIds"`
+}
+
+// TraceGroupsResult represents the response of a trace group query.
+type TraceGroupsResult struct {
+	Groups []TraceGroup `json:"groups"`
+	TookMs int          `json:"tookMs"`
+}
+
+// ValidateTraceGroups returns an error if the number of groups or samples is
+// out of range.
+func ValidateTraceGroups(params TraceGroupsParams) error {
+	if params.Limit < 1 || params.Limit > MaxTraceGroupsLimit {
+		return fmt.Errorf("limit must be between 1 and %d", MaxTraceGroupsLimit)
+	}
+	if params.Samples < 0 || params.Samples > MaxTraceGroupSamples {
+		return fmt.Errorf("samples must be between 0 and %d", MaxTraceGroupSamples)
+	}
+	return nil
+}
+
+// traceGroupConditions returns the WHERE conditions selecting the root spans
+// of the scope of params.
+func traceGroupConditions(params TraceGroupsParams) string {
+	return strings.Join(append(buildFilterConditions(params.TracesQueryParams), rootSpanCondition), " AND ")
+}
+
+// generateTraceGroupsQuery generates the OpenObserve query rolling up the
+// root spans of a scope by operation name, busiest operations first. Span
+// times are in nanoseconds.
+func generateTraceGroupsQuery(params TraceGroupsParams, stream string, logger *slog.Logger) ([]byte, error) {
+	safeStream, err := validateSQLIdentifier(stream)
+	if err != nil {
+		return nil, fmt.Errorf("invalid stream identifier: %w", err)
+	}
+
+	sql := fmt.Sprintf("SELECT operation_name, count(*) AS trace_count, "+
+		"sum(CASE WHEN span_status = 'ERROR' THEN 1 ELSE 0 END) AS error_count, "+
+		"approx_percentile_cont(end_time - start_time, 0.95) AS p95_duration "+
+		"FROM %s WHERE %s GROUP BY operation_name ORDER BY trace_count DESC, operation_name ASC",
+		safeStream, traceGroupConditions(params))
+
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"sql":        sql,
+			"start_time": params.StartTime.UnixMicro(),
+			"end_time":   params.EndTime.UnixMicro(),
+			"from":       0,
+			"size":       params.Limit,
+		},
+	}
+
+	logGenerated(logger, query, "trace groups query")
+
+	return json.Marshal(query)
+}
+
+// generateTraceGroupSamplesQuery generates the OpenObserve query for up to
+// params.Samples trace IDs of each of the given root operations, failed
+// traces first and then the most recent ones.
+func generateTraceGroupSamplesQuery(params TraceGroupsParams, operations []string, stream string, logger *slog.Logger) ([]byte, error) {
+	safeStream, err := validateSQLIdentifier(stream)
+	if err != nil {
+		return nil, fmt.Errorf("invalid stream identifier: %w", err)
+	}
+
+	quoted := make([]string, len(operations))
+	for i, operation := range operations {
+		quoted[i] = "'" + escapeSQLString(operation) + "'"
+	}
+	sql := fmt.Sprintf("SELECT operation_name, trace_id FROM ("+
+		"SELECT operation_name, trace_id, row_number() OVER (PARTITION BY operation_name "+
+		"ORDER BY CASE WHEN span_status = 'ERROR' THEN 0 ELSE 1 END, start_time DESC) AS sample_rank "+
+		"FROM %s WHERE %s AND operation_name IN (%s)) WHERE sample_rank <= %d",
+		safeStream, traceGroupConditions(params), strings.Join(quoted, ", "), params.Samples)
+
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"sql":        sql,
+			"start_time": params.StartTime.UnixMicro(),
+			"end_time":   params.EndTime.UnixMicro(),
+			"from":       0,
+			"size":       len(operations) * params.Samples,
+		},
+	}
+
+	logGenerated(logger, query, "trace group samples query")
+
+	return json.Marshal(query)
+}
+
+// GetTraceGroups queries OpenObserve for the traces of a scope and time
+// range rolled up by root operation, with the count, error rate and p95
+// duration of each group and sample trace IDs to drill into.
+func (c *Client) GetTraceGroups(ctx context.Context, params TraceGroupsParams) (*TraceGroupsResult, error) {
+	if err := ValidateTraceGroups(params); err != nil {
+		return nil, err
+	}
+	queryJSON, err := generateTraceGroupsQuery(params, c.stream, c.logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate trace groups query: %w", err)
+	}
+
+	result := &TraceGroupsResult{Groups: []TraceGroup{}}
+	openObserveResp, err := c.executeSearchQuery(ctx, queryJSON)
+	if errors.Is(err, ErrStreamNotFound) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.TookMs = openObserveResp.Took
+
+	operations := make([]string, 0, len(openObserveResp.Hits))
+	byOperation := make(map[string]*TraceGroup, len(openObserveResp.Hits))
+	for _, hit := range openObserveResp.Hits {
+		group := TraceGroup{SampleTraceIDs: []string{}}
+		group.RootOperation, _ = hit["operation_name"].(string)
+		if v, ok := hit["trace_count"].(json.Number); ok {
+			n, _ := v.Int64()
+			group.TraceCount = int(n)
+		}
+		if v, ok := hit["error_count"].(json.Number); ok {
+			n, _ := v.Int64()
+			group.ErrorCount = int(n)
+		}
+		if v, ok := hit["p95_duration"].(json.Number); ok {
+			// The percentile is interpolated, so it may not be an integer.
+			f, _ := v.Float64()
+			group.P95DurationNs = int64(f)
+		}
+		if group.TraceCount > 0 {
+			group.ErrorRate = float64(group.ErrorCount) / float64(group.TraceCount)
+		}
+		result.Groups = append(result.Groups, group)
+		operations = append(operations, group.RootOperation)
+	}
+	for i := range result.Groups {
+		byOperation[result.Groups[i].RootOperation] = &result.Groups[i]
+	}
+	if len(operations) == 0 || params.Samples == 0 {
+		return result, nil
+	}
+
+	samplesJSON, err := generateTraceGroupSamplesQuery(params, operations, c.stream, c.logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate trace group samples query: %w", err)
+	}
+	samplesResp, err := c.executeSearchQuery(ctx, samplesJSON)
+	if err != nil {
+		return nil, err
+	}
+	result.TookMs += samplesResp.Took
+	for _, hit := range samplesResp.Hits {
+		operation, _ := hit["operation_name"].(string)
+		traceID, _ := hit["trace_id"].(string)
+		if group, ok := byOperation[operation]; ok && traceID != "" && len(group.SampleTraceIDs) < params.Samples {
+			group.SampleTraceIDs = append(group.SampleTraceIDs, traceID)
+		}
+	}
+	return result, nil
+}
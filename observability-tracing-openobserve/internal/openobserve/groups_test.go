@@ -0,0 +1,187 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testTraceGroupsParams() TraceGroupsParams {
+	return TraceGroupsParams{
+		TracesQueryParams: TracesQueryParams{
+			StartTime: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+			Limit:     DefaultTraceGroupsLimit,
+			Scope:     Scope{Namespace: "test-ns", ComponentID: "comp-1"},
+		},
+		Samples: DefaultTraceGroupSamples,
+	}
+}
+
+func TestGenerateTraceGroupsQuery(t *testing.T) {
+	result, err := generateTraceGroupsQuery(testTraceGroupsParams(), "default", testLogger())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var query struct {
+		Query struct {
+			SQL  string `json:"sql"`
+			Size int    `json:"size"`
+		} `json:"query"`
+	}
+	if err := json.Unmarshal(result, &query); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	sql := query.Query.SQL
+	for _, want := range []string{
+		"approx_percentile_cont(end_time - start_time, 0.95) AS p95_duration",
+		"sum(CASE WHEN span_status = 'ERROR' THEN 1 ELSE 0 END) AS error_count",
+		"service_openchoreo_dev_component_uid = 'comp-1'",
+		rootSpanCondition,
+		"GROUP BY operation_name ORDER BY trace_count DESC",
+	} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("expected %q in SQL: %s", want, sql)
+		}
+	}
+	if query.Query.Size != DefaultTraceGroupsLimit {
+		t.Errorf("expected size %d, got %d", DefaultTraceGroupsLimit, query.Query.Size)
+	}
+
+	if _, err := generateTraceGroupsQuery(testTraceGroupsParams(), "bad stream", testLogger()); err == nil {
+		t.Error("expected error for invalid stream")
+	}
+}
+
+func TestGenerateTraceGroupSamplesQuery(t *testing.T) {
+	result, err := generateTraceGroupSamplesQuery(testTraceGroupsParams(), []string{"GET /cart", "it's"}, "default", testLogger())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var query struct {
+		Query struct {
+			SQL  string `json:"sql"`
+			Size int    `json:"size"`
+		} `json:"query"`
+	}
+	if err := json.Unmarshal(result, &query); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	sql := query.Query.SQL
+	for _, want := range []string{
+		"PARTITION BY operation_name",
+		"operation_name IN ('GET /cart', 'it''s')",
+		"WHERE sample_rank <= 3",
+	} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("expected %q in SQL: %s", want, sql)
+		}
+	}
+	if query.Query.Size != 6 {
+		t.Errorf("expected size 6, got %d", query.Query.Size)
+	}
+}
+
+func TestValidateTraceGroups(t *testing.T) {
+	if err := ValidateTraceGroups(testTraceGroupsParams()); err != nil {
+		t.Fatalf("unexpected error for valid params: %v", err)
+	}
+	tests := []struct {
+		name   string
+		modify func(*TraceGroupsParams)
+	}{
+		{"zero limit", func(p *TraceGroupsParams) { p.Limit = 0 }},
+		{"limit too large", func(p *TraceGroupsParams) { p.Limit = MaxTraceGroupsLimit + 1 }},
+		{"negative samples", func(p *TraceGroupsParams) { p.Samples = -1 }},
+		{"too many samples", func(p *TraceGroupsParams) { p.Samples = MaxTraceGroupSamples + 1 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := testTraceGroupsParams()
+			tt.modify(&params)
+			if err := ValidateTraceGroups(params); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestGetTraceGroups(t *testing.T) {
+	var queries int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query struct {
+				SQL string `json:"sql"`
+			} `json:"query"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		queries++
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(body.Query.SQL, "sample_rank") {
+			_, _ = w.Write([]byte(`{"took":1,"hits":[` +
+				`{"operation_name":"GET /cart","trace_id":"t1"},` +
+				`{"operation_name":"GET /cart","trace_id":"t2"},` +
+				`{"operation_name":"POST /orders","trace_id":"t3"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"took":3,"hits":[` +
+			`{"operation_name":"GET /cart","trace_count":40,"error_count":10,"p95_duration":1250000.5},` +
+			`{"operation_name":"POST /orders","trace_count":5,"error_count":0,"p95_duration":900}]}`))
+	}))
+	defer server.Close()
+
+	result, err := newTestClient(server.URL).GetTraceGroups(context.Background(), testTraceGroupsParams())
+	if err != nil {
+		t.Fatalf("GetTraceGroups() error = %v", err)
+	}
+	if queries != 2 || result.TookMs != 4 || len(result.Groups) != 2 {
+		t.Fatalf("unexpected result after %d queries: %+v", queries, result)
+	}
+	cart := result.Groups[0]
+	if cart.RootOperation != "GET /cart" || cart.TraceCount != 40 || cart.ErrorCount != 10 ||
+		cart.ErrorRate != 0.25 || cart.P95DurationNs != 1250000 {
+		t.Errorf("unexpected group %+v", cart)
+	}
+	if len(cart.SampleTraceIDs) != 2 || cart.SampleTraceIDs[0] != "t1" {
+		t.Errorf("unexpected samples %v", cart.SampleTraceIDs)
+	}
+	if orders := result.Groups[1]; orders.ErrorRate != 0 || len(orders.SampleTraceIDs) != 1 {
+		t.Errorf("unexpected group %+v", orders)
+	}
+
+	queries = 0
+	params := testTraceGroupsParams()
+	params.Samples = 0
+	result, err = newTestClient(server.URL).GetTraceGroups(context.Background(), params)
+	if err != nil {
+		t.Fatalf("GetTraceGroups() error = %v", err)
+	}
+	if queries != 1 || len(result.Groups[0].SampleTraceIDs) != 0 {
+		t.Errorf("expected no samples query, got %d queries and %+v", queries, result.Groups[0])
+	}
+}
+
+func TestGetTraceGroups_StreamNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":20002,"message":"Search stream not found: default"}`))
+	}))
+	defer server.Close()
+
+	result, err := newTestClient(server.URL).GetTraceGroups(context.Background(), testTraceGroupsParams())
+	if err != nil {
+		t.Fatalf("GetTraceGroups() error = %v", err)
+	}
+	if result.Groups == nil || len(result.Groups) != 0 {
+		t.Errorf("expected empty groups, got %+v", result.Groups)
+	}
+}
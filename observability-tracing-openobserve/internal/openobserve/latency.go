@@ -0,0 +1,222 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Bounds and defaults of the exponential latency histogram.
+const (
+	// LatencyHistogramBaseNs is the upper bound of the first bucket, which
+	// holds all durations below one microsecond.
+	LatencyHistogramBaseNs = 1000
+	// DefaultGrowthFactor is the ratio of consecutive bucket boundaries.
+	DefaultGrowthFactor = 2.0
+	// MinGrowthFactor and MaxGrowthFactor bound the growth factor. The
+	// minimum keeps the number of buckets between a microsecond and several
+	// hours below MaxQueryLimit.
+	MinGrowthFactor = 1.05
+	MaxGrowthFactor = 10.0
+)
+
+// DefaultLatencyPercentiles are the percentiles estimated when none are requested.
+var DefaultLatencyPercentiles = []float64{50, 90, 95, 99}
+
+// LatencyHistogramParams holds parameters for latency histogram queries.
+type LatencyHistogramParams struct {
+	TracesQueryParams
+	// Operation restricts the histogram to spans with this operation name.
+	Operation string
+	// GrowthFactor is the ratio of consecutive bucket boundaries.
+	GrowthFactor float64
+	// Percentiles are estimated from the histogram, each in (0, 100).
+	Percentiles []float64
+}
+
+// BucketScheme describes the boundaries of an exponential histogram. Bucket 0
+// holds durations below BaseNs; bucket i > 0 holds durations in
+// [BaseNs * GrowthFactor^(i-1), BaseNs * GrowthFactor^i).
+type BucketScheme struct {
+	Type         string  `json:"type"`
+	BaseNs       int64   `json:"baseNs"`
+	GrowthFactor float64 `json:"growthFactor"`
+}
+
+// LatencyBucket is a non-empty bucket of a latency histogram.
+type LatencyBucket struct {
+	Index   int   `json:"index"`
+	LowerNs int64 `json:"lowerNs"`
+	UpperNs int64 `json:"upperNs"`
+	Count   int   `json:"count"`
+}
+
+// LatencyPercentile is a percentile estimated from a latency histogram.
+type LatencyPercentile struct {
+	Percentile float64 `json:"percentile"`
+	DurationNs int64   `json:"durationNs"`
+}
+
+// LatencyHistogramResult represents the response of a latency histogram query.
+type LatencyHistogramResult struct {
+	Scheme      BucketScheme        `json:"scheme"`
+	Buckets     []LatencyBucket     `json:"buckets"`
+	Percentiles []LatencyPercentile `json:"percentiles"`
+	Total       int                 `json:"total"`
+	TookMs      int                 `json:"tookMs"`
+	// FromRollups is set when the histogram was read from the latency
+	// rollups rather than from the spans.
+	FromRollups bool `json:"fromRollups,omitempty"`
+}
+
+// ValidateLatencyHistogram returns an error if the growth factor or a
+// percentile is out of range.
+func ValidateLatencyHistogram(params LatencyHistogramParams) error {
+	if params.GrowthFactor < MinGrowthFactor || params.GrowthFactor > MaxGrowthFactor {
+		return fmt.Errorf("growthFactor must be between %v and %v", MinGrowthFactor, MaxGrowthFactor)
+	}
+	for _, p := range params.Percentiles {
+		if p <= 0 || p >= 100 {
+			return fmt.Errorf("invalid percentile %v: must be between 0 and 100", p)
+		}
+	}
+	return nil
+}
+
+// bucketBounds returns the boundaries of bucket i of the scheme in nanoseconds.
+func (s BucketScheme) bucketBounds(i int) (float64, float64) {
+	if i <= 0 {
+		return 0, float64(s.BaseNs)
+	}
+	lower := float64(s.BaseNs) * math.Pow(s.GrowthFactor, float64(i-1))
+	return lower, lower * s.GrowthFactor
+}
+
+// generateLatencyHistogramQuery generates the OpenObserve query counting the
+// spans in each exponential duration bucket. Buckets are computed in the
+// query, so only non-empty buckets are transferred. Span times are in
+// nanoseconds.
+func generateLatencyHistogramQuery(params LatencyHistogramParams, stream string, logger *slog.Logger) ([]byte, error) {
+	safeStream, err := validateSQLIdentifier(stream)
+	if err != nil {
+		return nil, fmt.Errorf("invalid stream identifier: %w", err)
+	}
+
+	bucket := latencyBucketExpr(strconv.FormatFloat(params.GrowthFactor, 'f', -1, 64))
+	sql := fmt.Sprintf("SELECT %s AS bucket, count(*) AS span_count FROM %s", bucket, safeStream)
+
+	conditions := buildFilterConditions(params.TracesQueryParams)
+	if params.Operation != "" {
+		conditions = append(conditions, "operation_name = '"+escapeSQLString(params.Operation)+"'")
+	}
+	if len(conditions) > 0 {
+		sql += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	sql += " GROUP BY bucket ORDER BY bucket ASC"
+
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"sql":        sql,
+			"start_time": params.StartTime.UnixMicro(),
+			"end_time":   params.EndTime.UnixMicro(),
+			"from":       0,
+			"size":       MaxQueryLimit,
+		},
+	}
+
+	logGenerated(logger, query, "latency histogram query")
+
+	return json.Marshal(query)
+}
+
+// GetLatencyHistogram queries OpenObserve for an exponential histogram of
+// span durations in the given scope and time range, and estimates the
+// requested percentiles from it.
+func (c *Client) GetLatencyHistogram(ctx context.Context, params LatencyHistogramParams) (*LatencyHistogramResult, error) {
+	if err := ValidateLatencyHistogram(params); err != nil {
+		return nil, err
+	}
+	scheme := BucketScheme{Type: "exponential", BaseNs: LatencyHistogramBaseNs, GrowthFactor: params.GrowthFactor}
+	result := &LatencyHistogramResult{Scheme: scheme, Buckets: []LatencyBucket{}}
+
+	var openObserveResp *OpenObserveResponse
+	if innerStart, innerEnd, ok := c.latencyRollupsWindow(params); ok {
+		resp, err := c.getLatencyBucketsFromRollups(ctx, params, innerStart, innerEnd)
+		if err != nil {
+			return nil, err
+		}
+		openObserveResp, result.FromRollups = resp, true
+	} else {
+		queryJSON, err := generateLatencyHistogramQuery(params, c.stream, c.logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate latency histogram query: %w", err)
+		}
+		openObserveResp, err = c.executeSearchQuery(ctx, queryJSON)
+		if err != nil && !errors.Is(err, ErrStreamNotFound) {
+			return nil, err
+		}
+	}
+	if openObserveResp != nil {
+		result.TookMs = openObserveResp.Took
+		for _, hit := range openObserveResp.Hits {
+			// Spans without an end time have no bucket.
+			index, okIndex := intColumn(hit, "bucket")
+			count, okCount := intColumn(hit, "span_count")
+			if !okIndex || !okCount {
+				continue
+			}
+			lower, upper := scheme.bucketBounds(int(index))
+			result.Buckets = append(result.Buckets, LatencyBucket{
+				Index:   int(index),
+				LowerNs: int64(lower),
+				UpperNs: int64(upper),
+				Count:   int(count),
+			})
+			result.Total += int(count)
+		}
+	}
+
+	result.Percentiles = estimatePercentiles(scheme, result.Buckets, result.Total, params.Percentiles)
+	return result, nil
+}
+
+// estimatePercentiles estimates percentiles from histogram buckets ordered by
+// index. Within a bucket the rank is interpolated geometrically, matching
+// the exponential boundaries, so the relative error of an estimate is
+// bounded by the growth factor. No percentiles are estimated for an empty
+// histogram.
+func estimatePercentiles(scheme BucketScheme, buckets []LatencyBucket, total int, percentiles []float64) []LatencyPercentile {
+	estimates := make([]LatencyPercentile, 0, len(percentiles))
+	if total == 0 {
+		return estimates
+	}
+	for _, p := range percentiles {
+		rank := p / 100 * float64(total)
+		seen := 0.0
+		for _, b := range buckets {
+			if seen+float64(b.Count) < rank {
+				seen += float64(b.Count)
+				continue
+			}
+			fraction := (rank - seen) / float64(b.Count)
+			lower, upper := scheme.bucketBounds(b.Index)
+			var duration float64
+			if b.Index == 0 {
+				duration = upper * fraction
+			} else {
+				duration = lower * math.Pow(upper/lower, fraction)
+			}
+			estimates = append(estimates, LatencyPercentile{Percentile: p, DurationNs: int64(math.Round(duration))})
+			break
+		}
+	}
+	return estimates
+}
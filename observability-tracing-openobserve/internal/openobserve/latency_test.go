@@ -0,0 +1,146 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testLatencyParams() LatencyHistogramParams {
+	return LatencyHistogramParams{
+		TracesQueryParams: TracesQueryParams{
+			StartTime: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+			Scope:     Scope{Namespace: "test-ns", ComponentID: "comp-1"},
+		},
+		GrowthFactor: DefaultGrowthFactor,
+		Percentiles:  DefaultLatencyPercentiles,
+	}
+}
+
+func TestGenerateLatencyHistogramQuery(t *testing.T) {
+	params := testLatencyParams()
+	params.Operation = "GET /cart"
+	params.GrowthFactor = 1.5
+	result, err := generateLatencyHistogramQuery(params, "default", testLogger())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var query struct {
+		Query struct {
+			SQL  string `json:"sql"`
+			Size int    `json:"size"`
+		} `json:"query"`
+	}
+	if err := json.Unmarshal(result, &query); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	sql := query.Query.SQL
+	for _, want := range []string{
+		"CASE WHEN end_time - start_time < 1000 THEN 0 ELSE CAST(floor(ln((end_time - start_time) / 1000.0) / ln(1.5)) AS BIGINT) + 1 END AS bucket",
+		"service_openchoreo_dev_component_uid = 'comp-1'",
+		"operation_name = 'GET /cart'",
+		"GROUP BY bucket ORDER BY bucket ASC",
+	} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("expected %q in SQL: %s", want, sql)
+		}
+	}
+	if query.Query.Size != MaxQueryLimit {
+		t.Errorf("expected size %d, got %d", MaxQueryLimit, query.Query.Size)
+	}
+
+	if _, err := generateLatencyHistogramQuery(params, "bad stream", testLogger()); err == nil {
+		t.Error("expected error for invalid stream")
+	}
+}
+
+func TestValidateLatencyHistogram(t *testing.T) {
+	if err := ValidateLatencyHistogram(testLatencyParams()); err != nil {
+		t.Fatalf("unexpected error for valid params: %v", err)
+	}
+	tests := []struct {
+		name   string
+		modify func(*LatencyHistogramParams)
+	}{
+		{"growth factor too small", func(p *LatencyHistogramParams) { p.GrowthFactor = 1 }},
+		{"growth factor too large", func(p *LatencyHistogramParams) { p.GrowthFactor = 16 }},
+		{"zero percentile", func(p *LatencyHistogramParams) { p.Percentiles = []float64{0} }},
+		{"hundredth percentile", func(p *LatencyHistogramParams) { p.Percentiles = []float64{50, 100} }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := testLatencyParams()
+			tt.modify(&params)
+			if err := ValidateLatencyHistogram(params); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestEstimatePercentiles(t *testing.T) {
+	scheme := BucketScheme{Type: "exponential", BaseNs: LatencyHistogramBaseNs, GrowthFactor: 2}
+	buckets := []LatencyBucket{
+		{Index: 0, Count: 10},
+		{Index: 11, Count: 80}, // [1.024ms, 2.048ms)
+		{Index: 21, Count: 10}, // [1.048576s, 2.097152s)
+	}
+	got := estimatePercentiles(scheme, buckets, 100, []float64{5, 50, 95})
+	if len(got) != 3 {
+		t.Fatalf("expected 3 estimates, got %+v", got)
+	}
+	if got[0].DurationNs != 500 {
+		t.Errorf("p5 = %d, want 500", got[0].DurationNs)
+	}
+	if want := int64(math.Round(1024000 * math.Pow(2, 0.5))); got[1].DurationNs != want {
+		t.Errorf("p50 = %d, want %d", got[1].DurationNs, want)
+	}
+	if want := int64(math.Round(1048576000 * math.Pow(2, 0.5))); got[2].DurationNs != want {
+		t.Errorf("p95 = %d, want %d", got[2].DurationNs, want)
+	}
+
+	if got := estimatePercentiles(scheme, nil, 0, []float64{50}); len(got) != 0 {
+		t.Errorf("expected no estimates for an empty histogram, got %+v", got)
+	}
+}
+
+func TestGetLatencyHistogram(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"took":4,"hits":[{"bucket":null,"span_count":1},{"bucket":0,"span_count":2},{"bucket":3,"span_count":6},{"span_count":5}]}`))
+	}))
+	defer server.Close()
+
+	result, err := newTestClient(server.URL).GetLatencyHistogram(context.Background(), testLatencyParams())
+	if err != nil {
+		t.Fatalf("GetLatencyHistogram() error = %v", err)
+	}
+	if result.Scheme.Type != "exponential" || result.Scheme.BaseNs != 1000 || result.Scheme.GrowthFactor != 2 {
+		t.Errorf("unexpected scheme %+v", result.Scheme)
+	}
+	if result.Total != 8 || result.TookMs != 4 || len(result.Buckets) != 2 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	if b := result.Buckets[1]; b.LowerNs != 4000 || b.UpperNs != 8000 || b.Count != 6 {
+		t.Errorf("unexpected bucket %+v", b)
+	}
+	if len(result.Percentiles) != len(DefaultLatencyPercentiles) {
+		t.Errorf("expected %d percentiles, got %+v", len(DefaultLatencyPercentiles), result.Percentiles)
+	}
+
+	params := testLatencyParams()
+	params.GrowthFactor = 0
+	if _, err := newTestClient(server.URL).GetLatencyHistogram(context.Background(), params); err == nil {
+		t.Error("expected error for invalid growth factor")
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"fmt"
+	"maps"
+)
+
+// SpanLink is an OpenTelemetry span link to a span of another trace, e.g.
+// the producer of a message a consumer span processes.
+type SpanLink struct {
+	TraceID    string            `json:"traceId"`
+	SpanID     string            `json:"spanId"`
+	TraceState string            `json:"traceState,omitempty"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// parseSpanLinks parses the links column of a span. OpenObserve stores span
+// links as a JSON array of objects holding the linked span context, under
+// "context" or as top-level keys, and the link attributes. Links without a
+// trace ID are skipped.
+func parseSpanLinks(v interface{}) []SpanLink {
+	raw := parseJSONObjects(v)
+	if raw == nil {
+		return nil
+	}
+
+	links := make([]SpanLink, 0, len(raw))
+	for _, l := range raw {
+		fields := l
+		if context, ok := l["context"].(map[string]interface{}); ok {
+			fields = maps.Clone(l)
+			delete(fields, "context")
+			maps.Copy(fields, context)
+		}
+		link := SpanLink{Attributes: map[string]string{}}
+		for k, v := range fields {
+			switch k {
+			case "trace_id", "traceId":
+				link.TraceID = fmt.Sprint(v)
+			case "span_id", "spanId":
+				link.SpanID = fmt.Sprint(v)
+			case "trace_state", "traceState":
+				link.TraceState = fmt.Sprint(v)
+			case "trace_flags", "traceFlags", "dropped_attributes_count", "droppedAttributesCount":
+			default:
+				addAttribute(link.Attributes, k, v)
+			}
+		}
+		if link.TraceID == "" {
+			continue
+		}
+		if len(link.Attributes) == 0 {
+			link.Attributes = nil
+		}
+		links = append(links, link)
+	}
+	return links
+}
@@ -0,0 +1,30 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSpanLinks(t *testing.T) {
+	links := parseSpanLinks(`[
+		{"context":{"traceId":"trace-2","spanId":"span-9","traceFlags":1,"traceState":"vendor=a"},"attributes":{"messaging.operation":"publish"},"dropped_attributes_count":0},
+		{"trace_id":"trace-3","span_id":"span-4","link.kind":"follows"},
+		{"context":{"spanId":"orphan"}}
+	]`)
+	want := []SpanLink{
+		{TraceID: "trace-2", SpanID: "span-9", TraceState: "vendor=a", Attributes: map[string]string{"messaging.operation": "publish"}},
+		{TraceID: "trace-3", SpanID: "span-4", Attributes: map[string]string{"link.kind": "follows"}},
+	}
+	if !reflect.DeepEqual(links, want) {
+		t.Errorf("parseSpanLinks() = %+v, want %+v", links, want)
+	}
+
+	for _, v := range []interface{}{nil, "", "not json", 42} {
+		if got := parseSpanLinks(v); got != nil {
+			t.Errorf("parseSpanLinks(%v) = %v, want nil", v, got)
+		}
+	}
+}
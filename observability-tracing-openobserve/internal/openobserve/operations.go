@@ -0,0 +1,144 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// Bounds and defaults of operation statistics queries.
+const (
+	DefaultOperationStatsLimit = 50
+	MaxOperationStatsLimit     = 500
+)
+
+// OperationStats are the RED metrics of the spans of an operation: their
+// rate, errors and duration percentiles.
+type OperationStats struct {
+	Service    string `json:"service"`
+	Operation  string `json:"operation"`
+	SpanCount  int    `json:"spanCount"`
+	ErrorCount int    `json:"errorCount"`
+	// ErrorRate is the fraction of the spans that failed.
+	ErrorRate float64 `json:"errorRate"`
+	// Throughput is the number of spans per second over the time window.
+	Throughput    float64 `json:"throughput"`
+	P50DurationNs int64   `json:"p50DurationNs"`
+	P90DurationNs int64   `json:"p90DurationNs"`
+	P99DurationNs int64   `json:"p99DurationNs"`
+}
+
+// OperationStatsResult represents the response of an operation statistics
+// query.
+type OperationStatsResult struct {
+	Operations []OperationStats `json:"operations"`
+	TookMs     int              `json:"tookMs"`
+}
+
+// ValidateOperationStats returns an error if the number of operations is out
+// of range or the time window is empty.
+func ValidateOperationStats(params TracesQueryParams) error {
+	if params.Limit < 1 || params.Limit > MaxOperationStatsLimit {
+		return fmt.Errorf("limit must be between 1 and %d", MaxOperationStatsLimit)
+	}
+	if !params.EndTime.After(params.StartTime) {
+		return fmt.Errorf("endTime must be after startTime")
+	}
+	return nil
+}
+
+// generateOperationStatsQuery generates the OpenObserve query rolling up the
+// spans of a scope by service and operation, busiest operations first.
+// Span times are in nanoseconds.
+func generateOperationStatsQuery(params TracesQueryParams, stream string, logger *slog.Logger) ([]byte, error) {
+	safeStream, err := validateSQLIdentifier(stream)
+	if err != nil {
+		return nil, fmt.Errorf("invalid stream identifier: %w", err)
+	}
+
+	sql := fmt.Sprintf("SELECT service_name, operation_name, count(*) AS span_count, "+
+		"sum(CASE WHEN span_status = 'ERROR' THEN 1 ELSE 0 END) AS error_count, "+
+		"approx_percentile_cont(end_time - start_time, 0.5) AS p50_duration, "+
+		"approx_percentile_cont(end_time - start_time, 0.9) AS p90_duration, "+
+		"approx_percentile_cont(end_time - start_time, 0.99) AS p99_duration "+
+		"FROM %s", safeStream)
+	if conditions := buildFilterConditions(params); len(conditions) > 0 {
+		sql += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	sql += " GROUP BY service_name, operation_name ORDER BY span_count DESC, service_name ASC, operation_name ASC"
+
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"sql":        sql,
+			"start_time": params.StartTime.UnixMicro(),
+			"end_time":   params.EndTime.UnixMicro(),
+			"from":       0,
+			"size":       params.Limit,
+		},
+	}
+
+	logGenerated(logger, query, "operation stats query")
+
+	return json.Marshal(query)
+}
+
+// GetOperationStats queries OpenObserve for the latency percentiles,
+// throughput and error rate of the operations of a scope and time range,
+// computed by OpenObserve rather than from the raw spans.
+func (c *Client) GetOperationStats(ctx context.Context, params TracesQueryParams) (*OperationStatsResult, error) {
+	if err := ValidateOperationStats(params); err != nil {
+		return nil, err
+	}
+	queryJSON, err := generateOperationStatsQuery(params, c.stream, c.logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate operation stats query: %w", err)
+	}
+
+	result := &OperationStatsResult{Operations: []OperationStats{}}
+	openObserveResp, err := c.executeSearchQuery(ctx, queryJSON)
+	if errors.Is(err, ErrStreamNotFound) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.TookMs = openObserveResp.Took
+
+	window := params.EndTime.Sub(params.StartTime).Seconds()
+	for _, hit := range openObserveResp.Hits {
+		var stats OperationStats
+		stats.Service, _ = hit["service_name"].(string)
+		stats.Operation, _ = hit["operation_name"].(string)
+		if v, ok := hit["span_count"].(json.Number); ok {
+			n, _ := v.Int64()
+			stats.SpanCount = int(n)
+		}
+		if v, ok := hit["error_count"].(json.Number); ok {
+			n, _ := v.Int64()
+			stats.ErrorCount = int(n)
+		}
+		// The percentiles are interpolated, so they may not be integers.
+		for field, dst := range map[string]*int64{
+			"p50_duration": &stats.P50DurationNs,
+			"p90_duration": &stats.P90DurationNs,
+			"p99_duration": &stats.P99DurationNs,
+		} {
+			if v, ok := hit[field].(json.Number); ok {
+				f, _ := v.Float64()
+				*dst = int64(f)
+			}
+		}
+		if stats.SpanCount > 0 {
+			stats.ErrorRate = float64(stats.ErrorCount) / float64(stats.SpanCount)
+		}
+		stats.Throughput = float64(stats.SpanCount) / window
+		result.Operations = append(result.Operations, stats)
+	}
+	return result, nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testOperationStatsParams() TracesQueryParams {
+	return TracesQueryParams{
+		StartTime: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2025, 1, 1, 0, 10, 0, 0, time.UTC),
+		Limit:     DefaultOperationStatsLimit,
+		Scope:     Scope{Namespace: "test-ns", ComponentID: "comp-1"},
+	}
+}
+
+func TestGenerateOperationStatsQuery(t *testing.T) {
+	result, err := generateOperationStatsQuery(testOperationStatsParams(), "default", testLogger())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var query struct {
+		Query struct {
+			SQL  string `json:"sql"`
+			Size int    `json:"size"`
+		} `json:"query"`
+	}
+	if err := json.Unmarshal(result, &query); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	sql := query.Query.SQL
+	for _, want := range []string{
+		"approx_percentile_cont(end_time - start_time, 0.5) AS p50_duration",
+		"approx_percentile_cont(end_time - start_time, 0.9) AS p90_duration",
+		"approx_percentile_cont(end_time - start_time, 0.99) AS p99_duration",
+		"service_openchoreo_dev_component_uid = 'comp-1'",
+		"GROUP BY service_name, operation_name ORDER BY span_count DESC",
+	} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("expected %q in SQL: %s", want, sql)
+		}
+	}
+	if query.Query.Size != DefaultOperationStatsLimit {
+		t.Errorf("expected size %d, got %d", DefaultOperationStatsLimit, query.Query.Size)
+	}
+}
+
+func TestValidateOperationStats(t *testing.T) {
+	if err := ValidateOperationStats(testOperationStatsParams()); err != nil {
+		t.Fatalf("unexpected error for valid params: %v", err)
+	}
+	tests := []struct {
+		name   string
+		modify func(*TracesQueryParams)
+	}{
+		{"zero limit", func(p *TracesQueryParams) { p.Limit = 0 }},
+		{"limit too large", func(p *TracesQueryParams) { p.Limit = MaxOperationStatsLimit + 1 }},
+		{"empty window", func(p *TracesQueryParams) { p.EndTime = p.StartTime }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := testOperationStatsParams()
+			tt.modify(&params)
+			if err := ValidateOperationStats(params); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestGetOperationStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"took":4,"hits":[` +
+			`{"service_name":"cart","operation_name":"GET /cart","span_count":1200,"error_count":30,` +
+			`"p50_duration":1000,"p90_duration":5000.7,"p99_duration":20000}]}`))
+	}))
+	defer server.Close()
+
+	result, err := newTestClient(server.URL).GetOperationStats(context.Background(), testOperationStatsParams())
+	if err != nil {
+		t.Fatalf("GetOperationStats() error = %v", err)
+	}
+	if result.TookMs != 4 || len(result.Operations) != 1 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	want := OperationStats{
+		Service: "cart", Operation: "GET /cart", SpanCount: 1200, ErrorCount: 30, ErrorRate: 0.025,
+		Throughput: 2, P50DurationNs: 1000, P90DurationNs: 5000, P99DurationNs: 20000,
+	}
+	if result.Operations[0] != want {
+		t.Errorf("got %+v, want %+v", result.Operations[0], want)
+	}
+}
+
+func TestGetOperationStats_StreamNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":20002,"message":"Search stream not found: default"}`))
+	}))
+	defer server.Close()
+
+	result, err := newTestClient(server.URL).GetOperationStats(context.Background(), testOperationStatsParams())
+	if err != nil {
+		t.Fatalf("GetOperationStats() error = %v", err)
+	}
+	if result.Operations == nil || len(result.Operations) != 0 {
+		t.Errorf("expected no operations, got %+v", result.Operations)
+	}
+}
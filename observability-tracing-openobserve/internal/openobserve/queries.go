@@ -10,6 +10,8 @@ import (
 	"math"
 	"regexp"
 	"strings"
+
+	ooclient "github.com/openchoreo/community-modules/common/openobserve"
 )
 
 // MaxQueryLimit is the upper bound for query result sizes to prevent
@@ -33,37 +35,42 @@ func validateSQLIdentifier(identifier string) (string, error) {
 
 // escapeSQLString escapes backslashes and single quotes in a value
 // to prevent SQL injection when interpolating into single-quoted SQL strings.
-func escapeSQLString(value string) string {
-	value = strings.ReplaceAll(value, `\`, `\\`)
-	value = strings.ReplaceAll(value, `'`, `''`)
-	return value
-}
+var escapeSQLString = ooclient.EscapeSQLString
 
-// generateTracesListQuery generates the OpenObserve query to list individual spans
-// so that traces can be grouped in Go code to identify root spans.
+// generateTracesListQuery generates the OpenObserve query listing a page of
+// traces, summarized from their spans, sorted by params.SortBy and starting
+// at params.Offset.
 func generateTracesListQuery(params TracesQueryParams, stream string, logger *slog.Logger) ([]byte, error) {
 	safeStream, err := validateSQLIdentifier(stream)
 	if err != nil {
 		return nil, fmt.Errorf("invalid stream identifier: %w", err)
 	}
 
-	sql := fmt.Sprintf(
-		"SELECT trace_id, span_id, operation_name, span_kind, "+
-			"start_time, end_time, reference_parent_span_id, span_status "+
-			"FROM %s",
-		safeStream,
-	)
-
 	conditions := buildFilterConditions(params)
-	if len(conditions) > 0 {
-		sql += " WHERE " + strings.Join(conditions, " AND ")
+	if !params.Filters.IsZero() {
+		// All spans of the traces with a matching span are summarized, so
+		// that the traces are reported whole.
+		filters, err := spanFilterConditions(params.Filters)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, fmt.Sprintf("trace_id IN (SELECT DISTINCT trace_id FROM %s WHERE %s)",
+			safeStream, strings.Join(append(buildFilterConditions(params), filters...), " AND ")))
+	}
+	if len(conditions) == 0 {
+		conditions = []string{"trace_id != ''"}
 	}
+	sql := traceSummarySQL(safeStream, conditions)
 
-	// Add sort order
+	sortColumn := "start_time"
+	if params.SortBy == TraceSortDuration {
+		sortColumn = "duration_ns"
+	}
+	// trace_id breaks ties so that pages do not overlap.
 	if params.SortOrder == "asc" || params.SortOrder == "ASC" {
-		sql += " ORDER BY start_time ASC"
+		sql += " ORDER BY " + sortColumn + " ASC, trace_id ASC"
 	} else {
-		sql += " ORDER BY start_time DESC"
+		sql += " ORDER BY " + sortColumn + " DESC, trace_id ASC"
 	}
 
 	limit := params.Limit
@@ -78,18 +85,45 @@ func generateTracesListQuery(params TracesQueryParams, stream string, logger *sl
 			"sql":        sql,
 			"start_time": params.StartTime.UnixMicro(),
 			"end_time":   params.EndTime.UnixMicro(),
-			"from":       0,
+			"from":       max(params.Offset, 0),
 			"size":       limit,
 		},
 	}
 
-	if logger.Enabled(nil, slog.LevelDebug) {
-		if prettyJSON, err := json.MarshalIndent(query, "", "    "); err == nil {
-			fmt.Printf("Generated query to list traces:\n")
-			fmt.Println(string(prettyJSON))
-		}
+	logGenerated(logger, query, "query to list traces")
+
+	return json.Marshal(query)
+}
+
+// generateTraceTreeQuery generates the OpenObserve query listing the span
+// and parent span IDs of the traces traceIDs, to check that their span trees
+// have no gaps. At most size spans are listed.
+func generateTraceTreeQuery(params TracesQueryParams, traceIDs []string, size int, stream string, logger *slog.Logger) ([]byte, error) {
+	safeStream, err := validateSQLIdentifier(stream)
+	if err != nil {
+		return nil, fmt.Errorf("invalid stream identifier: %w", err)
 	}
 
+	quoted := make([]string, len(traceIDs))
+	for i, id := range traceIDs {
+		quoted[i] = "'" + escapeSQLString(id) + "'"
+	}
+	conditions := append(buildFilterConditions(params), "trace_id IN ("+strings.Join(quoted, ", ")+")")
+	sql := fmt.Sprintf("SELECT trace_id, span_id, reference_parent_span_id FROM %s WHERE %s",
+		safeStream, strings.Join(conditions, " AND "))
+
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"sql":        sql,
+			"start_time": params.StartTime.UnixMicro(),
+			"end_time":   params.EndTime.UnixMicro(),
+			"from":       0,
+			"size":       size,
+		},
+	}
+
+	logGenerated(logger, query, "query to list the span trees of traces")
+
 	return json.Marshal(query)
 }
 
@@ -104,12 +138,12 @@ func generateSpansListQuery(params TracesQueryParams, stream string, logger *slo
 		return nil, fmt.Errorf("invalid stream identifier: %w", err)
 	}
 
-	sql := fmt.Sprintf(
-		"SELECT span_id, operation_name, span_kind, start_time, end_time, "+
-			"end_time - start_time as duration, reference_parent_span_id, span_status, status_message "+
-			"FROM %s WHERE %s",
-		safeStream, strings.Join(conditions, " AND "),
-	)
+	columns := "span_id, operation_name, span_kind, start_time, end_time, " +
+		"end_time - start_time as duration, reference_parent_span_id, span_status, status_message"
+	if params.IncludeEvents {
+		columns += ", events"
+	}
+	sql := fmt.Sprintf("SELECT %s FROM %s WHERE %s", columns, safeStream, strings.Join(conditions, " AND "))
 
 	// Add sort order
 	if params.SortOrder == "asc" || params.SortOrder == "ASC" {
@@ -136,22 +170,19 @@ func generateSpansListQuery(params TracesQueryParams, stream string, logger *slo
 		"timeout": 0,
 	}
 
-	if logger.Enabled(nil, slog.LevelDebug) {
-		if prettyJSON, err := json.MarshalIndent(query, "", "    "); err == nil {
-			fmt.Printf("Generated query to list spans for trace %s:\n", params.TraceID)
-			fmt.Println(string(prettyJSON))
-		}
-	}
+	logGenerated(logger, query, "query to list spans for trace %s", params.TraceID)
 
 	return json.Marshal(query)
 }
 
-// generateSpanDetailQuery generates the OpenObserve query to fetch a single span by traceId and spanId.
+// generateSpanDetailQuery generates the OpenObserve query to fetch a single span by spanId,
+// and by traceId when set. The query spans all time unless a time range is set.
 func generateSpanDetailQuery(params TracesQueryParams, stream string, logger *slog.Logger) ([]byte, error) {
-	conditions := []string{
-		"trace_id = '" + escapeSQLString(params.TraceID) + "'",
-		"span_id = '" + escapeSQLString(params.SpanID) + "'",
+	var conditions []string
+	if params.TraceID != "" {
+		conditions = append(conditions, "trace_id = '"+escapeSQLString(params.TraceID)+"'")
 	}
+	conditions = append(conditions, "span_id = '"+escapeSQLString(params.SpanID)+"'")
 
 	safeStream, err := validateSQLIdentifier(stream)
 	if err != nil {
@@ -173,14 +204,14 @@ func generateSpanDetailQuery(params TracesQueryParams, stream string, logger *sl
 		},
 		"timeout": 0,
 	}
-
-	if logger.Enabled(nil, slog.LevelDebug) {
-		if prettyJSON, err := json.MarshalIndent(query, "", "    "); err == nil {
-			fmt.Printf("Generated query to fetch span detail (trace=%s, span=%s):\n", params.TraceID, params.SpanID)
-			fmt.Println(string(prettyJSON))
-		}
+	if !params.StartTime.IsZero() && !params.EndTime.IsZero() {
+		q := query["query"].(map[string]interface{})
+		q["start_time"] = params.StartTime.UnixMicro()
+		q["end_time"] = params.EndTime.UnixMicro()
 	}
 
+	logGenerated(logger, query, "query to fetch span detail (trace=%s, span=%s)", params.TraceID, params.SpanID)
+
 	return json.Marshal(query)
 }
 
@@ -197,6 +228,11 @@ func generateTracesCountQuery(params TracesQueryParams, stream string, logger *s
 	)
 
 	conditions := buildFilterConditions(params)
+	filters, err := spanFilterConditions(params.Filters)
+	if err != nil {
+		return nil, err
+	}
+	conditions = append(conditions, filters...)
 	if len(conditions) > 0 {
 		sql += " WHERE " + strings.Join(conditions, " AND ")
 	}
@@ -211,12 +247,7 @@ func generateTracesCountQuery(params TracesQueryParams, stream string, logger *s
 		},
 	}
 
-	if logger.Enabled(nil, slog.LevelDebug) {
-		if prettyJSON, err := json.MarshalIndent(query, "", "    "); err == nil {
-			fmt.Printf("Generated count query for traces:\n")
-			fmt.Println(string(prettyJSON))
-		}
-	}
+	logGenerated(logger, query, "count query for traces")
 
 	return json.Marshal(query)
 }
@@ -247,13 +278,46 @@ func generateSpansCountQuery(params TracesQueryParams, stream string, logger *sl
 		},
 	}
 
-	if logger.Enabled(nil, slog.LevelDebug) {
-		if prettyJSON, err := json.MarshalIndent(query, "", "    "); err == nil {
-			fmt.Printf("Generated count query for spans (trace=%s):\n", params.TraceID)
-			fmt.Println(string(prettyJSON))
-		}
+	logGenerated(logger, query, "count query for spans (trace=%s)", params.TraceID)
+
+	return json.Marshal(query)
+}
+
+// generateServicesListQuery generates the OpenObserve query to list the distinct
+// services emitting spans within the scope, with their span counts.
+func generateServicesListQuery(params TracesQueryParams, stream string, logger *slog.Logger) ([]byte, error) {
+	safeStream, err := validateSQLIdentifier(stream)
+	if err != nil {
+		return nil, fmt.Errorf("invalid stream identifier: %w", err)
+	}
+
+	sql := fmt.Sprintf(
+		"SELECT service_name, service_openchoreo_dev_component_uid as component_uid, "+
+			"service_openchoreo_dev_project_uid as project_uid, "+
+			"service_openchoreo_dev_environment_uid as environment_uid, "+
+			"count(*) as span_count, max(start_time) as last_seen "+
+			"FROM %s",
+		safeStream,
+	)
+
+	conditions := buildFilterConditions(params)
+	if len(conditions) > 0 {
+		sql += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	sql += " GROUP BY service_name, component_uid, project_uid, environment_uid ORDER BY span_count DESC"
+
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"sql":        sql,
+			"start_time": params.StartTime.UnixMicro(),
+			"end_time":   params.EndTime.UnixMicro(),
+			"from":       0,
+			"size":       MaxQueryLimit,
+		},
 	}
 
+	logGenerated(logger, query, "query to list services")
+
 	return json.Marshal(query)
 }
 
@@ -276,3 +340,40 @@ func buildFilterConditions(params TracesQueryParams) []string {
 
 	return conditions
 }
+
+// generateTraceLogsQuery generates the OpenObserve query for the log lines of
+// a trace, oldest first. Log records are correlated through their trace_id
+// and span_id fields, as written by OpenTelemetry log bridges.
+func generateTraceLogsQuery(params TracesQueryParams, stream string, logger *slog.Logger) ([]byte, error) {
+	safeStream, err := validateSQLIdentifier(stream)
+	if err != nil {
+		return nil, fmt.Errorf("invalid stream identifier: %w", err)
+	}
+
+	sql := fmt.Sprintf(
+		"SELECT _timestamp, log, logLevel, span_id FROM %s WHERE trace_id = '%s' ORDER BY _timestamp ASC",
+		safeStream, escapeSQLString(params.TraceID),
+	)
+
+	limit := params.Limit
+	if limit <= 0 {
+		limit = 100
+	} else if limit > MaxQueryLimit {
+		limit = MaxQueryLimit
+	}
+
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"sql":        sql,
+			"start_time": params.StartTime.UnixMicro(),
+			"end_time":   params.EndTime.UnixMicro(),
+			"from":       0,
+			"size":       limit,
+		},
+		"timeout": 0,
+	}
+
+	logGenerated(logger, query, "query to fetch logs for trace %s", params.TraceID)
+
+	return json.Marshal(query)
+}
@@ -4,10 +4,9 @@
 package openobserve
 
 import (
+	"bytes"
 	"encoding/json"
-	"io"
 	"log/slog"
-	"os"
 	"strings"
 	"testing"
 	"time"
@@ -288,6 +287,70 @@ func TestGenerateTracesListQuery(t *testing.T) {
 	})
 }
 
+func TestGenerateTracesListQuery_Pagination(t *testing.T) {
+	params := TracesQueryParams{
+		Scope:     Scope{Namespace: "test-ns"},
+		StartTime: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+		Limit:     20,
+		SortBy:    TraceSortDuration,
+		Offset:    40,
+	}
+
+	result, err := generateTracesListQuery(params, "mystream", testLogger())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var query struct {
+		Query struct {
+			SQL  string `json:"sql"`
+			From int    `json:"from"`
+			Size int    `json:"size"`
+		} `json:"query"`
+	}
+	if err := json.Unmarshal(result, &query); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	for _, want := range []string{
+		"count(*) AS span_count",
+		"max(end_time) - min(start_time) AS duration_ns",
+		"GROUP BY trace_id ORDER BY duration_ns DESC, trace_id ASC",
+	} {
+		if !strings.Contains(query.Query.SQL, want) {
+			t.Errorf("expected %q in SQL: %s", want, query.Query.SQL)
+		}
+	}
+	if query.Query.From != 40 || query.Query.Size != 20 {
+		t.Errorf("expected the traces 40 to 60, got from %d size %d", query.Query.From, query.Query.Size)
+	}
+}
+
+func TestGenerateTraceTreeQuery(t *testing.T) {
+	params := TracesQueryParams{Scope: Scope{Namespace: "test-ns"}}
+	result, err := generateTraceTreeQuery(params, []string{"t1", "it's"}, 7, "mystream", testLogger())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var query struct {
+		Query struct {
+			SQL  string `json:"sql"`
+			Size int    `json:"size"`
+		} `json:"query"`
+	}
+	if err := json.Unmarshal(result, &query); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	want := "SELECT trace_id, span_id, reference_parent_span_id FROM mystream " +
+		"WHERE service_openchoreo_dev_namespace = 'test-ns' AND trace_id IN ('t1', 'it''s')"
+	if query.Query.SQL != want || query.Query.Size != 7 {
+		t.Errorf("unexpected query: %+v", query.Query)
+	}
+
+	if _, err := generateTraceTreeQuery(params, []string{"t1"}, 1, "bad;stream", testLogger()); err == nil {
+		t.Error("expected error for invalid stream identifier")
+	}
+}
+
 func TestGenerateSpansListQuery(t *testing.T) {
 	startTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
 	endTime := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
@@ -412,6 +475,29 @@ func TestGenerateSpanDetailQuery(t *testing.T) {
 		}
 	})
 
+	t.Run("span ID only within time range", func(t *testing.T) {
+		params := TracesQueryParams{
+			SpanID:    "span-1",
+			StartTime: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+		}
+
+		result, err := generateSpanDetailQuery(params, "mystream", testLogger())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var query map[string]interface{}
+		json.Unmarshal(result, &query)
+		q := query["query"].(map[string]interface{})
+		if sql := q["sql"].(string); sql != "SELECT * FROM mystream WHERE span_id = 'span-1'" {
+			t.Errorf("unexpected SQL: %s", sql)
+		}
+		if q["start_time"].(float64) != float64(params.StartTime.UnixMicro()) {
+			t.Errorf("expected start_time %d, got %v", params.StartTime.UnixMicro(), q["start_time"])
+		}
+	})
+
 	t.Run("SQL injection in traceID and spanID", func(t *testing.T) {
 		params := TracesQueryParams{
 			TraceID: "trace'; DROP TABLE spans;--",
@@ -448,31 +534,14 @@ func TestGenerateSpanDetailQuery(t *testing.T) {
 	})
 }
 
-// captureStdout runs fn and returns whatever it wrote to os.Stdout.
-func captureStdout(t *testing.T, fn func()) string {
+// captureDebugLogs runs fn with a debug-level logger and returns what it
+// logged.
+func captureDebugLogs(t *testing.T, fn func(logger *slog.Logger)) string {
 	t.Helper()
 
-	origStdout := os.Stdout
-	r, w, err := os.Pipe()
-	if err != nil {
-		t.Fatalf("failed to create pipe: %v", err)
-	}
-	os.Stdout = w
-
-	fn()
-
-	w.Close()
-	os.Stdout = origStdout
-
-	captured, err := io.ReadAll(r)
-	if err != nil {
-		t.Fatalf("failed to read captured output: %v", err)
-	}
-	return string(captured)
-}
-
-func debugLogger() *slog.Logger {
-	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	var logs bytes.Buffer
+	fn(slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	return logs.String()
 }
 
 func TestGenerateTracesListQuery_DebugLogging(t *testing.T) {
@@ -488,9 +557,9 @@ func TestGenerateTracesListQuery_DebugLogging(t *testing.T) {
 	}
 
 	var result []byte
-	output := captureStdout(t, func() {
+	output := captureDebugLogs(t, func(logger *slog.Logger) {
 		var err error
-		result, err = generateTracesListQuery(params, "mystream", debugLogger())
+		result, err = generateTracesListQuery(params, "mystream", logger)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -523,9 +592,9 @@ func TestGenerateSpansListQuery_DebugLogging(t *testing.T) {
 	}
 
 	var result []byte
-	output := captureStdout(t, func() {
+	output := captureDebugLogs(t, func(logger *slog.Logger) {
 		var err error
-		result, err = generateSpansListQuery(params, "mystream", debugLogger())
+		result, err = generateSpansListQuery(params, "mystream", logger)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -554,9 +623,9 @@ func TestGenerateSpanDetailQuery_DebugLogging(t *testing.T) {
 	}
 
 	var result []byte
-	output := captureStdout(t, func() {
+	output := captureDebugLogs(t, func(logger *slog.Logger) {
 		var err error
-		result, err = generateSpanDetailQuery(params, "mystream", debugLogger())
+		result, err = generateSpanDetailQuery(params, "mystream", logger)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -580,3 +649,40 @@ func TestGenerateSpanDetailQuery_DebugLogging(t *testing.T) {
 		t.Errorf("expected debug output to contain trace_id filter, got: %s", output)
 	}
 }
+
+func TestGenerateServicesListQuery(t *testing.T) {
+	params := TracesQueryParams{
+		StartTime: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+		Scope:     Scope{Namespace: "test-ns", EnvironmentID: "env-1"},
+	}
+
+	result, err := generateServicesListQuery(params, "default", testLogger())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var q map[string]interface{}
+	if err := json.Unmarshal(result, &q); err != nil {
+		t.Fatalf("failed to unmarshal query: %v", err)
+	}
+	query := q["query"].(map[string]interface{})
+	sql := query["sql"].(string)
+	for _, want := range []string{
+		"count(*) as span_count",
+		"service_openchoreo_dev_namespace = 'test-ns'",
+		"service_openchoreo_dev_environment_uid = 'env-1'",
+		"GROUP BY service_name, component_uid, project_uid, environment_uid",
+	} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("expected %q in SQL: %s", want, sql)
+		}
+	}
+	if query["size"].(float64) != float64(MaxQueryLimit) {
+		t.Errorf("expected size %d, got %v", MaxQueryLimit, query["size"])
+	}
+
+	if _, err := generateServicesListQuery(params, "bad stream;", testLogger()); err == nil {
+		t.Error("expected error for invalid stream identifier")
+	}
+}
@@ -0,0 +1,287 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/rollups"
+)
+
+// rollupBatchSize is the number of rollup rows read per query when rolling
+// up the traces stream.
+const rollupBatchSize = 5000
+
+// rollupStreamType is the type of the stream of the latency rollups, written
+// through the JSON ingestion API.
+const rollupStreamType = "logs"
+
+// minuteMicros is the length of a minute in the microseconds of _timestamp.
+const minuteMicros = int64(time.Minute / time.Microsecond)
+
+// rollupScopeColumns are the columns of the latency rollups holding the
+// scope of their spans, in the order of the scope columns of the traces
+// stream they are read from.
+var rollupScopeColumns = []string{"namespace", "project_uid", "environment_uid", "component_uid"}
+
+// SetLatencyRollups serves latency histograms from the latency rollups of
+// the traces stream, the number of spans per scope, operation, duration
+// bucket and minute, kept in stream by a rollups.Job building them with the
+// client. They are read for the minutes of coverage.
+func (c *Client) SetLatencyRollups(stream string, coverage *rollups.Coverage) {
+	c.rollupStream = stream
+	c.rollupCoverage = coverage
+}
+
+// RollupRange returns the first and last minutes of the latency rollups.
+func (c *Client) RollupRange(ctx context.Context) (time.Time, time.Time, bool, error) {
+	safeStream, err := validateSQLIdentifier(c.rollupStream)
+	if err != nil {
+		return time.Time{}, time.Time{}, false, fmt.Errorf("invalid stream identifier: %w", err)
+	}
+	queryJSON, err := json.Marshal(map[string]interface{}{
+		"query": map[string]interface{}{
+			"sql":        "SELECT min(_timestamp) AS first, max(_timestamp) AS last FROM " + safeStream,
+			"start_time": 0,
+			"end_time":   time.Now().UnixMicro(),
+			"from":       0,
+			"size":       1,
+		},
+	})
+	if err != nil {
+		return time.Time{}, time.Time{}, false, err
+	}
+	resp, err := c.executeSearch(ctx, rollupStreamType, queryJSON)
+	if errors.Is(err, ErrStreamNotFound) {
+		return time.Time{}, time.Time{}, false, nil
+	}
+	if err != nil {
+		return time.Time{}, time.Time{}, false, fmt.Errorf("failed to read the latency rollups range: %w", err)
+	}
+	if len(resp.Hits) == 0 {
+		return time.Time{}, time.Time{}, false, nil
+	}
+	first, okFirst := resp.Hits[0]["first"].(json.Number)
+	last, okLast := resp.Hits[0]["last"].(json.Number)
+	if !okFirst || !okLast {
+		return time.Time{}, time.Time{}, false, nil
+	}
+	firstMicros, _ := first.Int64()
+	lastMicros, _ := last.Int64()
+	return time.UnixMicro(firstMicros).UTC(), time.UnixMicro(lastMicros).UTC(), true, nil
+}
+
+// BuildRollups counts the spans of each scope, operation and duration
+// bucket of DefaultGrowthFactor in each minute of [start, end) and writes
+// the counts to the rollups stream, all in one request.
+func (c *Client) BuildRollups(ctx context.Context, start, end time.Time) error {
+	var records []map[string]interface{}
+	skipped := 0
+	for offset := 0; ; offset += rollupBatchSize {
+		queryJSON, err := generateLatencyRollupQuery(start, end, offset, c.stream)
+		if err != nil {
+			return err
+		}
+		resp, err := c.executeSearchQuery(ctx, queryJSON)
+		if errors.Is(err, ErrStreamNotFound) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to roll up span latencies: %w", err)
+		}
+		for _, hit := range resp.Hits {
+			// Spans without an end time have no bucket; their rows are
+			// left out rather than failing the whole rollup.
+			minute, okMinute := intColumn(hit, "minute")
+			bucket, okBucket := intColumn(hit, "bucket")
+			count, okCount := intColumn(hit, "span_count")
+			if !okMinute || !okBucket || !okCount {
+				skipped++
+				continue
+			}
+			record := map[string]interface{}{
+				"_timestamp":     minute,
+				"service_name":   hit["service_name"],
+				"operation_name": hit["operation_name"],
+				"bucket":         bucket,
+				"span_count":     count,
+			}
+			for _, column := range rollupScopeColumns {
+				record[column] = hit[column]
+			}
+			records = append(records, record)
+		}
+		if len(resp.Hits) < rollupBatchSize {
+			break
+		}
+	}
+	if skipped > 0 {
+		c.logger.Warn("Skipped latency rollup rows with null or missing columns",
+			slog.Int("rows", skipped), slog.Time("start", start), slog.Time("end", end))
+	}
+	if len(records) == 0 {
+		return nil
+	}
+	if err := c.Ingest(ctx, c.rollupStream, records); err != nil {
+		return fmt.Errorf("failed to write latency rollups: %w", err)
+	}
+	return nil
+}
+
+// latencyBucketExpr returns the SQL expression of the exponential duration
+// bucket of a span for a growth factor. Span times are in nanoseconds.
+func latencyBucketExpr(factor string) string {
+	return fmt.Sprintf(
+		"CASE WHEN end_time - start_time < %d THEN 0 "+
+			"ELSE CAST(floor(ln((end_time - start_time) / %d.0) / ln(%s)) AS BIGINT) + 1 END",
+		LatencyHistogramBaseNs, LatencyHistogramBaseNs, factor,
+	)
+}
+
+// generateLatencyRollupQuery generates the query counting the spans of each
+// scope, operation and duration bucket per minute between start and end, a
+// page of rollupBatchSize rows at offset.
+func generateLatencyRollupQuery(start, end time.Time, offset int, stream string) ([]byte, error) {
+	safeStream, err := validateSQLIdentifier(stream)
+	if err != nil {
+		return nil, fmt.Errorf("invalid stream identifier: %w", err)
+	}
+
+	keys := []string{"minute"}
+	selects := []string{fmt.Sprintf("_timestamp - _timestamp %% %d AS minute", minuteMicros)}
+	for _, column := range rollupScopeColumns {
+		keys = append(keys, column)
+		selects = append(selects, "service_openchoreo_dev_"+column+" AS "+column)
+	}
+	keys = append(keys, "service_name", "operation_name", "bucket")
+	selects = append(selects, "service_name", "operation_name", latencyBucketExpr("2")+" AS bucket")
+
+	sql := fmt.Sprintf("SELECT %s, count(*) AS span_count FROM %s GROUP BY %s ORDER BY %s",
+		strings.Join(selects, ", "), safeStream, strings.Join(keys, ", "), strings.Join(keys, ", "))
+	return json.Marshal(map[string]interface{}{
+		"query": map[string]interface{}{
+			"sql":        sql,
+			"start_time": start.UnixMicro(),
+			"end_time":   end.UnixMicro(),
+			"from":       offset,
+			"size":       rollupBatchSize,
+		},
+	})
+}
+
+// latencyRollupsWindow returns the whole minutes of the window of a latency
+// histogram that can be read from the latency rollups. Only histograms of
+// DefaultGrowthFactor can.
+func (c *Client) latencyRollupsWindow(params LatencyHistogramParams) (time.Time, time.Time, bool) {
+	if c.rollupStream == "" || params.GrowthFactor != DefaultGrowthFactor {
+		return time.Time{}, time.Time{}, false
+	}
+	return c.rollupCoverage.Split(params.StartTime, params.EndTime)
+}
+
+// getLatencyBucketsFromRollups reads the minutes of [innerStart, innerEnd)
+// of a latency histogram from the latency rollups, and the partial minutes
+// at the edges of its window from the traces stream, and adds up the counts
+// of each bucket.
+func (c *Client) getLatencyBucketsFromRollups(ctx context.Context, params LatencyHistogramParams, innerStart, innerEnd time.Time) (*OpenObserveResponse, error) {
+	queryJSON, err := generateLatencyRollupHistogramQuery(params, innerStart, innerEnd, c.rollupStream, c.logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate latency histogram query: %w", err)
+	}
+	resp, err := c.executeSearch(ctx, rollupStreamType, queryJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, edge := range [][2]time.Time{{params.StartTime, innerStart}, {innerEnd, params.EndTime}} {
+		if !edge[0].Before(edge[1]) {
+			continue
+		}
+		edgeParams := params
+		edgeParams.StartTime, edgeParams.EndTime = edge[0], edge[1]
+		edgeJSON, err := generateLatencyHistogramQuery(edgeParams, c.stream, c.logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate latency histogram query: %w", err)
+		}
+		edgeResp, err := c.executeSearchQuery(ctx, edgeJSON)
+		if errors.Is(err, ErrStreamNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		resp.Took += edgeResp.Took
+		resp.Hits = mergeLatencyHits(resp.Hits, edgeResp.Hits)
+	}
+	return resp, nil
+}
+
+// generateLatencyRollupHistogramQuery generates the query of the latency
+// histogram of a scope between start and end from the latency rollups.
+func generateLatencyRollupHistogramQuery(params LatencyHistogramParams, start, end time.Time, stream string, logger *slog.Logger) ([]byte, error) {
+	safeStream, err := validateSQLIdentifier(stream)
+	if err != nil {
+		return nil, fmt.Errorf("invalid stream identifier: %w", err)
+	}
+
+	sql := fmt.Sprintf("SELECT bucket, sum(span_count) AS span_count FROM %s", safeStream)
+	var conditions []string
+	scope := params.Scope
+	for i, value := range []string{scope.Namespace, scope.ProjectID, scope.EnvironmentID, scope.ComponentID} {
+		if value != "" {
+			conditions = append(conditions, rollupScopeColumns[i]+" = '"+escapeSQLString(value)+"'")
+		}
+	}
+	if params.Operation != "" {
+		conditions = append(conditions, "operation_name = '"+escapeSQLString(params.Operation)+"'")
+	}
+	if len(conditions) > 0 {
+		sql += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	sql += " GROUP BY bucket ORDER BY bucket ASC"
+
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"sql":        sql,
+			"start_time": start.UnixMicro(),
+			"end_time":   end.UnixMicro(),
+			"from":       0,
+			"size":       MaxQueryLimit,
+		},
+	}
+
+	logGenerated(logger, query, "latency rollup histogram query")
+
+	return json.Marshal(query)
+}
+
+// mergeLatencyHits adds the span counts of the bucket hits of b to those of
+// a, and returns them in ascending bucket order.
+func mergeLatencyHits(a, b []map[string]interface{}) []map[string]interface{} {
+	counts := map[int64]int64{}
+	for _, hit := range slices.Concat(a, b) {
+		index, okIndex := intColumn(hit, "bucket")
+		count, okCount := intColumn(hit, "span_count")
+		if !okIndex || !okCount {
+			continue
+		}
+		counts[index] += count
+	}
+	merged := make([]map[string]interface{}, 0, len(counts))
+	for _, index := range slices.Sorted(maps.Keys(counts)) {
+		merged = append(merged, map[string]interface{}{
+			"bucket":     json.Number(fmt.Sprint(index)),
+			"span_count": json.Number(fmt.Sprint(counts[index])),
+		})
+	}
+	return merged
+}
@@ -0,0 +1,173 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/rollups"
+)
+
+// searchQuery decodes the query of a search request.
+func searchQuery(r *http.Request) (sql string, start, end int64) {
+	var body struct {
+		Query struct {
+			SQL       string `json:"sql"`
+			StartTime int64  `json:"start_time"`
+			EndTime   int64  `json:"end_time"`
+		} `json:"query"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&body)
+	return body.Query.SQL, body.Query.StartTime, body.Query.EndTime
+}
+
+func TestBuildRollups(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	var ingested []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/default/latency_rollups/_json" {
+			_ = json.NewDecoder(r.Body).Decode(&ingested)
+			w.Write([]byte(`{"code":200,"status":[{"name":"latency_rollups","successful":1,"failed":0}]}`))
+			return
+		}
+		sql, _, _ := searchQuery(r)
+		if !strings.Contains(sql, "_timestamp - _timestamp % 60000000 AS minute") ||
+			!strings.Contains(sql, "service_openchoreo_dev_component_uid AS component_uid") ||
+			!strings.Contains(sql, "/ ln(2)) AS BIGINT) + 1 END AS bucket") {
+			t.Errorf("unexpected rollup query %q", sql)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"took":1,"hits":[{"minute":1767268800000000,"namespace":"acme","component_uid":"c1",` +
+			`"service_name":"checkout","operation_name":"GET /cart","bucket":11,"span_count":7}]}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	client.SetLatencyRollups("latency_rollups", &rollups.Coverage{})
+	if err := client.BuildRollups(context.Background(), start, start.Add(time.Hour)); err != nil {
+		t.Fatalf("BuildRollups() error = %v", err)
+	}
+	if len(ingested) != 1 {
+		t.Fatalf("expected one rollup record, got %v", ingested)
+	}
+	record := ingested[0]
+	if record["_timestamp"].(float64) != float64(start.UnixMicro()) || record["operation_name"] != "GET /cart" ||
+		record["bucket"].(float64) != 11 || record["span_count"].(float64) != 7 || record["component_uid"] != "c1" {
+		t.Errorf("unexpected rollup record %v", record)
+	}
+}
+
+func TestBuildRollups_NullColumns(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	var ingested []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/default/latency_rollups/_json" {
+			_ = json.NewDecoder(r.Body).Decode(&ingested)
+			w.Write([]byte(`{"code":200,"status":[{"name":"latency_rollups","successful":1,"failed":0}]}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"took":1,"hits":[` +
+			`{"minute":1767268800000000,"namespace":"acme","operation_name":"GET /cart","bucket":null,"span_count":3},` +
+			`{"namespace":"acme","operation_name":"GET /cart","bucket":4,"span_count":2},` +
+			`{"minute":1767268800000000,"namespace":"acme","operation_name":"GET /cart","bucket":11,"span_count":7}]}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	client.SetLatencyRollups("latency_rollups", &rollups.Coverage{})
+	if err := client.BuildRollups(context.Background(), start, start.Add(time.Hour)); err != nil {
+		t.Fatalf("BuildRollups() error = %v", err)
+	}
+	if len(ingested) != 1 || ingested[0]["bucket"].(float64) != 11 {
+		t.Errorf("expected the rows with null or missing columns to be skipped, got %v", ingested)
+	}
+
+	merged := mergeLatencyHits(
+		[]map[string]interface{}{{"bucket": json.Number("2"), "span_count": json.Number("5")}, {"bucket": nil, "span_count": json.Number("1")}},
+		[]map[string]interface{}{{"bucket": json.Number("2"), "span_count": nil}},
+	)
+	if len(merged) != 1 || merged[0]["span_count"] != json.Number("5") {
+		t.Errorf("unexpected merged hits %v", merged)
+	}
+}
+
+// coveredBuilder builds nothing, so that a job covers its window at once.
+type coveredBuilder struct{}
+
+func (coveredBuilder) RollupRange(context.Context) (time.Time, time.Time, bool, error) {
+	return time.Time{}, time.Time{}, false, nil
+}
+
+func (coveredBuilder) BuildRollups(context.Context, time.Time, time.Time) error { return nil }
+
+func TestGetLatencyHistogram_FromRollups(t *testing.T) {
+	var mu sync.Mutex
+	var rawQueries int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sql, _, _ := searchQuery(r)
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(sql, "FROM latency_rollups") {
+			if r.URL.Query().Get("type") != "logs" ||
+				!strings.Contains(sql, "WHERE namespace = 'acme' AND component_uid = 'c1' AND operation_name = 'GET /cart'") {
+				t.Errorf("unexpected rollup histogram query %q of %s", sql, r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"took":2,"hits":[{"bucket":10,"span_count":6},{"bucket":11,"span_count":2}]}`))
+			return
+		}
+		mu.Lock()
+		rawQueries++
+		mu.Unlock()
+		w.Write([]byte(`{"took":1,"hits":[{"bucket":11,"span_count":1},{"bucket":12,"span_count":1}]}`))
+	}))
+	defer server.Close()
+
+	now := time.Date(2026, 1, 1, 14, 0, 0, 0, time.UTC)
+	coverage := &rollups.Coverage{}
+	if err := rollups.NewJob(coveredBuilder{}, coverage, time.Minute, 2*time.Hour, testLogger()).Update(context.Background(), now); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	client := newTestClient(server.URL)
+	client.SetLatencyRollups("latency_rollups", coverage)
+
+	start := time.Date(2026, 1, 1, 12, 0, 30, 0, time.UTC)
+	params := LatencyHistogramParams{
+		TracesQueryParams: TracesQueryParams{
+			StartTime: start,
+			EndTime:   start.Add(30 * time.Minute),
+			Scope:     Scope{Namespace: "acme", ComponentID: "c1"},
+		},
+		Operation:    "GET /cart",
+		GrowthFactor: DefaultGrowthFactor,
+		Percentiles:  []float64{50},
+	}
+	result, err := client.GetLatencyHistogram(context.Background(), params)
+	if err != nil {
+		t.Fatalf("GetLatencyHistogram() error = %v", err)
+	}
+	if !result.FromRollups || rawQueries != 2 || result.Total != 12 || result.TookMs != 4 {
+		t.Fatalf("expected the rollups and both edges added up, got %+v after %d raw queries", result, rawQueries)
+	}
+	if len(result.Buckets) != 3 || result.Buckets[1].Index != 11 || result.Buckets[1].Count != 4 {
+		t.Errorf("unexpected buckets %+v", result.Buckets)
+	}
+
+	// Other growth factors are read from the spans.
+	rawQueries = 0
+	params.GrowthFactor = 1.5
+	result, err = client.GetLatencyHistogram(context.Background(), params)
+	if err != nil {
+		t.Fatalf("GetLatencyHistogram() error = %v", err)
+	}
+	if result.FromRollups || rawQueries != 1 {
+		t.Errorf("expected a single query of the spans, got %d", rawQueries)
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+)
+
+// Bounds and defaults of service graph queries.
+const (
+	DefaultServiceGraphEdges = 200
+	MaxServiceGraphEdges     = 1000
+)
+
+// ServiceGraphEdge aggregates the calls from one service to another: the
+// spans of the target service whose parent span belongs to the source.
+type ServiceGraphEdge struct {
+	Source     string `json:"source"`
+	Target     string `json:"target"`
+	CallCount  int    `json:"callCount"`
+	ErrorCount int    `json:"errorCount"`
+	// P50DurationNs and P95DurationNs are the latency of the calls as seen
+	// by the target service.
+	P50DurationNs int64 `json:"p50DurationNs"`
+	P95DurationNs int64 `json:"p95DurationNs"`
+}
+
+// ServiceGraphNode is a service of the graph with the calls it received
+// and made.
+type ServiceGraphNode struct {
+	Name     string `json:"name"`
+	CallsIn  int    `json:"callsIn"`
+	ErrorsIn int    `json:"errorsIn"`
+	CallsOut int    `json:"callsOut"`
+}
+
+// ServiceGraphResult represents the response of a service graph query.
+type ServiceGraphResult struct {
+	Nodes  []ServiceGraphNode `json:"nodes"`
+	Edges  []ServiceGraphEdge `json:"edges"`
+	TookMs int                `json:"tookMs"`
+}
+
+// ValidateServiceGraph returns an error if the number of edges is out of
+// range.
+func ValidateServiceGraph(params TracesQueryParams) error {
+	if params.Limit < 1 || params.Limit > MaxServiceGraphEdges {
+		return fmt.Errorf("limit must be between 1 and %d", MaxServiceGraphEdges)
+	}
+	return nil
+}
+
+// generateServiceGraphQuery generates the OpenObserve query joining the
+// spans of a scope to their parent spans and rolling up the calls that
+// cross service boundaries by caller and callee, busiest first. The scope
+// applies to the callee, so calls into the scope from outside it are
+// included. Span times are in nanoseconds.
+func generateServiceGraphQuery(params TracesQueryParams, stream string, logger *slog.Logger) ([]byte, error) {
+	safeStream, err := validateSQLIdentifier(stream)
+	if err != nil {
+		return nil, fmt.Errorf("invalid stream identifier: %w", err)
+	}
+
+	children := fmt.Sprintf("SELECT trace_id, reference_parent_span_id, service_name, span_status, "+
+		"end_time - start_time AS duration FROM %s", safeStream)
+	if conditions := buildFilterConditions(params); len(conditions) > 0 {
+		children += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	sql := fmt.Sprintf("SELECT p.service_name AS source, c.service_name AS target, count(*) AS call_count, "+
+		"sum(CASE WHEN c.span_status = 'ERROR' THEN 1 ELSE 0 END) AS error_count, "+
+		"approx_percentile_cont(c.duration, 0.5) AS p50_duration, "+
+		"approx_percentile_cont(c.duration, 0.95) AS p95_duration "+
+		"FROM (%s) AS c JOIN (SELECT trace_id, span_id, service_name FROM %s) AS p "+
+		"ON c.trace_id = p.trace_id AND c.reference_parent_span_id = p.span_id "+
+		"WHERE c.service_name <> p.service_name "+
+		"GROUP BY p.service_name, c.service_name ORDER BY call_count DESC, source ASC, target ASC",
+		children, safeStream)
+
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"sql":        sql,
+			"start_time": params.StartTime.UnixMicro(),
+			"end_time":   params.EndTime.UnixMicro(),
+			"from":       0,
+			"size":       params.Limit,
+		},
+	}
+
+	logGenerated(logger, query, "service graph query")
+
+	return json.Marshal(query)
+}
+
+// GetServiceGraph queries OpenObserve for the calls between the services of
+// a scope and time range, and returns them as call edges with the services
+// they connect as nodes.
+func (c *Client) GetServiceGraph(ctx context.Context, params TracesQueryParams) (*ServiceGraphResult, error) {
+	if err := ValidateServiceGraph(params); err != nil {
+		return nil, err
+	}
+	queryJSON, err := generateServiceGraphQuery(params, c.stream, c.logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate service graph query: %w", err)
+	}
+
+	result := &ServiceGraphResult{Nodes: []ServiceGraphNode{}, Edges: []ServiceGraphEdge{}}
+	openObserveResp, err := c.executeSearchQuery(ctx, queryJSON)
+	if errors.Is(err, ErrStreamNotFound) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.TookMs = openObserveResp.Took
+
+	nodes := make(map[string]*ServiceGraphNode)
+	node := func(name string) *ServiceGraphNode {
+		if n, ok := nodes[name]; ok {
+			return n
+		}
+		n := &ServiceGraphNode{Name: name}
+		nodes[name] = n
+		return n
+	}
+	for _, hit := range openObserveResp.Hits {
+		var edge ServiceGraphEdge
+		edge.Source, _ = hit["source"].(string)
+		edge.Target, _ = hit["target"].(string)
+		if v, ok := hit["call_count"].(json.Number); ok {
+			n, _ := v.Int64()
+			edge.CallCount = int(n)
+		}
+		if v, ok := hit["error_count"].(json.Number); ok {
+			n, _ := v.Int64()
+			edge.ErrorCount = int(n)
+		}
+		// The percentiles are interpolated, so they may not be integers.
+		if v, ok := hit["p50_duration"].(json.Number); ok {
+			f, _ := v.Float64()
+			edge.P50DurationNs = int64(f)
+		}
+		if v, ok := hit["p95_duration"].(json.Number); ok {
+			f, _ := v.Float64()
+			edge.P95DurationNs = int64(f)
+		}
+		result.Edges = append(result.Edges, edge)
+
+		node(edge.Source).CallsOut += edge.CallCount
+		target := node(edge.Target)
+		target.CallsIn += edge.CallCount
+		target.ErrorsIn += edge.ErrorCount
+	}
+	for _, n := range nodes {
+		result.Nodes = append(result.Nodes, *n)
+	}
+	sort.Slice(result.Nodes, func(i, j int) bool { return result.Nodes[i].Name < result.Nodes[j].Name })
+	return result, nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testServiceGraphParams() TracesQueryParams {
+	return TracesQueryParams{
+		StartTime: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+		Limit:     DefaultServiceGraphEdges,
+		Scope:     Scope{Namespace: "test-ns", ProjectID: "proj-1"},
+	}
+}
+
+func TestGenerateServiceGraphQuery(t *testing.T) {
+	result, err := generateServiceGraphQuery(testServiceGraphParams(), "default", testLogger())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var query struct {
+		Query struct {
+			SQL  string `json:"sql"`
+			Size int    `json:"size"`
+		} `json:"query"`
+	}
+	if err := json.Unmarshal(result, &query); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	sql := query.Query.SQL
+	for _, want := range []string{
+		"FROM default WHERE service_openchoreo_dev_namespace = 'test-ns' AND service_openchoreo_dev_project_uid = 'proj-1') AS c",
+		"JOIN (SELECT trace_id, span_id, service_name FROM default) AS p",
+		"ON c.trace_id = p.trace_id AND c.reference_parent_span_id = p.span_id",
+		"WHERE c.service_name <> p.service_name",
+		"approx_percentile_cont(c.duration, 0.5) AS p50_duration",
+		"approx_percentile_cont(c.duration, 0.95) AS p95_duration",
+		"GROUP BY p.service_name, c.service_name ORDER BY call_count DESC",
+	} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("expected %q in SQL: %s", want, sql)
+		}
+	}
+	if query.Query.Size != DefaultServiceGraphEdges {
+		t.Errorf("expected size %d, got %d", DefaultServiceGraphEdges, query.Query.Size)
+	}
+
+	if _, err := generateServiceGraphQuery(testServiceGraphParams(), "bad stream", testLogger()); err == nil {
+		t.Error("expected error for invalid stream")
+	}
+}
+
+func TestValidateServiceGraph(t *testing.T) {
+	if err := ValidateServiceGraph(testServiceGraphParams()); err != nil {
+		t.Fatalf("unexpected error for valid params: %v", err)
+	}
+	for _, limit := range []int{0, MaxServiceGraphEdges + 1} {
+		params := testServiceGraphParams()
+		params.Limit = limit
+		if err := ValidateServiceGraph(params); err == nil {
+			t.Errorf("expected error for limit %d", limit)
+		}
+	}
+}
+
+func TestGetServiceGraph(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"took":5,"hits":[` +
+			`{"source":"gateway","target":"cart","call_count":40,"error_count":4,"p50_duration":1200.5,"p95_duration":9000},` +
+			`{"source":"cart","target":"db","call_count":80,"error_count":0,"p50_duration":300,"p95_duration":700}]}`))
+	}))
+	defer server.Close()
+
+	result, err := newTestClient(server.URL).GetServiceGraph(context.Background(), testServiceGraphParams())
+	if err != nil {
+		t.Fatalf("GetServiceGraph() error = %v", err)
+	}
+	if result.TookMs != 5 || len(result.Edges) != 2 || len(result.Nodes) != 3 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	edge := result.Edges[0]
+	if edge.Source != "gateway" || edge.Target != "cart" || edge.CallCount != 40 || edge.ErrorCount != 4 ||
+		edge.P50DurationNs != 1200 || edge.P95DurationNs != 9000 {
+		t.Errorf("unexpected edge %+v", edge)
+	}
+	want := []ServiceGraphNode{
+		{Name: "cart", CallsIn: 40, ErrorsIn: 4, CallsOut: 80},
+		{Name: "db", CallsIn: 80},
+		{Name: "gateway", CallsOut: 40},
+	}
+	for i, node := range result.Nodes {
+		if node != want[i] {
+			t.Errorf("node %d = %+v, want %+v", i, node, want[i])
+		}
+	}
+}
+
+func TestGetServiceGraph_StreamNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":20002,"message":"Search stream not found: default"}`))
+	}))
+	defer server.Close()
+
+	result, err := newTestClient(server.URL).GetServiceGraph(context.Background(), testServiceGraphParams())
+	if err != nil {
+		t.Fatalf("GetServiceGraph() error = %v", err)
+	}
+	if result.Nodes == nil || result.Edges == nil || len(result.Edges) != 0 {
+		t.Errorf("expected an empty graph, got %+v", result)
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import "time"
+
+// SkewCorrection records the offset applied to a span's timestamps by CorrectClockSkew.
+type SkewCorrection struct {
+	SpanID   string `json:"spanId"`
+	OffsetNs int64  `json:"offsetNs"`
+}
+
+// CorrectClockSkew adjusts spans in place whose clocks are skewed relative to
+// their parent span, and returns the corrections that were applied.
+//
+// Spans produced on different nodes can carry skewed clocks, which shows up as
+// a child starting before its parent. Such a child is moved inside its parent:
+// when it fits, it is centered in the parent (assuming equal network latency on
+// the way in and out), otherwise its start is aligned with the parent's start.
+// The offset applied to a span is carried down to its descendants, since they
+// were most likely recorded against the same clock. Spans whose parent is not
+// part of the set are left untouched.
+func CorrectClockSkew(spans []SpanEntry) []SkewCorrection {
+	index := make(map[string]int, len(spans))
+	for i := range spans {
+		if spans[i].SpanID != "" {
+			index[spans[i].SpanID] = i
+		}
+	}
+
+	children := make(map[string][]int, len(spans))
+	var roots []int
+	for i := range spans {
+		if _, ok := index[spans[i].ParentSpanID]; ok && spans[i].ParentSpanID != spans[i].SpanID {
+			children[spans[i].ParentSpanID] = append(children[spans[i].ParentSpanID], i)
+		} else {
+			roots = append(roots, i)
+		}
+	}
+
+	type item struct {
+		idx      int
+		inherits int64
+	}
+
+	var corrections []SkewCorrection
+	visited := make(map[int]bool, len(spans))
+	queue := make([]item, 0, len(roots))
+	for _, r := range roots {
+		queue = append(queue, item{idx: r})
+	}
+
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		if visited[cur.idx] {
+			continue
+		}
+		visited[cur.idx] = true
+
+		span := &spans[cur.idx]
+		offset := cur.inherits
+		shiftSpan(span, cur.inherits)
+
+		if p, ok := index[span.ParentSpanID]; ok && p != cur.idx {
+			if adj := skewAdjustment(&spans[p], span); adj != 0 {
+				shiftSpan(span, adj)
+				offset += adj
+			}
+		}
+
+		if offset != 0 {
+			corrections = append(corrections, SkewCorrection{SpanID: span.SpanID, OffsetNs: offset})
+		}
+		for _, c := range children[span.SpanID] {
+			queue = append(queue, item{idx: c, inherits: offset})
+		}
+	}
+
+	return corrections
+}
+
+// skewAdjustment returns the offset in nanoseconds needed to move child inside
+// parent, or 0 when the child does not start before its parent.
+func skewAdjustment(parent, child *SpanEntry) int64 {
+	if !child.StartTime.Before(parent.StartTime) {
+		return 0
+	}
+	parentDur := parent.EndTime.Sub(parent.StartTime)
+	childDur := child.EndTime.Sub(child.StartTime)
+	target := parent.StartTime
+	if childDur >= 0 && childDur <= parentDur {
+		target = parent.StartTime.Add((parentDur - childDur) / 2)
+	}
+	return target.Sub(child.StartTime).Nanoseconds()
+}
+
+// shiftSpan moves both timestamps of span by offsetNs nanoseconds.
+func shiftSpan(span *SpanEntry, offsetNs int64) {
+	if offsetNs == 0 {
+		return
+	}
+	d := time.Duration(offsetNs)
+	span.StartTime = span.StartTime.Add(d)
+	span.EndTime = span.EndTime.Add(d)
+}
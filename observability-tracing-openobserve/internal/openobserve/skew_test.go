@@ -0,0 +1,82 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCorrectClockSkew(t *testing.T) {
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(ms int) time.Time { return base.Add(time.Duration(ms) * time.Millisecond) }
+
+	t.Run("no skew leaves spans untouched", func(t *testing.T) {
+		spans := []SpanEntry{
+			{SpanID: "root", StartTime: at(0), EndTime: at(100)},
+			{SpanID: "child", ParentSpanID: "root", StartTime: at(10), EndTime: at(50)},
+		}
+		if got := CorrectClockSkew(spans); len(got) != 0 {
+			t.Errorf("expected no corrections, got %+v", got)
+		}
+		if !spans[1].StartTime.Equal(at(10)) {
+			t.Errorf("child should not move, got start %v", spans[1].StartTime)
+		}
+	})
+
+	t.Run("child starting before parent is centered and offset propagates", func(t *testing.T) {
+		spans := []SpanEntry{
+			{SpanID: "root", StartTime: at(0), EndTime: at(100)},
+			{SpanID: "child", ParentSpanID: "root", StartTime: at(-70), EndTime: at(-10)},
+			{SpanID: "grandchild", ParentSpanID: "child", StartTime: at(-60), EndTime: at(-20)},
+		}
+		got := CorrectClockSkew(spans)
+
+		// child (60ms) is centered in root (100ms): starts at 20ms, offset +90ms.
+		if !spans[1].StartTime.Equal(at(20)) || !spans[1].EndTime.Equal(at(80)) {
+			t.Errorf("unexpected child times: %v - %v", spans[1].StartTime, spans[1].EndTime)
+		}
+		// grandchild is shifted along with its parent.
+		if !spans[2].StartTime.Equal(at(30)) || !spans[2].EndTime.Equal(at(70)) {
+			t.Errorf("unexpected grandchild times: %v - %v", spans[2].StartTime, spans[2].EndTime)
+		}
+
+		want := map[string]int64{
+			"child":      (90 * time.Millisecond).Nanoseconds(),
+			"grandchild": (90 * time.Millisecond).Nanoseconds(),
+		}
+		if len(got) != len(want) {
+			t.Fatalf("expected %d corrections, got %+v", len(want), got)
+		}
+		for _, c := range got {
+			if want[c.SpanID] != c.OffsetNs {
+				t.Errorf("unexpected correction for %s: %d", c.SpanID, c.OffsetNs)
+			}
+		}
+	})
+
+	t.Run("child longer than parent is aligned to parent start", func(t *testing.T) {
+		spans := []SpanEntry{
+			{SpanID: "root", StartTime: at(0), EndTime: at(10)},
+			{SpanID: "async", ParentSpanID: "root", StartTime: at(-5), EndTime: at(200)},
+		}
+		got := CorrectClockSkew(spans)
+		if len(got) != 1 || got[0].OffsetNs != (5*time.Millisecond).Nanoseconds() {
+			t.Fatalf("unexpected corrections: %+v", got)
+		}
+		if !spans[1].StartTime.Equal(at(0)) {
+			t.Errorf("expected async span to start with parent, got %v", spans[1].StartTime)
+		}
+	})
+
+	t.Run("orphans and self-parented spans are left untouched", func(t *testing.T) {
+		spans := []SpanEntry{
+			{SpanID: "orphan", ParentSpanID: "missing", StartTime: at(-50), EndTime: at(0)},
+			{SpanID: "self", ParentSpanID: "self", StartTime: at(-50), EndTime: at(0)},
+		}
+		if got := CorrectClockSkew(spans); len(got) != 0 {
+			t.Errorf("expected no corrections, got %+v", got)
+		}
+	})
+}
@@ -0,0 +1,122 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	ooclient "github.com/openchoreo/community-modules/common/openobserve"
+)
+
+// StreamRoutes route the traces queries of namespaces, projects or
+// environments to streams. See ParseStreamRoutes.
+type StreamRoutes = ooclient.StreamRoutes
+
+// ParseStreamRoutes parses a comma-separated list of stream routes, such as
+// "namespace:acme=acme_traces,environment:<uid>=prod_traces|default".
+var ParseStreamRoutes = ooclient.ParseStreamRoutes
+
+// SetStreamRoutes routes the traces queries of the scopes selected by
+// routes, for example those of per-environment or per-data-plane streams,
+// to their streams. Scopes routed to several streams, or only partly
+// routed, are queried in each stream, stream included, and their traces
+// merged. Spans, services and pinned traces are read from stream only.
+func (c *Client) SetStreamRoutes(routes StreamRoutes) {
+	c.routes = routes
+}
+
+// traceStreams returns the streams holding the spans of a traces query.
+func (c *Client) traceStreams(params TracesQueryParams) []string {
+	scope := ooclient.StreamScope{
+		Namespace: params.Scope.Namespace,
+		ProjectID: params.Scope.ProjectID,
+	}
+	if params.Scope.EnvironmentID != "" {
+		scope.EnvironmentIDs = []string{params.Scope.EnvironmentID}
+	}
+	return c.routes.Streams(scope, c.stream)
+}
+
+// getTracesAcross runs a traces query against each of streams in parallel,
+// reading each from the start to the end of the page, and merges their
+// traces into the page of the query, in its order. The total is the sum of
+// theirs, so a trace with spans in several streams is counted in each.
+func (c *Client) getTracesAcross(ctx context.Context, params TracesQueryParams, streams []string) (*TracesResult, error) {
+	offset := max(params.Offset, 0)
+	perStream := params
+	perStream.Limit, perStream.Offset = offset+params.Limit, 0
+	results := make([]*TracesResult, len(streams))
+	err := forEachStream(ctx, streams, func(ctx context.Context, i int, stream string) error {
+		var err error
+		results[i], err = c.getTraces(ctx, perStream, stream)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	merged := &TracesResult{Traces: []TraceEntry{}}
+	for _, r := range results {
+		merged.Traces = append(merged.Traces, r.Traces...)
+		merged.Total += r.Total
+		merged.TookMs = max(merged.TookMs, r.TookMs)
+	}
+	sortTraces(merged.Traces, params.SortBy, params.SortOrder)
+	start := min(offset, len(merged.Traces))
+	merged.Traces = merged.Traces[start:min(start+params.Limit, len(merged.Traces))]
+	merged.HasMore = len(merged.Traces) > 0 && offset+len(merged.Traces) < merged.Total
+	return merged, nil
+}
+
+// forEachStream calls query for each of streams in parallel, and returns
+// the first error. The queries still running are canceled once one fails,
+// so the first error is the failure rather than a cancellation it caused.
+func forEachStream(ctx context.Context, streams []string, query func(ctx context.Context, i int, stream string) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		wg    sync.WaitGroup
+		once  sync.Once
+		first error
+	)
+	for i, stream := range streams {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := query(ctx, i, stream); err != nil {
+				once.Do(func() {
+					first = err
+					cancel()
+				})
+			}
+		}()
+	}
+	wg.Wait()
+	return first
+}
+
+// sortTraces orders traces as a traces query does: by start time or
+// duration, with trace_id breaking ties.
+func sortTraces(traces []TraceEntry, sortBy, sortOrder string) {
+	asc := sortOrder == "asc" || sortOrder == "ASC"
+	slices.SortFunc(traces, func(a, b TraceEntry) int {
+		var c int
+		if sortBy == TraceSortDuration {
+			c = cmp.Compare(a.DurationNs, b.DurationNs)
+		} else {
+			c = a.StartTime.Compare(b.StartTime)
+		}
+		if !asc {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+		return strings.Compare(a.TraceID, b.TraceID)
+	})
+}
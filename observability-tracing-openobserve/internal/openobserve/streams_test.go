@@ -0,0 +1,124 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+)
+
+var fromStream = regexp.MustCompile(`FROM (\w+)`)
+
+// newStreamsServer returns a server answering the traces queries of each
+// stream of traces with its traces, given as their start times in seconds,
+// each lasting one second, and records the size of the list query of each.
+func newStreamsServer(t *testing.T, traces map[string][]int64) (*httptest.Server, map[string]int) {
+	t.Helper()
+	var mu sync.Mutex
+	sizes := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query struct {
+				SQL  string `json:"sql"`
+				Size int    `json:"size"`
+			} `json:"query"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		m := fromStream.FindStringSubmatch(body.Query.SQL)
+		if m == nil {
+			t.Errorf("no stream in query %q", body.Query.SQL)
+			return
+		}
+		stream := m[1]
+		resp := OpenObserveResponse{Took: len(stream), Hits: []map[string]interface{}{}}
+		switch {
+		case strings.Contains(body.Query.SQL, "count(distinct trace_id)"):
+			resp.Hits = append(resp.Hits, map[string]interface{}{"total": json.Number(fmt.Sprint(len(traces[stream])))})
+		case strings.Contains(body.Query.SQL, "GROUP BY trace_id"):
+			mu.Lock()
+			sizes[stream] = body.Query.Size
+			mu.Unlock()
+			for i, start := range traces[stream] {
+				resp.Hits = append(resp.Hits, map[string]interface{}{
+					"trace_id":     fmt.Sprintf("%s-%d", stream, i),
+					"root_span_id": "root",
+					"span_count":   json.Number("1"),
+					"start_time":   json.Number(fmt.Sprint(start * 1e9)),
+					"end_time":     json.Number(fmt.Sprint((start + 1) * 1e9)),
+				})
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+	return server, sizes
+}
+
+func TestGetTraces_AcrossStreams(t *testing.T) {
+	server, sizes := newStreamsServer(t, map[string][]int64{
+		"default":     {5, 2},
+		"prod_traces": {6, 4, 1},
+	})
+	routes, err := ParseStreamRoutes("environment:prod=prod_traces")
+	if err != nil {
+		t.Fatalf("ParseStreamRoutes() error = %v", err)
+	}
+	client := newTestClient(server.URL)
+	client.SetStreamRoutes(routes)
+
+	// The namespace spans both streams.
+	result, err := client.GetTraces(context.Background(), TracesQueryParams{
+		Scope:  Scope{Namespace: "acme"},
+		Limit:  2,
+		Offset: 1,
+	})
+	if err != nil {
+		t.Fatalf("GetTraces() error = %v", err)
+	}
+	var ids []string
+	for _, trace := range result.Traces {
+		ids = append(ids, trace.TraceID)
+	}
+	if strings.Join(ids, ",") != "default-0,prod_traces-1" {
+		t.Errorf("unexpected merged page %v", ids)
+	}
+	if result.Total != 5 || !result.HasMore || result.TookMs != len("prod_traces") {
+		t.Errorf("unexpected result %+v", result)
+	}
+	if sizes["default"] != 3 || sizes["prod_traces"] != 3 {
+		t.Errorf("expected each stream to be read up to the end of the page, got sizes %v", sizes)
+	}
+
+	// The environment is covered by its route, so its stream is queried alone.
+	result, err = client.GetTraces(context.Background(), TracesQueryParams{
+		Scope: Scope{Namespace: "acme", EnvironmentID: "prod"},
+		Limit: 10,
+	})
+	if err != nil {
+		t.Fatalf("GetTraces() error = %v", err)
+	}
+	if len(result.Traces) != 3 || result.Traces[0].TraceID != "prod_traces-0" || result.Total != 3 {
+		t.Errorf("expected the traces of prod_traces only, got %+v", result)
+	}
+}
+
+func TestSortTraces(t *testing.T) {
+	traces := []TraceEntry{
+		{TraceID: "b", DurationNs: 2},
+		{TraceID: "a", DurationNs: 2},
+		{TraceID: "c", DurationNs: 3},
+	}
+	sortTraces(traces, TraceSortDuration, "desc")
+	if traces[0].TraceID != "c" || traces[1].TraceID != "a" || traces[2].TraceID != "b" {
+		t.Errorf("unexpected order %+v", traces)
+	}
+}
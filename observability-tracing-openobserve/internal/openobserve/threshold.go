@@ -0,0 +1,188 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// DefaultThresholdBuckets is the number of time buckets a latency threshold
+// query splits its window into when no interval is requested.
+const DefaultThresholdBuckets = 60
+
+// LatencyThresholdParams holds parameters for latency threshold queries.
+type LatencyThresholdParams struct {
+	TracesQueryParams
+	// Operation is the operation name of the spans counted.
+	Operation string
+	// Service optionally restricts the spans to those of this service.
+	Service string
+	// Threshold is the duration a span must exceed to count as slow.
+	Threshold time.Duration
+	// Interval is the length of the time buckets, in whole seconds.
+	Interval time.Duration
+}
+
+// LatencyThresholdBucket counts the spans that started in a time bucket and
+// those of them that exceeded the threshold.
+type LatencyThresholdBucket struct {
+	Time      time.Time `json:"time"`
+	SpanCount int       `json:"spanCount"`
+	OverCount int       `json:"overThresholdCount"`
+	// OverRatio is OverCount / SpanCount, or 0 when the bucket has no span.
+	OverRatio float64 `json:"overThresholdRatio"`
+}
+
+// LatencyThresholdResult represents the response of a latency threshold
+// query. Buckets cover the whole window, including those without spans.
+type LatencyThresholdResult struct {
+	Operation       string                   `json:"operation"`
+	Service         string                   `json:"service,omitempty"`
+	ThresholdNs     int64                    `json:"thresholdNs"`
+	IntervalSeconds int64                    `json:"intervalSeconds"`
+	Buckets         []LatencyThresholdBucket `json:"buckets"`
+	SpanCount       int                      `json:"spanCount"`
+	OverCount       int                      `json:"overThresholdCount"`
+	OverRatio       float64                  `json:"overThresholdRatio"`
+	TookMs          int                      `json:"tookMs"`
+}
+
+// DefaultThresholdInterval returns the interval splitting the window of
+// params into DefaultThresholdBuckets buckets, rounded up to a whole second.
+func DefaultThresholdInterval(params TracesQueryParams) time.Duration {
+	interval := params.EndTime.Sub(params.StartTime) / DefaultThresholdBuckets
+	if rounded := interval.Truncate(time.Second); rounded < interval {
+		interval = rounded + time.Second
+	}
+	return max(interval, time.Second)
+}
+
+// ValidateLatencyThreshold returns an error if the operation is missing, the
+// threshold is not positive, or the interval is not a whole number of
+// seconds or splits the window into more than MaxQueryLimit buckets.
+func ValidateLatencyThreshold(params LatencyThresholdParams) error {
+	if params.Operation == "" {
+		return errors.New("operation is required")
+	}
+	if params.Threshold <= 0 {
+		return errors.New("threshold must be a positive duration")
+	}
+	if params.Interval < time.Second || params.Interval%time.Second != 0 {
+		return errors.New("interval must be a whole number of seconds")
+	}
+	if buckets := params.EndTime.Sub(params.StartTime) / params.Interval; buckets >= MaxQueryLimit {
+		return fmt.Errorf("interval must split the time range into fewer than %d buckets", MaxQueryLimit)
+	}
+	return nil
+}
+
+// generateLatencyThresholdQuery generates the OpenObserve query counting, per
+// time bucket, the spans of an operation and those longer than the
+// threshold. Span times are in nanoseconds and _timestamp in microseconds.
+func generateLatencyThresholdQuery(params LatencyThresholdParams, stream string, logger *slog.Logger) ([]byte, error) {
+	safeStream, err := validateSQLIdentifier(stream)
+	if err != nil {
+		return nil, fmt.Errorf("invalid stream identifier: %w", err)
+	}
+
+	intervalMicros := params.Interval.Microseconds()
+	sql := fmt.Sprintf("SELECT _timestamp - _timestamp %% %d AS bucket, count(*) AS span_count, "+
+		"sum(CASE WHEN end_time - start_time > %d THEN 1 ELSE 0 END) AS over_count FROM %s",
+		intervalMicros, params.Threshold.Nanoseconds(), safeStream)
+
+	conditions := buildFilterConditions(params.TracesQueryParams)
+	conditions = append(conditions, "operation_name = '"+escapeSQLString(params.Operation)+"'")
+	if params.Service != "" {
+		conditions = append(conditions, "service_name = '"+escapeSQLString(params.Service)+"'")
+	}
+	sql += " WHERE " + strings.Join(conditions, " AND ")
+	sql += " GROUP BY bucket ORDER BY bucket ASC"
+
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"sql":        sql,
+			"start_time": params.StartTime.UnixMicro(),
+			"end_time":   params.EndTime.UnixMicro(),
+			"from":       0,
+			"size":       MaxQueryLimit,
+		},
+	}
+
+	logGenerated(logger, query, "latency threshold query")
+
+	return json.Marshal(query)
+}
+
+// GetLatencyThreshold queries OpenObserve for the share of the spans of an
+// operation exceeding a duration threshold in each time bucket of the given
+// scope and time range. Buckets are aligned to multiples of the interval
+// since the Unix epoch.
+func (c *Client) GetLatencyThreshold(ctx context.Context, params LatencyThresholdParams) (*LatencyThresholdResult, error) {
+	if err := ValidateLatencyThreshold(params); err != nil {
+		return nil, err
+	}
+
+	queryJSON, err := generateLatencyThresholdQuery(params, c.stream, c.logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate latency threshold query: %w", err)
+	}
+	openObserveResp, err := c.executeSearchQuery(ctx, queryJSON)
+	if err != nil && !errors.Is(err, ErrStreamNotFound) {
+		return nil, err
+	}
+
+	type counts struct{ spans, over int }
+	byBucket := make(map[int64]counts)
+	result := &LatencyThresholdResult{
+		Operation:       params.Operation,
+		Service:         params.Service,
+		ThresholdNs:     params.Threshold.Nanoseconds(),
+		IntervalSeconds: int64(params.Interval / time.Second),
+		Buckets:         []LatencyThresholdBucket{},
+	}
+	if openObserveResp != nil {
+		result.TookMs = openObserveResp.Took
+		for _, hit := range openObserveResp.Hits {
+			// Rows without a bucket or span count are skipped; a null sum
+			// of slow spans counts none.
+			bucket, okBucket := intColumn(hit, "bucket")
+			spans, okSpans := intColumn(hit, "span_count")
+			if !okBucket || !okSpans {
+				continue
+			}
+			over, _ := intColumn(hit, "over_count")
+			byBucket[bucket] = counts{spans: int(spans), over: int(over)}
+		}
+	}
+
+	intervalMicros := params.Interval.Microseconds()
+	start := params.StartTime.UnixMicro()
+	for bucket := start - start%intervalMicros; bucket < params.EndTime.UnixMicro(); bucket += intervalMicros {
+		n := byBucket[bucket]
+		result.Buckets = append(result.Buckets, LatencyThresholdBucket{
+			Time:      time.UnixMicro(bucket).UTC(),
+			SpanCount: n.spans,
+			OverCount: n.over,
+			OverRatio: overRatio(n.over, n.spans),
+		})
+		result.SpanCount += n.spans
+		result.OverCount += n.over
+	}
+	result.OverRatio = overRatio(result.OverCount, result.SpanCount)
+	return result, nil
+}
+
+// overRatio returns over / spans, or 0 when there are no spans.
+func overRatio(over, spans int) float64 {
+	if spans == 0 {
+		return 0
+	}
+	return float64(over) / float64(spans)
+}
@@ -0,0 +1,143 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testThresholdParams() LatencyThresholdParams {
+	return LatencyThresholdParams{
+		TracesQueryParams: TracesQueryParams{
+			StartTime: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC),
+			Scope:     Scope{Namespace: "test-ns"},
+		},
+		Operation: "GET /cart",
+		Threshold: 500 * time.Millisecond,
+		Interval:  10 * time.Minute,
+	}
+}
+
+func TestGenerateLatencyThresholdQuery(t *testing.T) {
+	params := testThresholdParams()
+	params.Service = "cart"
+	result, err := generateLatencyThresholdQuery(params, "default", testLogger())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var query struct {
+		Query struct {
+			SQL string `json:"sql"`
+		} `json:"query"`
+	}
+	if err := json.Unmarshal(result, &query); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	for _, want := range []string{
+		"_timestamp - _timestamp % 600000000 AS bucket",
+		"sum(CASE WHEN end_time - start_time > 500000000 THEN 1 ELSE 0 END) AS over_count",
+		"operation_name = 'GET /cart'",
+		"service_name = 'cart'",
+		"GROUP BY bucket ORDER BY bucket ASC",
+	} {
+		if !strings.Contains(query.Query.SQL, want) {
+			t.Errorf("expected %q in SQL: %s", want, query.Query.SQL)
+		}
+	}
+
+	if _, err := generateLatencyThresholdQuery(params, "bad stream", testLogger()); err == nil {
+		t.Error("expected error for invalid stream")
+	}
+}
+
+func TestValidateLatencyThreshold(t *testing.T) {
+	if err := ValidateLatencyThreshold(testThresholdParams()); err != nil {
+		t.Fatalf("unexpected error for valid params: %v", err)
+	}
+	tests := []struct {
+		name   string
+		modify func(*LatencyThresholdParams)
+	}{
+		{"missing operation", func(p *LatencyThresholdParams) { p.Operation = "" }},
+		{"zero threshold", func(p *LatencyThresholdParams) { p.Threshold = 0 }},
+		{"sub-second interval", func(p *LatencyThresholdParams) { p.Interval = 500 * time.Millisecond }},
+		{"fractional interval", func(p *LatencyThresholdParams) { p.Interval = 1500 * time.Millisecond }},
+		{"too many buckets", func(p *LatencyThresholdParams) { p.Interval = time.Second }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := testThresholdParams()
+			tt.modify(&params)
+			if err := ValidateLatencyThreshold(params); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestDefaultThresholdInterval(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		window time.Duration
+		want   time.Duration
+	}{
+		{time.Hour, time.Minute},
+		{24 * time.Hour, 24 * time.Minute},
+		{100 * time.Second, 2 * time.Second},
+		{time.Second, time.Second},
+	}
+	for _, tt := range tests {
+		params := TracesQueryParams{StartTime: start, EndTime: start.Add(tt.window)}
+		if got := DefaultThresholdInterval(params); got != tt.want {
+			t.Errorf("DefaultThresholdInterval(%v) = %v, want %v", tt.window, got, tt.want)
+		}
+	}
+}
+
+func TestGetLatencyThreshold(t *testing.T) {
+	params := testThresholdParams()
+	second := params.StartTime.Add(10 * time.Minute).UnixMicro()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"took": 3,
+			"hits": []map[string]interface{}{
+				{"bucket": second, "span_count": 40, "over_count": 10},
+				{"bucket": nil, "span_count": 5, "over_count": 5},
+				{"span_count": 5},
+				{"bucket": params.StartTime.Add(20 * time.Minute).UnixMicro(), "span_count": 4, "over_count": nil},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "default", "default", "admin", "pass", testLogger())
+	result, err := client.GetLatencyThreshold(context.Background(), params)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Buckets) != 6 {
+		t.Fatalf("expected a bucket per 10 minutes of the hour, got %+v", result.Buckets)
+	}
+	if b := result.Buckets[1]; !b.Time.Equal(time.UnixMicro(second)) || b.SpanCount != 40 || b.OverCount != 10 || b.OverRatio != 0.25 {
+		t.Errorf("unexpected bucket: %+v", b)
+	}
+	if b := result.Buckets[0]; b.SpanCount != 0 || b.OverRatio != 0 {
+		t.Errorf("expected an empty first bucket, got %+v", b)
+	}
+	if b := result.Buckets[2]; b.SpanCount != 4 || b.OverCount != 0 {
+		t.Errorf("expected a null over count to count no slow span, got %+v", b)
+	}
+	if result.SpanCount != 44 || result.OverCount != 10 || result.ThresholdNs != 500000000 || result.IntervalSeconds != 600 || result.TookMs != 3 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Kinds of span timeline entries.
+const (
+	TimelineKindEvent = "event"
+	TimelineKindLog   = "log"
+)
+
+// SpanEvent is an OpenTelemetry span event, e.g. an exception.
+type SpanEvent struct {
+	Name       string            `json:"name"`
+	Time       time.Time         `json:"time"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// TraceLogEntry is a log line correlated with a span by its trace and span IDs.
+type TraceLogEntry struct {
+	SpanID    string
+	Timestamp time.Time
+	Log       string
+	LogLevel  string
+}
+
+// TimelineEntry is a span event or a log line on a span's timeline.
+type TimelineEntry struct {
+	Time time.Time `json:"time"`
+	// Kind is TimelineKindEvent or TimelineKindLog.
+	Kind string `json:"kind"`
+	// Name is the event name. Events only.
+	Name       string            `json:"name,omitempty"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+	// Log and Level are the log line and its level. Logs only.
+	Log   string `json:"log,omitempty"`
+	Level string `json:"level,omitempty"`
+}
+
+// SpanTimeline is the merged, time-ordered timeline of a span.
+type SpanTimeline struct {
+	Entries []TimelineEntry
+	// Truncated is true when entries beyond the per-span limit were dropped.
+	Truncated bool
+}
+
+// BuildSpanTimelines merges the events of each span and the log lines that
+// reference it into one timeline per span, ordered by time and bounded to
+// limit entries. Every span gets a timeline, possibly empty; log lines of
+// spans that are not in spans are dropped.
+func BuildSpanTimelines(spans []SpanEntry, logs []TraceLogEntry, limit int) map[string]SpanTimeline {
+	entries := make(map[string][]TimelineEntry, len(spans))
+	for _, s := range spans {
+		timeline := make([]TimelineEntry, 0, len(s.Events))
+		for _, e := range s.Events {
+			timeline = append(timeline, TimelineEntry{
+				Time:       e.Time,
+				Kind:       TimelineKindEvent,
+				Name:       e.Name,
+				Attributes: e.Attributes,
+			})
+		}
+		entries[s.SpanID] = timeline
+	}
+	for _, l := range logs {
+		timeline, ok := entries[l.SpanID]
+		if !ok {
+			continue
+		}
+		entries[l.SpanID] = append(timeline, TimelineEntry{
+			Time:  l.Timestamp,
+			Kind:  TimelineKindLog,
+			Log:   l.Log,
+			Level: l.LogLevel,
+		})
+	}
+
+	timelines := make(map[string]SpanTimeline, len(entries))
+	for spanID, timeline := range entries {
+		slices.SortStableFunc(timeline, func(a, b TimelineEntry) int {
+			return a.Time.Compare(b.Time)
+		})
+		truncated := len(timeline) > limit
+		if truncated {
+			timeline = timeline[:limit]
+		}
+		timelines[spanID] = SpanTimeline{Entries: timeline, Truncated: truncated}
+	}
+	return timelines
+}
+
+// parseSpanEvents parses the events column of a span. OpenObserve stores span
+// events as a JSON array of objects holding the event name, its timestamp in
+// nanoseconds and its attributes, as flattened keys or under "attributes".
+func parseSpanEvents(v interface{}) []SpanEvent {
+	raw := parseJSONObjects(v)
+	if raw == nil {
+		return nil
+	}
+
+	events := make([]SpanEvent, 0, len(raw))
+	for _, e := range raw {
+		event := SpanEvent{Attributes: map[string]string{}}
+		for k, v := range e {
+			switch k {
+			case "name":
+				event.Name = fmt.Sprint(v)
+			case "_timestamp", "time_unix_nano", "timeUnixNano":
+				if ns, ok := eventNanos(v); ok {
+					event.Time = time.Unix(0, ns).UTC()
+				}
+			default:
+				addAttribute(event.Attributes, k, v)
+			}
+		}
+		if len(event.Attributes) == 0 {
+			event.Attributes = nil
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+// parseJSONObjects parses a column holding a JSON array of objects, either
+// encoded as a string or already decoded. It returns nil when the column is
+// empty or not such an array.
+func parseJSONObjects(v interface{}) []map[string]interface{} {
+	var raw []map[string]interface{}
+	switch objects := v.(type) {
+	case string:
+		decoder := json.NewDecoder(strings.NewReader(objects))
+		decoder.UseNumber()
+		if objects == "" || decoder.Decode(&raw) != nil {
+			return nil
+		}
+	case []interface{}:
+		raw = make([]map[string]interface{}, 0, len(objects))
+		for _, o := range objects {
+			if m, ok := o.(map[string]interface{}); ok {
+				raw = append(raw, m)
+			}
+		}
+	}
+	return raw
+}
+
+// addAttribute adds the attribute k to attributes, flattening the nested
+// "attributes" object of events and links.
+func addAttribute(attributes map[string]string, k string, v interface{}) {
+	if nested, ok := v.(map[string]interface{}); ok && k == "attributes" {
+		for nk, nv := range nested {
+			attributes[nk] = fmt.Sprint(nv)
+		}
+		return
+	}
+	attributes[k] = fmt.Sprint(v)
+}
+
+// eventNanos converts a JSON number or numeric string to nanoseconds.
+func eventNanos(v interface{}) (int64, bool) {
+	switch n := v.(type) {
+	case json.Number:
+		ns, err := n.Int64()
+		return ns, err == nil
+	case float64:
+		return int64(n), true
+	case string:
+		ns, err := strconv.ParseInt(n, 10, 64)
+		return ns, err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,128 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseSpanEvents(t *testing.T) {
+	events := parseSpanEvents(`[{"name":"exception","_timestamp":1735732800000000123,"exception.message":"boom"},{"name":"retry"}]`)
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+	if events[0].Name != "exception" || events[0].Attributes["exception.message"] != "boom" {
+		t.Errorf("unexpected event %+v", events[0])
+	}
+	if got := events[0].Time.UnixNano(); got != 1735732800000000123 {
+		t.Errorf("expected nanosecond precision, got %d", got)
+	}
+	if events[1].Attributes != nil {
+		t.Errorf("expected no attributes, got %v", events[1].Attributes)
+	}
+
+	for _, v := range []interface{}{nil, "", "not json", 42} {
+		if got := parseSpanEvents(v); got != nil {
+			t.Errorf("parseSpanEvents(%v) = %v, want nil", v, got)
+		}
+	}
+}
+
+func TestParseSpanEvents_NestedAttributes(t *testing.T) {
+	events := parseSpanEvents([]interface{}{
+		map[string]interface{}{
+			"name":       "exception",
+			"attributes": map[string]interface{}{"exception.stacktrace": "at main()"},
+		},
+	})
+	if len(events) != 1 || events[0].Attributes["exception.stacktrace"] != "at main()" {
+		t.Errorf("expected the nested attributes to be flattened, got %+v", events)
+	}
+}
+
+func TestBuildSpanTimelines(t *testing.T) {
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	spans := []SpanEntry{
+		{SpanID: "root", Events: []SpanEvent{{Name: "exception", Time: base.Add(30 * time.Millisecond)}}},
+		{SpanID: "child"},
+		{SpanID: "quiet"},
+	}
+	logs := []TraceLogEntry{
+		{SpanID: "root", Timestamp: base.Add(10 * time.Millisecond), Log: "request received"},
+		{SpanID: "root", Timestamp: base.Add(40 * time.Millisecond), Log: "request failed", LogLevel: "ERROR"},
+		{SpanID: "child", Timestamp: base.Add(20 * time.Millisecond), Log: "calling upstream"},
+		{SpanID: "child", Timestamp: base.Add(25 * time.Millisecond), Log: "upstream timed out"},
+		{SpanID: "other", Timestamp: base, Log: "not part of the result"},
+	}
+
+	timelines := BuildSpanTimelines(spans, logs, 2)
+	if len(timelines) != 3 {
+		t.Fatalf("expected a timeline per span, got %d", len(timelines))
+	}
+
+	root := timelines["root"]
+	if !root.Truncated || len(root.Entries) != 2 {
+		t.Fatalf("expected the root timeline to be truncated to 2 entries, got %+v", root)
+	}
+	if root.Entries[0].Log != "request received" || root.Entries[1].Kind != TimelineKindEvent {
+		t.Errorf("expected entries ordered by time, got %+v", root.Entries)
+	}
+	if child := timelines["child"]; child.Truncated || len(child.Entries) != 2 {
+		t.Errorf("unexpected child timeline %+v", child)
+	}
+	if quiet := timelines["quiet"]; len(quiet.Entries) != 0 || quiet.Truncated {
+		t.Errorf("expected an empty timeline, got %+v", quiet)
+	}
+}
+
+func TestGetTraceLogs(t *testing.T) {
+	var gotURL, gotSQL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotURL = r.URL.String()
+		var body struct {
+			Query struct {
+				SQL string `json:"sql"`
+			} `json:"query"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		gotSQL = body.Query.SQL
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"hits":[{"_timestamp":1735732800000001,"log":"hello","logLevel":"INFO","span_id":"s1"}]}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	params := TracesQueryParams{TraceID: "t'1", Limit: 40}
+
+	t.Run("no logs stream", func(t *testing.T) {
+		logs, err := client.GetTraceLogs(context.Background(), params)
+		if err != nil || logs != nil {
+			t.Errorf("GetTraceLogs() = %v, %v; want no logs", logs, err)
+		}
+	})
+
+	t.Run("logs stream", func(t *testing.T) {
+		client.SetLogsStream("app_logs")
+		logs, err := client.GetTraceLogs(context.Background(), params)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.HasSuffix(gotURL, "_search?type=logs") {
+			t.Errorf("expected a logs search, got %s", gotURL)
+		}
+		if gotSQL != "SELECT _timestamp, log, logLevel, span_id FROM app_logs WHERE trace_id = 't''1' ORDER BY _timestamp ASC" {
+			t.Errorf("unexpected SQL %s", gotSQL)
+		}
+		want := TraceLogEntry{SpanID: "s1", Timestamp: time.UnixMicro(1735732800000001).UTC(), Log: "hello", LogLevel: "INFO"}
+		if len(logs) != 1 || logs[0] != want {
+			t.Errorf("GetTraceLogs() = %+v, want %+v", logs, want)
+		}
+	})
+}
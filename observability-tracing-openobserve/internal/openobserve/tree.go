@@ -0,0 +1,131 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"slices"
+	"time"
+)
+
+// SpanTreeNode is a span of a trace placed in its parent/child hierarchy.
+type SpanTreeNode struct {
+	// Index is the index of the span in the spans the tree was built from.
+	Index int
+	// Depth is 0 for roots and grows by one with each level of children.
+	Depth int
+	// OffsetNs is the time from the start of the trace, the earliest start
+	// of its spans, to the start of the span.
+	OffsetNs int64
+	// SelfTimeNs is the part of the span's duration not covered by any of
+	// its children.
+	SelfTimeNs int64
+	// Orphan is set on roots whose parent is not among the spans, e.g.
+	// because it was not exported or the trace was truncated.
+	Orphan bool
+	// Children are ordered by start time.
+	Children []*SpanTreeNode
+}
+
+// BuildSpanTree assembles spans into trees by their parent span IDs and
+// returns the roots, ordered by start time. Spans without a parent are
+// roots, as are orphaned spans, whose parent is not among spans, and spans
+// caught in a parent cycle, where the cycle is broken. Every span appears
+// exactly once in the trees.
+func BuildSpanTree(spans []SpanEntry) []*SpanTreeNode {
+	if len(spans) == 0 {
+		return []*SpanTreeNode{}
+	}
+	traceStart := spans[0].StartTime
+	index := make(map[string]int, len(spans))
+	for i := range spans {
+		if spans[i].StartTime.Before(traceStart) {
+			traceStart = spans[i].StartTime
+		}
+		if spans[i].SpanID != "" {
+			index[spans[i].SpanID] = i
+		}
+	}
+
+	nodes := make([]*SpanTreeNode, len(spans))
+	for i := range spans {
+		nodes[i] = &SpanTreeNode{Index: i, OffsetNs: spans[i].StartTime.Sub(traceStart).Nanoseconds()}
+	}
+	byStart := func(a, b *SpanTreeNode) int {
+		return spans[a.Index].StartTime.Compare(spans[b.Index].StartTime)
+	}
+
+	var roots []*SpanTreeNode
+	for i := range spans {
+		parent := spans[i].ParentSpanID
+		if p, ok := index[parent]; ok && p != i {
+			nodes[p].Children = append(nodes[p].Children, nodes[i])
+			continue
+		}
+		nodes[i].Orphan = parent != "" && parent != spans[i].SpanID
+		roots = append(roots, nodes[i])
+	}
+
+	// Spans in a cycle are not reachable from any root. The earliest span of
+	// each cycle becomes a root, cut from its parent.
+	placed := make([]bool, len(spans))
+	var walk func(node *SpanTreeNode, depth int)
+	walk = func(node *SpanTreeNode, depth int) {
+		placed[node.Index] = true
+		node.Depth = depth
+		node.Children = slices.DeleteFunc(node.Children, func(c *SpanTreeNode) bool { return placed[c.Index] })
+		slices.SortStableFunc(node.Children, byStart)
+		for _, c := range node.Children {
+			walk(c, depth+1)
+		}
+		node.SelfTimeNs = selfTime(spans, node)
+	}
+	slices.SortStableFunc(roots, byStart)
+	for _, r := range roots {
+		walk(r, 0)
+	}
+	unplaced := make([]*SpanTreeNode, 0)
+	for i, node := range nodes {
+		if !placed[i] {
+			unplaced = append(unplaced, node)
+		}
+	}
+	slices.SortStableFunc(unplaced, byStart)
+	for _, node := range unplaced {
+		if placed[node.Index] {
+			continue
+		}
+		node.Orphan = true
+		roots = append(roots, node)
+		walk(node, 0)
+	}
+	slices.SortStableFunc(roots, byStart)
+	return roots
+}
+
+// selfTime returns the duration of the span of node minus the time covered
+// by its children within it. Overlapping children, such as parallel calls,
+// are only counted once.
+func selfTime(spans []SpanEntry, node *SpanTreeNode) int64 {
+	span := spans[node.Index]
+	self := span.EndTime.Sub(span.StartTime)
+	var coveredUntil time.Time
+	for _, c := range node.Children {
+		// Children are ordered by start time.
+		start, end := spans[c.Index].StartTime, spans[c.Index].EndTime
+		if start.Before(span.StartTime) {
+			start = span.StartTime
+		}
+		if end.After(span.EndTime) {
+			end = span.EndTime
+		}
+		if start.Before(coveredUntil) {
+			start = coveredUntil
+		}
+		if end.After(start) {
+			self -= end.Sub(start)
+			coveredUntil = end
+		}
+	}
+	return max(self.Nanoseconds(), 0)
+}
@@ -0,0 +1,68 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openobserve
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuildSpanTree(t *testing.T) {
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	span := func(id, parent string, startMs, endMs int) SpanEntry {
+		return SpanEntry{
+			SpanID:       id,
+			ParentSpanID: parent,
+			StartTime:    base.Add(time.Duration(startMs) * time.Millisecond),
+			EndTime:      base.Add(time.Duration(endMs) * time.Millisecond),
+		}
+	}
+	spans := []SpanEntry{
+		span("db", "api", 30, 80),
+		span("api", "root", 20, 90),
+		span("cache", "api", 25, 50),
+		span("root", "", 10, 100),
+		span("late", "gone", 95, 99),
+		// a and b are each other's parent.
+		span("a", "b", 0, 5),
+		span("b", "a", 1, 4),
+	}
+
+	roots := BuildSpanTree(spans)
+	if len(roots) != 3 {
+		t.Fatalf("expected 3 roots, got %d", len(roots))
+	}
+	cycle, root, late := roots[0], roots[1], roots[2]
+
+	if spans[root.Index].SpanID != "root" || root.Orphan || root.Depth != 0 || root.OffsetNs != int64(10*time.Millisecond) {
+		t.Errorf("unexpected root %+v", root)
+	}
+	if root.SelfTimeNs != int64(20*time.Millisecond) {
+		t.Errorf("expected a root self time of 20ms, got %v", time.Duration(root.SelfTimeNs))
+	}
+	api := root.Children[0]
+	if spans[api.Index].SpanID != "api" || api.Depth != 1 || len(api.Children) != 2 {
+		t.Fatalf("unexpected api node %+v", api)
+	}
+	// cache (25-50ms) and db (30-80ms) overlap: they cover 55ms of 70ms.
+	if api.SelfTimeNs != int64(15*time.Millisecond) {
+		t.Errorf("expected overlapping children to be counted once, got %v", time.Duration(api.SelfTimeNs))
+	}
+	if spans[api.Children[0].Index].SpanID != "cache" || api.Children[1].Depth != 2 {
+		t.Errorf("expected children ordered by start time, got %+v", api.Children)
+	}
+
+	if spans[late.Index].SpanID != "late" || !late.Orphan {
+		t.Errorf("expected the span of a missing parent to be an orphan root, got %+v", late)
+	}
+	if spans[cycle.Index].SpanID != "a" || !cycle.Orphan || len(cycle.Children) != 1 || len(cycle.Children[0].Children) != 0 {
+		t.Errorf("expected the cycle to be broken at its earliest span, got %+v", cycle)
+	}
+}
+
+func TestBuildSpanTree_Empty(t *testing.T) {
+	if roots := BuildSpanTree(nil); roots == nil || len(roots) != 0 {
+		t.Errorf("expected no roots, got %v", roots)
+	}
+}
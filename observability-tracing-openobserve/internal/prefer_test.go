@@ -0,0 +1,74 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openchoreo/community-modules/common/prefer"
+)
+
+func TestWithPreferences(t *testing.T) {
+	t.Run("max-results is capped", func(t *testing.T) {
+		var got prefer.Preferences
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got = prefer.FromContext(r.Context())
+			w.WriteHeader(http.StatusOK)
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1alpha1/traces/query", strings.NewReader("{}"))
+		req.Header.Set("Prefer", "max-results=999999")
+		rec := httptest.NewRecorder()
+		withPreferences(next).ServeHTTP(rec, req)
+
+		if got.MaxResults != maxPreferredResults || rec.Header().Get("Preference-Applied") != "max-results=1000" {
+			t.Errorf("expected max-results capped at %d, got %d with %q", maxPreferredResults, got.MaxResults, rec.Header().Get("Preference-Applied"))
+		}
+	})
+
+	t.Run("queries exceeding the wait are answered 504", func(t *testing.T) {
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+			if errors.Is(r.Context().Err(), context.DeadlineExceeded) {
+				writeJSONError(w, http.StatusInternalServerError, "internalServerError", "context deadline exceeded")
+			}
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1alpha1/traces/query", strings.NewReader("{}"))
+		req.Header.Set("Prefer", "wait=1")
+		rec := httptest.NewRecorder()
+		withPreferences(next).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusGatewayTimeout || rec.Header().Get("Retry-After") != "1" {
+			t.Fatalf("expected 504 with Retry-After, got %d with %v: %s", rec.Code, rec.Header(), rec.Body.String())
+		}
+		var body struct {
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil || body.Title != string(gatewayTimeout) {
+			t.Errorf("unexpected body: %s", rec.Body.String())
+		}
+	})
+
+	t.Run("ignored on other endpoints", func(t *testing.T) {
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/healthz", strings.NewReader("{}"))
+		req.Header.Set("Prefer", "max-results=500")
+		rec := httptest.NewRecorder()
+		withPreferences(next).ServeHTTP(rec, req)
+
+		if h := rec.Header().Get("Preference-Applied"); h != "" {
+			t.Errorf("expected no Preference-Applied header, got %q", h)
+		}
+	})
+}
@@ -0,0 +1,41 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/openchoreo/community-modules/common/ratelimit"
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/api/gen"
+)
+
+// tooManyRequests is the title of the errors of requests rejected because
+// their namespace exceeded a limit.
+const tooManyRequests gen.ErrorResponseTitle = "tooManyRequests"
+
+// limitedRoutes are the routes whose requests are rate limited per
+// namespace: those querying the backend.
+var limitedRoutes = []string{
+	"POST /api/v1alpha1/traces/query",
+	"POST /api/v1alpha1/traces/{traceId}/spans/query",
+	"GET /api/v1alpha1/traces/{traceId}/spans/{spanId}",
+	"GET /api/v1alpha1/spans/{spanId}",
+	"GET /api/v1alpha1/traces/latency",
+	"GET /api/v1alpha1/traces/latency/threshold",
+	"GET /api/v1alpha1/traces/services",
+	"POST /api/v1alpha1/traces/groups",
+	"POST /api/v1alpha1/traces:batchGet",
+	"POST /api/v1alpha1/traces/service-graph",
+	"POST /api/v1alpha1/traces/operations/stats",
+}
+
+// withRateLimit rejects the requests for the limitedRoutes of namespaces
+// over the limits of limiter with 429. Span lookups, which name no
+// namespace, share the limits of the empty namespace. Requests are passed
+// through untouched when no limiter is configured.
+func withRateLimit(limiter *ratelimit.Limiter, next http.Handler) http.Handler {
+	return limiter.Middleware(limitedRoutes, ratelimit.Namespace, func(w http.ResponseWriter, r *http.Request, err error) {
+		writeJSONError(w, http.StatusTooManyRequests, tooManyRequests, err.Error())
+	}, next)
+}
@@ -0,0 +1,49 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openchoreo/community-modules/common/ratelimit"
+)
+
+func TestRateLimit(t *testing.T) {
+	handler := NewBackendHandler(&fakeBackend{}, testLogger())
+	limiter, err := ratelimit.New(ratelimit.Config{RequestsPerSecond: 0.01}, "tracing_adapter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler.SetRateLimiter(limiter)
+	srv := NewBackendServer("0", handler, testLogger()).httpServer.Handler
+
+	serve := func(namespace string) *httptest.ResponseRecorder {
+		body := `{"startTime":"2026-01-01T00:00:00Z","endTime":"2026-01-01T01:00:00Z","searchScope":{"namespace":"` + namespace + `"}}`
+		req := httptest.NewRequest(http.MethodPost, "/api/v1alpha1/traces/query", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := serve("acme"); rec.Code != http.StatusOK {
+		t.Fatalf("expected the first query to be served, got %d: %s", rec.Code, rec.Body.String())
+	}
+	rec := serve("acme")
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") == "" || !strings.Contains(rec.Body.String(), `"tooManyRequests"`) {
+		t.Fatalf("expected the second query to be rejected with Retry-After, got %d with %v: %s", rec.Code, rec.Header(), rec.Body.String())
+	}
+	if rec := serve("globex"); rec.Code != http.StatusOK {
+		t.Errorf("expected another namespace to be served, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if want := `tracing_adapter_rate_limited_requests_total{limit="rate"} 1`; !strings.Contains(rec.Body.String(), want) {
+		t.Errorf("expected metrics to contain %q, got:\n%s", want, rec.Body.String())
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/openchoreo/community-modules/common/respcache"
+)
+
+// cachedRoutes are the routes whose responses are served from the response
+// cache: the trace queries dashboards poll.
+var cachedRoutes = []string{
+	"POST /api/v1alpha1/traces/query",
+	"POST /api/v1alpha1/traces/{traceId}/spans/query",
+	"GET /api/v1alpha1/traces/{traceId}/spans/{spanId}",
+	"GET /api/v1alpha1/traces/latency",
+	"GET /api/v1alpha1/traces/latency/threshold",
+	"GET /api/v1alpha1/traces/services",
+	"POST /api/v1alpha1/traces/service-graph",
+	"POST /api/v1alpha1/traces/operations/stats",
+}
+
+// withResponseCache serves the cachedRoutes from cache. Requests are passed
+// through untouched when no cache is configured.
+func withResponseCache(cache *respcache.Cache, next http.Handler) http.Handler {
+	return cache.Middleware(cachedRoutes, nil, next)
+}
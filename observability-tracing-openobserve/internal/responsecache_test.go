@@ -0,0 +1,65 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openchoreo/community-modules/common/metrics"
+	"github.com/openchoreo/community-modules/common/respcache"
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/openobserve"
+)
+
+func TestResponseCache(t *testing.T) {
+	backend := &fakeBackend{}
+	handler := NewBackendHandler(backend, testLogger())
+	handler.SetMetrics(metrics.New("tracing_adapter"))
+	policy := respcache.Policy{RecentTTL: time.Minute, HistoricalTTL: time.Minute, Settle: respcache.DefaultSettle}
+	handler.SetResponseCache(respcache.NewCache(respcache.NewMemoryStore(respcache.MaxEntryBytes), policy, "tracing_adapter", testLogger()))
+	srv := NewBackendServer("0", handler, testLogger()).httpServer.Handler
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		return rec
+	}
+	const query = `{"startTime":"2026-01-01T00:00:00Z","endTime":"2026-01-01T01:00:00Z","searchScope":{"namespace":"acme"}}`
+
+	first := serve(http.MethodPost, "/api/v1alpha1/traces/query", query)
+	if first.Code != http.StatusOK || first.Header().Get(respcache.CacheHeader) != "miss" {
+		t.Fatalf("expected a miss, got %d with %v", first.Code, first.Header())
+	}
+	backend.tracesParams = openobserve.TracesQueryParams{}
+	rec := serve(http.MethodPost, "/api/v1alpha1/traces/query", query)
+	if rec.Header().Get(respcache.CacheHeader) != "hit" || rec.Body.String() != first.Body.String() {
+		t.Errorf("expected the repeated query to be served from cache, got %v: %s", rec.Header(), rec.Body.String())
+	}
+	if backend.tracesParams.Scope.Namespace != "" {
+		t.Error("expected no backend query for a hit")
+	}
+
+	if rec := serve(http.MethodGet, "/api/v1alpha1/traces/t1/spans/s1", ""); rec.Header().Get(respcache.CacheHeader) != "miss" {
+		t.Errorf("expected span details to be cached, got %q", rec.Header().Get(respcache.CacheHeader))
+	}
+	if rec := serve(http.MethodGet, "/api/v1alpha1/traces/t1/spans/s1", ""); rec.Header().Get(respcache.CacheHeader) != "hit" {
+		t.Errorf("expected repeated span details to hit, got %q", rec.Header().Get(respcache.CacheHeader))
+	}
+
+	rec = httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	for _, want := range []string{
+		"tracing_adapter_response_cache_hits_total 2\n",
+		`tracing_adapter_http_requests_total{handler="/api/v1alpha1/traces/query",method="POST",code="200"} 2`,
+	} {
+		if !strings.Contains(rec.Body.String(), want) {
+			t.Errorf("expected metrics to contain %q, got:\n%s", want, rec.Body.String())
+		}
+	}
+}
@@ -23,11 +23,27 @@ func NewServer(port string, tracingHandler *TracingHandler, logger *slog.Logger)
 	strictHandler := gen.NewStrictHandler(tracingHandler, nil)
 
 	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1alpha1/traces/services", tracingHandler.ListServices)
+	mux.HandleFunc("GET /api/v1alpha1/traces/latency", tracingHandler.GetLatencyHistogram)
+	mux.HandleFunc("GET /api/v1alpha1/traces/latency/threshold", tracingHandler.GetLatencyThreshold)
+	mux.HandleFunc("POST /api/v1alpha1/traces/groups", tracingHandler.QueryTraceGroups)
+	mux.HandleFunc("GET /api/v1alpha1/spans/{spanId}", tracingHandler.GetSpan)
+	mux.HandleFunc("POST /api/v1alpha1/traces/{traceId}/pin", tracingHandler.PinTrace)
+	mux.HandleFunc("GET /api/v1alpha1/traces/pins", tracingHandler.ListPinnedTraces)
+	mux.HandleFunc("POST /api/v1alpha1/traces/alerts/rules", tracingHandler.CreateAlertRule)
+	mux.HandleFunc("GET /api/v1alpha1/traces/alerts/rules/{ruleName}", tracingHandler.GetAlertRule)
+	mux.HandleFunc("PUT /api/v1alpha1/traces/alerts/rules/{ruleName}", tracingHandler.UpdateAlertRule)
+	mux.HandleFunc("DELETE /api/v1alpha1/traces/alerts/rules/{ruleName}", tracingHandler.DeleteAlertRule)
+	mux.HandleFunc("POST /api/v1alpha1/traces/shares", tracingHandler.CreateShareLink)
+	mux.HandleFunc("POST /api/v1alpha1/traces:batchGet", tracingHandler.BatchGetTraces)
+	mux.HandleFunc("POST /api/v1alpha1/traces/service-graph", tracingHandler.QueryServiceGraph)
+	mux.HandleFunc("POST /api/v1alpha1/traces/operations/stats", tracingHandler.QueryOperationStats)
+	handleMetrics(mux, tracingHandler)
 	handler := gen.HandlerFromMux(strictHandler, mux)
 
 	httpServer := &http.Server{
 		Addr:         ":" + port,
-		Handler:      handler,
+		Handler:      tracingHandler.metrics.Instrument(withAuthentication(tracingHandler.requestAuthenticator(), tracingHandler.authExemptPaths, withAccessPolicy(tracingHandler.access, withAudit(tracingHandler.auditLog, withQueryDebug(tracingHandler.queryDebugAdmins, withSharedViews(tracingHandler.shareSigner, withResponseCache(tracingHandler.responseCache, withRateLimit(tracingHandler.rateLimiter, withLocalization(withPreferences(withQueryExtensions(withSpanTimeHints(tracingHandler.metrics.Route(handler))))))))))))),
 		ReadTimeout:  15 * time.Second,
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
@@ -40,6 +56,57 @@ func NewServer(port string, tracingHandler *TracingHandler, logger *slog.Logger)
 	}
 }
 
+// NewBackendServer creates a server of the core tracing API of a handler
+// created with NewBackendHandler. The query extensions, preferences and
+// span time hints apply to it, but share links do not.
+func NewBackendServer(port string, tracingHandler *TracingHandler, logger *slog.Logger) *Server {
+	strictHandler := gen.NewStrictHandler(tracingHandler, nil)
+
+	mux := http.NewServeMux()
+	handleMetrics(mux, tracingHandler)
+	handler := gen.HandlerFromMux(strictHandler, mux)
+
+	httpServer := &http.Server{
+		Addr:         ":" + port,
+		Handler:      tracingHandler.metrics.Instrument(withAuthentication(tracingHandler.requestAuthenticator(), tracingHandler.authExemptPaths, withAccessPolicy(tracingHandler.access, withAudit(tracingHandler.auditLog, withResponseCache(tracingHandler.responseCache, withRateLimit(tracingHandler.rateLimiter, withLocalization(withPreferences(withQueryExtensions(withSpanTimeHints(tracingHandler.metrics.Route(handler))))))))))),
+		ReadTimeout:  15 * time.Second,
+		WriteTimeout: 15 * time.Second,
+		IdleTimeout:  60 * time.Second,
+	}
+
+	return &Server{
+		port:       port,
+		httpServer: httpServer,
+		logger:     logger,
+	}
+}
+
+// handleMetrics serves the request metrics and those of the response cache,
+// rate limiter and audit log of tracingHandler on GET /metrics, when any is
+// enabled.
+func handleMetrics(mux *http.ServeMux, tracingHandler *TracingHandler) {
+	var metrics []http.Handler
+	if tracingHandler.metrics != nil {
+		metrics = append(metrics, tracingHandler.metrics)
+	}
+	if tracingHandler.responseCache != nil {
+		metrics = append(metrics, tracingHandler.responseCache)
+	}
+	if tracingHandler.rateLimiter != nil {
+		metrics = append(metrics, tracingHandler.rateLimiter)
+	}
+	if tracingHandler.auditLog != nil {
+		metrics = append(metrics, tracingHandler.auditLog)
+	}
+	if len(metrics) > 0 {
+		mux.HandleFunc("GET /metrics", func(w http.ResponseWriter, r *http.Request) {
+			for _, m := range metrics {
+				m.ServeHTTP(w, r)
+			}
+		})
+	}
+}
+
 func (s *Server) Start() error {
 	s.logger.Info("Starting server", slog.String("port", s.port))
 	if err := s.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -0,0 +1,53 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openchoreo/community-modules/common/metrics"
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/openobserve"
+)
+
+func TestServerMetrics(t *testing.T) {
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "admin", "pass", testLogger())
+
+	rec := httptest.NewRecorder()
+	NewServer("0", NewTracingHandler(client, testLogger()), testLogger()).httpServer.Handler.
+		ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 without metrics, got %d", rec.Code)
+	}
+
+	m := metrics.New("tracing_adapter")
+	client.AddRequestObserver(m.ObserveBackend)
+	handler := NewTracingHandler(client, testLogger())
+	handler.SetMetrics(m)
+	srv := NewServer("0", handler, testLogger()).httpServer.Handler
+	srv.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1alpha1/traces/services?namespace=test-ns", nil))
+
+	rec = httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{
+		`tracing_adapter_http_requests_total{handler="/api/v1alpha1/traces/services",method="GET",code="500"} 1`,
+		`tracing_adapter_http_request_duration_seconds_count{handler="/api/v1alpha1/traces/services",method="GET"} 1`,
+		`tracing_adapter_openobserve_requests_total{operation="search",code="502"} 1`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected %q in:\n%s", want, body)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/api/gen"
+)
+
+const spanTimeHintKey contextKey = "spanTimeHint"
+
+// spanTimeHint is the time window a span is expected in, which narrows the
+// search for the span.
+type spanTimeHint struct {
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// withSpanTimeHints reads the optional startTime and endTime query parameters
+// of GET /api/v1alpha1/traces/{traceId}/spans/{spanId} into the request
+// context. The generated strict handler does not see query parameters that
+// are not part of the shared OpenAPI contract, so they are decoded here.
+// Malformed parameters are rejected with 400.
+func withSpanTimeHints(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || !isSpanDetailPath(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		start, end, err := parseTimeWindow(r.URL.Query())
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, gen.BadRequest, err.Error())
+			return
+		}
+		if !start.IsZero() {
+			r = r.WithContext(context.WithValue(r.Context(), spanTimeHintKey, spanTimeHint{StartTime: start, EndTime: end}))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isSpanDetailPath reports whether path is /api/v1alpha1/traces/{traceId}/spans/{spanId}.
+func isSpanDetailPath(path string) bool {
+	rest, ok := strings.CutPrefix(path, "/api/v1alpha1/traces/")
+	if !ok {
+		return false
+	}
+	parts := strings.Split(rest, "/")
+	return len(parts) == 3 && parts[0] != "" && parts[1] == "spans" && parts[2] != "" && parts[2] != "query"
+}
+
+// parseTimeWindow parses the startTime and endTime query parameters, in RFC
+// 3339 format. Both are zero when neither is set; setting only one of them is
+// an error.
+func parseTimeWindow(query url.Values) (time.Time, time.Time, error) {
+	start, end := query.Get("startTime"), query.Get("endTime")
+	if start == "" && end == "" {
+		return time.Time{}, time.Time{}, nil
+	}
+	startTime, err := time.Parse(time.RFC3339, start)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.New("startTime must be an RFC 3339 timestamp")
+	}
+	endTime, err := time.Parse(time.RFC3339, end)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.New("endTime must be an RFC 3339 timestamp")
+	}
+	if endTime.Before(startTime) {
+		return time.Time{}, time.Time{}, errors.New("endTime must be >= startTime")
+	}
+	return startTime, endTime, nil
+}
+
+// spanTimeHintFromContext returns the hint decoded by withSpanTimeHints and
+// whether there is one.
+func spanTimeHintFromContext(ctx context.Context) (spanTimeHint, bool) {
+	hint, ok := ctx.Value(spanTimeHintKey).(spanTimeHint)
+	return hint, ok
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/openobserve"
+)
+
+func TestGetSpanDetailsForTrace_TimeHint(t *testing.T) {
+	var windows [][2]int64
+	ooServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query struct {
+				StartTime int64 `json:"start_time"`
+				EndTime   int64 `json:"end_time"`
+			} `json:"query"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		windows = append(windows, [2]int64{body.Query.StartTime, body.Query.EndTime})
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"took":1,"hits":[{"trace_id":"trace-1","span_id":"span-1"}]}`))
+	}))
+	defer ooServer.Close()
+
+	client := openobserve.NewClient(ooServer.URL, "default", "default", "admin", "pass", testLogger())
+	srv := NewServer("0", NewTracingHandler(client, testLogger()), testLogger()).httpServer.Handler
+
+	get := func(target string) *httptest.ResponseRecorder {
+		windows = nil
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec
+	}
+
+	t.Run("bounded by the hint", func(t *testing.T) {
+		rec := get("/api/v1alpha1/traces/trace-1/spans/span-1?startTime=2025-01-01T12:00:00Z&endTime=2025-01-01T12:01:00Z")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		want := [2]int64{start.UnixMicro(), start.Add(time.Minute).UnixMicro()}
+		if len(windows) != 1 || windows[0] != want {
+			t.Errorf("expected window %v, got %v", want, windows)
+		}
+	})
+
+	t.Run("unbounded without a hint", func(t *testing.T) {
+		rec := get("/api/v1alpha1/traces/trace-1/spans/span-1")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if len(windows) != 1 || windows[0][0] != 1 {
+			t.Errorf("expected an unbounded search, got %v", windows)
+		}
+	})
+
+	for name, query := range map[string]string{
+		"start only":     "?startTime=2025-01-01T12:00:00Z",
+		"malformed end":  "?startTime=2025-01-01T12:00:00Z&endTime=yesterday",
+		"reversed times": "?startTime=2025-01-02T00:00:00Z&endTime=2025-01-01T00:00:00Z",
+	} {
+		t.Run(name, func(t *testing.T) {
+			rec := get("/api/v1alpha1/traces/trace-1/spans/span-1" + query)
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected 400, got %d", rec.Code)
+			}
+			if len(windows) != 0 {
+				t.Errorf("expected no search, got %v", windows)
+			}
+		})
+	}
+}
+
+func TestIsSpanDetailPath(t *testing.T) {
+	for path, want := range map[string]bool{
+		"/api/v1alpha1/traces/t1/spans/s1":    true,
+		"/api/v1alpha1/traces/t1/spans/query": false,
+		"/api/v1alpha1/traces/t1/spans/":      false,
+		"/api/v1alpha1/traces/t1/pin":         false,
+		"/api/v1alpha1/spans/s1":              false,
+	} {
+		if got := isSpanDetailPath(path); got != want {
+			t.Errorf("isSpanDetailPath(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,522 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tempo serves the tracing API from Grafana Tempo: traces are
+// searched with TraceQL and their spans read from the trace by ID API. It
+// takes and returns the types of the OpenObserve client, so that the
+// handlers serve either backend.
+package tempo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/openobserve"
+)
+
+// ErrUnavailable is returned when Tempo could not be reached or failed.
+var ErrUnavailable = errors.New("tempo is unavailable")
+
+// ErrUnsupported is returned for the parameters of queries that Tempo
+// cannot serve.
+var ErrUnsupported = errors.New("not supported by the Tempo backend")
+
+// defaultLimit matches the default limit of the OpenObserve queries.
+const defaultLimit = 100
+
+// Resource attributes of the spans holding their OpenChoreo scope, which
+// OpenObserve flattens to the service_openchoreo_dev_* columns.
+const (
+	NamespaceAttribute     = "openchoreo.dev/namespace"
+	ProjectAttribute       = "openchoreo.dev/project-uid"
+	ComponentAttribute     = "openchoreo.dev/component-uid"
+	EnvironmentAttribute   = "openchoreo.dev/environment-uid"
+	missingRootServiceName = "<root span not yet received>"
+)
+
+// Client queries the traces of a Tempo tenant.
+type Client struct {
+	baseURL    string
+	tenantID   string
+	user       string
+	password   string
+	httpClient *http.Client
+	logger     *slog.Logger
+}
+
+// NewClient returns a client of the Tempo instance at baseURL. tenantID is
+// sent as the X-Scope-OrgID header when set.
+func NewClient(baseURL, tenantID string, logger *slog.Logger) *Client {
+	return &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		tenantID:   tenantID,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		logger:     logger,
+	}
+}
+
+// SetBasicAuth authenticates the requests to Tempo with user and password,
+// for instances behind an authenticating gateway.
+func (c *Client) SetBasicAuth(user, password string) {
+	c.user = user
+	c.password = password
+}
+
+// CheckHealth returns an error unless Tempo reports itself ready.
+func (c *Client) CheckHealth(ctx context.Context) error {
+	body, err := c.get(ctx, "/ready", nil)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(string(body)) != "ready" {
+		return fmt.Errorf("tempo is not ready: %s", body)
+	}
+	return nil
+}
+
+// searchResponse is the response of /api/search.
+type searchResponse struct {
+	Traces []struct {
+		TraceID           string `json:"traceID"`
+		RootServiceName   string `json:"rootServiceName"`
+		RootTraceName     string `json:"rootTraceName"`
+		StartTimeUnixNano string `json:"startTimeUnixNano"`
+		DurationMs        int64  `json:"durationMs"`
+		SpanSets          []struct {
+			Spans []struct {
+				SpanID     string          `json:"spanID"`
+				Attributes []otlpAttribute `json:"attributes"`
+			} `json:"spans"`
+			Matched int `json:"matched"`
+		} `json:"spanSets"`
+	} `json:"traces"`
+	Metrics struct {
+		InspectedTraces int `json:"inspectedTraces"`
+	} `json:"metrics"`
+}
+
+// GetTraces searches Tempo for a page of the traces of a scope. Tempo has no
+// offset, no count and no order by duration, so only the first page of
+// traces ordered by start time is served; Total counts the traces of the
+// page. SpanCount is the number of spans of the trace matching the query, and
+// HasErrors is set from the spans Tempo returns with the search, at most
+// three per trace. The root span ID and kind are not known to the search.
+func (c *Client) GetTraces(ctx context.Context, params openobserve.TracesQueryParams) (*openobserve.TracesResult, error) {
+	if params.Offset > 0 {
+		return nil, fmt.Errorf("offset: %w", ErrUnsupported)
+	}
+	if params.SortBy != "" && params.SortBy != openobserve.TraceSortStartTime {
+		return nil, fmt.Errorf("sort by %q: %w", params.SortBy, ErrUnsupported)
+	}
+	query, err := tracesQuery(params)
+	if err != nil {
+		return nil, err
+	}
+	limit := params.Limit
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+
+	values := url.Values{}
+	values.Set("q", query)
+	// One more trace than the page tells whether traces follow it.
+	values.Set("limit", strconv.Itoa(limit+1))
+	setTimeRange(values, params.StartTime, params.EndTime)
+	start := time.Now()
+	body, err := c.get(ctx, "/api/search", values)
+	if err != nil {
+		return nil, err
+	}
+	var resp searchResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal search response: %w", err)
+	}
+
+	traces := make([]openobserve.TraceEntry, 0, len(resp.Traces))
+	for _, t := range resp.Traces {
+		startNs, _ := strconv.ParseInt(t.StartTimeUnixNano, 10, 64)
+		entry := openobserve.TraceEntry{
+			TraceID:      t.TraceID,
+			TraceName:    t.RootTraceName,
+			RootSpanName: t.RootTraceName,
+			StartTime:    time.Unix(0, startNs),
+			DurationNs:   t.DurationMs * int64(time.Millisecond),
+			Complete:     true,
+		}
+		entry.EndTime = entry.StartTime.Add(time.Duration(entry.DurationNs))
+		if t.RootServiceName == missingRootServiceName {
+			entry.Complete, entry.IncompleteReason = false, openobserve.IncompleteReasonMissingRoot
+		}
+		for _, set := range t.SpanSets {
+			entry.SpanCount = max(entry.SpanCount, set.Matched)
+			for _, span := range set.Spans {
+				for _, attr := range span.Attributes {
+					if attr.Key == "status" && attr.Value.String() == "error" {
+						entry.HasErrors = true
+					}
+				}
+			}
+		}
+		traces = append(traces, entry)
+	}
+	slices.SortStableFunc(traces, func(a, b openobserve.TraceEntry) int {
+		if strings.EqualFold(params.SortOrder, "asc") {
+			return a.StartTime.Compare(b.StartTime)
+		}
+		return b.StartTime.Compare(a.StartTime)
+	})
+
+	result := &openobserve.TracesResult{TookMs: int(time.Since(start).Milliseconds())}
+	if len(traces) > limit {
+		traces = traces[:limit]
+		result.HasMore = true
+	}
+	result.Traces = traces
+	result.Total = len(traces)
+	return result, nil
+}
+
+// tracesQuery returns the TraceQL query of the traces with a span of the
+// scope of params matching its filters.
+func tracesQuery(params openobserve.TracesQueryParams) (string, error) {
+	if params.Scope.Namespace == "" {
+		return "", fmt.Errorf("namespace is required for trace queries")
+	}
+	conditions := scopeConditions(params.Scope)
+	filters := params.Filters
+	if filters.OperationContains != "" {
+		conditions = append(conditions, fmt.Sprintf(`name =~ "(?i).*%s.*"`, escapeRegexp(filters.OperationContains)))
+	}
+	if filters.MinDurationNs > 0 {
+		conditions = append(conditions, fmt.Sprintf("duration >= %dns", filters.MinDurationNs))
+	}
+	if filters.ErrorsOnly {
+		conditions = append(conditions, "status = error")
+	}
+	for _, f := range filters.Attributes {
+		conditions = append(conditions, attributeCondition(f))
+	}
+	return "{ " + strings.Join(conditions, " && ") + " } | select(status)", nil
+}
+
+// scopeField is a resource attribute of spans and its value in a scope.
+type scopeField struct {
+	attribute string
+	value     string
+}
+
+// scopeFields returns the resource attributes of the spans of scope, with
+// their values. Fields unset in the scope are skipped.
+func scopeFields(scope openobserve.Scope) []scopeField {
+	var fields []scopeField
+	for _, field := range []scopeField{
+		{NamespaceAttribute, scope.Namespace},
+		{ProjectAttribute, scope.ProjectID},
+		{ComponentAttribute, scope.ComponentID},
+		{EnvironmentAttribute, scope.EnvironmentID},
+	} {
+		if field.value != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// scopeConditions returns the TraceQL conditions selecting the spans of
+// scope.
+func scopeConditions(scope openobserve.Scope) []string {
+	var conditions []string
+	for _, field := range scopeFields(scope) {
+		conditions = append(conditions, fmt.Sprintf("resource.%s = %s", quoteAttribute(field.attribute), quoteString(field.value)))
+	}
+	return conditions
+}
+
+// attributeCondition returns the TraceQL condition of an attribute filter.
+// The operators of attribute filters are those of TraceQL.
+func attributeCondition(f openobserve.AttributeFilter) string {
+	value := quoteString(f.Value)
+	if f.Numeric {
+		value = f.Value
+	}
+	return fmt.Sprintf(".%s %s %s", quoteAttribute(f.Attribute), f.Operator, value)
+}
+
+// quoteAttribute quotes the attribute names TraceQL cannot parse bare.
+func quoteAttribute(name string) string {
+	for _, r := range name {
+		if !(r == '_' || r == '.' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			return quoteString(name)
+		}
+	}
+	return name
+}
+
+// quoteString returns s as a TraceQL string.
+func quoteString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// escapeRegexp escapes s to be matched literally within a TraceQL regular
+// expression string.
+func escapeRegexp(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(`\.+*?()|[]{}^$`, r) {
+			b.WriteString(`\\`)
+		} else if r == '"' {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// GetSpans reads the spans of params.TraceID from Tempo. Only the spans of
+// the scope of params are listed, ordered by their start time and bounded
+// by params.Limit.
+func (c *Client) GetSpans(ctx context.Context, params openobserve.TracesQueryParams) (*openobserve.SpansResult, error) {
+	start := time.Now()
+	spans, err := c.getTrace(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]openobserve.SpanEntry, 0, len(spans))
+	for _, s := range spans {
+		if !s.inScope(params.Scope) {
+			continue
+		}
+		entry := openobserve.SpanEntry{
+			SpanID:        s.spanID,
+			SpanName:      s.Name,
+			SpanKind:      s.kind(),
+			StartTime:     s.startTime(),
+			EndTime:       s.endTime(),
+			ParentSpanID:  s.parentSpanID,
+			Status:        s.status(),
+			StatusMessage: s.Status.Message,
+		}
+		entry.DurationNs = entry.EndTime.Sub(entry.StartTime).Nanoseconds()
+		if params.IncludeEvents {
+			entry.Events = s.events()
+		}
+		entries = append(entries, entry)
+	}
+	slices.SortStableFunc(entries, func(a, b openobserve.SpanEntry) int {
+		if strings.EqualFold(params.SortOrder, "desc") {
+			return b.StartTime.Compare(a.StartTime)
+		}
+		return a.StartTime.Compare(b.StartTime)
+	})
+
+	total := len(entries)
+	limit := params.Limit
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return &openobserve.SpansResult{
+		Spans:  entries,
+		Total:  total,
+		TookMs: int(time.Since(start).Milliseconds()),
+	}, nil
+}
+
+// GetSpanDetail reads the span params.SpanID of params.TraceID from Tempo,
+// with its attributes, events and links.
+func (c *Client) GetSpanDetail(ctx context.Context, params openobserve.TracesQueryParams) (*openobserve.SpanDetailResult, error) {
+	spans, err := c.getTrace(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range spans {
+		if s.spanID != params.SpanID {
+			continue
+		}
+		detail := openobserve.SpanDetail{
+			SpanID:             s.spanID,
+			SpanName:           s.Name,
+			SpanKind:           s.kind(),
+			StartTime:          s.startTime(),
+			EndTime:            s.endTime(),
+			ParentSpanID:       s.parentSpanID,
+			Status:             s.status(),
+			StatusMessage:      s.Status.Message,
+			Attributes:         attributeValues(s.Attributes),
+			ResourceAttributes: attributeValues(s.resource),
+			Events:             s.events(),
+			Links:              s.links(),
+		}
+		detail.DurationNs = detail.EndTime.Sub(detail.StartTime).Nanoseconds()
+		return &openobserve.SpanDetailResult{Span: detail}, nil
+	}
+	return nil, fmt.Errorf("%w: traceId=%s, spanId=%s", openobserve.ErrSpanNotFound, params.TraceID, params.SpanID)
+}
+
+// getTrace returns the spans of params.TraceID, none when Tempo does not
+// hold the trace. The search is bounded by the time range of params when it
+// is set.
+func (c *Client) getTrace(ctx context.Context, params openobserve.TracesQueryParams) ([]span, error) {
+	if params.TraceID == "" {
+		return nil, fmt.Errorf("traceId is required")
+	}
+	values := url.Values{}
+	setTimeRange(values, params.StartTime, params.EndTime)
+	body, err := c.get(ctx, "/api/traces/"+url.PathEscape(params.TraceID), values)
+	var statusErr *statusError
+	if errors.As(err, &statusErr) && statusErr.statusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parseTrace(body)
+}
+
+// setTimeRange sets the start and end parameters of a Tempo query, in
+// seconds, when the time range is set.
+func setTimeRange(values url.Values, start, end time.Time) {
+	if start.IsZero() || end.IsZero() {
+		return
+	}
+	values.Set("start", strconv.FormatInt(start.Unix(), 10))
+	// The end is rounded up so that the range covers the last second.
+	values.Set("end", strconv.FormatInt(end.Add(time.Second-1).Unix(), 10))
+}
+
+// statusError is an unexpected HTTP status returned by Tempo.
+type statusError struct {
+	statusCode int
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("tempo returned status %d: response body omitted", e.statusCode)
+}
+
+// get sends a GET request for path to Tempo and returns the response body.
+// Server errors are reported as ErrUnavailable.
+func (c *Client) get(ctx context.Context, path string, values url.Values) ([]byte, error) {
+	endpoint := c.baseURL + path
+	if len(values) > 0 {
+		endpoint += "?" + values.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.tenantID != "" {
+		req.Header.Set("X-Scope-OrgID", c.tenantID)
+	}
+	if c.user != "" || c.password != "" {
+		req.SetBasicAuth(c.user, c.password)
+	}
+
+	c.logger.Debug("Querying Tempo", slog.String("path", path), slog.String("query", values.Get("q")))
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	switch {
+	case resp.StatusCode >= 500:
+		// The body may echo the query; it is only logged.
+		c.logger.Debug("Tempo request failed", slog.Int("status", resp.StatusCode), slog.String("body", string(body)))
+		return nil, fmt.Errorf("%w: status %d", ErrUnavailable, resp.StatusCode)
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		c.logger.Debug("Tempo request failed", slog.Int("status", resp.StatusCode), slog.String("body", string(body)))
+		return nil, &statusError{statusCode: resp.StatusCode}
+	}
+	return body, nil
+}
+
+// otlpAttribute is an attribute of the OTLP JSON encoding.
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+// otlpValue is an attribute value of the OTLP JSON encoding, of which one
+// field is set. Integers are encoded as strings.
+type otlpValue struct {
+	StringValue *string                       `json:"stringValue"`
+	BoolValue   *bool                         `json:"boolValue"`
+	IntValue    *json.Number                  `json:"intValue"`
+	DoubleValue *float64                      `json:"doubleValue"`
+	ArrayValue  *struct{ Values []otlpValue } `json:"arrayValue"`
+}
+
+// value returns the Go value of v.
+func (v otlpValue) value() interface{} {
+	switch {
+	case v.StringValue != nil:
+		return *v.StringValue
+	case v.BoolValue != nil:
+		return *v.BoolValue
+	case v.IntValue != nil:
+		if n, err := v.IntValue.Int64(); err == nil {
+			return n
+		}
+		return v.IntValue.String()
+	case v.DoubleValue != nil:
+		return *v.DoubleValue
+	case v.ArrayValue != nil:
+		values := make([]interface{}, 0, len(v.ArrayValue.Values))
+		for _, item := range v.ArrayValue.Values {
+			values = append(values, item.value())
+		}
+		return values
+	}
+	return nil
+}
+
+// String returns v formatted as a string.
+func (v otlpValue) String() string {
+	if v.StringValue != nil {
+		return *v.StringValue
+	}
+	if value := v.value(); value != nil {
+		return fmt.Sprint(value)
+	}
+	return ""
+}
+
+// attributeValues returns the Go values of attributes by key.
+func attributeValues(attributes []otlpAttribute) map[string]interface{} {
+	values := make(map[string]interface{}, len(attributes))
+	for _, attr := range attributes {
+		values[attr.Key] = attr.Value.value()
+	}
+	return values
+}
+
+// attributeStrings returns the attributes formatted as strings by key, or
+// nil when there are none.
+func attributeStrings(attributes []otlpAttribute) map[string]string {
+	if len(attributes) == 0 {
+		return nil
+	}
+	values := make(map[string]string, len(attributes))
+	for _, attr := range attributes {
+		values[attr.Key] = attr.Value.String()
+	}
+	return values
+}
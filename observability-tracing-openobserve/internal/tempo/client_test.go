@@ -0,0 +1,185 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tempo
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/openobserve"
+)
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+// testTrace holds two spans of the acme namespace and one of another
+// namespace, with base64 IDs as returned by Tempo.
+const testTrace = `{"batches":[
+ {"resource":{"attributes":[
+   {"key":"service.name","value":{"stringValue":"cart"}},
+   {"key":"openchoreo.dev/namespace","value":{"stringValue":"acme"}}]},
+  "scopeSpans":[{"spans":[
+   {"traceId":"AAAAAAAAAAAAAAAAAAAAAQ==","spanId":"AAAAAAAAAAE=","name":"GET /cart","kind":"SPAN_KIND_SERVER",
+    "startTimeUnixNano":"1000","endTimeUnixNano":"5000",
+    "attributes":[{"key":"http.status_code","value":{"intValue":"500"}}],
+    "status":{"code":"STATUS_CODE_ERROR","message":"boom"},
+    "events":[{"timeUnixNano":"2000","name":"exception","attributes":[{"key":"exception.type","value":{"stringValue":"IOError"}}]}],
+    "links":[{"traceId":"AAAAAAAAAAAAAAAAAAAAAg==","spanId":"AAAAAAAAAAI="}]},
+   {"traceId":"AAAAAAAAAAAAAAAAAAAAAQ==","spanId":"AAAAAAAAAAM=","parentSpanId":"AAAAAAAAAAE=","name":"SELECT","kind":"SPAN_KIND_CLIENT",
+    "startTimeUnixNano":"1500","endTimeUnixNano":"2500"}]}]},
+ {"resource":{"attributes":[{"key":"openchoreo.dev/namespace","value":{"stringValue":"other"}}]},
+  "scopeSpans":[{"spans":[
+   {"traceId":"AAAAAAAAAAAAAAAAAAAAAQ==","spanId":"AAAAAAAAAAQ=","name":"hidden","startTimeUnixNano":"1200","endTimeUnixNano":"1300"}]}]}
+]}`
+
+func TestGetTraces(t *testing.T) {
+	var query, limit, tenant string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/search" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		query, limit, tenant = r.URL.Query().Get("q"), r.URL.Query().Get("limit"), r.Header.Get("X-Scope-OrgID")
+		w.Write([]byte(`{"traces":[
+			{"traceID":"t1","rootServiceName":"cart","rootTraceName":"GET /cart","startTimeUnixNano":"1000000000","durationMs":12,
+			 "spanSets":[{"spans":[{"spanID":"s1","attributes":[{"key":"status","value":{"stringValue":"error"}}]}],"matched":4}]},
+			{"traceID":"t2","rootServiceName":"<root span not yet received>","startTimeUnixNano":"2000000000","durationMs":3},
+			{"traceID":"t3","rootServiceName":"cart","rootTraceName":"GET /","startTimeUnixNano":"500000000","durationMs":1}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "tenant-a", testLogger())
+	params := openobserve.TracesQueryParams{
+		Limit:     2,
+		StartTime: time.Unix(0, 0),
+		EndTime:   time.Unix(10, 0),
+		Scope:     openobserve.Scope{Namespace: "acme", ComponentID: "c1"},
+		Filters: openobserve.SpanFilters{
+			ErrorsOnly: true,
+			Attributes: []openobserve.AttributeFilter{{Attribute: "http.status_code", Operator: ">=", Value: "500", Numeric: true}},
+		},
+	}
+	result, err := client.GetTraces(context.Background(), params)
+	if err != nil {
+		t.Fatalf("GetTraces() error = %v", err)
+	}
+
+	wantQuery := `{ resource."openchoreo.dev/namespace" = "acme" && resource."openchoreo.dev/component-uid" = "c1" && ` +
+		`status = error && .http.status_code >= 500 } | select(status)`
+	if query != wantQuery || limit != "3" || tenant != "tenant-a" {
+		t.Errorf("unexpected search %q, limit %s, tenant %q", query, limit, tenant)
+	}
+	if len(result.Traces) != 2 || !result.HasMore || result.Total != 2 {
+		t.Fatalf("expected a page of two traces followed by more, got %+v", result)
+	}
+	// Newest first.
+	t2, t1 := result.Traces[0], result.Traces[1]
+	if t2.TraceID != "t2" || t2.Complete || t2.IncompleteReason != openobserve.IncompleteReasonMissingRoot {
+		t.Errorf("expected t2 to miss its root span, got %+v", t2)
+	}
+	if t1.TraceID != "t1" || !t1.Complete || !t1.HasErrors || t1.SpanCount != 4 || t1.DurationNs != 12_000_000 ||
+		!t1.EndTime.Equal(time.Unix(1, 12_000_000)) {
+		t.Errorf("unexpected trace %+v", t1)
+	}
+}
+
+func TestGetTraces_Unsupported(t *testing.T) {
+	client := NewClient("http://tempo.invalid", "", testLogger())
+	for name, params := range map[string]openobserve.TracesQueryParams{
+		"offset":   {Scope: openobserve.Scope{Namespace: "acme"}, Offset: 10},
+		"duration": {Scope: openobserve.Scope{Namespace: "acme"}, SortBy: openobserve.TraceSortDuration},
+	} {
+		if _, err := client.GetTraces(context.Background(), params); !errors.Is(err, ErrUnsupported) {
+			t.Errorf("%s: expected ErrUnsupported, got %v", name, err)
+		}
+	}
+}
+
+func TestGetSpans(t *testing.T) {
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Write([]byte(testTrace))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "", testLogger())
+	result, err := client.GetSpans(context.Background(), openobserve.TracesQueryParams{
+		TraceID:       "00000000000000000000000000000001",
+		Scope:         openobserve.Scope{Namespace: "acme"},
+		IncludeEvents: true,
+	})
+	if err != nil {
+		t.Fatalf("GetSpans() error = %v", err)
+	}
+	if path != "/api/traces/00000000000000000000000000000001" {
+		t.Errorf("unexpected path %s", path)
+	}
+	if result.Total != 2 || len(result.Spans) != 2 {
+		t.Fatalf("expected the spans of the namespace only, got %+v", result)
+	}
+	root, child := result.Spans[0], result.Spans[1]
+	if root.SpanID != "0000000000000001" || root.SpanKind != "SERVER" || root.Status != "error" ||
+		root.StatusMessage != "boom" || root.DurationNs != 4000 || len(root.Events) != 1 {
+		t.Errorf("unexpected root span %+v", root)
+	}
+	if child.ParentSpanID != "0000000000000001" || child.Status != "unset" {
+		t.Errorf("unexpected child span %+v", child)
+	}
+}
+
+func TestGetSpanDetail(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/traces/00000000000000000000000000000001" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(testTrace))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "", testLogger())
+	params := openobserve.TracesQueryParams{TraceID: "00000000000000000000000000000001", SpanID: "0000000000000001"}
+	result, err := client.GetSpanDetail(context.Background(), params)
+	if err != nil {
+		t.Fatalf("GetSpanDetail() error = %v", err)
+	}
+	span := result.Span
+	if span.Attributes["http.status_code"] != int64(500) || span.ResourceAttributes["service.name"] != "cart" {
+		t.Errorf("unexpected attributes %v, %v", span.Attributes, span.ResourceAttributes)
+	}
+	if len(span.Events) != 1 || span.Events[0].Attributes["exception.type"] != "IOError" {
+		t.Errorf("unexpected events %+v", span.Events)
+	}
+	if len(span.Links) != 1 || span.Links[0].TraceID != "00000000000000000000000000000002" || span.Links[0].SpanID != "0000000000000002" {
+		t.Errorf("unexpected links %+v", span.Links)
+	}
+
+	params.SpanID = "00000000000000ff"
+	if _, err := client.GetSpanDetail(context.Background(), params); !errors.Is(err, openobserve.ErrSpanNotFound) {
+		t.Errorf("expected ErrSpanNotFound for a missing span, got %v", err)
+	}
+	params.TraceID = "00000000000000000000000000000009"
+	if _, err := client.GetSpanDetail(context.Background(), params); !errors.Is(err, openobserve.ErrSpanNotFound) {
+		t.Errorf("expected ErrSpanNotFound for a missing trace, got %v", err)
+	}
+}
+
+func TestGet_Unavailable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "", testLogger())
+	if err := client.CheckHealth(context.Background()); !errors.Is(err, ErrUnavailable) {
+		t.Errorf("expected ErrUnavailable, got %v", err)
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2026 The OpenChoreo Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tempo
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/openobserve"
+)
+
+// traceResponse is a trace in the OTLP JSON encoding, as returned by
+// /api/traces/{traceID}: a list of batches of spans, each with the resource
+// that emitted them. Older Tempo versions name the batches and scopes
+// differently.
+type traceResponse struct {
+	Batches       []resourceSpans `json:"batches"`
+	ResourceSpans []resourceSpans `json:"resourceSpans"`
+}
+
+type resourceSpans struct {
+	Resource struct {
+		Attributes []otlpAttribute `json:"attributes"`
+	} `json:"resource"`
+	ScopeSpans                  []scopeSpans `json:"scopeSpans"`
+	InstrumentationLibrarySpans []scopeSpans `json:"instrumentationLibrarySpans"`
+}
+
+type scopeSpans struct {
+	Spans []span `json:"spans"`
+}
+
+// span is a span in the OTLP JSON encoding. Its resource attributes and
+// decoded IDs are set by parseTrace.
+type span struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId"`
+	Name              string          `json:"name"`
+	Kind              string          `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes"`
+	Status            struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"status"`
+	Events []struct {
+		TimeUnixNano string          `json:"timeUnixNano"`
+		Name         string          `json:"name"`
+		Attributes   []otlpAttribute `json:"attributes"`
+	} `json:"events"`
+	Links []struct {
+		TraceID    string          `json:"traceId"`
+		SpanID     string          `json:"spanId"`
+		TraceState string          `json:"traceState"`
+		Attributes []otlpAttribute `json:"attributes"`
+	} `json:"links"`
+
+	spanID       string
+	parentSpanID string
+	resource     []otlpAttribute
+}
+
+// parseTrace returns the spans of a trace response.
+func parseTrace(body []byte) ([]span, error) {
+	var resp traceResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal trace response: %w", err)
+	}
+	var spans []span
+	for _, batch := range append(resp.Batches, resp.ResourceSpans...) {
+		for _, scope := range append(batch.ScopeSpans, batch.InstrumentationLibrarySpans...) {
+			for _, s := range scope.Spans {
+				s.spanID = decodeID(s.SpanID)
+				s.parentSpanID = decodeID(s.ParentSpanID)
+				s.resource = batch.Resource.Attributes
+				spans = append(spans, s)
+			}
+		}
+	}
+	return spans, nil
+}
+
+// decodeID returns the hexadecimal form of a trace or span ID. Tempo encodes
+// the IDs of the OTLP JSON encoding in base64 rather than hexadecimal.
+func decodeID(id string) string {
+	if id == "" {
+		return ""
+	}
+	if len(id) == 16 || len(id) == 32 {
+		if _, err := hex.DecodeString(id); err == nil {
+			return strings.ToLower(id)
+		}
+	}
+	if b, err := base64.StdEncoding.DecodeString(id); err == nil {
+		return hex.EncodeToString(b)
+	}
+	return id
+}
+
+// inScope reports whether the resource of s belongs to scope.
+func (s span) inScope(scope openobserve.Scope) bool {
+	for _, field := range scopeFields(scope) {
+		if resourceAttribute(s.resource, field.attribute) != field.value {
+			return false
+		}
+	}
+	return true
+}
+
+// resourceAttribute returns the value of the attribute key, "" when absent.
+func resourceAttribute(attributes []otlpAttribute, key string) string {
+	for _, attr := range attributes {
+		if attr.Key == key {
+			return attr.Value.String()
+		}
+	}
+	return ""
+}
+
+// kind returns the kind of s as stored by OpenObserve, e.g. SERVER.
+func (s span) kind() string {
+	return strings.TrimPrefix(s.Kind, "SPAN_KIND_")
+}
+
+// status returns the status of s: "ok", "error" or "unset".
+func (s span) status() string {
+	switch s.Status.Code {
+	case "STATUS_CODE_ERROR", "2":
+		return "error"
+	case "STATUS_CODE_OK", "1":
+		return "ok"
+	}
+	return "unset"
+}
+
+func (s span) startTime() time.Time {
+	return unixNano(s.StartTimeUnixNano)
+}
+
+func (s span) endTime() time.Time {
+	return unixNano(s.EndTimeUnixNano)
+}
+
+// events returns the events of s, oldest first as recorded.
+func (s span) events() []openobserve.SpanEvent {
+	if len(s.Events) == 0 {
+		return nil
+	}
+	events := make([]openobserve.SpanEvent, 0, len(s.Events))
+	for _, e := range s.Events {
+		events = append(events, openobserve.SpanEvent{
+			Name:       e.Name,
+			Time:       unixNano(e.TimeUnixNano),
+			Attributes: attributeStrings(e.Attributes),
+		})
+	}
+	return events
+}
+
+// links returns the links of s to the spans of other traces.
+func (s span) links() []openobserve.SpanLink {
+	if len(s.Links) == 0 {
+		return nil
+	}
+	links := make([]openobserve.SpanLink, 0, len(s.Links))
+	for _, l := range s.Links {
+		links = append(links, openobserve.SpanLink{
+			TraceID:    decodeID(l.TraceID),
+			SpanID:     decodeID(l.SpanID),
+			TraceState: l.TraceState,
+			Attributes: attributeStrings(l.Attributes),
+		})
+	}
+	return links
+}
+
+// unixNano parses a timestamp in nanoseconds since the epoch.
+func unixNano(value string) time.Time {
+	n, _ := strconv.ParseInt(value, 10, 64)
+	return time.Unix(0, n)
+}
@@ -14,9 +14,20 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata" // time zones for the tz query parameter; the image has no zoneinfo
 
+	"github.com/openchoreo/community-modules/common/access"
+	"github.com/openchoreo/community-modules/common/audit"
+	"github.com/openchoreo/community-modules/common/auth"
+	"github.com/openchoreo/community-modules/common/metrics"
+	ooclient "github.com/openchoreo/community-modules/common/openobserve"
+	"github.com/openchoreo/community-modules/common/ratelimit"
+	"github.com/openchoreo/community-modules/common/respcache"
+	"github.com/openchoreo/community-modules/common/rollups"
+	"github.com/openchoreo/community-modules/common/shares"
 	app "github.com/openchoreo/community-modules/observability-tracing-openobserve/internal"
 	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/openobserve"
+	"github.com/openchoreo/community-modules/observability-tracing-openobserve/internal/tempo"
 )
 
 func main() {
@@ -33,11 +44,17 @@ func main() {
 		Level: cfg.LogLevel,
 	}))
 
+	if cfg.Backend == app.BackendTempo {
+		runTempoBackend(cfg, logger)
+		return
+	}
+
 	logger.Info("Configurations loaded from environment variables successfully",
 		slog.String("Log Level", cfg.LogLevel.String()),
 		slog.String("OpenObserve URL", cfg.OpenObserveURL),
 		slog.String("OpenObserve Org", cfg.OpenObserveOrg),
 		slog.String("OpenObserve Stream", cfg.OpenObserveStream),
+		slog.String("OpenObserve Logs Stream", cfg.OpenObserveLogsStream),
 		slog.String("OpenObserve User", cfg.OpenObserveUser),
 		slog.String("OpenObserve Password", string(cfg.OpenObservePassword[0])+"*****"),
 		slog.String("Server Port", cfg.ServerPort),
@@ -51,6 +68,23 @@ func main() {
 		cfg.OpenObservePassword,
 		logger,
 	)
+	if cfg.SecondaryPassword != "" {
+		client.SetSecondaryCredentials(cfg.SecondaryUser, cfg.SecondaryPassword)
+		logger.Info("Secondary OpenObserve credentials configured", slog.String("user", cfg.SecondaryUser))
+	}
+	client.SetLogsStream(cfg.OpenObserveLogsStream)
+	client.SetRetryPolicy(cfg.OpenObserveRetry)
+	client.SetAttributeRules(cfg.SpanAttributes)
+	serverMetrics := metrics.New("tracing_adapter")
+	client.AddRequestObserver(serverMetrics.ObserveBackend)
+	if cfg.TraceArchiveStream != "" {
+		client.SetArchiveStream(cfg.TraceArchiveStream)
+		logger.Info("Trace pinning enabled", slog.String("archiveStream", cfg.TraceArchiveStream))
+	}
+	if len(cfg.TraceStreamRoutes) > 0 {
+		client.SetStreamRoutes(cfg.TraceStreamRoutes)
+		logger.Info("Traces stream routing enabled", slog.Int("routes", len(cfg.TraceStreamRoutes)))
+	}
 
 	// Check OpenObserve connectivity when starting the adapter. If the connection fails,
 	// exit with an error because the adapter cannot function without connecting to
@@ -94,8 +128,58 @@ func main() {
 
 	logger.Info("Successfully connected to OpenObserve")
 
+	// Pick up rotated passwords from the secret store without a restart.
+	watchCtx, stopWatch := context.WithCancel(context.Background())
+	defer stopWatch()
+	if cfg.PasswordSecret != nil {
+		go cfg.PasswordSecret.Watch(watchCtx, func(password string) {
+			client.SetCredentials(cfg.OpenObserveUser, password)
+		}, logger.With(slog.String("secret", "OPENOBSERVE_PASSWORD_SOURCE")))
+		logger.Info("Watching the OpenObserve password for rotation",
+			slog.Duration("interval", cfg.SecretRefreshInterval))
+	}
+	if cfg.RollupStream != "" {
+		coverage := &rollups.Coverage{}
+		client.SetLatencyRollups(cfg.RollupStream, coverage)
+		job := rollups.NewJob(client, coverage, cfg.RollupInterval, cfg.RollupWindow, logger.With(slog.String("rollups", cfg.RollupStream)))
+		go job.Run(watchCtx)
+		logger.Info("Latency rollups enabled",
+			slog.String("stream", cfg.RollupStream),
+			slog.Duration("interval", cfg.RollupInterval),
+			slog.Duration("window", cfg.RollupWindow))
+	}
+
 	// Create handlers and server
 	tracingHandler := app.NewTracingHandler(client, logger)
+	tracingHandler.SetAlertDestinations(cfg.AlertDestinations)
+	tracingHandler.SetMetrics(serverMetrics)
+	authenticator, err := auth.New(cfg.Auth)
+	if err != nil {
+		logger.Error("Failed to configure authentication", slog.Any("error", err))
+		os.Exit(1)
+	}
+	if authenticator != nil {
+		tracingHandler.SetAuthenticator(authenticator, cfg.Auth.ExemptPaths)
+		logger.Info("Request authentication enabled",
+			slog.String("mode", cfg.Auth.Mode), slog.Any("exemptPaths", cfg.Auth.ExemptPaths))
+	}
+	setAccessPolicy(cfg, tracingHandler, logger)
+	setResponseCache(cfg, tracingHandler, logger)
+	setRateLimiter(cfg, tracingHandler, logger)
+	auditLog := setAuditLog(watchCtx, cfg, tracingHandler, client.Client, logger)
+	if cfg.DebugQueryToken != "" {
+		tracingHandler.SetQueryDebugToken(cfg.DebugQueryToken)
+		logger.Info("Query debugging enabled for admin callers")
+	}
+	if cfg.ShareSigningKey != "" {
+		signer, err := shares.NewSigner([]byte(cfg.ShareSigningKey))
+		if err != nil {
+			logger.Error("Failed to configure share links", slog.Any("error", err))
+			os.Exit(1)
+		}
+		tracingHandler.SetShareSigner(signer, cfg.ShareLinkMaxTTL)
+		logger.Info("Share links enabled", slog.Duration("maxTTL", cfg.ShareLinkMaxTTL))
+	}
 	srv := app.NewServer(cfg.ServerPort, tracingHandler, logger)
 
 	go func() {
@@ -119,6 +203,155 @@ func main() {
 		logger.Error("Error during shutdown", slog.Any("error", err))
 		os.Exit(1)
 	}
+	if auditLog != nil {
+		auditLog.Flush(ctx)
+	}
 
 	logger.Info("Server stopped")
 }
+
+// runTempoBackend serves the core tracing API from Tempo until the adapter
+// is stopped.
+func runTempoBackend(cfg *app.Config, logger *slog.Logger) {
+	logger.Info("Configurations loaded from environment variables successfully",
+		slog.String("Log Level", cfg.LogLevel.String()),
+		slog.String("Traces Backend", cfg.Backend),
+		slog.String("Tempo URL", cfg.TempoURL),
+		slog.String("Tempo Tenant ID", cfg.TempoTenantID),
+		slog.String("Server Port", cfg.ServerPort),
+	)
+
+	client := tempo.NewClient(cfg.TempoURL, cfg.TempoTenantID, logger)
+	client.SetBasicAuth(cfg.TempoUser, cfg.TempoPassword)
+
+	// The adapter cannot function without Tempo, so it exits when Tempo is
+	// not ready.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	err := client.CheckHealth(ctx)
+	cancel()
+	if err != nil {
+		logger.Error("Failed to connect to Tempo. Cannot continue without it. Hence shutting down", slog.Any("error", err))
+		os.Exit(1)
+	}
+	logger.Info("Tempo connectivity check succeeded")
+
+	tracingHandler := app.NewBackendHandler(client, logger)
+	tracingHandler.SetMetrics(metrics.New("tracing_adapter"))
+	authenticator, err := auth.New(cfg.Auth)
+	if err != nil {
+		logger.Error("Failed to configure authentication", slog.Any("error", err))
+		os.Exit(1)
+	}
+	if authenticator != nil {
+		tracingHandler.SetAuthenticator(authenticator, cfg.Auth.ExemptPaths)
+		logger.Info("Request authentication enabled",
+			slog.String("mode", cfg.Auth.Mode), slog.Any("exemptPaths", cfg.Auth.ExemptPaths))
+	}
+	setAccessPolicy(cfg, tracingHandler, logger)
+	setResponseCache(cfg, tracingHandler, logger)
+	setRateLimiter(cfg, tracingHandler, logger)
+	auditLog := setAuditLog(context.Background(), cfg, tracingHandler, nil, logger)
+	srv := app.NewBackendServer(cfg.ServerPort, tracingHandler, logger)
+
+	go func() {
+		if err := srv.Start(); err != nil {
+			logger.Error("Server error", slog.Any("error", err))
+			os.Exit(1)
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	logger.Info("Shutting down gracefully")
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		logger.Error("Error during shutdown", slog.Any("error", err))
+		os.Exit(1)
+	}
+	if auditLog != nil {
+		auditLog.Flush(ctx)
+	}
+
+	logger.Info("Server stopped")
+}
+
+// setResponseCache serves repeated queries of tracingHandler from the
+// response cache cfg configures, if any.
+func setResponseCache(cfg *app.Config, tracingHandler *app.TracingHandler, logger *slog.Logger) {
+	responseCache, err := respcache.New(cfg.ResponseCache, "tracing_adapter", logger)
+	if err != nil {
+		logger.Error("Failed to configure the response cache", slog.Any("error", err))
+		os.Exit(1)
+	}
+	if responseCache != nil {
+		tracingHandler.SetResponseCache(responseCache)
+		logger.Info("Response cache enabled",
+			slog.String("backend", cfg.ResponseCache.Backend),
+			slog.Duration("recentTTL", cfg.ResponseCache.RecentTTL),
+			slog.Duration("historicalTTL", cfg.ResponseCache.HistoricalTTL))
+	}
+}
+
+// setAccessPolicy restricts the callers of the access policy file of cfg,
+// if any, to aggregates in the namespaces of its rules.
+func setAccessPolicy(cfg *app.Config, tracingHandler *app.TracingHandler, logger *slog.Logger) {
+	if cfg.AccessPolicyFile == "" {
+		return
+	}
+	policyFile, err := access.LoadFile(cfg.AccessPolicyFile)
+	if err != nil {
+		logger.Error("Failed to load access policy", slog.Any("error", err))
+		os.Exit(1)
+	}
+	policy, err := access.NewPolicy(policyFile)
+	if err != nil {
+		logger.Error("Failed to configure access policy", slog.Any("error", err))
+		os.Exit(1)
+	}
+	tracingHandler.SetAccessPolicy(policy)
+	logger.Info("Aggregation-only access policy enabled",
+		slog.String("file", cfg.AccessPolicyFile),
+		slog.Int("callers", len(policyFile.Callers)),
+		slog.Int("rules", len(policyFile.AggregationOnly)))
+}
+
+// setRateLimiter limits the queries of each namespace of tracingHandler as
+// cfg configures, if at all.
+func setRateLimiter(cfg *app.Config, tracingHandler *app.TracingHandler, logger *slog.Logger) {
+	rateLimiter, err := ratelimit.New(cfg.RateLimit, "tracing_adapter")
+	if err != nil {
+		logger.Error("Failed to configure the rate limits", slog.Any("error", err))
+		os.Exit(1)
+	}
+	if rateLimiter != nil {
+		tracingHandler.SetRateLimiter(rateLimiter)
+		logger.Info("Queries rate limited per namespace",
+			slog.Float64("requestsPerSecond", cfg.RateLimit.RequestsPerSecond),
+			slog.Int("burst", cfg.RateLimit.Burst),
+			slog.Int("maxInFlight", cfg.RateLimit.MaxInFlight))
+	}
+}
+
+// setAuditLog audits the trace queries and alert operations of
+// tracingHandler as cfg configures, if at all, writing to the streams of
+// client for the stream sink. It returns the audit log, which runs until ctx
+// is canceled, or nil.
+func setAuditLog(ctx context.Context, cfg *app.Config, tracingHandler *app.TracingHandler, client *ooclient.Client, logger *slog.Logger) *audit.Log {
+	auditLog, err := audit.New(cfg.Audit, client, "tracing_adapter", logger)
+	if err != nil {
+		logger.Error("Failed to configure the audit log", slog.Any("error", err))
+		os.Exit(1)
+	}
+	if auditLog != nil {
+		tracingHandler.SetAuditLog(auditLog)
+		go auditLog.Run(ctx)
+		logger.Info("Trace queries and alert operations audited",
+			slog.String("sink", cfg.Audit.Sink),
+			slog.String("stream", cfg.Audit.Stream))
+	}
+	return auditLog
+}